@@ -0,0 +1,74 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+)
+
+// newKeyvault returns a manager for the RP keyvault with the given suffix,
+// authorised with the component's managed identity
+func newKeyvault(_env env.Core, suffix string) (keyvault.Manager, error) {
+	msiKVAuthorizer, err := _env.NewMSIAuthorizer(_env.Environment().KeyVaultScope)
+	if err != nil {
+		return nil, fmt.Errorf("MSI KeyVault Authorizer failed with: %s", err.Error())
+	}
+
+	if err := env.ValidateVars(envKeyVaultPrefix); err != nil {
+		return nil, err
+	}
+
+	return keyvault.NewManager(msiKVAuthorizer, keyvault.URI(_env, suffix, os.Getenv(envKeyVaultPrefix))), nil
+}
+
+// newDatabaseClient returns a client for the RP database account, authorised
+// according to the component's authentication mode, and the name of the
+// database to use
+func newDatabaseClient(ctx context.Context, log *logrus.Entry, _env env.Core, msiToken azcore.TokenCredential, m metrics.Emitter, aead encryption.AEAD) (cosmosdb.DatabaseClient, string, error) {
+	if err := env.ValidateVars(envDatabaseAccountName); err != nil {
+		return nil, "", err
+	}
+
+	dbAccountName := os.Getenv(envDatabaseAccountName)
+	dbAuthorizer, err := database.NewServiceAuthorizer(ctx, log.WithField("component", "database"), _env, msiToken, dbAccountName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dbc, err := database.NewDatabaseClient(log.WithField("component", "database"), _env, dbAuthorizer, m, aead, dbAccountName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dbName, err := DBName(_env.IsLocalDevelopmentMode())
+	if err != nil {
+		return nil, "", err
+	}
+
+	return dbc, dbName, nil
+}
+
+func DBName(isLocalDevelopmentMode bool) (string, error) {
+	if !isLocalDevelopmentMode {
+		return "ARO", nil
+	}
+
+	if err := env.ValidateVars(envDatabaseName); err != nil {
+		return "", fmt.Errorf("%v (development mode)", err.Error())
+	}
+
+	return os.Getenv(envDatabaseName), nil
+}
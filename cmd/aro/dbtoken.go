@@ -17,11 +17,11 @@ import (
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/golang"
-	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+	"github.com/Azure/ARO-RP/pkg/util/health"
 	"github.com/Azure/ARO-RP/pkg/util/oidc"
 )
 
-func dbtoken(ctx context.Context, log *logrus.Entry) error {
+func dbtoken(ctx context.Context, log *logrus.Entry, h *health.Health) error {
 	_env, err := env.NewCore(ctx, log, env.COMPONENT_DBTOKEN)
 	if err != nil {
 		return err
@@ -42,11 +42,6 @@ func dbtoken(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
-	msiKVAuthorizer, err := _env.NewMSIAuthorizer(_env.Environment().KeyVaultScope)
-	if err != nil {
-		return err
-	}
-
 	m := statsd.New(ctx, log.WithField("component", "dbtoken"), _env, os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
 
 	g, err := golang.NewMetrics(log.WithField("component", "dbtoken"), m)
@@ -62,6 +57,8 @@ func dbtoken(ctx context.Context, log *logrus.Entry) error {
 
 	dbAccountName := os.Getenv(envDatabaseAccountName)
 
+	// dbtoken manages database users and permissions, which requires the
+	// master key whatever the service authentication mode
 	clientOptions := &policy.ClientOptions{
 		ClientOptions: _env.Environment().ManagedIdentityCredentialOptions().ClientOptions,
 	}
@@ -88,12 +85,10 @@ func dbtoken(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
-	if err := env.ValidateVars(envKeyVaultPrefix); err != nil {
+	dbtokenKeyvault, err := newKeyvault(_env, env.DBTokenKeyvaultSuffix)
+	if err != nil {
 		return err
 	}
-	keyVaultPrefix := os.Getenv(envKeyVaultPrefix)
-	dbtokenKeyvaultURI := keyvault.URI(_env, env.DBTokenKeyvaultSuffix, keyVaultPrefix)
-	dbtokenKeyvault := keyvault.NewManager(msiKVAuthorizer, dbtokenKeyvaultURI)
 
 	servingKey, servingCerts, err := dbtokenKeyvault.GetCertificateSecret(ctx, env.DBTokenServerSecretName)
 	if err != nil {
@@ -126,5 +121,5 @@ func dbtoken(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
-	return server.Run(ctx)
+	return runUntilSIGTERM(ctx, log, h, server.Run)
 }
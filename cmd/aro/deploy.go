@@ -87,7 +87,26 @@ func deploy(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
-	err = deployer.PreDeploy(ctx)
+	// the deployment status is recorded for auditability only, so failing to
+	// save it does not fail the deployment
+	status := pkgdeploy.NewDeploymentStatus(deployVersion, location)
+	saveStatus := func() {
+		err := deployer.SaveDeploymentStatus(ctx, status)
+		if err != nil {
+			log.Warnf("failed to save deployment status: %v", err)
+		}
+	}
+
+	saveStatus()
+	err = runDeployment(ctx, log, deployer)
+	status.Finish(err)
+	saveStatus()
+
+	return err
+}
+
+func runDeployment(ctx context.Context, log *logrus.Entry, deployer pkgdeploy.Deployer) error {
+	err := deployer.PreDeploy(ctx)
 	if err != nil {
 		return err
 	}
@@ -6,30 +6,34 @@ package main
 import (
 	"context"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	pkgdbtoken "github.com/Azure/ARO-RP/pkg/dbtoken"
 	"github.com/Azure/ARO-RP/pkg/env"
 	pkggateway "github.com/Azure/ARO-RP/pkg/gateway"
+	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/golang"
+	"github.com/Azure/ARO-RP/pkg/util/health"
 	utilnet "github.com/Azure/ARO-RP/pkg/util/net"
+	"github.com/Azure/ARO-RP/pkg/util/oidc"
 )
 
-func gateway(ctx context.Context, log *logrus.Entry) error {
+func gateway(ctx context.Context, log *logrus.Entry, h *health.Health) error {
 	_env, err := env.NewCore(ctx, log, env.COMPONENT_GATEWAY)
 	if err != nil {
 		return err
 	}
 
-	if err = env.ValidateVars("AZURE_DBTOKEN_CLIENT_ID"); err != nil {
-		return err
+	if _env.AuthMode() != env.AuthModeManagedIdentity {
+		if err = env.ValidateVars("AZURE_DBTOKEN_CLIENT_ID"); err != nil {
+			return err
+		}
 	}
 
 	m := statsd.New(ctx, log.WithField("component", "gateway"), _env, os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
@@ -49,31 +53,24 @@ func gateway(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
-	// Access token GET request needs to be:
-	// http://169.254.169.254/metadata/identity/oauth2/token?api-version=2018-02-01&resource=$AZURE_DBTOKEN_CLIENT_ID
-	//
-	// In this context, the "resource" parameter is passed to azidentity as a
-	// "scope" argument even though a scope normally consists of an endpoint URL.
-	scope := os.Getenv("AZURE_DBTOKEN_CLIENT_ID")
-	msiRefresherAuthorizer, err := _env.NewMSIAuthorizer(scope)
-	if err != nil {
-		return err
-	}
+	if _env.AuthMode() == env.AuthModeManagedIdentity {
+		msiToken, err := _env.NewMSITokenCredential()
+		if err != nil {
+			return err
+		}
 
-	// TODO: refactor this poor man's feature flag
-	insecureSkipVerify := _env.IsLocalDevelopmentMode()
-	for _, feature := range strings.Split(os.Getenv("GATEWAY_FEATURES"), ",") {
-		if feature == "InsecureSkipVerifyDBTokenCertificate" {
-			insecureSkipVerify = true
-			break
+		dbAuthorizer, err := database.NewTokenCredentialAuthorizer(ctx, log.WithField("component", "database"), _env, msiToken, os.Getenv(envDatabaseAccountName))
+		if err != nil {
+			return err
 		}
-	}
 
-	url, err := getURL(_env.IsLocalDevelopmentMode())
-	if err != nil {
-		return err
+		dbc.SetAuthorizer(dbAuthorizer)
+	} else {
+		err = runDBTokenRefresher(ctx, log, _env, dbc, m)
+		if err != nil {
+			return err
+		}
 	}
-	dbRefresher := pkgdbtoken.NewRefresher(log, _env, msiRefresherAuthorizer, insecureSkipVerify, dbc, "gateway", m, "gateway", url)
 
 	dbName, err := DBName(_env.IsLocalDevelopmentMode())
 	if err != nil {
@@ -85,15 +82,6 @@ func gateway(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
-	go func() {
-		_ = dbRefresher.Run(ctx)
-	}()
-
-	log.Print("waiting for database token")
-	for !dbRefresher.HasSyncedOnce() {
-		time.Sleep(time.Second)
-	}
-
 	httpl, err := utilnet.Listen("tcp", ":8080", pkggateway.SocketSize)
 	if err != nil {
 		return err
@@ -109,28 +97,83 @@ func gateway(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
+	// the diagnostics endpoint is only enabled if the audience and the
+	// subjects allowed to call it are configured
+	var diagnosticsVerifier oidc.Verifier
+	var diagnosticsSubjects []string
+	if os.Getenv("GATEWAY_DIAGNOSTICS_CLIENT_ID") != "" && os.Getenv("GATEWAY_DIAGNOSTICS_SUBJECTS") != "" {
+		issuer := _env.Environment().ActiveDirectoryEndpoint + _env.TenantID() + "/v2.0"
+
+		diagnosticsVerifier, err = oidc.NewVerifier(ctx, issuer, os.Getenv("GATEWAY_DIAGNOSTICS_CLIENT_ID"))
+		if err != nil {
+			return err
+		}
+
+		diagnosticsSubjects = strings.Split(os.Getenv("GATEWAY_DIAGNOSTICS_SUBJECTS"), ",")
+	}
+
 	log.Print("listening")
 
-	p, err := pkggateway.NewGateway(ctx, _env, log.WithField("component", "gateway"), log.WithField("component", "gateway-access"), dbGateway, httpsl, httpl, healthListener, os.Getenv("ACR_RESOURCE_ID"), os.Getenv("GATEWAY_DOMAINS"), m)
+	p, err := pkggateway.NewGateway(ctx, _env, log.WithField("component", "gateway"), log.WithField("component", "gateway-access"), dbGateway, httpsl, httpl, healthListener, os.Getenv("ACR_RESOURCE_ID"), os.Getenv("GATEWAY_DOMAINS"), diagnosticsVerifier, diagnosticsSubjects, m)
 	if err != nil {
 		return err
 	}
 
-	sigterm := make(chan os.Signal, 1)
 	cancelCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	done := make(chan struct{})
-	signal.Notify(sigterm, syscall.SIGTERM)
 
 	go p.Run(cancelCtx, done)
 
-	<-sigterm
-	log.Print("received SIGTERM")
+	waitForSIGTERM(log, h)
+
 	cancel()
 	<-done
 
 	return nil
 }
 
+// runDBTokenRefresher starts refreshing the gateway's database token from the
+// dbtoken service and waits for the first token
+func runDBTokenRefresher(ctx context.Context, log *logrus.Entry, _env env.Core, dbc cosmosdb.DatabaseClient, m metrics.Emitter) error {
+	// Access token GET request needs to be:
+	// http://169.254.169.254/metadata/identity/oauth2/token?api-version=2018-02-01&resource=$AZURE_DBTOKEN_CLIENT_ID
+	//
+	// In this context, the "resource" parameter is passed to azidentity as a
+	// "scope" argument even though a scope normally consists of an endpoint URL.
+	scope := os.Getenv("AZURE_DBTOKEN_CLIENT_ID")
+	msiRefresherAuthorizer, err := _env.NewMSIAuthorizer(scope)
+	if err != nil {
+		return err
+	}
+
+	// TODO: refactor this poor man's feature flag
+	insecureSkipVerify := _env.IsLocalDevelopmentMode()
+	for _, feature := range strings.Split(os.Getenv("GATEWAY_FEATURES"), ",") {
+		if feature == "InsecureSkipVerifyDBTokenCertificate" {
+			insecureSkipVerify = true
+			break
+		}
+	}
+
+	url, err := getURL(_env.IsLocalDevelopmentMode())
+	if err != nil {
+		return err
+	}
+	dbRefresher := pkgdbtoken.NewRefresher(log, _env, msiRefresherAuthorizer, insecureSkipVerify, dbc, "gateway", m, "gateway", url)
+
+	go func() {
+		_ = dbRefresher.Run(ctx)
+	}()
+
+	log.Print("waiting for database token")
+	for !dbRefresher.HasSyncedOnce() {
+		time.Sleep(time.Second)
+	}
+
+	return nil
+}
+
 func getURL(isLocalDevelopmentMode bool) (string, error) {
 	if isLocalDevelopmentMode {
 		return "https://localhost:8445", nil
@@ -12,23 +12,115 @@ import (
 	"os"
 	"strings"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/health"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
 	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
 	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
+var (
+	healthAddr = flag.String("healthaddr", "localhost:6061", "address on which to serve /healthz and /readyz, or empty to disable")
+	configFile = flag.String("config", "", "configuration file (YAML or JSON) whose settings apply where the environment does not set them")
+	dumpConfig = flag.Bool("dump-config", false, "print the effective configuration, with secrets redacted, and exit")
+)
+
+// command is a component or tool run by the aro binary
+type command struct {
+	name string
+	// args describes the arguments which follow the name, for usage
+	args string
+	// minArgs and maxArgs bound the number of arguments which follow the
+	// name; maxArgs < 0 means unbounded
+	minArgs, maxArgs int
+	run              func(ctx context.Context, log, audit *logrus.Entry, h *health.Health) error
+}
+
+var commands = []command{
+	{
+		name: "backend",
+		run: func(ctx context.Context, log, audit *logrus.Entry, h *health.Health) error {
+			return rp(ctx, log, audit, h, false)
+		},
+	},
+	{
+		name: "dbtoken",
+		run: func(ctx context.Context, log, audit *logrus.Entry, h *health.Health) error {
+			return dbtoken(ctx, log, h)
+		},
+	},
+	{
+		name:    "deploy",
+		args:    "config.yaml location",
+		minArgs: 2,
+		maxArgs: 2,
+		run: func(ctx context.Context, log, audit *logrus.Entry, h *health.Health) error {
+			return deploy(ctx, log)
+		},
+	},
+	{
+		name: "gateway",
+		run: func(ctx context.Context, log, audit *logrus.Entry, h *health.Health) error {
+			return gateway(ctx, log, h)
+		},
+	},
+	{
+		name:    "mirror",
+		args:    "[release_image...]",
+		maxArgs: -1,
+		run: func(ctx context.Context, log, audit *logrus.Entry, h *health.Health) error {
+			return mirror(ctx, log, h)
+		},
+	},
+	{
+		name: "monitor",
+		run: func(ctx context.Context, log, audit *logrus.Entry, h *health.Health) error {
+			return monitor(ctx, log, h)
+		},
+	},
+	{
+		name:    "operator",
+		args:    "{master,worker}",
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(ctx context.Context, log, audit *logrus.Entry, h *health.Health) error {
+			return operator(ctx, log)
+		},
+	},
+	{
+		name: "portal",
+		run:  portal,
+	},
+	{
+		name:    "rollout-plan",
+		args:    "config.yaml",
+		minArgs: 1,
+		maxArgs: 1,
+		run: func(ctx context.Context, log, audit *logrus.Entry, h *health.Health) error {
+			return rolloutPlan(log)
+		},
+	},
+	{
+		name: "rp",
+		run: func(ctx context.Context, log, audit *logrus.Entry, h *health.Health) error {
+			return rp(ctx, log, audit, h, true)
+		},
+	},
+	{
+		name: "update-versions",
+		run: func(ctx context.Context, log, audit *logrus.Entry, h *health.Health) error {
+			return updateOCPVersions(ctx, log)
+		},
+	},
+}
+
 func usage() {
 	fmt.Fprint(flag.CommandLine.Output(), "usage:\n")
-	fmt.Fprintf(flag.CommandLine.Output(), "  %s dbtoken\n", os.Args[0])
-	fmt.Fprintf(flag.CommandLine.Output(), "  %s deploy config.yaml location\n", os.Args[0])
-	fmt.Fprintf(flag.CommandLine.Output(), "  %s gateway\n", os.Args[0])
-	fmt.Fprintf(flag.CommandLine.Output(), "  %s mirror [release_image...]\n", os.Args[0])
-	fmt.Fprintf(flag.CommandLine.Output(), "  %s monitor\n", os.Args[0])
-	fmt.Fprintf(flag.CommandLine.Output(), "  %s portal\n", os.Args[0])
-	fmt.Fprintf(flag.CommandLine.Output(), "  %s rp\n", os.Args[0])
-	fmt.Fprintf(flag.CommandLine.Output(), "  %s operator {master,worker}\n", os.Args[0])
-	fmt.Fprintf(flag.CommandLine.Output(), "  %s update-versions\n", os.Args[0])
+	for _, c := range commands {
+		fmt.Fprintf(flag.CommandLine.Output(), "  %s\n", strings.TrimSpace(strings.Join([]string{os.Args[0], c.name, c.args}, " ")))
+	}
 	flag.PrintDefaults()
 }
 
@@ -36,77 +128,69 @@ func main() {
 	flag.Usage = usage
 	flag.Parse()
 
+	c := findCommand(strings.ToLower(flag.Arg(0)))
+	if c == nil {
+		usage()
+		os.Exit(2)
+	}
+
 	ctx := context.Background()
 	audit := utillog.GetAuditEntry()
 	log := utillog.GetLogger()
 
+	err := env.LoadConfig(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = env.ValidateConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *dumpConfig {
+		err = env.DumpConfig(os.Stdout)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	go func() {
 		log.Warn(http.ListenAndServe("localhost:6060", nil))
 	}()
 
-	log.Printf("starting, git commit %s", version.GitCommit)
-
-	var err error
-	switch strings.ToLower(flag.Arg(0)) {
-	case "dbtoken":
-		checkArgs(1)
-		err = dbtoken(ctx, log)
-	case "deploy":
-		checkArgs(3)
-		err = deploy(ctx, log)
-	case "gateway":
-		checkArgs(1)
-		err = gateway(ctx, log)
-	case "mirror":
-		checkMinArgs(1)
-		err = mirror(ctx, log)
-	case "monitor":
-		checkArgs(1)
-		err = monitor(ctx, log)
-	case "rp":
-		checkArgs(1)
-		err = rp(ctx, log, audit)
-	case "portal":
-		checkArgs(1)
-		err = portal(ctx, log, audit)
-	case "operator":
-		checkArgs(2)
-		err = operator(ctx, log)
-	case "update-versions":
-		checkArgs(1)
-		err = updateOCPVersions(ctx, log)
-	default:
-		usage()
-		os.Exit(2)
+	h := health.New()
+	if *healthAddr != "" {
+		go func() {
+			log.Warn(http.ListenAndServe(*healthAddr, h.Handler()))
+		}()
 	}
 
+	log.Printf("starting %s, git commit %s", c.name, version.GitCommit)
+
+	err = c.run(ctx, log, audit, h)
 	if err != nil {
 		log.Fatal(err)
 	}
 }
 
-func checkArgs(required int) {
-	if len(flag.Args()) != required {
-		usage()
-		os.Exit(2)
-	}
-}
-
-func checkMinArgs(required int) {
-	if len(flag.Args()) < required {
-		usage()
-		os.Exit(2)
-	}
-}
+// findCommand returns the command called name if the number of arguments
+// given to it is valid, otherwise nil
+func findCommand(name string) *command {
+	for i := range commands {
+		c := &commands[i]
+		if c.name != name {
+			continue
+		}
 
-func DBName(isLocalDevelopmentMode bool) (string, error) {
-	if !isLocalDevelopmentMode {
-		return "ARO", nil
-	}
+		n := len(flag.Args()) - 1
+		if n < c.minArgs || (c.maxArgs >= 0 && n > c.maxArgs) {
+			return nil
+		}
 
-	if err := env.ValidateVars(envDatabaseName); err != nil {
-		return "", fmt.Errorf("%v (development mode)", err.Error())
+		return c
 	}
 
-	return os.Getenv(envDatabaseName), nil
+	return nil
 }
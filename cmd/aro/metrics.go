@@ -0,0 +1,37 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/metrics/multi"
+	"github.com/Azure/ARO-RP/pkg/metrics/otlp"
+	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
+)
+
+// newMetricsEmitter returns a metrics emitter which sends to mdm via statsd
+// and, if OTEL_EXPORTER_OTLP_ENDPOINT is set, to an OpenTelemetry collector as
+// well
+func newMetricsEmitter(ctx context.Context, log *logrus.Entry, _env env.Core, serviceName, account, namespace string) (metrics.Emitter, error) {
+	m := statsd.New(ctx, log, _env, account, namespace, os.Getenv("MDM_STATSD_SOCKET"))
+
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return m, nil
+	}
+
+	o, err := otlp.New(ctx, log, _env, endpoint, serviceName, namespace, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	return multi.New(m, o), nil
+}
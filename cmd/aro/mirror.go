@@ -11,13 +11,17 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/containers/image/v5/signature"
 	"github.com/containers/image/v5/types"
 	"github.com/sirupsen/logrus"
 
+	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
 	pkgmirror "github.com/Azure/ARO-RP/pkg/mirror"
+	"github.com/Azure/ARO-RP/pkg/util/health"
 	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
@@ -39,7 +43,9 @@ func getAuth(key string) (*types.DockerAuthConfig, error) {
 	}, nil
 }
 
-func mirror(ctx context.Context, log *logrus.Entry) error {
+// mirror mirrors the given releases once, or, if none are given, runs the
+// mirroring daemon until SIGTERM
+func mirror(ctx context.Context, log *logrus.Entry, h *health.Health) error {
 	err := env.ValidateVars(
 		"DST_AUTH",
 		"DST_ACR_NAME",
@@ -50,55 +56,68 @@ func mirror(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
-	env, err := env.NewCoreForCI(ctx, log)
+	dstAuth, err := getAuth("DST_AUTH")
 	if err != nil {
 		return err
 	}
 
-	acrDomainSuffix := "." + env.Environment().ContainerRegistryDNSSuffix
-
-	dstAuth, err := getAuth("DST_AUTH")
+	srcAuthQuay, err := getAuth("SRC_AUTH_QUAY")
 	if err != nil {
 		return err
 	}
 
-	dstAcr := os.Getenv("DST_ACR_NAME")
-
-	srcAuthQuay, err := getAuth("SRC_AUTH_QUAY")
+	srcAuthRedhat, err := getAuth("SRC_AUTH_REDHAT")
 	if err != nil {
 		return err
 	}
 
-	srcAuthRedhat, err := getAuth("SRC_AUTH_REDHAT")
+	srcAuth := func(ref string) *types.DockerAuthConfig {
+		switch {
+		case strings.HasPrefix(ref, "quay.io"):
+			return srcAuthQuay
+		case strings.HasPrefix(ref, "registry.redhat.io"), strings.HasPrefix(ref, "registry.access.redhat.com"), strings.HasPrefix(ref, "mcr.microsoft.com"):
+			return srcAuthRedhat
+		default:
+			// Geneva allows anonymous pulls
+			return nil
+		}
+	}
+
+	if len(flag.Args()) > 1 {
+		_env, err := env.NewCoreForCI(ctx, log)
+		if err != nil {
+			return err
+		}
+
+		return mirrorReleases(ctx, log, _env, dstAuth, srcAuth, flag.Args()[1:])
+	}
+
+	_env, err := env.NewCore(ctx, log, env.COMPONENT_MIRROR)
 	if err != nil {
 		return err
 	}
 
-	// Geneva allows anonymous pulls
-	var srcAuthGeneva *types.DockerAuthConfig
+	return mirrorDaemon(ctx, log, h, _env, dstAuth, srcAuth)
+}
 
-	// We can lose visibility of early image mirroring errors because logs are trimmed in the output of Ev2 pipelines.
-	// If images fail to mirror, those errors need to be returned together and logged at the end of the execution.
-	var imageMirroringErrors []string
+// mirrorImages returns the ARO component images to mirror to dstRepo
+func mirrorImages(_env env.Core, dstRepo string) []pkgmirror.Image {
+	var images []pkgmirror.Image
 
 	// Geneva mirroring from upstream only takes place in Public Cloud, in
 	// sovereign clouds a separate mirror process mirrors from the public cloud
-	if env.Environment().Environment == azure.PublicCloud {
-		srcAcrGeneva := "linuxgeneva-microsoft" + acrDomainSuffix
-		mirrorImages := []string{
+	if _env.Environment().Environment == azure.PublicCloud {
+		srcAcrGeneva := "linuxgeneva-microsoft." + _env.Environment().ContainerRegistryDNSSuffix
+		for _, ref := range []string{
 			// https://eng.ms/docs/products/geneva/collect/references/linuxcontainers
 			srcAcrGeneva + "/distroless/genevamdm:2.2024.328.1744-c5fb79-20240328t1935",
 			srcAcrGeneva + "/distroless/genevamdsd:mariner_20240327.2",
+		} {
+			images = append(images, pkgmirror.Image{
+				Source:      ref,
+				Destination: pkgmirror.DestLastIndex(dstRepo, ref),
+			})
 		}
-		for _, ref := range mirrorImages {
-			log.Printf("mirroring %s -> %s", ref, pkgmirror.DestLastIndex(dstAcr+acrDomainSuffix, ref))
-			err = pkgmirror.Copy(ctx, pkgmirror.DestLastIndex(dstAcr+acrDomainSuffix, ref), ref, dstAuth, srcAuthGeneva)
-			if err != nil {
-				imageMirroringErrors = append(imageMirroringErrors, fmt.Sprintf("%s: %s\n", ref, err))
-			}
-		}
-	} else {
-		log.Printf("skipping Geneva mirroring due to not being in Public")
 	}
 
 	for _, ref := range []string{
@@ -136,50 +155,55 @@ func mirror(ctx context.Context, log *logrus.Entry) error {
 		// https://quay.io/repository/app-sre/hive?tab=tags
 		"quay.io/app-sre/hive:5fbe0d158b",
 	} {
-		log.Printf("mirroring %s -> %s", ref, pkgmirror.Dest(dstAcr+acrDomainSuffix, ref))
+		images = append(images, pkgmirror.Image{
+			Source:      ref,
+			Destination: pkgmirror.Dest(dstRepo, ref),
+		})
+	}
 
-		srcAuth := srcAuthRedhat
-		if strings.Index(ref, "quay.io") == 0 {
-			srcAuth = srcAuthQuay
-		}
+	return images
+}
 
-		err = pkgmirror.Copy(ctx, pkgmirror.Dest(dstAcr+acrDomainSuffix, ref), ref, dstAuth, srcAuth)
+// mirrorReleases mirrors the component images and the given releases once
+func mirrorReleases(ctx context.Context, log *logrus.Entry, _env env.Core, dstAuth *types.DockerAuthConfig, srcAuth func(string) *types.DockerAuthConfig, args []string) error {
+	dstRepo := os.Getenv("DST_ACR_NAME") + "." + _env.Environment().ContainerRegistryDNSSuffix
+
+	// We can lose visibility of early image mirroring errors because logs are trimmed in the output of Ev2 pipelines.
+	// If images fail to mirror, those errors need to be returned together and logged at the end of the execution.
+	var imageMirroringErrors []string
+
+	for _, image := range mirrorImages(_env, dstRepo) {
+		log.Printf("mirroring %s -> %s", image.Source, image.Destination)
+
+		err := pkgmirror.Copy(ctx, image.Destination, image.Source, dstAuth, srcAuth(image.Source))
 		if err != nil {
-			imageMirroringErrors = append(imageMirroringErrors, fmt.Sprintf("%s: %s\n", ref, err))
+			imageMirroringErrors = append(imageMirroringErrors, fmt.Sprintf("%s: %s\n", image.Source, err))
 		}
 	}
 
 	// OCP release mirroring
 	var releases []pkgmirror.Node
-	if len(flag.Args()) == 1 {
-		log.Print("reading release graph")
-		releases, err = pkgmirror.AddFromGraph(version.NewVersion(4, 11))
-		if err != nil {
-			return err
-		}
-	} else {
-		for _, arg := range flag.Args()[1:] {
-			if strings.EqualFold(arg, "latest") {
-				releases = append(releases, pkgmirror.Node{
-					Version: version.DefaultInstallStream.Version.String(),
-					Payload: version.DefaultInstallStream.PullSpec,
-				})
-			} else {
-				vers, err := version.ParseVersion(arg)
-				if err != nil {
-					return err
-				}
-
-				node, err := pkgmirror.VersionInfo(vers)
-				if err != nil {
-					return err
-				}
-
-				releases = append(releases, pkgmirror.Node{
-					Version: node.Version,
-					Payload: node.Payload,
-				})
+	for _, arg := range args {
+		if strings.EqualFold(arg, "latest") {
+			releases = append(releases, pkgmirror.Node{
+				Version: version.DefaultInstallStream.Version.String(),
+				Payload: version.DefaultInstallStream.PullSpec,
+			})
+		} else {
+			vers, err := version.ParseVersion(arg)
+			if err != nil {
+				return err
 			}
+
+			node, err := pkgmirror.VersionInfo(vers)
+			if err != nil {
+				return err
+			}
+
+			releases = append(releases, pkgmirror.Node{
+				Version: node.Version,
+				Payload: node.Payload,
+			})
 		}
 	}
 
@@ -189,7 +213,7 @@ func mirror(ctx context.Context, log *logrus.Entry) error {
 			continue
 		}
 		log.Printf("mirroring release %s", release.Version)
-		err = pkgmirror.Mirror(ctx, log, dstAcr+acrDomainSuffix, release.Payload, dstAuth, srcAuthQuay)
+		err := pkgmirror.Mirror(ctx, log, dstRepo, release.Payload, dstAuth, srcAuth(release.Payload))
 		if err != nil {
 			imageMirroringErrors = append(imageMirroringErrors, fmt.Sprintf("%s: %s\n", release, err))
 		}
@@ -203,3 +227,62 @@ func mirror(ctx context.Context, log *logrus.Entry) error {
 
 	return nil
 }
+
+// mirrorDaemon continuously mirrors the component images and every release
+// in the release graph, recording what has been mirrored in the database
+func mirrorDaemon(ctx context.Context, log *logrus.Entry, h *health.Health, _env env.Core, dstAuth *types.DockerAuthConfig, srcAuth func(string) *types.DockerAuthConfig) error {
+	if !_env.IsLocalDevelopmentMode() {
+		err := env.ValidateVars("MDM_ACCOUNT", "MDM_NAMESPACE")
+		if err != nil {
+			return err
+		}
+	}
+
+	interval := env.DefaultMirrorInterval
+	if s := os.Getenv("MIRROR_INTERVAL"); s != "" {
+		var err error
+		interval, err = time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid MIRROR_INTERVAL: %w", err)
+		}
+	}
+
+	// MIRROR_SIGNATURE_POLICY names a containers-policy.json(5) file which
+	// every mirrored image must satisfy, e.g. requiring release images to be
+	// signed by Red Hat
+	var policy *signature.Policy
+	if path := os.Getenv("MIRROR_SIGNATURE_POLICY"); path != "" {
+		var err error
+		policy, err = signature.NewPolicyFromFile(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	m, err := newMetricsEmitter(ctx, log.WithField("component", "metrics"), _env, "aro-mirror", os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"))
+	if err != nil {
+		return err
+	}
+
+	msiToken, err := _env.NewMSITokenCredential()
+	if err != nil {
+		return err
+	}
+
+	// mirrored image documents hold no secure fields, so no AEAD is needed
+	dbc, dbName, err := newDatabaseClient(ctx, log, _env, msiToken, m, nil)
+	if err != nil {
+		return err
+	}
+
+	dbMirroredImages, err := database.NewMirroredImages(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
+	dstRepo := os.Getenv("DST_ACR_NAME") + "." + _env.Environment().ContainerRegistryDNSSuffix
+
+	d := pkgmirror.NewDaemon(log.WithField("component", "mirror"), m, dbMirroredImages, dstRepo, dstAuth, srcAuth, policy, mirrorImages(_env, dstRepo), version.NewVersion(4, 11), doNotMirrorTags, interval)
+
+	return runUntilSIGTERM(ctx, log, h, d.Run)
+}
@@ -6,8 +6,8 @@ package main
 import (
 	"context"
 	"os"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/policy"
 	"github.com/Azure/go-autorest/tracing"
 	"github.com/sirupsen/logrus"
 	kmetrics "k8s.io/client-go/tools/metrics"
@@ -15,17 +15,21 @@ import (
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/metrics/noop"
-	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/azure"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/golang"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/k8s"
 	pkgmonitor "github.com/Azure/ARO-RP/pkg/monitor"
+	"github.com/Azure/ARO-RP/pkg/monitor/canary"
+	"github.com/Azure/ARO-RP/pkg/monitor/cluster"
 	"github.com/Azure/ARO-RP/pkg/proxy"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/throttle"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
-	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+	"github.com/Azure/ARO-RP/pkg/util/health"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
-func monitor(ctx context.Context, log *logrus.Entry) error {
+func monitor(ctx context.Context, log *logrus.Entry, h *health.Health) error {
 	_env, err := env.NewEnv(ctx, log, env.COMPONENT_MONITOR)
 	if err != nil {
 		return err
@@ -43,7 +47,10 @@ func monitor(ctx context.Context, log *logrus.Entry) error {
 		}
 	}
 
-	m := statsd.New(ctx, log.WithField("component", "metrics"), _env, os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
+	m, err := newMetricsEmitter(ctx, log.WithField("component", "metrics"), _env, "aro-monitor", os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"))
+	if err != nil {
+		return err
+	}
 
 	g, err := golang.NewMetrics(log.WithField("component", "metrics"), m)
 	if err != nil {
@@ -52,86 +59,99 @@ func monitor(ctx context.Context, log *logrus.Entry) error {
 
 	go g.Run()
 
-	tracing.Register(azure.New(m))
+	throttleManager := throttle.NewManager(m)
+	tracing.Register(azureclient.NewTraceContextTracer(throttleManager.Tracer(azure.New(m))))
+	azureclient.RegisterPerCallPolicy(azure.NewPolicy(m))
+	azureclient.RegisterPerCallPolicy(throttleManager.Policy())
 	kmetrics.Register(kmetrics.RegisterOpts{
 		RequestResult:  k8s.NewResult(m),
 		RequestLatency: k8s.NewLatency(m),
 	})
 
-	clusterm := statsd.New(ctx, log.WithField("component", "metrics"), _env, os.Getenv("CLUSTER_MDM_ACCOUNT"), os.Getenv("CLUSTER_MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
-
-	msiToken, err := _env.NewMSITokenCredential()
+	clusterm, err := newMetricsEmitter(ctx, log.WithField("component", "metrics"), _env, "aro-monitor", os.Getenv("CLUSTER_MDM_ACCOUNT"), os.Getenv("CLUSTER_MDM_NAMESPACE"))
 	if err != nil {
 		return err
 	}
 
-	msiKVAuthorizer, err := _env.NewMSIAuthorizer(_env.Environment().KeyVaultScope)
+	msiToken, err := _env.NewMSITokenCredential()
 	if err != nil {
 		return err
 	}
 
-	if err := env.ValidateVars(envKeyVaultPrefix); err != nil {
+	// TODO: should not be using the service keyvault here
+	serviceKeyvault, err := newKeyvault(_env, env.ServiceKeyvaultSuffix)
+	if err != nil {
 		return err
 	}
-	keyVaultPrefix := os.Getenv(envKeyVaultPrefix)
-	// TODO: should not be using the service keyvault here
-	serviceKeyvaultURI := keyvault.URI(_env, env.ServiceKeyvaultSuffix, keyVaultPrefix)
-	serviceKeyvault := keyvault.NewManager(msiKVAuthorizer, serviceKeyvaultURI)
 
 	aead, err := encryption.NewMulti(ctx, serviceKeyvault, env.EncryptionSecretV2Name, env.EncryptionSecretName)
 	if err != nil {
 		return err
 	}
 
-	if err := env.ValidateVars(envDatabaseAccountName); err != nil {
+	dbc, dbName, err := newDatabaseClient(ctx, log, _env, msiToken, &noop.Noop{}, aead)
+	if err != nil {
 		return err
 	}
 
-	dbAccountName := os.Getenv(envDatabaseAccountName)
-	clientOptions := &policy.ClientOptions{
-		ClientOptions: _env.Environment().ManagedIdentityCredentialOptions().ClientOptions,
-	}
-	logrusEntry := log.WithField("component", "database")
-	dbAuthorizer, err := database.NewMasterKeyAuthorizer(ctx, logrusEntry, msiToken, clientOptions, _env.SubscriptionID(), _env.ResourceGroup(), dbAccountName)
+	dbMonitors, err := database.NewMonitors(ctx, dbc, dbName)
 	if err != nil {
 		return err
 	}
 
-	dbc, err := database.NewDatabaseClient(log.WithField("component", "database"), _env, dbAuthorizer, &noop.Noop{}, aead, dbAccountName)
+	dbOpenShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, dbName)
 	if err != nil {
 		return err
 	}
 
-	dbName, err := DBName(_env.IsLocalDevelopmentMode())
+	dbSubscriptions, err := database.NewSubscriptions(ctx, dbc, dbName)
 	if err != nil {
 		return err
 	}
-	dbMonitors, err := database.NewMonitors(ctx, dbc, dbName)
+
+	dbBilling, err := database.NewBilling(ctx, dbc, dbName)
 	if err != nil {
 		return err
 	}
 
-	dbOpenShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, dbName)
+	dialer, err := proxy.NewDialer(_env.IsLocalDevelopmentMode())
 	if err != nil {
 		return err
 	}
 
-	dbSubscriptions, err := database.NewSubscriptions(ctx, dbc, dbName)
+	liveConfig, err := _env.NewLiveConfigManager(ctx)
 	if err != nil {
 		return err
 	}
 
-	dialer, err := proxy.NewDialer(_env.IsLocalDevelopmentMode())
+	collectorConfigs, err := cluster.ParseCollectorConfigs(os.Getenv("CLUSTER_MONITOR_COLLECTORS"))
 	if err != nil {
 		return err
 	}
 
-	liveConfig, err := _env.NewLiveConfigManager(ctx)
-	if err != nil {
-		return err
+	var securityPostureMinimumVersion *version.Version
+	if v := os.Getenv("SECURITY_POSTURE_MINIMUM_VERSION"); v != "" {
+		securityPostureMinimumVersion, err = version.ParseVersion(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	if canaryResourceID := os.Getenv("CANARY_CLUSTER_RESOURCE_ID"); canaryResourceID != "" {
+		msiAuthorizer, err := _env.NewMSIAuthorizer(_env.Environment().ResourceManagerScope)
+		if err != nil {
+			return err
+		}
+
+		c, err := canary.New(log.WithField("component", "canary"), m, dbMonitors, _env.Environment(), msiAuthorizer, canaryResourceID, 5*time.Minute)
+		if err != nil {
+			return err
+		}
+
+		go c.Run(ctx)
 	}
 
-	mon := pkgmonitor.NewMonitor(log.WithField("component", "monitor"), dialer, dbMonitors, dbOpenShiftClusters, dbSubscriptions, m, clusterm, liveConfig, _env)
+	mon := pkgmonitor.NewMonitor(log.WithField("component", "monitor"), dialer, dbMonitors, dbOpenShiftClusters, dbSubscriptions, dbBilling, m, clusterm, liveConfig, _env, collectorConfigs, securityPostureMinimumVersion)
 
-	return mon.Run(ctx)
+	return runUntilSIGTERM(ctx, log, h, mon.Run)
 }
@@ -0,0 +1,50 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	pkgdeploy "github.com/Azure/ARO-RP/pkg/deploy"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+// rolloutPlan writes the plan for rolling the current version out to the
+// regions in the given config file to stdout, for the deploy tooling
+func rolloutPlan(log *logrus.Entry) error {
+	deployVersion := version.GitCommit
+	if deployVersion == "unknown" ||
+		(!env.IsLocalDevelopmentMode() && strings.Contains(deployVersion, "dirty")) {
+		return fmt.Errorf("invalid deploy version %q", deployVersion)
+	}
+
+	config, err := pkgdeploy.GetFleetConfig(flag.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	plan, err := pkgdeploy.PlanRollout(config, deployVersion)
+	if err != nil {
+		return err
+	}
+
+	for _, stage := range plan.Stages {
+		locations := make([]string, 0, len(stage.Regions))
+		for _, region := range stage.Regions {
+			locations = append(locations, region.Location)
+		}
+		log.Printf("%s: %s", stage.Name, strings.Join(locations, ", "))
+	}
+
+	e := json.NewEncoder(os.Stdout)
+	e.SetIndent("", "    ")
+	return e.Encode(plan)
+}
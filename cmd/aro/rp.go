@@ -7,10 +7,8 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/policy"
 	"github.com/Azure/go-autorest/tracing"
 	"github.com/sirupsen/logrus"
 	kmetrics "k8s.io/client-go/tools/metrics"
@@ -33,17 +31,19 @@ import (
 	"github.com/Azure/ARO-RP/pkg/frontend"
 	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
 	"github.com/Azure/ARO-RP/pkg/hive"
-	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/azure"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/golang"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/k8s"
+	"github.com/Azure/ARO-RP/pkg/rpfeatures"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/throttle"
 	"github.com/Azure/ARO-RP/pkg/util/clusterdata"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	"github.com/Azure/ARO-RP/pkg/util/health"
 )
 
-func rp(ctx context.Context, log, audit *logrus.Entry) error {
-	stop := make(chan struct{})
-
+// rp runs the RP backend and, if withFrontend is set, the RP frontend
+func rp(ctx context.Context, log, audit *logrus.Entry, h *health.Health, withFrontend bool) error {
 	_env, err := env.NewEnv(ctx, log, env.COMPONENT_RP)
 	if err != nil {
 		return err
@@ -78,7 +78,15 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 		return err
 	}
 
-	metrics := statsd.New(ctx, log.WithField("component", "metrics"), _env, os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
+	metrics, err := newMetricsEmitter(ctx, log.WithField("component", "metrics"), _env, "aro-rp", os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"))
+	if err != nil {
+		return err
+	}
+
+	err = setTracerProvider(ctx, log.WithField("component", "tracing"), _env, "aro-rp", os.Getenv("MDM_NAMESPACE"))
+	if err != nil {
+		return err
+	}
 
 	g, err := golang.NewMetrics(log.WithField("component", "metrics"), metrics)
 	if err != nil {
@@ -87,47 +95,35 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 
 	go g.Run()
 
-	tracing.Register(azure.New(metrics))
+	throttleManager := throttle.NewManager(metrics)
+	tracing.Register(azureclient.NewTraceContextTracer(throttleManager.Tracer(azure.New(metrics))))
+	azureclient.RegisterPerCallPolicy(azure.NewPolicy(metrics))
+	azureclient.RegisterPerCallPolicy(throttleManager.Policy())
 	kmetrics.Register(kmetrics.RegisterOpts{
 		RequestResult:  k8s.NewResult(metrics),
 		RequestLatency: k8s.NewLatency(metrics),
 	})
 
-	clusterm := statsd.New(ctx, log.WithField("component", "metrics"), _env, os.Getenv("CLUSTER_MDM_ACCOUNT"), os.Getenv("CLUSTER_MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
-
-	msiToken, err := _env.NewMSITokenCredential()
+	clusterm, err := newMetricsEmitter(ctx, log.WithField("component", "metrics"), _env, "aro-rp", os.Getenv("CLUSTER_MDM_ACCOUNT"), os.Getenv("CLUSTER_MDM_NAMESPACE"))
 	if err != nil {
 		return err
 	}
 
-	aead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.EncryptionSecretV2Name, env.EncryptionSecretName)
+	msiToken, err := _env.NewMSITokenCredential()
 	if err != nil {
 		return err
 	}
 
-	if err := env.ValidateVars(envDatabaseAccountName); err != nil {
-		return err
-	}
-
-	dbAccountName := os.Getenv(envDatabaseAccountName)
-	clientOptions := &policy.ClientOptions{
-		ClientOptions: _env.Environment().ManagedIdentityCredentialOptions().ClientOptions,
-	}
-	logrusEntry := log.WithField("component", "database")
-	dbAuthorizer, err := database.NewMasterKeyAuthorizer(ctx, logrusEntry, msiToken, clientOptions, _env.SubscriptionID(), _env.ResourceGroup(), dbAccountName)
+	aead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.EncryptionSecretV2Name, env.EncryptionSecretName)
 	if err != nil {
 		return err
 	}
 
-	dbc, err := database.NewDatabaseClient(log.WithField("component", "database"), _env, dbAuthorizer, metrics, aead, dbAccountName)
+	dbc, dbName, err := newDatabaseClient(ctx, log, _env, msiToken, metrics, aead)
 	if err != nil {
 		return err
 	}
 
-	dbName, err := DBName(env.IsLocalDevelopmentMode())
-	if err != nil {
-		return err
-	}
 	dbAsyncOperations, err := database.NewAsyncOperations(ctx, _env.IsLocalDevelopmentMode(), dbc, dbName)
 	if err != nil {
 		return err
@@ -148,6 +144,11 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 		return err
 	}
 
+	dbMonitors, err := database.NewMonitors(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
 	dbOpenShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, dbName)
 	if err != nil {
 		return err
@@ -163,45 +164,64 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 		return err
 	}
 
-	go database.EmitMetrics(ctx, log, dbOpenShiftClusters, metrics)
-
-	feAead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.FrontendEncryptionSecretV2Name, env.FrontendEncryptionSecretName)
+	dbPortalSessions, err := database.NewPortalSessions(ctx, dbc, dbName)
 	if err != nil {
 		return err
 	}
-	hiveClusterManager, err := hive.NewFromEnv(ctx, log, _env)
+
+	dbRPFeatures, err := database.NewRPFeatures(ctx, dbc, dbName)
 	if err != nil {
 		return err
 	}
-	f, err := frontend.NewFrontend(ctx, audit, log.WithField("component", "frontend"), _env, dbAsyncOperations, dbClusterManagerConfiguration, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, api.APIs, metrics, clusterm, feAead, hiveClusterManager, adminactions.NewKubeActions, adminactions.NewAzureActions, clusterdata.NewParallelEnricher(metrics, _env))
+
+	go database.EmitMetrics(ctx, log, dbOpenShiftClusters, metrics)
+	go rpfeatures.NewRefresher(log.WithField("component", "rpfeatures"), metrics, dbRPFeatures, _env.Features()).Run(ctx, 10*time.Second)
+
+	b, err := backend.NewBackend(ctx, log.WithField("component", "backend"), _env, dbAsyncOperations, dbBilling, dbGateway, dbMonitors, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, aead, metrics)
 	if err != nil {
 		return err
 	}
 
-	b, err := backend.NewBackend(ctx, log.WithField("component", "backend"), _env, dbAsyncOperations, dbBilling, dbGateway, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, aead, metrics)
-	if err != nil {
-		return err
+	var f frontend.Runnable
+	if withFrontend {
+		feAead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.FrontendEncryptionSecretV2Name, env.FrontendEncryptionSecretName)
+		if err != nil {
+			return err
+		}
+		hiveClusterManager, err := hive.NewFromEnv(ctx, log, _env)
+		if err != nil {
+			return err
+		}
+		f, err = frontend.NewFrontend(ctx, audit, log.WithField("component", "frontend"), _env, dbAsyncOperations, dbClusterManagerConfiguration, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, dbPortalSessions, api.APIs, metrics, clusterm, feAead, hiveClusterManager, adminactions.NewKubeActions, adminactions.NewAzureActions, clusterdata.NewParallelEnricher(metrics, _env))
+		if err != nil {
+			return err
+		}
 	}
 
 	// This part of the code orchestrates shutdown sequence. When sigterm is
-	// received, it will trigger backend to stop accepting new documents and
-	// finish old ones. Frontend will stop advertising itself to the loadbalancer.
-	// When shutdown completes for frontend and backend "/healthz" endpoint
-	// will go dark and external observer will know that shutdown sequence is finished
-	sigterm := make(chan os.Signal, 1)
+	// received, the frontend stops advertising itself to the loadbalancer and
+	// drains; only then is the backend told to stop accepting new documents
+	// and to finish old ones, so that it does not stop while the frontend
+	// still accepts requests.  /readyz goes dark as soon as shutdown starts.
+	stopF := make(chan struct{})
+	stopB := make(chan struct{})
 	doneF := make(chan struct{})
 	doneB := make(chan struct{})
-	signal.Notify(sigterm, syscall.SIGTERM)
 
 	log.Print("listening")
-	go b.Run(ctx, stop, doneB)
-	go f.Run(ctx, stop, doneF)
+	go b.Run(ctx, stopB, doneB)
+	if f != nil {
+		go f.Run(ctx, stopF, doneF)
+	}
 
-	<-sigterm
-	log.Print("received SIGTERM")
-	close(stop)
+	waitForSIGTERM(log, h)
+
+	if f != nil {
+		close(stopF)
+		<-doneF
+	}
+	close(stopB)
 	<-doneB
-	<-doneF
 
 	return nil
 }
@@ -0,0 +1,76 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/util/health"
+)
+
+// shutdownTimeout bounds the time a component which runs until its context
+// is cancelled is given to return after SIGTERM
+const shutdownTimeout = 30 * time.Second
+
+// waitForSIGTERM marks the component ready, blocks until SIGTERM is received
+// and then marks the component not ready.  The caller then stops the parts of
+// the component in order.
+func waitForSIGTERM(log *logrus.Entry, h *health.Health) {
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGTERM)
+	defer signal.Stop(sigterm)
+
+	h.SetReady(true)
+
+	<-sigterm
+	log.Print("received SIGTERM")
+	h.SetReady(false)
+}
+
+// runUntilSIGTERM runs run with a context which is cancelled on SIGTERM.  It
+// returns when run returns, or shutdownTimeout after SIGTERM if run does not
+// return once its context is cancelled.
+func runUntilSIGTERM(ctx context.Context, log *logrus.Entry, h *health.Health, run func(context.Context) error) error {
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGTERM)
+	defer signal.Stop(sigterm)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errch := make(chan error, 1)
+	go func() {
+		errch <- run(ctx)
+	}()
+
+	h.SetReady(true)
+
+	select {
+	case err := <-errch:
+		h.SetReady(false)
+		return err
+	case <-sigterm:
+	}
+
+	log.Print("received SIGTERM")
+	h.SetReady(false)
+	cancel()
+
+	select {
+	case err := <-errch:
+		if err == context.Canceled {
+			return nil
+		}
+		return err
+	case <-time.After(shutdownTimeout):
+		log.Warnf("not stopped after %s, exiting", shutdownTimeout)
+		return nil
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/tracing/otlp"
+)
+
+// setTracerProvider sends spans to an OpenTelemetry collector if
+// OTEL_EXPORTER_OTLP_ENDPOINT is set.  Otherwise, the global no-op tracer
+// provider is left in place and spans are not recorded.
+func setTracerProvider(ctx context.Context, log *logrus.Entry, _env env.Core, serviceName, namespace string) error {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return nil
+	}
+
+	tp, err := otlp.New(ctx, log, _env, endpoint, serviceName, namespace, 10*time.Second)
+	if err != nil {
+		return err
+	}
+
+	// export failures and spans rejected by the collector are reported here
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Error(err)
+	}))
+	otel.SetTracerProvider(tp)
+
+	return nil
+}
@@ -0,0 +1,374 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/jongio/azidext/go/azidext"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/benchmark"
+	"github.com/Azure/ARO-RP/pkg/util/cluster"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	"github.com/Azure/ARO-RP/pkg/util/storage"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+const (
+	Cluster                 = "CLUSTER"
+	BenchmarkStorageAccount = "BENCHMARK_STORAGE_ACCOUNT"
+
+	// rpURL is the development RP which is benchmarked
+	rpURL = "https://localhost:8443"
+
+	frontendAPIVersion = "2023-09-04"
+
+	benchmarkContainer = "benchmarks"
+)
+
+var (
+	benchmarks     = flag.String("benchmarks", "install,adminupdate,frontend", "comma separated benchmarks to run")
+	thresholdsFile = flag.String("thresholds", "", "JSON file configuring the regression thresholds")
+	baselineRuns   = flag.Int("baseline-runs", 10, "number of most recent runs from which the baseline is computed")
+	requests       = flag.Int("requests", 1000, "number of requests sent to the frontend")
+	concurrency    = flag.Int("concurrency", 20, "number of concurrent requests sent to the frontend")
+	dryRun         = flag.Bool("dry-run", false, "compare with the baseline without saving the results")
+)
+
+type benchmarker struct {
+	log *logrus.Entry
+	env env.Core
+	db  database.OpenShiftClusters
+	cli *http.Client
+
+	vnetResourceGroup string
+	clusterName       string
+	resourceID        string
+
+	measurements map[string]float64
+}
+
+// install creates the cluster and measures how long its installation, and
+// each step of it, took
+func (b *benchmarker) install(ctx context.Context) error {
+	_, err := b.db.Get(ctx, strings.ToLower(b.resourceID))
+	if err == nil {
+		return fmt.Errorf("cluster %s already exists: the install benchmark needs a new cluster", b.resourceID)
+	}
+	if !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		return err
+	}
+
+	profile, err := cluster.ProfileFromEnv(os.Getenv("CLUSTER_PROFILE"))
+	if err != nil {
+		return err
+	}
+
+	c, err := cluster.New(b.log, b.env, os.Getenv("CI") != "")
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = c.Create(ctx, b.vnetResourceGroup, b.clusterName, profile)
+	if err != nil {
+		return err
+	}
+	b.measurements["install.total"] = time.Since(start).Seconds()
+
+	return b.recordStepDurations(ctx, "install")
+}
+
+// adminUpdate runs an admin update of the cluster and measures how long it,
+// and each step of it, took
+func (b *benchmarker) adminUpdate(ctx context.Context) error {
+	start := time.Now()
+
+	resp, err := b.request(ctx, http.MethodPatch, "/admin"+b.resourceID+"?api-version="+admin.LatestAPIVersion, json.RawMessage("{}"), nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("admin update returned status code %d", resp.StatusCode)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Hour)
+	defer cancel()
+
+	t := time.NewTicker(30 * time.Second)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+		case <-timeoutCtx.Done():
+			return timeoutCtx.Err()
+		}
+
+		var oc *admin.OpenShiftCluster
+		resp, err := b.request(ctx, http.MethodGet, "/admin"+b.resourceID+"?api-version="+admin.LatestAPIVersion, nil, &oc)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("getting the cluster returned status code %d", resp.StatusCode)
+		}
+
+		if oc.Properties.ProvisioningState == admin.ProvisioningStateAdminUpdating {
+			continue
+		}
+
+		if oc.Properties.LastAdminUpdateError != "" {
+			return fmt.Errorf("admin update failed: %s", oc.Properties.LastAdminUpdateError)
+		}
+
+		break
+	}
+	b.measurements["adminupdate.total"] = time.Since(start).Seconds()
+
+	return b.recordStepDurations(ctx, "adminUpdate")
+}
+
+// frontend measures the latency of getting the cluster under concurrent load
+func (b *benchmarker) frontend(ctx context.Context) error {
+	latencies, failures := benchmark.Load(ctx, *requests, *concurrency, func(ctx context.Context) error {
+		resp, err := b.request(ctx, http.MethodGet, b.resourceID+"?api-version="+frontendAPIVersion, nil, nil)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return nil
+	})
+	if failures > 0 {
+		return fmt.Errorf("%d of %d requests to the frontend failed", failures, *requests)
+	}
+
+	for _, p := range []float64{50, 95, 99} {
+		b.measurements[fmt.Sprintf("frontend.get.p%v", p)] = benchmark.Percentile(latencies, p).Seconds()
+	}
+
+	return nil
+}
+
+// recordStepDurations records the durations of the steps of the operation
+// which the backend recorded on the cluster document
+func (b *benchmarker) recordStepDurations(ctx context.Context, operation string) error {
+	doc, err := b.db.Get(ctx, strings.ToLower(b.resourceID))
+	if err != nil {
+		return err
+	}
+
+	if doc.Progress == nil || doc.Progress.Operation != operation {
+		b.log.Warnf("no step durations were recorded for %s", operation)
+		return nil
+	}
+
+	for step, duration := range doc.Progress.StepDurations {
+		b.measurements[strings.ToLower(operation)+".step."+step] = float64(duration)
+	}
+
+	return nil
+}
+
+func (b *benchmarker) request(ctx context.Context, method, path string, in, out interface{}) (*http.Response, error) {
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rpURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode == http.StatusOK {
+		return resp, json.NewDecoder(resp.Body).Decode(out)
+	}
+
+	_, err = io.Copy(io.Discard, resp.Body)
+	return resp, err
+}
+
+func readThresholds(path string) (*benchmark.Thresholds, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var thresholds *benchmark.Thresholds
+	err = json.Unmarshal(b, &thresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	return thresholds, nil
+}
+
+func run(ctx context.Context, log *logrus.Entry) error {
+	flag.Parse()
+
+	if err := env.ValidateVars(Cluster, BenchmarkStorageAccount); err != nil {
+		return err
+	}
+
+	_env, err := env.NewCore(ctx, log, env.COMPONENT_TOOLING)
+	if err != nil {
+		return err
+	}
+
+	if !_env.IsLocalDevelopmentMode() {
+		return fmt.Errorf("only development RP mode is supported")
+	}
+
+	thresholds, err := readThresholds(*thresholdsFile)
+	if err != nil {
+		return err
+	}
+
+	tokenCredential, err := azidentity.NewAzureCLICredential(nil)
+	if err != nil {
+		return err
+	}
+
+	db, err := openShiftClusters(ctx, log, _env, tokenCredential)
+	if err != nil {
+		return err
+	}
+
+	authorizer := azidext.NewTokenCredentialAdapter(tokenCredential, []string{_env.Environment().ResourceManagerScope})
+	blobService, err := storage.NewManager(_env, _env.SubscriptionID(), authorizer).BlobService(ctx, _env.ResourceGroup(), os.Getenv(BenchmarkStorageAccount), mgmtstorage.Permissions("rwlc"), mgmtstorage.SignedResourceTypesC+mgmtstorage.SignedResourceTypesO)
+	if err != nil {
+		return err
+	}
+
+	// results are kept per location, as their durations are not comparable
+	store, err := benchmark.NewBlobStore(blobService, benchmarkContainer, _env.Location())
+	if err != nil {
+		return err
+	}
+
+	vnetResourceGroup := os.Getenv("RESOURCEGROUP")
+	if os.Getenv("CI") != "" {
+		vnetResourceGroup = os.Getenv(Cluster)
+	}
+	clusterName := os.Getenv(Cluster)
+
+	b := &benchmarker{
+		log: log,
+		env: _env,
+		db:  db,
+		cli: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true,
+				},
+				MaxIdleConnsPerHost: *concurrency,
+			},
+		},
+
+		vnetResourceGroup: vnetResourceGroup,
+		clusterName:       clusterName,
+		resourceID:        "/subscriptions/" + _env.SubscriptionID() + "/resourceGroups/" + vnetResourceGroup + "/providers/Microsoft.RedHatOpenShift/openShiftClusters/" + clusterName,
+
+		measurements: map[string]float64{},
+	}
+
+	result := &benchmark.Result{
+		RunAt:        time.Now().UTC(),
+		Location:     _env.Location(),
+		GitCommit:    version.GitCommit,
+		Measurements: b.measurements,
+	}
+
+	for _, name := range strings.Split(*benchmarks, ",") {
+		var f func(context.Context) error
+		switch strings.TrimSpace(name) {
+		case "install":
+			f = b.install
+		case "adminupdate":
+			f = b.adminUpdate
+		case "frontend":
+			f = b.frontend
+		default:
+			return fmt.Errorf("unknown benchmark %q", name)
+		}
+
+		log.Infof("running benchmark %s", name)
+		err = f(ctx)
+		if err != nil {
+			return fmt.Errorf("benchmark %s: %w", name, err)
+		}
+	}
+
+	baselineResults, err := store.Latest(ctx, *baselineRuns)
+	if err != nil {
+		return err
+	}
+
+	if !*dryRun {
+		err = store.Put(ctx, result)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(baselineResults) == 0 {
+		log.Info("no baseline results yet: not comparing")
+		return nil
+	}
+
+	regressions := benchmark.Compare(result, benchmark.Baseline(baselineResults), thresholds)
+	for _, regression := range regressions {
+		log.Error(regression)
+	}
+
+	if regressions != nil {
+		return fmt.Errorf("%d measurements regressed against the baseline of the last %d runs", len(regressions), len(baselineResults))
+	}
+
+	log.Infof("no measurements regressed against the baseline of the last %d runs", len(baselineResults))
+	return nil
+}
+
+func main() {
+	log := utillog.GetLogger()
+
+	if err := run(context.Background(), log); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -16,7 +16,6 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/cluster"
 	msgraph_errors "github.com/Azure/ARO-RP/pkg/util/graph/graphsdk/models/odataerrors"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
-	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
 const (
@@ -43,13 +42,11 @@ func run(ctx context.Context, log *logrus.Entry) error {
 	}
 	clusterName := os.Getenv(Cluster)
 
-	osClusterVersion := os.Getenv("OS_CLUSTER_VERSION")
-	if osClusterVersion == "" {
-		osClusterVersion = version.DefaultInstallStream.Version.String()
-		log.Infof("using default cluster version %s", osClusterVersion)
-	} else {
-		log.Infof("using specified cluster version %s", osClusterVersion)
+	profile, err := cluster.ProfileFromEnv(os.Getenv("CLUSTER_PROFILE"))
+	if err != nil {
+		return err
 	}
+	log.Infof("using cluster profile %s with version %s", profile.Name, profile.Version)
 
 	c, err := cluster.New(log, env, os.Getenv("CI") != "")
 	if err != nil {
@@ -58,7 +55,7 @@ func run(ctx context.Context, log *logrus.Entry) error {
 
 	switch strings.ToLower(os.Args[1]) {
 	case "create":
-		return c.Create(ctx, vnetResourceGroup, clusterName, osClusterVersion)
+		return c.Create(ctx, vnetResourceGroup, clusterName, profile)
 	case "createapp":
 		return c.CreateApp(ctx, clusterName)
 	case "deleteapp":
@@ -0,0 +1,61 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"crypto/tls"
+	"flag"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+// cosmosdb-server runs the in-memory CosmosDB fake so that a development RP
+// can run without an Azure CosmosDB account.  Start the RP components with
+// RP_MODE=development and DATABASE_SERVER set to the listen address.
+func run(log *logrus.Entry) error {
+	addr := flag.String("addr", "localhost:8445", "address to listen on")
+	path := flag.String("state", "cosmosdb-server.json", "file to persist state to; empty to keep state in memory")
+
+	flag.Parse()
+
+	s, err := testdatabase.NewServer(log, *path)
+	if err != nil {
+		return err
+	}
+
+	key, certs, err := utiltls.GenerateKeyAndCertificate("localhost", nil, nil, false, false)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:    *addr,
+		Handler: s,
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{
+				{
+					Certificate: [][]byte{certs[0].Raw},
+					PrivateKey:  key,
+				},
+			},
+		},
+	}
+
+	log.Printf("listening on %s, state in %q", *addr, *path)
+
+	return srv.ListenAndServeTLS("", "")
+}
+
+func main() {
+	log := utillog.GetLogger()
+
+	if err := run(log); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+)
+
+const (
+	DatabaseName        = "DATABASE_NAME"
+	DatabaseAccountName = "DATABASE_ACCOUNT_NAME"
+	KeyVaultPrefix      = "KEYVAULT_PREFIX"
+)
+
+// fixes are the fleet-wide data fixes which can be applied.  Each must be
+// idempotent, as an interrupted run repeats part of its last page when it is
+// resumed.
+var fixes = map[string]database.OpenShiftClusterDocumentMutator{
+	"clear-last-admin-update-error": func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.LastAdminUpdateError = ""
+		return nil
+	},
+}
+
+var (
+	fix                   = flag.String("fix", "", "name of the fix to apply")
+	checkpointFile        = flag.String("checkpoint", "patchall-checkpoint.json", "file to resume from and record progress in")
+	requestUnitsPerSecond = flag.Float64("request-units-per-second", 100, "request units per second to pace the run to")
+)
+
+func run(ctx context.Context, log *logrus.Entry) error {
+	flag.Parse()
+
+	f, found := fixes[*fix]
+	if !found {
+		var names []string
+		for name := range fixes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return fmt.Errorf("usage: %s -fix {%s}", os.Args[0], strings.Join(names, ","))
+	}
+
+	checkpoint, err := readCheckpoint(*checkpointFile)
+	if err != nil {
+		return err
+	}
+
+	_env, err := env.NewCore(ctx, log, env.COMPONENT_TOOLING)
+	if err != nil {
+		return err
+	}
+
+	tokenCredential, err := azidentity.NewAzureCLICredential(nil)
+	if err != nil {
+		return err
+	}
+
+	msiKVAuthorizer, err := _env.NewMSIAuthorizer(_env.Environment().KeyVaultScope)
+	if err != nil {
+		return err
+	}
+
+	if err := env.ValidateVars(KeyVaultPrefix); err != nil {
+		return err
+	}
+	keyVaultPrefix := os.Getenv(KeyVaultPrefix)
+	serviceKeyvaultURI := keyvault.URI(_env, env.ServiceKeyvaultSuffix, keyVaultPrefix)
+	serviceKeyvault := keyvault.NewManager(msiKVAuthorizer, serviceKeyvaultURI)
+
+	aead, err := encryption.NewMulti(ctx, serviceKeyvault, env.EncryptionSecretV2Name, env.EncryptionSecretName)
+	if err != nil {
+		return err
+	}
+
+	if err := env.ValidateVars(DatabaseAccountName); err != nil {
+		return err
+	}
+
+	dbAccountName := os.Getenv(DatabaseAccountName)
+	clientOptions := &policy.ClientOptions{
+		ClientOptions: _env.Environment().ManagedIdentityCredentialOptions().ClientOptions,
+	}
+	logrusEntry := log.WithField("component", "database")
+	dbAuthorizer, err := database.NewMasterKeyAuthorizer(ctx, logrusEntry, tokenCredential, clientOptions, _env.SubscriptionID(), _env.ResourceGroup(), dbAccountName)
+	if err != nil {
+		return err
+	}
+
+	dbc, err := database.NewDatabaseClient(log.WithField("component", "database"), _env, dbAuthorizer, &noop.Noop{}, aead, dbAccountName)
+	if err != nil {
+		return err
+	}
+
+	dbName, err := DBName(_env.IsLocalDevelopmentMode())
+	if err != nil {
+		return err
+	}
+
+	openShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
+	if checkpoint != nil {
+		log.Printf("resuming from %s: %d patched, %d failed", *checkpointFile, checkpoint.Patched, len(checkpoint.Failed))
+	}
+
+	checkpoint, err = openShiftClusters.PatchAll(ctx, nil, f, &database.PatchAllOptions{
+		RequestUnitsPerSecond: *requestUnitsPerSecond,
+		Checkpoint:            checkpoint,
+		OnCheckpoint: func(ctx context.Context, checkpoint *database.PatchAllCheckpoint) error {
+			log.Printf("%d patched, %d failed", checkpoint.Patched, len(checkpoint.Failed))
+			return writeCheckpoint(*checkpointFile, checkpoint)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, failure := range checkpoint.Failed {
+		log.Errorf("%s: %s", failure.Key, failure.Error)
+	}
+
+	log.Printf("done: %d patched, %d failed; remove %s before applying another fix", checkpoint.Patched, len(checkpoint.Failed), *checkpointFile)
+
+	return nil
+}
+
+func readCheckpoint(path string) (*database.PatchAllCheckpoint, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint *database.PatchAllCheckpoint
+	return checkpoint, json.Unmarshal(b, &checkpoint)
+}
+
+func writeCheckpoint(path string, checkpoint *database.PatchAllCheckpoint) error {
+	b, err := json.MarshalIndent(checkpoint, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0600)
+}
+
+func main() {
+	log := utillog.GetLogger()
+
+	if err := run(context.Background(), log); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func DBName(isLocalDevelopmentMode bool) (string, error) {
+	if !isLocalDevelopmentMode {
+		return "ARO", nil
+	}
+
+	if err := env.ValidateVars(DatabaseName); err != nil {
+		return "", fmt.Errorf("%v (development mode)", err.Error())
+	}
+
+	return os.Getenv(DatabaseName), nil
+}
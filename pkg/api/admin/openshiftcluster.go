@@ -16,6 +16,23 @@ type OpenShiftClusterList struct {
 	NextLink string `json:"nextLink,omitempty"`
 }
 
+// SoftDeletedOpenShiftClusterList represents a list of the tombstones of
+// deleted OpenShift clusters.
+type SoftDeletedOpenShiftClusterList struct {
+	// The list of tombstones.
+	SoftDeletedOpenShiftClusters []*SoftDeletedOpenShiftCluster `json:"value"`
+}
+
+// SoftDeletedOpenShiftCluster represents the tombstone of a deleted OpenShift
+// cluster.
+type SoftDeletedOpenShiftCluster struct {
+	// The time the cluster was deleted, in seconds since the epoch.
+	DeletionTime int `json:"deletionTime,omitempty"`
+
+	// The cluster as it was when it was deleted.
+	OpenShiftCluster *OpenShiftCluster `json:"openShiftCluster,omitempty"`
+}
+
 // OpenShiftCluster represents an Azure Red Hat OpenShift cluster.
 type OpenShiftCluster struct {
 	ID         string                     `json:"id,omitempty" mutable:"case"`
@@ -51,6 +68,8 @@ type OpenShiftClusterProperties struct {
 	WorkerProfiles []WorkerProfile `json:"workerProfiles,omitempty"`
 	// WorkerProfilesStatus is used to store the enriched worker profile data
 	WorkerProfilesStatus            []WorkerProfile   `json:"workerProfilesStatus,omitempty" swagger:"readOnly"`
+	ZonePlacement                   ZonePlacement     `json:"zonePlacement,omitempty"`
+	Zones                           []string          `json:"zones,omitempty"`
 	APIServerProfile                APIServerProfile  `json:"apiserverProfile,omitempty"`
 	IngressProfiles                 []IngressProfile  `json:"ingressProfiles,omitempty"`
 	Install                         *Install          `json:"install,omitempty"`
@@ -106,9 +125,10 @@ const (
 	// Maintenance tasks that perform work on the cluster
 	//
 
-	MaintenanceTaskEverything MaintenanceTask = "Everything"
-	MaintenanceTaskOperator   MaintenanceTask = "OperatorUpdate"
-	MaintenanceTaskRenewCerts MaintenanceTask = "CertificatesRenewal"
+	MaintenanceTaskEverything     MaintenanceTask = "Everything"
+	MaintenanceTaskOperator       MaintenanceTask = "OperatorUpdate"
+	MaintenanceTaskRenewCerts     MaintenanceTask = "CertificatesRenewal"
+	MaintenanceTaskRotateACRToken MaintenanceTask = "ACRTokenRotation"
 
 	//
 	// Maintenance tasks for updating customer maintenance signals
@@ -219,10 +239,12 @@ type NetworkProfile struct {
 	// The software defined network (SDN) to use when installing the cluster.
 	SoftwareDefinedNetwork SoftwareDefinedNetwork `json:"softwareDefinedNetwork,omitempty"`
 
-	PodCIDR      string       `json:"podCidr,omitempty"`
-	ServiceCIDR  string       `json:"serviceCidr,omitempty"`
-	MTUSize      MTUSize      `json:"mtuSize,omitempty"`
-	OutboundType OutboundType `json:"outboundType,omitempty" mutable:"true"`
+	PodCIDR         string       `json:"podCidr,omitempty"`
+	ServiceCIDR     string       `json:"serviceCidr,omitempty"`
+	PodCIDRIPv6     string       `json:"podCidrIpv6,omitempty"`
+	ServiceCIDRIPv6 string       `json:"serviceCidrIpv6,omitempty"`
+	MTUSize         MTUSize      `json:"mtuSize,omitempty"`
+	OutboundType    OutboundType `json:"outboundType,omitempty" mutable:"true"`
 
 	APIServerPrivateEndpointIP string               `json:"privateEndpointIp,omitempty"`
 	GatewayPrivateEndpointIP   string               `json:"gatewayPrivateEndpointIp,omitempty"`
@@ -249,12 +271,42 @@ const (
 	EncryptionAtHostDisabled EncryptionAtHost = "Disabled"
 )
 
+// DiskType represents the managed disk type of VMs
+type DiskType string
+
+// DiskType constants
+const (
+	DiskTypePremiumLRS  DiskType = "Premium_LRS"
+	DiskTypeUltraSSDLRS DiskType = "UltraSSD_LRS"
+)
+
+// AcceleratedNetworking represents accelerated networking state
+type AcceleratedNetworking string
+
+// AcceleratedNetworking constants
+const (
+	AcceleratedNetworkingEnabled  AcceleratedNetworking = "Enabled"
+	AcceleratedNetworkingDisabled AcceleratedNetworking = "Disabled"
+)
+
+// ZonePlacement represents how a cluster's VMs are placed in availability zones
+type ZonePlacement string
+
+// ZonePlacement constants
+const (
+	ZonePlacementAutomatic ZonePlacement = "Automatic"
+	ZonePlacementNonZonal  ZonePlacement = "NonZonal"
+)
+
 // MasterProfile represents a master profile.
 type MasterProfile struct {
-	VMSize              VMSize           `json:"vmSize,omitempty"`
-	SubnetID            string           `json:"subnetId,omitempty"`
-	EncryptionAtHost    EncryptionAtHost `json:"encryptionAtHost,omitempty"`
-	DiskEncryptionSetID string           `json:"diskEncryptionSetId,omitempty"`
+	VMSize                VMSize                `json:"vmSize,omitempty"`
+	SubnetID              string                `json:"subnetId,omitempty"`
+	EncryptionAtHost      EncryptionAtHost      `json:"encryptionAtHost,omitempty"`
+	DiskEncryptionSetID   string                `json:"diskEncryptionSetId,omitempty"`
+	DiskType              DiskType              `json:"diskType,omitempty"`
+	AcceleratedNetworking AcceleratedNetworking `json:"acceleratedNetworking,omitempty"`
+	Zones                 []string              `json:"zones,omitempty"`
 }
 
 // VMSize represents a VM size.
@@ -382,20 +434,35 @@ const (
 
 // WorkerProfile represents a worker profile.
 type WorkerProfile struct {
-	Name                string           `json:"name,omitempty"`
-	VMSize              VMSize           `json:"vmSize,omitempty"`
-	DiskSizeGB          int              `json:"diskSizeGB,omitempty"`
-	SubnetID            string           `json:"subnetId,omitempty"`
-	Count               int              `json:"count,omitempty"`
-	EncryptionAtHost    EncryptionAtHost `json:"encryptionAtHost,omitempty"`
-	DiskEncryptionSetID string           `json:"diskEncryptionSetId,omitempty"`
+	Name                  string                `json:"name,omitempty"`
+	VMSize                VMSize                `json:"vmSize,omitempty"`
+	DiskSizeGB            int                   `json:"diskSizeGB,omitempty"`
+	SubnetID              string                `json:"subnetId,omitempty"`
+	Count                 int                   `json:"count,omitempty"`
+	EncryptionAtHost      EncryptionAtHost      `json:"encryptionAtHost,omitempty"`
+	DiskEncryptionSetID   string                `json:"diskEncryptionSetId,omitempty"`
+	DiskType              DiskType              `json:"diskType,omitempty"`
+	AcceleratedNetworking AcceleratedNetworking `json:"acceleratedNetworking,omitempty"`
+	Zones                 []string              `json:"zones,omitempty"`
+	SpotVMOptions         *SpotVMOptions        `json:"spotVMOptions,omitempty"`
+	MaxSurge              int                   `json:"maxSurge,omitempty"`
 }
 
+// SpotVMOptions represents the Spot VM options of a worker profile.
+type SpotVMOptions struct {
+	MaxPrice       *float64           `json:"maxPrice,omitempty"`
+	EvictionPolicy SpotEvictionPolicy `json:"evictionPolicy,omitempty"`
+}
+
+// SpotEvictionPolicy represents a Spot VM eviction policy.
+type SpotEvictionPolicy string
+
 // APIServerProfile represents an API server profile.
 type APIServerProfile struct {
 	Visibility Visibility `json:"visibility,omitempty"`
 	URL        string     `json:"url,omitempty"`
 	IP         string     `json:"ip,omitempty"`
+	IPv6       string     `json:"ipv6,omitempty"`
 	IntIP      string     `json:"intIp,omitempty"`
 }
 
@@ -413,6 +480,7 @@ type IngressProfile struct {
 	Name       string     `json:"name,omitempty"`
 	Visibility Visibility `json:"visibility,omitempty"`
 	IP         string     `json:"ip,omitempty"`
+	IPv6       string     `json:"ipv6,omitempty"`
 }
 
 // PlatformWorkloadIdentityProfile encapsulates all information that is specific to workload identity clusters.
@@ -453,15 +521,20 @@ type Install struct {
 type InstallPhase int
 
 // InstallPhase constants.
+//
+// The values match those of the internal API, which are stored in the cluster
+// document, so new phases must be appended.
 const (
 	InstallPhaseBootstrap InstallPhase = iota
 	InstallPhaseRemoveBootstrap
+	InstallPhaseReserve
 )
 
 // RegistryProfile represents a registry profile
 type RegistryProfile struct {
-	Name     string `json:"name,omitempty"`
-	Username string `json:"username,omitempty"`
+	Name     string     `json:"name,omitempty"`
+	Username string     `json:"username,omitempty"`
+	Expiry   *time.Time `json:"expiry,omitempty"`
 }
 
 // ArchitectureVersion represents an architecture version
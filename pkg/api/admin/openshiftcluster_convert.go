@@ -53,6 +53,8 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 				SoftwareDefinedNetwork:     SoftwareDefinedNetwork(oc.Properties.NetworkProfile.SoftwareDefinedNetwork),
 				PodCIDR:                    oc.Properties.NetworkProfile.PodCIDR,
 				ServiceCIDR:                oc.Properties.NetworkProfile.ServiceCIDR,
+				PodCIDRIPv6:                oc.Properties.NetworkProfile.PodCIDRIPv6,
+				ServiceCIDRIPv6:            oc.Properties.NetworkProfile.ServiceCIDRIPv6,
 				MTUSize:                    MTUSize(oc.Properties.NetworkProfile.MTUSize),
 				OutboundType:               OutboundType(oc.Properties.NetworkProfile.OutboundType),
 				APIServerPrivateEndpointIP: oc.Properties.NetworkProfile.APIServerPrivateEndpointIP,
@@ -60,17 +62,23 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 				GatewayPrivateLinkID:       oc.Properties.NetworkProfile.GatewayPrivateLinkID,
 			},
 			MasterProfile: MasterProfile{
-				VMSize:              VMSize(oc.Properties.MasterProfile.VMSize),
-				SubnetID:            oc.Properties.MasterProfile.SubnetID,
-				EncryptionAtHost:    EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost),
-				DiskEncryptionSetID: oc.Properties.MasterProfile.DiskEncryptionSetID,
+				VMSize:                VMSize(oc.Properties.MasterProfile.VMSize),
+				SubnetID:              oc.Properties.MasterProfile.SubnetID,
+				EncryptionAtHost:      EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost),
+				DiskEncryptionSetID:   oc.Properties.MasterProfile.DiskEncryptionSetID,
+				DiskType:              DiskType(oc.Properties.MasterProfile.DiskType),
+				AcceleratedNetworking: AcceleratedNetworking(oc.Properties.MasterProfile.AcceleratedNetworking),
+				Zones:                 append([]string(nil), oc.Properties.MasterProfile.Zones...),
 			},
 			APIServerProfile: APIServerProfile{
 				Visibility: Visibility(oc.Properties.APIServerProfile.Visibility),
 				URL:        oc.Properties.APIServerProfile.URL,
 				IP:         oc.Properties.APIServerProfile.IP,
+				IPv6:       oc.Properties.APIServerProfile.IPv6,
 				IntIP:      oc.Properties.APIServerProfile.IntIP,
 			},
+			ZonePlacement:                   ZonePlacement(oc.Properties.ZonePlacement),
+			Zones:                           append([]string(nil), oc.Properties.Zones...),
 			StorageSuffix:                   oc.Properties.StorageSuffix,
 			ImageRegistryStorageAccountName: oc.Properties.ImageRegistryStorageAccountName,
 			InfraID:                         oc.Properties.InfraID,
@@ -122,13 +130,18 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.WorkerProfiles = make([]WorkerProfile, 0, len(oc.Properties.WorkerProfiles))
 		for _, p := range oc.Properties.WorkerProfiles {
 			out.Properties.WorkerProfiles = append(out.Properties.WorkerProfiles, WorkerProfile{
-				Name:                p.Name,
-				VMSize:              VMSize(p.VMSize),
-				DiskSizeGB:          p.DiskSizeGB,
-				SubnetID:            p.SubnetID,
-				Count:               p.Count,
-				EncryptionAtHost:    EncryptionAtHost(p.EncryptionAtHost),
-				DiskEncryptionSetID: p.DiskEncryptionSetID,
+				Name:                  p.Name,
+				VMSize:                VMSize(p.VMSize),
+				DiskSizeGB:            p.DiskSizeGB,
+				SubnetID:              p.SubnetID,
+				Count:                 p.Count,
+				EncryptionAtHost:      EncryptionAtHost(p.EncryptionAtHost),
+				DiskEncryptionSetID:   p.DiskEncryptionSetID,
+				DiskType:              DiskType(p.DiskType),
+				AcceleratedNetworking: AcceleratedNetworking(p.AcceleratedNetworking),
+				Zones:                 append([]string(nil), p.Zones...),
+				SpotVMOptions:         spotVMOptionsToExternal(p.SpotVMOptions),
+				MaxSurge:              p.MaxSurge,
 			})
 		}
 	}
@@ -137,13 +150,18 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.WorkerProfilesStatus = make([]WorkerProfile, 0, len(oc.Properties.WorkerProfilesStatus))
 		for _, p := range oc.Properties.WorkerProfilesStatus {
 			out.Properties.WorkerProfilesStatus = append(out.Properties.WorkerProfilesStatus, WorkerProfile{
-				Name:                p.Name,
-				VMSize:              VMSize(p.VMSize),
-				DiskSizeGB:          p.DiskSizeGB,
-				SubnetID:            p.SubnetID,
-				Count:               p.Count,
-				EncryptionAtHost:    EncryptionAtHost(p.EncryptionAtHost),
-				DiskEncryptionSetID: p.DiskEncryptionSetID,
+				Name:                  p.Name,
+				VMSize:                VMSize(p.VMSize),
+				DiskSizeGB:            p.DiskSizeGB,
+				SubnetID:              p.SubnetID,
+				Count:                 p.Count,
+				EncryptionAtHost:      EncryptionAtHost(p.EncryptionAtHost),
+				DiskEncryptionSetID:   p.DiskEncryptionSetID,
+				DiskType:              DiskType(p.DiskType),
+				AcceleratedNetworking: AcceleratedNetworking(p.AcceleratedNetworking),
+				Zones:                 append([]string(nil), p.Zones...),
+				SpotVMOptions:         spotVMOptionsToExternal(p.SpotVMOptions),
+				MaxSurge:              p.MaxSurge,
 			})
 		}
 	}
@@ -155,6 +173,7 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 				Name:       p.Name,
 				Visibility: Visibility(p.Visibility),
 				IP:         p.IP,
+				IPv6:       p.IPv6,
 			})
 		}
 	}
@@ -201,6 +220,7 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		for i, v := range oc.Properties.RegistryProfiles {
 			out.Properties.RegistryProfiles[i].Name = v.Name
 			out.Properties.RegistryProfiles[i].Username = v.Username
+			out.Properties.RegistryProfiles[i].Expiry = v.Expiry
 		}
 	}
 
@@ -227,6 +247,17 @@ func (c openShiftClusterConverter) ToExternalList(ocs []*api.OpenShiftCluster, n
 	return l
 }
 
+func spotVMOptionsToExternal(o *api.SpotVMOptions) *SpotVMOptions {
+	if o == nil {
+		return nil
+	}
+
+	return &SpotVMOptions{
+		MaxPrice:       o.MaxPrice,
+		EvictionPolicy: SpotEvictionPolicy(o.EvictionPolicy),
+	}
+}
+
 // ToInternal overwrites in place a pre-existing internal object, setting (only)
 // all mapped fields from the external representation. ToInternal modifies its
 // argument; there is no pointer aliasing between the passed and returned
@@ -290,6 +321,8 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	}
 	out.Properties.NetworkProfile.PodCIDR = oc.Properties.NetworkProfile.PodCIDR
 	out.Properties.NetworkProfile.ServiceCIDR = oc.Properties.NetworkProfile.ServiceCIDR
+	out.Properties.NetworkProfile.PodCIDRIPv6 = oc.Properties.NetworkProfile.PodCIDRIPv6
+	out.Properties.NetworkProfile.ServiceCIDRIPv6 = oc.Properties.NetworkProfile.ServiceCIDRIPv6
 	out.Properties.NetworkProfile.MTUSize = api.MTUSize(oc.Properties.NetworkProfile.MTUSize)
 	out.Properties.NetworkProfile.OutboundType = api.OutboundType(oc.Properties.NetworkProfile.OutboundType)
 	out.Properties.NetworkProfile.SoftwareDefinedNetwork = api.SoftwareDefinedNetwork(oc.Properties.NetworkProfile.SoftwareDefinedNetwork)
@@ -341,6 +374,11 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	out.Properties.MasterProfile.SubnetID = oc.Properties.MasterProfile.SubnetID
 	out.Properties.MasterProfile.EncryptionAtHost = api.EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost)
 	out.Properties.MasterProfile.DiskEncryptionSetID = oc.Properties.MasterProfile.DiskEncryptionSetID
+	out.Properties.MasterProfile.DiskType = api.DiskType(oc.Properties.MasterProfile.DiskType)
+	out.Properties.MasterProfile.AcceleratedNetworking = api.AcceleratedNetworking(oc.Properties.MasterProfile.AcceleratedNetworking)
+	out.Properties.MasterProfile.Zones = append([]string(nil), oc.Properties.MasterProfile.Zones...)
+	out.Properties.ZonePlacement = api.ZonePlacement(oc.Properties.ZonePlacement)
+	out.Properties.Zones = append([]string(nil), oc.Properties.Zones...)
 	out.Properties.StorageSuffix = oc.Properties.StorageSuffix
 	out.Properties.ImageRegistryStorageAccountName = oc.Properties.ImageRegistryStorageAccountName
 	out.Properties.WorkerProfiles = nil
@@ -354,6 +392,16 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.WorkerProfiles[i].Count = oc.Properties.WorkerProfiles[i].Count
 			out.Properties.WorkerProfiles[i].EncryptionAtHost = api.EncryptionAtHost(oc.Properties.WorkerProfiles[i].EncryptionAtHost)
 			out.Properties.WorkerProfiles[i].DiskEncryptionSetID = oc.Properties.WorkerProfiles[i].DiskEncryptionSetID
+			out.Properties.WorkerProfiles[i].DiskType = api.DiskType(oc.Properties.WorkerProfiles[i].DiskType)
+			out.Properties.WorkerProfiles[i].AcceleratedNetworking = api.AcceleratedNetworking(oc.Properties.WorkerProfiles[i].AcceleratedNetworking)
+			out.Properties.WorkerProfiles[i].Zones = append([]string(nil), oc.Properties.WorkerProfiles[i].Zones...)
+			out.Properties.WorkerProfiles[i].MaxSurge = oc.Properties.WorkerProfiles[i].MaxSurge
+			if oc.Properties.WorkerProfiles[i].SpotVMOptions != nil {
+				out.Properties.WorkerProfiles[i].SpotVMOptions = &api.SpotVMOptions{
+					MaxPrice:       oc.Properties.WorkerProfiles[i].SpotVMOptions.MaxPrice,
+					EvictionPolicy: api.SpotEvictionPolicy(oc.Properties.WorkerProfiles[i].SpotVMOptions.EvictionPolicy),
+				}
+			}
 		}
 	}
 	out.Properties.WorkerProfilesStatus = nil
@@ -367,11 +415,22 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.WorkerProfilesStatus[i].Count = oc.Properties.WorkerProfilesStatus[i].Count
 			out.Properties.WorkerProfilesStatus[i].EncryptionAtHost = api.EncryptionAtHost(oc.Properties.WorkerProfilesStatus[i].EncryptionAtHost)
 			out.Properties.WorkerProfilesStatus[i].DiskEncryptionSetID = oc.Properties.WorkerProfilesStatus[i].DiskEncryptionSetID
+			out.Properties.WorkerProfilesStatus[i].DiskType = api.DiskType(oc.Properties.WorkerProfilesStatus[i].DiskType)
+			out.Properties.WorkerProfilesStatus[i].AcceleratedNetworking = api.AcceleratedNetworking(oc.Properties.WorkerProfilesStatus[i].AcceleratedNetworking)
+			out.Properties.WorkerProfilesStatus[i].Zones = append([]string(nil), oc.Properties.WorkerProfilesStatus[i].Zones...)
+			out.Properties.WorkerProfilesStatus[i].MaxSurge = oc.Properties.WorkerProfilesStatus[i].MaxSurge
+			if oc.Properties.WorkerProfilesStatus[i].SpotVMOptions != nil {
+				out.Properties.WorkerProfilesStatus[i].SpotVMOptions = &api.SpotVMOptions{
+					MaxPrice:       oc.Properties.WorkerProfilesStatus[i].SpotVMOptions.MaxPrice,
+					EvictionPolicy: api.SpotEvictionPolicy(oc.Properties.WorkerProfilesStatus[i].SpotVMOptions.EvictionPolicy),
+				}
+			}
 		}
 	}
 	out.Properties.APIServerProfile.Visibility = api.Visibility(oc.Properties.APIServerProfile.Visibility)
 	out.Properties.APIServerProfile.URL = oc.Properties.APIServerProfile.URL
 	out.Properties.APIServerProfile.IP = oc.Properties.APIServerProfile.IP
+	out.Properties.APIServerProfile.IPv6 = oc.Properties.APIServerProfile.IPv6
 	out.Properties.APIServerProfile.IntIP = oc.Properties.APIServerProfile.IntIP
 	out.Properties.IngressProfiles = nil
 	if oc.Properties.IngressProfiles != nil {
@@ -380,6 +439,7 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.IngressProfiles[i].Name = oc.Properties.IngressProfiles[i].Name
 			out.Properties.IngressProfiles[i].Visibility = api.Visibility(oc.Properties.IngressProfiles[i].Visibility)
 			out.Properties.IngressProfiles[i].IP = oc.Properties.IngressProfiles[i].IP
+			out.Properties.IngressProfiles[i].IPv6 = oc.Properties.IngressProfiles[i].IPv6
 		}
 	}
 
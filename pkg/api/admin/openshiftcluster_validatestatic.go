@@ -23,10 +23,9 @@ func (sv openShiftClusterStaticValidator) Static(_oc interface{}, _current *api.
 }
 
 func (sv openShiftClusterStaticValidator) validateDelta(oc, current *OpenShiftCluster) error {
-	err := immutable.Validate("", oc, current)
+	err := immutable.ValidateDelta(oc, current)
 	if err != nil {
-		err := err.(*immutable.ValidationError)
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodePropertyChangeNotAllowed, err.Target, err.Message)
+		return err
 	}
 
 	return validateMaintenanceTask(oc.Properties.MaintenanceTask)
@@ -37,6 +36,7 @@ func validateMaintenanceTask(task MaintenanceTask) error {
 		task == MaintenanceTaskEverything ||
 		task == MaintenanceTaskOperator ||
 		task == MaintenanceTaskRenewCerts ||
+		task == MaintenanceTaskRotateACRToken ||
 		task == MaintenanceTaskPending ||
 		task == MaintenanceTaskNone ||
 		task == MaintenanceTaskCustomerActionNeeded) {
@@ -49,10 +49,5 @@ func (sv openShiftVersionStaticValidator) validate(new *OpenShiftVersion, isCrea
 }
 
 func (sv openShiftVersionStaticValidator) validateDelta(new, current *OpenShiftVersion) error {
-	err := immutable.Validate("", new, current)
-	if err != nil {
-		err := err.(*immutable.ValidationError)
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodePropertyChangeNotAllowed, err.Target, err.Message)
-	}
-	return nil
+	return immutable.ValidateDelta(new, current)
 }
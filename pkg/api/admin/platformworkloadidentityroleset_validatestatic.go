@@ -61,11 +61,6 @@ func (sv platformWorkloadIdentityRoleSetStaticValidator) validate(new *PlatformW
 }
 
 func (sv platformWorkloadIdentityRoleSetStaticValidator) validateDelta(new, current *PlatformWorkloadIdentityRoleSet) error {
-	err := immutable.Validate("", new, current)
-	if err != nil {
-		err := err.(*immutable.ValidationError)
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodePropertyChangeNotAllowed, err.Target, err.Message)
-	}
-	return nil
+	return immutable.ValidateDelta(new, current)
 }
 */
@@ -0,0 +1,53 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// PortalSessionList represents a list of portal sessions.
+type PortalSessionList struct {
+	// The list of portal sessions.
+	PortalSessions []*PortalSession `json:"value"`
+}
+
+// PortalSession represents an SRE being granted access to a cluster through
+// the portal.
+type PortalSession struct {
+	// The user who was granted access.
+	Username string `json:"username,omitempty"`
+
+	// The kind of access granted, either SSH, Kubeconfig, PodLogs, PodExec or
+	// BreakGlass.
+	Kind string `json:"kind,omitempty"`
+
+	// Whether elevated access was granted.
+	Elevated bool `json:"elevated,omitempty"`
+
+	// The index of the master VM accessed by an SSH session.
+	Master int `json:"master,omitempty"`
+
+	// The namespace of the pod accessed by a PodLogs or PodExec session.
+	Namespace string `json:"namespace,omitempty"`
+
+	// The pod accessed by a PodLogs or PodExec session.
+	Pod string `json:"pod,omitempty"`
+
+	// The container accessed by a PodLogs or PodExec session.
+	Container string `json:"container,omitempty"`
+
+	// The command run by a PodExec session.
+	Command []string `json:"command,omitempty"`
+
+	// The key ID of the certificate minted for an SSH session, or the common
+	// name of the certificate minted for a BreakGlass session.
+	CertificateKeyID string `json:"certificateKeyId,omitempty"`
+
+	// The serial number of the certificate minted for an SSH or BreakGlass session.
+	CertificateSerial uint64 `json:"certificateSerial,omitempty"`
+
+	// The expiry time of the certificate minted for an SSH or BreakGlass
+	// session, in seconds since the epoch.
+	CertificateValidBefore int `json:"certificateValidBefore,omitempty"`
+
+	// The time access was granted, in seconds since the epoch.
+	CreationTime int `json:"creationTime,omitempty"`
+}
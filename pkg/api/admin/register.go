@@ -4,17 +4,60 @@ package admin
 // Licensed under the Apache License 2.0.
 
 import (
+	"time"
+
 	"github.com/Azure/ARO-RP/pkg/api"
 )
 
-// APIVersion contains a version string as it will be used by clients
-const APIVersion = "admin"
+const (
+	// APIVersion is the original, unversioned admin API version.  It is
+	// deprecated in favour of the explicit admin API versions, and is served
+	// as APIVersion20241001.
+	APIVersion = "admin"
+
+	// APIVersion20241001 is the first explicitly versioned admin API version
+	APIVersion20241001 = "2024-10-01-admin"
+
+	// LatestAPIVersion is the admin API version which clients should use
+	LatestAPIVersion = APIVersion20241001
+)
+
+// Deprecation describes the deprecation of an admin API version
+type Deprecation struct {
+	// Successor is the admin API version which clients should move to
+	Successor string
+
+	// Sunset, if not zero, is the time after which the API version may be
+	// removed
+	Sunset time.Time
+}
+
+// APIVersions are the admin API versions, mapped to their deprecation, or to
+// nil if they are not deprecated.  A breaking change to the admin API is made
+// in a new API version with its own converters, and the previous version is
+// deprecated here so that SRE tooling is warned before it is removed.
+var APIVersions = map[string]*Deprecation{
+	APIVersion: {
+		Successor: APIVersion20241001,
+	},
+	APIVersion20241001: nil,
+}
+
+// IsAPIVersion returns true if apiVersion is an admin API version
+func IsAPIVersion(apiVersion string) bool {
+	_, ok := APIVersions[apiVersion]
+	return ok
+}
 
 func init() {
-	api.APIs[APIVersion] = &api.Version{
+	v := &api.Version{
 		OpenShiftClusterConverter:       openShiftClusterConverter{},
 		OpenShiftClusterStaticValidator: openShiftClusterStaticValidator{},
 		OpenShiftVersionConverter:       openShiftVersionConverter{},
 		OpenShiftVersionStaticValidator: openShiftVersionStaticValidator{},
 	}
+
+	for apiVersion := range APIVersions {
+		api.APIs[apiVersion] = v
+	}
 }
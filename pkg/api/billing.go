@@ -13,4 +13,78 @@ type Billing struct {
 
 	Location string `json:"location,omitempty"`
 	TenantID string `json:"tenantID,omitempty"`
+
+	// Snapshot is the most recently recorded billable shape of the cluster.
+	// It is refreshed on create, on delete and by the daily billing pass.
+	Snapshot *BillingSnapshot `json:"snapshot,omitempty"`
+
+	// Marketplace is set for clusters created with a marketplace plan, whose
+	// worker usage is reported to the Marketplace Metering API.
+	Marketplace *BillingMarketplace `json:"marketplace,omitempty"`
+}
+
+// BillingSnapshot represents the billable shape of a cluster at a point in
+// time
+type BillingSnapshot struct {
+	MissingFields
+
+	Timestamp int `json:"timestamp,omitempty" deep:"-"`
+
+	MasterVMSize   VMSize                 `json:"masterVMSize,omitempty"`
+	WorkerProfiles []BillingWorkerProfile `json:"workerProfiles,omitempty"`
+}
+
+// BillingWorkerProfile represents the billable shape of a worker profile
+type BillingWorkerProfile struct {
+	MissingFields
+
+	VMSize VMSize `json:"vmSize,omitempty"`
+	Count  int    `json:"count,omitempty"`
+}
+
+// BillingMarketplace represents the marketplace plan of a cluster and its
+// usage which is reported to the Marketplace Metering API
+type BillingMarketplace struct {
+	MissingFields
+
+	// ResourceID is the resource which usage events are reported against
+	ResourceID string `json:"resourceId,omitempty"`
+	PlanID     string `json:"planId,omitempty"`
+
+	// Usage holds the worker cores sampled by the monitor for each hour which
+	// is not yet reported, and the outcome of the recently reported hours
+	Usage []BillingUsage `json:"usage,omitempty"`
+}
+
+// BillingUsageState represents the reporting state of an hour of usage
+type BillingUsageState string
+
+// BillingUsageState constants.  An empty state means that the hour is not
+// yet reported.
+const (
+	// BillingUsageStateReported means that the Metering API accepted the
+	// usage event of the hour
+	BillingUsageStateReported BillingUsageState = "Reported"
+	// BillingUsageStateDuplicate means that the Metering API already held a
+	// usage event for the hour, e.g. because a response was lost
+	BillingUsageStateDuplicate BillingUsageState = "Duplicate"
+	// BillingUsageStateExpired means that the hour was not reported within
+	// the window accepted by the Metering API
+	BillingUsageStateExpired BillingUsageState = "Expired"
+	// BillingUsageStateRejected means that the Metering API rejected the
+	// usage event of the hour
+	BillingUsageStateRejected BillingUsageState = "Rejected"
+)
+
+// BillingUsage represents the worker usage of a cluster during an hour
+type BillingUsage struct {
+	MissingFields
+
+	// Hour is the start of the hour, in Unix seconds
+	Hour        int `json:"hour,omitempty"`
+	WorkerCores int `json:"workerCores,omitempty"`
+
+	State        BillingUsageState `json:"state,omitempty"`
+	UsageEventID string            `json:"usageEventId,omitempty"`
+	Attempts     int               `json:"attempts,omitempty"`
 }
@@ -0,0 +1,47 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// ClusterEventDataVersion is the version of the ClusterEventData schema, sent
+// as the dataVersion of each event.  Customers deserialise the data of the
+// events they subscribe to, so adding an optional field is the only change
+// which may be made without a new major version.
+const ClusterEventDataVersion = "1.0"
+
+// ClusterEventType is the type of a cluster lifecycle event published to
+// Event Grid
+type ClusterEventType string
+
+// ClusterEventType constants
+const (
+	ClusterEventTypeCreateStarted    ClusterEventType = "Microsoft.RedHatOpenShift.ClusterCreateStarted"
+	ClusterEventTypeCreateSucceeded  ClusterEventType = "Microsoft.RedHatOpenShift.ClusterCreateSucceeded"
+	ClusterEventTypeCreateFailed     ClusterEventType = "Microsoft.RedHatOpenShift.ClusterCreateFailed"
+	ClusterEventTypeUpgradeStarted   ClusterEventType = "Microsoft.RedHatOpenShift.ClusterUpgradeStarted"
+	ClusterEventTypeUpgradeSucceeded ClusterEventType = "Microsoft.RedHatOpenShift.ClusterUpgradeSucceeded"
+	ClusterEventTypeUpgradeFailed    ClusterEventType = "Microsoft.RedHatOpenShift.ClusterUpgradeFailed"
+	ClusterEventTypeDeleteStarted    ClusterEventType = "Microsoft.RedHatOpenShift.ClusterDeleteStarted"
+	ClusterEventTypeDeleteSucceeded  ClusterEventType = "Microsoft.RedHatOpenShift.ClusterDeleteSucceeded"
+	ClusterEventTypeDeleteFailed     ClusterEventType = "Microsoft.RedHatOpenShift.ClusterDeleteFailed"
+)
+
+// ClusterEventData is the data of a cluster lifecycle event
+type ClusterEventData struct {
+	// ResourceID is the resource ID of the cluster
+	ResourceID string `json:"resourceId"`
+
+	// OperationID is the ID of the asynchronous operation which the event
+	// belongs to, as returned in the Azure-AsyncOperation header
+	OperationID string `json:"operationId,omitempty"`
+
+	// Version is the OpenShift version of the cluster when the event was
+	// published
+	Version string `json:"version,omitempty"`
+
+	// MaintenanceTask is set on upgrade events
+	MaintenanceTask MaintenanceTask `json:"maintenanceTask,omitempty"`
+
+	// Error is set on failure events
+	Error *CloudErrorBody `json:"error,omitempty"`
+}
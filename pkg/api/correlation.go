@@ -25,4 +25,11 @@ type CorrelationData struct {
 
 	// RequestTime is the time that the request was received
 	RequestTime time.Time `json:"requestTime,omitempty"`
+
+	// TraceParent contains the W3C traceparent of the frontend span which
+	// served the request, so that backend work can be traced as part of it
+	TraceParent string `json:"traceParent,omitempty"`
+
+	// TraceState contains the W3C tracestate accompanying TraceParent
+	TraceState string `json:"traceState,omitempty"`
 }
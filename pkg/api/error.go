@@ -39,6 +39,12 @@ type CloudErrorBody struct {
 	// The target of the particular error. For example, the name of the property in error.
 	Target string `json:"target,omitempty"`
 
+	// The resource ID of the Azure resource in error, if any.
+	TargetResourceID string `json:"targetResourceId,omitempty"`
+
+	// A link to documentation describing how to resolve the error, if any.
+	RemediationURL string `json:"remediationUrl,omitempty"`
+
 	//A list of additional details about the error.
 	Details []CloudErrorBody `json:"details,omitempty"`
 }
@@ -79,10 +85,13 @@ const (
 	CloudErrorCodeClusterResourceGroupAlreadyExists  = "ClusterResourceGroupAlreadyExists"
 	CloudErrorCodeResourceNotFound                   = "ResourceNotFound"
 	CloudErrorCodeUnsupportedMediaType               = "UnsupportedMediaType"
+	CloudErrorCodeRequestEntityTooLarge              = "RequestEntityTooLarge"
 	CloudErrorCodeInvalidLinkedVNet                  = "InvalidLinkedVNet"
 	CloudErrorCodeInvalidLinkedRouteTable            = "InvalidLinkedRouteTable"
 	CloudErrorCodeInvalidLinkedNatGateway            = "InvalidLinkedNatGateway"
 	CloudErrorCodeInvalidLinkedDiskEncryptionSet     = "InvalidLinkedDiskEncryptionSet"
+	CloudErrorCodeInvalidLinkedWorkspace             = "InvalidLinkedWorkspace"
+	CloudErrorCodeInvalidLinkedPublicIPPrefix        = "InvalidLinkedPublicIPPrefix"
 	CloudErrorCodeNotFound                           = "NotFound"
 	CloudErrorCodeForbidden                          = "Forbidden"
 	CloudErrorCodeInvalidSubscriptionState           = "InvalidSubscriptionState"
@@ -106,6 +115,33 @@ const (
 	CloudErrorCodeThrottlingLimitExceeded            = "ThrottlingLimitExceeded"
 )
 
+// Links to documentation which customers can follow to resolve errors
+const (
+	RemediationURLPermissions     = "https://learn.microsoft.com/en-us/azure/openshift/create-cluster#verify-your-permissions"
+	RemediationURLQuota           = "https://learn.microsoft.com/en-us/azure/quotas/per-vm-quota-requests"
+	RemediationURLPolicy          = "https://learn.microsoft.com/en-us/azure/governance/policy/troubleshoot/general"
+	RemediationURLSKUNotAvailable = "https://learn.microsoft.com/en-us/azure/azure-resource-manager/templates/error-sku-not-available"
+	RemediationURLVMSizes         = "https://learn.microsoft.com/en-us/azure/openshift/support-policies-v4#supported-virtual-machine-sizes"
+)
+
+// remediationURLs maps the codes of Azure errors which customers can resolve
+// themselves to the documentation describing how
+var remediationURLs = map[string]string{
+	"AuthorizationFailed":                   RemediationURLPermissions,
+	"LinkedAuthorizationFailed":             RemediationURLPermissions,
+	CloudErrorCodeQuotaExceeded:             RemediationURLQuota,
+	CloudErrorCodeResourceQuotaExceeded:     RemediationURLQuota,
+	CloudErrorCodeRequestDisallowedByPolicy: RemediationURLPolicy,
+	"SkuNotAvailable":                       RemediationURLSKUNotAvailable,
+}
+
+// RemediationURLForCode returns a link to documentation describing how to
+// resolve Azure errors with the given code, or the empty string if there is
+// none
+func RemediationURLForCode(code string) string {
+	return remediationURLs[code]
+}
+
 // NewCloudError returns a new CloudError
 func NewCloudError(statusCode int, code, target, message string, a ...interface{}) *CloudError {
 	return &CloudError{
@@ -118,6 +154,26 @@ func NewCloudError(statusCode int, code, target, message string, a ...interface{
 	}
 }
 
+// WithTargetResourceID sets the resource ID of the Azure resource in error and
+// returns err
+func (err *CloudError) WithTargetResourceID(resourceID string) *CloudError {
+	err.TargetResourceID = resourceID
+	return err
+}
+
+// WithRemediationURL sets the link to documentation describing how to resolve
+// the error and returns err
+func (err *CloudError) WithRemediationURL(url string) *CloudError {
+	err.RemediationURL = url
+	return err
+}
+
+// WithDetails appends details to the details of the error and returns err
+func (err *CloudError) WithDetails(details ...CloudErrorBody) *CloudError {
+	err.Details = append(err.Details, details...)
+	return err
+}
+
 // WriteError constructs and writes a CloudError to the given ResponseWriter
 func WriteError(w http.ResponseWriter, statusCode int, code, target, message string, a ...interface{}) {
 	WriteCloudError(w, NewCloudError(statusCode, code, target, message, a...))
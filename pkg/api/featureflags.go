@@ -18,4 +18,18 @@ const (
 	// FeatureFlagCheckAccessTestToggle is used for safely testing the new check access
 	// API in production. The toggle will be removed once the testing has been completed.
 	FeatureFlagCheckAccessTestToggle = "Microsoft.RedHatOpenShift/CheckAccessTestToggle"
+
+	// FeatureFlagSecurityPostureExport is the feature in the subscription that
+	// causes the monitor to export the security findings of its clusters to
+	// Microsoft Defender for Cloud as assessments
+	FeatureFlagSecurityPostureExport = "Microsoft.RedHatOpenShift/SecurityPostureExport"
+
+	// FeatureFlagLargeClusters is the feature in the subscription that allows
+	// new clusters to set a maxNodesProfile and grow beyond the default worker
+	// count limit.
+	FeatureFlagLargeClusters = "Microsoft.RedHatOpenShift/LargeClusters"
+
+	// FeatureFlagDualStack is the feature in the subscription that allows new
+	// clusters to set IPv6 pod and service CIDRs and be installed dual-stack.
+	FeatureFlagDualStack = "Microsoft.RedHatOpenShift/DualStack"
 )
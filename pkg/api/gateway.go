@@ -12,4 +12,10 @@ type Gateway struct {
 
 	StorageSuffix                   string `json:"storageSuffix,omitempty"`
 	ImageRegistryStorageAccountName string `json:"imageRegistryStorageAccountName,omitempty"`
+
+	// MaxConnections and MaxBandwidthBytesPerSecond override the gateway's
+	// default per-cluster quotas on concurrent connections and on the
+	// bandwidth used in each direction.  Zero means the default.
+	MaxConnections             int   `json:"maxConnections,omitempty"`
+	MaxBandwidthBytesPerSecond int64 `json:"maxBandwidthBytesPerSecond,omitempty"`
 }
@@ -30,6 +30,10 @@ type GatewayDocument struct {
 	LSN         int                    `json:"_lsn,omitempty"`
 	Metadata    map[string]interface{} `json:"_metadata,omitempty"`
 
+	// Key is the lower case resource ID of the cluster this gateway record
+	// belongs to.  It allows the record to be looked up from the cluster.
+	Key string `json:"key,omitempty"`
+
 	Gateway *Gateway `json:"gateway,omitempty"`
 }
 
@@ -0,0 +1,27 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import "time"
+
+// MirroredImage records an image which has been mirrored to a regional ACR
+type MirroredImage struct {
+	MissingFields
+
+	// Source is the reference the image was mirrored from
+	Source string `json:"source,omitempty"`
+
+	// Destination is the reference the image was mirrored to
+	Destination string `json:"destination,omitempty"`
+
+	// Digest is the digest of the manifest which was mirrored
+	Digest string `json:"digest,omitempty"`
+
+	// Release is the OpenShift release version, if the image is a release
+	// payload
+	Release string `json:"release,omitempty"`
+
+	// MirroredAt is the time at which Digest was mirrored
+	MirroredAt time.Time `json:"mirroredAt,omitempty"`
+}
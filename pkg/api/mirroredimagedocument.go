@@ -0,0 +1,38 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// MirroredImageDocuments represents mirrored image documents.
+// pkg/database/cosmosdb requires its definition.
+type MirroredImageDocuments struct {
+	Count                  int                      `json:"_count,omitempty"`
+	ResourceID             string                   `json:"_rid,omitempty"`
+	MirroredImageDocuments []*MirroredImageDocument `json:"Documents,omitempty"`
+}
+
+func (c *MirroredImageDocuments) String() string {
+	return encodeJSON(c)
+}
+
+// MirroredImageDocument represents a mirrored image document.
+// pkg/database/cosmosdb requires its definition.
+type MirroredImageDocument struct {
+	MissingFields
+
+	ID          string                 `json:"id,omitempty"`
+	ResourceID  string                 `json:"_rid,omitempty"`
+	Timestamp   int                    `json:"_ts,omitempty"`
+	Self        string                 `json:"_self,omitempty"`
+	ETag        string                 `json:"_etag,omitempty" deep:"-"`
+	Attachments string                 `json:"_attachments,omitempty"`
+	TTL         int                    `json:"ttl,omitempty"`
+	LSN         int                    `json:"_lsn,omitempty"`
+	Metadata    map[string]interface{} `json:"_metadata,omitempty"`
+
+	MirroredImage *MirroredImage `json:"mirroredImage,omitempty"`
+}
+
+func (c *MirroredImageDocument) String() string {
+	return encodeJSON(c)
+}
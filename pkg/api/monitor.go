@@ -7,5 +7,6 @@ package api
 type Monitor struct {
 	MissingFields
 
-	Buckets []string `json:"buckets,omitempty"`
+	// OwnedBuckets is the list of buckets the monitor last computed it owns
+	OwnedBuckets []int `json:"ownedBuckets,omitempty"`
 }
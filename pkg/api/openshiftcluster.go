@@ -23,6 +23,11 @@ type OpenShiftCluster struct {
 	Properties OpenShiftClusterProperties `json:"properties,omitempty"`
 	Identity   *Identity                  `json:"identity,omitempty"`
 
+	// Plan is the Azure Marketplace plan which the cluster was created with,
+	// if any.  It is taken from the ARM resource on create and is not part of
+	// the versioned APIs.
+	Plan *Plan `json:"plan,omitempty"`
+
 	//this property is used in the enrichers. Should not be marshalled
 	Lock sync.Mutex `json:"-"`
 }
@@ -135,10 +140,25 @@ type OpenShiftClusterProperties struct {
 	// WorkerProfilesStatus is used to store the enriched worker profile data
 	WorkerProfilesStatus []WorkerProfile `json:"workerProfilesStatus,omitempty" swagger:"readOnly"`
 
+	// ZonePlacement is NonZonal if the customer requested that no
+	// availability zones be used.  Otherwise zones are used wherever the
+	// region and the VM sizes allow.
+	ZonePlacement ZonePlacement `json:"zonePlacement,omitempty"`
+
+	// Zones are the availability zones selected at creation in which the
+	// master and worker VM sizes are all available, for the profiles whose
+	// zones the customer did not choose.  It is empty if the region or the VM
+	// sizes are not zonal, or if ZonePlacement is NonZonal.
+	Zones []string `json:"zones,omitempty"`
+
 	APIServerProfile APIServerProfile `json:"apiserverProfile,omitempty"`
 
 	IngressProfiles []IngressProfile `json:"ingressProfiles,omitempty"`
 
+	DiagnosticSettingsProfile *DiagnosticSettingsProfile `json:"diagnosticSettingsProfile,omitempty"`
+
+	MaxNodesProfile *MaxNodesProfile `json:"maxNodesProfile,omitempty"`
+
 	// Install is non-nil only when an install is in progress
 	Install *Install `json:"install,omitempty"`
 
@@ -148,6 +168,18 @@ type OpenShiftClusterProperties struct {
 	InfraID string      `json:"infraId,omitempty"`
 	SSHKey  SecureBytes `json:"sshKey,omitempty"`
 
+	// SSHCAKey is the private key of the SSH certificate authority trusted by
+	// the cluster's nodes.  The portal signs a short-lived certificate with it
+	// for each SSH session.
+	SSHCAKey SecureBytes `json:"sshCAKey,omitempty"`
+
+	// BreakGlassCA is the PEM encoded private key and certificate of the
+	// certificate authority which signs short-lived break-glass client
+	// certificates.  It is trusted by the API server through the admin
+	// kubeconfig client CA bundle, and rotating it revokes every certificate
+	// it has signed.
+	BreakGlassCA SecureBytes `json:"breakGlassCA,omitempty"`
+
 	// AdminKubeconfig is installer generated kubeconfig. It is 10 year config,
 	// and should never be returned to the user.
 	AdminKubeconfig SecureBytes `json:"adminKubeconfig,omitempty"`
@@ -165,6 +197,8 @@ type OpenShiftClusterProperties struct {
 
 	HiveProfile HiveProfile `json:"hiveProfile,omitempty"`
 
+	OCMProfile OCMProfile `json:"ocmProfile,omitempty"`
+
 	MaintenanceState MaintenanceState `json:"maintenanceState,omitempty"`
 }
 
@@ -202,9 +236,10 @@ const (
 	// Maintenance tasks that perform work on the cluster
 	//
 
-	MaintenanceTaskEverything MaintenanceTask = "Everything"
-	MaintenanceTaskOperator   MaintenanceTask = "OperatorUpdate"
-	MaintenanceTaskRenewCerts MaintenanceTask = "CertificatesRenewal"
+	MaintenanceTaskEverything     MaintenanceTask = "Everything"
+	MaintenanceTaskOperator       MaintenanceTask = "OperatorUpdate"
+	MaintenanceTaskRenewCerts     MaintenanceTask = "CertificatesRenewal"
+	MaintenanceTaskRotateACRToken MaintenanceTask = "ACRTokenRotation"
 
 	//
 	// Maintenance tasks for updating customer maintenance signals
@@ -226,6 +261,7 @@ func (t MaintenanceTask) IsMaintenanceOngoingTask() bool {
 	result := (t == MaintenanceTaskEverything) ||
 		(t == MaintenanceTaskOperator) ||
 		(t == MaintenanceTaskRenewCerts) ||
+		(t == MaintenanceTaskRotateACRToken) ||
 		(t == "")
 	return result
 }
@@ -262,7 +298,7 @@ type ClusterProfile struct {
 	Domain               string               `json:"domain,omitempty"`
 	Version              string               `json:"version,omitempty"`
 	ResourceGroupID      string               `json:"resourceGroupId,omitempty"`
-	FipsValidatedModules FipsValidatedModules `json:"fipsValidatedModules,omitempty"`
+	FipsValidatedModules FipsValidatedModules `json:"fipsValidatedModules,omitempty" default:"Disabled"`
 	OIDCIssuer           OIDCIssuer           `json:"oidcIssuer,omitempty"`
 }
 
@@ -356,15 +392,26 @@ type NetworkProfile struct {
 
 	PodCIDR                string                 `json:"podCidr,omitempty"`
 	ServiceCIDR            string                 `json:"serviceCidr,omitempty"`
+	PodCIDRIPv6            string                 `json:"podCidrIpv6,omitempty"`
+	ServiceCIDRIPv6        string                 `json:"serviceCidrIpv6,omitempty"`
 	SoftwareDefinedNetwork SoftwareDefinedNetwork `json:"softwareDefinedNetwork,omitempty"`
 	MTUSize                MTUSize                `json:"mtuSize,omitempty"`
-	OutboundType           OutboundType           `json:"outboundType,omitempty"`
+	OutboundType           OutboundType           `json:"outboundType,omitempty" default:"Loadbalancer"`
 
 	APIServerPrivateEndpointIP string               `json:"privateEndpointIp,omitempty"`
 	GatewayPrivateEndpointIP   string               `json:"gatewayPrivateEndpointIp,omitempty"`
 	GatewayPrivateLinkID       string               `json:"gatewayPrivateLinkId,omitempty"`
-	PreconfiguredNSG           PreconfiguredNSG     `json:"preconfiguredNSG,omitempty"`
+	PreconfiguredNSG           PreconfiguredNSG     `json:"preconfiguredNSG,omitempty" default:"Disabled"`
 	LoadBalancerProfile        *LoadBalancerProfile `json:"loadBalancerProfile,omitempty"`
+
+	// PublicIPPrefixID, if set, is the customer's public IP prefix which the
+	// API server and default ingress public IPs are allocated from
+	PublicIPPrefixID string `json:"publicIpPrefixId,omitempty"`
+}
+
+// IsDualStack returns true if the cluster has IPv6 as well as IPv4 networking
+func (np NetworkProfile) IsDualStack() bool {
+	return np.PodCIDRIPv6 != ""
 }
 
 // PreconfiguredNSG represents whether customers want to use their own NSG attached to the subnets
@@ -385,14 +432,48 @@ const (
 	EncryptionAtHostDisabled EncryptionAtHost = "Disabled"
 )
 
+// DiskType represents the managed disk type of a profile's VMs.  OS disks
+// are always Premium_LRS: UltraSSD_LRS additionally enables the Ultra disk
+// capability on the VMs, so that Ultra disks can be attached to them as
+// persistent volumes.
+type DiskType string
+
+// DiskType constants
+const (
+	DiskTypePremiumLRS  DiskType = "Premium_LRS"
+	DiskTypeUltraSSDLRS DiskType = "UltraSSD_LRS"
+)
+
+// AcceleratedNetworking represents accelerated networking.
+type AcceleratedNetworking string
+
+// AcceleratedNetworking constants
+const (
+	AcceleratedNetworkingEnabled  AcceleratedNetworking = "Enabled"
+	AcceleratedNetworkingDisabled AcceleratedNetworking = "Disabled"
+)
+
+// ZonePlacement represents how a cluster's VMs are placed in availability
+// zones
+type ZonePlacement string
+
+// ZonePlacement constants
+const (
+	ZonePlacementAutomatic ZonePlacement = "Automatic"
+	ZonePlacementNonZonal  ZonePlacement = "NonZonal"
+)
+
 // MasterProfile represents a master profile
 type MasterProfile struct {
 	MissingFields
 
-	VMSize              VMSize           `json:"vmSize,omitempty"`
-	SubnetID            string           `json:"subnetId,omitempty"`
-	EncryptionAtHost    EncryptionAtHost `json:"encryptionAtHost,omitempty"`
-	DiskEncryptionSetID string           `json:"diskEncryptionSetId,omitempty"`
+	VMSize                VMSize                `json:"vmSize,omitempty"`
+	SubnetID              string                `json:"subnetId,omitempty"`
+	EncryptionAtHost      EncryptionAtHost      `json:"encryptionAtHost,omitempty" default:"Disabled"`
+	DiskEncryptionSetID   string                `json:"diskEncryptionSetId,omitempty"`
+	DiskType              DiskType              `json:"diskType,omitempty"`
+	AcceleratedNetworking AcceleratedNetworking `json:"acceleratedNetworking,omitempty"`
+	Zones                 []string              `json:"zones,omitempty"`
 }
 
 // VMSize represents a VM size
@@ -674,15 +755,40 @@ const (
 type WorkerProfile struct {
 	MissingFields
 
-	Name                string           `json:"name,omitempty"`
-	VMSize              VMSize           `json:"vmSize,omitempty"`
-	DiskSizeGB          int              `json:"diskSizeGB,omitempty"`
-	SubnetID            string           `json:"subnetId,omitempty"`
-	Count               int              `json:"count,omitempty"`
-	EncryptionAtHost    EncryptionAtHost `json:"encryptionAtHost,omitempty"`
-	DiskEncryptionSetID string           `json:"diskEncryptionSetId,omitempty"`
+	Name                  string                `json:"name,omitempty"`
+	VMSize                VMSize                `json:"vmSize,omitempty"`
+	DiskSizeGB            int                   `json:"diskSizeGB,omitempty"`
+	SubnetID              string                `json:"subnetId,omitempty"`
+	Count                 int                   `json:"count,omitempty"`
+	EncryptionAtHost      EncryptionAtHost      `json:"encryptionAtHost,omitempty" default:"Disabled"`
+	DiskEncryptionSetID   string                `json:"diskEncryptionSetId,omitempty"`
+	DiskType              DiskType              `json:"diskType,omitempty"`
+	AcceleratedNetworking AcceleratedNetworking `json:"acceleratedNetworking,omitempty"`
+	Zones                 []string              `json:"zones,omitempty"`
+	SpotVMOptions         *SpotVMOptions        `json:"spotVMOptions,omitempty"`
+	MaxSurge              int                   `json:"maxSurge,omitempty"`
 }
 
+// SpotVMOptions represents the options of a worker profile whose VMs are
+// Azure Spot VMs
+type SpotVMOptions struct {
+	MissingFields
+
+	// MaxPrice is the maximum hourly price in US dollars, or -1 to pay up to
+	// the on-demand price.  If unset, -1 is used.
+	MaxPrice       *float64           `json:"maxPrice,omitempty"`
+	EvictionPolicy SpotEvictionPolicy `json:"evictionPolicy,omitempty"`
+}
+
+// SpotEvictionPolicy represents what happens to a Spot VM when it is evicted
+type SpotEvictionPolicy string
+
+// SpotEvictionPolicy constants.  The machine API only supports deleting
+// evicted VMs.
+const (
+	SpotEvictionPolicyDelete SpotEvictionPolicy = "Delete"
+)
+
 // GetEnrichedWorkerProfiles returns WorkerProfilesStatus if not nil, otherwise WorkerProfiles
 // with their respective json property name
 func GetEnrichedWorkerProfiles(ocp OpenShiftClusterProperties) ([]WorkerProfile, string) {
@@ -699,6 +805,7 @@ type APIServerProfile struct {
 	Visibility Visibility `json:"visibility,omitempty"`
 	URL        string     `json:"url,omitempty"`
 	IP         string     `json:"ip,omitempty"`
+	IPv6       string     `json:"ipv6,omitempty"`
 	IntIP      string     `json:"intIp,omitempty"`
 }
 
@@ -718,6 +825,7 @@ type IngressProfile struct {
 	Name       string     `json:"name,omitempty"`
 	Visibility Visibility `json:"visibility,omitempty"`
 	IP         string     `json:"ip,omitempty"`
+	IPv6       string     `json:"ipv6,omitempty"`
 }
 
 // RegistryProfile represents a registry's login
@@ -727,6 +835,10 @@ type RegistryProfile struct {
 	Name     string       `json:"name,omitempty"`
 	Username string       `json:"username,omitempty"`
 	Password SecureString `json:"password,omitempty"`
+
+	// Expiry is when Password expires.  It is nil for passwords generated
+	// before expiries were set.
+	Expiry *time.Time `json:"expiry,omitempty"`
 }
 
 // Install represents an install process
@@ -741,9 +853,13 @@ type Install struct {
 type InstallPhase int
 
 // InstallPhase constants
+//
+// Phases are stored by value in the cluster document, so new phases must be
+// appended.  The backend defines the order in which the phases run.
 const (
 	InstallPhaseBootstrap InstallPhase = iota
 	InstallPhaseRemoveBootstrap
+	InstallPhaseReserve
 )
 
 // ArchitectureVersion represents an architecture version
@@ -769,6 +885,38 @@ type HiveProfile struct {
 	CreatedByHive bool `json:"createdByHive,omitempty"`
 }
 
+// OCMProfile records the registration of the cluster with OpenShift Cluster
+// Manager (OCM), through which Red Hat support entitlements and upgrade
+// recommendations reach the cluster
+type OCMProfile struct {
+	MissingFields
+
+	// ClusterID is the ID of the cluster's ClusterVersion, which OCM knows
+	// the cluster by
+	ClusterID string `json:"clusterId,omitempty"`
+
+	SubscriptionID     string `json:"subscriptionId,omitempty"`
+	SubscriptionStatus string `json:"subscriptionStatus,omitempty"`
+}
+
+// DiagnosticSettingsProfile represents the Azure Monitor diagnostic settings
+// configured on the cluster's Azure resources
+type DiagnosticSettingsProfile struct {
+	MissingFields
+
+	// WorkspaceResourceID is the Log Analytics workspace to which the logs
+	// and metrics of the cluster's resources are sent
+	WorkspaceResourceID string `json:"workspaceResourceId,omitempty"`
+}
+
+// MaxNodesProfile represents the largest number of worker nodes which a
+// cluster is sized for.  It is only set for large clusters.
+type MaxNodesProfile struct {
+	MissingFields
+
+	MaxNodes int `json:"maxNodes,omitempty"`
+}
+
 // PlatformWorkloadIdentityProfile encapsulates all information that is specific to workload identity clusters.
 type PlatformWorkloadIdentityProfile struct {
 	MissingFields
@@ -797,6 +945,15 @@ type ClusterUserAssignedIdentity struct {
 // UserAssignedIdentities stores a mapping from resource IDs of managed identities to their client/principal IDs.
 type UserAssignedIdentities map[string]ClusterUserAssignedIdentity
 
+// Plan represents the Azure Marketplace plan of a resource
+type Plan struct {
+	MissingFields
+
+	Name      string `json:"name,omitempty"`
+	Publisher string `json:"publisher,omitempty"`
+	Product   string `json:"product,omitempty"`
+}
+
 // Identity stores information about the cluster MSI(s) in a workload identity cluster.
 type Identity struct {
 	MissingFields
@@ -3,6 +3,10 @@ package api
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 
+import (
+	"time"
+)
+
 // OpenShiftClusterDocuments represents OpenShift cluster documents.
 // pkg/database/cosmosdb requires its definition.
 type OpenShiftClusterDocuments struct {
@@ -40,13 +44,116 @@ type OpenShiftClusterDocument struct {
 	LeaseExpires int    `json:"leaseExpires,omitempty" deep:"-"`
 	Dequeues     int    `json:"dequeues,omitempty"`
 
+	// ReconcileScope limits the backend's update of the cluster to the steps
+	// which reconcile the fields changed by the update.  The whole cluster is
+	// reconciled if it is empty.
+	ReconcileScope ReconcileScope `json:"reconcileScope,omitempty"`
+
 	AsyncOperationID string `json:"asyncOperationId,omitempty" deep:"-"`
 
 	OpenShiftCluster *OpenShiftCluster `json:"openShiftCluster,omitempty"`
 
 	CorrelationData *CorrelationData `json:"correlationData,omitempty" deep:"-"`
+
+	// Progress records how far the backend has got through the steps of the
+	// cluster's current or last operation
+	Progress *OperationProgress `json:"progress,omitempty" deep:"-"`
+
+	// SoftDeleted is set on the documents of deleted clusters, which are
+	// retained as tombstones until the backend purges them
+	SoftDeleted *SoftDeleted `json:"softDeleted,omitempty"`
+}
+
+// OperationProgress records the step which the backend is running, or last
+// ran, for a cluster operation
+type OperationProgress struct {
+	MissingFields
+
+	// Operation is the kind of operation, e.g. install, update or adminUpdate
+	Operation string `json:"operation,omitempty"`
+
+	Step      string    `json:"step,omitempty"`
+	StepIndex int       `json:"stepIndex,omitempty"`
+	StepCount int       `json:"stepCount,omitempty"`
+	StartTime time.Time `json:"startTime,omitempty"`
+
+	// StepDurations records how many seconds each completed step of the
+	// operation took, keyed by step name
+	StepDurations map[string]int64 `json:"stepDurations,omitempty"`
+
+	// StepStatuses records the status of each step of an operation whose
+	// steps run as a dependency graph, keyed by step name, so that a retry of
+	// the operation can skip the steps which have succeeded
+	StepStatuses map[string]StepStatus `json:"stepStatuses,omitempty"`
+}
+
+// StepStatus is the status of a step of an operation
+type StepStatus string
+
+const (
+	StepStatusRunning   StepStatus = "Running"
+	StepStatusSucceeded StepStatus = "Succeeded"
+	StepStatusFailed    StepStatus = "Failed"
+)
+
+// ReconcileScope is the part of a cluster which an update reconciles
+type ReconcileScope string
+
+const (
+	// ReconcileScopeWorkerProfiles reconciles the worker profiles, i.e. the
+	// VM size of the workers
+	ReconcileScopeWorkerProfiles ReconcileScope = "WorkerProfiles"
+
+	// ReconcileScopeLoadBalancerProfile reconciles the load balancer profile,
+	// i.e. the outbound IPs and SNAT ports of the cluster
+	ReconcileScopeLoadBalancerProfile ReconcileScope = "LoadBalancerProfile"
+)
+
+// SoftDeleted records the unique keys of a soft-deleted document.  While a
+// document is soft-deleted its unique keys are moved aside so that they can be
+// reused by a new cluster.
+type SoftDeleted struct {
+	MissingFields
+
+	DeletionTime int `json:"deletionTime,omitempty" deep:"-"`
+
+	Key                       string `json:"key,omitempty"`
+	ClusterResourceGroupIDKey string `json:"clusterResourceGroupIdKey,omitempty"`
+	ClientIDKey               string `json:"clientIdKey,omitempty"`
 }
 
 func (c *OpenShiftClusterDocument) String() string {
 	return encodeJSON(c)
 }
+
+// SetAdminUpdateProvisioningState prepares doc for an admin update (ex: cluster
+// maintenance) running its MaintenanceTask.  It is shared by the admin API and
+// the admin portal.
+func SetAdminUpdateProvisioningState(doc *OpenShiftClusterDocument) {
+	if doc.OpenShiftCluster.Properties.MaintenanceTask.IsMaintenanceOngoingTask() {
+		doc.OpenShiftCluster.Properties.LastProvisioningState = doc.OpenShiftCluster.Properties.ProvisioningState
+		doc.OpenShiftCluster.Properties.ProvisioningState = ProvisioningStateAdminUpdating
+		doc.OpenShiftCluster.Properties.LastAdminUpdateError = ""
+		doc.Dequeues = 0
+
+		// Set the maintenance to ongoing so we emit the appropriate signal to customerss
+		if doc.OpenShiftCluster.Properties.MaintenanceState == MaintenanceStatePending {
+			doc.OpenShiftCluster.Properties.MaintenanceState = MaintenanceStatePlanned
+		} else {
+			doc.OpenShiftCluster.Properties.MaintenanceState = MaintenanceStateUnplanned
+		}
+	} else {
+		// No default needed since we're using an enum
+		switch doc.OpenShiftCluster.Properties.MaintenanceTask {
+		case MaintenanceTaskPending:
+			doc.OpenShiftCluster.Properties.MaintenanceState = MaintenanceStatePending
+		case MaintenanceTaskNone:
+			doc.OpenShiftCluster.Properties.MaintenanceState = MaintenanceStateNone
+		case MaintenanceTaskCustomerActionNeeded:
+			doc.OpenShiftCluster.Properties.MaintenanceState = MaintenanceStateCustomerActionNeeded
+		}
+
+		// This enables future admin update actions with body `{}` to succeed
+		doc.OpenShiftCluster.Properties.MaintenanceTask = ""
+	}
+}
@@ -21,6 +21,24 @@ type SSH struct {
 
 	Master        int  `json:"master"`
 	Authenticated bool `json:"authenticated,omitempty"`
+
+	// Certificate is set if the portal is to authenticate to the cluster with
+	// a certificate minted for this session rather than the cluster SSH key
+	Certificate *SSHCertificate `json:"certificate,omitempty"`
+}
+
+// SSHCertificate describes a short-lived SSH user certificate signed by the
+// cluster SSH certificate authority.  KeyID and Serial are logged by sshd on
+// the node, allowing node logins to be matched to portal sessions.
+type SSHCertificate struct {
+	MissingFields
+
+	KeyID  string `json:"keyId,omitempty"`
+	Serial uint64 `json:"serial,omitempty" deep:"-"`
+
+	// ValidBefore is the expiry time of the certificate, in seconds since the
+	// epoch
+	ValidBefore int `json:"validBefore,omitempty" deep:"-"`
 }
 
 type Kubeconfig struct {
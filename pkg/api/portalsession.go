@@ -0,0 +1,64 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// PortalSessionKind is the kind of access granted by a portal session
+type PortalSessionKind string
+
+// PortalSessionKind constants
+const (
+	PortalSessionKindSSH        PortalSessionKind = "SSH"
+	PortalSessionKindKubeconfig PortalSessionKind = "Kubeconfig"
+	PortalSessionKindPodLogs    PortalSessionKind = "PodLogs"
+	PortalSessionKindPodExec    PortalSessionKind = "PodExec"
+	PortalSessionKindBreakGlass PortalSessionKind = "BreakGlass"
+)
+
+// PortalSession is the audit record of an SRE being granted access to a
+// cluster through the portal
+type PortalSession struct {
+	MissingFields
+
+	Username string `json:"username,omitempty"`
+
+	// ResourceID is the resource ID of the cluster which was accessed
+	ResourceID string `json:"resourceId,omitempty"`
+
+	Kind     PortalSessionKind `json:"kind,omitempty"`
+	Elevated bool              `json:"elevated,omitempty"`
+
+	// Master is the index of the master VM accessed by an SSH session
+	Master int `json:"master,omitempty"`
+
+	// Namespace, Pod and Container identify the container accessed by a
+	// PodLogs or PodExec session, and Command is the command run by a PodExec
+	// session
+	Namespace string   `json:"namespace,omitempty"`
+	Pod       string   `json:"pod,omitempty"`
+	Container string   `json:"container,omitempty"`
+	Command   []string `json:"command,omitempty"`
+
+	// SSHCertificate describes the certificate minted for an SSH session
+	SSHCertificate *SSHCertificate `json:"sshCertificate,omitempty"`
+
+	// BreakGlassCertificate describes the certificate minted for a BreakGlass
+	// session
+	BreakGlassCertificate *BreakGlassCertificate `json:"breakGlassCertificate,omitempty"`
+
+	CreationTime int `json:"creationTime,omitempty" deep:"-"`
+}
+
+// BreakGlassCertificate describes a short-lived client certificate signed by
+// the cluster break-glass certificate authority.  CommonName is the user name
+// recorded in the API server audit log for requests made with it.
+type BreakGlassCertificate struct {
+	MissingFields
+
+	CommonName string `json:"commonName,omitempty"`
+	Serial     uint64 `json:"serial,omitempty" deep:"-"`
+
+	// NotAfter is the expiry time of the certificate, in seconds since the
+	// epoch
+	NotAfter int `json:"notAfter,omitempty" deep:"-"`
+}
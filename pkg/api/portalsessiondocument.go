@@ -0,0 +1,42 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// PortalSessionDocuments represents portal session documents.
+// pkg/database/cosmosdb requires its definition.
+type PortalSessionDocuments struct {
+	Count                  int                      `json:"_count,omitempty"`
+	ResourceID             string                   `json:"_rid,omitempty"`
+	PortalSessionDocuments []*PortalSessionDocument `json:"Documents,omitempty"`
+}
+
+func (c *PortalSessionDocuments) String() string {
+	return encodeJSON(c)
+}
+
+// PortalSessionDocument represents a portal session document.
+// pkg/database/cosmosdb requires its definition.
+type PortalSessionDocument struct {
+	MissingFields
+
+	ID          string                 `json:"id,omitempty" deep:"-"`
+	ResourceID  string                 `json:"_rid,omitempty"`
+	Timestamp   int                    `json:"_ts,omitempty"`
+	Self        string                 `json:"_self,omitempty"`
+	ETag        string                 `json:"_etag,omitempty" deep:"-"`
+	Attachments string                 `json:"_attachments,omitempty"`
+	TTL         int                    `json:"ttl,omitempty"`
+	LSN         int                    `json:"_lsn,omitempty"`
+	Metadata    map[string]interface{} `json:"_metadata,omitempty"`
+
+	// Key is the lower-case resource ID of the cluster which was accessed
+	Key          string `json:"key,omitempty"`
+	PartitionKey string `json:"partitionKey,omitempty" deep:"-"`
+
+	PortalSession *PortalSession `json:"portalSession,omitempty"`
+}
+
+func (c *PortalSessionDocument) String() string {
+	return encodeJSON(c)
+}
@@ -75,6 +75,11 @@ type Version struct {
 	SyncIdentityProviderConverter            SyncIdentityProviderConverter
 	SecretConverter                          SecretConverter
 	ClusterManagerStaticValidator            ClusterManagerStaticValidator
+
+	// StrictDecoding causes PUT and PATCH request bodies which contain
+	// properties unknown to the API version to be rejected, rather than the
+	// properties being silently ignored
+	StrictDecoding bool
 }
 
 // APIs is the map of registered API versions
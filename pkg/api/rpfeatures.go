@@ -0,0 +1,18 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// RPFeatures is the runtime configuration of the RP feature flags.  Features
+// are named as in RP_FEATURES, e.g. "DisableReadinessDelay".
+type RPFeatures struct {
+	MissingFields
+
+	// Features holds the state of each configured feature, overriding the
+	// state set by RP_FEATURES
+	Features map[string]bool `json:"features,omitempty"`
+
+	// SubscriptionOverrides holds, by subscription ID, feature states which
+	// take precedence over Features for clusters in that subscription
+	SubscriptionOverrides map[string]map[string]bool `json:"subscriptionOverrides,omitempty"`
+}
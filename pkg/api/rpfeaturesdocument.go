@@ -0,0 +1,38 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// RPFeaturesDocuments represents RP feature flag configuration documents.
+// pkg/database/cosmosdb requires its definition.
+type RPFeaturesDocuments struct {
+	Count               int                   `json:"_count,omitempty"`
+	ResourceID          string                `json:"_rid,omitempty"`
+	RPFeaturesDocuments []*RPFeaturesDocument `json:"Documents,omitempty"`
+}
+
+func (c *RPFeaturesDocuments) String() string {
+	return encodeJSON(c)
+}
+
+// RPFeaturesDocument represents an RP feature flag configuration document.
+// pkg/database/cosmosdb requires its definition.
+type RPFeaturesDocument struct {
+	MissingFields
+
+	ID          string                 `json:"id,omitempty"`
+	ResourceID  string                 `json:"_rid,omitempty"`
+	Timestamp   int                    `json:"_ts,omitempty"`
+	Self        string                 `json:"_self,omitempty"`
+	ETag        string                 `json:"_etag,omitempty" deep:"-"`
+	Attachments string                 `json:"_attachments,omitempty"`
+	TTL         int                    `json:"ttl,omitempty"`
+	LSN         int                    `json:"_lsn,omitempty"`
+	Metadata    map[string]interface{} `json:"_metadata,omitempty"`
+
+	RPFeatures *RPFeatures `json:"rpFeatures,omitempty"`
+}
+
+func (c *RPFeaturesDocument) String() string {
+	return encodeJSON(c)
+}
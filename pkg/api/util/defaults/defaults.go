@@ -0,0 +1,77 @@
+package defaults
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Apply sets each zero-valued field tagged `default:"<value>"` of the struct
+// pointed to by v, and of the structs which it holds through struct fields,
+// non-nil pointers and slices, to its default value.  Only fields of string,
+// bool and integer kinds may be tagged.  Apply panics if a default can't be
+// parsed into the type of its field, which is a programming error.
+func Apply(v interface{}) {
+	apply(reflect.ValueOf(v))
+}
+
+func apply(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			apply(v.Elem())
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			apply(v.Index(i))
+		}
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			structField := v.Type().Field(i)
+			if !structField.IsExported() {
+				continue
+			}
+
+			f := v.Field(i)
+
+			def, ok := structField.Tag.Lookup("default")
+			if !ok {
+				apply(f)
+				continue
+			}
+
+			if f.CanSet() && f.IsZero() {
+				set(f, def, structField.Name)
+			}
+		}
+	}
+}
+
+func set(f reflect.Value, def, name string) {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(def)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(def)
+		if err != nil {
+			panic(fmt.Sprintf("invalid default %q of field %s: %v", def, name, err))
+		}
+		f.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(def, 10, f.Type().Bits())
+		if err != nil {
+			panic(fmt.Sprintf("invalid default %q of field %s: %v", def, name, err))
+		}
+		f.SetInt(n)
+
+	default:
+		panic(fmt.Sprintf("unsupported kind %s of field %s with a default", f.Kind(), name))
+	}
+}
@@ -0,0 +1,116 @@
+package defaults
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+)
+
+type mode string
+
+type nested struct {
+	Mode mode `json:"mode,omitempty" default:"Disabled"`
+}
+
+type ts struct {
+	String  string   `json:"string,omitempty" default:"default"`
+	Mode    mode     `json:"mode,omitempty" default:"Enabled"`
+	Bool    bool     `json:"bool,omitempty" default:"true"`
+	Int     int      `json:"int,omitempty" default:"3"`
+	None    string   `json:"none,omitempty"`
+	Nested  nested   `json:"nested,omitempty"`
+	Pointer *nested  `json:"pointer,omitempty"`
+	Slice   []nested `json:"slice,omitempty"`
+
+	unexported string
+}
+
+type invalid struct {
+	Int int `default:"three"`
+}
+
+type unsupported struct {
+	Map map[string]string `default:"{}"`
+}
+
+func TestApply(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		v    *ts
+		want *ts
+	}{
+		{
+			name: "zero values are defaulted",
+			v: &ts{
+				Pointer: &nested{},
+				Slice:   []nested{{}, {Mode: "Enabled"}},
+			},
+			want: &ts{
+				String:  "default",
+				Mode:    "Enabled",
+				Bool:    true,
+				Int:     3,
+				Nested:  nested{Mode: "Disabled"},
+				Pointer: &nested{Mode: "Disabled"},
+				Slice:   []nested{{Mode: "Disabled"}, {Mode: "Enabled"}},
+			},
+		},
+		{
+			name: "set values are kept",
+			v: &ts{
+				String: "value",
+				Mode:   "Disabled",
+				Int:    1,
+				None:   "value",
+				Nested: nested{Mode: "Enabled"},
+			},
+			want: &ts{
+				String: "value",
+				Mode:   "Disabled",
+				Bool:   true,
+				Int:    1,
+				None:   "value",
+				Nested: nested{Mode: "Enabled"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			Apply(tt.v)
+
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("got %#v, want %#v", tt.v, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyPanics(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		v         interface{}
+		wantPanic string
+	}{
+		{
+			name:      "invalid default",
+			v:         &invalid{},
+			wantPanic: `invalid default "three" of field Int: strconv.ParseInt: parsing "three": invalid syntax`,
+		},
+		{
+			name:      "unsupported kind",
+			v:         &unsupported{},
+			wantPanic: "unsupported kind map of field Map with a default",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.wantPanic {
+					t.Errorf("got panic %v, want %q", r, tt.wantPanic)
+				}
+			}()
+
+			Apply(tt.v)
+		})
+	}
+}
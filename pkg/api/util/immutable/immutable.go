@@ -5,8 +5,11 @@ package immutable
 
 import (
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
 )
 
 type ValidationError struct {
@@ -25,6 +28,19 @@ func Validate(path string, v, w interface{}) error {
 	return validate(path, reflect.ValueOf(v), reflect.ValueOf(w), false)
 }
 
+// ValidateDelta validates that an existing resource of an api version is only
+// changed in its fields tagged as mutable, as Validate does, and returns a
+// PropertyChangeNotAllowed CloudError for the first difference it finds.  The
+// static validators of the api versions use it, so that their immutable fields
+// are only declared through the tags of their types.
+func ValidateDelta(v, w interface{}) error {
+	err := Validate("", v, w)
+	if err, ok := err.(*ValidationError); ok {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodePropertyChangeNotAllowed, err.Target, "%s", err.Message)
+	}
+	return err
+}
+
 func validate(path string, v, w reflect.Value, ignoreCase bool) error {
 	if v.Type() != w.Type() {
 		return newValidationError(path)
@@ -176,6 +192,56 @@ func validate(path string, v, w reflect.Value, ignoreCase bool) error {
 	return nil
 }
 
+// Mutability returns the mutability of each field of the struct type t, and
+// of the structs which it holds, by path: "" for immutable fields, and the
+// value of the mutable tag for the others.  The fields of mutable fields are
+// not walked, and neither are the fields of slice, array and map elements,
+// which are given the path of their container.
+func Mutability(t reflect.Type) map[string]string {
+	m := map[string]string{}
+	mutability(m, "", t)
+	return m
+}
+
+func mutability(m map[string]string, path string, t reflect.Type) {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		mutability(m, path, t.Elem())
+
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			structField := t.Field(i)
+			if !structField.IsExported() {
+				continue
+			}
+
+			name := strings.SplitN(structField.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = structField.Name
+			}
+
+			subpath := path
+			if subpath != "" {
+				subpath += "."
+			}
+			subpath += name
+
+			tag := strings.ToLower(structField.Tag.Get("mutable"))
+			if tag == "false" {
+				tag = ""
+			}
+			m[subpath] = tag
+
+			if tag != "true" {
+				mutability(m, subpath, structField.Type)
+			}
+		}
+	}
+}
+
 func newValidationError(path string) error {
 	return &ValidationError{
 		Target:  path,
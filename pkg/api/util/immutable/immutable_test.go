@@ -4,8 +4,12 @@ package immutable
 // Licensed under the Apache License 2.0.
 
 import (
+	"net/http"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
 )
 
 type ts struct {
@@ -132,3 +136,56 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDelta(t *testing.T) {
+	before := ts{Mutable: "before", Empty: "before"}
+
+	err := ValidateDelta(&ts{Mutable: "after", Empty: "before"}, &before)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = ValidateDelta(&ts{Mutable: "before", Empty: "after"}, &before)
+	if cloudErr, ok := err.(*api.CloudError); !ok {
+		t.Errorf("%T", err)
+	} else if cloudErr.StatusCode != http.StatusBadRequest ||
+		cloudErr.Code != api.CloudErrorCodePropertyChangeNotAllowed ||
+		cloudErr.Target != "empty" ||
+		cloudErr.Message != "Changing property 'empty' is not allowed." {
+		t.Error(cloudErr)
+	}
+}
+
+func TestMutability(t *testing.T) {
+	type nested struct {
+		Immutable string `json:"immutable,omitempty"`
+		Mutable   string `json:"mutable,omitempty" mutable:"true"`
+	}
+
+	type s struct {
+		Nested        *nested  `json:"nested,omitempty"`
+		Slice         []nested `json:"slice,omitempty"`
+		MutableNested nested   `json:"mutableNested,omitempty" mutable:"true"`
+		Case          string   `json:"case,omitempty" mutable:"case"`
+		Skipped       string   `json:"-"`
+		NoJSON        string
+		unexported    string
+	}
+
+	want := map[string]string{
+		"nested":           "",
+		"nested.immutable": "",
+		"nested.mutable":   "true",
+		"slice":            "",
+		"slice.immutable":  "",
+		"slice.mutable":    "true",
+		"mutableNested":    "true",
+		"case":             "case",
+		"NoJSON":           "",
+	}
+
+	got := Mutability(reflect.TypeOf(s{}))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
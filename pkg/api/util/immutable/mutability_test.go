@@ -0,0 +1,61 @@
+package immutable_test
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api/util/immutable"
+	"github.com/Azure/ARO-RP/pkg/api/v20191231preview"
+	"github.com/Azure/ARO-RP/pkg/api/v20200430"
+	"github.com/Azure/ARO-RP/pkg/api/v20210901preview"
+	"github.com/Azure/ARO-RP/pkg/api/v20220401"
+	"github.com/Azure/ARO-RP/pkg/api/v20220904"
+	"github.com/Azure/ARO-RP/pkg/api/v20230401"
+	"github.com/Azure/ARO-RP/pkg/api/v20230701preview"
+	"github.com/Azure/ARO-RP/pkg/api/v20230904"
+	"github.com/Azure/ARO-RP/pkg/api/v20231122"
+	"github.com/Azure/ARO-RP/pkg/api/v20240812preview"
+)
+
+// TestMutabilityDrift checks that a field which is present in several API
+// versions has the same mutability in all of them, unless its mutability was
+// deliberately changed by a later version.
+func TestMutabilityDrift(t *testing.T) {
+	latest := immutable.Mutability(reflect.TypeOf(v20240812preview.OpenShiftCluster{}))
+
+	// changed deliberately: the VM size of the workers became mutable in
+	// v20240812preview
+	changed := map[string]bool{
+		"properties.workerProfiles.vmSize":       true,
+		"properties.workerProfilesStatus.vmSize": true,
+	}
+
+	for _, oc := range []interface{}{
+		v20191231preview.OpenShiftCluster{},
+		v20200430.OpenShiftCluster{},
+		v20210901preview.OpenShiftCluster{},
+		v20220401.OpenShiftCluster{},
+		v20220904.OpenShiftCluster{},
+		v20230401.OpenShiftCluster{},
+		v20230701preview.OpenShiftCluster{},
+		v20230904.OpenShiftCluster{},
+		v20231122.OpenShiftCluster{},
+	} {
+		typ := reflect.TypeOf(oc)
+		t.Run(typ.PkgPath(), func(t *testing.T) {
+			for path, mutable := range immutable.Mutability(typ) {
+				want, ok := latest[path]
+				if !ok || changed[path] {
+					continue
+				}
+
+				if mutable != want {
+					t.Errorf("%s: got mutable %q, want %q", path, mutable, want)
+				}
+			}
+		})
+	}
+}
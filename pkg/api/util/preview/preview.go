@@ -0,0 +1,179 @@
+package preview
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// IsRegisteredFunc reports whether the subscription making a request is
+// registered for the given AFEC feature
+type IsRegisteredFunc func(feature string) (bool, error)
+
+type ValidationError struct {
+	Target  string
+	Feature string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Validate returns nil if every struct field of v which is tagged
+// `preview:"<feature>"` is either unset or unchanged from current, or if
+// isRegistered reports that the subscription is registered for its feature.
+// Otherwise it returns a ValidationError indicating the first such field it
+// finds.  current may be nil, in which case v is treated as a new resource.
+func Validate(path string, v, current interface{}, isRegistered IsRegisteredFunc) error {
+	return validate(path, reflect.ValueOf(v), reflect.ValueOf(current), isRegistered)
+}
+
+// Features returns the features referenced by the preview tags of the struct
+// fields of t and of the types it contains
+func Features(t reflect.Type) []string {
+	seen := map[reflect.Type]bool{}
+	set := map[string]struct{}{}
+
+	var walk func(reflect.Type)
+	walk = func(t reflect.Type) {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			walk(t.Elem())
+
+		case reflect.Struct:
+			if seen[t] {
+				return
+			}
+			seen[t] = true
+
+			for i := 0; i < t.NumField(); i++ {
+				if feature := t.Field(i).Tag.Get("preview"); feature != "" {
+					set[feature] = struct{}{}
+				}
+				walk(t.Field(i).Type)
+			}
+		}
+	}
+	walk(t)
+
+	features := make([]string, 0, len(set))
+	for feature := range set {
+		features = append(features, feature)
+	}
+
+	return features
+}
+
+func validate(path string, v, w reflect.Value, isRegistered IsRegisteredFunc) error {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if w.IsValid() && w.Type() != v.Type() {
+		w = reflect.Value{}
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+
+		if w.IsValid() {
+			if w.IsNil() {
+				w = reflect.Value{}
+			} else {
+				w = w.Elem()
+			}
+		}
+
+		return validate(path, v.Elem(), w, isRegistered)
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			index := fmt.Sprintf("[%d]", i)
+			if v.Index(i).Kind() == reflect.Struct {
+				f := v.Index(i).FieldByName("Name")
+				if f.Kind() == reflect.String {
+					index = fmt.Sprintf("['%s']", f.String())
+				}
+			}
+
+			var wi reflect.Value
+			if w.IsValid() && i < w.Len() {
+				wi = w.Index(i)
+			}
+
+			err := validate(path+index, v.Index(i), wi, isRegistered)
+			if err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		i := v.MapRange()
+		for i.Next() {
+			var wk reflect.Value
+			if w.IsValid() && !w.IsNil() {
+				wk = w.MapIndex(i.Key())
+			}
+
+			err := validate(fmt.Sprintf("%s[%q]", path, i.Key().Interface()), i.Value(), wk, isRegistered)
+			if err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			structField := v.Type().Field(i)
+
+			name := strings.SplitN(structField.Tag.Get("json"), ",", 2)[0]
+			if name == "" {
+				name = structField.Name
+			}
+
+			subpath := path
+			if subpath != "" {
+				subpath += "."
+			}
+			subpath += name
+
+			var wf reflect.Value
+			if w.IsValid() {
+				wf = w.Field(i)
+			}
+
+			if feature := structField.Tag.Get("preview"); feature != "" && !v.Field(i).IsZero() &&
+				(!wf.IsValid() || !reflect.DeepEqual(v.Field(i).Interface(), wf.Interface())) {
+				registered, err := isRegistered(feature)
+				if err != nil {
+					return err
+				}
+
+				if !registered {
+					return newValidationError(subpath, feature)
+				}
+			}
+
+			err := validate(subpath, v.Field(i), wf, isRegistered)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func newValidationError(path, feature string) error {
+	return &ValidationError{
+		Target:  path,
+		Feature: feature,
+		Message: fmt.Sprintf("The subscription must be registered for the %s feature to set property '%s'.", feature, path),
+	}
+}
@@ -0,0 +1,133 @@
+package preview
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type tsProfile struct {
+	Name    string `json:"name,omitempty"`
+	Preview string `json:"preview,omitempty" preview:"Test/Profile"`
+}
+
+type tsNested struct {
+	Preview int `json:"preview,omitempty" preview:"Test/Nested"`
+}
+
+type ts struct {
+	GA       string      `json:"ga,omitempty"`
+	Preview  string      `json:"preview,omitempty" preview:"Test/String"`
+	Nested   *tsNested   `json:"nested,omitempty" preview:"Test/Pointer"`
+	Profiles []tsProfile `json:"profiles,omitempty"`
+}
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		v          *ts
+		current    *ts
+		registered []string
+		lookupErr  error
+		wantErr    string
+	}{
+		{
+			name: "create without preview properties",
+			v:    &ts{GA: "value"},
+		},
+		{
+			name:    "create with preview property, not registered",
+			v:       &ts{Preview: "value"},
+			wantErr: "The subscription must be registered for the Test/String feature to set property 'preview'.",
+		},
+		{
+			name:       "create with preview property, registered",
+			v:          &ts{Preview: "value"},
+			registered: []string{"Test/String"},
+		},
+		{
+			name:    "create with preview pointer, not registered",
+			v:       &ts{Nested: &tsNested{}},
+			wantErr: "The subscription must be registered for the Test/Pointer feature to set property 'nested'.",
+		},
+		{
+			name:       "create with preview property in preview pointer, registered for outer feature only",
+			v:          &ts{Nested: &tsNested{Preview: 1}},
+			registered: []string{"Test/Pointer"},
+			wantErr:    "The subscription must be registered for the Test/Nested feature to set property 'nested.preview'.",
+		},
+		{
+			name:    "create with preview property in slice, not registered",
+			v:       &ts{Profiles: []tsProfile{{Name: "a"}, {Name: "b", Preview: "value"}}},
+			wantErr: "The subscription must be registered for the Test/Profile feature to set property 'profiles['b'].preview'.",
+		},
+		{
+			name:    "update leaving preview properties unchanged, not registered",
+			v:       &ts{Preview: "value", Nested: &tsNested{Preview: 1}, Profiles: []tsProfile{{Name: "a", Preview: "value"}}},
+			current: &ts{Preview: "value", Nested: &tsNested{Preview: 1}, Profiles: []tsProfile{{Name: "a", Preview: "value"}}},
+		},
+		{
+			name:    "update removing preview property, not registered",
+			v:       &ts{},
+			current: &ts{Preview: "value"},
+		},
+		{
+			name:    "update changing preview property, not registered",
+			v:       &ts{Preview: "new"},
+			current: &ts{Preview: "old"},
+			wantErr: "The subscription must be registered for the Test/String feature to set property 'preview'.",
+		},
+		{
+			name:    "update adding preview property to slice, not registered",
+			v:       &ts{Profiles: []tsProfile{{Name: "a"}, {Name: "b", Preview: "value"}}},
+			current: &ts{Profiles: []tsProfile{{Name: "a"}}},
+			wantErr: "The subscription must be registered for the Test/Profile feature to set property 'profiles['b'].preview'.",
+		},
+		{
+			name:      "feature lookup fails",
+			v:         &ts{Preview: "value"},
+			lookupErr: errors.New("lookup failed"),
+			wantErr:   "lookup failed",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var current interface{}
+			if tt.current != nil {
+				current = tt.current
+			}
+
+			err := Validate("", tt.v, current, func(feature string) (bool, error) {
+				if tt.lookupErr != nil {
+					return false, tt.lookupErr
+				}
+				for _, f := range tt.registered {
+					if f == feature {
+						return true, nil
+					}
+				}
+				return false, nil
+			})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if verr, ok := err.(*ValidationError); ok && verr.Feature == "" {
+				t.Error("feature not set")
+			}
+		})
+	}
+}
+
+func TestFeatures(t *testing.T) {
+	features := Features(reflect.TypeOf(&ts{}))
+	sort.Strings(features)
+
+	want := []string{"Test/Nested", "Test/Pointer", "Test/Profile", "Test/String"}
+	if !reflect.DeepEqual(features, want) {
+		t.Error(features)
+	}
+}
@@ -369,11 +369,5 @@ func (sv openShiftClusterStaticValidator) validateIngressProfile(path string, p
 }
 
 func (sv openShiftClusterStaticValidator) validateDelta(oc, current *OpenShiftCluster) error {
-	err := immutable.Validate("", oc, current)
-	if err != nil {
-		err := err.(*immutable.ValidationError)
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodePropertyChangeNotAllowed, err.Target, err.Message)
-	}
-
-	return nil
+	return immutable.ValidateDelta(oc, current)
 }
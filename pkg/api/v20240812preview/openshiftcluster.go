@@ -73,11 +73,20 @@ type OpenShiftClusterProperties struct {
 	// The cluster worker profiles status.
 	WorkerProfilesStatus []WorkerProfile `json:"workerProfilesStatus,omitempty" swagger:"readOnly"`
 
+	// Whether the cluster VMs are placed in availability zones.  With Automatic, the zones of profiles which do not list their own are selected by the service.
+	ZonePlacement ZonePlacement `json:"zonePlacement,omitempty"`
+
 	// The cluster API server profile.
 	APIServerProfile APIServerProfile `json:"apiserverProfile,omitempty"`
 
 	// The cluster ingress profiles.
 	IngressProfiles []IngressProfile `json:"ingressProfiles,omitempty"`
+
+	// The cluster diagnostic settings profile.
+	DiagnosticSettingsProfile *DiagnosticSettingsProfile `json:"diagnosticSettingsProfile,omitempty" mutable:"true"`
+
+	// The cluster max nodes profile.
+	MaxNodesProfile *MaxNodesProfile `json:"maxNodesProfile,omitempty" preview:"Microsoft.RedHatOpenShift/LargeClusters"`
 }
 
 // ProvisioningState represents a provisioning state.
@@ -183,6 +192,12 @@ type NetworkProfile struct {
 	// The CIDR used for OpenShift/Kubernetes Services.
 	ServiceCIDR string `json:"serviceCidr,omitempty"`
 
+	// The IPv6 CIDR used for OpenShift/Kubernetes Pods of a dual-stack cluster.
+	PodCIDRIPv6 string `json:"podCidrIpv6,omitempty" preview:"Microsoft.RedHatOpenShift/DualStack"`
+
+	// The IPv6 CIDR used for OpenShift/Kubernetes Services of a dual-stack cluster.
+	ServiceCIDRIPv6 string `json:"serviceCidrIpv6,omitempty" preview:"Microsoft.RedHatOpenShift/DualStack"`
+
 	// The OutboundType used for egress traffic.
 	OutboundType OutboundType `json:"outboundType,omitempty"`
 
@@ -191,6 +206,9 @@ type NetworkProfile struct {
 
 	// Specifies whether subnets are pre-attached with an NSG
 	PreconfiguredNSG PreconfiguredNSG `json:"preconfiguredNSG,omitempty"`
+
+	// The resource ID of a public IP prefix which the public IPs of the API server and the default ingress are allocated from.
+	PublicIPPrefixID string `json:"publicIpPrefixId,omitempty"`
 }
 
 // PreconfiguredNSG represents whether customers want to use their own NSG attached to the subnets
@@ -211,6 +229,33 @@ const (
 	EncryptionAtHostDisabled EncryptionAtHost = "Disabled"
 )
 
+// DiskType represents the managed disk type of VMs.
+type DiskType string
+
+// DiskType constants
+const (
+	DiskTypePremiumLRS  DiskType = "Premium_LRS"
+	DiskTypeUltraSSDLRS DiskType = "UltraSSD_LRS"
+)
+
+// AcceleratedNetworking represents accelerated networking state
+type AcceleratedNetworking string
+
+// AcceleratedNetworking constants
+const (
+	AcceleratedNetworkingEnabled  AcceleratedNetworking = "Enabled"
+	AcceleratedNetworkingDisabled AcceleratedNetworking = "Disabled"
+)
+
+// ZonePlacement represents how the cluster VMs are placed in availability zones.
+type ZonePlacement string
+
+// ZonePlacement constants
+const (
+	ZonePlacementAutomatic ZonePlacement = "Automatic"
+	ZonePlacementNonZonal  ZonePlacement = "NonZonal"
+)
+
 // MasterProfile represents a master profile.
 type MasterProfile struct {
 	// The size of the master VMs.
@@ -224,6 +269,15 @@ type MasterProfile struct {
 
 	// The resource ID of an associated DiskEncryptionSet, if applicable.
 	DiskEncryptionSetID string `json:"diskEncryptionSetId,omitempty"`
+
+	// The managed disk type of the master VMs.  OS disks are always Premium_LRS; UltraSSD_LRS also allows Ultra disks to be attached.
+	DiskType DiskType `json:"diskType,omitempty"`
+
+	// Whether the master VMs use accelerated networking.
+	AcceleratedNetworking AcceleratedNetworking `json:"acceleratedNetworking,omitempty"`
+
+	// The availability zones of the master VMs.  If empty, the zones are selected by the service.
+	Zones []string `json:"zones,omitempty"`
 }
 
 // VM size availability varies by region.
@@ -236,8 +290,8 @@ type WorkerProfile struct {
 	// The worker profile name.
 	Name string `json:"name,omitempty"`
 
-	// The size of the worker VMs.
-	VMSize VMSize `json:"vmSize,omitempty"`
+	// The size of the worker VMs.  Changing it on an existing cluster replaces the worker VMs of the profile with VMs of the new size.
+	VMSize VMSize `json:"vmSize,omitempty" mutable:"true"`
 
 	// The disk size of the worker VMs.
 	DiskSizeGB int `json:"diskSizeGB,omitempty"`
@@ -253,8 +307,40 @@ type WorkerProfile struct {
 
 	// The resource ID of an associated DiskEncryptionSet, if applicable.
 	DiskEncryptionSetID string `json:"diskEncryptionSetId,omitempty"`
+
+	// The managed disk type of the worker VMs.  OS disks are always Premium_LRS; UltraSSD_LRS also allows Ultra disks to be attached.
+	DiskType DiskType `json:"diskType,omitempty"`
+
+	// Whether the worker VMs use accelerated networking.
+	AcceleratedNetworking AcceleratedNetworking `json:"acceleratedNetworking,omitempty"`
+
+	// The availability zones across which the worker VMs are spread.  If empty, the zones are selected by the service.
+	Zones []string `json:"zones,omitempty"`
+
+	// The Spot VM options, if the worker VMs are Azure Spot VMs.
+	SpotVMOptions *SpotVMOptions `json:"spotVMOptions,omitempty"`
+
+	// The number of worker VMs of the new size which may be created above the worker count of each MachineSet while the worker VMs are replaced after a change of size.  Defaults to 1.
+	MaxSurge int `json:"maxSurge,omitempty" mutable:"true"`
+}
+
+// SpotVMOptions represents the Spot VM options of a worker profile.
+type SpotVMOptions struct {
+	// The maximum hourly price in US dollars, or -1 to pay up to the on-demand price.
+	MaxPrice *float64 `json:"maxPrice,omitempty"`
+
+	// What happens to a worker VM when it is evicted.
+	EvictionPolicy SpotEvictionPolicy `json:"evictionPolicy,omitempty"`
 }
 
+// SpotEvictionPolicy represents a Spot VM eviction policy.
+type SpotEvictionPolicy string
+
+// SpotEvictionPolicy constants
+const (
+	SpotEvictionPolicyDelete SpotEvictionPolicy = "Delete"
+)
+
 // APIServerProfile represents an API server profile.
 type APIServerProfile struct {
 	// API server visibility.
@@ -265,6 +351,9 @@ type APIServerProfile struct {
 
 	// The IP of the cluster API server.
 	IP string `json:"ip,omitempty" swagger:"readOnly"`
+
+	// The IPv6 IP of the cluster API server of a dual-stack cluster.
+	IPv6 string `json:"ipv6,omitempty" swagger:"readOnly"`
 }
 
 // Visibility represents visibility.
@@ -286,6 +375,21 @@ type IngressProfile struct {
 
 	// The IP of the ingress.
 	IP string `json:"ip,omitempty" swagger:"readOnly"`
+
+	// The IPv6 IP of the ingress of a dual-stack cluster.
+	IPv6 string `json:"ipv6,omitempty" swagger:"readOnly"`
+}
+
+// DiagnosticSettingsProfile represents the Azure Monitor diagnostic settings of the cluster's load balancers, network security groups and key vaults.
+type DiagnosticSettingsProfile struct {
+	// The resource ID of the Log Analytics workspace to which logs and metrics are sent.
+	WorkspaceResourceID string `json:"workspaceResourceId,omitempty" mutable:"true"`
+}
+
+// MaxNodesProfile represents the largest number of worker nodes which a large cluster is sized for.
+type MaxNodesProfile struct {
+	// The maximum number of worker nodes.
+	MaxNodes int `json:"maxNodes,omitempty"`
 }
 
 // PlatformWorkloadIdentityProfile encapsulates all information that is specific to workload identity clusters.
@@ -39,19 +39,27 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 			NetworkProfile: NetworkProfile{
 				PodCIDR:          oc.Properties.NetworkProfile.PodCIDR,
 				ServiceCIDR:      oc.Properties.NetworkProfile.ServiceCIDR,
+				PodCIDRIPv6:      oc.Properties.NetworkProfile.PodCIDRIPv6,
+				ServiceCIDRIPv6:  oc.Properties.NetworkProfile.ServiceCIDRIPv6,
 				OutboundType:     OutboundType(oc.Properties.NetworkProfile.OutboundType),
 				PreconfiguredNSG: PreconfiguredNSG(oc.Properties.NetworkProfile.PreconfiguredNSG),
+				PublicIPPrefixID: oc.Properties.NetworkProfile.PublicIPPrefixID,
 			},
 			MasterProfile: MasterProfile{
-				VMSize:              VMSize(oc.Properties.MasterProfile.VMSize),
-				SubnetID:            oc.Properties.MasterProfile.SubnetID,
-				EncryptionAtHost:    EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost),
-				DiskEncryptionSetID: oc.Properties.MasterProfile.DiskEncryptionSetID,
+				VMSize:                VMSize(oc.Properties.MasterProfile.VMSize),
+				SubnetID:              oc.Properties.MasterProfile.SubnetID,
+				EncryptionAtHost:      EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost),
+				DiskEncryptionSetID:   oc.Properties.MasterProfile.DiskEncryptionSetID,
+				DiskType:              DiskType(oc.Properties.MasterProfile.DiskType),
+				AcceleratedNetworking: AcceleratedNetworking(oc.Properties.MasterProfile.AcceleratedNetworking),
+				Zones:                 append([]string(nil), oc.Properties.MasterProfile.Zones...),
 			},
+			ZonePlacement: ZonePlacement(oc.Properties.ZonePlacement),
 			APIServerProfile: APIServerProfile{
 				Visibility: Visibility(oc.Properties.APIServerProfile.Visibility),
 				URL:        oc.Properties.APIServerProfile.URL,
 				IP:         oc.Properties.APIServerProfile.IP,
+				IPv6:       oc.Properties.APIServerProfile.IPv6,
 			},
 		},
 	}
@@ -80,13 +88,18 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.WorkerProfiles = make([]WorkerProfile, 0, len(workerProfiles))
 		for _, p := range workerProfiles {
 			out.Properties.WorkerProfiles = append(out.Properties.WorkerProfiles, WorkerProfile{
-				Name:                p.Name,
-				VMSize:              VMSize(p.VMSize),
-				DiskSizeGB:          p.DiskSizeGB,
-				SubnetID:            p.SubnetID,
-				Count:               p.Count,
-				EncryptionAtHost:    EncryptionAtHost(p.EncryptionAtHost),
-				DiskEncryptionSetID: p.DiskEncryptionSetID,
+				Name:                  p.Name,
+				VMSize:                VMSize(p.VMSize),
+				DiskSizeGB:            p.DiskSizeGB,
+				SubnetID:              p.SubnetID,
+				Count:                 p.Count,
+				EncryptionAtHost:      EncryptionAtHost(p.EncryptionAtHost),
+				DiskEncryptionSetID:   p.DiskEncryptionSetID,
+				DiskType:              DiskType(p.DiskType),
+				AcceleratedNetworking: AcceleratedNetworking(p.AcceleratedNetworking),
+				Zones:                 append([]string(nil), p.Zones...),
+				SpotVMOptions:         spotVMOptionsToExternal(p.SpotVMOptions),
+				MaxSurge:              p.MaxSurge,
 			})
 		}
 	}
@@ -96,13 +109,18 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.WorkerProfilesStatus = make([]WorkerProfile, 0, len(workerProfiles))
 		for _, p := range workerProfiles {
 			out.Properties.WorkerProfilesStatus = append(out.Properties.WorkerProfilesStatus, WorkerProfile{
-				Name:                p.Name,
-				VMSize:              VMSize(p.VMSize),
-				DiskSizeGB:          p.DiskSizeGB,
-				SubnetID:            p.SubnetID,
-				Count:               p.Count,
-				EncryptionAtHost:    EncryptionAtHost(p.EncryptionAtHost),
-				DiskEncryptionSetID: p.DiskEncryptionSetID,
+				Name:                  p.Name,
+				VMSize:                VMSize(p.VMSize),
+				DiskSizeGB:            p.DiskSizeGB,
+				SubnetID:              p.SubnetID,
+				Count:                 p.Count,
+				EncryptionAtHost:      EncryptionAtHost(p.EncryptionAtHost),
+				DiskEncryptionSetID:   p.DiskEncryptionSetID,
+				DiskType:              DiskType(p.DiskType),
+				AcceleratedNetworking: AcceleratedNetworking(p.AcceleratedNetworking),
+				Zones:                 append([]string(nil), p.Zones...),
+				SpotVMOptions:         spotVMOptionsToExternal(p.SpotVMOptions),
+				MaxSurge:              p.MaxSurge,
 			})
 		}
 	}
@@ -114,6 +132,7 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 				Name:       p.Name,
 				Visibility: Visibility(p.Visibility),
 				IP:         p.IP,
+				IPv6:       p.IPv6,
 			})
 		}
 	}
@@ -148,6 +167,18 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		}
 	}
 
+	if oc.Properties.DiagnosticSettingsProfile != nil {
+		out.Properties.DiagnosticSettingsProfile = &DiagnosticSettingsProfile{
+			WorkspaceResourceID: oc.Properties.DiagnosticSettingsProfile.WorkspaceResourceID,
+		}
+	}
+
+	if oc.Properties.MaxNodesProfile != nil {
+		out.Properties.MaxNodesProfile = &MaxNodesProfile{
+			MaxNodes: oc.Properties.MaxNodesProfile.MaxNodes,
+		}
+	}
+
 	out.SystemData = &SystemData{
 		CreatedBy:          oc.SystemData.CreatedBy,
 		CreatedAt:          oc.SystemData.CreatedAt,
@@ -175,6 +206,17 @@ func (c openShiftClusterConverter) ToExternalList(ocs []*api.OpenShiftCluster, n
 	return l
 }
 
+func spotVMOptionsToExternal(o *api.SpotVMOptions) *SpotVMOptions {
+	if o == nil {
+		return nil
+	}
+
+	return &SpotVMOptions{
+		MaxPrice:       o.MaxPrice,
+		EvictionPolicy: SpotEvictionPolicy(o.EvictionPolicy),
+	}
+}
+
 // ToInternal overwrites in place a pre-existing internal object, setting (only)
 // all mapped fields from the external representation. ToInternal modifies its
 // argument; there is no pointer aliasing between the passed and returned
@@ -231,7 +273,10 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 
 	out.Properties.NetworkProfile.PodCIDR = oc.Properties.NetworkProfile.PodCIDR
 	out.Properties.NetworkProfile.ServiceCIDR = oc.Properties.NetworkProfile.ServiceCIDR
+	out.Properties.NetworkProfile.PodCIDRIPv6 = oc.Properties.NetworkProfile.PodCIDRIPv6
+	out.Properties.NetworkProfile.ServiceCIDRIPv6 = oc.Properties.NetworkProfile.ServiceCIDRIPv6
 	out.Properties.NetworkProfile.OutboundType = api.OutboundType(oc.Properties.NetworkProfile.OutboundType)
+	out.Properties.NetworkProfile.PublicIPPrefixID = oc.Properties.NetworkProfile.PublicIPPrefixID
 
 	if oc.Properties.NetworkProfile.LoadBalancerProfile != nil {
 		loadBalancerProfile := api.LoadBalancerProfile{}
@@ -262,6 +307,10 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	out.Properties.MasterProfile.SubnetID = oc.Properties.MasterProfile.SubnetID
 	out.Properties.MasterProfile.EncryptionAtHost = api.EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost)
 	out.Properties.MasterProfile.DiskEncryptionSetID = oc.Properties.MasterProfile.DiskEncryptionSetID
+	out.Properties.MasterProfile.DiskType = api.DiskType(oc.Properties.MasterProfile.DiskType)
+	out.Properties.MasterProfile.AcceleratedNetworking = api.AcceleratedNetworking(oc.Properties.MasterProfile.AcceleratedNetworking)
+	out.Properties.MasterProfile.Zones = append([]string(nil), oc.Properties.MasterProfile.Zones...)
+	out.Properties.ZonePlacement = api.ZonePlacement(oc.Properties.ZonePlacement)
 	out.Properties.WorkerProfiles = nil
 	if oc.Properties.WorkerProfiles != nil {
 		out.Properties.WorkerProfiles = make([]api.WorkerProfile, len(oc.Properties.WorkerProfiles))
@@ -273,6 +322,16 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.WorkerProfiles[i].Count = oc.Properties.WorkerProfiles[i].Count
 			out.Properties.WorkerProfiles[i].EncryptionAtHost = api.EncryptionAtHost(oc.Properties.WorkerProfiles[i].EncryptionAtHost)
 			out.Properties.WorkerProfiles[i].DiskEncryptionSetID = oc.Properties.WorkerProfiles[i].DiskEncryptionSetID
+			out.Properties.WorkerProfiles[i].DiskType = api.DiskType(oc.Properties.WorkerProfiles[i].DiskType)
+			out.Properties.WorkerProfiles[i].AcceleratedNetworking = api.AcceleratedNetworking(oc.Properties.WorkerProfiles[i].AcceleratedNetworking)
+			out.Properties.WorkerProfiles[i].Zones = append([]string(nil), oc.Properties.WorkerProfiles[i].Zones...)
+			out.Properties.WorkerProfiles[i].MaxSurge = oc.Properties.WorkerProfiles[i].MaxSurge
+			if oc.Properties.WorkerProfiles[i].SpotVMOptions != nil {
+				out.Properties.WorkerProfiles[i].SpotVMOptions = &api.SpotVMOptions{
+					MaxPrice:       oc.Properties.WorkerProfiles[i].SpotVMOptions.MaxPrice,
+					EvictionPolicy: api.SpotEvictionPolicy(oc.Properties.WorkerProfiles[i].SpotVMOptions.EvictionPolicy),
+				}
+			}
 		}
 	}
 	out.Properties.WorkerProfilesStatus = nil
@@ -286,6 +345,16 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.WorkerProfilesStatus[i].Count = oc.Properties.WorkerProfilesStatus[i].Count
 			out.Properties.WorkerProfilesStatus[i].EncryptionAtHost = api.EncryptionAtHost(oc.Properties.WorkerProfilesStatus[i].EncryptionAtHost)
 			out.Properties.WorkerProfilesStatus[i].DiskEncryptionSetID = oc.Properties.WorkerProfilesStatus[i].DiskEncryptionSetID
+			out.Properties.WorkerProfilesStatus[i].DiskType = api.DiskType(oc.Properties.WorkerProfilesStatus[i].DiskType)
+			out.Properties.WorkerProfilesStatus[i].AcceleratedNetworking = api.AcceleratedNetworking(oc.Properties.WorkerProfilesStatus[i].AcceleratedNetworking)
+			out.Properties.WorkerProfilesStatus[i].Zones = append([]string(nil), oc.Properties.WorkerProfilesStatus[i].Zones...)
+			out.Properties.WorkerProfilesStatus[i].MaxSurge = oc.Properties.WorkerProfilesStatus[i].MaxSurge
+			if oc.Properties.WorkerProfilesStatus[i].SpotVMOptions != nil {
+				out.Properties.WorkerProfilesStatus[i].SpotVMOptions = &api.SpotVMOptions{
+					MaxPrice:       oc.Properties.WorkerProfilesStatus[i].SpotVMOptions.MaxPrice,
+					EvictionPolicy: api.SpotEvictionPolicy(oc.Properties.WorkerProfilesStatus[i].SpotVMOptions.EvictionPolicy),
+				}
+			}
 		}
 	}
 	out.Properties.APIServerProfile.Visibility = api.Visibility(oc.Properties.APIServerProfile.Visibility)
@@ -295,6 +364,9 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	if oc.Properties.APIServerProfile.IP != "" {
 		out.Properties.APIServerProfile.IP = oc.Properties.APIServerProfile.IP
 	}
+	if oc.Properties.APIServerProfile.IPv6 != "" {
+		out.Properties.APIServerProfile.IPv6 = oc.Properties.APIServerProfile.IPv6
+	}
 	out.Properties.IngressProfiles = nil
 	if oc.Properties.IngressProfiles != nil {
 		out.Properties.IngressProfiles = make([]api.IngressProfile, len(oc.Properties.IngressProfiles))
@@ -304,6 +376,23 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			if oc.Properties.IngressProfiles[i].IP != "" {
 				out.Properties.IngressProfiles[i].IP = oc.Properties.IngressProfiles[i].IP
 			}
+			if oc.Properties.IngressProfiles[i].IPv6 != "" {
+				out.Properties.IngressProfiles[i].IPv6 = oc.Properties.IngressProfiles[i].IPv6
+			}
+		}
+	}
+
+	out.Properties.DiagnosticSettingsProfile = nil
+	if oc.Properties.DiagnosticSettingsProfile != nil {
+		out.Properties.DiagnosticSettingsProfile = &api.DiagnosticSettingsProfile{
+			WorkspaceResourceID: oc.Properties.DiagnosticSettingsProfile.WorkspaceResourceID,
+		}
+	}
+
+	out.Properties.MaxNodesProfile = nil
+	if oc.Properties.MaxNodesProfile != nil {
+		out.Properties.MaxNodesProfile = &api.MaxNodesProfile{
+			MaxNodes: oc.Properties.MaxNodesProfile.MaxNodes,
 		}
 	}
 
@@ -330,11 +419,15 @@ func (c openShiftClusterConverter) ExternalNoReadOnly(_oc interface{}) {
 	oc.Properties.ConsoleProfile.URL = ""
 	oc.Properties.APIServerProfile.URL = ""
 	oc.Properties.APIServerProfile.IP = ""
+	oc.Properties.APIServerProfile.IPv6 = ""
 	for i := range oc.Properties.IngressProfiles {
 		oc.Properties.IngressProfiles[i].IP = ""
+		oc.Properties.IngressProfiles[i].IPv6 = ""
 	}
-	for i := range oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities {
-		oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities[i].ClientID = ""
-		oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities[i].ObjectID = ""
+	if oc.Properties.PlatformWorkloadIdentityProfile != nil {
+		for i := range oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities {
+			oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities[i].ClientID = ""
+			oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities[i].ObjectID = ""
+		}
 	}
 }
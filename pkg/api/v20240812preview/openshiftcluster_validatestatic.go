@@ -21,6 +21,24 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
+const (
+	// defaultMaxWorkers is the worker count limit of a cluster without a
+	// maxNodesProfile, and maxLargeClusterWorkers that of a large cluster
+	defaultMaxWorkers      = 50
+	maxLargeClusterWorkers = 250
+
+	// hostPrefix is the size of the pod CIDR block allocated to each node
+	hostPrefix = 23
+
+	largeClusterServicePrefix = 20
+
+	// large clusters with more than largeControlPlaneWorkers workers need
+	// master VMs of at least largeControlPlaneCores cores to keep etcd and the
+	// API server responsive
+	largeControlPlaneWorkers = 120
+	largeControlPlaneCores   = 16
+)
+
 type openShiftClusterStaticValidator struct {
 	location            string
 	domain              string
@@ -107,19 +125,28 @@ func (sv openShiftClusterStaticValidator) validateProperties(path string, p *Ope
 	if err := sv.validateMasterProfile(path+".masterProfile", &p.MasterProfile); err != nil {
 		return err
 	}
+	if err := sv.validateZonePlacement(path, p); err != nil {
+		return err
+	}
 	if err := sv.validateAPIServerProfile(path+".apiserverProfile", &p.APIServerProfile); err != nil {
 		return err
 	}
+	if err := sv.validateDiagnosticSettingsProfile(path+".diagnosticSettingsProfile", p.DiagnosticSettingsProfile); err != nil {
+		return err
+	}
+	if err := sv.validateMaxNodesProfile(path+".maxNodesProfile", p.MaxNodesProfile, &p.NetworkProfile, &p.MasterProfile); err != nil {
+		return err
+	}
 
 	if isCreate {
 		if len(p.WorkerProfilesStatus) != 0 {
 			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workerProfilesStatus", "Worker Profile Status must be set to nil.")
 		}
 
-		if len(p.WorkerProfiles) != 1 {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workerProfiles", "There should be exactly one worker profile.")
+		if len(p.WorkerProfiles) == 0 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workerProfiles", "There should be at least one worker profile.")
 		}
-		if err := sv.validateWorkerProfile(path+".workerProfiles['"+p.WorkerProfiles[0].Name+"']", &p.WorkerProfiles[0], &p.MasterProfile); err != nil {
+		if err := sv.validateWorkerProfiles(path+".workerProfiles", p.WorkerProfiles, &p.MasterProfile, p.MaxNodesProfile); err != nil {
 			return err
 		}
 
@@ -230,6 +257,13 @@ func (sv openShiftClusterStaticValidator) validateNetworkProfile(path string, np
 		}
 	}
 
+	if np.PodCIDRIPv6 != "" || np.ServiceCIDRIPv6 != "" {
+		err := validateDualStack(path, np)
+		if err != nil {
+			return err
+		}
+	}
+
 	if np.OutboundType != "" {
 		if np.OutboundType != OutboundTypeLoadbalancer && np.OutboundType != OutboundTypeUserDefinedRouting {
 			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".outboundType", "The provided outboundType '%s' is invalid: must be UserDefinedRouting or Loadbalancer.", np.OutboundType)
@@ -243,6 +277,65 @@ func (sv openShiftClusterStaticValidator) validateNetworkProfile(path string, np
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".loadBalancerProfile", "The provided loadBalancerProfile is invalid: cannot use a loadBalancerProfile if outboundType is UserDefinedRouting.")
 	}
 
+	if np.PublicIPPrefixID != "" {
+		if !validate.RxPublicIPPrefixID.MatchString(np.PublicIPPrefixID) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".publicIpPrefixId", "The provided public IP prefix '%s' is invalid.", np.PublicIPPrefixID)
+		}
+		pr, err := azure.ParseResourceID(np.PublicIPPrefixID)
+		if err != nil {
+			return err
+		}
+		if pr.SubscriptionID != sv.r.SubscriptionID {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".publicIpPrefixId", "The provided public IP prefix '%s' is invalid: must be in same subscription as cluster.", np.PublicIPPrefixID)
+		}
+		if apiServerVisibility != VisibilityPublic && ingressVisibility != VisibilityPublic {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".publicIpPrefixId", "The provided public IP prefix '%s' is invalid: cannot use a public IP prefix if both API Server Visibility and Ingress Visibility are private.", np.PublicIPPrefixID)
+		}
+	}
+
+	return nil
+}
+
+// validateDualStack validates the IPv6 CIDRs of a dual-stack cluster
+func validateDualStack(path string, np *NetworkProfile) error {
+	_, pod, err := net.ParseCIDR(np.PodCIDRIPv6)
+	if err != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".podCidrIpv6", "The provided IPv6 pod CIDR '%s' is invalid: '%s'.", np.PodCIDRIPv6, err)
+	}
+	if pod.IP.To4() != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".podCidrIpv6", "The provided IPv6 pod CIDR '%s' is invalid: must be IPv6.", np.PodCIDRIPv6)
+	}
+	{
+		// each node is given a /64 of the pod CIDR
+		ones, _ := pod.Mask.Size()
+		if ones > 48 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".podCidrIpv6", "The provided IPv6 pod CIDR '%s' is invalid: must be /48 or larger.", np.PodCIDRIPv6)
+		}
+	}
+
+	_, service, err := net.ParseCIDR(np.ServiceCIDRIPv6)
+	if err != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".serviceCidrIpv6", "The provided IPv6 service CIDR '%s' is invalid: '%s'.", np.ServiceCIDRIPv6, err)
+	}
+	if service.IP.To4() != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".serviceCidrIpv6", "The provided IPv6 service CIDR '%s' is invalid: must be IPv6.", np.ServiceCIDRIPv6)
+	}
+	{
+		// the Kubernetes API server refuses IPv6 service CIDRs larger than /108
+		ones, _ := service.Mask.Size()
+		if ones < 108 || ones > 112 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".serviceCidrIpv6", "The provided IPv6 service CIDR '%s' is invalid: must be between /108 and /112.", np.ServiceCIDRIPv6)
+		}
+	}
+
+	if pod.Contains(service.IP) || service.Contains(pod.IP) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".serviceCidrIpv6", "The provided IPv6 service CIDR '%s' is invalid: must not overlap with the IPv6 pod CIDR '%s'.", np.ServiceCIDRIPv6, np.PodCIDRIPv6)
+	}
+
+	if np.OutboundType == OutboundTypeUserDefinedRouting {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".outboundType", "The provided outboundType '%s' is invalid: cannot use UserDefinedRouting on a dual-stack cluster.", np.OutboundType)
+	}
+
 	return nil
 }
 
@@ -295,6 +388,16 @@ func (sv openShiftClusterStaticValidator) validateMasterProfile(path string, mp
 	default:
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".encryptionAtHost", "The provided value '%s' is invalid.", mp.EncryptionAtHost)
 	}
+	switch mp.DiskType {
+	case "", DiskTypePremiumLRS, DiskTypeUltraSSDLRS:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".diskType", "The provided disk type '%s' is invalid.", mp.DiskType)
+	}
+	switch mp.AcceleratedNetworking {
+	case "", AcceleratedNetworkingDisabled, AcceleratedNetworkingEnabled:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".acceleratedNetworking", "The provided value '%s' is invalid.", mp.AcceleratedNetworking)
+	}
 	if mp.DiskEncryptionSetID != "" {
 		if !validate.RxDiskEncryptionSetID.MatchString(mp.DiskEncryptionSetID) {
 			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".diskEncryptionSetId", "The provided master disk encryption set '%s' is invalid.", mp.DiskEncryptionSetID)
@@ -311,9 +414,101 @@ func (sv openShiftClusterStaticValidator) validateMasterProfile(path string, mp
 	return nil
 }
 
-func (sv openShiftClusterStaticValidator) validateWorkerProfile(path string, wp *WorkerProfile, mp *MasterProfile) error {
-	if wp.Name != "worker" {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided worker name '%s' is invalid.", wp.Name)
+// validateZonePlacement validates the zone placement of the cluster and the
+// zones requested for the masters and each worker profile.  Whether the VM
+// sizes are available in the requested zones is validated dynamically.
+func (sv openShiftClusterStaticValidator) validateZonePlacement(path string, p *OpenShiftClusterProperties) error {
+	switch p.ZonePlacement {
+	case "", ZonePlacementAutomatic, ZonePlacementNonZonal:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".zonePlacement", "The provided zone placement '%s' is invalid.", p.ZonePlacement)
+	}
+
+	if err := validateZones(path+".masterProfile.zones", p.MasterProfile.Zones, p.ZonePlacement); err != nil {
+		return err
+	}
+	for i := range p.WorkerProfiles {
+		if err := validateZones(path+".workerProfiles['"+p.WorkerProfiles[i].Name+"'].zones", p.WorkerProfiles[i].Zones, p.ZonePlacement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateZones(path string, zones []string, zonePlacement ZonePlacement) error {
+	if len(zones) > 0 && zonePlacement == ZonePlacementNonZonal {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided zones are invalid: zones must not be set when the zone placement is '%s'.", ZonePlacementNonZonal)
+	}
+
+	seen := map[string]struct{}{}
+	for _, zone := range zones {
+		if !validate.RxAvailabilityZone.MatchString(zone) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided zone '%s' is invalid.", zone)
+		}
+		if _, ok := seen[zone]; ok {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided zone '%s' is not unique.", zone)
+		}
+		seen[zone] = struct{}{}
+	}
+
+	return nil
+}
+
+// validateWorkerProfiles validates the worker profiles of a new cluster.  The
+// first profile is installed with the cluster; any further profiles are pools
+// of Spot VMs which are added once the cluster is installed.
+func (sv openShiftClusterStaticValidator) validateWorkerProfiles(path string, wps []WorkerProfile, mp *MasterProfile, mnp *MaxNodesProfile) error {
+	names := map[string]struct{}{}
+	var count int
+
+	for i := range wps {
+		wpPath := path + "['" + wps[i].Name + "']"
+
+		if _, ok := names[wps[i].Name]; ok {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, wpPath+".name", "The provided worker name '%s' is not unique.", wps[i].Name)
+		}
+		names[wps[i].Name] = struct{}{}
+
+		if err := sv.validateWorkerProfile(wpPath, &wps[i], mp, mnp, i > 0); err != nil {
+			return err
+		}
+
+		count += wps[i].Count
+	}
+
+	if count > maxWorkers(mnp) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided total worker count '%d' is invalid.", count)
+	}
+
+	return nil
+}
+
+func maxWorkers(mnp *MaxNodesProfile) int {
+	if mnp != nil {
+		return mnp.MaxNodes
+	}
+	return defaultMaxWorkers
+}
+
+func (sv openShiftClusterStaticValidator) validateWorkerProfile(path string, wp *WorkerProfile, mp *MasterProfile, mnp *MaxNodesProfile, spot bool) error {
+	if spot {
+		if wp.Name == "worker" || !validate.RxSpotWorkerProfileName.MatchString(wp.Name) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided worker name '%s' is invalid.", wp.Name)
+		}
+		if wp.SpotVMOptions == nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".spotVMOptions", "The provided worker profile is invalid: additional worker profiles must use Spot VMs.")
+		}
+		if err := sv.validateSpotVMOptions(path+".spotVMOptions", wp.SpotVMOptions); err != nil {
+			return err
+		}
+	} else {
+		if wp.Name != "worker" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided worker name '%s' is invalid.", wp.Name)
+		}
+		if wp.SpotVMOptions != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".spotVMOptions", "The provided worker profile is invalid: the first worker profile must not use Spot VMs.")
+		}
 	}
 	if !validate.VMSizeIsValid(api.VMSize(wp.VMSize), sv.requireD2sV3Workers, false) {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".vmSize", "The provided worker VM size '%s' is invalid.", wp.VMSize)
@@ -329,6 +524,16 @@ func (sv openShiftClusterStaticValidator) validateWorkerProfile(path string, wp
 	default:
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".encryptionAtHost", "The provided value '%s' is invalid.", wp.EncryptionAtHost)
 	}
+	switch wp.DiskType {
+	case "", DiskTypePremiumLRS, DiskTypeUltraSSDLRS:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".diskType", "The provided disk type '%s' is invalid.", wp.DiskType)
+	}
+	switch wp.AcceleratedNetworking {
+	case "", AcceleratedNetworkingDisabled, AcceleratedNetworkingEnabled:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".acceleratedNetworking", "The provided value '%s' is invalid.", wp.AcceleratedNetworking)
+	}
 	workerVnetID, _, err := apisubnet.Split(wp.SubnetID)
 	if err != nil {
 		return err
@@ -343,12 +548,42 @@ func (sv openShiftClusterStaticValidator) validateWorkerProfile(path string, wp
 	if strings.EqualFold(mp.SubnetID, wp.SubnetID) {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided worker VM subnet '%s' is invalid: must be different to master VM subnet '%s'.", wp.SubnetID, mp.SubnetID)
 	}
-	if wp.Count < 2 || wp.Count > 50 {
+	minWorkers := 2
+	if spot {
+		minWorkers = 1
+	}
+	if wp.Count < minWorkers || wp.Count > maxWorkers(mnp) {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".count", "The provided worker count '%d' is invalid.", wp.Count)
 	}
 	if !strings.EqualFold(mp.DiskEncryptionSetID, wp.DiskEncryptionSetID) {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided worker disk encryption set '%s' is invalid: must be the same as master disk encryption set '%s'.", wp.DiskEncryptionSetID, mp.DiskEncryptionSetID)
 	}
+	if err := validateMaxSurge(path+".maxSurge", wp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateMaxSurge checks that the surge of a worker profile would not more
+// than double the worker VMs of any of its MachineSets while they are replaced
+func validateMaxSurge(path string, wp *WorkerProfile) error {
+	if wp.MaxSurge < 0 || wp.MaxSurge > wp.Count {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided max surge '%d' is invalid.", wp.MaxSurge)
+	}
+
+	return nil
+}
+
+func (sv openShiftClusterStaticValidator) validateSpotVMOptions(path string, o *SpotVMOptions) error {
+	if o.MaxPrice != nil && *o.MaxPrice != -1 && *o.MaxPrice <= 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".maxPrice", "The provided max price '%g' is invalid: must be -1 or greater than 0.", *o.MaxPrice)
+	}
+	switch o.EvictionPolicy {
+	case "", SpotEvictionPolicyDelete:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".evictionPolicy", "The provided eviction policy '%s' is invalid.", o.EvictionPolicy)
+	}
 
 	return nil
 }
@@ -399,11 +634,89 @@ func (sv openShiftClusterStaticValidator) validateIngressProfile(path string, p
 	return nil
 }
 
+func (sv openShiftClusterStaticValidator) validateDiagnosticSettingsProfile(path string, dsp *DiagnosticSettingsProfile) error {
+	if dsp == nil {
+		return nil
+	}
+
+	if !validate.RxWorkspaceID.MatchString(dsp.WorkspaceResourceID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workspaceResourceId", "The provided Log Analytics workspace '%s' is invalid.", dsp.WorkspaceResourceID)
+	}
+	wr, err := azure.ParseResourceID(dsp.WorkspaceResourceID)
+	if err != nil {
+		return err
+	}
+	if wr.SubscriptionID != sv.r.SubscriptionID {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workspaceResourceId", "The provided Log Analytics workspace '%s' is invalid: must be in same subscription as cluster.", dsp.WorkspaceResourceID)
+	}
+
+	return nil
+}
+
+// validateMaxNodesProfile checks that a large cluster's network and control
+// plane are sized for its maximum number of worker nodes
+func (sv openShiftClusterStaticValidator) validateMaxNodesProfile(path string, mnp *MaxNodesProfile, np *NetworkProfile, mp *MasterProfile) error {
+	if mnp == nil {
+		return nil
+	}
+
+	if mnp.MaxNodes <= defaultMaxWorkers || mnp.MaxNodes > maxLargeClusterWorkers {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".maxNodes", "The provided maxNodes %d is invalid: maxNodes must be in the range of %d to %d (inclusive).", mnp.MaxNodes, defaultMaxWorkers+1, maxLargeClusterWorkers)
+	}
+
+	// each node, including the three masters, is allocated a /23 of the pod
+	// CIDR
+	_, pod, err := net.ParseCIDR(np.PodCIDR)
+	if err != nil {
+		return err
+	}
+	ones, _ := pod.Mask.Size()
+	if ones > hostPrefix || 1<<(hostPrefix-ones) < mnp.MaxNodes+3 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.networkProfile.podCidr", "The provided pod CIDR '%s' is invalid: must be large enough to allocate a /%d to each of %d nodes.", np.PodCIDR, hostPrefix, mnp.MaxNodes+3)
+	}
+
+	_, service, err := net.ParseCIDR(np.ServiceCIDR)
+	if err != nil {
+		return err
+	}
+	if ones, _ := service.Mask.Size(); ones > largeClusterServicePrefix {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.networkProfile.serviceCidr", "The provided service CIDR '%s' is invalid: must be /%d or larger for large clusters.", np.ServiceCIDR, largeClusterServicePrefix)
+	}
+
+	if mnp.MaxNodes > largeControlPlaneWorkers {
+		if vm, ok := validate.VMSizeFromName(api.VMSize(mp.VMSize)); ok && vm.CoreCount < largeControlPlaneCores {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.masterProfile.vmSize", "The provided master VM size '%s' is invalid: clusters of more than %d worker nodes require master VMs with at least %d cores.", mp.VMSize, largeControlPlaneWorkers, largeControlPlaneCores)
+		}
+	}
+
+	return nil
+}
+
 func (sv openShiftClusterStaticValidator) validateDelta(oc, current *OpenShiftCluster) error {
-	err := immutable.Validate("", oc, current)
+	err := immutable.ValidateDelta(oc, current)
 	if err != nil {
-		err := err.(*immutable.ValidationError)
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodePropertyChangeNotAllowed, err.Target, err.Message)
+		return err
+	}
+
+	return sv.validateWorkerProfilesDelta("properties.workerProfiles", oc.Properties.WorkerProfiles, current.Properties.WorkerProfiles)
+}
+
+// validateWorkerProfilesDelta validates the mutable fields of the worker
+// profiles of an existing cluster which have changed.  validateDelta has
+// already checked that the profiles are otherwise unchanged.
+func (sv openShiftClusterStaticValidator) validateWorkerProfilesDelta(path string, wps, current []WorkerProfile) error {
+	for i := range wps {
+		wpPath := path + "['" + wps[i].Name + "']"
+
+		if wps[i].VMSize != current[i].VMSize &&
+			!validate.VMSizeIsValid(api.VMSize(wps[i].VMSize), sv.requireD2sV3Workers, false) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, wpPath+".vmSize", "The provided worker VM size '%s' is invalid.", wps[i].VMSize)
+		}
+		if wps[i].MaxSurge != current[i].MaxSurge {
+			if err := validateMaxSurge(wpPath+".maxSurge", &wps[i]); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
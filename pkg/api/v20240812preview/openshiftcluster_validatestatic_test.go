@@ -277,14 +277,16 @@ func TestOpenShiftClusterStaticValidateProperties(t *testing.T) {
 			modify: func(oc *OpenShiftCluster) {
 				oc.Properties.WorkerProfiles = nil
 			},
-			wantErr: "400: InvalidParameter: properties.workerProfiles: There should be exactly one worker profile.",
+			wantErr: "400: InvalidParameter: properties.workerProfiles: There should be at least one worker profile.",
 		},
 		{
-			name: "multiple workerProfiles invalid",
+			name: "multiple workerProfiles without spot VM options invalid",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.WorkerProfiles = []WorkerProfile{{}, {}}
+				wp := oc.Properties.WorkerProfiles[0]
+				wp.Name = "spot"
+				oc.Properties.WorkerProfiles = append(oc.Properties.WorkerProfiles, wp)
 			},
-			wantErr: "400: InvalidParameter: properties.workerProfiles: There should be exactly one worker profile.",
+			wantErr: "400: InvalidParameter: properties.workerProfiles['spot'].spotVMOptions: The provided worker profile is invalid: additional worker profiles must use Spot VMs.",
 		},
 		{
 			name: "workerProfileStatus nonNil",
@@ -582,6 +584,92 @@ func TestOpenShiftClusterStaticValidateNetworkProfile(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "public IP prefix valid",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PublicIPPrefixID = fmt.Sprintf("/subscriptions/%s/resourceGroups/network/providers/Microsoft.Network/publicIPPrefixes/prefix", subscriptionID)
+			},
+		},
+		{
+			name: "public IP prefix invalid",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PublicIPPrefixID = "invalid"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.publicIpPrefixId: The provided public IP prefix 'invalid' is invalid.",
+		},
+		{
+			name: "public IP prefix in another subscription",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PublicIPPrefixID = "/subscriptions/7a3036d1-60a1-4605-8a41-44955e050804/resourceGroups/network/providers/Microsoft.Network/publicIPPrefixes/prefix"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.publicIpPrefixId: The provided public IP prefix '/subscriptions/7a3036d1-60a1-4605-8a41-44955e050804/resourceGroups/network/providers/Microsoft.Network/publicIPPrefixes/prefix' is invalid: must be in same subscription as cluster.",
+		},
+		{
+			name: "public IP prefix with private visibility",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PublicIPPrefixID = fmt.Sprintf("/subscriptions/%s/resourceGroups/network/providers/Microsoft.Network/publicIPPrefixes/prefix", subscriptionID)
+				oc.Properties.IngressProfiles[0].Visibility = VisibilityPrivate
+				oc.Properties.APIServerProfile.Visibility = VisibilityPrivate
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.publicIpPrefixId: The provided public IP prefix '/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/network/providers/Microsoft.Network/publicIPPrefixes/prefix' is invalid: cannot use a public IP prefix if both API Server Visibility and Ingress Visibility are private.",
+		},
+		{
+			name: "dual-stack valid",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PodCIDRIPv6 = "fd01::/48"
+				oc.Properties.NetworkProfile.ServiceCIDRIPv6 = "fd02::/112"
+			},
+		},
+		{
+			name: "dual-stack without IPv6 service CIDR",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PodCIDRIPv6 = "fd01::/48"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.serviceCidrIpv6: The provided IPv6 service CIDR '' is invalid: 'invalid CIDR address: '.",
+		},
+		{
+			name: "dual-stack IPv4 pod CIDR",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PodCIDRIPv6 = "10.0.0.0/16"
+				oc.Properties.NetworkProfile.ServiceCIDRIPv6 = "fd02::/112"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.podCidrIpv6: The provided IPv6 pod CIDR '10.0.0.0/16' is invalid: must be IPv6.",
+		},
+		{
+			name: "dual-stack pod CIDR too small",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PodCIDRIPv6 = "fd01::/56"
+				oc.Properties.NetworkProfile.ServiceCIDRIPv6 = "fd02::/112"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.podCidrIpv6: The provided IPv6 pod CIDR 'fd01::/56' is invalid: must be /48 or larger.",
+		},
+		{
+			name: "dual-stack service CIDR too large",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PodCIDRIPv6 = "fd01::/48"
+				oc.Properties.NetworkProfile.ServiceCIDRIPv6 = "fd02::/104"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.serviceCidrIpv6: The provided IPv6 service CIDR 'fd02::/104' is invalid: must be between /108 and /112.",
+		},
+		{
+			name: "dual-stack overlapping CIDRs",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PodCIDRIPv6 = "fd01::/48"
+				oc.Properties.NetworkProfile.ServiceCIDRIPv6 = "fd01::/112"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.serviceCidrIpv6: The provided IPv6 service CIDR 'fd01::/112' is invalid: must not overlap with the IPv6 pod CIDR 'fd01::/48'.",
+		},
+		{
+			name: "dual-stack with UserDefinedRouting",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PodCIDRIPv6 = "fd01::/48"
+				oc.Properties.NetworkProfile.ServiceCIDRIPv6 = "fd02::/112"
+				oc.Properties.NetworkProfile.OutboundType = OutboundTypeUserDefinedRouting
+				oc.Properties.IngressProfiles[0].Visibility = VisibilityPrivate
+				oc.Properties.APIServerProfile.Visibility = VisibilityPrivate
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.outboundType: The provided outboundType 'UserDefinedRouting' is invalid: cannot use UserDefinedRouting on a dual-stack cluster.",
+		},
 	}
 
 	runTests(t, testModeCreate, tests)
@@ -725,9 +813,30 @@ func TestOpenShiftClusterStaticValidateMasterProfile(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: properties.masterProfile.encryptionAtHost: The provided value '' is invalid.",
 		},
+		{
+			name: "disk type invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.DiskType = "Standard_LRS"
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.diskType: The provided disk type 'Standard_LRS' is invalid.",
+		},
+		{
+			name: "accelerated networking invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.AcceleratedNetworking = "Banana"
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.acceleratedNetworking: The provided value 'Banana' is invalid.",
+		},
 	}
 
 	createTests := []*validateTest{
+		{
+			name: "disk type and accelerated networking valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.DiskType = DiskTypeUltraSSDLRS
+				oc.Properties.MasterProfile.AcceleratedNetworking = AcceleratedNetworkingEnabled
+			},
+		},
 		{
 			name: "disk encryption set is valid",
 			modify: func(oc *OpenShiftCluster) {
@@ -841,6 +950,127 @@ func TestOpenShiftClusterStaticValidateWorkerProfile(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].encryptionAtHost: The provided value '' is invalid.",
 		},
+		{
+			name: "disk type and accelerated networking valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].DiskType = DiskTypePremiumLRS
+				oc.Properties.WorkerProfiles[0].AcceleratedNetworking = AcceleratedNetworkingDisabled
+			},
+		},
+		{
+			name: "disk type invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].DiskType = "Standard_LRS"
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].diskType: The provided disk type 'Standard_LRS' is invalid.",
+		},
+		{
+			name: "accelerated networking invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].AcceleratedNetworking = "Banana"
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].acceleratedNetworking: The provided value 'Banana' is invalid.",
+		},
+	}
+
+	// We do not perform this validation on update
+	runTests(t, testModeCreate, tests)
+}
+
+func TestOpenShiftClusterStaticValidateSpotWorkerProfile(t *testing.T) {
+	addSpotProfile := func(oc *OpenShiftCluster, name string, count int, o *SpotVMOptions) {
+		wp := oc.Properties.WorkerProfiles[0]
+		wp.Name = name
+		wp.Count = count
+		wp.SpotVMOptions = o
+		oc.Properties.WorkerProfiles = append(oc.Properties.WorkerProfiles, wp)
+	}
+
+	tests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				addSpotProfile(oc, "spot", 1, &SpotVMOptions{})
+			},
+		},
+		{
+			name: "valid with max price and eviction policy",
+			modify: func(oc *OpenShiftCluster) {
+				addSpotProfile(oc, "spot", 3, &SpotVMOptions{
+					MaxPrice:       to.Float64Ptr(0.05),
+					EvictionPolicy: SpotEvictionPolicyDelete,
+				})
+			},
+		},
+		{
+			name: "valid with max price of the on-demand price",
+			modify: func(oc *OpenShiftCluster) {
+				addSpotProfile(oc, "spot", 3, &SpotVMOptions{
+					MaxPrice: to.Float64Ptr(-1),
+				})
+			},
+		},
+		{
+			name: "first worker profile spot",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].SpotVMOptions = &SpotVMOptions{}
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].spotVMOptions: The provided worker profile is invalid: the first worker profile must not use Spot VMs.",
+		},
+		{
+			name: "name worker",
+			modify: func(oc *OpenShiftCluster) {
+				addSpotProfile(oc, "worker", 1, &SpotVMOptions{})
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].name: The provided worker name 'worker' is not unique.",
+		},
+		{
+			name: "name invalid",
+			modify: func(oc *OpenShiftCluster) {
+				addSpotProfile(oc, "Spot_Pool", 1, &SpotVMOptions{})
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['Spot_Pool'].name: The provided worker name 'Spot_Pool' is invalid.",
+		},
+		{
+			name: "name not unique",
+			modify: func(oc *OpenShiftCluster) {
+				addSpotProfile(oc, "spot", 1, &SpotVMOptions{})
+				addSpotProfile(oc, "spot", 1, &SpotVMOptions{})
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['spot'].name: The provided worker name 'spot' is not unique.",
+		},
+		{
+			name: "count too small",
+			modify: func(oc *OpenShiftCluster) {
+				addSpotProfile(oc, "spot", 0, &SpotVMOptions{})
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['spot'].count: The provided worker count '0' is invalid.",
+		},
+		{
+			name: "total count too big",
+			modify: func(oc *OpenShiftCluster) {
+				addSpotProfile(oc, "spot", 48, &SpotVMOptions{})
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles: The provided total worker count '51' is invalid.",
+		},
+		{
+			name: "max price invalid",
+			modify: func(oc *OpenShiftCluster) {
+				addSpotProfile(oc, "spot", 1, &SpotVMOptions{
+					MaxPrice: to.Float64Ptr(0),
+				})
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['spot'].spotVMOptions.maxPrice: The provided max price '0' is invalid: must be -1 or greater than 0.",
+		},
+		{
+			name: "eviction policy invalid",
+			modify: func(oc *OpenShiftCluster) {
+				addSpotProfile(oc, "spot", 1, &SpotVMOptions{
+					EvictionPolicy: "Deallocate",
+				})
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['spot'].spotVMOptions.evictionPolicy: The provided eviction policy 'Deallocate' is invalid.",
+		},
 	}
 
 	// We do not perform this validation on update
@@ -902,6 +1132,177 @@ func TestOpenShiftClusterStaticValidateAPIServerProfile(t *testing.T) {
 	runTests(t, testModeUpdate, commonTests)
 }
 
+func TestOpenShiftClusterStaticValidateDiagnosticSettingsProfile(t *testing.T) {
+	tests := []*validateTest{
+		{
+			name: "valid",
+		},
+		{
+			name: "workspace valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DiagnosticSettingsProfile = &DiagnosticSettingsProfile{
+					WorkspaceResourceID: fmt.Sprintf("/subscriptions/%s/resourceGroups/logs/providers/Microsoft.OperationalInsights/workspaces/workspace", subscriptionID),
+				}
+			},
+		},
+		{
+			name: "workspace invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DiagnosticSettingsProfile = &DiagnosticSettingsProfile{
+					WorkspaceResourceID: "invalid",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.diagnosticSettingsProfile.workspaceResourceId: The provided Log Analytics workspace 'invalid' is invalid.",
+		},
+		{
+			name: "workspace missing",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DiagnosticSettingsProfile = &DiagnosticSettingsProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.diagnosticSettingsProfile.workspaceResourceId: The provided Log Analytics workspace '' is invalid.",
+		},
+		{
+			name: "workspace in another subscription",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DiagnosticSettingsProfile = &DiagnosticSettingsProfile{
+					WorkspaceResourceID: "/subscriptions/7a3036d1-60a1-4605-8a41-44955e050804/resourceGroups/logs/providers/Microsoft.OperationalInsights/workspaces/workspace",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.diagnosticSettingsProfile.workspaceResourceId: The provided Log Analytics workspace '/subscriptions/7a3036d1-60a1-4605-8a41-44955e050804/resourceGroups/logs/providers/Microsoft.OperationalInsights/workspaces/workspace' is invalid: must be in same subscription as cluster.",
+		},
+	}
+
+	runTests(t, testModeCreate, tests)
+	runTests(t, testModeUpdate, tests)
+}
+
+func TestOpenShiftClusterStaticValidateMaxNodesProfile(t *testing.T) {
+	tests := []*validateTest{
+		{
+			name: "valid",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.MaxNodesProfile = &MaxNodesProfile{MaxNodes: 100}
+			},
+		},
+		{
+			name: "valid with large control plane",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.MaxNodesProfile = &MaxNodesProfile{MaxNodes: 250}
+				oc.Properties.MasterProfile.VMSize = "Standard_D16s_v3"
+			},
+		},
+		{
+			name: "maxNodes too small",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.MaxNodesProfile = &MaxNodesProfile{MaxNodes: 50}
+			},
+			wantErr: "400: InvalidParameter: properties.maxNodesProfile.maxNodes: The provided maxNodes 50 is invalid: maxNodes must be in the range of 51 to 250 (inclusive).",
+		},
+		{
+			name: "maxNodes too big",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.MaxNodesProfile = &MaxNodesProfile{MaxNodes: 251}
+			},
+			wantErr: "400: InvalidParameter: properties.maxNodesProfile.maxNodes: The provided maxNodes 251 is invalid: maxNodes must be in the range of 51 to 250 (inclusive).",
+		},
+		{
+			name: "pod CIDR too small",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.MaxNodesProfile = &MaxNodesProfile{MaxNodes: 100}
+				oc.Properties.NetworkProfile.PodCIDR = "10.128.0.0/18"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.podCidr: The provided pod CIDR '10.128.0.0/18' is invalid: must be large enough to allocate a /23 to each of 103 nodes.",
+		},
+		{
+			name: "service CIDR too small",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.MaxNodesProfile = &MaxNodesProfile{MaxNodes: 100}
+				oc.Properties.NetworkProfile.ServiceCIDR = "172.30.0.0/22"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.serviceCidr: The provided service CIDR '172.30.0.0/22' is invalid: must be /20 or larger for large clusters.",
+		},
+		{
+			name: "master VM size too small",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.MaxNodesProfile = &MaxNodesProfile{MaxNodes: 121}
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.vmSize: The provided master VM size 'Standard_D8s_v3' is invalid: clusters of more than 120 worker nodes require master VMs with at least 16 cores.",
+		},
+	}
+
+	runTests(t, testModeCreate, tests)
+	runTests(t, testModeUpdate, tests)
+
+	runTests(t, testModeCreate, []*validateTest{
+		{
+			name: "worker count within maxNodes",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaxNodesProfile = &MaxNodesProfile{MaxNodes: 100}
+				oc.Properties.WorkerProfiles[0].Count = 100
+			},
+		},
+		{
+			name: "worker count above maxNodes",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaxNodesProfile = &MaxNodesProfile{MaxNodes: 100}
+				oc.Properties.WorkerProfiles[0].Count = 101
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].count: The provided worker count '101' is invalid.",
+		},
+	})
+}
+
+func TestOpenShiftClusterStaticValidateZonePlacement(t *testing.T) {
+	tests := []*validateTest{
+		{
+			name: "valid with zones",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.ZonePlacement = ZonePlacementAutomatic
+				oc.Properties.MasterProfile.Zones = []string{"1", "2", "3"}
+				oc.Properties.WorkerProfiles[0].Zones = []string{"2"}
+			},
+		},
+		{
+			name: "valid non-zonal",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.ZonePlacement = ZonePlacementNonZonal
+			},
+		},
+		{
+			name: "zone placement invalid",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.ZonePlacement = "invalid"
+			},
+			wantErr: "400: InvalidParameter: properties.zonePlacement: The provided zone placement 'invalid' is invalid.",
+		},
+		{
+			name: "master zone invalid",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.Zones = []string{"1", "4"}
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.zones: The provided zone '4' is invalid.",
+		},
+		{
+			name: "worker zone not unique",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].Zones = []string{"1", "1"}
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].zones: The provided zone '1' is not unique.",
+		},
+		{
+			name: "zones with non-zonal placement",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.ZonePlacement = ZonePlacementNonZonal
+				oc.Properties.WorkerProfiles[0].Zones = []string{"1"}
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].zones: The provided zones are invalid: zones must not be set when the zone placement is 'NonZonal'.",
+		},
+	}
+
+	runTests(t, testModeCreate, tests)
+	runTests(t, testModeUpdate, tests)
+}
+
 func TestOpenShiftClusterStaticValidateIngressProfile(t *testing.T) {
 	tests := []*validateTest{
 		{
@@ -1055,6 +1456,13 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			modify:  func(oc *OpenShiftCluster) { oc.Properties.NetworkProfile.ServiceCIDR = "0.0.0.0/0" },
 			wantErr: "400: PropertyChangeNotAllowed: properties.networkProfile.serviceCidr: Changing property 'properties.networkProfile.serviceCidr' is not allowed.",
 		},
+		{
+			name: "publicIpPrefixId change",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PublicIPPrefixID = fmt.Sprintf("/subscriptions/%s/resourceGroups/network/providers/Microsoft.Network/publicIPPrefixes/prefix", subscriptionID)
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.networkProfile.publicIpPrefixId: Changing property 'properties.networkProfile.publicIpPrefixId' is not allowed.",
+		},
 		{
 			name: "outboundType change",
 			modify: func(oc *OpenShiftCluster) {
@@ -1075,9 +1483,28 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			wantErr: "400: PropertyChangeNotAllowed: properties.workerProfiles['new-name'].name: Changing property 'properties.workerProfiles['new-name'].name' is not allowed.",
 		},
 		{
-			name:    "worker vmSize change",
-			modify:  func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].VMSize = "Standard_D8s_v3" },
-			wantErr: "400: PropertyChangeNotAllowed: properties.workerProfiles['worker'].vmSize: Changing property 'properties.workerProfiles['worker'].vmSize' is not allowed.",
+			name:   "valid worker vmSize change",
+			modify: func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].VMSize = "Standard_D8s_v3" },
+		},
+		{
+			name:    "invalid worker vmSize change",
+			modify:  func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].VMSize = "Standard_D2s_v3" },
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].vmSize: The provided worker VM size 'Standard_D2s_v3' is invalid.",
+		},
+		{
+			name:                "worker vmSize change to non-D2s_v3 when required",
+			modify:              func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].VMSize = "Standard_D8s_v3" },
+			requireD2sV3Workers: true,
+			wantErr:             "400: InvalidParameter: properties.workerProfiles['worker'].vmSize: The provided worker VM size 'Standard_D8s_v3' is invalid.",
+		},
+		{
+			name:   "valid worker maxSurge change",
+			modify: func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].MaxSurge = 2 },
+		},
+		{
+			name:    "invalid worker maxSurge change",
+			modify:  func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].MaxSurge = -1 },
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].maxSurge: The provided max surge '-1' is invalid.",
 		},
 		{
 			name:    "worker diskSizeGB change",
@@ -1096,6 +1523,16 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			modify:  func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].Count++ },
 			wantErr: "400: PropertyChangeNotAllowed: properties.workerProfiles['worker'].count: Changing property 'properties.workerProfiles['worker'].count' is not allowed.",
 		},
+		{
+			name:    "workerProfiles zones change",
+			modify:  func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].Zones = []string{"1"} },
+			wantErr: "400: PropertyChangeNotAllowed: properties.workerProfiles['worker'].zones: Changing property 'properties.workerProfiles['worker'].zones' is not allowed.",
+		},
+		{
+			name:    "zonePlacement change",
+			modify:  func(oc *OpenShiftCluster) { oc.Properties.ZonePlacement = ZonePlacementNonZonal },
+			wantErr: "400: PropertyChangeNotAllowed: properties.zonePlacement: Changing property 'properties.zonePlacement' is not allowed.",
+		},
 		{
 			name: "number of workerProfiles changes",
 			modify: func(oc *OpenShiftCluster) {
@@ -1157,6 +1594,21 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			},
 			wantErr: "400: PropertyChangeNotAllowed: properties.networkProfile.loadBalancerProfile.effectiveOutboundIps: Changing property 'properties.networkProfile.loadBalancerProfile.effectiveOutboundIps' is not allowed.",
 		},
+		{
+			name: "maxNodesProfile change",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaxNodesProfile = &MaxNodesProfile{MaxNodes: 100}
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.maxNodesProfile: Changing property 'properties.maxNodesProfile' is not allowed.",
+		},
+		{
+			name: "update DiagnosticSettingsProfile",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DiagnosticSettingsProfile = &DiagnosticSettingsProfile{
+					WorkspaceResourceID: fmt.Sprintf("/subscriptions/%s/resourceGroups/logs/providers/Microsoft.OperationalInsights/workspaces/workspace", subscriptionID),
+				}
+			},
+		},
 	}
 
 	runTests(t, testModeUpdate, tests)
@@ -50,5 +50,6 @@ func init() {
 		SyncIdentityProviderConverter: syncIdentityProviderConverter{},
 		SecretConverter:               secretConverter{},
 		ClusterManagerStaticValidator: clusterManagerStaticValidator{},
+		StrictDecoding:                true,
 	}
 }
@@ -6,9 +6,9 @@ import (
 	"fmt"
 )
 
-const _InstallPhaseName = "InstallPhaseBootstrapInstallPhaseRemoveBootstrap"
+const _InstallPhaseName = "InstallPhaseBootstrapInstallPhaseRemoveBootstrapInstallPhaseReserve"
 
-var _InstallPhaseIndex = [...]uint8{0, 21, 48}
+var _InstallPhaseIndex = [...]uint8{0, 21, 48, 67}
 
 func (i InstallPhase) String() string {
 	if i < 0 || i >= InstallPhase(len(_InstallPhaseIndex)-1) {
@@ -17,11 +17,12 @@ func (i InstallPhase) String() string {
 	return _InstallPhaseName[_InstallPhaseIndex[i]:_InstallPhaseIndex[i+1]]
 }
 
-var _InstallPhaseValues = []InstallPhase{0, 1}
+var _InstallPhaseValues = []InstallPhase{0, 1, 2}
 
 var _InstallPhaseNameToValueMap = map[string]InstallPhase{
 	_InstallPhaseName[0:21]:  0,
 	_InstallPhaseName[21:48]: 1,
+	_InstallPhaseName[48:67]: 2,
 }
 
 // InstallPhaseString retrieves an enum value from the enum constants string name.
@@ -0,0 +1,161 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/acrtoken"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const (
+	acrTokenCheckInterval = 24 * time.Hour
+	acrTokenLeaseInterval = time.Hour
+	acrTokenLeaseID       = "acrtokenrotation"
+
+	// acrTokenMaxRotationsPerRun bounds the number of admin updates queued in
+	// one day, so that a backlog of expiring tokens is worked off gradually
+	acrTokenMaxRotationsPerRun = 50
+)
+
+type acrTokenBackend struct {
+	*backend
+
+	now func() time.Time
+}
+
+func newACRTokenBackend(b *backend) *acrTokenBackend {
+	return &acrTokenBackend{
+		backend: b,
+		now:     time.Now,
+	}
+}
+
+// run checks the expiry of every cluster's ACR token password once per
+// acrTokenCheckInterval until stop is closed, and queues an ACRTokenRotation
+// admin update for clusters whose password is due for rotation.  As with
+// billing snapshots, a lease ensures only one backend replica does this each
+// day.
+func (ab *acrTokenBackend) run(ctx context.Context, stop <-chan struct{}) {
+	defer recover.Panic(ab.baseLog)
+
+	// tokens are not rotated in local development or CI
+	if ab.env.IsLocalDevelopmentMode() || ab.env.IsCI() {
+		return
+	}
+
+	t := time.NewTicker(acrTokenLeaseInterval)
+	defer t.Stop()
+
+	for {
+		err := ab.runOnce(ctx)
+		if err != nil {
+			ab.baseLog.Error(err)
+		}
+
+		select {
+		case <-t.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (ab *acrTokenBackend) runOnce(ctx context.Context) error {
+	ok, err := ab.dbMonitors.AcquireLease(ctx, acrTokenLeaseID, acrTokenCheckInterval)
+	if err != nil || !ok {
+		return err
+	}
+
+	return ab.checkAll(ctx)
+}
+
+// checkAll walks the OpenShiftClusters change feed from the beginning, emits
+// the time left before each expiring ACR token password expires, and queues
+// rotations for them.
+func (ab *acrTokenBackend) checkAll(ctx context.Context) error {
+	now := ab.now()
+	i := ab.dbOpenShiftClusters.ChangeFeed()
+
+	var due, queued int
+	for {
+		docs, err := i.Next(ctx, -1)
+		if err != nil {
+			return err
+		}
+		if docs == nil {
+			break
+		}
+
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			if doc.SoftDeleted != nil {
+				continue
+			}
+
+			log := ab.baseLog.WithField("resource", doc.OpenShiftCluster.ID)
+
+			rp, err := acrtoken.GetRegistryProfileFromCluster(ab.env, doc.OpenShiftCluster)
+			if err != nil {
+				return err
+			}
+			if rp == nil || !acrtoken.RotationDue(rp, now) {
+				continue
+			}
+
+			due++
+
+			if rp.Expiry != nil {
+				ab.m.EmitGauge("backend.acrtoken.expiry.hours", int64(rp.Expiry.Sub(now).Hours()), map[string]string{
+					"resourceId": doc.OpenShiftCluster.ID,
+				})
+			}
+
+			if queued >= acrTokenMaxRotationsPerRun {
+				continue
+			}
+
+			ok, err := ab.queueRotation(ctx, doc)
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+			if ok {
+				log.Info("queued ACR token rotation")
+				queued++
+			}
+		}
+	}
+
+	ab.m.EmitGauge("backend.acrtoken.rotationdue.count", int64(due), nil)
+	ab.m.EmitGauge("backend.acrtoken.rotationqueued.count", int64(queued), nil)
+
+	return nil
+}
+
+// queueRotation starts an ACRTokenRotation admin update on a cluster which is
+// not already busy.  It returns false if the cluster was busy.
+func (ab *acrTokenBackend) queueRotation(ctx context.Context, doc *api.OpenShiftClusterDocument) (bool, error) {
+	var busy bool
+	_, err := ab.dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		if doc.OpenShiftCluster.Properties.ProvisioningState != api.ProvisioningStateSucceeded {
+			busy = true
+			return fmt.Errorf("cluster is in provisioningState %q", doc.OpenShiftCluster.Properties.ProvisioningState)
+		}
+
+		doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskRotateACRToken
+		api.SetAdminUpdateProvisioningState(doc)
+		doc.AsyncOperationID = ""
+
+		return nil
+	})
+	if busy {
+		return false, nil
+	}
+
+	return err == nil, err
+}
@@ -0,0 +1,148 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestACRTokenRunOnce(t *testing.T) {
+	ctx := context.Background()
+	log := logrus.NewEntry(logrus.StandardLogger())
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	s, err := testdatabase.NewServer(log, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts, dbc := s.NewTLSServer()
+	defer ts.Close()
+
+	dbOpenShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, "ARO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dbMonitors, err := database.NewMonitors(ctx, dbc, "ARO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resourceID := func(i int) string {
+		return fmt.Sprintf("/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster%d", i)
+	}
+
+	expiry := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	for i, tt := range []struct {
+		state           api.ProvisioningState
+		registryProfile *api.RegistryProfile
+	}{
+		{
+			// expires soon: queued
+			state: api.ProvisioningStateSucceeded,
+			registryProfile: &api.RegistryProfile{
+				Name:   "arointsvc.azurecr.io",
+				Expiry: expiry(7 * 24 * time.Hour),
+			},
+		},
+		{
+			// recently rotated: left alone
+			state: api.ProvisioningStateSucceeded,
+			registryProfile: &api.RegistryProfile{
+				Name:   "arointsvc.azurecr.io",
+				Expiry: expiry(80 * 24 * time.Hour),
+			},
+		},
+		{
+			// no recorded expiry: queued
+			state: api.ProvisioningStateSucceeded,
+			registryProfile: &api.RegistryProfile{
+				Name: "arointsvc.azurecr.io",
+			},
+		},
+		{
+			// busy: left alone
+			state: api.ProvisioningStateUpdating,
+			registryProfile: &api.RegistryProfile{
+				Name:   "arointsvc.azurecr.io",
+				Expiry: expiry(-time.Hour),
+			},
+		},
+		{
+			// another registry: left alone
+			state: api.ProvisioningStateSucceeded,
+			registryProfile: &api.RegistryProfile{
+				Name: "other.azurecr.io",
+			},
+		},
+	} {
+		_, err := dbOpenShiftClusters.Create(ctx, &api.OpenShiftClusterDocument{
+			ID:  dbOpenShiftClusters.NewUUID(),
+			Key: strings.ToLower(resourceID(i)),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: resourceID(i),
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: tt.state,
+					RegistryProfiles:  []*api.RegistryProfile{tt.registryProfile},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	_env := mock_env.NewMockInterface(controller)
+	_env.EXPECT().ACRResourceID().AnyTimes().Return("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/global/providers/Microsoft.ContainerRegistry/registries/arointsvc")
+	_env.EXPECT().Environment().AnyTimes().Return(&azureclient.PublicCloud)
+
+	ab := newACRTokenBackend(&backend{
+		baseLog:             log,
+		env:                 _env,
+		dbMonitors:          dbMonitors,
+		dbOpenShiftClusters: dbOpenShiftClusters,
+		m:                   &noop.Noop{},
+	})
+	ab.now = func() time.Time { return now }
+
+	err = ab.runOnce(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, wantQueued := range []bool{true, false, true, false, false} {
+		doc, err := dbOpenShiftClusters.Get(ctx, strings.ToLower(resourceID(i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		queued := doc.OpenShiftCluster.Properties.ProvisioningState == api.ProvisioningStateAdminUpdating &&
+			doc.OpenShiftCluster.Properties.MaintenanceTask == api.MaintenanceTaskRotateACRToken
+		if queued != wantQueued {
+			t.Errorf("cluster%d: got queued %v, wanted %v", i, queued, wantQueued)
+		}
+	}
+}
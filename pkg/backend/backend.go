@@ -16,12 +16,14 @@ import (
 	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/util/billing"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	"github.com/Azure/ARO-RP/pkg/util/eventgrid"
+	"github.com/Azure/ARO-RP/pkg/util/marketplace"
+	"github.com/Azure/ARO-RP/pkg/util/ocm"
 	"github.com/Azure/ARO-RP/pkg/util/recover"
 )
 
 const (
-	maxWorkers      = 100
-	maxDequeueCount = 5
+	maxWorkers = 100
 )
 
 type backend struct {
@@ -31,6 +33,7 @@ type backend struct {
 	dbAsyncOperations   database.AsyncOperations
 	dbBilling           database.Billing
 	dbGateway           database.Gateway
+	dbMonitors          database.Monitors
 	dbOpenShiftClusters database.OpenShiftClusters
 	dbSubscriptions     database.Subscriptions
 	dbOpenShiftVersions database.OpenShiftVersions
@@ -38,6 +41,9 @@ type backend struct {
 	aead    encryption.AEAD
 	m       metrics.Emitter
 	billing billing.Manager
+	meter   billing.Meter
+	ocm     ocm.Client
+	eg      eventgrid.Publisher
 
 	mu       sync.Mutex
 	cond     *sync.Cond
@@ -46,6 +52,11 @@ type backend struct {
 
 	ocb *openShiftClusterBackend
 	sb  *subscriptionBackend
+	bb  *billingBackend
+	mb  *meteringBackend
+	sdb *softDeleteBackend
+	ab  *acrTokenBackend
+	ob  *ocmBackend
 }
 
 // Runnable represents a runnable object
@@ -54,23 +65,54 @@ type Runnable interface {
 }
 
 // NewBackend returns a new runnable backend
-func NewBackend(ctx context.Context, log *logrus.Entry, env env.Interface, dbAsyncOperations database.AsyncOperations, dbBilling database.Billing, dbGateway database.Gateway, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, dbOpenShiftVersions database.OpenShiftVersions, aead encryption.AEAD, m metrics.Emitter) (Runnable, error) {
-	b, err := newBackend(ctx, log, env, dbAsyncOperations, dbBilling, dbGateway, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, aead, m)
+func NewBackend(ctx context.Context, log *logrus.Entry, env env.Interface, dbAsyncOperations database.AsyncOperations, dbBilling database.Billing, dbGateway database.Gateway, dbMonitors database.Monitors, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, dbOpenShiftVersions database.OpenShiftVersions, aead encryption.AEAD, m metrics.Emitter) (Runnable, error) {
+	b, err := newBackend(ctx, log, env, dbAsyncOperations, dbBilling, dbGateway, dbMonitors, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, aead, m)
 	if err != nil {
 		return nil, err
 	}
 
-	b.ocb = newOpenShiftClusterBackend(b)
+	b.ocb, err = newOpenShiftClusterBackend(b)
+	if err != nil {
+		return nil, err
+	}
 	b.sb = newSubscriptionBackend(b)
+	b.bb = newBillingBackend(b)
+	b.mb = newMeteringBackend(b)
+	b.ab = newACRTokenBackend(b)
+	b.ob = newOCMBackend(b)
+	b.sdb, err = newSoftDeleteBackend(b)
+	if err != nil {
+		return nil, err
+	}
+
 	return b, nil
 }
 
-func newBackend(ctx context.Context, log *logrus.Entry, env env.Interface, dbAsyncOperations database.AsyncOperations, dbBilling database.Billing, dbGateway database.Gateway, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, dbOpenShiftVersions database.OpenShiftVersions, aead encryption.AEAD, m metrics.Emitter) (*backend, error) {
+func newBackend(ctx context.Context, log *logrus.Entry, env env.Interface, dbAsyncOperations database.AsyncOperations, dbBilling database.Billing, dbGateway database.Gateway, dbMonitors database.Monitors, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, dbOpenShiftVersions database.OpenShiftVersions, aead encryption.AEAD, m metrics.Emitter) (*backend, error) {
+	var meter billing.Meter
+	meteringClient, err := marketplace.NewMeteringClientFromEnvironment(env)
+	if err != nil {
+		return nil, err
+	}
+	if meteringClient != nil {
+		meter = billing.NewMeter(log, dbBilling, meteringClient)
+	}
+
 	billing, err := billing.NewManager(env, dbBilling, dbSubscriptions, log)
 	if err != nil {
 		return nil, err
 	}
 
+	ocm, err := ocm.NewClientFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	eg, err := eventgrid.NewPublisherFromEnvironment(env)
+	if err != nil {
+		return nil, err
+	}
+
 	b := &backend{
 		baseLog: log,
 		env:     env,
@@ -78,11 +120,15 @@ func newBackend(ctx context.Context, log *logrus.Entry, env env.Interface, dbAsy
 		dbAsyncOperations:   dbAsyncOperations,
 		dbBilling:           dbBilling,
 		dbGateway:           dbGateway,
+		dbMonitors:          dbMonitors,
 		dbOpenShiftClusters: dbOpenShiftClusters,
 		dbSubscriptions:     dbSubscriptions,
 		dbOpenShiftVersions: dbOpenShiftVersions,
 
 		billing: billing,
+		meter:   meter,
+		ocm:     ocm,
+		eg:      eg,
 		aead:    aead,
 		m:       m,
 	}
@@ -108,6 +154,12 @@ func (b *backend) Run(ctx context.Context, stop <-chan struct{}, done chan<- str
 		}()
 	}
 
+	go b.bb.run(ctx, stop)
+	go b.mb.run(ctx, stop)
+	go b.sdb.run(ctx, stop)
+	go b.ab.run(ctx, stop)
+	go b.ob.run(ctx, stop)
+
 	for {
 		b.mu.Lock()
 		for atomic.LoadInt32(&b.workers) >= maxWorkers && !b.stopping.Load().(bool) {
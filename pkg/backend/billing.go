@@ -0,0 +1,102 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const (
+	billingSnapshotInterval = 24 * time.Hour
+	billingLeaseInterval    = time.Hour
+	billingLeaseID          = "billingsnapshot"
+)
+
+type billingBackend struct {
+	*backend
+}
+
+func newBillingBackend(b *backend) *billingBackend {
+	return &billingBackend{backend: b}
+}
+
+// run refreshes the usage snapshot of every cluster's billing document once
+// per billingSnapshotInterval until stop is closed.  Every backend replica
+// tries to take the snapshot lease once per billingLeaseInterval; the lease
+// expires after billingSnapshotInterval, so only one replica walks the change
+// feed each day.
+func (bb *billingBackend) run(ctx context.Context, stop <-chan struct{}) {
+	defer recover.Panic(bb.baseLog)
+
+	t := time.NewTicker(billingLeaseInterval)
+	defer t.Stop()
+
+	for {
+		err := bb.runOnce(ctx)
+		if err != nil {
+			bb.baseLog.Error(err)
+		}
+
+		select {
+		case <-t.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (bb *billingBackend) runOnce(ctx context.Context) error {
+	ok, err := bb.dbMonitors.AcquireLease(ctx, billingLeaseID, billingSnapshotInterval)
+	if err != nil || !ok {
+		return err
+	}
+
+	return bb.snapshotAll(ctx)
+}
+
+// snapshotAll walks the OpenShiftClusters change feed from the beginning,
+// which yields the latest version of every cluster document, and records a
+// billing snapshot for each cluster which is not being created or deleted.
+// The tombstones of deleted clusters are skipped.
+func (bb *billingBackend) snapshotAll(ctx context.Context) error {
+	i := bb.dbOpenShiftClusters.ChangeFeed()
+
+	var count int
+	for {
+		docs, err := i.Next(ctx, -1)
+		if err != nil {
+			return err
+		}
+		if docs == nil {
+			break
+		}
+
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			if doc.SoftDeleted != nil {
+				continue
+			}
+
+			switch doc.OpenShiftCluster.Properties.ProvisioningState {
+			case api.ProvisioningStateCreating, api.ProvisioningStateDeleting:
+				continue
+			}
+
+			err = bb.billing.Snapshot(ctx, doc)
+			if err != nil {
+				bb.baseLog.WithField("resource", doc.OpenShiftCluster.ID).Error(err)
+				continue
+			}
+
+			count++
+		}
+	}
+
+	bb.m.EmitGauge("backend.billing.snapshots.count", int64(count), nil)
+
+	return nil
+}
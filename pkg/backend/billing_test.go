@@ -0,0 +1,119 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_billing "github.com/Azure/ARO-RP/pkg/util/mocks/billing"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestBillingRunOnce(t *testing.T) {
+	ctx := context.Background()
+	log := logrus.NewEntry(logrus.StandardLogger())
+
+	s, err := testdatabase.NewServer(log, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts, dbc := s.NewTLSServer()
+	defer ts.Close()
+
+	dbOpenShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, "ARO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dbMonitors, err := database.NewMonitors(ctx, dbc, "ARO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resourceID := func(i int) string {
+		return fmt.Sprintf("/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster%d", i)
+	}
+
+	for i, state := range []api.ProvisioningState{
+		api.ProvisioningStateSucceeded,
+		api.ProvisioningStateCreating,
+		api.ProvisioningStateDeleting,
+		api.ProvisioningStateFailed,
+		api.ProvisioningStateSucceeded,
+		api.ProvisioningStateSucceeded,
+	} {
+		doc, err := dbOpenShiftClusters.Create(ctx, &api.OpenShiftClusterDocument{
+			ID:  dbOpenShiftClusters.NewUUID(),
+			Key: strings.ToLower(resourceID(i)),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: resourceID(i),
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: state,
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// cluster5 is deleted, leaving a tombstone
+		if i == 5 {
+			err = dbOpenShiftClusters.SoftDelete(ctx, doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	var snapshotted []string
+	billing := mock_billing.NewMockManager(controller)
+	billing.EXPECT().Snapshot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+		snapshotted = append(snapshotted, doc.OpenShiftCluster.ID)
+		if doc.OpenShiftCluster.ID == resourceID(3) {
+			return errors.New("random error")
+		}
+		return nil
+	}).Times(3)
+
+	bb := newBillingBackend(&backend{
+		baseLog:             log,
+		dbMonitors:          dbMonitors,
+		dbOpenShiftClusters: dbOpenShiftClusters,
+		billing:             billing,
+		m:                   &noop.Noop{},
+	})
+
+	err = bb.runOnce(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	sort.Strings(snapshotted)
+	for _, l := range deep.Equal(snapshotted, []string{resourceID(0), resourceID(3), resourceID(4)}) {
+		t.Error(l)
+	}
+
+	// the lease is held, so a second run, e.g. by another replica, does
+	// nothing
+	err = bb.runOnce(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,115 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/eventgrid"
+	"github.com/Azure/ARO-RP/pkg/util/uuid"
+)
+
+type clusterEventPhase int
+
+const (
+	clusterEventPhaseStarted clusterEventPhase = iota
+	clusterEventPhaseSucceeded
+	clusterEventPhaseFailed
+)
+
+var clusterEventTypes = map[api.ProvisioningState][]api.ClusterEventType{
+	api.ProvisioningStateCreating:      {api.ClusterEventTypeCreateStarted, api.ClusterEventTypeCreateSucceeded, api.ClusterEventTypeCreateFailed},
+	api.ProvisioningStateAdminUpdating: {api.ClusterEventTypeUpgradeStarted, api.ClusterEventTypeUpgradeSucceeded, api.ClusterEventTypeUpgradeFailed},
+	api.ProvisioningStateDeleting:      {api.ClusterEventTypeDeleteStarted, api.ClusterEventTypeDeleteSucceeded, api.ClusterEventTypeDeleteFailed},
+}
+
+// clusterEventType returns the type of the lifecycle event for the operation
+// in progress on the cluster, or "" if the operation is not published.  Only
+// the admin updates which upgrade the cluster's RP-managed components are
+// published as upgrades.
+func clusterEventType(oc *api.OpenShiftCluster, phase clusterEventPhase) api.ClusterEventType {
+	if oc.Properties.ProvisioningState == api.ProvisioningStateAdminUpdating {
+		switch oc.Properties.MaintenanceTask {
+		case "", api.MaintenanceTaskEverything, api.MaintenanceTaskOperator:
+		default:
+			return ""
+		}
+	}
+
+	types, ok := clusterEventTypes[oc.Properties.ProvisioningState]
+	if !ok {
+		return ""
+	}
+
+	return types[phase]
+}
+
+// publishClusterEventStarted publishes the started event of the operation
+// when it is first dequeued.  An install is dequeued once per phase, so only
+// the first phase is published.
+func (ocb *openShiftClusterBackend) publishClusterEventStarted(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument) {
+	if doc.Dequeues != 1 {
+		return
+	}
+
+	if doc.OpenShiftCluster.Properties.ProvisioningState == api.ProvisioningStateCreating &&
+		doc.OpenShiftCluster.Properties.Install != nil {
+		return
+	}
+
+	ocb.publishClusterEvent(ctx, log, doc, clusterEventPhaseStarted, nil)
+}
+
+// publishClusterEvent publishes a lifecycle event of the cluster to the RP's
+// regional Event Grid topic.  Publishing is best effort: a failure is logged
+// and does not affect the operation.
+func (ocb *openShiftClusterBackend) publishClusterEvent(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument, phase clusterEventPhase, backendErr error) {
+	if ocb.eg == nil {
+		return
+	}
+
+	eventType := clusterEventType(doc.OpenShiftCluster, phase)
+	if eventType == "" {
+		return
+	}
+
+	data := &api.ClusterEventData{
+		ResourceID:  doc.OpenShiftCluster.ID,
+		OperationID: doc.AsyncOperationID,
+		Version:     doc.OpenShiftCluster.Properties.ClusterProfile.Version,
+	}
+
+	if doc.OpenShiftCluster.Properties.ProvisioningState == api.ProvisioningStateAdminUpdating {
+		data.MaintenanceTask = doc.OpenShiftCluster.Properties.MaintenanceTask
+	}
+
+	if phase == clusterEventPhaseFailed {
+		// as for the asynchronous operation, only CloudErrors are shown to
+		// the customer
+		if err, ok := backendErr.(*api.CloudError); ok {
+			data.Error = err.CloudErrorBody
+		} else {
+			data.Error = &api.CloudErrorBody{
+				Code:    api.CloudErrorCodeInternalServerError,
+				Message: "Internal server error.",
+			}
+		}
+	}
+
+	err := ocb.eg.Publish(ctx, &eventgrid.Event{
+		ID:          uuid.DefaultGenerator.Generate(),
+		Subject:     doc.OpenShiftCluster.ID,
+		EventType:   string(eventType),
+		EventTime:   time.Now().UTC(),
+		Data:        data,
+		DataVersion: api.ClusterEventDataVersion,
+	})
+	if err != nil {
+		log.Warnf("failed to publish %s event: %v", eventType, err)
+	}
+}
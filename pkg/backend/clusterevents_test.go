@@ -0,0 +1,154 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/eventgrid"
+	mock_eventgrid "github.com/Azure/ARO-RP/pkg/util/mocks/eventgrid"
+)
+
+func TestPublishClusterEvent(t *testing.T) {
+	ctx := context.Background()
+	log := logrus.NewEntry(logrus.StandardLogger())
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+
+	for _, tt := range []struct {
+		name            string
+		state           api.ProvisioningState
+		maintenanceTask api.MaintenanceTask
+		dequeues        int
+		install         *api.Install
+		phase           clusterEventPhase
+		backendErr      error
+		wantType        api.ClusterEventType
+		wantData        *api.ClusterEventData
+	}{
+		{
+			name:     "create started",
+			state:    api.ProvisioningStateCreating,
+			dequeues: 1,
+			phase:    clusterEventPhaseStarted,
+			wantType: api.ClusterEventTypeCreateStarted,
+			wantData: &api.ClusterEventData{ResourceID: resourceID, OperationID: "operation", Version: "4.14.16"},
+		},
+		{
+			name:     "later install phase is not published as started",
+			state:    api.ProvisioningStateCreating,
+			dequeues: 1,
+			install:  &api.Install{Phase: api.InstallPhaseRemoveBootstrap},
+			phase:    clusterEventPhaseStarted,
+		},
+		{
+			name:     "retried operation is not published as started again",
+			state:    api.ProvisioningStateDeleting,
+			dequeues: 2,
+			phase:    clusterEventPhaseStarted,
+		},
+		{
+			name:       "create failed with a CloudError",
+			state:      api.ProvisioningStateCreating,
+			phase:      clusterEventPhaseFailed,
+			backendErr: api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "Invalid."),
+			wantType:   api.ClusterEventTypeCreateFailed,
+			wantData: &api.ClusterEventData{ResourceID: resourceID, OperationID: "operation", Version: "4.14.16", Error: &api.CloudErrorBody{
+				Code:    api.CloudErrorCodeInvalidParameter,
+				Message: "Invalid.",
+			}},
+		},
+		{
+			name:       "delete failed with an internal error",
+			state:      api.ProvisioningStateDeleting,
+			phase:      clusterEventPhaseFailed,
+			backendErr: errors.New("oops"),
+			wantType:   api.ClusterEventTypeDeleteFailed,
+			wantData: &api.ClusterEventData{ResourceID: resourceID, OperationID: "operation", Version: "4.14.16", Error: &api.CloudErrorBody{
+				Code:    api.CloudErrorCodeInternalServerError,
+				Message: "Internal server error.",
+			}},
+		},
+		{
+			name:            "upgrade succeeded",
+			state:           api.ProvisioningStateAdminUpdating,
+			maintenanceTask: api.MaintenanceTaskEverything,
+			phase:           clusterEventPhaseSucceeded,
+			wantType:        api.ClusterEventTypeUpgradeSucceeded,
+			wantData:        &api.ClusterEventData{ResourceID: resourceID, OperationID: "operation", Version: "4.14.16", MaintenanceTask: api.MaintenanceTaskEverything},
+		},
+		{
+			name:            "certificate renewal is not published",
+			state:           api.ProvisioningStateAdminUpdating,
+			maintenanceTask: api.MaintenanceTaskRenewCerts,
+			phase:           clusterEventPhaseSucceeded,
+		},
+		{
+			name:     "update is not published",
+			state:    api.ProvisioningStateUpdating,
+			dequeues: 1,
+			phase:    clusterEventPhaseStarted,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			eg := mock_eventgrid.NewMockPublisher(controller)
+			if tt.wantType != "" {
+				eg.EXPECT().
+					Publish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, events ...*eventgrid.Event) error {
+						if len(events) != 1 {
+							t.Fatal(len(events))
+						}
+						if events[0].EventType != string(tt.wantType) ||
+							events[0].Subject != resourceID ||
+							events[0].DataVersion != api.ClusterEventDataVersion ||
+							events[0].ID == "" {
+							t.Error(events[0])
+						}
+						if !reflect.DeepEqual(events[0].Data, tt.wantData) {
+							t.Error(events[0].Data)
+						}
+						return errors.New("publishing failures are only logged")
+					})
+			}
+
+			ocb, err := newOpenShiftClusterBackend(&backend{eg: eg})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc := &api.OpenShiftClusterDocument{
+				Dequeues:         tt.dequeues,
+				AsyncOperationID: "operation",
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: tt.state,
+						MaintenanceTask:   tt.maintenanceTask,
+						Install:           tt.install,
+						ClusterProfile: api.ClusterProfile{
+							Version: "4.14.16",
+						},
+					},
+				},
+			}
+
+			if tt.phase == clusterEventPhaseStarted {
+				ocb.publishClusterEventStarted(ctx, log, doc)
+			} else {
+				ocb.publishClusterEvent(ctx, log, doc, tt.phase, tt.backendErr)
+			}
+		})
+	}
+}
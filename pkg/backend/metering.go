@@ -0,0 +1,134 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/util/billing"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const (
+	meteringInterval      = time.Hour
+	meteringLeaseInterval = 10 * time.Minute
+	meteringLeaseID       = "marketplacemetering"
+)
+
+type meteringBackend struct {
+	*backend
+}
+
+func newMeteringBackend(b *backend) *meteringBackend {
+	return &meteringBackend{backend: b}
+}
+
+// run reports the worker usage of the clusters with a marketplace plan to the
+// Marketplace Metering API once per meteringInterval until stop is closed.
+// Every backend replica tries to take the metering lease once per
+// meteringLeaseInterval; the lease expires after meteringInterval, so only
+// one replica reports each hour.
+func (mb *meteringBackend) run(ctx context.Context, stop <-chan struct{}) {
+	defer recover.Panic(mb.baseLog)
+
+	// no usage is reported if the Metering API is not configured
+	if mb.meter == nil {
+		return
+	}
+
+	t := time.NewTicker(meteringLeaseInterval)
+	defer t.Stop()
+
+	for {
+		err := mb.runOnce(ctx)
+		if err != nil {
+			mb.baseLog.Error(err)
+		}
+
+		select {
+		case <-t.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (mb *meteringBackend) runOnce(ctx context.Context) error {
+	ok, err := mb.dbMonitors.AcquireLease(ctx, meteringLeaseID, meteringInterval)
+	if err != nil || !ok {
+		return err
+	}
+
+	report, err := mb.reportAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	mb.emitReport(report)
+
+	return nil
+}
+
+// reportAll reports the usage of every billing document with a marketplace
+// plan, including those of deleted clusters whose last hours are not yet
+// reported, and returns the reconciliation report of the pass
+func (mb *meteringBackend) reportAll(ctx context.Context) (*billing.MeteringReport, error) {
+	total := &billing.MeteringReport{}
+
+	i := mb.dbBilling.List("")
+	for {
+		docs, err := i.Next(ctx, -1)
+		if err != nil {
+			return nil, err
+		}
+		if docs == nil {
+			break
+		}
+
+		for _, doc := range docs.BillingDocuments {
+			report, err := mb.meter.Report(ctx, doc)
+			if err != nil {
+				mb.baseLog.WithField("resource", doc.Key).Error(err)
+				continue
+			}
+			if report == nil {
+				continue
+			}
+
+			total.Add(report)
+		}
+	}
+
+	return total, nil
+}
+
+// emitReport logs the reconciliation report of a pass and emits its counts
+func (mb *meteringBackend) emitReport(report *billing.MeteringReport) {
+	mb.baseLog.WithFields(logrus.Fields{
+		"clusters":          report.Clusters,
+		"reported":          report.Reported,
+		"duplicate":         report.Duplicate,
+		"expired":           report.Expired,
+		"rejected":          report.Rejected,
+		"failed":            report.Failed,
+		"reportedCoreHours": report.ReportedCoreHours,
+		"missingHours":      report.MissingHours,
+	}).Print("marketplace metering reconciliation report")
+
+	for name, value := range map[string]int{
+		"clusters":          report.Clusters,
+		"reported":          report.Reported,
+		"duplicate":         report.Duplicate,
+		"expired":           report.Expired,
+		"rejected":          report.Rejected,
+		"failed":            report.Failed,
+		"reportedcorehours": report.ReportedCoreHours,
+		"missinghours":      report.MissingHours,
+	} {
+		mb.m.EmitGauge("backend.metering."+name+".count", int64(value), nil)
+	}
+}
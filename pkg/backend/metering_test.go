@@ -0,0 +1,102 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/billing"
+	mock_billing "github.com/Azure/ARO-RP/pkg/util/mocks/billing"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestMeteringReportAll(t *testing.T) {
+	ctx := context.Background()
+	log := logrus.NewEntry(logrus.StandardLogger())
+
+	s, err := testdatabase.NewServer(log, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts, dbc := s.NewTLSServer()
+	defer ts.Close()
+
+	dbMonitors, err := database.NewMonitors(ctx, dbc, "ARO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dbBilling, _ := testdatabase.NewFakeBilling()
+	fixture := testdatabase.NewFixture().WithBilling(dbBilling)
+	for i := 0; i < 4; i++ {
+		fixture.AddBillingDocuments(&api.BillingDocument{
+			ID:      fmt.Sprintf("%08d-0000-0000-0000-000000000000", i),
+			Key:     fmt.Sprintf("cluster%d", i),
+			Billing: &api.Billing{},
+		})
+	}
+	err = fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	meter := mock_billing.NewMockMeter(controller)
+	meter.EXPECT().Report(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, doc *api.BillingDocument) (*billing.MeteringReport, error) {
+		switch doc.Key {
+		case "cluster0":
+			// no marketplace plan
+			return nil, nil
+		case "cluster1":
+			return nil, errors.New("random error")
+		case "cluster2":
+			return &billing.MeteringReport{Clusters: 1, Reported: 2, ReportedCoreHours: 24}, nil
+		default:
+			return &billing.MeteringReport{Clusters: 1, Reported: 1, Failed: 1, ReportedCoreHours: 8, MissingHours: 2}, nil
+		}
+	}).Times(4)
+
+	mb := newMeteringBackend(&backend{
+		baseLog:    log,
+		dbMonitors: dbMonitors,
+		dbBilling:  dbBilling,
+		meter:      meter,
+		m:          &noop.Noop{},
+	})
+
+	report, err := mb.reportAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &billing.MeteringReport{Clusters: 2, Reported: 3, Failed: 1, ReportedCoreHours: 32, MissingHours: 2}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("got report %#v, want %#v", report, want)
+	}
+
+	// the lease is taken by the first run, so a second run, e.g. by another
+	// replica, reports nothing
+	ok, err := dbMonitors.AcquireLease(ctx, meteringLeaseID, meteringInterval)
+	if err != nil || !ok {
+		t.Fatal(ok, err)
+	}
+
+	err = mb.runOnce(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,177 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/util/ocm"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const (
+	ocmSyncInterval  = time.Hour
+	ocmLeaseInterval = 10 * time.Minute
+	ocmLeaseID       = "ocmsync"
+)
+
+type ocmBackend struct {
+	*backend
+}
+
+func newOCMBackend(b *backend) *ocmBackend {
+	return &ocmBackend{backend: b}
+}
+
+// run keeps the OCM subscription status of every registered cluster in step
+// with the state of the cluster and of its Azure subscription, once per
+// ocmSyncInterval until stop is closed.  A lease ensures only one backend
+// replica syncs at a time.  Clusters are registered by the install and admin
+// update steps, not here.
+func (ob *ocmBackend) run(ctx context.Context, stop <-chan struct{}) {
+	defer recover.Panic(ob.baseLog)
+
+	if ob.ocm == nil {
+		return
+	}
+
+	t := time.NewTicker(ocmLeaseInterval)
+	defer t.Stop()
+
+	for {
+		err := ob.runOnce(ctx)
+		if err != nil {
+			ob.baseLog.Error(err)
+		}
+
+		select {
+		case <-t.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (ob *ocmBackend) runOnce(ctx context.Context) error {
+	ok, err := ob.dbMonitors.AcquireLease(ctx, ocmLeaseID, ocmSyncInterval)
+	if err != nil || !ok {
+		return err
+	}
+
+	return ob.syncAll(ctx)
+}
+
+// syncAll walks the OpenShiftClusters change feed from the beginning and
+// updates the OCM subscriptions whose status differs from the one last
+// recorded.  The tombstones of deleted clusters are deprovisioned if the
+// delete did not manage to; as a tombstone cannot be updated, this is
+// repeated until it is purged.
+func (ob *ocmBackend) syncAll(ctx context.Context) error {
+	i := ob.dbOpenShiftClusters.ChangeFeed()
+	subscriptions := map[string]*api.SubscriptionDocument{}
+
+	var updated int
+	for {
+		docs, err := i.Next(ctx, -1)
+		if err != nil {
+			return err
+		}
+		if docs == nil {
+			break
+		}
+
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			if doc.OpenShiftCluster.Properties.OCMProfile.SubscriptionID == "" {
+				continue
+			}
+
+			log := ob.baseLog.WithField("resource", doc.OpenShiftCluster.ID)
+
+			sub, err := ob.getSubscription(ctx, subscriptions, doc)
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+
+			ok, err := ob.sync(ctx, doc, sub)
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+			if ok {
+				updated++
+			}
+		}
+	}
+
+	ob.m.EmitGauge("backend.ocm.subscriptionsupdated.count", int64(updated), nil)
+
+	return nil
+}
+
+// getSubscription returns the Azure subscription of the cluster, caching it
+// for the rest of the walk.  It returns nil if the subscription is unknown.
+func (ob *ocmBackend) getSubscription(ctx context.Context, subscriptions map[string]*api.SubscriptionDocument, doc *api.OpenShiftClusterDocument) (*api.SubscriptionDocument, error) {
+	r, err := azure.ParseResourceID(doc.OpenShiftCluster.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sub, found := subscriptions[r.SubscriptionID]; found {
+		return sub, nil
+	}
+
+	sub, err := ob.dbSubscriptions.Get(ctx, r.SubscriptionID)
+	if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		return nil, err
+	}
+
+	subscriptions[r.SubscriptionID] = sub
+	return sub, nil
+}
+
+// sync updates the OCM subscription of the cluster if its status changed.  It
+// returns true if the subscription was updated.
+func (ob *ocmBackend) sync(ctx context.Context, doc *api.OpenShiftClusterDocument, sub *api.SubscriptionDocument) (bool, error) {
+	p := doc.OpenShiftCluster.Properties.OCMProfile
+
+	status := ocm.SubscriptionStatusFor(doc, sub)
+	if string(status) == p.SubscriptionStatus {
+		return false, nil
+	}
+
+	token, err := ocm.Token(doc.OpenShiftCluster)
+	if err != nil || token == "" {
+		return false, err
+	}
+
+	err = ob.ocm.UpdateSubscription(ctx, token, &ocm.Cluster{
+		ID:          p.ClusterID,
+		DisplayName: doc.OpenShiftCluster.Name,
+		Version:     doc.OpenShiftCluster.Properties.ClusterProfile.Version,
+	}, &ocm.Subscription{
+		ID:     p.SubscriptionID,
+		Status: status,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if doc.SoftDeleted != nil {
+		return true, nil
+	}
+
+	_, err = ob.dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.OCMProfile.SubscriptionStatus = string(status)
+		return nil
+	})
+
+	return true, err
+}
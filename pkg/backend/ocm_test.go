@@ -0,0 +1,179 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_ocm "github.com/Azure/ARO-RP/pkg/util/mocks/ocm"
+	"github.com/Azure/ARO-RP/pkg/util/ocm"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestOCMRunOnce(t *testing.T) {
+	ctx := context.Background()
+	log := logrus.NewEntry(logrus.StandardLogger())
+
+	s, err := testdatabase.NewServer(log, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts, dbc := s.NewTLSServer()
+	defer ts.Close()
+
+	dbOpenShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, "ARO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dbMonitors, err := database.NewMonitors(ctx, dbc, "ARO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dbSubscriptions, err := database.NewSubscriptions(ctx, dbc, "ARO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		suspendedSubscriptionID = "00000000-0000-0000-0000-000000000001"
+		pullSecret              = `{"auths":{"cloud.openshift.com":{"auth":"token"}}}`
+	)
+
+	for _, id := range []string{"00000000-0000-0000-0000-000000000000", suspendedSubscriptionID} {
+		state := api.SubscriptionStateRegistered
+		if id == suspendedSubscriptionID {
+			state = api.SubscriptionStateSuspended
+		}
+
+		_, err = dbSubscriptions.Create(ctx, &api.SubscriptionDocument{
+			ID: id,
+			Subscription: &api.Subscription{
+				State: state,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resourceID := func(i int, subscriptionID string) string {
+		return fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster%d", subscriptionID, i)
+	}
+
+	clusters := []struct {
+		subscriptionID string
+		state          api.ProvisioningState
+		pullSecret     api.SecureString
+		ocmProfile     api.OCMProfile
+		wantStatus     string
+	}{
+		{
+			// in sync: left alone
+			subscriptionID: "00000000-0000-0000-0000-000000000000",
+			state:          api.ProvisioningStateSucceeded,
+			pullSecret:     pullSecret,
+			ocmProfile:     api.OCMProfile{ClusterID: "cluster0", SubscriptionID: "sub0", SubscriptionStatus: "Active"},
+			wantStatus:     "Active",
+		},
+		{
+			// Azure subscription suspended: disconnected
+			subscriptionID: suspendedSubscriptionID,
+			state:          api.ProvisioningStateSucceeded,
+			pullSecret:     pullSecret,
+			ocmProfile:     api.OCMProfile{ClusterID: "cluster1", SubscriptionID: "sub1", SubscriptionStatus: "Active"},
+			wantStatus:     "Disconnected",
+		},
+		{
+			// being deleted: deprovisioned
+			subscriptionID: "00000000-0000-0000-0000-000000000000",
+			state:          api.ProvisioningStateDeleting,
+			pullSecret:     pullSecret,
+			ocmProfile:     api.OCMProfile{ClusterID: "cluster2", SubscriptionID: "sub2", SubscriptionStatus: "Active"},
+			wantStatus:     "Deprovisioned",
+		},
+		{
+			// not registered: left alone
+			subscriptionID: "00000000-0000-0000-0000-000000000000",
+			state:          api.ProvisioningStateDeleting,
+			pullSecret:     pullSecret,
+		},
+		{
+			// no token: left alone
+			subscriptionID: "00000000-0000-0000-0000-000000000000",
+			state:          api.ProvisioningStateDeleting,
+			ocmProfile:     api.OCMProfile{ClusterID: "cluster4", SubscriptionID: "sub4", SubscriptionStatus: "Active"},
+			wantStatus:     "Active",
+		},
+	}
+
+	for i, tt := range clusters {
+		_, err := dbOpenShiftClusters.Create(ctx, &api.OpenShiftClusterDocument{
+			ID:  dbOpenShiftClusters.NewUUID(),
+			Key: strings.ToLower(resourceID(i, tt.subscriptionID)),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:   resourceID(i, tt.subscriptionID),
+				Name: fmt.Sprintf("cluster%d", i),
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: tt.state,
+					ClusterProfile: api.ClusterProfile{
+						PullSecret: tt.pullSecret,
+						Version:    "4.14.16",
+					},
+					OCMProfile: tt.ocmProfile,
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	client := mock_ocm.NewMockClient(controller)
+	client.EXPECT().
+		UpdateSubscription(gomock.Any(), "token", &ocm.Cluster{ID: "cluster1", DisplayName: "cluster1", Version: "4.14.16"}, &ocm.Subscription{ID: "sub1", Status: ocm.SubscriptionStatusDisconnected}).
+		Return(nil)
+	client.EXPECT().
+		UpdateSubscription(gomock.Any(), "token", &ocm.Cluster{ID: "cluster2", DisplayName: "cluster2", Version: "4.14.16"}, &ocm.Subscription{ID: "sub2", Status: ocm.SubscriptionStatusDeprovisioned}).
+		Return(nil)
+
+	ob := newOCMBackend(&backend{
+		baseLog:             log,
+		dbMonitors:          dbMonitors,
+		dbOpenShiftClusters: dbOpenShiftClusters,
+		dbSubscriptions:     dbSubscriptions,
+		ocm:                 client,
+		m:                   &noop.Noop{},
+	})
+
+	err = ob.runOnce(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, tt := range clusters {
+		doc, err := dbOpenShiftClusters.Get(ctx, strings.ToLower(resourceID(i, tt.subscriptionID)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if doc.OpenShiftCluster.Properties.OCMProfile.SubscriptionStatus != tt.wantStatus {
+			t.Errorf("cluster%d: got status %q, wanted %q", i, doc.OpenShiftCluster.Properties.OCMProfile.SubscriptionStatus, tt.wantStatus)
+		}
+	}
+}
@@ -31,13 +31,20 @@ type openShiftClusterBackend struct {
 	*backend
 
 	newManager func(context.Context, *logrus.Entry, env.Interface, database.OpenShiftClusters, database.Gateway, database.OpenShiftVersions, encryption.AEAD, billing.Manager, *api.OpenShiftClusterDocument, *api.SubscriptionDocument, hive.ClusterManager, metrics.Emitter) (cluster.Interface, error)
+	scheduler  *scheduler
 }
 
-func newOpenShiftClusterBackend(b *backend) *openShiftClusterBackend {
+func newOpenShiftClusterBackend(b *backend) (*openShiftClusterBackend, error) {
+	maxPerSubscription, err := maxWorkersPerSubscription()
+	if err != nil {
+		return nil, err
+	}
+
 	return &openShiftClusterBackend{
 		backend:    b,
 		newManager: cluster.New,
-	}
+		scheduler:  newScheduler(b.m, maxPerSubscription),
+	}, nil
 }
 
 // try tries to dequeue an OpenShiftClusterDocument for work, and works it on a
@@ -45,7 +52,7 @@ func newOpenShiftClusterBackend(b *backend) *openShiftClusterBackend {
 // succeeded in dequeuing anything - if this is false, the caller should sleep
 // before calling again
 func (ocb *openShiftClusterBackend) try(ctx context.Context) (bool, error) {
-	doc, err := ocb.dbOpenShiftClusters.Dequeue(ctx)
+	doc, err := ocb.dbOpenShiftClusters.DequeueWithPolicy(ctx, ocb.scheduler.order)
 	if err != nil || doc == nil {
 		return false, err
 	}
@@ -56,12 +63,13 @@ func (ocb *openShiftClusterBackend) try(ctx context.Context) (bool, error) {
 	log = utillog.EnrichWithClusterVersion(log, doc.OpenShiftCluster.Properties.ClusterProfile.Version)
 	log = utillog.EnrichWithClusterDeploymentNamespace(log, doc.OpenShiftCluster.Properties.HiveProfile.Namespace)
 
-	if doc.Dequeues > maxDequeueCount {
+	if doc.Dequeues > database.MaxDequeueCount {
 		err := fmt.Errorf("dequeued %d times, failing", doc.Dequeues)
 		return true, ocb.endLease(ctx, log, nil, doc, api.ProvisioningStateFailed, err)
 	}
 
 	log.Print("dequeued")
+	done := ocb.scheduler.start(doc)
 	atomic.AddInt32(&ocb.workers, 1)
 	ocb.m.EmitGauge("backend.openshiftcluster.workers.count", int64(atomic.LoadInt32(&ocb.workers)), nil)
 
@@ -71,6 +79,7 @@ func (ocb *openShiftClusterBackend) try(ctx context.Context) (bool, error) {
 		t := time.Now()
 
 		defer func() {
+			done()
 			atomic.AddInt32(&ocb.workers, -1)
 			ocb.m.EmitGauge("backend.openshiftcluster.workers.count", int64(atomic.LoadInt32(&ocb.workers)), nil)
 			ocb.cond.Signal()
@@ -78,7 +87,10 @@ func (ocb *openShiftClusterBackend) try(ctx context.Context) (bool, error) {
 			log.WithField("duration", time.Since(t).Seconds()).Print("done")
 		}()
 
-		err := ocb.handle(context.Background(), log, doc)
+		ctx, span := startOperationSpan(context.Background(), doc)
+
+		err := ocb.handle(ctx, log, doc)
+		endOperationSpan(span, err)
 		if err != nil {
 			log.Error(err)
 		}
@@ -134,6 +146,8 @@ func (ocb *openShiftClusterBackend) handle(ctx context.Context, log *logrus.Entr
 		return ocb.endLease(ctx, log, stop, doc, api.ProvisioningStateFailed, err)
 	}
 
+	ocb.publishClusterEventStarted(ctx, log, doc)
+
 	switch doc.OpenShiftCluster.Properties.ProvisioningState {
 	case api.ProvisioningStateCreating:
 		log.Print("creating")
@@ -193,6 +207,8 @@ func (ocb *openShiftClusterBackend) handle(ctx context.Context, log *logrus.Entr
 			return ocb.endLease(ctx, log, stop, doc, api.ProvisioningStateFailed, err)
 		}
 
+		ocb.publishClusterEvent(ctx, log, doc, clusterEventPhaseSucceeded, nil)
+
 		stop()
 
 		// This Sleep ensures that the monitor has enough time
@@ -200,7 +216,7 @@ func (ocb *openShiftClusterBackend) handle(ctx context.Context, log *logrus.Entr
 		// and stop monitoring the cluster.
 		// TODO: Provide better communication between RP and Monitor
 		time.Sleep(time.Until(t.Add(time.Second * 20)))
-		return ocb.dbOpenShiftClusters.Delete(ctx, doc)
+		return ocb.dbOpenShiftClusters.SoftDelete(ctx, doc)
 	}
 
 	return fmt.Errorf("unexpected provisioningState %q", doc.OpenShiftCluster.Properties.ProvisioningState)
@@ -307,6 +323,12 @@ func (ocb *openShiftClusterBackend) endLease(ctx context.Context, log *logrus.En
 		}
 		ocb.asyncOperationResultLog(log, initialProvisioningState, backendErr)
 		ocb.emitMetrics(doc, provisioningState)
+
+		if provisioningState == api.ProvisioningStateFailed {
+			ocb.publishClusterEvent(ctx, log, doc, clusterEventPhaseFailed, backendErr)
+		} else {
+			ocb.publishClusterEvent(ctx, log, doc, clusterEventPhaseSucceeded, nil)
+		}
 	}
 
 	if initialProvisioningState == api.ProvisioningStateAdminUpdating {
@@ -374,6 +396,11 @@ func (ocb *openShiftClusterBackend) emitMetrics(doc *api.OpenShiftClusterDocumen
 		"newProvisioningState": string(provisioningState),
 	})
 
+	ocb.m.EmitHistogram("backend.openshiftcluster.duration.histogram", float64(duration), map[string]string{
+		"oldProvisioningState": string(doc.OpenShiftCluster.Properties.ProvisioningState),
+		"newProvisioningState": string(provisioningState),
+	})
+
 	ocb.m.EmitGauge("backend.openshiftcluster.count", 1, map[string]string{
 		"oldProvisioningState": string(doc.OpenShiftCluster.Properties.ProvisioningState),
 		"newProvisioningState": string(provisioningState),
@@ -263,9 +263,10 @@ func TestBackendTry(t *testing.T) {
 			},
 		},
 		{
-			name: "StateDeleting success deletes the document",
+			name: "StateDeleting success soft-deletes the document",
 			fixture: func(f *testdatabase.Fixture) {
 				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					ID:  mockSubID,
 					Key: strings.ToLower(resourceID),
 					OpenShiftCluster: &api.OpenShiftCluster{
 						ID:       resourceID,
@@ -281,7 +282,24 @@ func TestBackendTry(t *testing.T) {
 					ID: mockSubID,
 				})
 			},
-			checker: func(c *testdatabase.Checker) {},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key:      "softdeleted/" + mockSubID,
+					Dequeues: 1,
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateDeleting,
+						},
+					},
+					SoftDeleted: &api.SoftDeleted{
+						Key: strings.ToLower(resourceID),
+					},
+				})
+			},
 			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
 				manager.EXPECT().Delete(gomock.Any()).Return(nil)
 			},
@@ -315,7 +333,7 @@ func TestBackendTry(t *testing.T) {
 				return manager, nil
 			}
 
-			b, err := newBackend(ctx, log, _env, nil, nil, nil, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, nil, &noop.Noop{})
+			b, err := newBackend(ctx, log, _env, nil, nil, nil, nil, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, nil, &noop.Noop{})
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -323,6 +341,7 @@ func TestBackendTry(t *testing.T) {
 			b.ocb = &openShiftClusterBackend{
 				backend:    b,
 				newManager: createManager,
+				scheduler:  newScheduler(b.m, env.DefaultRPBackendMaxWorkersPerSubscription),
 			}
 
 			worked, err := b.ocb.try(ctx)
@@ -0,0 +1,145 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+)
+
+// scheduler shares the backend's workers fairly between subscriptions, so that
+// one subscription creating many clusters at once cannot hold up the
+// operations of others.  Of the documents which are ready to be dequeued, it
+// prefers those of the subscriptions with the fewest operations running on
+// this backend, then those which have been queued longest, and leaves queued
+// those of subscriptions which are already running maxPerSubscription.
+type scheduler struct {
+	m   metrics.Emitter
+	now func() time.Time
+
+	maxPerSubscription int
+
+	mu      sync.Mutex
+	running map[string]int
+}
+
+func newScheduler(m metrics.Emitter, maxPerSubscription int) *scheduler {
+	return &scheduler{
+		m:   m,
+		now: time.Now,
+
+		maxPerSubscription: maxPerSubscription,
+
+		running: map[string]int{},
+	}
+}
+
+// maxWorkersPerSubscription returns how many operations of one subscription
+// each backend runs at once.  It can be overridden with the
+// RP_BACKEND_MAX_WORKERS_PER_SUBSCRIPTION environment variable.
+func maxWorkersPerSubscription() (int, error) {
+	s := os.Getenv("RP_BACKEND_MAX_WORKERS_PER_SUBSCRIPTION")
+	if s == "" {
+		return env.DefaultRPBackendMaxWorkersPerSubscription, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid RP_BACKEND_MAX_WORKERS_PER_SUBSCRIPTION %q", s)
+	}
+
+	return n, nil
+}
+
+// order is an OpenShiftClusterDequeuePolicy
+func (s *scheduler) order(docs []*api.OpenShiftClusterDocument) []*api.OpenShiftClusterDocument {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ordered := make([]*api.OpenShiftClusterDocument, 0, len(docs))
+	for _, doc := range docs {
+		if s.running[subscriptionID(doc)] < s.maxPerSubscription {
+			ordered = append(ordered, doc)
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ri, rj := s.running[subscriptionID(ordered[i])], s.running[subscriptionID(ordered[j])]
+		if ri != rj {
+			return ri < rj
+		}
+		return queuedSince(ordered[i]).Before(queuedSince(ordered[j]))
+	})
+
+	return ordered
+}
+
+// start records that the operation of doc is running, and returns a function
+// to call when it is done
+func (s *scheduler) start(doc *api.OpenShiftClusterDocument) func() {
+	subscriptionID := subscriptionID(doc)
+
+	// later dequeues of a document are retries after a backend stopped
+	// working it, so would include the time taken by the failed attempt
+	if doc.Dequeues == 1 {
+		s.m.EmitHistogram("backend.openshiftcluster.queue.duration", float64(s.now().Sub(queuedSince(doc)))/float64(time.Millisecond), map[string]string{
+			"subscriptionId":    subscriptionID,
+			"provisioningState": string(doc.OpenShiftCluster.Properties.ProvisioningState),
+		})
+	}
+
+	s.mu.Lock()
+	s.running[subscriptionID]++
+	s.emitRunning(subscriptionID)
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.running[subscriptionID]--
+		s.emitRunning(subscriptionID)
+		if s.running[subscriptionID] == 0 {
+			delete(s.running, subscriptionID)
+		}
+	}
+}
+
+func (s *scheduler) emitRunning(subscriptionID string) {
+	s.m.EmitGauge("backend.openshiftcluster.subscription.workers.count", int64(s.running[subscriptionID]), map[string]string{
+		"subscriptionId": subscriptionID,
+	})
+}
+
+// subscriptionID returns the subscription of the cluster of doc, in lower case
+// as resource IDs are not case sensitive
+func subscriptionID(doc *api.OpenShiftClusterDocument) string {
+	r, err := azure.ParseResourceID(doc.OpenShiftCluster.ID)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(r.SubscriptionID)
+}
+
+// queuedSince returns when the request which queued doc was received, or if
+// that is not known, when doc was last written
+func queuedSince(doc *api.OpenShiftClusterDocument) time.Time {
+	if doc.CorrelationData != nil && !doc.CorrelationData.RequestTime.IsZero() {
+		return doc.CorrelationData.RequestTime
+	}
+
+	return time.Unix(int64(doc.Timestamp), 0)
+}
@@ -0,0 +1,159 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func schedulerTestDocument(name, subscriptionID string, requestTime time.Time) *api.OpenShiftClusterDocument {
+	return &api.OpenShiftClusterDocument{
+		ID: name,
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID: "/subscriptions/" + subscriptionID + "/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/" + name,
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateCreating,
+			},
+		},
+		CorrelationData: &api.CorrelationData{
+			RequestTime: requestTime,
+		},
+		Dequeues: 1,
+	}
+}
+
+func TestSchedulerOrder(t *testing.T) {
+	now := time.Now()
+
+	for _, tt := range []struct {
+		name    string
+		running map[string]int
+		docs    []*api.OpenShiftClusterDocument
+		want    []string
+	}{
+		{
+			name: "oldest first when nothing is running",
+			docs: []*api.OpenShiftClusterDocument{
+				schedulerTestDocument("a1", "a", now),
+				schedulerTestDocument("a2", "a", now.Add(-time.Minute)),
+				schedulerTestDocument("b1", "b", now.Add(-30*time.Second)),
+			},
+			want: []string{"a2", "b1", "a1"},
+		},
+		{
+			name: "least busy subscription first",
+			running: map[string]int{
+				"a": 2,
+				"b": 1,
+			},
+			docs: []*api.OpenShiftClusterDocument{
+				schedulerTestDocument("a1", "a", now.Add(-time.Hour)),
+				schedulerTestDocument("b1", "b", now.Add(-time.Minute)),
+				schedulerTestDocument("c1", "c", now),
+			},
+			want: []string{"c1", "b1", "a1"},
+		},
+		{
+			name: "subscription at its limit stays queued",
+			running: map[string]int{
+				"a": 3,
+			},
+			docs: []*api.OpenShiftClusterDocument{
+				schedulerTestDocument("a1", "a", now.Add(-time.Hour)),
+				schedulerTestDocument("b1", "b", now),
+			},
+			want: []string{"b1"},
+		},
+		{
+			name: "subscription IDs are not case sensitive",
+			running: map[string]int{
+				"a": 3,
+			},
+			docs: []*api.OpenShiftClusterDocument{
+				schedulerTestDocument("a1", "A", now),
+			},
+			want: []string{},
+		},
+		{
+			name: "document timestamp used without request time",
+			docs: []*api.OpenShiftClusterDocument{
+				schedulerTestDocument("a1", "a", now),
+				func() *api.OpenShiftClusterDocument {
+					doc := schedulerTestDocument("b1", "b", time.Time{})
+					doc.Timestamp = int(now.Add(-time.Minute).Unix())
+					return doc
+				}(),
+			},
+			want: []string{"b1", "a1"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScheduler(&noop.Noop{}, 3)
+			for subscriptionID, running := range tt.running {
+				s.running[subscriptionID] = running
+			}
+
+			got := []string{}
+			for _, doc := range s.order(tt.docs) {
+				got = append(got, doc.ID)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestSchedulerStart(t *testing.T) {
+	now := time.Now()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	gomock.InOrder(
+		m.EXPECT().EmitHistogram("backend.openshiftcluster.queue.duration", float64(90000), map[string]string{
+			"subscriptionId":    "a",
+			"provisioningState": "Creating",
+		}),
+		m.EXPECT().EmitGauge("backend.openshiftcluster.subscription.workers.count", int64(1), map[string]string{
+			"subscriptionId": "a",
+		}),
+		// a retry does not emit its queue duration
+		m.EXPECT().EmitGauge("backend.openshiftcluster.subscription.workers.count", int64(2), map[string]string{
+			"subscriptionId": "a",
+		}),
+		m.EXPECT().EmitGauge("backend.openshiftcluster.subscription.workers.count", int64(1), map[string]string{
+			"subscriptionId": "a",
+		}),
+		m.EXPECT().EmitGauge("backend.openshiftcluster.subscription.workers.count", int64(0), map[string]string{
+			"subscriptionId": "a",
+		}),
+	)
+
+	s := newScheduler(m, 3)
+	s.now = func() time.Time { return now }
+
+	done1 := s.start(schedulerTestDocument("a1", "a", now.Add(-90*time.Second)))
+
+	retry := schedulerTestDocument("a2", "a", now.Add(-time.Hour))
+	retry.Dequeues = 2
+	done2 := s.start(retry)
+
+	done1()
+	done2()
+
+	if len(s.running) != 0 {
+		t.Error(s.running)
+	}
+}
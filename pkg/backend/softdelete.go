@@ -0,0 +1,93 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const softDeleteReapInterval = time.Hour
+
+type softDeleteBackend struct {
+	*backend
+
+	retention time.Duration
+}
+
+func newSoftDeleteBackend(b *backend) (*softDeleteBackend, error) {
+	retention, err := softDeleteRetention()
+	if err != nil {
+		return nil, err
+	}
+
+	return &softDeleteBackend{
+		backend:   b,
+		retention: retention,
+	}, nil
+}
+
+// softDeleteRetention returns how long the tombstones of deleted clusters are
+// kept for.  It can be overridden with the CLUSTER_SOFT_DELETE_RETENTION
+// environment variable, e.g. "72h".
+func softDeleteRetention() (time.Duration, error) {
+	retention := os.Getenv("CLUSTER_SOFT_DELETE_RETENTION")
+	if retention == "" {
+		return env.DefaultClusterSoftDeleteRetention, nil
+	}
+
+	return time.ParseDuration(retention)
+}
+
+// run purges expired tombstones once per softDeleteReapInterval until stop is
+// closed.  Purging is idempotent, so it is safe for every backend replica to
+// run this.
+func (sdb *softDeleteBackend) run(ctx context.Context, stop <-chan struct{}) {
+	defer recover.Panic(sdb.baseLog)
+
+	t := time.NewTicker(softDeleteReapInterval)
+	defer t.Stop()
+
+	for {
+		err := sdb.reap(ctx)
+		if err != nil {
+			sdb.baseLog.Error(err)
+		}
+
+		select {
+		case <-t.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// reap deletes every tombstone which is older than the retention period
+func (sdb *softDeleteBackend) reap(ctx context.Context) error {
+	docs, err := sdb.dbOpenShiftClusters.ListExpired(ctx, sdb.retention)
+	if err != nil {
+		return err
+	}
+
+	var count int
+	for _, doc := range docs.OpenShiftClusterDocuments {
+		err = sdb.dbOpenShiftClusters.Delete(ctx, doc)
+		if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+			sdb.baseLog.WithField("resource", doc.SoftDeleted.Key).Error(err)
+			continue
+		}
+
+		count++
+	}
+
+	sdb.m.EmitGauge("backend.softdelete.purged.count", int64(count), nil)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestSoftDeleteReap(t *testing.T) {
+	ctx := context.Background()
+
+	expired := &api.OpenShiftClusterDocument{
+		ID:  "00000000-0000-0000-0000-000000000001",
+		Key: "softdeleted/00000000-0000-0000-0000-000000000001",
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateDeleting,
+			},
+		},
+		SoftDeleted: &api.SoftDeleted{
+			DeletionTime: int(time.Now().Add(-2 * time.Hour).Unix()),
+			Key:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/expired",
+		},
+	}
+	retained := &api.OpenShiftClusterDocument{
+		ID:  "00000000-0000-0000-0000-000000000002",
+		Key: "softdeleted/00000000-0000-0000-0000-000000000002",
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateDeleting,
+			},
+		},
+		SoftDeleted: &api.SoftDeleted{
+			DeletionTime: int(time.Now().Unix()),
+			Key:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/retained",
+		},
+	}
+	live := &api.OpenShiftClusterDocument{
+		ID:  "00000000-0000-0000-0000-000000000003",
+		Key: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/live",
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateSucceeded,
+			},
+		},
+	}
+
+	dbOpenShiftClusters, clientOpenShiftClusters := testdatabase.NewFakeOpenShiftClusters()
+
+	// tombstones can't be created through the fixture, as their keys are not
+	// resource IDs
+	for _, doc := range []*api.OpenShiftClusterDocument{expired, retained, live} {
+		doc.PartitionKey = "00000000-0000-0000-0000-000000000000"
+		_, err := clientOpenShiftClusters.Create(ctx, doc.PartitionKey, doc, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sdb := &softDeleteBackend{
+		backend: &backend{
+			baseLog:             logrus.NewEntry(logrus.StandardLogger()),
+			dbOpenShiftClusters: dbOpenShiftClusters,
+			m:                   &noop.Noop{},
+		},
+		retention: time.Hour,
+	}
+
+	err := sdb.reap(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := testdatabase.NewChecker()
+	c.AddOpenShiftClusterDocuments(live, retained)
+
+	for _, err := range c.CheckOpenShiftClusters(clientOpenShiftClusters) {
+		t.Error(err)
+	}
+}
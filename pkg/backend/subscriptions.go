@@ -12,6 +12,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/util/recover"
 )
 
@@ -34,7 +35,7 @@ func (sb *subscriptionBackend) try(ctx context.Context) (bool, error) {
 	}
 
 	log := sb.baseLog.WithField("subscription", doc.ID)
-	if doc.Dequeues > maxDequeueCount {
+	if doc.Dequeues > database.MaxDequeueCount {
 		log.Errorf("dequeued %d times, failing", doc.Dequeues)
 		return true, sb.endLease(ctx, nil, doc, false, true)
 	}
@@ -57,6 +58,10 @@ func (sb *subscriptionBackend) try(ctx context.Context) (bool, error) {
 				"state": string(doc.Subscription.State),
 			})
 
+			sb.m.EmitHistogram("backend.subscriptions.duration.histogram", float64(time.Since(t).Milliseconds()), map[string]string{
+				"state": string(doc.Subscription.State),
+			})
+
 			sb.m.EmitGauge("backend.subscriptions.count", 1, nil)
 
 			log.WithField("duration", time.Since(t).Seconds()).Print("done")
@@ -0,0 +1,54 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+const tracerName = "github.com/Azure/ARO-RP/pkg/backend"
+
+var traceContext = propagation.TraceContext{}
+
+// startOperationSpan starts the span of the backend work on doc.  If the
+// frontend recorded the trace context of the request which queued the work in
+// the document's correlation data, the span is a child of the request's span,
+// so that the customer operation can be viewed as one trace.
+func startOperationSpan(ctx context.Context, doc *api.OpenShiftClusterDocument) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		attribute.String("aro.resource_id", doc.OpenShiftCluster.ID),
+		attribute.String("aro.provisioning_state", string(doc.OpenShiftCluster.Properties.ProvisioningState)),
+	}
+
+	if doc.CorrelationData != nil {
+		ctx = traceContext.Extract(ctx, propagation.MapCarrier{
+			"traceparent": doc.CorrelationData.TraceParent,
+			"tracestate":  doc.CorrelationData.TraceState,
+		})
+
+		attributes = append(attributes, attribute.String("aro.correlation_id", doc.CorrelationData.CorrelationID))
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, "backend.openshiftcluster."+string(doc.OpenShiftCluster.Properties.ProvisioningState),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+// endOperationSpan records err, if any, on span and ends it
+func endOperationSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
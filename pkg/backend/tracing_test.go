@@ -0,0 +1,64 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+func TestStartOperationSpan(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		correlationData *api.CorrelationData
+		wantTraceID     string
+	}{
+		{
+			name: "no correlation data",
+		},
+		{
+			name:            "no trace context",
+			correlationData: &api.CorrelationData{CorrelationID: "correlation"},
+		},
+		{
+			name: "trace context from the frontend",
+			correlationData: &api.CorrelationData{
+				TraceParent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+			},
+			wantTraceID: "0af7651916cd43dd8448eb211c80319c",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &api.OpenShiftClusterDocument{
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName",
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateCreating,
+					},
+				},
+				CorrelationData: tt.correlationData,
+			}
+
+			ctx, span := startOperationSpan(context.Background(), doc)
+			defer endOperationSpan(span, nil)
+
+			// without a tracer provider, the span is that of the frontend
+			spanContext := trace.SpanContextFromContext(ctx)
+			if tt.wantTraceID == "" {
+				if spanContext.IsValid() {
+					t.Error(spanContext)
+				}
+				return
+			}
+
+			if spanContext.TraceID().String() != tt.wantTraceID {
+				t.Error(spanContext.TraceID())
+			}
+		})
+	}
+}
@@ -76,6 +76,10 @@ type CloudErrorBody struct {
 	Message *string `json:"message,omitempty"`
 	// Target - The target of the particular error. For example, the name of the property in error.
 	Target *string `json:"target,omitempty"`
+	// TargetResourceID - The resource ID of the Azure resource in error, if any.
+	TargetResourceID *string `json:"targetResourceId,omitempty"`
+	// RemediationURL - A link to documentation describing how to resolve the error, if any.
+	RemediationURL *string `json:"remediationUrl,omitempty"`
 	// Details - A list of additional details about the error.
 	Details *[]CloudErrorBody `json:"details,omitempty"`
 }
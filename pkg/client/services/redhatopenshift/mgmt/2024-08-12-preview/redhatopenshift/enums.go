@@ -17,6 +17,21 @@ package redhatopenshift
 // Code generated by Microsoft (R) AutoRest Code Generator.
 // Changes may cause incorrect behavior and will be lost if the code is regenerated.
 
+// AcceleratedNetworking enumerates the values for accelerated networking.
+type AcceleratedNetworking string
+
+const (
+	// Disabled ...
+	Disabled AcceleratedNetworking = "Disabled"
+	// Enabled ...
+	Enabled AcceleratedNetworking = "Enabled"
+)
+
+// PossibleAcceleratedNetworkingValues returns an array of possible values for the AcceleratedNetworking const type.
+func PossibleAcceleratedNetworkingValues() []AcceleratedNetworking {
+	return []AcceleratedNetworking{Disabled, Enabled}
+}
+
 // CreatedByType enumerates the values for created by type.
 type CreatedByType string
 
@@ -36,19 +51,34 @@ func PossibleCreatedByTypeValues() []CreatedByType {
 	return []CreatedByType{Application, Key, ManagedIdentity, User}
 }
 
+// DiskType enumerates the values for disk type.
+type DiskType string
+
+const (
+	// PremiumLRS ...
+	PremiumLRS DiskType = "Premium_LRS"
+	// UltraSSDLRS ...
+	UltraSSDLRS DiskType = "UltraSSD_LRS"
+)
+
+// PossibleDiskTypeValues returns an array of possible values for the DiskType const type.
+func PossibleDiskTypeValues() []DiskType {
+	return []DiskType{PremiumLRS, UltraSSDLRS}
+}
+
 // EncryptionAtHost enumerates the values for encryption at host.
 type EncryptionAtHost string
 
 const (
-	// Disabled ...
-	Disabled EncryptionAtHost = "Disabled"
-	// Enabled ...
-	Enabled EncryptionAtHost = "Enabled"
+	// EncryptionAtHostDisabled ...
+	EncryptionAtHostDisabled EncryptionAtHost = "Disabled"
+	// EncryptionAtHostEnabled ...
+	EncryptionAtHostEnabled EncryptionAtHost = "Enabled"
 )
 
 // PossibleEncryptionAtHostValues returns an array of possible values for the EncryptionAtHost const type.
 func PossibleEncryptionAtHostValues() []EncryptionAtHost {
-	return []EncryptionAtHost{Disabled, Enabled}
+	return []EncryptionAtHost{EncryptionAtHostDisabled, EncryptionAtHostEnabled}
 }
 
 // FipsValidatedModules enumerates the values for fips validated modules.
@@ -121,6 +151,19 @@ func PossibleProvisioningStateValues() []ProvisioningState {
 	return []ProvisioningState{AdminUpdating, Canceled, Creating, Deleting, Failed, Succeeded, Updating}
 }
 
+// SpotEvictionPolicy enumerates the values for spot eviction policy.
+type SpotEvictionPolicy string
+
+const (
+	// Delete ...
+	Delete SpotEvictionPolicy = "Delete"
+)
+
+// PossibleSpotEvictionPolicyValues returns an array of possible values for the SpotEvictionPolicy const type.
+func PossibleSpotEvictionPolicyValues() []SpotEvictionPolicy {
+	return []SpotEvictionPolicy{Delete}
+}
+
 // Visibility enumerates the values for visibility.
 type Visibility string
 
@@ -135,3 +178,18 @@ const (
 func PossibleVisibilityValues() []Visibility {
 	return []Visibility{Private, Public}
 }
+
+// ZonePlacement enumerates the values for zone placement.
+type ZonePlacement string
+
+const (
+	// Automatic ...
+	Automatic ZonePlacement = "Automatic"
+	// NonZonal ...
+	NonZonal ZonePlacement = "NonZonal"
+)
+
+// PossibleZonePlacementValues returns an array of possible values for the ZonePlacement const type.
+func PossibleZonePlacementValues() []ZonePlacement {
+	return []ZonePlacement{Automatic, NonZonal}
+}
@@ -40,6 +40,8 @@ type APIServerProfile struct {
 	URL *string `json:"url,omitempty"`
 	// IP - READ-ONLY; The IP of the cluster API server.
 	IP *string `json:"ip,omitempty"`
+	// Ipv6 - READ-ONLY; The IPv6 IP of the cluster API server of a dual-stack cluster.
+	Ipv6 *string `json:"ipv6,omitempty"`
 }
 
 // MarshalJSON is the custom marshaler for APIServerProfile.
@@ -85,6 +87,10 @@ type CloudErrorBody struct {
 	Message *string `json:"message,omitempty"`
 	// Target - The target of the particular error. For example, the name of the property in error.
 	Target *string `json:"target,omitempty"`
+	// TargetResourceID - The resource ID of the Azure resource in error, if any.
+	TargetResourceID *string `json:"targetResourceId,omitempty"`
+	// RemediationURL - A link to documentation describing how to resolve the error, if any.
+	RemediationURL *string `json:"remediationUrl,omitempty"`
 	// Details - A list of additional details about the error.
 	Details *[]CloudErrorBody `json:"details,omitempty"`
 }
@@ -124,6 +130,13 @@ func (cp ConsoleProfile) MarshalJSON() ([]byte, error) {
 	return json.Marshal(objectMap)
 }
 
+// DiagnosticSettingsProfile diagnosticSettingsProfile represents the Azure Monitor diagnostic settings of
+// the cluster's load balancers, network security groups and key vaults.
+type DiagnosticSettingsProfile struct {
+	// WorkspaceResourceID - The resource ID of the Log Analytics workspace to which logs and metrics are sent.
+	WorkspaceResourceID *string `json:"workspaceResourceId,omitempty"`
+}
+
 // Display display represents the display details of an operation.
 type Display struct {
 	// Provider - Friendly name of the resource provider.
@@ -169,6 +182,8 @@ type IngressProfile struct {
 	Visibility Visibility `json:"visibility,omitempty"`
 	// IP - READ-ONLY; The IP of the ingress.
 	IP *string `json:"ip,omitempty"`
+	// Ipv6 - READ-ONLY; The IPv6 IP of the ingress of a dual-stack cluster.
+	Ipv6 *string `json:"ipv6,omitempty"`
 }
 
 // MarshalJSON is the custom marshaler for IngressProfile.
@@ -511,10 +526,23 @@ type MasterProfile struct {
 	VMSize *string `json:"vmSize,omitempty"`
 	// SubnetID - The Azure resource ID of the master subnet.
 	SubnetID *string `json:"subnetId,omitempty"`
-	// EncryptionAtHost - Whether master virtual machines are encrypted at host. Possible values include: 'Disabled', 'Enabled'
+	// EncryptionAtHost - Whether master virtual machines are encrypted at host. Possible values include: 'EncryptionAtHostDisabled', 'EncryptionAtHostEnabled'
 	EncryptionAtHost EncryptionAtHost `json:"encryptionAtHost,omitempty"`
 	// DiskEncryptionSetID - The resource ID of an associated DiskEncryptionSet, if applicable.
 	DiskEncryptionSetID *string `json:"diskEncryptionSetId,omitempty"`
+	// DiskType - The managed disk type of the master VMs.  OS disks are always Premium_LRS; UltraSSD_LRS also allows Ultra disks to be attached. Possible values include: 'PremiumLRS', 'UltraSSDLRS'
+	DiskType DiskType `json:"diskType,omitempty"`
+	// AcceleratedNetworking - Whether the master VMs use accelerated networking. Possible values include: 'Disabled', 'Enabled'
+	AcceleratedNetworking AcceleratedNetworking `json:"acceleratedNetworking,omitempty"`
+	// Zones - The availability zones of the master VMs.  If empty, the zones are selected by the service.
+	Zones *[]string `json:"zones,omitempty"`
+}
+
+// MaxNodesProfile maxNodesProfile represents the largest number of worker nodes which a large cluster is
+// sized for.
+type MaxNodesProfile struct {
+	// MaxNodes - The maximum number of worker nodes.
+	MaxNodes *int32 `json:"maxNodes,omitempty"`
 }
 
 // NetworkProfile networkProfile represents a network profile.
@@ -523,12 +551,18 @@ type NetworkProfile struct {
 	PodCidr *string `json:"podCidr,omitempty"`
 	// ServiceCidr - The CIDR used for OpenShift/Kubernetes Services.
 	ServiceCidr *string `json:"serviceCidr,omitempty"`
+	// PodCidrIpv6 - The IPv6 CIDR used for OpenShift/Kubernetes Pods of a dual-stack cluster.
+	PodCidrIpv6 *string `json:"podCidrIpv6,omitempty"`
+	// ServiceCidrIpv6 - The IPv6 CIDR used for OpenShift/Kubernetes Services of a dual-stack cluster.
+	ServiceCidrIpv6 *string `json:"serviceCidrIpv6,omitempty"`
 	// OutboundType - The OutboundType used for egress traffic. Possible values include: 'Loadbalancer', 'UserDefinedRouting'
 	OutboundType OutboundType `json:"outboundType,omitempty"`
 	// LoadBalancerProfile - The cluster load balancer profile.
 	LoadBalancerProfile *LoadBalancerProfile `json:"loadBalancerProfile,omitempty"`
 	// PreconfiguredNSG - Specifies whether subnets are pre-attached with an NSG. Possible values include: 'PreconfiguredNSGDisabled', 'PreconfiguredNSGEnabled'
 	PreconfiguredNSG PreconfiguredNSG `json:"preconfiguredNSG,omitempty"`
+	// PublicIPPrefixID - The resource ID of a public IP prefix which the public IPs of the API server and the default ingress are allocated from.
+	PublicIPPrefixID *string `json:"publicIpPrefixId,omitempty"`
 }
 
 // OpenShiftCluster openShiftCluster represents an Azure Red Hat OpenShift cluster.
@@ -839,10 +873,16 @@ type OpenShiftClusterProperties struct {
 	WorkerProfiles *[]WorkerProfile `json:"workerProfiles,omitempty"`
 	// WorkerProfilesStatus - READ-ONLY; The cluster worker profiles status.
 	WorkerProfilesStatus *[]WorkerProfile `json:"workerProfilesStatus,omitempty"`
+	// ZonePlacement - Whether the cluster VMs are placed in availability zones.  With Automatic, the zones of profiles which do not list their own are selected by the service. Possible values include: 'Automatic', 'NonZonal'
+	ZonePlacement ZonePlacement `json:"zonePlacement,omitempty"`
 	// ApiserverProfile - The cluster API server profile.
 	ApiserverProfile *APIServerProfile `json:"apiserverProfile,omitempty"`
 	// IngressProfiles - The cluster ingress profiles.
 	IngressProfiles *[]IngressProfile `json:"ingressProfiles,omitempty"`
+	// DiagnosticSettingsProfile - The cluster diagnostic settings profile.
+	DiagnosticSettingsProfile *DiagnosticSettingsProfile `json:"diagnosticSettingsProfile,omitempty"`
+	// MaxNodesProfile - The cluster max nodes profile.
+	MaxNodesProfile *MaxNodesProfile `json:"maxNodesProfile,omitempty"`
 }
 
 // MarshalJSON is the custom marshaler for OpenShiftClusterProperties.
@@ -872,12 +912,21 @@ func (oscp OpenShiftClusterProperties) MarshalJSON() ([]byte, error) {
 	if oscp.WorkerProfiles != nil {
 		objectMap["workerProfiles"] = oscp.WorkerProfiles
 	}
+	if oscp.ZonePlacement != "" {
+		objectMap["zonePlacement"] = oscp.ZonePlacement
+	}
 	if oscp.ApiserverProfile != nil {
 		objectMap["apiserverProfile"] = oscp.ApiserverProfile
 	}
 	if oscp.IngressProfiles != nil {
 		objectMap["ingressProfiles"] = oscp.IngressProfiles
 	}
+	if oscp.DiagnosticSettingsProfile != nil {
+		objectMap["diagnosticSettingsProfile"] = oscp.DiagnosticSettingsProfile
+	}
+	if oscp.MaxNodesProfile != nil {
+		objectMap["maxNodesProfile"] = oscp.MaxNodesProfile
+	}
 	return json.Marshal(objectMap)
 }
 
@@ -1872,6 +1921,14 @@ type ServicePrincipalProfile struct {
 	ClientSecret *string `json:"clientSecret,omitempty"`
 }
 
+// SpotVMOptions spotVMOptions represents the Spot VM options of a worker profile.
+type SpotVMOptions struct {
+	// MaxPrice - The maximum hourly price in US dollars, or -1 to pay up to the on-demand price.
+	MaxPrice *float64 `json:"maxPrice,omitempty"`
+	// EvictionPolicy - What happens to a worker VM when it is evicted. Possible values include: 'Delete'
+	EvictionPolicy SpotEvictionPolicy `json:"evictionPolicy,omitempty"`
+}
+
 // SyncIdentityProvider syncIdentityProvider represents a SyncIdentityProvider
 type SyncIdentityProvider struct {
 	autorest.Response `json:"-"`
@@ -2518,7 +2575,7 @@ func (tr TrackedResource) MarshalJSON() ([]byte, error) {
 type WorkerProfile struct {
 	// Name - The worker profile name.
 	Name *string `json:"name,omitempty"`
-	// VMSize - The size of the worker VMs.
+	// VMSize - The size of the worker VMs.  Changing it on an existing cluster replaces the worker VMs of the profile with VMs of the new size.
 	VMSize *string `json:"vmSize,omitempty"`
 	// DiskSizeGB - The disk size of the worker VMs.
 	DiskSizeGB *int32 `json:"diskSizeGB,omitempty"`
@@ -2526,8 +2583,18 @@ type WorkerProfile struct {
 	SubnetID *string `json:"subnetId,omitempty"`
 	// Count - The number of worker VMs.
 	Count *int32 `json:"count,omitempty"`
-	// EncryptionAtHost - Whether master virtual machines are encrypted at host. Possible values include: 'Disabled', 'Enabled'
+	// EncryptionAtHost - Whether master virtual machines are encrypted at host. Possible values include: 'EncryptionAtHostDisabled', 'EncryptionAtHostEnabled'
 	EncryptionAtHost EncryptionAtHost `json:"encryptionAtHost,omitempty"`
 	// DiskEncryptionSetID - The resource ID of an associated DiskEncryptionSet, if applicable.
 	DiskEncryptionSetID *string `json:"diskEncryptionSetId,omitempty"`
+	// DiskType - The managed disk type of the worker VMs.  OS disks are always Premium_LRS; UltraSSD_LRS also allows Ultra disks to be attached. Possible values include: 'PremiumLRS', 'UltraSSDLRS'
+	DiskType DiskType `json:"diskType,omitempty"`
+	// AcceleratedNetworking - Whether the worker VMs use accelerated networking. Possible values include: 'Disabled', 'Enabled'
+	AcceleratedNetworking AcceleratedNetworking `json:"acceleratedNetworking,omitempty"`
+	// Zones - The availability zones across which the worker VMs are spread.  If empty, the zones are selected by the service.
+	Zones *[]string `json:"zones,omitempty"`
+	// SpotVMOptions - The Spot VM options, if the worker VMs are Azure Spot VMs.
+	SpotVMOptions *SpotVMOptions `json:"spotVMOptions,omitempty"`
+	// MaxSurge - The number of worker VMs of the new size which may be created above the worker count of each MachineSet while the worker VMs are replaced after a change of size.  Defaults to 1.
+	MaxSurge *int32 `json:"maxSurge,omitempty"`
 }
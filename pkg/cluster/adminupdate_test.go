@@ -10,6 +10,7 @@ import (
 	"github.com/go-test/deep"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/operator"
 )
 
 func TestAdminUpdateSteps(t *testing.T) {
@@ -118,9 +119,12 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureResourceGroup-fm]",
 				"[Action createOrUpdateDenyAssignment-fm]",
 				"[Action ensureServiceEndpoints-fm]",
+				"[Action attachNSGs-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
 				"[Action migrateStorageAccounts-fm]",
 				"[Action fixSSH-fm]",
+				"[Action ensureSSHCAKey-fm]",
+				"[Action ensureBreakGlassCA-fm]",
 				"[Action populateDatabaseIntIP-fm]",
 				"[Action startVMs-fm]",
 				"[Condition apiServersReady-fm, timeout 30m0s]",
@@ -130,11 +134,72 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action fixMCSCert-fm]",
 				"[Action fixMCSUserData-fm]",
 				"[Action ensureGatewayUpgrade-fm]",
+				"[Action ensureGatewayKey-fm]",
 				"[Action rotateACRTokenPassword-fm]",
 				"[Action configureAPIServerCertificate-fm]",
 				"[Action configureIngressCertificate-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
 				"[Action ensureMTUSize-fm]",
+				"[Action ensureSSHCAMachineConfigs-fm]",
+				"[Action ensureBreakGlassClientCA-fm]",
+				"[Action registerOCMCluster-fm]",
+				"[Action initializeOperatorDeployer-fm]",
+				"[Action ensureAROOperator-fm]",
+				"[Condition aroDeploymentReady-fm, timeout 20m0s]",
+				"[Condition ensureAROOperatorRunningDesiredVersion-fm, timeout 5m0s]",
+				"[Action hiveCreateNamespace-fm]",
+				"[Action hiveEnsureResources-fm]",
+				"[Condition hiveClusterDeploymentReady-fm, timeout 5m0s]",
+				"[Action hiveResetCorrelationData-fm]",
+				"[Action updateProvisionedBy-fm]",
+			},
+		},
+		{
+			name: "Everything update with private DNS zone removal enabled",
+			fixture: func() (*api.OpenShiftClusterDocument, bool) {
+				doc := baseClusterDoc()
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskEverything
+				doc.OpenShiftCluster.Properties.OperatorFlags = api.OperatorFlags{
+					operator.DnsmasqEnabled:               operator.FlagTrue,
+					operator.PrivateDNSZoneRemovalEnabled: operator.FlagTrue,
+				}
+				return doc, true
+			},
+			shouldRunSteps: []string{
+				"[Action initializeKubernetesClients-fm]",
+				"[Action ensureBillingRecord-fm]",
+				"[Action ensureDefaults-fm]",
+				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
+				"[Action fixInfraID-fm]",
+				"[Action ensureResourceGroup-fm]",
+				"[Action createOrUpdateDenyAssignment-fm]",
+				"[Action ensureServiceEndpoints-fm]",
+				"[Action attachNSGs-fm]",
+				"[Action populateRegistryStorageAccountName-fm]",
+				"[Action migrateStorageAccounts-fm]",
+				"[Action fixSSH-fm]",
+				"[Action ensureSSHCAKey-fm]",
+				"[Action ensureBreakGlassCA-fm]",
+				"[Action populateDatabaseIntIP-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action fixSREKubeconfig-fm]",
+				"[Action fixUserAdminKubeconfig-fm]",
+				"[Action createOrUpdateRouterIPFromCluster-fm]",
+				"[Action fixMCSCert-fm]",
+				"[Action fixMCSUserData-fm]",
+				"[Action ensureGatewayUpgrade-fm]",
+				"[Action ensureGatewayKey-fm]",
+				"[Action rotateACRTokenPassword-fm]",
+				"[Action configureAPIServerCertificate-fm]",
+				"[Action configureIngressCertificate-fm]",
+				"[Action populateRegistryStorageAccountName-fm]",
+				"[Action ensureMTUSize-fm]",
+				"[Action ensureSSHCAMachineConfigs-fm]",
+				"[Action ensureBreakGlassClientCA-fm]",
+				"[Action registerOCMCluster-fm]",
+				"[Action removePrivateDNSZone-fm]",
 				"[Action initializeOperatorDeployer-fm]",
 				"[Action ensureAROOperator-fm]",
 				"[Condition aroDeploymentReady-fm, timeout 20m0s]",
@@ -164,9 +229,12 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureResourceGroup-fm]",
 				"[Action createOrUpdateDenyAssignment-fm]",
 				"[Action ensureServiceEndpoints-fm]",
+				"[Action attachNSGs-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
 				"[Action migrateStorageAccounts-fm]",
 				"[Action fixSSH-fm]",
+				"[Action ensureSSHCAKey-fm]",
+				"[Action ensureBreakGlassCA-fm]",
 				"[Action populateDatabaseIntIP-fm]",
 				"[Action startVMs-fm]",
 				"[Condition apiServersReady-fm, timeout 30m0s]",
@@ -176,11 +244,15 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action fixMCSCert-fm]",
 				"[Action fixMCSUserData-fm]",
 				"[Action ensureGatewayUpgrade-fm]",
+				"[Action ensureGatewayKey-fm]",
 				"[Action rotateACRTokenPassword-fm]",
 				"[Action configureAPIServerCertificate-fm]",
 				"[Action configureIngressCertificate-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
 				"[Action ensureMTUSize-fm]",
+				"[Action ensureSSHCAMachineConfigs-fm]",
+				"[Action ensureBreakGlassClientCA-fm]",
+				"[Action registerOCMCluster-fm]",
 				"[Action initializeOperatorDeployer-fm]",
 				"[Action hiveCreateNamespace-fm]",
 				"[Action hiveEnsureResources-fm]",
@@ -206,9 +278,12 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureResourceGroup-fm]",
 				"[Action createOrUpdateDenyAssignment-fm]",
 				"[Action ensureServiceEndpoints-fm]",
+				"[Action attachNSGs-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
 				"[Action migrateStorageAccounts-fm]",
 				"[Action fixSSH-fm]",
+				"[Action ensureSSHCAKey-fm]",
+				"[Action ensureBreakGlassCA-fm]",
 				"[Action populateDatabaseIntIP-fm]",
 				"[Action startVMs-fm]",
 				"[Condition apiServersReady-fm, timeout 30m0s]",
@@ -218,11 +293,15 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action fixMCSCert-fm]",
 				"[Action fixMCSUserData-fm]",
 				"[Action ensureGatewayUpgrade-fm]",
+				"[Action ensureGatewayKey-fm]",
 				"[Action rotateACRTokenPassword-fm]",
 				"[Action configureAPIServerCertificate-fm]",
 				"[Action configureIngressCertificate-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
 				"[Action ensureMTUSize-fm]",
+				"[Action ensureSSHCAMachineConfigs-fm]",
+				"[Action ensureBreakGlassClientCA-fm]",
+				"[Action registerOCMCluster-fm]",
 				"[Action initializeOperatorDeployer-fm]",
 				"[Action ensureAROOperator-fm]",
 				"[Condition aroDeploymentReady-fm, timeout 20m0s]",
@@ -247,9 +326,12 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureResourceGroup-fm]",
 				"[Action createOrUpdateDenyAssignment-fm]",
 				"[Action ensureServiceEndpoints-fm]",
+				"[Action attachNSGs-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
 				"[Action migrateStorageAccounts-fm]",
 				"[Action fixSSH-fm]",
+				"[Action ensureSSHCAKey-fm]",
+				"[Action ensureBreakGlassCA-fm]",
 				"[Action populateDatabaseIntIP-fm]",
 				"[Action startVMs-fm]",
 				"[Condition apiServersReady-fm, timeout 30m0s]",
@@ -259,11 +341,15 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action fixMCSCert-fm]",
 				"[Action fixMCSUserData-fm]",
 				"[Action ensureGatewayUpgrade-fm]",
+				"[Action ensureGatewayKey-fm]",
 				"[Action rotateACRTokenPassword-fm]",
 				"[Action configureAPIServerCertificate-fm]",
 				"[Action configureIngressCertificate-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
 				"[Action ensureMTUSize-fm]",
+				"[Action ensureSSHCAMachineConfigs-fm]",
+				"[Action ensureBreakGlassClientCA-fm]",
+				"[Action registerOCMCluster-fm]",
 				"[Action initializeOperatorDeployer-fm]",
 				"[Action ensureAROOperator-fm]",
 				"[Condition aroDeploymentReady-fm, timeout 20m0s]",
@@ -300,6 +386,25 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action renewMDSDCertificate-fm]",
 			},
 		},
+		{
+			name: "Rotate ACR token",
+			fixture: func() (*api.OpenShiftClusterDocument, bool) {
+				doc := baseClusterDoc()
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskRotateACRToken
+				return doc, true
+			},
+			shouldRunSteps: []string{
+				"[Action initializeKubernetesClients-fm]",
+				"[Action ensureBillingRecord-fm]",
+				"[Action ensureDefaults-fm]",
+				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
+				"[Action fixInfraID-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action rotateACRTokenPassword-fm]",
+			},
+		},
 		{
 			name: "adminUpdate() does not adopt Hive-created clusters",
 			fixture: func() (*api.OpenShiftClusterDocument, bool) {
@@ -319,9 +424,12 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureResourceGroup-fm]",
 				"[Action createOrUpdateDenyAssignment-fm]",
 				"[Action ensureServiceEndpoints-fm]",
+				"[Action attachNSGs-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
 				"[Action migrateStorageAccounts-fm]",
 				"[Action fixSSH-fm]",
+				"[Action ensureSSHCAKey-fm]",
+				"[Action ensureBreakGlassCA-fm]",
 				"[Action populateDatabaseIntIP-fm]",
 				"[Action startVMs-fm]",
 				"[Condition apiServersReady-fm, timeout 30m0s]",
@@ -331,11 +439,15 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action fixMCSCert-fm]",
 				"[Action fixMCSUserData-fm]",
 				"[Action ensureGatewayUpgrade-fm]",
+				"[Action ensureGatewayKey-fm]",
 				"[Action rotateACRTokenPassword-fm]",
 				"[Action configureAPIServerCertificate-fm]",
 				"[Action configureIngressCertificate-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
 				"[Action ensureMTUSize-fm]",
+				"[Action ensureSSHCAMachineConfigs-fm]",
+				"[Action ensureBreakGlassClientCA-fm]",
+				"[Action registerOCMCluster-fm]",
 				"[Action initializeOperatorDeployer-fm]",
 				"[Action ensureAROOperator-fm]",
 				"[Condition aroDeploymentReady-fm, timeout 20m0s]",
@@ -0,0 +1,84 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/breakglass"
+)
+
+func mutateBreakGlassCA(doc *api.OpenShiftClusterDocument) error {
+	if doc.OpenShiftCluster.Properties.BreakGlassCA != nil {
+		return nil
+	}
+
+	ca, err := breakglass.NewCA()
+	if err != nil {
+		return err
+	}
+
+	doc.OpenShiftCluster.Properties.BreakGlassCA = ca
+
+	return nil
+}
+
+func (m *manager) ensureBreakGlassCA(ctx context.Context) error {
+	updatedDoc, err := m.db.PatchWithLease(ctx, m.doc.Key, mutateBreakGlassCA)
+	m.doc = updatedDoc
+
+	return err
+}
+
+// ensureBreakGlassClientCA adds the cluster break-glass certificate authority
+// to the client CA bundle trusted by the API server for admin kubeconfigs,
+// replacing any previous one
+func (m *manager) ensureBreakGlassClientCA(ctx context.Context) error {
+	if m.doc.OpenShiftCluster.Properties.BreakGlassCA == nil {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := m.kubernetescli.CoreV1().ConfigMaps(breakglass.ClientCANamespace).Get(ctx, breakglass.ClientCAName, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			bundle, _, err := breakglass.UpdateClientCABundle("", m.doc.OpenShiftCluster.Properties.BreakGlassCA)
+			if err != nil {
+				return err
+			}
+
+			_, err = m.kubernetescli.CoreV1().ConfigMaps(breakglass.ClientCANamespace).Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      breakglass.ClientCAName,
+					Namespace: breakglass.ClientCANamespace,
+				},
+				Data: map[string]string{
+					breakglass.ClientCAKey: bundle,
+				},
+			}, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		bundle, changed, err := breakglass.UpdateClientCABundle(cm.Data[breakglass.ClientCAKey], m.doc.OpenShiftCluster.Properties.BreakGlassCA)
+		if err != nil || !changed {
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[breakglass.ClientCAKey] = bundle
+
+		_, err = m.kubernetescli.CoreV1().ConfigMaps(breakglass.ClientCANamespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
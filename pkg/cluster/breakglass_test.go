@@ -0,0 +1,130 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/breakglass"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
+)
+
+func TestEnsureBreakGlassClientCA(t *testing.T) {
+	ctx := context.Background()
+
+	ca, err := breakglass.NewCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	staleCA, err := breakglass.NewCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, caCerts, err := utilpem.Parse(ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, staleCerts, err := utilpem.Parse(staleCA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, signerCerts, err := utiltls.GenerateKeyAndCertificate("admin-kubeconfig-signer", nil, nil, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	existingBundle, err := utilpem.Encode(signerCerts[0], staleCerts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name         string
+		breakGlassCA []byte
+		objects      []runtime.Object
+		wantCerts    []string
+	}{
+		{
+			name: "no certificate authority",
+		},
+		{
+			name:         "creates the bundle",
+			breakGlassCA: ca,
+			wantCerts:    []string{string(caCerts[0].Raw)},
+		},
+		{
+			name:         "replaces the previous certificate authority",
+			breakGlassCA: ca,
+			objects: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      breakglass.ClientCAName,
+						Namespace: breakglass.ClientCANamespace,
+					},
+					Data: map[string]string{
+						breakglass.ClientCAKey: string(existingBundle),
+					},
+				},
+			},
+			wantCerts: []string{string(signerCerts[0].Raw), string(caCerts[0].Raw)},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			kubernetescli := fake.NewSimpleClientset(tt.objects...)
+
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							BreakGlassCA: tt.breakGlassCA,
+						},
+					},
+				},
+				kubernetescli: kubernetescli,
+			}
+
+			err := m.ensureBreakGlassClientCA(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cm, err := kubernetescli.CoreV1().ConfigMaps(breakglass.ClientCANamespace).Get(ctx, breakglass.ClientCAName, metav1.GetOptions{})
+			if tt.wantCerts == nil {
+				if err == nil {
+					t.Errorf("unexpected configmap %v", cm.Data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, certs, err := utilpem.Parse([]byte(cm.Data[breakglass.ClientCAKey]))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(certs) != len(tt.wantCerts) {
+				t.Fatalf("expected %d certificates, got %d", len(tt.wantCerts), len(certs))
+			}
+			for i, cert := range certs {
+				if string(cert.Raw) != tt.wantCerts[i] {
+					t.Errorf("unexpected certificate %d %s", i, cert.Subject.CommonName)
+				}
+			}
+		})
+	}
+}
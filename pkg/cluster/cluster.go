@@ -33,12 +33,14 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/authorization"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/insights"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/privatedns"
 	"github.com/Azure/ARO-RP/pkg/util/billing"
 	"github.com/Azure/ARO-RP/pkg/util/dns"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
 	utilgraph "github.com/Azure/ARO-RP/pkg/util/graph"
+	"github.com/Azure/ARO-RP/pkg/util/ocm"
 	"github.com/Azure/ARO-RP/pkg/util/refreshable"
 	"github.com/Azure/ARO-RP/pkg/util/storage"
 	"github.com/Azure/ARO-RP/pkg/util/subnet"
@@ -60,6 +62,7 @@ type manager struct {
 	dbOpenShiftVersions database.OpenShiftVersions
 
 	billing           billing.Manager
+	ocm               ocm.Client
 	doc               *api.OpenShiftClusterDocument
 	subscriptionDoc   *api.SubscriptionDocument
 	fpAuthorizer      refreshable.Authorizer
@@ -71,9 +74,13 @@ type manager struct {
 	virtualMachines       compute.VirtualMachinesClient
 	interfaces            network.InterfacesClient
 	publicIPAddresses     network.PublicIPAddressesClient
+	computeUsage          compute.UsageClient
+	networkUsage          network.UsageClient
 	loadBalancers         network.LoadBalancersClient
 	privateEndpoints      network.PrivateEndpointsClient
 	securityGroups        network.SecurityGroupsClient
+	diagnosticSettings    insights.DiagnosticSettingsClient
+	diagnosticCategories  insights.DiagnosticSettingsCategoryClient
 	deployments           features.DeploymentsClient
 	resourceGroups        features.ResourceGroupsClient
 	resources             features.ResourcesClient
@@ -140,6 +147,11 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database
 
 	storage := storage.NewManager(_env, r.SubscriptionID, fpAuthorizer)
 
+	ocmClient, err := ocm.NewClientFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
 	installViaHive, err := _env.LiveConfig().InstallViaHive(ctx)
 	if err != nil {
 		return nil, err
@@ -157,6 +169,7 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database
 		dbGateway:             dbGateway,
 		dbOpenShiftVersions:   dbOpenShiftVersions,
 		billing:               billing,
+		ocm:                   ocmClient,
 		doc:                   doc,
 		subscriptionDoc:       subscriptionDoc,
 		fpAuthorizer:          fpAuthorizer,
@@ -166,9 +179,13 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database
 		virtualMachines:       compute.NewVirtualMachinesClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		interfaces:            network.NewInterfacesClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		publicIPAddresses:     network.NewPublicIPAddressesClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
+		computeUsage:          compute.NewUsageClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
+		networkUsage:          network.NewUsageClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		loadBalancers:         network.NewLoadBalancersClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		privateEndpoints:      network.NewPrivateEndpointsClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		securityGroups:        network.NewSecurityGroupsClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
+		diagnosticSettings:    insights.NewDiagnosticSettingsClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
+		diagnosticCategories:  insights.NewDiagnosticSettingsCategoryClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		deployments:           features.NewDeploymentsClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		resourceGroups:        features.NewResourceGroupsClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		resources:             features.NewResourcesClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
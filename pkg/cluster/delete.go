@@ -26,6 +26,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
 	"github.com/Azure/ARO-RP/pkg/util/dns"
 	"github.com/Azure/ARO-RP/pkg/util/rbac"
+	"github.com/Azure/ARO-RP/pkg/util/steps"
 	"github.com/Azure/ARO-RP/pkg/util/stringutils"
 )
 
@@ -337,18 +338,19 @@ func (m *manager) deleteGatewayAndWait(ctx context.Context) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
-	if m.doc.OpenShiftCluster.Properties.NetworkProfile.GatewayPrivateLinkID == "" {
-		return nil
+	linkID, err := m.gatewayPrivateLinkID(ctx)
+	if err != nil || linkID == "" {
+		return err
 	}
 
-	err := m.deleteGateway(ctx)
+	err = m.deleteGateway(ctx, linkID)
 	if err != nil {
 		return err
 	}
 
 	m.log.Info("waiting for gateway record deletion")
 	return wait.PollImmediateUntil(15*time.Second, func() (bool, error) {
-		_, err := m.dbGateway.Get(ctx, m.doc.OpenShiftCluster.Properties.NetworkProfile.GatewayPrivateLinkID)
+		_, err := m.dbGateway.Get(ctx, linkID)
 		if err != nil && cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) /* already gone */ {
 			return true, nil
 		}
@@ -356,13 +358,33 @@ func (m *manager) deleteGatewayAndWait(ctx context.Context) error {
 	}, timeoutCtx.Done())
 }
 
-func (m *manager) deleteGateway(ctx context.Context) error {
-	if m.doc.OpenShiftCluster.Properties.NetworkProfile.GatewayPrivateLinkID == "" {
-		return nil
+// gatewayPrivateLinkID returns the private link ID of the cluster's gateway
+// record.  If the cluster document does not record it, for example because
+// cluster creation failed after the gateway record was created, the record is
+// looked up by cluster resource ID instead.
+func (m *manager) gatewayPrivateLinkID(ctx context.Context) (string, error) {
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.GatewayPrivateLinkID != "" {
+		return m.doc.OpenShiftCluster.Properties.NetworkProfile.GatewayPrivateLinkID, nil
 	}
 
+	if !m.doc.OpenShiftCluster.Properties.FeatureProfile.GatewayEnabled {
+		return "", nil
+	}
+
+	doc, err := m.dbGateway.GetByClusterResourceID(ctx, m.doc.Key)
+	if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return doc.ID, nil
+}
+
+func (m *manager) deleteGateway(ctx context.Context, linkID string) error {
 	// https://docs.microsoft.com/en-us/azure/cosmos-db/change-feed-design-patterns#deletes
-	_, err := m.dbGateway.Patch(ctx, m.doc.OpenShiftCluster.Properties.NetworkProfile.GatewayPrivateLinkID, func(doc *api.GatewayDocument) error {
+	_, err := m.dbGateway.Patch(ctx, linkID, func(doc *api.GatewayDocument) error {
 		doc.Gateway.Deleting = true
 		doc.TTL = 60
 		return nil
@@ -422,94 +444,147 @@ func (m *manager) deleteResourceGroup(ctx context.Context, name string) error {
 	return err
 }
 
+const (
+	deleteOperation = "delete"
+
+	// deleteParallelism is the number of steps of a cluster deletion which
+	// are run at once
+	deleteParallelism = 4
+)
+
+// Delete deletes the cluster's resources.  The deletion steps run as a
+// dependency graph, and the status of each step is recorded on the cluster
+// document so that a retried deletion skips the steps which have succeeded.
 func (m *manager) Delete(ctx context.Context) error {
-	m.log.Printf("running ensureResourceGroup")
-	err := m.ensureResourceGroup(ctx) // re-create RP RBAC if needed/missing on best-effort basics
-	if err != nil {
-		m.log.Error(err)
+	var statuses map[string]api.StepStatus
+	if m.doc.Progress != nil && m.doc.Progress.Operation == deleteOperation {
+		statuses = m.doc.Progress.StepStatuses
 	}
 
-	m.log.Printf("deleting dns")
-	err = m.dns.Delete(ctx, m.doc.OpenShiftCluster)
-	if err != nil {
-		return err
+	// ensureResourceGroup re-creates the resource group if it is missing, so
+	// it must not run once a previous attempt has deleted it
+	if statuses["deleteResourcesAndResourceGroup"] != api.StepStatusSucceeded {
+		m.log.Printf("running ensureResourceGroup")
+		err := m.ensureResourceGroup(ctx) // re-create RP RBAC if needed/missing on best-effort basics
+		if err != nil {
+			m.log.Error(err)
+		}
 	}
 
-	m.log.Print("deleting private endpoint")
-	err = m.fpPrivateEndpoints.DeleteAndWait(ctx, m.env.ResourceGroup(), env.RPPrivateEndpointPrefix+m.doc.ID)
-	if err != nil {
-		return err
+	return steps.RunGraph(ctx, m.log, m.deleteGraph(), deleteParallelism, statuses, m.recordStepStatus(deleteOperation))
+}
+
+// deleteGraph returns the steps of a cluster deletion.  Only
+// deprovisionOCMSubscription updates m.doc, so it runs on its own after the
+// steps which can run in parallel; billing is ended last.
+func (m *manager) deleteGraph() []steps.Node {
+	nodes := []steps.Node{
+		{Name: "deleteDNS", Step: steps.Action(m.deleteDNS)},
+		{Name: "deletePrivateEndpoint", Step: steps.Action(m.deletePrivateEndpoint)},
+		{Name: "deleteRoleAssignments", Step: steps.Action(m.deleteRoleAssignments)},
+		// a role definition cannot be deleted while it is assigned
+		{Name: "deleteRoleDefinition", Step: steps.Action(m.deleteRoleDefinition), DependsOn: []string{"deleteRoleAssignments"}},
+		// private endpoint LinkIDs are reused so we wait for the deletion of
+		// the gateway LinkID record before deleting the private endpoint in
+		// the cluster resource group.  This ensures that we don't delete a
+		// LinkID record that was previously in use on a newly created cluster
+		{Name: "deleteGatewayAndWait", Step: steps.Action(m.deleteGatewayAndWait)},
+		// the role assignments are listed from the resource group, so it is
+		// deleted once they are
+		{Name: "deleteResourcesAndResourceGroup", Step: steps.Action(m.deleteResourcesAndResourceGroup), DependsOn: []string{"deleteGatewayAndWait", "deleteRoleAssignments", "deleteRoleDefinition"}},
+		{Name: "deleteSignedCertificates", Step: steps.Action(m.deleteSignedCertificates)},
+		{Name: "deleteACRToken", Step: steps.Action(m.deleteACRToken)},
 	}
 
-	m.log.Printf("deleting role assignments")
-	err = m.deleteRoleAssignments(ctx)
-	if err != nil {
-		return err
+	if m.adoptViaHive || m.installViaHive {
+		nodes = append(nodes, steps.Node{Name: "hiveDeleteResources", Step: steps.Action(m.hiveDeleteResources), DependsOn: []string{"deleteResourcesAndResourceGroup"}})
 	}
 
-	m.log.Printf("deleting role definition")
-	err = m.deleteRoleDefinition(ctx)
-	if err != nil {
-		return err
+	var all []string
+	for _, n := range nodes {
+		all = append(all, n.Name)
 	}
 
-	// private endpoint LinkIDs are reused so we wait for the deletion of the
-	// gateway LinkID record before deleting the private endpoint
-	// this ensures that we don't delete a LinkID record that was previously in use
-	// on a newly created cluster
-	m.log.Printf("deleting gateway record")
-	err = m.deleteGatewayAndWait(ctx)
-	if err != nil {
+	return append(nodes,
+		steps.Node{Name: "deprovisionOCMSubscription", Step: steps.Action(m.deprovisionOCMSubscription), DependsOn: all},
+		steps.Node{Name: "deleteBilling", Step: steps.Action(m.deleteBilling), DependsOn: []string{"deprovisionOCMSubscription"}},
+	)
+}
+
+// recordStepStatus returns a steps.StatusFunc which records the status of
+// each step of the operation on the cluster document.  m.doc is not updated,
+// as the steps may be reading it concurrently.  Failing to record a status
+// does not fail the operation; the step is then re-run by a retry.
+func (m *manager) recordStepStatus(operation string) steps.StatusFunc {
+	return func(ctx context.Context, name string, status api.StepStatus) {
+		_, err := m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+			if doc.Progress == nil || doc.Progress.Operation != operation {
+				doc.Progress = &api.OperationProgress{
+					Operation: operation,
+					StartTime: time.Now().UTC(),
+				}
+			}
+
+			if doc.Progress.StepStatuses == nil {
+				doc.Progress.StepStatuses = map[string]api.StepStatus{}
+			}
+			doc.Progress.StepStatuses[name] = status
+			return nil
+		})
+		if err != nil {
+			m.log.Warnf("failed to record the status of step %s: %s", name, err)
+		}
+	}
+}
+
+func (m *manager) deleteDNS(ctx context.Context) error {
+	m.log.Printf("deleting dns")
+	return m.dns.Delete(ctx, m.doc.OpenShiftCluster)
+}
+
+func (m *manager) deletePrivateEndpoint(ctx context.Context) error {
+	m.log.Print("deleting private endpoint")
+	return m.fpPrivateEndpoints.DeleteAndWait(ctx, m.env.ResourceGroup(), env.RPPrivateEndpointPrefix+m.doc.ID)
+}
+
+func (m *manager) deleteSignedCertificates(ctx context.Context) error {
+	if m.env.FeatureIsSet(env.FeatureDisableSignedCertificates) {
+		return nil
+	}
+
+	managedDomain, err := dns.ManagedDomain(m.env, m.doc.OpenShiftCluster.Properties.ClusterProfile.Domain)
+	if err != nil || managedDomain == "" {
 		return err
 	}
 
-	err = m.deleteResourcesAndResourceGroup(ctx)
+	m.log.Print("deleting signed apiserver certificate")
+	err = m.env.ClusterKeyvault().EnsureCertificateDeleted(ctx, m.doc.ID+"-apiserver")
 	if err != nil {
 		return err
 	}
 
-	if !m.env.FeatureIsSet(env.FeatureDisableSignedCertificates) {
-		managedDomain, err := dns.ManagedDomain(m.env, m.doc.OpenShiftCluster.Properties.ClusterProfile.Domain)
-		if err != nil {
-			return err
-		}
-
-		if managedDomain != "" {
-			m.log.Print("deleting signed apiserver certificate")
-			err = m.env.ClusterKeyvault().EnsureCertificateDeleted(ctx, m.doc.ID+"-apiserver")
-			if err != nil {
-				return err
-			}
+	m.log.Print("deleting signed ingress certificate")
+	return m.env.ClusterKeyvault().EnsureCertificateDeleted(ctx, m.doc.ID+"-ingress")
+}
 
-			m.log.Print("deleting signed ingress certificate")
-			err = m.env.ClusterKeyvault().EnsureCertificateDeleted(ctx, m.doc.ID+"-ingress")
-			if err != nil {
-				return err
-			}
-		}
+func (m *manager) deleteACRToken(ctx context.Context) error {
+	if m.env.IsLocalDevelopmentMode() {
+		return nil
 	}
 
-	if !m.env.IsLocalDevelopmentMode() {
-		acrManager, err := acrtoken.NewManager(m.env, m.localFpAuthorizer)
-		if err != nil {
-			return err
-		}
-
-		rp := acrManager.GetRegistryProfile(m.doc.OpenShiftCluster)
-		if rp != nil {
-			err = acrManager.Delete(ctx, rp)
-			if err != nil {
-				return err
-			}
-		}
+	acrManager, err := acrtoken.NewManager(m.env, m.localFpAuthorizer)
+	if err != nil {
+		return err
 	}
 
-	if m.adoptViaHive || m.installViaHive {
-		err = m.hiveDeleteResources(ctx)
-		if err != nil {
-			return err
-		}
+	rp := acrManager.GetRegistryProfile(m.doc.OpenShiftCluster)
+	if rp == nil {
+		return nil
 	}
 
+	return acrManager.Delete(ctx, rp)
+}
+
+func (m *manager) deleteBilling(ctx context.Context) error {
 	return m.billing.Delete(ctx, m.doc)
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
 
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
@@ -22,6 +23,8 @@ import (
 	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
 	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
 	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	"github.com/Azure/ARO-RP/pkg/util/steps"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
@@ -274,3 +277,226 @@ func TestDeleteResourceGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestGatewayPrivateLinkID(t *testing.T) {
+	ctx := context.Background()
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/cluster"
+
+	for _, tt := range []struct {
+		name           string
+		linkID         string
+		gatewayEnabled bool
+		fixture        func(*testdatabase.Fixture)
+		want           string
+	}{
+		{
+			name:   "link ID recorded on the cluster",
+			linkID: "1234",
+			want:   "1234",
+		},
+		{
+			name: "gateway not enabled",
+		},
+		{
+			name:           "gateway enabled, no gateway record",
+			gatewayEnabled: true,
+		},
+		{
+			name:           "gateway enabled, orphaned gateway record",
+			gatewayEnabled: true,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddGatewayDocuments(&api.GatewayDocument{
+					ID:      "5678",
+					Key:     key,
+					Gateway: &api.Gateway{},
+				})
+			},
+			want: "5678",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dbGateway, _ := testdatabase.NewFakeGateway()
+
+			f := testdatabase.NewFixture().WithGateway(dbGateway)
+			if tt.fixture != nil {
+				tt.fixture(f)
+			}
+			err := f.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				dbGateway: dbGateway,
+				doc: &api.OpenShiftClusterDocument{
+					Key: key,
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							NetworkProfile: api.NetworkProfile{
+								GatewayPrivateLinkID: tt.linkID,
+							},
+							FeatureProfile: api.FeatureProfile{
+								GatewayEnabled: tt.gatewayEnabled,
+							},
+						},
+					},
+				},
+			}
+
+			got, err := m.gatewayPrivateLinkID(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestDeleteGraph(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		viaHive bool
+	}{
+		{
+			name: "not via hive",
+		},
+		{
+			name:    "via hive",
+			viaHive: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				installViaHive: tt.viaHive,
+			}
+
+			nodes := m.deleteGraph()
+
+			byName := map[string]steps.Node{}
+			for _, n := range nodes {
+				byName[n.Name] = n
+			}
+
+			if _, ok := byName["hiveDeleteResources"]; ok != tt.viaHive {
+				t.Errorf("hiveDeleteResources present: %t", ok)
+			}
+
+			if !reflect.DeepEqual(byName["deleteResourcesAndResourceGroup"].DependsOn, []string{"deleteGatewayAndWait", "deleteRoleAssignments", "deleteRoleDefinition"}) {
+				t.Errorf("got deleteResourcesAndResourceGroup dependencies %v", byName["deleteResourcesAndResourceGroup"].DependsOn)
+			}
+
+			if !reflect.DeepEqual(byName["deleteBilling"].DependsOn, []string{"deprovisionOCMSubscription"}) {
+				t.Errorf("got deleteBilling dependencies %v", byName["deleteBilling"].DependsOn)
+			}
+
+			// billing is ended, and m.doc is updated, only once every other
+			// step has succeeded
+			for _, n := range nodes[:len(nodes)-2] {
+				found := false
+				for _, d := range byName["deprovisionOCMSubscription"].DependsOn {
+					if d == n.Name {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("deprovisionOCMSubscription does not depend on %s", n.Name)
+				}
+			}
+
+			err := steps.RunGraph(context.Background(), logrus.NewEntry(logrus.StandardLogger()), nodes, deleteParallelism, allSucceeded(nodes), nil)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestDeleteRetryAfterResourceGroupDeleted(t *testing.T) {
+	m := &manager{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		doc: &api.OpenShiftClusterDocument{
+			OpenShiftCluster: &api.OpenShiftCluster{},
+		},
+	}
+	m.doc.Progress = &api.OperationProgress{
+		Operation:    deleteOperation,
+		StepStatuses: allSucceeded(m.deleteGraph()),
+	}
+
+	// the manager has no resource group client, so ensureResourceGroup would
+	// panic if it ran and re-created the deleted resource group
+	err := m.Delete(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func allSucceeded(nodes []steps.Node) map[string]api.StepStatus {
+	statuses := map[string]api.StepStatus{}
+	for _, n := range nodes {
+		statuses[n.Name] = api.StepStatusSucceeded
+	}
+	return statuses
+}
+
+func TestRecordStepStatus(t *testing.T) {
+	ctx := context.Background()
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/cluster"
+
+	fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+	fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+	fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+		Key: key,
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID: key,
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateDeleting,
+			},
+		},
+		Progress: &api.OperationProgress{
+			Operation: "install",
+			Step:      "[Action github.com/Azure/ARO-RP/pkg/cluster.(*manager).ensureBillingRecord-fm]",
+		},
+	})
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &manager{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		doc: doc,
+		db:  fakeOpenShiftClustersDatabase,
+	}
+
+	record := m.recordStepStatus(deleteOperation)
+	record(ctx, "deleteDNS", api.StepStatusRunning)
+	record(ctx, "deletePrivateEndpoint", api.StepStatusRunning)
+	record(ctx, "deleteDNS", api.StepStatusSucceeded)
+	record(ctx, "deletePrivateEndpoint", api.StepStatusFailed)
+
+	doc, err = fakeOpenShiftClustersDatabase.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Progress.Operation != deleteOperation {
+		t.Errorf("got operation %q", doc.Progress.Operation)
+	}
+
+	want := map[string]api.StepStatus{
+		"deleteDNS":             api.StepStatusSucceeded,
+		"deletePrivateEndpoint": api.StepStatusFailed,
+	}
+	if !reflect.DeepEqual(doc.Progress.StepStatuses, want) {
+		t.Errorf("got step statuses %v, want %v", doc.Progress.StepStatuses, want)
+	}
+}
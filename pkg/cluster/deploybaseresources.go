@@ -111,16 +111,17 @@ func (m *manager) ensureResourceGroup(ctx context.Context) (err error) {
 	if serviceError != nil && serviceError.Code == "RequestDisallowedByPolicy" {
 		// if request was disallowed by policy, inform user so they can take appropriate action
 		b, _ := json.Marshal(serviceError)
+		m.log.Printf("resource group creation disallowed by policy: %s", string(b))
+
+		detail := arm.ServiceErrorToCloudErrorBody(serviceError)
+		detail.TargetResourceID = m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID
 		return &api.CloudError{
 			StatusCode: http.StatusBadRequest,
 			CloudErrorBody: &api.CloudErrorBody{
-				Code:    api.CloudErrorCodeDeploymentFailed,
-				Message: "Deployment failed.",
-				Details: []api.CloudErrorBody{
-					{
-						Message: string(b),
-					},
-				},
+				Code:           api.CloudErrorCodeDeploymentFailed,
+				Message:        "Deployment failed.",
+				RemediationURL: api.RemediationURLPolicy,
+				Details:        []api.CloudErrorBody{detail},
 			},
 		}
 	}
@@ -162,7 +163,7 @@ func (m *manager) deployBaseResourceTemplate(ctx context.Context) error {
 		// If the cluster is public we still want the default public IP address
 		if m.doc.OpenShiftCluster.Properties.IngressProfiles[0].Visibility == api.VisibilityPublic {
 			resources = append(resources,
-				m.networkPublicIPAddress(azureRegion, infraID+"-default-v4"),
+				m.networkPublicIPAddressFromPrefix(azureRegion, infraID+"-default-v4"),
 			)
 		}
 	}
@@ -193,7 +194,7 @@ func (m *manager) newPublicLoadBalancer(ctx context.Context, resources *[]*arm.R
 	var outboundIPs []api.ResourceReference
 	if m.doc.OpenShiftCluster.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
 		*resources = append(*resources,
-			m.networkPublicIPAddress(azureRegion, infraID+"-pip-v4"),
+			m.networkPublicIPAddressFromPrefix(azureRegion, infraID+"-pip-v4"),
 		)
 		if m.doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPs != nil {
 			outboundIPs = append(outboundIPs, api.ResourceReference{ID: m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID + "/providers/Microsoft.Network/publicIPAddresses/" + infraID + "-pip-v4"})
@@ -208,6 +209,13 @@ func (m *manager) newPublicLoadBalancer(ctx context.Context, resources *[]*arm.R
 	}
 	m.patchEffectiveOutboundIPs(ctx, outboundIPs)
 
+	// the IPv6 IP is never taken from the public IP prefix, which is IPv4
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.IsDualStack() {
+		*resources = append(*resources,
+			m.networkPublicIPv6Address(azureRegion, infraID+"-pip-v6"),
+		)
+	}
+
 	*resources = append(*resources,
 		m.networkPublicLoadBalancer(azureRegion, outboundIPs),
 	)
@@ -383,6 +391,8 @@ func (m *manager) setMasterSubnetPolicies(ctx context.Context) error {
 					Message: fmt.Sprintf("Resource %s was disallowed by policy.",
 						subnetId[strings.LastIndex(subnetId, "/")+1:],
 					),
+					TargetResourceID: subnetId,
+					RemediationURL:   api.RemediationURLPolicy,
 					Details: []api.CloudErrorBody{
 						{
 							Code: api.CloudErrorCodeRequestDisallowedByPolicy,
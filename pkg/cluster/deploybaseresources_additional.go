@@ -283,6 +283,29 @@ func (m *manager) networkPublicIPAddress(azureRegion string, name string) *arm.R
 	}
 }
 
+// networkPublicIPAddressFromPrefix returns a public IP address which, if the
+// cluster has a public IP prefix, is allocated from that prefix
+func (m *manager) networkPublicIPAddressFromPrefix(azureRegion string, name string) *arm.Resource {
+	r := m.networkPublicIPAddress(azureRegion, name)
+
+	if prefixID := m.doc.OpenShiftCluster.Properties.NetworkProfile.PublicIPPrefixID; prefixID != "" {
+		r.Resource.(*mgmtnetwork.PublicIPAddress).PublicIPPrefix = &mgmtnetwork.SubResource{
+			ID: &prefixID,
+		}
+	}
+
+	return r
+}
+
+// networkPublicIPv6Address returns an IPv6 public IP address for the public
+// load balancer of a dual-stack cluster
+func (m *manager) networkPublicIPv6Address(azureRegion string, name string) *arm.Resource {
+	r := m.networkPublicIPAddress(azureRegion, name)
+	r.Resource.(*mgmtnetwork.PublicIPAddress).PublicIPAddressVersion = mgmtnetwork.IPv6
+
+	return r
+}
+
 func (m *manager) networkInternalLoadBalancer(azureRegion string) *arm.Resource {
 	return &arm.Resource{
 		Resource: &mgmtnetwork.LoadBalancer{
@@ -554,6 +577,19 @@ func (m *manager) networkPublicLoadBalancer(azureRegion string, outboundIPs []ap
 		}
 	}
 
+	// Azure allocates fewer SNAT ports per node as the backend pool grows, so
+	// pin the allocation for large clusters.  Dynamic validation checks that
+	// there are enough managed outbound IPs for this.
+	if m.doc.OpenShiftCluster.Properties.MaxNodesProfile != nil {
+		(*lb.OutboundRules)[0].AllocatedOutboundPorts = to.Int32Ptr(1024)
+	}
+
+	// this is added last as the managed outbound IPs above are indexed by
+	// their position in the frontend IP configurations
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.IsDualStack() {
+		m.addIPv6ToPublicLoadBalancer(lb)
+	}
+
 	armResource := &arm.Resource{
 		Resource:   lb,
 		APIVersion: azureclient.APIVersion("Microsoft.Network"),
@@ -569,5 +605,69 @@ func (m *manager) networkPublicLoadBalancer(azureRegion string, outboundIPs []ap
 		armResource.DependsOn = append(armResource.DependsOn, "Microsoft.Network/publicIPAddresses/"+ipName)
 	}
 
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.IsDualStack() {
+		armResource.DependsOn = append(armResource.DependsOn, "Microsoft.Network/publicIPAddresses/"+m.doc.OpenShiftCluster.Properties.InfraID+"-pip-v6")
+	}
+
 	return armResource
 }
+
+// addIPv6ToPublicLoadBalancer adds the IPv6 frontend, backend pool and
+// outbound rule of a dual-stack cluster to lb, and the IPv6 API server rule
+// if the API server is public.  Azure does not allow a backend pool to mix
+// address families, so IPv6 gets a pool of its own.
+func (m *manager) addIPv6ToPublicLoadBalancer(lb *mgmtnetwork.LoadBalancer) {
+	infraID := m.doc.OpenShiftCluster.Properties.InfraID
+
+	*lb.FrontendIPConfigurations = append(*lb.FrontendIPConfigurations, mgmtnetwork.FrontendIPConfiguration{
+		FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+			PublicIPAddress: &mgmtnetwork.PublicIPAddress{
+				ID: to.StringPtr("[resourceId('Microsoft.Network/publicIPAddresses', '" + infraID + "-pip-v6')]"),
+			},
+		},
+		Name: to.StringPtr("public-lb-ip-v6"),
+	})
+
+	*lb.BackendAddressPools = append(*lb.BackendAddressPools, mgmtnetwork.BackendAddressPool{
+		Name: to.StringPtr(infraID + "-ipv6"),
+	})
+
+	*lb.OutboundRules = append(*lb.OutboundRules, mgmtnetwork.OutboundRule{
+		OutboundRulePropertiesFormat: &mgmtnetwork.OutboundRulePropertiesFormat{
+			FrontendIPConfigurations: &[]mgmtnetwork.SubResource{
+				{
+					ID: to.StringPtr(fmt.Sprintf("[resourceId('Microsoft.Network/loadBalancers/frontendIPConfigurations', '%s', 'public-lb-ip-v6')]", infraID)),
+				},
+			},
+			BackendAddressPool: &mgmtnetwork.SubResource{
+				ID: to.StringPtr(fmt.Sprintf("[resourceId('Microsoft.Network/loadBalancers/backendAddressPools', '%s', '%[1]s-ipv6')]", infraID)),
+			},
+			Protocol:             mgmtnetwork.LoadBalancerOutboundRuleProtocolAll,
+			IdleTimeoutInMinutes: to.Int32Ptr(30),
+		},
+		Name: to.StringPtr("outbound-rule-v6"),
+	})
+
+	if m.doc.OpenShiftCluster.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
+		*lb.LoadBalancingRules = append(*lb.LoadBalancingRules, mgmtnetwork.LoadBalancingRule{
+			LoadBalancingRulePropertiesFormat: &mgmtnetwork.LoadBalancingRulePropertiesFormat{
+				FrontendIPConfiguration: &mgmtnetwork.SubResource{
+					ID: to.StringPtr(fmt.Sprintf("[resourceId('Microsoft.Network/loadBalancers/frontendIPConfigurations', '%s', 'public-lb-ip-v6')]", infraID)),
+				},
+				BackendAddressPool: &mgmtnetwork.SubResource{
+					ID: to.StringPtr(fmt.Sprintf("[resourceId('Microsoft.Network/loadBalancers/backendAddressPools', '%s', '%[1]s-ipv6')]", infraID)),
+				},
+				Probe: &mgmtnetwork.SubResource{
+					ID: to.StringPtr(fmt.Sprintf("[resourceId('Microsoft.Network/loadBalancers/probes', '%s', 'api-internal-probe')]", infraID)),
+				},
+				Protocol:             mgmtnetwork.TransportProtocolTCP,
+				LoadDistribution:     mgmtnetwork.LoadDistributionDefault,
+				FrontendPort:         to.Int32Ptr(6443),
+				BackendPort:          to.Int32Ptr(6443),
+				IdleTimeoutInMinutes: to.Int32Ptr(30),
+				DisableOutboundSnat:  to.BoolPtr(true),
+			},
+			Name: to.StringPtr("api-internal-v6"),
+		})
+	}
+}
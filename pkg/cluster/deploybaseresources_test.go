@@ -184,7 +184,7 @@ func TestEnsureResourceGroup(t *testing.T) {
 					IsLocalDevelopmentMode().
 					Return(false)
 			},
-			wantErr: `400: DeploymentFailed: : Deployment failed. Details: : : {"code":"RequestDisallowedByPolicy","message":"","target":null,"details":null,"innererror":null,"additionalInfo":null}`,
+			wantErr: `400: DeploymentFailed: : Deployment failed. Details: RequestDisallowedByPolicy: : `,
 		},
 		{
 			name:              "fail - CreateOrUpdate returns generic error",
@@ -1394,3 +1394,180 @@ func TestNewPublicLoadBalancer(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkPublicIPAddressFromPrefix(t *testing.T) {
+	location := "eastus"
+	prefixID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/network/providers/Microsoft.Network/publicIPPrefixes/prefix"
+
+	for _, tt := range []struct {
+		name               string
+		prefixID           string
+		wantPublicIPPrefix *mgmtnetwork.SubResource
+	}{
+		{
+			name: "no public IP prefix",
+		},
+		{
+			name:               "public IP prefix",
+			prefixID:           prefixID,
+			wantPublicIPPrefix: &mgmtnetwork.SubResource{ID: &prefixID},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							NetworkProfile: api.NetworkProfile{
+								PublicIPPrefixID: tt.prefixID,
+							},
+						},
+					},
+				},
+			}
+
+			r := m.networkPublicIPAddressFromPrefix(location, "infraID-default-v4")
+
+			want := m.networkPublicIPAddress(location, "infraID-default-v4")
+			want.Resource.(*mgmtnetwork.PublicIPAddress).PublicIPPrefix = tt.wantPublicIPPrefix
+
+			if !reflect.DeepEqual(r, want) {
+				t.Error(r.Resource.(*mgmtnetwork.PublicIPAddress).PublicIPPrefix)
+			}
+		})
+	}
+}
+
+func TestNetworkPublicLoadBalancerAllocatedOutboundPorts(t *testing.T) {
+	for _, tt := range []struct {
+		name                       string
+		maxNodesProfile            *api.MaxNodesProfile
+		wantAllocatedOutboundPorts *int32
+	}{
+		{
+			name: "not a large cluster",
+		},
+		{
+			name: "large cluster",
+			maxNodesProfile: &api.MaxNodesProfile{
+				MaxNodes: 120,
+			},
+			wantAllocatedOutboundPorts: to.Int32Ptr(1024),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							InfraID: "infraID",
+							APIServerProfile: api.APIServerProfile{
+								Visibility: api.VisibilityPrivate,
+							},
+							NetworkProfile: api.NetworkProfile{
+								LoadBalancerProfile: &api.LoadBalancerProfile{},
+							},
+							MaxNodesProfile: tt.maxNodesProfile,
+						},
+					},
+				},
+			}
+
+			r := m.networkPublicLoadBalancer("eastus", nil)
+
+			rule := (*r.Resource.(*mgmtnetwork.LoadBalancer).OutboundRules)[0]
+			assert.Equal(t, tt.wantAllocatedOutboundPorts, rule.AllocatedOutboundPorts)
+		})
+	}
+}
+
+func TestNetworkPublicLoadBalancerDualStack(t *testing.T) {
+	for _, tt := range []struct {
+		name                   string
+		visibility             api.Visibility
+		podCIDRIPv6            string
+		wantFrontendIPConfigs  []string
+		wantBackendPools       []string
+		wantLoadBalancingRules []string
+		wantOutboundRules      []string
+		wantDependsOn          []string
+	}{
+		{
+			name:                   "single-stack",
+			visibility:             api.VisibilityPublic,
+			wantFrontendIPConfigs:  []string{"public-lb-ip-v4"},
+			wantBackendPools:       []string{"infraID"},
+			wantLoadBalancingRules: []string{"api-internal-v4"},
+			wantOutboundRules:      []string{"outbound-rule-v4"},
+			wantDependsOn:          []string{"Microsoft.Network/publicIPAddresses/infraID-pip-v4"},
+		},
+		{
+			name:                   "dual-stack, public API server",
+			visibility:             api.VisibilityPublic,
+			podCIDRIPv6:            "fd01::/48",
+			wantFrontendIPConfigs:  []string{"public-lb-ip-v4", "public-lb-ip-v6"},
+			wantBackendPools:       []string{"infraID", "infraID-ipv6"},
+			wantLoadBalancingRules: []string{"api-internal-v4", "api-internal-v6"},
+			wantOutboundRules:      []string{"outbound-rule-v4", "outbound-rule-v6"},
+			wantDependsOn: []string{
+				"Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+				"Microsoft.Network/publicIPAddresses/infraID-pip-v6",
+			},
+		},
+		{
+			name:                   "dual-stack, private API server",
+			visibility:             api.VisibilityPrivate,
+			podCIDRIPv6:            "fd01::/48",
+			wantFrontendIPConfigs:  []string{"public-lb-ip-v6"},
+			wantBackendPools:       []string{"infraID", "infraID-ipv6"},
+			wantLoadBalancingRules: []string{},
+			wantOutboundRules:      []string{"outbound-rule-v4", "outbound-rule-v6"},
+			wantDependsOn:          []string{"Microsoft.Network/publicIPAddresses/infraID-pip-v6"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							InfraID: "infraID",
+							APIServerProfile: api.APIServerProfile{
+								Visibility: tt.visibility,
+							},
+							NetworkProfile: api.NetworkProfile{
+								PodCIDRIPv6:         tt.podCIDRIPv6,
+								LoadBalancerProfile: &api.LoadBalancerProfile{},
+							},
+						},
+					},
+				},
+			}
+
+			r := m.networkPublicLoadBalancer("eastus", nil)
+			lb := r.Resource.(*mgmtnetwork.LoadBalancer)
+
+			frontendIPConfigs := []string{}
+			for _, c := range *lb.FrontendIPConfigurations {
+				frontendIPConfigs = append(frontendIPConfigs, *c.Name)
+			}
+			backendPools := []string{}
+			for _, p := range *lb.BackendAddressPools {
+				backendPools = append(backendPools, *p.Name)
+			}
+			loadBalancingRules := []string{}
+			for _, r := range *lb.LoadBalancingRules {
+				loadBalancingRules = append(loadBalancingRules, *r.Name)
+			}
+			outboundRules := []string{}
+			for _, r := range *lb.OutboundRules {
+				outboundRules = append(outboundRules, *r.Name)
+			}
+
+			assert.Equal(t, tt.wantFrontendIPConfigs, frontendIPConfigs)
+			assert.Equal(t, tt.wantBackendPools, backendPools)
+			assert.Equal(t, tt.wantLoadBalancingRules, loadBalancingRules)
+			assert.Equal(t, tt.wantOutboundRules, outboundRules)
+			assert.Equal(t, tt.wantDependsOn, r.DependsOn)
+		})
+	}
+}
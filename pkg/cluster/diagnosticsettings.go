@@ -0,0 +1,112 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+
+	mgmtinsights "github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// diagnosticSettingName is the name of the diagnostic setting which the RP
+// owns on each resource.  Diagnostic settings created by the customer under
+// other names are left alone.
+const diagnosticSettingName = "aro"
+
+// diagnosticSettingsResourceTypes are the types of the resources in the
+// cluster resource group which are sent to the customer's workspace
+var diagnosticSettingsResourceTypes = map[string]bool{
+	"microsoft.network/loadbalancers":         true,
+	"microsoft.network/networksecuritygroups": true,
+	"microsoft.keyvault/vaults":               true,
+}
+
+// reconcileDiagnosticSettings sends the logs and metrics of the load
+// balancers, network security groups and key vaults in the cluster resource
+// group to the Log Analytics workspace of the cluster's diagnostic settings
+// profile, or removes the RP's diagnostic setting from them if the cluster has
+// no such profile.  The certificates of the cluster are held in the RP's key
+// vault, whose logs are not shared with the customer.
+func (m *manager) reconcileDiagnosticSettings(ctx context.Context) error {
+	// a new cluster has no diagnostic settings to remove
+	if m.doc.OpenShiftCluster.Properties.DiagnosticSettingsProfile == nil &&
+		m.doc.OpenShiftCluster.Properties.ProvisioningState == api.ProvisioningStateCreating {
+		return nil
+	}
+
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	resources, err := m.resources.ListByResourceGroup(ctx, resourceGroup, "", "", nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		if !diagnosticSettingsResourceTypes[strings.ToLower(*resource.Type)] {
+			continue
+		}
+
+		if m.doc.OpenShiftCluster.Properties.DiagnosticSettingsProfile == nil {
+			_, err = m.diagnosticSettings.Delete(ctx, *resource.ID, diagnosticSettingName)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		m.log.Printf("configuring diagnostic settings on %s", *resource.ID)
+		err = m.createOrUpdateDiagnosticSetting(ctx, *resource.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createOrUpdateDiagnosticSetting enables every log and metric category which
+// the resource supports
+func (m *manager) createOrUpdateDiagnosticSetting(ctx context.Context, resourceID string) error {
+	categories, err := m.diagnosticCategories.List(ctx, resourceID)
+	if err != nil {
+		return err
+	}
+
+	logs := []mgmtinsights.LogSettings{}
+	metrics := []mgmtinsights.MetricSettings{}
+	if categories.Value != nil {
+		for _, category := range *categories.Value {
+			if category.DiagnosticSettingsCategory == nil {
+				continue
+			}
+
+			switch category.CategoryType {
+			case mgmtinsights.Logs:
+				logs = append(logs, mgmtinsights.LogSettings{
+					Category: category.Name,
+					Enabled:  to.BoolPtr(true),
+				})
+			case mgmtinsights.Metrics:
+				metrics = append(metrics, mgmtinsights.MetricSettings{
+					Category: category.Name,
+					Enabled:  to.BoolPtr(true),
+				})
+			}
+		}
+	}
+
+	_, err = m.diagnosticSettings.CreateOrUpdate(ctx, resourceID, mgmtinsights.DiagnosticSettingsResource{
+		DiagnosticSettings: &mgmtinsights.DiagnosticSettings{
+			WorkspaceID: &m.doc.OpenShiftCluster.Properties.DiagnosticSettingsProfile.WorkspaceResourceID,
+			Logs:        &logs,
+			Metrics:     &metrics,
+		},
+	}, diagnosticSettingName)
+	return err
+}
@@ -0,0 +1,168 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	mgmtinsights "github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
+	mock_insights "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/insights"
+)
+
+func TestReconcileDiagnosticSettings(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		resourceGroupID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/aro-cluster"
+		workspaceID     = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/logs/providers/Microsoft.OperationalInsights/workspaces/workspace"
+		lbID            = resourceGroupID + "/providers/Microsoft.Network/loadBalancers/infra-internal"
+		nsgID           = resourceGroupID + "/providers/Microsoft.Network/networkSecurityGroups/infra-nsg"
+	)
+
+	resources := []mgmtfeatures.GenericResourceExpanded{
+		{
+			ID:   to.StringPtr(lbID),
+			Type: to.StringPtr("Microsoft.Network/loadBalancers"),
+		},
+		{
+			ID:   to.StringPtr(nsgID),
+			Type: to.StringPtr("Microsoft.Network/networkSecurityGroups"),
+		},
+		{
+			ID:   to.StringPtr(resourceGroupID + "/providers/Microsoft.Compute/virtualMachines/infra-master-0"),
+			Type: to.StringPtr("Microsoft.Compute/virtualMachines"),
+		},
+	}
+
+	for _, tt := range []struct {
+		name              string
+		provisioningState api.ProvisioningState
+		profile           *api.DiagnosticSettingsProfile
+		mocks             func(*mock_features.MockResourcesClient, *mock_insights.MockDiagnosticSettingsClient, *mock_insights.MockDiagnosticSettingsCategoryClient)
+	}{
+		{
+			name:              "install without profile",
+			provisioningState: api.ProvisioningStateCreating,
+		},
+		{
+			name:              "install with profile",
+			provisioningState: api.ProvisioningStateCreating,
+			profile:           &api.DiagnosticSettingsProfile{WorkspaceResourceID: workspaceID},
+			mocks: func(resourcesClient *mock_features.MockResourcesClient, diagnosticSettings *mock_insights.MockDiagnosticSettingsClient, diagnosticCategories *mock_insights.MockDiagnosticSettingsCategoryClient) {
+				resourcesClient.EXPECT().
+					ListByResourceGroup(gomock.Any(), "aro-cluster", "", "", nil).
+					Return(resources, nil)
+
+				diagnosticCategories.EXPECT().
+					List(gomock.Any(), lbID).
+					Return(mgmtinsights.DiagnosticSettingsCategoryResourceCollection{
+						Value: &[]mgmtinsights.DiagnosticSettingsCategoryResource{
+							{
+								Name:                       to.StringPtr("AllMetrics"),
+								DiagnosticSettingsCategory: &mgmtinsights.DiagnosticSettingsCategory{CategoryType: mgmtinsights.Metrics},
+							},
+						},
+					}, nil)
+				diagnosticSettings.EXPECT().
+					CreateOrUpdate(gomock.Any(), lbID, mgmtinsights.DiagnosticSettingsResource{
+						DiagnosticSettings: &mgmtinsights.DiagnosticSettings{
+							WorkspaceID: to.StringPtr(workspaceID),
+							Logs:        &[]mgmtinsights.LogSettings{},
+							Metrics: &[]mgmtinsights.MetricSettings{
+								{Category: to.StringPtr("AllMetrics"), Enabled: to.BoolPtr(true)},
+							},
+						},
+					}, diagnosticSettingName).
+					Return(mgmtinsights.DiagnosticSettingsResource{}, nil)
+
+				diagnosticCategories.EXPECT().
+					List(gomock.Any(), nsgID).
+					Return(mgmtinsights.DiagnosticSettingsCategoryResourceCollection{
+						Value: &[]mgmtinsights.DiagnosticSettingsCategoryResource{
+							{
+								Name:                       to.StringPtr("NetworkSecurityGroupEvent"),
+								DiagnosticSettingsCategory: &mgmtinsights.DiagnosticSettingsCategory{CategoryType: mgmtinsights.Logs},
+							},
+							{
+								Name:                       to.StringPtr("NetworkSecurityGroupRuleCounter"),
+								DiagnosticSettingsCategory: &mgmtinsights.DiagnosticSettingsCategory{CategoryType: mgmtinsights.Logs},
+							},
+						},
+					}, nil)
+				diagnosticSettings.EXPECT().
+					CreateOrUpdate(gomock.Any(), nsgID, mgmtinsights.DiagnosticSettingsResource{
+						DiagnosticSettings: &mgmtinsights.DiagnosticSettings{
+							WorkspaceID: to.StringPtr(workspaceID),
+							Logs: &[]mgmtinsights.LogSettings{
+								{Category: to.StringPtr("NetworkSecurityGroupEvent"), Enabled: to.BoolPtr(true)},
+								{Category: to.StringPtr("NetworkSecurityGroupRuleCounter"), Enabled: to.BoolPtr(true)},
+							},
+							Metrics: &[]mgmtinsights.MetricSettings{},
+						},
+					}, diagnosticSettingName).
+					Return(mgmtinsights.DiagnosticSettingsResource{}, nil)
+			},
+		},
+		{
+			name:              "update removing profile",
+			provisioningState: api.ProvisioningStateUpdating,
+			mocks: func(resourcesClient *mock_features.MockResourcesClient, diagnosticSettings *mock_insights.MockDiagnosticSettingsClient, diagnosticCategories *mock_insights.MockDiagnosticSettingsCategoryClient) {
+				resourcesClient.EXPECT().
+					ListByResourceGroup(gomock.Any(), "aro-cluster", "", "", nil).
+					Return(resources, nil)
+
+				diagnosticSettings.EXPECT().
+					Delete(gomock.Any(), lbID, diagnosticSettingName).
+					Return(autorest.Response{}, nil)
+				diagnosticSettings.EXPECT().
+					Delete(gomock.Any(), nsgID, diagnosticSettingName).
+					Return(autorest.Response{}, nil)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			resourcesClient := mock_features.NewMockResourcesClient(controller)
+			diagnosticSettings := mock_insights.NewMockDiagnosticSettingsClient(controller)
+			diagnosticCategories := mock_insights.NewMockDiagnosticSettingsCategoryClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(resourcesClient, diagnosticSettings, diagnosticCategories)
+			}
+
+			m := &manager{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: tt.provisioningState,
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: resourceGroupID,
+							},
+							DiagnosticSettingsProfile: tt.profile,
+						},
+					},
+				},
+				resources:            resourcesClient,
+				diagnosticSettings:   diagnosticSettings,
+				diagnosticCategories: diagnosticCategories,
+			}
+
+			err := m.reconcileDiagnosticSettings(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
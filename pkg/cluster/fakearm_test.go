@@ -0,0 +1,201 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
+	"github.com/Azure/ARO-RP/pkg/util/steps"
+	"github.com/Azure/ARO-RP/pkg/util/subnet"
+	"github.com/Azure/ARO-RP/test/util/fakearm"
+)
+
+// TestStepsAgainstFakeARM runs the install steps which configure the customer
+// vnet and the cluster VMs against a fake ARM, with scripted failures
+func TestStepsAgainstFakeARM(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		subscriptionID  = "00000000-0000-0000-0000-000000000000"
+		vnetID          = "/subscriptions/" + subscriptionID + "/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet"
+		masterSubnetID  = vnetID + "/subnets/master"
+		workerSubnetID  = vnetID + "/subnets/worker"
+		clusterRGID     = "/subscriptions/" + subscriptionID + "/resourceGroups/aro-12345678"
+		nsgID           = clusterRGID + "/providers/Microsoft.Network/networkSecurityGroups/infra-nsg"
+		masterVMID      = clusterRGID + "/providers/Microsoft.Compute/virtualMachines/infra-master-0"
+		subnetPutRegexp = `/subnets/worker$`
+	)
+
+	for _, tt := range []struct {
+		name     string
+		failures []*fakearm.Failure
+		wantErr  string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name: "success after the NSG is not ready once",
+			failures: []*fakearm.Failure{
+				{
+					Method:     http.MethodPut,
+					Path:       regexp.MustCompile(subnetPutRegexp),
+					StatusCode: http.StatusBadRequest,
+					Code:       "InvalidResourceReference",
+					Message:    "Resource " + nsgID + " referenced by resource " + workerSubnetID + " was not found.",
+					Times:      1,
+				},
+			},
+		},
+		{
+			name: "failure updating the worker subnet",
+			failures: []*fakearm.Failure{
+				{
+					Method:     http.MethodPut,
+					Path:       regexp.MustCompile(subnetPutRegexp),
+					StatusCode: http.StatusForbidden,
+					Code:       "AuthorizationFailed",
+					Message:    "The client does not have authorization to perform action.",
+				},
+			},
+			wantErr: "AuthorizationFailed",
+		},
+		{
+			name: "failure listing the cluster VMs",
+			failures: []*fakearm.Failure{
+				{
+					Method:     http.MethodGet,
+					Path:       regexp.MustCompile(`/virtualMachines$`),
+					StatusCode: http.StatusNotFound,
+					Code:       "ResourceGroupNotFound",
+					Message:    "Resource group 'aro-12345678' could not be found.",
+				},
+			},
+			wantErr: "ResourceGroupNotFound",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			arm := fakearm.NewServer()
+			defer arm.Close()
+
+			for _, id := range []string{masterSubnetID, workerSubnetID} {
+				err := arm.Put(id, &mgmtnetwork.Subnet{
+					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+						AddressPrefix: to.StringPtr("10.0.0.0/24"),
+					},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// the SDK does not marshal the read-only instance view
+			err := arm.Put(masterVMID, map[string]interface{}{
+				"properties": map[string]interface{}{
+					"instanceView": map[string]interface{}{
+						"statuses": []interface{}{
+							map[string]interface{}{"code": "PowerState/deallocated"},
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, f := range tt.failures {
+				arm.Fail(f)
+			}
+
+			m := &manager{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							ArchitectureVersion: api.ArchitectureVersionV2,
+							InfraID:             "infra",
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: clusterRGID,
+							},
+							MasterProfile: api.MasterProfile{
+								SubnetID: masterSubnetID,
+							},
+							WorkerProfiles: []api.WorkerProfile{
+								{
+									Name:     "worker",
+									SubnetID: workerSubnetID,
+								},
+							},
+						},
+					},
+				},
+				subnet:          subnet.NewManager(arm.Environment(), subscriptionID, arm.Authorizer()),
+				virtualMachines: compute.NewVirtualMachinesClient(arm.Environment(), subscriptionID, arm.Authorizer()),
+			}
+
+			_, err = steps.Run(ctx, m.log, time.Millisecond, []steps.Step{
+				steps.Action(m.setMasterSubnetPolicies),
+				steps.Action(func(ctx context.Context) error {
+					return m._attachNSGs(ctx, time.Second, 10*time.Millisecond)
+				}),
+				steps.Action(m.ensureServiceEndpoints),
+				steps.Action(m.startVMs),
+			}, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, wanted %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, id := range []string{masterSubnetID, workerSubnetID} {
+				var s mgmtnetwork.Subnet
+				_, err = arm.Get(id, &s)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if s.NetworkSecurityGroup == nil || !strings.EqualFold(*s.NetworkSecurityGroup.ID, nsgID) {
+					t.Errorf("%s: NSG not attached", id)
+				}
+				if s.ServiceEndpoints == nil || len(*s.ServiceEndpoints) != len(api.SubnetsEndpoints) {
+					t.Errorf("%s: unexpected service endpoints %v", id, s.ServiceEndpoints)
+				}
+			}
+
+			var master mgmtnetwork.Subnet
+			_, err = arm.Get(masterSubnetID, &master)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if master.PrivateLinkServiceNetworkPolicies == nil || *master.PrivateLinkServiceNetworkPolicies != "Disabled" {
+				t.Error("private link service network policies not disabled on the master subnet")
+			}
+
+			var started bool
+			for _, r := range arm.Requests() {
+				if r.Method == http.MethodPost && strings.EqualFold(r.Path, masterVMID+"/start") {
+					started = true
+				}
+			}
+			if !started {
+				t.Error("deallocated master VM was not started")
+			}
+		})
+	}
+}
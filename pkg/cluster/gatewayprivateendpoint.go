@@ -5,11 +5,19 @@ package cluster
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/util/arm"
 	"github.com/Azure/ARO-RP/pkg/util/stringutils"
 )
 
+var errGatewayKeySet = errors.New("gateway key already set")
+
 // ensureGatewayUpgrade checks to see if the cluster should have the gateway
 // enabled but doesn't yet.  If so, it sets the master subnet policies, deploys
 // the private endpoint, approves the gateway PE/PLS connection, creates the
@@ -47,3 +55,37 @@ func (m *manager) ensureGatewayUpgrade(ctx context.Context) error {
 
 	return nil
 }
+
+// ensureGatewayKey backfills the cluster resource ID on gateway records created
+// before it was recorded, so that they can be found by
+// GetByClusterResourceID.
+func (m *manager) ensureGatewayKey(ctx context.Context) error {
+	linkID := m.doc.OpenShiftCluster.Properties.NetworkProfile.GatewayPrivateLinkID
+	if linkID == "" {
+		return nil
+	}
+
+	return m.backfillGatewayKey(ctx, linkID)
+}
+
+// backfillGatewayKey sets the cluster resource ID on the gateway record linkID
+// if it is missing.  The record must belong to the cluster.
+func (m *manager) backfillGatewayKey(ctx context.Context, linkID string) error {
+	_, err := m.dbGateway.Patch(ctx, linkID, func(doc *api.GatewayDocument) error {
+		if doc.Key == m.doc.Key {
+			return errGatewayKeySet
+		}
+
+		if !strings.EqualFold(doc.Gateway.ID, m.doc.OpenShiftCluster.ID) {
+			return fmt.Errorf("gateway record '%s' belongs to a different cluster '%s'", linkID, doc.Gateway.ID)
+		}
+
+		doc.Key = m.doc.Key
+		return nil
+	})
+	if err == errGatewayKeySet || cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,117 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEnsureGatewayKey(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+
+	for _, tt := range []struct {
+		name    string
+		linkID  string
+		fixture func(*testdatabase.Fixture)
+		checker func(*testdatabase.Checker)
+		wantErr string
+	}{
+		{
+			name: "noop: no gateway",
+		},
+		{
+			name:   "noop: record gone",
+			linkID: "1234",
+		},
+		{
+			name:   "backfill key",
+			linkID: "1234",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddGatewayDocuments(&api.GatewayDocument{
+					ID: "1234",
+					Gateway: &api.Gateway{
+						ID: resourceID,
+					},
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddGatewayDocuments(&api.GatewayDocument{
+					ID:  "1234",
+					Key: strings.ToLower(resourceID),
+					Gateway: &api.Gateway{
+						ID: resourceID,
+					},
+				})
+			},
+		},
+		{
+			name:   "error: record belongs to another cluster",
+			linkID: "1234",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddGatewayDocuments(&api.GatewayDocument{
+					ID: "1234",
+					Gateway: &api.Gateway{
+						ID: "otherCluster",
+					},
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddGatewayDocuments(&api.GatewayDocument{
+					ID: "1234",
+					Gateway: &api.Gateway{
+						ID: "otherCluster",
+					},
+				})
+			},
+			wantErr: "gateway record '1234' belongs to a different cluster 'otherCluster'",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dbGateway, clientGateway := testdatabase.NewFakeGateway()
+
+			f := testdatabase.NewFixture().WithGateway(dbGateway)
+			if tt.fixture != nil {
+				tt.fixture(f)
+			}
+			err := f.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				dbGateway: dbGateway,
+				doc: &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+						Properties: api.OpenShiftClusterProperties{
+							NetworkProfile: api.NetworkProfile{
+								GatewayPrivateLinkID: tt.linkID,
+							},
+						},
+					},
+				},
+			}
+
+			err = m.ensureGatewayKey(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			c := testdatabase.NewChecker()
+			if tt.checker != nil {
+				tt.checker(c)
+			}
+
+			for _, err := range c.CheckGateways(clientGateway) {
+				t.Error(err)
+			}
+		})
+	}
+}
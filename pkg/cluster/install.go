@@ -47,6 +47,7 @@ func (m *manager) adminUpdate() []steps.Step {
 	isEverything := task == api.MaintenanceTaskEverything || task == ""
 	isOperator := task == api.MaintenanceTaskOperator
 	isRenewCerts := task == api.MaintenanceTaskRenewCerts
+	isRotateACRToken := task == api.MaintenanceTaskRotateACRToken
 
 	// Generic fix-up or setup actions that are fairly safe to always take, and
 	// don't require a running cluster
@@ -67,10 +68,12 @@ func (m *manager) adminUpdate() []steps.Step {
 			steps.Action(m.ensureResourceGroup), // re-create RP RBAC if needed after tenant migration
 			steps.Action(m.createOrUpdateDenyAssignment),
 			steps.Action(m.ensureServiceEndpoints),
+			steps.Action(m.attachNSGs),                         // re-attach NSGs detached from the cluster subnets
 			steps.Action(m.populateRegistryStorageAccountName), // must go before migrateStorageAccounts
 			steps.Action(m.migrateStorageAccounts),
 			steps.Action(m.fixSSH),
-			// steps.Action(m.removePrivateDNSZone), // TODO(mj): re-enable once we communicate this out
+			steps.Action(m.ensureSSHCAKey),
+			steps.Action(m.ensureBreakGlassCA),
 		)
 	}
 
@@ -105,6 +108,12 @@ func (m *manager) adminUpdate() []steps.Step {
 	if isEverything {
 		toRun = append(toRun,
 			steps.Action(m.ensureGatewayUpgrade),
+			steps.Action(m.ensureGatewayKey),
+		)
+	}
+
+	if isEverything || isRotateACRToken {
+		toRun = append(toRun,
 			steps.Action(m.rotateACRTokenPassword),
 		)
 	}
@@ -120,7 +129,16 @@ func (m *manager) adminUpdate() []steps.Step {
 		toRun = append(toRun,
 			steps.Action(m.populateRegistryStorageAccountName),
 			steps.Action(m.ensureMTUSize),
+			steps.Action(m.ensureSSHCAMachineConfigs),
+			steps.Action(m.ensureBreakGlassClientCA),
+			steps.Action(m.registerOCMCluster),
 		)
+
+		if m.privateDNSZoneRemovalEnabled() {
+			toRun = append(toRun,
+				steps.Action(m.removePrivateDNSZone),
+			)
+		}
 	}
 
 	if isEverything || isOperator || isRenewCerts {
@@ -183,6 +201,34 @@ func (m *manager) clusterWasCreatedByHive() bool {
 }
 
 func (m *manager) Update(ctx context.Context) error {
+	toRun := m.update()
+	return m.runSteps(ctx, toRun, "update")
+}
+
+// update returns the steps of an update of the cluster.  An update whose
+// ReconcileScope is set, e.g. by a PATCH which only changes the worker
+// profiles, only runs the steps which reconcile that part of the cluster.
+func (m *manager) update() []steps.Step {
+	switch m.doc.ReconcileScope {
+	case api.ReconcileScopeWorkerProfiles:
+		return []steps.Step{
+			steps.AuthorizationRetryingAction(m.fpAuthorizer, m.validateResources),
+			steps.Action(m.initializeKubernetesClients),
+			steps.Action(m.startVMs),
+			steps.Condition(m.apiServersReady, 30*time.Minute, true),
+			steps.Action(m.reconcileWorkerVMSize),
+			steps.Condition(m.workerVMSizeReconciled, 2*time.Hour, true),
+		}
+
+	case api.ReconcileScopeLoadBalancerProfile:
+		return []steps.Step{
+			steps.AuthorizationRetryingAction(m.fpAuthorizer, m.validateResources),
+			steps.Action(m.initializeKubernetesClients),
+			steps.Action(m.reconcileLoadBalancerProfile),
+			steps.Action(m.reconcileOutboundSNAT),
+		}
+	}
+
 	s := []steps.Step{
 		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.validateResources),
 		steps.Action(m.initializeKubernetesClients), // All init steps are first
@@ -209,6 +255,10 @@ func (m *manager) Update(ctx context.Context) error {
 		steps.Action(m.restartAROOperatorMaster), // depends on m.updateOpenShiftSecret; the point of restarting is to pick up any changes made to the secret
 		steps.Condition(m.aroDeploymentReady, 5*time.Minute, true),
 		steps.Action(m.reconcileLoadBalancerProfile),
+		steps.Action(m.reconcileOutboundSNAT),
+		steps.Action(m.reconcileDiagnosticSettings),
+		steps.Action(m.reconcileWorkerVMSize),
+		steps.Condition(m.workerVMSizeReconciled, 2*time.Hour, true),
 	}
 
 	if m.adoptViaHive {
@@ -222,7 +272,7 @@ func (m *manager) Update(ctx context.Context) error {
 		)
 	}
 
-	return m.runSteps(ctx, s, "update")
+	return s
 }
 
 func (m *manager) runPodmanInstaller(ctx context.Context) error {
@@ -264,16 +314,16 @@ func setFieldCreatedByHive(createdByHive bool) database.OpenShiftClusterDocument
 	}
 }
 
-func (m *manager) bootstrap() []steps.Step {
-	s := []steps.Step{
+// reserve claims the names and resources which the cluster needs from Azure
+// and checks the subscription still has the quota for it, before bootstrap
+// does the heavy lifting of the install.  It takes a minute or so, so that
+// conflicts with existing resources and quota taken since the cluster was
+// validated fail the install early, when there is little to clean up.
+func (m *manager) reserve() []steps.Step {
+	return []steps.Step{
 		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.validateResources),
-		steps.Action(m.ensurePreconfiguredNSG),
-		steps.Action(m.ensureACRToken),
+		steps.Action(m.validateQuota), // must run before any public IP is created
 		steps.Action(m.ensureInfraID),
-		steps.Action(m.ensureSSHKey),
-		steps.Action(m.ensureStorageSuffix),
-		steps.Action(m.populateMTUSize),
-
 		steps.Action(m.createDNS),
 		steps.Action(m.initializeClusterSPClients), // must run before clusterSPObjectID
 
@@ -282,6 +332,21 @@ func (m *manager) bootstrap() []steps.Step {
 		// to advance
 		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.clusterSPObjectID),
 		steps.Action(m.ensureResourceGroup),
+		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.deployPublicIPAddresses),
+		steps.Action(m.incrInstallPhase),
+	}
+}
+
+func (m *manager) bootstrap() []steps.Step {
+	s := []steps.Step{
+		steps.Action(m.ensurePreconfiguredNSG),
+		steps.Action(m.ensureACRToken),
+		steps.Action(m.ensureSSHKey),
+		steps.Action(m.ensureSSHCAKey),
+		steps.Action(m.ensureBreakGlassCA),
+		steps.Action(m.ensureStorageSuffix),
+		steps.Action(m.populateMTUSize),
+
 		steps.Action(m.ensureServiceEndpoints),
 		steps.Action(m.setMasterSubnetPolicies),
 		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.deployBaseResourceTemplate),
@@ -341,35 +406,58 @@ func (m *manager) bootstrap() []steps.Step {
 	return s
 }
 
+func (m *manager) removeBootstrapPhase() []steps.Step {
+	s := []steps.Step{
+		steps.Action(m.initializeKubernetesClients),
+		steps.Action(m.initializeOperatorDeployer), // depends on kube clients
+		steps.Action(m.removeBootstrap),
+		steps.Action(m.removeBootstrapIgnition),
+		// Occasionally, the apiserver experiences disruptions, causing the certificate configuration step to fail.
+		// This issue is currently under investigation.
+		steps.Condition(m.apiServersReady, 30*time.Minute, true),
+		steps.Action(m.configureAPIServerCertificate),
+		steps.Condition(m.apiServersReady, 30*time.Minute, true),
+		steps.Action(m.ensureSSHCAMachineConfigs),
+		steps.Action(m.ensureBreakGlassClientCA),
+		steps.Condition(m.minimumWorkerNodesReady, 30*time.Minute, true),
+		steps.Action(m.ensureSpotMachineSets),
+		steps.Condition(m.operatorConsoleExists, 30*time.Minute, true),
+		steps.Action(m.updateConsoleBranding),
+		steps.Condition(m.operatorConsoleReady, 20*time.Minute, true),
+		steps.Action(m.disableSamples),
+		steps.Action(m.disableOperatorHubSources),
+		steps.Action(m.disableUpdates),
+		steps.Condition(m.clusterVersionReady, 30*time.Minute, true),
+		steps.Condition(m.aroDeploymentReady, 20*time.Minute, true),
+		steps.Action(m.updateClusterData),
+		steps.Action(m.configureIngressCertificate),
+		steps.Condition(m.ingressControllerReady, 30*time.Minute, true),
+		steps.Action(m.configureDefaultStorageClass),
+		steps.Action(m.registerOCMCluster),
+		steps.Action(m.reconcileDiagnosticSettings),
+		steps.Action(m.reconcileWorkerVMSize),
+		steps.Condition(m.workerVMSizeReconciled, 2*time.Hour, true),
+	}
+
+	if m.privateDNSZoneRemovalEnabled() {
+		// best effort: if the dnsmasq config has not yet rolled out, the zone
+		// is removed by a later admin update
+		s = append(s,
+			steps.Action(m.removePrivateDNSZone),
+		)
+	}
+
+	return append(s,
+		steps.Action(m.finishInstallation),
+	)
+}
+
 // Install installs an ARO cluster
 func (m *manager) Install(ctx context.Context) error {
 	steps := map[api.InstallPhase][]steps.Step{
-		api.InstallPhaseBootstrap: m.bootstrap(),
-		api.InstallPhaseRemoveBootstrap: {
-			steps.Action(m.initializeKubernetesClients),
-			steps.Action(m.initializeOperatorDeployer), // depends on kube clients
-			steps.Action(m.removeBootstrap),
-			steps.Action(m.removeBootstrapIgnition),
-			// Occasionally, the apiserver experiences disruptions, causing the certificate configuration step to fail.
-			// This issue is currently under investigation.
-			steps.Condition(m.apiServersReady, 30*time.Minute, true),
-			steps.Action(m.configureAPIServerCertificate),
-			steps.Condition(m.apiServersReady, 30*time.Minute, true),
-			steps.Condition(m.minimumWorkerNodesReady, 30*time.Minute, true),
-			steps.Condition(m.operatorConsoleExists, 30*time.Minute, true),
-			steps.Action(m.updateConsoleBranding),
-			steps.Condition(m.operatorConsoleReady, 20*time.Minute, true),
-			steps.Action(m.disableSamples),
-			steps.Action(m.disableOperatorHubSources),
-			steps.Action(m.disableUpdates),
-			steps.Condition(m.clusterVersionReady, 30*time.Minute, true),
-			steps.Condition(m.aroDeploymentReady, 20*time.Minute, true),
-			steps.Action(m.updateClusterData),
-			steps.Action(m.configureIngressCertificate),
-			steps.Condition(m.ingressControllerReady, 30*time.Minute, true),
-			steps.Action(m.configureDefaultStorageClass),
-			steps.Action(m.finishInstallation),
-		},
+		api.InstallPhaseReserve:         m.reserve(),
+		api.InstallPhaseBootstrap:       m.bootstrap(),
+		api.InstallPhaseRemoveBootstrap: m.removeBootstrapPhase(),
 	}
 
 	err := m.startInstallation(ctx)
@@ -385,10 +473,14 @@ func (m *manager) Install(ctx context.Context) error {
 }
 
 func (m *manager) runSteps(ctx context.Context, s []steps.Step, metricsTopic string) error {
+	if m.doc != nil {
+		ctx = steps.WithResourceID(ctx, m.doc.OpenShiftCluster.ID)
+	}
+
 	var err error
 	if metricsTopic != "" {
 		var stepsTimeRun map[string]int64
-		stepsTimeRun, err = steps.Run(ctx, m.log, 10*time.Second, s, m.now)
+		stepsTimeRun, err = steps.RunWithProgress(ctx, m.log, 10*time.Second, s, m.now, m.recordProgress(metricsTopic))
 		if err == nil {
 			var totalInstallTime int64
 			for stepName, duration := range stepsTimeRun {
@@ -399,6 +491,8 @@ func (m *manager) runSteps(ctx context.Context, s []steps.Step, metricsTopic str
 
 			metricName := fmt.Sprintf("backend.openshiftcluster.%s.duration.total.seconds", metricsTopic)
 			m.metricsEmitter.EmitGauge(metricName, totalInstallTime, nil)
+
+			m.recordStepDurations(ctx, metricsTopic, stepsTimeRun)
 		}
 	} else {
 		_, err = steps.Run(ctx, m.log, 10*time.Second, s, nil)
@@ -409,6 +503,62 @@ func (m *manager) runSteps(ctx context.Context, s []steps.Step, metricsTopic str
 	return err
 }
 
+// recordProgress returns a steps.ProgressFunc which records the step being run
+// on the cluster document, so that the portal can show the progress of the
+// operation.  Failing to record progress does not fail the operation.
+func (m *manager) recordProgress(operation string) steps.ProgressFunc {
+	return func(ctx context.Context, step string, index, count int) {
+		doc, err := m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+			// keep the step durations of the earlier phases of the operation
+			var stepDurations map[string]int64
+			if doc.Progress != nil && doc.Progress.Operation == operation {
+				stepDurations = doc.Progress.StepDurations
+			}
+
+			doc.Progress = &api.OperationProgress{
+				Operation:     operation,
+				Step:          step,
+				StepIndex:     index,
+				StepCount:     count,
+				StartTime:     time.Now().UTC(),
+				StepDurations: stepDurations,
+			}
+			return nil
+		})
+		if err != nil {
+			m.log.Warnf("failed to record progress: %s", err)
+			return
+		}
+		m.doc = doc
+	}
+}
+
+// recordStepDurations records on the cluster document how long each step of a
+// successful run of the operation took, so that benchmarks can track them.
+// Failing to record them does not fail the operation.
+func (m *manager) recordStepDurations(ctx context.Context, operation string, stepDurations map[string]int64) {
+	doc, err := m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		if doc.Progress == nil || doc.Progress.Operation != operation {
+			doc.Progress = &api.OperationProgress{
+				Operation: operation,
+			}
+		}
+
+		if doc.Progress.StepDurations == nil {
+			doc.Progress.StepDurations = map[string]int64{}
+		}
+		for step, duration := range stepDurations {
+			doc.Progress.StepDurations[step] = duration
+		}
+		return nil
+	})
+	if err != nil {
+		m.log.Warnf("failed to record step durations: %s", err)
+		return
+	}
+	m.doc = doc
+}
+
 func (m *manager) startInstallation(ctx context.Context) error {
 	var err error
 	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
@@ -416,7 +566,8 @@ func (m *manager) startInstallation(ctx context.Context) error {
 			// set the install time which is used for the SAS token with which
 			// the bootstrap node retrieves its ignition payload
 			doc.OpenShiftCluster.Properties.Install = &api.Install{
-				Now: time.Now().UTC(),
+				Now:   time.Now().UTC(),
+				Phase: api.InstallPhaseReserve,
 			}
 		}
 		return nil
@@ -424,10 +575,22 @@ func (m *manager) startInstallation(ctx context.Context) error {
 	return err
 }
 
+// nextInstallPhase is the phase which follows each install phase but the last.
+// The values of the phases don't follow their order, since the reserve phase
+// was added before the existing ones.
+var nextInstallPhase = map[api.InstallPhase]api.InstallPhase{
+	api.InstallPhaseReserve:   api.InstallPhaseBootstrap,
+	api.InstallPhaseBootstrap: api.InstallPhaseRemoveBootstrap,
+}
+
 func (m *manager) incrInstallPhase(ctx context.Context) error {
 	var err error
 	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
-		doc.OpenShiftCluster.Properties.Install.Phase++
+		next, ok := nextInstallPhase[doc.OpenShiftCluster.Properties.Install.Phase]
+		if !ok {
+			return fmt.Errorf("no phase follows phase %s", doc.OpenShiftCluster.Properties.Install.Phase)
+		}
+		doc.OpenShiftCluster.Properties.Install.Phase = next
 		return nil
 	})
 	return err
@@ -6,6 +6,7 @@ package cluster
 import (
 	"context"
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -55,6 +56,9 @@ func (e *fakeMetricsEmitter) EmitGauge(metricName string, metricValue int64, dim
 func (e *fakeMetricsEmitter) EmitFloat(metricName string, metricValue float64, dimensions map[string]string) {
 }
 
+func (e *fakeMetricsEmitter) EmitHistogram(metricName string, metricValue float64, dimensions map[string]string) {
+}
+
 var clusterOperator = &configv1.ClusterOperator{
 	ObjectMeta: metav1.ObjectMeta{
 		Name: "operator",
@@ -232,7 +236,66 @@ func TestUpdateProvisionedBy(t *testing.T) {
 	}
 }
 
+func TestInstallPhases(t *testing.T) {
+	ctx := context.Background()
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName1"
+
+	// the phases of installs in flight are stored by value
+	if api.InstallPhaseBootstrap != 0 || api.InstallPhaseRemoveBootstrap != 1 {
+		t.Fatal("install phases were renumbered")
+	}
+
+	openShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+	fixture := testdatabase.NewFixture().WithOpenShiftClusters(openShiftClustersDatabase)
+	fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+		Key: strings.ToLower(key),
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID: key,
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateCreating,
+			},
+		},
+	})
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clusterdoc, err := openShiftClustersDatabase.Dequeue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &manager{
+		doc: clusterdoc,
+		db:  openShiftClustersDatabase,
+	}
+
+	err = m.startInstallation(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	phases := []api.InstallPhase{m.doc.OpenShiftCluster.Properties.Install.Phase}
+	for i := 0; i < 2; i++ {
+		err = m.incrInstallPhase(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		phases = append(phases, m.doc.OpenShiftCluster.Properties.Install.Phase)
+	}
+
+	want := []api.InstallPhase{api.InstallPhaseReserve, api.InstallPhaseBootstrap, api.InstallPhaseRemoveBootstrap}
+	if !reflect.DeepEqual(phases, want) {
+		t.Errorf("got phases %v, want %v", phases, want)
+	}
+
+	err = m.incrInstallPhase(ctx)
+	utilerror.AssertErrorMessage(t, err, "no phase follows phase InstallPhaseRemoveBootstrap")
+}
+
 func TestInstallationTimeMetrics(t *testing.T) {
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName1"
 	_, log := testlog.New()
 	fm := newfakeMetricsEmitter()
 
@@ -242,6 +305,7 @@ func TestInstallationTimeMetrics(t *testing.T) {
 		timePerStep   int64
 		steps         []steps.Step
 		wantedMetrics map[string]int64
+		wantDurations map[string]int64
 	}{
 		{
 			name:         "Failed step run will not generate any install time metrics",
@@ -265,6 +329,10 @@ func TestInstallationTimeMetrics(t *testing.T) {
 				"backend.openshiftcluster.install.action.successfulActionStep.duration.seconds":       2,
 				"backend.openshiftcluster.install.condition.successfulConditionStep.duration.seconds": 2,
 			},
+			wantDurations: map[string]int64{
+				"action.successfulActionStep":       2,
+				"condition.successfulConditionStep": 2,
+			},
 		},
 		{
 			name:         "Succeeded step run for cluster update will generate a valid install time metrics",
@@ -280,17 +348,59 @@ func TestInstallationTimeMetrics(t *testing.T) {
 				"backend.openshiftcluster.update.action.successfulActionStep.duration.seconds":       3,
 				"backend.openshiftcluster.update.condition.successfulConditionStep.duration.seconds": 3,
 			},
+			wantDurations: map[string]int64{
+				"action.successfulActionStep":       3,
+				"condition.successfulConditionStep": 3,
+			},
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
+
+			fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(key),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: key,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateCreating,
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
 			m := &manager{
 				log:            log,
 				metricsEmitter: fm,
+				db:             fakeOpenShiftClustersDatabase,
+				doc:            doc,
 				now:            func() time.Time { return time.Now().Add(time.Duration(tt.timePerStep) * time.Second) },
 			}
 
-			err := m.runSteps(ctx, tt.steps, tt.metricsTopic)
+			err = m.runSteps(ctx, tt.steps, tt.metricsTopic)
+
+			progress := m.doc.Progress
+			if progress == nil ||
+				progress.Operation != tt.metricsTopic ||
+				progress.Step != tt.steps[len(tt.steps)-1].String() ||
+				progress.StepIndex != len(tt.steps)-1 ||
+				progress.StepCount != len(tt.steps) {
+				t.Errorf("unexpected progress %#v", progress)
+			}
+
+			if progress != nil && !reflect.DeepEqual(progress.StepDurations, tt.wantDurations) {
+				t.Errorf("unexpected step durations %v, wanted %v", progress.StepDurations, tt.wantDurations)
+			}
+
 			if err != nil {
 				if len(fm.Metrics) != 0 {
 					t.Error("fake metrics obj should be empty when run steps failed")
@@ -7,11 +7,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/Azure/ARO-RP/pkg/api"
@@ -76,6 +78,19 @@ func (m *manager) createOrUpdateRouterIPFromCluster(ctx context.Context) error {
 
 	ipAddress := svc.Status.LoadBalancer.Ingress[0].IP
 
+	var ipv6Address string
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.IsDualStack() {
+		ipAddress, ipv6Address = routerIPsByFamily(svc.Status.LoadBalancer.Ingress)
+		if ipAddress == "" || ipv6Address == "" {
+			return fmt.Errorf("routerIP not found for both IPv4 and IPv6")
+		}
+
+		err = m.dns.CreateOrUpdateRouterIPv6(ctx, m.doc.OpenShiftCluster, ipv6Address)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = m.dns.CreateOrUpdateRouter(ctx, m.doc.OpenShiftCluster, ipAddress)
 	if err != nil {
 		return err
@@ -83,11 +98,33 @@ func (m *manager) createOrUpdateRouterIPFromCluster(ctx context.Context) error {
 
 	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
 		doc.OpenShiftCluster.Properties.IngressProfiles[0].IP = ipAddress
+		doc.OpenShiftCluster.Properties.IngressProfiles[0].IPv6 = ipv6Address
 		return nil
 	})
 	return err
 }
 
+// routerIPsByFamily returns the first IPv4 and the first IPv6 address of the
+// router service of a dual-stack cluster, which are not in a fixed order
+func routerIPsByFamily(ingresses []corev1.LoadBalancerIngress) (ipv4 string, ipv6 string) {
+	for _, ingress := range ingresses {
+		ip := net.ParseIP(ingress.IP)
+		switch {
+		case ip == nil:
+		case ip.To4() != nil:
+			if ipv4 == "" {
+				ipv4 = ingress.IP
+			}
+		default:
+			if ipv6 == "" {
+				ipv6 = ingress.IP
+			}
+		}
+	}
+
+	return ipv4, ipv6
+}
+
 func (m *manager) createOrUpdateRouterIPEarly(ctx context.Context) error {
 	infraID := m.doc.OpenShiftCluster.Properties.InfraID
 
@@ -190,8 +227,26 @@ func (m *manager) updateAPIIPEarly(ctx context.Context) error {
 		return err
 	}
 
+	// the internal load balancer is IPv4 only, so a private API server has no
+	// IPv6 address
+	var ipv6Address string
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.IsDualStack() &&
+		m.doc.OpenShiftCluster.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
+		ip, err := m.publicIPAddresses.Get(ctx, resourceGroup, infraID+"-pip-v6", "")
+		if err != nil {
+			return err
+		}
+		ipv6Address = *ip.IPAddress
+
+		err = m.dns.UpdateIPv6(ctx, m.doc.OpenShiftCluster, ipv6Address)
+		if err != nil {
+			return err
+		}
+	}
+
 	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
 		doc.OpenShiftCluster.Properties.APIServerProfile.IP = ipAddress
+		doc.OpenShiftCluster.Properties.APIServerProfile.IPv6 = ipv6Address
 		doc.OpenShiftCluster.Properties.APIServerProfile.IntIP = intIPAddress
 		return nil
 	})
@@ -250,7 +305,8 @@ func (m *manager) ensureGatewayCreate(ctx context.Context) error {
 	}
 
 	_, err = m.dbGateway.Create(ctx, &api.GatewayDocument{
-		ID: linkIdentifier,
+		ID:  linkIdentifier,
+		Key: m.doc.Key,
 		Gateway: &api.Gateway{
 			ID:                              m.doc.OpenShiftCluster.ID,
 			StorageSuffix:                   m.doc.OpenShiftCluster.Properties.StorageSuffix,
@@ -263,18 +319,26 @@ func (m *manager) ensureGatewayCreate(ctx context.Context) error {
 		return err
 	}
 
-	// ensure the record is this clusters if it exists
+	// ensure the record is this clusters if it exists.  It may have been
+	// created by an earlier attempt, possibly by an RP which didn't record the
+	// key, in which case the key is backfilled.
 	if recordExists {
 		gwyDoc, err := m.dbGateway.Get(ctx, linkIdentifier)
 		if err != nil {
 			return err
 		}
 		if !strings.EqualFold(gwyDoc.Gateway.ID, m.doc.OpenShiftCluster.ID) ||
-			!strings.EqualFold(gwyDoc.ID, m.doc.OpenShiftCluster.Properties.NetworkProfile.GatewayPrivateLinkID) ||
 			!strings.EqualFold(gwyDoc.Gateway.ImageRegistryStorageAccountName, m.doc.OpenShiftCluster.Properties.ImageRegistryStorageAccountName) ||
 			!strings.EqualFold(gwyDoc.Gateway.StorageSuffix, m.doc.OpenShiftCluster.Properties.StorageSuffix) {
 			return fmt.Errorf("gateway record '%s' already exists for a different cluster '%s'", linkIdentifier, gwyDoc.Gateway.ID)
 		}
+
+		if gwyDoc.Key == "" {
+			err = m.backfillGatewayKey(ctx, linkIdentifier)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
@@ -83,6 +83,62 @@ func TestCreateOrUpdateRouterIPFromCluster(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "dual-stack create/update success",
+			fixtureChecker: func(fixture *testdatabase.Fixture, checker *testdatabase.Checker, dbClient *cosmosdb.FakeOpenShiftClusterDocumentClient) {
+				doc := &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: key,
+						Properties: api.OpenShiftClusterProperties{
+							NetworkProfile: api.NetworkProfile{
+								PodCIDRIPv6:     "fd01::/48",
+								ServiceCIDRIPv6: "fd02::/112",
+							},
+							IngressProfiles: []api.IngressProfile{
+								{
+									Visibility: api.VisibilityPublic,
+									Name:       "default",
+								},
+							},
+							ProvisioningState: api.ProvisioningStateCreating,
+						},
+					},
+				}
+				fixture.AddOpenShiftClusterDocuments(doc)
+
+				doc.Dequeues = 1
+				doc.OpenShiftCluster.Properties.IngressProfiles[0].IP = "1.2.3.4"
+				doc.OpenShiftCluster.Properties.IngressProfiles[0].IPv6 = "2001:db8::1"
+				checker.AddOpenShiftClusterDocuments(doc)
+			},
+			mocks: func(dns *mock_dns.MockManager) {
+				dns.EXPECT().
+					CreateOrUpdateRouterIPv6(gomock.Any(), gomock.Any(), "2001:db8::1").
+					Return(nil)
+				dns.EXPECT().
+					CreateOrUpdateRouter(gomock.Any(), gomock.Any(), "1.2.3.4").
+					Return(nil)
+			},
+			kubernetescli: fake.NewSimpleClientset(&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "router-default",
+					Namespace: "openshift-ingress",
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{
+							{
+								IP: "2001:db8::1",
+							},
+							{
+								IP: "1.2.3.4",
+							},
+						},
+					},
+				},
+			}),
+		},
 		{
 			name: "create/update failed - router IP issue",
 			fixtureChecker: func(fixture *testdatabase.Fixture, checker *testdatabase.Checker, dbClient *cosmosdb.FakeOpenShiftClusterDocumentClient) {
@@ -595,6 +651,73 @@ func TestUpdateAPIIPEarly(t *testing.T) {
 					Return(nil)
 			},
 		},
+		{
+			name: "public dual-stack",
+			fixtureChecker: func(fixture *testdatabase.Fixture, checker *testdatabase.Checker, dbClient *cosmosdb.FakeOpenShiftClusterDocumentClient) {
+				doc := &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: key,
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: resourceGroupID,
+							},
+							NetworkProfile: api.NetworkProfile{
+								PodCIDRIPv6:     "fd01::/48",
+								ServiceCIDRIPv6: "fd02::/112",
+							},
+							APIServerProfile: api.APIServerProfile{
+								Visibility: api.VisibilityPublic,
+							},
+							ProvisioningState: api.ProvisioningStateCreating,
+							InfraID:           "infra",
+						},
+					},
+				}
+				fixture.AddOpenShiftClusterDocuments(doc)
+
+				doc.Dequeues = 1
+				doc.OpenShiftCluster.Properties.APIServerProfile.IP = "1.2.3.4"
+				doc.OpenShiftCluster.Properties.APIServerProfile.IPv6 = "2001:db8::1"
+				doc.OpenShiftCluster.Properties.APIServerProfile.IntIP = "10.0.0.1"
+				checker.AddOpenShiftClusterDocuments(doc)
+			},
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient, dns *mock_dns.MockManager) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-internal", "").
+					Return(mgmtnetwork.LoadBalancer{
+						LoadBalancerPropertiesFormat: &mgmtnetwork.LoadBalancerPropertiesFormat{
+							FrontendIPConfigurations: &[]mgmtnetwork.FrontendIPConfiguration{
+								{
+									FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+										PrivateIPAddress: to.StringPtr("10.0.0.1"),
+									},
+								},
+							},
+						},
+					}, nil)
+				publicIPAddresses.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-pip-v4", "").
+					Return(mgmtnetwork.PublicIPAddress{
+						PublicIPAddressPropertiesFormat: &mgmtnetwork.PublicIPAddressPropertiesFormat{
+							IPAddress: to.StringPtr("1.2.3.4"),
+						},
+					}, nil)
+				publicIPAddresses.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-pip-v6", "").
+					Return(mgmtnetwork.PublicIPAddress{
+						PublicIPAddressPropertiesFormat: &mgmtnetwork.PublicIPAddressPropertiesFormat{
+							IPAddress: to.StringPtr("2001:db8::1"),
+						},
+					}, nil)
+				dns.EXPECT().
+					Update(gomock.Any(), gomock.Any(), "1.2.3.4").
+					Return(nil)
+				dns.EXPECT().
+					UpdateIPv6(gomock.Any(), gomock.Any(), "2001:db8::1").
+					Return(nil)
+			},
+		},
 		{
 			name: "private",
 			fixtureChecker: func(fixture *testdatabase.Fixture, checker *testdatabase.Checker, dbClient *cosmosdb.FakeOpenShiftClusterDocumentClient) {
@@ -692,6 +815,66 @@ func TestEnsureGatewayCreate(t *testing.T) {
 	ctx := context.Background()
 	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
 
+	okMocks := func(env *mock_env.MockInterface, privateEndpoints *mock_network.MockPrivateEndpointsClient, rpPrivateLinkServices *mock_network.MockPrivateLinkServicesClient) {
+		env.EXPECT().GatewayResourceGroup().AnyTimes().Return("gatewayResourceGroup")
+		privateEndpoints.EXPECT().Get(ctx, "clusterResourceGroup", "infra-pe", "networkInterfaces").Return(mgmtnetwork.PrivateEndpoint{
+			PrivateEndpointProperties: &mgmtnetwork.PrivateEndpointProperties{
+				NetworkInterfaces: &[]mgmtnetwork.Interface{
+					{
+						InterfacePropertiesFormat: &mgmtnetwork.InterfacePropertiesFormat{
+							IPConfigurations: &[]mgmtnetwork.InterfaceIPConfiguration{
+								{
+									InterfaceIPConfigurationPropertiesFormat: &mgmtnetwork.InterfaceIPConfigurationPropertiesFormat{
+										PrivateIPAddress: to.StringPtr("1.2.3.4"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ID: to.StringPtr("peID"),
+		}, nil)
+		rpPrivateLinkServices.EXPECT().Get(ctx, "gatewayResourceGroup", "gateway-pls-001", "").Return(mgmtnetwork.PrivateLinkService{
+			PrivateLinkServiceProperties: &mgmtnetwork.PrivateLinkServiceProperties{
+				PrivateEndpointConnections: &[]mgmtnetwork.PrivateEndpointConnection{
+					{
+						PrivateEndpointConnectionProperties: &mgmtnetwork.PrivateEndpointConnectionProperties{
+							PrivateEndpoint: &mgmtnetwork.PrivateEndpoint{
+								ID: to.StringPtr("otherPeID"),
+							},
+						},
+					},
+					{
+						PrivateEndpointConnectionProperties: &mgmtnetwork.PrivateEndpointConnectionProperties{
+							PrivateEndpoint: &mgmtnetwork.PrivateEndpoint{
+								ID: to.StringPtr("peID"),
+							},
+							PrivateLinkServiceConnectionState: &mgmtnetwork.PrivateLinkServiceConnectionState{
+								Status: to.StringPtr(""),
+							},
+							LinkIdentifier: to.StringPtr("1234"),
+						},
+						Name: to.StringPtr("conn"),
+					},
+				},
+			},
+		}, nil)
+		rpPrivateLinkServices.EXPECT().UpdatePrivateEndpointConnection(ctx, "gatewayResourceGroup", "gateway-pls-001", "conn", mgmtnetwork.PrivateEndpointConnection{
+			PrivateEndpointConnectionProperties: &mgmtnetwork.PrivateEndpointConnectionProperties{
+				PrivateEndpoint: &mgmtnetwork.PrivateEndpoint{
+					ID: to.StringPtr("peID"),
+				},
+				PrivateLinkServiceConnectionState: &mgmtnetwork.PrivateLinkServiceConnectionState{
+					Status:      to.StringPtr("Approved"),
+					Description: to.StringPtr("Approved"),
+				},
+				LinkIdentifier: to.StringPtr("1234"),
+			},
+			Name: to.StringPtr("conn"),
+		}).Return(mgmtnetwork.PrivateEndpointConnection{}, nil)
+	}
+
 	for _, tt := range []struct {
 		name                     string
 		mocks                    func(*mock_env.MockInterface, *mock_network.MockPrivateEndpointsClient, *mock_network.MockPrivateLinkServicesClient)
@@ -740,66 +923,89 @@ func TestEnsureGatewayCreate(t *testing.T) {
 			wantErr:        "private endpoint connection not found",
 		},
 		{
-			name: "ok",
-			mocks: func(env *mock_env.MockInterface, privateEndpoints *mock_network.MockPrivateEndpointsClient, rpPrivateLinkServices *mock_network.MockPrivateLinkServicesClient) {
-				env.EXPECT().GatewayResourceGroup().AnyTimes().Return("gatewayResourceGroup")
-				privateEndpoints.EXPECT().Get(ctx, "clusterResourceGroup", "infra-pe", "networkInterfaces").Return(mgmtnetwork.PrivateEndpoint{
-					PrivateEndpointProperties: &mgmtnetwork.PrivateEndpointProperties{
-						NetworkInterfaces: &[]mgmtnetwork.Interface{
-							{
-								InterfacePropertiesFormat: &mgmtnetwork.InterfacePropertiesFormat{
-									IPConfigurations: &[]mgmtnetwork.InterfaceIPConfiguration{
-										{
-											InterfaceIPConfigurationPropertiesFormat: &mgmtnetwork.InterfaceIPConfigurationPropertiesFormat{
-												PrivateIPAddress: to.StringPtr("1.2.3.4"),
-											},
-										},
-									},
-								},
+			name:  "ok",
+			mocks: okMocks,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+					},
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+						Properties: api.OpenShiftClusterProperties{
+							NetworkProfile: api.NetworkProfile{
+								GatewayPrivateEndpointIP: "1.2.3.4",
+								GatewayPrivateLinkID:     "1234",
 							},
 						},
 					},
-					ID: to.StringPtr("peID"),
-				}, nil)
-				rpPrivateLinkServices.EXPECT().Get(ctx, "gatewayResourceGroup", "gateway-pls-001", "").Return(mgmtnetwork.PrivateLinkService{
-					PrivateLinkServiceProperties: &mgmtnetwork.PrivateLinkServiceProperties{
-						PrivateEndpointConnections: &[]mgmtnetwork.PrivateEndpointConnection{
-							{
-								PrivateEndpointConnectionProperties: &mgmtnetwork.PrivateEndpointConnectionProperties{
-									PrivateEndpoint: &mgmtnetwork.PrivateEndpoint{
-										ID: to.StringPtr("otherPeID"),
-									},
-								},
-							},
-							{
-								PrivateEndpointConnectionProperties: &mgmtnetwork.PrivateEndpointConnectionProperties{
-									PrivateEndpoint: &mgmtnetwork.PrivateEndpoint{
-										ID: to.StringPtr("peID"),
-									},
-									PrivateLinkServiceConnectionState: &mgmtnetwork.PrivateLinkServiceConnectionState{
-										Status: to.StringPtr(""),
-									},
-									LinkIdentifier: to.StringPtr("1234"),
-								},
-								Name: to.StringPtr("conn"),
+				})
+				c.AddGatewayDocuments(&api.GatewayDocument{
+					ID:  "1234",
+					Key: strings.ToLower(resourceID),
+					Gateway: &api.Gateway{
+						ID:                              resourceID,
+						StorageSuffix:                   "storageSuffix",
+						ImageRegistryStorageAccountName: "imageRegistryStorageAccountName",
+					},
+				})
+			},
+			gatewayEnabled: true,
+		},
+		{
+			name:  "ok: record already created by an earlier attempt",
+			mocks: okMocks,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+					},
+				})
+				f.AddGatewayDocuments(&api.GatewayDocument{
+					ID:  "1234",
+					Key: strings.ToLower(resourceID),
+					Gateway: &api.Gateway{
+						ID:                              resourceID,
+						StorageSuffix:                   "storageSuffix",
+						ImageRegistryStorageAccountName: "imageRegistryStorageAccountName",
+					},
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+						Properties: api.OpenShiftClusterProperties{
+							NetworkProfile: api.NetworkProfile{
+								GatewayPrivateEndpointIP: "1.2.3.4",
+								GatewayPrivateLinkID:     "1234",
 							},
 						},
 					},
-				}, nil)
-				rpPrivateLinkServices.EXPECT().UpdatePrivateEndpointConnection(ctx, "gatewayResourceGroup", "gateway-pls-001", "conn", mgmtnetwork.PrivateEndpointConnection{
-					PrivateEndpointConnectionProperties: &mgmtnetwork.PrivateEndpointConnectionProperties{
-						PrivateEndpoint: &mgmtnetwork.PrivateEndpoint{
-							ID: to.StringPtr("peID"),
-						},
-						PrivateLinkServiceConnectionState: &mgmtnetwork.PrivateLinkServiceConnectionState{
-							Status:      to.StringPtr("Approved"),
-							Description: to.StringPtr("Approved"),
-						},
-						LinkIdentifier: to.StringPtr("1234"),
+				})
+				c.AddGatewayDocuments(&api.GatewayDocument{
+					ID:  "1234",
+					Key: strings.ToLower(resourceID),
+					Gateway: &api.Gateway{
+						ID:                              resourceID,
+						StorageSuffix:                   "storageSuffix",
+						ImageRegistryStorageAccountName: "imageRegistryStorageAccountName",
 					},
-					Name: to.StringPtr("conn"),
-				}).Return(mgmtnetwork.PrivateEndpointConnection{}, nil)
+				})
 			},
+			gatewayEnabled: true,
+		},
+		{
+			name:  "ok: record without key created by an earlier attempt",
+			mocks: okMocks,
 			fixture: func(f *testdatabase.Fixture) {
 				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
 					Key: strings.ToLower(resourceID),
@@ -807,6 +1013,14 @@ func TestEnsureGatewayCreate(t *testing.T) {
 						ID: resourceID,
 					},
 				})
+				f.AddGatewayDocuments(&api.GatewayDocument{
+					ID: "1234",
+					Gateway: &api.Gateway{
+						ID:                              resourceID,
+						StorageSuffix:                   "storageSuffix",
+						ImageRegistryStorageAccountName: "imageRegistryStorageAccountName",
+					},
+				})
 			},
 			checker: func(c *testdatabase.Checker) {
 				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
@@ -822,7 +1036,8 @@ func TestEnsureGatewayCreate(t *testing.T) {
 					},
 				})
 				c.AddGatewayDocuments(&api.GatewayDocument{
-					ID: "1234",
+					ID:  "1234",
+					Key: strings.ToLower(resourceID),
 					Gateway: &api.Gateway{
 						ID:                              resourceID,
 						StorageSuffix:                   "storageSuffix",
@@ -832,6 +1047,58 @@ func TestEnsureGatewayCreate(t *testing.T) {
 			},
 			gatewayEnabled: true,
 		},
+		{
+			name:  "error: record belongs to another cluster",
+			mocks: okMocks,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddGatewayDocuments(&api.GatewayDocument{
+					ID:  "1234",
+					Key: "othercluster",
+					Gateway: &api.Gateway{
+						ID: "otherCluster",
+					},
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddGatewayDocuments(&api.GatewayDocument{
+					ID:  "1234",
+					Key: "othercluster",
+					Gateway: &api.Gateway{
+						ID: "otherCluster",
+					},
+				})
+			},
+			gatewayEnabled: true,
+			wantErr:        "gateway record '1234' already exists for a different cluster 'otherCluster'",
+		},
+		{
+			name:  "error: record belongs to another cluster with the same resource ID",
+			mocks: okMocks,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddGatewayDocuments(&api.GatewayDocument{
+					ID:  "1234",
+					Key: strings.ToLower(resourceID),
+					Gateway: &api.Gateway{
+						ID:                              resourceID,
+						StorageSuffix:                   "otherStorageSuffix",
+						ImageRegistryStorageAccountName: "imageRegistryStorageAccountName",
+					},
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddGatewayDocuments(&api.GatewayDocument{
+					ID:  "1234",
+					Key: strings.ToLower(resourceID),
+					Gateway: &api.Gateway{
+						ID:                              resourceID,
+						StorageSuffix:                   "otherStorageSuffix",
+						ImageRegistryStorageAccountName: "imageRegistryStorageAccountName",
+					},
+				})
+			},
+			gatewayEnabled: true,
+			wantErr:        "gateway record '1234' already exists for a different cluster '" + resourceID + "'",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			controller := gomock.NewController(t)
@@ -0,0 +1,105 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/ocm"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+// registerOCMCluster registers the cluster with OpenShift Cluster Manager
+// using the cloud.openshift.com token of the customer's pull secret, and
+// records the resulting subscription.  Clusters whose pull secret has no such
+// token are not registered.  OCM is not on the critical path: failures are
+// logged, and the registration is retried by the next admin update.
+func (m *manager) registerOCMCluster(ctx context.Context) error {
+	if m.ocm == nil {
+		return nil
+	}
+
+	token, err := ocm.Token(m.doc.OpenShiftCluster)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		m.log.Print("no cloud.openshift.com token in the pull secret, not registering with OCM")
+		return nil
+	}
+
+	cv, err := m.configcli.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	v, err := version.GetClusterVersion(cv)
+	if err != nil {
+		return err
+	}
+
+	cluster := &ocm.Cluster{
+		ID:          string(cv.Spec.ClusterID),
+		DisplayName: m.doc.OpenShiftCluster.Name,
+		Version:     v.String(),
+		Channel:     cv.Spec.Channel,
+	}
+
+	subscription, err := m.ocm.RegisterCluster(ctx, token, cluster)
+	if err != nil {
+		m.log.Warnf("registering with OCM failed: %s", err)
+		return nil
+	}
+
+	subscription.Status = ocm.SubscriptionStatusFor(m.doc, m.subscriptionDoc)
+	err = m.ocm.UpdateSubscription(ctx, token, cluster, subscription)
+	if err != nil {
+		m.log.Warnf("updating the OCM subscription failed: %s", err)
+		return nil
+	}
+
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.OCMProfile = api.OCMProfile{
+			ClusterID:          cluster.ID,
+			SubscriptionID:     subscription.ID,
+			SubscriptionStatus: string(subscription.Status),
+		}
+		return nil
+	})
+	return err
+}
+
+// deprovisionOCMSubscription marks the OCM subscription of a deleted cluster
+// as deprovisioned, which releases its support entitlement.  If this fails,
+// the backend OCM sync retries it.
+func (m *manager) deprovisionOCMSubscription(ctx context.Context) error {
+	p := m.doc.OpenShiftCluster.Properties.OCMProfile
+	if m.ocm == nil || p.SubscriptionID == "" || p.SubscriptionStatus == string(ocm.SubscriptionStatusDeprovisioned) {
+		return nil
+	}
+
+	token, err := ocm.Token(m.doc.OpenShiftCluster)
+	if err != nil || token == "" {
+		return err
+	}
+
+	m.log.Print("deprovisioning the OCM subscription")
+	err = m.ocm.UpdateSubscription(ctx, token, &ocm.Cluster{ID: p.ClusterID}, &ocm.Subscription{
+		ID:     p.SubscriptionID,
+		Status: ocm.SubscriptionStatusDeprovisioned,
+	})
+	if err != nil {
+		m.log.Warnf("deprovisioning the OCM subscription failed: %s", err)
+		return nil
+	}
+
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.OCMProfile.SubscriptionStatus = string(ocm.SubscriptionStatusDeprovisioned)
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,217 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	configv1 "github.com/openshift/api/config/v1"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_ocm "github.com/Azure/ARO-RP/pkg/util/mocks/ocm"
+	"github.com/Azure/ARO-RP/pkg/util/ocm"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestRegisterOCMCluster(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+
+	const (
+		token     = "b2NtOnRva2Vu"
+		clusterID = "11111111-1111-1111-1111-111111111111"
+	)
+
+	cluster := &ocm.Cluster{
+		ID:          clusterID,
+		DisplayName: "resourceName",
+		Version:     "4.14.16",
+		Channel:     "stable-4.14",
+	}
+
+	for _, tt := range []struct {
+		name           string
+		pullSecret     api.SecureString
+		mocks          func(*mock_ocm.MockClient)
+		wantOCMProfile api.OCMProfile
+	}{
+		{
+			name:       "registered",
+			pullSecret: `{"auths":{"cloud.openshift.com":{"auth":"` + token + `"}}}`,
+			mocks: func(client *mock_ocm.MockClient) {
+				client.EXPECT().
+					RegisterCluster(gomock.Any(), token, cluster).
+					Return(&ocm.Subscription{ID: "sub", Status: "Reserved"}, nil)
+				client.EXPECT().
+					UpdateSubscription(gomock.Any(), token, cluster, &ocm.Subscription{ID: "sub", Status: ocm.SubscriptionStatusActive}).
+					Return(nil)
+			},
+			wantOCMProfile: api.OCMProfile{
+				ClusterID:          clusterID,
+				SubscriptionID:     "sub",
+				SubscriptionStatus: "Active",
+			},
+		},
+		{
+			name:       "no cloud.openshift.com token",
+			pullSecret: `{"auths":{"registry.redhat.io":{"auth":"` + token + `"}}}`,
+		},
+		{
+			name: "no pull secret",
+		},
+		{
+			name:       "OCM unavailable",
+			pullSecret: `{"auths":{"cloud.openshift.com":{"auth":"` + token + `"}}}`,
+			mocks: func(client *mock_ocm.MockClient) {
+				client.EXPECT().
+					RegisterCluster(gomock.Any(), token, cluster).
+					Return(nil, errors.New("unexpected status code 503"))
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			client := mock_ocm.NewMockClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(client)
+			}
+
+			fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID:   resourceID,
+					Name: "resourceName",
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateCreating,
+						ClusterProfile: api.ClusterProfile{
+							PullSecret: tt.pullSecret,
+						},
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				doc: doc,
+				db:  fakeOpenShiftClustersDatabase,
+				ocm: client,
+				configcli: configfake.NewSimpleClientset(&configv1.ClusterVersion{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "version",
+					},
+					Spec: configv1.ClusterVersionSpec{
+						ClusterID: clusterID,
+						Channel:   "stable-4.14",
+					},
+					Status: configv1.ClusterVersionStatus{
+						History: []configv1.UpdateHistory{
+							{
+								State:   configv1.CompletedUpdate,
+								Version: "4.14.16",
+							},
+						},
+					},
+				}),
+			}
+
+			err = m.registerOCMCluster(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err = fakeOpenShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(doc.OpenShiftCluster.Properties.OCMProfile, tt.wantOCMProfile) {
+				t.Error(doc.OpenShiftCluster.Properties.OCMProfile)
+			}
+		})
+	}
+}
+
+func TestDeprovisionOCMSubscription(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	client := mock_ocm.NewMockClient(controller)
+	client.EXPECT().
+		UpdateSubscription(gomock.Any(), "b2NtOnRva2Vu", &ocm.Cluster{ID: "cluster"}, &ocm.Subscription{ID: "sub", Status: ocm.SubscriptionStatusDeprovisioned}).
+		Return(nil)
+
+	fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+	fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+	fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+		Key: strings.ToLower(resourceID),
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID: resourceID,
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateDeleting,
+				ClusterProfile: api.ClusterProfile{
+					PullSecret: `{"auths":{"cloud.openshift.com":{"auth":"b2NtOnRva2Vu"}}}`,
+				},
+				OCMProfile: api.OCMProfile{
+					ClusterID:          "cluster",
+					SubscriptionID:     "sub",
+					SubscriptionStatus: "Active",
+				},
+			},
+		},
+	})
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &manager{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		doc: doc,
+		db:  fakeOpenShiftClustersDatabase,
+		ocm: client,
+	}
+
+	err = m.deprovisionOCMSubscription(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.doc.OpenShiftCluster.Properties.OCMProfile.SubscriptionStatus != "Deprovisioned" {
+		t.Error(m.doc.OpenShiftCluster.Properties.OCMProfile.SubscriptionStatus)
+	}
+
+	// a deprovisioned subscription is left alone
+	err = m.deprovisionOCMSubscription(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
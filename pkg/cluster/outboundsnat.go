@@ -0,0 +1,139 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+const (
+	// snatPortsPerIP is the number of SNAT ports provided by each frontend IP
+	// of an outbound rule
+	snatPortsPerIP = 64000
+
+	// defaultAllocatedOutboundPorts is the number of SNAT ports allocated to
+	// each node when the cluster does not specify allocatedOutboundPorts
+	defaultAllocatedOutboundPorts = 1024
+
+	maxManagedOutboundIPs = 20
+)
+
+// reconcileOutboundSNAT sizes the SNAT allocation of outbound-rule-v4 to the
+// current number of nodes.  If the managed outbound IPs cannot provide every
+// node with its desired allocated outbound ports, more are added, up to the
+// maximum of 20; if that is still not enough, the ports allocated to each node
+// are reduced so that the load balancer accepts the allocation.  Managed
+// outbound IPs are never removed, so that the cluster's egress IPs do not
+// change as it scales down.
+func (m *manager) reconcileOutboundSNAT(ctx context.Context) error {
+	lbp := m.doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.OutboundType != api.OutboundTypeLoadbalancer ||
+		m.doc.OpenShiftCluster.Properties.ArchitectureVersion == api.ArchitectureVersionV1 ||
+		lbp == nil {
+		return nil
+	}
+
+	nodes, err := m.kubernetescli.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	nodeCount := len(nodes.Items)
+	if nodeCount == 0 {
+		return nil
+	}
+
+	desiredPorts := defaultAllocatedOutboundPorts
+	if lbp.AllocatedOutboundPorts != nil && *lbp.AllocatedOutboundPorts > 0 {
+		desiredPorts = *lbp.AllocatedOutboundPorts
+	}
+
+	if lbp.ManagedOutboundIPs != nil {
+		required := requiredOutboundIPs(nodeCount, desiredPorts)
+		if required > lbp.ManagedOutboundIPs.Count {
+			m.log.Infof("scaling managed outbound IPs from %d to %d for %d nodes", lbp.ManagedOutboundIPs.Count, required, nodeCount)
+
+			m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+				doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPs.Count = required
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			err = m.reconcileLoadBalancerProfile(ctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	resourceGroupName := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+	infraID := m.doc.OpenShiftCluster.Properties.InfraID
+
+	lb, err := m.loadBalancers.Get(ctx, resourceGroupName, infraID, "")
+	if err != nil {
+		return err
+	}
+
+	outboundIPCount := len(getOutboundIPsFromLB(lb))
+	if outboundIPCount == 0 {
+		return nil
+	}
+
+	ports := allocatedOutboundPorts(nodeCount, outboundIPCount, desiredPorts)
+	if ports < desiredPorts {
+		m.log.Warnf("%d outbound IPs cannot provide %d nodes with %d SNAT ports each, allocating %d", outboundIPCount, nodeCount, desiredPorts, ports)
+	}
+
+	for _, obRule := range *lb.LoadBalancerPropertiesFormat.OutboundRules {
+		if *obRule.Name != outboundRuleV4 {
+			continue
+		}
+
+		if obRule.AllocatedOutboundPorts != nil && int(*obRule.AllocatedOutboundPorts) == ports {
+			return nil
+		}
+
+		m.log.Infof("allocating %d SNAT ports to each of %d nodes", ports, nodeCount)
+		obRule.AllocatedOutboundPorts = to.Int32Ptr(int32(ports))
+
+		return m.loadBalancers.CreateOrUpdateAndWait(ctx, resourceGroupName, infraID, lb)
+	}
+
+	return nil
+}
+
+// requiredOutboundIPs returns the number of outbound IPs, up to the maximum
+// number of managed outbound IPs, needed to allocate ports SNAT ports to each
+// of nodeCount nodes
+func requiredOutboundIPs(nodeCount, ports int) int {
+	required := (nodeCount*ports + snatPortsPerIP - 1) / snatPortsPerIP
+
+	if required < 1 {
+		return 1
+	}
+	if required > maxManagedOutboundIPs {
+		return maxManagedOutboundIPs
+	}
+	return required
+}
+
+// allocatedOutboundPorts returns the SNAT ports to allocate to each of
+// nodeCount nodes: desiredPorts if outboundIPCount outbound IPs provide enough
+// ports, otherwise as many as they do.  Allocations are multiples of 8.
+func allocatedOutboundPorts(nodeCount, outboundIPCount, desiredPorts int) int {
+	ports := outboundIPCount * snatPortsPerIP / nodeCount
+	if ports > desiredPorts {
+		ports = desiredPorts
+	}
+
+	return ports / 8 * 8
+}
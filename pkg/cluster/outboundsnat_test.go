@@ -0,0 +1,229 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	"github.com/Azure/ARO-RP/pkg/util/uuid"
+	uuidfake "github.com/Azure/ARO-RP/pkg/util/uuid/fake"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestRequiredOutboundIPs(t *testing.T) {
+	for _, tt := range []struct {
+		nodeCount int
+		ports     int
+		want      int
+	}{
+		{nodeCount: 6, ports: 1024, want: 1},
+		{nodeCount: 62, ports: 1024, want: 1},
+		{nodeCount: 63, ports: 1024, want: 2},
+		{nodeCount: 250, ports: 1024, want: 4},
+		{nodeCount: 2000, ports: 1024, want: 20},
+	} {
+		t.Run(fmt.Sprintf("%d nodes with %d ports", tt.nodeCount, tt.ports), func(t *testing.T) {
+			if got := requiredOutboundIPs(tt.nodeCount, tt.ports); got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestAllocatedOutboundPorts(t *testing.T) {
+	for _, tt := range []struct {
+		nodeCount       int
+		outboundIPCount int
+		desiredPorts    int
+		want            int
+	}{
+		{nodeCount: 6, outboundIPCount: 1, desiredPorts: 1024, want: 1024},
+		{nodeCount: 100, outboundIPCount: 1, desiredPorts: 1024, want: 640},
+		{nodeCount: 3000, outboundIPCount: 20, desiredPorts: 1024, want: 424},
+		{nodeCount: 6, outboundIPCount: 1, desiredPorts: 1001, want: 1000},
+	} {
+		t.Run(fmt.Sprintf("%d nodes with %d IPs", tt.nodeCount, tt.outboundIPCount), func(t *testing.T) {
+			if got := allocatedOutboundPorts(tt.nodeCount, tt.outboundIPCount, tt.desiredPorts); got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestReconcileOutboundSNAT(t *testing.T) {
+	ctx := context.Background()
+	infraID := "infraID"
+	location := "eastus"
+	clusterRGID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG"
+	clusterRGName := "clusterRG"
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+
+	nodes := func(count int) []runtime.Object {
+		objects := make([]runtime.Object, 0, count)
+		for i := 0; i < count; i++ {
+			objects = append(objects, &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("node-%d", i),
+				},
+			})
+		}
+		return objects
+	}
+
+	withAllocatedOutboundPorts := func(lb mgmtnetwork.LoadBalancer, ports int32) mgmtnetwork.LoadBalancer {
+		(*lb.OutboundRules)[0].AllocatedOutboundPorts = to.Int32Ptr(ports)
+		return lb
+	}
+
+	for _, tt := range []struct {
+		name             string
+		nodeCount        int
+		managedIPCount   int
+		uuids            []string
+		mocks            func(*mock_network.MockLoadBalancersClient, *mock_network.MockPublicIPAddressesClient)
+		wantManagedCount int
+	}{
+		{
+			name:           "ports are allocated to each node",
+			nodeCount:      6,
+			managedIPCount: 1,
+			mocks: func(loadBalancersClient *mock_network.MockLoadBalancersClient, _ *mock_network.MockPublicIPAddressesClient) {
+				loadBalancersClient.EXPECT().
+					Get(gomock.Any(), clusterRGName, infraID, "").
+					Return(fakeLoadBalancersGet(0, api.VisibilityPublic), nil)
+				loadBalancersClient.EXPECT().
+					CreateOrUpdateAndWait(gomock.Any(), clusterRGName, infraID, withAllocatedOutboundPorts(fakeLoadBalancersGet(0, api.VisibilityPublic), 1024)).
+					Return(nil)
+			},
+			wantManagedCount: 1,
+		},
+		{
+			name:           "allocation is unchanged",
+			nodeCount:      6,
+			managedIPCount: 1,
+			mocks: func(loadBalancersClient *mock_network.MockLoadBalancersClient, _ *mock_network.MockPublicIPAddressesClient) {
+				loadBalancersClient.EXPECT().
+					Get(gomock.Any(), clusterRGName, infraID, "").
+					Return(withAllocatedOutboundPorts(fakeLoadBalancersGet(0, api.VisibilityPublic), 1024), nil)
+			},
+			wantManagedCount: 1,
+		},
+		{
+			name:           "managed outbound IPs are added for a large cluster",
+			nodeCount:      100,
+			managedIPCount: 1,
+			uuids:          []string{"uuid1"},
+			mocks: func(loadBalancersClient *mock_network.MockLoadBalancersClient, publicIPAddressClient *mock_network.MockPublicIPAddressesClient) {
+				publicIPAddressClient.EXPECT().
+					List(gomock.Any(), clusterRGName).
+					Return(getFakePublicIPList(0), nil)
+				publicIPAddressClient.EXPECT().
+					CreateOrUpdateAndWait(gomock.Any(), clusterRGName, "uuid1-outbound-pip-v4", getFakePublicIPAddress("uuid1-outbound-pip-v4", location)).
+					Return(nil)
+				loadBalancersClient.EXPECT().
+					Get(gomock.Any(), clusterRGName, infraID, "").
+					Return(fakeLoadBalancersGet(0, api.VisibilityPublic), nil)
+				loadBalancersClient.EXPECT().
+					CreateOrUpdateAndWait(gomock.Any(), clusterRGName, infraID, fakeUpdatedLoadBalancer(1)).
+					Return(nil)
+				publicIPAddressClient.EXPECT().
+					List(gomock.Any(), clusterRGName).
+					Return(getFakePublicIPList(1), nil)
+				loadBalancersClient.EXPECT().
+					Get(gomock.Any(), clusterRGName, infraID, "").
+					Return(fakeLoadBalancersGet(1, api.VisibilityPublic), nil)
+				loadBalancersClient.EXPECT().
+					Get(gomock.Any(), clusterRGName, infraID, "").
+					Return(fakeLoadBalancersGet(1, api.VisibilityPublic), nil)
+				loadBalancersClient.EXPECT().
+					CreateOrUpdateAndWait(gomock.Any(), clusterRGName, infraID, withAllocatedOutboundPorts(fakeLoadBalancersGet(1, api.VisibilityPublic), 1024)).
+					Return(nil)
+			},
+			wantManagedCount: 2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			doc := &api.OpenShiftClusterDocument{
+				Key: strings.ToLower(key),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID:       key,
+					Location: location,
+					Properties: api.OpenShiftClusterProperties{
+						ArchitectureVersion: api.ArchitectureVersionV2,
+						ProvisioningState:   api.ProvisioningStateUpdating,
+						ClusterProfile: api.ClusterProfile{
+							ResourceGroupID: clusterRGID,
+						},
+						InfraID: infraID,
+						APIServerProfile: api.APIServerProfile{
+							Visibility: api.VisibilityPublic,
+						},
+						NetworkProfile: api.NetworkProfile{
+							OutboundType: api.OutboundTypeLoadbalancer,
+							LoadBalancerProfile: &api.LoadBalancerProfile{
+								ManagedOutboundIPs: &api.ManagedOutboundIPs{
+									Count: tt.managedIPCount,
+								},
+								EffectiveOutboundIPs: []api.EffectiveOutboundIP{
+									{
+										ID: clusterRGID + "/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			openShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(openShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(doc)
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			uuid.DefaultGenerator = uuidfake.NewGenerator(tt.uuids)
+
+			loadBalancersClient := mock_network.NewMockLoadBalancersClient(controller)
+			publicIPAddressClient := mock_network.NewMockPublicIPAddressesClient(controller)
+			tt.mocks(loadBalancersClient, publicIPAddressClient)
+
+			m := &manager{
+				log:               logrus.NewEntry(logrus.StandardLogger()),
+				doc:               doc,
+				db:                openShiftClustersDatabase,
+				kubernetescli:     fake.NewSimpleClientset(nodes(tt.nodeCount)...),
+				loadBalancers:     loadBalancersClient,
+				publicIPAddresses: publicIPAddressClient,
+			}
+
+			err = m.reconcileOutboundSNAT(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if count := m.doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPs.Count; count != tt.wantManagedCount {
+				t.Error(count)
+			}
+		})
+	}
+}
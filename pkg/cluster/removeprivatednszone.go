@@ -11,11 +11,25 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 
+	"github.com/Azure/ARO-RP/pkg/operator"
 	"github.com/Azure/ARO-RP/pkg/util/ready"
 	"github.com/Azure/ARO-RP/pkg/util/stringutils"
 	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
+// privateDNSZoneRemovalEnabled returns true if the cluster should be migrated
+// off its private DNS zone.  Clusters created before the flag was introduced
+// are opted in by setting it via the admin API.
+func (m *manager) privateDNSZoneRemovalEnabled() bool {
+	flags := m.doc.OpenShiftCluster.Properties.OperatorFlags
+	return flags[operator.DnsmasqEnabled] == operator.FlagTrue &&
+		flags[operator.PrivateDNSZoneRemovalEnabled] == operator.FlagTrue
+}
+
+// removePrivateDNSZone removes the cluster's private DNS zone once every node
+// resolves the cluster domains locally via dnsmasq.  The ARO operator clears
+// the zone from the cluster DNS config under the same conditions; whichever
+// runs first wins.
 func (m *manager) removePrivateDNSZone(ctx context.Context) error {
 	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
 
@@ -0,0 +1,77 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/arm"
+	"github.com/Azure/ARO-RP/pkg/util/quota"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// validateQuota checks the quota of the subscription against what is in use
+// now.  The frontend checked it when the cluster was created, but other
+// clusters and the customer's own resources may have taken quota since.
+// Clusters which are queued but have no VMs yet are not counted: those already
+// being installed by another backend may have created their VMs, which the
+// Usage API would count a second time.
+func (m *manager) validateQuota(ctx context.Context) error {
+	return quota.Validate(ctx, m.doc.OpenShiftCluster, nil, m.env.VMSku, m.networkUsage, m.computeUsage)
+}
+
+// reservedPublicIPAddresses returns the public IP addresses of the cluster which have
+// fixed names, as they are declared by the base resource template.  Further
+// managed outbound IPs are named randomly when that template is deployed, so
+// are not reserved.
+func (m *manager) reservedPublicIPAddresses() []*arm.Resource {
+	infraID := m.doc.OpenShiftCluster.Properties.InfraID
+	azureRegion := strings.ToLower(m.doc.OpenShiftCluster.Location) // Used in k8s object names, so must pass DNS-1123 validation
+
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.OutboundType != api.OutboundTypeLoadbalancer {
+		return nil
+	}
+
+	var resources []*arm.Resource
+	if m.doc.OpenShiftCluster.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
+		resources = append(resources,
+			m.networkPublicIPAddressFromPrefix(azureRegion, infraID+"-pip-v4"),
+		)
+	}
+
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.IsDualStack() {
+		resources = append(resources,
+			m.networkPublicIPv6Address(azureRegion, infraID+"-pip-v6"),
+		)
+	}
+
+	if m.doc.OpenShiftCluster.Properties.IngressProfiles[0].Visibility == api.VisibilityPublic {
+		resources = append(resources,
+			m.networkPublicIPAddressFromPrefix(azureRegion, infraID+"-default-v4"),
+		)
+	}
+
+	return resources
+}
+
+// deployPublicIPAddresses creates the public IP addresses of the cluster ahead
+// of the base resource template, which declares them again unchanged
+func (m *manager) deployPublicIPAddresses(ctx context.Context) error {
+	resources := m.reservedPublicIPAddresses()
+	if len(resources) == 0 {
+		return nil
+	}
+
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	t := &arm.Template{
+		Schema:         "https://schema.management.azure.com/schemas/2015-01-01/deploymentTemplate.json#",
+		ContentVersion: "1.0.0.0",
+		Resources:      resources,
+	}
+
+	return arm.DeployTemplate(ctx, m.log, m.deployments, resourceGroup, "reserve", t, nil)
+}
@@ -0,0 +1,239 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/arm"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateQuota(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name    string
+		inUse   int32
+		wantErr string
+	}{
+		{
+			name:  "quota available",
+			inUse: 40,
+		},
+		{
+			name:    "quota taken since the cluster was validated",
+			inUse:   90,
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of cores exceeded. Maximum allowed: 100, Current in use: 90, Additional requested: 12.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().VMSku(gomock.Any()).AnyTimes().DoAndReturn(func(vmSize string) (*mgmtcompute.ResourceSku, error) {
+				return nil, fmt.Errorf("sku information not found for vm size %q", vmSize)
+			})
+
+			computeUsage := mock_compute.NewMockUsageClient(controller)
+			computeUsage.EXPECT().List(gomock.Any(), "eastus").Return([]mgmtcompute.Usage{
+				{
+					Name:         &mgmtcompute.UsageName{Value: to.StringPtr("cores")},
+					CurrentValue: to.Int32Ptr(tt.inUse),
+					Limit:        to.Int64Ptr(100),
+				},
+			}, nil)
+
+			networkUsage := mock_network.NewMockUsageClient(controller)
+			networkUsage.EXPECT().List(gomock.Any(), "eastus").AnyTimes().Return(nil, nil)
+
+			m := &manager{
+				env:          _env,
+				computeUsage: computeUsage,
+				networkUsage: networkUsage,
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Location: "eastus",
+						Properties: api.OpenShiftClusterProperties{
+							MasterProfile: api.MasterProfile{
+								VMSize: api.VMSizeStandardD2sV3,
+							},
+							WorkerProfiles: []api.WorkerProfile{
+								{
+									VMSize: api.VMSizeStandardD2sV3,
+									Count:  2,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := m.validateQuota(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReservedPublicIPAddresses(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		modify    func(*api.OpenShiftClusterProperties)
+		wantNames []string
+	}{
+		{
+			name:      "public cluster",
+			wantNames: []string{"infraID-pip-v4", "infraID-default-v4"},
+		},
+		{
+			name: "private cluster",
+			modify: func(p *api.OpenShiftClusterProperties) {
+				p.APIServerProfile.Visibility = api.VisibilityPrivate
+				p.IngressProfiles[0].Visibility = api.VisibilityPrivate
+			},
+		},
+		{
+			name: "private API server, public ingress",
+			modify: func(p *api.OpenShiftClusterProperties) {
+				p.APIServerProfile.Visibility = api.VisibilityPrivate
+			},
+			wantNames: []string{"infraID-default-v4"},
+		},
+		{
+			name: "dual-stack public cluster",
+			modify: func(p *api.OpenShiftClusterProperties) {
+				p.NetworkProfile.PodCIDRIPv6 = "fd01::/48"
+			},
+			wantNames: []string{"infraID-pip-v4", "infraID-pip-v6", "infraID-default-v4"},
+		},
+		{
+			name: "user defined routing",
+			modify: func(p *api.OpenShiftClusterProperties) {
+				p.NetworkProfile.OutboundType = api.OutboundTypeUserDefinedRouting
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Location: "eastus",
+						Properties: api.OpenShiftClusterProperties{
+							InfraID: "infraID",
+							NetworkProfile: api.NetworkProfile{
+								OutboundType: api.OutboundTypeLoadbalancer,
+							},
+							APIServerProfile: api.APIServerProfile{
+								Visibility: api.VisibilityPublic,
+							},
+							IngressProfiles: []api.IngressProfile{
+								{
+									Visibility: api.VisibilityPublic,
+								},
+							},
+						},
+					},
+				},
+			}
+			if tt.modify != nil {
+				tt.modify(&m.doc.OpenShiftCluster.Properties)
+			}
+
+			var names []string
+			for _, r := range m.reservedPublicIPAddresses() {
+				names = append(names, *r.Resource.(*mgmtnetwork.PublicIPAddress).Name)
+			}
+
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Error(names)
+			}
+		})
+	}
+}
+
+func TestDeployPublicIPAddresses(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name       string
+		visibility api.Visibility
+		mocks      func(*mock_features.MockDeploymentsClient)
+	}{
+		{
+			name:       "public cluster",
+			visibility: api.VisibilityPublic,
+			mocks: func(deployments *mock_features.MockDeploymentsClient) {
+				deployments.EXPECT().CreateOrUpdateAndWait(ctx, "clusterResourceGroup", "reserve", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, resourceGroupName, deploymentName string, parameters mgmtfeatures.Deployment) error {
+						if len(parameters.Properties.Template.(*arm.Template).Resources) != 2 {
+							t.Error(parameters.Properties.Template)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name:       "private cluster",
+			visibility: api.VisibilityPrivate,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			deployments := mock_features.NewMockDeploymentsClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(deployments)
+			}
+
+			m := &manager{
+				log:         logrus.NewEntry(logrus.StandardLogger()),
+				deployments: deployments,
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Location: "eastus",
+						Properties: api.OpenShiftClusterProperties{
+							InfraID: "infraID",
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/clusterResourceGroup",
+							},
+							NetworkProfile: api.NetworkProfile{
+								OutboundType: api.OutboundTypeLoadbalancer,
+							},
+							APIServerProfile: api.APIServerProfile{
+								Visibility: tt.visibility,
+							},
+							IngressProfiles: []api.IngressProfile{
+								{
+									Visibility: tt.visibility,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			err := m.deployPublicIPAddresses(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
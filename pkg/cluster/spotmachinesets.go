@@ -0,0 +1,216 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+)
+
+const (
+	machineSetNamespace = "openshift-machine-api"
+	machineSetLabel     = "machine.openshift.io/cluster-api-machineset"
+	machineRoleLabel    = "machine.openshift.io/cluster-api-machine-role"
+)
+
+// ensureSpotMachineSets creates the MachineSets of the worker profiles which
+// use Spot VMs.  The installer only creates the MachineSets of the first
+// worker profile, so each Spot worker profile gets a copy of each of those,
+// i.e. one per zone, with its count spread across them.  A Spot worker profile
+// which requests its own zones instead gets a copy of the first of them in
+// each of its zones.  MachineSets which already exist are left alone.
+func (m *manager) ensureSpotMachineSets(ctx context.Context) error {
+	var spotProfiles []api.WorkerProfile
+	for _, wp := range m.doc.OpenShiftCluster.Properties.WorkerProfiles {
+		if wp.SpotVMOptions != nil {
+			spotProfiles = append(spotProfiles, wp)
+		}
+	}
+
+	if len(spotProfiles) == 0 {
+		return nil
+	}
+
+	machineSets, err := m.maocli.MachineV1beta1().MachineSets(machineSetNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: machineRoleLabel + "=worker",
+	})
+	if err != nil {
+		return err
+	}
+
+	infraID := m.doc.OpenShiftCluster.Properties.InfraID
+	prefix := infraID + "-worker-"
+
+	var templates []machinev1beta1.MachineSet
+	for _, ms := range machineSets.Items {
+		if strings.HasPrefix(ms.Name, prefix) {
+			templates = append(templates, ms)
+		}
+	}
+	if len(templates) == 0 {
+		return fmt.Errorf("no worker MachineSets found")
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+
+	for _, wp := range spotProfiles {
+		var targets []spotTarget
+		if len(wp.Zones) > 0 {
+			for _, zone := range wp.Zones {
+				// named as the installer names its MachineSets
+				targets = append(targets, spotTarget{
+					template: &templates[0],
+					suffix:   m.doc.OpenShiftCluster.Location + zone,
+					zone:     zone,
+				})
+			}
+		} else {
+			for i := range templates {
+				targets = append(targets, spotTarget{
+					template: &templates[i],
+					suffix:   strings.TrimPrefix(templates[i].Name, prefix),
+				})
+			}
+		}
+
+		for i, target := range targets {
+			replicas := wp.Count / len(targets)
+			if i < wp.Count%len(targets) {
+				replicas++
+			}
+
+			name := infraID + "-" + wp.Name + "-" + target.suffix
+
+			ms, err := spotMachineSet(target.template, name, target.zone, &wp, int32(replicas))
+			if err != nil {
+				return err
+			}
+
+			m.log.Printf("creating MachineSet %s", name)
+			_, err = m.maocli.MachineV1beta1().MachineSets(machineSetNamespace).Create(ctx, ms, metav1.CreateOptions{})
+			if err != nil && !kerrors.IsAlreadyExists(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// spotTarget is a MachineSet to create for a Spot worker profile from a
+// MachineSet created by the installer.  If zone is empty, the MachineSet is
+// in the zone of the template.
+type spotTarget struct {
+	template *machinev1beta1.MachineSet
+	suffix   string
+	zone     string
+}
+
+// setDiskTypeAndAcceleratedNetworking applies the disk type and accelerated
+// networking of a profile to providerSpec.  Where either is unset, the value
+// copied from the installer's MachineSet is kept.
+func setDiskTypeAndAcceleratedNetworking(providerSpec *machinev1beta1.AzureMachineProviderSpec, diskType api.DiskType, acceleratedNetworking api.AcceleratedNetworking) {
+	switch diskType {
+	case api.DiskTypePremiumLRS:
+		providerSpec.OSDisk.ManagedDisk.StorageAccountType = string(machinev1beta1.StorageAccountPremiumLRS)
+		providerSpec.UltraSSDCapability = ""
+	case api.DiskTypeUltraSSDLRS:
+		// Azure does not support Ultra OS disks
+		providerSpec.OSDisk.ManagedDisk.StorageAccountType = string(machinev1beta1.StorageAccountPremiumLRS)
+		providerSpec.UltraSSDCapability = machinev1beta1.AzureUltraSSDCapabilityEnabled
+	}
+
+	switch acceleratedNetworking {
+	case api.AcceleratedNetworkingEnabled:
+		providerSpec.AcceleratedNetworking = true
+	case api.AcceleratedNetworkingDisabled:
+		providerSpec.AcceleratedNetworking = false
+	}
+}
+
+// spotMachineSet returns a copy of template named name which creates
+// replicas Spot VMs of the size, disk size and subnet of wp, in zone if it is
+// set
+func spotMachineSet(template *machinev1beta1.MachineSet, name, zone string, wp *api.WorkerProfile, replicas int32) (*machinev1beta1.MachineSet, error) {
+	providerSpec, err := machineSetProviderSpec(template)
+	if err != nil {
+		return nil, err
+	}
+
+	vnetID, subnetName, err := apisubnet.Split(wp.SubnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := azure.ParseResourceID(vnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	providerSpec.VMSize = string(wp.VMSize)
+	providerSpec.OSDisk.DiskSizeGB = int32(wp.DiskSizeGB)
+	providerSpec.NetworkResourceGroup = r.ResourceGroup
+	providerSpec.Vnet = r.ResourceName
+	providerSpec.Subnet = subnetName
+	setDiskTypeAndAcceleratedNetworking(providerSpec, wp.DiskType, wp.AcceleratedNetworking)
+	if zone != "" {
+		providerSpec.Zone = &zone
+	}
+	providerSpec.SpotVMOptions = &machinev1beta1.SpotVMOptions{}
+
+	// the machine API treats an unset max price as the on-demand price
+	if wp.SpotVMOptions.MaxPrice != nil && *wp.SpotVMOptions.MaxPrice != -1 {
+		maxPrice, err := resource.ParseQuantity(strconv.FormatFloat(*wp.SpotVMOptions.MaxPrice, 'f', -1, 64))
+		if err != nil {
+			return nil, err
+		}
+		providerSpec.SpotVMOptions.MaxPrice = &maxPrice
+	}
+
+	b, err := json.Marshal(providerSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &machinev1beta1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: machineSetNamespace,
+			Labels:    template.Labels,
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+
+	ms.Spec.Replicas = &replicas
+	ms.Spec.Selector = metav1.LabelSelector{
+		MatchLabels: map[string]string{},
+	}
+	for k, v := range template.Spec.Selector.MatchLabels {
+		ms.Spec.Selector.MatchLabels[k] = v
+	}
+	ms.Spec.Selector.MatchLabels[machineSetLabel] = name
+
+	if ms.Spec.Template.Labels == nil {
+		ms.Spec.Template.Labels = map[string]string{}
+	}
+	ms.Spec.Template.Labels[machineSetLabel] = name
+
+	ms.Spec.Template.Spec.ProviderSpec.Value = &kruntime.RawExtension{Raw: b}
+
+	return ms, nil
+}
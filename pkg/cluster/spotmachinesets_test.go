@@ -0,0 +1,275 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	machinefake "github.com/openshift/client-go/machine/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEnsureSpotMachineSets(t *testing.T) {
+	ctx := context.Background()
+	infraID := "infra"
+	subnetID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/spot"
+
+	workerMachineSet := func(t *testing.T, name string, zone string) *machinev1beta1.MachineSet {
+		b, err := json.Marshal(&machinev1beta1.AzureMachineProviderSpec{
+			VMSize: "Standard_D4s_v3",
+			OSDisk: machinev1beta1.OSDisk{
+				DiskSizeGB: 128,
+			},
+			NetworkResourceGroup: "vnet-rg",
+			Vnet:                 "vnet",
+			Subnet:               "worker",
+			Zone:                 to.StringPtr(zone),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &machinev1beta1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: machineSetNamespace,
+				Labels: map[string]string{
+					machineRoleLabel: "worker",
+				},
+			},
+			Spec: machinev1beta1.MachineSetSpec{
+				Replicas: to.Int32Ptr(1),
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						machineSetLabel: name,
+					},
+				},
+				Template: machinev1beta1.MachineTemplateSpec{
+					ObjectMeta: machinev1beta1.ObjectMeta{
+						Labels: map[string]string{
+							machineSetLabel: name,
+						},
+					},
+					Spec: machinev1beta1.MachineSpec{
+						ProviderSpec: machinev1beta1.ProviderSpec{
+							Value: &kruntime.RawExtension{Raw: b},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	type wantMachineSet struct {
+		replicas              int32
+		zone                  string
+		maxPrice              string
+		ultraSSD              bool
+		acceleratedNetworking bool
+	}
+
+	for _, tt := range []struct {
+		name            string
+		workerProfiles  []api.WorkerProfile
+		machineSets     func(*testing.T) []kruntime.Object
+		wantMachineSets map[string]wantMachineSet
+		wantErr         string
+	}{
+		{
+			name: "no spot worker profiles",
+			workerProfiles: []api.WorkerProfile{
+				{Name: "worker", Count: 3},
+			},
+			machineSets: func(t *testing.T) []kruntime.Object {
+				return []kruntime.Object{workerMachineSet(t, "infra-worker-eastus1", "1")}
+			},
+		},
+		{
+			name: "no worker machinesets",
+			workerProfiles: []api.WorkerProfile{
+				{Name: "worker", Count: 3},
+				{Name: "spot", Count: 1, SubnetID: subnetID, SpotVMOptions: &api.SpotVMOptions{}},
+			},
+			machineSets: func(t *testing.T) []kruntime.Object {
+				return nil
+			},
+			wantErr: "no worker MachineSets found",
+		},
+		{
+			name: "spot worker profile spread across zones",
+			workerProfiles: []api.WorkerProfile{
+				{Name: "worker", Count: 3},
+				{
+					Name:       "spot",
+					VMSize:     api.VMSizeStandardD8sV3,
+					DiskSizeGB: 256,
+					SubnetID:   subnetID,
+					Count:      5,
+					SpotVMOptions: &api.SpotVMOptions{
+						MaxPrice: to.Float64Ptr(0.05),
+					},
+				},
+			},
+			machineSets: func(t *testing.T) []kruntime.Object {
+				return []kruntime.Object{
+					workerMachineSet(t, "infra-worker-eastus1", "1"),
+					workerMachineSet(t, "infra-worker-eastus2", "2"),
+					workerMachineSet(t, "infra-worker-eastus3", "3"),
+				}
+			},
+			wantMachineSets: map[string]wantMachineSet{
+				"infra-spot-eastus1": {replicas: 2, zone: "1", maxPrice: "50m"},
+				"infra-spot-eastus2": {replicas: 2, zone: "2", maxPrice: "50m"},
+				"infra-spot-eastus3": {replicas: 1, zone: "3", maxPrice: "50m"},
+			},
+		},
+		{
+			name: "on-demand max price and existing machineset",
+			workerProfiles: []api.WorkerProfile{
+				{Name: "worker", Count: 3},
+				{
+					Name:       "spot",
+					VMSize:     api.VMSizeStandardD8sV3,
+					DiskSizeGB: 256,
+					SubnetID:   subnetID,
+					Count:      1,
+					SpotVMOptions: &api.SpotVMOptions{
+						MaxPrice: to.Float64Ptr(-1),
+					},
+				},
+			},
+			machineSets: func(t *testing.T) []kruntime.Object {
+				existing := workerMachineSet(t, "infra-spot-eastus1", "1")
+				existing.Labels = nil
+
+				return []kruntime.Object{
+					workerMachineSet(t, "infra-worker-eastus1", "1"),
+					workerMachineSet(t, "infra-worker-eastus2", "2"),
+					existing,
+				}
+			},
+			wantMachineSets: map[string]wantMachineSet{
+				"infra-spot-eastus2": {replicas: 0, zone: "2"},
+			},
+		},
+		{
+			name: "ultra disks and accelerated networking",
+			workerProfiles: []api.WorkerProfile{
+				{Name: "worker", Count: 3},
+				{
+					Name:                  "spot",
+					VMSize:                api.VMSizeStandardD8sV3,
+					DiskSizeGB:            256,
+					SubnetID:              subnetID,
+					Count:                 1,
+					DiskType:              api.DiskTypeUltraSSDLRS,
+					AcceleratedNetworking: api.AcceleratedNetworkingEnabled,
+					SpotVMOptions:         &api.SpotVMOptions{},
+				},
+			},
+			machineSets: func(t *testing.T) []kruntime.Object {
+				return []kruntime.Object{workerMachineSet(t, "infra-worker-eastus1", "1")}
+			},
+			wantMachineSets: map[string]wantMachineSet{
+				"infra-spot-eastus1": {replicas: 1, zone: "1", ultraSSD: true, acceleratedNetworking: true},
+			},
+		},
+		{
+			name: "spot worker profile in requested zones",
+			workerProfiles: []api.WorkerProfile{
+				{Name: "worker", Count: 3},
+				{
+					Name:          "spot",
+					VMSize:        api.VMSizeStandardD8sV3,
+					DiskSizeGB:    256,
+					SubnetID:      subnetID,
+					Count:         3,
+					Zones:         []string{"2", "3"},
+					SpotVMOptions: &api.SpotVMOptions{},
+				},
+			},
+			machineSets: func(t *testing.T) []kruntime.Object {
+				return []kruntime.Object{workerMachineSet(t, "infra-worker-eastus1", "1")}
+			},
+			wantMachineSets: map[string]wantMachineSet{
+				"infra-spot-eastus2": {replicas: 2, zone: "2"},
+				"infra-spot-eastus3": {replicas: 1, zone: "3"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			maocli := machinefake.NewSimpleClientset(tt.machineSets(t)...)
+
+			m := &manager{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Location: "eastus",
+						Properties: api.OpenShiftClusterProperties{
+							InfraID:        infraID,
+							WorkerProfiles: tt.workerProfiles,
+						},
+					},
+				},
+				maocli: maocli,
+			}
+
+			err := m.ensureSpotMachineSets(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			for name, want := range tt.wantMachineSets {
+				ms, err := maocli.MachineV1beta1().MachineSets(machineSetNamespace).Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if *ms.Spec.Replicas != want.replicas {
+					t.Errorf("%s: replicas %d", name, *ms.Spec.Replicas)
+				}
+				if ms.Spec.Selector.MatchLabels[machineSetLabel] != name ||
+					ms.Spec.Template.Labels[machineSetLabel] != name {
+					t.Errorf("%s: labels %v %v", name, ms.Spec.Selector.MatchLabels, ms.Spec.Template.Labels)
+				}
+
+				providerSpec := &machinev1beta1.AzureMachineProviderSpec{}
+				err = json.Unmarshal(ms.Spec.Template.Spec.ProviderSpec.Value.Raw, providerSpec)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if providerSpec.VMSize != string(api.VMSizeStandardD8sV3) ||
+					providerSpec.OSDisk.DiskSizeGB != 256 ||
+					providerSpec.Subnet != "spot" ||
+					*providerSpec.Zone != want.zone {
+					t.Errorf("%s: %#v", name, providerSpec)
+				}
+				if providerSpec.SpotVMOptions == nil {
+					t.Fatalf("%s: not a spot MachineSet", name)
+				}
+				var maxPrice string
+				if providerSpec.SpotVMOptions.MaxPrice != nil {
+					maxPrice = providerSpec.SpotVMOptions.MaxPrice.String()
+				}
+				if maxPrice != want.maxPrice {
+					t.Errorf("%s: max price %q", name, maxPrice)
+				}
+				if (providerSpec.UltraSSDCapability == machinev1beta1.AzureUltraSSDCapabilityEnabled) != want.ultraSSD {
+					t.Errorf("%s: ultra SSD capability %q", name, providerSpec.UltraSSDCapability)
+				}
+				if providerSpec.AcceleratedNetworking != want.acceleratedNetworking {
+					t.Errorf("%s: accelerated networking %t", name, providerSpec.AcceleratedNetworking)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+
+	"github.com/coreos/go-semver/semver"
+	ign3types "github.com/coreos/ignition/v2/config/v3_2/types"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	cryptossh "golang.org/x/crypto/ssh"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+func mutateSSHCAKey(doc *api.OpenShiftClusterDocument) error {
+	if doc.OpenShiftCluster.Properties.SSHCAKey != nil {
+		return nil
+	}
+
+	sshCAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+
+	doc.OpenShiftCluster.Properties.SSHCAKey = x509.MarshalPKCS1PrivateKey(sshCAKey)
+
+	return nil
+}
+
+func (m *manager) ensureSSHCAKey(ctx context.Context) error {
+	updatedDoc, err := m.db.PatchWithLease(ctx, m.doc.Key, mutateSSHCAKey)
+	m.doc = updatedDoc
+
+	return err
+}
+
+// sshCAMachineConfig returns a MachineConfig which adds the SSH certificate
+// authority to the core user's authorized keys.  Unlike a TrustedUserCAKeys
+// sshd configuration drop-in, changes to authorized keys are applied by MCO
+// without rebooting nodes.
+func sshCAMachineConfig(role string, sshCAKey []byte) (*mcv1.MachineConfig, error) {
+	key, err := x509.ParsePKCS1PrivateKey(sshCAKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := cryptossh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ign := &ign3types.Config{
+		Ignition: ign3types.Ignition{
+			Version: semver.Version{
+				Major: 3,
+				Minor: 2,
+			}.String(),
+		},
+		Passwd: ign3types.Passwd{
+			Users: []ign3types.PasswdUser{
+				{
+					Name: "core",
+					SSHAuthorizedKeys: []ign3types.SSHAuthorizedKey{
+						ign3types.SSHAuthorizedKey(`cert-authority,principals="core" ` + string(bytes.TrimSpace(cryptossh.MarshalAuthorizedKey(pubKey)))),
+					},
+				},
+			},
+		},
+	}
+
+	b, err := json.Marshal(ign)
+	if err != nil {
+		return nil, err
+	}
+
+	// canonicalise the machineconfig payload the same way as MCO
+	var i interface{}
+	err = json.Unmarshal(b, &i)
+	if err != nil {
+		return nil, err
+	}
+
+	rawExt := runtime.RawExtension{}
+	rawExt.Raw, err = json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcv1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("99-%s-aro-ssh-ca", role),
+			Labels: map[string]string{
+				"machineconfiguration.openshift.io/role": role,
+			},
+		},
+		Spec: mcv1.MachineConfigSpec{
+			Config: rawExt,
+		},
+	}, nil
+}
+
+// ensureSSHCAMachineConfigs pushes the cluster SSH certificate authority to
+// the master and worker nodes
+func (m *manager) ensureSSHCAMachineConfigs(ctx context.Context) error {
+	if m.doc.OpenShiftCluster.Properties.SSHCAKey == nil {
+		return nil
+	}
+
+	for _, role := range []string{"master", "worker"} {
+		mc, err := sshCAMachineConfig(role, m.doc.OpenShiftCluster.Properties.SSHCAKey)
+		if err != nil {
+			return err
+		}
+
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			existing, err := m.mcocli.MachineconfigurationV1().MachineConfigs().Get(ctx, mc.Name, metav1.GetOptions{})
+			if kerrors.IsNotFound(err) {
+				_, err = m.mcocli.MachineconfigurationV1().MachineConfigs().Create(ctx, mc, metav1.CreateOptions{})
+				return err
+			}
+			if err != nil {
+				return err
+			}
+
+			if bytes.Equal(existing.Spec.Config.Raw, mc.Spec.Config.Raw) {
+				return nil
+			}
+
+			existing.Labels = mc.Labels
+			existing.Spec = mc.Spec
+
+			_, err = m.mcocli.MachineconfigurationV1().MachineConfigs().Update(ctx, existing, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
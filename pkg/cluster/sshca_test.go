@@ -0,0 +1,134 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	ign3types "github.com/coreos/ignition/v2/config/v3_2/types"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	mcofake "github.com/openshift/machine-config-operator/pkg/generated/clientset/versioned/fake"
+	cryptossh "golang.org/x/crypto/ssh"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
+)
+
+func TestEnsureSSHCAMachineConfigs(t *testing.T) {
+	ctx := context.Background()
+
+	caKey, _, err := utiltls.GenerateKeyAndCertificate("ca", nil, nil, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caPubKey, err := cryptossh.NewPublicKey(&caKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		sshCAKey []byte
+		objects  []runtime.Object
+		wantMCs  bool
+	}{
+		{
+			name: "no CA key",
+		},
+		{
+			name:     "creates machineconfigs",
+			sshCAKey: x509.MarshalPKCS1PrivateKey(caKey),
+			wantMCs:  true,
+		},
+		{
+			name:     "updates stale machineconfigs",
+			sshCAKey: x509.MarshalPKCS1PrivateKey(caKey),
+			objects: []runtime.Object{
+				&mcv1.MachineConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "99-master-aro-ssh-ca",
+					},
+					Spec: mcv1.MachineConfigSpec{
+						Config: runtime.RawExtension{Raw: []byte(`{}`)},
+					},
+				},
+			},
+			wantMCs: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mcocli := mcofake.NewSimpleClientset(tt.objects...)
+
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							SSHCAKey: tt.sshCAKey,
+						},
+					},
+				},
+				mcocli: mcocli,
+			}
+
+			err := m.ensureSSHCAMachineConfigs(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mcs, err := mcocli.MachineconfigurationV1().MachineConfigs().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !tt.wantMCs {
+				if len(mcs.Items) != 0 {
+					t.Errorf("unexpected machineconfigs %v", mcs.Items)
+				}
+				return
+			}
+
+			if len(mcs.Items) != 2 {
+				t.Fatalf("expected 2 machineconfigs, got %d", len(mcs.Items))
+			}
+
+			for _, mc := range mcs.Items {
+				role := mc.Labels["machineconfiguration.openshift.io/role"]
+				if mc.Name != "99-"+role+"-aro-ssh-ca" {
+					t.Errorf("unexpected machineconfig %s with role %q", mc.Name, role)
+				}
+
+				var ign ign3types.Config
+				err = json.Unmarshal(mc.Spec.Config.Raw, &ign)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if len(ign.Passwd.Users) != 1 || ign.Passwd.Users[0].Name != "core" || len(ign.Passwd.Users[0].SSHAuthorizedKeys) != 1 {
+					t.Fatalf("unexpected passwd %#v", ign.Passwd)
+				}
+
+				line := string(ign.Passwd.Users[0].SSHAuthorizedKeys[0])
+				if !strings.HasPrefix(line, `cert-authority,principals="core" `) {
+					t.Error(line)
+				}
+
+				pubKey, _, options, _, err := cryptossh.ParseAuthorizedKey([]byte(line))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if len(options) != 2 || string(pubKey.Marshal()) != string(caPubKey.Marshal()) {
+					t.Error(line)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+func TestUpdateSteps(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		reconcileScope api.ReconcileScope
+		adoptViaHive   bool
+		shouldRunSteps []string
+	}{
+		{
+			name: "full update",
+			shouldRunSteps: []string{
+				"[AuthorizationRetryingAction validateResources-fm]",
+				"[Action initializeKubernetesClients-fm]",
+				"[Action initializeOperatorDeployer-fm]",
+				"[Action initializeClusterSPClients-fm]",
+				"[AuthorizationRetryingAction clusterSPObjectID-fm]",
+				"[Action createOrUpdateClusterServicePrincipalRBAC-fm]",
+				"[Action createOrUpdateDenyAssignment-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action rotateACRTokenPassword-fm]",
+				"[Action configureAPIServerCertificate-fm]",
+				"[Action configureIngressCertificate-fm]",
+				"[Action renewMDSDCertificate-fm]",
+				"[Action ensureCredentialsRequest-fm]",
+				"[Action updateOpenShiftSecret-fm]",
+				"[Condition aroCredentialsRequestReconciled-fm, timeout 3m0s]",
+				"[Action updateAROSecret-fm]",
+				"[Action restartAROOperatorMaster-fm]",
+				"[Condition aroDeploymentReady-fm, timeout 5m0s]",
+				"[Action reconcileLoadBalancerProfile-fm]",
+				"[Action reconcileOutboundSNAT-fm]",
+				"[Action reconcileDiagnosticSettings-fm]",
+				"[Action reconcileWorkerVMSize-fm]",
+				"[Condition workerVMSizeReconciled-fm, timeout 2h0m0s]",
+			},
+		},
+		{
+			name:         "full update adopted via hive",
+			adoptViaHive: true,
+			shouldRunSteps: []string{
+				"[AuthorizationRetryingAction validateResources-fm]",
+				"[Action initializeKubernetesClients-fm]",
+				"[Action initializeOperatorDeployer-fm]",
+				"[Action initializeClusterSPClients-fm]",
+				"[AuthorizationRetryingAction clusterSPObjectID-fm]",
+				"[Action createOrUpdateClusterServicePrincipalRBAC-fm]",
+				"[Action createOrUpdateDenyAssignment-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action rotateACRTokenPassword-fm]",
+				"[Action configureAPIServerCertificate-fm]",
+				"[Action configureIngressCertificate-fm]",
+				"[Action renewMDSDCertificate-fm]",
+				"[Action ensureCredentialsRequest-fm]",
+				"[Action updateOpenShiftSecret-fm]",
+				"[Condition aroCredentialsRequestReconciled-fm, timeout 3m0s]",
+				"[Action updateAROSecret-fm]",
+				"[Action restartAROOperatorMaster-fm]",
+				"[Condition aroDeploymentReady-fm, timeout 5m0s]",
+				"[Action reconcileLoadBalancerProfile-fm]",
+				"[Action reconcileOutboundSNAT-fm]",
+				"[Action reconcileDiagnosticSettings-fm]",
+				"[Action reconcileWorkerVMSize-fm]",
+				"[Condition workerVMSizeReconciled-fm, timeout 2h0m0s]",
+				"[Action hiveCreateNamespace-fm]",
+				"[Action hiveEnsureResources-fm]",
+				"[Condition hiveClusterDeploymentReady-fm, timeout 5m0s]",
+				"[Action hiveResetCorrelationData-fm]",
+			},
+		},
+		{
+			name:           "worker profiles",
+			reconcileScope: api.ReconcileScopeWorkerProfiles,
+			adoptViaHive:   true,
+			shouldRunSteps: []string{
+				"[AuthorizationRetryingAction validateResources-fm]",
+				"[Action initializeKubernetesClients-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action reconcileWorkerVMSize-fm]",
+				"[Condition workerVMSizeReconciled-fm, timeout 2h0m0s]",
+			},
+		},
+		{
+			name:           "load balancer profile",
+			reconcileScope: api.ReconcileScopeLoadBalancerProfile,
+			adoptViaHive:   true,
+			shouldRunSteps: []string{
+				"[AuthorizationRetryingAction validateResources-fm]",
+				"[Action initializeKubernetesClients-fm]",
+				"[Action reconcileLoadBalancerProfile-fm]",
+				"[Action reconcileOutboundSNAT-fm]",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					ReconcileScope:   tt.reconcileScope,
+					OpenShiftCluster: &api.OpenShiftCluster{},
+				},
+				adoptViaHive: tt.adoptViaHive,
+			}
+			toRun := m.update()
+
+			var stepsToRun []string
+			for _, s := range toRun {
+				o := strings.Replace(s.String(), "github.com/Azure/ARO-RP/pkg/cluster.(*manager).", "", -1)
+				stepsToRun = append(stepsToRun, o)
+			}
+
+			diff := deep.Equal(stepsToRun, tt.shouldRunSteps)
+			for _, d := range diff {
+				t.Error(d)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// resizeReplicasAnnotation is set on a MachineSet whose worker VMs are being
+// replaced after a change of size, and holds its replica count from before the
+// surge
+const resizeReplicasAnnotation = "aro.openshift.io/resize-replicas"
+
+// machine phases, which the machine API does not export
+const (
+	machinePhaseRunning = "Running"
+	machinePhaseFailed  = "Failed"
+)
+
+// reconcileWorkerVMSize starts the replacement of the worker VMs of each
+// MachineSet whose VM size differs from that of its worker profile.  The
+// MachineSet is changed to create VMs of the new size and is scaled up by the
+// surge of the profile; workerVMSizeReconciled then replaces its old VMs.
+func (m *manager) reconcileWorkerVMSize(ctx context.Context) error {
+	machineSets, err := m.maocli.MachineV1beta1().MachineSets(machineSetNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: machineRoleLabel + "=worker",
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range machineSets.Items {
+		ms := &machineSets.Items[i]
+
+		wp := m.workerProfileOfMachineSet(ms)
+		if wp == nil {
+			continue
+		}
+
+		providerSpec, err := machineSetProviderSpec(ms)
+		if err != nil {
+			return err
+		}
+
+		if providerSpec.VMSize == string(wp.VMSize) {
+			continue
+		}
+
+		m.log.Printf("resizing MachineSet %s from %s to %s", ms.Name, providerSpec.VMSize, wp.VMSize)
+
+		providerSpec.VMSize = string(wp.VMSize)
+		b, err := json.Marshal(providerSpec)
+		if err != nil {
+			return err
+		}
+		ms.Spec.Template.Spec.ProviderSpec.Value = &kruntime.RawExtension{Raw: b}
+
+		// a MachineSet which was resized again before its previous resize
+		// completed keeps the replica count from before the first surge
+		if _, ok := ms.Annotations[resizeReplicasAnnotation]; !ok {
+			var replicas int32
+			if ms.Spec.Replicas != nil {
+				replicas = *ms.Spec.Replicas
+			}
+
+			if ms.Annotations == nil {
+				ms.Annotations = map[string]string{}
+			}
+			ms.Annotations[resizeReplicasAnnotation] = strconv.Itoa(int(replicas))
+
+			replicas += int32(maxSurge(wp))
+			ms.Spec.Replicas = &replicas
+		}
+
+		_, err = m.maocli.MachineV1beta1().MachineSets(machineSetNamespace).Update(ctx, ms, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// workerVMSizeReconciled replaces the old worker VMs of the MachineSets being
+// resized, up to the surge of their worker profile at a time, and returns true
+// once all of them have been replaced.  Old VMs are only deleted once every VM
+// of the new size is running, so the MachineSet never has fewer running VMs
+// than it had before it was resized.
+func (m *manager) workerVMSizeReconciled(ctx context.Context) (bool, error) {
+	machineSets, err := m.maocli.MachineV1beta1().MachineSets(machineSetNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: machineRoleLabel + "=worker",
+	})
+	if err != nil {
+		return false, err
+	}
+
+	done := true
+	for i := range machineSets.Items {
+		ms := &machineSets.Items[i]
+
+		if _, ok := ms.Annotations[resizeReplicasAnnotation]; !ok {
+			continue
+		}
+
+		resized, err := m.resizeMachineSet(ctx, ms)
+		if err != nil {
+			return false, err
+		}
+
+		done = done && resized
+	}
+
+	return done, nil
+}
+
+// resizeMachineSet makes the next step in replacing the old VMs of ms and
+// returns true once they have all been replaced and ms has been scaled back
+// down
+func (m *manager) resizeMachineSet(ctx context.Context, ms *machinev1beta1.MachineSet) (bool, error) {
+	providerSpec, err := machineSetProviderSpec(ms)
+	if err != nil {
+		return false, err
+	}
+
+	machines, err := m.maocli.MachineV1beta1().Machines(machineSetNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: machineSetLabel + "=" + ms.Name,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var old []*machinev1beta1.Machine
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+
+		// wait for any deletion to complete
+		if machine.DeletionTimestamp != nil {
+			return false, nil
+		}
+
+		vmSize, err := machineVMSize(machine)
+		if err != nil {
+			return false, err
+		}
+
+		if vmSize != providerSpec.VMSize {
+			old = append(old, machine)
+			continue
+		}
+
+		// wait for the VMs of the new size to run
+		if machine.Status.Phase == nil || *machine.Status.Phase != machinePhaseRunning {
+			if machine.Status.Phase != nil && *machine.Status.Phase == machinePhaseFailed {
+				return false, fmt.Errorf("Machine %s failed", machine.Name)
+			}
+			return false, nil
+		}
+	}
+
+	if len(old) == 0 {
+		replicas, err := strconv.Atoi(ms.Annotations[resizeReplicasAnnotation])
+		if err != nil {
+			return false, err
+		}
+
+		m.log.Printf("resized MachineSet %s", ms.Name)
+
+		r := int32(replicas)
+		ms.Spec.Replicas = &r
+		delete(ms.Annotations, resizeReplicasAnnotation)
+
+		_, err = m.maocli.MachineV1beta1().MachineSets(machineSetNamespace).Update(ctx, ms, metav1.UpdateOptions{})
+		return err == nil, err
+	}
+
+	surge := 1
+	if wp := m.workerProfileOfMachineSet(ms); wp != nil {
+		surge = maxSurge(wp)
+	}
+
+	for i := 0; i < surge && i < len(old); i++ {
+		m.log.Printf("deleting Machine %s", old[i].Name)
+		err = m.maocli.MachineV1beta1().Machines(machineSetNamespace).Delete(ctx, old[i].Name, metav1.DeleteOptions{})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// workerProfileOfMachineSet returns the worker profile whose MachineSets are
+// named after it and ms, or nil if there is none.  MachineSets which the
+// customer has created are not named after a worker profile.  The longest
+// matching name wins, as a Spot worker profile may be named worker-*.
+func (m *manager) workerProfileOfMachineSet(ms *machinev1beta1.MachineSet) *api.WorkerProfile {
+	var match *api.WorkerProfile
+	for i, wp := range m.doc.OpenShiftCluster.Properties.WorkerProfiles {
+		prefix := m.doc.OpenShiftCluster.Properties.InfraID + "-" + wp.Name + "-"
+		if strings.HasPrefix(ms.Name, prefix) && (match == nil || len(wp.Name) > len(match.Name)) {
+			match = &m.doc.OpenShiftCluster.Properties.WorkerProfiles[i]
+		}
+	}
+
+	return match
+}
+
+// maxSurge returns the number of VMs by which the MachineSets of wp are
+// scaled up while they are resized
+func maxSurge(wp *api.WorkerProfile) int {
+	if wp.MaxSurge > 0 {
+		return wp.MaxSurge
+	}
+	return 1
+}
+
+func machineSetProviderSpec(ms *machinev1beta1.MachineSet) (*machinev1beta1.AzureMachineProviderSpec, error) {
+	if ms.Spec.Template.Spec.ProviderSpec.Value == nil {
+		return nil, fmt.Errorf("MachineSet %s has no provider spec", ms.Name)
+	}
+
+	providerSpec := &machinev1beta1.AzureMachineProviderSpec{}
+	err := json.Unmarshal(ms.Spec.Template.Spec.ProviderSpec.Value.Raw, providerSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	return providerSpec, nil
+}
+
+func machineVMSize(machine *machinev1beta1.Machine) (string, error) {
+	if machine.Spec.ProviderSpec.Value == nil {
+		return "", fmt.Errorf("Machine %s has no provider spec", machine.Name)
+	}
+
+	providerSpec := &machinev1beta1.AzureMachineProviderSpec{}
+	err := json.Unmarshal(machine.Spec.ProviderSpec.Value.Raw, providerSpec)
+	if err != nil {
+		return "", err
+	}
+
+	return providerSpec.VMSize, nil
+}
@@ -0,0 +1,243 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	machinefake "github.com/openshift/client-go/machine/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+	"github.com/Azure/ARO-RP/test/util/machine"
+)
+
+var resizeWorkerLabels = map[string]string{
+	machineRoleLabel: "worker",
+}
+
+func resizeDoc() *api.OpenShiftClusterDocument {
+	return &api.OpenShiftClusterDocument{
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				InfraID: "infra",
+				WorkerProfiles: []api.WorkerProfile{
+					{
+						Name:     "worker",
+						VMSize:   api.VMSizeStandardD8sV3,
+						MaxSurge: 2,
+					},
+					{
+						Name:   "worker-spot",
+						VMSize: api.VMSizeStandardD4sV3,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReconcileWorkerVMSize(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name         string
+		machineSets  []kruntime.Object
+		wantVMSize   map[string]string
+		wantReplicas map[string]int32
+		wantOriginal map[string]string
+	}{
+		{
+			name: "MachineSets of a resized profile surge",
+			machineSets: []kruntime.Object{
+				machine.MachineSet(t, "infra-worker-eastus1", "Standard_D4s_v3", 1, resizeWorkerLabels, nil),
+				machine.MachineSet(t, "infra-worker-spot-eastus1", "Standard_D4s_v3", 1, resizeWorkerLabels, nil),
+				machine.MachineSet(t, "custom", "Standard_D4s_v3", 1, resizeWorkerLabels, nil),
+			},
+			wantVMSize: map[string]string{
+				"infra-worker-eastus1":      "Standard_D8s_v3",
+				"infra-worker-spot-eastus1": "Standard_D4s_v3",
+				"custom":                    "Standard_D4s_v3",
+			},
+			wantReplicas: map[string]int32{
+				"infra-worker-eastus1":      3,
+				"infra-worker-spot-eastus1": 1,
+				"custom":                    1,
+			},
+			wantOriginal: map[string]string{
+				"infra-worker-eastus1": "1",
+			},
+		},
+		{
+			name: "MachineSet resized again keeps its original replicas",
+			machineSets: []kruntime.Object{
+				machine.MachineSet(t, "infra-worker-eastus1", "Standard_D16s_v3", 3, resizeWorkerLabels, map[string]string{resizeReplicasAnnotation: "1"}),
+			},
+			wantVMSize: map[string]string{
+				"infra-worker-eastus1": "Standard_D8s_v3",
+			},
+			wantReplicas: map[string]int32{
+				"infra-worker-eastus1": 3,
+			},
+			wantOriginal: map[string]string{
+				"infra-worker-eastus1": "1",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				doc:    resizeDoc(),
+				maocli: machinefake.NewSimpleClientset(tt.machineSets...),
+			}
+
+			err := m.reconcileWorkerVMSize(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			machineSets, err := m.maocli.MachineV1beta1().MachineSets(machineSetNamespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := range machineSets.Items {
+				ms := &machineSets.Items[i]
+
+				providerSpec, err := machineSetProviderSpec(ms)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if providerSpec.VMSize != tt.wantVMSize[ms.Name] {
+					t.Errorf("%s: got VM size %s, want %s", ms.Name, providerSpec.VMSize, tt.wantVMSize[ms.Name])
+				}
+				if *ms.Spec.Replicas != tt.wantReplicas[ms.Name] {
+					t.Errorf("%s: got replicas %d, want %d", ms.Name, *ms.Spec.Replicas, tt.wantReplicas[ms.Name])
+				}
+				if ms.Annotations[resizeReplicasAnnotation] != tt.wantOriginal[ms.Name] {
+					t.Errorf("%s: got annotation %q, want %q", ms.Name, ms.Annotations[resizeReplicasAnnotation], tt.wantOriginal[ms.Name])
+				}
+			}
+		})
+	}
+}
+
+func TestWorkerVMSizeReconciled(t *testing.T) {
+	ctx := context.Background()
+	annotations := map[string]string{resizeReplicasAnnotation: "3"}
+
+	for _, tt := range []struct {
+		name         string
+		objects      []kruntime.Object
+		wantDone     bool
+		wantMachines []string
+		wantReplicas int32
+		wantErr      string
+	}{
+		{
+			name: "waits for new Machines to run",
+			objects: []kruntime.Object{
+				machine.MachineSet(t, "infra-worker-eastus1", "Standard_D8s_v3", 5, resizeWorkerLabels, annotations),
+				machine.Machine(t, "old-0", "infra-worker-eastus1", "Standard_D4s_v3", "Running"),
+				machine.Machine(t, "old-1", "infra-worker-eastus1", "Standard_D4s_v3", "Running"),
+				machine.Machine(t, "old-2", "infra-worker-eastus1", "Standard_D4s_v3", "Running"),
+				machine.Machine(t, "new-0", "infra-worker-eastus1", "Standard_D8s_v3", "Provisioned"),
+				machine.Machine(t, "new-1", "infra-worker-eastus1", "Standard_D8s_v3", "Running"),
+			},
+			wantMachines: []string{"new-0", "new-1", "old-0", "old-1", "old-2"},
+			wantReplicas: 5,
+		},
+		{
+			name: "deletes up to the surge of old Machines",
+			objects: []kruntime.Object{
+				machine.MachineSet(t, "infra-worker-eastus1", "Standard_D8s_v3", 5, resizeWorkerLabels, annotations),
+				machine.Machine(t, "old-0", "infra-worker-eastus1", "Standard_D4s_v3", "Running"),
+				machine.Machine(t, "old-1", "infra-worker-eastus1", "Standard_D4s_v3", "Running"),
+				machine.Machine(t, "old-2", "infra-worker-eastus1", "Standard_D4s_v3", "Running"),
+				machine.Machine(t, "new-0", "infra-worker-eastus1", "Standard_D8s_v3", "Running"),
+				machine.Machine(t, "new-1", "infra-worker-eastus1", "Standard_D8s_v3", "Running"),
+			},
+			wantMachines: []string{"new-0", "new-1", "old-2"},
+			wantReplicas: 5,
+		},
+		{
+			name: "scales back down once every Machine is replaced",
+			objects: []kruntime.Object{
+				machine.MachineSet(t, "infra-worker-eastus1", "Standard_D8s_v3", 5, resizeWorkerLabels, annotations),
+				machine.Machine(t, "new-0", "infra-worker-eastus1", "Standard_D8s_v3", "Running"),
+				machine.Machine(t, "new-1", "infra-worker-eastus1", "Standard_D8s_v3", "Running"),
+			},
+			wantDone:     true,
+			wantMachines: []string{"new-0", "new-1"},
+			wantReplicas: 3,
+		},
+		{
+			name: "fails if a new Machine fails",
+			objects: []kruntime.Object{
+				machine.MachineSet(t, "infra-worker-eastus1", "Standard_D8s_v3", 5, resizeWorkerLabels, annotations),
+				machine.Machine(t, "old-0", "infra-worker-eastus1", "Standard_D4s_v3", "Running"),
+				machine.Machine(t, "new-0", "infra-worker-eastus1", "Standard_D8s_v3", "Failed"),
+			},
+			wantMachines: []string{"new-0", "old-0"},
+			wantReplicas: 5,
+			wantErr:      "Machine new-0 failed",
+		},
+		{
+			name: "MachineSets which are not being resized are done",
+			objects: []kruntime.Object{
+				machine.MachineSet(t, "infra-worker-eastus1", "Standard_D4s_v3", 3, resizeWorkerLabels, nil),
+				machine.Machine(t, "old-0", "infra-worker-eastus1", "Standard_D4s_v3", "Running"),
+			},
+			wantDone:     true,
+			wantMachines: []string{"old-0"},
+			wantReplicas: 3,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				doc:    resizeDoc(),
+				maocli: machinefake.NewSimpleClientset(tt.objects...),
+			}
+
+			done, err := m.workerVMSizeReconciled(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if done != tt.wantDone {
+				t.Errorf("got done %t, want %t", done, tt.wantDone)
+			}
+
+			machines, err := m.maocli.MachineV1beta1().Machines(machineSetNamespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for _, machine := range machines.Items {
+				names = append(names, machine.Name)
+			}
+			if len(names) != len(tt.wantMachines) {
+				t.Fatalf("got Machines %v, want %v", names, tt.wantMachines)
+			}
+			for i := range names {
+				if names[i] != tt.wantMachines[i] {
+					t.Errorf("got Machines %v, want %v", names, tt.wantMachines)
+				}
+			}
+
+			ms, err := m.maocli.MachineV1beta1().MachineSets(machineSetNamespace).Get(ctx, "infra-worker-eastus1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *ms.Spec.Replicas != tt.wantReplicas {
+				t.Errorf("got replicas %d, want %d", *ms.Spec.Replicas, tt.wantReplicas)
+			}
+		})
+	}
+}
@@ -23,6 +23,9 @@ type Billing interface {
 	Get(context.Context, string) (*api.BillingDocument, error)
 	MarkForDeletion(context.Context, string) (*api.BillingDocument, error)
 	UpdateLastBillingTimestamp(context.Context, string, int) (*api.BillingDocument, error)
+	UpdateSnapshot(context.Context, string, *api.BillingSnapshot) (*api.BillingDocument, error)
+	RecordWorkerCores(context.Context, string, int, int) (*api.BillingDocument, error)
+	UpdateUsage(context.Context, string, []api.BillingUsage, int) (*api.BillingDocument, error)
 	List(string) cosmosdb.BillingDocumentIterator
 	ListAll(context.Context) (*api.BillingDocuments, error)
 	Delete(context.Context, *api.BillingDocument) error
@@ -157,3 +160,74 @@ func (c *billing) UpdateLastBillingTimestamp(ctx context.Context, id string, tim
 		return nil
 	}, nil)
 }
+
+// UpdateSnapshot replaces the usage snapshot in the document with the one
+// provided
+func (c *billing) UpdateSnapshot(ctx context.Context, id string, snapshot *api.BillingSnapshot) (*api.BillingDocument, error) {
+	return c.patch(ctx, id, func(billingdoc *api.BillingDocument) error {
+		billingdoc.Billing.Snapshot = snapshot
+		return nil
+	}, nil)
+}
+
+// RecordWorkerCores records the worker cores sampled during the hour starting
+// at hour in the document of a cluster with a marketplace plan.  A later
+// sample of the same hour replaces the earlier one until the hour is
+// reported.  Documents without a marketplace plan are left unchanged.
+func (c *billing) RecordWorkerCores(ctx context.Context, id string, hour int, cores int) (*api.BillingDocument, error) {
+	return c.patch(ctx, id, func(billingdoc *api.BillingDocument) error {
+		marketplace := billingdoc.Billing.Marketplace
+		if marketplace == nil {
+			return nil
+		}
+
+		for i, u := range marketplace.Usage {
+			if u.Hour == hour {
+				if u.State == "" {
+					marketplace.Usage[i].WorkerCores = cores
+				}
+				return nil
+			}
+		}
+
+		marketplace.Usage = append(marketplace.Usage, api.BillingUsage{
+			Hour:        hour,
+			WorkerCores: cores,
+		})
+		return nil
+	}, nil)
+}
+
+// UpdateUsage records the reporting state of the given hours of usage, and
+// removes the hours which are no longer pending and started before before
+func (c *billing) UpdateUsage(ctx context.Context, id string, usage []api.BillingUsage, before int) (*api.BillingDocument, error) {
+	return c.patch(ctx, id, func(billingdoc *api.BillingDocument) error {
+		marketplace := billingdoc.Billing.Marketplace
+		if marketplace == nil {
+			return nil
+		}
+
+		updates := make(map[int]api.BillingUsage, len(usage))
+		for _, u := range usage {
+			updates[u.Hour] = u
+		}
+
+		kept := marketplace.Usage[:0]
+		for _, u := range marketplace.Usage {
+			if update, ok := updates[u.Hour]; ok {
+				u.State = update.State
+				u.UsageEventID = update.UsageEventID
+				u.Attempts = update.Attempts
+			}
+
+			if u.State != "" && u.Hour < before {
+				continue
+			}
+
+			kept = append(kept, u)
+		}
+		marketplace.Usage = kept
+
+		return nil
+	}, nil)
+}
@@ -0,0 +1,313 @@
+// Code generated by github.com/jewzaam/go-cosmosdb, DO NOT EDIT.
+
+package cosmosdb
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pkg "github.com/Azure/ARO-RP/pkg/api"
+)
+
+type mirroredImageDocumentClient struct {
+	*databaseClient
+	path string
+}
+
+// MirroredImageDocumentClient is a mirroredImageDocument client
+type MirroredImageDocumentClient interface {
+	Create(context.Context, string, *pkg.MirroredImageDocument, *Options) (*pkg.MirroredImageDocument, error)
+	List(*Options) MirroredImageDocumentIterator
+	ListAll(context.Context, *Options) (*pkg.MirroredImageDocuments, error)
+	Get(context.Context, string, string, *Options) (*pkg.MirroredImageDocument, error)
+	Replace(context.Context, string, *pkg.MirroredImageDocument, *Options) (*pkg.MirroredImageDocument, error)
+	Delete(context.Context, string, *pkg.MirroredImageDocument, *Options) error
+	Query(string, *Query, *Options) MirroredImageDocumentRawIterator
+	QueryAll(context.Context, string, *Query, *Options) (*pkg.MirroredImageDocuments, error)
+	ChangeFeed(*Options) MirroredImageDocumentIterator
+}
+
+type mirroredImageDocumentChangeFeedIterator struct {
+	*mirroredImageDocumentClient
+	continuation string
+	options      *Options
+}
+
+type mirroredImageDocumentListIterator struct {
+	*mirroredImageDocumentClient
+	continuation string
+	done         bool
+	options      *Options
+}
+
+type mirroredImageDocumentQueryIterator struct {
+	*mirroredImageDocumentClient
+	partitionkey string
+	query        *Query
+	continuation string
+	done         bool
+	options      *Options
+}
+
+// MirroredImageDocumentIterator is a mirroredImageDocument iterator
+type MirroredImageDocumentIterator interface {
+	Next(context.Context, int) (*pkg.MirroredImageDocuments, error)
+	Continuation() string
+}
+
+// MirroredImageDocumentRawIterator is a mirroredImageDocument raw iterator
+type MirroredImageDocumentRawIterator interface {
+	MirroredImageDocumentIterator
+	NextRaw(context.Context, int, interface{}) error
+}
+
+// NewMirroredImageDocumentClient returns a new mirroredImageDocument client
+func NewMirroredImageDocumentClient(collc CollectionClient, collid string) MirroredImageDocumentClient {
+	return &mirroredImageDocumentClient{
+		databaseClient: collc.(*collectionClient).databaseClient,
+		path:           collc.(*collectionClient).path + "/colls/" + collid,
+	}
+}
+
+func (c *mirroredImageDocumentClient) all(ctx context.Context, i MirroredImageDocumentIterator) (*pkg.MirroredImageDocuments, error) {
+	allmirroredImageDocuments := &pkg.MirroredImageDocuments{}
+
+	for {
+		mirroredImageDocuments, err := i.Next(ctx, -1)
+		if err != nil {
+			return nil, err
+		}
+		if mirroredImageDocuments == nil {
+			break
+		}
+
+		allmirroredImageDocuments.Count += mirroredImageDocuments.Count
+		allmirroredImageDocuments.ResourceID = mirroredImageDocuments.ResourceID
+		allmirroredImageDocuments.MirroredImageDocuments = append(allmirroredImageDocuments.MirroredImageDocuments, mirroredImageDocuments.MirroredImageDocuments...)
+	}
+
+	return allmirroredImageDocuments, nil
+}
+
+func (c *mirroredImageDocumentClient) Create(ctx context.Context, partitionkey string, newmirroredImageDocument *pkg.MirroredImageDocument, options *Options) (mirroredImageDocument *pkg.MirroredImageDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	if options == nil {
+		options = &Options{}
+	}
+	options.NoETag = true
+
+	err = c.setOptions(options, newmirroredImageDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodPost, c.path+"/docs", "docs", c.path, http.StatusCreated, &newmirroredImageDocument, &mirroredImageDocument, headers)
+	return
+}
+
+func (c *mirroredImageDocumentClient) List(options *Options) MirroredImageDocumentIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &mirroredImageDocumentListIterator{mirroredImageDocumentClient: c, options: options, continuation: continuation}
+}
+
+func (c *mirroredImageDocumentClient) ListAll(ctx context.Context, options *Options) (*pkg.MirroredImageDocuments, error) {
+	return c.all(ctx, c.List(options))
+}
+
+func (c *mirroredImageDocumentClient) Get(ctx context.Context, partitionkey, mirroredImageDocumentid string, options *Options) (mirroredImageDocument *pkg.MirroredImageDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodGet, c.path+"/docs/"+mirroredImageDocumentid, "docs", c.path+"/docs/"+mirroredImageDocumentid, http.StatusOK, nil, &mirroredImageDocument, headers)
+	return
+}
+
+func (c *mirroredImageDocumentClient) Replace(ctx context.Context, partitionkey string, newmirroredImageDocument *pkg.MirroredImageDocument, options *Options) (mirroredImageDocument *pkg.MirroredImageDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, newmirroredImageDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodPut, c.path+"/docs/"+newmirroredImageDocument.ID, "docs", c.path+"/docs/"+newmirroredImageDocument.ID, http.StatusOK, &newmirroredImageDocument, &mirroredImageDocument, headers)
+	return
+}
+
+func (c *mirroredImageDocumentClient) Delete(ctx context.Context, partitionkey string, mirroredImageDocument *pkg.MirroredImageDocument, options *Options) (err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, mirroredImageDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodDelete, c.path+"/docs/"+mirroredImageDocument.ID, "docs", c.path+"/docs/"+mirroredImageDocument.ID, http.StatusNoContent, nil, nil, headers)
+	return
+}
+
+func (c *mirroredImageDocumentClient) Query(partitionkey string, query *Query, options *Options) MirroredImageDocumentRawIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &mirroredImageDocumentQueryIterator{mirroredImageDocumentClient: c, partitionkey: partitionkey, query: query, options: options, continuation: continuation}
+}
+
+func (c *mirroredImageDocumentClient) QueryAll(ctx context.Context, partitionkey string, query *Query, options *Options) (*pkg.MirroredImageDocuments, error) {
+	return c.all(ctx, c.Query(partitionkey, query, options))
+}
+
+func (c *mirroredImageDocumentClient) ChangeFeed(options *Options) MirroredImageDocumentIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &mirroredImageDocumentChangeFeedIterator{mirroredImageDocumentClient: c, options: options, continuation: continuation}
+}
+
+func (c *mirroredImageDocumentClient) setOptions(options *Options, mirroredImageDocument *pkg.MirroredImageDocument, headers http.Header) error {
+	if options == nil {
+		return nil
+	}
+
+	if mirroredImageDocument != nil && !options.NoETag {
+		if mirroredImageDocument.ETag == "" {
+			return ErrETagRequired
+		}
+		headers.Set("If-Match", mirroredImageDocument.ETag)
+	}
+	if len(options.PreTriggers) > 0 {
+		headers.Set("X-Ms-Documentdb-Pre-Trigger-Include", strings.Join(options.PreTriggers, ","))
+	}
+	if len(options.PostTriggers) > 0 {
+		headers.Set("X-Ms-Documentdb-Post-Trigger-Include", strings.Join(options.PostTriggers, ","))
+	}
+	if len(options.PartitionKeyRangeID) > 0 {
+		headers.Set("X-Ms-Documentdb-PartitionKeyRangeID", options.PartitionKeyRangeID)
+	}
+
+	return nil
+}
+
+func (i *mirroredImageDocumentChangeFeedIterator) Next(ctx context.Context, maxItemCount int) (mirroredImageDocuments *pkg.MirroredImageDocuments, err error) {
+	headers := http.Header{}
+	headers.Set("A-IM", "Incremental feed")
+
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	if i.continuation != "" {
+		headers.Set("If-None-Match", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodGet, i.path+"/docs", "docs", i.path, http.StatusOK, nil, &mirroredImageDocuments, headers)
+	if IsErrorStatusCode(err, http.StatusNotModified) {
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("Etag")
+
+	return
+}
+
+func (i *mirroredImageDocumentChangeFeedIterator) Continuation() string {
+	return i.continuation
+}
+
+func (i *mirroredImageDocumentListIterator) Next(ctx context.Context, maxItemCount int) (mirroredImageDocuments *pkg.MirroredImageDocuments, err error) {
+	if i.done {
+		return
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	if i.continuation != "" {
+		headers.Set("X-Ms-Continuation", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodGet, i.path+"/docs", "docs", i.path, http.StatusOK, nil, &mirroredImageDocuments, headers)
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("X-Ms-Continuation")
+	i.done = i.continuation == ""
+
+	return
+}
+
+func (i *mirroredImageDocumentListIterator) Continuation() string {
+	return i.continuation
+}
+
+func (i *mirroredImageDocumentQueryIterator) Next(ctx context.Context, maxItemCount int) (mirroredImageDocuments *pkg.MirroredImageDocuments, err error) {
+	err = i.NextRaw(ctx, maxItemCount, &mirroredImageDocuments)
+	return
+}
+
+func (i *mirroredImageDocumentQueryIterator) NextRaw(ctx context.Context, maxItemCount int, raw interface{}) (err error) {
+	if i.done {
+		return
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	headers.Set("X-Ms-Documentdb-Isquery", "True")
+	headers.Set("Content-Type", "application/query+json")
+	if i.partitionkey != "" {
+		headers.Set("X-Ms-Documentdb-Partitionkey", `["`+i.partitionkey+`"]`)
+	} else {
+		headers.Set("X-Ms-Documentdb-Query-Enablecrosspartition", "True")
+	}
+	if i.continuation != "" {
+		headers.Set("X-Ms-Continuation", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodPost, i.path+"/docs", "docs", i.path, http.StatusOK, &i.query, &raw, headers)
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("X-Ms-Continuation")
+	i.done = i.continuation == ""
+
+	return
+}
+
+func (i *mirroredImageDocumentQueryIterator) Continuation() string {
+	return i.continuation
+}
@@ -0,0 +1,361 @@
+// Code generated by github.com/jewzaam/go-cosmosdb, DO NOT EDIT.
+
+package cosmosdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ugorji/go/codec"
+
+	pkg "github.com/Azure/ARO-RP/pkg/api"
+)
+
+type fakeMirroredImageDocumentTriggerHandler func(context.Context, *pkg.MirroredImageDocument) error
+type fakeMirroredImageDocumentQueryHandler func(MirroredImageDocumentClient, *Query, *Options) MirroredImageDocumentRawIterator
+
+var _ MirroredImageDocumentClient = &FakeMirroredImageDocumentClient{}
+
+// NewFakeMirroredImageDocumentClient returns a FakeMirroredImageDocumentClient
+func NewFakeMirroredImageDocumentClient(h *codec.JsonHandle) *FakeMirroredImageDocumentClient {
+	return &FakeMirroredImageDocumentClient{
+		jsonHandle:             h,
+		mirroredImageDocuments: make(map[string]*pkg.MirroredImageDocument),
+		triggerHandlers:        make(map[string]fakeMirroredImageDocumentTriggerHandler),
+		queryHandlers:          make(map[string]fakeMirroredImageDocumentQueryHandler),
+	}
+}
+
+// FakeMirroredImageDocumentClient is a FakeMirroredImageDocumentClient
+type FakeMirroredImageDocumentClient struct {
+	lock                   sync.RWMutex
+	jsonHandle             *codec.JsonHandle
+	mirroredImageDocuments map[string]*pkg.MirroredImageDocument
+	triggerHandlers        map[string]fakeMirroredImageDocumentTriggerHandler
+	queryHandlers          map[string]fakeMirroredImageDocumentQueryHandler
+	sorter                 func([]*pkg.MirroredImageDocument)
+	etag                   int
+
+	// returns true if documents conflict
+	conflictChecker func(*pkg.MirroredImageDocument, *pkg.MirroredImageDocument) bool
+
+	// err, if not nil, is an error to return when attempting to communicate
+	// with this Client
+	err error
+}
+
+// SetError sets or unsets an error that will be returned on any
+// FakeMirroredImageDocumentClient method invocation
+func (c *FakeMirroredImageDocumentClient) SetError(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.err = err
+}
+
+// SetSorter sets or unsets a sorter function which will be used to sort values
+// returned by List() for test stability
+func (c *FakeMirroredImageDocumentClient) SetSorter(sorter func([]*pkg.MirroredImageDocument)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.sorter = sorter
+}
+
+// SetConflictChecker sets or unsets a function which can be used to validate
+// additional unique keys in a MirroredImageDocument
+func (c *FakeMirroredImageDocumentClient) SetConflictChecker(conflictChecker func(*pkg.MirroredImageDocument, *pkg.MirroredImageDocument) bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.conflictChecker = conflictChecker
+}
+
+// SetTriggerHandler sets or unsets a trigger handler
+func (c *FakeMirroredImageDocumentClient) SetTriggerHandler(triggerName string, trigger fakeMirroredImageDocumentTriggerHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.triggerHandlers[triggerName] = trigger
+}
+
+// SetQueryHandler sets or unsets a query handler
+func (c *FakeMirroredImageDocumentClient) SetQueryHandler(queryName string, query fakeMirroredImageDocumentQueryHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.queryHandlers[queryName] = query
+}
+
+func (c *FakeMirroredImageDocumentClient) deepCopy(mirroredImageDocument *pkg.MirroredImageDocument) (*pkg.MirroredImageDocument, error) {
+	var b []byte
+	err := codec.NewEncoderBytes(&b, c.jsonHandle).Encode(mirroredImageDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	mirroredImageDocument = nil
+	err = codec.NewDecoderBytes(b, c.jsonHandle).Decode(&mirroredImageDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	return mirroredImageDocument, nil
+}
+
+func (c *FakeMirroredImageDocumentClient) apply(ctx context.Context, partitionkey string, mirroredImageDocument *pkg.MirroredImageDocument, options *Options, isCreate bool) (*pkg.MirroredImageDocument, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	mirroredImageDocument, err := c.deepCopy(mirroredImageDocument) // copy now because pretriggers can mutate mirroredImageDocument
+	if err != nil {
+		return nil, err
+	}
+
+	if options != nil {
+		err := c.processPreTriggers(ctx, mirroredImageDocument, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	existingMirroredImageDocument, exists := c.mirroredImageDocuments[mirroredImageDocument.ID]
+	if isCreate && exists {
+		return nil, &Error{
+			StatusCode: http.StatusConflict,
+			Message:    "Entity with the specified id already exists in the system",
+		}
+	}
+	if !isCreate {
+		if !exists {
+			return nil, &Error{StatusCode: http.StatusNotFound}
+		}
+
+		if mirroredImageDocument.ETag != existingMirroredImageDocument.ETag {
+			return nil, &Error{StatusCode: http.StatusPreconditionFailed}
+		}
+	}
+
+	if c.conflictChecker != nil {
+		for _, mirroredImageDocumentToCheck := range c.mirroredImageDocuments {
+			if c.conflictChecker(mirroredImageDocumentToCheck, mirroredImageDocument) {
+				return nil, &Error{
+					StatusCode: http.StatusConflict,
+					Message:    "Entity with the specified id already exists in the system",
+				}
+			}
+		}
+	}
+
+	mirroredImageDocument.ETag = fmt.Sprint(c.etag)
+	c.etag++
+
+	c.mirroredImageDocuments[mirroredImageDocument.ID] = mirroredImageDocument
+
+	return c.deepCopy(mirroredImageDocument)
+}
+
+// Create creates a MirroredImageDocument in the database
+func (c *FakeMirroredImageDocumentClient) Create(ctx context.Context, partitionkey string, mirroredImageDocument *pkg.MirroredImageDocument, options *Options) (*pkg.MirroredImageDocument, error) {
+	return c.apply(ctx, partitionkey, mirroredImageDocument, options, true)
+}
+
+// Replace replaces a MirroredImageDocument in the database
+func (c *FakeMirroredImageDocumentClient) Replace(ctx context.Context, partitionkey string, mirroredImageDocument *pkg.MirroredImageDocument, options *Options) (*pkg.MirroredImageDocument, error) {
+	return c.apply(ctx, partitionkey, mirroredImageDocument, options, false)
+}
+
+// List returns a MirroredImageDocumentIterator to list all MirroredImageDocuments in the database
+func (c *FakeMirroredImageDocumentClient) List(*Options) MirroredImageDocumentIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeMirroredImageDocumentErroringRawIterator(c.err)
+	}
+
+	mirroredImageDocuments := make([]*pkg.MirroredImageDocument, 0, len(c.mirroredImageDocuments))
+	for _, mirroredImageDocument := range c.mirroredImageDocuments {
+		mirroredImageDocument, err := c.deepCopy(mirroredImageDocument)
+		if err != nil {
+			return NewFakeMirroredImageDocumentErroringRawIterator(err)
+		}
+		mirroredImageDocuments = append(mirroredImageDocuments, mirroredImageDocument)
+	}
+
+	if c.sorter != nil {
+		c.sorter(mirroredImageDocuments)
+	}
+
+	return NewFakeMirroredImageDocumentIterator(mirroredImageDocuments, 0)
+}
+
+// ListAll lists all MirroredImageDocuments in the database
+func (c *FakeMirroredImageDocumentClient) ListAll(ctx context.Context, options *Options) (*pkg.MirroredImageDocuments, error) {
+	iter := c.List(options)
+	return iter.Next(ctx, -1)
+}
+
+// Get gets a MirroredImageDocument from the database
+func (c *FakeMirroredImageDocumentClient) Get(ctx context.Context, partitionkey string, id string, options *Options) (*pkg.MirroredImageDocument, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	mirroredImageDocument, exists := c.mirroredImageDocuments[id]
+	if !exists {
+		return nil, &Error{StatusCode: http.StatusNotFound}
+	}
+
+	return c.deepCopy(mirroredImageDocument)
+}
+
+// Delete deletes a MirroredImageDocument from the database
+func (c *FakeMirroredImageDocumentClient) Delete(ctx context.Context, partitionKey string, mirroredImageDocument *pkg.MirroredImageDocument, options *Options) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	_, exists := c.mirroredImageDocuments[mirroredImageDocument.ID]
+	if !exists {
+		return &Error{StatusCode: http.StatusNotFound}
+	}
+
+	delete(c.mirroredImageDocuments, mirroredImageDocument.ID)
+	return nil
+}
+
+// ChangeFeed is unimplemented
+func (c *FakeMirroredImageDocumentClient) ChangeFeed(*Options) MirroredImageDocumentIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeMirroredImageDocumentErroringRawIterator(c.err)
+	}
+
+	return NewFakeMirroredImageDocumentErroringRawIterator(ErrNotImplemented)
+}
+
+func (c *FakeMirroredImageDocumentClient) processPreTriggers(ctx context.Context, mirroredImageDocument *pkg.MirroredImageDocument, options *Options) error {
+	for _, triggerName := range options.PreTriggers {
+		if triggerHandler := c.triggerHandlers[triggerName]; triggerHandler != nil {
+			c.lock.Unlock()
+			err := triggerHandler(ctx, mirroredImageDocument)
+			c.lock.Lock()
+			if err != nil {
+				return err
+			}
+		} else {
+			return ErrNotImplemented
+		}
+	}
+
+	return nil
+}
+
+// Query calls a query handler to implement database querying
+func (c *FakeMirroredImageDocumentClient) Query(name string, query *Query, options *Options) MirroredImageDocumentRawIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeMirroredImageDocumentErroringRawIterator(c.err)
+	}
+
+	if queryHandler := c.queryHandlers[query.Query]; queryHandler != nil {
+		c.lock.RUnlock()
+		i := queryHandler(c, query, options)
+		c.lock.RLock()
+		return i
+	}
+
+	return NewFakeMirroredImageDocumentErroringRawIterator(ErrNotImplemented)
+}
+
+// QueryAll calls a query handler to implement database querying
+func (c *FakeMirroredImageDocumentClient) QueryAll(ctx context.Context, partitionkey string, query *Query, options *Options) (*pkg.MirroredImageDocuments, error) {
+	iter := c.Query("", query, options)
+	return iter.Next(ctx, -1)
+}
+
+func NewFakeMirroredImageDocumentIterator(mirroredImageDocuments []*pkg.MirroredImageDocument, continuation int) MirroredImageDocumentRawIterator {
+	return &fakeMirroredImageDocumentIterator{mirroredImageDocuments: mirroredImageDocuments, continuation: continuation}
+}
+
+type fakeMirroredImageDocumentIterator struct {
+	mirroredImageDocuments []*pkg.MirroredImageDocument
+	continuation           int
+	done                   bool
+}
+
+func (i *fakeMirroredImageDocumentIterator) NextRaw(ctx context.Context, maxItemCount int, out interface{}) error {
+	return ErrNotImplemented
+}
+
+func (i *fakeMirroredImageDocumentIterator) Next(ctx context.Context, maxItemCount int) (*pkg.MirroredImageDocuments, error) {
+	if i.done {
+		return nil, nil
+	}
+
+	var mirroredImageDocuments []*pkg.MirroredImageDocument
+	if maxItemCount == -1 {
+		mirroredImageDocuments = i.mirroredImageDocuments[i.continuation:]
+		i.continuation = len(i.mirroredImageDocuments)
+		i.done = true
+	} else {
+		max := i.continuation + maxItemCount
+		if max > len(i.mirroredImageDocuments) {
+			max = len(i.mirroredImageDocuments)
+		}
+		mirroredImageDocuments = i.mirroredImageDocuments[i.continuation:max]
+		i.continuation += max
+		i.done = i.Continuation() == ""
+	}
+
+	return &pkg.MirroredImageDocuments{
+		MirroredImageDocuments: mirroredImageDocuments,
+		Count:                  len(mirroredImageDocuments),
+	}, nil
+}
+
+func (i *fakeMirroredImageDocumentIterator) Continuation() string {
+	if i.continuation >= len(i.mirroredImageDocuments) {
+		return ""
+	}
+	return fmt.Sprintf("%d", i.continuation)
+}
+
+// NewFakeMirroredImageDocumentErroringRawIterator returns a MirroredImageDocumentRawIterator which
+// whose methods return the given error
+func NewFakeMirroredImageDocumentErroringRawIterator(err error) MirroredImageDocumentRawIterator {
+	return &fakeMirroredImageDocumentErroringRawIterator{err: err}
+}
+
+type fakeMirroredImageDocumentErroringRawIterator struct {
+	err error
+}
+
+func (i *fakeMirroredImageDocumentErroringRawIterator) Next(ctx context.Context, maxItemCount int) (*pkg.MirroredImageDocuments, error) {
+	return nil, i.err
+}
+
+func (i *fakeMirroredImageDocumentErroringRawIterator) NextRaw(context.Context, int, interface{}) error {
+	return i.err
+}
+
+func (i *fakeMirroredImageDocumentErroringRawIterator) Continuation() string {
+	return ""
+}
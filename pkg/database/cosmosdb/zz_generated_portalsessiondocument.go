@@ -0,0 +1,313 @@
+// Code generated by github.com/jewzaam/go-cosmosdb, DO NOT EDIT.
+
+package cosmosdb
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pkg "github.com/Azure/ARO-RP/pkg/api"
+)
+
+type portalSessionDocumentClient struct {
+	*databaseClient
+	path string
+}
+
+// PortalSessionDocumentClient is a portalSessionDocument client
+type PortalSessionDocumentClient interface {
+	Create(context.Context, string, *pkg.PortalSessionDocument, *Options) (*pkg.PortalSessionDocument, error)
+	List(*Options) PortalSessionDocumentIterator
+	ListAll(context.Context, *Options) (*pkg.PortalSessionDocuments, error)
+	Get(context.Context, string, string, *Options) (*pkg.PortalSessionDocument, error)
+	Replace(context.Context, string, *pkg.PortalSessionDocument, *Options) (*pkg.PortalSessionDocument, error)
+	Delete(context.Context, string, *pkg.PortalSessionDocument, *Options) error
+	Query(string, *Query, *Options) PortalSessionDocumentRawIterator
+	QueryAll(context.Context, string, *Query, *Options) (*pkg.PortalSessionDocuments, error)
+	ChangeFeed(*Options) PortalSessionDocumentIterator
+}
+
+type portalSessionDocumentChangeFeedIterator struct {
+	*portalSessionDocumentClient
+	continuation string
+	options      *Options
+}
+
+type portalSessionDocumentListIterator struct {
+	*portalSessionDocumentClient
+	continuation string
+	done         bool
+	options      *Options
+}
+
+type portalSessionDocumentQueryIterator struct {
+	*portalSessionDocumentClient
+	partitionkey string
+	query        *Query
+	continuation string
+	done         bool
+	options      *Options
+}
+
+// PortalSessionDocumentIterator is a portalSessionDocument iterator
+type PortalSessionDocumentIterator interface {
+	Next(context.Context, int) (*pkg.PortalSessionDocuments, error)
+	Continuation() string
+}
+
+// PortalSessionDocumentRawIterator is a portalSessionDocument raw iterator
+type PortalSessionDocumentRawIterator interface {
+	PortalSessionDocumentIterator
+	NextRaw(context.Context, int, interface{}) error
+}
+
+// NewPortalSessionDocumentClient returns a new portalSessionDocument client
+func NewPortalSessionDocumentClient(collc CollectionClient, collid string) PortalSessionDocumentClient {
+	return &portalSessionDocumentClient{
+		databaseClient: collc.(*collectionClient).databaseClient,
+		path:           collc.(*collectionClient).path + "/colls/" + collid,
+	}
+}
+
+func (c *portalSessionDocumentClient) all(ctx context.Context, i PortalSessionDocumentIterator) (*pkg.PortalSessionDocuments, error) {
+	allportalSessionDocuments := &pkg.PortalSessionDocuments{}
+
+	for {
+		portalSessionDocuments, err := i.Next(ctx, -1)
+		if err != nil {
+			return nil, err
+		}
+		if portalSessionDocuments == nil {
+			break
+		}
+
+		allportalSessionDocuments.Count += portalSessionDocuments.Count
+		allportalSessionDocuments.ResourceID = portalSessionDocuments.ResourceID
+		allportalSessionDocuments.PortalSessionDocuments = append(allportalSessionDocuments.PortalSessionDocuments, portalSessionDocuments.PortalSessionDocuments...)
+	}
+
+	return allportalSessionDocuments, nil
+}
+
+func (c *portalSessionDocumentClient) Create(ctx context.Context, partitionkey string, newportalSessionDocument *pkg.PortalSessionDocument, options *Options) (portalSessionDocument *pkg.PortalSessionDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	if options == nil {
+		options = &Options{}
+	}
+	options.NoETag = true
+
+	err = c.setOptions(options, newportalSessionDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodPost, c.path+"/docs", "docs", c.path, http.StatusCreated, &newportalSessionDocument, &portalSessionDocument, headers)
+	return
+}
+
+func (c *portalSessionDocumentClient) List(options *Options) PortalSessionDocumentIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &portalSessionDocumentListIterator{portalSessionDocumentClient: c, options: options, continuation: continuation}
+}
+
+func (c *portalSessionDocumentClient) ListAll(ctx context.Context, options *Options) (*pkg.PortalSessionDocuments, error) {
+	return c.all(ctx, c.List(options))
+}
+
+func (c *portalSessionDocumentClient) Get(ctx context.Context, partitionkey, portalSessionDocumentid string, options *Options) (portalSessionDocument *pkg.PortalSessionDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodGet, c.path+"/docs/"+portalSessionDocumentid, "docs", c.path+"/docs/"+portalSessionDocumentid, http.StatusOK, nil, &portalSessionDocument, headers)
+	return
+}
+
+func (c *portalSessionDocumentClient) Replace(ctx context.Context, partitionkey string, newportalSessionDocument *pkg.PortalSessionDocument, options *Options) (portalSessionDocument *pkg.PortalSessionDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, newportalSessionDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodPut, c.path+"/docs/"+newportalSessionDocument.ID, "docs", c.path+"/docs/"+newportalSessionDocument.ID, http.StatusOK, &newportalSessionDocument, &portalSessionDocument, headers)
+	return
+}
+
+func (c *portalSessionDocumentClient) Delete(ctx context.Context, partitionkey string, portalSessionDocument *pkg.PortalSessionDocument, options *Options) (err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, portalSessionDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodDelete, c.path+"/docs/"+portalSessionDocument.ID, "docs", c.path+"/docs/"+portalSessionDocument.ID, http.StatusNoContent, nil, nil, headers)
+	return
+}
+
+func (c *portalSessionDocumentClient) Query(partitionkey string, query *Query, options *Options) PortalSessionDocumentRawIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &portalSessionDocumentQueryIterator{portalSessionDocumentClient: c, partitionkey: partitionkey, query: query, options: options, continuation: continuation}
+}
+
+func (c *portalSessionDocumentClient) QueryAll(ctx context.Context, partitionkey string, query *Query, options *Options) (*pkg.PortalSessionDocuments, error) {
+	return c.all(ctx, c.Query(partitionkey, query, options))
+}
+
+func (c *portalSessionDocumentClient) ChangeFeed(options *Options) PortalSessionDocumentIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &portalSessionDocumentChangeFeedIterator{portalSessionDocumentClient: c, options: options, continuation: continuation}
+}
+
+func (c *portalSessionDocumentClient) setOptions(options *Options, portalSessionDocument *pkg.PortalSessionDocument, headers http.Header) error {
+	if options == nil {
+		return nil
+	}
+
+	if portalSessionDocument != nil && !options.NoETag {
+		if portalSessionDocument.ETag == "" {
+			return ErrETagRequired
+		}
+		headers.Set("If-Match", portalSessionDocument.ETag)
+	}
+	if len(options.PreTriggers) > 0 {
+		headers.Set("X-Ms-Documentdb-Pre-Trigger-Include", strings.Join(options.PreTriggers, ","))
+	}
+	if len(options.PostTriggers) > 0 {
+		headers.Set("X-Ms-Documentdb-Post-Trigger-Include", strings.Join(options.PostTriggers, ","))
+	}
+	if len(options.PartitionKeyRangeID) > 0 {
+		headers.Set("X-Ms-Documentdb-PartitionKeyRangeID", options.PartitionKeyRangeID)
+	}
+
+	return nil
+}
+
+func (i *portalSessionDocumentChangeFeedIterator) Next(ctx context.Context, maxItemCount int) (portalSessionDocuments *pkg.PortalSessionDocuments, err error) {
+	headers := http.Header{}
+	headers.Set("A-IM", "Incremental feed")
+
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	if i.continuation != "" {
+		headers.Set("If-None-Match", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodGet, i.path+"/docs", "docs", i.path, http.StatusOK, nil, &portalSessionDocuments, headers)
+	if IsErrorStatusCode(err, http.StatusNotModified) {
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("Etag")
+
+	return
+}
+
+func (i *portalSessionDocumentChangeFeedIterator) Continuation() string {
+	return i.continuation
+}
+
+func (i *portalSessionDocumentListIterator) Next(ctx context.Context, maxItemCount int) (portalSessionDocuments *pkg.PortalSessionDocuments, err error) {
+	if i.done {
+		return
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	if i.continuation != "" {
+		headers.Set("X-Ms-Continuation", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodGet, i.path+"/docs", "docs", i.path, http.StatusOK, nil, &portalSessionDocuments, headers)
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("X-Ms-Continuation")
+	i.done = i.continuation == ""
+
+	return
+}
+
+func (i *portalSessionDocumentListIterator) Continuation() string {
+	return i.continuation
+}
+
+func (i *portalSessionDocumentQueryIterator) Next(ctx context.Context, maxItemCount int) (portalSessionDocuments *pkg.PortalSessionDocuments, err error) {
+	err = i.NextRaw(ctx, maxItemCount, &portalSessionDocuments)
+	return
+}
+
+func (i *portalSessionDocumentQueryIterator) NextRaw(ctx context.Context, maxItemCount int, raw interface{}) (err error) {
+	if i.done {
+		return
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	headers.Set("X-Ms-Documentdb-Isquery", "True")
+	headers.Set("Content-Type", "application/query+json")
+	if i.partitionkey != "" {
+		headers.Set("X-Ms-Documentdb-Partitionkey", `["`+i.partitionkey+`"]`)
+	} else {
+		headers.Set("X-Ms-Documentdb-Query-Enablecrosspartition", "True")
+	}
+	if i.continuation != "" {
+		headers.Set("X-Ms-Continuation", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodPost, i.path+"/docs", "docs", i.path, http.StatusOK, &i.query, &raw, headers)
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("X-Ms-Continuation")
+	i.done = i.continuation == ""
+
+	return
+}
+
+func (i *portalSessionDocumentQueryIterator) Continuation() string {
+	return i.continuation
+}
@@ -0,0 +1,361 @@
+// Code generated by github.com/jewzaam/go-cosmosdb, DO NOT EDIT.
+
+package cosmosdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ugorji/go/codec"
+
+	pkg "github.com/Azure/ARO-RP/pkg/api"
+)
+
+type fakePortalSessionDocumentTriggerHandler func(context.Context, *pkg.PortalSessionDocument) error
+type fakePortalSessionDocumentQueryHandler func(PortalSessionDocumentClient, *Query, *Options) PortalSessionDocumentRawIterator
+
+var _ PortalSessionDocumentClient = &FakePortalSessionDocumentClient{}
+
+// NewFakePortalSessionDocumentClient returns a FakePortalSessionDocumentClient
+func NewFakePortalSessionDocumentClient(h *codec.JsonHandle) *FakePortalSessionDocumentClient {
+	return &FakePortalSessionDocumentClient{
+		jsonHandle:             h,
+		portalSessionDocuments: make(map[string]*pkg.PortalSessionDocument),
+		triggerHandlers:        make(map[string]fakePortalSessionDocumentTriggerHandler),
+		queryHandlers:          make(map[string]fakePortalSessionDocumentQueryHandler),
+	}
+}
+
+// FakePortalSessionDocumentClient is a FakePortalSessionDocumentClient
+type FakePortalSessionDocumentClient struct {
+	lock                   sync.RWMutex
+	jsonHandle             *codec.JsonHandle
+	portalSessionDocuments map[string]*pkg.PortalSessionDocument
+	triggerHandlers        map[string]fakePortalSessionDocumentTriggerHandler
+	queryHandlers          map[string]fakePortalSessionDocumentQueryHandler
+	sorter                 func([]*pkg.PortalSessionDocument)
+	etag                   int
+
+	// returns true if documents conflict
+	conflictChecker func(*pkg.PortalSessionDocument, *pkg.PortalSessionDocument) bool
+
+	// err, if not nil, is an error to return when attempting to communicate
+	// with this Client
+	err error
+}
+
+// SetError sets or unsets an error that will be returned on any
+// FakePortalSessionDocumentClient method invocation
+func (c *FakePortalSessionDocumentClient) SetError(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.err = err
+}
+
+// SetSorter sets or unsets a sorter function which will be used to sort values
+// returned by List() for test stability
+func (c *FakePortalSessionDocumentClient) SetSorter(sorter func([]*pkg.PortalSessionDocument)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.sorter = sorter
+}
+
+// SetConflictChecker sets or unsets a function which can be used to validate
+// additional unique keys in a PortalSessionDocument
+func (c *FakePortalSessionDocumentClient) SetConflictChecker(conflictChecker func(*pkg.PortalSessionDocument, *pkg.PortalSessionDocument) bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.conflictChecker = conflictChecker
+}
+
+// SetTriggerHandler sets or unsets a trigger handler
+func (c *FakePortalSessionDocumentClient) SetTriggerHandler(triggerName string, trigger fakePortalSessionDocumentTriggerHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.triggerHandlers[triggerName] = trigger
+}
+
+// SetQueryHandler sets or unsets a query handler
+func (c *FakePortalSessionDocumentClient) SetQueryHandler(queryName string, query fakePortalSessionDocumentQueryHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.queryHandlers[queryName] = query
+}
+
+func (c *FakePortalSessionDocumentClient) deepCopy(portalSessionDocument *pkg.PortalSessionDocument) (*pkg.PortalSessionDocument, error) {
+	var b []byte
+	err := codec.NewEncoderBytes(&b, c.jsonHandle).Encode(portalSessionDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	portalSessionDocument = nil
+	err = codec.NewDecoderBytes(b, c.jsonHandle).Decode(&portalSessionDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	return portalSessionDocument, nil
+}
+
+func (c *FakePortalSessionDocumentClient) apply(ctx context.Context, partitionkey string, portalSessionDocument *pkg.PortalSessionDocument, options *Options, isCreate bool) (*pkg.PortalSessionDocument, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	portalSessionDocument, err := c.deepCopy(portalSessionDocument) // copy now because pretriggers can mutate portalSessionDocument
+	if err != nil {
+		return nil, err
+	}
+
+	if options != nil {
+		err := c.processPreTriggers(ctx, portalSessionDocument, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	existingPortalSessionDocument, exists := c.portalSessionDocuments[portalSessionDocument.ID]
+	if isCreate && exists {
+		return nil, &Error{
+			StatusCode: http.StatusConflict,
+			Message:    "Entity with the specified id already exists in the system",
+		}
+	}
+	if !isCreate {
+		if !exists {
+			return nil, &Error{StatusCode: http.StatusNotFound}
+		}
+
+		if portalSessionDocument.ETag != existingPortalSessionDocument.ETag {
+			return nil, &Error{StatusCode: http.StatusPreconditionFailed}
+		}
+	}
+
+	if c.conflictChecker != nil {
+		for _, portalSessionDocumentToCheck := range c.portalSessionDocuments {
+			if c.conflictChecker(portalSessionDocumentToCheck, portalSessionDocument) {
+				return nil, &Error{
+					StatusCode: http.StatusConflict,
+					Message:    "Entity with the specified id already exists in the system",
+				}
+			}
+		}
+	}
+
+	portalSessionDocument.ETag = fmt.Sprint(c.etag)
+	c.etag++
+
+	c.portalSessionDocuments[portalSessionDocument.ID] = portalSessionDocument
+
+	return c.deepCopy(portalSessionDocument)
+}
+
+// Create creates a PortalSessionDocument in the database
+func (c *FakePortalSessionDocumentClient) Create(ctx context.Context, partitionkey string, portalSessionDocument *pkg.PortalSessionDocument, options *Options) (*pkg.PortalSessionDocument, error) {
+	return c.apply(ctx, partitionkey, portalSessionDocument, options, true)
+}
+
+// Replace replaces a PortalSessionDocument in the database
+func (c *FakePortalSessionDocumentClient) Replace(ctx context.Context, partitionkey string, portalSessionDocument *pkg.PortalSessionDocument, options *Options) (*pkg.PortalSessionDocument, error) {
+	return c.apply(ctx, partitionkey, portalSessionDocument, options, false)
+}
+
+// List returns a PortalSessionDocumentIterator to list all PortalSessionDocuments in the database
+func (c *FakePortalSessionDocumentClient) List(*Options) PortalSessionDocumentIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakePortalSessionDocumentErroringRawIterator(c.err)
+	}
+
+	portalSessionDocuments := make([]*pkg.PortalSessionDocument, 0, len(c.portalSessionDocuments))
+	for _, portalSessionDocument := range c.portalSessionDocuments {
+		portalSessionDocument, err := c.deepCopy(portalSessionDocument)
+		if err != nil {
+			return NewFakePortalSessionDocumentErroringRawIterator(err)
+		}
+		portalSessionDocuments = append(portalSessionDocuments, portalSessionDocument)
+	}
+
+	if c.sorter != nil {
+		c.sorter(portalSessionDocuments)
+	}
+
+	return NewFakePortalSessionDocumentIterator(portalSessionDocuments, 0)
+}
+
+// ListAll lists all PortalSessionDocuments in the database
+func (c *FakePortalSessionDocumentClient) ListAll(ctx context.Context, options *Options) (*pkg.PortalSessionDocuments, error) {
+	iter := c.List(options)
+	return iter.Next(ctx, -1)
+}
+
+// Get gets a PortalSessionDocument from the database
+func (c *FakePortalSessionDocumentClient) Get(ctx context.Context, partitionkey string, id string, options *Options) (*pkg.PortalSessionDocument, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	portalSessionDocument, exists := c.portalSessionDocuments[id]
+	if !exists {
+		return nil, &Error{StatusCode: http.StatusNotFound}
+	}
+
+	return c.deepCopy(portalSessionDocument)
+}
+
+// Delete deletes a PortalSessionDocument from the database
+func (c *FakePortalSessionDocumentClient) Delete(ctx context.Context, partitionKey string, portalSessionDocument *pkg.PortalSessionDocument, options *Options) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	_, exists := c.portalSessionDocuments[portalSessionDocument.ID]
+	if !exists {
+		return &Error{StatusCode: http.StatusNotFound}
+	}
+
+	delete(c.portalSessionDocuments, portalSessionDocument.ID)
+	return nil
+}
+
+// ChangeFeed is unimplemented
+func (c *FakePortalSessionDocumentClient) ChangeFeed(*Options) PortalSessionDocumentIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakePortalSessionDocumentErroringRawIterator(c.err)
+	}
+
+	return NewFakePortalSessionDocumentErroringRawIterator(ErrNotImplemented)
+}
+
+func (c *FakePortalSessionDocumentClient) processPreTriggers(ctx context.Context, portalSessionDocument *pkg.PortalSessionDocument, options *Options) error {
+	for _, triggerName := range options.PreTriggers {
+		if triggerHandler := c.triggerHandlers[triggerName]; triggerHandler != nil {
+			c.lock.Unlock()
+			err := triggerHandler(ctx, portalSessionDocument)
+			c.lock.Lock()
+			if err != nil {
+				return err
+			}
+		} else {
+			return ErrNotImplemented
+		}
+	}
+
+	return nil
+}
+
+// Query calls a query handler to implement database querying
+func (c *FakePortalSessionDocumentClient) Query(name string, query *Query, options *Options) PortalSessionDocumentRawIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakePortalSessionDocumentErroringRawIterator(c.err)
+	}
+
+	if queryHandler := c.queryHandlers[query.Query]; queryHandler != nil {
+		c.lock.RUnlock()
+		i := queryHandler(c, query, options)
+		c.lock.RLock()
+		return i
+	}
+
+	return NewFakePortalSessionDocumentErroringRawIterator(ErrNotImplemented)
+}
+
+// QueryAll calls a query handler to implement database querying
+func (c *FakePortalSessionDocumentClient) QueryAll(ctx context.Context, partitionkey string, query *Query, options *Options) (*pkg.PortalSessionDocuments, error) {
+	iter := c.Query("", query, options)
+	return iter.Next(ctx, -1)
+}
+
+func NewFakePortalSessionDocumentIterator(portalSessionDocuments []*pkg.PortalSessionDocument, continuation int) PortalSessionDocumentRawIterator {
+	return &fakePortalSessionDocumentIterator{portalSessionDocuments: portalSessionDocuments, continuation: continuation}
+}
+
+type fakePortalSessionDocumentIterator struct {
+	portalSessionDocuments []*pkg.PortalSessionDocument
+	continuation           int
+	done                   bool
+}
+
+func (i *fakePortalSessionDocumentIterator) NextRaw(ctx context.Context, maxItemCount int, out interface{}) error {
+	return ErrNotImplemented
+}
+
+func (i *fakePortalSessionDocumentIterator) Next(ctx context.Context, maxItemCount int) (*pkg.PortalSessionDocuments, error) {
+	if i.done {
+		return nil, nil
+	}
+
+	var portalSessionDocuments []*pkg.PortalSessionDocument
+	if maxItemCount == -1 {
+		portalSessionDocuments = i.portalSessionDocuments[i.continuation:]
+		i.continuation = len(i.portalSessionDocuments)
+		i.done = true
+	} else {
+		max := i.continuation + maxItemCount
+		if max > len(i.portalSessionDocuments) {
+			max = len(i.portalSessionDocuments)
+		}
+		portalSessionDocuments = i.portalSessionDocuments[i.continuation:max]
+		i.continuation += max
+		i.done = i.Continuation() == ""
+	}
+
+	return &pkg.PortalSessionDocuments{
+		PortalSessionDocuments: portalSessionDocuments,
+		Count:                  len(portalSessionDocuments),
+	}, nil
+}
+
+func (i *fakePortalSessionDocumentIterator) Continuation() string {
+	if i.continuation >= len(i.portalSessionDocuments) {
+		return ""
+	}
+	return fmt.Sprintf("%d", i.continuation)
+}
+
+// NewFakePortalSessionDocumentErroringRawIterator returns a PortalSessionDocumentRawIterator which
+// whose methods return the given error
+func NewFakePortalSessionDocumentErroringRawIterator(err error) PortalSessionDocumentRawIterator {
+	return &fakePortalSessionDocumentErroringRawIterator{err: err}
+}
+
+type fakePortalSessionDocumentErroringRawIterator struct {
+	err error
+}
+
+func (i *fakePortalSessionDocumentErroringRawIterator) Next(ctx context.Context, maxItemCount int) (*pkg.PortalSessionDocuments, error) {
+	return nil, i.err
+}
+
+func (i *fakePortalSessionDocumentErroringRawIterator) NextRaw(context.Context, int, interface{}) error {
+	return i.err
+}
+
+func (i *fakePortalSessionDocumentErroringRawIterator) Continuation() string {
+	return ""
+}
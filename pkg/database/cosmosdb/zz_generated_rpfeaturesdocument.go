@@ -0,0 +1,313 @@
+// Code generated by github.com/jewzaam/go-cosmosdb, DO NOT EDIT.
+
+package cosmosdb
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pkg "github.com/Azure/ARO-RP/pkg/api"
+)
+
+type rPFeaturesDocumentClient struct {
+	*databaseClient
+	path string
+}
+
+// RPFeaturesDocumentClient is a rPFeaturesDocument client
+type RPFeaturesDocumentClient interface {
+	Create(context.Context, string, *pkg.RPFeaturesDocument, *Options) (*pkg.RPFeaturesDocument, error)
+	List(*Options) RPFeaturesDocumentIterator
+	ListAll(context.Context, *Options) (*pkg.RPFeaturesDocuments, error)
+	Get(context.Context, string, string, *Options) (*pkg.RPFeaturesDocument, error)
+	Replace(context.Context, string, *pkg.RPFeaturesDocument, *Options) (*pkg.RPFeaturesDocument, error)
+	Delete(context.Context, string, *pkg.RPFeaturesDocument, *Options) error
+	Query(string, *Query, *Options) RPFeaturesDocumentRawIterator
+	QueryAll(context.Context, string, *Query, *Options) (*pkg.RPFeaturesDocuments, error)
+	ChangeFeed(*Options) RPFeaturesDocumentIterator
+}
+
+type rPFeaturesDocumentChangeFeedIterator struct {
+	*rPFeaturesDocumentClient
+	continuation string
+	options      *Options
+}
+
+type rPFeaturesDocumentListIterator struct {
+	*rPFeaturesDocumentClient
+	continuation string
+	done         bool
+	options      *Options
+}
+
+type rPFeaturesDocumentQueryIterator struct {
+	*rPFeaturesDocumentClient
+	partitionkey string
+	query        *Query
+	continuation string
+	done         bool
+	options      *Options
+}
+
+// RPFeaturesDocumentIterator is a rPFeaturesDocument iterator
+type RPFeaturesDocumentIterator interface {
+	Next(context.Context, int) (*pkg.RPFeaturesDocuments, error)
+	Continuation() string
+}
+
+// RPFeaturesDocumentRawIterator is a rPFeaturesDocument raw iterator
+type RPFeaturesDocumentRawIterator interface {
+	RPFeaturesDocumentIterator
+	NextRaw(context.Context, int, interface{}) error
+}
+
+// NewRPFeaturesDocumentClient returns a new rPFeaturesDocument client
+func NewRPFeaturesDocumentClient(collc CollectionClient, collid string) RPFeaturesDocumentClient {
+	return &rPFeaturesDocumentClient{
+		databaseClient: collc.(*collectionClient).databaseClient,
+		path:           collc.(*collectionClient).path + "/colls/" + collid,
+	}
+}
+
+func (c *rPFeaturesDocumentClient) all(ctx context.Context, i RPFeaturesDocumentIterator) (*pkg.RPFeaturesDocuments, error) {
+	allrPFeaturesDocuments := &pkg.RPFeaturesDocuments{}
+
+	for {
+		rPFeaturesDocuments, err := i.Next(ctx, -1)
+		if err != nil {
+			return nil, err
+		}
+		if rPFeaturesDocuments == nil {
+			break
+		}
+
+		allrPFeaturesDocuments.Count += rPFeaturesDocuments.Count
+		allrPFeaturesDocuments.ResourceID = rPFeaturesDocuments.ResourceID
+		allrPFeaturesDocuments.RPFeaturesDocuments = append(allrPFeaturesDocuments.RPFeaturesDocuments, rPFeaturesDocuments.RPFeaturesDocuments...)
+	}
+
+	return allrPFeaturesDocuments, nil
+}
+
+func (c *rPFeaturesDocumentClient) Create(ctx context.Context, partitionkey string, newrPFeaturesDocument *pkg.RPFeaturesDocument, options *Options) (rPFeaturesDocument *pkg.RPFeaturesDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	if options == nil {
+		options = &Options{}
+	}
+	options.NoETag = true
+
+	err = c.setOptions(options, newrPFeaturesDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodPost, c.path+"/docs", "docs", c.path, http.StatusCreated, &newrPFeaturesDocument, &rPFeaturesDocument, headers)
+	return
+}
+
+func (c *rPFeaturesDocumentClient) List(options *Options) RPFeaturesDocumentIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &rPFeaturesDocumentListIterator{rPFeaturesDocumentClient: c, options: options, continuation: continuation}
+}
+
+func (c *rPFeaturesDocumentClient) ListAll(ctx context.Context, options *Options) (*pkg.RPFeaturesDocuments, error) {
+	return c.all(ctx, c.List(options))
+}
+
+func (c *rPFeaturesDocumentClient) Get(ctx context.Context, partitionkey, rPFeaturesDocumentid string, options *Options) (rPFeaturesDocument *pkg.RPFeaturesDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodGet, c.path+"/docs/"+rPFeaturesDocumentid, "docs", c.path+"/docs/"+rPFeaturesDocumentid, http.StatusOK, nil, &rPFeaturesDocument, headers)
+	return
+}
+
+func (c *rPFeaturesDocumentClient) Replace(ctx context.Context, partitionkey string, newrPFeaturesDocument *pkg.RPFeaturesDocument, options *Options) (rPFeaturesDocument *pkg.RPFeaturesDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, newrPFeaturesDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodPut, c.path+"/docs/"+newrPFeaturesDocument.ID, "docs", c.path+"/docs/"+newrPFeaturesDocument.ID, http.StatusOK, &newrPFeaturesDocument, &rPFeaturesDocument, headers)
+	return
+}
+
+func (c *rPFeaturesDocumentClient) Delete(ctx context.Context, partitionkey string, rPFeaturesDocument *pkg.RPFeaturesDocument, options *Options) (err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, rPFeaturesDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodDelete, c.path+"/docs/"+rPFeaturesDocument.ID, "docs", c.path+"/docs/"+rPFeaturesDocument.ID, http.StatusNoContent, nil, nil, headers)
+	return
+}
+
+func (c *rPFeaturesDocumentClient) Query(partitionkey string, query *Query, options *Options) RPFeaturesDocumentRawIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &rPFeaturesDocumentQueryIterator{rPFeaturesDocumentClient: c, partitionkey: partitionkey, query: query, options: options, continuation: continuation}
+}
+
+func (c *rPFeaturesDocumentClient) QueryAll(ctx context.Context, partitionkey string, query *Query, options *Options) (*pkg.RPFeaturesDocuments, error) {
+	return c.all(ctx, c.Query(partitionkey, query, options))
+}
+
+func (c *rPFeaturesDocumentClient) ChangeFeed(options *Options) RPFeaturesDocumentIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &rPFeaturesDocumentChangeFeedIterator{rPFeaturesDocumentClient: c, options: options, continuation: continuation}
+}
+
+func (c *rPFeaturesDocumentClient) setOptions(options *Options, rPFeaturesDocument *pkg.RPFeaturesDocument, headers http.Header) error {
+	if options == nil {
+		return nil
+	}
+
+	if rPFeaturesDocument != nil && !options.NoETag {
+		if rPFeaturesDocument.ETag == "" {
+			return ErrETagRequired
+		}
+		headers.Set("If-Match", rPFeaturesDocument.ETag)
+	}
+	if len(options.PreTriggers) > 0 {
+		headers.Set("X-Ms-Documentdb-Pre-Trigger-Include", strings.Join(options.PreTriggers, ","))
+	}
+	if len(options.PostTriggers) > 0 {
+		headers.Set("X-Ms-Documentdb-Post-Trigger-Include", strings.Join(options.PostTriggers, ","))
+	}
+	if len(options.PartitionKeyRangeID) > 0 {
+		headers.Set("X-Ms-Documentdb-PartitionKeyRangeID", options.PartitionKeyRangeID)
+	}
+
+	return nil
+}
+
+func (i *rPFeaturesDocumentChangeFeedIterator) Next(ctx context.Context, maxItemCount int) (rPFeaturesDocuments *pkg.RPFeaturesDocuments, err error) {
+	headers := http.Header{}
+	headers.Set("A-IM", "Incremental feed")
+
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	if i.continuation != "" {
+		headers.Set("If-None-Match", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodGet, i.path+"/docs", "docs", i.path, http.StatusOK, nil, &rPFeaturesDocuments, headers)
+	if IsErrorStatusCode(err, http.StatusNotModified) {
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("Etag")
+
+	return
+}
+
+func (i *rPFeaturesDocumentChangeFeedIterator) Continuation() string {
+	return i.continuation
+}
+
+func (i *rPFeaturesDocumentListIterator) Next(ctx context.Context, maxItemCount int) (rPFeaturesDocuments *pkg.RPFeaturesDocuments, err error) {
+	if i.done {
+		return
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	if i.continuation != "" {
+		headers.Set("X-Ms-Continuation", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodGet, i.path+"/docs", "docs", i.path, http.StatusOK, nil, &rPFeaturesDocuments, headers)
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("X-Ms-Continuation")
+	i.done = i.continuation == ""
+
+	return
+}
+
+func (i *rPFeaturesDocumentListIterator) Continuation() string {
+	return i.continuation
+}
+
+func (i *rPFeaturesDocumentQueryIterator) Next(ctx context.Context, maxItemCount int) (rPFeaturesDocuments *pkg.RPFeaturesDocuments, err error) {
+	err = i.NextRaw(ctx, maxItemCount, &rPFeaturesDocuments)
+	return
+}
+
+func (i *rPFeaturesDocumentQueryIterator) NextRaw(ctx context.Context, maxItemCount int, raw interface{}) (err error) {
+	if i.done {
+		return
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	headers.Set("X-Ms-Documentdb-Isquery", "True")
+	headers.Set("Content-Type", "application/query+json")
+	if i.partitionkey != "" {
+		headers.Set("X-Ms-Documentdb-Partitionkey", `["`+i.partitionkey+`"]`)
+	} else {
+		headers.Set("X-Ms-Documentdb-Query-Enablecrosspartition", "True")
+	}
+	if i.continuation != "" {
+		headers.Set("X-Ms-Continuation", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodPost, i.path+"/docs", "docs", i.path, http.StatusOK, &i.query, &raw, headers)
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("X-Ms-Continuation")
+	i.done = i.continuation == ""
+
+	return
+}
+
+func (i *rPFeaturesDocumentQueryIterator) Continuation() string {
+	return i.continuation
+}
@@ -0,0 +1,361 @@
+// Code generated by github.com/jewzaam/go-cosmosdb, DO NOT EDIT.
+
+package cosmosdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ugorji/go/codec"
+
+	pkg "github.com/Azure/ARO-RP/pkg/api"
+)
+
+type fakeRPFeaturesDocumentTriggerHandler func(context.Context, *pkg.RPFeaturesDocument) error
+type fakeRPFeaturesDocumentQueryHandler func(RPFeaturesDocumentClient, *Query, *Options) RPFeaturesDocumentRawIterator
+
+var _ RPFeaturesDocumentClient = &FakeRPFeaturesDocumentClient{}
+
+// NewFakeRPFeaturesDocumentClient returns a FakeRPFeaturesDocumentClient
+func NewFakeRPFeaturesDocumentClient(h *codec.JsonHandle) *FakeRPFeaturesDocumentClient {
+	return &FakeRPFeaturesDocumentClient{
+		jsonHandle:          h,
+		rPFeaturesDocuments: make(map[string]*pkg.RPFeaturesDocument),
+		triggerHandlers:     make(map[string]fakeRPFeaturesDocumentTriggerHandler),
+		queryHandlers:       make(map[string]fakeRPFeaturesDocumentQueryHandler),
+	}
+}
+
+// FakeRPFeaturesDocumentClient is a FakeRPFeaturesDocumentClient
+type FakeRPFeaturesDocumentClient struct {
+	lock                sync.RWMutex
+	jsonHandle          *codec.JsonHandle
+	rPFeaturesDocuments map[string]*pkg.RPFeaturesDocument
+	triggerHandlers     map[string]fakeRPFeaturesDocumentTriggerHandler
+	queryHandlers       map[string]fakeRPFeaturesDocumentQueryHandler
+	sorter              func([]*pkg.RPFeaturesDocument)
+	etag                int
+
+	// returns true if documents conflict
+	conflictChecker func(*pkg.RPFeaturesDocument, *pkg.RPFeaturesDocument) bool
+
+	// err, if not nil, is an error to return when attempting to communicate
+	// with this Client
+	err error
+}
+
+// SetError sets or unsets an error that will be returned on any
+// FakeRPFeaturesDocumentClient method invocation
+func (c *FakeRPFeaturesDocumentClient) SetError(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.err = err
+}
+
+// SetSorter sets or unsets a sorter function which will be used to sort values
+// returned by List() for test stability
+func (c *FakeRPFeaturesDocumentClient) SetSorter(sorter func([]*pkg.RPFeaturesDocument)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.sorter = sorter
+}
+
+// SetConflictChecker sets or unsets a function which can be used to validate
+// additional unique keys in a RPFeaturesDocument
+func (c *FakeRPFeaturesDocumentClient) SetConflictChecker(conflictChecker func(*pkg.RPFeaturesDocument, *pkg.RPFeaturesDocument) bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.conflictChecker = conflictChecker
+}
+
+// SetTriggerHandler sets or unsets a trigger handler
+func (c *FakeRPFeaturesDocumentClient) SetTriggerHandler(triggerName string, trigger fakeRPFeaturesDocumentTriggerHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.triggerHandlers[triggerName] = trigger
+}
+
+// SetQueryHandler sets or unsets a query handler
+func (c *FakeRPFeaturesDocumentClient) SetQueryHandler(queryName string, query fakeRPFeaturesDocumentQueryHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.queryHandlers[queryName] = query
+}
+
+func (c *FakeRPFeaturesDocumentClient) deepCopy(rPFeaturesDocument *pkg.RPFeaturesDocument) (*pkg.RPFeaturesDocument, error) {
+	var b []byte
+	err := codec.NewEncoderBytes(&b, c.jsonHandle).Encode(rPFeaturesDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	rPFeaturesDocument = nil
+	err = codec.NewDecoderBytes(b, c.jsonHandle).Decode(&rPFeaturesDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	return rPFeaturesDocument, nil
+}
+
+func (c *FakeRPFeaturesDocumentClient) apply(ctx context.Context, partitionkey string, rPFeaturesDocument *pkg.RPFeaturesDocument, options *Options, isCreate bool) (*pkg.RPFeaturesDocument, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	rPFeaturesDocument, err := c.deepCopy(rPFeaturesDocument) // copy now because pretriggers can mutate rPFeaturesDocument
+	if err != nil {
+		return nil, err
+	}
+
+	if options != nil {
+		err := c.processPreTriggers(ctx, rPFeaturesDocument, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	existingRPFeaturesDocument, exists := c.rPFeaturesDocuments[rPFeaturesDocument.ID]
+	if isCreate && exists {
+		return nil, &Error{
+			StatusCode: http.StatusConflict,
+			Message:    "Entity with the specified id already exists in the system",
+		}
+	}
+	if !isCreate {
+		if !exists {
+			return nil, &Error{StatusCode: http.StatusNotFound}
+		}
+
+		if rPFeaturesDocument.ETag != existingRPFeaturesDocument.ETag {
+			return nil, &Error{StatusCode: http.StatusPreconditionFailed}
+		}
+	}
+
+	if c.conflictChecker != nil {
+		for _, rPFeaturesDocumentToCheck := range c.rPFeaturesDocuments {
+			if c.conflictChecker(rPFeaturesDocumentToCheck, rPFeaturesDocument) {
+				return nil, &Error{
+					StatusCode: http.StatusConflict,
+					Message:    "Entity with the specified id already exists in the system",
+				}
+			}
+		}
+	}
+
+	rPFeaturesDocument.ETag = fmt.Sprint(c.etag)
+	c.etag++
+
+	c.rPFeaturesDocuments[rPFeaturesDocument.ID] = rPFeaturesDocument
+
+	return c.deepCopy(rPFeaturesDocument)
+}
+
+// Create creates a RPFeaturesDocument in the database
+func (c *FakeRPFeaturesDocumentClient) Create(ctx context.Context, partitionkey string, rPFeaturesDocument *pkg.RPFeaturesDocument, options *Options) (*pkg.RPFeaturesDocument, error) {
+	return c.apply(ctx, partitionkey, rPFeaturesDocument, options, true)
+}
+
+// Replace replaces a RPFeaturesDocument in the database
+func (c *FakeRPFeaturesDocumentClient) Replace(ctx context.Context, partitionkey string, rPFeaturesDocument *pkg.RPFeaturesDocument, options *Options) (*pkg.RPFeaturesDocument, error) {
+	return c.apply(ctx, partitionkey, rPFeaturesDocument, options, false)
+}
+
+// List returns a RPFeaturesDocumentIterator to list all RPFeaturesDocuments in the database
+func (c *FakeRPFeaturesDocumentClient) List(*Options) RPFeaturesDocumentIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeRPFeaturesDocumentErroringRawIterator(c.err)
+	}
+
+	rPFeaturesDocuments := make([]*pkg.RPFeaturesDocument, 0, len(c.rPFeaturesDocuments))
+	for _, rPFeaturesDocument := range c.rPFeaturesDocuments {
+		rPFeaturesDocument, err := c.deepCopy(rPFeaturesDocument)
+		if err != nil {
+			return NewFakeRPFeaturesDocumentErroringRawIterator(err)
+		}
+		rPFeaturesDocuments = append(rPFeaturesDocuments, rPFeaturesDocument)
+	}
+
+	if c.sorter != nil {
+		c.sorter(rPFeaturesDocuments)
+	}
+
+	return NewFakeRPFeaturesDocumentIterator(rPFeaturesDocuments, 0)
+}
+
+// ListAll lists all RPFeaturesDocuments in the database
+func (c *FakeRPFeaturesDocumentClient) ListAll(ctx context.Context, options *Options) (*pkg.RPFeaturesDocuments, error) {
+	iter := c.List(options)
+	return iter.Next(ctx, -1)
+}
+
+// Get gets a RPFeaturesDocument from the database
+func (c *FakeRPFeaturesDocumentClient) Get(ctx context.Context, partitionkey string, id string, options *Options) (*pkg.RPFeaturesDocument, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	rPFeaturesDocument, exists := c.rPFeaturesDocuments[id]
+	if !exists {
+		return nil, &Error{StatusCode: http.StatusNotFound}
+	}
+
+	return c.deepCopy(rPFeaturesDocument)
+}
+
+// Delete deletes a RPFeaturesDocument from the database
+func (c *FakeRPFeaturesDocumentClient) Delete(ctx context.Context, partitionKey string, rPFeaturesDocument *pkg.RPFeaturesDocument, options *Options) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	_, exists := c.rPFeaturesDocuments[rPFeaturesDocument.ID]
+	if !exists {
+		return &Error{StatusCode: http.StatusNotFound}
+	}
+
+	delete(c.rPFeaturesDocuments, rPFeaturesDocument.ID)
+	return nil
+}
+
+// ChangeFeed is unimplemented
+func (c *FakeRPFeaturesDocumentClient) ChangeFeed(*Options) RPFeaturesDocumentIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeRPFeaturesDocumentErroringRawIterator(c.err)
+	}
+
+	return NewFakeRPFeaturesDocumentErroringRawIterator(ErrNotImplemented)
+}
+
+func (c *FakeRPFeaturesDocumentClient) processPreTriggers(ctx context.Context, rPFeaturesDocument *pkg.RPFeaturesDocument, options *Options) error {
+	for _, triggerName := range options.PreTriggers {
+		if triggerHandler := c.triggerHandlers[triggerName]; triggerHandler != nil {
+			c.lock.Unlock()
+			err := triggerHandler(ctx, rPFeaturesDocument)
+			c.lock.Lock()
+			if err != nil {
+				return err
+			}
+		} else {
+			return ErrNotImplemented
+		}
+	}
+
+	return nil
+}
+
+// Query calls a query handler to implement database querying
+func (c *FakeRPFeaturesDocumentClient) Query(name string, query *Query, options *Options) RPFeaturesDocumentRawIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeRPFeaturesDocumentErroringRawIterator(c.err)
+	}
+
+	if queryHandler := c.queryHandlers[query.Query]; queryHandler != nil {
+		c.lock.RUnlock()
+		i := queryHandler(c, query, options)
+		c.lock.RLock()
+		return i
+	}
+
+	return NewFakeRPFeaturesDocumentErroringRawIterator(ErrNotImplemented)
+}
+
+// QueryAll calls a query handler to implement database querying
+func (c *FakeRPFeaturesDocumentClient) QueryAll(ctx context.Context, partitionkey string, query *Query, options *Options) (*pkg.RPFeaturesDocuments, error) {
+	iter := c.Query("", query, options)
+	return iter.Next(ctx, -1)
+}
+
+func NewFakeRPFeaturesDocumentIterator(rPFeaturesDocuments []*pkg.RPFeaturesDocument, continuation int) RPFeaturesDocumentRawIterator {
+	return &fakeRPFeaturesDocumentIterator{rPFeaturesDocuments: rPFeaturesDocuments, continuation: continuation}
+}
+
+type fakeRPFeaturesDocumentIterator struct {
+	rPFeaturesDocuments []*pkg.RPFeaturesDocument
+	continuation        int
+	done                bool
+}
+
+func (i *fakeRPFeaturesDocumentIterator) NextRaw(ctx context.Context, maxItemCount int, out interface{}) error {
+	return ErrNotImplemented
+}
+
+func (i *fakeRPFeaturesDocumentIterator) Next(ctx context.Context, maxItemCount int) (*pkg.RPFeaturesDocuments, error) {
+	if i.done {
+		return nil, nil
+	}
+
+	var rPFeaturesDocuments []*pkg.RPFeaturesDocument
+	if maxItemCount == -1 {
+		rPFeaturesDocuments = i.rPFeaturesDocuments[i.continuation:]
+		i.continuation = len(i.rPFeaturesDocuments)
+		i.done = true
+	} else {
+		max := i.continuation + maxItemCount
+		if max > len(i.rPFeaturesDocuments) {
+			max = len(i.rPFeaturesDocuments)
+		}
+		rPFeaturesDocuments = i.rPFeaturesDocuments[i.continuation:max]
+		i.continuation += max
+		i.done = i.Continuation() == ""
+	}
+
+	return &pkg.RPFeaturesDocuments{
+		RPFeaturesDocuments: rPFeaturesDocuments,
+		Count:               len(rPFeaturesDocuments),
+	}, nil
+}
+
+func (i *fakeRPFeaturesDocumentIterator) Continuation() string {
+	if i.continuation >= len(i.rPFeaturesDocuments) {
+		return ""
+	}
+	return fmt.Sprintf("%d", i.continuation)
+}
+
+// NewFakeRPFeaturesDocumentErroringRawIterator returns a RPFeaturesDocumentRawIterator which
+// whose methods return the given error
+func NewFakeRPFeaturesDocumentErroringRawIterator(err error) RPFeaturesDocumentRawIterator {
+	return &fakeRPFeaturesDocumentErroringRawIterator{err: err}
+}
+
+type fakeRPFeaturesDocumentErroringRawIterator struct {
+	err error
+}
+
+func (i *fakeRPFeaturesDocumentErroringRawIterator) Next(ctx context.Context, maxItemCount int) (*pkg.RPFeaturesDocuments, error) {
+	return nil, i.err
+}
+
+func (i *fakeRPFeaturesDocumentErroringRawIterator) NextRaw(context.Context, int, interface{}) error {
+	return i.err
+}
+
+func (i *fakeRPFeaturesDocumentErroringRawIterator) Continuation() string {
+	return ""
+}
@@ -30,32 +30,59 @@ const (
 	collBilling           = "Billing"
 	collClusterManager    = "ClusterManagerConfigurations"
 	collGateway           = "Gateway"
+	collMirroredImages    = "MirroredImages"
 	collMonitors          = "Monitors"
 	collOpenShiftClusters = "OpenShiftClusters"
 	collOpenShiftVersion  = "OpenShiftVersions"
 	collPortal            = "Portal"
+	collPortalSessions    = "PortalSessions"
+	collRPFeatures        = "RPFeatures"
 	collSubscriptions     = "Subscriptions"
 )
 
+// MaxDequeueCount is the number of times a document may be dequeued by the
+// backend before its operation is failed
+const MaxDequeueCount = 5
+
 func NewDatabaseClient(log *logrus.Entry, _env env.Core, authorizer cosmosdb.Authorizer, m metrics.Emitter, aead encryption.AEAD, databaseAccountName string) (cosmosdb.DatabaseClient, error) {
 	h, err := NewJSONHandle(aead)
 	if err != nil {
 		return nil, err
 	}
 
+	transport := &http.Transport{
+		// disable HTTP/2 for now: https://github.com/golang/go/issues/36026
+		TLSNextProto:        map[string]func(string, *tls.Conn) http.RoundTripper{},
+		MaxIdleConnsPerHost: 20,
+	}
+
+	databaseHostname := databaseAccountName + "." + _env.Environment().CosmosDBDNSSuffix
+
+	// in development mode, the database may be the local fake, which serves a
+	// self-signed certificate and does not authorize requests
+	if server := env.LocalDatabaseServer(); server != "" {
+		log.Warnf("using local database server %s", server)
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+		databaseHostname = server
+		authorizer = nil
+	}
+
 	c := &http.Client{
-		Transport: dbmetrics.New(log, &http.Transport{
-			// disable HTTP/2 for now: https://github.com/golang/go/issues/36026
-			TLSNextProto:        map[string]func(string, *tls.Conn) http.RoundTripper{},
-			MaxIdleConnsPerHost: 20,
-		}, m),
-		Timeout: 30 * time.Second,
+		Transport: &tracingRoundTripper{tr: dbmetrics.New(log, transport, m)},
+		Timeout:   30 * time.Second,
 	}
 
-	return cosmosdb.NewDatabaseClient(log, c, h, databaseAccountName+"."+_env.Environment().CosmosDBDNSSuffix, authorizer), nil
+	return cosmosdb.NewDatabaseClient(log, c, h, databaseHostname, authorizer), nil
 }
 
 func NewMasterKeyAuthorizer(ctx context.Context, log *logrus.Entry, token azcore.TokenCredential, clientOptions *policy.ClientOptions, subscriptionID, resourceGroup, databaseAccountName string) (cosmosdb.Authorizer, error) {
+	// the local database server has no keys to list
+	if env.LocalDatabaseServer() != "" {
+		return nil, nil
+	}
+
 	databaseaccounts, err := armcosmos.NewDatabaseAccountsClient(subscriptionID, token, clientOptions)
 	if err != nil {
 		return nil, err
@@ -71,6 +98,21 @@ func NewMasterKeyAuthorizer(ctx context.Context, log *logrus.Entry, token azcore
 	return cosmosdb.NewMasterKeyAuthorizer(getDatabaseKey(keys, log))
 }
 
+// NewServiceAuthorizer returns the cosmosdb.Authorizer for the AuthMode of
+// _env: Azure AD tokens of msiToken in AuthModeManagedIdentity, otherwise the
+// account master key, listed using msiToken
+func NewServiceAuthorizer(ctx context.Context, log *logrus.Entry, _env env.Core, msiToken azcore.TokenCredential, databaseAccountName string) (cosmosdb.Authorizer, error) {
+	if _env.AuthMode() == env.AuthModeManagedIdentity {
+		return NewTokenCredentialAuthorizer(ctx, log, _env, msiToken, databaseAccountName)
+	}
+
+	clientOptions := &policy.ClientOptions{
+		ClientOptions: _env.Environment().ManagedIdentityCredentialOptions().ClientOptions,
+	}
+
+	return NewMasterKeyAuthorizer(ctx, log, msiToken, clientOptions, _env.SubscriptionID(), _env.ResourceGroup(), databaseAccountName)
+}
+
 func getDatabaseKey(keys sdkcosmos.DatabaseAccountsClientListKeysResponse, log *logrus.Entry) string {
 	keyName := "SecondaryMasterKey"
 	log.Infof("Using %s to authenticate with CosmosDB", keyName)
@@ -6,6 +6,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/Azure/ARO-RP/pkg/api"
@@ -13,6 +14,10 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 )
 
+const (
+	GatewayGetByClusterResourceIDQuery = `SELECT * FROM Gateway doc WHERE doc.key = @key`
+)
+
 type gateway struct {
 	c             cosmosdb.GatewayDocumentClient
 	uuidGenerator uuid.Generator
@@ -23,6 +28,7 @@ type Gateway interface {
 	Create(context.Context, *api.GatewayDocument) (*api.GatewayDocument, error)
 	Delete(context.Context, *api.GatewayDocument) error
 	Get(context.Context, string) (*api.GatewayDocument, error)
+	GetByClusterResourceID(context.Context, string) (*api.GatewayDocument, error)
 	Patch(context.Context, string, func(*api.GatewayDocument) error) (*api.GatewayDocument, error)
 	NewUUID() string
 }
@@ -73,6 +79,36 @@ func (c *gateway) Get(ctx context.Context, id string) (*api.GatewayDocument, err
 	return c.c.Get(ctx, id, id, nil)
 }
 
+// GetByClusterResourceID returns the gateway record belonging to the cluster
+// with the given resource ID
+func (c *gateway) GetByClusterResourceID(ctx context.Context, key string) (*api.GatewayDocument, error) {
+	if key != strings.ToLower(key) {
+		return nil, fmt.Errorf("key %q is not lower case", key)
+	}
+
+	docs, err := c.c.QueryAll(ctx, "", &cosmosdb.Query{
+		Query: GatewayGetByClusterResourceIDQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@key",
+				Value: key,
+			},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(docs.GatewayDocuments) > 1:
+		return nil, fmt.Errorf("read %d documents, expected <= 1", len(docs.GatewayDocuments))
+	case len(docs.GatewayDocuments) == 1:
+		return docs.GatewayDocuments[0], nil
+	default:
+		return nil, &cosmosdb.Error{StatusCode: http.StatusNotFound}
+	}
+}
+
 func (c *gateway) Patch(ctx context.Context, id string, f func(*api.GatewayDocument) error) (*api.GatewayDocument, error) {
 	var doc *api.GatewayDocument
 
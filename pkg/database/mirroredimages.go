@@ -0,0 +1,84 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+type mirroredImages struct {
+	c cosmosdb.MirroredImageDocumentClient
+}
+
+// MirroredImages is the database interface for MirroredImageDocuments
+type MirroredImages interface {
+	Create(context.Context, *api.MirroredImageDocument) (*api.MirroredImageDocument, error)
+	Get(context.Context, string) (*api.MirroredImageDocument, error)
+	Patch(context.Context, string, func(*api.MirroredImageDocument) error) (*api.MirroredImageDocument, error)
+	ListAll(context.Context) (*api.MirroredImageDocuments, error)
+}
+
+// NewMirroredImages returns a new MirroredImages
+func NewMirroredImages(ctx context.Context, dbc cosmosdb.DatabaseClient, dbName string) (MirroredImages, error) {
+	collc := cosmosdb.NewCollectionClient(dbc, dbName)
+
+	documentClient := cosmosdb.NewMirroredImageDocumentClient(collc, collMirroredImages)
+	return NewMirroredImagesWithProvidedClient(documentClient), nil
+}
+
+func NewMirroredImagesWithProvidedClient(client cosmosdb.MirroredImageDocumentClient) MirroredImages {
+	return &mirroredImages{
+		c: client,
+	}
+}
+
+func (c *mirroredImages) Create(ctx context.Context, doc *api.MirroredImageDocument) (*api.MirroredImageDocument, error) {
+	if doc.ID != strings.ToLower(doc.ID) {
+		return nil, fmt.Errorf("id %q is not lower case", doc.ID)
+	}
+
+	return c.c.Create(ctx, doc.ID, doc, nil)
+}
+
+func (c *mirroredImages) Get(ctx context.Context, id string) (*api.MirroredImageDocument, error) {
+	if id != strings.ToLower(id) {
+		return nil, fmt.Errorf("id %q is not lower case", id)
+	}
+
+	return c.c.Get(ctx, id, id, nil)
+}
+
+func (c *mirroredImages) Patch(ctx context.Context, id string, f func(*api.MirroredImageDocument) error) (*api.MirroredImageDocument, error) {
+	var doc *api.MirroredImageDocument
+
+	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
+		doc, err = c.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		err = f(doc)
+		if err != nil {
+			return
+		}
+
+		if doc.ID != strings.ToLower(doc.ID) {
+			return fmt.Errorf("id %q is not lower case", doc.ID)
+		}
+
+		doc, err = c.c.Replace(ctx, doc.ID, doc, nil)
+		return
+	})
+
+	return doc, err
+}
+
+func (c *mirroredImages) ListAll(ctx context.Context) (*api.MirroredImageDocuments, error) {
+	return c.c.ListAll(ctx, nil)
+}
@@ -8,12 +8,17 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 )
 
+// MonitorsListQuery lists the live monitors.  The "master" document is left
+// over from bucket master election and is ignored, as are leases.
+const MonitorsListQuery = `SELECT * FROM Monitors doc WHERE doc.id != "master" AND IS_DEFINED(doc.monitor)`
+
 type monitors struct {
 	c    cosmosdb.MonitorDocumentClient
 	uuid string
@@ -22,40 +27,16 @@ type monitors struct {
 // Monitors is the database interface for MonitorDocuments
 type Monitors interface {
 	Create(context.Context, *api.MonitorDocument) (*api.MonitorDocument, error)
-	PatchWithLease(context.Context, string, func(*api.MonitorDocument) error) (*api.MonitorDocument, error)
-	TryLease(context.Context) (*api.MonitorDocument, error)
-	ListBuckets(context.Context) ([]int, error)
 	ListMonitors(context.Context) (*api.MonitorDocuments, error)
-	MonitorHeartbeat(context.Context) error
+	MonitorHeartbeat(context.Context, []int) error
+	MonitorID() string
+	AcquireLease(context.Context, string, time.Duration) (bool, error)
 }
 
 // NewMonitors returns a new Monitors
 func NewMonitors(ctx context.Context, dbc cosmosdb.DatabaseClient, dbName string) (Monitors, error) {
 	collc := cosmosdb.NewCollectionClient(dbc, dbName)
 
-	triggers := []*cosmosdb.Trigger{
-		{
-			ID:               "renewLease",
-			TriggerOperation: cosmosdb.TriggerOperationAll,
-			TriggerType:      cosmosdb.TriggerTypePre,
-			Body: `function trigger() {
-	var request = getContext().getRequest();
-	var body = request.getBody();
-	var date = new Date();
-	body["leaseExpires"] = Math.floor(date.getTime() / 1000) + 60;
-	request.setBody(body);
-}`,
-		},
-	}
-
-	triggerc := cosmosdb.NewTriggerClient(collc, collMonitors)
-	for _, trigger := range triggers {
-		_, err := triggerc.Create(ctx, trigger)
-		if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusConflict) {
-			return nil, err
-		}
-	}
-
 	return &monitors{
 		c:    cosmosdb.NewMonitorDocumentClient(collc, collMonitors),
 		uuid: uuid.DefaultGenerator.Generate(),
@@ -76,45 +57,6 @@ func (c *monitors) Create(ctx context.Context, doc *api.MonitorDocument) (*api.M
 	return doc, err
 }
 
-func (c *monitors) get(ctx context.Context, id string) (*api.MonitorDocument, error) {
-	if id != strings.ToLower(id) {
-		return nil, fmt.Errorf("id %q is not lower case", id)
-	}
-
-	return c.c.Get(ctx, id, id, nil)
-}
-
-func (c *monitors) patch(ctx context.Context, id string, f func(*api.MonitorDocument) error, options *cosmosdb.Options) (*api.MonitorDocument, error) {
-	var doc *api.MonitorDocument
-
-	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
-		doc, err = c.get(ctx, id)
-		if err != nil {
-			return
-		}
-
-		err = f(doc)
-		if err != nil {
-			return
-		}
-
-		doc, err = c.update(ctx, doc, options)
-		return
-	})
-
-	return doc, err
-}
-
-func (c *monitors) PatchWithLease(ctx context.Context, id string, f func(*api.MonitorDocument) error) (*api.MonitorDocument, error) {
-	return c.patch(ctx, id, func(doc *api.MonitorDocument) error {
-		if doc.LeaseOwner != c.uuid {
-			return fmt.Errorf("lost lease")
-		}
-
-		return f(doc)
-	}, &cosmosdb.Options{PreTriggers: []string{"renewLease"}})
-}
-
 func (c *monitors) update(ctx context.Context, doc *api.MonitorDocument, options *cosmosdb.Options) (*api.MonitorDocument, error) {
 	if doc.ID != strings.ToLower(doc.ID) {
 		return nil, fmt.Errorf("id %q is not lower case", doc.ID)
@@ -123,54 +65,20 @@ func (c *monitors) update(ctx context.Context, doc *api.MonitorDocument, options
 	return c.c.Replace(ctx, doc.ID, doc, options)
 }
 
-func (c *monitors) TryLease(ctx context.Context) (*api.MonitorDocument, error) {
-	docs, err := c.c.QueryAll(ctx, "", &cosmosdb.Query{
-		Query: `SELECT * FROM Monitors doc WHERE doc.id = "master" AND (doc.leaseExpires ?? 0) < GetCurrentTimestamp() / 1000`,
-	}, nil)
-	if err != nil {
-		return nil, err
-	}
-	if docs == nil {
-		return nil, nil
-	}
-
-	for _, doc := range docs.MonitorDocuments {
-		doc.LeaseOwner = c.uuid
-		doc, err = c.update(ctx, doc, &cosmosdb.Options{PreTriggers: []string{"renewLease"}})
-		if cosmosdb.IsErrorStatusCode(err, http.StatusPreconditionFailed) { // someone else got there first
-			continue
-		}
-		return doc, err
-	}
-
-	return nil, nil
-}
-
-func (c *monitors) ListBuckets(ctx context.Context) (buckets []int, err error) {
-	doc, err := c.get(ctx, "master")
-	if err != nil || doc == nil {
-		return nil, err
-	}
-
-	for i, monitor := range doc.Monitor.Buckets {
-		if monitor == c.uuid {
-			buckets = append(buckets, i)
-		}
-	}
-
-	return buckets, nil
-}
-
 func (c *monitors) ListMonitors(ctx context.Context) (*api.MonitorDocuments, error) {
 	return c.c.QueryAll(ctx, "", &cosmosdb.Query{
-		Query: `SELECT * FROM Monitors doc WHERE doc.id != "master"`,
+		Query: MonitorsListQuery,
 	}, nil)
 }
 
-func (c *monitors) MonitorHeartbeat(ctx context.Context) error {
+// MonitorHeartbeat advertises our liveness, and persists the buckets we own
+func (c *monitors) MonitorHeartbeat(ctx context.Context, buckets []int) error {
 	doc := &api.MonitorDocument{
 		ID:  c.uuid,
 		TTL: 60,
+		Monitor: &api.Monitor{
+			OwnedBuckets: buckets,
+		},
 	}
 	_, err := c.update(ctx, doc, &cosmosdb.Options{NoETag: true})
 	if err != nil && cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
@@ -178,3 +86,25 @@ func (c *monitors) MonitorHeartbeat(ctx context.Context) error {
 	}
 	return err
 }
+
+// MonitorID returns the ID of our own MonitorDocument
+func (c *monitors) MonitorID() string {
+	return c.uuid
+}
+
+// AcquireLease creates the lease document id, which expires after ttl.  It
+// returns false if the lease is already held, so that periodic work shared by
+// several replicas is done once per ttl.  The lease is not released when the
+// work is done.
+func (c *monitors) AcquireLease(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	_, err := c.Create(ctx, &api.MonitorDocument{
+		ID:         id,
+		TTL:        int(ttl / time.Second),
+		LeaseOwner: c.uuid,
+	})
+	if cosmosdb.IsErrorStatusCode(err, http.StatusPreconditionFailed) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
@@ -7,7 +7,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/azure"
 
@@ -17,16 +19,30 @@ import (
 )
 
 const (
-	OpenShiftClustersDequeueQuery       = `SELECT * FROM OpenShiftClusters doc WHERE doc.openShiftCluster.properties.provisioningState IN ("Creating", "Deleting", "Updating", "AdminUpdating") AND (doc.leaseExpires ?? 0) < GetCurrentTimestamp() / 1000`
-	OpenShiftClustersQueueLengthQuery   = `SELECT VALUE COUNT(1) FROM OpenShiftClusters doc WHERE doc.openShiftCluster.properties.provisioningState IN ("Creating", "Deleting", "Updating", "AdminUpdating") AND (doc.leaseExpires ?? 0) < GetCurrentTimestamp() / 1000`
-	OpenShiftClustersGetQuery           = `SELECT * FROM OpenShiftClusters doc WHERE doc.key = @key`
-	OpenshiftClustersPrefixQuery        = `SELECT * FROM OpenShiftClusters doc WHERE STARTSWITH(doc.key, @prefix)`
-	OpenshiftClustersClientIdQuery      = `SELECT * FROM OpenShiftClusters doc WHERE doc.clientIdKey = @clientID`
-	OpenshiftClustersResourceGroupQuery = `SELECT * FROM OpenShiftClusters doc WHERE doc.clusterResourceGroupIdKey = @resourceGroupID`
+	OpenShiftClustersDequeueQuery        = `SELECT * FROM OpenShiftClusters doc WHERE doc.openShiftCluster.properties.provisioningState IN ("Creating", "Deleting", "Updating", "AdminUpdating") AND NOT IS_DEFINED(doc.softDeleted) AND (doc.leaseExpires ?? 0) < GetCurrentTimestamp() / 1000`
+	OpenShiftClustersQueueLengthQuery    = `SELECT VALUE COUNT(1) FROM OpenShiftClusters doc WHERE doc.openShiftCluster.properties.provisioningState IN ("Creating", "Deleting", "Updating", "AdminUpdating") AND NOT IS_DEFINED(doc.softDeleted) AND (doc.leaseExpires ?? 0) < GetCurrentTimestamp() / 1000`
+	OpenShiftClustersGetQuery            = `SELECT * FROM OpenShiftClusters doc WHERE doc.key = @key`
+	OpenshiftClustersPrefixQuery         = `SELECT * FROM OpenShiftClusters doc WHERE STARTSWITH(doc.key, @prefix)`
+	OpenshiftClustersClientIdQuery       = `SELECT * FROM OpenShiftClusters doc WHERE doc.clientIdKey = @clientID`
+	OpenshiftClustersResourceGroupQuery  = `SELECT * FROM OpenShiftClusters doc WHERE doc.clusterResourceGroupIdKey = @resourceGroupID`
+	OpenShiftClustersSoftDeletedQuery    = `SELECT * FROM OpenShiftClusters doc WHERE doc.softDeleted.key = @key`
+	OpenShiftClustersExpiredQuery        = `SELECT * FROM OpenShiftClusters doc WHERE IS_DEFINED(doc.softDeleted) AND doc.softDeleted.deletionTime < GetCurrentTimestamp() / 1000 - StringToNumber(@retention)`
+	OpenShiftClustersNotSoftDeletedQuery = `SELECT * FROM OpenShiftClusters doc WHERE NOT IS_DEFINED(doc.softDeleted)`
 )
 
+// softDeletedKeyPrefix is prepended to the ID of a soft-deleted document to
+// form the unique keys it holds while soft-deleted.  It deliberately does not
+// start with "/subscriptions/", so that soft-deleted documents are not
+// returned by prefix queries.
+const softDeletedKeyPrefix = "softdeleted/"
+
 type OpenShiftClusterDocumentMutator func(*api.OpenShiftClusterDocument) error
 
+// OpenShiftClusterDequeuePolicy returns the documents which are ready to be
+// dequeued in the order in which they should be tried, omitting any which
+// should be left in the queue for now
+type OpenShiftClusterDequeuePolicy func([]*api.OpenShiftClusterDocument) []*api.OpenShiftClusterDocument
+
 type openShiftClusters struct {
 	c             cosmosdb.OpenShiftClusterDocumentClient
 	collc         cosmosdb.CollectionClient
@@ -43,11 +59,17 @@ type OpenShiftClusters interface {
 	PatchWithLease(context.Context, string, OpenShiftClusterDocumentMutator) (*api.OpenShiftClusterDocument, error)
 	Update(context.Context, *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error)
 	Delete(context.Context, *api.OpenShiftClusterDocument) error
+	SoftDelete(context.Context, *api.OpenShiftClusterDocument) error
+	ListSoftDeleted(context.Context, string) (*api.OpenShiftClusterDocuments, error)
+	ListExpired(context.Context, time.Duration) (*api.OpenShiftClusterDocuments, error)
+	Restore(context.Context, string) (*api.OpenShiftClusterDocument, error)
+	PatchAll(context.Context, *cosmosdb.Query, OpenShiftClusterDocumentMutator, *PatchAllOptions) (*PatchAllCheckpoint, error)
 	ChangeFeed() cosmosdb.OpenShiftClusterDocumentIterator
 	List(string) cosmosdb.OpenShiftClusterDocumentIterator
 	ListAll(context.Context) (*api.OpenShiftClusterDocuments, error)
 	ListByPrefix(string, string, string) (cosmosdb.OpenShiftClusterDocumentIterator, error)
 	Dequeue(context.Context) (*api.OpenShiftClusterDocument, error)
+	DequeueWithPolicy(context.Context, OpenShiftClusterDequeuePolicy) (*api.OpenShiftClusterDocument, error)
 	Lease(context.Context, string) (*api.OpenShiftClusterDocument, error)
 	EndLease(context.Context, string, api.ProvisioningState, api.ProvisioningState, *string) (*api.OpenShiftClusterDocument, error)
 	GetByClientID(ctx context.Context, partitionKey, clientID string) (*api.OpenShiftClusterDocuments, error)
@@ -242,6 +264,112 @@ func (c *openShiftClusters) Delete(ctx context.Context, doc *api.OpenShiftCluste
 	return c.c.Delete(ctx, doc.PartitionKey, doc, &cosmosdb.Options{NoETag: true})
 }
 
+// SoftDelete turns the document into a tombstone instead of removing it.  Its
+// unique keys are moved aside so that a new cluster can reuse them.
+func (c *openShiftClusters) SoftDelete(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	_, err := c.patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.SoftDeleted = &api.SoftDeleted{
+			DeletionTime:              int(time.Now().Unix()),
+			Key:                       doc.Key,
+			ClusterResourceGroupIDKey: doc.ClusterResourceGroupIDKey,
+			ClientIDKey:               doc.ClientIDKey,
+		}
+
+		doc.Key = softDeletedKeyPrefix + doc.ID
+		if doc.ClusterResourceGroupIDKey != "" {
+			doc.ClusterResourceGroupIDKey = softDeletedKeyPrefix + doc.ID
+		}
+		if doc.ClientIDKey != "" {
+			doc.ClientIDKey = softDeletedKeyPrefix + doc.ID
+		}
+
+		doc.LeaseOwner = ""
+		doc.LeaseExpires = 0
+
+		return nil
+	}, nil)
+
+	return err
+}
+
+// ListSoftDeleted returns the tombstones of all deleted clusters which had the
+// given key
+func (c *openShiftClusters) ListSoftDeleted(ctx context.Context, key string) (*api.OpenShiftClusterDocuments, error) {
+	if key != strings.ToLower(key) {
+		return nil, fmt.Errorf("key %q is not lower case", key)
+	}
+
+	partitionKey, err := c.partitionKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.c.QueryAll(ctx, partitionKey, &cosmosdb.Query{
+		Query: OpenShiftClustersSoftDeletedQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@key",
+				Value: key,
+			},
+		},
+	}, nil)
+}
+
+// ListExpired returns the tombstones, across all partitions, which were
+// soft-deleted longer than retention ago
+func (c *openShiftClusters) ListExpired(ctx context.Context, retention time.Duration) (*api.OpenShiftClusterDocuments, error) {
+	return c.c.QueryAll(ctx, "", &cosmosdb.Query{
+		Query: OpenShiftClustersExpiredQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@retention",
+				Value: strconv.FormatInt(int64(retention.Seconds()), 10),
+			},
+		},
+	}, nil)
+}
+
+// Restore reinstates the most recent tombstone with the given key.  It fails
+// if the key, or any of the tombstone's other unique keys, has since been
+// reused.  The restored cluster is left in a failed deletion, which is
+// terminal, so that the backend does not dequeue and delete it again; it can
+// then only be deleted.
+func (c *openShiftClusters) Restore(ctx context.Context, key string) (*api.OpenShiftClusterDocument, error) {
+	docs, err := c.ListSoftDeleted(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc *api.OpenShiftClusterDocument
+	for _, d := range docs.OpenShiftClusterDocuments {
+		if doc == nil || d.SoftDeleted.DeletionTime > doc.SoftDeleted.DeletionTime {
+			doc = d
+		}
+	}
+	if doc == nil {
+		return nil, &cosmosdb.Error{StatusCode: http.StatusNotFound}
+	}
+
+	doc.Key = doc.SoftDeleted.Key
+	doc.ClusterResourceGroupIDKey = doc.SoftDeleted.ClusterResourceGroupIDKey
+	doc.ClientIDKey = doc.SoftDeleted.ClientIDKey
+	doc.SoftDeleted = nil
+
+	doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateFailed
+	doc.OpenShiftCluster.Properties.FailedProvisioningState = api.ProvisioningStateDeleting
+	doc.LeaseOwner = ""
+	doc.LeaseExpires = 0
+	doc.Dequeues = 0
+	doc.AsyncOperationID = ""
+
+	doc, err = c.update(ctx, doc, nil)
+	if err, ok := err.(*cosmosdb.Error); ok && err.StatusCode == http.StatusConflict {
+		err.StatusCode = http.StatusPreconditionFailed
+	}
+
+	return doc, err
+}
+
 func (c *openShiftClusters) ChangeFeed() cosmosdb.OpenShiftClusterDocumentIterator {
 	return c.c.ChangeFeed(nil)
 }
@@ -275,29 +403,46 @@ func (c *openShiftClusters) ListByPrefix(subscriptionID, prefix, continuation st
 }
 
 func (c *openShiftClusters) Dequeue(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+	return c.DequeueWithPolicy(ctx, nil)
+}
+
+// DequeueWithPolicy leases the first document returned by policy which no
+// other backend has leased in the meantime.  As policy must see the whole
+// queue, every ready document is read first.  A nil policy takes the documents
+// in the order in which the query returns them.
+func (c *openShiftClusters) DequeueWithPolicy(ctx context.Context, policy OpenShiftClusterDequeuePolicy) (*api.OpenShiftClusterDocument, error) {
 	i := c.c.Query("", &cosmosdb.Query{
 		Query: OpenShiftClustersDequeueQuery,
 	}, nil)
 
+	var ready []*api.OpenShiftClusterDocument
 	for {
 		docs, err := i.Next(ctx, -1)
 		if err != nil {
 			return nil, err
 		}
 		if docs == nil {
-			return nil, nil
+			break
 		}
 
-		for _, doc := range docs.OpenShiftClusterDocuments {
-			doc.LeaseOwner = c.uuid
-			doc.Dequeues++
-			doc, err = c.update(ctx, doc, &cosmosdb.Options{PreTriggers: []string{"renewLease"}})
-			if cosmosdb.IsErrorStatusCode(err, http.StatusPreconditionFailed) { // someone else got there first
-				continue
-			}
-			return doc, err
+		ready = append(ready, docs.OpenShiftClusterDocuments...)
+	}
+
+	if policy != nil {
+		ready = policy(ready)
+	}
+
+	for _, doc := range ready {
+		doc.LeaseOwner = c.uuid
+		doc.Dequeues++
+		doc, err := c.update(ctx, doc, &cosmosdb.Options{PreTriggers: []string{"renewLease"}})
+		if cosmosdb.IsErrorStatusCode(err, http.StatusPreconditionFailed) { // someone else got there first
+			continue
 		}
+		return doc, err
 	}
+
+	return nil, nil
 }
 
 func (c *openShiftClusters) Lease(ctx context.Context, key string) (*api.OpenShiftClusterDocument, error) {
@@ -311,6 +456,7 @@ func (c *openShiftClusters) EndLease(ctx context.Context, key string, provisioni
 		doc.OpenShiftCluster.Properties.ProvisioningState = provisioningState
 		doc.OpenShiftCluster.Properties.FailedProvisioningState = failedProvisioningState
 		doc.OpenShiftCluster.Properties.MaintenanceTask = ""
+		doc.ReconcileScope = ""
 
 		doc.LeaseOwner = ""
 		doc.LeaseExpires = 0
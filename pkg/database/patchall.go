@@ -0,0 +1,192 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+const (
+	defaultPatchAllPageSize             = 50
+	defaultPatchAllRequestUnitsPerPatch = 15
+	patchAllThrottledBackoff            = 5 * time.Second
+)
+
+// PatchAllCheckpoint records how far a PatchAll run has got.  Passing the last
+// checkpoint back in PatchAllOptions resumes an interrupted run.
+type PatchAllCheckpoint struct {
+	Continuation string            `json:"continuation,omitempty"`
+	Patched      int               `json:"patched"`
+	Failed       []PatchAllFailure `json:"failed,omitempty"`
+}
+
+// PatchAllFailure records a document which could not be patched and why
+type PatchAllFailure struct {
+	Key   string `json:"key"`
+	Error string `json:"error"`
+}
+
+// PatchAllOptions controls the pacing and checkpointing of PatchAll
+type PatchAllOptions struct {
+	// RequestUnitsPerSecond is the RU budget PatchAll paces itself to.  Zero
+	// disables pacing.
+	RequestUnitsPerSecond float64
+
+	// RequestUnitsPerPatch is the estimated cost of reading and replacing a
+	// single document.  The cosmosdb client does not surface request charges,
+	// so this is used to account against the budget.
+	RequestUnitsPerPatch float64
+
+	// PageSize is the number of documents read from the query per page
+	PageSize int
+
+	// Checkpoint, if set, is the checkpoint to resume from
+	Checkpoint *PatchAllCheckpoint
+
+	// OnCheckpoint, if set, is called after every completed page.  Returning
+	// an error stops the run.
+	OnCheckpoint func(context.Context, *PatchAllCheckpoint) error
+}
+
+// PatchAll applies f to every document returned by query, one page at a time.
+// A nil query patches every cluster document.  Tombstones of deleted clusters
+// are never patched, even if query returns them.  A checkpoint is taken after
+// each page; because a resumed run restarts from the beginning of the page it
+// was interrupted in, f must be idempotent.  The keys of documents which fail
+// to patch are recorded in the checkpoint along with the error, and the run
+// carries on.
+func (c *openShiftClusters) PatchAll(ctx context.Context, query *cosmosdb.Query, f OpenShiftClusterDocumentMutator, options *PatchAllOptions) (*PatchAllCheckpoint, error) {
+	if query == nil {
+		query = &cosmosdb.Query{Query: OpenShiftClustersNotSoftDeletedQuery}
+	}
+
+	if options == nil {
+		options = &PatchAllOptions{}
+	}
+
+	pageSize := options.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPatchAllPageSize
+	}
+
+	requestUnitsPerPatch := options.RequestUnitsPerPatch
+	if requestUnitsPerPatch == 0 {
+		requestUnitsPerPatch = defaultPatchAllRequestUnitsPerPatch
+	}
+
+	checkpoint := &PatchAllCheckpoint{}
+	if options.Checkpoint != nil {
+		*checkpoint = *options.Checkpoint
+		checkpoint.Failed = append([]PatchAllFailure(nil), options.Checkpoint.Failed...)
+	}
+
+	pacer := &requestUnitPacer{
+		requestUnitsPerSecond: options.RequestUnitsPerSecond,
+		start:                 time.Now(),
+	}
+
+	i := c.c.Query("", query, &cosmosdb.Options{Continuation: checkpoint.Continuation})
+
+	for {
+		docs, err := i.Next(ctx, pageSize)
+		if err != nil {
+			return checkpoint, err
+		}
+		if docs == nil {
+			break
+		}
+
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			if doc.SoftDeleted != nil {
+				continue
+			}
+
+			err = pacer.wait(ctx, requestUnitsPerPatch)
+			if err != nil {
+				return checkpoint, err
+			}
+
+			_, err = c.patch(ctx, doc.Key, f, nil)
+			if cosmosdb.IsErrorStatusCode(err, http.StatusTooManyRequests) {
+				// the client has already exhausted its own retries, so
+				// give the container a chance to recover before carrying on
+				err = pacer.backoff(ctx, patchAllThrottledBackoff)
+				if err != nil {
+					return checkpoint, err
+				}
+
+				_, err = c.patch(ctx, doc.Key, f, nil)
+			}
+			if err != nil {
+				checkpoint.Failed = append(checkpoint.Failed, PatchAllFailure{
+					Key:   doc.Key,
+					Error: err.Error(),
+				})
+				continue
+			}
+
+			checkpoint.Patched++
+		}
+
+		checkpoint.Continuation = i.Continuation()
+
+		if options.OnCheckpoint != nil {
+			err = options.OnCheckpoint(ctx, checkpoint)
+			if err != nil {
+				return checkpoint, err
+			}
+		}
+
+		if checkpoint.Continuation == "" {
+			break
+		}
+	}
+
+	return checkpoint, nil
+}
+
+// requestUnitPacer spaces out operations so that their estimated cost stays
+// within a request units per second budget averaged since start
+type requestUnitPacer struct {
+	requestUnitsPerSecond float64
+	start                 time.Time
+	spent                 float64
+}
+
+func (p *requestUnitPacer) wait(ctx context.Context, requestUnits float64) error {
+	if p.requestUnitsPerSecond <= 0 {
+		return nil
+	}
+
+	p.spent += requestUnits
+
+	due := p.start.Add(time.Duration(p.spent / p.requestUnitsPerSecond * float64(time.Second)))
+	return p.sleep(ctx, time.Until(due))
+}
+
+func (p *requestUnitPacer) backoff(ctx context.Context, d time.Duration) error {
+	p.start = p.start.Add(d)
+	return p.sleep(ctx, d)
+}
+
+func (p *requestUnitPacer) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("patch all interrupted: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,93 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/util/uuid"
+)
+
+const (
+	PortalSessionsListByClusterResourceIDQuery = `SELECT * FROM PortalSessions doc WHERE doc.key = @key ORDER BY doc.portalSession.creationTime DESC`
+)
+
+type portalSessions struct {
+	c             cosmosdb.PortalSessionDocumentClient
+	uuidGenerator uuid.Generator
+}
+
+// PortalSessions is the database interface for PortalSessionDocuments
+type PortalSessions interface {
+	Create(context.Context, *api.PortalSessionDocument) (*api.PortalSessionDocument, error)
+	ListByClusterResourceID(context.Context, string) (*api.PortalSessionDocuments, error)
+	NewUUID() string
+}
+
+// NewPortalSessions returns a new PortalSessions
+func NewPortalSessions(ctx context.Context, dbc cosmosdb.DatabaseClient, dbName string) (PortalSessions, error) {
+	collc := cosmosdb.NewCollectionClient(dbc, dbName)
+
+	documentClient := cosmosdb.NewPortalSessionDocumentClient(collc, collPortalSessions)
+	return NewPortalSessionsWithProvidedClient(documentClient, uuid.DefaultGenerator), nil
+}
+
+func NewPortalSessionsWithProvidedClient(client cosmosdb.PortalSessionDocumentClient, uuidGenerator uuid.Generator) PortalSessions {
+	return &portalSessions{
+		c:             client,
+		uuidGenerator: uuidGenerator,
+	}
+}
+
+func (c *portalSessions) NewUUID() string {
+	return c.uuidGenerator.Generate()
+}
+
+func (c *portalSessions) Create(ctx context.Context, doc *api.PortalSessionDocument) (*api.PortalSessionDocument, error) {
+	if doc.Key != strings.ToLower(doc.Key) {
+		return nil, fmt.Errorf("key %q is not lower case", doc.Key)
+	}
+
+	var err error
+	doc.PartitionKey, err = c.partitionKey(doc.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.c.Create(ctx, doc.PartitionKey, doc, nil)
+}
+
+// ListByClusterResourceID returns the sessions opened against the cluster with
+// the given key, most recent first
+func (c *portalSessions) ListByClusterResourceID(ctx context.Context, key string) (*api.PortalSessionDocuments, error) {
+	if key != strings.ToLower(key) {
+		return nil, fmt.Errorf("key %q is not lower case", key)
+	}
+
+	partitionKey, err := c.partitionKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.c.QueryAll(ctx, partitionKey, &cosmosdb.Query{
+		Query: PortalSessionsListByClusterResourceIDQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@key",
+				Value: key,
+			},
+		},
+	}, nil)
+}
+
+func (c *portalSessions) partitionKey(key string) (string, error) {
+	r, err := azure.ParseResourceID(key)
+	return r.SubscriptionID, err
+}
@@ -0,0 +1,84 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+type rpFeatures struct {
+	c cosmosdb.RPFeaturesDocumentClient
+}
+
+// RPFeatures is the database interface for RPFeaturesDocuments
+type RPFeatures interface {
+	ChangeFeed() cosmosdb.RPFeaturesDocumentIterator
+	Create(context.Context, *api.RPFeaturesDocument) (*api.RPFeaturesDocument, error)
+	Get(context.Context, string) (*api.RPFeaturesDocument, error)
+	Patch(context.Context, string, func(*api.RPFeaturesDocument) error) (*api.RPFeaturesDocument, error)
+}
+
+// NewRPFeatures returns a new RPFeatures
+func NewRPFeatures(ctx context.Context, dbc cosmosdb.DatabaseClient, dbName string) (RPFeatures, error) {
+	collc := cosmosdb.NewCollectionClient(dbc, dbName)
+
+	documentClient := cosmosdb.NewRPFeaturesDocumentClient(collc, collRPFeatures)
+	return NewRPFeaturesWithProvidedClient(documentClient), nil
+}
+
+func NewRPFeaturesWithProvidedClient(client cosmosdb.RPFeaturesDocumentClient) RPFeatures {
+	return &rpFeatures{
+		c: client,
+	}
+}
+
+func (c *rpFeatures) ChangeFeed() cosmosdb.RPFeaturesDocumentIterator {
+	return c.c.ChangeFeed(nil)
+}
+
+func (c *rpFeatures) Create(ctx context.Context, doc *api.RPFeaturesDocument) (*api.RPFeaturesDocument, error) {
+	if doc.ID != strings.ToLower(doc.ID) {
+		return nil, fmt.Errorf("id %q is not lower case", doc.ID)
+	}
+
+	return c.c.Create(ctx, doc.ID, doc, nil)
+}
+
+func (c *rpFeatures) Get(ctx context.Context, id string) (*api.RPFeaturesDocument, error) {
+	if id != strings.ToLower(id) {
+		return nil, fmt.Errorf("id %q is not lower case", id)
+	}
+
+	return c.c.Get(ctx, id, id, nil)
+}
+
+func (c *rpFeatures) Patch(ctx context.Context, id string, f func(*api.RPFeaturesDocument) error) (*api.RPFeaturesDocument, error) {
+	var doc *api.RPFeaturesDocument
+
+	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
+		doc, err = c.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		err = f(doc)
+		if err != nil {
+			return
+		}
+
+		if doc.ID != strings.ToLower(doc.ID) {
+			return fmt.Errorf("id %q is not lower case", doc.ID)
+		}
+
+		doc, err = c.c.Replace(ctx, doc.ID, doc, nil)
+		return
+	})
+
+	return doc, err
+}
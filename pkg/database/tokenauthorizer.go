@@ -0,0 +1,94 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	sdkazcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/azcore"
+)
+
+// tokenRefreshMargin is how long before its expiry a token is replaced
+const tokenRefreshMargin = 5 * time.Minute
+
+// tokenCredentialAuthorizer authorizes Cosmos DB requests with Azure AD tokens
+// of a token credential, such as the service's managed identity.  The token is
+// cached and replaced when it is close to expiry; if a replacement cannot be
+// obtained, the cached token is used for as long as it remains valid.
+type tokenCredentialAuthorizer struct {
+	log   *logrus.Entry
+	cred  azcore.TokenCredential
+	scope string
+	now   func() time.Time
+
+	mu    sync.Mutex
+	token sdkazcore.AccessToken
+}
+
+// NewTokenCredentialAuthorizer returns a cosmosdb.Authorizer which
+// authenticates to databaseAccountName with tokens of cred.  It obtains the
+// first token before returning, so that a missing role assignment is reported
+// at startup.
+func NewTokenCredentialAuthorizer(ctx context.Context, log *logrus.Entry, _env env.Core, cred azcore.TokenCredential, databaseAccountName string) (cosmosdb.Authorizer, error) {
+	// the local database server does not authorize requests
+	if env.LocalDatabaseServer() != "" {
+		return nil, nil
+	}
+
+	a := &tokenCredentialAuthorizer{
+		log:   log,
+		cred:  cred,
+		scope: "https://" + databaseAccountName + "." + _env.Environment().CosmosDBDNSSuffix + "/.default",
+		now:   time.Now,
+	}
+
+	log.Info("Using Azure AD tokens to authenticate with CosmosDB")
+
+	_, err := a.getToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *tokenCredentialAuthorizer) Authorize(req *http.Request, resourceType, resourceLink string) {
+	token, err := a.getToken(req.Context())
+	if err != nil {
+		a.log.Error(err)
+	}
+
+	req.Header.Set("Authorization", url.QueryEscape("type=aad&ver=1.0&sig="+token))
+	req.Header.Set("x-ms-date", a.now().UTC().Format(http.TimeFormat))
+}
+
+// getToken returns the cached token, replacing it first if it is close to
+// expiry.  If the token cannot be replaced, getToken returns the error along
+// with the cached token.
+func (a *tokenCredentialAuthorizer) getToken(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.now().Add(tokenRefreshMargin).Before(a.token.ExpiresOn) {
+		return a.token.Token, nil
+	}
+
+	token, err := a.cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{a.scope}})
+	if err != nil {
+		return a.token.Token, err
+	}
+
+	a.token = token
+	return a.token.Token, nil
+}
@@ -0,0 +1,88 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	sdkazcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	mock_azcore "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/azcore"
+)
+
+func TestTokenCredentialAuthorizer(t *testing.T) {
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	now := time.Unix(0, 0)
+	options := policy.TokenRequestOptions{Scopes: []string{"https://account.documents.azure.com/.default"}}
+
+	cred := mock_azcore.NewMockTokenCredential(controller)
+	gomock.InOrder(
+		cred.EXPECT().GetToken(gomock.Any(), options).Return(sdkazcore.AccessToken{Token: "first", ExpiresOn: now.Add(time.Hour)}, nil),
+		cred.EXPECT().GetToken(gomock.Any(), options).Return(sdkazcore.AccessToken{}, errors.New("failed")),
+		cred.EXPECT().GetToken(gomock.Any(), options).Return(sdkazcore.AccessToken{Token: "second", ExpiresOn: now.Add(2 * time.Hour)}, nil),
+	)
+
+	a := &tokenCredentialAuthorizer{
+		log:   logrus.NewEntry(logrus.StandardLogger()),
+		cred:  cred,
+		scope: options.Scopes[0],
+		now:   func() time.Time { return now },
+	}
+
+	for _, tt := range []struct {
+		name string
+		now  time.Time
+		want string
+	}{
+		{
+			name: "first token is fetched",
+			now:  now,
+			want: "type%3Daad%26ver%3D1.0%26sig%3Dfirst",
+		},
+		{
+			name: "token is cached",
+			now:  now.Add(54 * time.Minute),
+			want: "type%3Daad%26ver%3D1.0%26sig%3Dfirst",
+		},
+		{
+			name: "cached token is used if it cannot be refreshed",
+			now:  now.Add(56 * time.Minute),
+			want: "type%3Daad%26ver%3D1.0%26sig%3Dfirst",
+		},
+		{
+			name: "token is refreshed",
+			now:  now.Add(57 * time.Minute),
+			want: "type%3Daad%26ver%3D1.0%26sig%3Dsecond",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a.now = func() time.Time { return tt.now }
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://account.documents.azure.com/dbs", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a.Authorize(req, "dbs", "")
+
+			if got := req.Header.Get("Authorization"); got != tt.want {
+				t.Error(got)
+			}
+			if req.Header.Get("x-ms-date") == "" {
+				t.Error("x-ms-date unset")
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Azure/ARO-RP/pkg/database"
+
+var _ http.RoundTripper = (*tracingRoundTripper)(nil)
+
+// tracingRoundTripper records a client span for each request to Cosmos DB, so
+// that the database calls made while serving a request or running a backend
+// step appear in its trace
+type tracingRoundTripper struct {
+	tr http.RoundTripper
+}
+
+func (t *tracingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(tracerName).Start(req.Context(), "cosmosdb "+req.Method+" "+spanPath(req.URL.Path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "cosmosdb"),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	resp, err := t.tr.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	return resp, nil
+}
+
+// spanPath returns path with any document ID replaced, as document IDs are not
+// useful in span names and would make their number unbounded
+func spanPath(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) >= 2 && parts[len(parts)-2] == "docs" {
+		parts[len(parts)-1] = "{id}"
+	}
+
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,34 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+)
+
+func TestSpanPath(t *testing.T) {
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{
+			path: "/dbs/ARO/colls/OpenShiftClusters/docs",
+			want: "/dbs/ARO/colls/OpenShiftClusters/docs",
+		},
+		{
+			path: "/dbs/ARO/colls/OpenShiftClusters/docs/00000000-0000-0000-0000-000000000000",
+			want: "/dbs/ARO/colls/OpenShiftClusters/docs/{id}",
+		},
+		{
+			path: "/dbs/ARO/colls/OpenShiftClusters",
+			want: "/dbs/ARO/colls/OpenShiftClusters",
+		},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := spanPath(tt.path); got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	mgmtinsights "github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	"github.com/Azure/go-autorest/autorest/to"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const defaultRPVMSSCapacity = 3
+
+var (
+	// rpCanaryPollInterval is the interval at which the error rate of the
+	// new scaleset is checked during the bake period
+	rpCanaryPollInterval = time.Minute
+	// rpCanaryBakeUnit is the unit of RPCanaryConfiguration.BakeMinutes
+	rpCanaryBakeUnit = time.Minute
+)
+
+// rpCanaryError is returned when the new scaleset breaches the error rate
+// threshold during a canary upgrade
+type rpCanaryError struct {
+	step     int
+	errors   float64
+	requests float64
+}
+
+func (err *rpCanaryError) Error() string {
+	return fmt.Sprintf("canary failed at %d%%: %.0f of %.0f frontend requests failed", err.step, err.errors, err.requests)
+}
+
+// rpCapacity returns the capacity of the RP scaleset at percent of its full
+// capacity, which is never less than one instance
+func (d *deployer) rpCapacity(percent int) int64 {
+	capacity := defaultRPVMSSCapacity
+	if d.config.Configuration.RPVMSSCapacity != nil {
+		capacity = *d.config.Configuration.RPVMSSCapacity
+	}
+
+	n := (capacity*percent + 99) / 100
+	if n < 1 {
+		n = 1
+	}
+
+	return int64(n)
+}
+
+// rpOldScalesets returns the names of the RP scalesets other than the one
+// being deployed
+func (d *deployer) rpOldScalesets(ctx context.Context) ([]string, error) {
+	scalesets, err := d.vmss.List(ctx, d.config.RPResourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, vmss := range scalesets {
+		if *vmss.Name != rpVMSSPrefix+d.version {
+			names = append(names, *vmss.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// rpCanaryUpgrade scales the new scaleset, which DeployRP created with the
+// capacity of the first step, through each step of the canary configuration.
+// The load balancer only sends traffic to instances whose health probe
+// passes, so after each step it waits for the new instances to be healthy and
+// then watches their frontend error rate for the bake period.  If either
+// fails, the new scaleset is rolled back and the old scalesets are left
+// serving; otherwise the old scalesets are removed.
+func (d *deployer) rpCanaryUpgrade(ctx context.Context) error {
+	canary := d.config.Configuration.RPCanary
+	vmssName := rpVMSSPrefix + d.version
+
+	oldScalesets, err := d.rpOldScalesets(ctx)
+	if err != nil {
+		return err
+	}
+
+	// DeployRP created the new scaleset at full capacity
+	if len(oldScalesets) == 0 {
+		d.log.Print("no old scalesets, skipping canary")
+		return d.rpUpgrade(ctx)
+	}
+
+	for i, step := range canary.Steps {
+		if i > 0 {
+			capacity := d.rpCapacity(step)
+			d.log.Printf("scaling %s to %d instances (%d%%)", vmssName, capacity, step)
+			err := d.vmss.UpdateAndWait(ctx, d.config.RPResourceGroupName, vmssName, mgmtcompute.VirtualMachineScaleSetUpdate{
+				Sku: &mgmtcompute.Sku{
+					Capacity: &capacity,
+				},
+			})
+			if err != nil {
+				return d.rpCanaryRollback(ctx, vmssName, err)
+			}
+		}
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Hour)
+		err := d.rpWaitForReadiness(timeoutCtx, vmssName)
+		cancel()
+		if err != nil {
+			return d.rpCanaryRollback(ctx, vmssName, err)
+		}
+
+		err = d.rpCanaryBake(ctx, step)
+		if err != nil {
+			return d.rpCanaryRollback(ctx, vmssName, err)
+		}
+	}
+
+	return d.rpRemoveOldScalesets(ctx)
+}
+
+// rpCanaryBake watches the frontend error rate of the new scaleset since the
+// start of the bake period until it ends, returning an *rpCanaryError if the
+// error rate exceeds the threshold
+func (d *deployer) rpCanaryBake(ctx context.Context, step int) error {
+	canary := d.config.Configuration.RPCanary
+	bake := time.Duration(canary.BakeMinutes) * rpCanaryBakeUnit
+
+	d.log.Printf("baking at %d%% for %s", step, bake)
+
+	bakeCtx, cancel := context.WithTimeout(ctx, bake)
+	defer cancel()
+
+	start := time.Now()
+	err := wait.PollUntil(rpCanaryPollInterval, func() (bool, error) {
+		errors, requests, err := d.rpFrontendErrors(ctx, start, time.Now())
+		if err != nil {
+			// metrics may lag or be briefly unavailable; only a breach of
+			// the threshold fails the canary
+			d.log.Warn(err)
+			return false, nil
+		}
+
+		if requests < canary.MinRequests || requests == 0 {
+			return false, nil
+		}
+
+		d.log.Printf("%.0f of %.0f frontend requests failed", errors, requests)
+		if errors*100/requests > canary.MaxErrorRatePercent {
+			return false, &rpCanaryError{step: step, errors: errors, requests: requests}
+		}
+
+		return false, nil
+	}, bakeCtx.Done())
+	if err == wait.ErrWaitTimeout && ctx.Err() == nil {
+		return nil
+	}
+
+	return err
+}
+
+// rpFrontendErrors returns the number of frontend requests served by the new
+// scaleset between start and end which failed with a server error, and the
+// total number of requests it served
+func (d *deployer) rpFrontendErrors(ctx context.Context, start, end time.Time) (errors, requests float64, err error) {
+	canary := d.config.Configuration.RPCanary
+	timespan := start.UTC().Format(time.RFC3339) + "/" + end.UTC().Format(time.RFC3339)
+
+	// the hostnames of the new scaleset's instances start with its computer
+	// name prefix
+	filter := fmt.Sprintf("hostname sw 'rp-%s-' and code eq '*'", d.version)
+
+	resp, err := d.metrics.List(ctx, canary.MetricsResourceID, timespan, to.StringPtr("PT1M"), "frontend.count", "Total", nil, "", filter, mgmtinsights.Data, canary.MetricsNamespace)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if resp.Value == nil {
+		return 0, 0, nil
+	}
+
+	for _, metric := range *resp.Value {
+		if metric.Timeseries == nil {
+			continue
+		}
+
+		for _, ts := range *metric.Timeseries {
+			var total float64
+			if ts.Data != nil {
+				for _, v := range *ts.Data {
+					if v.Total != nil {
+						total += *v.Total
+					}
+				}
+			}
+
+			requests += total
+			if strings.HasPrefix(metadataValue(ts, "code"), "5") {
+				errors += total
+			}
+		}
+	}
+
+	return errors, requests, nil
+}
+
+func metadataValue(ts mgmtinsights.TimeSeriesElement, name string) string {
+	if ts.Metadatavalues == nil {
+		return ""
+	}
+
+	for _, v := range *ts.Metadatavalues {
+		if v.Name != nil && v.Name.Value != nil && strings.EqualFold(*v.Name.Value, name) && v.Value != nil {
+			return *v.Value
+		}
+	}
+
+	return ""
+}
+
+// rpCanaryRollback takes the new scaleset out of the RP load balancers'
+// backend pools, so that the old scalesets serve all traffic, and then
+// deletes it.  If the backend pools cannot be changed, the RP is stopped on
+// the new instances instead, so that they fail their health probes.  It
+// returns cause, or the error which prevented the rollback.
+func (d *deployer) rpCanaryRollback(ctx context.Context, vmssName string, cause error) error {
+	d.log.Errorf("rolling back %s: %s", vmssName, cause)
+
+	err := d.rpRemoveFromLoadBalancers(ctx, vmssName)
+	if err != nil {
+		d.log.Error(err)
+
+		err = d.rpStopScaleset(ctx, vmssName)
+		if err != nil {
+			d.log.Error(err)
+			return fmt.Errorf("%v; rollback failed: %v", cause, err)
+		}
+	}
+
+	d.log.Printf("deleting scaleset %s", vmssName)
+	err = d.vmss.DeleteAndWait(ctx, d.config.RPResourceGroupName, vmssName)
+	if err != nil {
+		// the scaleset no longer receives traffic, so the rollback has
+		// succeeded even if the scaleset remains
+		d.log.Error(err)
+	}
+
+	return cause
+}
+
+// rpRemoveFromLoadBalancers removes every load balancer backend pool from the
+// network profile of the scaleset and applies the change to its instances
+func (d *deployer) rpRemoveFromLoadBalancers(ctx context.Context, vmssName string) error {
+	vmss, err := d.vmss.Get(ctx, d.config.RPResourceGroupName, vmssName)
+	if err != nil {
+		return err
+	}
+
+	if vmss.VirtualMachineScaleSetProperties == nil ||
+		vmss.VirtualMachineProfile == nil ||
+		vmss.VirtualMachineProfile.NetworkProfile == nil ||
+		vmss.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations == nil {
+		return fmt.Errorf("scaleset %s has no network profile", vmssName)
+	}
+
+	// a PATCH replaces the whole list of network interface configurations,
+	// so every configuration is sent with its backend pools emptied
+	var nics []mgmtcompute.VirtualMachineScaleSetUpdateNetworkConfiguration
+	for _, nic := range *vmss.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations {
+		var ipconfigs []mgmtcompute.VirtualMachineScaleSetUpdateIPConfiguration
+		if nic.VirtualMachineScaleSetNetworkConfigurationProperties != nil && nic.IPConfigurations != nil {
+			for _, ipconfig := range *nic.IPConfigurations {
+				properties := &mgmtcompute.VirtualMachineScaleSetUpdateIPConfigurationProperties{
+					LoadBalancerBackendAddressPools: &[]mgmtcompute.SubResource{},
+				}
+				if ipconfig.VirtualMachineScaleSetIPConfigurationProperties != nil {
+					properties.Subnet = ipconfig.Subnet
+					properties.Primary = ipconfig.Primary
+					if ipconfig.PublicIPAddressConfiguration != nil {
+						properties.PublicIPAddressConfiguration = &mgmtcompute.VirtualMachineScaleSetUpdatePublicIPAddressConfiguration{
+							Name: ipconfig.PublicIPAddressConfiguration.Name,
+						}
+					}
+				}
+
+				ipconfigs = append(ipconfigs, mgmtcompute.VirtualMachineScaleSetUpdateIPConfiguration{
+					Name: ipconfig.Name,
+					VirtualMachineScaleSetUpdateIPConfigurationProperties: properties,
+				})
+			}
+		}
+
+		properties := &mgmtcompute.VirtualMachineScaleSetUpdateNetworkConfigurationProperties{
+			IPConfigurations: &ipconfigs,
+		}
+		if nic.VirtualMachineScaleSetNetworkConfigurationProperties != nil {
+			properties.Primary = nic.Primary
+		}
+
+		nics = append(nics, mgmtcompute.VirtualMachineScaleSetUpdateNetworkConfiguration{
+			Name: nic.Name,
+			VirtualMachineScaleSetUpdateNetworkConfigurationProperties: properties,
+		})
+	}
+
+	d.log.Printf("removing %s from the load balancers", vmssName)
+	err = d.vmss.UpdateAndWait(ctx, d.config.RPResourceGroupName, vmssName, mgmtcompute.VirtualMachineScaleSetUpdate{
+		VirtualMachineScaleSetUpdateProperties: &mgmtcompute.VirtualMachineScaleSetUpdateProperties{
+			VirtualMachineProfile: &mgmtcompute.VirtualMachineScaleSetUpdateVMProfile{
+				NetworkProfile: &mgmtcompute.VirtualMachineScaleSetUpdateNetworkProfile{
+					NetworkInterfaceConfigurations: &nics,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	scalesetVMs, err := d.vmssvms.List(ctx, d.config.RPResourceGroupName, vmssName, "", "", "")
+	if err != nil {
+		return err
+	}
+
+	instanceIDs := make([]string, 0, len(scalesetVMs))
+	for _, vm := range scalesetVMs {
+		instanceIDs = append(instanceIDs, *vm.InstanceID)
+	}
+
+	return d.vmss.UpdateInstancesAndWait(ctx, d.config.RPResourceGroupName, vmssName, instanceIDs)
+}
@@ -0,0 +1,299 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	mgmtinsights "github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	mock_insights "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/insights"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func frontendCountResponse(errors, requests float64) mgmtinsights.Response {
+	timeseries := func(code string, total float64) mgmtinsights.TimeSeriesElement {
+		return mgmtinsights.TimeSeriesElement{
+			Metadatavalues: &[]mgmtinsights.MetadataValue{
+				{
+					Name:  &mgmtinsights.LocalizableString{Value: to.StringPtr("code")},
+					Value: to.StringPtr(code),
+				},
+			},
+			Data: &[]mgmtinsights.MetricValue{
+				{
+					Total: to.Float64Ptr(total / 2),
+				},
+				{
+					Total: to.Float64Ptr(total / 2),
+				},
+			},
+		}
+	}
+
+	return mgmtinsights.Response{
+		Value: &[]mgmtinsights.Metric{
+			{
+				Timeseries: &[]mgmtinsights.TimeSeriesElement{
+					timeseries("200", requests-errors),
+					timeseries("500", errors),
+				},
+			},
+		},
+	}
+}
+
+func TestRPCanaryUpgrade(t *testing.T) {
+	ctx := context.Background()
+
+	rpCanaryPollInterval = time.Millisecond
+	rpCanaryBakeUnit = 10 * time.Millisecond
+	defer func() {
+		rpCanaryPollInterval = time.Minute
+		rpCanaryBakeUnit = time.Minute
+	}()
+
+	version := "new"
+	newVMSS := rpVMSSPrefix + version
+	oldVMSS := rpVMSSPrefix + "old"
+
+	vms := []mgmtcompute.VirtualMachineScaleSetVM{
+		{
+			InstanceID: to.StringPtr("0"),
+		},
+	}
+
+	scaleset := mgmtcompute.VirtualMachineScaleSet{
+		Name: to.StringPtr(newVMSS),
+		VirtualMachineScaleSetProperties: &mgmtcompute.VirtualMachineScaleSetProperties{
+			VirtualMachineProfile: &mgmtcompute.VirtualMachineScaleSetVMProfile{
+				NetworkProfile: &mgmtcompute.VirtualMachineScaleSetNetworkProfile{
+					NetworkInterfaceConfigurations: &[]mgmtcompute.VirtualMachineScaleSetNetworkConfiguration{
+						{
+							Name: to.StringPtr("rp-vmss-nic"),
+							VirtualMachineScaleSetNetworkConfigurationProperties: &mgmtcompute.VirtualMachineScaleSetNetworkConfigurationProperties{
+								Primary: to.BoolPtr(true),
+								IPConfigurations: &[]mgmtcompute.VirtualMachineScaleSetIPConfiguration{
+									{
+										Name: to.StringPtr("rp-vmss-ipconfig"),
+										VirtualMachineScaleSetIPConfigurationProperties: &mgmtcompute.VirtualMachineScaleSetIPConfigurationProperties{
+											Subnet: &mgmtcompute.APIEntityReference{
+												ID: to.StringPtr("subnet"),
+											},
+											Primary: to.BoolPtr(true),
+											PublicIPAddressConfiguration: &mgmtcompute.VirtualMachineScaleSetPublicIPAddressConfiguration{
+												Name: to.StringPtr("rp-vmss-pip"),
+											},
+											LoadBalancerBackendAddressPools: &[]mgmtcompute.SubResource{
+												{
+													ID: to.StringPtr("rp-backend"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	removeFromLoadBalancers := mgmtcompute.VirtualMachineScaleSetUpdate{
+		VirtualMachineScaleSetUpdateProperties: &mgmtcompute.VirtualMachineScaleSetUpdateProperties{
+			VirtualMachineProfile: &mgmtcompute.VirtualMachineScaleSetUpdateVMProfile{
+				NetworkProfile: &mgmtcompute.VirtualMachineScaleSetUpdateNetworkProfile{
+					NetworkInterfaceConfigurations: &[]mgmtcompute.VirtualMachineScaleSetUpdateNetworkConfiguration{
+						{
+							Name: to.StringPtr("rp-vmss-nic"),
+							VirtualMachineScaleSetUpdateNetworkConfigurationProperties: &mgmtcompute.VirtualMachineScaleSetUpdateNetworkConfigurationProperties{
+								Primary: to.BoolPtr(true),
+								IPConfigurations: &[]mgmtcompute.VirtualMachineScaleSetUpdateIPConfiguration{
+									{
+										Name: to.StringPtr("rp-vmss-ipconfig"),
+										VirtualMachineScaleSetUpdateIPConfigurationProperties: &mgmtcompute.VirtualMachineScaleSetUpdateIPConfigurationProperties{
+											Subnet: &mgmtcompute.APIEntityReference{
+												ID: to.StringPtr("subnet"),
+											},
+											Primary: to.BoolPtr(true),
+											PublicIPAddressConfiguration: &mgmtcompute.VirtualMachineScaleSetUpdatePublicIPAddressConfiguration{
+												Name: to.StringPtr("rp-vmss-pip"),
+											},
+											LoadBalancerBackendAddressPools: &[]mgmtcompute.SubResource{},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	scaleTo := func(capacity int64) mgmtcompute.VirtualMachineScaleSetUpdate {
+		return mgmtcompute.VirtualMachineScaleSetUpdate{
+			Sku: &mgmtcompute.Sku{
+				Capacity: to.Int64Ptr(capacity),
+			},
+		}
+	}
+
+	healthy := func(vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient) {
+		vmssvms.EXPECT().GetInstanceView(gomock.Any(), rgName, newVMSS, "0").Return(healthyVMSS, nil).AnyTimes()
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_compute.MockVirtualMachineScaleSetsClient, *mock_compute.MockVirtualMachineScaleSetVMsClient, *mock_insights.MockMetricsClient)
+		wantErr string
+	}{
+		{
+			name: "no old scalesets",
+			mocks: func(vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient, metrics *mock_insights.MockMetricsClient) {
+				vmss.EXPECT().List(gomock.Any(), rgName).Return([]mgmtcompute.VirtualMachineScaleSet{{Name: to.StringPtr(newVMSS)}}, nil).Times(2)
+				vmssvms.EXPECT().List(gomock.Any(), rgName, newVMSS, "", "", "").Return(vms, nil)
+				healthy(vmssvms)
+			},
+		},
+		{
+			name: "canary succeeds",
+			mocks: func(vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient, metrics *mock_insights.MockMetricsClient) {
+				vmss.EXPECT().List(gomock.Any(), rgName).Return([]mgmtcompute.VirtualMachineScaleSet{{Name: to.StringPtr(oldVMSS)}, {Name: to.StringPtr(newVMSS)}}, nil).Times(2)
+				vmssvms.EXPECT().List(gomock.Any(), rgName, newVMSS, "", "", "").Return(vms, nil).Times(2)
+				healthy(vmssvms)
+				metrics.EXPECT().List(gomock.Any(), "metrics", gomock.Any(), to.StringPtr("PT1M"), "frontend.count", "Total", nil, "", "hostname sw 'rp-new-' and code eq '*'", mgmtinsights.Data, "namespace").Return(frontendCountResponse(1, 100), nil).AnyTimes()
+				vmss.EXPECT().UpdateAndWait(gomock.Any(), rgName, newVMSS, scaleTo(4)).Return(nil)
+				vmssvms.EXPECT().List(gomock.Any(), rgName, oldVMSS, "", "", "").Return(nil, nil)
+				vmss.EXPECT().DeleteAndWait(gomock.Any(), rgName, oldVMSS).Return(nil)
+			},
+		},
+		{
+			name: "error rate breached, new scaleset removed from load balancers",
+			mocks: func(vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient, metrics *mock_insights.MockMetricsClient) {
+				vmss.EXPECT().List(gomock.Any(), rgName).Return([]mgmtcompute.VirtualMachineScaleSet{{Name: to.StringPtr(oldVMSS)}, {Name: to.StringPtr(newVMSS)}}, nil)
+				vmssvms.EXPECT().List(gomock.Any(), rgName, newVMSS, "", "", "").Return(vms, nil).Times(2)
+				healthy(vmssvms)
+				metrics.EXPECT().List(gomock.Any(), "metrics", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(frontendCountResponse(10, 100), nil)
+				vmss.EXPECT().Get(gomock.Any(), rgName, newVMSS).Return(scaleset, nil)
+				vmss.EXPECT().UpdateAndWait(gomock.Any(), rgName, newVMSS, removeFromLoadBalancers).Return(nil)
+				vmss.EXPECT().UpdateInstancesAndWait(gomock.Any(), rgName, newVMSS, []string{"0"}).Return(nil)
+				vmss.EXPECT().DeleteAndWait(gomock.Any(), rgName, newVMSS).Return(nil)
+			},
+			wantErr: "canary failed at 50%: 10 of 100 frontend requests failed",
+		},
+		{
+			name: "error rate breached, load balancers cannot be changed",
+			mocks: func(vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient, metrics *mock_insights.MockMetricsClient) {
+				vmss.EXPECT().List(gomock.Any(), rgName).Return([]mgmtcompute.VirtualMachineScaleSet{{Name: to.StringPtr(oldVMSS)}, {Name: to.StringPtr(newVMSS)}}, nil)
+				vmssvms.EXPECT().List(gomock.Any(), rgName, newVMSS, "", "", "").Return(vms, nil).Times(2)
+				healthy(vmssvms)
+				metrics.EXPECT().List(gomock.Any(), "metrics", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(frontendCountResponse(10, 100), nil)
+				vmss.EXPECT().Get(gomock.Any(), rgName, newVMSS).Return(scaleset, nil)
+				vmss.EXPECT().UpdateAndWait(gomock.Any(), rgName, newVMSS, removeFromLoadBalancers).Return(errors.New("invalid"))
+				vmssvms.EXPECT().RunCommandAndWait(gomock.Any(), rgName, newVMSS, "0", gomock.Any()).Return(nil)
+				vmss.EXPECT().DeleteAndWait(gomock.Any(), rgName, newVMSS).Return(nil)
+			},
+			wantErr: "canary failed at 50%: 10 of 100 frontend requests failed",
+		},
+		{
+			name: "too few requests to judge",
+			mocks: func(vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient, metrics *mock_insights.MockMetricsClient) {
+				vmss.EXPECT().List(gomock.Any(), rgName).Return([]mgmtcompute.VirtualMachineScaleSet{{Name: to.StringPtr(oldVMSS)}, {Name: to.StringPtr(newVMSS)}}, nil).Times(2)
+				vmssvms.EXPECT().List(gomock.Any(), rgName, newVMSS, "", "", "").Return(vms, nil).Times(2)
+				healthy(vmssvms)
+				metrics.EXPECT().List(gomock.Any(), "metrics", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(frontendCountResponse(5, 5), nil).AnyTimes()
+				vmss.EXPECT().UpdateAndWait(gomock.Any(), rgName, newVMSS, scaleTo(4)).Return(nil)
+				vmssvms.EXPECT().List(gomock.Any(), rgName, oldVMSS, "", "", "").Return(nil, nil)
+				vmss.EXPECT().DeleteAndWait(gomock.Any(), rgName, oldVMSS).Return(nil)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			vmss := mock_compute.NewMockVirtualMachineScaleSetsClient(controller)
+			vmssvms := mock_compute.NewMockVirtualMachineScaleSetVMsClient(controller)
+			metrics := mock_insights.NewMockMetricsClient(controller)
+			tt.mocks(vmss, vmssvms, metrics)
+
+			d := deployer{
+				log:     logrus.NewEntry(logrus.StandardLogger()),
+				vmss:    vmss,
+				vmssvms: vmssvms,
+				metrics: metrics,
+				config: &RPConfig{
+					RPResourceGroupName: rgName,
+					Configuration: &Configuration{
+						RPVMSSCapacity: to.IntPtr(4),
+						RPCanary: &RPCanaryConfiguration{
+							Steps:               []int{50, 100},
+							BakeMinutes:         1,
+							MaxErrorRatePercent: 5,
+							MinRequests:         10,
+							MetricsResourceID:   "metrics",
+							MetricsNamespace:    "namespace",
+						},
+					},
+				},
+				version: version,
+			}
+
+			err := d.UpgradeRP(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRPCapacity(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		capacity *int
+		percent  int
+		want     int64
+	}{
+		{
+			name:    "default capacity",
+			percent: 100,
+			want:    3,
+		},
+		{
+			name:     "rounds up",
+			capacity: to.IntPtr(10),
+			percent:  25,
+			want:     3,
+		},
+		{
+			name:     "at least one instance",
+			capacity: to.IntPtr(3),
+			percent:  1,
+			want:     1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d := deployer{
+				config: &RPConfig{
+					Configuration: &Configuration{
+						RPVMSSCapacity: tt.capacity,
+					},
+				},
+			}
+
+			got := d.rpCapacity(tt.percent)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
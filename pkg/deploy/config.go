@@ -21,8 +21,9 @@ import (
 
 // Config represents configuration object for deployer tooling
 type Config struct {
-	RPs           []RPConfig     `json:"rps,omitempty"`
-	Configuration *Configuration `json:"configuration,omitempty"`
+	RPs           []RPConfig            `json:"rps,omitempty"`
+	Configuration *Configuration        `json:"configuration,omitempty"`
+	Rollout       *RolloutConfiguration `json:"rollout,omitempty"`
 }
 
 // RPConfig represents individual RP configuration
@@ -50,10 +51,12 @@ type Configuration struct {
 	ARMClientID                        *string                `json:"armClientId,omitempty"`
 	BillingE2EStorageAccountID         *string                `json:"billingE2EStorageAccountId,omitempty"`
 	BillingServicePrincipalID          *string                `json:"billingServicePrincipalId,omitempty"`
+	CanaryClusterResourceID            *string                `json:"canaryClusterResourceId,omitempty"`
 	ClusterMDMAccount                  *string                `json:"clusterMdmAccount,omitempty" value:"required"`
 	ClusterMDSDAccount                 *string                `json:"clusterMdsdAccount,omitempty" value:"required"`
 	ClusterMDSDConfigVersion           *string                `json:"clusterMdsdConfigVersion,omitempty" value:"required"`
 	ClusterMDSDNamespace               *string                `json:"clusterMdsdNamespace,omitempty" value:"required"`
+	ClusterMonitorCollectors           *string                `json:"clusterMonitorCollectors,omitempty"`
 	ClusterParentDomainName            *string                `json:"clusterParentDomainName,omitempty" value:"required"`
 	DatabaseAccountName                *string                `json:"databaseAccountName,omitempty" value:"required"`
 	CosmosDB                           *CosmosDBConfiguration `json:"cosmosDB,omitempty"`
@@ -69,6 +72,7 @@ type Configuration struct {
 	FPClientID                         *string                `json:"fpClientId,omitempty" value:"required"`
 	FPServerCertCommonName             *string                `json:"fpServerCertCommonName,omitempty"`
 	FPServicePrincipalID               *string                `json:"fpServicePrincipalId,omitempty" value:"required"`
+	GatewayDiagnosticsClientID         *string                `json:"gatewayDiagnosticsClientId,omitempty"`
 	GatewayDomains                     []string               `json:"gatewayDomains,omitempty"`
 	GatewayFeatures                    []string               `json:"gatewayFeatures,omitempty"`
 	GatewayMDSDConfigVersion           *string                `json:"gatewayMdsdConfigVersion,omitempty" value:"required"`
@@ -94,7 +98,9 @@ type Configuration struct {
 	RPMDSDNamespace                    *string                `json:"rpMdsdNamespace,omitempty" value:"required"`
 	RPNSGPortalSourceAddressPrefixes   []string               `json:"rpNsgPortalSourceAddressPrefixes,omitempty"`
 	RPParentDomainName                 *string                `json:"rpParentDomainName,omitempty" value:"required"`
+	RPCanary                           *RPCanaryConfiguration `json:"rpCanary,omitempty"`
 	RPVMSSCapacity                     *int                   `json:"rpVmssCapacity,omitempty"`
+	ServiceAuthMode                    *string                `json:"serviceAuthMode,omitempty"`
 	SSHPublicKey                       *string                `json:"sshPublicKey,omitempty"`
 	StorageAccountDomain               *string                `json:"storageAccountDomain,omitempty" value:"required"`
 	SubscriptionResourceGroupName      *string                `json:"subscriptionResourceGroupName,omitempty" value:"required"`
@@ -116,8 +122,45 @@ type CosmosDBConfiguration struct {
 	GatewayProvisionedThroughput  int `json:"gatewayProvisionedThroughput,omitempty"`
 }
 
-// GetConfig return RP configuration from the file
-func GetConfig(path, location string) (*RPConfig, error) {
+// RPCanaryConfiguration enables canary upgrades of the RP.  If it is
+// provided, the new RP scaleset is deployed alongside the old one with a
+// fraction of its capacity and scaled up in steps.  After each step, the new
+// scaleset's frontend error rate is watched for the bake period, and if it
+// exceeds the threshold the new scaleset is removed from the load balancer
+// and deleted, leaving the old one serving.
+type RPCanaryConfiguration struct {
+	// Steps are the percentages of the RP scaleset capacity to which the new
+	// scaleset is scaled in turn, e.g. [10, 50, 100]
+	Steps []int `json:"steps,omitempty"`
+	// BakeMinutes is the time for which the error rate is watched after
+	// each step
+	BakeMinutes int `json:"bakeMinutes,omitempty"`
+	// MaxErrorRatePercent is the highest percentage of frontend requests
+	// served by the new scaleset which may fail with a server error
+	MaxErrorRatePercent float64 `json:"maxErrorRatePercent,omitempty"`
+	// MinRequests is the number of requests which the new scaleset must
+	// serve in a window before its error rate is considered
+	MinRequests float64 `json:"minRequests,omitempty"`
+	// MetricsResourceID and MetricsNamespace locate the RP frontend metrics
+	// in Azure Monitor
+	MetricsResourceID string `json:"metricsResourceId,omitempty"`
+	MetricsNamespace  string `json:"metricsNamespace,omitempty"`
+}
+
+// RolloutConfiguration controls the order in which a release is rolled out
+// across the regions of the fleet.  The canary regions are deployed first,
+// one at a time; the remaining regions follow in the order in which they are
+// listed in rps, at most MaxParallelRegions at a time.
+type RolloutConfiguration struct {
+	CanaryRegions      []string `json:"canaryRegions,omitempty"`
+	MaxParallelRegions int      `json:"maxParallelRegions,omitempty"`
+	// BakeMinutes is the time for which the deploy tooling waits after each
+	// stage before starting the next
+	BakeMinutes int `json:"bakeMinutes,omitempty"`
+}
+
+// GetFleetConfig returns the configuration of every RP from the file
+func GetFleetConfig(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -129,6 +172,16 @@ func GetConfig(path, location string) (*RPConfig, error) {
 		return nil, err
 	}
 
+	return config, nil
+}
+
+// GetConfig return RP configuration from the file
+func GetConfig(path, location string) (*RPConfig, error) {
+	config, err := GetFleetConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, c := range config.RPs {
 		if c.Location == location {
 			configuration, err := mergeConfig(c.Configuration, config.Configuration)
@@ -187,9 +240,39 @@ func (conf *RPConfig) validate() error {
 		}
 	}
 
-	if len(missingFields) == 0 {
-		return nil
+	if len(missingFields) > 0 {
+		return fmt.Errorf("configuration has missing fields: %s", strings.Join(missingFields, ","))
+	}
+
+	if configuration.RPCanary != nil {
+		return configuration.RPCanary.validate()
+	}
+
+	return nil
+}
+
+func (c *RPCanaryConfiguration) validate() error {
+	if len(c.Steps) == 0 || c.Steps[len(c.Steps)-1] != 100 {
+		return fmt.Errorf("rpCanary steps must end at 100")
+	}
+
+	for i, step := range c.Steps {
+		if step <= 0 || step > 100 || (i > 0 && step <= c.Steps[i-1]) {
+			return fmt.Errorf("rpCanary steps must be increasing percentages")
+		}
+	}
+
+	if c.BakeMinutes <= 0 {
+		return fmt.Errorf("rpCanary bakeMinutes must be positive")
+	}
+
+	if c.MaxErrorRatePercent <= 0 {
+		return fmt.Errorf("rpCanary maxErrorRatePercent must be positive")
+	}
+
+	if c.MetricsResourceID == "" || c.MetricsNamespace == "" {
+		return fmt.Errorf("rpCanary metricsResourceId and metricsNamespace are required")
 	}
 
-	return fmt.Errorf("configuration has missing fields: %s", strings.Join(missingFields, ","))
+	return nil
 }
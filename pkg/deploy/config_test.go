@@ -113,3 +113,86 @@ func TestConfigNilable(t *testing.T) {
 		val.Field(i).IsNil()
 	}
 }
+
+func TestRPCanaryConfigurationValidate(t *testing.T) {
+	valid := func() *RPCanaryConfiguration {
+		return &RPCanaryConfiguration{
+			Steps:               []int{10, 50, 100},
+			BakeMinutes:         15,
+			MaxErrorRatePercent: 1,
+			MetricsResourceID:   "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Insights/components/rp",
+			MetricsNamespace:    "rp",
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mutate  func(*RPCanaryConfiguration)
+		wantErr string
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name: "no steps",
+			mutate: func(c *RPCanaryConfiguration) {
+				c.Steps = nil
+			},
+			wantErr: "rpCanary steps must end at 100",
+		},
+		{
+			name: "steps do not end at 100",
+			mutate: func(c *RPCanaryConfiguration) {
+				c.Steps = []int{10, 50}
+			},
+			wantErr: "rpCanary steps must end at 100",
+		},
+		{
+			name: "steps not increasing",
+			mutate: func(c *RPCanaryConfiguration) {
+				c.Steps = []int{50, 10, 100}
+			},
+			wantErr: "rpCanary steps must be increasing percentages",
+		},
+		{
+			name: "zero step",
+			mutate: func(c *RPCanaryConfiguration) {
+				c.Steps = []int{0, 100}
+			},
+			wantErr: "rpCanary steps must be increasing percentages",
+		},
+		{
+			name: "no bake time",
+			mutate: func(c *RPCanaryConfiguration) {
+				c.BakeMinutes = 0
+			},
+			wantErr: "rpCanary bakeMinutes must be positive",
+		},
+		{
+			name: "no error rate",
+			mutate: func(c *RPCanaryConfiguration) {
+				c.MaxErrorRatePercent = 0
+			},
+			wantErr: "rpCanary maxErrorRatePercent must be positive",
+		},
+		{
+			name: "no metrics resource",
+			mutate: func(c *RPCanaryConfiguration) {
+				c.MetricsResourceID = ""
+			},
+			wantErr: "rpCanary metricsResourceId and metricsNamespace are required",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			if tt.mutate != nil {
+				tt.mutate(c)
+			}
+
+			err := c.validate()
+			if err == nil && tt.wantErr != "" || err != nil && err.Error() != tt.wantErr {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/dns"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/insights"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/msi"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/storage"
@@ -37,6 +38,7 @@ type Deployer interface {
 	UpgradeRP(context.Context) error
 	UpgradeGateway(context.Context) error
 	SaveVersion(context.Context) error
+	SaveDeploymentStatus(context.Context, *DeploymentStatus) error
 }
 
 type deployer struct {
@@ -50,6 +52,7 @@ type deployer struct {
 	deployments            features.DeploymentsClient
 	groups                 features.ResourceGroupsClient
 	loadbalancers          network.LoadBalancersClient
+	metrics                insights.MetricsClient
 	userassignedidentities msi.UserAssignedIdentitiesClient
 	providers              features.ProvidersClient
 	publicipaddresses      network.PublicIPAddressesClient
@@ -102,6 +105,7 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Core, config *RPConfig
 		deployments:            features.NewDeploymentsClient(_env.Environment(), config.SubscriptionID, authorizer),
 		groups:                 features.NewResourceGroupsClient(_env.Environment(), config.SubscriptionID, authorizer),
 		loadbalancers:          network.NewLoadBalancersClient(_env.Environment(), config.SubscriptionID, authorizer),
+		metrics:                insights.NewMetricsClient(_env.Environment(), config.SubscriptionID, authorizer),
 		userassignedidentities: msi.NewUserAssignedIdentitiesClient(_env.Environment(), config.SubscriptionID, authorizer),
 		providers:              features.NewProvidersClient(_env.Environment(), config.SubscriptionID, authorizer),
 		roleassignments:        authorization.NewRoleAssignmentsClient(_env.Environment(), config.SubscriptionID, authorizer),
@@ -77,6 +77,20 @@ func (d *deployer) DeployRP(ctx context.Context) error {
 	parameters.Parameters["azureCloudName"] = &arm.ParametersParameter{
 		Value: d.env.Environment().ActualCloudName,
 	}
+	if d.config.Configuration.RPCanary != nil {
+		// a canary upgrade starts the new scaleset at the capacity of its
+		// first step, unless there is no old scaleset to fall back to
+		oldScalesets, err := d.rpOldScalesets(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(oldScalesets) > 0 {
+			parameters.Parameters["rpVmssCapacity"] = &arm.ParametersParameter{
+				Value: d.rpCapacity(d.config.Configuration.RPCanary.Steps[0]),
+			}
+		}
+	}
 	if d.config.Configuration.CosmosDB != nil {
 		parameters.Parameters["cosmosDB"] = &arm.ParametersParameter{
 			Value: map[string]int{
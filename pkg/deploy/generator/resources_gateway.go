@@ -205,6 +205,7 @@ func (g *generator) gatewayVMSS() *arm.Resource {
 		"mdsdEnvironment",
 		"fluentbitImage",
 		"gatewayMdsdConfigVersion",
+		"gatewayDiagnosticsClientId",
 		"gatewayDomains",
 		"gatewayFeatures",
 		"keyvaultDNSSuffix",
@@ -213,6 +214,8 @@ func (g *generator) gatewayVMSS() *arm.Resource {
 		"rpMdmAccount",
 		"rpMdsdAccount",
 		"rpMdsdNamespace",
+		"rpServicePrincipalId",
+		"serviceAuthMode",
 	} {
 		parts = append(parts,
 			fmt.Sprintf("'%s=$(base64 -d <<<'''", strings.ToUpper(variable)),
@@ -417,6 +420,13 @@ func (g *generator) gatewayRBAC() []*arm.Resource {
 			"Microsoft.Network/privateLinkServices",
 			"'gateway-pls-001'",
 		),
+		// the RP finds the gateway instances to query for diagnostics
+		rbac.ResourceGroupRoleAssignmentWithName(
+			rbac.RoleReader,
+			"parameters('rpServicePrincipalId')",
+			"guid(resourceGroup().id, parameters('rpServicePrincipalId'), 'RP / Reader')",
+			"[not(empty(parameters('gatewayDiagnosticsClientId')))]",
+		),
 	}
 }
 
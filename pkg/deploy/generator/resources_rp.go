@@ -458,16 +458,19 @@ func (g *generator) rpVMSS() *arm.Resource {
 		"azureSecPackQualysUrl",
 		"azureSecPackVSATenantId",
 		"billingE2EStorageAccountId",
+		"canaryClusterResourceId",
 		"clusterMdmAccount",
 		"clusterMdsdAccount",
 		"clusterMdsdConfigVersion",
 		"clusterMdsdNamespace",
+		"clusterMonitorCollectors",
 		"clusterParentDomainName",
 		"databaseAccountName",
 		"dbtokenClientId",
 		"fluentbitImage",
 		"fpClientId",
 		"fpServicePrincipalId",
+		"gatewayDiagnosticsClientId",
 		"gatewayDomains",
 		"gatewayResourceGroupName",
 		"gatewayServicePrincipalId",
@@ -485,6 +488,7 @@ func (g *generator) rpVMSS() *arm.Resource {
 		"rpMdsdConfigVersion",
 		"rpMdsdNamespace",
 		"rpParentDomainName",
+		"serviceAuthMode",
 
 		// TODO: Replace with Live Service Configuration in KeyVault
 		"clustersInstallViaHive",
@@ -1031,11 +1035,37 @@ func (g *generator) rpCosmosDB() []*arm.Resource {
 	if g.production {
 		rs = append(rs, g.database("'ARO'", true)...)
 		rs = append(rs, g.rpCosmosDBAlert(10, 90, 3, "rp-cosmosdb-alert", "PT5M", "PT1H"))
+		rs = append(rs,
+			g.rpCosmosDBDataContributor("rpServicePrincipalId", "RP"),
+			g.rpCosmosDBDataContributor("gatewayServicePrincipalId", "Gateway"),
+		)
 	}
 
 	return rs
 }
 
+// rpCosmosDBDataContributor grants the Cosmos DB Built-in Data Contributor role
+// on the database account to the principal in the given parameter, for the
+// services to use their managed identities in the ManagedIdentity auth mode
+func (g *generator) rpCosmosDBDataContributor(principalIDParameter, description string) *arm.Resource {
+	return &arm.Resource{
+		Resource: &sdkcosmos.SQLRoleAssignmentCreateUpdateParameters{
+			Properties: &sdkcosmos.SQLRoleAssignmentResource{
+				PrincipalID:      to.StringPtr("[parameters('" + principalIDParameter + "')]"),
+				RoleDefinitionID: to.StringPtr("[resourceId('Microsoft.DocumentDB/databaseAccounts/sqlRoleDefinitions', parameters('databaseAccountName'), '00000000-0000-0000-0000-000000000002')]"),
+				Scope:            to.StringPtr("[resourceId('Microsoft.DocumentDB/databaseAccounts', parameters('databaseAccountName'))]"),
+			},
+		},
+		Name:       "[concat(parameters('databaseAccountName'), '/', guid(resourceId('Microsoft.DocumentDB/databaseAccounts', parameters('databaseAccountName')), parameters('" + principalIDParameter + "'), '" + description + " / Cosmos DB Built-in Data Contributor'))]",
+		Type:       "Microsoft.DocumentDB/databaseAccounts/sqlRoleAssignments",
+		Condition:  "[equals(parameters('serviceAuthMode'), 'ManagedIdentity')]",
+		APIVersion: azureclient.APIVersion("Microsoft.DocumentDB"),
+		DependsOn: []string{
+			"[resourceId('Microsoft.DocumentDB/databaseAccounts', parameters('databaseAccountName'))]",
+		},
+	}
+}
+
 func (g *generator) database(databaseName string, addDependsOn bool) []*arm.Resource {
 	database := &arm.Resource{
 		Resource: &sdkcosmos.SQLDatabaseCreateUpdateParameters{
@@ -1173,6 +1203,56 @@ func (g *generator) database(databaseName string, addDependsOn bool) []*arm.Reso
 			},
 			Type: "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
 		},
+		{
+			Resource: &sdkcosmos.SQLContainerCreateUpdateParameters{
+				Properties: &sdkcosmos.SQLContainerCreateUpdateProperties{
+					Resource: &sdkcosmos.SQLContainerResource{
+						ID: to.StringPtr("RPFeatures"),
+						PartitionKey: &sdkcosmos.ContainerPartitionKey{
+							Paths: []*string{
+								to.StringPtr("/id"),
+							},
+							Kind: &hashPartitionKey,
+						},
+						DefaultTTL: to.Int32Ptr(-1),
+					},
+					Options: &sdkcosmos.CreateUpdateOptions{},
+				},
+				Name:     to.StringPtr("[concat(parameters('databaseAccountName'), '/', " + databaseName + ", '/RPFeatures')]"),
+				Type:     to.StringPtr("Microsoft.DocumentDB/databaseAccounts/sqlDatabases/containers"),
+				Location: to.StringPtr("[resourceGroup().location]"),
+			},
+			APIVersion: azureclient.APIVersion("Microsoft.DocumentDB"),
+			DependsOn: []string{
+				"[resourceId('Microsoft.DocumentDB/databaseAccounts/sqlDatabases', parameters('databaseAccountName'), " + databaseName + ")]",
+			},
+			Type: "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
+		},
+		{
+			Resource: &sdkcosmos.SQLContainerCreateUpdateParameters{
+				Properties: &sdkcosmos.SQLContainerCreateUpdateProperties{
+					Resource: &sdkcosmos.SQLContainerResource{
+						ID: to.StringPtr("MirroredImages"),
+						PartitionKey: &sdkcosmos.ContainerPartitionKey{
+							Paths: []*string{
+								to.StringPtr("/id"),
+							},
+							Kind: &hashPartitionKey,
+						},
+						DefaultTTL: to.Int32Ptr(-1),
+					},
+					Options: &sdkcosmos.CreateUpdateOptions{},
+				},
+				Name:     to.StringPtr("[concat(parameters('databaseAccountName'), '/', " + databaseName + ", '/MirroredImages')]"),
+				Type:     to.StringPtr("Microsoft.DocumentDB/databaseAccounts/sqlDatabases/containers"),
+				Location: to.StringPtr("[resourceGroup().location]"),
+			},
+			APIVersion: azureclient.APIVersion("Microsoft.DocumentDB"),
+			DependsOn: []string{
+				"[resourceId('Microsoft.DocumentDB/databaseAccounts/sqlDatabases', parameters('databaseAccountName'), " + databaseName + ")]",
+			},
+			Type: "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
+		},
 		{
 			Resource: &sdkcosmos.SQLContainerCreateUpdateParameters{
 				Properties: &sdkcosmos.SQLContainerCreateUpdateProperties{
@@ -1291,6 +1371,31 @@ func (g *generator) database(databaseName string, addDependsOn bool) []*arm.Reso
 			Type: "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
 		},
 		portal,
+		{
+			Resource: &sdkcosmos.SQLContainerCreateUpdateParameters{
+				Properties: &sdkcosmos.SQLContainerCreateUpdateProperties{
+					Resource: &sdkcosmos.SQLContainerResource{
+						ID: to.StringPtr("PortalSessions"),
+						PartitionKey: &sdkcosmos.ContainerPartitionKey{
+							Paths: []*string{
+								to.StringPtr("/partitionKey"),
+							},
+							Kind: &hashPartitionKey,
+						},
+						DefaultTTL: to.Int32Ptr(90 * 86400), // 90 days
+					},
+					Options: &sdkcosmos.CreateUpdateOptions{},
+				},
+				Name:     to.StringPtr("[concat(parameters('databaseAccountName'), '/', " + databaseName + ", '/PortalSessions')]"),
+				Type:     to.StringPtr("Microsoft.DocumentDB/databaseAccounts/sqlDatabases/containers"),
+				Location: to.StringPtr("[resourceGroup().location]"),
+			},
+			APIVersion: azureclient.APIVersion("Microsoft.DocumentDB"),
+			DependsOn: []string{
+				"[resourceId('Microsoft.DocumentDB/databaseAccounts/sqlDatabases', parameters('databaseAccountName'), " + databaseName + ")]",
+			},
+			Type: "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
+		},
 		{
 			Resource: &sdkcosmos.SQLContainerCreateUpdateParameters{
 				Properties: &sdkcosmos.SQLContainerCreateUpdateProperties{
@@ -1544,6 +1649,19 @@ func (g *generator) rpVersionStorageAccount() []*arm.Resource {
 				"[resourceId('Microsoft.Storage/storageAccounts', parameters('rpVersionStorageAccountName'))]",
 			},
 		},
+		{
+			Resource: &mgmtstorage.BlobContainer{
+				Name: to.StringPtr("[concat(parameters('rpVersionStorageAccountName'), '/default/rolloutstatus')]"),
+				Type: to.StringPtr("Microsoft.Storage/storageAccounts/blobServices/containers"),
+				ContainerProperties: &mgmtstorage.ContainerProperties{
+					PublicAccess: mgmtstorage.PublicAccessNone,
+				},
+			},
+			APIVersion: azureclient.APIVersion("Microsoft.Storage"),
+			DependsOn: []string{
+				"[resourceId('Microsoft.Storage/storageAccounts', parameters('rpVersionStorageAccountName'))]",
+			},
+		},
 	}
 }
 
@@ -269,9 +269,12 @@ AZURE_DBTOKEN_CLIENT_ID='$DBTOKENCLIENTID'
 DBTOKEN_URL='$DBTOKENURL'
 MDM_ACCOUNT="$RPMDMACCOUNT"
 MDM_NAMESPACE=Gateway
+GATEWAY_DIAGNOSTICS_CLIENT_ID='$GATEWAYDIAGNOSTICSCLIENTID'
+GATEWAY_DIAGNOSTICS_SUBJECTS='$RPSERVICEPRINCIPALID'
 GATEWAY_DOMAINS='$GATEWAYDOMAINS'
 GATEWAY_FEATURES='$GATEWAYFEATURES'
 RPIMAGE='$RPIMAGE'
+SERVICE_AUTH_MODE='$SERVICEAUTHMODE'
 EOF
 
 cat >/etc/systemd/system/aro-gateway.service <<EOF
@@ -292,10 +295,13 @@ ExecStart=/usr/bin/docker run \
   -e DATABASE_ACCOUNT_NAME \
   -e AZURE_DBTOKEN_CLIENT_ID \
   -e DBTOKEN_URL \
+  -e GATEWAY_DIAGNOSTICS_CLIENT_ID \
+  -e GATEWAY_DIAGNOSTICS_SUBJECTS \
   -e GATEWAY_DOMAINS \
   -e GATEWAY_FEATURES \
   -e MDM_ACCOUNT \
   -e MDM_NAMESPACE \
+  -e SERVICE_AUTH_MODE \
   -m 2g \
   -p 80:8080 \
   -p 8081:8081 \
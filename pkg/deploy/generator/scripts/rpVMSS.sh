@@ -280,6 +280,7 @@ CLUSTER_MDSD_CONFIG_VERSION='$CLUSTERMDSDCONFIGVERSION'
 CLUSTER_MDSD_NAMESPACE='$CLUSTERMDSDNAMESPACE'
 DATABASE_ACCOUNT_NAME='$DATABASEACCOUNTNAME'
 DOMAIN_NAME='$LOCATION.$CLUSTERPARENTDOMAINNAME'
+GATEWAY_DIAGNOSTICS_CLIENT_ID='$GATEWAYDIAGNOSTICSCLIENTID'
 GATEWAY_DOMAINS='$GATEWAYDOMAINS'
 GATEWAY_RESOURCEGROUP='$GATEWAYRESOURCEGROUPNAME'
 KEYVAULT_PREFIX='$KEYVAULTPREFIX'
@@ -288,6 +289,7 @@ MDM_NAMESPACE=RP
 MDSD_ENVIRONMENT='$MDSDENVIRONMENT'
 RP_FEATURES='$RPFEATURES'
 RPIMAGE='$RPIMAGE'
+SERVICE_AUTH_MODE='$SERVICEAUTHMODE'
 ARO_INSTALL_VIA_HIVE='$CLUSTERSINSTALLVIAHIVE'
 ARO_HIVE_DEFAULT_INSTALLER_PULLSPEC='$CLUSTERDEFAULTINSTALLERPULLSPEC'
 ARO_ADOPT_BY_HIVE='$CLUSTERSADOPTBYHIVE'
@@ -320,6 +322,7 @@ ExecStart=/usr/bin/docker run \
   -e CLUSTER_MDSD_NAMESPACE \
   -e DATABASE_ACCOUNT_NAME \
   -e DOMAIN_NAME \
+  -e GATEWAY_DIAGNOSTICS_CLIENT_ID \
   -e GATEWAY_DOMAINS \
   -e GATEWAY_RESOURCEGROUP \
   -e KEYVAULT_PREFIX \
@@ -327,6 +330,7 @@ ExecStart=/usr/bin/docker run \
   -e MDM_NAMESPACE \
   -e MDSD_ENVIRONMENT \
   -e RP_FEATURES \
+  -e SERVICE_AUTH_MODE \
   -e ARO_INSTALL_VIA_HIVE \
   -e ARO_HIVE_DEFAULT_INSTALLER_PULLSPEC \
   -e ARO_ADOPT_BY_HIVE \
@@ -400,6 +404,7 @@ echo "configuring aro-monitor service"
 cat >/etc/sysconfig/aro-monitor <<EOF
 AZURE_FP_CLIENT_ID='$FPCLIENTID'
 DOMAIN_NAME='$LOCATION.$CLUSTERPARENTDOMAINNAME'
+CANARY_CLUSTER_RESOURCE_ID='$CANARYCLUSTERRESOURCEID'
 CLUSTER_MDSD_ACCOUNT='$CLUSTERMDSDACCOUNT'
 CLUSTER_MDSD_CONFIG_VERSION='$CLUSTERMDSDCONFIGVERSION'
 GATEWAY_DOMAINS='$GATEWAYDOMAINS'
@@ -408,11 +413,13 @@ MDSD_ENVIRONMENT='$MDSDENVIRONMENT'
 CLUSTER_MDSD_NAMESPACE='$CLUSTERMDSDNAMESPACE'
 CLUSTER_MDM_ACCOUNT='$CLUSTERMDMACCOUNT'
 CLUSTER_MDM_NAMESPACE=BBM
+CLUSTER_MONITOR_COLLECTORS='$CLUSTERMONITORCOLLECTORS'
 DATABASE_ACCOUNT_NAME='$DATABASEACCOUNTNAME'
 KEYVAULT_PREFIX='$KEYVAULTPREFIX'
 MDM_ACCOUNT='$RPMDMACCOUNT'
 MDM_NAMESPACE=BBM
 RPIMAGE='$RPIMAGE'
+SERVICE_AUTH_MODE='$SERVICEAUTHMODE'
 EOF
 
 cat >/etc/systemd/system/aro-monitor.service <<'EOF'
@@ -430,6 +437,7 @@ ExecStart=/usr/bin/docker run \
   --cap-drop net_raw \
   -e AZURE_FP_CLIENT_ID \
   -e DOMAIN_NAME \
+  -e CANARY_CLUSTER_RESOURCE_ID \
   -e CLUSTER_MDSD_ACCOUNT \
   -e CLUSTER_MDSD_CONFIG_VERSION \
   -e GATEWAY_DOMAINS \
@@ -438,10 +446,12 @@ ExecStart=/usr/bin/docker run \
   -e CLUSTER_MDSD_NAMESPACE \
   -e CLUSTER_MDM_ACCOUNT \
   -e CLUSTER_MDM_NAMESPACE \
+  -e CLUSTER_MONITOR_COLLECTORS \
   -e DATABASE_ACCOUNT_NAME \
   -e KEYVAULT_PREFIX \
   -e MDM_ACCOUNT \
   -e MDM_NAMESPACE \
+  -e SERVICE_AUTH_MODE \
   -m 2.5g \
   -v /run/systemd/journal:/run/systemd/journal \
   -v /var/etw:/var/etw:z \
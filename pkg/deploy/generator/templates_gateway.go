@@ -30,6 +30,7 @@ func (g *generator) gatewayTemplate() *arm.Template {
 		"dbtokenClientId",
 		"dbtokenUrl",
 		"fluentbitImage",
+		"gatewayDiagnosticsClientId",
 		"gatewayDomains",
 		"gatewayFeatures",
 		"gatewayMdsdConfigVersion",
@@ -48,6 +49,7 @@ func (g *generator) gatewayTemplate() *arm.Template {
 		"rpMdsdNamespace",
 		"rpResourceGroupName",
 		"rpServicePrincipalId",
+		"serviceAuthMode",
 		"sshPublicKey",
 		"vmssCleanupEnabled",
 		"vmssName",
@@ -56,8 +58,10 @@ func (g *generator) gatewayTemplate() *arm.Template {
 	for _, param := range params {
 		p := &arm.TemplateParameter{Type: "string"}
 		switch param {
-		case "gatewayDomains",
-			"gatewayFeatures":
+		case "gatewayDiagnosticsClientId",
+			"gatewayDomains",
+			"gatewayFeatures",
+			"serviceAuthMode":
 			p.DefaultValue = ""
 		case "gatewayVmSize":
 			p.DefaultValue = "Standard_D4s_v3"
@@ -43,10 +43,12 @@ func (g *generator) rpTemplate() *arm.Template {
 			"azureSecPackVSATenantId",
 			"billingE2EStorageAccountId",
 			"billingServicePrincipalId",
+			"canaryClusterResourceId",
 			"clusterMdmAccount",
 			"clusterMdsdAccount",
 			"clusterMdsdConfigVersion",
 			"clusterMdsdNamespace",
+			"clusterMonitorCollectors",
 			"cosmosDB",
 			"dbtokenClientId",
 			"disableCosmosDBFirewall",
@@ -56,6 +58,7 @@ func (g *generator) rpTemplate() *arm.Template {
 			"ipRules",
 			"keyvaultPrefix",
 			"keyvaultDNSSuffix",
+			"gatewayDiagnosticsClientId",
 			"gatewayDomains",
 			"gatewayResourceGroupName",
 			"gatewayServicePrincipalId",
@@ -74,6 +77,7 @@ func (g *generator) rpTemplate() *arm.Template {
 			"rpMdsdNamespace",
 			"rpParentDomainName",
 			"rpVmssCapacity",
+			"serviceAuthMode",
 			"sshPublicKey",
 			"storageAccountDomain",
 			"subscriptionResourceGroupName",
@@ -102,8 +106,12 @@ func (g *generator) rpTemplate() *arm.Template {
 			"armClientId",
 			"billingServicePrincipalId",
 			"billingE2EStorageAccountId",
+			"canaryClusterResourceId",
+			"clusterMonitorCollectors",
+			"gatewayDiagnosticsClientId",
 			"gatewayDomains",
-			"rpFeatures":
+			"rpFeatures",
+			"serviceAuthMode":
 			p.DefaultValue = ""
 		case "vmSize":
 			p.DefaultValue = "Standard_D2s_v3"
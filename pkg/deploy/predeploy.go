@@ -399,6 +399,13 @@ func (d *deployer) configureServiceSecrets(ctx context.Context) error {
 		return err
 	}
 
+	// fail before restarting or deploying any VMs which would not start with
+	// the secrets as they are
+	err = d.validateServiceSecrets(ctx)
+	if err != nil {
+		return err
+	}
+
 	if isRotated {
 		err = d.restartOldScalesets(ctx)
 		if err != nil {
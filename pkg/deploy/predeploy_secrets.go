@@ -0,0 +1,146 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+)
+
+const (
+	// Certificates which expire sooner than this fail validation, so that they
+	// are rotated before VMs which depend on them are rolled out
+	minCertificateValidity = 72 * time.Hour
+	minRSAKeyBits          = 2048
+)
+
+type secretKind int
+
+const (
+	// secretKindCertificate is a PEM encoded RSA private key and certificate
+	// chain
+	secretKindCertificate secretKind = iota
+	// secretKindRSAKey is a base64 encoded PKCS#1 RSA private key
+	secretKindRSAKey
+)
+
+type secretCheck struct {
+	kv         keyvault.Manager
+	vault      string
+	secretName string
+	kind       secretKind
+	// latest is set for certificates which the RP reads with
+	// GetLatestCertificateSecret, so that a staged version which is not yet
+	// valid is skipped as it is by the RP
+	latest bool
+}
+
+// validateServiceSecrets checks that every secret and certificate the RP
+// services expect exists, is of the expected key type and, for certificates,
+// is currently valid.  All problems are reported in a single error so that
+// they can be fixed at once.
+func (d *deployer) validateServiceSecrets(ctx context.Context) error {
+	var problems []string
+	for _, c := range d.secretChecks() {
+		err := d.validateSecret(ctx, c)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s/%s: %v", c.vault, c.secretName, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("keyvault secrets failed validation:\n%s", strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+func (d *deployer) secretChecks() []secretCheck {
+	serviceKeyvault := *d.config.Configuration.KeyvaultPrefix + env.ServiceKeyvaultSuffix
+	dbtokenKeyvault := *d.config.Configuration.KeyvaultPrefix + env.DBTokenKeyvaultSuffix
+	portalKeyvault := *d.config.Configuration.KeyvaultPrefix + env.PortalKeyvaultSuffix
+
+	return []secretCheck{
+		{d.serviceKeyvault, serviceKeyvault, env.RPFirstPartySecretName, secretKindCertificate, true},
+		{d.serviceKeyvault, serviceKeyvault, env.RPServerSecretName, secretKindCertificate, false},
+		{d.serviceKeyvault, serviceKeyvault, env.RPMDMSecretName, secretKindCertificate, false},
+		{d.serviceKeyvault, serviceKeyvault, env.RPMDSDSecretName, secretKindCertificate, false},
+		{d.serviceKeyvault, serviceKeyvault, env.ClusterLoggingSecretName, secretKindCertificate, true},
+		{d.dbtokenKeyvault, dbtokenKeyvault, env.DBTokenServerSecretName, secretKindCertificate, false},
+		{d.portalKeyvault, portalKeyvault, env.PortalServerSecretName, secretKindCertificate, false},
+		{d.portalKeyvault, portalKeyvault, env.PortalServerClientSecretName, secretKindCertificate, false},
+		{d.portalKeyvault, portalKeyvault, env.PortalServerSSHKeySecretName, secretKindRSAKey, false},
+	}
+}
+
+func (d *deployer) validateSecret(ctx context.Context, c secretCheck) error {
+	switch c.kind {
+	case secretKindCertificate:
+		getCertificateSecret := c.kv.GetCertificateSecret
+		if c.latest {
+			getCertificateSecret = c.kv.GetLatestCertificateSecret
+		}
+
+		key, certs, err := getCertificateSecret(ctx, c.secretName)
+		if err != nil {
+			return secretError(err)
+		}
+
+		if key.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("RSA key is %d bits, want at least %d", key.N.BitLen(), minRSAKeyBits)
+		}
+
+		if !key.PublicKey.Equal(certs[0].PublicKey) {
+			return fmt.Errorf("private key does not match certificate")
+		}
+
+		return validateCertificateValidity(certs[0], time.Now())
+
+	case secretKindRSAKey:
+		b, err := c.kv.GetBase64Secret(ctx, c.secretName, "")
+		if err != nil {
+			return secretError(err)
+		}
+
+		key, err := x509.ParsePKCS1PrivateKey(b)
+		if err != nil {
+			return fmt.Errorf("not a PKCS#1 RSA private key")
+		}
+
+		if key.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("RSA key is %d bits, want at least %d", key.N.BitLen(), minRSAKeyBits)
+		}
+	}
+
+	return nil
+}
+
+func validateCertificateValidity(cert *x509.Certificate, now time.Time) error {
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("certificate is not valid until %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	}
+
+	if now.Add(minCertificateValidity).After(cert.NotAfter) {
+		return fmt.Errorf("certificate expires at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func secretError(err error) error {
+	if detailedErr, ok := err.(autorest.DetailedError); ok && detailedErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("not found")
+	}
+
+	return err
+}
@@ -0,0 +1,154 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/keyvault"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+// expectValidSecrets sets up k to return a valid certificate for every
+// certificate secret and a valid SSH key, for any number of calls
+func expectValidSecrets(t *testing.T, k *mock_keyvault.MockManager) {
+	key, certs, err := utiltls.GenerateKeyAndCertificate("test", nil, nil, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k.EXPECT().GetCertificateSecret(gomock.Any(), gomock.Any()).Return(key, certs, nil).AnyTimes()
+	k.EXPECT().GetLatestCertificateSecret(gomock.Any(), gomock.Any()).Return(key, certs, nil).AnyTimes()
+	k.EXPECT().GetBase64Secret(gomock.Any(), env.PortalServerSSHKeySecretName, "").Return(x509.MarshalPKCS1PrivateKey(key), nil).AnyTimes()
+}
+
+func TestValidateServiceSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	_, certs, err := utiltls.GenerateKeyAndCertificate("test", nil, nil, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKey, _, err := utiltls.GenerateKeyAndCertificate("other", nil, nil, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiringKey, expiringCerts, err := utiltls.GenerateTestKeyAndCertificate("expiring", nil, nil, false, false, func(template *x509.Certificate) {
+		template.NotAfter = time.Now().Add(time.Hour)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notFound := autorest.DetailedError{
+		StatusCode: http.StatusNotFound,
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_keyvault.MockManager)
+		wantErr string
+	}{
+		{
+			name: "all secrets valid",
+		},
+		{
+			name: "problems are reported together",
+			mocks: func(k *mock_keyvault.MockManager) {
+				k.EXPECT().GetCertificateSecret(ctx, env.RPMDMSecretName).Return(nil, nil, notFound)
+				k.EXPECT().GetCertificateSecret(ctx, env.RPServerSecretName).Return(otherKey, certs, nil)
+				k.EXPECT().GetLatestCertificateSecret(ctx, env.RPFirstPartySecretName).Return(expiringKey, expiringCerts, nil)
+				k.EXPECT().GetBase64Secret(ctx, env.PortalServerSSHKeySecretName, "").Return(x509.MarshalPKCS1PrivateKey(smallKey), nil)
+			},
+			wantErr: "keyvault secrets failed validation:\n" +
+				"testkv-svc/rp-firstparty: certificate expires at " + expiringCerts[0].NotAfter.UTC().Format(time.RFC3339) + "\n" +
+				"testkv-svc/rp-server: private key does not match certificate\n" +
+				"testkv-svc/rp-mdm: not found\n" +
+				"testkv-por/portal-sshkey: RSA key is 1024 bits, want at least 2048",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockKV := mock_keyvault.NewMockManager(controller)
+			if tt.mocks != nil {
+				tt.mocks(mockKV)
+			}
+			expectValidSecrets(t, mockKV)
+
+			d := deployer{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				config: &RPConfig{
+					Configuration: &Configuration{
+						KeyvaultPrefix: to.StringPtr("testkv"),
+					},
+				},
+				serviceKeyvault: mockKV,
+				dbtokenKeyvault: mockKV,
+				portalKeyvault:  mockKV,
+			}
+
+			err := d.validateServiceSecrets(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateCertificateValidity(t *testing.T) {
+	now := time.Now()
+
+	for _, tt := range []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		wantErr   string
+	}{
+		{
+			name:      "valid",
+			notBefore: now.Add(-time.Hour),
+			notAfter:  now.Add(30 * 24 * time.Hour),
+		},
+		{
+			name:      "not yet valid",
+			notBefore: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+			notAfter:  time.Date(2101, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:   "certificate is not valid until 2100-01-01T00:00:00Z",
+		},
+		{
+			name:      "expired",
+			notBefore: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			notAfter:  time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:   "certificate expires at 2001-01-01T00:00:00Z",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCertificateValidity(&x509.Certificate{
+				NotBefore: tt.notBefore,
+				NotAfter:  tt.notAfter,
+			}, now)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
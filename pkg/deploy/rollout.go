@@ -0,0 +1,114 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+)
+
+// RolloutPlan is the machine readable order in which a version of the RP is
+// rolled out across the fleet.  The deploy tooling deploys the regions of each
+// stage in parallel, and does not start a stage until every region of the
+// previous stage has succeeded and the previous stage's bake time has passed.
+type RolloutPlan struct {
+	Version string         `json:"version"`
+	Stages  []RolloutStage `json:"stages"`
+}
+
+// RolloutStage is a set of regions which are deployed together
+type RolloutStage struct {
+	Name        string          `json:"name"`
+	Canary      bool            `json:"canary,omitempty"`
+	BakeMinutes int             `json:"bakeMinutes,omitempty"`
+	Regions     []RolloutRegion `json:"regions"`
+}
+
+// RolloutRegion identifies the RP deployed in a region
+type RolloutRegion struct {
+	Location                 string `json:"location"`
+	SubscriptionID           string `json:"subscriptionId"`
+	RPResourceGroupName      string `json:"rpResourceGroupName"`
+	GatewayResourceGroupName string `json:"gatewayResourceGroupName"`
+}
+
+// PlanRollout returns the plan for rolling version out to the regions in
+// config.  If config has no rollout configuration, the regions are deployed
+// one at a time in the order in which they are listed.
+func PlanRollout(config *Config, version string) (*RolloutPlan, error) {
+	rollout := config.Rollout
+	if rollout == nil {
+		rollout = &RolloutConfiguration{}
+	}
+
+	if rollout.MaxParallelRegions < 0 {
+		return nil, fmt.Errorf("rollout maxParallelRegions must not be negative")
+	}
+
+	if rollout.BakeMinutes < 0 {
+		return nil, fmt.Errorf("rollout bakeMinutes must not be negative")
+	}
+
+	maxParallelRegions := rollout.MaxParallelRegions
+	if maxParallelRegions == 0 {
+		maxParallelRegions = 1
+	}
+
+	regions := map[string]RolloutRegion{}
+	for _, rp := range config.RPs {
+		if _, found := regions[rp.Location]; found {
+			return nil, fmt.Errorf("location %s is listed more than once", rp.Location)
+		}
+
+		regions[rp.Location] = RolloutRegion{
+			Location:                 rp.Location,
+			SubscriptionID:           rp.SubscriptionID,
+			RPResourceGroupName:      rp.RPResourceGroupName,
+			GatewayResourceGroupName: rp.GatewayResourceGroupName,
+		}
+	}
+
+	plan := &RolloutPlan{
+		Version: version,
+		Stages:  []RolloutStage{},
+	}
+
+	canaries := map[string]struct{}{}
+	for _, location := range rollout.CanaryRegions {
+		region, found := regions[location]
+		if !found {
+			return nil, fmt.Errorf("canary region %s is not in rps", location)
+		}
+
+		if _, found := canaries[location]; found {
+			return nil, fmt.Errorf("canary region %s is listed more than once", location)
+		}
+		canaries[location] = struct{}{}
+
+		plan.Stages = append(plan.Stages, RolloutStage{
+			Name:        fmt.Sprintf("canary-%d", len(plan.Stages)+1),
+			Canary:      true,
+			BakeMinutes: rollout.BakeMinutes,
+			Regions:     []RolloutRegion{region},
+		})
+	}
+
+	var stage *RolloutStage
+	for _, rp := range config.RPs {
+		if _, found := canaries[rp.Location]; found {
+			continue
+		}
+
+		if stage == nil || len(stage.Regions) == maxParallelRegions {
+			plan.Stages = append(plan.Stages, RolloutStage{
+				Name:        fmt.Sprintf("stage-%d", len(plan.Stages)-len(canaries)+1),
+				BakeMinutes: rollout.BakeMinutes,
+			})
+			stage = &plan.Stages[len(plan.Stages)-1]
+		}
+
+		stage.Regions = append(stage.Regions, regions[rp.Location])
+	}
+
+	return plan, nil
+}
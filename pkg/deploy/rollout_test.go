@@ -0,0 +1,142 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestPlanRollout(t *testing.T) {
+	rps := []RPConfig{
+		{Location: "eastus", SubscriptionID: "sub1", RPResourceGroupName: "rp-eastus", GatewayResourceGroupName: "gwy-eastus"},
+		{Location: "westus", SubscriptionID: "sub1", RPResourceGroupName: "rp-westus", GatewayResourceGroupName: "gwy-westus"},
+		{Location: "westcentralus", SubscriptionID: "sub2", RPResourceGroupName: "rp-westcentralus", GatewayResourceGroupName: "gwy-westcentralus"},
+		{Location: "northeurope", SubscriptionID: "sub2", RPResourceGroupName: "rp-northeurope", GatewayResourceGroupName: "gwy-northeurope"},
+		{Location: "uksouth", SubscriptionID: "sub3", RPResourceGroupName: "rp-uksouth", GatewayResourceGroupName: "gwy-uksouth"},
+	}
+
+	region := func(i int) RolloutRegion {
+		return RolloutRegion{
+			Location:                 rps[i].Location,
+			SubscriptionID:           rps[i].SubscriptionID,
+			RPResourceGroupName:      rps[i].RPResourceGroupName,
+			GatewayResourceGroupName: rps[i].GatewayResourceGroupName,
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		rps     []RPConfig
+		rollout *RolloutConfiguration
+		want    []RolloutStage
+		wantErr string
+	}{
+		{
+			name: "no rollout configuration deploys one region at a time",
+			rps:  rps[:3],
+			want: []RolloutStage{
+				{Name: "stage-1", Regions: []RolloutRegion{region(0)}},
+				{Name: "stage-2", Regions: []RolloutRegion{region(1)}},
+				{Name: "stage-3", Regions: []RolloutRegion{region(2)}},
+			},
+		},
+		{
+			name: "canary regions first, then the rest in parallel",
+			rps:  rps,
+			rollout: &RolloutConfiguration{
+				CanaryRegions:      []string{"westcentralus", "uksouth"},
+				MaxParallelRegions: 2,
+				BakeMinutes:        60,
+			},
+			want: []RolloutStage{
+				{Name: "canary-1", Canary: true, BakeMinutes: 60, Regions: []RolloutRegion{region(2)}},
+				{Name: "canary-2", Canary: true, BakeMinutes: 60, Regions: []RolloutRegion{region(4)}},
+				{Name: "stage-1", BakeMinutes: 60, Regions: []RolloutRegion{region(0), region(1)}},
+				{Name: "stage-2", BakeMinutes: 60, Regions: []RolloutRegion{region(3)}},
+			},
+		},
+		{
+			name: "every region is a canary",
+			rps:  rps[:1],
+			rollout: &RolloutConfiguration{
+				CanaryRegions: []string{"eastus"},
+			},
+			want: []RolloutStage{
+				{Name: "canary-1", Canary: true, Regions: []RolloutRegion{region(0)}},
+			},
+		},
+		{
+			name: "unknown canary region",
+			rps:  rps,
+			rollout: &RolloutConfiguration{
+				CanaryRegions: []string{"centralus"},
+			},
+			wantErr: "canary region centralus is not in rps",
+		},
+		{
+			name: "duplicate canary region",
+			rps:  rps,
+			rollout: &RolloutConfiguration{
+				CanaryRegions: []string{"eastus", "eastus"},
+			},
+			wantErr: "canary region eastus is listed more than once",
+		},
+		{
+			name:    "duplicate location",
+			rps:     []RPConfig{rps[0], rps[0]},
+			wantErr: "location eastus is listed more than once",
+		},
+		{
+			name: "negative maxParallelRegions",
+			rps:  rps,
+			rollout: &RolloutConfiguration{
+				MaxParallelRegions: -1,
+			},
+			wantErr: "rollout maxParallelRegions must not be negative",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := PlanRollout(&Config{RPs: tt.rps, Rollout: tt.rollout}, "v1")
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.wantErr != "" {
+				return
+			}
+
+			if plan.Version != "v1" {
+				t.Error(plan.Version)
+			}
+
+			if !reflect.DeepEqual(plan.Stages, tt.want) {
+				t.Errorf("got %#v, want %#v", plan.Stages, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeploymentStatus(t *testing.T) {
+	s := NewDeploymentStatus("v1", "eastus")
+	if s.State != DeploymentStateInProgress || s.FinishedAt != nil {
+		t.Error(s)
+	}
+
+	if s.blobName() != "v1/eastus.json" {
+		t.Error(s.blobName())
+	}
+
+	s.Finish(errors.New("failed"))
+	if s.State != DeploymentStateFailed || s.Error != "failed" || s.FinishedAt == nil {
+		t.Error(s)
+	}
+
+	s = NewDeploymentStatus("v1", "eastus")
+	s.Finish(nil)
+	if s.State != DeploymentStateSucceeded || s.Error != "" || s.FinishedAt == nil {
+		t.Error(s)
+	}
+}
@@ -0,0 +1,84 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// rolloutStatusContainer holds a DeploymentStatus document for each version
+// deployed to each region, named <version>/<location>.json, so that the
+// progress and outcome of every rollout can be audited
+const rolloutStatusContainer = "rolloutstatus"
+
+type DeploymentState string
+
+const (
+	DeploymentStateInProgress DeploymentState = "InProgress"
+	DeploymentStateSucceeded  DeploymentState = "Succeeded"
+	DeploymentStateFailed     DeploymentState = "Failed"
+)
+
+// DeploymentStatus records the deployment of a version of the RP to a region
+type DeploymentStatus struct {
+	Version    string          `json:"version"`
+	Location   string          `json:"location"`
+	State      DeploymentState `json:"state"`
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt *time.Time      `json:"finishedAt,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// NewDeploymentStatus returns the status of a deployment of version to
+// location which is starting now
+func NewDeploymentStatus(version, location string) *DeploymentStatus {
+	return &DeploymentStatus{
+		Version:   version,
+		Location:  location,
+		State:     DeploymentStateInProgress,
+		StartedAt: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// Finish records the outcome of the deployment: it succeeded if err is nil
+func (s *DeploymentStatus) Finish(err error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	s.FinishedAt = &now
+
+	if err != nil {
+		s.State = DeploymentStateFailed
+		s.Error = err.Error()
+		return
+	}
+
+	s.State = DeploymentStateSucceeded
+}
+
+// blobName returns the name of the blob in which s is saved
+func (s *DeploymentStatus) blobName() string {
+	return s.Version + "/" + s.Location + ".json"
+}
+
+// SaveDeploymentStatus saves status to the shared storage account for the
+// environment, replacing any status previously saved for the same version and
+// location
+func (d *deployer) SaveDeploymentStatus(ctx context.Context, status *DeploymentStatus) error {
+	b, err := json.MarshalIndent(status, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	blobClient, err := d.rpVersionBlobClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	containerRef := blobClient.GetContainerReference(rolloutStatusContainer)
+	blobRef := containerRef.GetBlobReference(status.blobName())
+	blobRef.Properties.ContentType = "application/json"
+	return blobRef.CreateBlockBlobFromReader(bytes.NewReader(b), nil)
+}
@@ -17,6 +17,20 @@ import (
 // SaveVersion for current location in shared storage account for environment
 func (d *deployer) SaveVersion(ctx context.Context) error {
 	d.log.Printf("saving RP and OCP versions for RP %s deployed in %s to storage account %s", d.version, d.config.Location, *d.config.Configuration.RPVersionStorageAccountName)
+	blobClient, err := d.rpVersionBlobClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	// save version of RP which is deployed in this location
+	containerRef := blobClient.GetContainerReference("rpversion")
+	blobRef := containerRef.GetBlobReference(d.config.Location)
+	return blobRef.CreateBlockBlobFromReader(bytes.NewReader([]byte(d.version)), nil)
+}
+
+// rpVersionBlobClient returns a client which may create and write blobs in
+// the shared RP version storage account
+func (d *deployer) rpVersionBlobClient(ctx context.Context) (*azstorage.BlobStorageClient, error) {
 	t := time.Now().UTC().Truncate(time.Second)
 	res, err := d.globalaccounts.ListAccountSAS(
 		ctx, *d.config.Configuration.GlobalResourceGroupName, *d.config.Configuration.RPVersionStorageAccountName, mgmtstorage.AccountSasParameters{
@@ -28,19 +42,16 @@ func (d *deployer) SaveVersion(ctx context.Context) error {
 			SharedAccessExpiryTime: &date.Time{Time: t.Add(24 * time.Hour)},
 		})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	v, err := url.ParseQuery(*res.AccountSasToken)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	blobClient := azstorage.NewAccountSASClient(
 		*d.config.Configuration.RPVersionStorageAccountName, v, (*d.env.Environment()).Environment).GetBlobService()
 
-	// save version of RP which is deployed in this location
-	containerRef := blobClient.GetContainerReference("rpversion")
-	blobRef := containerRef.GetBlobReference(d.config.Location)
-	return blobRef.CreateBlockBlobFromReader(bytes.NewReader([]byte(d.version)), nil)
+	return &blobClient, nil
 }
@@ -17,6 +17,16 @@ const (
 )
 
 func (d *deployer) UpgradeRP(ctx context.Context) error {
+	if d.config.Configuration.RPCanary != nil {
+		return d.rpCanaryUpgrade(ctx)
+	}
+
+	return d.rpUpgrade(ctx)
+}
+
+// rpUpgrade waits for the new scaleset to be healthy and removes the old
+// scalesets
+func (d *deployer) rpUpgrade(ctx context.Context) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, time.Hour)
 	defer cancel()
 	err := d.rpWaitForReadiness(timeoutCtx, rpVMSSPrefix+d.version)
@@ -71,6 +81,19 @@ func (d *deployer) rpRemoveOldScalesets(ctx context.Context) error {
 }
 
 func (d *deployer) rpRemoveOldScaleset(ctx context.Context, vmssName string) error {
+	err := d.rpStopScaleset(ctx, vmssName)
+	if err != nil {
+		return err
+	}
+
+	d.log.Printf("deleting scaleset %s", vmssName)
+	return d.vmss.DeleteAndWait(ctx, d.config.RPResourceGroupName, vmssName)
+}
+
+// rpStopScaleset stops the RP on every instance of the scaleset.  The
+// instances' health probes then fail, so the load balancer stops sending them
+// traffic.
+func (d *deployer) rpStopScaleset(ctx context.Context, vmssName string) error {
 	scalesetVMs, err := d.vmssvms.List(ctx, d.config.RPResourceGroupName, vmssName, "", "", "")
 	if err != nil {
 		return err
@@ -95,6 +118,5 @@ func (d *deployer) rpRemoveOldScaleset(ctx context.Context, vmssName string) err
 		}
 	}
 
-	d.log.Printf("deleting scaleset %s", vmssName)
-	return d.vmss.DeleteAndWait(ctx, d.config.RPResourceGroupName, vmssName)
+	return nil
 }
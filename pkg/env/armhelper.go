@@ -89,7 +89,7 @@ func newARMHelper(ctx context.Context, log *logrus.Entry, env Interface) (ARMHel
 	armHelperAuthorizer := azidext.NewTokenCredentialAdapter(armHelperTokenCredential, scopes)
 
 	// Graph service client uses the first party service principal.
-	fpTokenCredential, err := env.FPNewClientCertificateCredential(env.TenantID())
+	fpTokenCredential, err := env.FPNewTokenCredential(env.TenantID())
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,44 @@
+package env
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// AuthMode selects how a service authenticates to ARM, Key Vault and Cosmos
+// DB.  It is set per deployment by the SERVICE_AUTH_MODE environment variable.
+type AuthMode string
+
+const (
+	// AuthModeCertificate is the default: the service's managed identity is
+	// used for ARM and Key Vault, the first party application authenticates
+	// with the certificate held in the service key vault, and Cosmos DB is
+	// accessed with the account master key (or, in the gateway, with a token
+	// from the dbtoken service).
+	AuthModeCertificate AuthMode = "Certificate"
+
+	// AuthModeManagedIdentity authenticates exclusively with the service's
+	// managed identity: the first party application trusts it through a
+	// federated credential and Cosmos DB is accessed with Azure AD tokens, so
+	// no certificate or key is loaded by the service.
+	AuthModeManagedIdentity AuthMode = "ManagedIdentity"
+)
+
+const envServiceAuthMode = "SERVICE_AUTH_MODE"
+
+// AuthModeFromEnvironment returns the AuthMode set by SERVICE_AUTH_MODE,
+// defaulting to AuthModeCertificate if it is unset
+func AuthModeFromEnvironment() (AuthMode, error) {
+	switch strings.ToLower(os.Getenv(envServiceAuthMode)) {
+	case "", strings.ToLower(string(AuthModeCertificate)):
+		return AuthModeCertificate, nil
+	case strings.ToLower(string(AuthModeManagedIdentity)):
+		return AuthModeManagedIdentity, nil
+	}
+
+	return "", fmt.Errorf("invalid %s %q", envServiceAuthMode, os.Getenv(envServiceAuthMode))
+}
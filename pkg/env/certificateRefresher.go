@@ -6,35 +6,49 @@ package env
 import (
 	"context"
 	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
 	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/util/keyvault"
 )
 
 type CertificateRefresher interface {
 	Start(context.Context) error
 	GetCertificates() (*rsa.PrivateKey, []*x509.Certificate)
+	// GetTLSCertificate is suitable for use as tls.Config.GetCertificate, so
+	// that a listener serves each new version of the certificate without
+	// being restarted
+	GetTLSCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error)
+	// EmitMetrics emits the age and the remaining validity of the current
+	// version of the certificate
+	EmitMetrics(metrics.Emitter)
 }
 
 type refreshingCertificate struct {
 	lock      sync.RWMutex
 	certs     []*x509.Certificate
 	key       *rsa.PrivateKey
+	tlsCert   *tls.Certificate
 	logger    *logrus.Entry
 	kv        keyvault.Manager
 	certName  string
+	now       func() time.Time
 	newTicker func() (tick <-chan time.Time, stop func())
 }
 
-func newCertificateRefresher(logger *logrus.Entry, interval time.Duration, kv keyvault.Manager, certificateName string) CertificateRefresher {
+// NewCertificateRefresher returns a CertificateRefresher which polls kv every
+// interval for a new version of certificateName
+func NewCertificateRefresher(logger *logrus.Entry, interval time.Duration, kv keyvault.Manager, certificateName string) CertificateRefresher {
 	return &refreshingCertificate{
 		logger:   logger,
 		kv:       kv,
 		certName: certificateName,
+		now:      time.Now,
 		newTicker: func() (tick <-chan time.Time, stop func()) {
 			ticker := time.NewTicker(interval)
 			return ticker.C, func() { ticker.Stop() }
@@ -62,20 +76,56 @@ func (r *refreshingCertificate) GetCertificates() (*rsa.PrivateKey, []*x509.Cert
 	return r.key, r.certs
 }
 
+func (r *refreshingCertificate) GetTLSCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.tlsCert, nil
+}
+
+func (r *refreshingCertificate) EmitMetrics(m metrics.Emitter) {
+	_, certs := r.GetCertificates()
+	if len(certs) == 0 {
+		return
+	}
+
+	now := r.now()
+	dims := map[string]string{
+		"certificate": r.certName,
+	}
+
+	m.EmitGauge("certificate.age", int64(now.Sub(certs[0].NotBefore)/time.Second), dims)
+	m.EmitGauge("certificate.remaining", int64(certs[0].NotAfter.Sub(now)/time.Second), dims)
+}
+
 // fetchCertificateOnce access keyvault via preset getter and download new set
 // of certificates.
 // in case of failure error is returned and old certificate is left in the
 // synced store
 func (r *refreshingCertificate) fetchCertificateOnce(ctx context.Context) error {
-	key, certs, err := r.kv.GetCertificateSecret(ctx, r.certName)
+	key, certs, err := r.kv.GetLatestCertificateSecret(ctx, r.certName)
 	if err != nil {
 		return err
 	}
 
+	tlsCert := &tls.Certificate{
+		PrivateKey: key,
+		Leaf:       certs[0],
+	}
+	for _, cert := range certs {
+		tlsCert.Certificate = append(tlsCert.Certificate, cert.Raw)
+	}
+
 	r.lock.Lock()
 	defer r.lock.Unlock()
+
+	if len(r.certs) > 0 && !r.certs[0].Equal(certs[0]) {
+		r.logger.Infof("certificate %s rotated, new version expires at %s", r.certName, certs[0].NotAfter.UTC().Format(time.RFC3339))
+	}
+
 	r.key = key
 	r.certs = certs
+	r.tlsCert = tlsCert
 
 	return nil
 }
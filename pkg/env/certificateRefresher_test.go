@@ -16,6 +16,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/util/keyvault"
 	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/keyvault"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
 	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
 )
 
@@ -189,7 +190,7 @@ func TestRefreshingCertificate(t *testing.T) {
 			name: "test initial certificate, pull exactly once, ticks one time",
 			managerFactory: func(controller *gomock.Controller) keyvault.Manager {
 				manager := mock_keyvault.NewMockManager(controller)
-				manager.EXPECT().GetCertificateSecret(gomock.Any(), testCertName).Return(key1, certs1, nil)
+				manager.EXPECT().GetLatestCertificateSecret(gomock.Any(), testCertName).Return(key1, certs1, nil)
 				return manager
 			},
 			tickCount: 0,
@@ -202,8 +203,8 @@ func TestRefreshingCertificate(t *testing.T) {
 			managerFactory: func(controller *gomock.Controller) keyvault.Manager {
 				manager := mock_keyvault.NewMockManager(mockController)
 				gomock.InOrder(
-					manager.EXPECT().GetCertificateSecret(gomock.Any(), testCertName).Return(key1, certs1, nil),
-					manager.EXPECT().GetCertificateSecret(gomock.Any(), testCertName).Return(key2, certs2, nil),
+					manager.EXPECT().GetLatestCertificateSecret(gomock.Any(), testCertName).Return(key1, certs1, nil),
+					manager.EXPECT().GetLatestCertificateSecret(gomock.Any(), testCertName).Return(key2, certs2, nil),
 				)
 				return manager
 			},
@@ -216,7 +217,7 @@ func TestRefreshingCertificate(t *testing.T) {
 			name: "test initial error, pull exactly once with an error, no tick",
 			managerFactory: func(controller *gomock.Controller) keyvault.Manager {
 				manager := mock_keyvault.NewMockManager(mockController)
-				manager.EXPECT().GetCertificateSecret(gomock.Any(), testCertName).Return(nil, nil, cannotPull)
+				manager.EXPECT().GetLatestCertificateSecret(gomock.Any(), testCertName).Return(nil, nil, cannotPull)
 				return manager
 			},
 			tickCount: 0,
@@ -229,8 +230,8 @@ func TestRefreshingCertificate(t *testing.T) {
 			managerFactory: func(controller *gomock.Controller) keyvault.Manager {
 				manager := mock_keyvault.NewMockManager(controller)
 				gomock.InOrder(
-					manager.EXPECT().GetCertificateSecret(gomock.Any(), testCertName).Return(key1, certs1, nil),
-					manager.EXPECT().GetCertificateSecret(gomock.Any(), testCertName).Return(nil, nil, cannotPull),
+					manager.EXPECT().GetLatestCertificateSecret(gomock.Any(), testCertName).Return(key1, certs1, nil),
+					manager.EXPECT().GetLatestCertificateSecret(gomock.Any(), testCertName).Return(nil, nil, cannotPull),
 				)
 				return manager
 			},
@@ -243,7 +244,7 @@ func TestRefreshingCertificate(t *testing.T) {
 			name: "test refresh, pull exactly 5 times, 4 ticks",
 			managerFactory: func(controller *gomock.Controller) keyvault.Manager {
 				manager := mock_keyvault.NewMockManager(controller)
-				manager.EXPECT().GetCertificateSecret(gomock.Any(), testCertName).Return(key1, certs1, nil).Times(5)
+				manager.EXPECT().GetLatestCertificateSecret(gomock.Any(), testCertName).Return(key1, certs1, nil).Times(5)
 				return manager
 			},
 			tickCount: 4,
@@ -260,7 +261,7 @@ func TestRefreshingCertificate(t *testing.T) {
 
 			mock, tick := newMockTicker(test.tickCount)
 
-			refreshing := newCertificateRefresher(
+			refreshing := NewCertificateRefresher(
 				logrus.NewEntry(logrus.StandardLogger()),
 				// interval is not used in tests, it is mocked
 				0,
@@ -291,6 +292,42 @@ func TestRefreshingCertificate(t *testing.T) {
 			if !testCerts[0].Equal(test.wantCert) {
 				t.Error("returned certificate does not match")
 			}
+
+			tlsCert, err := refreshing.GetTLSCertificate(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tlsCert.Leaf.Equal(test.wantCert) || !tlsCert.PrivateKey.(*rsa.PrivateKey).Equal(test.wantKey) {
+				t.Error("returned TLS certificate does not match")
+			}
 		})
 	}
 }
+
+func TestEmitMetrics(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	r := &refreshingCertificate{
+		certName: "rp-server",
+		certs: []*x509.Certificate{
+			{
+				NotBefore: notBefore,
+				NotAfter:  notBefore.Add(48 * time.Hour),
+			},
+		},
+		now: func() time.Time { return notBefore.Add(time.Hour) },
+	}
+
+	dims := map[string]string{
+		"certificate": "rp-server",
+	}
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("certificate.age", int64(3600), dims)
+	m.EXPECT().EmitGauge("certificate.remaining", int64(47*3600), dims)
+
+	r.EmitMetrics(m)
+}
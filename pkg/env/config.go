@@ -0,0 +1,319 @@
+package env
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ConfigVersion is the version of the configuration file format understood by
+// LoadConfig
+const ConfigVersion = 1
+
+const redacted = "[REDACTED]"
+
+// Defaults of the settings which are read outside this package.  The code
+// reading each of these settings falls back to the same constant, so that the
+// defaults set by LoadConfig cannot drift from it.
+const (
+	DefaultClusterSoftDeleteRetention         = 7 * 24 * time.Hour
+	DefaultMirrorInterval                     = time.Hour
+	DefaultRPBackendMaxWorkersPerSubscription = 10
+	DefaultRPFrontendDrainTimeout             = 2 * time.Minute
+	DefaultRPFrontendMaxBodyBytes             = 1048576
+)
+
+// Config is the configuration file of the ARO services, in YAML or JSON.  Its
+// environment holds settings named after the environment variables which the
+// services have historically been configured with, e.g.:
+//
+//	version: 1
+//	environment:
+//	  LOCATION: eastus
+//	  RP_FRONTEND_DRAIN_TIMEOUT: 5m
+//
+// A setting which is also set in the environment is overridden by it.
+type Config struct {
+	Version     int               `json:"version"`
+	Environment map[string]string `json:"environment,omitempty"`
+}
+
+// setting describes a setting which may be given in the configuration file
+type setting struct {
+	// secret settings are redacted from DumpConfig
+	secret bool
+
+	// defaultValue is set by LoadConfig if the setting is otherwise unset.  It
+	// must match the default of the code reading the setting, so it is taken
+	// from the same constant.
+	defaultValue string
+
+	// validate, if set, checks a non-empty value of the setting
+	validate func(string) error
+}
+
+// settings are the settings which the configuration file may hold
+var settings = map[string]setting{
+	"ACR_RESOURCE_ID":                         {},
+	"ADMIN_API_CLIENT_CERT_COMMON_NAME":       {},
+	"ARM_API_CLIENT_CERT_COMMON_NAME":         {},
+	"ARM_POP_TOKEN_AUDIENCE":                  {},
+	"ARM_POP_TOKEN_ISSUER":                    {},
+	"ARO_ADOPT_BY_HIVE":                       {},
+	"ARO_HIVE_DEFAULT_INSTALLER_PULLSPEC":     {},
+	"ARO_IMAGE":                               {},
+	"ARO_INSTALL_VIA_HIVE":                    {},
+	"AZURE_ARM_CLIENT_ID":                     {},
+	"AZURE_CLIENT_ID":                         {},
+	"AZURE_CLIENT_SECRET":                     {secret: true},
+	"AZURE_CLUSTER_APP_ID":                    {},
+	"AZURE_CLUSTER_APP_SECRET":                {secret: true},
+	"AZURE_DBTOKEN_CLIENT_ID":                 {},
+	"AZURE_ENVIRONMENT":                       {},
+	"AZURE_EV2":                               {},
+	"AZURE_FP_CLIENT_ID":                      {},
+	"AZURE_FP_SERVICE_PRINCIPAL_ID":           {},
+	"AZURE_GATEWAY_CLIENT_ID":                 {},
+	"AZURE_GATEWAY_CLIENT_SECRET":             {secret: true},
+	"AZURE_GATEWAY_SERVICE_PRINCIPAL_ID":      {},
+	"AZURE_PORTAL_ACCESS_GROUP_IDS":           {},
+	"AZURE_PORTAL_CLIENT_ID":                  {},
+	"AZURE_PORTAL_ELEVATED_GROUP_IDS":         {},
+	"AZURE_PORTAL_VIEWER_GROUP_IDS":           {},
+	"AZURE_RP_CLIENT_ID":                      {},
+	"AZURE_RP_CLIENT_SECRET":                  {secret: true},
+	"AZURE_SERVICE_PRINCIPAL_ID":              {},
+	"AZURE_SUBSCRIPTION_ID":                   {},
+	"AZURE_TENANT_ID":                         {},
+	"BILLING_E2E_STORAGE_ACCOUNT_ID":          {},
+	"CANARY_CLUSTER_RESOURCE_ID":              {},
+	"CLUSTER_MDM_ACCOUNT":                     {},
+	"CLUSTER_MDM_NAMESPACE":                   {},
+	"CLUSTER_MDSD_ACCOUNT":                    {},
+	"CLUSTER_MDSD_CONFIG_VERSION":             {},
+	"CLUSTER_MDSD_NAMESPACE":                  {},
+	"CLUSTER_MONITOR_COLLECTORS":              {},
+	"CLUSTER_SOFT_DELETE_RETENTION":           {defaultValue: DefaultClusterSoftDeleteRetention.String(), validate: validateDuration},
+	"DATABASE_ACCOUNT_NAME":                   {},
+	"DATABASE_NAME":                           {},
+	"DATABASE_SERVER":                         {},
+	"DBTOKEN_URL":                             {},
+	"DOMAIN_NAME":                             {},
+	"DST_ACR_NAME":                            {},
+	"DST_AUTH":                                {secret: true},
+	"EVENTGRID_TOPIC_ENDPOINT":                {},
+	"GATEWAY_DIAGNOSTICS_CLIENT_ID":           {},
+	"GATEWAY_DIAGNOSTICS_SUBJECTS":            {},
+	"GATEWAY_DOMAINS":                         {},
+	"GATEWAY_FEATURES":                        {},
+	"GATEWAY_RESOURCEGROUP":                   {},
+	"HOSTNAME_OVERRIDE":                       {},
+	"INSTALLER_IMAGE_DIGESTS":                 {},
+	"KEYVAULT_PREFIX":                         {},
+	"LOCATION":                                {},
+	"MARKETPLACE_METERING_ENDPOINT":           {},
+	"MDM_ACCOUNT":                             {},
+	"MDM_NAMESPACE":                           {},
+	"MDM_STATSD_SOCKET":                       {},
+	"MDSD_ENVIRONMENT":                        {},
+	"MIRROR_INTERVAL":                         {defaultValue: DefaultMirrorInterval.String(), validate: validateDuration},
+	"MIRROR_SIGNATURE_POLICY":                 {},
+	"OCM_URL":                                 {},
+	"OPENSHIFT_VERSIONS":                      {},
+	"OTEL_EXPORTER_OTLP_ENDPOINT":             {},
+	"PORTAL_HOSTNAME":                         {},
+	"PROXY_HOSTNAME":                          {},
+	"PULL_SECRET":                             {secret: true},
+	"RESOURCEGROUP":                           {},
+	"RP_BACKEND_MAX_WORKERS_PER_SUBSCRIPTION": {defaultValue: strconv.Itoa(DefaultRPBackendMaxWorkersPerSubscription), validate: validatePositiveInt},
+	"RP_FEATURES":                             {validate: validateFeatures},
+	"RP_FRONTEND_DRAIN_TIMEOUT":               {defaultValue: DefaultRPFrontendDrainTimeout.String(), validate: validateDuration},
+	"RP_FRONTEND_MAX_BODY_BYTES":              {defaultValue: strconv.Itoa(DefaultRPFrontendMaxBodyBytes), validate: validatePositiveInt},
+	"RP_MODE":                                 {},
+	"SECURITY_POSTURE_MINIMUM_VERSION":        {},
+	envServiceAuthMode:                        {defaultValue: string(AuthModeCertificate), validate: validateAuthMode},
+	"SRC_AUTH_QUAY":                           {secret: true},
+	"SRC_AUTH_REDHAT":                         {secret: true},
+	"USE_CHECKACCESS":                         {},
+}
+
+// LoadConfig reads the configuration file at path, if path is not empty, and
+// sets each of its settings which is not already set in the environment.  It
+// then sets the defaults of the settings which remain unset.
+func LoadConfig(path string) error {
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		config, err := parseConfig(b)
+		if err != nil {
+			return fmt.Errorf("invalid configuration file %s: %w", path, err)
+		}
+
+		for name, value := range config.Environment {
+			if os.Getenv(name) != "" {
+				continue
+			}
+
+			err = os.Setenv(name, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, s := range settings {
+		if s.defaultValue == "" || os.Getenv(name) != "" {
+			continue
+		}
+
+		err := os.Setenv(name, s.defaultValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseConfig(b []byte) (*Config, error) {
+	config := &Config{}
+
+	err := yaml.Unmarshal(b, config, yaml.DisallowUnknownFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Version != ConfigVersion {
+		return nil, fmt.Errorf("unsupported version %d, expected %d", config.Version, ConfigVersion)
+	}
+
+	names := make([]string, 0, len(config.Environment))
+	for name := range config.Environment {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var merr error
+	for _, name := range names {
+		if _, ok := settings[name]; !ok {
+			merr = multierror.Append(merr, fmt.Errorf("unknown setting %q", name))
+		}
+	}
+
+	return config, merr
+}
+
+// ValidateConfig validates the value of each setting which is set in the
+// environment, so that a misconfiguration is reported at startup rather than
+// when the setting is first used
+func ValidateConfig() error {
+	var merr error
+
+	for _, name := range settingNames() {
+		value := os.Getenv(name)
+		s := settings[name]
+		if value == "" || s.validate == nil {
+			continue
+		}
+
+		err := s.validate(value)
+		if err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("invalid %s %q: %w", name, value, err))
+		}
+	}
+
+	return merr
+}
+
+// EffectiveConfig returns the configuration in effect, i.e. the settings which
+// are set in the environment, with the secrets redacted
+func EffectiveConfig() *Config {
+	config := &Config{
+		Version:     ConfigVersion,
+		Environment: map[string]string{},
+	}
+
+	for name, s := range settings {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
+		if s.secret {
+			value = redacted
+		}
+
+		config.Environment[name] = value
+	}
+
+	return config
+}
+
+// DumpConfig writes the configuration in effect to w as YAML, with the
+// secrets redacted
+func DumpConfig(w io.Writer) error {
+	b, err := yaml.Marshal(EffectiveConfig())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+func settingNames() []string {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validateDuration(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	if d < 0 {
+		return fmt.Errorf("negative duration")
+	}
+	return nil
+}
+
+func validatePositiveInt(s string) error {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	if n <= 0 {
+		return fmt.Errorf("not positive")
+	}
+	return nil
+}
+
+func validateFeatures(s string) error {
+	_, err := ParseFeatures(s)
+	return err
+}
+
+func validateAuthMode(s string) error {
+	switch strings.ToLower(s) {
+	case strings.ToLower(string(AuthModeCertificate)), strings.ToLower(string(AuthModeManagedIdentity)):
+		return nil
+	}
+	return fmt.Errorf("expected %s or %s", AuthModeCertificate, AuthModeManagedIdentity)
+}
@@ -0,0 +1,167 @@
+package env
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestLoadConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		config  string
+		env     map[string]string
+		wantEnv map[string]string
+		wantErr string
+	}{
+		{
+			name: "yaml",
+			config: `version: 1
+environment:
+  LOCATION: eastus
+  RP_FRONTEND_DRAIN_TIMEOUT: 5m
+`,
+			wantEnv: map[string]string{
+				"CLUSTER_SOFT_DELETE_RETENTION": "168h0m0s",
+				"LOCATION":                      "eastus",
+				"RP_FRONTEND_DRAIN_TIMEOUT":     "5m",
+				"RP_FRONTEND_MAX_BODY_BYTES":    "1048576",
+				"SERVICE_AUTH_MODE":             "Certificate",
+			},
+		},
+		{
+			name:   "json",
+			config: `{"version": 1, "environment": {"LOCATION": "eastus"}}`,
+			wantEnv: map[string]string{
+				"LOCATION": "eastus",
+			},
+		},
+		{
+			name: "environment overrides the file",
+			config: `version: 1
+environment:
+  LOCATION: eastus
+  SERVICE_AUTH_MODE: ManagedIdentity
+`,
+			env: map[string]string{
+				"LOCATION": "westeurope",
+			},
+			wantEnv: map[string]string{
+				"LOCATION":          "westeurope",
+				"SERVICE_AUTH_MODE": "ManagedIdentity",
+			},
+		},
+		{
+			name: "unsupported version",
+			config: `version: 2
+`,
+			wantErr: "unsupported version 2, expected 1",
+		},
+		{
+			name: "unknown setting",
+			config: `version: 1
+environment:
+  LOCATOIN: eastus
+`,
+			wantErr: `unknown setting "LOCATOIN"`,
+		},
+		{
+			name: "unknown field",
+			config: `version: 1
+env:
+  LOCATION: eastus
+`,
+			wantErr: `unknown field "env"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for name := range settings {
+				t.Setenv(name, "")
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			err := os.WriteFile(path, []byte(tt.config), 0666)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = LoadConfig(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			utilerror.AssertErrorMessage(t, err, "")
+
+			for name, value := range tt.wantEnv {
+				if got := os.Getenv(name); got != value {
+					t.Errorf("%s: got %q, want %q", name, got, value)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	for name := range settings {
+		t.Setenv(name, "")
+	}
+
+	err := LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ValidateConfig()
+	if err != nil {
+		t.Fatalf("defaults do not validate: %v", err)
+	}
+
+	t.Setenv("RP_FRONTEND_DRAIN_TIMEOUT", "forever")
+	t.Setenv("SERVICE_AUTH_MODE", "password")
+
+	err = ValidateConfig()
+	utilerror.AssertErrorMessage(t, err, `2 errors occurred:
+	* invalid RP_FRONTEND_DRAIN_TIMEOUT "forever": time: invalid duration "forever"
+	* invalid SERVICE_AUTH_MODE "password": expected Certificate or ManagedIdentity
+
+`)
+}
+
+func TestDumpConfig(t *testing.T) {
+	for name := range settings {
+		t.Setenv(name, "")
+	}
+	t.Setenv("AZURE_RP_CLIENT_SECRET", "secret")
+	t.Setenv("LOCATION", "eastus")
+	t.Setenv("PULL_SECRET", `{"auths":{}}`)
+	t.Setenv("RP_FRONTEND_DRAIN_TIMEOUT", "5m")
+
+	buf := &bytes.Buffer{}
+	err := DumpConfig(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `environment:
+  AZURE_RP_CLIENT_SECRET: '[REDACTED]'
+  LOCATION: eastus
+  PULL_SECRET: '[REDACTED]'
+  RP_FRONTEND_DRAIN_TIMEOUT: 5m
+version: 1
+`
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
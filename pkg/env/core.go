@@ -37,6 +37,7 @@ const (
 type Core interface {
 	IsLocalDevelopmentMode() bool
 	IsCI() bool
+	AuthMode() AuthMode
 	NewMSITokenCredential() (azcore.TokenCredential, error)
 	NewMSIAuthorizer(...string) (autorest.Authorizer, error)
 	NewLiveConfigManager(context.Context) (liveconfig.Manager, error)
@@ -51,6 +52,7 @@ type core struct {
 
 	isLocalDevelopmentMode bool
 	isCI                   bool
+	authMode               AuthMode
 
 	component    ServiceComponent
 	componentLog *logrus.Entry
@@ -64,6 +66,10 @@ func (c *core) IsCI() bool {
 	return c.isCI
 }
 
+func (c *core) AuthMode() AuthMode {
+	return c.authMode
+}
+
 func (c *core) Component() string {
 	return string(c.component)
 }
@@ -96,18 +102,25 @@ func NewCore(ctx context.Context, log *logrus.Entry, component ServiceComponent)
 		log.Info("running in local development mode")
 	}
 
+	authMode, err := AuthModeFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
 	im, err := instancemetadata.New(ctx, log, isLocalDevelopmentMode)
 	if err != nil {
 		return nil, err
 	}
 
 	log.Infof("InstanceMetadata: running on %s", im.Environment().Name)
+	log.Infof("authenticating with %s credentials", authMode)
 
 	return &core{
 		InstanceMetadata: im,
 
 		isLocalDevelopmentMode: isLocalDevelopmentMode,
 		isCI:                   isCI,
+		authMode:               authMode,
 		component:              component,
 		componentLog:           componentLog,
 	}, nil
@@ -132,5 +145,6 @@ func NewCoreForCI(ctx context.Context, log *logrus.Entry) (Core, error) {
 	return &core{
 		InstanceMetadata:       im,
 		isLocalDevelopmentMode: isLocalDevelopmentMode,
+		authMode:               AuthModeCertificate,
 	}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"net"
 	"os"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/jongio/azidext/go/azidext"
@@ -37,7 +38,7 @@ func newDev(ctx context.Context, log *logrus.Entry, component ServiceComponent)
 		FeatureRequireD2sV3Workers,
 		FeatureDisableReadinessDelay,
 	} {
-		d.features[feature] = true
+		d.features.setBase(feature)
 	}
 
 	d.prod.clusterGenevaLoggingAccount = version.DevClusterGenevaLoggingAccount
@@ -77,7 +78,7 @@ func (d *dev) Listen() (net.Listener, error) {
 }
 
 func (d *dev) FPAuthorizer(tenantID string, scopes ...string) (autorest.Authorizer, error) {
-	fpTokenCredential, err := d.FPNewClientCertificateCredential(tenantID)
+	fpTokenCredential, err := d.FPNewTokenCredential(tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +86,7 @@ func (d *dev) FPAuthorizer(tenantID string, scopes ...string) (autorest.Authoriz
 	return azidext.NewTokenCredentialAdapter(fpTokenCredential, scopes), nil
 }
 
-func (d *dev) FPNewClientCertificateCredential(tenantID string) (*azidentity.ClientCertificateCredential, error) {
+func (d *dev) FPNewTokenCredential(tenantID string) (azcore.TokenCredential, error) {
 	fpPrivateKey, fpCertificates := d.fpCertificateRefresher.GetCertificates()
 
 	options := d.Environment().ClientCertificateCredentialOptions()
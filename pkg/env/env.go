@@ -12,7 +12,7 @@ import (
 	"os"
 	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-multierror"
@@ -27,7 +27,9 @@ import (
 type Feature int
 
 // At least to start with, features are intended to be used so that the
-// production default is not set (in production RP_FEATURES is unset).
+// production default is not set (in production RP_FEATURES is unset).  Features
+// can also be toggled at runtime, globally or per subscription, through the
+// RPFeatures database collection; see Features.
 const (
 	FeatureDisableDenyAssignments Feature = iota
 	FeatureDisableSignedCertificates
@@ -41,6 +43,8 @@ const (
 	RPDevARMSecretName               = "dev-arm"
 	RPFirstPartySecretName           = "rp-firstparty"
 	RPServerSecretName               = "rp-server"
+	RPMDMSecretName                  = "rp-mdm"
+	RPMDSDSecretName                 = "rp-mdsd"
 	ClusterLoggingSecretName         = "cluster-mdsd"
 	EncryptionSecretName             = "encryption-key"
 	EncryptionSecretV2Name           = "encryption-key-v2"
@@ -84,8 +88,11 @@ type Interface interface {
 	ClusterKeyvault() keyvault.Manager
 	Domain() string
 	FeatureIsSet(Feature) bool
+	FeatureIsSetForSubscription(Feature, string) bool
+	Features() *Features
 	FPAuthorizer(string, ...string) (autorest.Authorizer, error)
-	FPNewClientCertificateCredential(string) (*azidentity.ClientCertificateCredential, error)
+	FPCertificateRefresher() CertificateRefresher
+	FPNewTokenCredential(string) (azcore.TokenCredential, error)
 	FPClientID() string
 	Listen() (net.Listener, error)
 	GatewayDomains() []string
@@ -116,6 +123,15 @@ func IsLocalDevelopmentMode() bool {
 	return strings.EqualFold(os.Getenv("RP_MODE"), "development")
 }
 
+// LocalDatabaseServer returns the host:port of the local CosmosDB fake started
+// by hack/cosmosdb-server, if DATABASE_SERVER is set in development mode
+func LocalDatabaseServer() string {
+	if !IsLocalDevelopmentMode() {
+		return ""
+	}
+	return os.Getenv("DATABASE_SERVER")
+}
+
 func IsCI() bool {
 	return strings.EqualFold(os.Getenv("CI"), "true")
 }
@@ -0,0 +1,151 @@
+package env
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Features is the state of the RP feature flags.  The features set by
+// RP_FEATURES (and, in development, by default) are the baseline; Apply
+// replaces the runtime configuration layered on top of it, so that features
+// can be toggled without restarting the RP.
+type Features struct {
+	mu sync.RWMutex
+
+	base     map[Feature]bool
+	features map[Feature]bool
+	// overrides holds feature states by lower case subscription ID
+	overrides map[string]map[Feature]bool
+}
+
+// NewFeatures returns Features with the given baseline features set
+func NewFeatures(base ...Feature) *Features {
+	f := &Features{
+		base:      map[Feature]bool{},
+		features:  map[Feature]bool{},
+		overrides: map[string]map[Feature]bool{},
+	}
+
+	for _, feature := range base {
+		f.base[feature] = true
+		f.features[feature] = true
+	}
+
+	return f
+}
+
+// setBase adds feature to the baseline features
+func (f *Features) setBase(feature Feature) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.base[feature] = true
+	f.features[feature] = true
+}
+
+// ParseFeatures parses a comma separated list of feature names without their
+// Feature prefix, as found in RP_FEATURES
+func ParseFeatures(s string) ([]Feature, error) {
+	var features []Feature
+
+	if s == "" {
+		return nil, nil
+	}
+
+	for _, name := range strings.Split(s, ",") {
+		f, err := FeatureString("Feature" + name)
+		if err != nil {
+			return nil, err
+		}
+
+		features = append(features, f)
+	}
+
+	return features, nil
+}
+
+// IsSet returns whether feature is set
+func (f *Features) IsSet(feature Feature) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.features[feature]
+}
+
+// IsSetForSubscription returns whether feature is set for clusters in the
+// given subscription, taking any override for the subscription into account
+func (f *Features) IsSetForSubscription(feature Feature, subscriptionID string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if set, found := f.overrides[strings.ToLower(subscriptionID)][feature]; found {
+		return set
+	}
+
+	return f.features[feature]
+}
+
+// Apply replaces the runtime configuration of the features with features and
+// per subscription overrides, both keyed by feature name without its Feature
+// prefix.  Features which are not configured revert to their baseline state.
+// Unknown feature names are ignored and returned in the error, so that a typo
+// in one flag does not prevent the others from being applied.
+func (f *Features) Apply(features map[string]bool, overrides map[string]map[string]bool) error {
+	unknown := map[string]struct{}{}
+
+	parse := func(m map[string]bool) map[Feature]bool {
+		parsed := map[Feature]bool{}
+		for name, set := range m {
+			feature, err := FeatureString("Feature" + name)
+			if err != nil {
+				unknown[name] = struct{}{}
+				continue
+			}
+			parsed[feature] = set
+		}
+		return parsed
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	newFeatures := map[Feature]bool{}
+	for feature, set := range f.base {
+		newFeatures[feature] = set
+	}
+	for feature, set := range parse(features) {
+		newFeatures[feature] = set
+	}
+
+	newOverrides := map[string]map[Feature]bool{}
+	for subscriptionID, m := range overrides {
+		newOverrides[strings.ToLower(subscriptionID)] = parse(m)
+	}
+
+	f.features = newFeatures
+	f.overrides = newOverrides
+
+	if len(unknown) > 0 {
+		names := make([]string, 0, len(unknown))
+		for name := range unknown {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown features %s", strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+// Overrides returns the number of subscriptions with feature overrides
+func (f *Features) Overrides() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return len(f.overrides)
+}
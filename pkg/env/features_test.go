@@ -0,0 +1,93 @@
+package env
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFeatures(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		s       string
+		want    []Feature
+		wantErr string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "valid",
+			s:    "DisableDenyAssignments,RequireD2sV3Workers",
+			want: []Feature{FeatureDisableDenyAssignments, FeatureRequireD2sV3Workers},
+		},
+		{
+			name:    "invalid",
+			s:       "DisableDenyAssignments,Invalid",
+			wantErr: "FeatureInvalid does not belong to Feature values",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFeatures(tt.s)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestFeaturesApply(t *testing.T) {
+	f := NewFeatures(FeatureDisableDenyAssignments)
+
+	err := f.Apply(map[string]bool{
+		"DisableDenyAssignments": false,
+		"RequireD2sV3Workers":    true,
+		"Invalid":                true,
+	}, map[string]map[string]bool{
+		"00000000-0000-0000-0000-00000000000A": {
+			"RequireD2sV3Workers": false,
+			"Invalid":             true,
+		},
+	})
+	if err == nil || err.Error() != "unknown features Invalid" {
+		t.Error(err)
+	}
+
+	if f.IsSet(FeatureDisableDenyAssignments) {
+		t.Error("DisableDenyAssignments should be unset")
+	}
+	if !f.IsSet(FeatureRequireD2sV3Workers) {
+		t.Error("RequireD2sV3Workers should be set")
+	}
+	if f.IsSetForSubscription(FeatureRequireD2sV3Workers, "00000000-0000-0000-0000-00000000000a") {
+		t.Error("RequireD2sV3Workers should be unset for overridden subscription")
+	}
+	if !f.IsSetForSubscription(FeatureRequireD2sV3Workers, "00000000-0000-0000-0000-00000000000b") {
+		t.Error("RequireD2sV3Workers should be set for other subscriptions")
+	}
+	if f.Overrides() != 1 {
+		t.Error(f.Overrides())
+	}
+
+	// features which are no longer configured revert to their baseline
+	err = f.Apply(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !f.IsSet(FeatureDisableDenyAssignments) {
+		t.Error("DisableDenyAssignments should be set")
+	}
+	if f.IsSetForSubscription(FeatureRequireD2sV3Workers, "00000000-0000-0000-0000-00000000000b") {
+		t.Error("RequireD2sV3Workers should be unset")
+	}
+	if f.Overrides() != 0 {
+		t.Error(f.Overrides())
+	}
+}
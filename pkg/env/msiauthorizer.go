@@ -4,13 +4,18 @@ package env
 // Licensed under the Apache License 2.0.
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/jongio/azidext/go/azidext"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 )
 
 type MSIContext string
@@ -57,3 +62,24 @@ func (c *core) NewMSIAuthorizer(scopes ...string) (autorest.Authorizer, error) {
 	}
 	return azidext.NewTokenCredentialAdapter(token, scopes), nil
 }
+
+// newFederatedCredential returns a credential for the application clientID in
+// tenantID which presents a token of msi as its client assertion.  The
+// application must trust msi through a federated identity credential.  The
+// assertion is requested whenever a new application token is needed, and msi
+// caches and refreshes its own tokens, so neither can go stale.
+func newFederatedCredential(environment *azureclient.AROEnvironment, tenantID, clientID string, msi azcore.TokenCredential) (azcore.TokenCredential, error) {
+	if environment.TokenExchangeScope == "" {
+		return nil, errors.New("cloud environment does not support federated credentials")
+	}
+
+	getAssertion := func(ctx context.Context) (string, error) {
+		token, err := msi.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{environment.TokenExchangeScope}})
+		if err != nil {
+			return "", err
+		}
+		return token.Token, nil
+	}
+
+	return azidentity.NewClientAssertionCredential(tenantID, clientID, getAssertion, environment.ClientAssertionCredentialOptions())
+}
@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	"github.com/Azure/go-autorest/autorest"
@@ -47,8 +48,11 @@ type prod struct {
 	acrDomain string
 	vmskus    map[string]*mgmtcompute.ResourceSku
 
+	// fpCertificateRefresher is nil in AuthModeManagedIdentity, where the first
+	// party application federates with msiCredential instead
 	fpCertificateRefresher CertificateRefresher
 	fpClientID             string
+	msiCredential          azcore.TokenCredential
 
 	clusterKeyvault keyvault.Manager
 	serviceKeyvault keyvault.Manager
@@ -64,7 +68,7 @@ type prod struct {
 
 	log *logrus.Entry
 
-	features map[Feature]bool
+	features *Features
 }
 
 func newProd(ctx context.Context, log *logrus.Entry, component ServiceComponent) (*prod, error) {
@@ -91,6 +95,10 @@ func newProd(ctx context.Context, log *logrus.Entry, component ServiceComponent)
 		return nil, err
 	}
 
+	if core.IsLocalDevelopmentMode() && core.AuthMode() == AuthModeManagedIdentity {
+		return nil, fmt.Errorf("%s is not supported in development mode", AuthModeManagedIdentity)
+	}
+
 	dialer, err := proxy.NewDialer(core.IsLocalDevelopmentMode())
 	if err != nil {
 		return nil, err
@@ -108,21 +116,13 @@ func newProd(ctx context.Context, log *logrus.Entry, component ServiceComponent)
 		clusterGenevaLoggingNamespace:     os.Getenv("CLUSTER_MDSD_NAMESPACE"),
 
 		log: log,
-
-		features: map[Feature]bool{},
 	}
 
-	features := os.Getenv("RP_FEATURES")
-	if features != "" {
-		for _, feature := range strings.Split(features, ",") {
-			f, err := FeatureString("Feature" + feature)
-			if err != nil {
-				return nil, err
-			}
-
-			p.features[f] = true
-		}
+	features, err := ParseFeatures(os.Getenv("RP_FEATURES"))
+	if err != nil {
+		return nil, err
 	}
+	p.features = NewFeatures(features...)
 
 	msiAuthorizer, err := p.NewMSIAuthorizer(p.Environment().ResourceManagerScope)
 	if err != nil {
@@ -147,10 +147,17 @@ func newProd(ctx context.Context, log *logrus.Entry, component ServiceComponent)
 		return nil, err
 	}
 
-	p.fpCertificateRefresher = newCertificateRefresher(log, 1*time.Hour, p.serviceKeyvault, RPFirstPartySecretName)
-	err = p.fpCertificateRefresher.Start(ctx)
-	if err != nil {
-		return nil, err
+	if p.AuthMode() == AuthModeManagedIdentity {
+		p.msiCredential, err = p.NewMSITokenCredential()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		p.fpCertificateRefresher = NewCertificateRefresher(log, 1*time.Hour, p.serviceKeyvault, RPFirstPartySecretName)
+		err = p.fpCertificateRefresher.Start(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	localFPKVAuthorizer, err := p.FPAuthorizer(p.TenantID(), p.Environment().KeyVaultScope)
@@ -161,7 +168,7 @@ func newProd(ctx context.Context, log *logrus.Entry, component ServiceComponent)
 	clusterKeyvaultURI := keyvault.URI(p, ClusterKeyvaultSuffix, keyVaultPrefix)
 	p.clusterKeyvault = keyvault.NewManager(localFPKVAuthorizer, clusterKeyvaultURI)
 
-	clusterGenevaLoggingPrivateKey, clusterGenevaLoggingCertificates, err := p.serviceKeyvault.GetCertificateSecret(ctx, ClusterLoggingSecretName)
+	clusterGenevaLoggingPrivateKey, clusterGenevaLoggingCertificates, err := p.serviceKeyvault.GetLatestCertificateSecret(ctx, ClusterLoggingSecretName)
 	if err != nil {
 		return nil, err
 	}
@@ -178,8 +185,8 @@ func newProd(ctx context.Context, log *logrus.Entry, component ServiceComponent)
 		p.acrDomain = acrResource.ResourceName + "." + p.Environment().ContainerRegistryDNSSuffix
 		acrDataDomain = acrResource.ResourceName + "." + p.Location() + ".data." + p.Environment().ContainerRegistryDNSSuffix
 	} else {
-		p.acrDomain = "arointsvc." + azure.PublicCloud.ContainerRegistryDNSSuffix                             // TODO: make cloud aware once this is set up for US Gov Cloud
-		acrDataDomain = "arointsvc." + p.Location() + ".data." + azure.PublicCloud.ContainerRegistryDNSSuffix // TODO: make cloud aware once this is set up for US Gov Cloud
+		p.acrDomain = "arointsvc." + p.Environment().ContainerRegistryDNSSuffix
+		acrDataDomain = "arointsvc." + p.Location() + ".data." + p.Environment().ContainerRegistryDNSSuffix
 	}
 
 	if !p.IsLocalDevelopmentMode() {
@@ -311,11 +318,19 @@ func (p *prod) Domain() string {
 }
 
 func (p *prod) FeatureIsSet(f Feature) bool {
-	return p.features[f]
+	return p.features.IsSet(f)
+}
+
+func (p *prod) FeatureIsSetForSubscription(f Feature, subscriptionID string) bool {
+	return p.features.IsSetForSubscription(f, subscriptionID)
+}
+
+func (p *prod) Features() *Features {
+	return p.features
 }
 
 func (p *prod) FPAuthorizer(tenantID string, scopes ...string) (autorest.Authorizer, error) {
-	fpTokenCredential, err := p.FPNewClientCertificateCredential(tenantID)
+	fpTokenCredential, err := p.FPNewTokenCredential(tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -359,7 +374,21 @@ func (p *prod) LiveConfig() liveconfig.Manager {
 	return p.liveConfig
 }
 
-func (p *prod) FPNewClientCertificateCredential(tenantID string) (*azidentity.ClientCertificateCredential, error) {
+// FPCertificateRefresher returns the refresher of the first party
+// application's certificate, or nil in AuthModeManagedIdentity
+func (p *prod) FPCertificateRefresher() CertificateRefresher {
+	return p.fpCertificateRefresher
+}
+
+// FPNewTokenCredential returns a credential for the first party application in
+// tenantID.  In AuthModeManagedIdentity the application trusts the service's
+// managed identity through a federated credential; otherwise it authenticates
+// with its certificate.
+func (p *prod) FPNewTokenCredential(tenantID string) (azcore.TokenCredential, error) {
+	if p.msiCredential != nil {
+		return newFederatedCredential(p.Environment(), tenantID, p.fpClientID, p.msiCredential)
+	}
+
 	fpPrivateKey, fpCertificates := p.fpCertificateRefresher.GetCertificates()
 
 	options := p.Environment().ClientCertificateCredentialOptions()
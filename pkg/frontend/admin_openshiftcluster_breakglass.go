@@ -0,0 +1,161 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/breakglass"
+)
+
+func (f *frontend) postAdminOpenShiftClusterBreakGlassKubeconfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._postAdminOpenShiftClusterBreakGlassKubeconfig(ctx, r, log)
+
+	adminReply(log, w, nil, b, err)
+}
+
+// _postAdminOpenShiftClusterBreakGlassKubeconfig mints a short-lived
+// break-glass kubeconfig for the caller and records its issuance as a portal
+// session, so that it is listed alongside portal access to the cluster
+func (f *frontend) _postAdminOpenShiftClusterBreakGlassKubeconfig(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	username := r.Context().Value(middleware.ContextKeyCorrelationData).(*api.CorrelationData).ClientPrincipalName
+	if username == "" {
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "A client principal name is required to issue a break-glass kubeconfig.")
+	}
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	if doc.OpenShiftCluster.Properties.BreakGlassCA == nil {
+		return nil, api.NewCloudError(http.StatusConflict, api.CloudErrorCodeRequestNotAllowed, "", "The cluster has no break-glass certificate authority; run an admin update first.")
+	}
+
+	now := time.Now()
+
+	b, cert, err := breakglass.NewKubeconfig(doc.OpenShiftCluster, username, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = f.dbPortalSessions.Create(ctx, &api.PortalSessionDocument{
+		ID:  f.dbPortalSessions.NewUUID(),
+		Key: strings.ToLower(resourceID),
+		PortalSession: &api.PortalSession{
+			Username:              username,
+			ResourceID:            doc.OpenShiftCluster.ID,
+			Kind:                  api.PortalSessionKindBreakGlass,
+			Elevated:              true,
+			BreakGlassCertificate: cert,
+			CreationTime:          int(now.Unix()),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithField("commonName", cert.CommonName).Infof("issued break-glass kubeconfig with serial %d", cert.Serial)
+
+	return b, nil
+}
+
+func (f *frontend) postAdminOpenShiftClusterRotateBreakGlassCA(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f._postAdminOpenShiftClusterRotateBreakGlassCA(ctx, r, log)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+// _postAdminOpenShiftClusterRotateBreakGlassCA replaces the cluster
+// break-glass certificate authority, revoking every break-glass kubeconfig
+// issued so far.  If the cluster cannot be updated, the new certificate
+// authority is pushed to it by the next admin update, and the request can be
+// retried.
+func (f *frontend) _postAdminOpenShiftClusterRotateBreakGlassCA(ctx context.Context, r *http.Request, log *logrus.Entry) error {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	ca, err := breakglass.NewCA()
+	if err != nil {
+		return err
+	}
+
+	doc, err := f.dbOpenShiftClusters.Patch(ctx, resourceID, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.BreakGlassCA = ca
+		return nil
+	})
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return err
+	}
+
+	k, err := f.kubeActionsFactory(log, f.env, doc.OpenShiftCluster)
+	if err != nil {
+		return err
+	}
+
+	cm := &unstructured.Unstructured{}
+	b, err := k.KubeGet(ctx, "ConfigMap", breakglass.ClientCANamespace, breakglass.ClientCAName)
+	switch {
+	case kerrors.IsNotFound(err):
+		cm.SetAPIVersion("v1")
+		cm.SetKind("ConfigMap")
+		cm.SetNamespace(breakglass.ClientCANamespace)
+		cm.SetName(breakglass.ClientCAName)
+	case err != nil:
+		return err
+	default:
+		err = cm.UnmarshalJSON(b)
+		if err != nil {
+			return err
+		}
+	}
+
+	bundle, _, err := unstructured.NestedString(cm.Object, "data", breakglass.ClientCAKey)
+	if err != nil {
+		return err
+	}
+
+	bundle, _, err = breakglass.UpdateClientCABundle(bundle, ca)
+	if err != nil {
+		return err
+	}
+
+	err = unstructured.SetNestedField(cm.Object, bundle, "data", breakglass.ClientCAKey)
+	if err != nil {
+		return err
+	}
+
+	log.Info("rotating break-glass certificate authority")
+
+	return k.KubeCreateOrUpdate(ctx, cm)
+}
@@ -0,0 +1,295 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/breakglass"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminBreakGlassKubeconfig(t *testing.T) {
+	ctx := context.Background()
+
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+
+	ca, err := breakglass.NewCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type test struct {
+		name           string
+		breakGlassCA   []byte
+		username       string
+		wantStatusCode int
+		wantError      string
+		wantSession    bool
+	}
+
+	for _, tt := range []*test{
+		{
+			name:           "kubeconfig issued",
+			breakGlassCA:   ca,
+			username:       "sre@example.com",
+			wantStatusCode: http.StatusOK,
+			wantSession:    true,
+		},
+		{
+			name:           "no certificate authority",
+			username:       "sre@example.com",
+			wantStatusCode: http.StatusConflict,
+			wantError:      "409: RequestNotAllowed: : The cluster has no break-glass certificate authority; run an admin update first.",
+		},
+		{
+			name:           "no client principal name",
+			breakGlassCA:   ca,
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: : A client principal name is required to issue a break-glass kubeconfig.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithPortalSessions()
+			defer ti.done()
+
+			ti.fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ClusterProfile: api.ClusterProfile{
+							Domain: "cluster.location.aroapp.io",
+						},
+						APIServerProfile: api.APIServerProfile{
+							URL: "https://api.example.com:6443/",
+						},
+						BreakGlassCA: tt.breakGlassCA,
+					},
+				},
+			})
+
+			if tt.wantSession {
+				ti.checker.AddPortalSessionDocuments(&api.PortalSessionDocument{
+					Key: strings.ToLower(resourceID),
+					PortalSession: &api.PortalSession{
+						Username:   tt.username,
+						ResourceID: resourceID,
+						Kind:       api.PortalSessionKindBreakGlass,
+						Elevated:   true,
+						BreakGlassCertificate: &api.BreakGlassCertificate{
+							CommonName: breakglass.UsernamePrefix + tt.username,
+						},
+					},
+				})
+			}
+
+			err := ti.buildFixtures(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, nil, nil, ti.openShiftClustersDatabase, nil, nil, ti.portalSessionsDatabase, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			header := http.Header{}
+			if tt.username != "" {
+				header.Set("X-Ms-Client-Principal-Name", tt.username)
+			}
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server/admin%s/breakglasskubeconfig", resourceID),
+				header, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantError != "" {
+				err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, nil)
+				if err != nil {
+					t.Error(err)
+				}
+			} else {
+				if resp.StatusCode != tt.wantStatusCode {
+					t.Fatalf("unexpected status code %d, wanted %d", resp.StatusCode, tt.wantStatusCode)
+				}
+
+				var kubeconfig *clientcmdv1.Config
+				err = json.Unmarshal(b, &kubeconfig)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				cert, err := utilpem.ParseFirstCertificate(kubeconfig.AuthInfos[0].AuthInfo.ClientCertificateData)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if cert.Subject.CommonName != breakglass.UsernamePrefix+tt.username {
+					t.Error(cert.Subject.CommonName)
+				}
+			}
+
+			for _, err := range ti.checker.CheckPortalSessions(ti.portalSessionsClient) {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestAdminRotateBreakGlassCA(t *testing.T) {
+	ctx := context.Background()
+
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+
+	oldCA, err := breakglass.NewCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldBundle, _, err := breakglass.UpdateClientCABundle("", oldCA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type test struct {
+		name           string
+		existing       func() ([]byte, error)
+		wantStatusCode int
+	}
+
+	for _, tt := range []*test{
+		{
+			name: "replaces the previous certificate authority",
+			existing: func() ([]byte, error) {
+				cm := &unstructured.Unstructured{}
+				cm.SetAPIVersion("v1")
+				cm.SetKind("ConfigMap")
+				cm.SetNamespace(breakglass.ClientCANamespace)
+				cm.SetName(breakglass.ClientCAName)
+				err := unstructured.SetNestedField(cm.Object, oldBundle, "data", breakglass.ClientCAKey)
+				if err != nil {
+					return nil, err
+				}
+				return cm.MarshalJSON()
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "creates the client CA bundle",
+			existing: func() ([]byte, error) {
+				return nil, kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, breakglass.ClientCAName)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters()
+			defer ti.done()
+
+			var pushed *unstructured.Unstructured
+			k := mock_adminactions.NewMockKubeActions(ti.controller)
+			k.EXPECT().
+				KubeGet(gomock.Any(), "ConfigMap", breakglass.ClientCANamespace, breakglass.ClientCAName).
+				DoAndReturn(func(context.Context, string, string, string) ([]byte, error) {
+					return tt.existing()
+				})
+			k.EXPECT().
+				KubeCreateOrUpdate(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, o *unstructured.Unstructured) error {
+					pushed = o
+					return nil
+				})
+
+			ti.fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						BreakGlassCA: oldCA,
+					},
+				},
+			})
+
+			err := ti.buildFixtures(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, nil, nil, ti.openShiftClustersDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (adminactions.KubeActions, error) {
+				return k, nil
+			}, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server/admin%s/rotatebreakglassca", resourceID),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, "", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := ti.openShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if bytes.Equal(doc.OpenShiftCluster.Properties.BreakGlassCA, oldCA) {
+				t.Fatal("certificate authority was not rotated")
+			}
+
+			_, caCerts, err := utilpem.Parse(doc.OpenShiftCluster.Properties.BreakGlassCA)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bundle, _, err := unstructured.NestedString(pushed.Object, "data", breakglass.ClientCAKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, certs, err := utilpem.Parse([]byte(bundle))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(certs) != 1 || !certs[0].Equal(caCerts[0]) {
+				t.Errorf("unexpected client CA bundle %s", bundle)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/gateway"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+type fakeGatewayDiagnostics struct {
+	diag *gateway.AggregatedDiagnostics
+	err  error
+}
+
+func (c *fakeGatewayDiagnostics) Diagnostics(ctx context.Context, clusterResourceID string) (*gateway.AggregatedDiagnostics, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	if !strings.EqualFold(clusterResourceID, c.diag.ResourceID) {
+		return nil, gateway.ErrDiagnosticsNotFound
+	}
+
+	return c.diag, nil
+}
+
+func TestAdminGatewayDiagnostics(t *testing.T) {
+	ctx := context.Background()
+
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+
+	diag := &gateway.AggregatedDiagnostics{
+		ResourceID:         resourceID,
+		AllowedConnections: 3,
+		DeniedConnections:  1,
+		Instances: []*gateway.Diagnostics{
+			{
+				ResourceID:         resourceID,
+				Hostname:           "gateway-vmss000000",
+				StartTime:          time.Unix(1, 0).UTC(),
+				AllowedConnections: 3,
+				DeniedConnections:  1,
+				Rejections: []*gateway.DiagnosticsRejection{
+					{
+						Time:     time.Unix(2, 0).UTC(),
+						Protocol: "https",
+						Hostname: "example.com",
+						Reason:   "hostname not allowed",
+					},
+				},
+				DNS: []*gateway.DiagnosticsDNSResult{
+					{
+						Hostname:  "management.azure.com",
+						Addresses: []string{"10.0.0.1"},
+					},
+				},
+			},
+		},
+	}
+
+	clusterDoc := func(gatewayEnabled bool) func(*testdatabase.Fixture) {
+		return func(f *testdatabase.Fixture) {
+			f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						FeatureProfile: api.FeatureProfile{
+							GatewayEnabled: gatewayEnabled,
+						},
+					},
+				},
+			})
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		fixture        func(*testdatabase.Fixture)
+		client         gateway.DiagnosticsClient
+		wantStatusCode int
+		wantResponse   *gateway.AggregatedDiagnostics
+		wantError      string
+	}{
+		{
+			name:           "diagnostics returned",
+			fixture:        clusterDoc(true),
+			client:         &fakeGatewayDiagnostics{diag: diag},
+			wantStatusCode: http.StatusOK,
+			wantResponse:   diag,
+		},
+		{
+			name:           "diagnostics not configured",
+			fixture:        clusterDoc(true),
+			wantStatusCode: http.StatusNotImplemented,
+			wantError:      "501: InternalServerError: : Gateway diagnostics are not configured in this region.",
+		},
+		{
+			name:           "cluster not found",
+			client:         &fakeGatewayDiagnostics{diag: diag},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+		{
+			name:           "gateway not enabled",
+			fixture:        clusterDoc(false),
+			client:         &fakeGatewayDiagnostics{diag: diag},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidRequestContent: : The cluster does not use the gateway.",
+		},
+		{
+			name:           "gateway record not found",
+			fixture:        clusterDoc(true),
+			client:         &fakeGatewayDiagnostics{diag: &gateway.AggregatedDiagnostics{ResourceID: "other"}},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: : The gateway has no record of the cluster.",
+		},
+		{
+			name:           "gateway error",
+			fixture:        clusterDoc(true),
+			client:         &fakeGatewayDiagnostics{err: fmt.Errorf("unexpected status code 403")},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "500: InternalServerError: : Internal server error.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters()
+			defer ti.done()
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, nil, nil, ti.openShiftClustersDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.gatewayDiagnostics = tt.client
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodGet,
+				fmt.Sprintf("https://server/admin%s/gatewaydiagnostics", resourceID),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
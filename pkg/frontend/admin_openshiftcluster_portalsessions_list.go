@@ -0,0 +1,67 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+func (f *frontend) listAdminOpenShiftClusterPortalSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._listAdminOpenShiftClusterPortalSessions(ctx, strings.TrimPrefix(r.URL.Path, "/admin"))
+
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _listAdminOpenShiftClusterPortalSessions(ctx context.Context, resourceID string) ([]byte, error) {
+	docs, err := f.dbPortalSessions.ListByClusterResourceID(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &admin.PortalSessionList{
+		PortalSessions: make([]*admin.PortalSession, 0, len(docs.PortalSessionDocuments)),
+	}
+	for _, doc := range docs.PortalSessionDocuments {
+		ps := &admin.PortalSession{
+			Username:     doc.PortalSession.Username,
+			Kind:         string(doc.PortalSession.Kind),
+			Elevated:     doc.PortalSession.Elevated,
+			Master:       doc.PortalSession.Master,
+			Namespace:    doc.PortalSession.Namespace,
+			Pod:          doc.PortalSession.Pod,
+			Container:    doc.PortalSession.Container,
+			Command:      doc.PortalSession.Command,
+			CreationTime: doc.PortalSession.CreationTime,
+		}
+
+		if doc.PortalSession.SSHCertificate != nil {
+			ps.CertificateKeyID = doc.PortalSession.SSHCertificate.KeyID
+			ps.CertificateSerial = doc.PortalSession.SSHCertificate.Serial
+			ps.CertificateValidBefore = doc.PortalSession.SSHCertificate.ValidBefore
+		}
+
+		if doc.PortalSession.BreakGlassCertificate != nil {
+			ps.CertificateKeyID = doc.PortalSession.BreakGlassCertificate.CommonName
+			ps.CertificateSerial = doc.PortalSession.BreakGlassCertificate.Serial
+			ps.CertificateValidBefore = doc.PortalSession.BreakGlassCertificate.NotAfter
+		}
+
+		l.PortalSessions = append(l.PortalSessions, ps)
+	}
+
+	return json.MarshalIndent(l, "", "    ")
+}
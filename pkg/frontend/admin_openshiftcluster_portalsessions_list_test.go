@@ -0,0 +1,155 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminListOpenShiftClusterPortalSessions(t *testing.T) {
+	ctx := context.Background()
+
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+
+	type test struct {
+		name           string
+		fixture        func(*testdatabase.Fixture)
+		wantStatusCode int
+		wantResponse   *admin.PortalSessionList
+	}
+
+	for _, tt := range []*test{
+		{
+			name: "sessions exist",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddPortalSessionDocuments(
+					&api.PortalSessionDocument{
+						Key: strings.ToLower(resourceID),
+						PortalSession: &api.PortalSession{
+							Username:     "username",
+							ResourceID:   resourceID,
+							Kind:         api.PortalSessionKindKubeconfig,
+							CreationTime: 1,
+						},
+					},
+					&api.PortalSessionDocument{
+						Key: strings.ToLower(resourceID),
+						PortalSession: &api.PortalSession{
+							Username:   "username",
+							ResourceID: resourceID,
+							Kind:       api.PortalSessionKindSSH,
+							Elevated:   true,
+							Master:     2,
+							SSHCertificate: &api.SSHCertificate{
+								KeyID:       "keyid",
+								Serial:      42,
+								ValidBefore: 3,
+							},
+							CreationTime: 2,
+						},
+					},
+					&api.PortalSessionDocument{
+						Key: strings.ToLower(resourceID),
+						PortalSession: &api.PortalSession{
+							Username:   "username",
+							ResourceID: resourceID,
+							Kind:       api.PortalSessionKindBreakGlass,
+							Elevated:   true,
+							BreakGlassCertificate: &api.BreakGlassCertificate{
+								CommonName: "system:aro-break-glass:username",
+								Serial:     43,
+								NotAfter:   5,
+							},
+							CreationTime: 4,
+						},
+					},
+					&api.PortalSessionDocument{
+						Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "otherResourceName")),
+						PortalSession: &api.PortalSession{
+							Username:     "username",
+							ResourceID:   testdatabase.GetResourcePath(mockSubID, "otherResourceName"),
+							Kind:         api.PortalSessionKindKubeconfig,
+							CreationTime: 3,
+						},
+					},
+				)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.PortalSessionList{
+				PortalSessions: []*admin.PortalSession{
+					{
+						Username:               "username",
+						Kind:                   "BreakGlass",
+						Elevated:               true,
+						CertificateKeyID:       "system:aro-break-glass:username",
+						CertificateSerial:      43,
+						CertificateValidBefore: 5,
+						CreationTime:           4,
+					},
+					{
+						Username:               "username",
+						Kind:                   "SSH",
+						Elevated:               true,
+						Master:                 2,
+						CertificateKeyID:       "keyid",
+						CertificateSerial:      42,
+						CertificateValidBefore: 3,
+						CreationTime:           2,
+					},
+					{
+						Username:     "username",
+						Kind:         "Kubeconfig",
+						CreationTime: 1,
+					},
+				},
+			},
+		},
+		{
+			name:           "no sessions",
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.PortalSessionList{
+				PortalSessions: []*admin.PortalSession{},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithPortalSessions()
+			defer ti.done()
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, nil, nil, nil, nil, nil, ti.portalSessionsDatabase, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodGet,
+				fmt.Sprintf("https://server/admin%s/portalsessions", resourceID),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, "", tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+func (f *frontend) postAdminOpenShiftClusterRestore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._postAdminOpenShiftClusterRestore(ctx, r)
+
+	adminReply(log, w, nil, b, err)
+}
+
+// _postAdminOpenShiftClusterRestore reinstates the most recently deleted
+// cluster document with the request's resource ID from its tombstone
+func (f *frontend) _postAdminOpenShiftClusterRestore(ctx context.Context, r *http.Request) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Restore(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"No deleted Resource '%s/%s' under resource group '%s' was found.",
+			resType, resName, resGroupName)
+	case cosmosdb.IsErrorStatusCode(err, http.StatusPreconditionFailed):
+		return nil, api.NewCloudError(http.StatusConflict, api.CloudErrorCodeRequestNotAllowed, "",
+			"Resource '%s/%s' under resource group '%s' cannot be restored as its name or one of its unique keys is in use.",
+			resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	converter := f.apis[adminAPIVersion(r)].OpenShiftClusterConverter
+
+	return json.MarshalIndent(converter.ToExternal(doc.OpenShiftCluster), "", "    ")
+}
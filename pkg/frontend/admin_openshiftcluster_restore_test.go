@@ -0,0 +1,152 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminRestoreOpenShiftCluster(t *testing.T) {
+	ctx := context.Background()
+
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+
+	type test struct {
+		name           string
+		softDelete     bool
+		reuseKey       bool
+		wantStatusCode int
+		wantResponse   *admin.OpenShiftCluster
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name:           "cluster was deleted",
+			softDelete:     true,
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OpenShiftCluster{
+				ID:   resourceID,
+				Name: "resourceName",
+				Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+				Properties: admin.OpenShiftClusterProperties{
+					ProvisioningState:       admin.ProvisioningStateFailed,
+					FailedProvisioningState: admin.ProvisioningStateDeleting,
+				},
+			},
+		},
+		{
+			name:           "cluster was not deleted",
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : No deleted Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was found.",
+		},
+		{
+			name:           "resource ID was reused",
+			softDelete:     true,
+			reuseKey:       true,
+			wantStatusCode: http.StatusConflict,
+			wantError:      "409: RequestNotAllowed: : Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' cannot be restored as its name or one of its unique keys is in use.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters()
+			defer ti.done()
+
+			newDoc := func() *api.OpenShiftClusterDocument {
+				return &api.OpenShiftClusterDocument{
+					ID:  ti.openShiftClustersDatabase.NewUUID(),
+					Key: strings.ToLower(resourceID),
+					// the fake enforces the unique client ID key, so reusing
+					// it makes the restore conflict
+					ClientIDKey: "11111111-1111-1111-1111-111111111111",
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   resourceID,
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateDeleting,
+							ClusterProfile: api.ClusterProfile{
+								PullSecret: "{}",
+							},
+						},
+					},
+				}
+			}
+
+			err := ti.buildFixtures(func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(newDoc())
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.softDelete {
+				doc, err := ti.openShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = ti.openShiftClustersDatabase.SoftDelete(ctx, doc)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.reuseKey {
+				_, err = ti.openShiftClustersDatabase.Create(ctx, newDoc())
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, nil, nil, ti.openShiftClustersDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server/admin%s/restore", resourceID),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if tt.wantResponse != nil {
+				doc, err := ti.openShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if doc.SoftDeleted != nil {
+					t.Error("expected document to be restored")
+				}
+
+				// the restored cluster is not dequeued and deleted again
+				dequeued, err := ti.openShiftClustersDatabase.Dequeue(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if dequeued != nil {
+					t.Error("expected the restored document not to be dequeued")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+func (f *frontend) listAdminSoftDeletedOpenShiftClusters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._listAdminSoftDeletedOpenShiftClusters(ctx, r)
+
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _listAdminSoftDeletedOpenShiftClusters(ctx context.Context, r *http.Request) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	docs, err := f.dbOpenShiftClusters.ListSoftDeleted(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs.OpenShiftClusterDocuments) == 0 {
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"No deleted Resource '%s/%s' under resource group '%s' was found.",
+			resType, resName, resGroupName)
+	}
+
+	sort.Slice(docs.OpenShiftClusterDocuments, func(i, j int) bool {
+		return docs.OpenShiftClusterDocuments[i].SoftDeleted.DeletionTime > docs.OpenShiftClusterDocuments[j].SoftDeleted.DeletionTime
+	})
+
+	converter := f.apis[adminAPIVersion(r)].OpenShiftClusterConverter
+
+	l := &admin.SoftDeletedOpenShiftClusterList{
+		SoftDeletedOpenShiftClusters: make([]*admin.SoftDeletedOpenShiftCluster, 0, len(docs.OpenShiftClusterDocuments)),
+	}
+	for _, doc := range docs.OpenShiftClusterDocuments {
+		l.SoftDeletedOpenShiftClusters = append(l.SoftDeletedOpenShiftClusters, &admin.SoftDeletedOpenShiftCluster{
+			DeletionTime:     doc.SoftDeleted.DeletionTime,
+			OpenShiftCluster: converter.ToExternal(doc.OpenShiftCluster).(*admin.OpenShiftCluster),
+		})
+	}
+
+	return json.MarshalIndent(l, "", "    ")
+}
@@ -0,0 +1,136 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminListSoftDeletedOpenShiftClusters(t *testing.T) {
+	ctx := context.Background()
+
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+
+	type test struct {
+		name           string
+		softDelete     bool
+		wantStatusCode int
+		wantResponse   *admin.SoftDeletedOpenShiftClusterList
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name:           "cluster was deleted",
+			softDelete:     true,
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.SoftDeletedOpenShiftClusterList{
+				SoftDeletedOpenShiftClusters: []*admin.SoftDeletedOpenShiftCluster{
+					{
+						OpenShiftCluster: &admin.OpenShiftCluster{
+							ID:   resourceID,
+							Name: "resourceName",
+							Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+							Properties: admin.OpenShiftClusterProperties{
+								ProvisioningState: admin.ProvisioningStateDeleting,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "cluster was not deleted",
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : No deleted Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters()
+			defer ti.done()
+
+			err := ti.buildFixtures(func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   resourceID,
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateDeleting,
+							ClusterProfile: api.ClusterProfile{
+								PullSecret: "{}",
+							},
+						},
+					},
+				})
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.softDelete {
+				doc, err := ti.openShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = ti.openShiftClustersDatabase.SoftDelete(ctx, doc)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, nil, nil, ti.openShiftClustersDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodGet,
+				fmt.Sprintf("https://server/admin%s/softdeleted", resourceID),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantResponse != nil {
+				// the deletion time is not deterministic, so check that it is
+				// set and then clear it
+				var l *admin.SoftDeletedOpenShiftClusterList
+				err = json.Unmarshal(b, &l)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, sd := range l.SoftDeletedOpenShiftClusters {
+					if sd.DeletionTime == 0 {
+						t.Error("expected deletion time to be set")
+					}
+					sd.DeletionTime = 0
+				}
+				b, err = json.Marshal(l)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/api"
-	"github.com/Azure/ARO-RP/pkg/api/admin"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
 )
 
@@ -22,7 +21,7 @@ func (f *frontend) getAdminOpenShiftVersions(w http.ResponseWriter, r *http.Requ
 	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
 	r.URL.Path = filepath.Dir(r.URL.Path)
 
-	converter := f.apis[admin.APIVersion].OpenShiftVersionConverter
+	converter := f.apis[adminAPIVersion(r)].OpenShiftVersionConverter
 
 	// changefeed only tracks the enabled versions so use ListAll here
 	docs, err := f.dbOpenShiftVersions.ListAll(ctx)
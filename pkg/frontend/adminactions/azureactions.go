@@ -68,12 +68,12 @@ func NewAzureActions(log *logrus.Entry, env env.Interface, oc *api.OpenShiftClus
 		return nil, err
 	}
 
-	fpClientCertCred, err := env.FPNewClientCertificateCredential(env.Environment().AppLensTenantID)
+	fpTokenCred, err := env.FPNewTokenCredential(env.Environment().AppLensTenantID)
 	if err != nil {
 		return nil, err
 	}
 
-	appLensClient, err := applens.NewAppLensClient(env.Environment(), fpClientCertCred)
+	appLensClient, err := applens.NewAppLensClient(env.Environment(), fpTokenCred)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,309 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20191231preview"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20200430"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20210901preview"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20220401"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20220904"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20230401"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20230701preview"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20230904"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20231122"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20240812preview"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/bucket"
+	mock_frontend "github.com/Azure/ARO-RP/pkg/util/mocks/frontend"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+// The contract tests replay the ARM requests in testdata/contract/requests
+// against the frontend for every supported API version, and compare the
+// responses with those recorded in testdata/contract/<api-version>.  After an
+// intended change to the API, or when adding an API version, rerun them with
+// -update-contract-fixtures to record the new responses.
+var updateContractFixtures = flag.Bool("update-contract-fixtures", false, "record the responses of the API contract tests")
+
+const contractTestdata = "testdata/contract"
+
+// contractRequest is a recorded ARM request
+type contractRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// contractResponse is the recorded response of the frontend to a
+// contractRequest for an API version
+type contractResponse struct {
+	StatusCode int             `json:"statusCode"`
+	Body       json.RawMessage `json:"body,omitempty"`
+}
+
+// contractFixture is the state of the database against which the requests
+// are replayed
+func contractFixture(f *testdatabase.Fixture) {
+	const subID = "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(subID, "existing")
+
+	f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+		ID: subID,
+		Subscription: &api.Subscription{
+			State: api.SubscriptionStateRegistered,
+			Properties: &api.SubscriptionProperties{
+				TenantID: "11111111-1111-1111-1111-111111111111",
+			},
+		},
+	})
+
+	f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+		Key: strings.ToLower(resourceID),
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID:       resourceID,
+			Name:     "existing",
+			Type:     "Microsoft.RedHatOpenShift/openShiftClusters",
+			Location: "eastus",
+			Tags:     map[string]string{"key": "value"},
+			Properties: api.OpenShiftClusterProperties{
+				ArchitectureVersion: api.ArchitectureVersionV2,
+				ProvisioningState:   api.ProvisioningStateSucceeded,
+				CreatedAt:           time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				ClusterProfile: api.ClusterProfile{
+					PullSecret:           `{"auths":{}}`,
+					Domain:               "example",
+					Version:              "4.10.20",
+					ResourceGroupID:      "/subscriptions/" + subID + "/resourcegroups/aro-example",
+					FipsValidatedModules: api.FipsValidatedModulesDisabled,
+				},
+				ConsoleProfile: api.ConsoleProfile{
+					URL: "https://console-openshift-console.apps.example.eastus.aroapp.io/",
+				},
+				ServicePrincipalProfile: api.ServicePrincipalProfile{
+					ClientID:     "22222222-2222-2222-2222-222222222222",
+					ClientSecret: "clientSecret",
+				},
+				NetworkProfile: api.NetworkProfile{
+					PodCIDR:                "10.128.0.0/14",
+					ServiceCIDR:            "172.30.0.0/16",
+					OutboundType:           api.OutboundTypeLoadbalancer,
+					SoftwareDefinedNetwork: api.SoftwareDefinedNetworkOVNKubernetes,
+				},
+				MasterProfile: api.MasterProfile{
+					VMSize:           api.VMSizeStandardD8sV3,
+					SubnetID:         "/subscriptions/" + subID + "/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet/subnets/master",
+					EncryptionAtHost: api.EncryptionAtHostDisabled,
+				},
+				WorkerProfiles: []api.WorkerProfile{
+					{
+						Name:             "worker",
+						VMSize:           api.VMSizeStandardD4sV3,
+						DiskSizeGB:       128,
+						SubnetID:         "/subscriptions/" + subID + "/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet/subnets/worker",
+						Count:            3,
+						EncryptionAtHost: api.EncryptionAtHostDisabled,
+					},
+				},
+				APIServerProfile: api.APIServerProfile{
+					Visibility: api.VisibilityPublic,
+					URL:        "https://api.example.eastus.aroapp.io:6443/",
+					IP:         "1.2.3.4",
+				},
+				IngressProfiles: []api.IngressProfile{
+					{
+						Name:       "default",
+						Visibility: api.VisibilityPublic,
+						IP:         "1.2.3.5",
+					},
+				},
+				KubeadminPassword: "kubeadminPassword",
+			},
+		},
+	})
+}
+
+func TestContract(t *testing.T) {
+	ctx := context.Background()
+
+	requestPaths, err := filepath.Glob(filepath.Join(contractTestdata, "requests", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requestPaths) == 0 {
+		t.Fatal("no contract requests found")
+	}
+
+	apiVersions := make([]string, 0, len(api.APIs))
+	for apiVersion := range api.APIs {
+		if !admin.IsAPIVersion(apiVersion) {
+			apiVersions = append(apiVersions, apiVersion)
+		}
+	}
+	sort.Strings(apiVersions)
+
+	for _, apiVersion := range apiVersions {
+		for _, requestPath := range requestPaths {
+			name := strings.TrimSuffix(filepath.Base(requestPath), ".json")
+			responsePath := filepath.Join(contractTestdata, apiVersion, name+".json")
+
+			t.Run(apiVersion+"/"+name, func(t *testing.T) {
+				var req contractRequest
+				readContractFile(t, versionedContractRequestPath(apiVersion, requestPath), &req)
+
+				ti := newTestInfra(t).
+					WithOpenShiftClusters().
+					WithSubscriptions().
+					WithAsyncOperations()
+				defer ti.done()
+
+				err := ti.buildFixtures(contractFixture)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				quotaValidator := mock_frontend.NewMockQuotaValidator(ti.controller)
+				quotaValidator.EXPECT().ValidateQuota(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+				skuValidator := mock_frontend.NewMockSkuValidator(ti.controller)
+				skuValidator.EXPECT().ValidateVMSku(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+				providersValidator := mock_frontend.NewMockProvidersValidator(ti.controller)
+				providersValidator.EXPECT().ValidateProviders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+				f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				f.quotaValidator = quotaValidator
+				f.skuValidator = skuValidator
+				f.providersValidator = providersValidator
+				f.bucketAllocator = bucket.Fixed(1)
+				f.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+				f.enabledOcpVersions = map[string]*api.OpenShiftVersion{
+					"4.10.20": {
+						Properties: api.OpenShiftVersionProperties{
+							Version: "4.10.20",
+							Enabled: true,
+							Default: true,
+						},
+					},
+				}
+				f.defaultOcpVersion = "4.10.20"
+
+				go f.Run(ctx, nil, nil)
+
+				var body interface{}
+				if req.Body != nil {
+					body = req.Body
+				}
+
+				resp, b, err := ti.request(req.Method,
+					"https://server"+req.Path+"?api-version="+apiVersion,
+					http.Header{
+						"Content-Type": []string{"application/json"},
+					}, body)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := contractResponse{StatusCode: resp.StatusCode}
+				if len(b) > 0 {
+					got.Body = b
+				}
+
+				if *updateContractFixtures {
+					writeContractFile(t, responsePath, &got)
+					return
+				}
+
+				var want contractResponse
+				readContractFile(t, responsePath, &want)
+
+				if got.StatusCode != want.StatusCode {
+					t.Errorf("unexpected status code %d, wanted %d", got.StatusCode, want.StatusCode)
+				}
+
+				var gotBody, wantBody interface{}
+				unmarshalContractBody(t, got.Body, &gotBody)
+				unmarshalContractBody(t, want.Body, &wantBody)
+
+				for _, diff := range deep.Equal(gotBody, wantBody) {
+					t.Error(diff)
+				}
+			})
+		}
+	}
+}
+
+// versionedContractRequestPath returns the path of the copy of the request at
+// requestPath in testdata/contract/<api-version>/requests, if there is one.
+// API versions which decode strictly need such a copy of any request which
+// sets properties that they do not have.
+func versionedContractRequestPath(apiVersion, requestPath string) string {
+	path := filepath.Join(contractTestdata, apiVersion, "requests", filepath.Base(requestPath))
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+
+	return requestPath
+}
+
+func readContractFile(t *testing.T, path string, v interface{}) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("%s does not exist: run the test with -update-contract-fixtures to record it", path)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeContractFile(t *testing.T, path string, v interface{}) {
+	b, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0777)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(path, append(b, '\n'), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func unmarshalContractBody(t *testing.T, b []byte, v interface{}) {
+	if len(b) == 0 {
+		return
+	}
+
+	err := json.Unmarshal(b, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,61 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+)
+
+// drainTimeoutFromEnvironment returns the time for which the frontend waits for
+// in-flight requests, including admin streams, to complete on shutdown: the
+// duration in RP_FRONTEND_DRAIN_TIMEOUT, or env.DefaultRPFrontendDrainTimeout
+// if it is not set
+func drainTimeoutFromEnvironment() (time.Duration, error) {
+	s := os.Getenv("RP_FRONTEND_DRAIN_TIMEOUT")
+	if s == "" {
+		return env.DefaultRPFrontendDrainTimeout, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid RP_FRONTEND_DRAIN_TIMEOUT %q", s)
+	}
+
+	return d, nil
+}
+
+// trackInFlight counts the requests which are being served
+func (f *frontend) trackInFlight(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.inFlight.Add(1)
+		defer f.inFlight.Add(-1)
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// drain stops the frontend accepting new connections and waits for up to
+// drainTimeout for in-flight requests to complete.  Any requests still in
+// flight after that have their connections closed.
+func (f *frontend) drain() {
+	ctx, cancel := context.WithTimeout(context.Background(), f.drainTimeout)
+	defer cancel()
+
+	f.baseLog.Printf("draining %d in-flight requests", f.inFlight.Load())
+
+	err := f.s.Shutdown(ctx)
+	if err != nil {
+		f.baseLog.Warnf("%d requests still in flight after %s, closing connections", f.inFlight.Load(), f.drainTimeout)
+		_ = f.s.Close()
+		return
+	}
+
+	f.baseLog.Print("drained")
+}
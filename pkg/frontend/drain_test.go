@@ -0,0 +1,143 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+)
+
+func TestDrainTimeoutFromEnvironment(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr string
+	}{
+		{
+			name: "unset",
+			want: env.DefaultRPFrontendDrainTimeout,
+		},
+		{
+			name:  "set",
+			value: "30s",
+			want:  30 * time.Second,
+		},
+		{
+			name:    "invalid",
+			value:   "soon",
+			wantErr: `invalid RP_FRONTEND_DRAIN_TIMEOUT "soon"`,
+		},
+		{
+			name:    "negative",
+			value:   "-1s",
+			wantErr: `invalid RP_FRONTEND_DRAIN_TIMEOUT "-1s"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RP_FRONTEND_DRAIN_TIMEOUT", tt.value)
+
+			got, err := drainTimeoutFromEnvironment()
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		drainTimeout time.Duration
+		release      bool
+		wantErr      bool
+	}{
+		{
+			name:         "in-flight request completes",
+			drainTimeout: time.Minute,
+			release:      true,
+		},
+		{
+			name:         "in-flight request exceeds deadline",
+			drainTimeout: 100 * time.Millisecond,
+			wantErr:      true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+			defer close(release)
+
+			f := &frontend{
+				baseLog:      logrus.NewEntry(logrus.StandardLogger()),
+				drainTimeout: tt.drainTimeout,
+			}
+			f.s = &http.Server{
+				Handler: f.trackInFlight(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					close(started)
+					<-release
+				})),
+			}
+			go func() {
+				_ = f.s.Serve(l)
+			}()
+
+			errch := make(chan error, 1)
+			go func() {
+				resp, err := http.Get("http://" + l.Addr().String())
+				if err == nil {
+					resp.Body.Close()
+				}
+				errch <- err
+			}()
+			<-started
+
+			if f.inFlight.Load() != 1 {
+				t.Error(f.inFlight.Load())
+			}
+
+			drained := make(chan struct{})
+			go func() {
+				f.drain()
+				close(drained)
+			}()
+
+			// new connections are refused while the request is in flight
+			for {
+				c, err := net.Dial("tcp", l.Addr().String())
+				if err != nil {
+					break
+				}
+				c.Close()
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			if tt.release {
+				release <- struct{}{}
+			}
+
+			<-drained
+
+			err = <-errch
+			if (err != nil) != tt.wantErr {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -21,17 +22,22 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/gateway"
 	"github.com/Azure/ARO-RP/pkg/hive"
 	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/util/bucket"
+	"github.com/Azure/ARO-RP/pkg/util/clientauthorizer"
 	"github.com/Azure/ARO-RP/pkg/util/clusterdata"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	"github.com/Azure/ARO-RP/pkg/util/feature"
 	"github.com/Azure/ARO-RP/pkg/util/heartbeat"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	"github.com/Azure/ARO-RP/pkg/util/oidc"
 	"github.com/Azure/ARO-RP/pkg/util/recover"
 )
 
@@ -56,12 +62,14 @@ type frontend struct {
 	authMiddleware        middleware.AuthMiddleware
 	apiVersionMiddleware  middleware.ApiVersionValidator
 	maintenanceMiddleware middleware.MaintenanceMiddleware
+	bodyMiddleware        middleware.BodyMiddleware
 
 	dbAsyncOperations             database.AsyncOperations
 	dbClusterManagerConfiguration database.ClusterManagerConfigurations
 	dbOpenShiftClusters           database.OpenShiftClusters
 	dbSubscriptions               database.Subscriptions
 	dbOpenShiftVersions           database.OpenShiftVersions
+	dbPortalSessions              database.PortalSessions
 
 	defaultOcpVersion  string // always enabled
 	enabledOcpVersions map[string]*api.OpenShiftVersion
@@ -80,11 +88,27 @@ type frontend struct {
 	quotaValidator     QuotaValidator
 	providersValidator ProvidersValidator
 
+	// featureClient reports the AFEC features which subscriptions are
+	// registered for, which gate the preview properties of the API
+	featureClient feature.Client
+
 	clusterEnricher clusterdata.BestEffortEnricher
 
+	// gatewayDiagnostics is nil unless GATEWAY_DIAGNOSTICS_CLIENT_ID is set
+	gatewayDiagnostics gateway.DiagnosticsClient
+
 	l net.Listener
 	s *http.Server
 
+	// certificates holds the refreshers of the certificates which the
+	// frontend serves and authenticates with, whose age is emitted as metrics
+	certificates []env.CertificateRefresher
+
+	// inFlight is the number of requests being served; on shutdown the
+	// frontend waits for up to drainTimeout for them to complete
+	inFlight     atomic.Int64
+	drainTimeout time.Duration
+
 	bucketAllocator bucket.Allocator
 
 	startTime time.Time
@@ -117,6 +141,7 @@ func NewFrontend(ctx context.Context,
 	dbOpenShiftClusters database.OpenShiftClusters,
 	dbSubscriptions database.Subscriptions,
 	dbOpenShiftVersions database.OpenShiftVersions,
+	dbPortalSessions database.PortalSessions,
 	apis map[string]*api.Version,
 	m metrics.Emitter,
 	clusterm metrics.Emitter,
@@ -145,6 +170,7 @@ func NewFrontend(ctx context.Context,
 			Apis:     api.APIs,
 		},
 		authMiddleware: middleware.AuthMiddleware{
+			Emitter:   m,
 			AdminAuth: _env.AdminClientAuthorizer(),
 			ArmAuth:   _env.ArmClientAuthorizer(),
 		},
@@ -153,6 +179,7 @@ func NewFrontend(ctx context.Context,
 		dbOpenShiftClusters:           dbOpenShiftClusters,
 		dbSubscriptions:               dbSubscriptions,
 		dbOpenShiftVersions:           dbOpenShiftVersions,
+		dbPortalSessions:              dbPortalSessions,
 		apis:                          apis,
 		m:                             middleware.MetricsMiddleware{Emitter: m},
 		maintenanceMiddleware:         middleware.MaintenanceMiddleware{Emitter: clusterm},
@@ -161,9 +188,11 @@ func NewFrontend(ctx context.Context,
 		kubeActionsFactory:            kubeActionsFactory,
 		azureActionsFactory:           azureActionsFactory,
 
-		quotaValidator:     quotaValidator{},
-		skuValidator:       skuValidator{},
-		providersValidator: providersValidator{},
+		quotaValidator:     newQuotaValidator(dbOpenShiftClusters),
+		skuValidator:       newSkuValidator(),
+		providersValidator: newProvidersValidator(),
+
+		featureClient: feature.NewClient(dbSubscriptions, featureCacheTTL),
 
 		clusterEnricher: enricher,
 
@@ -184,22 +213,64 @@ func NewFrontend(ctx context.Context,
 		streamResponder: defaultResponder{},
 	}
 
+	drainTimeout, err := drainTimeoutFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	f.drainTimeout = drainTimeout
+
+	maxBodyBytes, err := maxBodyBytesFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	f.bodyMiddleware.MaxBodyBytes = maxBodyBytes
+
+	if diagnosticsClientID := os.Getenv("GATEWAY_DIAGNOSTICS_CLIENT_ID"); diagnosticsClientID != "" {
+		armAuthorizer, err := _env.NewMSIAuthorizer(_env.Environment().ResourceManagerScope)
+		if err != nil {
+			return nil, err
+		}
+
+		authorizer, err := _env.NewMSIAuthorizer(diagnosticsClientID + "/.default")
+		if err != nil {
+			return nil, err
+		}
+
+		f.gatewayDiagnostics = gateway.NewDiagnosticsClient(_env.Environment(), _env.SubscriptionID(), armAuthorizer, authorizer, _env.GatewayResourceGroup())
+	}
+
+	// if ARM_POP_TOKEN_ISSUER is set, ARM requests must also carry a
+	// proof-of-possession token whose access token is issued by it for
+	// ARM_POP_TOKEN_AUDIENCE
+	if popIssuer := os.Getenv("ARM_POP_TOKEN_ISSUER"); popIssuer != "" {
+		verifier, err := oidc.NewVerifier(ctx, popIssuer, os.Getenv("ARM_POP_TOKEN_AUDIENCE"))
+		if err != nil {
+			return nil, err
+		}
+
+		f.authMiddleware.ArmPoP = clientauthorizer.NewPoP(verifier)
+	}
+
 	l, err := f.env.Listen()
 	if err != nil {
 		return nil, err
 	}
 
-	key, certs, err := f.env.ServiceKeyvault().GetCertificateSecret(ctx, env.RPServerSecretName)
+	// the server certificate is refreshed in the background, so that a new
+	// version is served without restarting the frontend
+	serverCertificate := env.NewCertificateRefresher(baseLog, time.Hour, f.env.ServiceKeyvault(), env.RPServerSecretName)
+	err = serverCertificate.Start(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	f.certificates = []env.CertificateRefresher{serverCertificate}
+	if fpCertificate := f.env.FPCertificateRefresher(); fpCertificate != nil {
+		f.certificates = append(f.certificates, fpCertificate)
+	}
+
 	config := &tls.Config{
-		Certificates: []tls.Certificate{
-			{
-				PrivateKey: key,
-			},
-		},
+		GetCertificate:         serverCertificate.GetTLSCertificate,
 		NextProtos:             []string{"h2", "http/1.1"},
 		ClientAuth:             tls.RequestClientCert,
 		SessionTicketsDisabled: true,
@@ -210,10 +281,6 @@ func NewFrontend(ctx context.Context,
 		},
 	}
 
-	for _, cert := range certs {
-		config.Certificates[0].Certificate = append(config.Certificates[0].Certificate, cert.Raw)
-	}
-
 	f.l = tls.NewListener(l, config)
 
 	f.ready.Store(true)
@@ -284,6 +351,8 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 	//Admin Actions
 
 	r.Route("/admin", func(r chi.Router) {
+		r.Use(f.apiVersionMiddleware.ValidateAdminAPIVersion)
+
 		r.Route("/versions", func(r chi.Router) {
 			r.Get("/", f.getAdminOpenShiftVersions)
 			r.Put("/", f.putAdminOpenShiftVersion)
@@ -311,6 +380,16 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 
 				r.Get("/clusterdeployment", f.getAdminHiveClusterDeployment)
 
+				r.Get("/softdeleted", f.listAdminSoftDeletedOpenShiftClusters)
+				r.Post("/restore", f.postAdminOpenShiftClusterRestore)
+
+				r.Get("/portalsessions", f.listAdminOpenShiftClusterPortalSessions)
+
+				r.Post("/breakglasskubeconfig", f.postAdminOpenShiftClusterBreakGlassKubeconfig)
+				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/rotatebreakglassca", f.postAdminOpenShiftClusterRotateBreakGlassCA)
+
+				r.Get("/gatewaydiagnostics", f.getAdminOpenShiftClusterGatewayDiagnostics)
+
 				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/redeployvm", f.postAdminOpenShiftClusterRedeployVM)
 
 				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/stopvm", f.postAdminOpenShiftClusterStopVM)
@@ -352,17 +431,18 @@ func notFound(w http.ResponseWriter, r *http.Request) {
 func (f *frontend) setupRouter() chi.Router {
 	chiRouter := chi.NewMux()
 
-	chiRouter.Use(chiMiddlewares.CleanPath)
+	chiRouter.Use(chiMiddlewares.CleanPath, f.trackInFlight)
 
 	chiRouter.NotFound(f.authMiddleware.Authenticate(http.HandlerFunc(notFound)).ServeHTTP)
 	registered := chiRouter.With(
 		chiMiddlewares.CleanPath,
 		f.logMiddleware.Log,
+		middleware.Trace,
 		f.m.Metrics,
 		middleware.Panic,
 		middleware.Headers,
 		f.validateMiddleware.Validate,
-		middleware.Body,
+		f.bodyMiddleware.Body,
 		middleware.SystemData)
 	f.chiAuthenticatedRoutes(registered)
 	f.chiUnauthenticatedRoutes(registered)
@@ -374,34 +454,39 @@ func (f *frontend) Run(ctx context.Context, stop <-chan struct{}, done chan<- st
 	defer recover.Panic(f.baseLog)
 	go f.changefeed(ctx)
 
+	f.s = &http.Server{
+		Handler:     middleware.Lowercase(f.setupRouter()),
+		ReadTimeout: 10 * time.Second,
+		IdleTimeout: 2 * time.Minute,
+		ErrorLog:    log.New(f.baseLog.Writer(), "", 0),
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
 	if stop != nil {
 		go func() {
 			defer recover.Panic(f.baseLog)
 
 			<-stop
 
+			f.baseLog.Print("marking not ready")
+			f.ready.Store(false)
+
 			if !f.env.FeatureIsSet(env.FeatureDisableReadinessDelay) {
-				// mark not ready and wait for ((#probes + 1) * interval + longest
-				// connection timeout + margin) to stop receiving new connections
-				f.baseLog.Print("marking not ready and waiting 80 seconds")
-				f.ready.Store(false)
+				// wait for ((#probes + 1) * interval + longest connection
+				// timeout + margin) to stop receiving new connections
+				f.baseLog.Print("waiting 80 seconds")
 				time.Sleep(80 * time.Second)
 			}
 
+			f.drain()
+
 			f.baseLog.Print("exiting")
 			close(done)
 		}()
 	}
 
-	f.s = &http.Server{
-		Handler:     middleware.Lowercase(f.setupRouter()),
-		ReadTimeout: 10 * time.Second,
-		IdleTimeout: 2 * time.Minute,
-		ErrorLog:    log.New(f.baseLog.Writer(), "", 0),
-		BaseContext: func(net.Listener) context.Context { return ctx },
-	}
-
 	go heartbeat.EmitHeartbeat(f.baseLog, f.m, "frontend.heartbeat", stop, f.checkReady)
+	go f.emitCertificateMetrics(ctx)
 
 	err := f.s.Serve(f.l)
 	if err != http.ErrServerClosed {
@@ -409,6 +494,36 @@ func (f *frontend) Run(ctx context.Context, stop <-chan struct{}, done chan<- st
 	}
 }
 
+// emitCertificateMetrics emits the age of each certificate every minute, so
+// that a rotation which has not been picked up can be alerted on
+func (f *frontend) emitCertificateMetrics(ctx context.Context) {
+	defer recover.Panic(f.baseLog)
+
+	t := time.NewTicker(time.Minute)
+	defer t.Stop()
+
+	for {
+		for _, c := range f.certificates {
+			c.EmitMetrics(f.m)
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// adminAPIVersion returns the admin API version of the request, which
+// ValidateAdminAPIVersion has validated
+func adminAPIVersion(r *http.Request) string {
+	if apiVersion := r.URL.Query().Get(api.APIVersionKey); apiVersion != "" {
+		return apiVersion
+	}
+	return admin.APIVersion
+}
+
 func adminReply(log *logrus.Entry, w http.ResponseWriter, header http.Header, b []byte, err error) {
 	if apiErr, ok := err.(kerrors.APIStatus); ok {
 		status := apiErr.Status()
@@ -9,25 +9,44 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/util/clientauthorizer"
 )
 
 type AuthMiddleware struct {
+	metrics.Emitter
+
 	AdminAuth clientauthorizer.ClientAuthorizer
 	ArmAuth   clientauthorizer.ClientAuthorizer
+	// ArmPoP, if set, additionally requires ARM requests to carry a valid
+	// proof-of-possession token
+	ArmPoP clientauthorizer.PoPAuthorizer
 }
 
 func (a AuthMiddleware) Authenticate(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		apiVersion := r.URL.Query().Get(api.APIVersionKey)
-		var clientAuthorizer clientauthorizer.ClientAuthorizer
-		if apiVersion == admin.APIVersion || strings.HasPrefix(r.URL.Path, "/admin") {
+
+		client := "arm"
+		clientAuthorizer := a.ArmAuth
+		if admin.IsAPIVersion(apiVersion) || strings.HasPrefix(r.URL.Path, "/admin") {
+			client = "admin"
 			clientAuthorizer = a.AdminAuth
-		} else {
-			clientAuthorizer = a.ArmAuth
 		}
 
-		if !clientAuthorizer.IsAuthorized(r.TLS) {
+		err := clientAuthorizer.Authorize(r.TLS)
+		if err == nil && client == "arm" && a.ArmPoP != nil {
+			err = a.ArmPoP.Authorize(r)
+		}
+
+		if err != nil {
+			if a.Emitter != nil {
+				a.EmitGauge("frontend.auth.failure", 1, map[string]string{
+					"client": client,
+					"reason": clientauthorizer.Reason(err),
+				})
+			}
+
 			api.WriteError(w, http.StatusForbidden, api.CloudErrorCodeForbidden, "", "Forbidden.")
 			return
 		}
@@ -0,0 +1,121 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/util/clientauthorizer"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+type fakePoP struct {
+	err error
+}
+
+func (f *fakePoP) Authorize(*http.Request) error {
+	return f.err
+}
+
+func TestAuthenticate(t *testing.T) {
+	validCert := []byte("valid")
+
+	for _, tt := range []struct {
+		name       string
+		path       string
+		cert       []byte
+		pop        clientauthorizer.PoPAuthorizer
+		wantStatus int
+		wantDims   map[string]string
+	}{
+		{
+			name:       "arm client authorized",
+			path:       "/subscriptions/123",
+			cert:       validCert,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "arm client with unknown certificate",
+			path:       "/subscriptions/123",
+			cert:       []byte("invalid"),
+			wantStatus: http.StatusForbidden,
+			wantDims: map[string]string{
+				"client": "arm",
+				"reason": clientauthorizer.ReasonUnknownCertificate,
+			},
+		},
+		{
+			name:       "admin client without certificate",
+			path:       "/admin/providers/microsoft.redhatopenshift/openshiftclusters",
+			wantStatus: http.StatusForbidden,
+			wantDims: map[string]string{
+				"client": "admin",
+				"reason": clientauthorizer.ReasonNoCertificate,
+			},
+		},
+		{
+			name:       "arm client authorized with PoP token",
+			path:       "/subscriptions/123",
+			cert:       validCert,
+			pop:        &fakePoP{},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "arm client with invalid PoP token",
+			path:       "/subscriptions/123",
+			cert:       validCert,
+			pop:        &fakePoP{err: &clientauthorizer.UnauthorizedError{Reason: clientauthorizer.ReasonInvalidPoPToken}},
+			wantStatus: http.StatusForbidden,
+			wantDims: map[string]string{
+				"client": "arm",
+				"reason": clientauthorizer.ReasonInvalidPoPToken,
+			},
+		},
+		{
+			name:       "PoP token not required of admin client",
+			path:       "/admin/providers/microsoft.redhatopenshift/openshiftclusters",
+			cert:       validCert,
+			pop:        &fakePoP{err: &clientauthorizer.UnauthorizedError{Reason: clientauthorizer.ReasonMissingPoPToken}},
+			wantStatus: http.StatusOK,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if tt.wantDims != nil {
+				m.EXPECT().EmitGauge("frontend.auth.failure", int64(1), tt.wantDims)
+			}
+
+			authMiddleware := AuthMiddleware{
+				Emitter:   m,
+				AdminAuth: clientauthorizer.NewOne(validCert),
+				ArmAuth:   clientauthorizer.NewOne(validCert),
+				ArmPoP:    tt.pop,
+			}
+
+			handler := authMiddleware.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			r.TLS = &tls.ConnectionState{}
+			if tt.cert != nil {
+				r.TLS.PeerCertificates = []*x509.Certificate{{Raw: tt.cert}}
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Error(w.Code)
+			}
+		})
+	}
+}
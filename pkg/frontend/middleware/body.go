@@ -5,18 +5,39 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
 )
 
-func Body(h http.Handler) http.Handler {
+// DefaultMaxBodyBytes is the largest request body accepted when
+// BodyMiddleware.MaxBodyBytes is not set
+const DefaultMaxBodyBytes = env.DefaultRPFrontendMaxBodyBytes
+
+type BodyMiddleware struct {
+	MaxBodyBytes int64
+}
+
+func (b BodyMiddleware) Body(h http.Handler) http.Handler {
+	maxBodyBytes := b.MaxBodyBytes
+	if maxBodyBytes == 0 {
+		maxBodyBytes = DefaultMaxBodyBytes
+	}
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodPatch, http.MethodPost, http.MethodPut:
-			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1048576))
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				api.WriteError(w, http.StatusRequestEntityTooLarge, api.CloudErrorCodeRequestEntityTooLarge, "", "The request content exceeds the maximum size of %d bytes.", maxBytesErr.Limit)
+				return
+			}
 			if err != nil {
 				api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidResource, "", "The resource definition is invalid.")
 				return
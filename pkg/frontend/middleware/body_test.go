@@ -16,11 +16,12 @@ import (
 
 func TestBody(t *testing.T) {
 	tests := []struct {
-		name    string
-		isGet   bool
-		header  http.Header
-		body    []byte
-		wantErr string
+		name         string
+		isGet        bool
+		header       http.Header
+		maxBodyBytes int64
+		body         []byte
+		wantErr      string
 	}{
 		{
 			name:  "GET request - valid",
@@ -29,7 +30,21 @@ func TestBody(t *testing.T) {
 		{
 			name:    "non-GET request - large body",
 			body:    bytes.Repeat([]byte{0}, 1048577),
-			wantErr: "400: InvalidResource: : The resource definition is invalid.",
+			wantErr: "413: RequestEntityTooLarge: : The request content exceeds the maximum size of 1048576 bytes.",
+		},
+		{
+			name: "non-GET request - body within configured limit",
+			header: http.Header{
+				"Content-Type": []string{"application/json"},
+			},
+			maxBodyBytes: 2097152,
+			body:         bytes.Repeat([]byte{0}, 1048577),
+		},
+		{
+			name:         "non-GET request - body over configured limit",
+			maxBodyBytes: 16,
+			body:         bytes.Repeat([]byte{0}, 17),
+			wantErr:      "413: RequestEntityTooLarge: : The request content exceeds the maximum size of 16 bytes.",
 		},
 		{
 			name: "non-GET request - invalid media type",
@@ -77,7 +92,7 @@ func TestBody(t *testing.T) {
 
 				w := httptest.NewRecorder()
 
-				Body(http.HandlerFunc(func(w http.ResponseWriter, _r *http.Request) {
+				BodyMiddleware{MaxBodyBytes: tt.maxBodyBytes}.Body(http.HandlerFunc(func(w http.ResponseWriter, _r *http.Request) {
 					r = _r
 				})).ServeHTTP(w, r)
 
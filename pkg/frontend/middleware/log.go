@@ -72,7 +72,7 @@ func (l LogMiddleware) Log(h http.Handler) http.Handler {
 			RequestTime:     t,
 		}
 
-		if r.URL.Query().Get(api.APIVersionKey) == admin.APIVersion || isAdminOp(r) {
+		if admin.IsAPIVersion(r.URL.Query().Get(api.APIVersionKey)) || isAdminOp(r) {
 			correlationData.ClientPrincipalName = r.Header.Get("X-Ms-Client-Principal-Name")
 		}
 
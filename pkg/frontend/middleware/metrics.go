@@ -24,6 +24,7 @@ func (mm MetricsMiddleware) Metrics(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		apiVersion := r.URL.Query().Get(api.APIVersionKey)
 		t := time.Now()
+		stop := metrics.StartTimer(mm, "frontend.duration.histogram")
 
 		w = &logResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
@@ -39,11 +40,16 @@ func (mm MetricsMiddleware) Metrics(h http.Handler) http.Handler {
 			"route":       routePattern,
 		})
 
-		mm.EmitGauge("frontend.duration", time.Since(t).Milliseconds(), map[string]string{
+		dimensions := map[string]string{
 			"verb":        r.Method,
 			"api-version": apiVersion,
 			"code":        strconv.Itoa(w.(*logResponseWriter).statusCode),
 			"route":       routePattern,
-		})
+		}
+
+		// frontend.duration stays a gauge so that existing dashboards and
+		// alerts keep working; the histogram is emitted alongside it
+		mm.EmitGauge("frontend.duration", time.Since(t).Milliseconds(), dimensions)
+		stop(dimensions)
 	})
 }
@@ -0,0 +1,72 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+const tracerName = "github.com/Azure/ARO-RP/pkg/frontend/middleware"
+
+var traceContext = propagation.TraceContext{}
+
+// Trace starts a span for each request, as a child of the span in any W3C
+// Trace Context headers sent by ARM.  The trace context of the span is
+// recorded in the correlation data of the request, which is stored with the
+// documents the request creates or updates, so that the backend work done for
+// them is traced as part of the request.  Trace must follow Log.
+func Trace(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := traceContext.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		ctx, span := otel.Tracer(tracerName).Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("aro.api_version", r.URL.Query().Get(api.APIVersionKey)),
+			),
+		)
+		defer span.End()
+
+		if correlationData, ok := ctx.Value(ContextKeyCorrelationData).(*api.CorrelationData); ok {
+			span.SetAttributes(
+				attribute.String("aro.correlation_id", correlationData.CorrelationID),
+				attribute.String("aro.request_id", correlationData.RequestID),
+			)
+
+			carrier := propagation.MapCarrier{}
+			traceContext.Inject(ctx, carrier)
+			correlationData.TraceParent = carrier.Get("traceparent")
+			correlationData.TraceState = carrier.Get("tracestate")
+		}
+
+		w = &logResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		h.ServeHTTP(w, r.WithContext(ctx))
+
+		statusCode := w.(*logResponseWriter).statusCode
+		span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+		if statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(statusCode))
+		}
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			if routePattern := strings.Join(rctx.RoutePatterns, ""); routePattern != "" {
+				span.SetName(r.Method + " " + routePattern)
+				span.SetAttributes(attribute.String("http.route", routePattern))
+			}
+		}
+	})
+}
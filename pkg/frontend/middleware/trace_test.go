@@ -0,0 +1,80 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+func TestTrace(t *testing.T) {
+	const traceParent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+	for _, tt := range []struct {
+		name            string
+		header          http.Header
+		wantTraceParent string
+		wantTraceState  string
+	}{
+		{
+			name: "no trace context",
+		},
+		{
+			name: "trace context from ARM",
+			header: http.Header{
+				"Traceparent": []string{traceParent},
+				"Tracestate":  []string{"arm=1"},
+			},
+			wantTraceParent: traceParent,
+			wantTraceState:  "arm=1",
+		},
+		{
+			name: "invalid trace context",
+			header: http.Header{
+				"Traceparent": []string{"invalid"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			correlationData := &api.CorrelationData{}
+
+			r, err := http.NewRequest(http.MethodPut, "/subscriptions/00000000-0000-0000-0000-000000000000", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.Header = tt.header
+			r = r.WithContext(context.WithValue(r.Context(), ContextKeyCorrelationData, correlationData))
+
+			w := httptest.NewRecorder()
+
+			var spanContext trace.SpanContext
+			Trace(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				spanContext = trace.SpanContextFromContext(r.Context())
+				w.WriteHeader(http.StatusCreated)
+			})).ServeHTTP(w, r)
+
+			if w.Code != http.StatusCreated {
+				t.Error(w.Code)
+			}
+
+			// without a tracer provider, the span of the request is that of
+			// ARM, so the trace context is passed through unchanged
+			if correlationData.TraceParent != tt.wantTraceParent {
+				t.Error(correlationData.TraceParent)
+			}
+			if correlationData.TraceState != tt.wantTraceState {
+				t.Error(correlationData.TraceState)
+			}
+			if spanContext.IsValid() != (tt.wantTraceParent != "") {
+				t.Error(spanContext)
+			}
+		})
+	}
+}
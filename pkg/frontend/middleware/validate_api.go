@@ -4,11 +4,13 @@ package middleware
 // Licensed under the Apache License 2.0.
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
 )
 
 type ApiVersionValidator struct {
@@ -25,6 +27,10 @@ func (a ApiVersionValidator) ValidateAPIVersion(h http.Handler) http.Handler {
 			return
 		}
 
+		if admin.IsAPIVersion(apiVersion) {
+			setAdminDeprecationHeaders(w, apiVersion, false)
+		}
+
 		h.ServeHTTP(w, r)
 	})
 }
@@ -41,3 +47,46 @@ func (a ApiVersionValidator) ValidatePreflightAPIVersion(h http.Handler) http.Ha
 		h.ServeHTTP(w, r)
 	})
 }
+
+// ValidateAdminAPIVersion validates the api-version of requests to the admin
+// API.  For compatibility with existing tooling, a request without an
+// api-version is served as the unversioned admin API version, with a
+// deprecation warning.
+func (a ApiVersionValidator) ValidateAdminAPIVersion(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiVersion := r.URL.Query().Get(api.APIVersionKey)
+		implicit := apiVersion == ""
+		if implicit {
+			apiVersion = admin.APIVersion
+		}
+
+		if _, apiVersionExists := a.APIs[apiVersion]; !apiVersionExists || !admin.IsAPIVersion(apiVersion) {
+			api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, api.APIVersionKey, "The api-version '%s' is not a supported admin API version. The latest admin API version is '%s'.", apiVersion, admin.LatestAPIVersion)
+			return
+		}
+
+		setAdminDeprecationHeaders(w, apiVersion, implicit)
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// setAdminDeprecationHeaders sets the Deprecation, Sunset and Warning headers
+// on the response if the admin API version is deprecated
+func setAdminDeprecationHeaders(w http.ResponseWriter, apiVersion string, implicit bool) {
+	deprecation := admin.APIVersions[apiVersion]
+	if deprecation == nil {
+		return
+	}
+
+	w.Header().Set("Deprecation", "true")
+	if !deprecation.Sunset.IsZero() {
+		w.Header().Set("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+	}
+
+	warning := fmt.Sprintf("The admin api-version '%s' is deprecated, use '%s'.", apiVersion, deprecation.Successor)
+	if implicit {
+		warning = fmt.Sprintf("Requests without an api-version are deprecated, use api-version '%s'.", deprecation.Successor)
+	}
+	w.Header().Set("Warning", fmt.Sprintf("299 - %q", warning))
+}
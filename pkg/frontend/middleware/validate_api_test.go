@@ -0,0 +1,81 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20231122"
+)
+
+func TestValidateAdminAPIVersion(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		apiVersion      string
+		wantStatusCode  int
+		wantDeprecation bool
+		wantWarning     string
+	}{
+		{
+			name:           "latest version",
+			apiVersion:     admin.LatestAPIVersion,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:            "deprecated version",
+			apiVersion:      admin.APIVersion,
+			wantStatusCode:  http.StatusOK,
+			wantDeprecation: true,
+			wantWarning:     `299 - "The admin api-version 'admin' is deprecated, use '2024-10-01-admin'."`,
+		},
+		{
+			name:            "no version",
+			wantStatusCode:  http.StatusOK,
+			wantDeprecation: true,
+			wantWarning:     `299 - "Requests without an api-version are deprecated, use api-version '2024-10-01-admin'."`,
+		},
+		{
+			name:           "ARM version",
+			apiVersion:     "2023-11-22",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown version",
+			apiVersion:     "2099-01-01-admin",
+			wantStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a := ApiVersionValidator{APIs: api.APIs}
+
+			handler := a.ValidateAdminAPIVersion(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			path := "/admin/versions"
+			if tt.apiVersion != "" {
+				path += "?api-version=" + tt.apiVersion
+			}
+			r, err := http.NewRequest(http.MethodGet, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatusCode {
+				t.Errorf("got status code %d, want %d", w.Code, tt.wantStatusCode)
+			}
+			if (w.Header().Get("Deprecation") == "true") != tt.wantDeprecation {
+				t.Errorf("got Deprecation header %q", w.Header().Get("Deprecation"))
+			}
+			if w.Header().Get("Warning") != tt.wantWarning {
+				t.Errorf("got Warning header %q, want %q", w.Header().Get("Warning"), tt.wantWarning)
+			}
+		})
+	}
+}
@@ -55,6 +55,9 @@ func (f *frontend) _getOpenShiftClusters(ctx context.Context, log *logrus.Entry,
 	var ocs []*api.OpenShiftCluster
 	if docs != nil {
 		for _, doc := range docs.OpenShiftClusterDocuments {
+			if doc.SoftDeleted != nil {
+				continue
+			}
 			ocs = append(ocs, doc.OpenShiftCluster)
 		}
 	}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-chi/chi/v5"
 	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/api"
@@ -31,6 +32,7 @@ func (f *frontend) preflightValidation(w http.ResponseWriter, r *http.Request) {
 	var b []byte
 
 	body := r.Context().Value(middleware.ContextKeyBody).([]byte)
+	subId := chi.URLParam(r, "subscriptionId")
 
 	resources, err := unmarshalRequest(body)
 	if err != nil {
@@ -52,7 +54,7 @@ func (f *frontend) preflightValidation(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 		if strings.EqualFold(typeMeta.Type, "Microsoft.RedHatOpenShift/openShiftClusters") {
-			res := f._preflightValidation(ctx, log, raw, typeMeta.APIVersion, typeMeta.Id)
+			res := f._preflightValidation(ctx, log, raw, typeMeta.APIVersion, typeMeta.Id, subId)
 			if res.Status == api.ValidationStatusFailed {
 				log.Warningf("preflight validation failed")
 				b = marshalValidationResult(res)
@@ -67,7 +69,7 @@ func (f *frontend) preflightValidation(w http.ResponseWriter, r *http.Request) {
 	reply(log, w, header, b, statusCodeError(http.StatusOK))
 }
 
-func (f *frontend) _preflightValidation(ctx context.Context, log *logrus.Entry, raw json.RawMessage, apiVersion string, resourceID string) api.ValidationResult {
+func (f *frontend) _preflightValidation(ctx context.Context, log *logrus.Entry, raw json.RawMessage, apiVersion string, resourceID string, subId string) api.ValidationResult {
 	// unmarshal raw to OpenShiftCluster type
 	oc := &api.OpenShiftCluster{}
 	oc.Properties.ProvisioningState = api.ProvisioningStateSucceeded
@@ -89,7 +91,7 @@ func (f *frontend) _preflightValidation(ctx context.Context, log *logrus.Entry,
 	}
 
 	converter.ToInternal(ext, oc)
-	if err := staticValidator.Static(ext, nil, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), resourceID); err != nil {
+	if err := staticValidator.Static(ext, nil, f.env.Location(), f.env.Domain(), f.env.FeatureIsSetForSubscription(env.FeatureRequireD2sV3Workers, subId), resourceID); err != nil {
 		return api.ValidationResult{
 			Status: api.ValidationStatusFailed,
 			Error: &api.ManagementErrorWithDetails{
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 	"time"
 
@@ -153,9 +154,21 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 
 	converter.ExternalNoReadOnly(ext)
 
-	err = json.Unmarshal(body, &ext)
+	// the cluster as the customer sees it before the request, to find which
+	// fields a PATCH changes
+	before, err := json.Marshal(ext)
 	if err != nil {
-		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized: %q.", err)
+		return nil, err
+	}
+
+	plan, body, err := splitPlan(body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = unmarshalRequestBody(body, &ext, f.apis[apiVersion].StrictDecoding)
+	if err != nil {
+		return nil, err
 	}
 
 	if isCreate {
@@ -165,16 +178,31 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 			return nil, err
 		}
 	} else {
-		err = staticValidator.Static(ext, doc.OpenShiftCluster, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), path)
+		err = staticValidator.Static(ext, doc.OpenShiftCluster, f.env.Location(), f.env.Domain(), f.env.FeatureIsSetForSubscription(env.FeatureRequireD2sV3Workers, subId), path)
+		if err != nil {
+			return nil, err
+		}
+
+		err = f.validatePreviewFeatures(ctx, subId, ext, converter.ToExternal(doc.OpenShiftCluster))
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	oldID, oldName, oldType, oldSystemData := doc.OpenShiftCluster.ID, doc.OpenShiftCluster.Name, doc.OpenShiftCluster.Type, doc.OpenShiftCluster.SystemData
+	oldWorkerProfiles := doc.OpenShiftCluster.Properties.WorkerProfiles
 	converter.ToInternal(ext, doc.OpenShiftCluster)
 	doc.OpenShiftCluster.ID, doc.OpenShiftCluster.Name, doc.OpenShiftCluster.Type, doc.OpenShiftCluster.SystemData = oldID, oldName, oldType, oldSystemData
 
+	// the new size of resized worker profiles must be available to the
+	// subscription, in the zones of the profile
+	if !isCreate && workerProfilesResized(oldWorkerProfiles, doc.OpenShiftCluster.Properties.WorkerProfiles) {
+		err = f.skuValidator.ValidateVMSku(ctx, f.env.Environment(), f.env, subscription.ID, subscription.Subscription.Properties.TenantID, doc.OpenShiftCluster)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// This will update systemData from the values in the header. Old values, which
 	// is not provided in the header must be preserved
 	f.systemDataClusterDocEnricher(doc, systemData)
@@ -193,11 +221,31 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 		doc.ClientIDKey = strings.ToLower(doc.OpenShiftCluster.Properties.ServicePrincipalProfile.ClientID)
 		doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateCreating
 
+		// the plan of a resource can't be changed after it is created
+		doc.OpenShiftCluster.Plan = plan
+
 		doc.Bucket, err = f.bucketAllocator.Allocate()
 		if err != nil {
 			return nil, err
 		}
 	} else {
+		// a PATCH of a healthy cluster which only changes e.g. its worker
+		// profiles only reconciles them; anything else reconciles the whole
+		// cluster
+		doc.ReconcileScope = ""
+		if method == http.MethodPatch && !admin.IsAPIVersion(apiVersion) &&
+			doc.OpenShiftCluster.Properties.ProvisioningState == api.ProvisioningStateSucceeded {
+			after, err := json.Marshal(ext)
+			if err != nil {
+				return nil, err
+			}
+
+			doc.ReconcileScope, err = reconcileScope(before, after)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		setUpdateProvisioningState(doc, apiVersion)
 	}
 
@@ -278,7 +326,12 @@ func enrichClusterSystemData(doc *api.OpenShiftClusterDocument, systemData *api.
 }
 
 func (f *frontend) ValidateNewCluster(ctx context.Context, subscription *api.SubscriptionDocument, cluster *api.OpenShiftCluster, staticValidator api.OpenShiftClusterStaticValidator, ext interface{}, path string) error {
-	err := staticValidator.Static(ext, nil, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), path)
+	err := staticValidator.Static(ext, nil, f.env.Location(), f.env.Domain(), f.env.FeatureIsSetForSubscription(env.FeatureRequireD2sV3Workers, subscription.ID), path)
+	if err != nil {
+		return err
+	}
+
+	err = f.validatePreviewFeatures(ctx, subscription.ID, ext, nil)
 	if err != nil {
 		return err
 	}
@@ -301,12 +354,82 @@ func (f *frontend) ValidateNewCluster(ctx context.Context, subscription *api.Sub
 	return nil
 }
 
+// workerProfilesResized returns true if the VM size of any worker profile
+// differs between current and wps
+func workerProfilesResized(current, wps []api.WorkerProfile) bool {
+	for i := range wps {
+		if i < len(current) && wps[i].VMSize != current[i].VMSize {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reconcileScopes are the scopes which an update can be limited to, with the
+// path of the external field which they reconcile
+var reconcileScopes = []struct {
+	scope api.ReconcileScope
+	path  []string
+}{
+	{
+		scope: api.ReconcileScopeWorkerProfiles,
+		path:  []string{"properties", "workerProfiles"},
+	},
+	{
+		scope: api.ReconcileScopeLoadBalancerProfile,
+		path:  []string{"properties", "networkProfile", "loadBalancerProfile"},
+	},
+}
+
+// reconcileScope returns the scope of the update of an external cluster from
+// before to after, both marshalled to JSON: the scope whose field is the only
+// one that changed, or the empty scope, i.e. the whole cluster, otherwise
+func reconcileScope(before, after []byte) (api.ReconcileScope, error) {
+	for _, rs := range reconcileScopes {
+		var b, a map[string]interface{}
+
+		err := json.Unmarshal(before, &b)
+		if err != nil {
+			return "", err
+		}
+
+		err = json.Unmarshal(after, &a)
+		if err != nil {
+			return "", err
+		}
+
+		bv, av := removePath(b, rs.path), removePath(a, rs.path)
+		if !reflect.DeepEqual(bv, av) && reflect.DeepEqual(b, a) {
+			return rs.scope, nil
+		}
+	}
+
+	return "", nil
+}
+
+// removePath removes the field at path from m and returns its value, or nil if
+// it is not set
+func removePath(m map[string]interface{}, path []string) interface{} {
+	for _, name := range path[:len(path)-1] {
+		var ok bool
+		m, ok = m[name].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+	}
+
+	v := m[path[len(path)-1]]
+	delete(m, path[len(path)-1])
+
+	return v
+}
+
 // setUpdateProvisioningState Sets either the admin update or update provisioning state
 func setUpdateProvisioningState(doc *api.OpenShiftClusterDocument, apiVersion string) {
-	switch apiVersion {
-	case admin.APIVersion:
-		adminUpdateProvisioningState(doc)
-	default:
+	if admin.IsAPIVersion(apiVersion) {
+		api.SetAdminUpdateProvisioningState(doc)
+	} else {
 		updateProvisioningState(doc)
 	}
 }
@@ -317,33 +440,3 @@ func updateProvisioningState(doc *api.OpenShiftClusterDocument) {
 	doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateUpdating
 	doc.Dequeues = 0
 }
-
-// Admin update (ex: cluster maintenance)
-func adminUpdateProvisioningState(doc *api.OpenShiftClusterDocument) {
-	if doc.OpenShiftCluster.Properties.MaintenanceTask.IsMaintenanceOngoingTask() {
-		doc.OpenShiftCluster.Properties.LastProvisioningState = doc.OpenShiftCluster.Properties.ProvisioningState
-		doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
-		doc.OpenShiftCluster.Properties.LastAdminUpdateError = ""
-		doc.Dequeues = 0
-
-		// Set the maintenance to ongoing so we emit the appropriate signal to customerss
-		if doc.OpenShiftCluster.Properties.MaintenanceState == api.MaintenanceStatePending {
-			doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStatePlanned
-		} else {
-			doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateUnplanned
-		}
-	} else {
-		// No default needed since we're using an enum
-		switch doc.OpenShiftCluster.Properties.MaintenanceTask {
-		case api.MaintenanceTaskPending:
-			doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStatePending
-		case api.MaintenanceTaskNone:
-			doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateNone
-		case api.MaintenanceTaskCustomerActionNeeded:
-			doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateCustomerActionNeeded
-		}
-
-		// This enables future admin update actions with body `{}` to succeed
-		doc.OpenShiftCluster.Properties.MaintenanceTask = ""
-	}
-}
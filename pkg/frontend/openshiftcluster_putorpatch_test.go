@@ -1727,7 +1727,7 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2474,6 +2474,113 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "patch the worker profiles of a cluster from succeeded",
+			request: func(oc *v20200430.OpenShiftCluster) {
+				oc.Properties.WorkerProfiles = []v20200430.WorkerProfile{{Name: "changed"}}
+			},
+			isPatch: true,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Tags: map[string]string{"tag": "will-be-kept"},
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							IngressProfiles:   []api.IngressProfile{{Name: "default"}},
+							WorkerProfiles: []api.WorkerProfile{
+								{
+									Name:             "default",
+									EncryptionAtHost: api.EncryptionAtHostDisabled,
+								},
+							},
+							NetworkProfile: api.NetworkProfile{
+								SoftwareDefinedNetwork: api.SoftwareDefinedNetworkOpenShiftSDN,
+								OutboundType:           api.OutboundTypeLoadbalancer,
+							},
+							MasterProfile: api.MasterProfile{
+								EncryptionAtHost: api.EncryptionAtHostDisabled,
+							},
+							OperatorFlags: api.OperatorFlags{},
+						},
+					},
+				})
+			},
+			wantSystemDataEnriched: true,
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+					OpenShiftClusterKey: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					AsyncOperation: &api.AsyncOperation{
+						InitialProvisioningState: api.ProvisioningStateUpdating,
+						ProvisioningState:        api.ProvisioningStateUpdating,
+					},
+				})
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key:            strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					ReconcileScope: api.ReconcileScopeWorkerProfiles,
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Tags: map[string]string{"tag": "will-be-kept"},
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:     api.ProvisioningStateUpdating,
+							LastProvisioningState: api.ProvisioningStateSucceeded,
+							ClusterProfile: api.ClusterProfile{
+								FipsValidatedModules: api.FipsValidatedModulesDisabled,
+							},
+							IngressProfiles: []api.IngressProfile{{Name: "default"}},
+							WorkerProfiles: []api.WorkerProfile{
+								{
+									Name:             "changed",
+									EncryptionAtHost: api.EncryptionAtHostDisabled,
+								},
+							},
+							NetworkProfile: api.NetworkProfile{
+								SoftwareDefinedNetwork: api.SoftwareDefinedNetworkOpenShiftSDN,
+								OutboundType:           api.OutboundTypeLoadbalancer,
+								PreconfiguredNSG:       api.PreconfiguredNSGDisabled,
+								LoadBalancerProfile: &api.LoadBalancerProfile{
+									ManagedOutboundIPs: &api.ManagedOutboundIPs{
+										Count: 1,
+									},
+								},
+							},
+							MasterProfile: api.MasterProfile{
+								EncryptionAtHost: api.EncryptionAtHostDisabled,
+							},
+							OperatorFlags: api.OperatorFlags{},
+						},
+					},
+				})
+			},
+			wantEnriched:   []string{testdatabase.GetResourcePath(mockSubID, "resourceName")},
+			wantAsync:      true,
+			wantStatusCode: http.StatusOK,
+			wantResponse: &v20200430.OpenShiftCluster{
+				ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+				Name: "resourceName",
+				Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+				Tags: map[string]string{"tag": "will-be-kept"},
+				Properties: v20200430.OpenShiftClusterProperties{
+					ProvisioningState: v20200430.ProvisioningStateUpdating,
+					IngressProfiles:   []v20200430.IngressProfile{{Name: "default"}},
+					WorkerProfiles:    []v20200430.WorkerProfile{{Name: "changed"}},
+				},
+			},
+		},
 		{
 			name: "patch a cluster from failed during update",
 			request: func(oc *v20200430.OpenShiftCluster) {
@@ -2589,6 +2696,54 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "patch a cluster resizing workers to an unavailable sku",
+			request: func(oc *v20200430.OpenShiftCluster) {
+				oc.Properties.WorkerProfiles = []v20200430.WorkerProfile{{Name: "default", VMSize: "Standard_D8s_v3"}}
+			},
+			isPatch: true,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							IngressProfiles:   []api.IngressProfile{{Name: "default"}},
+							WorkerProfiles: []api.WorkerProfile{
+								{
+									Name:             "default",
+									VMSize:           api.VMSizeStandardD4sV3,
+									EncryptionAtHost: api.EncryptionAtHostDisabled,
+								},
+							},
+							NetworkProfile: api.NetworkProfile{
+								SoftwareDefinedNetwork: api.SoftwareDefinedNetworkOpenShiftSDN,
+								OutboundType:           api.OutboundTypeLoadbalancer,
+							},
+							MasterProfile: api.MasterProfile{
+								EncryptionAtHost: api.EncryptionAtHostDisabled,
+							},
+						},
+					},
+				})
+			},
+			skuValidatorError: api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The selected SKU '%v' is unavailable in region '%v'", "Standard_D8s_v3", "somewhere"),
+			wantEnriched:      []string{testdatabase.GetResourcePath(mockSubID, "resourceName")},
+			wantStatusCode:    http.StatusBadRequest,
+			wantError:         "400: InvalidParameter: : The selected SKU 'Standard_D8s_v3' is unavailable in region 'somewhere'",
+		},
 		{
 			name: "patch a cluster from failed during creation",
 			request: func(oc *v20200430.OpenShiftCluster) {
@@ -2795,7 +2950,7 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, ti.openShiftVersionsDatabase, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, ti.openShiftVersionsDatabase, nil, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -3128,7 +3283,7 @@ func TestPutOrPatchOpenShiftClusterValidated(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, ti.openShiftVersionsDatabase, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, ti.openShiftVersionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -3300,3 +3455,88 @@ func TestEnrichClusterSystemData(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileScope(t *testing.T) {
+	const before = `{
+	"properties": {
+		"clusterProfile": {"domain": "example"},
+		"networkProfile": {"outboundType": "Loadbalancer", "loadBalancerProfile": {"managedOutboundIps": {"count": 1}}},
+		"workerProfiles": [{"name": "worker", "vmSize": "Standard_D4s_v3"}]
+	}
+}`
+
+	for _, tt := range []struct {
+		name  string
+		after string
+		want  api.ReconcileScope
+	}{
+		{
+			name:  "no change",
+			after: before,
+		},
+		{
+			name: "worker profiles changed",
+			after: `{
+	"properties": {
+		"clusterProfile": {"domain": "example"},
+		"networkProfile": {"outboundType": "Loadbalancer", "loadBalancerProfile": {"managedOutboundIps": {"count": 1}}},
+		"workerProfiles": [{"name": "worker", "vmSize": "Standard_D8s_v3"}]
+	}
+}`,
+			want: api.ReconcileScopeWorkerProfiles,
+		},
+		{
+			name: "load balancer profile changed",
+			after: `{
+	"properties": {
+		"clusterProfile": {"domain": "example"},
+		"networkProfile": {"outboundType": "Loadbalancer", "loadBalancerProfile": {"managedOutboundIps": {"count": 2}}},
+		"workerProfiles": [{"name": "worker", "vmSize": "Standard_D4s_v3"}]
+	}
+}`,
+			want: api.ReconcileScopeLoadBalancerProfile,
+		},
+		{
+			name: "worker and load balancer profiles changed",
+			after: `{
+	"properties": {
+		"clusterProfile": {"domain": "example"},
+		"networkProfile": {"outboundType": "Loadbalancer", "loadBalancerProfile": {"managedOutboundIps": {"count": 2}}},
+		"workerProfiles": [{"name": "worker", "vmSize": "Standard_D8s_v3"}]
+	}
+}`,
+		},
+		{
+			name: "worker profiles and another field changed",
+			after: `{
+	"properties": {
+		"clusterProfile": {"domain": "changed"},
+		"networkProfile": {"outboundType": "Loadbalancer", "loadBalancerProfile": {"managedOutboundIps": {"count": 1}}},
+		"workerProfiles": [{"name": "worker", "vmSize": "Standard_D8s_v3"}]
+	}
+}`,
+		},
+		{
+			name: "load balancer profile removed",
+			after: `{
+	"properties": {
+		"clusterProfile": {"domain": "example"},
+		"networkProfile": {"outboundType": "Loadbalancer"},
+		"workerProfiles": [{"name": "worker", "vmSize": "Standard_D4s_v3"}]
+	}
+}`,
+			want: api.ReconcileScopeLoadBalancerProfile,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := reconcileScope([]byte(before), []byte(tt.after))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
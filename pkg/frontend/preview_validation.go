@@ -0,0 +1,33 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/util/preview"
+)
+
+// featureCacheTTL is how long the registered features of a subscription are
+// remembered.  ARM sends us the subscription whenever its features change, so
+// a newly registered feature takes at most this long to become usable.
+const featureCacheTTL = time.Minute
+
+// validatePreviewFeatures checks that the external cluster ext only sets or
+// changes the properties tagged as preview if the subscription is registered
+// for their features.  current is the external form of the existing cluster,
+// or nil on create.
+func (f *frontend) validatePreviewFeatures(ctx context.Context, subscriptionID string, ext, current interface{}) error {
+	err := preview.Validate("", ext, current, func(feature string) (bool, error) {
+		return f.featureClient.IsRegisteredForFeature(ctx, subscriptionID, feature)
+	})
+	if err, ok := err.(*preview.ValidationError); ok {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, err.Target, "%s", err.Message)
+	}
+
+	return err
+}
@@ -0,0 +1,100 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	v20240812preview "github.com/Azure/ARO-RP/pkg/api/v20240812preview"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type fakeFeatureClient []string
+
+func (c fakeFeatureClient) IsRegisteredForFeature(ctx context.Context, subscriptionID, feature string) (bool, error) {
+	for _, f := range c {
+		if f == feature {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestValidatePreviewFeatures(t *testing.T) {
+	ctx := context.Background()
+
+	largeCluster := func() *v20240812preview.OpenShiftCluster {
+		return &v20240812preview.OpenShiftCluster{
+			Properties: v20240812preview.OpenShiftClusterProperties{
+				MaxNodesProfile: &v20240812preview.MaxNodesProfile{MaxNodes: 100},
+			},
+		}
+	}
+
+	dualStackCluster := func() *v20240812preview.OpenShiftCluster {
+		return &v20240812preview.OpenShiftCluster{
+			Properties: v20240812preview.OpenShiftClusterProperties{
+				NetworkProfile: v20240812preview.NetworkProfile{
+					PodCIDRIPv6:     "fd01::/48",
+					ServiceCIDRIPv6: "fd02::/112",
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name       string
+		ext        *v20240812preview.OpenShiftCluster
+		current    *v20240812preview.OpenShiftCluster
+		registered fakeFeatureClient
+		wantErr    string
+	}{
+		{
+			name: "no preview properties",
+			ext:  &v20240812preview.OpenShiftCluster{},
+		},
+		{
+			name:       "large cluster, subscription registered",
+			ext:        largeCluster(),
+			registered: fakeFeatureClient{api.FeatureFlagLargeClusters},
+		},
+		{
+			name:    "large cluster, subscription not registered",
+			ext:     largeCluster(),
+			wantErr: "400: InvalidParameter: properties.maxNodesProfile: The subscription must be registered for the Microsoft.RedHatOpenShift/LargeClusters feature to set property 'properties.maxNodesProfile'.",
+		},
+		{
+			name:       "dual-stack cluster, subscription registered",
+			ext:        dualStackCluster(),
+			registered: fakeFeatureClient{api.FeatureFlagDualStack},
+		},
+		{
+			name:       "dual-stack cluster, subscription registered for another feature",
+			ext:        dualStackCluster(),
+			registered: fakeFeatureClient{api.FeatureFlagLargeClusters},
+			wantErr:    "400: InvalidParameter: properties.networkProfile.podCidrIpv6: The subscription must be registered for the Microsoft.RedHatOpenShift/DualStack feature to set property 'properties.networkProfile.podCidrIpv6'.",
+		},
+		{
+			name:    "existing dual-stack cluster updated, subscription no longer registered",
+			ext:     dualStackCluster(),
+			current: dualStackCluster(),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &frontend{
+				featureClient: tt.registered,
+			}
+
+			var current interface{}
+			if tt.current != nil {
+				current = tt.current
+			}
+
+			err := f.validatePreviewFeatures(ctx, "00000000-0000-0000-0000-000000000000", tt.ext, current)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
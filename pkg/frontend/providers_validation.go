@@ -6,6 +6,7 @@ package frontend
 import (
 	"context"
 	"net/http"
+	"time"
 
 	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
 
@@ -13,13 +14,28 @@ import (
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
+	"github.com/Azure/ARO-RP/pkg/util/cache"
 )
 
 type ProvidersValidator interface {
 	ValidateProviders(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string) error
 }
 
-type providersValidator struct{}
+// providersCacheTTL is how long a subscription is remembered to have all the
+// required resource providers registered.  Only successful validations are
+// cached, so a customer who registers a missing provider can retry at once.
+const providersCacheTTL = time.Hour
+
+type providersValidator struct {
+	// registered caches the subscriptions which passed validation
+	registered *cache.Cache[string, struct{}]
+}
+
+func newProvidersValidator() *providersValidator {
+	return &providersValidator{
+		registered: cache.New[string, struct{}](providersCacheTTL),
+	}
+}
 
 var requiredResourceProviders = []string{
 	"Microsoft.Authorization",
@@ -28,15 +44,18 @@ var requiredResourceProviders = []string{
 	"Microsoft.Storage",
 }
 
-func (p providersValidator) ValidateProviders(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string) error {
-	fpAuthorizer, err := environment.FPAuthorizer(tenantID, environment.Environment().ResourceManagerScope)
-	if err != nil {
-		return err
-	}
+func (p *providersValidator) ValidateProviders(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string) error {
+	_, err := p.registered.GetOrLoad(ctx, subscriptionID, func(ctx context.Context) (struct{}, error) {
+		fpAuthorizer, err := environment.FPAuthorizer(tenantID, environment.Environment().ResourceManagerScope)
+		if err != nil {
+			return struct{}{}, err
+		}
 
-	providersClient := features.NewProvidersClient(azEnv, subscriptionID, fpAuthorizer)
+		providersClient := features.NewProvidersClient(azEnv, subscriptionID, fpAuthorizer)
 
-	return validateProviders(ctx, providersClient)
+		return struct{}{}, validateProviders(ctx, providersClient)
+	})
+	return err
 }
 
 func validateProviders(ctx context.Context, providersClient features.ProvidersClient) error {
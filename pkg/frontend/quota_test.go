@@ -5,195 +5,64 @@ package frontend
 
 import (
 	"context"
+	"strings"
 	"testing"
-
-	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
-	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
-	"github.com/Azure/go-autorest/autorest/to"
-	"github.com/golang/mock/gomock"
+	"time"
 
 	"github.com/Azure/ARO-RP/pkg/api"
-	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
-	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
-	utilerror "github.com/Azure/ARO-RP/test/util/error"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
 )
 
-func TestValidateQuota(t *testing.T) {
+func TestPendingClusters(t *testing.T) {
 	ctx := context.Background()
 
-	type test struct {
-		name    string
-		mocks   func(*test, *mock_compute.MockUsageClient, *mock_network.MockUsageClient)
-		wantErr string
-	}
-	for _, tt := range []*test{
-		{
-			name: "allow when there's enough resources - limits set to exact requirements, offset by 100 of current value",
-			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
-				cuc.EXPECT().
-					List(ctx, "ocLocation").
-					Return([]mgmtcompute.Usage{
-						{
-							Name: &mgmtcompute.UsageName{
-								Value: to.StringPtr("cores"),
-							},
-							CurrentValue: to.Int32Ptr(100),
-							Limit:        to.Int64Ptr(212),
-						},
-						{
-							Name: &mgmtcompute.UsageName{
-								Value: to.StringPtr("virtualMachines"),
-							},
-							CurrentValue: to.Int32Ptr(100),
-							Limit:        to.Int64Ptr(114),
-						},
-						{
-							Name: &mgmtcompute.UsageName{
-								Value: to.StringPtr("standardDSv3Family"),
-							},
-							CurrentValue: to.Int32Ptr(100),
-							Limit:        to.Int64Ptr(212),
-						},
-						{
-							Name: &mgmtcompute.UsageName{
-								Value: to.StringPtr("PremiumDiskCount"),
-							},
-							CurrentValue: to.Int32Ptr(100),
-							Limit:        to.Int64Ptr(114),
-						},
-					}, nil)
-				nuc.EXPECT().
-					List(ctx, "ocLocation").
-					Return([]mgmtnetwork.Usage{
-						{
-							Name: &mgmtnetwork.UsageName{
-								Value: to.StringPtr("PublicIPAddresses"),
-							},
-							CurrentValue: to.Int64Ptr(4),
-							Limit:        to.Int64Ptr(10),
-						},
-					}, nil)
-			},
-		},
-		{
-			name:    "not enough cores",
-			wantErr: "400: ResourceQuotaExceeded: : Resource quota of cores exceeded. Maximum allowed: 212, Current in use: 101, Additional requested: 112.",
-			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
-				cuc.EXPECT().
-					List(ctx, "ocLocation").
-					Return([]mgmtcompute.Usage{
-						{
-							Name: &mgmtcompute.UsageName{
-								Value: to.StringPtr("cores"),
-							},
-							CurrentValue: to.Int32Ptr(101),
-							Limit:        to.Int64Ptr(212),
-						},
-					}, nil)
-			},
-		},
-		{
-			name:    "not enough virtualMachines",
-			wantErr: "400: ResourceQuotaExceeded: : Resource quota of virtualMachines exceeded. Maximum allowed: 114, Current in use: 101, Additional requested: 14.",
-			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
-				cuc.EXPECT().
-					List(ctx, "ocLocation").
-					Return([]mgmtcompute.Usage{
-						{
-							Name: &mgmtcompute.UsageName{
-								Value: to.StringPtr("virtualMachines"),
-							},
-							CurrentValue: to.Int32Ptr(101),
-							Limit:        to.Int64Ptr(114),
-						},
-					}, nil)
-			},
-		},
-		{
-			name:    "not enough standardDSv3Family",
-			wantErr: "400: ResourceQuotaExceeded: : Resource quota of standardDSv3Family exceeded. Maximum allowed: 212, Current in use: 101, Additional requested: 112.",
-			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
-				cuc.EXPECT().
-					List(ctx, "ocLocation").
-					Return([]mgmtcompute.Usage{
-						{
-							Name: &mgmtcompute.UsageName{
-								Value: to.StringPtr("standardDSv3Family"),
-							},
-							CurrentValue: to.Int32Ptr(101),
-							Limit:        to.Int64Ptr(212),
-						},
-					}, nil)
-			},
-		},
-		{
-			name:    "not enough premium disks",
-			wantErr: "400: ResourceQuotaExceeded: : Resource quota of PremiumDiskCount exceeded. Maximum allowed: 114, Current in use: 101, Additional requested: 14.",
-			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
-				cuc.EXPECT().
-					List(ctx, "ocLocation").
-					Return([]mgmtcompute.Usage{
-						{
-							Name: &mgmtcompute.UsageName{
-								Value: to.StringPtr("PremiumDiskCount"),
-							},
-							CurrentValue: to.Int32Ptr(101),
-							Limit:        to.Int64Ptr(114),
-						},
-					}, nil)
-			},
-		},
-		{
-			name:    "not enough public ip addresses",
-			wantErr: "400: ResourceQuotaExceeded: : Resource quota of PublicIPAddresses exceeded. Maximum allowed: 6, Current in use: 4, Additional requested: 3.",
-			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
-				cuc.EXPECT().
-					List(ctx, "ocLocation").
-					Return([]mgmtcompute.Usage{}, nil)
-				nuc.EXPECT().
-					List(ctx, "ocLocation").
-					Return([]mgmtnetwork.Usage{
-						{
-							Name: &mgmtnetwork.UsageName{
-								Value: to.StringPtr("PublicIPAddresses"),
-							},
-							CurrentValue: to.Int64Ptr(4),
-							Limit:        to.Int64Ptr(6),
-						},
-					}, nil)
-			},
-		},
-	} {
-		t.Run(tt.name, func(t *testing.T) {
-			controller := gomock.NewController(t)
-			defer controller.Finish()
+	const mockSubID = "00000000-0000-0000-0000-000000000000"
 
-			computeUsageClient := mock_compute.NewMockUsageClient(controller)
-			networkUsageClient := mock_network.NewMockUsageClient(controller)
-			if tt.mocks != nil {
-				tt.mocks(tt, computeUsageClient, networkUsageClient)
-			}
+	now := time.Unix(1700000000, 0)
 
-			oc := &api.OpenShiftCluster{
-				Location: "ocLocation",
+	cluster := func(name, location string, provisioningState api.ProvisioningState, leaseExpires int) *api.OpenShiftClusterDocument {
+		id := testdatabase.GetResourcePath(mockSubID, name)
+		return &api.OpenShiftClusterDocument{
+			Key:          strings.ToLower(id),
+			LeaseExpires: leaseExpires,
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:       id,
+				Location: location,
 				Properties: api.OpenShiftClusterProperties{
-					Install: &api.Install{
-						Phase: api.InstallPhaseBootstrap,
-					},
-					MasterProfile: api.MasterProfile{
-						VMSize: "Standard_D8s_v3",
-					},
-					WorkerProfiles: []api.WorkerProfile{
-						{
-							VMSize: "Standard_D8s_v3",
-							Count:  10,
-						},
-					},
+					ProvisioningState: provisioningState,
 				},
-			}
+			},
+		}
+	}
+
+	dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+	fixture := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+	fixture.AddOpenShiftClusterDocuments(
+		cluster("queued", "ocLocation", api.ProvisioningStateCreating, 0),
+		cluster("otherlocation", "otherLocation", api.ProvisioningStateCreating, 0),
+		cluster("leased", "ocLocation", api.ProvisioningStateCreating, int(now.Unix())+60),
+		cluster("succeeded", "ocLocation", api.ProvisioningStateSucceeded, 0),
+		cluster("resourceName", "ocLocation", api.ProvisioningStateCreating, 0),
+	)
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := &quotaValidator{
+		dbOpenShiftClusters: dbOpenShiftClusters,
+		now:                 func() time.Time { return now },
+	}
+
+	pending, err := q.pendingClusters(ctx, mockSubID, &api.OpenShiftCluster{
+		ID:       testdatabase.GetResourcePath(mockSubID, "resourceName"),
+		Location: "ocLocation",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
 
-			err := validateQuota(ctx, oc, networkUsageClient, computeUsageClient)
-			utilerror.AssertErrorMessage(t, err, tt.wantErr)
-		})
+	if len(pending) != 1 || pending[0].ID != testdatabase.GetResourcePath(mockSubID, "queued") {
+		t.Error(pending)
 	}
 }
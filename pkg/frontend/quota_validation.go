@@ -5,40 +5,38 @@ package frontend
 
 import (
 	"context"
-	"net/http"
+	"strings"
+	"time"
 
 	"github.com/Azure/ARO-RP/pkg/api"
-	"github.com/Azure/ARO-RP/pkg/api/validate"
+	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+	"github.com/Azure/ARO-RP/pkg/util/quota"
 )
 
 type QuotaValidator interface {
 	ValidateQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster) error
 }
 
-type quotaValidator struct{}
+type quotaValidator struct {
+	dbOpenShiftClusters database.OpenShiftClusters
+	now                 func() time.Time
+}
 
-func addRequiredResources(requiredResources map[string]int, vmSize api.VMSize, count int) error {
-	vm, ok := validate.VMSizeFromName(vmSize)
-	if !ok {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The provided VM SKU %s is not supported.", vmSize)
+func newQuotaValidator(dbOpenShiftClusters database.OpenShiftClusters) *quotaValidator {
+	return &quotaValidator{
+		dbOpenShiftClusters: dbOpenShiftClusters,
+		now:                 time.Now,
 	}
-
-	requiredResources["virtualMachines"] += count
-	requiredResources["PremiumDiskCount"] += count
-
-	requiredResources[vm.Family] += vm.CoreCount * count
-	requiredResources["cores"] += vm.CoreCount * count
-	return nil
 }
 
 // ValidateQuota checks usage quotas vs. resources required by cluster before cluster
 // creation
 // It is a method on struct so we can make use of interfaces.
-func (q quotaValidator) ValidateQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster) error {
+func (q *quotaValidator) ValidateQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster) error {
 	fpAuthorizer, err := environment.FPAuthorizer(tenantID, environment.Environment().ResourceManagerScope)
 	if err != nil {
 		return err
@@ -47,59 +45,46 @@ func (q quotaValidator) ValidateQuota(ctx context.Context, azEnv *azureclient.AR
 	spComputeUsage := compute.NewUsageClient(azEnv, subscriptionID, fpAuthorizer)
 	spNetworkUsage := network.NewUsageClient(azEnv, subscriptionID, fpAuthorizer)
 
-	return validateQuota(ctx, oc, spNetworkUsage, spComputeUsage)
-}
-
-func validateQuota(ctx context.Context, oc *api.OpenShiftCluster, spNetworkUsage network.UsageClient, spComputeUsage compute.UsageClient) error {
-	// If ValidateQuota runs outside install process, we should skip quota validation
-	requiredResources := map[string]int{}
-
-	err := addRequiredResources(requiredResources, oc.Properties.MasterProfile.VMSize, 4)
+	pending, err := q.pendingClusters(ctx, subscriptionID, oc)
 	if err != nil {
 		return err
 	}
 
-	workerProfiles, _ := api.GetEnrichedWorkerProfiles(oc.Properties)
-	//worker node resource calculation
-	for _, w := range workerProfiles {
-		err := addRequiredResources(requiredResources, w.VMSize, w.Count)
-		if err != nil {
-			return err
-		}
-	}
-
-	//Public IP Addresses minimum requirement: 2 for ARM template deployment and 1 for kube-controller-manager
-	requiredResources["PublicIPAddresses"] = 3
+	return quota.Validate(ctx, oc, pending, environment.VMSku, spNetworkUsage, spComputeUsage)
+}
 
-	//check requirements vs. usage
+// pendingClusters returns the other clusters in the subscription and region
+// of oc which are queued for creation but not yet picked up by a backend.
+// Their VMs do not exist yet, so the quota they will consume is not yet
+// reflected by the Usage API.
+func (q *quotaValidator) pendingClusters(ctx context.Context, subscriptionID string, oc *api.OpenShiftCluster) ([]*api.OpenShiftCluster, error) {
+	var pending []*api.OpenShiftCluster
 
-	// we're only checking the limits returned by the Usage API and ignoring usage limits missing from the results
-	// rationale:
-	// 1. if the Usage API doesn't send a limit because a resource is no longer limited, RP will continue cluster creation without impact
-	// 2. if the Usage API doesn't send a limit that is still enforced, cluster creation will fail on the backend and we will get an error in the RP logs
-	computeUsages, err := spComputeUsage.List(ctx, oc.Location)
+	i, err := q.dbOpenShiftClusters.ListByPrefix(subscriptionID, "/subscriptions/"+subscriptionID+"/", "")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, usage := range computeUsages {
-		required, present := requiredResources[*usage.Name.Value]
-		if present && int64(required) > (*usage.Limit-int64(*usage.CurrentValue)) {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceQuotaExceeded, "", "Resource quota of %s exceeded. Maximum allowed: %d, Current in use: %d, Additional requested: %d.", *usage.Name.Value, *usage.Limit, *usage.CurrentValue, required)
+	for {
+		docs, err := i.Next(ctx, -1)
+		if err != nil {
+			return nil, err
+		}
+		if docs == nil {
+			break
 		}
-	}
 
-	netUsages, err := spNetworkUsage.List(ctx, oc.Location)
-	if err != nil {
-		return err
-	}
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			if doc.OpenShiftCluster.Properties.ProvisioningState != api.ProvisioningStateCreating ||
+				int64(doc.LeaseExpires) >= q.now().Unix() ||
+				!strings.EqualFold(doc.OpenShiftCluster.Location, oc.Location) ||
+				strings.EqualFold(doc.OpenShiftCluster.ID, oc.ID) {
+				continue
+			}
 
-	for _, netUsage := range netUsages {
-		required, present := requiredResources[*netUsage.Name.Value]
-		if present && int64(required) > (*netUsage.Limit-*netUsage.CurrentValue) {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceQuotaExceeded, "", "Resource quota of %s exceeded. Maximum allowed: %d, Current in use: %d, Additional requested: %d.", *netUsage.Name.Value, *netUsage.Limit, *netUsage.CurrentValue, required)
+			pending = append(pending, doc.OpenShiftCluster)
 		}
 	}
 
-	return nil
+	return pending, nil
 }
@@ -0,0 +1,103 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+// maxBodyBytesFromEnvironment returns the number of bytes in
+// RP_FRONTEND_MAX_BODY_BYTES, or middleware.DefaultMaxBodyBytes if it is not
+// set
+func maxBodyBytesFromEnvironment() (int64, error) {
+	s := os.Getenv("RP_FRONTEND_MAX_BODY_BYTES")
+	if s == "" {
+		return middleware.DefaultMaxBodyBytes, nil
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid RP_FRONTEND_MAX_BODY_BYTES %q", s)
+	}
+
+	return n, nil
+}
+
+// unmarshalRequestBody unmarshals the JSON request body into v.  If strict is
+// set, properties which v does not have are rejected with a CloudError naming
+// the first of them, rather than being ignored.
+func unmarshalRequestBody(body []byte, v interface{}, strict bool) error {
+	if !strict {
+		err := json.Unmarshal(body, v)
+		if err != nil {
+			return newInvalidRequestContentError(err)
+		}
+		return nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.DisallowUnknownFields()
+
+	err := d.Decode(v)
+	if err != nil {
+		// encoding/json does not export a type for this error
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			field, _ = strconv.Unquote(field)
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, field, "The request content contained the unknown property '%s'.", field)
+		}
+		return newInvalidRequestContentError(err)
+	}
+
+	// json.Unmarshal rejects trailing data, which a Decoder does not
+	if _, err = d.Token(); err != io.EOF {
+		return newInvalidRequestContentError(fmt.Errorf("invalid character after top-level value"))
+	}
+
+	return nil
+}
+
+func newInvalidRequestContentError(err error) error {
+	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized: %q.", err)
+}
+
+// splitPlan returns the marketplace plan in the ARM resource in the request
+// body, if any, and the body without it.  The versioned APIs do not model the
+// plan, so it would otherwise be rejected by strict decoding.
+func splitPlan(body []byte) (*api.Plan, []byte, error) {
+	var m map[string]json.RawMessage
+	err := json.Unmarshal(body, &m)
+	if err != nil {
+		// left for unmarshalRequestBody to report
+		return nil, body, nil
+	}
+
+	b, ok := m["plan"]
+	if !ok {
+		return nil, body, nil
+	}
+	delete(m, "plan")
+
+	var plan *api.Plan
+	err = json.Unmarshal(b, &plan)
+	if err != nil {
+		return nil, nil, newInvalidRequestContentError(err)
+	}
+
+	body, err = json.Marshal(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plan, body, nil
+}
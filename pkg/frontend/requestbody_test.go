@@ -0,0 +1,170 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestMaxBodyBytesFromEnvironment(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		value   string
+		want    int64
+		wantErr string
+	}{
+		{
+			name: "unset",
+			want: middleware.DefaultMaxBodyBytes,
+		},
+		{
+			name:  "set",
+			value: "4194304",
+			want:  4194304,
+		},
+		{
+			name:    "invalid",
+			value:   "4MiB",
+			wantErr: `invalid RP_FRONTEND_MAX_BODY_BYTES "4MiB"`,
+		},
+		{
+			name:    "zero",
+			value:   "0",
+			wantErr: `invalid RP_FRONTEND_MAX_BODY_BYTES "0"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RP_FRONTEND_MAX_BODY_BYTES", tt.value)
+
+			got, err := maxBodyBytesFromEnvironment()
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalRequestBody(t *testing.T) {
+	type nested struct {
+		Value string `json:"value,omitempty"`
+	}
+
+	type resource struct {
+		Name   string  `json:"name,omitempty"`
+		Nested *nested `json:"nested,omitempty"`
+	}
+
+	for _, tt := range []struct {
+		name    string
+		body    string
+		strict  bool
+		want    resource
+		wantErr string
+	}{
+		{
+			name: "known properties",
+			body: `{"name": "a", "nested": {"value": "b"}}`,
+			want: resource{Name: "a", Nested: &nested{Value: "b"}},
+		},
+		{
+			name:   "known properties, strict",
+			body:   `{"name": "a", "nested": {"value": "b"}}`,
+			strict: true,
+			want:   resource{Name: "a", Nested: &nested{Value: "b"}},
+		},
+		{
+			name: "unknown property ignored",
+			body: `{"name": "a", "nmae": "b"}`,
+			want: resource{Name: "a"},
+		},
+		{
+			name:    "unknown property, strict",
+			body:    `{"name": "a", "nmae": "b"}`,
+			strict:  true,
+			wantErr: "400: InvalidRequestContent: nmae: The request content contained the unknown property 'nmae'.",
+		},
+		{
+			name:    "unknown nested property, strict",
+			body:    `{"nested": {"vaule": "b"}}`,
+			strict:  true,
+			wantErr: "400: InvalidRequestContent: vaule: The request content contained the unknown property 'vaule'.",
+		},
+		{
+			name:    "invalid JSON",
+			body:    `{"name": 1}`,
+			wantErr: `400: InvalidRequestContent: : The request content was invalid and could not be deserialized: "json: cannot unmarshal number into Go struct field resource.name of type string".`,
+		},
+		{
+			name:    "trailing data, strict",
+			body:    `{"name": "a"} {}`,
+			strict:  true,
+			wantErr: `400: InvalidRequestContent: : The request content was invalid and could not be deserialized: "invalid character after top-level value".`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var got resource
+
+			err := unmarshalRequestBody([]byte(tt.body), &got, tt.strict)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.wantErr == "" && (got.Name != tt.want.Name || (got.Nested == nil) != (tt.want.Nested == nil) ||
+				got.Nested != nil && *got.Nested != *tt.want.Nested) {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestSplitPlan(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		body     string
+		wantPlan *api.Plan
+		wantBody string
+		wantErr  string
+	}{
+		{
+			name:     "no plan",
+			body:     `{"name": "a"}`,
+			wantBody: `{"name": "a"}`,
+		},
+		{
+			name:     "plan",
+			body:     `{"name": "a", "plan": {"name": "plan", "publisher": "redhat", "product": "aro"}}`,
+			wantPlan: &api.Plan{Name: "plan", Publisher: "redhat", Product: "aro"},
+			wantBody: `{"name":"a"}`,
+		},
+		{
+			name:     "invalid JSON is left for unmarshalRequestBody",
+			body:     `{"name": "a"} {}`,
+			wantBody: `{"name": "a"} {}`,
+		},
+		{
+			name:    "invalid plan",
+			body:    `{"plan": "plan"}`,
+			wantErr: `400: InvalidRequestContent: : The request content was invalid and could not be deserialized: "json: cannot unmarshal string into Go value of type api.Plan".`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, body, err := splitPlan([]byte(tt.body))
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(plan, tt.wantPlan) {
+				t.Errorf("got plan %#v, want %#v", plan, tt.wantPlan)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
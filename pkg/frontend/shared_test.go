@@ -80,6 +80,8 @@ type testInfra struct {
 	subscriptionsDatabase     database.Subscriptions
 	openShiftVersionsClient   *cosmosdb.FakeOpenShiftVersionDocumentClient
 	openShiftVersionsDatabase database.OpenShiftVersions
+	portalSessionsClient      *cosmosdb.FakePortalSessionDocumentClient
+	portalSessionsDatabase    database.PortalSessions
 }
 
 func newTestInfra(t *testing.T) *testInfra {
@@ -95,7 +97,7 @@ func newTestInfraWithFeatures(t *testing.T, features map[env.Feature]bool) *test
 	controller := gomock.NewController(t)
 
 	keyvault := mock_keyvault.NewMockManager(controller)
-	keyvault.EXPECT().GetCertificateSecret(gomock.Any(), env.RPServerSecretName).AnyTimes().Return(serverkey, servercerts, nil)
+	keyvault.EXPECT().GetLatestCertificateSecret(gomock.Any(), env.RPServerSecretName).AnyTimes().Return(serverkey, servercerts, nil)
 
 	_env := mock_env.NewMockInterface(controller)
 	_env.EXPECT().IsLocalDevelopmentMode().AnyTimes().Return(false)
@@ -103,12 +105,14 @@ func newTestInfraWithFeatures(t *testing.T, features map[env.Feature]bool) *test
 	_env.EXPECT().Hostname().AnyTimes().Return("testhost")
 	_env.EXPECT().Location().AnyTimes().Return("eastus")
 	_env.EXPECT().ServiceKeyvault().AnyTimes().Return(keyvault)
+	_env.EXPECT().FPCertificateRefresher().AnyTimes().Return(nil)
 	_env.EXPECT().ArmClientAuthorizer().AnyTimes().Return(clientauthorizer.NewOne(clientcerts[0].Raw))
 	_env.EXPECT().AdminClientAuthorizer().AnyTimes().Return(clientauthorizer.NewOne(clientcerts[0].Raw))
 	_env.EXPECT().Domain().AnyTimes().Return("aro.example")
 	_env.EXPECT().Listen().AnyTimes().Return(l, nil)
 	for f, val := range features {
 		_env.EXPECT().FeatureIsSet(f).AnyTimes().Return(val)
+		_env.EXPECT().FeatureIsSetForSubscription(f, gomock.Any()).AnyTimes().Return(val)
 	}
 
 	enricherMock := mock_clusterdata.NewMockBestEffortEnricher(controller)
@@ -185,6 +189,12 @@ func (ti *testInfra) WithClusterManagerConfigurations() *testInfra {
 	return ti
 }
 
+func (ti *testInfra) WithPortalSessions() *testInfra {
+	ti.portalSessionsDatabase, ti.portalSessionsClient = testdatabase.NewFakePortalSessions()
+	ti.fixture.WithPortalSessions(ti.portalSessionsDatabase)
+	return ti
+}
+
 func (ti *testInfra) done() {
 	ti.controller.Finish()
 	ti.cli.CloseIdleConnections()
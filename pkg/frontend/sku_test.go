@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
@@ -229,8 +230,299 @@ func TestValidateVMSku(t *testing.T) {
 				List(gomock.Any(), fmt.Sprintf("location eq %v", "eastus")).
 				Return(skus, tt.resourceSkusClientErr)
 
-			err := validateVMSku(context.Background(), oc, resourceSkusClient)
+			err := newSkuValidator().validateVMSku(context.Background(), "subscriptionID", oc, resourceSkusClient)
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
 		})
 	}
 }
+
+func TestValidateVMSkuCached(t *testing.T) {
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	oc := &api.OpenShiftCluster{
+		Location: "eastus",
+		Properties: api.OpenShiftClusterProperties{
+			MasterProfile: api.MasterProfile{
+				VMSize: api.VMSizeStandardD8sV3,
+			},
+		},
+	}
+
+	resourceSkusClient := mock_compute.NewMockResourceSkusClient(controller)
+	resourceSkusClient.EXPECT().
+		List(gomock.Any(), "location eq eastus").
+		Return([]mgmtcompute.ResourceSku{
+			{
+				Name:         to.StringPtr(string(api.VMSizeStandardD8sV3)),
+				Locations:    &[]string{"eastus"},
+				LocationInfo: &[]mgmtcompute.ResourceSkuLocationInfo{{Zones: &[]string{"1", "2", "3"}}},
+				Restrictions: &[]mgmtcompute.ResourceSkuRestrictions{},
+				Capabilities: &[]mgmtcompute.ResourceSkuCapabilities{},
+				ResourceType: to.StringPtr("virtualMachines"),
+			},
+		}, nil).
+		Times(1)
+
+	s := newSkuValidator()
+
+	// the second validation uses the cached SKUs
+	for i := 0; i < 2; i++ {
+		err := s.validateVMSku(ctx, "subscriptionID", oc, resourceSkusClient)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestValidateVMSkuZones(t *testing.T) {
+	ctx := context.Background()
+
+	sku := func(vmSize string, zones []string, restrictedZones []string, capabilities ...mgmtcompute.ResourceSkuCapabilities) mgmtcompute.ResourceSku {
+		restrictions := []mgmtcompute.ResourceSkuRestrictions{}
+		if restrictedZones != nil {
+			restrictions = append(restrictions, mgmtcompute.ResourceSkuRestrictions{
+				Type:       mgmtcompute.Zone,
+				ReasonCode: mgmtcompute.NotAvailableForSubscription,
+				RestrictionInfo: &mgmtcompute.ResourceSkuRestrictionInfo{
+					Locations: &[]string{"eastus"},
+					Zones:     &restrictedZones,
+				},
+			})
+		}
+
+		return mgmtcompute.ResourceSku{
+			Name:         to.StringPtr(vmSize),
+			Locations:    &[]string{"eastus"},
+			LocationInfo: &[]mgmtcompute.ResourceSkuLocationInfo{{Zones: &zones}},
+			Restrictions: &restrictions,
+			Capabilities: &capabilities,
+			ResourceType: to.StringPtr("virtualMachines"),
+		}
+	}
+
+	encryptionAtHost := mgmtcompute.ResourceSkuCapabilities{
+		Name:  to.StringPtr("EncryptionAtHostSupported"),
+		Value: to.StringPtr("True"),
+	}
+	premiumIO := mgmtcompute.ResourceSkuCapabilities{
+		Name:  to.StringPtr("PremiumIO"),
+		Value: to.StringPtr("True"),
+	}
+	acceleratedNetworking := mgmtcompute.ResourceSkuCapabilities{
+		Name:  to.StringPtr("AcceleratedNetworkingEnabled"),
+		Value: to.StringPtr("True"),
+	}
+
+	withUltraSSD := func(sku mgmtcompute.ResourceSku, zones ...string) mgmtcompute.ResourceSku {
+		(*sku.LocationInfo)[0].ZoneDetails = &[]mgmtcompute.ResourceSkuZoneDetails{
+			{
+				Name: &zones,
+				Capabilities: &[]mgmtcompute.ResourceSkuCapabilities{
+					{Name: to.StringPtr("UltraSSDAvailable"), Value: to.StringPtr("True")},
+				},
+			},
+		}
+		return sku
+	}
+
+	for _, tt := range []struct {
+		name                   string
+		skus                   []mgmtcompute.ResourceSku
+		workerEncryptionAtHost api.EncryptionAtHost
+		modify                 func(*api.OpenShiftCluster)
+		wantZones              []string
+		wantErr                string
+	}{
+		{
+			name: "all zones available",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"3", "1", "2"}, nil),
+				sku("Standard_D4s_v3", []string{"1", "2", "3"}, nil),
+			},
+			wantZones: []string{"1", "2", "3"},
+		},
+		{
+			name: "worker sku restricted in a zone",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, nil),
+				sku("Standard_D4s_v3", []string{"1", "2", "3"}, []string{"3"}),
+			},
+			wantZones: []string{"1", "2"},
+		},
+		{
+			name: "region is not zonal",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", nil, nil),
+				sku("Standard_D4s_v3", nil, nil),
+			},
+		},
+		{
+			name: "master sku restricted in every zone",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, []string{"1", "2", "3"}),
+				sku("Standard_D4s_v3", []string{"1", "2", "3"}, nil),
+				sku("Standard_D16s_v3", []string{"1", "2", "3"}, nil),
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.VMSize: The selected SKU 'Standard_D8s_v3' is restricted in every zone of region 'eastus' for selected subscription. Viable VM sizes: Standard_D16s_v3",
+		},
+		{
+			name: "no zone in which all skus are available",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, []string{"2", "3"}),
+				sku("Standard_D4s_v3", []string{"1", "2", "3"}, []string{"1"}),
+				sku("Standard_D16s_v3", []string{"1", "2", "3"}, nil),
+				sku("Standard_D32s_v3", []string{"1", "2", "3"}, []string{"1"}),
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles[0].VMSize: The selected SKU 'Standard_D4s_v3' is unavailable in zones 1 of region 'eastus' in which the other selected SKUs are available. Viable VM sizes: Standard_D16s_v3, Standard_D8s_v3",
+		},
+		{
+			name: "worker sku does not support encryption at host",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, nil),
+				sku("Standard_D4s_v3", []string{"1", "2", "3"}, nil),
+				sku("Standard_D16s_v3", []string{"1", "2", "3"}, nil, encryptionAtHost),
+			},
+			workerEncryptionAtHost: api.EncryptionAtHostEnabled,
+			wantErr:                "400: InvalidParameter: properties.workerProfiles[0].VMSize: The selected SKU 'Standard_D4s_v3' does not support encryption at host in region 'eastus'. Viable VM sizes: Standard_D16s_v3",
+		},
+		{
+			name: "worker sku does not support accelerated networking",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, nil),
+				sku("Standard_D4s_v3", []string{"1", "2", "3"}, nil),
+				sku("Standard_D16s_v3", []string{"1", "2", "3"}, nil, acceleratedNetworking),
+			},
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].AcceleratedNetworking = api.AcceleratedNetworkingEnabled
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles[0].VMSize: The selected SKU 'Standard_D4s_v3' does not support accelerated networking in region 'eastus'. Viable VM sizes: Standard_D16s_v3",
+		},
+		{
+			name: "master sku does not support premium disks",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, nil),
+				sku("Standard_D4s_v3", []string{"1", "2", "3"}, nil),
+			},
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.MasterProfile.DiskType = api.DiskTypePremiumLRS
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.VMSize: The selected SKU 'Standard_D8s_v3' does not support premium disks in region 'eastus'",
+		},
+		{
+			name: "ultra disks are only available in some zones",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, nil),
+				withUltraSSD(sku("Standard_D4s_v3", []string{"1", "2", "3"}, nil, premiumIO), "1", "3"),
+			},
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].DiskType = api.DiskTypeUltraSSDLRS
+			},
+			wantZones: []string{"1", "3"},
+		},
+		{
+			name: "worker sku does not support ultra disks",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, nil),
+				sku("Standard_D4s_v3", []string{"1", "2", "3"}, nil, premiumIO),
+				withUltraSSD(sku("Standard_D16s_v3", []string{"1", "2", "3"}, nil, premiumIO), "1"),
+			},
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].DiskType = api.DiskTypeUltraSSDLRS
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles[0].VMSize: The selected SKU 'Standard_D4s_v3' does not support Ultra disks in region 'eastus'. Viable VM sizes: Standard_D16s_v3",
+		},
+		{
+			name: "non-zonal placement requested",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, nil),
+				sku("Standard_D4s_v3", []string{"1", "2", "3"}, nil),
+			},
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.ZonePlacement = api.ZonePlacementNonZonal
+			},
+		},
+		{
+			name: "worker zones requested, master zones selected",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, []string{"1"}),
+				sku("Standard_D4s_v3", []string{"1", "2", "3"}, []string{"3"}),
+			},
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].Zones = []string{"1", "2"}
+			},
+			wantZones: []string{"2", "3"},
+		},
+		{
+			name: "master sku restricted in a requested zone",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, []string{"2"}),
+				sku("Standard_D4s_v3", []string{"1", "2", "3"}, nil),
+			},
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.MasterProfile.Zones = []string{"1", "2", "3"}
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.zones: The selected SKU 'Standard_D8s_v3' is unavailable in zone '2' of region 'eastus'. Available zones: 1, 3",
+		},
+		{
+			name: "ultra disks unavailable in a requested zone",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", []string{"1", "2", "3"}, nil),
+				withUltraSSD(sku("Standard_D4s_v3", []string{"1", "2", "3"}, nil, premiumIO), "1", "3"),
+			},
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].DiskType = api.DiskTypeUltraSSDLRS
+				oc.Properties.WorkerProfiles[0].Zones = []string{"2"}
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles[0].zones: The selected SKU 'Standard_D4s_v3' is unavailable in zone '2' of region 'eastus'. Available zones: 1, 3",
+		},
+		{
+			name: "zones requested in a region which is not zonal",
+			skus: []mgmtcompute.ResourceSku{
+				sku("Standard_D8s_v3", nil, nil),
+				sku("Standard_D4s_v3", nil, nil),
+			},
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].Zones = []string{"1"}
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles[0].zones: The selected SKU 'Standard_D4s_v3' is not zonal in region 'eastus'",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			oc := &api.OpenShiftCluster{
+				Location: "eastus",
+				Properties: api.OpenShiftClusterProperties{
+					MasterProfile: api.MasterProfile{
+						VMSize: api.VMSizeStandardD8sV3,
+					},
+					WorkerProfiles: []api.WorkerProfile{
+						{
+							VMSize:           api.VMSizeStandardD4sV3,
+							EncryptionAtHost: tt.workerEncryptionAtHost,
+						},
+					},
+				},
+			}
+			if tt.modify != nil {
+				tt.modify(oc)
+			}
+
+			resourceSkusClient := mock_compute.NewMockResourceSkusClient(controller)
+			resourceSkusClient.EXPECT().
+				List(gomock.Any(), "location eq eastus").
+				Return(tt.skus, nil)
+
+			err := newSkuValidator().validateVMSku(ctx, "subscriptionID", oc, resourceSkusClient)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(oc.Properties.Zones, tt.wantZones) {
+				t.Error(oc.Properties.Zones)
+			}
+		})
+	}
+}
@@ -7,13 +7,18 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
+	"github.com/Azure/ARO-RP/pkg/util/cache"
 	"github.com/Azure/ARO-RP/pkg/util/computeskus"
 )
 
@@ -21,35 +26,60 @@ type SkuValidator interface {
 	ValidateVMSku(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster) error
 }
 
-type skuValidator struct{}
+// skuCacheTTL is how long the VM SKUs available to a subscription in a region
+// are cached for.  SKU availability and restrictions change rarely.
+const skuCacheTTL = 10 * time.Minute
 
-func (s skuValidator) ValidateVMSku(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster) error {
+type skuValidator struct {
+	// skus caches the VM SKUs per subscription and region
+	skus *cache.Cache[string, map[string]*mgmtcompute.ResourceSku]
+}
+
+func newSkuValidator() *skuValidator {
+	return &skuValidator{
+		skus: cache.New[string, map[string]*mgmtcompute.ResourceSku](skuCacheTTL),
+	}
+}
+
+func (s *skuValidator) ValidateVMSku(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster) error {
 	fpAuthorizer, err := environment.FPAuthorizer(tenantID, environment.Environment().ResourceManagerScope)
 	if err != nil {
 		return err
 	}
 	resourceSkusClient := compute.NewResourceSkusClient(azEnv, subscriptionID, fpAuthorizer)
 
-	return validateVMSku(ctx, oc, resourceSkusClient)
+	return s.validateVMSku(ctx, subscriptionID, oc, resourceSkusClient)
 }
 
-// validateVMSku uses resourceSkusClient to ensure that the VM sizes listed in the cluster document are available for use in the target region.
-func validateVMSku(ctx context.Context, oc *api.OpenShiftCluster, resourceSkusClient compute.ResourceSkusClient) error {
+// validateVMSku uses resourceSkusClient to ensure that the VM sizes listed in the cluster document are available for use in the target region
+// and in any zones requested for them, and selects the zones of the region in which the VM sizes of the other profiles all are.
+func (s *skuValidator) validateVMSku(ctx context.Context, subscriptionID string, oc *api.OpenShiftCluster, resourceSkusClient compute.ResourceSkusClient) error {
 	// Get a list of available worker SKUs, filtering by location. We initialized a new resourceSkusClient
 	// so that we can determine SKU availability within target cluster subscription instead of within RP subscription.
 	location := oc.Location
-	filter := fmt.Sprintf("location eq %s", location)
-	skus, err := resourceSkusClient.List(ctx, filter)
+	filteredSkus, err := s.skus.GetOrLoad(ctx, subscriptionID+"/"+strings.ToLower(location), func(ctx context.Context) (map[string]*mgmtcompute.ResourceSku, error) {
+		filter := fmt.Sprintf("location eq %s", location)
+		skus, err := resourceSkusClient.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		return computeskus.FilterVMSizes(skus, location), nil
+	})
 	if err != nil {
 		return err
 	}
 
-	filteredSkus := computeskus.FilterVMSizes(skus, location)
-	masterProfileSku := string(oc.Properties.MasterProfile.VMSize)
-
-	err = checkSKUAvailability(filteredSkus, location, "properties.masterProfile.VMSize", masterProfileSku)
-	if err != nil {
-		return err
+	profiles := []vmProfile{
+		{
+			path:                  "properties.masterProfile",
+			role:                  validate.VMRoleMaster,
+			vmSize:                string(oc.Properties.MasterProfile.VMSize),
+			encryptionAtHost:      oc.Properties.MasterProfile.EncryptionAtHost == api.EncryptionAtHostEnabled,
+			diskType:              oc.Properties.MasterProfile.DiskType,
+			acceleratedNetworking: oc.Properties.MasterProfile.AcceleratedNetworking == api.AcceleratedNetworkingEnabled,
+			zones:                 oc.Properties.MasterProfile.Zones,
+		},
 	}
 
 	workerProfiles, _ := api.GetEnrichedWorkerProfiles(oc.Properties)
@@ -57,29 +87,249 @@ func validateVMSku(ctx context.Context, oc *api.OpenShiftCluster, resourceSkusCl
 	// In case there are multiple WorkerProfiles listed in the cluster document (such as post-install),
 	// compare VMSize in each WorkerProfile to the resourceSkusClient call above to ensure that the sku is available in region.
 	for i, workerprofile := range workerProfiles {
-		workerProfileSku := string(workerprofile.VMSize)
+		profiles = append(profiles, vmProfile{
+			path:                  fmt.Sprintf("properties.workerProfiles[%d]", i),
+			role:                  validate.VMRoleWorker,
+			vmSize:                string(workerprofile.VMSize),
+			encryptionAtHost:      workerprofile.EncryptionAtHost == api.EncryptionAtHostEnabled,
+			diskType:              workerprofile.DiskType,
+			acceleratedNetworking: workerprofile.AcceleratedNetworking == api.AcceleratedNetworkingEnabled,
+			zones:                 workerprofile.Zones,
+		})
+	}
 
-		err = checkSKUAvailability(filteredSkus, location, fmt.Sprintf("properties.workerProfiles[%d].VMSize", i), workerProfileSku)
+	for _, p := range profiles {
+		err = checkSKUAvailability(filteredSkus, location, p)
 		if err != nil {
 			return err
 		}
 	}
 
+	if oc.Properties.ZonePlacement == api.ZonePlacementNonZonal {
+		oc.Properties.Zones = nil
+		return nil
+	}
+
+	// the zones of profiles which don't request their own are selected
+	var automatic []vmProfile
+	for _, p := range profiles {
+		if len(p.zones) == 0 {
+			automatic = append(automatic, p)
+			continue
+		}
+
+		err = checkZoneAvailability(filteredSkus, location, p)
+		if err != nil {
+			return err
+		}
+	}
+
+	zones, err := selectZones(filteredSkus, location, automatic)
+	if err != nil {
+		return err
+	}
+
+	oc.Properties.Zones = zones
+
 	return nil
 }
 
-func checkSKUAvailability(skus map[string]*mgmtcompute.ResourceSku, location, path, vmsize string) error {
+// vmProfile is a VM size requested for the masters or for a worker profile.
+// path is that of the profile.
+type vmProfile struct {
+	path                  string
+	role                  string
+	vmSize                string
+	encryptionAtHost      bool
+	diskType              api.DiskType
+	acceleratedNetworking bool
+	zones                 []string
+}
+
+// supportedBy returns whether sku supports the encryption at host, disk type
+// and accelerated networking requested by p.  Ultra disks are only available
+// in some of the zones of a VM size: see ultraSSDZones.
+func (p vmProfile) supportedBy(sku *mgmtcompute.ResourceSku) (bool, string) {
+	if p.encryptionAtHost && !computeskus.HasCapability(sku, "EncryptionAtHostSupported") {
+		return false, "encryption at host"
+	}
+
+	if p.diskType != "" && !computeskus.HasCapability(sku, "PremiumIO") {
+		return false, "premium disks"
+	}
+
+	if p.diskType == api.DiskTypeUltraSSDLRS && len(computeskus.ZonesWithCapability(sku, ultraSSDCapability)) == 0 {
+		return false, "Ultra disks"
+	}
+
+	if p.acceleratedNetworking && !computeskus.HasCapability(sku, "AcceleratedNetworkingEnabled") {
+		return false, "accelerated networking"
+	}
+
+	return true, ""
+}
+
+const ultraSSDCapability = "UltraSSDAvailable"
+
+func checkSKUAvailability(skus map[string]*mgmtcompute.ResourceSku, location string, p vmProfile) error {
 	// Ensure desired sku exists in target region
-	if skus[vmsize] == nil {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The selected SKU '%v' is unavailable in region '%v'", vmsize, location)
+	if skus[p.vmSize] == nil {
+		return skuError(skus, location, p, nil, "The selected SKU '%v' is unavailable in region '%v'", p.vmSize, location)
 	}
 
 	// Fail if sku is available, but restricted within the subscription. Restrictions are subscription-specific.
 	// https://docs.microsoft.com/en-us/azure/azure-resource-manager/templates/error-sku-not-available
-	isRestricted := computeskus.IsRestricted(skus, location, vmsize)
+	isRestricted := computeskus.IsRestricted(skus, location, p.vmSize)
 	if isRestricted {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The selected SKU '%v' is restricted in region '%v' for selected subscription", vmsize, location)
+		return skuError(skus, location, p, nil, "The selected SKU '%v' is restricted in region '%v' for selected subscription", p.vmSize, location)
+	}
+
+	if ok, feature := p.supportedBy(skus[p.vmSize]); !ok {
+		return skuError(skus, location, p, nil, "The selected SKU '%v' does not support %s in region '%v'", p.vmSize, feature, location)
 	}
 
 	return nil
 }
+
+// selectZones returns the zones of location in which all of the requested VM
+// sizes are available to the subscription.  VM sizes which are not zonal in
+// location don't constrain the selection, and if none are zonal no zones are
+// selected.
+func selectZones(skus map[string]*mgmtcompute.ResourceSku, location string, profiles []vmProfile) ([]string, error) {
+	var zones []string
+	var zonal bool
+
+	for _, p := range profiles {
+		sku := skus[p.vmSize]
+		if len(computeskus.Zones(sku)) == 0 {
+			continue
+		}
+
+		available := p.availableZones(sku, location)
+
+		if !zonal {
+			if len(available) == 0 {
+				return nil, skuError(skus, location, p, nil, "The selected SKU '%v' is restricted in every zone of region '%v' for selected subscription", p.vmSize, location)
+			}
+
+			zones, zonal = available, true
+			continue
+		}
+
+		intersection := intersectZones(zones, available)
+		if len(intersection) == 0 {
+			return nil, skuError(skus, location, p, zones, "The selected SKU '%v' is unavailable in zones %s of region '%v' in which the other selected SKUs are available", p.vmSize, strings.Join(zones, ", "), location)
+		}
+
+		zones = intersection
+	}
+
+	sort.Strings(zones)
+
+	return zones, nil
+}
+
+// checkZoneAvailability ensures that the VM size of p is available to the
+// subscription, with the features p requires, in each of the zones which p
+// requests
+func checkZoneAvailability(skus map[string]*mgmtcompute.ResourceSku, location string, p vmProfile) error {
+	sku := skus[p.vmSize]
+	if len(computeskus.Zones(sku)) == 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, p.path+".zones", "The selected SKU '%v' is not zonal in region '%v'", p.vmSize, location).WithRemediationURL(api.RemediationURLSKUNotAvailable)
+	}
+
+	available := p.availableZones(sku, location)
+	sort.Strings(available)
+
+	availableSet := map[string]struct{}{}
+	for _, zone := range available {
+		availableSet[zone] = struct{}{}
+	}
+
+	for _, zone := range p.zones {
+		if _, ok := availableSet[zone]; !ok {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, p.path+".zones", "The selected SKU '%v' is unavailable in zone '%v' of region '%v'. Available zones: %s", p.vmSize, zone, location, strings.Join(available, ", ")).WithRemediationURL(api.RemediationURLSKUNotAvailable)
+		}
+	}
+
+	return nil
+}
+
+// availableZones returns the zones of location in which sku is available to
+// the subscription, limited to those which support Ultra disks if p requires
+// them
+func (p vmProfile) availableZones(sku *mgmtcompute.ResourceSku, location string) []string {
+	available := computeskus.AvailableZones(sku, location)
+	if p.diskType == api.DiskTypeUltraSSDLRS {
+		available = intersectZones(available, computeskus.ZonesWithCapability(sku, ultraSSDCapability))
+	}
+
+	return available
+}
+
+func intersectZones(zones, other []string) []string {
+	var intersection []string
+
+	for _, zone := range zones {
+		for _, o := range other {
+			if zone == o {
+				intersection = append(intersection, zone)
+				break
+			}
+		}
+	}
+
+	return intersection
+}
+
+// skuError returns an InvalidParameter error for p which lists the VM sizes
+// that could be requested instead: see viableVMSizes
+func skuError(skus map[string]*mgmtcompute.ResourceSku, location string, p vmProfile, zones []string, format string, a ...interface{}) error {
+	message := fmt.Sprintf(format, a...)
+
+	if viable := viableVMSizes(skus, location, p, zones); len(viable) > 0 {
+		message += fmt.Sprintf(". Viable VM sizes: %s", strings.Join(viable, ", "))
+	}
+
+	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, p.path+".VMSize", "%s", message).WithRemediationURL(api.RemediationURLSKUNotAvailable)
+}
+
+// viableVMSizes returns the VM sizes supported for the role of p which are
+// available and unrestricted in location, support the features which p
+// requires and, if p's VM size is known in location, match its premium
+// storage and confidential computing support.  If zones is not empty, the VM
+// sizes must also be available in at least one of them.
+func viableVMSizes(skus map[string]*mgmtcompute.ResourceSku, location string, p vmProfile, zones []string) []string {
+	requested := skus[p.vmSize]
+
+	var viable []string
+	for vmSize := range validate.SupportedVMSizesByRole(p.role) {
+		sku := skus[string(vmSize)]
+		if string(vmSize) == p.vmSize || sku == nil || computeskus.IsRestricted(skus, location, string(vmSize)) {
+			continue
+		}
+
+		if ok, _ := p.supportedBy(sku); !ok {
+			continue
+		}
+
+		if requested != nil &&
+			(computeskus.HasCapability(sku, "PremiumIO") != computeskus.HasCapability(requested, "PremiumIO") ||
+				computeskus.IsConfidential(sku) != computeskus.IsConfidential(requested)) {
+			continue
+		}
+
+		if len(computeskus.Zones(sku)) > 0 {
+			available := computeskus.AvailableZones(sku, location)
+			if len(available) == 0 || (len(zones) > 0 && len(intersectZones(zones, available)) == 0) {
+				continue
+			}
+		}
+
+		viable = append(viable, string(vmSize))
+	}
+
+	sort.Strings(viable)
+
+	return viable
+}
@@ -61,8 +61,11 @@ func (g *gateway) updateGateways(docs []*api.GatewayDocument) {
 		if doc.Gateway.Deleting {
 			// https://docs.microsoft.com/en-us/azure/cosmos-db/change-feed-design-patterns#deletes
 			delete(g.gateways, doc.ID)
+			g.quotas.delete(doc.Gateway.ID)
+			g.diagnostics.delete(doc.Gateway.ID)
 		} else {
 			g.gateways[doc.ID] = doc.Gateway
+			g.quotas.update(doc.Gateway)
 		}
 	}
 }
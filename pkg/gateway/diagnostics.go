@@ -0,0 +1,276 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+// maxRejections is the number of recent rejections kept for each cluster
+const maxRejections = 20
+
+// lookupHost resolves the allowed endpoints of a cluster.  It is a variable so
+// that it can be replaced in unit tests.
+var lookupHost = net.DefaultResolver.LookupHost
+
+// Diagnostics is the connectivity information that a gateway instance reports
+// for a cluster.  Each gateway instance keeps its own counts, since the start
+// time of the gateway process.
+type Diagnostics struct {
+	ResourceID string    `json:"resourceId"`
+	Hostname   string    `json:"hostname"`
+	StartTime  time.Time `json:"startTime"`
+
+	OpenConnections      int64      `json:"openConnections"`
+	AllowedConnections   int64      `json:"allowedConnections"`
+	DeniedConnections    int64      `json:"deniedConnections"`
+	ThrottledConnections int64      `json:"throttledConnections"`
+	LastConnectionTime   *time.Time `json:"lastConnectionTime,omitempty"`
+
+	Rejections []*DiagnosticsRejection `json:"rejections"`
+	DNS        []*DiagnosticsDNSResult `json:"dns"`
+}
+
+// DiagnosticsRejection records why a connection from a cluster was refused
+type DiagnosticsRejection struct {
+	Time     time.Time `json:"time"`
+	Protocol string    `json:"protocol"`
+	Hostname string    `json:"hostname"`
+	Reason   string    `json:"reason"`
+}
+
+// DiagnosticsDNSResult is the result of resolving an endpoint which the
+// cluster is allowed to connect to
+type DiagnosticsDNSResult struct {
+	Hostname  string   `json:"hostname"`
+	Addresses []string `json:"addresses,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+type clusterDiagnostics struct {
+	allowed        int64
+	denied         int64
+	throttled      int64
+	lastConnection time.Time
+	rejections     []*DiagnosticsRejection
+}
+
+// diagnostics records the outcome of the connections of each cluster, keyed by
+// lower case cluster resource ID.  The zero value is ready to use.
+type diagnostics struct {
+	mu sync.Mutex
+	m  map[string]*clusterDiagnostics
+}
+
+func (d *diagnostics) get(clusterResourceID string) *clusterDiagnostics {
+	key := strings.ToLower(clusterResourceID)
+
+	if d.m == nil {
+		d.m = map[string]*clusterDiagnostics{}
+	}
+
+	cd := d.m[key]
+	if cd == nil {
+		cd = &clusterDiagnostics{}
+		d.m[key] = cd
+	}
+
+	return cd
+}
+
+func (d *diagnostics) recordAllowed(clusterResourceID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cd := d.get(clusterResourceID)
+	cd.allowed++
+	cd.lastConnection = time.Now()
+}
+
+func (d *diagnostics) recordRejected(clusterResourceID, protocol, hostname, reason string, throttled bool) {
+	if clusterResourceID == "" {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cd := d.get(clusterResourceID)
+	if throttled {
+		cd.throttled++
+	} else {
+		cd.denied++
+	}
+	cd.lastConnection = time.Now()
+
+	cd.rejections = append(cd.rejections, &DiagnosticsRejection{
+		Time:     cd.lastConnection,
+		Protocol: protocol,
+		Hostname: hostname,
+		Reason:   reason,
+	})
+	if len(cd.rejections) > maxRejections {
+		cd.rejections = cd.rejections[len(cd.rejections)-maxRejections:]
+	}
+}
+
+func (d *diagnostics) delete(clusterResourceID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.m, strings.ToLower(clusterResourceID))
+}
+
+// fill copies the recorded counts and rejections of the cluster into diag
+func (d *diagnostics) fill(diag *Diagnostics) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cd := d.m[strings.ToLower(diag.ResourceID)]
+	if cd == nil {
+		return
+	}
+
+	diag.AllowedConnections = cd.allowed
+	diag.DeniedConnections = cd.denied
+	diag.ThrottledConnections = cd.throttled
+	if !cd.lastConnection.IsZero() {
+		lastConnection := cd.lastConnection
+		diag.LastConnectionTime = &lastConnection
+	}
+	diag.Rejections = append(diag.Rejections, cd.rejections...)
+}
+
+// allowedHostnames returns the endpoints which the cluster is allowed to
+// connect to
+func (g *gateway) allowedHostnames(gateway *api.Gateway) []string {
+	hostnames := make([]string, 0, len(g.allowList)+2)
+	for hostname := range g.allowList {
+		hostnames = append(hostnames, hostname)
+	}
+	sort.Strings(hostnames)
+
+	if gateway.ImageRegistryStorageAccountName != "" {
+		hostnames = append(hostnames, strings.ToLower(gateway.ImageRegistryStorageAccountName+".blob."+g.env.Environment().StorageEndpointSuffix))
+	}
+	if gateway.StorageSuffix != "" {
+		hostnames = append(hostnames, strings.ToLower("cluster"+gateway.StorageSuffix+".blob."+g.env.Environment().StorageEndpointSuffix))
+	}
+
+	return hostnames
+}
+
+func resolve(ctx context.Context, hostnames []string) []*DiagnosticsDNSResult {
+	results := make([]*DiagnosticsDNSResult, len(hostnames))
+
+	var wg sync.WaitGroup
+	for i, hostname := range hostnames {
+		results[i] = &DiagnosticsDNSResult{
+			Hostname: hostname,
+		}
+
+		wg.Add(1)
+		go func(result *DiagnosticsDNSResult) {
+			defer wg.Done()
+
+			addresses, err := lookupHost(ctx, result.Hostname)
+			if err != nil {
+				result.Error = err.Error()
+				return
+			}
+			result.Addresses = addresses
+		}(results[i])
+	}
+	wg.Wait()
+
+	return results
+}
+
+// getDiagnostics reports connectivity information for the cluster given by the
+// resourceId query parameter, for triage via the admin API
+func (g *gateway) getDiagnostics(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	resourceID := r.URL.Query().Get("resourceId")
+	if resourceID == "" {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	doc, err := g.dbGateway.GetByClusterResourceID(ctx, strings.ToLower(resourceID))
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		http.Error(w, "gateway record not found", http.StatusNotFound)
+		return
+	case err != nil:
+		g.log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	case doc.Gateway.Deleting:
+		http.Error(w, "gateway record not found", http.StatusNotFound)
+		return
+	}
+
+	gateway := doc.Gateway
+
+	hostname, _ := os.Hostname()
+
+	diag := &Diagnostics{
+		ResourceID: gateway.ID,
+		Hostname:   hostname,
+		StartTime:  g.startTime,
+		Rejections: []*DiagnosticsRejection{},
+	}
+
+	if q := g.quotas.snapshot()[strings.ToLower(gateway.ID)]; q != nil {
+		diag.OpenConnections = atomic.LoadInt64(&q.connections)
+	}
+
+	g.diagnostics.fill(diag)
+
+	diag.DNS = resolve(ctx, g.allowedHostnames(gateway))
+
+	w.Header().Set("Content-Type", "application/json")
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "    ")
+	_ = e.Encode(diag)
+}
+
+// authenticateDiagnostics only allows requests carrying a valid bearer token
+// whose subject is one of the configured diagnostics subjects
+func (g *gateway) authenticateDiagnostics(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		token, err := g.diagnosticsVerifier.Verify(r.Context(), strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		if _, found := g.diagnosticsSubjects[strings.ToLower(token.Subject())]; !found {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
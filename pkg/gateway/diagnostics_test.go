@@ -0,0 +1,248 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	"github.com/Azure/ARO-RP/pkg/util/oidc"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestDiagnosticsRecordRejected(t *testing.T) {
+	var d diagnostics
+
+	for i := 0; i < maxRejections+5; i++ {
+		d.recordRejected("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster", "https", fmt.Sprintf("host%d", i), "hostname not allowed", false)
+	}
+	d.recordRejected("/subscriptions/sub/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster", "http", "host", "connection quota exceeded", true)
+	d.recordAllowed("/subscriptions/sub/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster")
+
+	// rejections without a known cluster are dropped
+	d.recordRejected("", "https", "host", "gateway record not found", false)
+
+	diag := &Diagnostics{ResourceID: "/subscriptions/sub/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster"}
+	d.fill(diag)
+
+	if diag.AllowedConnections != 1 || diag.DeniedConnections != maxRejections+5 || diag.ThrottledConnections != 1 {
+		t.Error(diag.AllowedConnections, diag.DeniedConnections, diag.ThrottledConnections)
+	}
+	if len(diag.Rejections) != maxRejections {
+		t.Fatal(len(diag.Rejections))
+	}
+	if diag.Rejections[0].Hostname != "host6" {
+		t.Error(diag.Rejections[0].Hostname)
+	}
+	if diag.Rejections[maxRejections-1].Reason != "connection quota exceeded" {
+		t.Error(diag.Rejections[maxRejections-1].Reason)
+	}
+	if diag.LastConnectionTime == nil {
+		t.Error("expected lastConnectionTime to be set")
+	}
+	if len(d.m) != 1 {
+		t.Error(d.m)
+	}
+
+	d.delete("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster")
+
+	if len(d.m) != 0 {
+		t.Error(d.m)
+	}
+}
+
+func TestGetDiagnostics(t *testing.T) {
+	resourceID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster"
+
+	defer func(f func(context.Context, string) ([]string, error)) { lookupHost = f }(lookupHost)
+	lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		if host == "clusterabc.blob.core.windows.net" {
+			return nil, errors.New("no such host")
+		}
+		return []string{"10.0.0.1"}, nil
+	}
+
+	for _, tt := range []struct {
+		name           string
+		authorization  string
+		query          string
+		deleting       bool
+		wantStatusCode int
+		wantResponse   *Diagnostics
+	}{
+		{
+			name:           "diagnostics returned",
+			authorization:  `Bearer {"sub":"00000000-0000-0000-0000-000000000001"}`,
+			query:          "?resourceId=" + resourceID,
+			wantStatusCode: http.StatusOK,
+			wantResponse: &Diagnostics{
+				ResourceID:         resourceID,
+				OpenConnections:    1,
+				AllowedConnections: 1,
+				Rejections:         []*DiagnosticsRejection{},
+				DNS: []*DiagnosticsDNSResult{
+					{
+						Hostname:  "management.azure.com",
+						Addresses: []string{"10.0.0.1"},
+					},
+					{
+						Hostname:  "registry.blob.core.windows.net",
+						Addresses: []string{"10.0.0.1"},
+					},
+					{
+						Hostname: "clusterabc.blob.core.windows.net",
+						Error:    "no such host",
+					},
+				},
+			},
+		},
+		{
+			name:           "missing token",
+			query:          "?resourceId=" + resourceID,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "subject not allowed",
+			authorization:  `Bearer {"sub":"00000000-0000-0000-0000-000000000002"}`,
+			query:          "?resourceId=" + resourceID,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "missing resource ID",
+			authorization:  `Bearer {"sub":"00000000-0000-0000-0000-000000000001"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "resource ID in lower case",
+			authorization:  `Bearer {"sub":"00000000-0000-0000-0000-000000000001"}`,
+			query:          "?resourceId=" + strings.ToLower(resourceID),
+			wantStatusCode: http.StatusOK,
+			wantResponse: &Diagnostics{
+				ResourceID:         resourceID,
+				OpenConnections:    1,
+				AllowedConnections: 1,
+				Rejections:         []*DiagnosticsRejection{},
+				DNS: []*DiagnosticsDNSResult{
+					{
+						Hostname:  "management.azure.com",
+						Addresses: []string{"10.0.0.1"},
+					},
+					{
+						Hostname:  "registry.blob.core.windows.net",
+						Addresses: []string{"10.0.0.1"},
+					},
+					{
+						Hostname: "clusterabc.blob.core.windows.net",
+						Error:    "no such host",
+					},
+				},
+			},
+		},
+		{
+			name:           "gateway record being deleted",
+			authorization:  `Bearer {"sub":"00000000-0000-0000-0000-000000000001"}`,
+			query:          "?resourceId=" + resourceID,
+			deleting:       true,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "unknown cluster",
+			authorization:  `Bearer {"sub":"00000000-0000-0000-0000-000000000001"}`,
+			query:          "?resourceId=/subscriptions/sub/resourceGroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/other",
+			wantStatusCode: http.StatusNotFound,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			env := mock_env.NewMockCore(controller)
+			env.EXPECT().Environment().AnyTimes().Return(&azureclient.AROEnvironment{
+				Environment: azure.Environment{
+					StorageEndpointSuffix: "core.windows.net",
+				},
+			})
+
+			dbGateway, _ := testdatabase.NewFakeGateway()
+			f := testdatabase.NewFixture().WithGateway(dbGateway)
+			f.AddGatewayDocuments(&api.GatewayDocument{
+				ID:  "1",
+				Key: strings.ToLower(resourceID),
+				Gateway: &api.Gateway{
+					ID:                              resourceID,
+					StorageSuffix:                   "abc",
+					ImageRegistryStorageAccountName: "registry",
+					Deleting:                        tt.deleting,
+				},
+			})
+			err := f.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			g := &gateway{
+				env:       env,
+				dbGateway: dbGateway,
+				allowList: map[string]struct{}{
+					"management.azure.com": {},
+				},
+				diagnosticsVerifier: &oidc.NoopVerifier{},
+				diagnosticsSubjects: map[string]struct{}{
+					"00000000-0000-0000-0000-000000000001": {},
+				},
+			}
+
+			g.diagnostics.recordAllowed(resourceID)
+			g.quotas.get(resourceID).acquire()
+
+			r, err := http.NewRequest(http.MethodGet, "/diagnostics"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+
+			w := httptest.NewRecorder()
+			g.authenticateDiagnostics(http.HandlerFunc(g.getDiagnostics)).ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatusCode {
+				t.Fatal(w.Code)
+			}
+
+			if tt.wantResponse == nil {
+				return
+			}
+
+			var diag *Diagnostics
+			err = json.NewDecoder(w.Body).Decode(&diag)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diag.LastConnectionTime == nil {
+				t.Error("expected lastConnectionTime to be set")
+			}
+			diag.LastConnectionTime = nil
+			diag.Hostname = ""
+
+			if !reflect.DeepEqual(diag, tt.wantResponse) {
+				t.Errorf("%#v", diag)
+			}
+		})
+	}
+}
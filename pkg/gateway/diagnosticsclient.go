@@ -0,0 +1,236 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+)
+
+// diagnosticsPort is the port of the gateway health listener, which serves the
+// diagnostics endpoint
+const diagnosticsPort = "8081"
+
+// ErrDiagnosticsNotFound is returned when the gateway has no record of the
+// cluster
+var ErrDiagnosticsNotFound = errors.New("gateway record not found")
+
+// AggregatedDiagnostics is the connectivity information of a cluster across
+// the gateway instances.  The counts are summed over the instances which
+// answered; the rejections and DNS results of each instance are in Instances.
+type AggregatedDiagnostics struct {
+	ResourceID string `json:"resourceId"`
+
+	OpenConnections      int64      `json:"openConnections"`
+	AllowedConnections   int64      `json:"allowedConnections"`
+	DeniedConnections    int64      `json:"deniedConnections"`
+	ThrottledConnections int64      `json:"throttledConnections"`
+	LastConnectionTime   *time.Time `json:"lastConnectionTime,omitempty"`
+
+	Instances []*Diagnostics `json:"instances"`
+
+	// Errors lists the gateway instances which could not be queried
+	Errors []*DiagnosticsError `json:"errors,omitempty"`
+}
+
+// DiagnosticsError records why a gateway instance could not be queried
+type DiagnosticsError struct {
+	Address string `json:"address"`
+	Error   string `json:"error"`
+}
+
+// DiagnosticsClient retrieves cluster diagnostics from the gateway
+type DiagnosticsClient interface {
+	Diagnostics(ctx context.Context, clusterResourceID string) (*AggregatedDiagnostics, error)
+}
+
+type doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+type diagnosticsClient struct {
+	c          doer
+	authorizer autorest.Authorizer
+
+	virtualMachineScaleSets compute.VirtualMachineScaleSetsClient
+	interfaces              network.InterfacesClient
+	resourceGroup           string
+	port                    string
+}
+
+// NewDiagnosticsClient returns a client which queries the diagnostics endpoint
+// of every gateway instance in the gateway resource group.  Each instance
+// keeps its own counts, so all of them are asked.  armAuthorizer must be
+// allowed to read the scalesets and network interfaces of the resource group,
+// and authorizer must produce tokens for the audience that the gateway
+// verifies.
+func NewDiagnosticsClient(environment *azureclient.AROEnvironment, subscriptionID string, armAuthorizer, authorizer autorest.Authorizer, resourceGroup string) DiagnosticsClient {
+	return &diagnosticsClient{
+		c: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		authorizer: authorizer,
+
+		virtualMachineScaleSets: compute.NewVirtualMachineScaleSetsClient(environment, subscriptionID, armAuthorizer),
+		interfaces:              network.NewInterfacesClient(environment, subscriptionID, armAuthorizer),
+		resourceGroup:           resourceGroup,
+		port:                    diagnosticsPort,
+	}
+}
+
+// addresses returns the private IP addresses of the gateway instances
+func (c *diagnosticsClient) addresses(ctx context.Context) ([]string, error) {
+	scaleSets, err := c.virtualMachineScaleSets.List(ctx, c.resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+	for _, scaleSet := range scaleSets {
+		interfaces, err := c.interfaces.ListVirtualMachineScaleSetNetworkInterfaces(ctx, c.resourceGroup, *scaleSet.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, intf := range interfaces {
+			if intf.InterfacePropertiesFormat == nil || intf.IPConfigurations == nil {
+				continue
+			}
+
+			for _, ipc := range *intf.IPConfigurations {
+				if ipc.InterfaceIPConfigurationPropertiesFormat != nil && ipc.PrivateIPAddress != nil {
+					addresses = append(addresses, *ipc.PrivateIPAddress)
+				}
+			}
+		}
+	}
+
+	return addresses, nil
+}
+
+func (c *diagnosticsClient) Diagnostics(ctx context.Context, clusterResourceID string) (*AggregatedDiagnostics, error) {
+	addresses, err := c.addresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addresses) == 0 {
+		return nil, errors.New("no gateway instances found")
+	}
+
+	results := make([]*Diagnostics, len(addresses))
+	errs := make([]error, len(addresses))
+
+	var wg sync.WaitGroup
+	for i := range addresses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = c.instanceDiagnostics(ctx, addresses[i], clusterResourceID)
+		}(i)
+	}
+	wg.Wait()
+
+	agg := &AggregatedDiagnostics{
+		ResourceID: clusterResourceID,
+		Instances:  []*Diagnostics{},
+	}
+
+	var notFound int
+	for i, diag := range results {
+		if errs[i] != nil {
+			if errs[i] == ErrDiagnosticsNotFound {
+				notFound++
+			}
+			agg.Errors = append(agg.Errors, &DiagnosticsError{
+				Address: addresses[i],
+				Error:   errs[i].Error(),
+			})
+			continue
+		}
+
+		agg.ResourceID = diag.ResourceID
+		agg.OpenConnections += diag.OpenConnections
+		agg.AllowedConnections += diag.AllowedConnections
+		agg.DeniedConnections += diag.DeniedConnections
+		agg.ThrottledConnections += diag.ThrottledConnections
+		if diag.LastConnectionTime != nil && (agg.LastConnectionTime == nil || diag.LastConnectionTime.After(*agg.LastConnectionTime)) {
+			agg.LastConnectionTime = diag.LastConnectionTime
+		}
+
+		agg.Instances = append(agg.Instances, diag)
+	}
+
+	if len(agg.Instances) == 0 {
+		if notFound == len(addresses) {
+			return nil, ErrDiagnosticsNotFound
+		}
+		return nil, fmt.Errorf("no gateway instance answered: %s", agg.Errors[0].Error)
+	}
+
+	sort.Slice(agg.Instances, func(i, j int) bool {
+		return agg.Instances[i].Hostname < agg.Instances[j].Hostname
+	})
+
+	return agg, nil
+}
+
+// instanceDiagnostics queries the diagnostics endpoint of the gateway instance
+// at address
+func (c *diagnosticsClient) instanceDiagnostics(ctx context.Context, address, clusterResourceID string) (*Diagnostics, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+net.JoinHostPort(address, c.port)+"/diagnostics", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{
+		"resourceId": []string{clusterResourceID},
+	}
+	req.URL.RawQuery = q.Encode()
+
+	req, err = autorest.Prepare(req, c.authorizer.WithAuthorization())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDiagnosticsNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if resp.Header.Get("Content-Type") != "application/json" {
+		return nil, fmt.Errorf("unexpected content type %q", resp.Header.Get("Content-Type"))
+	}
+
+	var diag *Diagnostics
+	err = json.NewDecoder(resp.Body).Decode(&diag)
+	if err != nil {
+		return nil, err
+	}
+
+	return diag, nil
+}
@@ -0,0 +1,183 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type fakeDoer func(*http.Request) (*http.Response, error)
+
+func (f fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestDiagnosticsClient(t *testing.T) {
+	ctx := context.Background()
+
+	resourceID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster"
+	lastConnection1 := time.Unix(10, 0).UTC()
+	lastConnection2 := time.Unix(20, 0).UTC()
+
+	instance1 := &Diagnostics{
+		ResourceID:         resourceID,
+		Hostname:           "gateway-vmss000001",
+		OpenConnections:    1,
+		AllowedConnections: 2,
+		LastConnectionTime: &lastConnection1,
+		Rejections:         []*DiagnosticsRejection{},
+	}
+	instance2 := &Diagnostics{
+		ResourceID:           resourceID,
+		Hostname:             "gateway-vmss000000",
+		AllowedConnections:   3,
+		DeniedConnections:    4,
+		ThrottledConnections: 5,
+		LastConnectionTime:   &lastConnection2,
+		Rejections:           []*DiagnosticsRejection{},
+	}
+
+	reply := func(diag *Diagnostics) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(diag)
+		}
+	}
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gateway record not found", http.StatusNotFound)
+	})
+	forbidden := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	for _, tt := range []struct {
+		name      string
+		listErr   error
+		instances map[string]http.Handler
+		want      *AggregatedDiagnostics
+		wantErr   string
+	}{
+		{
+			name: "counts are summed across instances",
+			instances: map[string]http.Handler{
+				"10.0.8.4": reply(instance1),
+				"10.0.8.5": reply(instance2),
+				"10.0.8.6": forbidden,
+			},
+			want: &AggregatedDiagnostics{
+				ResourceID:           resourceID,
+				OpenConnections:      1,
+				AllowedConnections:   5,
+				DeniedConnections:    4,
+				ThrottledConnections: 5,
+				LastConnectionTime:   &lastConnection2,
+				Instances:            []*Diagnostics{instance2, instance1},
+				Errors: []*DiagnosticsError{
+					{
+						Address: "10.0.8.6",
+						Error:   "unexpected status code 403",
+					},
+				},
+			},
+		},
+		{
+			name: "no instance has a record",
+			instances: map[string]http.Handler{
+				"10.0.8.4": notFound,
+				"10.0.8.5": notFound,
+			},
+			wantErr: "gateway record not found",
+		},
+		{
+			name: "no instance answered",
+			instances: map[string]http.Handler{
+				"10.0.8.4": notFound,
+				"10.0.8.5": forbidden,
+			},
+			wantErr: "no gateway instance answered: gateway record not found",
+		},
+		{
+			name:    "listing fails",
+			listErr: errors.New("forbidden"),
+			wantErr: "forbidden",
+		},
+		{
+			name:    "no instances",
+			wantErr: "no gateway instances found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			scaleSets := mock_compute.NewMockVirtualMachineScaleSetsClient(controller)
+			scaleSets.EXPECT().List(gomock.Any(), "gateway-eastus").Return([]mgmtcompute.VirtualMachineScaleSet{
+				{Name: to.StringPtr("gateway-vmss-1")},
+			}, tt.listErr)
+
+			var ipConfigurations []mgmtnetwork.InterfaceIPConfiguration
+			for address := range tt.instances {
+				ipConfigurations = append(ipConfigurations, mgmtnetwork.InterfaceIPConfiguration{
+					InterfaceIPConfigurationPropertiesFormat: &mgmtnetwork.InterfaceIPConfigurationPropertiesFormat{
+						PrivateIPAddress: to.StringPtr(address),
+					},
+				})
+			}
+
+			interfaces := mock_network.NewMockInterfacesClient(controller)
+			if tt.listErr == nil {
+				interfaces.EXPECT().ListVirtualMachineScaleSetNetworkInterfaces(gomock.Any(), "gateway-eastus", "gateway-vmss-1").Return([]mgmtnetwork.Interface{
+					{
+						InterfacePropertiesFormat: &mgmtnetwork.InterfacePropertiesFormat{
+							IPConfigurations: &ipConfigurations,
+						},
+					},
+				}, nil)
+			}
+
+			c := &diagnosticsClient{
+				c: fakeDoer(func(req *http.Request) (*http.Response, error) {
+					if req.URL.Port() != diagnosticsPort || req.URL.Path != "/diagnostics" || req.URL.Query().Get("resourceId") != resourceID {
+						t.Errorf("unexpected request %s", req.URL)
+					}
+
+					w := httptest.NewRecorder()
+					tt.instances[req.URL.Hostname()].ServeHTTP(w, req)
+					return w.Result(), nil
+				}),
+				authorizer:              autorest.NullAuthorizer{},
+				virtualMachineScaleSets: scaleSets,
+				interfaces:              interfaces,
+				resourceGroup:           "gateway-eastus",
+				port:                    diagnosticsPort,
+			}
+
+			got, err := c.Diagnostics(ctx, resourceID)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				gotb, _ := json.Marshal(got)
+				wantb, _ := json.Marshal(tt.want)
+				t.Error(string(gotb), string(wantb))
+			}
+		})
+	}
+}
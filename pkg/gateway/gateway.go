@@ -26,6 +26,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/portal/middleware"
 	"github.com/Azure/ARO-RP/pkg/util/heartbeat"
+	"github.com/Azure/ARO-RP/pkg/util/oidc"
 )
 
 type Runnable interface {
@@ -69,7 +70,18 @@ type gateway struct {
 	server       *http.Server
 	healthServer *http.Server
 
-	allowList map[string]struct{}
+	allowList   map[string]struct{}
+	quotas      quotas
+	diagnostics diagnostics
+	breakers    breakers
+	pool        pool
+
+	// dial replaces the upstream dialer in unit tests
+	dial func(ctx context.Context, network, address string) (net.Conn, error)
+
+	diagnosticsVerifier oidc.Verifier
+	diagnosticsSubjects map[string]struct{}
+	startTime           time.Time
 
 	m                metrics.Emitter
 	httpConnections  int64
@@ -90,7 +102,7 @@ const SocketSize = 65536
 
 // TODO: may one day want to limit gateway readiness on # active connections
 
-func NewGateway(ctx context.Context, env env.Core, baseLog, accessLog *logrus.Entry, dbGateway database.Gateway, httpsl, httpl, httpHealthl net.Listener, acrResourceID, gatewayDomains string, m metrics.Emitter) (Runnable, error) {
+func NewGateway(ctx context.Context, env env.Core, baseLog, accessLog *logrus.Entry, dbGateway database.Gateway, httpsl, httpl, httpHealthl net.Listener, acrResourceID, gatewayDomains string, diagnosticsVerifier oidc.Verifier, diagnosticsSubjects []string, m metrics.Emitter) (Runnable, error) {
 	var domains []string
 	if gatewayDomains != "" {
 		domains = strings.Split(gatewayDomains, ",")
@@ -129,6 +141,11 @@ func NewGateway(ctx context.Context, env env.Core, baseLog, accessLog *logrus.En
 		allowList[strings.ToLower(domain)] = struct{}{}
 	}
 
+	subjects := make(map[string]struct{}, len(diagnosticsSubjects))
+	for _, subject := range diagnosticsSubjects {
+		subjects[strings.ToLower(subject)] = struct{}{}
+	}
+
 	g := &gateway{
 		env:       env,
 		log:       baseLog,
@@ -171,7 +188,12 @@ func NewGateway(ctx context.Context, env env.Core, baseLog, accessLog *logrus.En
 		},
 
 		allowList: allowList,
-		m:         m,
+
+		diagnosticsVerifier: diagnosticsVerifier,
+		diagnosticsSubjects: subjects,
+		startTime:           time.Now(),
+
+		m: m,
 	}
 
 	panicMiddleware := middleware.Panic(baseLog)
@@ -183,7 +205,19 @@ func NewGateway(ctx context.Context, env env.Core, baseLog, accessLog *logrus.En
 	chiRouter.Connect("/*", http.HandlerFunc(g.handleConnect))
 
 	g.server.Handler = chiRouter
-	g.healthServer.Handler = chiRouter
+
+	// the diagnostics endpoint is only served on the health listener, which
+	// is not reachable from clusters via the private link service
+	healthRouter := chi.NewMux()
+	healthRouter.Use(panicMiddleware)
+
+	healthRouter.Get("/healthz/ready", http.HandlerFunc(g.checkReady))
+	healthRouter.Connect("/*", http.HandlerFunc(g.handleConnect))
+	if diagnosticsVerifier != nil {
+		healthRouter.With(g.authenticateDiagnostics).Get("/diagnostics", http.HandlerFunc(g.getDiagnostics))
+	}
+
+	g.healthServer.Handler = healthRouter
 
 	g.ready.Store(true)
 
@@ -194,6 +228,7 @@ func (g *gateway) Run(ctx context.Context, done chan<- struct{}) {
 	go g.changefeed(ctx)
 
 	go g.emitMetrics()
+	go g.prunePool(ctx)
 	go heartbeat.EmitHeartbeat(g.log, g.m, "gateway.heartbeat", nil, g.isReady)
 
 	go func() {
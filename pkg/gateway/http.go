@@ -4,6 +4,7 @@ package gateway
 // Licensed under the Apache License 2.0.
 
 import (
+	"io"
 	"net"
 	"net/http"
 	"sync/atomic"
@@ -36,6 +37,7 @@ func (g *gateway) handleConnect(w http.ResponseWriter, r *http.Request) {
 	clusterResourceID, isAllowed, err := g.isAllowed(conn, host)
 	if err != nil {
 		g.log.Error(err)
+		g.diagnostics.recordRejected(clusterResourceID, "http", host, err.Error(), false)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
@@ -49,20 +51,54 @@ func (g *gateway) handleConnect(w http.ResponseWriter, r *http.Request) {
 			"protocol": "http",
 			"action":   "denied",
 		})
+		reason := "hostname not allowed"
+		if isAllowed {
+			reason = "port " + port + " not allowed"
+		}
+		g.diagnostics.recordRejected(clusterResourceID, "http", host, reason, false)
 		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
 		return
 	}
 
+	quota := g.quotas.get(clusterResourceID)
+	if !quota.acquire() {
+		log.Print("connection quota exceeded")
+		g.m.EmitGauge("gateway.connections", 1, map[string]string{
+			"protocol": "http",
+			"action":   "throttled",
+		})
+		g.diagnostics.recordRejected(clusterResourceID, "http", host, "connection quota exceeded", true)
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+		return
+	}
+	defer quota.release()
+
+	c2, err := g.dialUpstream(ctx, "http", host, port)
+	if err != nil {
+		g.upstreamUnavailable(log, clusterResourceID, "http", host, err)
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+
 	log.Print("access allowed")
 	g.m.EmitGauge("gateway.connections", 1, map[string]string{
 		"protocol": "http",
 		"action":   "allowed",
 	})
+	g.diagnostics.recordAllowed(clusterResourceID)
 
 	atomic.AddInt64(&g.httpConnections, 1)
 	defer atomic.AddInt64(&g.httpConnections, -1)
 
-	proxy.Proxy(g.log, w, r, SocketSize)
+	// conn is the connection which the HTTP stack hands over to the proxy on
+	// Hijack()
+	setKeepAlive(conn.Raw())
+	idle := newIdleTracker(idleTimeout, conn, c2)
+	defer g.emitConnectionClosed("http", time.Now(), idle)
+
+	proxy.ProxyConn(g.log, w, r, c2, func(r io.Reader) io.Reader {
+		return idle.reader(quota.reader(ctx, r))
+	})
 }
 
 func (g *gateway) checkReady(w http.ResponseWriter, r *http.Request) {
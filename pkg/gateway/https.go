@@ -10,11 +10,11 @@ import (
 	"io"
 	"net"
 	"sync/atomic"
+	"time"
 
 	"github.com/pires/go-proxyproto"
 
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
-	utilnet "github.com/Azure/ARO-RP/pkg/util/net"
 	"github.com/Azure/ARO-RP/pkg/util/recover"
 )
 
@@ -61,6 +61,7 @@ func (g *gateway) handleHTTPS(ctx context.Context, _c net.Conn) {
 	clusterResourceID, isAllowed, err := g.isAllowed(conn, serverName)
 	if err != nil {
 		g.log.Error(err)
+		g.diagnostics.recordRejected(clusterResourceID, "https", serverName, err.Error(), false)
 		return
 	}
 
@@ -73,28 +74,48 @@ func (g *gateway) handleHTTPS(ctx context.Context, _c net.Conn) {
 			"protocol": "https",
 			"action":   "denied",
 		})
+		g.diagnostics.recordRejected(clusterResourceID, "https", serverName, "hostname not allowed", false)
 		return
 	}
 
+	quota := g.quotas.get(clusterResourceID)
+	if !quota.acquire() {
+		log.Print("connection quota exceeded")
+		g.m.EmitGauge("gateway.connections", 1, map[string]string{
+			"protocol": "https",
+			"action":   "throttled",
+		})
+		g.diagnostics.recordRejected(clusterResourceID, "https", serverName, "connection quota exceeded", true)
+		return
+	}
+	defer quota.release()
+
+	// 3. Dial the second leg of the connection (c2).
+	c2, err := g.dialUpstream(ctx, "https", serverName, "443")
+	if err != nil {
+		g.upstreamUnavailable(log, clusterResourceID, "https", serverName, err)
+		return
+	}
+
+	defer c2.Close()
+
 	log.Print("access allowed")
 	g.m.EmitGauge("gateway.connections", 1, map[string]string{
 		"protocol": "https",
 		"action":   "allowed",
 	})
+	g.diagnostics.recordAllowed(clusterResourceID)
 
 	atomic.AddInt64(&g.httpsConnections, 1)
 	defer atomic.AddInt64(&g.httpsConnections, -1)
 
-	// 3. Dial the second leg of the connection (c2).
-	c2, err := utilnet.Dial("tcp", serverName+":443", SocketSize)
-	if err != nil {
-		return
-	}
+	setKeepAlive(conn.Raw())
+	idle := newIdleTracker(idleTimeout, conn, c2)
+	defer g.emitConnectionClosed("https", time.Now(), idle)
 
-	defer c2.Close()
 	ch := make(chan struct{})
 
-	// 4. Proxy c1<->c2.
+	// 4. Proxy c1<->c2, keeping within the cluster's bandwidth quota.
 	go func() {
 		defer recover.Panic(g.log)
 		defer close(ch)
@@ -102,7 +123,7 @@ func (g *gateway) handleHTTPS(ctx context.Context, _c net.Conn) {
 			_ = conn.Raw().(*net.TCPConn).CloseWrite()
 		}()
 
-		_, _ = io.Copy(c1, c2)
+		_, _ = io.Copy(c1, idle.reader(quota.reader(ctx, c2)))
 	}()
 
 	func() {
@@ -110,7 +131,7 @@ func (g *gateway) handleHTTPS(ctx context.Context, _c net.Conn) {
 			_ = c2.(*net.TCPConn).CloseWrite()
 		}()
 
-		_, _ = io.Copy(c2, c1)
+		_, _ = io.Copy(c2, idle.reader(quota.reader(ctx, c1)))
 	}()
 
 	<-ch
@@ -30,6 +30,49 @@ func (g *gateway) _emitMetrics() {
 		"protocol": "https",
 	})
 
+	// only clusters with traffic since the last emission are reported, to
+	// keep the number of metric series down
+	for resourceID, q := range g.quotas.snapshot() {
+		connections := atomic.LoadInt64(&q.connections)
+		bytes := atomic.SwapInt64(&q.bytes, 0)
+		throttled := atomic.SwapInt64(&q.throttled, 0)
+
+		if connections == 0 && bytes == 0 && throttled == 0 {
+			continue
+		}
+
+		dims := map[string]string{
+			"resourceId": resourceID,
+		}
+
+		g.m.EmitGauge("gateway.cluster.connections.open", connections, dims)
+		g.m.EmitGauge("gateway.cluster.bytes", bytes, dims)
+		g.m.EmitGauge("gateway.cluster.connections.throttled", throttled, dims)
+	}
+
+	// only endpoints whose circuit breaker is open or refused connections
+	// since the last emission are reported
+	now := time.Now()
+	for address, b := range g.breakers.snapshot() {
+		rejected := atomic.SwapInt64(&b.rejected, 0)
+
+		var open int64
+		if b.isOpen(now) {
+			open = 1
+		}
+
+		if open == 0 && rejected == 0 {
+			continue
+		}
+
+		dims := map[string]string{
+			"endpoint": address,
+		}
+
+		g.m.EmitGauge("gateway.upstream.circuit.open", open, dims)
+		g.m.EmitGauge("gateway.upstream.circuit.rejected", rejected, dims)
+	}
+
 	if lastChangefeed, ok := g.lastChangefeed.Load().(time.Time); ok {
 		g.m.EmitGauge("gateway.lastchangefeed", lastChangefeed.Unix(), nil)
 	}
@@ -55,3 +55,69 @@ func TestEmitMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestEmitClusterMetrics(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	mock_metrics := mock_metrics.NewMockEmitter(mockController)
+
+	gateway := gateway{
+		m: mock_metrics,
+	}
+
+	busy := gateway.quotas.get("/subscriptions/sub/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/busy")
+	busy.connections = 3
+	busy.bytes = 1000
+	busy.throttled = 2
+
+	// idle clusters are not reported
+	gateway.quotas.get("/subscriptions/sub/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/idle")
+
+	dims := map[string]string{"resourceId": "/subscriptions/sub/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/busy"}
+
+	mock_metrics.EXPECT().EmitGauge("gateway.connections.open", int64(0), map[string]string{"protocol": "http"})
+	mock_metrics.EXPECT().EmitGauge("gateway.connections.open", int64(0), map[string]string{"protocol": "https"})
+	mock_metrics.EXPECT().EmitGauge("gateway.cluster.connections.open", int64(3), dims)
+	mock_metrics.EXPECT().EmitGauge("gateway.cluster.bytes", int64(1000), dims)
+	mock_metrics.EXPECT().EmitGauge("gateway.cluster.connections.throttled", int64(2), dims)
+
+	gateway._emitMetrics()
+
+	if busy.bytes != 0 || busy.throttled != 0 {
+		t.Error(busy.bytes, busy.throttled)
+	}
+}
+
+func TestEmitCircuitMetrics(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	mock_metrics := mock_metrics.NewMockEmitter(mockController)
+
+	gateway := gateway{
+		m: mock_metrics,
+	}
+
+	now := time.Now()
+
+	open := gateway.breakers.get("gcs.prod.monitoring.core.windows.net:443")
+	for i := 0; i < breakerThreshold; i++ {
+		open.failure(now)
+	}
+	open.rejected = 4
+
+	// healthy endpoints are not reported
+	gateway.breakers.get("arosvc.azurecr.io:443").success()
+
+	dims := map[string]string{"endpoint": "gcs.prod.monitoring.core.windows.net:443"}
+
+	mock_metrics.EXPECT().EmitGauge("gateway.connections.open", int64(0), map[string]string{"protocol": "http"})
+	mock_metrics.EXPECT().EmitGauge("gateway.connections.open", int64(0), map[string]string{"protocol": "https"})
+	mock_metrics.EXPECT().EmitGauge("gateway.upstream.circuit.open", int64(1), dims)
+	mock_metrics.EXPECT().EmitGauge("gateway.upstream.circuit.rejected", int64(4), dims)
+
+	gateway._emitMetrics()
+
+	if open.rejected != 0 {
+		t.Error(open.rejected)
+	}
+}
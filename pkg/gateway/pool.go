@@ -0,0 +1,200 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// poolSize is the number of warm upstream connections kept for each
+	// endpoint which is in use
+	poolSize = 2
+
+	// poolMaxIdle is the time for which a warm upstream connection is kept.
+	// Upstream servers close connections which don't start a TLS handshake or
+	// an HTTP request soon enough, so warm connections are kept well within
+	// their timeouts.
+	poolMaxIdle = 10 * time.Second
+)
+
+type pooledConn struct {
+	net.Conn
+	dialled time.Time
+}
+
+// pool holds warm upstream connections, keyed by lower case host:port.  A
+// connection from a cluster is handed a warm connection if one is available,
+// which saves it the latency of the upstream dial, and the pool of the
+// endpoint is then refilled in the background.  The pool of an endpoint is
+// only refilled while connections to it are made, and its warm connections
+// expire after poolMaxIdle, so endpoints which aren't in use hold no
+// connections.  The zero value is ready to use.
+type pool struct {
+	mu      sync.Mutex
+	conns   map[string][]pooledConn
+	filling map[string]bool
+}
+
+// take returns a warm connection to address, or nil if there is none
+func (p *pool) take(address string, now time.Time) net.Conn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := strings.ToLower(address)
+
+	for len(p.conns[key]) > 0 {
+		// take the newest connection, which is the likeliest to be alive
+		conns := p.conns[key]
+		pc := conns[len(conns)-1]
+		p.conns[key] = conns[:len(conns)-1]
+
+		if now.Sub(pc.dialled) < poolMaxIdle && alive(pc.Conn) {
+			return pc.Conn
+		}
+
+		pc.Close()
+	}
+
+	return nil
+}
+
+// put adds a warm connection to address to the pool, and returns false if the
+// pool of the endpoint is already full
+func (p *pool) put(address string, c net.Conn, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := strings.ToLower(address)
+
+	if len(p.conns[key]) >= poolSize {
+		return false
+	}
+
+	if p.conns == nil {
+		p.conns = map[string][]pooledConn{}
+	}
+	p.conns[key] = append(p.conns[key], pooledConn{Conn: c, dialled: now})
+
+	return true
+}
+
+// startFilling marks the pool of address as being refilled, and returns false
+// if it already is
+func (p *pool) startFilling(address string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := strings.ToLower(address)
+
+	if p.filling[key] || len(p.conns[key]) >= poolSize {
+		return false
+	}
+
+	if p.filling == nil {
+		p.filling = map[string]bool{}
+	}
+	p.filling[key] = true
+
+	return true
+}
+
+func (p *pool) stopFilling(address string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.filling, strings.ToLower(address))
+}
+
+// prune closes the warm connections which have expired
+func (p *pool) prune(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, conns := range p.conns {
+		var kept []pooledConn
+		for _, pc := range conns {
+			if now.Sub(pc.dialled) < poolMaxIdle {
+				kept = append(kept, pc)
+			} else {
+				pc.Close()
+			}
+		}
+
+		if len(kept) > 0 {
+			p.conns[key] = kept
+		} else {
+			delete(p.conns, key)
+		}
+	}
+}
+
+// alive returns whether the upstream server has neither closed c nor sent
+// anything on it.  The servers behind the gateway never speak first, so a
+// warm connection which can be read from is not usable.
+func alive(c net.Conn) bool {
+	err := c.SetReadDeadline(time.Now())
+	if err != nil {
+		return false
+	}
+
+	_, err = c.Read(make([]byte, 1))
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		return false
+	}
+
+	return c.SetReadDeadline(time.Time{}) == nil
+}
+
+// refill dials warm connections to address through its circuit breaker until
+// its pool is full or a dial fails
+func (g *gateway) refill(address string) {
+	if !g.pool.startFilling(address) {
+		return
+	}
+	defer g.pool.stopFilling(address)
+
+	b := g.breakers.get(address)
+
+	for {
+		if !b.allow(time.Now()) {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+		c, err := g.dialer()(ctx, "tcp", address)
+		cancel()
+		if err != nil {
+			b.failure(time.Now())
+			return
+		}
+		b.success()
+
+		if !g.pool.put(address, c, time.Now()) {
+			c.Close()
+			return
+		}
+	}
+}
+
+// prunePool closes the expired warm upstream connections until ctx is done
+func (g *gateway) prunePool(ctx context.Context) {
+	t := time.NewTicker(poolMaxIdle)
+	defer t.Stop()
+
+	for {
+		select {
+		case now := <-t.C:
+			g.pool.prune(now)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestPool(t *testing.T) {
+	now := time.Now()
+	p := &pool{}
+
+	closed1, closed2 := net.Pipe()
+	closed2.Close()
+	defer closed1.Close()
+
+	alive1, alive2 := net.Pipe()
+	defer alive1.Close()
+	defer alive2.Close()
+
+	expired1, expired2 := net.Pipe()
+	defer expired1.Close()
+	defer expired2.Close()
+
+	if !p.put("host:443", expired1, now.Add(-poolMaxIdle)) ||
+		!p.put("HOST:443", alive1, now) {
+		t.Fatal("expected the pool to take two connections")
+	}
+	if p.put("host:443", closed1, now) {
+		t.Fatal("expected the pool to be full")
+	}
+	if p.startFilling("host:443") {
+		t.Fatal("expected a full pool not to be refilled")
+	}
+
+	if c := p.take("host:443", now); c != alive1 {
+		t.Errorf("got %v, want the live connection", c)
+	}
+
+	// the expired connection is discarded
+	if c := p.take("host:443", now); c != nil {
+		t.Error(c)
+	}
+
+	// so is one which the server closed
+	p.put("host:443", closed1, now)
+	if c := p.take("host:443", now); c != nil {
+		t.Error(c)
+	}
+
+	if !p.startFilling("host:443") || p.startFilling("host:443") {
+		t.Error("expected a single refill at a time")
+	}
+	p.stopFilling("host:443")
+
+	p.put("host:443", alive1, now.Add(-poolMaxIdle))
+	p.prune(now)
+	if len(p.conns) != 0 {
+		t.Error(p.conns)
+	}
+}
+
+func TestDialUpstreamPooled(t *testing.T) {
+	ctx := context.Background()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	accepted := make(chan net.Conn, 10)
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- c
+		}
+	}()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("gateway.upstream.dial.duration", gomock.Any(), map[string]string{
+		"protocol": "https",
+		"hostname": "gcs.prod.monitoring.core.windows.net",
+		"result":   "success",
+	})
+	m.EXPECT().EmitGauge("gateway.upstream.pooled", int64(1), map[string]string{
+		"protocol": "https",
+		"hostname": "gcs.prod.monitoring.core.windows.net",
+	})
+
+	var dialer net.Dialer
+	g := &gateway{
+		m: m,
+		dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, l.Addr().String())
+		},
+	}
+
+	c, err := g.dialUpstream(ctx, "https", "gcs.prod.monitoring.core.windows.net", "443")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// the first connection and the warm connections of the pool
+	for i := 0; i < 1+poolSize; i++ {
+		select {
+		case c := <-accepted:
+			defer c.Close()
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for connection %d", i)
+		}
+	}
+
+	// wait for the refill to put the warm connections in the pool
+	for {
+		g.pool.mu.Lock()
+		n := len(g.pool.conns["gcs.prod.monitoring.core.windows.net:443"])
+		g.pool.mu.Unlock()
+
+		if n == poolSize {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	pooled, err := g.dialUpstream(ctx, "https", "gcs.prod.monitoring.core.windows.net", "443")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pooled.Close()
+}
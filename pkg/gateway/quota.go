@@ -0,0 +1,183 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/time/rate"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+const (
+	// defaultMaxConnections is the number of connections a cluster may have
+	// open through the gateway at once if its gateway record does not say
+	// otherwise
+	defaultMaxConnections = 1024
+
+	// defaultMaxBandwidthBytesPerSecond is the rate at which a cluster may
+	// transfer data through the gateway in each direction if its gateway
+	// record does not say otherwise
+	defaultMaxBandwidthBytesPerSecond = 128 << 20
+)
+
+// clusterQuota enforces the connection and bandwidth quotas of a single
+// cluster.  All of a cluster's connections share the same clusterQuota, so
+// that one misbehaving cluster cannot saturate the shared gateway VMSS.
+type clusterQuota struct {
+	maxConnections int64
+	limiter        *rate.Limiter
+
+	connections int64
+	// bytes and throttled count the bytes copied and the connections refused
+	// since metrics were last emitted
+	bytes     int64
+	throttled int64
+}
+
+func newClusterQuota() *clusterQuota {
+	return &clusterQuota{
+		maxConnections: defaultMaxConnections,
+		limiter:        rate.NewLimiter(defaultMaxBandwidthBytesPerSecond, defaultMaxBandwidthBytesPerSecond),
+	}
+}
+
+// update applies the quotas in gateway, falling back to the defaults for any
+// which are not set
+func (q *clusterQuota) update(gateway *api.Gateway) {
+	maxConnections := int64(gateway.MaxConnections)
+	if maxConnections <= 0 {
+		maxConnections = defaultMaxConnections
+	}
+	atomic.StoreInt64(&q.maxConnections, maxConnections)
+
+	maxBandwidth := gateway.MaxBandwidthBytesPerSecond
+	if maxBandwidth <= 0 {
+		maxBandwidth = defaultMaxBandwidthBytesPerSecond
+	}
+	q.limiter.SetLimit(rate.Limit(maxBandwidth))
+	q.limiter.SetBurst(int(maxBandwidth))
+}
+
+// acquire reserves a connection slot, returning false if the cluster already
+// has its maximum number of connections open.  Every successful call must be
+// matched by a call to release.
+func (q *clusterQuota) acquire() bool {
+	if atomic.AddInt64(&q.connections, 1) > atomic.LoadInt64(&q.maxConnections) {
+		atomic.AddInt64(&q.connections, -1)
+		atomic.AddInt64(&q.throttled, 1)
+		return false
+	}
+
+	return true
+}
+
+func (q *clusterQuota) release() {
+	atomic.AddInt64(&q.connections, -1)
+}
+
+// reader returns a reader which counts the bytes read from r and blocks as
+// needed to keep the cluster within its bandwidth quota
+func (q *clusterQuota) reader(ctx context.Context, r io.Reader) io.Reader {
+	return &quotaReader{ctx: ctx, r: r, q: q}
+}
+
+// wait blocks until n bytes may be transferred.  The limiter refuses to wait
+// for more than its burst at once, so large reads are waited for in chunks.
+func (q *clusterQuota) wait(ctx context.Context, n int) error {
+	for n > 0 {
+		chunk := q.limiter.Burst()
+		if chunk > n {
+			chunk = n
+		}
+
+		err := q.limiter.WaitN(ctx, chunk)
+		if err != nil {
+			return err
+		}
+
+		n -= chunk
+	}
+
+	return nil
+}
+
+type quotaReader struct {
+	ctx context.Context
+	r   io.Reader
+	q   *clusterQuota
+}
+
+func (r *quotaReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	if n > 0 {
+		atomic.AddInt64(&r.q.bytes, int64(n))
+
+		if waitErr := r.q.wait(r.ctx, n); waitErr != nil && err == nil {
+			err = waitErr
+		}
+	}
+
+	return n, err
+}
+
+// quotas holds the clusterQuota of each cluster, keyed by lower case cluster
+// resource ID.  It is kept up to date with the gateway records by the change
+// feed.  The zero value is ready to use.
+type quotas struct {
+	mu sync.Mutex
+	m  map[string]*clusterQuota
+}
+
+// get returns the quota of the given cluster, creating one with the default
+// quotas if the cluster's gateway record has not been seen yet
+func (qs *quotas) get(clusterResourceID string) *clusterQuota {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	key := strings.ToLower(clusterResourceID)
+
+	if qs.m == nil {
+		qs.m = map[string]*clusterQuota{}
+	}
+
+	q := qs.m[key]
+	if q == nil {
+		q = newClusterQuota()
+		qs.m[key] = q
+	}
+
+	return q
+}
+
+func (qs *quotas) update(gateway *api.Gateway) {
+	qs.get(gateway.ID).update(gateway)
+}
+
+// delete forgets the quota of the given cluster.  Connections which are
+// already open keep their reference to it until they are closed.
+func (qs *quotas) delete(clusterResourceID string) {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	delete(qs.m, strings.ToLower(clusterResourceID))
+}
+
+// snapshot returns the current quotas for emitting metrics
+func (qs *quotas) snapshot() map[string]*clusterQuota {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+
+	m := make(map[string]*clusterQuota, len(qs.m))
+	for k, v := range qs.m {
+		m[k] = v
+	}
+
+	return m
+}
@@ -0,0 +1,130 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"golang.org/x/time/rate"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+func TestClusterQuotaUpdate(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		gateway            *api.Gateway
+		wantMaxConnections int64
+		wantLimit          rate.Limit
+	}{
+		{
+			name:               "defaults",
+			gateway:            &api.Gateway{},
+			wantMaxConnections: defaultMaxConnections,
+			wantLimit:          defaultMaxBandwidthBytesPerSecond,
+		},
+		{
+			name: "overridden",
+			gateway: &api.Gateway{
+				MaxConnections:             10,
+				MaxBandwidthBytesPerSecond: 1000,
+			},
+			wantMaxConnections: 10,
+			wantLimit:          1000,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newClusterQuota()
+			q.update(&api.Gateway{MaxConnections: 1, MaxBandwidthBytesPerSecond: 1})
+			q.update(tt.gateway)
+
+			if q.maxConnections != tt.wantMaxConnections {
+				t.Error(q.maxConnections)
+			}
+			if q.limiter.Limit() != tt.wantLimit {
+				t.Error(q.limiter.Limit())
+			}
+			if q.limiter.Burst() != int(tt.wantLimit) {
+				t.Error(q.limiter.Burst())
+			}
+		})
+	}
+}
+
+func TestClusterQuotaAcquire(t *testing.T) {
+	q := newClusterQuota()
+	q.update(&api.Gateway{MaxConnections: 2})
+
+	for i, want := range []bool{true, true, false} {
+		if got := q.acquire(); got != want {
+			t.Errorf("%d: %v", i, got)
+		}
+	}
+
+	if q.connections != 2 {
+		t.Error(q.connections)
+	}
+	if q.throttled != 1 {
+		t.Error(q.throttled)
+	}
+
+	q.release()
+
+	if !q.acquire() {
+		t.Error("expected acquire to succeed after release")
+	}
+}
+
+func TestClusterQuotaReader(t *testing.T) {
+	ctx := context.Background()
+
+	q := newClusterQuota()
+	// a burst smaller than the data forces the reader to wait in chunks
+	q.update(&api.Gateway{MaxBandwidthBytesPerSecond: 1 << 20})
+	q.limiter.SetBurst(1024)
+
+	data := bytes.Repeat([]byte("x"), 10000)
+
+	b, err := io.ReadAll(q.reader(ctx, bytes.NewReader(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(b, data) {
+		t.Error(len(b))
+	}
+	if q.bytes != int64(len(data)) {
+		t.Error(q.bytes)
+	}
+}
+
+func TestQuotas(t *testing.T) {
+	var qs quotas
+
+	qs.update(&api.Gateway{ID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster", MaxConnections: 5})
+
+	q := qs.get("/subscriptions/sub/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/cluster")
+	if q.maxConnections != 5 {
+		t.Error(q.maxConnections)
+	}
+
+	if len(qs.snapshot()) != 1 {
+		t.Error(qs.snapshot())
+	}
+
+	qs.delete("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster")
+
+	if len(qs.snapshot()) != 0 {
+		t.Error(qs.snapshot())
+	}
+
+	// an unknown cluster gets the default quotas
+	q = qs.get("/subscriptions/sub/resourcegroups/rg/providers/microsoft.redhatopenshift/openshiftclusters/other")
+	if q.maxConnections != defaultMaxConnections {
+		t.Error(q.maxConnections)
+	}
+}
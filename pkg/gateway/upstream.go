@@ -0,0 +1,331 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	utilnet "github.com/Azure/ARO-RP/pkg/util/net"
+)
+
+// Connections are proxied one to one: every connection from a cluster gets its
+// own upstream connection.  Since TLS is never terminated by the gateway, the
+// TLS sessions of different cluster connections cannot be multiplexed over a
+// shared upstream connection.  Instead, upstream connections are pooled: a
+// connection from a cluster is handed a warm upstream connection, dialled
+// ahead of time, when one is available.  Connection churn towards Geneva and
+// ACR is kept down by keeping long-lived connections alive through the Azure
+// load balancers and NATs, by closing connection pairs which have gone idle,
+// and by failing fast while an endpoint cannot be dialled.
+const (
+	// keepAlivePeriod is the TCP keep-alive period of both legs of proxied
+	// connections.  It is well within the 4 minute idle timeout of the Azure
+	// load balancers and NAT gateways in the path, so that long-lived Geneva
+	// connections which are quiet between uploads are not silently dropped.
+	keepAlivePeriod = 30 * time.Second
+
+	// dialTimeout is the time allowed to establish an upstream connection
+	dialTimeout = 10 * time.Second
+
+	// idleTimeout is the time after which a proxied connection pair which has
+	// carried no data in either direction is closed
+	idleTimeout = 15 * time.Minute
+
+	// breakerThreshold is the number of consecutive failures to dial an
+	// endpoint after which its circuit breaker opens
+	breakerThreshold = 5
+
+	// breakerCooldown is the time for which an open circuit breaker refuses
+	// connections before letting a single trial connection through
+	breakerCooldown = 30 * time.Second
+)
+
+var errCircuitOpen = errors.New("upstream circuit open")
+
+// breaker is the circuit breaker of a single upstream endpoint.  While it is
+// open, connections to the endpoint are refused without dialling it, rather
+// than each one waiting for the dial to time out.
+type breaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+
+	// rejected counts the connections refused since metrics were last emitted
+	rejected int64
+}
+
+// allow returns whether a connection to the endpoint may be dialled.  Once the
+// cooldown has passed, a single trial connection is allowed through until its
+// outcome is known.
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < breakerThreshold {
+		return true
+	}
+
+	if now.Before(b.openUntil) || b.trial {
+		atomic.AddInt64(&b.rejected, 1)
+		return false
+	}
+
+	b.trial = true
+	return true
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.trial = false
+}
+
+func (b *breaker) failure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.trial = false
+	if b.failures >= breakerThreshold {
+		b.openUntil = now.Add(breakerCooldown)
+	}
+}
+
+// cancelled releases a trial connection whose dial was abandoned, e.g.
+// because the cluster hung up, without counting it as a failure of the
+// endpoint
+func (b *breaker) cancelled() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+func (b *breaker) isOpen(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.failures >= breakerThreshold && now.Before(b.openUntil)
+}
+
+// breakers holds the breaker of each upstream endpoint, keyed by lower case
+// host:port.  Endpoints are limited to the allow list, so the map is bounded.
+// The zero value is ready to use.
+type breakers struct {
+	mu sync.Mutex
+	m  map[string]*breaker
+}
+
+func (bs *breakers) get(address string) *breaker {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	key := strings.ToLower(address)
+
+	if bs.m == nil {
+		bs.m = map[string]*breaker{}
+	}
+
+	b := bs.m[key]
+	if b == nil {
+		b = &breaker{}
+		bs.m[key] = b
+	}
+
+	return b
+}
+
+// snapshot returns the current breakers for emitting metrics
+func (bs *breakers) snapshot() map[string]*breaker {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	m := make(map[string]*breaker, len(bs.m))
+	for k, v := range bs.m {
+		m[k] = v
+	}
+
+	return m
+}
+
+// dialer returns the function dialling upstream connections
+func (g *gateway) dialer() func(ctx context.Context, network, address string) (net.Conn, error) {
+	if g.dial != nil {
+		return g.dial
+	}
+
+	dialer := utilnet.Dialer(SocketSize)
+	dialer.Timeout = dialTimeout
+	dialer.KeepAlive = keepAlivePeriod
+
+	return dialer.DialContext
+}
+
+// dialUpstream returns the upstream leg of a proxied connection: a warm
+// connection from the pool if there is one, or else a connection dialled
+// through the endpoint's circuit breaker, whose latency it emits.  Either way
+// the pool of the endpoint is then refilled.
+func (g *gateway) dialUpstream(ctx context.Context, protocol, hostname, port string) (net.Conn, error) {
+	address := net.JoinHostPort(hostname, port)
+
+	if c := g.pool.take(address, time.Now()); c != nil {
+		g.m.EmitGauge("gateway.upstream.pooled", 1, map[string]string{
+			"protocol": protocol,
+			"hostname": strings.ToLower(hostname),
+		})
+		go g.refill(address)
+		return c, nil
+	}
+
+	b := g.breakers.get(address)
+
+	if !b.allow(time.Now()) {
+		return nil, errCircuitOpen
+	}
+
+	start := time.Now()
+	c, err := g.dialer()(ctx, "tcp", address)
+
+	result := "success"
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// the dial was cancelled by the cluster leg, which says nothing
+		// about the endpoint
+		result = "cancelled"
+		b.cancelled()
+	case err != nil:
+		result = "failure"
+		b.failure(time.Now())
+	default:
+		b.success()
+		go g.refill(address)
+	}
+
+	g.m.EmitGauge("gateway.upstream.dial.duration", time.Since(start).Milliseconds(), map[string]string{
+		"protocol": protocol,
+		"hostname": strings.ToLower(hostname),
+		"result":   result,
+	})
+
+	return c, err
+}
+
+// setKeepAlive tunes the TCP keep-alive of the cluster leg of a proxied
+// connection
+func setKeepAlive(c net.Conn) {
+	tc, ok := c.(*net.TCPConn)
+	if !ok {
+		return
+	}
+
+	_ = tc.SetKeepAlive(true)
+	_ = tc.SetKeepAlivePeriod(keepAlivePeriod)
+}
+
+// idleTracker closes a proxied connection pair which has carried no data in
+// either direction for its timeout, by pushing back the read deadline of both
+// connections whenever data is read from either.  Deadlines are only pushed
+// back once in a while, to keep the overhead per read down.
+type idleTracker struct {
+	timeout time.Duration
+	conns   []net.Conn
+
+	// extended is the time the deadlines were last pushed back, in
+	// nanoseconds since the epoch, or zero before the proxying starts
+	extended int64
+}
+
+func newIdleTracker(timeout time.Duration, conns ...net.Conn) *idleTracker {
+	return &idleTracker{
+		timeout: timeout,
+		conns:   conns,
+	}
+}
+
+func (t *idleTracker) extend(now time.Time) {
+	atomic.StoreInt64(&t.extended, now.UnixNano())
+
+	for _, c := range t.conns {
+		_ = c.SetReadDeadline(now.Add(t.timeout))
+	}
+}
+
+func (t *idleTracker) touch() {
+	now := time.Now()
+	if now.UnixNano()-atomic.LoadInt64(&t.extended) > int64(t.timeout/16) {
+		t.extend(now)
+	}
+}
+
+// expired returns whether the connection pair was closed for being idle
+func (t *idleTracker) expired() bool {
+	extended := atomic.LoadInt64(&t.extended)
+
+	return extended != 0 && time.Since(time.Unix(0, extended)) >= t.timeout
+}
+
+// reader returns a reader which marks the connection pair as active whenever
+// data is read from r.  The idle timeout starts when reader is first called,
+// since hijacking a connection from the HTTP stack clears its deadlines.
+func (t *idleTracker) reader(r io.Reader) io.Reader {
+	t.extend(time.Now())
+
+	return &idleReader{r: r, t: t}
+}
+
+type idleReader struct {
+	r io.Reader
+	t *idleTracker
+}
+
+func (r *idleReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	if n > 0 {
+		r.t.touch()
+	}
+
+	return n, err
+}
+
+// upstreamUnavailable records a connection which was refused because its
+// upstream endpoint could not be dialled
+func (g *gateway) upstreamUnavailable(log *logrus.Entry, clusterResourceID, protocol, hostname string, err error) {
+	action := "unavailable"
+	if errors.Is(err, errCircuitOpen) {
+		action = "circuitopen"
+	}
+
+	log.Printf("upstream unavailable: %v", err)
+	g.m.EmitGauge("gateway.connections", 1, map[string]string{
+		"protocol": protocol,
+		"action":   action,
+	})
+	g.diagnostics.recordRejected(clusterResourceID, protocol, hostname, err.Error(), false)
+}
+
+// emitConnectionClosed emits the lifetime of a proxied connection pair which
+// was opened at start, and whether it was closed for being idle
+func (g *gateway) emitConnectionClosed(protocol string, start time.Time, idle *idleTracker) {
+	dims := map[string]string{
+		"protocol": protocol,
+	}
+
+	g.m.EmitGauge("gateway.connections.duration", time.Since(start).Milliseconds(), dims)
+
+	if idle.expired() {
+		g.m.EmitGauge("gateway.connections.idleclosed", 1, dims)
+	}
+}
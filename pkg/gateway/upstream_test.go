@@ -0,0 +1,175 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := &breaker{}
+
+	for i := 0; i < breakerThreshold; i++ {
+		if !b.allow(now) {
+			t.Fatalf("connection %d refused before the threshold", i)
+		}
+		b.failure(now)
+	}
+
+	if b.allow(now) || !b.isOpen(now) {
+		t.Fatal("expected the breaker to open at the threshold")
+	}
+
+	now = now.Add(breakerCooldown)
+
+	if !b.allow(now) {
+		t.Fatal("expected a trial connection after the cooldown")
+	}
+	if b.allow(now) {
+		t.Fatal("expected a single trial connection")
+	}
+
+	b.failure(now)
+	if b.allow(now) || !b.isOpen(now) {
+		t.Fatal("expected a failed trial to reopen the breaker")
+	}
+
+	now = now.Add(breakerCooldown)
+
+	if !b.allow(now) {
+		t.Fatal("expected a trial connection after the cooldown")
+	}
+	b.cancelled()
+
+	if !b.allow(now) {
+		t.Fatal("expected another trial connection after a cancelled one")
+	}
+	b.success()
+
+	if !b.allow(now) || b.isOpen(now) {
+		t.Fatal("expected a successful trial to close the breaker")
+	}
+
+	if b.rejected != 3 {
+		t.Error(b.rejected)
+	}
+}
+
+func TestDialUpstream(t *testing.T) {
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("gateway.upstream.dial.duration", gomock.Any(), map[string]string{
+		"protocol": "https",
+		"hostname": "gcs.prod.monitoring.core.windows.net",
+		"result":   "failure",
+	}).Times(breakerThreshold)
+
+	var dials int
+	g := &gateway{
+		m: m,
+		dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			dials++
+			if address != "gcs.prod.monitoring.core.windows.net:443" {
+				t.Error(address)
+			}
+			return nil, errors.New("connection refused")
+		},
+	}
+
+	for i := 0; i < breakerThreshold; i++ {
+		_, err := g.dialUpstream(ctx, "https", "gcs.prod.monitoring.core.windows.net", "443")
+		if err == nil || errors.Is(err, errCircuitOpen) {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := g.dialUpstream(ctx, "https", "gcs.prod.monitoring.core.windows.net", "443")
+	if !errors.Is(err, errCircuitOpen) {
+		t.Error(err)
+	}
+
+	if dials != breakerThreshold {
+		t.Error(dials)
+	}
+}
+
+func TestDialUpstreamCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("gateway.upstream.dial.duration", gomock.Any(), map[string]string{
+		"protocol": "https",
+		"hostname": "gcs.prod.monitoring.core.windows.net",
+		"result":   "cancelled",
+	}).Times(breakerThreshold + 1)
+
+	g := &gateway{
+		m: m,
+		dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return nil, ctx.Err()
+		},
+	}
+
+	// cancelled dials don't open the breaker of the endpoint
+	for i := 0; i < breakerThreshold+1; i++ {
+		_, err := g.dialUpstream(ctx, "https", "gcs.prod.monitoring.core.windows.net", "443")
+		if !errors.Is(err, context.Canceled) {
+			t.Fatal(err)
+		}
+	}
+
+	if g.breakers.get("gcs.prod.monitoring.core.windows.net:443").isOpen(time.Now()) {
+		t.Error("expected the breaker to stay closed")
+	}
+}
+
+func TestIdleTracker(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c1.Close()
+	defer c2.Close()
+
+	idle := newIdleTracker(50*time.Millisecond, c1, c2)
+	if idle.expired() {
+		t.Fatal("expected the tracker not to expire before the proxying starts")
+	}
+
+	r := idle.reader(c1)
+
+	go func() {
+		_, _ = c2.Write([]byte("data"))
+	}()
+
+	b := make([]byte, 4)
+	_, err := r.Read(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.Read(b)
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatal(err)
+	}
+
+	if !idle.expired() {
+		t.Error("expected the tracker to have expired")
+	}
+}
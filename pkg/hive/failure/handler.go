@@ -39,7 +39,7 @@ func HandleProvisionFailed(ctx context.Context, cd *hivev1.ClusterDeployment, co
 		return wrapArmError(
 			AzureRequestDisallowedByPolicy.Message,
 			*armError,
-		)
+		).WithRemediationURL(api.RemediationURLPolicy)
 	case AzureInvalidTemplateDeployment.Reason:
 		armError, err := parseDeploymentFailedJson(*installLog)
 		if err != nil {
@@ -84,13 +84,20 @@ func wrapArmError(errorMessage string, armError mgmtfeatures.ErrorResponse) *api
 
 func errorResponseToCloudErrorBody(errorResponse mgmtfeatures.ErrorResponse) api.CloudErrorBody {
 	body := api.CloudErrorBody{
-		Code:    *errorResponse.Code,
-		Message: *errorResponse.Message,
+		Code:           *errorResponse.Code,
+		Message:        *errorResponse.Message,
+		RemediationURL: api.RemediationURLForCode(*errorResponse.Code),
 	}
 
 	if errorResponse.Target != nil {
 		body.Target = *errorResponse.Target
 	}
 
+	if errorResponse.Details != nil {
+		for _, detail := range *errorResponse.Details {
+			body.Details = append(body.Details, errorResponseToCloudErrorBody(detail))
+		}
+	}
+
 	return body
 }
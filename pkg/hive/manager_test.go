@@ -317,28 +317,33 @@ func TestIsClusterInstallationComplete(t *testing.T) {
 			wantErr: &api.CloudError{
 				StatusCode: http.StatusBadRequest,
 				CloudErrorBody: &api.CloudErrorBody{
-					Code:    api.CloudErrorCodeDeploymentFailed,
-					Message: "Deployment failed due to RequestDisallowedByPolicy. Please see details for more information.",
+					Code:           api.CloudErrorCodeDeploymentFailed,
+					Message:        "Deployment failed due to RequestDisallowedByPolicy. Please see details for more information.",
+					RemediationURL: api.RemediationURLPolicy,
 					Details: []api.CloudErrorBody{
 						{
-							Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
-							Message: "Resource 'aro-test-aaaaa-bootstrap' was disallowed by policy.",
-							Target:  "aro-test-aaaaa-bootstrap",
+							Code:           api.CloudErrorCodeRequestDisallowedByPolicy,
+							Message:        "Resource 'aro-test-aaaaa-bootstrap' was disallowed by policy.",
+							Target:         "aro-test-aaaaa-bootstrap",
+							RemediationURL: api.RemediationURLPolicy,
 						},
 						{
-							Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
-							Message: "Resource 'aro-test-aaaaa-master-0' was disallowed by policy.",
-							Target:  "aro-test-aaaaa-master-0",
+							Code:           api.CloudErrorCodeRequestDisallowedByPolicy,
+							Message:        "Resource 'aro-test-aaaaa-master-0' was disallowed by policy.",
+							Target:         "aro-test-aaaaa-master-0",
+							RemediationURL: api.RemediationURLPolicy,
 						},
 						{
-							Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
-							Message: "Resource 'aro-test-aaaaa-master-1' was disallowed by policy.",
-							Target:  "aro-test-aaaaa-master-1",
+							Code:           api.CloudErrorCodeRequestDisallowedByPolicy,
+							Message:        "Resource 'aro-test-aaaaa-master-1' was disallowed by policy.",
+							Target:         "aro-test-aaaaa-master-1",
+							RemediationURL: api.RemediationURLPolicy,
 						},
 						{
-							Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
-							Message: "Resource 'aro-test-aaaaa-master-2' was disallowed by policy.",
-							Target:  "aro-test-aaaaa-master-2",
+							Code:           api.CloudErrorCodeRequestDisallowedByPolicy,
+							Message:        "Resource 'aro-test-aaaaa-master-2' was disallowed by policy.",
+							Target:         "aro-test-aaaaa-master-2",
+							RemediationURL: api.RemediationURLPolicy,
 						},
 					},
 				},
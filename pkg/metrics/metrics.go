@@ -3,8 +3,29 @@ package metrics
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 
+import (
+	"time"
+)
+
 // Emitter emits different types of metrics
 type Emitter interface {
 	EmitFloat(metricName string, metricValue float64, dimensions map[string]string)
 	EmitGauge(metricName string, metricValue int64, dimensions map[string]string)
+	// EmitHistogram records a single observation of a distribution, e.g. a
+	// request latency, from which percentiles can be computed
+	EmitHistogram(metricName string, metricValue float64, dimensions map[string]string)
+}
+
+// StartTimer starts timing an operation.  Calling the returned function emits
+// the time elapsed in milliseconds as a histogram, e.g.
+//
+//	stop := metrics.StartTimer(m, "operation.duration")
+//	...
+//	stop(map[string]string{"result": "success"})
+func StartTimer(m Emitter, metricName string) func(dimensions map[string]string) {
+	t := time.Now()
+
+	return func(dimensions map[string]string) {
+		m.EmitHistogram(metricName, float64(time.Since(t))/float64(time.Millisecond), dimensions)
+	}
 }
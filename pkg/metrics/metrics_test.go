@@ -0,0 +1,41 @@
+package metrics
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeEmitter struct {
+	name       string
+	value      float64
+	dimensions map[string]string
+}
+
+func (e *fakeEmitter) EmitFloat(metricName string, metricValue float64, dimensions map[string]string) {
+}
+func (e *fakeEmitter) EmitGauge(metricName string, metricValue int64, dimensions map[string]string) {}
+func (e *fakeEmitter) EmitHistogram(metricName string, metricValue float64, dimensions map[string]string) {
+	e.name, e.value, e.dimensions = metricName, metricValue, dimensions
+}
+
+func TestStartTimer(t *testing.T) {
+	e := &fakeEmitter{}
+
+	stop := StartTimer(e, "operation.duration")
+	time.Sleep(10 * time.Millisecond)
+	stop(map[string]string{"result": "success"})
+
+	if e.name != "operation.duration" {
+		t.Error(e.name)
+	}
+	if e.value < 10 {
+		t.Error(e.value)
+	}
+	if !reflect.DeepEqual(e.dimensions, map[string]string{"result": "success"}) {
+		t.Error(e.dimensions)
+	}
+}
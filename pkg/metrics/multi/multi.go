@@ -0,0 +1,50 @@
+package multi
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"github.com/Azure/ARO-RP/pkg/metrics"
+)
+
+type multi []metrics.Emitter
+
+// New returns a metrics.Emitter which emits every metric to all of emitters
+func New(emitters ...metrics.Emitter) metrics.Emitter {
+	return multi(emitters)
+}
+
+// EmitFloat records float information
+func (m multi) EmitFloat(metricName string, metricValue float64, dimensions map[string]string) {
+	for _, e := range m {
+		e.EmitFloat(metricName, metricValue, copyDimensions(dimensions))
+	}
+}
+
+// EmitGauge records gauge information
+func (m multi) EmitGauge(metricName string, metricValue int64, dimensions map[string]string) {
+	for _, e := range m {
+		e.EmitGauge(metricName, metricValue, copyDimensions(dimensions))
+	}
+}
+
+// EmitHistogram records an observation of a distribution
+func (m multi) EmitHistogram(metricName string, metricValue float64, dimensions map[string]string) {
+	for _, e := range m {
+		e.EmitHistogram(metricName, metricValue, copyDimensions(dimensions))
+	}
+}
+
+// copyDimensions gives each emitter its own copy of the dimensions, as
+// emitters may add to them
+func copyDimensions(dimensions map[string]string) map[string]string {
+	if dimensions == nil {
+		return nil
+	}
+
+	c := make(map[string]string, len(dimensions))
+	for k, v := range dimensions {
+		c[k] = v
+	}
+	return c
+}
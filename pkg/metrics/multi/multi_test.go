@@ -0,0 +1,38 @@
+package multi
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestMulti(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dims := map[string]string{"key": "value"}
+
+	a := mock_metrics.NewMockEmitter(controller)
+	a.EXPECT().EmitGauge("gauge", int64(1), dims).Do(func(_ string, _ int64, dimensions map[string]string) {
+		// emitters may add dimensions without affecting each other
+		dimensions["location"] = "eastus"
+	})
+	a.EXPECT().EmitFloat("float", 1.5, nil)
+
+	b := mock_metrics.NewMockEmitter(controller)
+	b.EXPECT().EmitGauge("gauge", int64(1), map[string]string{"key": "value"})
+	b.EXPECT().EmitFloat("float", 1.5, nil)
+
+	m := New(a, b)
+	m.EmitGauge("gauge", 1, dims)
+	m.EmitFloat("float", 1.5, nil)
+
+	if len(dims) != 1 {
+		t.Error(dims)
+	}
+}
@@ -0,0 +1,205 @@
+package otlp
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// OTLP/gRPC implementation for https://opentelemetry.io/docs/specs/otlp/
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const (
+	scopeName = "github.com/Azure/ARO-RP/pkg/metrics/otlp"
+
+	// maxBatch is the most metrics we hold on to between exports.  If the
+	// collector is unavailable, the oldest metrics are dropped.
+	maxBatch = 10000
+)
+
+type metric struct {
+	name           string
+	dimensions     map[string]string
+	valueFloat     *float64
+	valueGauge     *int64
+	valueHistogram *float64
+	timestamp      time.Time
+}
+
+type otlp struct {
+	log *logrus.Entry
+
+	conn     *grpc.ClientConn
+	client   colmetricspb.MetricsServiceClient
+	resource map[string]string
+	interval time.Duration
+
+	ch chan *metric
+
+	now func() time.Time
+}
+
+// New returns a new metrics.Emitter which exports to the OTLP/gRPC endpoint,
+// e.g. http://localhost:4317.  An http endpoint is connected to without TLS.
+// Metrics are batched and exported every interval.  serviceName and namespace
+// are recorded as the service.name and service.namespace resource attributes.
+func New(ctx context.Context, log *logrus.Entry, env env.Core, endpoint, serviceName, namespace string, interval time.Duration) (metrics.Emitter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds credentials.TransportCredentials
+	switch u.Scheme {
+	case "http":
+		creds = insecure.NewCredentials()
+	case "https":
+		creds = credentials.NewTLS(&tls.Config{})
+	default:
+		return nil, fmt.Errorf("unsupported OTLP endpoint %q. Expecting http://<host>:<port> or https://<host>:<port>", endpoint)
+	}
+
+	// grpc.Dial does not block, so a collector which is not yet available does
+	// not stop us starting
+	conn, err := grpc.Dial(u.Host, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+
+	o := &otlp{
+		log: log,
+
+		conn:   conn,
+		client: colmetricspb.NewMetricsServiceClient(conn),
+		resource: map[string]string{
+			"service.name":      serviceName,
+			"service.namespace": namespace,
+			"cloud.region":      env.Location(),
+			"host.name":         env.Hostname(),
+		},
+		interval: interval,
+
+		ch: make(chan *metric, 1024),
+
+		now: time.Now,
+	}
+
+	go o.run(ctx)
+
+	return o, nil
+}
+
+// EmitFloat records float information
+func (o *otlp) EmitFloat(metricName string, metricValue float64, dimensions map[string]string) {
+	o.emitMetric(&metric{
+		name:       metricName,
+		dimensions: dimensions,
+		valueFloat: &metricValue,
+	})
+}
+
+// EmitGauge records gauge information
+func (o *otlp) EmitGauge(metricName string, metricValue int64, dimensions map[string]string) {
+	o.emitMetric(&metric{
+		name:       metricName,
+		dimensions: dimensions,
+		valueGauge: &metricValue,
+	})
+}
+
+// EmitHistogram records an observation of a distribution
+func (o *otlp) EmitHistogram(metricName string, metricValue float64, dimensions map[string]string) {
+	o.emitMetric(&metric{
+		name:           metricName,
+		dimensions:     dimensions,
+		valueHistogram: &metricValue,
+	})
+}
+
+func (o *otlp) emitMetric(m *metric) {
+	m.timestamp = o.now()
+
+	o.ch <- m
+}
+
+func (o *otlp) run(ctx context.Context) {
+	defer recover.Panic(o.log)
+
+	t := time.NewTicker(o.interval)
+	defer t.Stop()
+
+	var batch []*metric
+	var lastLog time.Time
+
+	for {
+		select {
+		case m := <-o.ch:
+			batch = append(batch, m)
+			if len(batch) > maxBatch {
+				batch = batch[len(batch)-maxBatch:]
+			}
+
+		case <-t.C:
+			if len(batch) == 0 {
+				continue
+			}
+
+			err := o.export(ctx, batch)
+			if err != nil {
+				if o.now().After(lastLog.Add(time.Second)) {
+					lastLog = o.now()
+					o.log.Error(err)
+				}
+				continue
+			}
+
+			batch = nil
+
+		case <-ctx.Done():
+			_ = o.conn.Close()
+			return
+		}
+	}
+}
+
+func (o *otlp) export(ctx context.Context, batch []*metric) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := o.client.Export(ctx, newExportMetricsServiceRequest(o.resource, scopeName, batch))
+	if err != nil {
+		return err
+	}
+
+	err = partialSuccessError(resp)
+	if err != nil {
+		o.log.Warn(err)
+	}
+
+	return nil
+}
+
+// partialSuccessError returns an error if the collector reports that it
+// rejected data points.  Rejected data points must not be retried, so the
+// batch is still considered exported.
+func partialSuccessError(resp *colmetricspb.ExportMetricsServiceResponse) error {
+	partialSuccess := resp.GetPartialSuccess()
+	if partialSuccess.GetRejectedDataPoints() == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("collector rejected %d data points: %s", partialSuccess.GetRejectedDataPoints(), partialSuccess.GetErrorMessage())
+}
@@ -0,0 +1,275 @@
+package otlp
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type dataPoint struct {
+	attributes map[string]string
+	asInt      *int64
+	asDouble   *float64
+}
+
+func attributes(kvs []*commonpb.KeyValue) map[string]string {
+	m := map[string]string{}
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value.GetStringValue()
+	}
+	return m
+}
+
+// decode returns the resource attributes and the gauge data points of each
+// metric in an ExportMetricsServiceRequest
+func decode(req *colmetricspb.ExportMetricsServiceRequest) (map[string]string, map[string][]dataPoint) {
+	resourceMetrics := req.ResourceMetrics[0]
+	resource := attributes(resourceMetrics.Resource.GetAttributes())
+
+	metrics := map[string][]dataPoint{}
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, metric := range scopeMetrics.Metrics {
+			for _, dp := range metric.GetGauge().GetDataPoints() {
+				p := dataPoint{
+					attributes: attributes(dp.Attributes),
+				}
+				switch v := dp.Value.(type) {
+				case *metricspb.NumberDataPoint_AsInt:
+					p.asInt = &v.AsInt
+				case *metricspb.NumberDataPoint_AsDouble:
+					p.asDouble = &v.AsDouble
+				}
+				metrics[metric.Name] = append(metrics[metric.Name], p)
+			}
+		}
+	}
+
+	return resource, metrics
+}
+
+func TestNewExportMetricsServiceRequest(t *testing.T) {
+	one, two := int64(1), int64(2)
+	half := 0.5
+	now := time.Now()
+
+	req := newExportMetricsServiceRequest(map[string]string{"service.name": "test"}, scopeName, []*metric{
+		{name: "a", valueGauge: &one, dimensions: map[string]string{"k": "1"}, timestamp: now},
+		{name: "b", valueFloat: &half, timestamp: now},
+		{name: "a", valueGauge: &two, dimensions: map[string]string{"k": "2"}, timestamp: now},
+	})
+
+	// the request must survive the trip over the wire
+	b, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = &colmetricspb.ExportMetricsServiceRequest{}
+	err = proto.Unmarshal(b, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name := req.ResourceMetrics[0].ScopeMetrics[0].Scope.GetName(); name != scopeName {
+		t.Error(name)
+	}
+
+	resource, metrics := decode(req)
+
+	if !reflect.DeepEqual(resource, map[string]string{"service.name": "test"}) {
+		t.Error(resource)
+	}
+
+	if len(metrics["a"]) != 2 ||
+		*metrics["a"][0].asInt != 1 || metrics["a"][0].attributes["k"] != "1" ||
+		*metrics["a"][1].asInt != 2 || metrics["a"][1].attributes["k"] != "2" {
+		t.Errorf("%#v", metrics["a"])
+	}
+
+	if len(metrics["b"]) != 1 || *metrics["b"][0].asDouble != 0.5 || len(metrics["b"][0].attributes) != 0 {
+		t.Errorf("%#v", metrics["b"])
+	}
+}
+
+func TestNewHistogram(t *testing.T) {
+	now := time.Now()
+	observe := func(v float64, dimensions map[string]string) *metric {
+		return &metric{name: "latency", valueHistogram: &v, dimensions: dimensions, timestamp: now}
+	}
+
+	req := newExportMetricsServiceRequest(nil, scopeName, []*metric{
+		observe(3, map[string]string{"route": "a"}),
+		observe(30, map[string]string{"route": "a"}),
+		observe(20000, map[string]string{"route": "b"}),
+	})
+
+	metric := req.ResourceMetrics[0].ScopeMetrics[0].Metrics[0]
+	if metric.Name != "latency" {
+		t.Fatal(metric.Name)
+	}
+
+	histogram := metric.GetHistogram()
+	if histogram.AggregationTemporality != metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA {
+		t.Error(histogram.AggregationTemporality)
+	}
+
+	type want struct {
+		count        uint64
+		sum          float64
+		min, max     float64
+		bucketCounts map[int]uint64
+	}
+	wants := map[string]want{
+		"a": {count: 2, sum: 33, min: 3, max: 30, bucketCounts: map[int]uint64{1: 1, 4: 1}},
+		"b": {count: 1, sum: 20000, min: 20000, max: 20000, bucketCounts: map[int]uint64{len(histogramBounds): 1}},
+	}
+
+	if len(histogram.DataPoints) != len(wants) {
+		t.Fatal(len(histogram.DataPoints))
+	}
+
+	for _, dp := range histogram.DataPoints {
+		route := attributes(dp.Attributes)["route"]
+		w := wants[route]
+
+		if dp.Count != w.count {
+			t.Errorf("%s: count %d", route, dp.Count)
+		}
+		if dp.GetSum() != w.sum {
+			t.Errorf("%s: sum %f", route, dp.GetSum())
+		}
+		if dp.GetMin() != w.min || dp.GetMax() != w.max {
+			t.Errorf("%s: min %f, max %f", route, dp.GetMin(), dp.GetMax())
+		}
+		if !reflect.DeepEqual(dp.ExplicitBounds, histogramBounds) {
+			t.Errorf("%s: bounds %v", route, dp.ExplicitBounds)
+		}
+		for i, c := range dp.BucketCounts {
+			if c != w.bucketCounts[i] {
+				t.Errorf("%s: bucket %d: %d", route, i, c)
+			}
+		}
+	}
+}
+
+func TestPartialSuccessError(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		resp    *colmetricspb.ExportMetricsServiceResponse
+		wantErr string
+	}{
+		{
+			name: "empty response",
+			resp: &colmetricspb.ExportMetricsServiceResponse{},
+		},
+		{
+			name: "rejected data points",
+			resp: &colmetricspb.ExportMetricsServiceResponse{
+				PartialSuccess: &colmetricspb.ExportMetricsPartialSuccess{
+					RejectedDataPoints: 3,
+					ErrorMessage:       "bad metric",
+				},
+			},
+			wantErr: "collector rejected 3 data points: bad metric",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := partialSuccessError(tt.resp)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+// fakeMetricsService passes the requests it receives to reqs
+type fakeMetricsService struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	reqs chan *colmetricspb.ExportMetricsServiceRequest
+}
+
+func (s *fakeMetricsService) Export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	s.reqs <- req
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func TestEmitter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reqs := make(chan *colmetricspb.ExportMetricsServiceRequest, 10)
+
+	s := grpc.NewServer()
+	colmetricspb.RegisterMetricsServiceServer(s, &fakeMetricsService{reqs: reqs})
+	defer s.Stop()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = s.Serve(l)
+	}()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	env := mock_env.NewMockCore(controller)
+	env.EXPECT().Location().AnyTimes().Return("eastus")
+	env.EXPECT().Hostname().AnyTimes().Return("test-host")
+
+	o, err := New(ctx, utillog.GetLogger(), env, "http://"+l.Addr().String(), "aro-test", "test", 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o.EmitGauge("gauge", 42, map[string]string{"key": "value"})
+	o.EmitFloat("float", 1.5, nil)
+
+	got := map[string][]dataPoint{}
+	timeout := time.After(10 * time.Second)
+	for len(got) < 2 {
+		select {
+		case req := <-reqs:
+			resource, metrics := decode(req)
+			if !reflect.DeepEqual(resource, map[string]string{
+				"service.name":      "aro-test",
+				"service.namespace": "test",
+				"cloud.region":      "eastus",
+				"host.name":         "test-host",
+			}) {
+				t.Error(resource)
+			}
+			for name, dps := range metrics {
+				got[name] = append(got[name], dps...)
+			}
+		case <-timeout:
+			t.Fatalf("timed out, got %#v", got)
+		}
+	}
+
+	if len(got["gauge"]) != 1 || *got["gauge"][0].asInt != 42 || got["gauge"][0].attributes["key"] != "value" {
+		t.Errorf("%#v", got["gauge"])
+	}
+	if len(got["float"]) != 1 || *got["float"][0].asDouble != 1.5 {
+		t.Errorf("%#v", got["float"])
+	}
+}
+
+func TestNewInvalidEndpoint(t *testing.T) {
+	_, err := New(context.Background(), utillog.GetLogger(), nil, "unix:///tmp/socket", "aro-test", "test", time.Second)
+	utilerror.AssertErrorMessage(t, err, `unsupported OTLP endpoint "unix:///tmp/socket". Expecting http://<host>:<port> or https://<host>:<port>`)
+}
@@ -0,0 +1,181 @@
+package otlp
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+// histogramBounds are the explicit bucket boundaries used for histograms.
+// They are the OpenTelemetry SDK defaults, which suit latencies in
+// milliseconds.
+var histogramBounds = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// newExportMetricsServiceRequest returns an ExportMetricsServiceRequest
+// containing a single ResourceMetrics with a single ScopeMetrics.  Gauges and
+// floats with the same name are sent as data points of one Gauge; histogram
+// observations with the same name and dimensions are aggregated into one
+// data point of a delta Histogram.
+func newExportMetricsServiceRequest(resource map[string]string, scope string, ms []*metric) *colmetricspb.ExportMetricsServiceRequest {
+	type key struct {
+		name      string
+		histogram bool
+	}
+
+	var keys []key
+	byKey := map[key][]*metric{}
+	for _, m := range ms {
+		k := key{name: m.name, histogram: m.valueHistogram != nil}
+		if _, ok := byKey[k]; !ok {
+			keys = append(keys, k)
+		}
+		byKey[k] = append(byKey[k], m)
+	}
+
+	scopeMetrics := &metricspb.ScopeMetrics{
+		Scope: &commonpb.InstrumentationScope{
+			Name: scope,
+		},
+	}
+
+	for _, k := range keys {
+		m := &metricspb.Metric{
+			Name: k.name,
+		}
+
+		if k.histogram {
+			m.Data = &metricspb.Metric_Histogram{
+				Histogram: newHistogram(byKey[k]),
+			}
+		} else {
+			gauge := &metricspb.Gauge{}
+			for _, m := range byKey[k] {
+				gauge.DataPoints = append(gauge.DataPoints, newNumberDataPoint(m))
+			}
+			m.Data = &metricspb.Metric_Gauge{
+				Gauge: gauge,
+			}
+		}
+
+		scopeMetrics.Metrics = append(scopeMetrics.Metrics, m)
+	}
+
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{
+			{
+				Resource: &resourcepb.Resource{
+					Attributes: newAttributes(resource),
+				},
+				ScopeMetrics: []*metricspb.ScopeMetrics{scopeMetrics},
+			},
+		},
+	}
+}
+
+// newHistogram aggregates the observations in ms into one data point per set
+// of dimensions
+func newHistogram(ms []*metric) *metricspb.Histogram {
+	var keys []string
+	dps := map[string]*metricspb.HistogramDataPoint{}
+
+	for _, m := range ms {
+		v := *m.valueHistogram
+
+		k := dimensionsKey(m.dimensions)
+		dp, ok := dps[k]
+		if !ok {
+			lo, hi := v, v
+			dp = &metricspb.HistogramDataPoint{
+				Attributes:        newAttributes(m.dimensions),
+				StartTimeUnixNano: uint64(m.timestamp.UnixNano()),
+				Sum:               new(float64),
+				Min:               &lo,
+				Max:               &hi,
+				BucketCounts:      make([]uint64, len(histogramBounds)+1),
+				ExplicitBounds:    histogramBounds,
+			}
+			dps[k] = dp
+			keys = append(keys, k)
+		}
+
+		dp.TimeUnixNano = uint64(m.timestamp.UnixNano())
+		dp.Count++
+		*dp.Sum += v
+		*dp.Min = math.Min(*dp.Min, v)
+		*dp.Max = math.Max(*dp.Max, v)
+		dp.BucketCounts[sort.SearchFloat64s(histogramBounds, v)]++
+	}
+
+	h := &metricspb.Histogram{
+		AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+	}
+	for _, k := range keys {
+		h.DataPoints = append(h.DataPoints, dps[k])
+	}
+
+	return h
+}
+
+// dimensionsKey returns a string uniquely identifying a set of dimensions
+func dimensionsKey(dimensions map[string]string) string {
+	keys := make([]string, 0, len(dimensions))
+	for k := range dimensions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(strconv.Quote(k))
+		sb.WriteByte('=')
+		sb.WriteString(strconv.Quote(dimensions[k]))
+		sb.WriteByte(',')
+	}
+	return sb.String()
+}
+
+func newNumberDataPoint(m *metric) *metricspb.NumberDataPoint {
+	dp := &metricspb.NumberDataPoint{
+		Attributes:   newAttributes(m.dimensions),
+		TimeUnixNano: uint64(m.timestamp.UnixNano()),
+	}
+
+	if m.valueFloat != nil {
+		dp.Value = &metricspb.NumberDataPoint_AsDouble{AsDouble: *m.valueFloat}
+	} else if m.valueGauge != nil {
+		dp.Value = &metricspb.NumberDataPoint_AsInt{AsInt: *m.valueGauge}
+	}
+
+	return dp
+}
+
+// newAttributes returns attributes as KeyValues with string values, in key
+// order
+func newAttributes(attributes map[string]string) []*commonpb.KeyValue {
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]*commonpb.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, &commonpb.KeyValue{
+			Key: k,
+			Value: &commonpb.AnyValue{
+				Value: &commonpb.AnyValue_StringValue{StringValue: attributes[k]},
+			},
+		})
+	}
+
+	return kvs
+}
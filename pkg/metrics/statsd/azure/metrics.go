@@ -28,6 +28,7 @@ const (
 
 type metric struct {
 	t    time.Time
+	stop func(map[string]string)
 	name string
 }
 
@@ -46,6 +47,7 @@ func (t *tracer) StartSpan(ctx context.Context, name string) context.Context {
 	return context.WithValue(ctx, contextKeyMetric, metric{
 		name: name,
 		t:    start,
+		stop: metrics.StartTimer(t.m, "client.azure.duration.histogram"),
 	})
 }
 
@@ -57,6 +59,11 @@ func (t *tracer) EndSpan(ctx context.Context, httpStatusCode int, err error) {
 		"code":   strconv.Itoa(httpStatusCode),
 	})
 
+	metric.stop(map[string]string{
+		"client": metric.name,
+		"code":   strconv.Itoa(httpStatusCode),
+	})
+
 	t.m.EmitGauge("client.azure.count", 1, map[string]string{
 		"client": metric.name,
 		"code":   strconv.Itoa(httpStatusCode),
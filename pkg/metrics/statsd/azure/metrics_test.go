@@ -24,6 +24,10 @@ func TestTracer(t *testing.T) {
 		"client": "test",
 		"code":   "401",
 	})
+	m.EXPECT().EmitHistogram("client.azure.duration.histogram", gomock.Any(), map[string]string{
+		"client": "test",
+		"code":   "401",
+	})
 	m.EXPECT().EmitGauge("client.azure.count", int64(1), map[string]string{
 		"client": "test",
 		"code":   "401",
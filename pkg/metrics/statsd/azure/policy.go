@@ -0,0 +1,84 @@
+package azure
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+
+	"github.com/Azure/ARO-RP/pkg/metrics"
+)
+
+var _ policy.Policy = (*metricsPolicy)(nil)
+
+// metricsPolicy emits the same metrics as tracer for clients built on
+// azure-sdk-for-go/sdk.  Most of those clients do not create tracing spans,
+// so the metrics are emitted from the HTTP pipeline instead, naming each API
+// by its ARM resource type and verb.
+type metricsPolicy struct {
+	m metrics.Emitter
+}
+
+// NewPolicy returns a per-call policy which emits client.azure.* metrics for
+// each request made through the pipeline
+func NewPolicy(m metrics.Emitter) policy.Policy {
+	return &metricsPolicy{
+		m: m,
+	}
+}
+
+func (p *metricsPolicy) Do(req *policy.Request) (*http.Response, error) {
+	start := time.Now()
+	stop := metrics.StartTimer(p.m, "client.azure.duration.histogram")
+
+	resp, err := req.Next()
+
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+
+	dims := map[string]string{
+		"client": apiName(req.Raw()),
+		"code":   strconv.Itoa(statusCode),
+	}
+
+	p.m.EmitGauge("client.azure.duration", time.Since(start).Milliseconds(), dims)
+	stop(dims)
+	p.m.EmitGauge("client.azure.count", 1, dims)
+
+	if err != nil || statusCode >= http.StatusBadRequest {
+		p.m.EmitGauge("client.azure.errors", 1, dims)
+	}
+
+	return resp, err
+}
+
+// apiName returns a low-cardinality name for the API a request calls, e.g.
+// "Microsoft.Network/virtualNetworks/subnets.GET".  Requests which are not for
+// an ARM provider resource are named by their host.
+func apiName(req *http.Request) string {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	for i := len(parts) - 1; i >= 0; i-- {
+		if !strings.EqualFold(parts[i], "providers") || i+1 >= len(parts) {
+			continue
+		}
+
+		// the provider namespace is followed by alternating resource types
+		// and names
+		types := []string{parts[i+1]}
+		for j := i + 2; j < len(parts); j += 2 {
+			types = append(types, parts[j])
+		}
+
+		return strings.Join(types, "/") + "." + req.Method
+	}
+
+	return req.URL.Host + "." + req.Method
+}
@@ -0,0 +1,107 @@
+package azure
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/golang/mock/gomock"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type fakeTransporter struct {
+	resp *http.Response
+	err  error
+}
+
+func (t *fakeTransporter) Do(req *http.Request) (*http.Response, error) {
+	return t.resp, t.err
+}
+
+func TestPolicy(t *testing.T) {
+	subnetURL := "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet/subnets/master?api-version=2022-07-01"
+
+	for _, tt := range []struct {
+		name      string
+		url       string
+		transport *fakeTransporter
+		mocks     func(*mock_metrics.MockEmitter)
+		wantErr   string
+	}{
+		{
+			name:      "success",
+			url:       subnetURL,
+			transport: &fakeTransporter{resp: &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				dims := map[string]string{
+					"client": "Microsoft.Network/virtualNetworks/subnets.GET",
+					"code":   "200",
+				}
+				m.EXPECT().EmitGauge("client.azure.duration", gomock.Any(), dims)
+				m.EXPECT().EmitHistogram("client.azure.duration.histogram", gomock.Any(), dims)
+				m.EXPECT().EmitGauge("client.azure.count", int64(1), dims)
+			},
+		},
+		{
+			name:      "error status",
+			url:       "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.DocumentDB/databaseAccounts/db/listKeys",
+			transport: &fakeTransporter{resp: &http.Response{StatusCode: http.StatusForbidden, Body: http.NoBody}},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				dims := map[string]string{
+					"client": "Microsoft.DocumentDB/databaseAccounts/listKeys.GET",
+					"code":   "403",
+				}
+				m.EXPECT().EmitGauge("client.azure.duration", gomock.Any(), dims)
+				m.EXPECT().EmitHistogram("client.azure.duration.histogram", gomock.Any(), dims)
+				m.EXPECT().EmitGauge("client.azure.count", int64(1), dims)
+				m.EXPECT().EmitGauge("client.azure.errors", int64(1), dims)
+			},
+		},
+		{
+			name:      "transport error",
+			url:       "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+			transport: &fakeTransporter{err: errors.New("connection refused")},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				dims := map[string]string{
+					"client": "login.microsoftonline.com.GET",
+					"code":   "0",
+				}
+				m.EXPECT().EmitGauge("client.azure.duration", gomock.Any(), dims)
+				m.EXPECT().EmitHistogram("client.azure.duration.histogram", gomock.Any(), dims)
+				m.EXPECT().EmitGauge("client.azure.count", int64(1), dims)
+				m.EXPECT().EmitGauge("client.azure.errors", int64(1), dims)
+			},
+			wantErr: "connection refused",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			tt.mocks(m)
+
+			pl := runtime.NewPipeline("test", "v0.0.0", runtime.PipelineOptions{}, &policy.ClientOptions{
+				PerCallPolicies: []policy.Policy{NewPolicy(m)},
+				Retry:           policy.RetryOptions{MaxRetries: -1},
+				Transport:       tt.transport,
+			})
+
+			req, err := runtime.NewRequest(context.Background(), http.MethodGet, tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = pl.Do(req)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -18,8 +18,9 @@ type metric struct {
 	dimensions map[string]string
 	timestamp  time.Time
 
-	valueGauge *int64
-	valueFloat *float64
+	valueGauge     *int64
+	valueFloat     *float64
+	valueHistogram *float64
 }
 
 // MarshalJSON marshals a metric into JSON format.
@@ -60,6 +61,12 @@ func (m *metric) marshalStatsd() ([]byte, error) {
 		if err != nil {
 			return nil, err
 		}
+	} else if m.valueHistogram != nil {
+		// statsd timers are aggregated into distributions by the receiver
+		_, err = fmt.Fprintf(buf, ":%f|ms\n", *m.valueHistogram)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		_, err = fmt.Fprintf(buf, ":%d|g\n", *m.valueGauge)
 		if err != nil {
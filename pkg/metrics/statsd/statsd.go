@@ -78,6 +78,15 @@ func (s *statsd) EmitGauge(metricName string, metricValue int64, dimensions map[
 	})
 }
 
+// EmitHistogram records an observation of a distribution
+func (s *statsd) EmitHistogram(metricName string, metricValue float64, dimensions map[string]string) {
+	s.emitMetric(&metric{
+		name:           metricName,
+		dimensions:     dimensions,
+		valueHistogram: &metricValue,
+	})
+}
+
 func (s *statsd) emitMetric(m *metric) {
 	m.account = s.account
 	m.namespace = s.namespace
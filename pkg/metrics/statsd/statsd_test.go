@@ -85,6 +85,41 @@ func TestEmitFloat(t *testing.T) {
 	}
 }
 
+func TestEmitHistogram(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	env := mock_env.NewMockInterface(controller)
+	env.EXPECT().Location().AnyTimes().Return("eastus")
+	env.EXPECT().Hostname().AnyTimes().Return("test-host")
+
+	c1, c2 := net.Pipe()
+
+	s := &statsd{
+		env: env,
+
+		account:   "*",
+		namespace: "*",
+
+		conn: c1,
+		ch:   make(chan *metric),
+
+		now: func() time.Time { return time.Time{} },
+	}
+
+	go s.run()
+
+	s.EmitHistogram("tests.test_key", 5, map[string]string{"key": "value"})
+
+	m, err := bufio.NewReader(c2).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m != `{"Metric":"tests.test_key","Account":"*","Namespace":"*","Dims":{"hostname":"test-host","key":"value","location":"eastus"},"TS":"0001-01-01T00:00:00.000"}:5.000000|ms`+"\n" {
+		t.Error(m)
+	}
+}
+
 func TestParseSocketEnv(t *testing.T) {
 	for _, tt := range []struct {
 		name        string
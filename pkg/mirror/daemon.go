@@ -0,0 +1,257 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+// Image is an image which is mirrored from Source to Destination
+type Image struct {
+	Source      string
+	Destination string
+}
+
+// Daemon continuously mirrors OpenShift releases and ARO component images to
+// an ACR.  The digest of each image mirrored is recorded in the database, so
+// that releases which have already been mirrored are not mirrored again.
+type Daemon struct {
+	log              *logrus.Entry
+	m                metrics.Emitter
+	dbMirroredImages database.MirroredImages
+
+	dstrepo string
+	dstauth *types.DockerAuthConfig
+	// srcauth returns the credentials with which to pull a source reference
+	srcauth func(string) *types.DockerAuthConfig
+	policy  *signature.Policy
+
+	images   []Image
+	skip     map[string]struct{}
+	interval time.Duration
+
+	// pending holds, by version, the time at which each release which has
+	// not yet been mirrored was first seen
+	pending map[string]time.Time
+
+	now           func() time.Time
+	releases      func() ([]Node, error)
+	copy          func(ctx context.Context, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig, policy *signature.Policy) (digest.Digest, error)
+	mirrorRelease func(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig, policy *signature.Policy) (digest.Digest, error)
+}
+
+// NewDaemon returns a Daemon which mirrors images and the releases in the
+// release graph of version minVersion and later, except those in skip, to
+// dstrepo every interval
+func NewDaemon(log *logrus.Entry, m metrics.Emitter, dbMirroredImages database.MirroredImages, dstrepo string, dstauth *types.DockerAuthConfig, srcauth func(string) *types.DockerAuthConfig, policy *signature.Policy, images []Image, minVersion *version.Version, skip map[string]struct{}, interval time.Duration) *Daemon {
+	return &Daemon{
+		log:              log,
+		m:                m,
+		dbMirroredImages: dbMirroredImages,
+
+		dstrepo: dstrepo,
+		dstauth: dstauth,
+		srcauth: srcauth,
+		policy:  policy,
+
+		images:   images,
+		skip:     skip,
+		interval: interval,
+
+		pending: map[string]time.Time{},
+
+		now: time.Now,
+		releases: func() ([]Node, error) {
+			return AddFromGraph(minVersion)
+		},
+		copy:          CopyAndVerify,
+		mirrorRelease: MirrorAndVerify,
+	}
+}
+
+// Run mirrors every interval until ctx is cancelled
+func (d *Daemon) Run(ctx context.Context) error {
+	t := time.NewTicker(d.interval)
+	defer t.Stop()
+
+	for {
+		err := d.mirror(ctx)
+		if err != nil {
+			d.log.Error(err)
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// mirror mirrors each image, and each release which has not already been
+// mirrored.  Failures to mirror individual images are logged and counted
+// rather than returned, so that one failing image does not block the others.
+func (d *Daemon) mirror(ctx context.Context) error {
+	docs, err := d.dbMirroredImages.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	mirrored := map[string]*api.MirroredImageDocument{}
+	for _, doc := range docs.MirroredImageDocuments {
+		mirrored[doc.ID] = doc
+	}
+
+	var errors int64
+
+	for _, image := range d.images {
+		d.log.Printf("mirroring %s -> %s", image.Source, image.Destination)
+		dgst, err := d.copy(ctx, image.Destination, image.Source, d.dstauth, d.srcauth(image.Source), d.policy)
+		if err != nil {
+			d.log.Errorf("%s: %s", image.Source, err)
+			errors++
+			continue
+		}
+
+		err = d.record(ctx, mirrored, &api.MirroredImage{
+			Source:      image.Source,
+			Destination: image.Destination,
+			Digest:      dgst.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	releases, err := d.releases()
+	if err != nil {
+		return err
+	}
+
+	for _, release := range releases {
+		if _, found := d.skip[release.Version]; found {
+			continue
+		}
+
+		dst := Dest(d.dstrepo, release.Payload)
+		if doc := mirrored[documentID(dst)]; doc != nil && doc.MirroredImage.Digest == payloadDigest(release.Payload) {
+			delete(d.pending, release.Version)
+			continue
+		}
+
+		if _, found := d.pending[release.Version]; !found {
+			d.pending[release.Version] = d.now()
+		}
+
+		d.log.Printf("mirroring release %s", release.Version)
+		stop := metrics.StartTimer(d.m, "mirror.release.duration")
+		dgst, err := d.mirrorRelease(ctx, d.log, d.dstrepo, release.Payload, d.dstauth, d.srcauth(release.Payload), d.policy)
+		if err != nil {
+			stop(map[string]string{"result": "failure"})
+			d.log.Errorf("release %s: %s", release.Version, err)
+			errors++
+			continue
+		}
+		stop(map[string]string{"result": "success"})
+
+		err = d.record(ctx, mirrored, &api.MirroredImage{
+			Source:      release.Payload,
+			Destination: dst,
+			Digest:      dgst.String(),
+			Release:     release.Version,
+		})
+		if err != nil {
+			return err
+		}
+
+		delete(d.pending, release.Version)
+		d.emitLag()
+	}
+
+	d.emitLag()
+	d.m.EmitGauge("mirror.errors", errors, nil)
+
+	return nil
+}
+
+// record records image as mirrored, unless the same digest is already
+// recorded
+func (d *Daemon) record(ctx context.Context, mirrored map[string]*api.MirroredImageDocument, image *api.MirroredImage) error {
+	image.MirroredAt = d.now().UTC()
+	id := documentID(image.Destination)
+
+	if doc := mirrored[id]; doc != nil {
+		if doc.MirroredImage.Digest == image.Digest {
+			return nil
+		}
+
+		doc, err := d.dbMirroredImages.Patch(ctx, id, func(doc *api.MirroredImageDocument) error {
+			doc.MirroredImage = image
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		mirrored[id] = doc
+		return nil
+	}
+
+	doc, err := d.dbMirroredImages.Create(ctx, &api.MirroredImageDocument{
+		ID:            id,
+		MirroredImage: image,
+	})
+	if err != nil {
+		return err
+	}
+
+	mirrored[id] = doc
+	return nil
+}
+
+// emitLag emits the number of releases which have been seen but not mirrored
+// and the time since the oldest of them was first seen
+func (d *Daemon) emitLag() {
+	var lag time.Duration
+	for _, seen := range d.pending {
+		if since := d.now().Sub(seen); since > lag {
+			lag = since
+		}
+	}
+
+	d.m.EmitGauge("mirror.releases.pending", int64(len(d.pending)), nil)
+	d.m.EmitGauge("mirror.lag", int64(lag/time.Second), nil)
+}
+
+// documentID returns the ID of the document which records the image mirrored
+// to destination.  Document IDs may not contain '/', so a hash is used.
+func documentID(destination string) string {
+	h := sha256.Sum256([]byte(destination))
+	return hex.EncodeToString(h[:])
+}
+
+// payloadDigest returns the digest by which a release payload is pinned, or
+// the empty string if it is not pinned by digest
+func payloadDigest(payload string) string {
+	i := strings.LastIndexByte(payload, '@')
+	if i == -1 {
+		return ""
+	}
+
+	return payload[i+1:]
+}
@@ -0,0 +1,185 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/types"
+	"github.com/golang/mock/gomock"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestDaemonMirror(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Unix(0, 0)
+	release := Node{
+		Version: "4.14.1",
+		Payload: "quay.io/openshift-release-dev/ocp-release@sha256:1111",
+	}
+	skipped := Node{
+		Version: "4.8.8",
+		Payload: "quay.io/openshift-release-dev/ocp-release@sha256:8888",
+	}
+	image := Image{
+		Source:      "registry.redhat.io/rhel9/support-tools:latest",
+		Destination: "dst.azurecr.io/rhel9/support-tools:latest",
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitHistogram("mirror.release.duration", gomock.Any(), gomock.Any()).AnyTimes()
+
+	dbMirroredImages, client := testdatabase.NewFakeMirroredImages()
+
+	var copies, releaseMirrors int
+	imageDigest := digest.Digest("sha256:aaaa")
+	releaseErr := errors.New("failed")
+
+	d := NewDaemon(logrus.NewEntry(logrus.StandardLogger()), m, dbMirroredImages, "dst.azurecr.io", nil, func(string) *types.DockerAuthConfig { return nil }, nil, []Image{image}, nil, map[string]struct{}{skipped.Version: {}}, time.Hour)
+	d.now = func() time.Time { return now }
+	d.releases = func() ([]Node, error) {
+		return []Node{release, skipped}, nil
+	}
+	d.copy = func(ctx context.Context, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig, policy *signature.Policy) (digest.Digest, error) {
+		copies++
+		if dstreference != image.Destination || srcreference != image.Source {
+			t.Error(dstreference, srcreference)
+		}
+		return imageDigest, nil
+	}
+	d.mirrorRelease = func(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig, policy *signature.Policy) (digest.Digest, error) {
+		releaseMirrors++
+		if srcrelease != release.Payload {
+			t.Error(srcrelease)
+		}
+		if releaseErr != nil {
+			return "", releaseErr
+		}
+		return "sha256:1111", nil
+	}
+
+	// the release fails to mirror and is pending
+	m.EXPECT().EmitGauge("mirror.releases.pending", int64(1), nil)
+	m.EXPECT().EmitGauge("mirror.lag", int64(0), nil)
+	m.EXPECT().EmitGauge("mirror.errors", int64(1), nil)
+
+	err := d.mirror(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := client.ListAll(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs.MirroredImageDocuments) != 1 {
+		t.Fatal(len(docs.MirroredImageDocuments))
+	}
+	if got := docs.MirroredImageDocuments[0].MirroredImage; got.Source != image.Source || got.Destination != image.Destination || got.Digest != imageDigest.String() {
+		t.Error(got)
+	}
+
+	// the release is mirrored an hour after it was first seen, and the image
+	// digest changes
+	now = now.Add(time.Hour)
+	releaseErr = nil
+	imageDigest = "sha256:bbbb"
+
+	m.EXPECT().EmitGauge("mirror.releases.pending", int64(0), nil).Times(2)
+	m.EXPECT().EmitGauge("mirror.lag", int64(0), nil).Times(2)
+	m.EXPECT().EmitGauge("mirror.errors", int64(0), nil)
+
+	err = d.mirror(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := dbMirroredImages.Get(ctx, documentID(image.Destination))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.MirroredImage.Digest != "sha256:bbbb" || !doc.MirroredImage.MirroredAt.Equal(now) {
+		t.Error(doc.MirroredImage)
+	}
+
+	doc, err = dbMirroredImages.Get(ctx, documentID("dst.azurecr.io/openshift-release-dev/ocp-release@sha256:1111"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.MirroredImage.Release != release.Version || doc.MirroredImage.Digest != "sha256:1111" {
+		t.Error(doc.MirroredImage)
+	}
+
+	// the release is not mirrored again
+	m.EXPECT().EmitGauge("mirror.releases.pending", int64(0), nil)
+	m.EXPECT().EmitGauge("mirror.lag", int64(0), nil)
+	m.EXPECT().EmitGauge("mirror.errors", int64(0), nil)
+
+	err = d.mirror(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if copies != 3 {
+		t.Error(copies)
+	}
+	if releaseMirrors != 2 {
+		t.Error(releaseMirrors)
+	}
+}
+
+func TestDaemonEmitLag(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	now := time.Unix(3600, 0)
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("mirror.releases.pending", int64(2), nil)
+	m.EXPECT().EmitGauge("mirror.lag", int64(1800), nil)
+
+	d := &Daemon{
+		m: m,
+		pending: map[string]time.Time{
+			"4.14.1": now.Add(-10 * time.Minute),
+			"4.14.2": now.Add(-30 * time.Minute),
+		},
+		now: func() time.Time { return now },
+	}
+
+	d.emitLag()
+}
+
+func TestPayloadDigest(t *testing.T) {
+	for _, tt := range []struct {
+		payload string
+		want    string
+	}{
+		{
+			payload: "quay.io/openshift-release-dev/ocp-release@sha256:1111",
+			want:    "sha256:1111",
+		},
+		{
+			payload: "quay.io/openshift-release-dev/ocp-release:4.14.1-x86_64",
+		},
+	} {
+		t.Run(tt.payload, func(t *testing.T) {
+			if got := payloadDigest(tt.payload); got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -13,32 +13,55 @@ import (
 
 	"github.com/containers/image/v5/copy"
 	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/manifest"
 	"github.com/containers/image/v5/signature"
 	"github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
 	"github.com/sirupsen/logrus"
 )
 
+// Copy copies srcreference to dstreference, accepting any signature
 func Copy(ctx context.Context, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig) error {
-	policyctx, err := signature.NewPolicyContext(&signature.Policy{
-		Default: signature.PolicyRequirements{
-			signature.NewPRInsecureAcceptAnything(),
-		},
-	})
+	_, err := CopyAndVerify(ctx, dstreference, srcreference, dstauth, srcauth, nil)
+	return err
+}
+
+// CopyAndVerify copies srcreference to dstreference and returns the digest of
+// the copied manifest.  The source image must satisfy policy; a nil policy
+// accepts any image.  If srcreference is pinned by digest, the copied manifest
+// must have the same digest.
+func CopyAndVerify(ctx context.Context, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig, policy *signature.Policy) (digest.Digest, error) {
+	if policy == nil {
+		policy = &signature.Policy{
+			Default: signature.PolicyRequirements{
+				signature.NewPRInsecureAcceptAnything(),
+			},
+		}
+	}
+
+	policyctx, err := signature.NewPolicyContext(policy)
 	if err != nil {
-		return err
+		return "", err
 	}
+	defer policyctx.Destroy()
 
 	src, err := docker.ParseReference("//" + srcreference)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	dst, err := docker.ParseReference("//" + dstreference)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = copy.Image(ctx, policyctx, dst, src, &copy.Options{
+	var want digest.Digest
+	if canonical, ok := src.DockerReference().(reference.Canonical); ok {
+		want = canonical.Digest()
+	}
+
+	copied, err := copy.Image(ctx, policyctx, dst, src, &copy.Options{
 		SourceCtx: &types.SystemContext{
 			DockerAuthConfig: srcauth,
 		},
@@ -50,9 +73,23 @@ func Copy(ctx context.Context, dstreference, srcreference string, dstauth, srcau
 		// equal before attempting to push it (and sending no blobs because
 		// they're all already there)
 		OptimizeDestinationImageAlreadyExists: true,
+		// Images pinned by digest must not be modified in transit
+		PreserveDigests: want != "",
 	})
+	if err != nil {
+		return "", err
+	}
 
-	return err
+	got, err := manifest.Digest(copied)
+	if err != nil {
+		return "", err
+	}
+
+	if want != "" && got != want {
+		return "", fmt.Errorf("digest mismatch copying %s: expected %s, got %s", srcreference, want, got)
+	}
+
+	return got, nil
 }
 
 // This will return repo and image name, preserving path
@@ -73,11 +110,21 @@ func DestLastIndex(repo, reference string) string {
 	return repo + reference[strings.LastIndex(reference, "/"):]
 }
 
+// Mirror mirrors the release srcrelease and the images it references to
+// dstrepo, accepting any signature
 func Mirror(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig) error {
+	_, err := MirrorAndVerify(ctx, log, dstrepo, srcrelease, dstauth, srcauth, nil)
+	return err
+}
+
+// MirrorAndVerify mirrors the release srcrelease and the images it references
+// to dstrepo, verifying each as CopyAndVerify does, and returns the digest of
+// the release image
+func MirrorAndVerify(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig, policy *signature.Policy) (digest.Digest, error) {
 	log.Printf("reading imagestream from %s", srcrelease)
 	is, err := getReleaseImageStream(ctx, srcrelease, srcauth)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	type work struct {
@@ -92,23 +139,32 @@ func Mirror(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string,
 	wg := &sync.WaitGroup{}
 	var errorOccurred atomic.Value
 	errorOccurred.Store(false)
+	var releaseDigest digest.Digest
 
 	for i := 0; i < 10; i++ {
 		wg.Add(1)
 		go func() {
 			for w := range ch {
 				log.Printf("mirroring %s", w.tag)
+				var d digest.Digest
 				var err error
+			retry:
 				for retry := 0; retry < 6; retry++ {
-					err = Copy(ctx, w.dstreference, w.srcreference, w.dstauth, w.srcauth)
+					d, err = CopyAndVerify(ctx, w.dstreference, w.srcreference, w.dstauth, w.srcauth, policy)
 					if err == nil {
 						break
 					}
-					time.Sleep(10 * time.Second)
+					select {
+					case <-time.After(10 * time.Second):
+					case <-ctx.Done():
+						break retry
+					}
 				}
 				if err != nil {
 					log.Errorf("%s: %s\n", w.tag, err)
 					errorOccurred.Store(true)
+				} else if w.tag == "release" {
+					releaseDigest = d
 				}
 			}
 			wg.Done()
@@ -139,8 +195,8 @@ func Mirror(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string,
 	wg.Wait()
 
 	if errorOccurred.Load().(bool) {
-		return fmt.Errorf("an error occurred")
+		return "", fmt.Errorf("an error occurred")
 	}
 
-	return nil
+	return releaseDigest, nil
 }
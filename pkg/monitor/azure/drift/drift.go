@@ -0,0 +1,330 @@
+package drift
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/monitor/dimension"
+	"github.com/Azure/ARO-RP/pkg/monitor/emitter"
+	"github.com/Azure/ARO-RP/pkg/monitor/monitoring"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+const (
+	MetricFailedMonitorCreation = "monitor.drift.failedmonitorcreation"
+	MetricDrift                 = "monitor.drift"
+
+	outboundRuleV4 = "outbound-rule-v4"
+)
+
+// Drift reasons
+const (
+	ReasonMasterVMSize = "masterVMSize"
+	ReasonNSG          = "nsg"
+	ReasonOutboundIPs  = "outboundIPs"
+)
+
+// Kinds of drifted resources
+const (
+	KindVirtualMachine = "virtualMachine"
+	KindSubnet         = "subnet"
+	KindLoadBalancer   = "loadBalancer"
+)
+
+var _ monitoring.Monitor = (*DriftMonitor)(nil)
+
+// DriftMonitor compares key fields of the cluster document with the live
+// Azure resources and emits a metric for each difference, so that customer
+// modifications to RP-managed resources are surfaced.
+type DriftMonitor struct {
+	log     *logrus.Entry
+	emitter metrics.Emitter
+	oc      *api.OpenShiftCluster
+
+	wg *sync.WaitGroup
+
+	virtualMachines compute.VirtualMachinesClient
+	subnets         network.SubnetsClient
+	loadBalancers   network.LoadBalancersClient
+	dims            map[string]string
+}
+
+func NewMonitor(log *logrus.Entry, oc *api.OpenShiftCluster, e env.Interface, subscriptionID string, tenantID string, emitter metrics.Emitter, dims map[string]string, wg *sync.WaitGroup, trigger <-chan time.Time) monitoring.Monitor {
+	if oc == nil {
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	// while the RP is changing the cluster, the document and the resources
+	// are expected to differ
+	if oc.Properties.ProvisioningState != api.ProvisioningStateSucceeded {
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	select {
+	case <-trigger:
+	default:
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	authorizer, err := e.FPAuthorizer(tenantID, e.Environment().ResourceManagerScope)
+	if err != nil {
+		log.Error("Unable to create FP Authorizer for drift monitoring.", err)
+		emitter.EmitGauge(MetricFailedMonitorCreation, int64(1), dims)
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	return newMonitor(log, oc, emitter, dims, wg,
+		compute.NewVirtualMachinesClient(e.Environment(), subscriptionID, authorizer),
+		network.NewSubnetsClient(e.Environment(), subscriptionID, authorizer),
+		network.NewLoadBalancersClient(e.Environment(), subscriptionID, authorizer),
+	)
+}
+
+func newMonitor(log *logrus.Entry, oc *api.OpenShiftCluster, emitter metrics.Emitter, dims map[string]string, wg *sync.WaitGroup, virtualMachines compute.VirtualMachinesClient, subnets network.SubnetsClient, loadBalancers network.LoadBalancersClient) *DriftMonitor {
+	return &DriftMonitor{
+		log:     log,
+		emitter: emitter,
+		oc:      oc,
+
+		wg: wg,
+
+		virtualMachines: virtualMachines,
+		subnets:         subnets,
+		loadBalancers:   loadBalancers,
+		dims:            dims,
+	}
+}
+
+// Monitor emits a drift metric for each master VM size, subnet NSG
+// association and outbound IP set which differs from the cluster document.
+// Worker VMs are not checked: customers legitimately scale and resize them
+// through MachineSets, so the worker profiles in the document are only the
+// values the cluster was created with.
+func (d *DriftMonitor) Monitor(ctx context.Context) (errs []error) {
+	defer d.wg.Done()
+
+	resourceGroup := stringutils.LastTokenByte(d.oc.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	for _, f := range []func(context.Context, string) error{
+		d.checkMasterVMs,
+		d.checkNSGs,
+		d.checkOutboundIPs,
+	} {
+		err := f(ctx, resourceGroup)
+		if err != nil {
+			d.log.Error(err)
+			errs = append(errs, err)
+			// keep going
+		}
+	}
+
+	return errs
+}
+
+// emitDrift emits the drift metric with only the reason and the kind of the
+// drifted resource as dimensions, to keep the metric's cardinality low.  The
+// resource and the expected and actual values are logged.
+func (d *DriftMonitor) emitDrift(reason, kind string, fields logrus.Fields) {
+	d.log.WithFields(fields).Warnf("%s drift on %s", reason, kind)
+
+	emitter.EmitGauge(d.emitter, MetricDrift, 1, d.dims, map[string]string{
+		dimension.Reason:       reason,
+		dimension.ResourceKind: kind,
+	})
+}
+
+// checkMasterVMs compares the size of each master VM with the master profile
+func (d *DriftMonitor) checkMasterVMs(ctx context.Context, resourceGroup string) error {
+	vms, err := d.virtualMachines.List(ctx, resourceGroup)
+	if err != nil {
+		return err
+	}
+
+	for _, vm := range vms {
+		if vm.Name == nil || vm.VirtualMachineProperties == nil || vm.HardwareProfile == nil ||
+			!strings.Contains(*vm.Name, "-master-") {
+			continue
+		}
+
+		vmSize := string(vm.HardwareProfile.VMSize)
+
+		if !strings.EqualFold(vmSize, string(d.oc.Properties.MasterProfile.VMSize)) {
+			d.emitDrift(ReasonMasterVMSize, KindVirtualMachine, logrus.Fields{
+				"vm":       *vm.Name,
+				"expected": d.oc.Properties.MasterProfile.VMSize,
+				"actual":   vmSize,
+			})
+		}
+	}
+
+	return nil
+}
+
+// checkNSGs checks that the master and worker subnets are still associated
+// with the NSG the RP created, unless the customer brought their own
+func (d *DriftMonitor) checkNSGs(ctx context.Context, resourceGroup string) error {
+	if d.oc.Properties.NetworkProfile.PreconfiguredNSG == api.PreconfiguredNSGEnabled {
+		return nil
+	}
+
+	subnetIDs := map[string]string{
+		strings.ToLower(d.oc.Properties.MasterProfile.SubnetID): d.oc.Properties.MasterProfile.SubnetID,
+	}
+	workerProfiles, _ := api.GetEnrichedWorkerProfiles(d.oc.Properties)
+	for _, wp := range workerProfiles {
+		if wp.SubnetID != "" {
+			subnetIDs[strings.ToLower(wp.SubnetID)] = wp.SubnetID
+		}
+	}
+
+	for _, k := range sortedKeys(subnetIDs) {
+		subnetID := subnetIDs[k]
+
+		expected, err := apisubnet.NetworkSecurityGroupID(d.oc, subnetID)
+		if err != nil {
+			return err
+		}
+
+		vnetID, subnetName, err := apisubnet.Split(subnetID)
+		if err != nil {
+			return err
+		}
+
+		r, err := azure.ParseResourceID(vnetID)
+		if err != nil {
+			return err
+		}
+
+		subnet, err := d.subnets.Get(ctx, r.ResourceGroup, r.ResourceName, subnetName, "")
+		if err != nil {
+			return err
+		}
+
+		var actual string
+		if subnet.SubnetPropertiesFormat != nil &&
+			subnet.NetworkSecurityGroup != nil &&
+			subnet.NetworkSecurityGroup.ID != nil {
+			actual = *subnet.NetworkSecurityGroup.ID
+		}
+
+		if !strings.EqualFold(expected, actual) {
+			d.emitDrift(ReasonNSG, KindSubnet, logrus.Fields{
+				"subnet":   subnetID,
+				"expected": expected,
+				"actual":   actual,
+			})
+		}
+	}
+
+	return nil
+}
+
+// checkOutboundIPs compares the public IPs of the public load balancer's
+// outbound rule with the effective outbound IPs in the cluster document
+func (d *DriftMonitor) checkOutboundIPs(ctx context.Context, resourceGroup string) error {
+	if d.oc.Properties.NetworkProfile.OutboundType != api.OutboundTypeLoadbalancer ||
+		d.oc.Properties.ArchitectureVersion == api.ArchitectureVersionV1 ||
+		d.oc.Properties.NetworkProfile.LoadBalancerProfile == nil {
+		return nil
+	}
+
+	lb, err := d.loadBalancers.Get(ctx, resourceGroup, d.oc.Properties.InfraID, "")
+	if err != nil {
+		return err
+	}
+
+	expected := map[string]string{}
+	for _, ip := range d.oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs {
+		expected[strings.ToLower(ip.ID)] = ip.ID
+	}
+
+	actual := outboundIPs(lb)
+
+	if strings.Join(sortedKeys(expected), ",") != strings.Join(sortedKeys(actual), ",") {
+		d.emitDrift(ReasonOutboundIPs, KindLoadBalancer, logrus.Fields{
+			"loadBalancer": d.oc.Properties.InfraID,
+			"expected":     join(expected),
+			"actual":       join(actual),
+		})
+	}
+
+	return nil
+}
+
+// outboundIPs returns the IDs of the public IPs used by the load balancer's
+// outbound rule, keyed by their lower-cased IDs
+func outboundIPs(lb mgmtnetwork.LoadBalancer) map[string]string {
+	ips := map[string]string{}
+
+	if lb.LoadBalancerPropertiesFormat == nil ||
+		lb.FrontendIPConfigurations == nil ||
+		lb.OutboundRules == nil {
+		return ips
+	}
+
+	publicIPs := map[string]string{}
+	for _, fipConfig := range *lb.FrontendIPConfigurations {
+		if fipConfig.ID == nil ||
+			fipConfig.FrontendIPConfigurationPropertiesFormat == nil ||
+			fipConfig.PublicIPAddress == nil ||
+			fipConfig.PublicIPAddress.ID == nil {
+			continue
+		}
+		publicIPs[strings.ToLower(*fipConfig.ID)] = *fipConfig.PublicIPAddress.ID
+	}
+
+	for _, rule := range *lb.OutboundRules {
+		if rule.Name == nil || *rule.Name != outboundRuleV4 ||
+			rule.OutboundRulePropertiesFormat == nil ||
+			rule.OutboundRulePropertiesFormat.FrontendIPConfigurations == nil {
+			continue
+		}
+
+		for _, fipConfig := range *rule.OutboundRulePropertiesFormat.FrontendIPConfigurations {
+			if fipConfig.ID == nil {
+				continue
+			}
+			if id, ok := publicIPs[strings.ToLower(*fipConfig.ID)]; ok {
+				ips[strings.ToLower(id)] = id
+			}
+		}
+	}
+
+	return ips
+}
+
+// sortedKeys returns the keys of a map of lower-cased to original values
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// join returns the original values of a map of lower-cased to original
+// values, comma-separated in a stable order
+func join(m map[string]string) string {
+	values := make([]string, 0, len(m))
+	for _, k := range sortedKeys(m) {
+		values = append(values, m[k])
+	}
+	return strings.Join(values, ",")
+}
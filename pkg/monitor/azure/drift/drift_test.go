@@ -0,0 +1,198 @@
+package drift
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/monitor/dimension"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+
+	resourceGroup := "aro-cluster"
+	resourceGroupID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/" + resourceGroup
+	vnetID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet"
+	nsgID := resourceGroupID + "/providers/Microsoft.Network/networkSecurityGroups/infra-nsg"
+	fipConfigID := resourceGroupID + "/providers/Microsoft.Network/loadBalancers/infra/frontendIPConfigurations/public-lb-ip-v4"
+	publicIPID := resourceGroupID + "/providers/Microsoft.Network/publicIPAddresses/infra-pip-v4"
+	otherPublicIPID := resourceGroupID + "/providers/Microsoft.Network/publicIPAddresses/customer-pip"
+
+	oc := &api.OpenShiftCluster{
+		Properties: api.OpenShiftClusterProperties{
+			ArchitectureVersion: api.ArchitectureVersionV2,
+			InfraID:             "infra",
+			ClusterProfile: api.ClusterProfile{
+				ResourceGroupID: resourceGroupID,
+			},
+			MasterProfile: api.MasterProfile{
+				VMSize:   api.VMSizeStandardD8sV3,
+				SubnetID: vnetID + "/subnets/master",
+			},
+			WorkerProfiles: []api.WorkerProfile{
+				{
+					VMSize:   api.VMSizeStandardD4sV3,
+					SubnetID: vnetID + "/subnets/worker",
+					Count:    2,
+				},
+			},
+			NetworkProfile: api.NetworkProfile{
+				OutboundType: api.OutboundTypeLoadbalancer,
+				LoadBalancerProfile: &api.LoadBalancerProfile{
+					EffectiveOutboundIPs: []api.EffectiveOutboundIP{
+						{ID: publicIPID},
+					},
+				},
+			},
+		},
+	}
+	dims := map[string]string{
+		dimension.ResourceID: "resourceID",
+	}
+	withDims := func(m map[string]string) map[string]string {
+		m[dimension.ResourceID] = "resourceID"
+		return m
+	}
+
+	vm := func(name string, size mgmtcompute.VirtualMachineSizeTypes) mgmtcompute.VirtualMachine {
+		return mgmtcompute.VirtualMachine{
+			Name: to.StringPtr(name),
+			VirtualMachineProperties: &mgmtcompute.VirtualMachineProperties{
+				HardwareProfile: &mgmtcompute.HardwareProfile{
+					VMSize: size,
+				},
+			},
+		}
+	}
+	subnet := func(nsgID string) mgmtnetwork.Subnet {
+		return mgmtnetwork.Subnet{
+			SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+				NetworkSecurityGroup: &mgmtnetwork.SecurityGroup{
+					ID: to.StringPtr(nsgID),
+				},
+			},
+		}
+	}
+	lb := func(publicIPID string) mgmtnetwork.LoadBalancer {
+		return mgmtnetwork.LoadBalancer{
+			LoadBalancerPropertiesFormat: &mgmtnetwork.LoadBalancerPropertiesFormat{
+				FrontendIPConfigurations: &[]mgmtnetwork.FrontendIPConfiguration{
+					{
+						ID: to.StringPtr(fipConfigID),
+						FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+							PublicIPAddress: &mgmtnetwork.PublicIPAddress{
+								ID: to.StringPtr(publicIPID),
+							},
+						},
+					},
+				},
+				OutboundRules: &[]mgmtnetwork.OutboundRule{
+					{
+						Name: to.StringPtr(outboundRuleV4),
+						OutboundRulePropertiesFormat: &mgmtnetwork.OutboundRulePropertiesFormat{
+							FrontendIPConfigurations: &[]mgmtnetwork.SubResource{
+								{ID: to.StringPtr(fipConfigID)},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		mocks    func(*mock_compute.MockVirtualMachinesClient, *mock_network.MockSubnetsClient, *mock_network.MockLoadBalancersClient, *mock_metrics.MockEmitter)
+		wantErrs int
+	}{
+		{
+			name: "no drift",
+			mocks: func(vms *mock_compute.MockVirtualMachinesClient, subnets *mock_network.MockSubnetsClient, lbs *mock_network.MockLoadBalancersClient, m *mock_metrics.MockEmitter) {
+				vms.EXPECT().List(gomock.Any(), resourceGroup).Return([]mgmtcompute.VirtualMachine{
+					vm("infra-master-0", mgmtcompute.VirtualMachineSizeTypesStandardD8sV3),
+					vm("infra-worker-eastus1-abcde", mgmtcompute.VirtualMachineSizeTypesStandardD4sV3),
+					vm("infra-worker-eastus2-fghij", mgmtcompute.VirtualMachineSizeTypesStandardD4sV3),
+				}, nil)
+				subnets.EXPECT().Get(gomock.Any(), "vnet", "vnet", "master", "").Return(subnet(nsgID), nil)
+				subnets.EXPECT().Get(gomock.Any(), "vnet", "vnet", "worker", "").Return(subnet(nsgID), nil)
+				lbs.EXPECT().Get(gomock.Any(), resourceGroup, "infra", "").Return(lb(publicIPID), nil)
+			},
+		},
+		{
+			name: "drift",
+			mocks: func(vms *mock_compute.MockVirtualMachinesClient, subnets *mock_network.MockSubnetsClient, lbs *mock_network.MockLoadBalancersClient, m *mock_metrics.MockEmitter) {
+				vms.EXPECT().List(gomock.Any(), resourceGroup).Return([]mgmtcompute.VirtualMachine{
+					vm("infra-master-0", mgmtcompute.VirtualMachineSizeTypesStandardD16sV3),
+					vm("infra-worker-eastus1-abcde", mgmtcompute.VirtualMachineSizeTypesStandardD2sV3),
+				}, nil)
+				// the worker has been resized and scaled down through its
+				// MachineSet, which is not drift
+				m.EXPECT().EmitGauge(MetricDrift, int64(1), withDims(map[string]string{
+					dimension.Reason:       ReasonMasterVMSize,
+					dimension.ResourceKind: KindVirtualMachine,
+				}))
+
+				subnets.EXPECT().Get(gomock.Any(), "vnet", "vnet", "master", "").Return(subnet(nsgID), nil)
+				subnets.EXPECT().Get(gomock.Any(), "vnet", "vnet", "worker", "").Return(mgmtnetwork.Subnet{
+					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{},
+				}, nil)
+				m.EXPECT().EmitGauge(MetricDrift, int64(1), withDims(map[string]string{
+					dimension.Reason:       ReasonNSG,
+					dimension.ResourceKind: KindSubnet,
+				}))
+
+				lbs.EXPECT().Get(gomock.Any(), resourceGroup, "infra", "").Return(lb(otherPublicIPID), nil)
+				m.EXPECT().EmitGauge(MetricDrift, int64(1), withDims(map[string]string{
+					dimension.Reason:       ReasonOutboundIPs,
+					dimension.ResourceKind: KindLoadBalancer,
+				}))
+			},
+		},
+		{
+			name: "errors do not stop other checks",
+			mocks: func(vms *mock_compute.MockVirtualMachinesClient, subnets *mock_network.MockSubnetsClient, lbs *mock_network.MockLoadBalancersClient, m *mock_metrics.MockEmitter) {
+				vms.EXPECT().List(gomock.Any(), resourceGroup).Return(nil, errors.New("vm error"))
+				subnets.EXPECT().Get(gomock.Any(), "vnet", "vnet", "master", "").Return(mgmtnetwork.Subnet{}, errors.New("subnet error"))
+				lbs.EXPECT().Get(gomock.Any(), resourceGroup, "infra", "").Return(lb(publicIPID), nil)
+			},
+			wantErrs: 2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			vms := mock_compute.NewMockVirtualMachinesClient(controller)
+			subnets := mock_network.NewMockSubnetsClient(controller)
+			lbs := mock_network.NewMockLoadBalancersClient(controller)
+			m := mock_metrics.NewMockEmitter(controller)
+
+			tt.mocks(vms, subnets, lbs, m)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			mon := newMonitor(logrus.NewEntry(logrus.StandardLogger()), oc, m, dims, &wg, vms, subnets, lbs)
+
+			errs := mon.Monitor(ctx)
+			if len(errs) != tt.wantErrs {
+				t.Error(errs)
+			}
+		})
+	}
+}
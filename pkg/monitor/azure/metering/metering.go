@@ -0,0 +1,167 @@
+package metering
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/monitor/emitter"
+	"github.com/Azure/ARO-RP/pkg/monitor/monitoring"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+const (
+	MetricFailedMonitorCreation = "monitor.metering.failedmonitorcreation"
+	MetricWorkerCores           = "monitor.metering.workercores"
+	MetricUnknownVMSize         = "monitor.metering.unknownvmsize"
+)
+
+var _ monitoring.Monitor = (*MeteringMonitor)(nil)
+
+// MeteringMonitor samples the worker cores of a cluster with a marketplace
+// plan from its VMs, and records them in its billing document for the hour,
+// from which the backend reports them to the Marketplace Metering API
+type MeteringMonitor struct {
+	log     *logrus.Entry
+	emitter metrics.Emitter
+	doc     *api.OpenShiftClusterDocument
+
+	wg *sync.WaitGroup
+
+	virtualMachines compute.VirtualMachinesClient
+	dbBilling       database.Billing
+	dims            map[string]string
+
+	now func() time.Time
+}
+
+func NewMonitor(log *logrus.Entry, doc *api.OpenShiftClusterDocument, e env.Interface, subscriptionID string, tenantID string, emitter metrics.Emitter, dims map[string]string, wg *sync.WaitGroup, trigger <-chan time.Time, dbBilling database.Billing) monitoring.Monitor {
+	if doc.OpenShiftCluster == nil || doc.OpenShiftCluster.Plan == nil || dbBilling == nil {
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	// usage is billed from the end of the install until the start of the
+	// deletion
+	switch doc.OpenShiftCluster.Properties.ProvisioningState {
+	case api.ProvisioningStateCreating, api.ProvisioningStateDeleting:
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	select {
+	case <-trigger:
+	default:
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	authorizer, err := e.FPAuthorizer(tenantID, e.Environment().ResourceManagerScope)
+	if err != nil {
+		log.Error("Unable to create FP Authorizer for metering monitoring.", err)
+		emitter.EmitGauge(MetricFailedMonitorCreation, int64(1), dims)
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	return newMonitor(log, doc, emitter, dims, wg,
+		compute.NewVirtualMachinesClient(e.Environment(), subscriptionID, authorizer),
+		dbBilling,
+	)
+}
+
+func newMonitor(log *logrus.Entry, doc *api.OpenShiftClusterDocument, emitter metrics.Emitter, dims map[string]string, wg *sync.WaitGroup, virtualMachines compute.VirtualMachinesClient, dbBilling database.Billing) *MeteringMonitor {
+	return &MeteringMonitor{
+		log:     log,
+		emitter: emitter,
+		doc:     doc,
+
+		wg: wg,
+
+		virtualMachines: virtualMachines,
+		dbBilling:       dbBilling,
+		dims:            dims,
+
+		now: time.Now,
+	}
+}
+
+// Monitor records the worker cores of the cluster for the current hour.  A
+// later sample in the same hour replaces an earlier one.
+func (mon *MeteringMonitor) Monitor(ctx context.Context) (errs []error) {
+	defer mon.wg.Done()
+
+	cores, err := mon.workerCores(ctx)
+	if err != nil {
+		mon.log.Error(err)
+		return []error{err}
+	}
+
+	emitter.EmitGauge(mon.emitter, MetricWorkerCores, int64(cores), mon.dims, nil)
+
+	hour := int(mon.now().Truncate(time.Hour).Unix())
+
+	_, err = mon.dbBilling.RecordWorkerCores(ctx, mon.doc.ID, hour, cores)
+	if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		return nil
+	}
+	if err != nil {
+		mon.log.Error(err)
+		return []error{err}
+	}
+
+	return nil
+}
+
+// workerCores returns the number of cores of the billable VMs of the cluster,
+// i.e. all of its VMs except for the masters, the bootstrap node and the
+// infra nodes.  VMs of a size which is not a supported worker size are not
+// counted.
+func (mon *MeteringMonitor) workerCores(ctx context.Context) (int, error) {
+	resourceGroup := stringutils.LastTokenByte(mon.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	vms, err := mon.virtualMachines.List(ctx, resourceGroup)
+	if err != nil {
+		return 0, err
+	}
+
+	supported := validate.SupportedVMSizesByRole(validate.VMRoleWorker)
+
+	var cores int
+	for _, vm := range vms {
+		if vm.Name == nil || vm.VirtualMachineProperties == nil || vm.HardwareProfile == nil {
+			continue
+		}
+
+		if strings.Contains(*vm.Name, "-master-") ||
+			strings.Contains(*vm.Name, "-bootstrap") ||
+			strings.Contains(*vm.Name, "-infra-") {
+			continue
+		}
+
+		vmSize := api.VMSize(vm.HardwareProfile.VMSize)
+
+		s, ok := supported[vmSize]
+		if !ok {
+			mon.log.Warnf("VM %s has unknown worker size %s", *vm.Name, vmSize)
+			emitter.EmitGauge(mon.emitter, MetricUnknownVMSize, 1, mon.dims, map[string]string{
+				"vmSize": string(vmSize),
+			})
+			continue
+		}
+
+		cores += s.CoreCount
+	}
+
+	return cores, nil
+}
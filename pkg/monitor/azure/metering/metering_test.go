@@ -0,0 +1,177 @@
+package metering
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/monitor/dimension"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+
+	const docID = "00000000-0000-0000-0000-000000000000"
+	resourceGroup := "aro-cluster"
+
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	hour := int(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC).Unix())
+
+	doc := &api.OpenShiftClusterDocument{
+		ID: docID,
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Plan: &api.Plan{Name: "plan"},
+			Properties: api.OpenShiftClusterProperties{
+				ClusterProfile: api.ClusterProfile{
+					ResourceGroupID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/" + resourceGroup,
+				},
+			},
+		},
+	}
+	dims := map[string]string{
+		dimension.ResourceID: "resourceID",
+	}
+
+	vm := func(name string, size mgmtcompute.VirtualMachineSizeTypes) mgmtcompute.VirtualMachine {
+		return mgmtcompute.VirtualMachine{
+			Name: to.StringPtr(name),
+			VirtualMachineProperties: &mgmtcompute.VirtualMachineProperties{
+				HardwareProfile: &mgmtcompute.HardwareProfile{
+					VMSize: size,
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name      string
+		usage     []api.BillingUsage
+		vms       []mgmtcompute.VirtualMachine
+		listErr   error
+		mocks     func(*mock_metrics.MockEmitter)
+		wantUsage []api.BillingUsage
+		wantErrs  []error
+	}{
+		{
+			name: "worker cores are recorded for the hour",
+			vms: []mgmtcompute.VirtualMachine{
+				vm("infra-master-0", mgmtcompute.VirtualMachineSizeTypesStandardD8sV3),
+				vm("infra-bootstrap", mgmtcompute.VirtualMachineSizeTypesStandardD8sV3),
+				vm("infra-infra-eastus1-abcde", mgmtcompute.VirtualMachineSizeTypesStandardD8sV3),
+				vm("infra-worker-eastus1-abcde", mgmtcompute.VirtualMachineSizeTypesStandardD4sV3),
+				vm("infra-worker-eastus2-abcde", mgmtcompute.VirtualMachineSizeTypesStandardD4sV3),
+				vm("custom-gpu-abcde", mgmtcompute.VirtualMachineSizeTypesStandardD16sV3),
+				vm("infra-worker-eastus3-abcde", mgmtcompute.VirtualMachineSizeTypesStandardA1),
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge(MetricUnknownVMSize, int64(1), map[string]string{
+					dimension.ResourceID: "resourceID",
+					"vmSize":             "Standard_A1",
+				})
+				m.EXPECT().EmitGauge(MetricWorkerCores, int64(24), dims)
+			},
+			wantUsage: []api.BillingUsage{
+				{Hour: hour, WorkerCores: 24},
+			},
+		},
+		{
+			name: "a later sample replaces a pending hour",
+			usage: []api.BillingUsage{
+				{Hour: hour - 3600, WorkerCores: 8, State: api.BillingUsageStateReported},
+				{Hour: hour, WorkerCores: 8},
+			},
+			vms: []mgmtcompute.VirtualMachine{
+				vm("infra-worker-eastus1-abcde", mgmtcompute.VirtualMachineSizeTypesStandardD4sV3),
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge(MetricWorkerCores, int64(4), dims)
+			},
+			wantUsage: []api.BillingUsage{
+				{Hour: hour - 3600, WorkerCores: 8, State: api.BillingUsageStateReported},
+				{Hour: hour, WorkerCores: 4},
+			},
+		},
+		{
+			name: "a reported hour is not changed",
+			usage: []api.BillingUsage{
+				{Hour: hour, WorkerCores: 8, State: api.BillingUsageStateReported},
+			},
+			vms: []mgmtcompute.VirtualMachine{
+				vm("infra-worker-eastus1-abcde", mgmtcompute.VirtualMachineSizeTypesStandardD4sV3),
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge(MetricWorkerCores, int64(4), dims)
+			},
+			wantUsage: []api.BillingUsage{
+				{Hour: hour, WorkerCores: 8, State: api.BillingUsageStateReported},
+			},
+		},
+		{
+			name:     "nothing is recorded if the VMs can't be listed",
+			listErr:  errors.New("random error"),
+			mocks:    func(m *mock_metrics.MockEmitter) {},
+			wantErrs: []error{errors.New("random error")},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			virtualMachines := mock_compute.NewMockVirtualMachinesClient(controller)
+			virtualMachines.EXPECT().List(gomock.Any(), resourceGroup).Return(tt.vms, tt.listErr)
+
+			m := mock_metrics.NewMockEmitter(controller)
+			tt.mocks(m)
+
+			dbBilling, _ := testdatabase.NewFakeBilling()
+			fixture := testdatabase.NewFixture().WithBilling(dbBilling)
+			fixture.AddBillingDocuments(&api.BillingDocument{
+				ID: docID,
+				Billing: &api.Billing{
+					Marketplace: &api.BillingMarketplace{
+						PlanID: "plan",
+						Usage:  tt.usage,
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			mon := newMonitor(logrus.NewEntry(logrus.StandardLogger()), doc, m, dims, &wg, virtualMachines, dbBilling)
+			mon.now = func() time.Time { return now }
+
+			errs := mon.Monitor(ctx)
+			if !reflect.DeepEqual(errs, tt.wantErrs) {
+				t.Errorf("got errors %v, want %v", errs, tt.wantErrs)
+			}
+
+			billingDoc, err := dbBilling.Get(ctx, docID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(billingDoc.Billing.Marketplace.Usage, tt.wantUsage) {
+				t.Errorf("got usage %#v, want %#v", billingDoc.Billing.Marketplace.Usage, tt.wantUsage)
+			}
+		})
+	}
+}
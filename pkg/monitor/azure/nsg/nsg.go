@@ -68,17 +68,14 @@ func NewMonitor(log *logrus.Entry, oc *api.OpenShiftCluster, e env.Interface, su
 		return &monitoring.NoOpMonitor{Wg: wg}
 	}
 
-	token, err := e.FPNewClientCertificateCredential(tenantID)
+	token, err := e.FPNewTokenCredential(tenantID)
 	if err != nil {
 		log.Error("Unable to create FP Authorizer for NSG monitoring.", err)
 		emitter.EmitGauge(MetricFailedNSGMonitorCreation, int64(1), dims)
 		return &monitoring.NoOpMonitor{Wg: wg}
 	}
 
-	options := arm.ClientOptions{
-		ClientOptions: e.Environment().ClientCertificateCredentialOptions().ClientOptions,
-	}
-	client, err := armnetwork.NewSubnetsClient(subscriptionID, token, &options)
+	client, err := sdknetwork.NewSubnetsClient(subscriptionID, token, e.Environment().ArmClientOptions())
 	if err != nil {
 		log.Error("Unable to create the subnet client for NSG monitoring", err)
 		emitter.EmitGauge(MetricFailedNSGMonitorCreation, int64(1), dims)
@@ -0,0 +1,299 @@
+package resourcehealth
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	mgmtinsights "github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/monitor/dimension"
+	"github.com/Azure/ARO-RP/pkg/monitor/emitter"
+	"github.com/Azure/ARO-RP/pkg/monitor/monitoring"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/insights"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+const (
+	MetricFailedMonitorCreation       = "monitor.resourcehealth.failedmonitorcreation"
+	MetricVMPowerState                = "monitor.resourcehealth.vm.powerstate"
+	MetricLoadBalancerDipAvailability = "monitor.resourcehealth.loadbalancer.dipavailability"
+	MetricPLSConnections              = "monitor.resourcehealth.privatelinkservice.connections"
+)
+
+// dipAvailabilityWindow is how far back load balancer probe availability is
+// read.  Azure Monitor metrics lag by a few minutes.
+const dipAvailabilityWindow = 10 * time.Minute
+
+var _ monitoring.Monitor = (*ResourceHealthMonitor)(nil)
+
+// ResourceHealthMonitor emits the state of the Azure resources in the cluster
+// resource group.  It does not use the Kubernetes API, so the metrics are
+// still available when the API server is down.
+type ResourceHealthMonitor struct {
+	log     *logrus.Entry
+	emitter metrics.Emitter
+	oc      *api.OpenShiftCluster
+
+	wg *sync.WaitGroup
+
+	virtualMachines     compute.VirtualMachinesClient
+	metrics             insights.MetricsClient
+	privateLinkServices network.PrivateLinkServicesClient
+	dims                map[string]string
+
+	now func() time.Time
+}
+
+func NewMonitor(log *logrus.Entry, oc *api.OpenShiftCluster, e env.Interface, subscriptionID string, tenantID string, emitter metrics.Emitter, dims map[string]string, wg *sync.WaitGroup, trigger <-chan time.Time) monitoring.Monitor {
+	if oc == nil {
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	select {
+	case <-trigger:
+	default:
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	authorizer, err := e.FPAuthorizer(tenantID, e.Environment().ResourceManagerScope)
+	if err != nil {
+		log.Error("Unable to create FP Authorizer for resource health monitoring.", err)
+		emitter.EmitGauge(MetricFailedMonitorCreation, int64(1), dims)
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	return newMonitor(log, oc, emitter, dims, wg,
+		compute.NewVirtualMachinesClient(e.Environment(), subscriptionID, authorizer),
+		insights.NewMetricsClient(e.Environment(), subscriptionID, authorizer),
+		network.NewPrivateLinkServicesClient(e.Environment(), subscriptionID, authorizer),
+	)
+}
+
+func newMonitor(log *logrus.Entry, oc *api.OpenShiftCluster, emitter metrics.Emitter, dims map[string]string, wg *sync.WaitGroup, virtualMachines compute.VirtualMachinesClient, metricsClient insights.MetricsClient, privateLinkServices network.PrivateLinkServicesClient) *ResourceHealthMonitor {
+	return &ResourceHealthMonitor{
+		log:     log,
+		emitter: emitter,
+		oc:      oc,
+
+		wg: wg,
+
+		virtualMachines:     virtualMachines,
+		metrics:             metricsClient,
+		privateLinkServices: privateLinkServices,
+		dims:                dims,
+
+		now: time.Now,
+	}
+}
+
+// Monitor emits VM power and provisioning states, load balancer health probe
+// availability and private link service connection states
+func (r *ResourceHealthMonitor) Monitor(ctx context.Context) (errs []error) {
+	defer r.wg.Done()
+
+	resourceGroup := stringutils.LastTokenByte(r.oc.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	for _, f := range []func(context.Context, string) error{
+		r.emitVMPowerStates,
+		r.emitLoadBalancerDipAvailability,
+		r.emitPLSConnections,
+	} {
+		err := f(ctx, resourceGroup)
+		if err != nil {
+			r.log.Error(err)
+			errs = append(errs, err)
+			// keep going
+		}
+	}
+
+	return errs
+}
+
+// emitVMPowerStates emits the power and provisioning state of each VM in the
+// cluster resource group.  Listing with statusOnly returns the instance views
+// of every VM in the subscription in one paged call, rather than one call per
+// VM, but the list has to be filtered to the cluster resource group.
+func (r *ResourceHealthMonitor) emitVMPowerStates(ctx context.Context, resourceGroup string) error {
+	vms, err := r.virtualMachines.ListAll(ctx, "true")
+	if err != nil {
+		return err
+	}
+
+	for _, vm := range vms {
+		if vm.Name == nil || vm.ID == nil {
+			continue
+		}
+
+		resource, err := azure.ParseResourceID(*vm.ID)
+		if err != nil || !strings.EqualFold(resource.ResourceGroup, resourceGroup) {
+			continue
+		}
+
+		powerState, provisioningState := "Unknown", "Unknown"
+		if vm.VirtualMachineProperties != nil && vm.InstanceView != nil && vm.InstanceView.Statuses != nil {
+			for _, status := range *vm.InstanceView.Statuses {
+				if status.Code == nil {
+					continue
+				}
+
+				switch {
+				case strings.HasPrefix(*status.Code, "PowerState/"):
+					powerState = strings.TrimPrefix(*status.Code, "PowerState/")
+				case strings.HasPrefix(*status.Code, "ProvisioningState/"):
+					provisioningState = strings.TrimPrefix(*status.Code, "ProvisioningState/")
+				}
+			}
+		}
+
+		emitter.EmitGauge(r.emitter, MetricVMPowerState, 1, r.dims, map[string]string{
+			dimension.VM:                *vm.Name,
+			dimension.PowerState:        powerState,
+			dimension.ProvisioningState: provisioningState,
+		})
+	}
+
+	return nil
+}
+
+// emitLoadBalancerDipAvailability emits the health probe availability of each
+// backend of the cluster's load balancers, as a percentage, from the
+// DipAvailability Azure Monitor metric.  NRP itself does not expose live probe
+// results.
+func (r *ResourceHealthMonitor) emitLoadBalancerDipAvailability(ctx context.Context, resourceGroup string) error {
+	infraID := r.oc.Properties.InfraID
+	if infraID == "" {
+		infraID = "aro"
+	}
+
+	var lbNames []string
+	switch r.oc.Properties.ArchitectureVersion {
+	case api.ArchitectureVersionV1:
+		lbNames = []string{infraID + "-public-lb", infraID + "-internal-lb"}
+	case api.ArchitectureVersionV2:
+		lbNames = []string{infraID, infraID + "-internal"}
+	default:
+		return fmt.Errorf("unknown architecture version %d", r.oc.Properties.ArchitectureVersion)
+	}
+
+	now := r.now()
+	timespan := now.Add(-dipAvailabilityWindow).UTC().Format(time.RFC3339) + "/" + now.UTC().Format(time.RFC3339)
+
+	for _, lbName := range lbNames {
+		resp, err := r.metrics.List(ctx, r.oc.Properties.ClusterProfile.ResourceGroupID+"/providers/Microsoft.Network/loadBalancers/"+lbName,
+			timespan, to.StringPtr("PT1M"), "DipAvailability", "Average", nil, "",
+			"BackendIPAddress eq '*' and BackendPort eq '*'", mgmtinsights.Data, "Microsoft.Network/loadBalancers")
+		if azureerrors.IsNotFoundError(err) {
+			// e.g. private clusters with user defined routing have no
+			// public load balancer
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if resp.Value == nil {
+			continue
+		}
+
+		for _, metric := range *resp.Value {
+			if metric.Timeseries == nil {
+				continue
+			}
+
+			for _, ts := range *metric.Timeseries {
+				availability := latestAverage(ts)
+				if availability == nil {
+					continue
+				}
+
+				emitter.EmitGauge(r.emitter, MetricLoadBalancerDipAvailability, int64(math.Round(*availability)), r.dims, map[string]string{
+					dimension.LoadBalancer:     lbName,
+					dimension.BackendIPAddress: metadataValue(ts, "BackendIPAddress"),
+					dimension.BackendPort:      metadataValue(ts, "BackendPort"),
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// latestAverage returns the most recent average in the time series, or nil if
+// there is none
+func latestAverage(ts mgmtinsights.TimeSeriesElement) *float64 {
+	if ts.Data == nil {
+		return nil
+	}
+
+	for i := len(*ts.Data) - 1; i >= 0; i-- {
+		if (*ts.Data)[i].Average != nil {
+			return (*ts.Data)[i].Average
+		}
+	}
+
+	return nil
+}
+
+func metadataValue(ts mgmtinsights.TimeSeriesElement, name string) string {
+	if ts.Metadatavalues == nil {
+		return ""
+	}
+
+	for _, v := range *ts.Metadatavalues {
+		if v.Name != nil && v.Name.Value != nil && strings.EqualFold(*v.Name.Value, name) && v.Value != nil {
+			return *v.Value
+		}
+	}
+
+	return ""
+}
+
+func (r *ResourceHealthMonitor) emitPLSConnections(ctx context.Context, resourceGroup string) error {
+	plss, err := r.privateLinkServices.List(ctx, resourceGroup)
+	if err != nil {
+		return err
+	}
+
+	for _, pls := range plss {
+		if pls.Name == nil || pls.PrivateLinkServiceProperties == nil || pls.PrivateEndpointConnections == nil {
+			continue
+		}
+
+		m := map[string]int64{}
+		for _, conn := range *pls.PrivateEndpointConnections {
+			status := "Unknown"
+			if conn.PrivateEndpointConnectionProperties != nil &&
+				conn.PrivateLinkServiceConnectionState != nil &&
+				conn.PrivateLinkServiceConnectionState.Status != nil {
+				status = *conn.PrivateLinkServiceConnectionState.Status
+			}
+
+			m[status]++
+		}
+
+		for status, count := range m {
+			emitter.EmitGauge(r.emitter, MetricPLSConnections, count, r.dims, map[string]string{
+				dimension.PrivateLinkService: *pls.Name,
+				dimension.Status:             status,
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package resourcehealth
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	mgmtinsights "github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/monitor/dimension"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	mock_insights "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/insights"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+
+	resourceGroup := "aro-cluster"
+	oc := &api.OpenShiftCluster{
+		Properties: api.OpenShiftClusterProperties{
+			ArchitectureVersion: api.ArchitectureVersionV2,
+			InfraID:             "infra",
+			ClusterProfile: api.ClusterProfile{
+				ResourceGroupID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/" + resourceGroup,
+			},
+		},
+	}
+	dims := map[string]string{
+		dimension.ResourceID: "resourceID",
+	}
+	withDims := func(m map[string]string) map[string]string {
+		m[dimension.ResourceID] = "resourceID"
+		return m
+	}
+
+	for _, tt := range []struct {
+		name     string
+		mocks    func(*mock_compute.MockVirtualMachinesClient, *mock_insights.MockMetricsClient, *mock_network.MockPrivateLinkServicesClient, *mock_metrics.MockEmitter)
+		wantErrs int
+	}{
+		{
+			name: "all resources",
+			mocks: func(vms *mock_compute.MockVirtualMachinesClient, metrics *mock_insights.MockMetricsClient, plss *mock_network.MockPrivateLinkServicesClient, m *mock_metrics.MockEmitter) {
+				vms.EXPECT().ListAll(gomock.Any(), "true").Return([]mgmtcompute.VirtualMachine{
+					{
+						ID:   to.StringPtr("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/ARO-CLUSTER/providers/Microsoft.Compute/virtualMachines/infra-master-0"),
+						Name: to.StringPtr("infra-master-0"),
+						VirtualMachineProperties: &mgmtcompute.VirtualMachineProperties{
+							InstanceView: &mgmtcompute.VirtualMachineInstanceView{
+								Statuses: &[]mgmtcompute.InstanceViewStatus{
+									{Code: to.StringPtr("ProvisioningState/succeeded")},
+									{Code: to.StringPtr("PowerState/deallocated")},
+								},
+							},
+						},
+					},
+					{
+						ID:   to.StringPtr("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/other/providers/Microsoft.Compute/virtualMachines/customer-vm"),
+						Name: to.StringPtr("customer-vm"),
+					},
+				}, nil)
+				m.EXPECT().EmitGauge(MetricVMPowerState, int64(1), withDims(map[string]string{
+					dimension.VM:                "infra-master-0",
+					dimension.PowerState:        "deallocated",
+					dimension.ProvisioningState: "succeeded",
+				}))
+
+				metrics.EXPECT().List(gomock.Any(), oc.Properties.ClusterProfile.ResourceGroupID+"/providers/Microsoft.Network/loadBalancers/infra",
+					"2026-01-01T11:50:00Z/2026-01-01T12:00:00Z", to.StringPtr("PT1M"), "DipAvailability", "Average", nil, "",
+					"BackendIPAddress eq '*' and BackendPort eq '*'", mgmtinsights.Data, "Microsoft.Network/loadBalancers").
+					Return(mgmtinsights.Response{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					})
+				metrics.EXPECT().List(gomock.Any(), oc.Properties.ClusterProfile.ResourceGroupID+"/providers/Microsoft.Network/loadBalancers/infra-internal",
+					gomock.Any(), gomock.Any(), "DipAvailability", "Average", nil, "", gomock.Any(), mgmtinsights.Data, gomock.Any()).
+					Return(mgmtinsights.Response{
+						Value: &[]mgmtinsights.Metric{
+							{
+								Timeseries: &[]mgmtinsights.TimeSeriesElement{
+									{
+										Metadatavalues: &[]mgmtinsights.MetadataValue{
+											{Name: &mgmtinsights.LocalizableString{Value: to.StringPtr("backendipaddress")}, Value: to.StringPtr("10.0.0.4")},
+											{Name: &mgmtinsights.LocalizableString{Value: to.StringPtr("backendport")}, Value: to.StringPtr("6443")},
+										},
+										Data: &[]mgmtinsights.MetricValue{
+											{Average: to.Float64Ptr(100)},
+											{Average: to.Float64Ptr(66.6)},
+											{},
+										},
+									},
+									{
+										Metadatavalues: &[]mgmtinsights.MetadataValue{
+											{Name: &mgmtinsights.LocalizableString{Value: to.StringPtr("backendipaddress")}, Value: to.StringPtr("10.0.0.5")},
+											{Name: &mgmtinsights.LocalizableString{Value: to.StringPtr("backendport")}, Value: to.StringPtr("6443")},
+										},
+									},
+								},
+							},
+						},
+					}, nil)
+				m.EXPECT().EmitGauge(MetricLoadBalancerDipAvailability, int64(67), withDims(map[string]string{
+					dimension.LoadBalancer:     "infra-internal",
+					dimension.BackendIPAddress: "10.0.0.4",
+					dimension.BackendPort:      "6443",
+				}))
+
+				plss.EXPECT().List(gomock.Any(), resourceGroup).Return([]mgmtnetwork.PrivateLinkService{
+					{
+						Name: to.StringPtr("infra-pls"),
+						PrivateLinkServiceProperties: &mgmtnetwork.PrivateLinkServiceProperties{
+							PrivateEndpointConnections: &[]mgmtnetwork.PrivateEndpointConnection{
+								{
+									PrivateEndpointConnectionProperties: &mgmtnetwork.PrivateEndpointConnectionProperties{
+										PrivateLinkServiceConnectionState: &mgmtnetwork.PrivateLinkServiceConnectionState{
+											Status: to.StringPtr("Approved"),
+										},
+									},
+								},
+								{
+									PrivateEndpointConnectionProperties: &mgmtnetwork.PrivateEndpointConnectionProperties{
+										PrivateLinkServiceConnectionState: &mgmtnetwork.PrivateLinkServiceConnectionState{
+											Status: to.StringPtr("Approved"),
+										},
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+				m.EXPECT().EmitGauge(MetricPLSConnections, int64(2), withDims(map[string]string{
+					dimension.PrivateLinkService: "infra-pls",
+					dimension.Status:             "Approved",
+				}))
+			},
+		},
+		{
+			name: "errors do not stop other checks",
+			mocks: func(vms *mock_compute.MockVirtualMachinesClient, metrics *mock_insights.MockMetricsClient, plss *mock_network.MockPrivateLinkServicesClient, m *mock_metrics.MockEmitter) {
+				vms.EXPECT().ListAll(gomock.Any(), "true").Return(nil, errors.New("vm error"))
+				metrics.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mgmtinsights.Response{}, errors.New("metrics error"))
+				plss.EXPECT().List(gomock.Any(), resourceGroup).Return(nil, nil)
+			},
+			wantErrs: 2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			vms := mock_compute.NewMockVirtualMachinesClient(controller)
+			metrics := mock_insights.NewMockMetricsClient(controller)
+			plss := mock_network.NewMockPrivateLinkServicesClient(controller)
+			m := mock_metrics.NewMockEmitter(controller)
+
+			tt.mocks(vms, metrics, plss, m)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			mon := newMonitor(logrus.NewEntry(logrus.StandardLogger()), oc, m, dims, &wg, vms, metrics, plss)
+			mon.now = func() time.Time { return time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) }
+
+			errs := mon.Monitor(ctx)
+			if len(errs) != tt.wantErrs {
+				t.Error(errs)
+			}
+		})
+	}
+}
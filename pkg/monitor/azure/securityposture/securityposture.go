@@ -0,0 +1,298 @@
+package securityposture
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/monitor/dimension"
+	"github.com/Azure/ARO-RP/pkg/monitor/emitter"
+	"github.com/Azure/ARO-RP/pkg/monitor/monitoring"
+	sdknetwork "github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armnetwork"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armsecurity"
+	"github.com/Azure/ARO-RP/pkg/util/feature"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+const (
+	MetricFailedMonitorCreation = "monitor.securityposture.failedmonitorcreation"
+	MetricAssessment            = "monitor.securityposture.assessment"
+)
+
+// Assessment names.  Defender for Cloud identifies an assessment by its name,
+// which must be a GUID, so these must never change.
+const (
+	AssessmentOutdatedVersion = "b9d8a4a1-6d2e-4b6c-8f3a-0c5e7f1d2a34"
+	AssessmentMissingNSG      = "3f2c9e7b-8a1d-4e5f-b6c7-9d0a1b2c3e45"
+	AssessmentPublicAPIServer = "e4a7c2d9-5b3f-4a8e-9c1d-6f2b0a7e8d56"
+)
+
+var _ monitoring.Monitor = (*SecurityPostureMonitor)(nil)
+
+// SecurityPostureMonitor exports the security-relevant findings about a
+// cluster to Microsoft Defender for Cloud as assessments of the cluster
+// resource, so that they appear in the customer's security posture.  It only
+// runs for subscriptions registered for the SecurityPostureExport feature.
+type SecurityPostureMonitor struct {
+	log     *logrus.Entry
+	emitter metrics.Emitter
+	oc      *api.OpenShiftCluster
+
+	wg *sync.WaitGroup
+
+	// minimumVersion, if set, is the oldest OpenShift version which is not
+	// reported as outdated
+	minimumVersion *version.Version
+
+	assessments armsecurity.AssessmentsClient
+	subnets     sdknetwork.SubnetsClient
+	dims        map[string]string
+}
+
+type assessment struct {
+	name     string
+	key      string
+	metadata armsecurity.AssessmentMetadata
+
+	// assess returns the status of the assessment, or nil if it cannot be
+	// assessed and should not be exported
+	assess func(context.Context) (*armsecurity.AssessmentStatus, error)
+}
+
+func NewMonitor(log *logrus.Entry, oc *api.OpenShiftCluster, e env.Interface, sub *api.SubscriptionDocument, minimumVersion *version.Version, emitter metrics.Emitter, dims map[string]string, wg *sync.WaitGroup, trigger <-chan time.Time) monitoring.Monitor {
+	if oc == nil || sub == nil || sub.Subscription == nil {
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	if !feature.IsRegisteredForFeature(sub.Subscription.Properties, api.FeatureFlagSecurityPostureExport) {
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	select {
+	case <-trigger:
+	default:
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	token, err := e.FPNewTokenCredential(sub.Subscription.Properties.TenantID)
+	if err != nil {
+		log.Error("Unable to create FP Authorizer for security posture monitoring.", err)
+		emitter.EmitGauge(MetricFailedMonitorCreation, int64(1), dims)
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	assessments, err := armsecurity.NewAssessmentsClient(token, e.Environment().ArmClientOptions())
+	if err != nil {
+		log.Error("Unable to create the assessments client for security posture monitoring", err)
+		emitter.EmitGauge(MetricFailedMonitorCreation, int64(1), dims)
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	subnets, err := sdknetwork.NewSubnetsClient(sub.ID, token, e.Environment().ArmClientOptions())
+	if err != nil {
+		log.Error("Unable to create the subnet client for security posture monitoring", err)
+		emitter.EmitGauge(MetricFailedMonitorCreation, int64(1), dims)
+		return &monitoring.NoOpMonitor{Wg: wg}
+	}
+
+	return newMonitor(log, oc, emitter, dims, wg, minimumVersion, assessments, subnets)
+}
+
+func newMonitor(log *logrus.Entry, oc *api.OpenShiftCluster, emitter metrics.Emitter, dims map[string]string, wg *sync.WaitGroup, minimumVersion *version.Version, assessments armsecurity.AssessmentsClient, subnets sdknetwork.SubnetsClient) *SecurityPostureMonitor {
+	return &SecurityPostureMonitor{
+		log:     log,
+		emitter: emitter,
+		oc:      oc,
+
+		wg: wg,
+
+		minimumVersion: minimumVersion,
+
+		assessments: assessments,
+		subnets:     subnets,
+		dims:        dims,
+	}
+}
+
+func (s *SecurityPostureMonitor) assessmentList() []assessment {
+	return []assessment{
+		{
+			name: AssessmentOutdatedVersion,
+			key:  "outdatedVersion",
+			metadata: armsecurity.AssessmentMetadata{
+				DisplayName:            "Azure Red Hat OpenShift clusters should run a supported OpenShift version",
+				Description:            "Clusters running an OpenShift version older than the oldest supported version no longer receive security fixes.",
+				RemediationDescription: "Upgrade the cluster to a supported OpenShift version.",
+				Severity:               armsecurity.SeverityHigh,
+			},
+			assess: s.assessOutdatedVersion,
+		},
+		{
+			name: AssessmentMissingNSG,
+			key:  "missingNSG",
+			metadata: armsecurity.AssessmentMetadata{
+				DisplayName:            "Azure Red Hat OpenShift cluster subnets should be associated with a network security group",
+				Description:            "Subnets of the cluster without a network security group do not restrict the traffic which reaches the cluster's nodes.",
+				RemediationDescription: "Associate the cluster's network security group with each of its master and worker subnets.",
+				Severity:               armsecurity.SeverityMedium,
+			},
+			assess: s.assessMissingNSG,
+		},
+		{
+			name: AssessmentPublicAPIServer,
+			key:  "publicAPIServer",
+			metadata: armsecurity.AssessmentMetadata{
+				DisplayName:            "Azure Red Hat OpenShift cluster API servers should not be publicly accessible",
+				Description:            "The API server of the cluster is reachable from the internet.",
+				RemediationDescription: "Create clusters with private API server visibility where the API server does not need to be reached from outside the virtual network.",
+				Severity:               armsecurity.SeverityLow,
+			},
+			assess: s.assessPublicAPIServer,
+		},
+	}
+}
+
+// Monitor assesses the cluster and exports each result to Defender for Cloud
+func (s *SecurityPostureMonitor) Monitor(ctx context.Context) (errs []error) {
+	defer s.wg.Done()
+
+	for _, a := range s.assessmentList() {
+		err := s.export(ctx, a)
+		if err != nil {
+			s.log.Error(err)
+			errs = append(errs, err)
+			// keep going
+		}
+	}
+
+	return errs
+}
+
+func (s *SecurityPostureMonitor) export(ctx context.Context, a assessment) error {
+	status, err := a.assess(ctx)
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		return nil
+	}
+
+	metadata := a.metadata
+	metadata.AssessmentType = "CustomerManaged"
+
+	err = s.assessments.CreateOrUpdate(ctx, s.oc.ID, a.name, &armsecurity.Assessment{
+		Properties: armsecurity.AssessmentProperties{
+			ResourceDetails: armsecurity.ResourceDetails{
+				Source: "Azure",
+				ID:     s.oc.ID,
+			},
+			Status:   *status,
+			Metadata: &metadata,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", a.key, err)
+	}
+
+	emitter.EmitGauge(s.emitter, MetricAssessment, 1, s.dims, map[string]string{
+		dimension.Assessment: a.key,
+		dimension.Status:     string(status.Code),
+	})
+
+	return nil
+}
+
+// assessOutdatedVersion compares the cluster version with the minimum
+// version.  It is not assessed if no minimum version is configured.
+func (s *SecurityPostureMonitor) assessOutdatedVersion(ctx context.Context) (*armsecurity.AssessmentStatus, error) {
+	if s.minimumVersion == nil {
+		return nil, nil
+	}
+
+	v, err := version.ParseVersion(s.oc.Properties.ClusterProfile.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Lt(s.minimumVersion) {
+		return &armsecurity.AssessmentStatus{
+			Code:        armsecurity.AssessmentStatusCodeUnhealthy,
+			Cause:       "OutdatedVersion",
+			Description: fmt.Sprintf("The cluster runs OpenShift %s, which is older than the minimum supported version %s.", v, s.minimumVersion),
+		}, nil
+	}
+
+	return &armsecurity.AssessmentStatus{Code: armsecurity.AssessmentStatusCodeHealthy}, nil
+}
+
+// assessMissingNSG checks that each master and worker subnet is associated
+// with a network security group.  Unlike drift monitoring, any NSG will do:
+// the finding is the absence of one.
+func (s *SecurityPostureMonitor) assessMissingNSG(ctx context.Context) (*armsecurity.AssessmentStatus, error) {
+	subnetIDs := map[string]string{
+		strings.ToLower(s.oc.Properties.MasterProfile.SubnetID): s.oc.Properties.MasterProfile.SubnetID,
+	}
+	workerProfiles, _ := api.GetEnrichedWorkerProfiles(s.oc.Properties)
+	for _, wp := range workerProfiles {
+		if wp.SubnetID != "" {
+			subnetIDs[strings.ToLower(wp.SubnetID)] = wp.SubnetID
+		}
+	}
+
+	keys := make([]string, 0, len(subnetIDs))
+	for k := range subnetIDs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var missing []string
+	for _, k := range keys {
+		r, err := arm.ParseResourceID(subnetIDs[k])
+		if err != nil {
+			return nil, err
+		}
+
+		subnet, err := s.subnets.Get(ctx, r.ResourceGroupName, r.Parent.Name, r.Name, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if subnet.Properties == nil || subnet.Properties.NetworkSecurityGroup == nil {
+			missing = append(missing, r.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return &armsecurity.AssessmentStatus{
+			Code:        armsecurity.AssessmentStatusCodeUnhealthy,
+			Cause:       "MissingNetworkSecurityGroup",
+			Description: fmt.Sprintf("The subnets %s are not associated with a network security group.", strings.Join(missing, ", ")),
+		}, nil
+	}
+
+	return &armsecurity.AssessmentStatus{Code: armsecurity.AssessmentStatusCodeHealthy}, nil
+}
+
+func (s *SecurityPostureMonitor) assessPublicAPIServer(ctx context.Context) (*armsecurity.AssessmentStatus, error) {
+	if s.oc.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
+		return &armsecurity.AssessmentStatus{
+			Code:        armsecurity.AssessmentStatusCodeUnhealthy,
+			Cause:       "PublicAPIServer",
+			Description: "The API server visibility of the cluster is Public.",
+		}, nil
+	}
+
+	return &armsecurity.AssessmentStatus{Code: armsecurity.AssessmentStatusCodeHealthy}, nil
+}
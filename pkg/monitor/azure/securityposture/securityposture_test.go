@@ -0,0 +1,191 @@
+package securityposture
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/monitor/dimension"
+	"github.com/Azure/ARO-RP/pkg/monitor/monitoring"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armsecurity"
+	mock_armnetwork "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/armnetwork"
+	mock_armsecurity "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/armsecurity"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+	vnetID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet"
+	nsgID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/aro-cluster/providers/Microsoft.Network/networkSecurityGroups/infra-nsg"
+
+	dims := map[string]string{
+		dimension.ResourceID: "resourceID",
+	}
+	withDims := func(m map[string]string) map[string]string {
+		m[dimension.ResourceID] = "resourceID"
+		return m
+	}
+
+	subnet := func(nsgID string) armnetwork.SubnetsClientGetResponse {
+		s := armnetwork.SubnetsClientGetResponse{
+			Subnet: armnetwork.Subnet{
+				Properties: &armnetwork.SubnetPropertiesFormat{},
+			},
+		}
+		if nsgID != "" {
+			s.Properties.NetworkSecurityGroup = &armnetwork.SecurityGroup{ID: &nsgID}
+		}
+		return s
+	}
+
+	expectExport := func(assessments *mock_armsecurity.MockAssessmentsClient, m *mock_metrics.MockEmitter, name, key string, status armsecurity.AssessmentStatus) {
+		assessments.EXPECT().
+			CreateOrUpdate(gomock.Any(), resourceID, name, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, resourceID, name string, assessment *armsecurity.Assessment) error {
+				if assessment.Properties.Status != status {
+					t.Errorf("%s: %#v", key, assessment.Properties.Status)
+				}
+				if assessment.Properties.ResourceDetails.ID != resourceID ||
+					assessment.Properties.Metadata == nil ||
+					assessment.Properties.Metadata.AssessmentType != "CustomerManaged" {
+					t.Errorf("%s: %#v", key, assessment.Properties)
+				}
+				return nil
+			})
+		m.EXPECT().EmitGauge(MetricAssessment, int64(1), withDims(map[string]string{
+			dimension.Assessment: key,
+			dimension.Status:     string(status.Code),
+		}))
+	}
+
+	for _, tt := range []struct {
+		name           string
+		version        string
+		visibility     api.Visibility
+		minimumVersion *version.Version
+		mocks          func(*mock_armsecurity.MockAssessmentsClient, *mock_armnetwork.MockSubnetsClient, *mock_metrics.MockEmitter)
+		wantErrs       int
+	}{
+		{
+			name:           "healthy",
+			version:        "4.14.16",
+			visibility:     api.VisibilityPrivate,
+			minimumVersion: version.NewVersion(4, 12),
+			mocks: func(assessments *mock_armsecurity.MockAssessmentsClient, subnets *mock_armnetwork.MockSubnetsClient, m *mock_metrics.MockEmitter) {
+				subnets.EXPECT().Get(gomock.Any(), "vnet", "vnet", "master", nil).Return(subnet(nsgID), nil)
+				subnets.EXPECT().Get(gomock.Any(), "vnet", "vnet", "worker", nil).Return(subnet(nsgID), nil)
+
+				expectExport(assessments, m, AssessmentOutdatedVersion, "outdatedVersion", armsecurity.AssessmentStatus{Code: armsecurity.AssessmentStatusCodeHealthy})
+				expectExport(assessments, m, AssessmentMissingNSG, "missingNSG", armsecurity.AssessmentStatus{Code: armsecurity.AssessmentStatusCodeHealthy})
+				expectExport(assessments, m, AssessmentPublicAPIServer, "publicAPIServer", armsecurity.AssessmentStatus{Code: armsecurity.AssessmentStatusCodeHealthy})
+			},
+		},
+		{
+			name:           "unhealthy",
+			version:        "4.11.44",
+			visibility:     api.VisibilityPublic,
+			minimumVersion: version.NewVersion(4, 12),
+			mocks: func(assessments *mock_armsecurity.MockAssessmentsClient, subnets *mock_armnetwork.MockSubnetsClient, m *mock_metrics.MockEmitter) {
+				subnets.EXPECT().Get(gomock.Any(), "vnet", "vnet", "master", nil).Return(subnet(nsgID), nil)
+				subnets.EXPECT().Get(gomock.Any(), "vnet", "vnet", "worker", nil).Return(subnet(""), nil)
+
+				expectExport(assessments, m, AssessmentOutdatedVersion, "outdatedVersion", armsecurity.AssessmentStatus{
+					Code:        armsecurity.AssessmentStatusCodeUnhealthy,
+					Cause:       "OutdatedVersion",
+					Description: "The cluster runs OpenShift 4.11.44, which is older than the minimum supported version 4.12.0.",
+				})
+				expectExport(assessments, m, AssessmentMissingNSG, "missingNSG", armsecurity.AssessmentStatus{
+					Code:        armsecurity.AssessmentStatusCodeUnhealthy,
+					Cause:       "MissingNetworkSecurityGroup",
+					Description: "The subnets worker are not associated with a network security group.",
+				})
+				expectExport(assessments, m, AssessmentPublicAPIServer, "publicAPIServer", armsecurity.AssessmentStatus{
+					Code:        armsecurity.AssessmentStatusCodeUnhealthy,
+					Cause:       "PublicAPIServer",
+					Description: "The API server visibility of the cluster is Public.",
+				})
+			},
+		},
+		{
+			name:       "no minimum version and errors do not stop other assessments",
+			version:    "4.14.16",
+			visibility: api.VisibilityPrivate,
+			mocks: func(assessments *mock_armsecurity.MockAssessmentsClient, subnets *mock_armnetwork.MockSubnetsClient, m *mock_metrics.MockEmitter) {
+				subnets.EXPECT().Get(gomock.Any(), "vnet", "vnet", "master", nil).Return(armnetwork.SubnetsClientGetResponse{}, errors.New("subnet error"))
+
+				assessments.EXPECT().
+					CreateOrUpdate(gomock.Any(), resourceID, AssessmentPublicAPIServer, gomock.Any()).
+					Return(errors.New("assessment error"))
+			},
+			wantErrs: 2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			assessments := mock_armsecurity.NewMockAssessmentsClient(controller)
+			subnets := mock_armnetwork.NewMockSubnetsClient(controller)
+			m := mock_metrics.NewMockEmitter(controller)
+
+			tt.mocks(assessments, subnets, m)
+
+			oc := &api.OpenShiftCluster{
+				ID: resourceID,
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Version: tt.version,
+					},
+					APIServerProfile: api.APIServerProfile{
+						Visibility: tt.visibility,
+					},
+					MasterProfile: api.MasterProfile{
+						SubnetID: vnetID + "/subnets/master",
+					},
+					WorkerProfiles: []api.WorkerProfile{
+						{
+							SubnetID: vnetID + "/subnets/worker",
+						},
+					},
+				},
+			}
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			mon := newMonitor(logrus.NewEntry(logrus.StandardLogger()), oc, m, dims, &wg, tt.minimumVersion, assessments, subnets)
+
+			errs := mon.Monitor(ctx)
+			if len(errs) != tt.wantErrs {
+				t.Error(errs)
+			}
+		})
+	}
+}
+
+func TestNewMonitorRequiresFeature(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	mon := NewMonitor(logrus.NewEntry(logrus.StandardLogger()), &api.OpenShiftCluster{}, nil, &api.SubscriptionDocument{
+		Subscription: &api.Subscription{
+			Properties: &api.SubscriptionProperties{},
+		},
+	}, nil, nil, nil, &wg, nil)
+
+	if _, ok := mon.(*monitoring.NoOpMonitor); !ok {
+		t.Error(mon)
+	}
+}
@@ -0,0 +1,192 @@
+package canary
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	redhatopenshift20231122 "github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/redhatopenshift/2023-11-22/redhatopenshift"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const (
+	leaseInterval = time.Minute
+	leaseID       = "canary"
+)
+
+// Canary periodically exercises the public RP API against a designated probe
+// cluster, going through ARM just like a customer would.  This detects
+// regressions in the frontend, its DNS records and the private link service
+// which ARM reaches us through, before customers report them.
+type Canary struct {
+	log        *logrus.Entry
+	m          metrics.Emitter
+	dbMonitors database.Monitors
+
+	client        redhatopenshift20231122.OpenShiftClustersClient
+	resourceID    string
+	resourceGroup string
+	resourceName  string
+
+	interval time.Duration
+}
+
+type operation struct {
+	name string
+	call func(context.Context) error
+}
+
+// New returns a Canary which probes the cluster with the given resource ID.
+// Every monitor replica runs a Canary; dbMonitors is used to take a lease so
+// that the cluster is probed by only one of them each interval.
+func New(log *logrus.Entry, m metrics.Emitter, dbMonitors database.Monitors, environment *azureclient.AROEnvironment, authorizer autorest.Authorizer, resourceID string, interval time.Duration) (*Canary, error) {
+	r, err := azure.ParseResourceID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Canary{
+		log:        log,
+		m:          m,
+		dbMonitors: dbMonitors,
+
+		client:        redhatopenshift20231122.NewOpenShiftClustersClient(environment, r.SubscriptionID, authorizer),
+		resourceID:    resourceID,
+		resourceGroup: r.ResourceGroup,
+		resourceName:  r.ResourceName,
+
+		interval: interval,
+	}, nil
+}
+
+// Run tries to take the canary lease every leaseInterval until ctx is
+// cancelled.  The lease expires after interval, so the cluster is probed
+// about once per interval whichever replica holds it.
+func (c *Canary) Run(ctx context.Context) {
+	defer recover.Panic(c.log)
+
+	t := time.NewTicker(leaseInterval)
+	defer t.Stop()
+
+	for {
+		err := c.runOnce(ctx)
+		if err != nil {
+			c.log.Error(err)
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Canary) runOnce(ctx context.Context) error {
+	ok, err := c.dbMonitors.AcquireLease(ctx, leaseID, c.interval)
+	if err != nil || !ok {
+		return err
+	}
+
+	c.probe(ctx)
+
+	return nil
+}
+
+func (c *Canary) operations() []operation {
+	return []operation{
+		{
+			name: "list",
+			call: func(ctx context.Context) error {
+				clusters, err := c.client.ListByResourceGroup(ctx, c.resourceGroup)
+				if err != nil {
+					return err
+				}
+
+				for _, cluster := range clusters {
+					if cluster.ID != nil && strings.EqualFold(*cluster.ID, c.resourceID) {
+						return nil
+					}
+				}
+
+				return fmt.Errorf("cluster %s not found in list", c.resourceID)
+			},
+		},
+		{
+			name: "get",
+			call: func(ctx context.Context) error {
+				_, err := c.client.Get(ctx, c.resourceGroup, c.resourceName)
+				return err
+			},
+		},
+		{
+			name: "listCredentials",
+			call: func(ctx context.Context) error {
+				creds, err := c.client.ListCredentials(ctx, c.resourceGroup, c.resourceName)
+				if err != nil {
+					return err
+				}
+
+				if creds.KubeadminPassword == nil || *creds.KubeadminPassword == "" {
+					return fmt.Errorf("empty credentials returned")
+				}
+
+				return nil
+			},
+		},
+	}
+}
+
+// probe runs each operation against the probe cluster in turn, emitting its
+// result and latency
+func (c *Canary) probe(ctx context.Context) {
+	for _, op := range c.operations() {
+		stop := metrics.StartTimer(c.m, "canary.duration")
+
+		err := op.call(ctx)
+
+		result := "success"
+		if err != nil {
+			result = "failure"
+			c.log.Warnf("canary %s: %s", op.name, err)
+		}
+
+		dims := map[string]string{
+			"operation": op.name,
+			"result":    result,
+			"code":      strconv.Itoa(statusCode(err)),
+		}
+
+		stop(dims)
+		c.m.EmitGauge("canary.count", 1, dims)
+	}
+}
+
+// statusCode returns the HTTP status code of the response which produced err,
+// or 0 if there was no response
+func statusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	if detailedErr, ok := err.(autorest.DetailedError); ok {
+		if code, ok := detailedErr.StatusCode.(int); ok {
+			return code
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,152 @@
+package canary
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+
+	mgmtredhatopenshift20231122 "github.com/Azure/ARO-RP/pkg/client/services/redhatopenshift/mgmt/2023-11-22/redhatopenshift"
+	"github.com/Azure/ARO-RP/pkg/database"
+	redhatopenshift20231122 "github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/redhatopenshift/2023-11-22/redhatopenshift"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+const resourceID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/canary/providers/Microsoft.RedHatOpenShift/openShiftClusters/probe"
+
+type fakeClient struct {
+	redhatopenshift20231122.OpenShiftClustersClient
+
+	clusters []mgmtredhatopenshift20231122.OpenShiftCluster
+	getErr   error
+	password string
+}
+
+func (c *fakeClient) ListByResourceGroup(ctx context.Context, resourceGroupName string) ([]mgmtredhatopenshift20231122.OpenShiftCluster, error) {
+	return c.clusters, nil
+}
+
+func (c *fakeClient) Get(ctx context.Context, resourceGroupName string, resourceName string) (mgmtredhatopenshift20231122.OpenShiftCluster, error) {
+	return mgmtredhatopenshift20231122.OpenShiftCluster{}, c.getErr
+}
+
+func (c *fakeClient) ListCredentials(ctx context.Context, resourceGroupName string, resourceName string) (mgmtredhatopenshift20231122.OpenShiftClusterCredentials, error) {
+	return mgmtredhatopenshift20231122.OpenShiftClusterCredentials{KubeadminPassword: &c.password}, nil
+}
+
+func TestProbe(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		client *fakeClient
+		want   map[string][2]string
+	}{
+		{
+			name: "all operations succeed",
+			client: &fakeClient{
+				clusters: []mgmtredhatopenshift20231122.OpenShiftCluster{{ID: to.StringPtr(resourceID)}},
+				password: "password",
+			},
+			want: map[string][2]string{
+				"list":            {"success", "200"},
+				"get":             {"success", "200"},
+				"listCredentials": {"success", "200"},
+			},
+		},
+		{
+			name: "operations fail",
+			client: &fakeClient{
+				getErr: autorest.DetailedError{StatusCode: http.StatusServiceUnavailable},
+			},
+			want: map[string][2]string{
+				"list":            {"failure", "0"},
+				"get":             {"failure", "503"},
+				"listCredentials": {"failure", "0"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+
+			c := &Canary{
+				log:           utillog.GetLogger(),
+				m:             m,
+				client:        tt.client,
+				resourceID:    resourceID,
+				resourceGroup: "canary",
+				resourceName:  "probe",
+			}
+
+			for operation, want := range tt.want {
+				dims := map[string]string{
+					"operation": operation,
+					"result":    want[0],
+					"code":      want[1],
+				}
+				m.EXPECT().EmitHistogram("canary.duration", gomock.Any(), dims)
+				m.EXPECT().EmitGauge("canary.count", int64(1), dims)
+			}
+
+			c.probe(context.Background())
+		})
+	}
+}
+
+func TestRunOnce(t *testing.T) {
+	ctx := context.Background()
+	log := utillog.GetLogger()
+
+	s, err := testdatabase.NewServer(log, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts, dbc := s.NewTLSServer()
+	defer ts.Close()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+
+	// only the replica which takes the lease probes the cluster
+	m.EXPECT().EmitHistogram("canary.duration", gomock.Any(), gomock.Any()).Times(3)
+	m.EXPECT().EmitGauge("canary.count", int64(1), gomock.Any()).Times(3)
+
+	for i := 0; i < 2; i++ {
+		dbMonitors, err := database.NewMonitors(ctx, dbc, "ARO")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c := &Canary{
+			log:        log,
+			m:          m,
+			dbMonitors: dbMonitors,
+			client: &fakeClient{
+				clusters: []mgmtredhatopenshift20231122.OpenShiftCluster{{ID: to.StringPtr(resourceID)}},
+				password: "password",
+			},
+			resourceID:    resourceID,
+			resourceGroup: "canary",
+			resourceName:  "probe",
+			interval:      5 * time.Minute,
+		}
+
+		err = c.runOnce(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
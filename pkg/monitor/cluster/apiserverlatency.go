@@ -0,0 +1,158 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// apiServerProbeRounds is the number of times each probe request is made per
+// run
+var apiServerProbeRounds = 3
+
+// apiServerProbes returns the standard set of read requests used to measure
+// API server latency over the cluster's private endpoint.  They are cheap,
+// and cover both core resources and OpenShift custom resources.
+func (mon *Monitor) apiServerProbes() map[string]func(context.Context) error {
+	return map[string]func(context.Context) error{
+		"namespaces": func(ctx context.Context) error {
+			_, err := mon.cli.CoreV1().Namespaces().List(ctx, metav1.ListOptions{Limit: 1})
+			return err
+		},
+		"nodes": func(ctx context.Context) error {
+			_, err := mon.cli.CoreV1().Nodes().List(ctx, metav1.ListOptions{Limit: 1})
+			return err
+		},
+		"clusterversion": func(ctx context.Context) error {
+			_, err := mon.configcli.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+			return err
+		},
+	}
+}
+
+// publicAPIServerProbes returns a request against the public API server
+// endpoint, dialled directly rather than through the private endpoint, as a
+// customer outside the cluster's virtual network would reach it.  /readyz
+// can be read without credentials.
+func (mon *Monitor) publicAPIServerProbes() (map[string]func(context.Context) error, error) {
+	config := rest.CopyConfig(mon.restconfig)
+	config.Dial = nil
+
+	tlsConfig, err := rest.TLSConfigFor(config)
+	if err != nil {
+		return nil, err
+	}
+
+	hc := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+			// don't leave a connection open to every public cluster between
+			// runs
+			DisableKeepAlives: true,
+		},
+	}
+
+	return map[string]func(context.Context) error{
+		"readyz": func(ctx context.Context) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.Host+"/readyz", nil)
+			if err != nil {
+				return err
+			}
+
+			resp, err := hc.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+// emitAPIServerLatency times the probe requests against the API server and
+// emits the duration of every request in milliseconds, so that percentiles
+// are computed over all observations rather than a single run, and the
+// percentage of requests which failed.  Requests are made over the private
+// endpoint and, for public clusters, the public endpoint, distinguished by the
+// endpoint dimension.  Comparing these across clusters lets us spot
+// region-wide API degradation.
+func (mon *Monitor) emitAPIServerLatency(ctx context.Context) error {
+	err := mon.probeAPIServer(ctx, "private", mon.apiServerProbes())
+	if err != nil {
+		return err
+	}
+
+	if mon.oc.Properties.APIServerProfile.Visibility != api.VisibilityPublic {
+		return nil
+	}
+
+	probes, err := mon.publicAPIServerProbes()
+	if err != nil {
+		return err
+	}
+
+	return mon.probeAPIServer(ctx, "public", probes)
+}
+
+func (mon *Monitor) probeAPIServer(ctx context.Context, endpoint string, probes map[string]func(context.Context) error) error {
+	names := make([]string, 0, len(probes))
+	for name := range probes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	type observation struct {
+		probe    string
+		duration time.Duration
+	}
+
+	var observations []observation
+	var failures int
+
+	for i := 0; i < apiServerProbeRounds; i++ {
+		for _, name := range names {
+			start := time.Now()
+			err := probes[name](ctx)
+			observations = append(observations, observation{probe: name, duration: time.Since(start)})
+
+			if err != nil {
+				mon.log.Debugf("apiserver %s probe %s: %s", endpoint, name, err)
+				failures++
+			}
+		}
+	}
+
+	// requests cut short by our own deadline would skew the figures
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	for _, o := range observations {
+		mon.emitHistogram("apiserver.requests.duration", float64(o.duration)/float64(time.Millisecond), map[string]string{
+			"endpoint": endpoint,
+			"probe":    o.probe,
+		})
+	}
+
+	mon.emitGauge("apiserver.requests.errorrate", int64(failures*100/len(observations)), map[string]string{
+		"endpoint": endpoint,
+	})
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	configv1 "github.com/openshift/api/config/v1"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestEmitAPIServerLatency(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name                string
+		visibility          api.Visibility
+		failNodes           bool
+		publicStatusCode    int
+		wantErrorRate       int64
+		wantPublicErrorRate int64
+	}{
+		{
+			name:       "all requests succeed",
+			visibility: api.VisibilityPrivate,
+		},
+		{
+			name:          "one probe fails",
+			visibility:    api.VisibilityPrivate,
+			failNodes:     true,
+			wantErrorRate: 33,
+		},
+		{
+			name:             "public cluster",
+			visibility:       api.VisibilityPublic,
+			publicStatusCode: http.StatusOK,
+		},
+		{
+			name:                "public endpoint not ready",
+			visibility:          api.VisibilityPublic,
+			publicStatusCode:    http.StatusInternalServerError,
+			wantPublicErrorRate: 100,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/readyz" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.publicStatusCode)
+			}))
+			defer ts.Close()
+
+			cli := fake.NewSimpleClientset()
+			if tt.failNodes {
+				cli.PrependReactor("list", "nodes", func(action ktesting.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("timeout")
+				})
+			}
+
+			configcli := configfake.NewSimpleClientset(&configv1.ClusterVersion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "version",
+				},
+			})
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+
+			mon := &Monitor{
+				log: utillog.GetLogger(),
+				oc: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						APIServerProfile: api.APIServerProfile{
+							Visibility: tt.visibility,
+						},
+					},
+				},
+				restconfig: &rest.Config{
+					Host: ts.URL,
+					TLSClientConfig: rest.TLSClientConfig{
+						CAData: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}),
+					},
+				},
+				cli:       cli,
+				configcli: configcli,
+				m:         m,
+			}
+
+			for _, probe := range []string{"clusterversion", "namespaces", "nodes"} {
+				m.EXPECT().EmitHistogram("apiserver.requests.duration", gomock.Any(), map[string]string{
+					"endpoint": "private",
+					"probe":    probe,
+				}).Times(apiServerProbeRounds)
+			}
+			m.EXPECT().EmitGauge("apiserver.requests.errorrate", tt.wantErrorRate, map[string]string{
+				"endpoint": "private",
+			})
+
+			if tt.visibility == api.VisibilityPublic {
+				m.EXPECT().EmitHistogram("apiserver.requests.duration", gomock.Any(), map[string]string{
+					"endpoint": "public",
+					"probe":    "readyz",
+				}).Times(apiServerProbeRounds)
+				m.EXPECT().EmitGauge("apiserver.requests.errorrate", tt.wantPublicErrorRate, map[string]string{
+					"endpoint": "public",
+				})
+			}
+
+			err := mon.emitAPIServerLatency(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
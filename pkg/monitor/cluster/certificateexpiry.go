@@ -0,0 +1,243 @@
+package cluster
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/genevalogging"
+	utilcert "github.com/Azure/ARO-RP/pkg/util/cert"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+	"github.com/Azure/ARO-RP/pkg/util/pem"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+const (
+	certificateExpirationMetricName = "certificate.expirationdate"
+	secretMissingMetricName         = "certificate.secretnotfound"
+	ingressNamespace                = "openshift-ingress-operator"
+	ingressName                     = "default"
+
+	// defaultCertificateWarningDays is the number of days before expiry at
+	// which certificates are flagged with the warning dimension, unless
+	// overridden by the certificateExpiry collector's warningDays setting
+	defaultCertificateWarningDays = 30
+)
+
+// certificateSource is a secret holding a certificate we report on
+type certificateSource struct {
+	kind      string
+	namespace string
+	name      string
+	key       string
+
+	// optional sources are skipped silently if the secret does not exist
+	optional bool
+}
+
+// internalCASources are the signers of the cluster's internal certificates.
+// They are rotated by their operators, but we want to know if rotation gets
+// stuck.  The set differs slightly between OpenShift versions, so they are
+// optional.
+var internalCASources = []certificateSource{
+	{kind: "ca", namespace: "openshift-kube-apiserver-operator", name: "aggregator-client-signer", key: corev1.TLSCertKey, optional: true},
+	{kind: "ca", namespace: "openshift-kube-apiserver-operator", name: "kube-apiserver-to-kubelet-signer", key: corev1.TLSCertKey, optional: true},
+	{kind: "ca", namespace: "openshift-kube-apiserver-operator", name: "kube-control-plane-signer", key: corev1.TLSCertKey, optional: true},
+	{kind: "ca", namespace: "openshift-kube-apiserver-operator", name: "loadbalancer-serving-signer", key: corev1.TLSCertKey, optional: true},
+	{kind: "ca", namespace: "openshift-kube-apiserver-operator", name: "localhost-serving-signer", key: corev1.TLSCertKey, optional: true},
+	{kind: "ca", namespace: "openshift-kube-apiserver-operator", name: "service-network-serving-signer", key: corev1.TLSCertKey, optional: true},
+	{kind: "ca", namespace: "openshift-kube-controller-manager-operator", name: "csr-signer-signer", key: corev1.TLSCertKey, optional: true},
+	{kind: "ca", namespace: "openshift-service-ca", name: "signing-key", key: corev1.TLSCertKey, optional: true},
+}
+
+// emitCertificateExpiry reports the days until expiry of the Geneva
+// certificate, the cluster's internal CAs, the ingress and API serving
+// certificates (on managed domains) and the etcd certificates (before 4.9,
+// which does not rotate them automatically).  Every certificate carries a
+// "warning" dimension which is set once it is within the warning threshold.
+// A failure reading one certificate does not stop the others being reported.
+func (mon *Monitor) emitCertificateExpiry(ctx context.Context) error {
+	var errs []error
+
+	sources := []certificateSource{
+		{kind: "geneva", namespace: operator.Namespace, name: operator.SecretName, key: genevalogging.GenevaCertName},
+	}
+	sources = append(sources, internalCASources...)
+
+	managedSources, err := mon.managedDomainCertificateSources(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	sources = append(sources, managedSources...)
+
+	for _, source := range sources {
+		certificate, err := mon.getCertificate(ctx, source.namespace, source.name, source.key)
+		if kerrors.IsNotFound(err) {
+			if !source.optional {
+				mon.emitGauge(secretMissingMetricName, int64(1), secretMissingMetric(source.namespace, source.name))
+			}
+			continue
+		} else if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		mon.emitCertificateExpiryGauge(source.kind, source.namespace, source.name, certificate)
+	}
+
+	err = mon.emitEtcdCertificateExpiry(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	return nil
+}
+
+// managedDomainCertificateSources returns the ingress and API serving
+// certificates which we manage for clusters on managed domains
+func (mon *Monitor) managedDomainCertificateSources(ctx context.Context) ([]certificateSource, error) {
+	host, err := getHostFromAPIURL(mon.oc.Properties.APIServerProfile.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dns.IsManagedDomain(host) {
+		return nil, nil
+	}
+
+	ic := &operatorv1.IngressController{}
+	err = mon.ocpclientset.Get(ctx, client.ObjectKey{
+		Namespace: ingressNamespace,
+		Name:      ingressName,
+	}, ic)
+	if err != nil {
+		return nil, err
+	}
+
+	if ic.Spec.DefaultCertificate == nil {
+		return nil, fmt.Errorf("ingresscontroller spec invalid, unable to get default certificate name")
+	}
+
+	ingressSecretName := ic.Spec.DefaultCertificate.Name
+
+	// secret with managed certificates is uuid + "-ingress" or "-apiserver"
+	return []certificateSource{
+		{kind: "ingress", namespace: operator.Namespace, name: ingressSecretName, key: corev1.TLSCertKey},
+		{kind: "apiserver", namespace: operator.Namespace, name: strings.Replace(ingressSecretName, "-ingress", "-apiserver", 1), key: corev1.TLSCertKey},
+	}, nil
+}
+
+func (mon *Monitor) emitEtcdCertificateExpiry(ctx context.Context) error {
+	cv, err := mon.getClusterVersion(ctx)
+	if err != nil {
+		return err
+	}
+	v, err := version.ParseVersion(actualVersion(cv))
+	if err != nil {
+		return err
+	}
+	// ETCD ceritificates are autorotated by the operator when close to expiry for cluster running 4.9+
+	if !v.Lt(version.NewVersion(4, 9)) {
+		return nil
+	}
+
+	secretList, err := mon.cli.CoreV1().Secrets("openshift-etcd").List(ctx, metav1.ListOptions{FieldSelector: fmt.Sprintf("type=%s", corev1.SecretTypeTLS)})
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secretList.Items {
+		// Parse secrets with name containing "etcd-peer", "etcd-serving", "etcd-serving-metrics"
+		if strings.Contains(secret.ObjectMeta.Name, "etcd-peer") || strings.Contains(secret.ObjectMeta.Name, "etcd-serving") {
+			_, certs, err := pem.Parse(secret.Data[corev1.TLSCertKey])
+			if err != nil {
+				return err
+			}
+			mon.emitCertificateExpiryGauge("etcd", "openshift-etcd", secret.GetObjectMeta().GetName(), certs[0])
+		}
+	}
+
+	return nil
+}
+
+func (mon *Monitor) emitCertificateExpiryGauge(kind, namespace, name string, certificate *x509.Certificate) {
+	daysUntilExpiration := utilcert.DaysUntilExpiration(certificate)
+
+	mon.emitGauge(certificateExpirationMetricName, int64(daysUntilExpiration), map[string]string{
+		"kind":      kind,
+		"subject":   certificate.Subject.CommonName,
+		"name":      name,
+		"namespace": namespace,
+		"warning":   strconv.FormatBool(daysUntilExpiration < mon.certificateWarningDays()),
+	})
+}
+
+// certificateExpiryOptions are the options of the certificateExpiry collector
+type certificateExpiryOptions struct {
+	// WarningDays, if set, is the number of days before expiry at which
+	// certificates are flagged with the warning dimension
+	WarningDays *int `json:"warningDays,omitempty"`
+}
+
+func (o *certificateExpiryOptions) validate() error {
+	if o.WarningDays != nil && *o.WarningDays < 0 {
+		return fmt.Errorf("warningDays must not be negative")
+	}
+
+	return nil
+}
+
+// certificateWarningDays returns the configured warning threshold in days
+func (mon *Monitor) certificateWarningDays() int {
+	if config := mon.collectorConfigs["certificateExpiry"]; config != nil {
+		if o, ok := config.options.(*certificateExpiryOptions); ok && o.WarningDays != nil {
+			return *o.WarningDays
+		}
+	}
+
+	return defaultCertificateWarningDays
+}
+
+func (mon *Monitor) getCertificate(ctx context.Context, secretNamespace, secretName, secretKey string) (*x509.Certificate, error) {
+	secret := &corev1.Secret{}
+	err := mon.ocpclientset.Get(ctx, client.ObjectKey{
+		Namespace: secretNamespace,
+		Name:      secretName,
+	}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.ParseFirstCertificate(secret.Data[secretKey])
+}
+
+func secretMissingMetric(namespace, name string) map[string]string {
+	return map[string]string{
+		"namespace": namespace,
+		"name":      name,
+	}
+}
+
+func getHostFromAPIURL(apiURL string) (string, error) {
+	domain, err := url.Parse(apiURL)
+	if err != nil {
+		return "", err
+	}
+	return domain.Hostname(), nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -36,7 +37,7 @@ const (
 	unmanagedDomainApiURL = "https://api.aro.contoso.com:6443"
 )
 
-func TestEmitCertificateExpirationStatuses(t *testing.T) {
+func TestEmitCertificateExpiry(t *testing.T) {
 	expiration := time.Now().Add(time.Hour * 24 * 5)
 	daysUntilExpiration := 4
 	//expirationString := expiration.UTC().Format(time.RFC3339)
@@ -59,6 +60,8 @@ func TestEmitCertificateExpirationStatuses(t *testing.T) {
 		url               string
 		ingressController *operatorv1.IngressController
 		certsPresent      []certInfo
+		caCertsPresent    []certInfo
+		warningDays       *int
 		wantExpirations   []map[string]string
 		wantWarning       []map[string]string
 		wantErr           string
@@ -70,9 +73,11 @@ func TestEmitCertificateExpirationStatuses(t *testing.T) {
 			certsPresent:      []certInfo{{"cluster", "geneva.certificate"}},
 			wantExpirations: []map[string]string{
 				{
+					"kind":      "geneva",
 					"subject":   "geneva.certificate",
 					"name":      "cluster",
 					"namespace": "openshift-azure-operator",
+					"warning":   "true",
 				},
 			},
 		},
@@ -87,19 +92,64 @@ func TestEmitCertificateExpirationStatuses(t *testing.T) {
 			},
 			wantExpirations: []map[string]string{
 				{
+					"kind":      "geneva",
 					"subject":   "geneva.certificate",
 					"name":      "cluster",
 					"namespace": "openshift-azure-operator",
+					"warning":   "true",
 				},
 				{
+					"kind":      "ingress",
 					"subject":   "contoso.aroapp.io",
 					"name":      clusterID + "-ingress",
 					"namespace": "openshift-azure-operator",
+					"warning":   "true",
 				},
 				{
+					"kind":      "apiserver",
 					"subject":   "api.contoso.aroapp.io",
 					"name":      clusterID + "-apiserver",
 					"namespace": "openshift-azure-operator",
+					"warning":   "true",
+				},
+			},
+		},
+		{
+			name:              "includes internal CAs which are present",
+			url:               unmanagedDomainApiURL,
+			ingressController: defaultIngressController,
+			certsPresent:      []certInfo{{"cluster", "geneva.certificate"}},
+			caCertsPresent:    []certInfo{{"signing-key", "openshift-service-serving-signer"}},
+			wantExpirations: []map[string]string{
+				{
+					"kind":      "geneva",
+					"subject":   "geneva.certificate",
+					"name":      "cluster",
+					"namespace": "openshift-azure-operator",
+					"warning":   "true",
+				},
+				{
+					"kind":      "ca",
+					"subject":   "openshift-service-serving-signer",
+					"name":      "signing-key",
+					"namespace": "openshift-service-ca",
+					"warning":   "true",
+				},
+			},
+		},
+		{
+			name:              "warning threshold is configurable",
+			url:               unmanagedDomainApiURL,
+			ingressController: defaultIngressController,
+			certsPresent:      []certInfo{{"cluster", "geneva.certificate"}},
+			warningDays:       to.IntPtr(2),
+			wantExpirations: []map[string]string{
+				{
+					"kind":      "geneva",
+					"subject":   "geneva.certificate",
+					"name":      "cluster",
+					"namespace": "openshift-azure-operator",
+					"warning":   "false",
 				},
 			},
 		},
@@ -124,14 +174,18 @@ func TestEmitCertificateExpirationStatuses(t *testing.T) {
 			},
 			wantExpirations: []map[string]string{
 				{
+					"kind":      "geneva",
 					"subject":   "geneva.certificate",
 					"name":      "cluster",
 					"namespace": "openshift-azure-operator",
+					"warning":   "true",
 				},
 				{
+					"kind":      "ingress",
 					"subject":   "contoso.aroapp.io",
 					"name":      clusterID + "-ingress",
 					"namespace": "openshift-azure-operator",
+					"warning":   "true",
 				},
 			},
 			wantWarning: []map[string]string{
@@ -156,9 +210,11 @@ func TestEmitCertificateExpirationStatuses(t *testing.T) {
 			},
 			wantExpirations: []map[string]string{
 				{
+					"kind":      "geneva",
 					"subject":   "geneva.certificate",
 					"name":      "cluster",
 					"namespace": "openshift-azure-operator",
+					"warning":   "true",
 				},
 			},
 			wantErr: "ingresscontroller spec invalid, unable to get default certificate name",
@@ -174,6 +230,15 @@ func TestEmitCertificateExpirationStatuses(t *testing.T) {
 			}
 			secrets = append(secrets, secretsFromCertInfo...)
 
+			caSecrets, err := generateTestSecrets(tt.caCertsPresent, tweakTemplateFn(expiration))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range caSecrets {
+				s.SetNamespace("openshift-service-ca")
+			}
+			secrets = append(secrets, caSecrets...)
+
 			m := mock_metrics.NewMockEmitter(gomock.NewController(t))
 			for _, w := range tt.wantWarning {
 				m.EXPECT().EmitGauge(secretMissingMetricName, int64(1), w)
@@ -183,8 +248,13 @@ func TestEmitCertificateExpirationStatuses(t *testing.T) {
 			}
 
 			mon := buildMonitor(m, tt.url, clusterID, tt.ingressController, secrets...)
+			if tt.warningDays != nil {
+				mon.collectorConfigs = CollectorConfigs{
+					"certificateExpiry": {options: &certificateExpiryOptions{WarningDays: tt.warningDays}},
+				}
+			}
 
-			err = mon.emitCertificateExpirationStatuses(ctx)
+			err = mon.emitCertificateExpiry(ctx)
 
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
 		})
@@ -198,10 +268,10 @@ func TestEmitCertificateExpirationStatuses(t *testing.T) {
 
 		ctx := context.Background()
 		m := mock_metrics.NewMockEmitter(gomock.NewController(t))
-		mon := buildMonitor(m, managedDomainApiURL, clusterID, defaultIngressController, secrets...)
+		mon := buildMonitor(m, unmanagedDomainApiURL, clusterID, defaultIngressController, secrets...)
 
 		wantErr := "unable to find certificate"
-		err := mon.emitCertificateExpirationStatuses(ctx)
+		err := mon.emitCertificateExpiry(ctx)
 		utilerror.AssertErrorMessage(t, err, wantErr)
 	})
 }
@@ -253,7 +323,21 @@ func buildMonitor(m *mock_metrics.MockEmitter, url, id string, ingressController
 		Build()
 	return &Monitor{
 		ocpclientset: ocpclientset,
-		m:            m,
+		cli:          fakeClient.NewSimpleClientset(),
+		configcli: configfake.NewSimpleClientset(&configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "version",
+			},
+			Status: configv1.ClusterVersionStatus{
+				History: []configv1.UpdateHistory{
+					{
+						State:   configv1.CompletedUpdate,
+						Version: "4.10.1",
+					},
+				},
+			},
+		}),
+		m: m,
 		oc: &api.OpenShiftCluster{
 			Properties: api.OpenShiftClusterProperties{
 				APIServerProfile: api.APIServerProfile{
@@ -322,9 +406,11 @@ func TestEtcdCertificateExpiry(t *testing.T) {
 			}
 
 			m.EXPECT().EmitGauge(certificateExpirationMetricName, int64(tt.minDaysUntilExpiration), map[string]string{
+				"kind":      "etcd",
 				"namespace": "openshift-etcd",
 				"name":      "etcd-peer-master-0",
 				"subject":   "etcd-cert",
+				"warning":   "true",
 			})
 			err = mon.emitEtcdCertificateExpiry(ctx)
 			if err != nil {
@@ -51,6 +51,11 @@ type Monitor struct {
 	ocpclientset  client.Client
 	hiveclientset client.Client
 
+	collectorConfigs CollectorConfigs
+	// metricAllowlist, if set, restricts the metrics emitted by the
+	// collector currently running
+	metricAllowlist map[string]struct{}
+
 	// access below only via the helper functions in cache.go
 	cache struct {
 		cos   *configv1.ClusterOperatorList
@@ -63,7 +68,7 @@ type Monitor struct {
 	wg *sync.WaitGroup
 }
 
-func NewMonitor(log *logrus.Entry, restConfig *rest.Config, oc *api.OpenShiftCluster, m metrics.Emitter, hiveRestConfig *rest.Config, hourlyRun bool, wg *sync.WaitGroup) (*Monitor, error) {
+func NewMonitor(log *logrus.Entry, restConfig *rest.Config, oc *api.OpenShiftCluster, m metrics.Emitter, hiveRestConfig *rest.Config, hourlyRun bool, collectorConfigs CollectorConfigs, wg *sync.WaitGroup) (*Monitor, error) {
 	r, err := azure.ParseResourceID(oc.ID)
 	if err != nil {
 		return nil, err
@@ -135,7 +140,9 @@ func NewMonitor(log *logrus.Entry, restConfig *rest.Config, oc *api.OpenShiftClu
 		m:             m,
 		ocpclientset:  ocpclientset,
 		hiveclientset: hiveclientset,
-		wg:            wg,
+
+		collectorConfigs: collectorConfigs,
+		wg:               wg,
 	}, nil
 }
 
@@ -187,37 +194,11 @@ func (mon *Monitor) Monitor(ctx context.Context) (errs []error) {
 		}
 		return
 	}
-	for _, f := range []func(context.Context) error{
-		mon.emitAroOperatorHeartbeat,
-		mon.emitAroOperatorConditions,
-		mon.emitNSGReconciliation,
-		mon.emitClusterOperatorConditions,
-		mon.emitClusterOperatorVersions,
-		mon.emitClusterVersionConditions,
-		mon.emitClusterVersions,
-		mon.emitDaemonsetStatuses,
-		mon.emitDeploymentStatuses,
-		mon.emitMachineConfigPoolConditions,
-		mon.emitMachineConfigPoolUnmanagedNodeCounts,
-		mon.emitNodeConditions,
-		mon.emitPodConditions,
-		mon.emitDebugPodsCount,
-		mon.detectQuotaFailure,
-		mon.emitReplicasetStatuses,
-		mon.emitStatefulsetStatuses,
-		mon.emitJobConditions,
-		mon.emitSummary,
-		mon.emitHiveRegistrationStatus,
-		mon.emitOperatorFlagsAndSupportBanner,
-		mon.emitMaintenanceState,
-		mon.emitCertificateExpirationStatuses,
-		mon.emitEtcdCertificateExpiry,
-		mon.emitPrometheusAlerts, // at the end for now because it's the slowest/least reliable
-	} {
-		err = f(ctx)
+	for _, c := range mon.collectors() {
+		err = mon.runCollector(ctx, c)
 		if err != nil {
 			errs = append(errs, err)
-			mon.emitFailureToGatherMetric(steps.FriendlyName(f), err)
+			mon.emitFailureToGatherMetric(steps.FriendlyName(c.collect), err)
 			// keep going
 		}
 	}
@@ -231,5 +212,21 @@ func (mon *Monitor) emitFailureToGatherMetric(friendlyFuncName string, err error
 }
 
 func (mon *Monitor) emitGauge(m string, value int64, dims map[string]string) {
+	if mon.metricAllowlist != nil {
+		if _, ok := mon.metricAllowlist[m]; !ok {
+			return
+		}
+	}
+
 	emitter.EmitGauge(mon.m, m, value, mon.dims, dims)
 }
+
+func (mon *Monitor) emitHistogram(m string, value float64, dims map[string]string) {
+	if mon.metricAllowlist != nil {
+		if _, ok := mon.metricAllowlist[m]; !ok {
+			return
+		}
+	}
+
+	emitter.EmitHistogram(mon.m, m, value, mon.dims, dims)
+}
@@ -43,6 +43,8 @@ func (e *fakeMetricsEmitter) EmitGauge(topic string, value int64, dims map[strin
 
 func (e *fakeMetricsEmitter) EmitFloat(topic string, value float64, dims map[string]string) {}
 
+func (e *fakeMetricsEmitter) EmitHistogram(topic string, value float64, dims map[string]string) {}
+
 func generateDefaultFlags() arov1alpha1.OperatorFlags {
 	df := make(arov1alpha1.OperatorFlags)
 	for k, v := range operator.DefaultOperatorFlags() {
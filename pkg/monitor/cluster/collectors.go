@@ -0,0 +1,188 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// collector is a single source of cluster metrics.  Collectors are run in
+// order by Monitor once the API server has been found to be healthy.
+type collector struct {
+	name    string
+	collect func(context.Context) error
+
+	// newOptions, if set, returns a pointer to the zero value of the
+	// collector's options, which its CollectorConfig Options are decoded
+	// into.  Collectors without it take no options.
+	newOptions func() collectorOptions
+}
+
+// collectorOptions are the settings specific to a single collector
+type collectorOptions interface {
+	// validate checks the options once they are decoded
+	validate() error
+}
+
+// collectors returns the registered collectors.  To add a new collector,
+// append it here; it can then be disabled or tuned per environment through
+// CollectorConfigs without further code changes.
+func (mon *Monitor) collectors() []collector {
+	return []collector{
+		{name: "apiServerLatency", collect: mon.emitAPIServerLatency},
+		{name: "aroOperatorHeartbeat", collect: mon.emitAroOperatorHeartbeat},
+		{name: "aroOperatorConditions", collect: mon.emitAroOperatorConditions},
+		{name: "nsgReconciliation", collect: mon.emitNSGReconciliation},
+		{name: "clusterOperatorConditions", collect: mon.emitClusterOperatorConditions},
+		{name: "clusterOperatorVersions", collect: mon.emitClusterOperatorVersions},
+		{name: "clusterVersionConditions", collect: mon.emitClusterVersionConditions},
+		{name: "clusterVersions", collect: mon.emitClusterVersions},
+		{name: "daemonsetStatuses", collect: mon.emitDaemonsetStatuses},
+		{name: "deploymentStatuses", collect: mon.emitDeploymentStatuses},
+		{name: "machineConfigPoolConditions", collect: mon.emitMachineConfigPoolConditions},
+		{name: "machineConfigPoolUnmanagedNodeCounts", collect: mon.emitMachineConfigPoolUnmanagedNodeCounts},
+		{name: "machineConfigPoolRollouts", collect: mon.emitMachineConfigPoolRollouts, newOptions: func() collectorOptions { return &machineConfigPoolRolloutsOptions{} }},
+		{name: "nodeConditions", collect: mon.emitNodeConditions},
+		{name: "spotEvictions", collect: mon.emitSpotEvictions},
+		{name: "podConditions", collect: mon.emitPodConditions},
+		{name: "debugPodsCount", collect: mon.emitDebugPodsCount},
+		{name: "quotaFailure", collect: mon.detectQuotaFailure},
+		{name: "replicasetStatuses", collect: mon.emitReplicasetStatuses},
+		{name: "statefulsetStatuses", collect: mon.emitStatefulsetStatuses},
+		{name: "jobConditions", collect: mon.emitJobConditions},
+		{name: "summary", collect: mon.emitSummary},
+		{name: "hiveRegistrationStatus", collect: mon.emitHiveRegistrationStatus},
+		{name: "hiveClusterSync", collect: mon.emitHiveClusterSync},
+		{name: "operatorFlagsAndSupportBanner", collect: mon.emitOperatorFlagsAndSupportBanner},
+		{name: "maintenanceState", collect: mon.emitMaintenanceState},
+		{name: "certificateExpiry", collect: mon.emitCertificateExpiry, newOptions: func() collectorOptions { return &certificateExpiryOptions{} }},
+		{name: "thanosCriticalAlerts", collect: mon.emitThanosCriticalAlerts},
+		{name: "prometheusAlerts", collect: mon.emitPrometheusAlerts}, // at the end for now because it's the slowest/least reliable
+	}
+}
+
+// CollectorConfig overrides the behaviour of a single collector
+type CollectorConfig struct {
+	// Enabled, if set to false, stops the collector from running
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Timeout, if set, bounds how long a single run of the collector may take,
+	// e.g. "10s"
+	Timeout string `json:"timeout,omitempty"`
+
+	// Metrics, if set, is the allowlist of metric names the collector may
+	// emit.  Other metrics are dropped.
+	Metrics []string `json:"metrics,omitempty"`
+
+	// Options, if set, holds settings specific to the collector.  Settings
+	// which the collector does not know are rejected.
+	Options json.RawMessage `json:"options,omitempty"`
+
+	timeout time.Duration
+	metrics map[string]struct{}
+	options collectorOptions
+}
+
+// CollectorConfigs maps collector names to their configuration
+type CollectorConfigs map[string]*CollectorConfig
+
+// ParseCollectorConfigs parses a JSON collector configuration, e.g.
+// `{"prometheusAlerts":{"enabled":false},"nodeConditions":{"timeout":"10s"},"certificateExpiry":{"options":{"warningDays":14}}}`.
+// An empty string returns an empty configuration.
+func ParseCollectorConfigs(s string) (CollectorConfigs, error) {
+	configs := CollectorConfigs{}
+	if s == "" {
+		return configs, nil
+	}
+
+	// reject misspelt or misplaced settings rather than ignore them
+	d := json.NewDecoder(bytes.NewBufferString(s))
+	d.DisallowUnknownFields()
+
+	err := d.Decode(&configs)
+	if err != nil {
+		return nil, err
+	}
+
+	collectors := map[string]collector{}
+	for _, c := range (&Monitor{}).collectors() {
+		collectors[c.name] = c
+	}
+
+	for name, config := range configs {
+		c, ok := collectors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown collector %q", name)
+		}
+
+		if config == nil {
+			return nil, fmt.Errorf("collector %q: empty configuration", name)
+		}
+
+		if config.Timeout != "" {
+			config.timeout, err = time.ParseDuration(config.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("collector %q: %w", name, err)
+			}
+		}
+
+		if config.Options != nil {
+			if c.newOptions == nil {
+				return nil, fmt.Errorf("collector %q: takes no options", name)
+			}
+
+			config.options = c.newOptions()
+
+			d := json.NewDecoder(bytes.NewReader(config.Options))
+			d.DisallowUnknownFields()
+
+			err = d.Decode(config.options)
+			if err != nil {
+				return nil, fmt.Errorf("collector %q: %w", name, err)
+			}
+
+			err = config.options.validate()
+			if err != nil {
+				return nil, fmt.Errorf("collector %q: %w", name, err)
+			}
+		}
+
+		if config.Metrics != nil {
+			config.metrics = map[string]struct{}{}
+			for _, m := range config.Metrics {
+				config.metrics[m] = struct{}{}
+			}
+		}
+	}
+
+	return configs, nil
+}
+
+// runCollector runs c subject to its configuration
+func (mon *Monitor) runCollector(ctx context.Context, c collector) error {
+	config := mon.collectorConfigs[c.name]
+	if config == nil {
+		return c.collect(ctx)
+	}
+
+	if config.Enabled != nil && !*config.Enabled {
+		mon.log.Debugf("collector %s is disabled", c.name)
+		return nil
+	}
+
+	if config.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.timeout)
+		defer cancel()
+	}
+
+	mon.metricAllowlist = config.metrics
+	defer func() { mon.metricAllowlist = nil }()
+
+	return c.collect(ctx)
+}
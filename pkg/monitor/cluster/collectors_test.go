@@ -0,0 +1,173 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestParseCollectorConfigs(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		s       string
+		check   func(*testing.T, CollectorConfigs)
+		wantErr string
+	}{
+		{
+			name: "empty",
+			check: func(t *testing.T, configs CollectorConfigs) {
+				if len(configs) != 0 {
+					t.Error(configs)
+				}
+			},
+		},
+		{
+			name: "valid",
+			s:    `{"prometheusAlerts":{"enabled":false},"nodeConditions":{"timeout":"10s","metrics":["node.conditions"]}}`,
+			check: func(t *testing.T, configs CollectorConfigs) {
+				if *configs["prometheusAlerts"].Enabled {
+					t.Error("expected prometheusAlerts to be disabled")
+				}
+				if configs["nodeConditions"].timeout != 10*time.Second {
+					t.Error(configs["nodeConditions"].timeout)
+				}
+				if _, ok := configs["nodeConditions"].metrics["node.conditions"]; !ok {
+					t.Error(configs["nodeConditions"].metrics)
+				}
+			},
+		},
+		{
+			name:    "unknown collector",
+			s:       `{"unknown":{"enabled":false}}`,
+			wantErr: `unknown collector "unknown"`,
+		},
+		{
+			name:    "invalid timeout",
+			s:       `{"nodeConditions":{"timeout":"soon"}}`,
+			wantErr: `collector "nodeConditions": time: invalid duration "soon"`,
+		},
+		{
+			name: "options",
+			s:    `{"machineConfigPoolRollouts":{"options":{"stallThreshold":"3h"}},"certificateExpiry":{"options":{"warningDays":14}}}`,
+			check: func(t *testing.T, configs CollectorConfigs) {
+				if o := configs["machineConfigPoolRollouts"].options.(*machineConfigPoolRolloutsOptions); o.stallThreshold != 3*time.Hour {
+					t.Error(o.stallThreshold)
+				}
+				if o := configs["certificateExpiry"].options.(*certificateExpiryOptions); *o.WarningDays != 14 {
+					t.Error(*o.WarningDays)
+				}
+			},
+		},
+		{
+			name:    "invalid option",
+			s:       `{"machineConfigPoolRollouts":{"options":{"stallThreshold":"soon"}}}`,
+			wantErr: `collector "machineConfigPoolRollouts": time: invalid duration "soon"`,
+		},
+		{
+			name:    "option of another collector",
+			s:       `{"machineConfigPoolRollouts":{"options":{"warningDays":14}}}`,
+			wantErr: `collector "machineConfigPoolRollouts": json: unknown field "warningDays"`,
+		},
+		{
+			name:    "collector without options",
+			s:       `{"nodeConditions":{"options":{"stallThreshold":"3h"}}}`,
+			wantErr: `collector "nodeConditions": takes no options`,
+		},
+		{
+			name:    "settings outside options",
+			s:       `{"certificateExpiry":{"warningDays":14}}`,
+			wantErr: `json: unknown field "warningDays"`,
+		},
+		{
+			name:    "invalid json",
+			s:       `{`,
+			wantErr: "unexpected EOF",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			configs, err := ParseCollectorConfigs(tt.s)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.check != nil {
+				tt.check(t, configs)
+			}
+		})
+	}
+}
+
+func TestRunCollector(t *testing.T) {
+	ctx := context.Background()
+
+	configs, err := ParseCollectorConfigs(`{"nodeConditions":{"enabled":false},"podConditions":{"timeout":"1m","metrics":["allowed"]}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+
+	mon := &Monitor{
+		log:              logrus.NewEntry(logrus.StandardLogger()),
+		m:                m,
+		collectorConfigs: configs,
+	}
+
+	t.Run("disabled collector is not run", func(t *testing.T) {
+		err := mon.runCollector(ctx, collector{
+			name: "nodeConditions",
+			collect: func(context.Context) error {
+				return errors.New("should not run")
+			},
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("configured collector is time limited and filtered", func(t *testing.T) {
+		m.EXPECT().EmitGauge("allowed", int64(1), gomock.Any())
+
+		err := mon.runCollector(ctx, collector{
+			name: "podConditions",
+			collect: func(ctx context.Context) error {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("expected deadline")
+				}
+
+				mon.emitGauge("allowed", 1, nil)
+				mon.emitGauge("dropped", 1, nil)
+				return nil
+			},
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("unconfigured collector is run as is", func(t *testing.T) {
+		m.EXPECT().EmitGauge("unfiltered", int64(1), gomock.Any())
+
+		err := mon.runCollector(ctx, collector{
+			name: "summary",
+			collect: func(ctx context.Context) error {
+				mon.emitGauge("unfiltered", 1, nil)
+				return nil
+			},
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Azure/ARO-RP/pkg/hive"
+)
+
+const (
+	hiveClusterSyncConditionsMetricName = "hive.clustersync.conditions"
+	hiveClusterSyncFailuresMetricName   = "hive.clustersync.failures"
+)
+
+// clusterSyncGVK is the ClusterSync kind.  Hive records the result of applying
+// each SyncSet and SelectorSyncSet to a cluster in a ClusterSync with the same
+// name and namespace as the ClusterDeployment.  The hiveinternal API is not
+// vendored, so ClusterSyncs are read as unstructured objects.
+var clusterSyncGVK = schema.GroupVersionKind{
+	Group:   "hiveinternal.openshift.io",
+	Version: "v1alpha1",
+	Kind:    "ClusterSync",
+}
+
+// emitHiveClusterSync reports the conditions of the cluster's ClusterSync, and
+// each SyncSet and SelectorSyncSet which Hive failed to apply, so that syncset
+// failures are visible without access to the Hive shard.  The SyncSetFailed
+// condition of the ClusterDeployment is already emitted by
+// emitHiveRegistrationStatus.
+func (mon *Monitor) emitHiveClusterSync(ctx context.Context) error {
+	if mon.hiveclientset == nil {
+		// TODO(hive): remove this once we have Hive everywhere
+		mon.log.Info("skipping: no hive cluster manager")
+		return nil
+	}
+
+	if mon.oc.Properties.HiveProfile.Namespace == "" {
+		return fmt.Errorf("cluster %s not adopted. No namespace in the clusterdocument", mon.oc.Name)
+	}
+
+	cs := &unstructured.Unstructured{}
+	cs.SetGroupVersionKind(clusterSyncGVK)
+	err := mon.hiveclientset.Get(ctx, client.ObjectKey{
+		Namespace: mon.oc.Properties.HiveProfile.Namespace,
+		Name:      hive.ClusterDeploymentName,
+	}, cs)
+	if kerrors.IsNotFound(err) {
+		// Hive creates the ClusterSync once it starts syncing the cluster
+		mon.log.Info("skipping: no clustersync")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	conditions, _, err := unstructured.NestedSlice(cs.Object, "status", "conditions")
+	if err != nil {
+		return err
+	}
+
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		mon.emitGauge(hiveClusterSyncConditionsMetricName, 1, map[string]string{
+			"type":   stringField(condition, "type"),
+			"status": stringField(condition, "status"),
+			"reason": stringField(condition, "reason"),
+		})
+	}
+
+	for field, kind := range map[string]string{
+		"syncSets":         "SyncSet",
+		"selectorSyncSets": "SelectorSyncSet",
+	} {
+		statuses, _, err := unstructured.NestedSlice(cs.Object, "status", field)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range statuses {
+			status, ok := s.(map[string]interface{})
+			if !ok || stringField(status, "result") != "Failure" {
+				continue
+			}
+
+			mon.emitGauge(hiveClusterSyncFailuresMetricName, 1, map[string]string{
+				"kind": kind,
+				"name": stringField(status, "name"),
+			})
+
+			if mon.hourlyRun {
+				mon.log.WithFields(logrus.Fields{
+					"metric":         hiveClusterSyncFailuresMetricName,
+					"kind":           kind,
+					"name":           stringField(status, "name"),
+					"failureMessage": stringField(status, "failureMessage"),
+				}).Print()
+			}
+		}
+	}
+
+	return nil
+}
+
+func stringField(m map[string]interface{}, field string) string {
+	s, _ := m[field].(string)
+	return s
+}
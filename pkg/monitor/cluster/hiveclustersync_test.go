@@ -0,0 +1,127 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/hive"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEmitHiveClusterSync(t *testing.T) {
+	ctx := context.Background()
+	fakeNamespace := "fake-namespace"
+
+	cs := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":      hive.ClusterDeploymentName,
+				"namespace": fakeNamespace,
+			},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":   "Failed",
+						"status": "True",
+						"reason": "Failure",
+					},
+				},
+				"syncSets": []interface{}{
+					map[string]interface{}{
+						"name":           "failing",
+						"result":         "Failure",
+						"failureMessage": "Apply failed",
+					},
+					map[string]interface{}{ // should be ignored
+						"name":   "succeeding",
+						"result": "Success",
+					},
+				},
+				"selectorSyncSets": []interface{}{
+					map[string]interface{}{
+						"name":   "failing-selector",
+						"result": "Failure",
+					},
+				},
+			},
+		},
+	}
+	cs.SetGroupVersionKind(clusterSyncGVK)
+
+	for _, tt := range []struct {
+		name       string
+		objects    []kruntime.Object
+		withClient bool
+		mocks      func(*mock_metrics.MockEmitter)
+		wantErr    string
+	}{
+		{
+			name: "no hiveclient",
+		},
+		{
+			name:       "no clustersync",
+			withClient: true,
+		},
+		{
+			name:       "send metrics data",
+			withClient: true,
+			objects:    []kruntime.Object{cs},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge("hive.clustersync.conditions", int64(1), map[string]string{
+					"type":   "Failed",
+					"status": "True",
+					"reason": "Failure",
+				})
+				m.EXPECT().EmitGauge("hive.clustersync.failures", int64(1), map[string]string{
+					"kind": "SyncSet",
+					"name": "failing",
+				})
+				m.EXPECT().EmitGauge("hive.clustersync.failures", int64(1), map[string]string{
+					"kind": "SelectorSyncSet",
+					"name": "failing-selector",
+				})
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if tt.mocks != nil {
+				tt.mocks(m)
+			}
+
+			mon := &Monitor{
+				log: utillog.GetLogger(),
+				oc: &api.OpenShiftCluster{
+					Name: "testcluster",
+					Properties: api.OpenShiftClusterProperties{
+						HiveProfile: api.HiveProfile{
+							Namespace: fakeNamespace,
+						},
+					},
+				},
+				m: m,
+			}
+
+			if tt.withClient {
+				mon.hiveclientset = fakeclient.NewClientBuilder().WithRuntimeObjects(tt.objects...).Build()
+			}
+
+			err := mon.emitHiveClusterSync(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
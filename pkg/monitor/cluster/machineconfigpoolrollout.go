@@ -0,0 +1,129 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	machineConfigPoolRolloutMetricName = "machineconfigpool.rollout.stalled"
+
+	// defaultMachineConfigPoolStallThreshold is how long a MachineConfigPool
+	// may be Updating before its rollout is considered stalled, unless
+	// overridden by the machineConfigPoolRollouts collector's stallThreshold
+	// setting.  Nodes are drained and rebooted one at a time by default, so
+	// rollouts on large pools legitimately take a while.
+	defaultMachineConfigPoolStallThreshold = 2 * time.Hour
+)
+
+// emitMachineConfigPoolRollouts reports MachineConfigPools which are degraded
+// or have been updating for longer than the stall threshold.  The value is
+// the number of unready nodes in the pool, and the rendered and target
+// configurations are included so that stuck rollouts can be correlated across
+// the fleet.
+func (mon *Monitor) emitMachineConfigPoolRollouts(ctx context.Context) error {
+	var cont string
+	for {
+		mcps, err := mon.mcocli.MachineconfigurationV1().MachineConfigPools().List(ctx, metav1.ListOptions{Limit: 500, Continue: cont})
+		if err != nil {
+			return err
+		}
+
+		for _, mcp := range mcps.Items {
+			for _, reason := range mon.machineConfigPoolStallReasons(&mcp) {
+				unready := int64(mcp.Status.MachineCount - mcp.Status.ReadyMachineCount)
+
+				mon.emitGauge(machineConfigPoolRolloutMetricName, unready, map[string]string{
+					"name":           mcp.Name,
+					"reason":         reason,
+					"renderedConfig": mcp.Status.Configuration.Name,
+					"targetConfig":   mcp.Spec.Configuration.Name,
+				})
+
+				if mon.hourlyRun {
+					mon.log.WithFields(logrus.Fields{
+						"metric":           machineConfigPoolRolloutMetricName,
+						"name":             mcp.Name,
+						"reason":           reason,
+						"renderedConfig":   mcp.Status.Configuration.Name,
+						"targetConfig":     mcp.Spec.Configuration.Name,
+						"unreadyMachines":  unready,
+						"degradedMachines": mcp.Status.DegradedMachineCount,
+					}).Print()
+				}
+			}
+		}
+
+		cont = mcps.Continue
+		if cont == "" {
+			break
+		}
+	}
+
+	return nil
+}
+
+// machineConfigPoolStallReasons returns "degraded" if any of the pool's
+// degraded conditions is true, and "updating" if it has been updating for
+// longer than the stall threshold
+func (mon *Monitor) machineConfigPoolStallReasons(mcp *mcv1.MachineConfigPool) (reasons []string) {
+	var degraded bool
+	for _, c := range mcp.Status.Conditions {
+		switch c.Type {
+		case mcv1.MachineConfigPoolDegraded, mcv1.MachineConfigPoolNodeDegraded, mcv1.MachineConfigPoolRenderDegraded:
+			if c.Status == corev1.ConditionTrue {
+				degraded = true
+			}
+		}
+	}
+	if degraded {
+		reasons = append(reasons, "degraded")
+	}
+
+	for _, c := range mcp.Status.Conditions {
+		if c.Type == mcv1.MachineConfigPoolUpdating &&
+			c.Status == corev1.ConditionTrue &&
+			time.Since(c.LastTransitionTime.Time) > mon.machineConfigPoolStallThreshold() {
+			reasons = append(reasons, "updating")
+		}
+	}
+
+	return reasons
+}
+
+// machineConfigPoolRolloutsOptions are the options of the
+// machineConfigPoolRollouts collector
+type machineConfigPoolRolloutsOptions struct {
+	// StallThreshold, if set, is how long a MachineConfigPool may be updating
+	// before it is reported, e.g. "3h"
+	StallThreshold string `json:"stallThreshold,omitempty"`
+
+	stallThreshold time.Duration
+}
+
+func (o *machineConfigPoolRolloutsOptions) validate() (err error) {
+	if o.StallThreshold != "" {
+		o.stallThreshold, err = time.ParseDuration(o.StallThreshold)
+	}
+
+	return err
+}
+
+// machineConfigPoolStallThreshold returns the configured stall threshold
+func (mon *Monitor) machineConfigPoolStallThreshold() time.Duration {
+	if config := mon.collectorConfigs["machineConfigPoolRollouts"]; config != nil {
+		if o, ok := config.options.(*machineConfigPoolRolloutsOptions); ok && o.stallThreshold > 0 {
+			return o.stallThreshold
+		}
+	}
+
+	return defaultMachineConfigPoolStallThreshold
+}
@@ -0,0 +1,143 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	mcofake "github.com/openshift/machine-config-operator/pkg/generated/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestEmitMachineConfigPoolRollouts(t *testing.T) {
+	ctx := context.Background()
+
+	mcp := func(name string, conditions ...mcv1.MachineConfigPoolCondition) *mcv1.MachineConfigPool {
+		return &mcv1.MachineConfigPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: mcv1.MachineConfigPoolSpec{
+				Configuration: mcv1.MachineConfigPoolStatusConfiguration{
+					ObjectReference: corev1.ObjectReference{Name: "rendered-" + name + "-new"},
+				},
+			},
+			Status: mcv1.MachineConfigPoolStatus{
+				MachineCount:      3,
+				ReadyMachineCount: 1,
+				Configuration: mcv1.MachineConfigPoolStatusConfiguration{
+					ObjectReference: corev1.ObjectReference{Name: "rendered-" + name + "-old"},
+				},
+				Conditions: conditions,
+			},
+		}
+	}
+	updating := func(since time.Duration) mcv1.MachineConfigPoolCondition {
+		return mcv1.MachineConfigPoolCondition{
+			Type:               mcv1.MachineConfigPoolUpdating,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+		}
+	}
+	degraded := mcv1.MachineConfigPoolCondition{
+		Type:   mcv1.MachineConfigPoolNodeDegraded,
+		Status: corev1.ConditionTrue,
+	}
+
+	for _, tt := range []struct {
+		name             string
+		objects          []runtime.Object
+		collectorConfigs CollectorConfigs
+		mocks            func(*mock_metrics.MockEmitter)
+	}{
+		{
+			name: "recent rollout is not reported",
+			objects: []runtime.Object{
+				mcp("worker", updating(time.Hour)),
+			},
+		},
+		{
+			name: "stalled rollout",
+			objects: []runtime.Object{
+				mcp("worker", updating(3*time.Hour)),
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge("machineconfigpool.rollout.stalled", int64(2), map[string]string{
+					"name":           "worker",
+					"reason":         "updating",
+					"renderedConfig": "rendered-worker-old",
+					"targetConfig":   "rendered-worker-new",
+				})
+			},
+		},
+		{
+			name: "degraded and stalled rollout",
+			objects: []runtime.Object{
+				mcp("master", degraded, updating(3*time.Hour)),
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge("machineconfigpool.rollout.stalled", int64(2), map[string]string{
+					"name":           "master",
+					"reason":         "degraded",
+					"renderedConfig": "rendered-master-old",
+					"targetConfig":   "rendered-master-new",
+				})
+				m.EXPECT().EmitGauge("machineconfigpool.rollout.stalled", int64(2), map[string]string{
+					"name":           "master",
+					"reason":         "updating",
+					"renderedConfig": "rendered-master-old",
+					"targetConfig":   "rendered-master-new",
+				})
+			},
+		},
+		{
+			name: "configured stall threshold",
+			objects: []runtime.Object{
+				mcp("worker", updating(time.Hour)),
+			},
+			collectorConfigs: CollectorConfigs{
+				"machineConfigPoolRollouts": {options: &machineConfigPoolRolloutsOptions{stallThreshold: 30 * time.Minute}},
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge("machineconfigpool.rollout.stalled", int64(2), map[string]string{
+					"name":           "worker",
+					"reason":         "updating",
+					"renderedConfig": "rendered-worker-old",
+					"targetConfig":   "rendered-worker-new",
+				})
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if tt.mocks != nil {
+				tt.mocks(m)
+			}
+
+			mon := &Monitor{
+				log:              utillog.GetLogger(),
+				mcocli:           mcofake.NewSimpleClientset(tt.objects...),
+				m:                m,
+				collectorConfigs: tt.collectorConfigs,
+			}
+
+			err := mon.emitMachineConfigPoolRollouts(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
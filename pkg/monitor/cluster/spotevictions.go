@@ -0,0 +1,60 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nodeTerminating is the condition which the machine API termination handler
+// sets on a Spot node when Azure schedules it for eviction
+const nodeTerminating corev1.NodeConditionType = "Terminating"
+
+func (mon *Monitor) emitSpotEvictions(ctx context.Context) error {
+	spotInstances := mon.getSpotInstances(ctx)
+	if len(spotInstances) == 0 {
+		return nil
+	}
+
+	ns, err := mon.listNodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	for _, n := range ns.Items {
+		if _, isSpotInstance := spotInstances[n.Name]; !isSpotInstance {
+			continue
+		}
+
+		for _, c := range n.Status.Conditions {
+			if c.Type != nodeTerminating || c.Status != corev1.ConditionTrue {
+				continue
+			}
+
+			count++
+
+			mon.emitGauge("node.spot.evictions", 1, map[string]string{
+				"nodeName": n.Name,
+				"reason":   c.Reason,
+			})
+
+			if mon.hourlyRun {
+				mon.log.WithFields(logrus.Fields{
+					"metric":  "node.spot.evictions",
+					"name":    n.Name,
+					"reason":  c.Reason,
+					"message": c.Message,
+				}).Print()
+			}
+		}
+	}
+
+	mon.emitGauge("node.spot.evictions.count", count, nil)
+
+	return nil
+}
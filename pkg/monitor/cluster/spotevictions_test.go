@@ -0,0 +1,130 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	machinefake "github.com/openshift/client-go/machine/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestEmitSpotEvictions(t *testing.T) {
+	ctx := context.Background()
+
+	machine := func(t *testing.T, name string, spot bool) *machinev1beta1.Machine {
+		spec := machinev1beta1.AzureMachineProviderSpec{}
+		if spot {
+			spec.SpotVMOptions = &machinev1beta1.SpotVMOptions{}
+		}
+
+		b, err := json.Marshal(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &machinev1beta1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "openshift-machine-api",
+			},
+			Spec: machinev1beta1.MachineSpec{
+				ProviderSpec: machinev1beta1.ProviderSpec{
+					Value: &kruntime.RawExtension{
+						Raw: b,
+					},
+				},
+			},
+		}
+	}
+
+	node := func(name string, conditions ...corev1.NodeCondition) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Status: corev1.NodeStatus{
+				Conditions: conditions,
+			},
+		}
+	}
+
+	terminating := corev1.NodeCondition{
+		Type:   nodeTerminating,
+		Status: corev1.ConditionTrue,
+		Reason: "TerminationRequested",
+	}
+
+	for _, tt := range []struct {
+		name     string
+		machines func(*testing.T) []kruntime.Object
+		nodes    []kruntime.Object
+		mocks    func(*mock_metrics.MockEmitter)
+	}{
+		{
+			name: "no spot instances",
+			machines: func(t *testing.T) []kruntime.Object {
+				return []kruntime.Object{machine(t, "aro-worker-0", false)}
+			},
+			nodes: []kruntime.Object{node("aro-worker-0", terminating)},
+		},
+		{
+			name: "spot instances",
+			machines: func(t *testing.T) []kruntime.Object {
+				return []kruntime.Object{
+					machine(t, "aro-worker-0", false),
+					machine(t, "aro-spot-0", true),
+					machine(t, "aro-spot-1", true),
+					machine(t, "aro-spot-2", true),
+				}
+			},
+			nodes: []kruntime.Object{
+				node("aro-worker-0", terminating),
+				node("aro-spot-0", terminating),
+				node("aro-spot-1", corev1.NodeCondition{
+					Type:   nodeTerminating,
+					Status: corev1.ConditionFalse,
+				}),
+				node("aro-spot-2"),
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge("node.spot.evictions", int64(1), map[string]string{
+					"nodeName": "aro-spot-0",
+					"reason":   "TerminationRequested",
+				})
+				m.EXPECT().EmitGauge("node.spot.evictions.count", int64(1), map[string]string{})
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if tt.mocks != nil {
+				tt.mocks(m)
+			}
+
+			mon := &Monitor{
+				cli:    fake.NewSimpleClientset(tt.nodes...),
+				maocli: machinefake.NewSimpleClientset(tt.machines(t)...),
+				m:      m,
+			}
+
+			err := mon.emitSpotEvictions(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
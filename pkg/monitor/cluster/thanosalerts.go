@@ -0,0 +1,128 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/util/namespace"
+	"github.com/Azure/ARO-RP/pkg/util/portforward"
+)
+
+const (
+	thanosCriticalAlertsQuery = `ALERTS{alertstate="firing",severity="critical"}`
+
+	// thanos-query only listens on localhost inside its pod; the service
+	// ports go through an oauth proxy which doesn't accept the monitor's
+	// client certificate.  A port-forward reaches the localhost listener.
+	thanosQueryPort = "9090"
+)
+
+type thanosQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   struct {
+		ResultType model.ValueType `json:"resultType"`
+		Result     model.Vector    `json:"result"`
+	} `json:"data"`
+}
+
+// emitThanosCriticalAlerts queries the in-cluster thanos-querier for firing
+// critical alerts.  This gives us the same view as Telemeter-based alerting
+// for clusters which cannot reach Telemeter.
+func (mon *Monitor) emitThanosCriticalAlerts(ctx context.Context) error {
+	pods, err := mon.cli.CoreV1().Pods("openshift-monitoring").List(ctx, metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/name=thanos-query",
+	})
+	if err != nil {
+		return err
+	}
+
+	var podName string
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning {
+			podName = pod.Name
+			break
+		}
+	}
+	if podName == "" {
+		return errors.New("no running thanos-querier pod")
+	}
+
+	hc := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				return portforward.DialContext(ctx, mon.log, mon.restconfig, "openshift-monitoring", podName, thanosQueryPort)
+			},
+			// see emitPrometheusAlerts
+			DisableKeepAlives: true,
+		},
+	}
+
+	return mon.queryThanosCriticalAlerts(ctx, hc)
+}
+
+func (mon *Monitor) queryThanosCriticalAlerts(ctx context.Context, hc *http.Client) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost:"+thanosQueryPort+"/api/v1/query?"+url.Values{
+		"query": []string{thanosCriticalAlertsQuery},
+	}.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// the query API returns a JSON error body with 4xx and 5xx status codes
+	var r thanosQueryResponse
+	err = json.NewDecoder(resp.Body).Decode(&r)
+	if err != nil {
+		return fmt.Errorf("unexpected status code %d: %w", resp.StatusCode, err)
+	}
+
+	if r.Status != "success" {
+		return fmt.Errorf("query failed: %s", r.Error)
+	}
+
+	if r.Data.ResultType != model.ValVector {
+		return fmt.Errorf("unexpected result type %q", r.Data.ResultType)
+	}
+
+	m := map[string]int64{}
+
+	for _, sample := range r.Data.Result {
+		if !namespace.IsOpenShiftNamespace(string(sample.Metric["namespace"])) {
+			continue
+		}
+
+		alertName := string(sample.Metric[model.AlertNameLabel])
+		if alertIsIgnored(alertName) {
+			continue
+		}
+
+		m[alertName]++
+	}
+
+	for alertName, count := range m {
+		mon.emitGauge("thanos.alerts", count, map[string]string{
+			"alertname": alertName,
+			"severity":  "critical",
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEmitThanosCriticalAlertsNoPod(t *testing.T) {
+	ctx := context.Background()
+
+	mon := &Monitor{
+		cli: fake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "thanos-querier-1",
+				Namespace: "openshift-monitoring",
+				Labels: map[string]string{
+					"app.kubernetes.io/name": "thanos-query",
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+			},
+		}),
+	}
+
+	err := mon.emitThanosCriticalAlerts(ctx)
+	utilerror.AssertErrorMessage(t, err, "no running thanos-querier pod")
+}
+
+func TestQueryThanosCriticalAlerts(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name       string
+		statusCode int
+		body       string
+		mocks      func(*mock_metrics.MockEmitter)
+		wantErr    string
+	}{
+		{
+			name:       "firing alerts are counted",
+			statusCode: http.StatusOK,
+			body: `{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"alertname":"KubeAPIErrorBudgetBurn","namespace":"openshift-kube-apiserver","severity":"critical"},"value":[1,"1"]},
+				{"metric":{"alertname":"KubeAPIErrorBudgetBurn","namespace":"openshift-kube-apiserver","severity":"critical"},"value":[1,"1"]},
+				{"metric":{"alertname":"TargetDown","severity":"critical"},"value":[1,"1"]},
+				{"metric":{"alertname":"CustomerAlert","namespace":"customer","severity":"critical"},"value":[1,"1"]},
+				{"metric":{"alertname":"APIRemovedInNextReleaseInUse","namespace":"openshift-kube-apiserver","severity":"critical"},"value":[1,"1"]}
+			]}}`,
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge("thanos.alerts", int64(2), map[string]string{
+					"alertname": "KubeAPIErrorBudgetBurn",
+					"severity":  "critical",
+				})
+				m.EXPECT().EmitGauge("thanos.alerts", int64(1), map[string]string{
+					"alertname": "TargetDown",
+					"severity":  "critical",
+				})
+			},
+		},
+		{
+			name:       "query error",
+			statusCode: http.StatusBadRequest,
+			body:       `{"status":"error","error":"bad query"}`,
+			wantErr:    "query failed: bad query",
+		},
+		{
+			name:       "unexpected result type",
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			wantErr:    `unexpected result type "matrix"`,
+		},
+		{
+			name:       "non-JSON response",
+			statusCode: http.StatusBadGateway,
+			body:       `bad gateway`,
+			wantErr:    "unexpected status code 502: invalid character 'b' looking for beginning of value",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/query" || r.URL.Query().Get("query") != thanosCriticalAlertsQuery {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			hc := &http.Client{
+				Transport: &http.Transport{
+					DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+						return (&net.Dialer{}).DialContext(ctx, "tcp", ts.Listener.Addr().String())
+					},
+				},
+			}
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if tt.mocks != nil {
+				tt.mocks(m)
+			}
+
+			mon := &Monitor{
+				m: m,
+			}
+
+			err := mon.queryThanosCriticalAlerts(ctx, hc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -20,4 +20,18 @@ const (
 	NSGRuleName         = "rulename"
 	NSGRulePriority     = "priority"
 	NSGRuleSources      = "sources"
+
+	BackendIPAddress   = "backendipaddress"
+	BackendPort        = "backendport"
+	LoadBalancer       = "loadbalancer"
+	PowerState         = "powerstate"
+	PrivateLinkService = "privatelinkservice"
+	ProvisioningState  = "provisioningstate"
+	Status             = "status"
+	VM                 = "vm"
+
+	Reason       = "reason"
+	ResourceKind = "resourcekind"
+
+	Assessment = "assessment"
 )
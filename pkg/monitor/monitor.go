@@ -5,7 +5,6 @@ package monitor
 
 import (
 	"context"
-	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -15,13 +14,13 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/database"
-	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/monitor/cluster"
 	"github.com/Azure/ARO-RP/pkg/proxy"
-	"github.com/Azure/ARO-RP/pkg/util/bucket"
 	"github.com/Azure/ARO-RP/pkg/util/heartbeat"
 	"github.com/Azure/ARO-RP/pkg/util/liveconfig"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
 type monitor struct {
@@ -31,6 +30,7 @@ type monitor struct {
 	dbMonitors          database.Monitors
 	dbOpenShiftClusters database.OpenShiftClusters
 	dbSubscriptions     database.Subscriptions
+	dbBilling           database.Billing
 
 	m        metrics.Emitter
 	clusterm metrics.Emitter
@@ -39,9 +39,7 @@ type monitor struct {
 	subs     map[string]*api.SubscriptionDocument
 	env      env.Interface
 
-	isMaster    bool
-	bucketCount int
-	buckets     map[int]struct{}
+	buckets map[int]struct{}
 
 	lastBucketlist atomic.Value //time.Time
 	lastChangefeed atomic.Value //time.Time
@@ -50,13 +48,19 @@ type monitor struct {
 	liveConfig       liveconfig.Manager
 	hiveShardConfigs map[int]*rest.Config
 	shardMutex       sync.RWMutex
+
+	collectorConfigs cluster.CollectorConfigs
+
+	securityPostureMinimumVersion *version.Version
+
+	pool *pool
 }
 
 type Runnable interface {
 	Run(context.Context) error
 }
 
-func NewMonitor(log *logrus.Entry, dialer proxy.Dialer, dbMonitors database.Monitors, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, m, clusterm metrics.Emitter, liveConfig liveconfig.Manager, e env.Interface) Runnable {
+func NewMonitor(log *logrus.Entry, dialer proxy.Dialer, dbMonitors database.Monitors, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, dbBilling database.Billing, m, clusterm metrics.Emitter, liveConfig liveconfig.Manager, e env.Interface, collectorConfigs cluster.CollectorConfigs, securityPostureMinimumVersion *version.Version) Runnable {
 	return &monitor{
 		baseLog: log,
 		dialer:  dialer,
@@ -64,6 +68,7 @@ func NewMonitor(log *logrus.Entry, dialer proxy.Dialer, dbMonitors database.Moni
 		dbMonitors:          dbMonitors,
 		dbOpenShiftClusters: dbOpenShiftClusters,
 		dbSubscriptions:     dbSubscriptions,
+		dbBilling:           dbBilling,
 
 		m:        m,
 		clusterm: clusterm,
@@ -71,48 +76,43 @@ func NewMonitor(log *logrus.Entry, dialer proxy.Dialer, dbMonitors database.Moni
 		subs:     map[string]*api.SubscriptionDocument{},
 		env:      e,
 
-		bucketCount: bucket.Buckets,
-		buckets:     map[int]struct{}{},
+		buckets: map[int]struct{}{},
 
 		startTime: time.Now(),
 
 		liveConfig: liveConfig,
 
 		hiveShardConfigs: map[int]*rest.Config{},
+
+		collectorConfigs: collectorConfigs,
+
+		securityPostureMinimumVersion: securityPostureMinimumVersion,
+
+		pool: newPool(log.WithField("component", "pool"), m, poolWorkers, poolQueueLength, poolMaxWait),
 	}
 }
 
 func (mon *monitor) Run(ctx context.Context) error {
-	_, err := mon.dbMonitors.Create(ctx, &api.MonitorDocument{
-		ID: "master",
-	})
-	if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusPreconditionFailed) {
-		return err
-	}
-
 	// fill the cache from the database change feed
 	go mon.changefeed(ctx, mon.baseLog.WithField("component", "changefeed"), nil)
 
+	// run cluster monitoring on a bounded pool of workers
+	go mon.pool.run(ctx)
+
 	t := time.NewTicker(10 * time.Second)
 	defer t.Stop()
 
 	go heartbeat.EmitHeartbeat(mon.baseLog, mon.m, "monitor.heartbeat", nil, mon.checkReady)
 
 	for {
-		// register ourself as a monitor
-		err = mon.dbMonitors.MonitorHeartbeat(ctx)
-		if err != nil {
-			mon.baseLog.Error(err)
-		}
-
-		// try to become master and share buckets across registered monitors
-		err = mon.master(ctx)
+		// register ourself as a monitor, recording the buckets we own
+		err := mon.dbMonitors.MonitorHeartbeat(ctx, mon.ownedBuckets())
 		if err != nil {
 			mon.baseLog.Error(err)
 		}
 
-		// read our bucket allocation from the master
-		err = mon.listBuckets(ctx)
+		// work out our share of the buckets from the registered monitors
+		err = mon.shard(ctx)
 		if err != nil {
 			mon.baseLog.Error(err)
 		} else {
@@ -0,0 +1,153 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const (
+	// poolWorkers is the number of clusters monitored concurrently.  Memory
+	// use is dominated by in-flight monitoring runs (REST clients and cached
+	// API objects), so this bounds the monitor's memory however many clusters
+	// it owns.
+	poolWorkers = 500
+
+	// poolQueueLength is the number of runs which may wait for a worker.  Runs
+	// submitted when the queue is full are shed.
+	poolQueueLength = 5000
+
+	// poolMaxWait is how long a run may wait for a worker.  Runs which wait
+	// longer are shed rather than run late; the cluster's next run will be
+	// submitted on its next tick.
+	poolMaxWait = fastMonitoringInterval
+)
+
+// pool is a fixed-size pool of goroutines which run monitoring jobs.  When
+// the monitor cannot keep up, e.g. because cluster API servers are slow to
+// respond, runs are shed instead of accumulating.
+type pool struct {
+	log *logrus.Entry
+	m   metrics.Emitter
+
+	workers int
+	maxWait time.Duration
+	queue   chan *job
+	active  atomic.Int64
+
+	now func() time.Time
+}
+
+type job struct {
+	f      func()
+	queued time.Time
+	stop   <-chan struct{}
+	ran    chan bool
+}
+
+func newPool(log *logrus.Entry, m metrics.Emitter, workers, queueLength int, maxWait time.Duration) *pool {
+	return &pool{
+		log: log,
+		m:   m,
+
+		workers: workers,
+		maxWait: maxWait,
+		queue:   make(chan *job, queueLength),
+
+		now: time.Now,
+	}
+}
+
+// run starts the pool's workers and emits its queue depth and number of
+// active runs until ctx is done
+func (p *pool) run(ctx context.Context) {
+	for i := 0; i < p.workers; i++ {
+		go p.worker(ctx)
+	}
+
+	t := time.NewTicker(10 * time.Second)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			p.m.EmitGauge("monitor.pool.queued", int64(len(p.queue)), nil)
+			p.m.EmitGauge("monitor.pool.active", p.active.Load(), nil)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *pool) worker(ctx context.Context) {
+	for {
+		select {
+		case j := <-p.queue:
+			j.ran <- p.execute(j)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// execute runs j unless its caller has stopped waiting for it or it has
+// waited too long, and returns whether it ran
+func (p *pool) execute(j *job) bool {
+	select {
+	case <-j.stop:
+		return false
+	default:
+	}
+
+	if p.now().Sub(j.queued) > p.maxWait {
+		p.m.EmitGauge("monitor.pool.shed", 1, map[string]string{
+			"reason": "expired",
+		})
+		return false
+	}
+
+	p.active.Add(1)
+	defer p.active.Add(-1)
+	defer recover.Panic(p.log)
+
+	j.f()
+
+	return true
+}
+
+// do runs f on the pool and waits for it to complete or for stop to be
+// closed.  It returns false if f was shed, either because the queue was full
+// or because it waited too long for a worker, or if stop was closed first, in
+// which case f is not started if it is still queued.
+func (p *pool) do(stop <-chan struct{}, f func()) bool {
+	j := &job{
+		f:      f,
+		queued: p.now(),
+		stop:   stop,
+		ran:    make(chan bool, 1),
+	}
+
+	select {
+	case p.queue <- j:
+	default:
+		p.m.EmitGauge("monitor.pool.shed", 1, map[string]string{
+			"reason": "full",
+		})
+		return false
+	}
+
+	select {
+	case ran := <-j.ran:
+		return ran
+	case <-stop:
+		return false
+	}
+}
@@ -0,0 +1,105 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestPoolDo(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	p := newPool(utillog.GetLogger(), m, 1, 1, time.Minute)
+	go p.run(ctx)
+
+	var ran bool
+	if !p.do(nil, func() { ran = true }) {
+		t.Error("expected job to run")
+	}
+	if !ran {
+		t.Error("job did not run")
+	}
+
+	if p.do(nil, func() { panic("boom") }) {
+		t.Error("expected panicking job to report it did not complete")
+	}
+
+	// the pool survives a panicking job
+	if !p.do(nil, func() {}) {
+		t.Error("expected job to run")
+	}
+}
+
+func TestPoolDoStop(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+
+	// no workers are running, so the job stays queued
+	p := newPool(utillog.GetLogger(), m, 0, 1, time.Minute)
+
+	stop := make(chan struct{})
+	close(stop)
+
+	if p.do(stop, func() { t.Error("stopped job ran") }) {
+		t.Error("expected job to report it did not run")
+	}
+
+	// a worker picking up the job afterwards does not run it
+	if p.execute(<-p.queue) {
+		t.Error("expected job to be skipped")
+	}
+}
+
+func TestPoolShedsWhenFull(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("monitor.pool.shed", int64(1), map[string]string{"reason": "full"})
+
+	// no workers are running, so the first job fills the queue
+	p := newPool(utillog.GetLogger(), m, 0, 1, time.Minute)
+	p.queue <- &job{}
+
+	if p.do(nil, func() { t.Error("shed job ran") }) {
+		t.Error("expected job to be shed")
+	}
+}
+
+func TestPoolShedsExpired(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("monitor.pool.shed", int64(1), map[string]string{"reason": "expired"})
+
+	now := time.Now()
+	p := newPool(utillog.GetLogger(), m, 0, 1, time.Minute)
+	p.now = func() time.Time { return now }
+
+	j := &job{
+		f:      func() { t.Error("expired job ran") },
+		queued: now.Add(-2 * time.Minute),
+	}
+
+	if p.execute(j) {
+		t.Error("expected job to be shed")
+	}
+}
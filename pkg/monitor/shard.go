@@ -0,0 +1,99 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/util/bucket"
+)
+
+// shard works out which buckets we own by placing all the advertised monitors,
+// including ourself, on a consistent hash ring.  When a monitor joins or
+// leaves, only the buckets it gains or loses change hands, so the rest of the
+// fleet carries on monitoring undisturbed.  Buckets are weighted by the
+// monitoring tier of their clusters, so that no monitor ends up with much more
+// than its share of fast-tier clusters.
+func (mon *monitor) shard(ctx context.Context) error {
+	docs, err := mon.dbMonitors.ListMonitors(ctx)
+	if err != nil {
+		return err
+	}
+
+	id := mon.dbMonitors.MonitorID()
+
+	// we may not have been advertised yet, but we're definitely alive
+	monitors := []string{id}
+	if docs != nil {
+		for _, doc := range docs.MonitorDocuments {
+			if doc.ID != id {
+				monitors = append(monitors, doc.ID)
+			}
+		}
+	}
+
+	// weights are evaluated on a minute boundary so that monitors agree on
+	// which clusters have just left the fast tier
+	mon.mu.RLock()
+	weights := mon.bucketWeights(time.Now().Truncate(time.Minute))
+	mon.mu.RUnlock()
+
+	buckets := bucket.NewRing(monitors).BalancedBuckets(id, weights)
+
+	mon.m.EmitGauge("monitor.shard.monitors", int64(len(monitors)), nil)
+	mon.m.EmitGauge("monitor.shard.buckets", int64(len(buckets)), nil)
+
+	mon.setBuckets(buckets)
+
+	return nil
+}
+
+// setBuckets replaces the set of buckets we own
+func (mon *monitor) setBuckets(buckets []int) {
+	mon.mu.Lock()
+	defer mon.mu.Unlock()
+
+	newBuckets := make(map[int]struct{}, len(buckets))
+	for _, i := range buckets {
+		newBuckets[i] = struct{}{}
+	}
+
+	var moved int
+	for i := range newBuckets {
+		if _, found := mon.buckets[i]; !found {
+			moved++
+		}
+	}
+	for i := range mon.buckets {
+		if _, found := newBuckets[i]; !found {
+			moved++
+		}
+	}
+
+	if moved == 0 {
+		return
+	}
+
+	mon.buckets = newBuckets
+
+	mon.m.EmitGauge("monitor.shard.moved", int64(moved), nil)
+	mon.baseLog.Printf("servicing %d buckets (%d moved)", len(mon.buckets), moved)
+	mon.fixDocs()
+}
+
+// ownedBuckets returns the buckets we own, in order
+func (mon *monitor) ownedBuckets() []int {
+	mon.mu.RLock()
+	defer mon.mu.RUnlock()
+
+	buckets := make([]int, 0, len(mon.buckets))
+	for i := range mon.buckets {
+		buckets = append(buckets, i)
+	}
+	sort.Ints(buckets)
+
+	return buckets
+}
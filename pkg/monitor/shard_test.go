@@ -0,0 +1,107 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/util/bucket"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+type fakeMonitors struct {
+	database.Monitors
+	id       string
+	monitors []string
+}
+
+func (f *fakeMonitors) ListMonitors(context.Context) (*api.MonitorDocuments, error) {
+	docs := &api.MonitorDocuments{}
+	for _, id := range f.monitors {
+		docs.MonitorDocuments = append(docs.MonitorDocuments, &api.MonitorDocument{ID: id})
+	}
+	return docs, nil
+}
+
+func (f *fakeMonitors) MonitorID() string {
+	return f.id
+}
+
+func TestShard(t *testing.T) {
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+
+	dbMonitors := &fakeMonitors{
+		id:       "one",
+		monitors: []string{"two"}, // we haven't been advertised yet
+	}
+
+	mon := &monitor{
+		baseLog:    logrus.NewEntry(logrus.StandardLogger()),
+		dbMonitors: dbMonitors,
+		m:          m,
+		docs:       map[string]*cacheDoc{},
+		buckets:    map[int]struct{}{},
+	}
+
+	want := bucket.NewRing([]string{"one", "two"}).Buckets("one")
+
+	m.EXPECT().EmitGauge("monitor.shard.monitors", int64(2), nil)
+	m.EXPECT().EmitGauge("monitor.shard.buckets", int64(len(want)), nil)
+	m.EXPECT().EmitGauge("monitor.shard.moved", int64(len(want)), nil)
+
+	err := mon.shard(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mon.ownedBuckets(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// a third monitor joins: we only lose buckets to it
+	dbMonitors.monitors = []string{"one", "two", "three"}
+	after := bucket.NewRing(dbMonitors.monitors).Buckets("one")
+
+	m.EXPECT().EmitGauge("monitor.shard.monitors", int64(3), nil)
+	m.EXPECT().EmitGauge("monitor.shard.buckets", int64(len(after)), nil)
+	m.EXPECT().EmitGauge("monitor.shard.moved", int64(len(want)-len(after)), nil)
+
+	err = mon.shard(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := map[int]struct{}{}
+	for _, i := range want {
+		before[i] = struct{}{}
+	}
+	for _, i := range after {
+		if _, found := before[i]; !found {
+			t.Errorf("gained bucket %d", i)
+		}
+	}
+	if got := mon.ownedBuckets(); !reflect.DeepEqual(got, after) {
+		t.Fatalf("got %v, want %v", got, after)
+	}
+
+	// nothing changes: no buckets move
+	m.EXPECT().EmitGauge("monitor.shard.monitors", int64(3), nil)
+	m.EXPECT().EmitGauge("monitor.shard.buckets", int64(len(after)), nil)
+
+	err = mon.shard(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,64 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/bucket"
+)
+
+const (
+	// fastMonitoringInterval is used for clusters which are not in steady
+	// state, so that problems are detected quickly
+	fastMonitoringInterval = time.Minute
+
+	// slowMonitoringInterval is used for healthy clusters which have not been
+	// changed recently, to reduce load on their API servers
+	slowMonitoringInterval = 10 * time.Minute
+
+	// recentlyUpdatedWindow is how long after a customer change a cluster is
+	// kept in the fast tier
+	recentlyUpdatedWindow = time.Hour
+)
+
+// monitoringInterval returns how often the cluster should be monitored, based
+// on its state at time now
+func monitoringInterval(doc *api.OpenShiftClusterDocument, now time.Time) time.Duration {
+	oc := doc.OpenShiftCluster
+
+	if oc.Properties.ProvisioningState != api.ProvisioningStateSucceeded {
+		return fastMonitoringInterval
+	}
+
+	if oc.SystemData.LastModifiedAt != nil && now.Sub(*oc.SystemData.LastModifiedAt) < recentlyUpdatedWindow {
+		return fastMonitoringInterval
+	}
+
+	return slowMonitoringInterval
+}
+
+// monitoringWeight returns the relative cost of monitoring the cluster, used
+// to balance buckets across monitors
+func monitoringWeight(doc *api.OpenShiftClusterDocument, now time.Time) int {
+	return int(slowMonitoringInterval / monitoringInterval(doc, now))
+}
+
+// bucketWeights returns the total monitoring weight of the clusters in each
+// bucket.  Every monitor caches every cluster, so all monitors arrive at the
+// same weights.  Caller must hold mon.mu.RLock.
+func (mon *monitor) bucketWeights(now time.Time) []int {
+	weights := make([]int, bucket.Buckets)
+
+	for _, v := range mon.docs {
+		if v.doc.Bucket < 0 || v.doc.Bucket >= bucket.Buckets {
+			continue
+		}
+
+		weights[v.doc.Bucket] += monitoringWeight(v.doc, now)
+	}
+
+	return weights
+}
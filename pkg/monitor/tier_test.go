@@ -0,0 +1,104 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+func TestMonitoringInterval(t *testing.T) {
+	now := time.Now()
+	recently := now.Add(-10 * time.Minute)
+	longAgo := now.Add(-2 * time.Hour)
+
+	for _, tt := range []struct {
+		name              string
+		provisioningState api.ProvisioningState
+		lastModifiedAt    *time.Time
+		want              time.Duration
+	}{
+		{
+			name:              "steady state",
+			provisioningState: api.ProvisioningStateSucceeded,
+			lastModifiedAt:    &longAgo,
+			want:              slowMonitoringInterval,
+		},
+		{
+			name:              "never modified",
+			provisioningState: api.ProvisioningStateSucceeded,
+			want:              slowMonitoringInterval,
+		},
+		{
+			name:              "recently modified",
+			provisioningState: api.ProvisioningStateSucceeded,
+			lastModifiedAt:    &recently,
+			want:              fastMonitoringInterval,
+		},
+		{
+			name:              "failed",
+			provisioningState: api.ProvisioningStateFailed,
+			lastModifiedAt:    &longAgo,
+			want:              fastMonitoringInterval,
+		},
+		{
+			name:              "updating",
+			provisioningState: api.ProvisioningStateAdminUpdating,
+			want:              fastMonitoringInterval,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &api.OpenShiftClusterDocument{
+				OpenShiftCluster: &api.OpenShiftCluster{
+					SystemData: api.SystemData{
+						LastModifiedAt: tt.lastModifiedAt,
+					},
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: tt.provisioningState,
+					},
+				},
+			}
+
+			got := monitoringInterval(doc, now)
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBucketWeights(t *testing.T) {
+	now := time.Now()
+
+	doc := func(id string, bucket int, provisioningState api.ProvisioningState) *cacheDoc {
+		return &cacheDoc{
+			doc: &api.OpenShiftClusterDocument{
+				ID:     id,
+				Bucket: bucket,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: provisioningState,
+					},
+				},
+			},
+		}
+	}
+
+	mon := &monitor{
+		docs: map[string]*cacheDoc{
+			"healthy": doc("healthy", 1, api.ProvisioningStateSucceeded),
+			"failed":  doc("failed", 1, api.ProvisioningStateFailed),
+			"other":   doc("other", 2, api.ProvisioningStateSucceeded),
+			"invalid": doc("invalid", -1, api.ProvisioningStateFailed),
+		},
+	}
+
+	weights := mon.bucketWeights(now)
+
+	if weights[1] != 11 || weights[2] != 1 || weights[0] != 0 {
+		t.Error(weights[:3])
+	}
+}
@@ -6,7 +6,6 @@ package monitor
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"sync"
 	"time"
 
@@ -15,7 +14,11 @@ import (
 	"k8s.io/client-go/rest"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/monitor/azure/drift"
+	"github.com/Azure/ARO-RP/pkg/monitor/azure/metering"
 	"github.com/Azure/ARO-RP/pkg/monitor/azure/nsg"
+	"github.com/Azure/ARO-RP/pkg/monitor/azure/resourcehealth"
+	"github.com/Azure/ARO-RP/pkg/monitor/azure/securityposture"
 	"github.com/Azure/ARO-RP/pkg/monitor/cluster"
 	"github.com/Azure/ARO-RP/pkg/monitor/dimension"
 	"github.com/Azure/ARO-RP/pkg/monitor/monitoring"
@@ -27,6 +30,23 @@ import (
 // nsgMonitoringFrequency is used for initializing NSG monitoring ticker
 var nsgMonitoringFrequency = 10 * time.Minute
 
+// resourceHealthMonitoringFrequency is used for initializing the Azure resource
+// health monitoring ticker
+var resourceHealthMonitoringFrequency = 10 * time.Minute
+
+// driftMonitoringFrequency is used for initializing the drift monitoring
+// ticker.  Drift is not urgent, so it is checked less often.
+var driftMonitoringFrequency = time.Hour
+
+// securityPostureMonitoringFrequency is used for initializing the security
+// posture monitoring ticker.  Defender for Cloud refreshes its posture view
+// far less often than this.
+var securityPostureMonitoringFrequency = time.Hour
+
+// meteringMonitoringFrequency is used for initializing the metering monitoring
+// ticker.  Usage is reported to the Marketplace Metering API by the hour.
+var meteringMonitoringFrequency = time.Hour
+
 // This function will continue to run until such time as it has a config to add to the global Hive shard map
 // Note that because the mon.hiveShardConfigs[shard] is set to `nil` when its created, the cluster
 // monitors will simply ignore Hive stats until this function populates the config
@@ -51,28 +71,6 @@ func (mon *monitor) populateHiveShardRestConfig(ctx context.Context, shard int)
 	}
 }
 
-// listBuckets reads our bucket allocation from the master
-func (mon *monitor) listBuckets(ctx context.Context) error {
-	buckets, err := mon.dbMonitors.ListBuckets(ctx)
-
-	mon.mu.Lock()
-	defer mon.mu.Unlock()
-
-	oldBuckets := mon.buckets
-	mon.buckets = make(map[int]struct{}, len(buckets))
-
-	for _, i := range buckets {
-		mon.buckets[i] = struct{}{}
-	}
-
-	if !reflect.DeepEqual(mon.buckets, oldBuckets) {
-		mon.baseLog.Printf("servicing %d buckets", len(mon.buckets))
-		mon.fixDocs()
-	}
-
-	return err
-}
-
 // changefeed tracks the OpenShiftClusters change feed and keeps mon.docs
 // up-to-date.  We don't monitor clusters in Creating state, hence we don't add
 // them to mon.docs.  We also don't monitor clusters in Deleting state; when
@@ -199,10 +197,19 @@ func (mon *monitor) worker(stop <-chan struct{}, delay time.Duration, id string)
 
 	nsgMonitoringTicker := time.NewTicker(nsgMonitoringFrequency)
 	defer nsgMonitoringTicker.Stop()
-	t := time.NewTicker(time.Minute)
+	resourceHealthMonitoringTicker := time.NewTicker(resourceHealthMonitoringFrequency)
+	defer resourceHealthMonitoringTicker.Stop()
+	driftMonitoringTicker := time.NewTicker(driftMonitoringFrequency)
+	defer driftMonitoringTicker.Stop()
+	securityPostureMonitoringTicker := time.NewTicker(securityPostureMonitoringFrequency)
+	defer securityPostureMonitoringTicker.Stop()
+	meteringMonitoringTicker := time.NewTicker(meteringMonitoringFrequency)
+	defer meteringMonitoringTicker.Stop()
+	t := time.NewTicker(fastMonitoringInterval)
 	defer t.Stop()
 
 	h := time.Now().Hour()
+	var lastRun time.Time
 
 out:
 	for {
@@ -215,13 +222,24 @@ out:
 			break
 		}
 
-		newh := time.Now().Hour()
-
-		// TODO: later can modify here to poll once per N minutes and re-issue
-		// cached metrics in the remaining minutes
-
-		if sub != nil && sub.Subscription != nil && sub.Subscription.State != api.SubscriptionStateSuspended && sub.Subscription.State != api.SubscriptionStateWarned {
-			mon.workOne(context.Background(), log, v.doc, sub, newh != h, nsgMonitoringTicker)
+		now := time.Now()
+		newh := now.Hour()
+
+		// the tier is re-evaluated on every tick so that a cluster which
+		// stops being healthy moves to the fast tier straight away.  Allow
+		// half a tick of slack so that ticker jitter doesn't skip a run.
+		due := now.Sub(lastRun) > monitoringInterval(v.doc, now)-fastMonitoringInterval/2
+
+		if due && sub != nil && sub.Subscription != nil && sub.Subscription.State != api.SubscriptionStateSuspended && sub.Subscription.State != api.SubscriptionStateWarned {
+			// if the run is shed because the pool is saturated, lastRun is
+			// not updated, so it is retried on the next tick
+			ran := mon.pool.do(stop, func() {
+				mon.workOne(context.Background(), log, v.doc, sub, newh != h, nsgMonitoringTicker, resourceHealthMonitoringTicker, driftMonitoringTicker, securityPostureMonitoringTicker, meteringMonitoringTicker)
+			})
+			if ran {
+				lastRun = now
+				h = newh
+			}
 		}
 
 		select {
@@ -229,15 +247,13 @@ out:
 		case <-stop:
 			break out
 		}
-
-		h = newh
 	}
 
 	log.Debug("stopping monitoring")
 }
 
 // workOne checks the API server health of a cluster
-func (mon *monitor) workOne(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument, sub *api.SubscriptionDocument, hourlyRun bool, nsgMonTicker *time.Ticker) {
+func (mon *monitor) workOne(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument, sub *api.SubscriptionDocument, hourlyRun bool, nsgMonTicker, resourceHealthMonTicker, driftMonTicker, securityPostureMonTicker, meteringMonTicker *time.Ticker) {
 	ctx, cancel := context.WithTimeout(ctx, 50*time.Second)
 	defer cancel()
 
@@ -266,16 +282,28 @@ func (mon *monitor) workOne(ctx context.Context, log *logrus.Entry, doc *api.Ope
 
 	nsgMon := nsg.NewMonitor(log, doc.OpenShiftCluster, mon.env, sub.ID, sub.Subscription.Properties.TenantID, mon.clusterm, dims, &wg, nsgMonTicker.C)
 
-	c, err := cluster.NewMonitor(log, restConfig, doc.OpenShiftCluster, mon.clusterm, hiveRestConfig, hourlyRun, &wg)
+	resourceHealthMon := resourcehealth.NewMonitor(log, doc.OpenShiftCluster, mon.env, sub.ID, sub.Subscription.Properties.TenantID, mon.clusterm, dims, &wg, resourceHealthMonTicker.C)
+
+	driftMon := drift.NewMonitor(log, doc.OpenShiftCluster, mon.env, sub.ID, sub.Subscription.Properties.TenantID, mon.clusterm, dims, &wg, driftMonTicker.C)
+
+	securityPostureMon := securityposture.NewMonitor(log, doc.OpenShiftCluster, mon.env, sub, mon.securityPostureMinimumVersion, mon.clusterm, dims, &wg, securityPostureMonTicker.C)
+
+	meteringMon := metering.NewMonitor(log, doc, mon.env, sub.ID, sub.Subscription.Properties.TenantID, mon.clusterm, dims, &wg, meteringMonTicker.C, mon.dbBilling)
+
+	// the Azure monitors above have consumed their triggers and do not need
+	// the API server, so they still run if the cluster monitor can't be built
+	monitors = append(monitors, nsgMon, resourceHealthMon, driftMon, securityPostureMon, meteringMon)
+
+	c, err := cluster.NewMonitor(log, restConfig, doc.OpenShiftCluster, mon.clusterm, hiveRestConfig, hourlyRun, mon.collectorConfigs, &wg)
 	if err != nil {
 		log.Error(err)
 		mon.m.EmitGauge("monitor.cluster.failedworker", 1, map[string]string{
 			"resourceId": doc.OpenShiftCluster.ID,
 		})
-		return
+	} else {
+		monitors = append(monitors, c)
 	}
 
-	monitors = append(monitors, c, nsgMon)
 	allJobsDone := make(chan bool)
 	go execute(ctx, allJobsDone, &wg, monitors)
 
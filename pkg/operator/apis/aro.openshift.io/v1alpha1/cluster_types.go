@@ -29,6 +29,10 @@ const (
 	DefaultIngressCertificate = "DefaultIngressCertificate"
 	DefaultClusterDNS         = "DefaultClusterDNS"
 	GuardRailsStatus          = "GuardRailsStatus"
+
+	// TLSSecurityProfileCompliant is false while the API server or ingress
+	// allows TLS versions or ciphers weaker than the Intermediate profile
+	TLSSecurityProfileCompliant = "TLSSecurityProfileCompliant"
 )
 
 // AllConditionTypes is a operator conditions currently in use, any condition not in this list is not
@@ -43,6 +47,7 @@ func AllConditionTypes() []string {
 		DefaultIngressCertificate,
 		DefaultClusterDNS,
 		GuardRailsStatus,
+		TLSSecurityProfileCompliant,
 	}
 }
 
@@ -111,11 +116,15 @@ type ClusterSpec struct {
 	VnetID                   string              `json:"vnetId,omitempty"`
 	APIIntIP                 string              `json:"apiIntIP,omitempty"`
 	IngressIP                string              `json:"ingressIP,omitempty"`
+	IngressIPv6              string              `json:"ingressIPv6,omitempty"`
 	GatewayDomains           []string            `json:"gatewayDomains,omitempty"`
 	GatewayPrivateEndpointIP string              `json:"gatewayPrivateEndpointIP,omitempty"`
 	Banner                   Banner              `json:"banner,omitempty"`
 	ServiceSubnets           []string            `json:"serviceSubnets,omitempty"`
 
+	// MaxNodes is the maximum worker count of a large cluster, or zero
+	MaxNodes int `json:"maxNodes,omitempty"`
+
 	// OperatorFlags defines feature gates for the ARO Operator
 	OperatorFlags OperatorFlags `json:"operatorflags,omitempty"`
 }
@@ -130,6 +139,34 @@ type ClusterStatus struct {
 	OperatorVersion   string                         `json:"operatorVersion,omitempty"`
 	Conditions        []operatorv1.OperatorCondition `json:"conditions,omitempty"`
 	RedHatKeysPresent []string                       `json:"redHatKeysPresent,omitempty"`
+
+	// ConditionHistory holds the most recent transitions of each condition,
+	// oldest first, so that flapping conditions can be diagnosed
+	ConditionHistory []ConditionTransition `json:"conditionHistory,omitempty"`
+
+	// NodeReservations holds the resources which the kubelet reserves for the
+	// system and for Kubernetes on the nodes of each MachineConfigPool whose
+	// reservations the operator manages
+	NodeReservations []NodeReservation `json:"nodeReservations,omitempty"`
+}
+
+// ConditionTransition records a change of a condition's status, reason or
+// message
+type ConditionTransition struct {
+	Type    string                     `json:"type"`
+	Status  operatorv1.ConditionStatus `json:"status"`
+	Reason  string                     `json:"reason,omitempty"`
+	Message string                     `json:"message,omitempty"`
+	Time    metav1.Time                `json:"time"`
+}
+
+// NodeReservation records the resources reserved on the nodes of a
+// MachineConfigPool, and the VM size they were computed from
+type NodeReservation struct {
+	MachineConfigPool string            `json:"machineConfigPool"`
+	VMSize            string            `json:"vmSize"`
+	SystemReserved    map[string]string `json:"systemReserved,omitempty"`
+	KubeReserved      map[string]string `json:"kubeReserved,omitempty"`
 }
 
 // Cluster is the Schema for the clusters API
@@ -73,7 +73,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	defaultConfig := makeConfig()
+	defaultConfig := makeConfig(aro.Spec.OperatorFlags.GetSimpleBoolean(operator.NodeReservationEnabled))
 
 	var config mcv1.KubeletConfig
 	err = r.client.Get(ctx, key, &config)
@@ -117,21 +117,33 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func makeConfig() mcv1.KubeletConfig {
+// makeConfig returns the KubeletConfig which turns on auto sized nodes on the
+// built-in MachineConfigPools, or only on the master one if the NodeReservation
+// controller manages the reservations of the workers
+func makeConfig(workersReserved bool) mcv1.KubeletConfig {
+	selector := &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      "machineconfiguration.openshift.io/mco-built-in",
+				Operator: metav1.LabelSelectorOpExists,
+			},
+		},
+	}
+	if workersReserved {
+		selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"pools.operator.machineconfiguration.openshift.io/master": "",
+			},
+		}
+	}
+
 	return mcv1.KubeletConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: configName,
 		},
 		Spec: mcv1.KubeletConfigSpec{
-			AutoSizingReserved: to.BoolPtr(true),
-			MachineConfigPoolSelector: &metav1.LabelSelector{
-				MatchExpressions: []metav1.LabelSelectorRequirement{
-					{
-						Key:      "machineconfiguration.openshift.io/mco-built-in",
-						Operator: metav1.LabelSelectorOpExists,
-					},
-				},
-			},
+			AutoSizingReserved:        to.BoolPtr(true),
+			MachineConfigPoolSelector: selector,
 		},
 	}
 }
@@ -43,8 +43,12 @@ func TestAutosizednodesReconciler(t *testing.T) {
 		}
 	}
 
+	aroWithNodeReservation := aro(true)
+	aroWithNodeReservation.Spec.OperatorFlags[operator.NodeReservationEnabled] = operator.FlagTrue
+
 	emptyConfig := mcv1.KubeletConfig{}
-	config := makeConfig()
+	config := makeConfig(false)
+	masterConfig := makeConfig(true)
 
 	tests := []struct {
 		name       string
@@ -68,6 +72,11 @@ func TestAutosizednodesReconciler(t *testing.T) {
 			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(true), &config).Build(),
 			wantConfig: &config,
 		},
+		{
+			name:       "is needed on masters only when the workers' reservations are managed",
+			client:     fake.NewClientBuilder().WithRuntimeObjects(aroWithNodeReservation, &config).Build(),
+			wantConfig: &masterConfig,
+		},
 		{
 			name:       "is not needed and is present",
 			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(false), &config).Build(),
@@ -7,3 +7,6 @@ package autosizednodes
 // that tells machine-config-operator to turn on auto sized nodes feature
 // the code that is executed by the mco:
 // - https://github.com/openshift/machine-config-operator/blob/fbc4d8e46a7746442f4de3651113d2181d458b12/templates/common/_base/files/kubelet-auto-sizing.yaml
+//
+// while the NodeReservation controller manages the reservations of the
+// workers, the KubeletConfig only selects the master MachineConfigPool
@@ -25,7 +25,7 @@ const (
 	prescriptFileName = "aro-dnsmasq-pre.sh"
 )
 
-func config(clusterDomain, apiIntIP, ingressIP string, gatewayDomains []string, gatewayPrivateEndpointIP string) ([]byte, error) {
+func config(clusterDomain, apiIntIP, ingressIP, ingressIPv6 string, gatewayDomains []string, gatewayPrivateEndpointIP string) ([]byte, error) {
 	t := template.Must(template.New(configFileName).Parse(configFile))
 	buf := &bytes.Buffer{}
 
@@ -33,12 +33,14 @@ func config(clusterDomain, apiIntIP, ingressIP string, gatewayDomains []string,
 		ClusterDomain            string
 		APIIntIP                 string
 		IngressIP                string
+		IngressIPv6              string
 		GatewayDomains           []string
 		GatewayPrivateEndpointIP string
 	}{
 		ClusterDomain:            clusterDomain,
 		APIIntIP:                 apiIntIP,
 		IngressIP:                ingressIP,
+		IngressIPv6:              ingressIPv6,
 		GatewayDomains:           gatewayDomains,
 		GatewayPrivateEndpointIP: gatewayPrivateEndpointIP,
 	})
@@ -73,13 +75,13 @@ func startpre() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func ignition3Config(clusterDomain, apiIntIP, ingressIP string, gatewayDomains []string, gatewayPrivateEndpointIP string, restartDnsmasq bool) (*ign3types.Config, error) {
+func ignition3Config(clusterDomain, apiIntIP, ingressIP, ingressIPv6 string, gatewayDomains []string, gatewayPrivateEndpointIP string, restartDnsmasq bool) (*ign3types.Config, error) {
 	service, err := service()
 	if err != nil {
 		return nil, err
 	}
 
-	config, err := config(clusterDomain, apiIntIP, ingressIP, gatewayDomains, gatewayPrivateEndpointIP)
+	config, err := config(clusterDomain, apiIntIP, ingressIP, ingressIPv6, gatewayDomains, gatewayPrivateEndpointIP)
 	if err != nil {
 		return nil, err
 	}
@@ -156,8 +158,8 @@ func ignition3Config(clusterDomain, apiIntIP, ingressIP string, gatewayDomains [
 	return ign, nil
 }
 
-func dnsmasqMachineConfig(clusterDomain, apiIntIP, ingressIP, role string, gatewayDomains []string, gatewayPrivateEndpointIP string, restartDnsmasq bool) (*mcv1.MachineConfig, error) {
-	ignConfig, err := ignition3Config(clusterDomain, apiIntIP, ingressIP, gatewayDomains, gatewayPrivateEndpointIP, restartDnsmasq)
+func dnsmasqMachineConfig(clusterDomain, apiIntIP, ingressIP, ingressIPv6, role string, gatewayDomains []string, gatewayPrivateEndpointIP string, restartDnsmasq bool) (*mcv1.MachineConfig, error) {
+	ignConfig, err := ignition3Config(clusterDomain, apiIntIP, ingressIP, ingressIPv6, gatewayDomains, gatewayPrivateEndpointIP, restartDnsmasq)
 	if err != nil {
 		return nil, err
 	}
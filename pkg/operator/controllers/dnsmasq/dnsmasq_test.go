@@ -0,0 +1,60 @@
+package dnsmasq
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		ingressIPv6 string
+		want        string
+	}{
+		{
+			name: "single-stack",
+			want: `
+resolv-file=/etc/resolv.conf.dnsmasq
+strict-order
+address=/api.cluster.example.com/10.0.0.1
+address=/api-int.cluster.example.com/10.0.0.1
+address=/.apps.cluster.example.com/1.2.3.4
+user=dnsmasq
+group=dnsmasq
+no-hosts
+cache-size=0
+`,
+		},
+		{
+			name:        "dual-stack",
+			ingressIPv6: "2001:db8::1",
+			want: `
+resolv-file=/etc/resolv.conf.dnsmasq
+strict-order
+address=/api.cluster.example.com/10.0.0.1
+address=/api-int.cluster.example.com/10.0.0.1
+address=/.apps.cluster.example.com/1.2.3.4
+address=/.apps.cluster.example.com/2001:db8::1
+user=dnsmasq
+group=dnsmasq
+no-hosts
+cache-size=0
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := config("cluster.example.com", "10.0.0.1", "1.2.3.4", tt.ingressIPv6, nil, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, diff := range deep.Equal(string(b), tt.want) {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -7,8 +7,8 @@ package dnsmasq
 // exist and are correctly configured to lay down dnsmasq configuration on
 // cluster VMs.  The data path is:
 //
-// * RP sets Domain, APIIntIP, IngressIP fields on the ARO Cluster object at
-//   admin upgrade time.
+// * RP sets Domain, APIIntIP, IngressIP and, on dual-stack clusters,
+//   IngressIPv6 fields on the ARO Cluster object at admin upgrade time.
 //
 // * ClusterReconciler controller ensures MachineConfigs exist.
 //
@@ -30,3 +30,10 @@ package dnsmasq
 //
 // Internally, all of the above functions call the same reconciler
 // (reconcileMachineConfigs).
+//
+// func (*PrivateDNSZoneReconciler) Reconcile watches MachineConfigPool objects
+// and the cluster DNS config.  When aro.dnsmasq.privatednszoneremoval.enabled
+// is set and every node runs the 99-%s-aro-dns machineconfig, it removes the
+// cluster's private DNS zone from the DNS config so that the ingress operator
+// stops publishing records into it.  The RP then deletes the zone at the next
+// admin update.
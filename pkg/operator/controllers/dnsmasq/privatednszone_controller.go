@@ -0,0 +1,200 @@
+package dnsmasq
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/ready"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+const (
+	PrivateDNSZoneControllerName = "DnsmasqPrivateDNSZone"
+)
+
+type PrivateDNSZoneReconciler struct {
+	base.AROController
+}
+
+func NewPrivateDNSZoneReconciler(log *logrus.Entry, client client.Client) *PrivateDNSZoneReconciler {
+	return &PrivateDNSZoneReconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   PrivateDNSZoneControllerName,
+		},
+	}
+}
+
+// Reconcile moves the cluster off the managed private DNS zone once every
+// node resolves the cluster domains locally through dnsmasq.  It does this by
+// removing the private zone from the cluster DNS config, which stops the
+// ingress operator from writing records into it.  The RP deletes the zone
+// itself once it is no longer referenced.
+func (r *PrivateDNSZoneReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(operator.DnsmasqEnabled) ||
+		!instance.Spec.OperatorFlags.GetSimpleBoolean(operator.PrivateDNSZoneRemovalEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	dns := &configv1.DNS{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: "cluster"}, dns)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	if !referencesClusterPrivateZone(dns, instance.Spec.ClusterResourceGroupID) {
+		r.ClearConditions(ctx)
+		return reconcile.Result{}, nil
+	}
+
+	msg, err := r.readyForRemoval(ctx)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+	if msg != "" {
+		r.Log.Info(msg)
+		r.SetProgressing(ctx, msg)
+		r.ClearDegraded(ctx)
+		return reconcile.Result{}, nil
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		dns := &configv1.DNS{}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: "cluster"}, dns)
+		if err != nil {
+			return err
+		}
+
+		if !referencesClusterPrivateZone(dns, instance.Spec.ClusterResourceGroupID) {
+			return nil
+		}
+
+		dns.Spec.PrivateZone = nil
+
+		return r.Client.Update(ctx, dns)
+	})
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.Log.Info("removed private DNS zone from cluster DNS config")
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+// readyForRemoval returns a non-empty message describing why it is not yet
+// safe to stop using the private DNS zone.
+func (r *PrivateDNSZoneReconciler) readyForRemoval(ctx context.Context) (string, error) {
+	mcps := &mcv1.MachineConfigPoolList{}
+	err := r.Client.List(ctx, mcps)
+	if err != nil {
+		return "", err
+	}
+
+	var machineCount int
+	for _, mcp := range mcps.Items {
+		var found bool
+		for _, source := range mcp.Status.Configuration.Source {
+			if source.Name == "99-"+mcp.Name+"-aro-dns" {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Sprintf("ARO DNS config not found in MCP %s", mcp.Name), nil
+		}
+
+		if !ready.MachineConfigPoolIsReady(&mcp) {
+			return fmt.Sprintf("MCP %s not ready", mcp.Name), nil
+		}
+
+		machineCount += int(mcp.Status.MachineCount)
+	}
+
+	nodes := &corev1.NodeList{}
+	err = r.Client.List(ctx, nodes)
+	if err != nil {
+		return "", err
+	}
+
+	if len(nodes.Items) != machineCount {
+		return fmt.Sprintf("cluster has %d nodes but %d under MCPs", len(nodes.Items), machineCount), nil
+	}
+
+	cv := &configv1.ClusterVersion{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: "version"}, cv)
+	if err != nil {
+		return "", err
+	}
+
+	v, err := version.GetClusterVersion(cv)
+	if err != nil {
+		return "", err
+	}
+
+	if v.Lt(version.NewVersion(4, 4)) {
+		// 4.3 uses SRV records for etcd
+		return "cluster version < 4.4", nil
+	}
+
+	return "", nil
+}
+
+func referencesClusterPrivateZone(dns *configv1.DNS, clusterResourceGroupID string) bool {
+	return dns.Spec.PrivateZone != nil &&
+		strings.HasPrefix(strings.ToLower(dns.Spec.PrivateZone.ID), strings.ToLower(clusterResourceGroupID))
+}
+
+// SetupWithManager setup our mananger
+func (r *PrivateDNSZoneReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	clusterDNSPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == "cluster"
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(&source.Kind{Type: &configv1.DNS{}}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(clusterDNSPredicate)).
+		Watches(&source.Kind{Type: &mcv1.MachineConfigPool{}}, &handler.EnqueueRequestForObject{}). // to reconcile on MCP rollout progress
+		Named(PrivateDNSZoneControllerName).
+		Complete(r)
+}
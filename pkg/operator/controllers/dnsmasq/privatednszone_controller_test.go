@@ -0,0 +1,220 @@
+package dnsmasq
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestPrivateDNSZoneReconciler(t *testing.T) {
+	const (
+		resourceGroupID = "/subscriptions/0000000-0000-0000-0000-000000000000/resourceGroups/testGroup"
+		zoneID          = resourceGroupID + "/providers/Microsoft.Network/privateDnsZones/zone1"
+	)
+
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(PrivateDNSZoneControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(PrivateDNSZoneControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(PrivateDNSZoneControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	cluster := func(removalEnabled string) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				ClusterResourceGroupID: resourceGroupID,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					operator.DnsmasqEnabled:               operator.FlagTrue,
+					operator.PrivateDNSZoneRemovalEnabled: removalEnabled,
+				},
+			},
+		}
+	}
+
+	dns := func(zoneID string) *configv1.DNS {
+		dns := &configv1.DNS{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		}
+		if zoneID != "" {
+			dns.Spec.PrivateZone = &configv1.DNSZone{ID: zoneID}
+		}
+		return dns
+	}
+
+	mcp := func(name string, withDNSConfig bool, readyMachineCount int32) *mcv1.MachineConfigPool {
+		mcp := &mcv1.MachineConfigPool{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: mcv1.MachineConfigPoolStatus{
+				MachineCount:        1,
+				UpdatedMachineCount: 1,
+				ReadyMachineCount:   readyMachineCount,
+			},
+		}
+		if withDNSConfig {
+			mcp.Status.Configuration.Source = []corev1.ObjectReference{
+				{Name: "99-" + name + "-aro-dns"},
+			}
+		}
+		return mcp
+	}
+
+	node := func(name string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	clusterVersion := &configv1.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "version"},
+		Status: configv1.ClusterVersionStatus{
+			History: []configv1.UpdateHistory{
+				{
+					State:   configv1.CompletedUpdate,
+					Version: "4.12.25",
+				},
+			},
+		},
+	}
+
+	progressing := func(message string) []operatorv1.OperatorCondition {
+		cnd := defaultProgressing
+		cnd.Status = operatorv1.ConditionTrue
+		cnd.Message = message
+		return []operatorv1.OperatorCondition{defaultAvailable, cnd, defaultDegraded}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		objects        []client.Object
+		wantZoneID     string
+		wantErrMsg     string
+		wantConditions []operatorv1.OperatorCondition
+	}{
+		{
+			name:       "no cluster",
+			wantErrMsg: `clusters.aro.openshift.io "cluster" not found`,
+		},
+		{
+			name: "controller disabled",
+			objects: []client.Object{
+				cluster(operator.FlagFalse),
+				dns(zoneID),
+			},
+			wantZoneID: zoneID,
+		},
+		{
+			name: "no private zone configured",
+			objects: []client.Object{
+				cluster(operator.FlagTrue),
+				dns(""),
+			},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "private zone not owned by the cluster is left alone",
+			objects: []client.Object{
+				cluster(operator.FlagTrue),
+				dns("/subscriptions/0000000-0000-0000-0000-000000000000/resourceGroups/other/providers/Microsoft.Network/privateDnsZones/zone1"),
+			},
+			wantZoneID:     "/subscriptions/0000000-0000-0000-0000-000000000000/resourceGroups/other/providers/Microsoft.Network/privateDnsZones/zone1",
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "dnsmasq config not yet rendered",
+			objects: []client.Object{
+				cluster(operator.FlagTrue),
+				dns(zoneID),
+				mcp("master", false, 1),
+			},
+			wantZoneID:     zoneID,
+			wantConditions: progressing("ARO DNS config not found in MCP master"),
+		},
+		{
+			name: "pool not yet ready",
+			objects: []client.Object{
+				cluster(operator.FlagTrue),
+				dns(zoneID),
+				mcp("master", true, 0),
+			},
+			wantZoneID:     zoneID,
+			wantConditions: progressing("MCP master not ready"),
+		},
+		{
+			name: "node not under a pool",
+			objects: []client.Object{
+				cluster(operator.FlagTrue),
+				dns(zoneID),
+				mcp("master", true, 1),
+				node("master-0"),
+				node("orphan"),
+			},
+			wantZoneID:     zoneID,
+			wantConditions: progressing("cluster has 2 nodes but 1 under MCPs"),
+		},
+		{
+			name: "all pools rolled out, private zone removed",
+			objects: []client.Object{
+				cluster(operator.FlagTrue),
+				dns(zoneID),
+				mcp("master", true, 1),
+				mcp("worker", true, 1),
+				node("master-0"),
+				node("worker-0"),
+				clusterVersion,
+			},
+			wantConditions: defaultConditions,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			r := NewPrivateDNSZoneReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+
+			if tt.wantErrMsg != "" {
+				return
+			}
+
+			got := &configv1.DNS{}
+			err = client.Get(ctx, types.NamespacedName{Name: "cluster"}, got)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotZoneID string
+			if got.Spec.PrivateZone != nil {
+				gotZoneID = got.Spec.PrivateZone.ID
+			}
+			if gotZoneID != tt.wantZoneID {
+				t.Errorf("got private zone %q, wanted %q", gotZoneID, tt.wantZoneID)
+			}
+		})
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/Azure/go-autorest/autorest/azure"
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/types"
@@ -131,7 +130,7 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-// Switch case to ensure the correct registries are added depending on the cloud environment (Gov or Public cloud)
+// GetCloudAwareRegistries ensures the correct registries are added depending on the cloud environment
 func GetCloudAwareRegistries(instance *arov1alpha1.Cluster) ([]string, error) {
 	var replicationRegistry string
 	var dnsSuffix string
@@ -142,14 +141,11 @@ func GetCloudAwareRegistries(instance *arov1alpha1.Cluster) ([]string, error) {
 		return nil, fmt.Errorf("azure container registry domain is not present or is malformed")
 	}
 
-	switch instance.Spec.AZEnvironment {
-	case azureclient.PublicCloud.Environment.Name:
-		dnsSuffix = azure.PublicCloud.ContainerRegistryDNSSuffix
-	case azureclient.USGovernmentCloud.Environment.Name:
-		dnsSuffix = azure.USGovernmentCloud.ContainerRegistryDNSSuffix
-	default:
-		return nil, fmt.Errorf("cloud environment %s is not supported", instance.Spec.AZEnvironment)
+	azEnv, err := azureclient.EnvironmentFromName(instance.Spec.AZEnvironment)
+	if err != nil {
+		return nil, err
 	}
+	dnsSuffix = azEnv.ContainerRegistryDNSSuffix
 	replicationRegistry = fmt.Sprintf("%s.%s.data.%s", acrSubdomain, instance.Spec.Location, dnsSuffix)
 	return []string{acrDomain, replicationRegistry}, nil
 }
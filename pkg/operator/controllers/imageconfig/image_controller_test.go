@@ -361,7 +361,7 @@ func TestGetCloudAwareRegistries(t *testing.T) {
 					Location:      "eastus",
 				},
 			},
-			wantErr: "cloud environment FakeCloud is not supported",
+			wantErr: `cloud environment "FakeCloud" is unsupported by ARO`,
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,45 @@
+package infranodes
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package creates and maintains dedicated infra nodes and
+moves the default ingress controller, the image registry and the monitoring
+stack onto them, following the infrastructure MachineSet best practice in the
+OpenShift documentation.
+
+For each MachineSet which the installer created for the workers, i.e. one per
+zone, the controller creates a copy named <infraID>-infra-<region><zone>.
+Between them they run three VMs, which are labelled and tainted with
+node-role.kubernetes.io/infra, so that only workloads which tolerate the taint
+run on them.  The replica counts of the infra MachineSets are only set when
+they are created.
+
+The VM size of the infra nodes follows the number of workers:
+
+- up to 25 workers: Standard_E4s_v3
+- up to 100 workers: Standard_E8s_v3
+- up to 250 workers: Standard_E16s_v3
+- more than 250 workers: Standard_E32s_v3
+
+The controller only ever sizes infra nodes up, and leaves infra MachineSets
+whose VM size has been changed to one not listed above alone.  When it sizes
+up a MachineSet, it replaces its Machines one at a time.
+
+Once at least three infra nodes are ready, the controller sets the node
+selector and tolerations of the default ingress controller, of the image
+registry and of the components of the monitoring stack.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.infranodes.enabled:
+- When set to false, the controller will noop
+- When set to true, the controller will create and maintain the infra nodes
+  and move workloads onto them
+
+More information on infrastructure MachineSets can be found here:
+https://docs.openshift.com/container-platform/4.12/machine_management/creating-infrastructure-machinesets.html
+
+*/
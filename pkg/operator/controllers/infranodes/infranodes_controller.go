@@ -0,0 +1,123 @@
+package infranodes
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/sirupsen/logrus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "InfraNodes"
+
+	// requeueInterval is how often the controller checks on infra nodes which
+	// are being created or resized, as it does not watch nodes
+	requeueInterval = time.Minute
+)
+
+// Reconciler creates and sizes the infra MachineSets, and moves ingress,
+// registry and monitoring workloads onto the infra nodes once they are ready
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(operator.InfraNodesEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+
+	settled, err := r.reconcileMachineSets(ctx)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	ready, err := r.infraNodesReady(ctx)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	if !ready {
+		r.SetProgressing(ctx, "waiting for infra nodes to be ready")
+		return reconcile.Result{RequeueAfter: requeueInterval}, nil
+	}
+
+	for _, f := range []func(context.Context) error{
+		r.reconcileIngressController,
+		r.reconcileImageRegistry,
+		r.reconcileMonitoring,
+	} {
+		err = f(ctx)
+		if err != nil {
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+			return reconcile.Result{}, err
+		}
+	}
+
+	if !settled {
+		r.SetProgressing(ctx, "resizing infra nodes")
+		return reconcile.Result{RequeueAfter: requeueInterval}, nil
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager setup the mananger for the worker and infra MachineSets
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	machineSetPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		role := o.GetLabels()[machineRoleLabel]
+		return o.GetNamespace() == machineSetsNamespace && (role == workerRole || role == infraRole)
+	})
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &machinev1beta1.MachineSet{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(machineSetPredicate),
+		)
+
+	return builder.Named(ControllerName).Complete(r)
+}
@@ -0,0 +1,423 @@
+package infranodes
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	imageregistryv1 "github.com/openshift/api/imageregistry/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+	"github.com/Azure/ARO-RP/test/util/machine"
+)
+
+func machineSetFixture(t *testing.T, name, role, vmSize, zone string, spot bool) *machinev1beta1.MachineSet {
+	ms := machine.MachineSet(t, name, vmSize, 1, map[string]string{
+		clusterIDLabel:   "infra",
+		machineRoleLabel: role,
+		machineTypeLabel: role,
+	}, nil)
+	ms.Spec.Selector.MatchLabels[clusterIDLabel] = "infra"
+
+	spec := &machinev1beta1.AzureMachineProviderSpec{
+		Location: "eastus",
+		VMSize:   vmSize,
+	}
+	if zone != "" {
+		spec.Zone = to.StringPtr(zone)
+	}
+	if spot {
+		spec.SpotVMOptions = &machinev1beta1.SpotVMOptions{}
+	}
+	ms.Spec.Template.Spec.ProviderSpec.Value = machine.ProviderSpec(t, spec)
+
+	if role == infraRole {
+		ms.Spec.Template.Spec.Labels = map[string]string{infraNodeRoleLabel: ""}
+		ms.Spec.Template.Spec.Taints = []corev1.Taint{infraTaint}
+	}
+
+	return ms
+}
+
+func nodeFixtures(role string, count int) []client.Object {
+	var nodes []client.Object
+	for i := 0; i < count; i++ {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("%s-%d", role, i),
+				Labels: map[string]string{
+					workerNodeRoleLabel: "",
+				},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:   corev1.NodeReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+		if role == infraRole {
+			node.Labels[infraNodeRoleLabel] = ""
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	installerMachineSets := func() []client.Object {
+		return []client.Object{
+			machineSetFixture(t, "infra-worker-eastus1", workerRole, "Standard_D4s_v3", "1", false),
+			machineSetFixture(t, "infra-worker-eastus2", workerRole, "Standard_D4s_v3", "2", false),
+			machineSetFixture(t, "infra-worker-eastus3", workerRole, "Standard_D4s_v3", "3", false),
+			machineSetFixture(t, "infra-worker-spot-eastus1", workerRole, "Standard_D4s_v3", "1", true),
+			machineSetFixture(t, "custom", workerRole, "Standard_D4s_v3", "1", false),
+		}
+	}
+
+	infraMachineSets := func(vmSize string) []client.Object {
+		return []client.Object{
+			machineSetFixture(t, "infra-infra-eastus1", infraRole, vmSize, "1", false),
+			machineSetFixture(t, "infra-infra-eastus2", infraRole, vmSize, "2", false),
+			machineSetFixture(t, "infra-infra-eastus3", infraRole, vmSize, "3", false),
+		}
+	}
+
+	for _, tt := range []struct {
+		name             string
+		enabled          string
+		objects          []client.Object
+		wantInfraVMSize  map[string]string
+		wantReplicas     map[string]int32
+		wantMachines     []string
+		wantRequeue      bool
+		wantWorkloadsSet bool
+		wantErr          string
+	}{
+		{
+			name:    "controller disabled",
+			enabled: operator.FlagFalse,
+			objects: append(installerMachineSets(), nodeFixtures(workerRole, 3)...),
+		},
+		{
+			name:    "infra MachineSets are created in each zone",
+			enabled: operator.FlagTrue,
+			objects: append(installerMachineSets(), nodeFixtures(workerRole, 3)...),
+			wantInfraVMSize: map[string]string{
+				"infra-infra-eastus1": "Standard_E4s_v3",
+				"infra-infra-eastus2": "Standard_E4s_v3",
+				"infra-infra-eastus3": "Standard_E4s_v3",
+			},
+			wantReplicas: map[string]int32{
+				"infra-infra-eastus1": 1,
+				"infra-infra-eastus2": 1,
+				"infra-infra-eastus3": 1,
+			},
+			wantRequeue: true,
+		},
+		{
+			name:    "a non-zonal cluster gets one infra MachineSet",
+			enabled: operator.FlagTrue,
+			objects: append([]client.Object{
+				machineSetFixture(t, "infra-worker-eastus", workerRole, "Standard_D4s_v3", "", false),
+			}, nodeFixtures(workerRole, 30)...),
+			wantInfraVMSize: map[string]string{
+				"infra-infra-eastus": "Standard_E8s_v3",
+			},
+			wantReplicas: map[string]int32{
+				"infra-infra-eastus": 3,
+			},
+			wantRequeue: true,
+		},
+		{
+			name:    "workloads are moved once the infra nodes are ready",
+			enabled: operator.FlagTrue,
+			objects: append(append(append(installerMachineSets(), infraMachineSets("Standard_E4s_v3")...), nodeFixtures(workerRole, 3)...), nodeFixtures(infraRole, 3)...),
+			wantInfraVMSize: map[string]string{
+				"infra-infra-eastus1": "Standard_E4s_v3",
+				"infra-infra-eastus2": "Standard_E4s_v3",
+				"infra-infra-eastus3": "Standard_E4s_v3",
+			},
+			wantReplicas: map[string]int32{
+				"infra-infra-eastus1": 1,
+				"infra-infra-eastus2": 1,
+				"infra-infra-eastus3": 1,
+			},
+			wantWorkloadsSet: true,
+		},
+		{
+			name:    "infra nodes are sized up as the workers grow",
+			enabled: operator.FlagTrue,
+			objects: append(append(append(append(installerMachineSets(), infraMachineSets("Standard_E4s_v3")...), nodeFixtures(workerRole, 26)...), nodeFixtures(infraRole, 3)...),
+				machine.Machine(t, "infra-infra-eastus1-a", "infra-infra-eastus1", "Standard_E4s_v3", machinePhaseRunning),
+				machine.Machine(t, "infra-infra-eastus2-a", "infra-infra-eastus2", "Standard_E4s_v3", machinePhaseRunning),
+				machine.Machine(t, "infra-infra-eastus3-a", "infra-infra-eastus3", "Standard_E8s_v3", "Provisioned"),
+			),
+			wantInfraVMSize: map[string]string{
+				"infra-infra-eastus1": "Standard_E8s_v3",
+				"infra-infra-eastus2": "Standard_E8s_v3",
+				"infra-infra-eastus3": "Standard_E8s_v3",
+			},
+			wantReplicas: map[string]int32{
+				"infra-infra-eastus1": 1,
+				"infra-infra-eastus2": 1,
+				"infra-infra-eastus3": 1,
+			},
+			wantMachines:     []string{"infra-infra-eastus3-a"},
+			wantRequeue:      true,
+			wantWorkloadsSet: true,
+		},
+		{
+			name:    "infra nodes are not sized down",
+			enabled: operator.FlagTrue,
+			objects: append(append(append(installerMachineSets(), infraMachineSets("Standard_E16s_v3")...), nodeFixtures(workerRole, 3)...), nodeFixtures(infraRole, 3)...),
+			wantInfraVMSize: map[string]string{
+				"infra-infra-eastus1": "Standard_E16s_v3",
+				"infra-infra-eastus2": "Standard_E16s_v3",
+				"infra-infra-eastus3": "Standard_E16s_v3",
+			},
+			wantReplicas: map[string]int32{
+				"infra-infra-eastus1": 1,
+				"infra-infra-eastus2": 1,
+				"infra-infra-eastus3": 1,
+			},
+			wantWorkloadsSet: true,
+		},
+		{
+			name:    "no worker MachineSets",
+			enabled: operator.FlagTrue,
+			objects: []client.Object{
+				machineSetFixture(t, "custom", workerRole, "Standard_D4s_v3", "1", false),
+			},
+			wantErr: "no worker MachineSets found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					OperatorFlags: arov1alpha1.OperatorFlags{
+						operator.InfraNodesEnabled: tt.enabled,
+					},
+				},
+			}
+
+			objects := append([]client.Object{
+				cluster,
+				&operatorv1.IngressController{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      ingressControllerName.Name,
+						Namespace: ingressControllerName.Namespace,
+					},
+				},
+				&imageregistryv1.Config{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: imageRegistryName.Name,
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      monitoringName.Name,
+						Namespace: monitoringName.Namespace,
+					},
+					Data: map[string]string{
+						"config.yaml": "enableUserWorkload: true\nprometheusK8s:\n  retention: 15d\n",
+					},
+				},
+			}, tt.objects...)
+
+			clientFake := ctrlfake.NewClientBuilder().WithObjects(objects...).Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+
+			result, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if (result.RequeueAfter != 0) != tt.wantRequeue {
+				t.Errorf("got requeue after %s, want requeue %t", result.RequeueAfter, tt.wantRequeue)
+			}
+
+			machineSets := &machinev1beta1.MachineSetList{}
+			err = clientFake.List(ctx, machineSets, client.MatchingLabels{machineRoleLabel: infraRole})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(machineSets.Items) != len(tt.wantInfraVMSize) {
+				t.Errorf("got %d infra MachineSets, want %d", len(machineSets.Items), len(tt.wantInfraVMSize))
+			}
+
+			for i := range machineSets.Items {
+				ms := &machineSets.Items[i]
+
+				spec, err := machineSetProviderSpec(ms)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if spec.VMSize != tt.wantInfraVMSize[ms.Name] {
+					t.Errorf("%s: got VM size %s, want %s", ms.Name, spec.VMSize, tt.wantInfraVMSize[ms.Name])
+				}
+				if *ms.Spec.Replicas != tt.wantReplicas[ms.Name] {
+					t.Errorf("%s: got replicas %d, want %d", ms.Name, *ms.Spec.Replicas, tt.wantReplicas[ms.Name])
+				}
+				if ms.Spec.Selector.MatchLabels[machineSetLabel] != ms.Name ||
+					ms.Spec.Template.Labels[machineSetLabel] != ms.Name ||
+					ms.Spec.Template.Labels[machineRoleLabel] != infraRole {
+					t.Errorf("%s: got wrong selector or template labels", ms.Name)
+				}
+				if _, ok := ms.Spec.Template.Spec.Labels[infraNodeRoleLabel]; !ok {
+					t.Errorf("%s: infra node label missing", ms.Name)
+				}
+				if !reflect.DeepEqual(ms.Spec.Template.Spec.Taints, []corev1.Taint{infraTaint}) {
+					t.Errorf("%s: got taints %#v", ms.Name, ms.Spec.Template.Spec.Taints)
+				}
+			}
+
+			machines := &machinev1beta1.MachineList{}
+			err = clientFake.List(ctx, machines)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, machine := range machines.Items {
+				names = append(names, machine.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantMachines) {
+				t.Errorf("got Machines %v, want %v", names, tt.wantMachines)
+			}
+
+			ingress := &operatorv1.IngressController{}
+			err = clientFake.Get(ctx, ingressControllerName, ingress)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (ingress.Spec.NodePlacement != nil) != tt.wantWorkloadsSet {
+				t.Errorf("got IngressController node placement %#v", ingress.Spec.NodePlacement)
+			}
+
+			config := &imageregistryv1.Config{}
+			err = clientFake.Get(ctx, imageRegistryName, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (config.Spec.NodeSelector != nil) != tt.wantWorkloadsSet {
+				t.Errorf("got image registry node selector %#v", config.Spec.NodeSelector)
+			}
+
+			cm := &corev1.ConfigMap{}
+			err = clientFake.Get(ctx, monitoringName, cm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantConfig := "enableUserWorkload: true\nprometheusK8s:\n  retention: 15d\n"
+			if tt.wantWorkloadsSet {
+				wantConfig = monitoringConfigWithPlacement
+			}
+			if cm.Data["config.yaml"] != wantConfig {
+				t.Errorf("got monitoring config\n%s\nwant\n%s", cm.Data["config.yaml"], wantConfig)
+			}
+		})
+	}
+}
+
+const monitoringConfigWithPlacement = `alertmanagerMain:
+  nodeSelector:
+    node-role.kubernetes.io/infra: ""
+  tolerations:
+  - effect: NoSchedule
+    key: node-role.kubernetes.io/infra
+    operator: Exists
+enableUserWorkload: true
+k8sPrometheusAdapter:
+  nodeSelector:
+    node-role.kubernetes.io/infra: ""
+  tolerations:
+  - effect: NoSchedule
+    key: node-role.kubernetes.io/infra
+    operator: Exists
+kubeStateMetrics:
+  nodeSelector:
+    node-role.kubernetes.io/infra: ""
+  tolerations:
+  - effect: NoSchedule
+    key: node-role.kubernetes.io/infra
+    operator: Exists
+openshiftStateMetrics:
+  nodeSelector:
+    node-role.kubernetes.io/infra: ""
+  tolerations:
+  - effect: NoSchedule
+    key: node-role.kubernetes.io/infra
+    operator: Exists
+prometheusK8s:
+  nodeSelector:
+    node-role.kubernetes.io/infra: ""
+  retention: 15d
+  tolerations:
+  - effect: NoSchedule
+    key: node-role.kubernetes.io/infra
+    operator: Exists
+prometheusOperator:
+  nodeSelector:
+    node-role.kubernetes.io/infra: ""
+  tolerations:
+  - effect: NoSchedule
+    key: node-role.kubernetes.io/infra
+    operator: Exists
+telemeterClient:
+  nodeSelector:
+    node-role.kubernetes.io/infra: ""
+  tolerations:
+  - effect: NoSchedule
+    key: node-role.kubernetes.io/infra
+    operator: Exists
+thanosQuerier:
+  nodeSelector:
+    node-role.kubernetes.io/infra: ""
+  tolerations:
+  - effect: NoSchedule
+    key: node-role.kubernetes.io/infra
+    operator: Exists
+`
+
+func TestInfraVMSize(t *testing.T) {
+	for _, tt := range []struct {
+		workers int
+		want    api.VMSize
+	}{
+		{workers: 3, want: api.VMSizeStandardE4sV3},
+		{workers: 25, want: api.VMSizeStandardE4sV3},
+		{workers: 26, want: api.VMSizeStandardE8sV3},
+		{workers: 250, want: api.VMSizeStandardE16sV3},
+		{workers: 251, want: api.VMSizeStandardE32sV3},
+	} {
+		t.Run(fmt.Sprint(tt.workers), func(t *testing.T) {
+			if got := infraVMSize(tt.workers); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package infranodes
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+const (
+	machineSetsNamespace = "openshift-machine-api"
+	clusterIDLabel       = "machine.openshift.io/cluster-api-cluster"
+	machineSetLabel      = "machine.openshift.io/cluster-api-machineset"
+	machineRoleLabel     = "machine.openshift.io/cluster-api-machine-role"
+	machineTypeLabel     = "machine.openshift.io/cluster-api-machine-type"
+	infraNodeRoleLabel   = "node-role.kubernetes.io/infra"
+	workerNodeRoleLabel  = "node-role.kubernetes.io/worker"
+
+	workerRole = "worker"
+	infraRole  = "infra"
+
+	// infraNodeCount is the number of infra nodes, spread across the zones
+	// of the cluster
+	infraNodeCount = 3
+
+	machinePhaseRunning = "Running"
+)
+
+// infraVMSizes are the VM sizes of the infra nodes by the number of workers,
+// smallest first
+var infraVMSizes = []struct {
+	maxWorkers int
+	vmSize     api.VMSize
+}{
+	{maxWorkers: 25, vmSize: api.VMSizeStandardE4sV3},
+	{maxWorkers: 100, vmSize: api.VMSizeStandardE8sV3},
+	{maxWorkers: 250, vmSize: api.VMSizeStandardE16sV3},
+	{maxWorkers: math.MaxInt32, vmSize: api.VMSizeStandardE32sV3},
+}
+
+// infraTaint keeps workloads which do not tolerate it off the infra nodes
+var infraTaint = corev1.Taint{
+	Key:    infraNodeRoleLabel,
+	Effect: corev1.TaintEffectNoSchedule,
+}
+
+// reconcileMachineSets creates an infra MachineSet for each MachineSet which
+// the installer created for the workers, and sizes up the existing ones if the
+// number of workers has grown.  It returns false while infra Machines are
+// still being replaced.
+func (r *Reconciler) reconcileMachineSets(ctx context.Context) (bool, error) {
+	machineSets := &machinev1beta1.MachineSetList{}
+	err := r.Client.List(ctx, machineSets, client.InNamespace(machineSetsNamespace))
+	if err != nil {
+		return false, err
+	}
+
+	workers, err := r.workerNodeCount(ctx)
+	if err != nil {
+		return false, err
+	}
+	vmSize := infraVMSize(workers)
+
+	var templates []*machinev1beta1.MachineSet
+	infra := map[string]*machinev1beta1.MachineSet{}
+	for i := range machineSets.Items {
+		ms := &machineSets.Items[i]
+
+		switch ms.Labels[machineRoleLabel] {
+		case workerRole:
+			installed, err := isInstallerMachineSet(ms)
+			if err != nil {
+				return false, err
+			}
+			if installed {
+				templates = append(templates, ms)
+			}
+		case infraRole:
+			infra[ms.Name] = ms
+		}
+	}
+
+	if len(templates) == 0 {
+		return false, fmt.Errorf("no worker MachineSets found")
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	if len(templates) > infraNodeCount {
+		templates = templates[:infraNodeCount]
+	}
+
+	settled := true
+	for i, template := range templates {
+		name, err := infraMachineSetName(template)
+		if err != nil {
+			return false, err
+		}
+
+		if ms, ok := infra[name]; ok {
+			resized, err := r.resizeMachineSet(ctx, ms, vmSize)
+			if err != nil {
+				return false, err
+			}
+			settled = settled && resized
+			continue
+		}
+
+		replicas := infraNodeCount / len(templates)
+		if i < infraNodeCount%len(templates) {
+			replicas++
+		}
+
+		ms, err := infraMachineSet(template, name, vmSize, int32(replicas))
+		if err != nil {
+			return false, err
+		}
+
+		r.Log.Infof("creating MachineSet %s", name)
+		err = r.Client.Create(ctx, ms)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return settled, nil
+}
+
+// resizeMachineSet sizes up ms to vmSize and replaces its Machines of an older
+// size one at a time.  It returns true once every Machine of ms is of its size.
+func (r *Reconciler) resizeMachineSet(ctx context.Context, ms *machinev1beta1.MachineSet, vmSize api.VMSize) (bool, error) {
+	providerSpec, err := machineSetProviderSpec(ms)
+	if err != nil {
+		return false, err
+	}
+
+	current := infraVMSizeIndex(api.VMSize(providerSpec.VMSize))
+	if current != -1 && current < infraVMSizeIndex(vmSize) {
+		r.Log.Infof("resizing MachineSet %s from %s to %s", ms.Name, providerSpec.VMSize, vmSize)
+
+		providerSpec.VMSize = string(vmSize)
+		b, err := json.Marshal(providerSpec)
+		if err != nil {
+			return false, err
+		}
+		ms.Spec.Template.Spec.ProviderSpec.Value = &kruntime.RawExtension{Raw: b}
+
+		err = r.Client.Update(ctx, ms)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	machines := &machinev1beta1.MachineList{}
+	err = r.Client.List(ctx, machines, client.InNamespace(machineSetsNamespace), client.MatchingLabels{machineSetLabel: ms.Name})
+	if err != nil {
+		return false, err
+	}
+
+	var old *machinev1beta1.Machine
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+
+		// wait for any deletion to complete
+		if machine.DeletionTimestamp != nil {
+			return false, nil
+		}
+
+		size, err := machineVMSize(machine)
+		if err != nil {
+			return false, err
+		}
+
+		if size != providerSpec.VMSize {
+			if old == nil {
+				old = machine
+			}
+			continue
+		}
+
+		// wait for the Machines of the new size to run
+		if machine.Status.Phase == nil || *machine.Status.Phase != machinePhaseRunning {
+			return false, nil
+		}
+	}
+
+	if old == nil {
+		return true, nil
+	}
+
+	r.Log.Infof("deleting Machine %s", old.Name)
+	return false, r.Client.Delete(ctx, old)
+}
+
+// workerNodeCount returns the number of workers which are not infra nodes
+func (r *Reconciler) workerNodeCount(ctx context.Context) (int, error) {
+	selector, err := labels.Parse(workerNodeRoleLabel + ",!" + infraNodeRoleLabel)
+	if err != nil {
+		return 0, err
+	}
+
+	nodes := &corev1.NodeList{}
+	err = r.Client.List(ctx, nodes, &client.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(nodes.Items), nil
+}
+
+// infraNodesReady returns true once enough infra nodes are ready to move
+// workloads onto them
+func (r *Reconciler) infraNodesReady(ctx context.Context) (bool, error) {
+	nodes := &corev1.NodeList{}
+	err := r.Client.List(ctx, nodes, client.HasLabels{infraNodeRoleLabel})
+	if err != nil {
+		return false, err
+	}
+
+	var ready int
+	for _, node := range nodes.Items {
+		for _, c := range node.Status.Conditions {
+			if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue {
+				ready++
+			}
+		}
+	}
+
+	return ready >= infraNodeCount, nil
+}
+
+// isInstallerMachineSet returns true if ms was created by the installer for
+// the workers, i.e. it is named <infraID>-worker-<region><zone> and does not
+// use Spot VMs.  The MachineSets of other worker profiles and those which the
+// customer has created are not.
+func isInstallerMachineSet(ms *machinev1beta1.MachineSet) (bool, error) {
+	providerSpec, err := machineSetProviderSpec(ms)
+	if err != nil {
+		return false, err
+	}
+
+	if providerSpec.SpotVMOptions != nil {
+		return false, nil
+	}
+
+	return ms.Name == machineSetName(ms.Labels[clusterIDLabel], workerRole, providerSpec), nil
+}
+
+// infraMachineSetName returns the name of the infra MachineSet in the zone of
+// template
+func infraMachineSetName(template *machinev1beta1.MachineSet) (string, error) {
+	providerSpec, err := machineSetProviderSpec(template)
+	if err != nil {
+		return "", err
+	}
+
+	return machineSetName(template.Labels[clusterIDLabel], infraRole, providerSpec), nil
+}
+
+// machineSetName names a MachineSet as the installer does
+func machineSetName(infraID, role string, providerSpec *machinev1beta1.AzureMachineProviderSpec) string {
+	var zone string
+	if providerSpec.Zone != nil {
+		zone = *providerSpec.Zone
+	}
+
+	return infraID + "-" + role + "-" + providerSpec.Location + zone
+}
+
+// infraMachineSet returns a copy of template named name which creates replicas
+// tainted infra nodes of vmSize
+func infraMachineSet(template *machinev1beta1.MachineSet, name string, vmSize api.VMSize, replicas int32) (*machinev1beta1.MachineSet, error) {
+	providerSpec, err := machineSetProviderSpec(template)
+	if err != nil {
+		return nil, err
+	}
+
+	providerSpec.VMSize = string(vmSize)
+
+	b, err := json.Marshal(providerSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &machinev1beta1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: machineSetsNamespace,
+			Labels:    map[string]string{},
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	for k, v := range template.Labels {
+		ms.Labels[k] = v
+	}
+	ms.Labels[machineRoleLabel] = infraRole
+	ms.Labels[machineTypeLabel] = infraRole
+
+	ms.Spec.Replicas = &replicas
+	ms.Spec.Selector = metav1.LabelSelector{
+		MatchLabels: map[string]string{},
+	}
+	for k, v := range template.Spec.Selector.MatchLabels {
+		ms.Spec.Selector.MatchLabels[k] = v
+	}
+	ms.Spec.Selector.MatchLabels[machineSetLabel] = name
+
+	if ms.Spec.Template.Labels == nil {
+		ms.Spec.Template.Labels = map[string]string{}
+	}
+	ms.Spec.Template.Labels[machineSetLabel] = name
+	ms.Spec.Template.Labels[machineRoleLabel] = infraRole
+	ms.Spec.Template.Labels[machineTypeLabel] = infraRole
+
+	if ms.Spec.Template.Spec.Labels == nil {
+		ms.Spec.Template.Spec.Labels = map[string]string{}
+	}
+	ms.Spec.Template.Spec.Labels[infraNodeRoleLabel] = ""
+	ms.Spec.Template.Spec.Taints = []corev1.Taint{infraTaint}
+
+	ms.Spec.Template.Spec.ProviderSpec.Value = &kruntime.RawExtension{Raw: b}
+
+	return ms, nil
+}
+
+// infraVMSize returns the VM size of the infra nodes of a cluster with workers
+// workers
+func infraVMSize(workers int) api.VMSize {
+	for _, s := range infraVMSizes {
+		if workers <= s.maxWorkers {
+			return s.vmSize
+		}
+	}
+	return infraVMSizes[len(infraVMSizes)-1].vmSize
+}
+
+// infraVMSizeIndex returns the index of vmSize in infraVMSizes, or -1 if it
+// is not one of them
+func infraVMSizeIndex(vmSize api.VMSize) int {
+	for i, s := range infraVMSizes {
+		if s.vmSize == vmSize {
+			return i
+		}
+	}
+	return -1
+}
+
+func machineSetProviderSpec(ms *machinev1beta1.MachineSet) (*machinev1beta1.AzureMachineProviderSpec, error) {
+	if ms.Spec.Template.Spec.ProviderSpec.Value == nil {
+		return nil, fmt.Errorf("MachineSet %s has no provider spec", ms.Name)
+	}
+
+	providerSpec := &machinev1beta1.AzureMachineProviderSpec{}
+	err := json.Unmarshal(ms.Spec.Template.Spec.ProviderSpec.Value.Raw, providerSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	return providerSpec, nil
+}
+
+func machineVMSize(machine *machinev1beta1.Machine) (string, error) {
+	if machine.Spec.ProviderSpec.Value == nil {
+		return "", fmt.Errorf("Machine %s has no provider spec", machine.Name)
+	}
+
+	providerSpec := &machinev1beta1.AzureMachineProviderSpec{}
+	err := json.Unmarshal(machine.Spec.ProviderSpec.Value.Raw, providerSpec)
+	if err != nil {
+		return "", err
+	}
+
+	return providerSpec.VMSize, nil
+}
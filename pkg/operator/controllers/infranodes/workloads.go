@@ -0,0 +1,173 @@
+package infranodes
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/ghodss/yaml"
+	imageregistryv1 "github.com/openshift/api/imageregistry/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	ingressControllerName = types.NamespacedName{Name: "default", Namespace: "openshift-ingress-operator"}
+	imageRegistryName     = types.NamespacedName{Name: "cluster"}
+	monitoringName        = types.NamespacedName{Name: "cluster-monitoring-config", Namespace: "openshift-monitoring"}
+
+	// monitoringComponents are the components of the monitoring stack in
+	// cluster-monitoring-config which are moved onto the infra nodes
+	monitoringComponents = []string{
+		"alertmanagerMain",
+		"k8sPrometheusAdapter",
+		"kubeStateMetrics",
+		"openshiftStateMetrics",
+		"prometheusK8s",
+		"prometheusOperator",
+		"telemeterClient",
+		"thanosQuerier",
+	}
+)
+
+func infraNodeSelector() map[string]string {
+	return map[string]string{infraNodeRoleLabel: ""}
+}
+
+func infraTolerations() []corev1.Toleration {
+	return []corev1.Toleration{
+		{
+			Key:      infraTaint.Key,
+			Operator: corev1.TolerationOpExists,
+			Effect:   infraTaint.Effect,
+		},
+	}
+}
+
+func (r *Reconciler) reconcileIngressController(ctx context.Context) error {
+	ingress := &operatorv1.IngressController{}
+	err := r.Client.Get(ctx, ingressControllerName, ingress)
+	if err != nil {
+		return err
+	}
+
+	nodePlacement := &operatorv1.NodePlacement{
+		NodeSelector: &metav1.LabelSelector{
+			MatchLabels: infraNodeSelector(),
+		},
+		Tolerations: infraTolerations(),
+	}
+
+	if reflect.DeepEqual(ingress.Spec.NodePlacement, nodePlacement) {
+		return nil
+	}
+
+	r.Log.Infof("moving IngressController %s onto the infra nodes", ingressControllerName)
+	ingress.Spec.NodePlacement = nodePlacement
+	return r.Client.Update(ctx, ingress)
+}
+
+func (r *Reconciler) reconcileImageRegistry(ctx context.Context) error {
+	config := &imageregistryv1.Config{}
+	err := r.Client.Get(ctx, imageRegistryName, config)
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(config.Spec.NodeSelector, infraNodeSelector()) &&
+		reflect.DeepEqual(config.Spec.Tolerations, infraTolerations()) {
+		return nil
+	}
+
+	r.Log.Info("moving the image registry onto the infra nodes")
+	config.Spec.NodeSelector = infraNodeSelector()
+	config.Spec.Tolerations = infraTolerations()
+	return r.Client.Update(ctx, config)
+}
+
+// reconcileMonitoring sets the node selector and tolerations of each
+// component of the monitoring stack.  The configuration is handled as a
+// generic document so that the settings of the customer and of the monitoring
+// controller are preserved.
+func (r *Reconciler) reconcileMonitoring(ctx context.Context) error {
+	cm := &corev1.ConfigMap{}
+	isCreate := false
+	err := r.Client.Get(ctx, monitoringName, cm)
+	if kerrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      monitoringName.Name,
+				Namespace: monitoringName.Namespace,
+			},
+		}
+		isCreate = true
+	} else if err != nil {
+		return err
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+
+	config := map[string]interface{}{}
+	err = yaml.Unmarshal([]byte(cm.Data["config.yaml"]), &config)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+
+	// round trip the placement so that it compares equal to the unmarshalled
+	// configuration
+	var placement map[string]interface{}
+	b, err := yaml.Marshal(map[string]interface{}{
+		"nodeSelector": infraNodeSelector(),
+		"tolerations":  infraTolerations(),
+	})
+	if err != nil {
+		return err
+	}
+	err = yaml.Unmarshal(b, &placement)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, name := range monitoringComponents {
+		component, _ := config[name].(map[string]interface{})
+		if component == nil {
+			component = map[string]interface{}{}
+		}
+
+		for k, v := range placement {
+			if !reflect.DeepEqual(component[k], v) {
+				component[k] = v
+				changed = true
+			}
+		}
+
+		config[name] = component
+	}
+
+	if !isCreate && !changed {
+		return nil
+	}
+
+	b, err = yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	cm.Data["config.yaml"] = string(b)
+
+	r.Log.Info("moving the monitoring stack onto the infra nodes")
+	if isCreate {
+		return r.Client.Create(ctx, cm)
+	}
+	return r.Client.Update(ctx, cm)
+}
@@ -0,0 +1,149 @@
+package largecluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "LargeCluster"
+
+	kubeAPIServerName = "cluster"
+)
+
+// apiServerArguments are the kube-apiserver arguments which large clusters
+// need to handle the request load of their nodes
+var apiServerArguments = map[string]interface{}{
+	"max-requests-inflight":          []interface{}{"3000"},
+	"max-mutating-requests-inflight": []interface{}{"1000"},
+	"etcd-compaction-interval":       []interface{}{"2m0s"},
+}
+
+// Reconciler tunes kube-apiserver and etcd for clusters which were created
+// with a maximum node count
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(operator.LargeClusterEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	if instance.Spec.MaxNodes == 0 {
+		r.Log.Debug("not a large cluster")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	err = r.reconcileKubeAPIServer(ctx)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+// reconcileKubeAPIServer merges apiServerArguments into the unsupported
+// config overrides of the kube-apiserver operator, preserving any other
+// overrides
+func (r *Reconciler) reconcileKubeAPIServer(ctx context.Context) error {
+	kas := &operatorv1.KubeAPIServer{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: kubeAPIServerName}, kas)
+	if err != nil {
+		return err
+	}
+
+	overrides := map[string]interface{}{}
+	if len(kas.Spec.UnsupportedConfigOverrides.Raw) > 0 {
+		err = json.Unmarshal(kas.Spec.UnsupportedConfigOverrides.Raw, &overrides)
+		if err != nil {
+			return err
+		}
+	}
+
+	args, _ := overrides["apiServerArguments"].(map[string]interface{})
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+
+	changed := false
+	for k, v := range apiServerArguments {
+		if !reflect.DeepEqual(args[k], v) {
+			args[k] = v
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	overrides["apiServerArguments"] = args
+
+	b, err := json.Marshal(overrides)
+	if err != nil {
+		return err
+	}
+	kas.Spec.UnsupportedConfigOverrides = kruntime.RawExtension{Raw: b}
+
+	return r.Client.Update(ctx, kas)
+}
+
+// SetupWithManager setup the mananger for the kube-apiserver operator resource
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	kubeAPIServerPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == kubeAPIServerName
+	})
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &operatorv1.KubeAPIServer{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(kubeAPIServerPredicate),
+		)
+
+	return builder.Named(ControllerName).Complete(r)
+}
@@ -0,0 +1,153 @@
+package largecluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	fakeCluster := func(controllerEnabledFlag string, maxNodes int) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: arov1alpha1.SingletonClusterName,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				MaxNodes: maxNodes,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					operator.LargeClusterEnabled: controllerEnabledFlag,
+				},
+			},
+		}
+	}
+
+	fakeKubeAPIServer := func(overrides string) *operatorv1.KubeAPIServer {
+		kas := &operatorv1.KubeAPIServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: kubeAPIServerName,
+			},
+		}
+		if overrides != "" {
+			kas.Spec.UnsupportedConfigOverrides = kruntime.RawExtension{Raw: []byte(overrides)}
+		}
+		return kas
+	}
+
+	tunedOverrides := `{"apiServerArguments":{"etcd-compaction-interval":["2m0s"],"max-mutating-requests-inflight":["1000"],"max-requests-inflight":["3000"]}}`
+
+	for _, tt := range []struct {
+		name                  string
+		controllerEnabledFlag string
+		maxNodes              int
+		kubeAPIServer         *operatorv1.KubeAPIServer
+		wantOverrides         string
+		wantErr               string
+		wantConditions        []operatorv1.OperatorCondition
+	}{
+		{
+			name:                  "controller disabled",
+			controllerEnabledFlag: operator.FlagFalse,
+			maxNodes:              120,
+			kubeAPIServer:         fakeKubeAPIServer(""),
+			wantConditions:        defaultConditions,
+		},
+		{
+			name:                  "not a large cluster",
+			controllerEnabledFlag: operator.FlagTrue,
+			kubeAPIServer:         fakeKubeAPIServer(""),
+			wantConditions:        defaultConditions,
+		},
+		{
+			name:                  "kube-apiserver not found",
+			controllerEnabledFlag: operator.FlagTrue,
+			maxNodes:              120,
+			wantErr:               `kubeapiservers.operator.openshift.io "cluster" not found`,
+			wantConditions: []operatorv1.OperatorCondition{
+				defaultAvailable,
+				defaultProgressing,
+				{
+					Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+					Status:             operatorv1.ConditionTrue,
+					LastTransitionTime: transitionTime,
+					Message:            `kubeapiservers.operator.openshift.io "cluster" not found`,
+				},
+			},
+		},
+		{
+			name:                  "tunes kube-apiserver",
+			controllerEnabledFlag: operator.FlagTrue,
+			maxNodes:              120,
+			kubeAPIServer:         fakeKubeAPIServer(""),
+			wantOverrides:         tunedOverrides,
+			wantConditions:        defaultConditions,
+		},
+		{
+			name:                  "preserves other overrides",
+			controllerEnabledFlag: operator.FlagTrue,
+			maxNodes:              250,
+			kubeAPIServer:         fakeKubeAPIServer(`{"apiServerArguments":{"max-requests-inflight":["400"],"shutdown-delay-duration":["70s"]},"other":true}`),
+			wantOverrides:         `{"apiServerArguments":{"etcd-compaction-interval":["2m0s"],"max-mutating-requests-inflight":["1000"],"max-requests-inflight":["3000"],"shutdown-delay-duration":["70s"]},"other":true}`,
+			wantConditions:        defaultConditions,
+		},
+		{
+			name:                  "already tuned",
+			controllerEnabledFlag: operator.FlagTrue,
+			maxNodes:              120,
+			kubeAPIServer:         fakeKubeAPIServer(tunedOverrides),
+			wantOverrides:         tunedOverrides,
+			wantConditions:        defaultConditions,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := fakeCluster(tt.controllerEnabledFlag, tt.maxNodes)
+			cluster.Status.Conditions = append(cluster.Status.Conditions, defaultConditions...)
+
+			clientBuilder := ctrlfake.NewClientBuilder().WithObjects(cluster)
+			if tt.kubeAPIServer != nil {
+				clientBuilder = clientBuilder.WithObjects(tt.kubeAPIServer)
+			}
+			clientFake := clientBuilder.Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+			ctx := context.Background()
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			utilconditions.AssertControllerConditions(t, ctx, clientFake, tt.wantConditions)
+
+			if tt.kubeAPIServer != nil {
+				kas := &operatorv1.KubeAPIServer{}
+				err = clientFake.Get(ctx, types.NamespacedName{Name: kubeAPIServerName}, kas)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(kas.Spec.UnsupportedConfigOverrides.Raw) != tt.wantOverrides {
+					t.Error(string(kas.Spec.UnsupportedConfigOverrides.Raw))
+				}
+			}
+		})
+	}
+}
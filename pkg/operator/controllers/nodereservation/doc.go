@@ -0,0 +1,33 @@
+package nodereservation
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package sets the resources which the kubelet reserves
+for the system (systemReserved) and for Kubernetes (kubeReserved) on the
+workers, from a table indexed by the number of cores of their VM size.  Small
+workers otherwise leave too little to the kubelet and the container runtime,
+and become unstable under load.
+
+The workers share the worker MachineConfigPool, so the reservations are
+computed from the smallest VM size among them, i.e. the one with the fewest
+cores.  The controller maintains the "aro-worker-reservation" KubeletConfig,
+which applies them to the worker MachineConfigPool, and records them in the
+nodeReservations field of the status of the Cluster resource.
+
+While this controller is enabled, the AutoSizedNodes controller only enables
+automatic sizing of the reservations on the masters.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.nodereservation.enabled:
+- When set to false, the controller will remove its KubeletConfig and the
+  reservations from the status of the Cluster resource
+- When set to true, the controller will manage the reservations of the workers
+
+More information on the reservations can be found here:
+https://kubernetes.io/docs/tasks/administer-cluster/reserve-compute-resources/
+
+*/
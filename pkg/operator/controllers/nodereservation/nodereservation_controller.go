@@ -0,0 +1,314 @@
+package nodereservation
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"reflect"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "NodeReservation"
+
+	configName          = "aro-worker-reservation"
+	workerPool          = "worker"
+	workerPoolLabel     = "pools.operator.machineconfiguration.openshift.io/worker"
+	workerNodeRoleLabel = "node-role.kubernetes.io/worker"
+)
+
+// reservation holds the resources reserved on nodes with up to maxCores cores
+type reservation struct {
+	maxCores       int
+	systemReserved map[string]string
+	kubeReserved   map[string]string
+}
+
+// reservations are the reservations by number of cores, smallest first.  The
+// smaller the node, the larger the share of it which is reserved.
+var reservations = []reservation{
+	{
+		maxCores:       4,
+		systemReserved: map[string]string{"cpu": "500m", "memory": "1Gi", "ephemeral-storage": "1Gi"},
+		kubeReserved:   map[string]string{"cpu": "250m", "memory": "1Gi", "ephemeral-storage": "1Gi"},
+	},
+	{
+		maxCores:       8,
+		systemReserved: map[string]string{"cpu": "500m", "memory": "1536Mi", "ephemeral-storage": "1Gi"},
+		kubeReserved:   map[string]string{"cpu": "500m", "memory": "1536Mi", "ephemeral-storage": "1Gi"},
+	},
+	{
+		maxCores:       16,
+		systemReserved: map[string]string{"cpu": "1", "memory": "2Gi", "ephemeral-storage": "1Gi"},
+		kubeReserved:   map[string]string{"cpu": "500m", "memory": "2Gi", "ephemeral-storage": "1Gi"},
+	},
+	{
+		maxCores:       32,
+		systemReserved: map[string]string{"cpu": "1", "memory": "3Gi", "ephemeral-storage": "1Gi"},
+		kubeReserved:   map[string]string{"cpu": "1", "memory": "3Gi", "ephemeral-storage": "1Gi"},
+	},
+	{
+		maxCores:       math.MaxInt32,
+		systemReserved: map[string]string{"cpu": "2", "memory": "4Gi", "ephemeral-storage": "1Gi"},
+		kubeReserved:   map[string]string{"cpu": "1", "memory": "4Gi", "ephemeral-storage": "1Gi"},
+	},
+}
+
+// Reconciler sets the resources reserved on the workers from their VM size
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(operator.NodeReservationEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, r.removeReservations(ctx)
+	}
+
+	r.Log.Debug("running")
+
+	vmSize, cores, err := r.smallestWorkerVMSize(ctx)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	// wait for a worker of a known size to join the cluster
+	if vmSize == "" {
+		return reconcile.Result{}, nil
+	}
+
+	res := reservationFor(cores)
+
+	err = r.reconcileKubeletConfig(ctx, res)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	err = r.setNodeReservations(ctx, []arov1alpha1.NodeReservation{
+		{
+			MachineConfigPool: workerPool,
+			VMSize:            string(vmSize),
+			SystemReserved:    res.systemReserved,
+			KubeReserved:      res.kubeReserved,
+		},
+	})
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+// smallestWorkerVMSize returns the supported worker VM size with the fewest
+// cores among the workers, and its number of cores.  Workers of an unknown
+// size are ignored.
+func (r *Reconciler) smallestWorkerVMSize(ctx context.Context) (api.VMSize, int, error) {
+	nodes := &corev1.NodeList{}
+	err := r.Client.List(ctx, nodes, client.HasLabels{workerNodeRoleLabel})
+	if err != nil {
+		return "", 0, err
+	}
+
+	supported := validate.SupportedVMSizesByRole(validate.VMRoleWorker)
+
+	var smallest api.VMSize
+	var cores int
+	for _, node := range nodes.Items {
+		vmSize := api.VMSize(node.Labels[corev1.LabelInstanceTypeStable])
+
+		s, ok := supported[vmSize]
+		if !ok {
+			continue
+		}
+
+		if smallest == "" || s.CoreCount < cores {
+			smallest = vmSize
+			cores = s.CoreCount
+		}
+	}
+
+	return smallest, cores, nil
+}
+
+func (r *Reconciler) reconcileKubeletConfig(ctx context.Context, res reservation) error {
+	want, err := makeConfig(res)
+	if err != nil {
+		return err
+	}
+
+	config := &mcv1.KubeletConfig{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: configName}, config)
+	if kerrors.IsNotFound(err) {
+		r.Log.Infof("creating KubeletConfig %s", configName)
+		return r.Client.Create(ctx, want)
+	}
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(config.Spec, want.Spec) {
+		return nil
+	}
+
+	r.Log.Infof("updating KubeletConfig %s", configName)
+	config.Spec = want.Spec
+	return r.Client.Update(ctx, config)
+}
+
+// removeReservations removes the KubeletConfig of the controller, so that the
+// workers go back to the default reservations, and clears the reservations
+// from the status
+func (r *Reconciler) removeReservations(ctx context.Context) error {
+	err := r.Client.Delete(ctx, &mcv1.KubeletConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: configName,
+		},
+	})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	return r.setNodeReservations(ctx, nil)
+}
+
+func (r *Reconciler) setNodeReservations(ctx context.Context, nodeReservations []arov1alpha1.NodeReservation) error {
+	cluster, err := r.GetCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(cluster.Status.NodeReservations, nodeReservations) {
+		return nil
+	}
+
+	cluster.Status.NodeReservations = nodeReservations
+	return r.Client.Status().Update(ctx, cluster)
+}
+
+// reservationFor returns the reservation of nodes with cores cores
+func reservationFor(cores int) reservation {
+	for _, res := range reservations {
+		if cores <= res.maxCores {
+			return res
+		}
+	}
+	return reservations[len(reservations)-1]
+}
+
+func makeConfig(res reservation) (*mcv1.KubeletConfig, error) {
+	b, err := json.Marshal(map[string]interface{}{
+		"systemReserved": res.systemReserved,
+		"kubeReserved":   res.kubeReserved,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcv1.KubeletConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: configName,
+		},
+		Spec: mcv1.KubeletConfigSpec{
+			// automatic sizing would override the reservations
+			AutoSizingReserved: to.BoolPtr(false),
+			MachineConfigPoolSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					workerPoolLabel: "",
+				},
+			},
+			KubeletConfig: &kruntime.RawExtension{Raw: b},
+		},
+	}, nil
+}
+
+// SetupWithManager setup the mananger for the workers and the KubeletConfig of
+// the controller.  Only workers joining or leaving the cluster can change its
+// smallest VM size, so updates to nodes are ignored.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	workerPredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			_, ok := e.Object.GetLabels()[workerNodeRoleLabel]
+			return ok
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			_, ok := e.Object.GetLabels()[workerNodeRoleLabel]
+			return ok
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	kubeletConfigPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == configName
+	})
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &corev1.Node{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(workerPredicate),
+		).
+		Watches(
+			&source.Kind{Type: &mcv1.KubeletConfig{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(kubeletConfigPredicate),
+		)
+
+	return builder.Named(ControllerName).Complete(r)
+}
@@ -0,0 +1,185 @@
+package nodereservation
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+)
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	worker := func(name, vmSize string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					workerNodeRoleLabel:            "",
+					corev1.LabelInstanceTypeStable: vmSize,
+				},
+			},
+		}
+	}
+
+	master := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "master-0",
+			Labels: map[string]string{
+				"node-role.kubernetes.io/master": "",
+				corev1.LabelInstanceTypeStable:   "Standard_D2s_v3",
+			},
+		},
+	}
+
+	small := reservationFor(4)
+	smallConfig, err := makeConfig(small)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name                 string
+		enabled              string
+		objects              []client.Object
+		status               []arov1alpha1.NodeReservation
+		wantConfig           *mcv1.KubeletConfig
+		wantNodeReservations []arov1alpha1.NodeReservation
+	}{
+		{
+			name:    "controller disabled removes the reservations",
+			enabled: operator.FlagFalse,
+			objects: []client.Object{worker("worker-0", "Standard_D4s_v3"), smallConfig},
+			status: []arov1alpha1.NodeReservation{
+				{MachineConfigPool: workerPool, VMSize: "Standard_D4s_v3"},
+			},
+		},
+		{
+			name:    "reservations follow the smallest worker",
+			enabled: operator.FlagTrue,
+			objects: []client.Object{
+				master,
+				worker("worker-0", "Standard_D16s_v3"),
+				worker("worker-1", "Standard_D4s_v3"),
+				worker("worker-2", "Standard_Unknown"),
+			},
+			wantConfig: smallConfig,
+			wantNodeReservations: []arov1alpha1.NodeReservation{
+				{
+					MachineConfigPool: workerPool,
+					VMSize:            "Standard_D4s_v3",
+					SystemReserved:    small.systemReserved,
+					KubeReserved:      small.kubeReserved,
+				},
+			},
+		},
+		{
+			name:    "modified KubeletConfig is reverted",
+			enabled: operator.FlagTrue,
+			objects: []client.Object{
+				worker("worker-0", "Standard_D4s_v3"),
+				&mcv1.KubeletConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: configName,
+					},
+				},
+			},
+			wantConfig: smallConfig,
+			wantNodeReservations: []arov1alpha1.NodeReservation{
+				{
+					MachineConfigPool: workerPool,
+					VMSize:            "Standard_D4s_v3",
+					SystemReserved:    small.systemReserved,
+					KubeReserved:      small.kubeReserved,
+				},
+			},
+		},
+		{
+			name:    "no workers of a known size",
+			enabled: operator.FlagTrue,
+			objects: []client.Object{master},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					OperatorFlags: arov1alpha1.OperatorFlags{
+						operator.NodeReservationEnabled: tt.enabled,
+					},
+				},
+				Status: arov1alpha1.ClusterStatus{
+					NodeReservations: tt.status,
+				},
+			}
+
+			clientFake := ctrlfake.NewClientBuilder().WithObjects(append(tt.objects, cluster)...).Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := &mcv1.KubeletConfig{}
+			err = clientFake.Get(ctx, types.NamespacedName{Name: configName}, config)
+			if tt.wantConfig == nil {
+				if !kerrors.IsNotFound(err) {
+					t.Errorf("got KubeletConfig %#v, error %v", config.Spec, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(config.Spec, tt.wantConfig.Spec) {
+					t.Errorf("got KubeletConfig %#v, want %#v", config.Spec, tt.wantConfig.Spec)
+				}
+			}
+
+			cluster = &arov1alpha1.Cluster{}
+			err = clientFake.Get(ctx, types.NamespacedName{Name: arov1alpha1.SingletonClusterName}, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cluster.Status.NodeReservations, tt.wantNodeReservations) {
+				t.Errorf("got node reservations %#v, want %#v", cluster.Status.NodeReservations, tt.wantNodeReservations)
+			}
+		})
+	}
+}
+
+func TestReservationFor(t *testing.T) {
+	for _, tt := range []struct {
+		cores        int
+		wantMaxCores int
+	}{
+		{cores: 2, wantMaxCores: 4},
+		{cores: 4, wantMaxCores: 4},
+		{cores: 8, wantMaxCores: 8},
+		{cores: 16, wantMaxCores: 16},
+		{cores: 64, wantMaxCores: reservations[len(reservations)-1].maxCores},
+	} {
+		if got := reservationFor(tt.cores); got.maxCores != tt.wantMaxCores {
+			t.Errorf("%d cores: got reservation for up to %d cores, want %d", tt.cores, got.maxCores, tt.wantMaxCores)
+		}
+	}
+}
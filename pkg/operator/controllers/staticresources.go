@@ -0,0 +1,85 @@
+package controllers
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"embed"
+	"io/fs"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+//go:embed */staticresources
+var staticFiles embed.FS
+
+// ManagedResource is a resource which a controller deploys from its
+// staticresources manifests
+type ManagedResource struct {
+	Controller string
+	Object     *unstructured.Unstructured
+}
+
+// ManagedResources returns the resources deployed from the staticresources
+// manifests of every controller.  Manifests are rendered with their template
+// fields empty, so the returned objects identify the resources but are not
+// what the controllers deploy.
+func ManagedResources() ([]ManagedResource, error) {
+	var resources []ManagedResource
+
+	err := fs.WalkDir(staticFiles, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		b, err := staticFiles.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		tmpl, err := template.New(p).Option("missingkey=zero").Parse(string(b))
+		if err != nil {
+			return err
+		}
+
+		buf := &bytes.Buffer{}
+		err = tmpl.Execute(buf, map[string]string{})
+		if err != nil {
+			return err
+		}
+
+		controller, _, _ := strings.Cut(p, "/")
+
+		for _, doc := range strings.Split(buf.String(), "\n---") {
+			if strings.TrimSpace(strings.TrimPrefix(doc, "---")) == "" {
+				continue
+			}
+
+			o := &unstructured.Unstructured{}
+			err = yaml.Unmarshal([]byte(doc), &o.Object)
+			if err != nil {
+				return err
+			}
+
+			if o.GetKind() == "" {
+				continue
+			}
+
+			resources = append(resources, ManagedResource{
+				Controller: controller,
+				Object:     o,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,32 @@
+package controllers
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+)
+
+func TestManagedResources(t *testing.T) {
+	resources, err := ManagedResources()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := map[string]bool{}
+	for _, r := range resources {
+		found[r.Controller+"/"+r.Object.GetKind()+"/"+r.Object.GetNamespace()+"/"+r.Object.GetName()] = true
+	}
+
+	for _, want := range []string{
+		"muo/Deployment/openshift-managed-upgrade-operator/managed-upgrade-operator",
+		"muo/CustomResourceDefinition//upgradeconfigs.upgrade.managed.openshift.io",
+		"rbac/ClusterRole//system:aro-sre",
+		"machinehealthcheck/MachineHealthCheck/openshift-machine-api/aro-machinehealthcheck",
+		"guardrails/Deployment//gatekeeper-controller-manager",
+	} {
+		if !found[want] {
+			t.Error(want)
+		}
+	}
+}
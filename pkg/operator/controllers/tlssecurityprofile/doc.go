@@ -0,0 +1,29 @@
+package tlssecurityprofile
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package checks that the TLS security profiles of the
+API server and of the default ingress controller are at least as strict as the
+Intermediate profile, i.e. they allow TLS 1.2 or later and only the ciphers of
+the Intermediate profile.  Unset profiles and the Modern profile comply.
+
+There are two flags which control the operations performed by this controller:
+
+aro.tlssecurityprofile.enabled:
+- When set to false, the controller will noop and set the
+  TLSSecurityProfileCompliant condition to Unknown
+- When set to true, the controller will report weaker profiles in the
+  TLSSecurityProfileCompliant condition
+
+aro.tlssecurityprofile.managed:
+- When set to true, the controller will also replace weaker profiles with the
+  Intermediate profile
+- When set to false, weaker profiles are only reported
+
+More information on TLS security profiles can be found here:
+https://docs.openshift.com/container-platform/4.12/security/tls-security-profiles.html
+
+*/
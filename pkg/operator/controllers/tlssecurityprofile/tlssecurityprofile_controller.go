@@ -0,0 +1,240 @@
+package tlssecurityprofile
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/conditions"
+)
+
+const (
+	ControllerName = "TLSSecurityProfile"
+
+	apiServerName                = "cluster"
+	ingressControllerNamespace   = "openshift-ingress-operator"
+	ingressControllerName        = "default"
+	recommendedTLSProfileType    = configv1.TLSProfileIntermediateType
+	conditionReasonCompliant     = "Compliant"
+	conditionReasonWeakTLSConfig = "WeakTLSConfig"
+)
+
+// Reconciler checks the TLS security profiles of the API server and the
+// default ingress controller against the Intermediate profile, and replaces
+// weaker ones if the controller is managed
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(operator.TLSSecurityProfileEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, conditions.SetCondition(ctx, r.Client, &operatorv1.OperatorCondition{
+			Type:   arov1alpha1.TLSSecurityProfileCompliant,
+			Status: operatorv1.ConditionUnknown,
+		}, operator.RoleMaster)
+	}
+
+	r.Log.Debug("running")
+	managed := instance.Spec.OperatorFlags.GetSimpleBoolean(operator.TLSSecurityProfileManaged)
+
+	var drift []string
+	for _, f := range []func(context.Context, bool) (string, error){
+		r.reconcileAPIServer,
+		r.reconcileIngressController,
+	} {
+		d, err := f(ctx, managed)
+		if err != nil {
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+			return reconcile.Result{}, err
+		}
+		if d != "" {
+			drift = append(drift, d)
+		}
+	}
+
+	err = conditions.SetCondition(ctx, r.Client, condition(drift), operator.RoleMaster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+// reconcileAPIServer returns why the TLS security profile of the API server
+// is weaker than the Intermediate profile, unless it is managed, in which case
+// it is replaced
+func (r *Reconciler) reconcileAPIServer(ctx context.Context, managed bool) (string, error) {
+	apiServer := &configv1.APIServer{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: apiServerName}, apiServer)
+	if err != nil {
+		return "", err
+	}
+
+	weakness := profileWeakness(apiServer.Spec.TLSSecurityProfile)
+	if weakness == "" {
+		return "", nil
+	}
+
+	if !managed {
+		return fmt.Sprintf("APIServer %s %s", apiServerName, weakness), nil
+	}
+
+	r.Log.Infof("replacing TLS security profile of APIServer %s, which %s", apiServerName, weakness)
+	apiServer.Spec.TLSSecurityProfile = recommendedProfile()
+	return "", r.Client.Update(ctx, apiServer)
+}
+
+// reconcileIngressController does the same as reconcileAPIServer for the
+// default ingress controller.  An ingress controller without a profile uses
+// that of the API server.
+func (r *Reconciler) reconcileIngressController(ctx context.Context, managed bool) (string, error) {
+	ingress := &operatorv1.IngressController{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: ingressControllerNamespace, Name: ingressControllerName}, ingress)
+	if err != nil {
+		return "", err
+	}
+
+	weakness := profileWeakness(ingress.Spec.TLSSecurityProfile)
+	if weakness == "" {
+		return "", nil
+	}
+
+	if !managed {
+		return fmt.Sprintf("IngressController %s/%s %s", ingressControllerNamespace, ingressControllerName, weakness), nil
+	}
+
+	r.Log.Infof("replacing TLS security profile of IngressController %s/%s, which %s", ingressControllerNamespace, ingressControllerName, weakness)
+	ingress.Spec.TLSSecurityProfile = recommendedProfile()
+	return "", r.Client.Update(ctx, ingress)
+}
+
+// profileWeakness returns why p is weaker than the Intermediate profile, or
+// the empty string if it is not.  An unset profile defaults to Intermediate.
+func profileWeakness(p *configv1.TLSSecurityProfile) string {
+	if p == nil {
+		return ""
+	}
+
+	switch p.Type {
+	case configv1.TLSProfileOldType:
+		return "uses the Old profile"
+
+	case configv1.TLSProfileCustomType:
+		if p.Custom == nil {
+			return ""
+		}
+
+		switch p.Custom.MinTLSVersion {
+		case configv1.VersionTLS10, configv1.VersionTLS11:
+			return fmt.Sprintf("allows %s", p.Custom.MinTLSVersion)
+		}
+
+		allowed := map[string]bool{}
+		for _, cipher := range configv1.TLSProfiles[recommendedTLSProfileType].Ciphers {
+			allowed[cipher] = true
+		}
+
+		var weak []string
+		for _, cipher := range p.Custom.Ciphers {
+			if !allowed[cipher] {
+				weak = append(weak, cipher)
+			}
+		}
+		if len(weak) > 0 {
+			return fmt.Sprintf("allows the ciphers %s", strings.Join(weak, ", "))
+		}
+	}
+
+	return ""
+}
+
+func recommendedProfile() *configv1.TLSSecurityProfile {
+	return &configv1.TLSSecurityProfile{
+		Type:         recommendedTLSProfileType,
+		Intermediate: &configv1.IntermediateTLSProfile{},
+	}
+}
+
+func condition(drift []string) *operatorv1.OperatorCondition {
+	if len(drift) == 0 {
+		return &operatorv1.OperatorCondition{
+			Type:   arov1alpha1.TLSSecurityProfileCompliant,
+			Status: operatorv1.ConditionTrue,
+			Reason: conditionReasonCompliant,
+		}
+	}
+
+	return &operatorv1.OperatorCondition{
+		Type:    arov1alpha1.TLSSecurityProfileCompliant,
+		Status:  operatorv1.ConditionFalse,
+		Reason:  conditionReasonWeakTLSConfig,
+		Message: strings.Join(drift, "; "),
+	}
+}
+
+// SetupWithManager setup the mananger for the API server and ingress
+// controller resources
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	apiServerPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == apiServerName
+	})
+
+	ingressControllerPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetNamespace() == ingressControllerNamespace && o.GetName() == ingressControllerName
+	})
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &configv1.APIServer{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(apiServerPredicate),
+		).
+		Watches(
+			&source.Kind{Type: &operatorv1.IngressController{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(ingressControllerPredicate),
+		)
+
+	return builder.Named(ControllerName).Complete(r)
+}
@@ -0,0 +1,256 @@
+package tlssecurityprofile
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	compliant := operatorv1.OperatorCondition{
+		Type:               arov1alpha1.TLSSecurityProfileCompliant,
+		Status:             operatorv1.ConditionTrue,
+		LastTransitionTime: transitionTime,
+		Reason:             conditionReasonCompliant,
+	}
+
+	oldProfile := &configv1.TLSSecurityProfile{
+		Type: configv1.TLSProfileOldType,
+		Old:  &configv1.OldTLSProfile{},
+	}
+
+	fakeCluster := func(enabled, managed string) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: arov1alpha1.SingletonClusterName,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					operator.TLSSecurityProfileEnabled: enabled,
+					operator.TLSSecurityProfileManaged: managed,
+				},
+			},
+		}
+	}
+
+	fakeAPIServer := func(profile *configv1.TLSSecurityProfile) *configv1.APIServer {
+		return &configv1.APIServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: apiServerName,
+			},
+			Spec: configv1.APIServerSpec{
+				TLSSecurityProfile: profile,
+			},
+		}
+	}
+
+	fakeIngressController := func(profile *configv1.TLSSecurityProfile) *operatorv1.IngressController {
+		return &operatorv1.IngressController{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ingressControllerNamespace,
+				Name:      ingressControllerName,
+			},
+			Spec: operatorv1.IngressControllerSpec{
+				TLSSecurityProfile: profile,
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name                 string
+		enabled              string
+		managed              string
+		apiServerProfile     *configv1.TLSSecurityProfile
+		ingressProfile       *configv1.TLSSecurityProfile
+		noIngressController  bool
+		wantAPIServerProfile *configv1.TLSSecurityProfile
+		wantIngressProfile   *configv1.TLSSecurityProfile
+		wantConditions       []operatorv1.OperatorCondition
+		wantErr              string
+	}{
+		{
+			name:                 "controller disabled",
+			enabled:              operator.FlagFalse,
+			managed:              operator.FlagTrue,
+			apiServerProfile:     oldProfile,
+			wantAPIServerProfile: oldProfile,
+			wantConditions: append([]operatorv1.OperatorCondition{
+				{
+					Type:               arov1alpha1.TLSSecurityProfileCompliant,
+					Status:             operatorv1.ConditionUnknown,
+					LastTransitionTime: transitionTime,
+				},
+			}, defaultConditions...),
+		},
+		{
+			name:           "default profiles comply",
+			enabled:        operator.FlagTrue,
+			managed:        operator.FlagTrue,
+			wantConditions: append([]operatorv1.OperatorCondition{compliant}, defaultConditions...),
+		},
+		{
+			name:    "modern and strict custom profiles comply",
+			enabled: operator.FlagTrue,
+			managed: operator.FlagTrue,
+			apiServerProfile: &configv1.TLSSecurityProfile{
+				Type:   configv1.TLSProfileModernType,
+				Modern: &configv1.ModernTLSProfile{},
+			},
+			ingressProfile: &configv1.TLSSecurityProfile{
+				Type: configv1.TLSProfileCustomType,
+				Custom: &configv1.CustomTLSProfile{
+					TLSProfileSpec: configv1.TLSProfileSpec{
+						Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+						MinTLSVersion: configv1.VersionTLS12,
+					},
+				},
+			},
+			wantAPIServerProfile: &configv1.TLSSecurityProfile{
+				Type:   configv1.TLSProfileModernType,
+				Modern: &configv1.ModernTLSProfile{},
+			},
+			wantIngressProfile: &configv1.TLSSecurityProfile{
+				Type: configv1.TLSProfileCustomType,
+				Custom: &configv1.CustomTLSProfile{
+					TLSProfileSpec: configv1.TLSProfileSpec{
+						Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+						MinTLSVersion: configv1.VersionTLS12,
+					},
+				},
+			},
+			wantConditions: append([]operatorv1.OperatorCondition{compliant}, defaultConditions...),
+		},
+		{
+			name:             "weak profiles are reported when not managed",
+			enabled:          operator.FlagTrue,
+			managed:          operator.FlagFalse,
+			apiServerProfile: oldProfile,
+			ingressProfile: &configv1.TLSSecurityProfile{
+				Type: configv1.TLSProfileCustomType,
+				Custom: &configv1.CustomTLSProfile{
+					TLSProfileSpec: configv1.TLSProfileSpec{
+						Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256", "AES128-SHA", "DES-CBC3-SHA"},
+						MinTLSVersion: configv1.VersionTLS12,
+					},
+				},
+			},
+			wantAPIServerProfile: oldProfile,
+			wantIngressProfile: &configv1.TLSSecurityProfile{
+				Type: configv1.TLSProfileCustomType,
+				Custom: &configv1.CustomTLSProfile{
+					TLSProfileSpec: configv1.TLSProfileSpec{
+						Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256", "AES128-SHA", "DES-CBC3-SHA"},
+						MinTLSVersion: configv1.VersionTLS12,
+					},
+				},
+			},
+			wantConditions: append([]operatorv1.OperatorCondition{
+				{
+					Type:               arov1alpha1.TLSSecurityProfileCompliant,
+					Status:             operatorv1.ConditionFalse,
+					LastTransitionTime: transitionTime,
+					Reason:             conditionReasonWeakTLSConfig,
+					Message:            "APIServer cluster uses the Old profile; IngressController openshift-ingress-operator/default allows the ciphers AES128-SHA, DES-CBC3-SHA",
+				},
+			}, defaultConditions...),
+		},
+		{
+			name:             "weak profiles are replaced when managed",
+			enabled:          operator.FlagTrue,
+			managed:          operator.FlagTrue,
+			apiServerProfile: oldProfile,
+			ingressProfile: &configv1.TLSSecurityProfile{
+				Type: configv1.TLSProfileCustomType,
+				Custom: &configv1.CustomTLSProfile{
+					TLSProfileSpec: configv1.TLSProfileSpec{
+						Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+						MinTLSVersion: configv1.VersionTLS10,
+					},
+				},
+			},
+			wantAPIServerProfile: recommendedProfile(),
+			wantIngressProfile:   recommendedProfile(),
+			wantConditions:       append([]operatorv1.OperatorCondition{compliant}, defaultConditions...),
+		},
+		{
+			name:                "ingress controller not found",
+			enabled:             operator.FlagTrue,
+			managed:             operator.FlagTrue,
+			noIngressController: true,
+			wantErr:             `ingresscontrollers.operator.openshift.io "default" not found`,
+			wantConditions: []operatorv1.OperatorCondition{
+				defaultAvailable,
+				defaultProgressing,
+				{
+					Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+					Status:             operatorv1.ConditionTrue,
+					LastTransitionTime: transitionTime,
+					Message:            `ingresscontrollers.operator.openshift.io "default" not found`,
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := fakeCluster(tt.enabled, tt.managed)
+			cluster.Status.Conditions = append(cluster.Status.Conditions, defaultConditions...)
+
+			objects := []client.Object{cluster, fakeAPIServer(tt.apiServerProfile)}
+			if !tt.noIngressController {
+				objects = append(objects, fakeIngressController(tt.ingressProfile))
+			}
+			clientFake := ctrlfake.NewClientBuilder().WithObjects(objects...).Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+			ctx := context.Background()
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			utilconditions.AssertControllerConditions(t, ctx, clientFake, tt.wantConditions)
+
+			apiServer := &configv1.APIServer{}
+			err = clientFake.Get(ctx, types.NamespacedName{Name: apiServerName}, apiServer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(apiServer.Spec.TLSSecurityProfile, tt.wantAPIServerProfile) {
+				t.Errorf("got APIServer profile %#v, want %#v", apiServer.Spec.TLSSecurityProfile, tt.wantAPIServerProfile)
+			}
+
+			if !tt.noIngressController {
+				ingress := &operatorv1.IngressController{}
+				err = clientFake.Get(ctx, types.NamespacedName{Namespace: ingressControllerNamespace, Name: ingressControllerName}, ingress)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(ingress.Spec.TLSSecurityProfile, tt.wantIngressProfile) {
+					t.Errorf("got IngressController profile %#v, want %#v", ingress.Spec.TLSSecurityProfile, tt.wantIngressProfile)
+				}
+			}
+		})
+	}
+}
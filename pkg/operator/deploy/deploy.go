@@ -217,7 +217,7 @@ func (o *operator) resources(ctx context.Context) ([]kruntime.Object, error) {
 		domain += "." + o.env.Domain()
 	}
 
-	ingressIP, err := checkIngressIP(o.oc.Properties.IngressProfiles)
+	ingressIP, ingressIPv6, err := checkIngressIP(o.oc.Properties.IngressProfiles)
 	if err != nil {
 		return nil, err
 	}
@@ -265,12 +265,17 @@ func (o *operator) resources(ctx context.Context) ([]kruntime.Object, error) {
 
 			APIIntIP:                 o.oc.Properties.APIServerProfile.IntIP,
 			IngressIP:                ingressIP,
+			IngressIPv6:              ingressIPv6,
 			GatewayPrivateEndpointIP: o.oc.Properties.NetworkProfile.GatewayPrivateEndpointIP,
 			// Update the OperatorFlags from the version in the RP
 			OperatorFlags: arov1alpha1.OperatorFlags(o.oc.Properties.OperatorFlags),
 		},
 	}
 
+	if o.oc.Properties.MaxNodesProfile != nil {
+		cluster.Spec.MaxNodes = o.oc.Properties.MaxNodesProfile.MaxNodes
+	}
+
 	if o.oc.Properties.FeatureProfile.GatewayEnabled && o.oc.Properties.NetworkProfile.GatewayPrivateEndpointIP != "" {
 		cluster.Spec.GatewayDomains = append(o.env.GatewayDomains(), o.oc.Properties.ImageRegistryStorageAccountName+".blob."+o.env.Environment().StorageEndpointSuffix)
 	} else {
@@ -433,15 +438,12 @@ func (o *operator) RenewMDSDCertificate(ctx context.Context) error {
 }
 
 func (o *operator) IsReady(ctx context.Context) (bool, error) {
-	ok, err := ready.CheckDeploymentIsReady(ctx, o.kubernetescli.AppsV1().Deployments(pkgoperator.Namespace), "aro-operator-master")()
-	o.log.Infof("deployment %q ok status is: %v, err is: %v", "aro-operator-master", ok, err)
-	if !ok || err != nil {
-		return ok, err
-	}
-	ok, err = ready.CheckDeploymentIsReady(ctx, o.kubernetescli.AppsV1().Deployments(pkgoperator.Namespace), "aro-operator-worker")()
-	o.log.Infof("deployment %q ok status is: %v, err is: %v", "aro-operator-worker", ok, err)
-	if !ok || err != nil {
-		return ok, err
+	for _, name := range []string{"aro-operator-master", "aro-operator-worker"} {
+		ok, err := ready.WithLogging(o.log, "deployment", name,
+			ready.CheckDeploymentIsReady(ctx, o.kubernetescli.AppsV1().Deployments(pkgoperator.Namespace), name))()
+		if !ok || err != nil {
+			return ok, err
+		}
 	}
 
 	return true, nil
@@ -527,19 +529,21 @@ func (o *operator) IsRunningDesiredVersion(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
-func checkIngressIP(ingressProfiles []api.IngressProfile) (string, error) {
+// checkIngressIP returns the IP and, on dual-stack clusters, the IPv6 IP of
+// the default ingress
+func checkIngressIP(ingressProfiles []api.IngressProfile) (string, string, error) {
 	if ingressProfiles == nil || len(ingressProfiles) < 1 {
-		return "", errors.New("no Ingress Profiles found")
+		return "", "", errors.New("no Ingress Profiles found")
 	}
-	ingressIP := ingressProfiles[0].IP
+	ingressProfile := ingressProfiles[0]
 	if len(ingressProfiles) > 1 {
 		for _, p := range ingressProfiles {
 			if p.Name == "default" {
-				return p.IP, nil
+				return p.IP, p.IPv6, nil
 			}
 		}
 	}
-	return ingressIP, nil
+	return ingressProfile.IP, ingressProfile.IPv6, nil
 }
 
 func isCRDEstablished(crd *extensionsv1.CustomResourceDefinition) bool {
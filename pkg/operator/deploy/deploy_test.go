@@ -27,6 +27,7 @@ func TestCheckIngressIP(t *testing.T) {
 		name       string
 		oc         func() *api.OpenShiftClusterProperties
 		want       string
+		wantIPv6   string
 		wantErrMsg string
 	}
 
@@ -57,6 +58,7 @@ func TestCheckIngressIP(t *testing.T) {
 						{
 							Name: "default",
 							IP:   "1.2.3.4",
+							IPv6: "2001:db8::1",
 						},
 						{
 							Name: "not-default",
@@ -65,7 +67,8 @@ func TestCheckIngressIP(t *testing.T) {
 					},
 				}
 			},
-			want: "1.2.3.4",
+			want:     "1.2.3.4",
+			wantIPv6: "2001:db8::1",
 		},
 		{
 			name: "Single Ingress Profile, No Default",
@@ -118,13 +121,16 @@ func TestCheckIngressIP(t *testing.T) {
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			oc := tt.oc()
-			ingressIP, err := checkIngressIP(oc.IngressProfiles)
+			ingressIP, ingressIPv6, err := checkIngressIP(oc.IngressProfiles)
 
 			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
 
 			if tt.want != ingressIP {
 				t.Error(cmp.Diff(ingressIP, tt.want))
 			}
+			if tt.wantIPv6 != ingressIPv6 {
+				t.Error(cmp.Diff(ingressIPv6, tt.wantIPv6))
+			}
 		})
 	}
 }
@@ -11,6 +11,7 @@ const (
 	BannerEnabled                      = "aro.banner.enabled"
 	CheckerEnabled                     = "aro.checker.enabled"
 	DnsmasqEnabled                     = "aro.dnsmasq.enabled"
+	PrivateDNSZoneRemovalEnabled       = "aro.dnsmasq.privatednszoneremoval.enabled"
 	RestartDnsmasqEnabled              = "aro.restartdnsmasq.enabled"
 	GenevaLoggingEnabled               = "aro.genevalogging.enabled"
 	ImageConfigEnabled                 = "aro.imageconfig.enabled"
@@ -33,6 +34,11 @@ const (
 	GuardrailsEnabled                  = "aro.guardrails.enabled"
 	GuardrailsDeployManaged            = "aro.guardrails.deploy.managed"
 	CloudProviderConfigEnabled         = "aro.cloudproviderconfig.enabled"
+	LargeClusterEnabled                = "aro.largecluster.enabled"
+	TLSSecurityProfileEnabled          = "aro.tlssecurityprofile.enabled"
+	TLSSecurityProfileManaged          = "aro.tlssecurityprofile.managed"
+	InfraNodesEnabled                  = "aro.infranodes.enabled"
+	NodeReservationEnabled             = "aro.nodereservation.enabled"
 	FlagTrue                           = "true"
 	FlagFalse                          = "false"
 )
@@ -48,6 +54,7 @@ func DefaultOperatorFlags() map[string]string {
 		BannerEnabled:                      FlagFalse,
 		CheckerEnabled:                     FlagTrue,
 		DnsmasqEnabled:                     FlagTrue,
+		PrivateDNSZoneRemovalEnabled:       FlagTrue,
 		RestartDnsmasqEnabled:              FlagFalse,
 		GenevaLoggingEnabled:               FlagTrue,
 		ImageConfigEnabled:                 FlagTrue,
@@ -70,5 +77,10 @@ func DefaultOperatorFlags() map[string]string {
 		GuardrailsEnabled:                  FlagFalse,
 		GuardrailsDeployManaged:            FlagFalse,
 		CloudProviderConfigEnabled:         FlagTrue,
+		LargeClusterEnabled:                FlagTrue,
+		TLSSecurityProfileEnabled:          FlagTrue,
+		TLSSecurityProfileManaged:          FlagFalse,
+		InfraNodesEnabled:                  FlagFalse,
+		NodeReservationEnabled:             FlagFalse,
 	}
 }
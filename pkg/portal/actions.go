@@ -0,0 +1,166 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/portal/middleware"
+)
+
+// actionMaintenanceTasks maps the admin update actions which the portal can
+// trigger to the maintenance task run by the backend
+var actionMaintenanceTasks = map[string]api.MaintenanceTask{
+	"adminupdate":       api.MaintenanceTaskEverything,
+	"renewcertificates": api.MaintenanceTaskRenewCerts,
+}
+
+const actionRequeue = "requeue"
+
+var errActionNotAllowed = errors.New("action not allowed")
+
+// action runs an admin action against the cluster addressed by the request
+// path.  Actions are restricted to elevated users and, like the equivalent
+// admin API requests, are audited by the request logging middleware.
+func (p *portal) action(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resourceID := strings.Join(strings.Split(r.URL.Path, "/")[:9], "/")
+	if !validate.RxClusterID.MatchString(resourceID) {
+		http.Error(w, fmt.Sprintf("invalid resourceId %q", resourceID), http.StatusBadRequest)
+		return
+	}
+
+	action := mux.Vars(r)["action"]
+
+	if !p.isElevated(r) {
+		http.Error(w, "Elevated access is required.", http.StatusForbidden)
+		return
+	}
+
+	username, _ := ctx.Value(middleware.ContextKeyUsername).(string)
+	log := p.log.WithFields(logrus.Fields{
+		"username":   username,
+		"resourceID": resourceID,
+		"action":     action,
+	})
+
+	var err error
+	if task, ok := actionMaintenanceTasks[action]; ok {
+		err = p.adminUpdate(ctx, resourceID, task)
+	} else if action == actionRequeue {
+		err = p.requeue(ctx, resourceID)
+	} else {
+		http.Error(w, fmt.Sprintf("invalid action %q", action), http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errActionNotAllowed):
+		log.Info(err)
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		p.internalServerError(w, err)
+		return
+	}
+
+	log.Info("portal action started")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// adminUpdate starts an admin update running the given maintenance task, in
+// the same way as an admin API PATCH of the cluster
+func (p *portal) adminUpdate(ctx context.Context, resourceID string, task api.MaintenanceTask) error {
+	asyncOperationID := p.dbAsyncOperations.NewUUID()
+
+	doc, err := p.dbOpenShiftClusters.Patch(ctx, resourceID, func(doc *api.OpenShiftClusterDocument) error {
+		ps := doc.OpenShiftCluster.Properties.ProvisioningState
+		if !ps.IsTerminal() ||
+			(ps == api.ProvisioningStateFailed && doc.OpenShiftCluster.Properties.FailedProvisioningState != api.ProvisioningStateUpdating) {
+			return fmt.Errorf("%w in provisioningState %q", errActionNotAllowed, ps)
+		}
+
+		doc.OpenShiftCluster.Properties.MaintenanceTask = task
+		api.SetAdminUpdateProvisioningState(doc)
+		doc.AsyncOperationID = asyncOperationID
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.createAsyncOperation(ctx, resourceID, doc)
+}
+
+// requeue gives a cluster operation a fresh set of dequeue attempts.  This is
+// allowed for an operation which is queued in the backend but not currently
+// leased, and for one which the backend failed because it was dequeued too
+// many times, which is put back into its original provisioning state.
+func (p *portal) requeue(ctx context.Context, resourceID string) error {
+	asyncOperationID := p.dbAsyncOperations.NewUUID()
+
+	var failed bool
+	doc, err := p.dbOpenShiftClusters.Patch(ctx, resourceID, func(doc *api.OpenShiftClusterDocument) error {
+		ps := doc.OpenShiftCluster.Properties.ProvisioningState
+		failed = ps == api.ProvisioningStateFailed &&
+			doc.OpenShiftCluster.Properties.FailedProvisioningState != "" &&
+			doc.Dequeues > database.MaxDequeueCount
+
+		switch {
+		case failed:
+			doc.OpenShiftCluster.Properties.ProvisioningState = doc.OpenShiftCluster.Properties.FailedProvisioningState
+			doc.OpenShiftCluster.Properties.FailedProvisioningState = ""
+			doc.AsyncOperationID = asyncOperationID
+		case ps.IsTerminal():
+			return fmt.Errorf("%w in provisioningState %q", errActionNotAllowed, ps)
+		case doc.LeaseExpires > int(time.Now().Unix()):
+			return fmt.Errorf("%w while the document is leased", errActionNotAllowed)
+		}
+
+		doc.Dequeues = 0
+
+		return nil
+	})
+	if err != nil || !failed {
+		return err
+	}
+
+	return p.createAsyncOperation(ctx, resourceID, doc)
+}
+
+// createAsyncOperation creates the asynchronous operation of the operation
+// which has just been queued on doc
+func (p *portal) createAsyncOperation(ctx context.Context, resourceID string, doc *api.OpenShiftClusterDocument) error {
+	r, err := azure.ParseResourceID(resourceID)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.dbAsyncOperations.Create(ctx, &api.AsyncOperationDocument{
+		ID:                  doc.AsyncOperationID,
+		OpenShiftClusterKey: doc.Key,
+		AsyncOperation: &api.AsyncOperation{
+			ID:                       "/subscriptions/" + r.SubscriptionID + "/providers/" + r.Provider + "/locations/" + strings.ToLower(p.env.Location()) + "/operationsstatus/" + doc.AsyncOperationID,
+			Name:                     doc.AsyncOperationID,
+			InitialProvisioningState: doc.OpenShiftCluster.Properties.ProvisioningState,
+			ProvisioningState:        doc.OpenShiftCluster.Properties.ProvisioningState,
+			StartTime:                time.Now().UTC(),
+		},
+	})
+	return err
+}
@@ -0,0 +1,309 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/portal/middleware"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAction(t *testing.T) {
+	ctx := context.Background()
+
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/resourcename"
+	elevatedGroupIDs := []string{"00000000-0000-0000-0000-000000000001"}
+
+	for _, tt := range []struct {
+		name              string
+		action            string
+		groups            []string
+		doc               *api.OpenShiftClusterDocument
+		wantStatusCode    int
+		wantDoc           *api.OpenShiftClusterDocument
+		wantAsyncOpStatus api.ProvisioningState
+	}{
+		{
+			name:   "admin update",
+			action: "adminupdate",
+			groups: elevatedGroupIDs,
+			doc: &api.OpenShiftClusterDocument{
+				Key: resourceID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState:    api.ProvisioningStateSucceeded,
+						LastAdminUpdateError: "error",
+					},
+				},
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantDoc: &api.OpenShiftClusterDocument{
+				Key: resourceID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState:     api.ProvisioningStateAdminUpdating,
+						LastProvisioningState: api.ProvisioningStateSucceeded,
+						MaintenanceTask:       api.MaintenanceTaskEverything,
+						MaintenanceState:      api.MaintenanceStateUnplanned,
+					},
+				},
+			},
+			wantAsyncOpStatus: api.ProvisioningStateAdminUpdating,
+		},
+		{
+			name:   "renew certificates during planned maintenance",
+			action: "renewcertificates",
+			groups: elevatedGroupIDs,
+			doc: &api.OpenShiftClusterDocument{
+				Key: resourceID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateSucceeded,
+						MaintenanceState:  api.MaintenanceStatePending,
+					},
+				},
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantDoc: &api.OpenShiftClusterDocument{
+				Key: resourceID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState:     api.ProvisioningStateAdminUpdating,
+						LastProvisioningState: api.ProvisioningStateSucceeded,
+						MaintenanceTask:       api.MaintenanceTaskRenewCerts,
+						MaintenanceState:      api.MaintenanceStatePlanned,
+					},
+				},
+			},
+			wantAsyncOpStatus: api.ProvisioningStateAdminUpdating,
+		},
+		{
+			name:   "admin update while updating",
+			action: "adminupdate",
+			groups: elevatedGroupIDs,
+			doc: &api.OpenShiftClusterDocument{
+				Key: resourceID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateUpdating,
+					},
+				},
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name:   "requeue",
+			action: "requeue",
+			groups: elevatedGroupIDs,
+			doc: &api.OpenShiftClusterDocument{
+				Key:      resourceID,
+				Dequeues: 5,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateUpdating,
+					},
+				},
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantDoc: &api.OpenShiftClusterDocument{
+				Key: resourceID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateUpdating,
+					},
+				},
+			},
+		},
+		{
+			name:   "requeue while leased",
+			action: "requeue",
+			groups: elevatedGroupIDs,
+			doc: &api.OpenShiftClusterDocument{
+				Key:          resourceID,
+				Dequeues:     5,
+				LeaseExpires: int(time.Now().Add(time.Minute).Unix()),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateUpdating,
+					},
+				},
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name:   "requeue in terminal state",
+			action: "requeue",
+			groups: elevatedGroupIDs,
+			doc: &api.OpenShiftClusterDocument{
+				Key: resourceID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateSucceeded,
+					},
+				},
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name:   "requeue after too many dequeues",
+			action: "requeue",
+			groups: elevatedGroupIDs,
+			doc: &api.OpenShiftClusterDocument{
+				Key:      resourceID,
+				Dequeues: 6,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState:       api.ProvisioningStateFailed,
+						FailedProvisioningState: api.ProvisioningStateCreating,
+					},
+				},
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantDoc: &api.OpenShiftClusterDocument{
+				Key: resourceID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateCreating,
+					},
+				},
+			},
+			wantAsyncOpStatus: api.ProvisioningStateCreating,
+		},
+		{
+			name:   "requeue after a failed operation",
+			action: "requeue",
+			groups: elevatedGroupIDs,
+			doc: &api.OpenShiftClusterDocument{
+				Key:      resourceID,
+				Dequeues: 1,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState:       api.ProvisioningStateFailed,
+						FailedProvisioningState: api.ProvisioningStateCreating,
+					},
+				},
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name:           "not elevated",
+			action:         "adminupdate",
+			groups:         []string{},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "invalid action",
+			action:         "invalid",
+			groups:         elevatedGroupIDs,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockCore(controller)
+			_env.EXPECT().Location().AnyTimes().Return("eastus")
+
+			dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+			dbAsyncOperations, _ := testdatabase.NewFakeAsyncOperations()
+
+			if tt.doc != nil {
+				fixture := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+				fixture.AddOpenShiftClusterDocuments(tt.doc)
+				err := fixture.Create()
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			p := &portal{
+				env:                 _env,
+				log:                 utillog.GetLogger(),
+				elevatedGroupIDs:    elevatedGroupIDs,
+				dbOpenShiftClusters: dbOpenShiftClusters,
+				dbAsyncOperations:   dbAsyncOperations,
+			}
+
+			req, err := http.NewRequest(http.MethodPost, resourceID+"/actions/"+tt.action, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reqCtx := context.WithValue(req.Context(), middleware.ContextKeyUsername, "username")
+			reqCtx = context.WithValue(reqCtx, middleware.ContextKeyGroups, tt.groups)
+			req = req.WithContext(reqCtx)
+
+			r := mux.NewRouter()
+			p.aadAuthenticatedRoutes(r, nil, nil, nil)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatusCode {
+				t.Error(w.Code, w.Body.String())
+			}
+
+			if tt.wantDoc == nil {
+				return
+			}
+
+			doc, err := dbOpenShiftClusters.Get(ctx, resourceID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if doc.OpenShiftCluster.Properties.ProvisioningState != tt.wantDoc.OpenShiftCluster.Properties.ProvisioningState ||
+				doc.OpenShiftCluster.Properties.LastProvisioningState != tt.wantDoc.OpenShiftCluster.Properties.LastProvisioningState ||
+				doc.OpenShiftCluster.Properties.FailedProvisioningState != tt.wantDoc.OpenShiftCluster.Properties.FailedProvisioningState ||
+				doc.OpenShiftCluster.Properties.MaintenanceTask != tt.wantDoc.OpenShiftCluster.Properties.MaintenanceTask ||
+				doc.OpenShiftCluster.Properties.MaintenanceState != tt.wantDoc.OpenShiftCluster.Properties.MaintenanceState ||
+				doc.OpenShiftCluster.Properties.LastAdminUpdateError != "" ||
+				doc.Dequeues != 0 {
+				t.Errorf("unexpected document %#v", doc.OpenShiftCluster.Properties)
+			}
+
+			if tt.wantAsyncOpStatus == "" {
+				if doc.AsyncOperationID != "" {
+					t.Error(doc.AsyncOperationID)
+				}
+				return
+			}
+
+			asyncOp, err := dbAsyncOperations.Get(ctx, doc.AsyncOperationID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if asyncOp.OpenShiftClusterKey != resourceID ||
+				asyncOp.AsyncOperation.ProvisioningState != tt.wantAsyncOpStatus ||
+				asyncOp.AsyncOperation.ID != "/subscriptions/00000000-0000-0000-0000-000000000000/providers/microsoft.redhatopenshift/locations/eastus/operationsstatus/"+doc.AsyncOperationID {
+				t.Errorf("unexpected async operation %#v", asyncOp.AsyncOperation)
+			}
+		})
+	}
+}
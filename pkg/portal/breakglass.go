@@ -0,0 +1,93 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/portal/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/breakglass"
+)
+
+// breakGlassKubeconfig mints a short-lived kubeconfig authenticating directly
+// to the API server with a client certificate signed by the cluster
+// break-glass certificate authority.  Unlike portal kubeconfigs, it does not
+// depend on the portal proxy or on the cluster's authentication stack.  The
+// issuance is recorded as a portal session before the kubeconfig is returned.
+func (p *portal) breakGlassKubeconfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resourceID := strings.Join(strings.Split(r.URL.Path, "/")[:9], "/")
+	if !validate.RxClusterID.MatchString(resourceID) {
+		http.Error(w, fmt.Sprintf("invalid resourceId %q", resourceID), http.StatusBadRequest)
+		return
+	}
+
+	if !p.isElevated(r) {
+		http.Error(w, "Elevated access is required.", http.StatusForbidden)
+		return
+	}
+
+	username, _ := ctx.Value(middleware.ContextKeyUsername).(string)
+
+	doc, err := p.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	case err != nil:
+		p.internalServerError(w, err)
+		return
+	}
+
+	if doc.OpenShiftCluster.Properties.BreakGlassCA == nil {
+		http.Error(w, "The cluster has no break-glass certificate authority; run an admin update first.", http.StatusConflict)
+		return
+	}
+
+	now := time.Now()
+
+	b, cert, err := breakglass.NewKubeconfig(doc.OpenShiftCluster, username, now)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	_, err = p.dbPortalSessions.Create(ctx, &api.PortalSessionDocument{
+		ID:  p.dbPortalSessions.NewUUID(),
+		Key: strings.ToLower(resourceID),
+		PortalSession: &api.PortalSession{
+			Username:              username,
+			ResourceID:            resourceID,
+			Kind:                  api.PortalSessionKindBreakGlass,
+			Elevated:              true,
+			BreakGlassCertificate: cert,
+			CreationTime:          int(now.Unix()),
+		},
+	})
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"username":   username,
+		"resourceID": resourceID,
+		"commonName": cert.CommonName,
+	}).Infof("issued break-glass kubeconfig with serial %d", cert.Serial)
+
+	filename := strings.Split(r.URL.Path, "/")[8] + "-breakglass"
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Content-Disposition", `attachment; filename="`+filename+`.kubeconfig"`)
+	_, _ = w.Write(b)
+}
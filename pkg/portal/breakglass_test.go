@@ -0,0 +1,162 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/portal/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/breakglass"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestBreakGlassKubeconfig(t *testing.T) {
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/resourcename"
+	elevatedGroupIDs := []string{"00000000-0000-0000-0000-000000000001"}
+
+	ca, err := breakglass.NewCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name           string
+		groups         []string
+		breakGlassCA   []byte
+		wantStatusCode int
+		wantSession    bool
+	}{
+		{
+			name:           "kubeconfig issued",
+			groups:         elevatedGroupIDs,
+			breakGlassCA:   ca,
+			wantStatusCode: http.StatusOK,
+			wantSession:    true,
+		},
+		{
+			name:           "no certificate authority",
+			groups:         elevatedGroupIDs,
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name:           "not elevated",
+			groups:         []string{},
+			breakGlassCA:   ca,
+			wantStatusCode: http.StatusForbidden,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockCore(controller)
+
+			dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+			dbPortalSessions, portalSessionsClient := testdatabase.NewFakePortalSessions()
+
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: resourceID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ClusterProfile: api.ClusterProfile{
+							Domain: "cluster.location.aroapp.io",
+						},
+						APIServerProfile: api.APIServerProfile{
+							URL: "https://api.example.com:6443/",
+						},
+						BreakGlassCA: tt.breakGlassCA,
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checker := testdatabase.NewChecker()
+			if tt.wantSession {
+				checker.AddPortalSessionDocuments(&api.PortalSessionDocument{
+					Key: resourceID,
+					PortalSession: &api.PortalSession{
+						Username:   "username",
+						ResourceID: resourceID,
+						Kind:       api.PortalSessionKindBreakGlass,
+						Elevated:   true,
+						BreakGlassCertificate: &api.BreakGlassCertificate{
+							CommonName: breakglass.UsernamePrefix + "username",
+						},
+					},
+				})
+			}
+
+			p := &portal{
+				env:                 _env,
+				log:                 utillog.GetLogger(),
+				elevatedGroupIDs:    elevatedGroupIDs,
+				dbOpenShiftClusters: dbOpenShiftClusters,
+				dbPortalSessions:    dbPortalSessions,
+			}
+
+			req, err := http.NewRequest(http.MethodPost, resourceID+"/breakglass/new", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reqCtx := context.WithValue(req.Context(), middleware.ContextKeyUsername, "username")
+			reqCtx = context.WithValue(reqCtx, middleware.ContextKeyGroups, tt.groups)
+			req = req.WithContext(reqCtx)
+
+			r := mux.NewRouter()
+			p.aadAuthenticatedRoutes(r, nil, nil, nil)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatusCode {
+				t.Error(w.Code, w.Body.String())
+			}
+
+			for _, err = range checker.CheckPortalSessions(portalSessionsClient) {
+				t.Error(err)
+			}
+
+			if !tt.wantSession {
+				return
+			}
+
+			if w.Header().Get("Content-Disposition") != `attachment; filename="resourcename-breakglass.kubeconfig"` {
+				t.Error(w.Header().Get("Content-Disposition"))
+			}
+
+			var kubeconfig *clientcmdv1.Config
+			err = json.Unmarshal(w.Body.Bytes(), &kubeconfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cert, err := utilpem.ParseFirstCertificate(kubeconfig.AuthInfos[0].AuthInfo.ClientCertificateData)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cert.Subject.CommonName != breakglass.UsernamePrefix+"username" {
+				t.Error(cert.Subject.CommonName)
+			}
+		})
+	}
+}
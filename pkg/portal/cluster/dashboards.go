@@ -0,0 +1,113 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"errors"
+)
+
+// Panel is a single PromQL range query on a dashboard
+type Panel struct {
+	Title string `json:"title"`
+	Query string `json:"query"`
+	Unit  string `json:"unit,omitempty"`
+}
+
+// Dashboard is a named set of panels which are queried together
+type Dashboard struct {
+	Name   string  `json:"name"`
+	Title  string  `json:"title"`
+	Panels []Panel `json:"panels"`
+}
+
+// dashboards are the built-in dashboards.  Names must be lower case, as the
+// portal lower-cases request paths.
+var dashboards = []Dashboard{
+	{
+		Name:  "etcd",
+		Title: "etcd health",
+		Panels: []Panel{
+			{
+				Title: "Has leader",
+				Query: `max(etcd_server_has_leader) by (pod)`,
+			},
+			{
+				Title: "Leader changes",
+				Query: `sum(changes(etcd_server_leader_changes_seen_total[1h])) by (pod)`,
+			},
+			{
+				Title: "WAL fsync p99",
+				Query: `histogram_quantile(0.99, sum(rate(etcd_disk_wal_fsync_duration_seconds_bucket[5m])) by (pod, le))`,
+				Unit:  "s",
+			},
+			{
+				Title: "Backend commit p99",
+				Query: `histogram_quantile(0.99, sum(rate(etcd_disk_backend_commit_duration_seconds_bucket[5m])) by (pod, le))`,
+				Unit:  "s",
+			},
+			{
+				Title: "Database size",
+				Query: `max(etcd_mvcc_db_total_size_in_bytes) by (pod)`,
+				Unit:  "bytes",
+			},
+		},
+	},
+	{
+		Name:  "apilatency",
+		Title: "API latency",
+		Panels: []Panel{
+			{
+				Title: "Request latency p99 by verb",
+				Query: `histogram_quantile(0.99, sum(rate(apiserver_request_duration_seconds_bucket{job="apiserver",verb!~"WATCH|CONNECT"}[5m])) by (verb, le))`,
+				Unit:  "s",
+			},
+			{
+				Title: "Request rate by code",
+				Query: `sum(rate(apiserver_request_total{job="apiserver"}[5m])) by (code)`,
+				Unit:  "req/s",
+			},
+			{
+				Title: "Inflight requests",
+				Query: `sum(apiserver_current_inflight_requests) by (request_kind)`,
+			},
+		},
+	},
+	{
+		Name:  "noderesources",
+		Title: "Node resources",
+		Panels: []Panel{
+			{
+				Title: "CPU utilisation",
+				Query: `1 - avg(rate(node_cpu_seconds_total{mode="idle"}[5m])) by (instance)`,
+				Unit:  "ratio",
+			},
+			{
+				Title: "Memory utilisation",
+				Query: `1 - sum(node_memory_MemAvailable_bytes) by (instance) / sum(node_memory_MemTotal_bytes) by (instance)`,
+				Unit:  "ratio",
+			},
+			{
+				Title: "Root filesystem utilisation",
+				Query: `1 - sum(node_filesystem_avail_bytes{mountpoint="/"}) by (instance) / sum(node_filesystem_size_bytes{mountpoint="/"}) by (instance)`,
+				Unit:  "ratio",
+			},
+		},
+	},
+}
+
+// Dashboards returns the built-in dashboards
+func Dashboards() []Dashboard {
+	return dashboards
+}
+
+// GetDashboard returns the built-in dashboard with the given name
+func GetDashboard(name string) (*Dashboard, error) {
+	for i := range dashboards {
+		if dashboards[i].Name == name {
+			return &dashboards[i], nil
+		}
+	}
+
+	return nil, errors.New("invalid dashboard '" + name + "'")
+}
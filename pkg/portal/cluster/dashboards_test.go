@@ -0,0 +1,49 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"strings"
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestDashboards(t *testing.T) {
+	names := map[string]struct{}{}
+
+	for _, dashboard := range Dashboards() {
+		if dashboard.Name != strings.ToLower(dashboard.Name) {
+			t.Errorf("dashboard %q: name must be lower case", dashboard.Name)
+		}
+
+		if _, ok := names[dashboard.Name]; ok {
+			t.Errorf("dashboard %q: duplicate name", dashboard.Name)
+		}
+		names[dashboard.Name] = struct{}{}
+
+		if len(dashboard.Panels) == 0 {
+			t.Errorf("dashboard %q: no panels", dashboard.Name)
+		}
+
+		for _, panel := range dashboard.Panels {
+			if panel.Title == "" || panel.Query == "" {
+				t.Errorf("dashboard %q: incomplete panel %#v", dashboard.Name, panel)
+			}
+		}
+	}
+}
+
+func TestGetDashboard(t *testing.T) {
+	dashboard, err := GetDashboard("etcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dashboard.Name != "etcd" {
+		t.Error(dashboard.Name)
+	}
+
+	_, err = GetDashboard("invalid")
+	utilerror.AssertErrorMessage(t, err, "invalid dashboard 'invalid'")
+}
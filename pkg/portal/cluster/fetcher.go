@@ -5,6 +5,7 @@ package cluster
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"time"
 
@@ -12,8 +13,10 @@ import (
 	machineclient "github.com/openshift/client-go/machine/clientset/versioned"
 	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	aroclient "github.com/Azure/ARO-RP/pkg/operator/clientset/versioned"
 	"github.com/Azure/ARO-RP/pkg/proxy"
 	"github.com/Azure/ARO-RP/pkg/util/restconfig"
 )
@@ -26,6 +29,10 @@ type FetchClient interface {
 	Machines(context.Context) (*MachineListInformation, error)
 	MachineSets(context.Context) (*MachineSetListInformation, error)
 	Statistics(context.Context, *http.Client, string, time.Duration, time.Time, string) ([]Metrics, error)
+	Pods(context.Context) (*PodListInformation, error)
+	PodLogs(context.Context, string, string, string, bool, io.Writer) error
+	PodExec(context.Context, string, string, string, []string, io.Writer, io.Writer) error
+	OperatorFlags(context.Context) (map[string]string, error)
 }
 
 // client is an implementation of FetchClient. It currently contains a "fetcher"
@@ -45,9 +52,11 @@ type client struct {
 // structures. The concrete implementation of FetchClient wraps this.
 type realFetcher struct {
 	log           *logrus.Entry
+	restConfig    *rest.Config
 	configCli     configclient.Interface
 	kubernetesCli kubernetes.Interface
 	machineClient machineclient.Interface
+	aroClient     aroclient.Interface
 }
 
 func newRealFetcher(log *logrus.Entry, dialer proxy.Dialer, doc *api.OpenShiftClusterDocument) (*realFetcher, error) {
@@ -74,11 +83,19 @@ func newRealFetcher(log *logrus.Entry, dialer proxy.Dialer, doc *api.OpenShiftCl
 		return nil, err
 	}
 
+	aroClient, err := aroclient.NewForConfig(restConfig)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
 	return &realFetcher{
 		log:           log,
+		restConfig:    restConfig,
 		configCli:     configCli,
 		kubernetesCli: kubernetesCli,
 		machineClient: machineClient,
+		aroClient:     aroClient,
 	}, nil
 }
 
@@ -0,0 +1,25 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+)
+
+func (f *realFetcher) OperatorFlags(ctx context.Context) (map[string]string, error) {
+	cluster, err := f.aroClient.AroV1alpha1().Clusters().Get(ctx, arov1alpha1.SingletonClusterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster.Spec.OperatorFlags, nil
+}
+
+func (c *client) OperatorFlags(ctx context.Context) (map[string]string, error) {
+	return c.fetcher.OperatorFlags(ctx)
+}
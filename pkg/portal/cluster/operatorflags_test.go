@@ -0,0 +1,74 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	arofake "github.com/Azure/ARO-RP/pkg/operator/clientset/versioned/fake"
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestOperatorFlags(t *testing.T) {
+	ctx := context.Background()
+
+	_, log := testlog.New()
+
+	for _, tt := range []struct {
+		name      string
+		cluster   *arov1alpha1.Cluster
+		wantFlags map[string]string
+		wantErr   string
+	}{
+		{
+			name: "flags",
+			cluster: &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					OperatorFlags: arov1alpha1.OperatorFlags{
+						"aro.alertwebhook.enabled": "true",
+					},
+				},
+			},
+			wantFlags: map[string]string{
+				"aro.alertwebhook.enabled": "true",
+			},
+		},
+		{
+			name:    "no cluster",
+			wantErr: `clusters.aro.openshift.io "cluster" not found`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			aroClient := arofake.NewSimpleClientset()
+			if tt.cluster != nil {
+				aroClient = arofake.NewSimpleClientset(tt.cluster)
+			}
+
+			rf := &realFetcher{
+				aroClient: aroClient,
+				log:       log,
+			}
+
+			c := &client{fetcher: rf, log: log}
+
+			flags, err := c.OperatorFlags(ctx)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+
+			for _, l := range deep.Equal(flags, tt.wantFlags) {
+				t.Error(l)
+			}
+		})
+	}
+}
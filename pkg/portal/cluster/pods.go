@@ -0,0 +1,155 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+type PodContainer struct {
+	Name         string `json:"name"`
+	Ready        bool   `json:"ready"`
+	RestartCount int32  `json:"restartCount"`
+}
+
+type PodInformation struct {
+	Name        string         `json:"name"`
+	Namespace   string         `json:"namespace"`
+	NodeName    string         `json:"nodeName"`
+	Phase       string         `json:"phase"`
+	CreatedTime string         `json:"createdTime"`
+	Containers  []PodContainer `json:"containers"`
+}
+
+type PodListInformation struct {
+	Pods []PodInformation `json:"pods"`
+}
+
+// IsOpenShiftNamespace returns true if namespace is one of the platform
+// namespaces which the portal allows pod logs and exec in
+func IsOpenShiftNamespace(namespace string) bool {
+	return strings.HasPrefix(namespace, "openshift-")
+}
+
+func PodsFromPodList(pods *corev1.PodList) *PodListInformation {
+	final := &PodListInformation{
+		Pods: []PodInformation{},
+	}
+
+	for _, pod := range pods.Items {
+		if !IsOpenShiftNamespace(pod.Namespace) {
+			continue
+		}
+
+		final.Pods = append(final.Pods, PodInformation{
+			Name:        pod.Name,
+			Namespace:   pod.Namespace,
+			NodeName:    pod.Spec.NodeName,
+			Phase:       string(pod.Status.Phase),
+			CreatedTime: pod.CreationTimestamp.String(),
+			Containers:  getPodContainers(pod),
+		})
+	}
+
+	return final
+}
+
+func (f *realFetcher) Pods(ctx context.Context) (*PodListInformation, error) {
+	r, err := f.kubernetesCli.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return PodsFromPodList(r), nil
+}
+
+func (c *client) Pods(ctx context.Context) (*PodListInformation, error) {
+	return c.fetcher.Pods(ctx)
+}
+
+// PodLogs streams the logs of a container to w.  If follow is set, it returns
+// once the container exits or ctx is done.
+func (f *realFetcher) PodLogs(ctx context.Context, namespace, name, container string, follow bool, w io.Writer) error {
+	if !IsOpenShiftNamespace(namespace) {
+		return fmt.Errorf("namespace %q is not an openshift namespace", namespace)
+	}
+
+	rc, err := f.kubernetesCli.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{
+		Container: container,
+		Follow:    follow,
+	}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	_, err = io.Copy(w, rc)
+	return err
+}
+
+func (c *client) PodLogs(ctx context.Context, namespace, name, container string, follow bool, w io.Writer) error {
+	return c.fetcher.PodLogs(ctx, namespace, name, container, follow, w)
+}
+
+// PodExec runs command in a container and streams its output to stdout and
+// stderr until the command exits.  No stdin or TTY is attached, so the
+// session is non-interactive.
+func (f *realFetcher) PodExec(ctx context.Context, namespace, name, container string, command []string, stdout, stderr io.Writer) error {
+	if !IsOpenShiftNamespace(namespace) {
+		return fmt.Errorf("namespace %q is not an openshift namespace", namespace)
+	}
+
+	req := f.kubernetesCli.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(f.restConfig, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	return exec.Stream(remotecommand.StreamOptions{
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+func (c *client) PodExec(ctx context.Context, namespace, name, container string, command []string, stdout, stderr io.Writer) error {
+	return c.fetcher.PodExec(ctx, namespace, name, container, command, stdout, stderr)
+}
+
+// Helper Functions
+func getPodContainers(pod corev1.Pod) []PodContainer {
+	statuses := map[string]corev1.ContainerStatus{}
+	for _, status := range pod.Status.ContainerStatuses {
+		statuses[status.Name] = status
+	}
+
+	containers := make([]PodContainer, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, PodContainer{
+			Name:         container.Name,
+			Ready:        statuses[container.Name].Ready,
+			RestartCount: statuses[container.Name].RestartCount,
+		})
+	}
+
+	return containers
+}
@@ -0,0 +1,109 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestPods(t *testing.T) {
+	ctx := context.Background()
+
+	kubernetes := fake.NewSimpleClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "apiserver-0",
+				Namespace: "openshift-apiserver",
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "aro-master-0",
+				Containers: []corev1.Container{
+					{Name: "openshift-apiserver"},
+					{Name: "openshift-apiserver-check-endpoints"},
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name:         "openshift-apiserver",
+						Ready:        true,
+						RestartCount: 2,
+					},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "customer",
+				Namespace: "default",
+			},
+		},
+	)
+
+	_, log := testlog.New()
+
+	rf := &realFetcher{
+		kubernetesCli: kubernetes,
+		log:           log,
+	}
+
+	c := &client{fetcher: rf, log: log}
+
+	info, err := c.Pods(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &PodListInformation{
+		Pods: []PodInformation{
+			{
+				Name:        "apiserver-0",
+				Namespace:   "openshift-apiserver",
+				NodeName:    "aro-master-0",
+				Phase:       "Running",
+				CreatedTime: metav1.Time{}.String(),
+				Containers: []PodContainer{
+					{
+						Name:         "openshift-apiserver",
+						Ready:        true,
+						RestartCount: 2,
+					},
+					{
+						Name: "openshift-apiserver-check-endpoints",
+					},
+				},
+			},
+		},
+	}
+
+	for _, l := range deep.Equal(expected, info) {
+		t.Error(l)
+	}
+}
+
+func TestPodLogsRejectsNonOpenShiftNamespace(t *testing.T) {
+	_, log := testlog.New()
+
+	rf := &realFetcher{
+		kubernetesCli: fake.NewSimpleClientset(),
+		log:           log,
+	}
+
+	err := rf.PodLogs(context.Background(), "default", "customer", "", false, &bytes.Buffer{})
+	utilerror.AssertErrorMessage(t, err, `namespace "default" is not an openshift namespace`)
+
+	err = rf.PodExec(context.Background(), "default", "customer", "", []string{"ls"}, &bytes.Buffer{}, &bytes.Buffer{})
+	utilerror.AssertErrorMessage(t, err, `namespace "default" is not an openshift namespace`)
+}
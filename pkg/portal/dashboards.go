@@ -0,0 +1,178 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Azure/ARO-RP/pkg/portal/cluster"
+	"github.com/Azure/ARO-RP/pkg/portal/prometheus"
+)
+
+type panelResult struct {
+	cluster.Panel
+	Metrics []cluster.Metrics `json:"metrics"`
+	Error   string            `json:"error,omitempty"`
+}
+
+type dashboardResult struct {
+	Name   string        `json:"name"`
+	Title  string        `json:"title"`
+	Panels []panelResult `json:"panels"`
+}
+
+// promQuerier runs range queries against a cluster's in-cluster Prometheus,
+// which is reached through a port-forward via the cluster's API server
+type promQuerier struct {
+	fetcher       cluster.FetchClient
+	httpClient    *http.Client
+	prometheusURL string
+}
+
+func (q *promQuerier) query(ctx context.Context, promQuery string, duration time.Duration, endTime time.Time) ([]cluster.Metrics, error) {
+	return q.fetcher.Statistics(ctx, q.httpClient, promQuery, duration, endTime, q.prometheusURL)
+}
+
+func (p *portal) makePromQuerier(ctx context.Context, r *http.Request) (*promQuerier, error) {
+	apiVars := mux.Vars(r)
+	resourceID := p.getResourceID(apiVars["subscription"], apiVars["resourceGroup"], apiVars["clusterName"])
+
+	prom := prometheus.New(p.log, p.dbOpenShiftClusters, p.dialer)
+	httpClient, err := prom.Cli(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	promHost, promScheme := prom.GetPrometheusHostAndScheme()
+
+	return &promQuerier{
+		fetcher:       fetcher,
+		httpClient:    httpClient,
+		prometheusURL: promScheme + "://" + promHost,
+	}, nil
+}
+
+// parseTimeRange returns the duration and endtime query parameters of r
+func parseTimeRange(r *http.Request) (time.Duration, time.Time, error) {
+	duration, err := time.ParseDuration(r.URL.Query().Get("duration"))
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	endTime, err := time.Parse(time.RFC3339, r.URL.Query().Get("endtime"))
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return duration, endTime, nil
+}
+
+// prometheusQuery runs the PromQL range query given in the query parameter
+func (p *portal) prometheusQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	duration, endTime, err := parseTimeRange(r)
+	if err != nil {
+		p.badRequest(w, err)
+		return
+	}
+
+	promQuery := r.URL.Query().Get("query")
+	if promQuery == "" {
+		p.badRequest(w, errors.New("query is required"))
+		return
+	}
+
+	q, err := p.makePromQuerier(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	metrics, err := q.query(ctx, promQuery, duration, endTime)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	p.writeJSON(w, metrics)
+}
+
+func (p *portal) dashboards(w http.ResponseWriter, r *http.Request) {
+	p.writeJSON(w, cluster.Dashboards())
+}
+
+// dashboard runs each panel of a built-in dashboard.  A panel whose query
+// fails is returned with its error so that the other panels can be shown.
+func (p *portal) dashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	duration, endTime, err := parseTimeRange(r)
+	if err != nil {
+		p.badRequest(w, err)
+		return
+	}
+
+	dashboard, err := cluster.GetDashboard(mux.Vars(r)["dashboard"])
+	if err != nil {
+		p.badRequest(w, err)
+		return
+	}
+
+	q, err := p.makePromQuerier(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	result := &dashboardResult{
+		Name:   dashboard.Name,
+		Title:  dashboard.Title,
+		Panels: make([]panelResult, 0, len(dashboard.Panels)),
+	}
+
+	for _, panel := range dashboard.Panels {
+		pr := panelResult{
+			Panel:   panel,
+			Metrics: []cluster.Metrics{},
+		}
+
+		metrics, err := q.query(ctx, panel.Query, duration, endTime)
+		if err != nil {
+			p.log.Warn(err)
+			pr.Error = err.Error()
+		} else {
+			pr.Metrics = metrics
+		}
+
+		result.Panels = append(result.Panels, pr)
+	}
+
+	p.writeJSON(w, result)
+}
+
+func (p *portal) writeJSON(w http.ResponseWriter, v interface{}) {
+	b, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(b)
+	if err != nil {
+		p.log.Error(err)
+	}
+}
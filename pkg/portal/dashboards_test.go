@@ -0,0 +1,85 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Azure/ARO-RP/pkg/portal/cluster"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+)
+
+func TestDashboardRoutes(t *testing.T) {
+	clusterPath := "/api/00000000-0000-0000-0000-000000000000/resourcegroupname/resourcename"
+
+	for _, tt := range []struct {
+		name           string
+		path           string
+		wantStatusCode int
+	}{
+		{
+			name:           "list dashboards",
+			path:           clusterPath + "/dashboards",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "invalid dashboard",
+			path:           clusterPath + "/dashboards/invalid?duration=1h&endtime=2011-01-02T01:03:00Z",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "dashboard with invalid duration",
+			path:           clusterPath + "/dashboards/etcd?duration=soon&endtime=2011-01-02T01:03:00Z",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "query without query",
+			path:           clusterPath + "/prometheus/query?duration=1h&endtime=2011-01-02T01:03:00Z",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "query with invalid endtime",
+			path:           clusterPath + "/prometheus/query?query=up&duration=1h&endtime=yesterday",
+			wantStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &portal{
+				log: utillog.GetLogger(),
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := mux.NewRouter()
+			p.aadAuthenticatedRoutes(r, nil, nil, nil)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatusCode {
+				t.Error(w.Code)
+			}
+
+			if tt.wantStatusCode == http.StatusOK {
+				var dashboards []cluster.Dashboard
+				err = json.NewDecoder(w.Body).Decode(&dashboards)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if len(dashboards) != len(cluster.Dashboards()) {
+					t.Error(len(dashboards))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/gorilla/mux"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/operator"
+)
+
+// subscriptionFeatures are the subscription feature flags read by the RP
+var subscriptionFeatures = []string{
+	api.FeatureFlagCheckAccessTestToggle,
+	api.FeatureFlagDualStack,
+	api.FeatureFlagLargeClusters,
+	api.FeatureFlagMTU3900,
+	api.FeatureFlagSaveAROTestConfig,
+	api.FeatureFlagSecurityPostureExport,
+}
+
+type SubscriptionFeature struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+
+	// Known is false for registered features which the RP does not read
+	Known bool `json:"known"`
+}
+
+type OperatorFlag struct {
+	Name     string `json:"name"`
+	Default  string `json:"default"`
+	Document string `json:"document"`
+	Cluster  string `json:"cluster"`
+
+	// NonDefault is set if the cluster document value is not the default
+	NonDefault bool `json:"nonDefault"`
+
+	// Drift is set if the live Cluster CR value differs from the cluster
+	// document, i.e. the operator flags have not been pushed to the cluster
+	Drift bool `json:"drift"`
+
+	// Change describes the admin API request which sets this flag
+	Change *AdminRequest `json:"change"`
+}
+
+type AdminRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body"`
+}
+
+type FlagsInformation struct {
+	SubscriptionFeatures []SubscriptionFeature `json:"subscriptionFeatures"`
+	OperatorFlags        []OperatorFlag        `json:"operatorFlags"`
+
+	// ClusterError is set if the live Cluster CR could not be read
+	ClusterError string `json:"clusterError,omitempty"`
+}
+
+// operatorFlagChange returns the admin API request which sets an operator flag
+// on the cluster document and pushes the flags to the cluster
+func operatorFlagChange(resourceID, name, value string) (*AdminRequest, error) {
+	b, err := json.Marshal(map[string]interface{}{
+		"properties": map[string]interface{}{
+			"maintenanceTask": api.MaintenanceTaskOperator,
+			"operatorFlags": map[string]string{
+				name: value,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdminRequest{
+		Method: http.MethodPatch,
+		URL:    "/admin" + resourceID + "?api-version=" + admin.LatestAPIVersion,
+		Body:   string(b),
+	}, nil
+}
+
+// flags returns the subscription feature flags, and the cluster document and
+// live Cluster CR operator flags side by side
+func (p *portal) flags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	apiVars := mux.Vars(r)
+	resourceID := p.getResourceID(apiVars["subscription"], apiVars["resourceGroup"], apiVars["clusterName"])
+
+	doc, err := p.dbOpenShiftClusters.Get(ctx, resourceID)
+	if err != nil {
+		http.Error(w, "Cluster not found", http.StatusNotFound)
+		return
+	}
+
+	resource, err := azure.ParseResourceID(resourceID)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	subscriptionDoc, err := p.dbSubscriptions.Get(ctx, resource.SubscriptionID)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	info := &FlagsInformation{
+		SubscriptionFeatures: []SubscriptionFeature{},
+		OperatorFlags:        []OperatorFlag{},
+	}
+
+	registered := map[string]string{}
+	if subscriptionDoc.Subscription.Properties != nil {
+		for _, f := range subscriptionDoc.Subscription.Properties.RegisteredFeatures {
+			registered[f.Name] = f.State
+		}
+	}
+
+	for _, name := range subscriptionFeatures {
+		state, ok := registered[name]
+		if !ok {
+			state = "NotRegistered"
+		}
+		delete(registered, name)
+
+		info.SubscriptionFeatures = append(info.SubscriptionFeatures, SubscriptionFeature{
+			Name:  name,
+			State: state,
+			Known: true,
+		})
+	}
+
+	for name, state := range registered {
+		info.SubscriptionFeatures = append(info.SubscriptionFeatures, SubscriptionFeature{
+			Name:  name,
+			State: state,
+		})
+	}
+
+	sort.SliceStable(info.SubscriptionFeatures, func(i, j int) bool {
+		return strings.Compare(info.SubscriptionFeatures[i].Name, info.SubscriptionFeatures[j].Name) < 0
+	})
+
+	var clusterFlags map[string]string
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err == nil {
+		clusterFlags, err = fetcher.OperatorFlags(ctx)
+	}
+	if err != nil {
+		p.log.Warn(err)
+		info.ClusterError = err.Error()
+	}
+
+	defaults := operator.DefaultOperatorFlags()
+
+	names := map[string]struct{}{}
+	for name := range defaults {
+		names[name] = struct{}{}
+	}
+	for name := range doc.OpenShiftCluster.Properties.OperatorFlags {
+		names[name] = struct{}{}
+	}
+	for name := range clusterFlags {
+		names[name] = struct{}{}
+	}
+
+	for name := range names {
+		flag := OperatorFlag{
+			Name:     name,
+			Default:  defaults[name],
+			Document: doc.OpenShiftCluster.Properties.OperatorFlags[name],
+			Cluster:  clusterFlags[name],
+		}
+
+		flag.NonDefault = flag.Document != flag.Default
+		flag.Drift = info.ClusterError == "" && flag.Cluster != flag.Document
+
+		flag.Change, err = operatorFlagChange(doc.OpenShiftCluster.ID, name, flag.Document)
+		if err != nil {
+			p.internalServerError(w, err)
+			return
+		}
+
+		info.OperatorFlags = append(info.OperatorFlags, flag)
+	}
+
+	sort.SliceStable(info.OperatorFlags, func(i, j int) bool {
+		return strings.Compare(info.OperatorFlags[i].Name, info.OperatorFlags[j].Name) < 0
+	})
+
+	p.writeJSON(w, info)
+}
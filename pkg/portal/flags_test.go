@@ -0,0 +1,139 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/operator"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestFlags(t *testing.T) {
+	subscriptionID := "00000000-0000-0000-0000-000000000000"
+	resourceID := "/subscriptions/" + subscriptionID + "/resourcegroups/resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/resourcename"
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	_env := mock_env.NewMockCore(controller)
+	_env.EXPECT().IsLocalDevelopmentMode().AnyTimes().Return(false)
+
+	dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+	dbSubscriptions, _ := testdatabase.NewFakeSubscriptions()
+
+	fixture := testdatabase.NewFixture().
+		WithOpenShiftClusters(dbOpenShiftClusters).
+		WithSubscriptions(dbSubscriptions)
+
+	operatorFlags := operator.DefaultOperatorFlags()
+	operatorFlags[operator.BannerEnabled] = operator.FlagTrue
+
+	fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+		Key: resourceID,
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID: resourceID,
+			Properties: api.OpenShiftClusterProperties{
+				OperatorFlags: operatorFlags,
+			},
+		},
+	})
+	fixture.AddSubscriptionDocuments(&api.SubscriptionDocument{
+		ID: subscriptionID,
+		Subscription: &api.Subscription{
+			State: api.SubscriptionStateRegistered,
+			Properties: &api.SubscriptionProperties{
+				RegisteredFeatures: []api.RegisteredFeatureProfile{
+					{
+						Name:  api.FeatureFlagMTU3900,
+						State: "Registered",
+					},
+					{
+						Name:  "Microsoft.RedHatOpenShift/Other",
+						State: "Registered",
+					},
+				},
+			},
+		},
+	})
+
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &portal{
+		env:                 _env,
+		log:                 utillog.GetLogger(),
+		dbOpenShiftClusters: dbOpenShiftClusters,
+		dbSubscriptions:     dbSubscriptions,
+	}
+
+	r := mux.NewRouter()
+	p.aadAuthenticatedRoutes(r, nil, nil, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/"+subscriptionID+"/resourcegroupname/resourcename/flags", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatal(w.Code, w.Body.String())
+	}
+
+	var info *FlagsInformation
+	err = json.NewDecoder(w.Body).Decode(&info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, l := range deep.Equal(info.SubscriptionFeatures, []SubscriptionFeature{
+		{Name: api.FeatureFlagCheckAccessTestToggle, State: "NotRegistered", Known: true},
+		{Name: api.FeatureFlagDualStack, State: "NotRegistered", Known: true},
+		{Name: api.FeatureFlagLargeClusters, State: "NotRegistered", Known: true},
+		{Name: api.FeatureFlagMTU3900, State: "Registered", Known: true},
+		{Name: "Microsoft.RedHatOpenShift/Other", State: "Registered"},
+		{Name: api.FeatureFlagSaveAROTestConfig, State: "NotRegistered", Known: true},
+		{Name: api.FeatureFlagSecurityPostureExport, State: "NotRegistered", Known: true},
+	}) {
+		t.Error(l)
+	}
+
+	// the cluster has no API server, so the live flags can't be read and no
+	// drift is reported
+	if info.ClusterError != "privateEndpointIP is empty" {
+		t.Error(info.ClusterError)
+	}
+
+	if len(info.OperatorFlags) != len(operatorFlags) {
+		t.Fatal(len(info.OperatorFlags))
+	}
+
+	for _, flag := range info.OperatorFlags {
+		if flag.Drift {
+			t.Errorf("unexpected drift for %s", flag.Name)
+		}
+		if flag.NonDefault != (flag.Name == operator.BannerEnabled) {
+			t.Errorf("unexpected nonDefault for %s", flag.Name)
+		}
+		if flag.Change.Method != http.MethodPatch ||
+			flag.Change.URL != "/admin"+resourceID+"?api-version="+admin.LatestAPIVersion {
+			t.Errorf("unexpected change %#v", flag.Change)
+		}
+	}
+}
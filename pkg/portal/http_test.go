@@ -33,7 +33,7 @@ func NewTestPortal(_env env.Core, dbOpenShiftClusters database.OpenShiftClusters
 	auditHook, portalAuditLog := testlog.NewAudit()
 
 	l := listener.NewListener()
-	p := NewPortal(_env, portalAuditLog, portalLog, portalAccessLog, l, nil, nil, "", nil, nil, "", nil, nil, make([]byte, 32), nil, nonElevatedGroupIDs, elevatedGroupIDs, dbOpenShiftClusters, dbPortal, nil, nil).(*portal)
+	p := NewPortal(_env, portalAuditLog, portalLog, portalAccessLog, l, nil, nil, "", nil, nil, "", nil, nil, make([]byte, 32), nil, viewerGroupIDs, nonElevatedGroupIDs, elevatedGroupIDs, dbOpenShiftClusters, dbPortal, nil, nil, nil, nil, nil).(*portal)
 
 	return &testPortal{
 		p:             p,
@@ -74,6 +74,18 @@ func (p *testPortal) Run(ctx context.Context) error {
 }
 
 func (p *testPortal) Request(method string, path string, authenticated bool, elevated bool) (*http.Response, error) {
+	if !authenticated {
+		return p.RequestWithGroups(method, path, nil)
+	}
+	if elevated {
+		return p.RequestWithGroups(method, path, elevatedGroupIDs)
+	}
+	return p.RequestWithGroups(method, path, nonElevatedGroupIDs)
+}
+
+// RequestWithGroups makes a request authenticated as a member of groups, or
+// an unauthenticated request if groups is nil
+func (p *testPortal) RequestWithGroups(method string, path string, groups []string) (*http.Response, error) {
 	p.portalLogHook.Reset()
 
 	req, err := http.NewRequest(method, "http://server"+path, nil)
@@ -86,13 +98,7 @@ func (p *testPortal) Request(method string, path string, authenticated bool, ele
 		return nil, err
 	}
 
-	if authenticated {
-		var groups []string
-		if elevated {
-			groups = elevatedGroupIDs
-		} else {
-			groups = nonElevatedGroupIDs
-		}
+	if groups != nil {
 		err = addAuth(req, groups)
 		if err != nil {
 			return nil, err
@@ -17,18 +17,20 @@ type PortalInfo struct {
 	Location  string `json:"location"`
 	CSRFToken string `json:"csrf"`
 	Elevated  bool   `json:"elevated"`
+	Role      string `json:"role"`
 	Username  string `json:"username"`
 	RPVersion string `json:"rpversion"`
 }
 
 func (p *portal) info(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	elevated := len(middleware.GroupsIntersect(p.elevatedGroupIDs, ctx.Value(middleware.ContextKeyGroups).([]string))) > 0
+	role := p.roles().RequestRole(r)
 
 	resp := PortalInfo{
 		Location:  p.env.Location(),
 		CSRFToken: csrf.Token(r),
-		Elevated:  elevated,
+		Elevated:  role >= middleware.RoleBreakglass,
+		Role:      role.String(),
 		Username:  ctx.Value(middleware.ContextKeyUsername).(string),
 		RPVersion: version.GitCommit,
 	}
@@ -45,35 +45,46 @@ func TestInfo(t *testing.T) {
 		name               string
 		expectedResponse   PortalInfo
 		expectedStatusCode int
-		authenticated      bool
-		elevated           bool
+		groups             []string
 	}{
+		{
+			name:               "viewer",
+			groups:             viewerGroupIDs,
+			expectedStatusCode: 200,
+			expectedResponse: PortalInfo{
+				Location:  "eastus",
+				Username:  "username",
+				Elevated:  false,
+				Role:      "viewer",
+				RPVersion: version.GitCommit,
+			},
+		},
 		{
 			name:               "basic",
-			authenticated:      true,
-			elevated:           false,
+			groups:             nonElevatedGroupIDs,
 			expectedStatusCode: 200,
 			expectedResponse: PortalInfo{
 				Location:  "eastus",
 				Username:  "username",
 				Elevated:  false,
+				Role:      "operator",
 				RPVersion: version.GitCommit,
 			},
 		},
 		{
 			name:               "elevated",
-			authenticated:      true,
-			elevated:           true,
+			groups:             elevatedGroupIDs,
 			expectedStatusCode: 200,
 			expectedResponse: PortalInfo{
 				Location:  "eastus",
 				Username:  "username",
 				Elevated:  true,
+				Role:      "breakglass",
 				RPVersion: version.GitCommit,
 			},
 		},
 	} {
-		resp, err := p.Request("GET", "/api/info", tt.authenticated, tt.elevated)
+		resp, err := p.RequestWithGroups("GET", "/api/info", tt.groups)
 		if err != nil {
 			p.DumpLogs(t)
 			t.Error(err)
@@ -41,6 +41,7 @@ type Kubeconfig struct {
 
 	dbOpenShiftClusters database.OpenShiftClusters
 	DbPortal            database.Portal
+	dbPortalSessions    database.PortalSessions
 
 	dialer      proxy.Dialer
 	clientCache clientcache.ClientCache
@@ -57,6 +58,7 @@ func New(baseLog *logrus.Entry,
 	elevatedGroupIDs []string,
 	dbOpenShiftClusters database.OpenShiftClusters,
 	dbPortal database.Portal,
+	dbPortalSessions database.PortalSessions,
 	dialer proxy.Dialer,
 ) *Kubeconfig {
 	k := &Kubeconfig{
@@ -69,6 +71,7 @@ func New(baseLog *logrus.Entry,
 
 		dbOpenShiftClusters: dbOpenShiftClusters,
 		DbPortal:            dbPortal,
+		dbPortalSessions:    dbPortalSessions,
 
 		dialer:      dialer,
 		clientCache: clientcache.New(time.Hour),
@@ -116,6 +119,22 @@ func (k *Kubeconfig) New(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	_, err = k.dbPortalSessions.Create(ctx, &api.PortalSessionDocument{
+		ID:  k.dbPortalSessions.NewUUID(),
+		Key: strings.ToLower(resourceID),
+		PortalSession: &api.PortalSession{
+			Username:     portalDoc.Portal.Username,
+			ResourceID:   resourceID,
+			Kind:         api.PortalSessionKindKubeconfig,
+			Elevated:     elevated,
+			CreationTime: int(time.Now().Unix()),
+		},
+	})
+	if err != nil {
+		k.internalServerError(w, err)
+		return
+	}
+
 	b, err := k.makeKubeconfig("https://"+r.Host+resourceID+"/kubeconfig/proxy", token)
 	if err != nil {
 		k.internalServerError(w, err)
@@ -55,6 +55,14 @@ func TestNew(t *testing.T) {
 					},
 				}
 				checker.AddPortalDocuments(portalDocument)
+				checker.AddPortalSessionDocuments(&api.PortalSessionDocument{
+					Key: resourceID,
+					PortalSession: &api.PortalSession{
+						Username:   username,
+						ResourceID: resourceID,
+						Kind:       api.PortalSessionKindKubeconfig,
+					},
+				})
 			},
 			wantStatusCode: http.StatusOK,
 			wantHeaders: http.Header{
@@ -78,6 +86,15 @@ func TestNew(t *testing.T) {
 					},
 				}
 				checker.AddPortalDocuments(portalDocument)
+				checker.AddPortalSessionDocuments(&api.PortalSessionDocument{
+					Key: resourceID,
+					PortalSession: &api.PortalSession{
+						Username:   username,
+						ResourceID: resourceID,
+						Kind:       api.PortalSessionKindKubeconfig,
+						Elevated:   true,
+					},
+				})
 			},
 			wantStatusCode: http.StatusOK,
 			wantHeaders: http.Header{
@@ -106,6 +123,7 @@ func TestNew(t *testing.T) {
 			ctx := context.Background()
 
 			dbPortal, portalClient := testdatabase.NewFakePortal()
+			dbPortalSessions, portalSessionsClient := testdatabase.NewFakePortalSessions()
 
 			fixture := testdatabase.NewFixture().
 				WithPortal(dbPortal)
@@ -148,7 +166,7 @@ func TestNew(t *testing.T) {
 			_, audit := testlog.NewAudit()
 			_, baseLog := testlog.New()
 			_, baseAccessLog := testlog.New()
-			k := New(baseLog, audit, _env, baseAccessLog, servingCert, elevatedGroupIDs, nil, dbPortal, nil)
+			k := New(baseLog, audit, _env, baseAccessLog, servingCert, elevatedGroupIDs, nil, dbPortal, dbPortalSessions, nil)
 
 			if tt.r != nil {
 				tt.r(r)
@@ -166,6 +184,10 @@ func TestNew(t *testing.T) {
 				t.Error(err)
 			}
 
+			for _, err = range checker.CheckPortalSessions(portalSessionsClient) {
+				t.Error(err)
+			}
+
 			resp := w.Response()
 
 			if resp.StatusCode != tt.wantStatusCode {
@@ -0,0 +1,86 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+)
+
+// Role is a tier of portal access.  Each role includes the access of the
+// roles below it.
+type Role int
+
+const (
+	RoleNone Role = iota
+	// RoleViewer can view clusters, their state and their metrics
+	RoleViewer
+	// RoleOperator can additionally obtain read-only kubeconfigs
+	RoleOperator
+	// RoleBreakglass can additionally obtain elevated kubeconfigs, SSH to
+	// cluster nodes, view pod logs, run commands in pods and start admin
+	// actions
+	RoleBreakglass
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleOperator:
+		return "operator"
+	case RoleBreakglass:
+		return "breakglass"
+	}
+	return "none"
+}
+
+// Roles maps AAD groups to roles
+type Roles struct {
+	ViewerGroupIDs     []string
+	OperatorGroupIDs   []string
+	BreakglassGroupIDs []string
+}
+
+// AllGroupIDs returns the groups of every role, i.e. the groups whose members
+// may log in to the portal
+func (rs *Roles) AllGroupIDs() []string {
+	allGroups := append([]string{}, rs.ViewerGroupIDs...)
+	allGroups = append(allGroups, rs.OperatorGroupIDs...)
+	return append(allGroups, rs.BreakglassGroupIDs...)
+}
+
+// Role returns the highest role granted by any of groups
+func (rs *Roles) Role(groups []string) Role {
+	switch {
+	case len(GroupsIntersect(rs.BreakglassGroupIDs, groups)) > 0:
+		return RoleBreakglass
+	case len(GroupsIntersect(rs.OperatorGroupIDs, groups)) > 0:
+		return RoleOperator
+	case len(GroupsIntersect(rs.ViewerGroupIDs, groups)) > 0:
+		return RoleViewer
+	}
+	return RoleNone
+}
+
+// RequestRole returns the role of the user making r, whose groups have been
+// set by the AAD middleware
+func (rs *Roles) RequestRole(r *http.Request) Role {
+	groups, _ := r.Context().Value(ContextKeyGroups).([]string)
+	return rs.Role(groups)
+}
+
+// RequireRole returns a middleware which refuses requests from users without
+// at least the given role
+func (rs *Roles) RequireRole(role Role) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if rs.RequestRole(r) < role {
+				http.Error(w, "The "+role.String()+" role is required.", http.StatusForbidden)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
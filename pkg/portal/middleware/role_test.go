@@ -0,0 +1,81 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoles(t *testing.T) {
+	roles := &Roles{
+		ViewerGroupIDs:     []string{"viewer"},
+		OperatorGroupIDs:   []string{"operator"},
+		BreakglassGroupIDs: []string{"breakglass"},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		groups         []string
+		require        Role
+		wantRole       Role
+		wantStatusCode int
+	}{
+		{
+			name:           "no groups",
+			require:        RoleViewer,
+			wantRole:       RoleNone,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "viewer",
+			groups:         []string{"other", "viewer"},
+			require:        RoleViewer,
+			wantRole:       RoleViewer,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "viewer requires operator",
+			groups:         []string{"viewer"},
+			require:        RoleOperator,
+			wantRole:       RoleViewer,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "operator",
+			groups:         []string{"operator"},
+			require:        RoleOperator,
+			wantRole:       RoleOperator,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "highest role is used",
+			groups:         []string{"viewer", "breakglass", "operator"},
+			require:        RoleBreakglass,
+			wantRole:       RoleBreakglass,
+			wantStatusCode: http.StatusOK,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = r.WithContext(context.WithValue(r.Context(), ContextKeyGroups, tt.groups))
+
+			if role := roles.RequestRole(r); role != tt.wantRole {
+				t.Error(role)
+			}
+
+			w := httptest.NewRecorder()
+			roles.RequireRole(tt.require)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatusCode {
+				t.Error(w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/portal/cluster"
+	"github.com/Azure/ARO-RP/pkg/portal/middleware"
+)
+
+// podExecCommand describes the arguments which a pod exec command may be run
+// with
+type podExecCommand struct {
+	// flags are the flags which may be passed
+	flags map[string]struct{}
+
+	// paths is whether paths, i.e. arguments which aren't flags, may be passed
+	paths bool
+}
+
+// podExecCommands are the commands which may be run by a pod exec session.
+// They do not modify the container and they exit without input.  Their whole
+// argument vector is checked: commands and flags which print file contents,
+// e.g. cat, or du --files0-from, are not allowed, as they could read the
+// service account token and other secrets mounted into the container.
+var podExecCommands = map[string]podExecCommand{
+	"df": {
+		flags: map[string]struct{}{"-h": {}, "-i": {}, "-T": {}},
+	},
+	"ls": {
+		flags: map[string]struct{}{"-a": {}, "-h": {}, "-l": {}, "-la": {}, "-lah": {}, "-lh": {}},
+		paths: true,
+	},
+	"ps": {
+		flags: map[string]struct{}{"aux": {}, "-e": {}, "-f": {}, "-ef": {}},
+	},
+	"uptime": {},
+}
+
+// validatePodExecCommand returns an error unless command is an allowed command
+// run with allowed arguments
+func validatePodExecCommand(command []string) error {
+	c, ok := podExecCommands[command[0]]
+	if !ok {
+		return fmt.Errorf("command %q is not allowed", command[0])
+	}
+
+	for _, arg := range command[1:] {
+		if _, ok := c.flags[arg]; ok {
+			continue
+		}
+
+		if c.paths && !strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		return fmt.Errorf("argument %q of command %q is not allowed", arg, command[0])
+	}
+
+	return nil
+}
+
+type podExecRequest struct {
+	Container string   `json:"container,omitempty"`
+	Command   []string `json:"command,omitempty"`
+}
+
+// flushWriter flushes each write so that streamed output reaches the browser
+// as it is produced
+type flushWriter struct {
+	w io.Writer
+	f http.Flusher
+}
+
+func (fw *flushWriter) Write(b []byte) (int, error) {
+	n, err := fw.w.Write(b)
+	fw.f.Flush()
+	return n, err
+}
+
+func newFlushWriter(w http.ResponseWriter) io.Writer {
+	if f, ok := w.(http.Flusher); ok {
+		return &flushWriter{w: w, f: f}
+	}
+	return w
+}
+
+func (p *portal) pods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	pods, err := fetcher.Pods(ctx)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(pods, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// podLogs streams the logs of a container in an openshift-* namespace.  The
+// session is recorded before any logs are returned.
+func (p *portal) podLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	apiVars := mux.Vars(r)
+	namespace := apiVars["namespace"]
+	podName := apiVars["podName"]
+	container := r.URL.Query().Get("container")
+	follow := r.URL.Query().Get("follow") == "true"
+
+	if !p.isElevated(r) {
+		http.Error(w, "Elevated access is required.", http.StatusForbidden)
+		return
+	}
+
+	if !cluster.IsOpenShiftNamespace(namespace) {
+		p.badRequest(w, fmt.Errorf("namespace %q is not an openshift namespace", namespace))
+		return
+	}
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	err = p.recordPodSession(r, &api.PortalSession{
+		Kind:      api.PortalSessionKindPodLogs,
+		Namespace: namespace,
+		Pod:       podName,
+		Container: container,
+	})
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	err = fetcher.PodLogs(ctx, namespace, podName, container, follow, newFlushWriter(w))
+	if err != nil {
+		// headers may already have been sent, so the error can only be logged
+		p.log.Warn(err)
+	}
+}
+
+// podExec runs one of podExecCommands in a container in an openshift-*
+// namespace and streams its output.  No stdin or TTY is attached.  The
+// session, including the command, is recorded before the command is run.
+func (p *portal) podExec(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	apiVars := mux.Vars(r)
+	namespace := apiVars["namespace"]
+	podName := apiVars["podName"]
+
+	if !p.isElevated(r) {
+		http.Error(w, "Elevated access is required.", http.StatusForbidden)
+		return
+	}
+
+	if !cluster.IsOpenShiftNamespace(namespace) {
+		p.badRequest(w, fmt.Errorf("namespace %q is not an openshift namespace", namespace))
+		return
+	}
+
+	mediatype, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediatype != "application/json" {
+		http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var req *podExecRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req == nil || len(req.Command) == 0 {
+		p.badRequest(w, err)
+		return
+	}
+
+	err = validatePodExecCommand(req.Command)
+	if err != nil {
+		p.badRequest(w, err)
+		return
+	}
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	err = p.recordPodSession(r, &api.PortalSession{
+		Kind:      api.PortalSessionKindPodExec,
+		Namespace: namespace,
+		Pod:       podName,
+		Container: req.Container,
+		Command:   req.Command,
+	})
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	out := newFlushWriter(w)
+	err = fetcher.PodExec(ctx, namespace, podName, req.Container, req.Command, out, out)
+	if err != nil {
+		// headers may already have been sent, so the error can only be logged
+		p.log.Warn(err)
+	}
+}
+
+func (p *portal) isElevated(r *http.Request) bool {
+	return p.roles().RequestRole(r) >= middleware.RoleBreakglass
+}
+
+// recordPodSession records a pod logs or exec session against the cluster
+// addressed by r
+func (p *portal) recordPodSession(r *http.Request, session *api.PortalSession) error {
+	ctx := r.Context()
+
+	apiVars := mux.Vars(r)
+	resourceID := p.getResourceID(apiVars["subscription"], apiVars["resourceGroup"], apiVars["clusterName"])
+
+	session.Username, _ = ctx.Value(middleware.ContextKeyUsername).(string)
+	session.ResourceID = resourceID
+	session.Elevated = true
+	session.CreationTime = int(time.Now().Unix())
+
+	p.log.WithFields(logrus.Fields{
+		"username":   session.Username,
+		"resourceID": resourceID,
+		"kind":       session.Kind,
+		"namespace":  session.Namespace,
+		"pod":        session.Pod,
+		"container":  session.Container,
+		"command":    strings.Join(session.Command, " "),
+	}).Info("portal pod session")
+
+	_, err := p.dbPortalSessions.Create(ctx, &api.PortalSessionDocument{
+		ID:            p.dbPortalSessions.NewUUID(),
+		Key:           strings.ToLower(resourceID),
+		PortalSession: session,
+	})
+	return err
+}
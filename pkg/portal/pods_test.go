@@ -0,0 +1,177 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Azure/ARO-RP/pkg/portal/middleware"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestPodLogsAndExecValidation(t *testing.T) {
+	elevatedGroupIDs := []string{"00000000-0000-0000-0000-000000000001"}
+	clusterPath := "/api/00000000-0000-0000-0000-000000000000/resourcegroupname/resourcename"
+
+	for _, tt := range []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		groups         []string
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "logs, not elevated",
+			method:         http.MethodGet,
+			path:           clusterPath + "/namespaces/openshift-apiserver/pods/apiserver-0/logs",
+			groups:         []string{},
+			wantStatusCode: http.StatusForbidden,
+			wantBody:       "Elevated access is required.\n",
+		},
+		{
+			name:           "logs, not an openshift namespace",
+			method:         http.MethodGet,
+			path:           clusterPath + "/namespaces/default/pods/customer/logs",
+			groups:         elevatedGroupIDs,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "Bad Request\n",
+		},
+		{
+			name:           "exec, not elevated",
+			method:         http.MethodPost,
+			path:           clusterPath + "/namespaces/openshift-apiserver/pods/apiserver-0/exec",
+			body:           `{"command":["ls"]}`,
+			groups:         []string{},
+			wantStatusCode: http.StatusForbidden,
+			wantBody:       "Elevated access is required.\n",
+		},
+		{
+			name:           "exec, not an openshift namespace",
+			method:         http.MethodPost,
+			path:           clusterPath + "/namespaces/default/pods/customer/exec",
+			body:           `{"command":["ls"]}`,
+			groups:         elevatedGroupIDs,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "Bad Request\n",
+		},
+		{
+			name:           "exec, no command",
+			method:         http.MethodPost,
+			path:           clusterPath + "/namespaces/openshift-apiserver/pods/apiserver-0/exec",
+			body:           `{}`,
+			groups:         elevatedGroupIDs,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "Bad Request\n",
+		},
+		{
+			name:           "exec, command not allowed",
+			method:         http.MethodPost,
+			path:           clusterPath + "/namespaces/openshift-apiserver/pods/apiserver-0/exec",
+			body:           `{"command":["rm","-rf","/"]}`,
+			groups:         elevatedGroupIDs,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "Bad Request\n",
+		},
+		{
+			name:           "exec, argument can read secrets",
+			method:         http.MethodPost,
+			path:           clusterPath + "/namespaces/openshift-apiserver/pods/apiserver-0/exec",
+			body:           `{"command":["du","--files0-from=/var/run/secrets/kubernetes.io/serviceaccount/token"]}`,
+			groups:         elevatedGroupIDs,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "Bad Request\n",
+		},
+		{
+			name:           "exec, flag not allowed",
+			method:         http.MethodPost,
+			path:           clusterPath + "/namespaces/openshift-apiserver/pods/apiserver-0/exec",
+			body:           `{"command":["ls","--color=always","/"]}`,
+			groups:         elevatedGroupIDs,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "Bad Request\n",
+		},
+		{
+			name:           "exec, command can read secrets",
+			method:         http.MethodPost,
+			path:           clusterPath + "/namespaces/openshift-apiserver/pods/apiserver-0/exec",
+			body:           `{"command":["cat","/var/run/secrets/kubernetes.io/serviceaccount/token"]}`,
+			groups:         elevatedGroupIDs,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "Bad Request\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &portal{
+				log:              utillog.GetLogger(),
+				elevatedGroupIDs: elevatedGroupIDs,
+			}
+
+			req, err := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			ctx := context.WithValue(req.Context(), middleware.ContextKeyUsername, "username")
+			ctx = context.WithValue(ctx, middleware.ContextKeyGroups, tt.groups)
+			req = req.WithContext(ctx)
+
+			r := mux.NewRouter()
+			p.aadAuthenticatedRoutes(r, nil, nil, nil)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatusCode {
+				t.Error(w.Code)
+			}
+
+			if w.Body.String() != tt.wantBody {
+				t.Error(w.Body.String())
+			}
+		})
+	}
+}
+
+func TestValidatePodExecCommand(t *testing.T) {
+	for _, tt := range []struct {
+		command []string
+		wantErr string
+	}{
+		{command: []string{"uptime"}},
+		{command: []string{"df", "-h"}},
+		{command: []string{"ps", "aux"}},
+		{command: []string{"ls", "-la", "/var/run/secrets/kubernetes.io/serviceaccount"}},
+		{
+			command: []string{"uptime", "-p"},
+			wantErr: `argument "-p" of command "uptime" is not allowed`,
+		},
+		{
+			command: []string{"df", "/"},
+			wantErr: `argument "/" of command "df" is not allowed`,
+		},
+		{
+			command: []string{"ls", "--dereference-command-line-symlink-to-dir", "/"},
+			wantErr: `argument "--dereference-command-line-symlink-to-dir" of command "ls" is not allowed`,
+		},
+		{
+			command: []string{"stat", "/"},
+			wantErr: `command "stat" is not allowed`,
+		},
+	} {
+		t.Run(strings.Join(tt.command, " "), func(t *testing.T) {
+			err := validatePodExecCommand(tt.command)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -61,11 +61,15 @@ type portal struct {
 	sessionKey   []byte
 	sshKey       *rsa.PrivateKey
 
+	viewerGroupIDs   []string
 	groupIDs         []string
 	elevatedGroupIDs []string
 
 	dbPortal            database.Portal
+	dbPortalSessions    database.PortalSessions
 	dbOpenShiftClusters database.OpenShiftClusters
+	dbAsyncOperations   database.AsyncOperations
+	dbSubscriptions     database.Subscriptions
 
 	dialer proxy.Dialer
 
@@ -92,10 +96,14 @@ func NewPortal(env env.Core,
 	clientCerts []*x509.Certificate,
 	sessionKey []byte,
 	sshKey *rsa.PrivateKey,
+	viewerGroupIDs []string,
 	groupIDs []string,
 	elevatedGroupIDs []string,
 	dbOpenShiftClusters database.OpenShiftClusters,
 	dbPortal database.Portal,
+	dbPortalSessions database.PortalSessions,
+	dbAsyncOperations database.AsyncOperations,
+	dbSubscriptions database.Subscriptions,
 	dialer proxy.Dialer,
 	m metrics.Emitter,
 ) Runnable {
@@ -117,11 +125,15 @@ func NewPortal(env env.Core,
 		sessionKey:   sessionKey,
 		sshKey:       sshKey,
 
+		viewerGroupIDs:   viewerGroupIDs,
 		groupIDs:         groupIDs,
 		elevatedGroupIDs: elevatedGroupIDs,
 
 		dbOpenShiftClusters: dbOpenShiftClusters,
 		dbPortal:            dbPortal,
+		dbPortalSessions:    dbPortalSessions,
+		dbAsyncOperations:   dbAsyncOperations,
+		dbSubscriptions:     dbSubscriptions,
 
 		dialer: dialer,
 
@@ -157,10 +169,7 @@ func (p *portal) setupRouter(kconfig *kubeconfig.Kubeconfig, prom *prometheus.Pr
 	bearerRoutes(unauthenticatedRouter, kconfig)
 	p.unauthenticatedRoutes(unauthenticatedRouter)
 
-	allGroups := append([]string{}, p.groupIDs...)
-	allGroups = append(allGroups, p.elevatedGroupIDs...)
-
-	p.aad, err = middleware.NewAAD(p.log, p.audit, p.env, p.baseAccessLog, p.hostname, p.sessionKey, p.clientID, p.clientKey, p.clientCerts, allGroups, unauthenticatedRouter, p.verifier)
+	p.aad, err = middleware.NewAAD(p.log, p.audit, p.env, p.baseAccessLog, p.hostname, p.sessionKey, p.clientID, p.clientKey, p.clientCerts, p.roles().AllGroupIDs(), unauthenticatedRouter, p.verifier)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +186,7 @@ func (p *portal) setupRouter(kconfig *kubeconfig.Kubeconfig, prom *prometheus.Pr
 }
 
 func (p *portal) setupServices() (*kubeconfig.Kubeconfig, *prometheus.Prometheus, *ssh.SSH, error) {
-	ssh, err := ssh.New(p.env, p.log, p.baseAccessLog, p.sshl, p.sshKey, p.elevatedGroupIDs, p.dbOpenShiftClusters, p.dbPortal, p.dialer)
+	ssh, err := ssh.New(p.env, p.log, p.baseAccessLog, p.sshl, p.sshKey, p.elevatedGroupIDs, p.dbOpenShiftClusters, p.dbPortal, p.dbPortalSessions, p.dialer)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -187,7 +196,7 @@ func (p *portal) setupServices() (*kubeconfig.Kubeconfig, *prometheus.Prometheus
 		return nil, nil, nil, err
 	}
 
-	k := kubeconfig.New(p.log, p.audit, p.env, p.baseAccessLog, p.servingCerts[0], p.elevatedGroupIDs, p.dbOpenShiftClusters, p.dbPortal, p.dialer)
+	k := kubeconfig.New(p.log, p.audit, p.env, p.baseAccessLog, p.servingCerts[0], p.elevatedGroupIDs, p.dbOpenShiftClusters, p.dbPortal, p.dbPortalSessions, p.dialer)
 
 	prom := prometheus.New(p.log, p.dbOpenShiftClusters, p.dialer)
 
@@ -278,6 +287,7 @@ func (p *portal) aadAuthenticatedRoutes(r *mux.Router, prom *prometheus.Promethe
 	r.Methods(http.MethodGet).Path("/api/clusters").HandlerFunc(p.clusters)
 	r.Methods(http.MethodGet).Path("/api/info").HandlerFunc(p.info)
 	r.Methods(http.MethodGet).Path("/api/regions").HandlerFunc(p.regions)
+	r.Methods(http.MethodGet).Path("/api/search").HandlerFunc(p.search)
 
 	// Cluster-specific routes
 	r.Path("/api/{subscription}/{resourceGroup}/{clusterName}/clusteroperators").HandlerFunc(p.clusterOperators)
@@ -286,6 +296,14 @@ func (p *portal) aadAuthenticatedRoutes(r *mux.Router, prom *prometheus.Promethe
 	r.Path("/api/{subscription}/{resourceGroup}/{clusterName}/machines").HandlerFunc(p.machines)
 	r.Path("/api/{subscription}/{resourceGroup}/{clusterName}/machine-sets").HandlerFunc(p.machineSets)
 	r.Path("/api/{subscription}/{resourceGroup}/{clusterName}/statistics/{statisticsType}").HandlerFunc(p.statistics)
+	r.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/prometheus/query").HandlerFunc(p.prometheusQuery)
+	r.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/dashboards").HandlerFunc(p.dashboards)
+	r.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/dashboards/{dashboard}").HandlerFunc(p.dashboard)
+	r.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/flags").HandlerFunc(p.flags)
+	r.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/progress").HandlerFunc(p.progress)
+	r.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/pods").HandlerFunc(p.pods)
+	r.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/namespaces/{namespace}/pods/{podName}/logs").HandlerFunc(p.podLogs)
+	r.Methods(http.MethodPost).Path("/api/{subscription}/{resourceGroup}/{clusterName}/namespaces/{namespace}/pods/{podName}/exec").HandlerFunc(p.podExec)
 	r.Path("/api/{subscription}/{resourceGroup}/{clusterName}").HandlerFunc(p.clusterInfo)
 
 	// prometheus
@@ -307,9 +325,15 @@ func (p *portal) aadAuthenticatedRoutes(r *mux.Router, prom *prometheus.Promethe
 
 	//kubeconfig
 	if kconfig != nil {
-		r.Methods(http.MethodPost).Path("/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/microsoft.redhatopenshift/openshiftclusters/{resourceName}/kubeconfig/new").HandlerFunc(kconfig.New)
+		r.Methods(http.MethodPost).Path("/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/microsoft.redhatopenshift/openshiftclusters/{resourceName}/kubeconfig/new").Handler(p.roles().RequireRole(middleware.RoleOperator)(http.HandlerFunc(kconfig.New)))
 	}
 
+	// break-glass kubeconfig
+	r.Methods(http.MethodPost).Path("/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/microsoft.redhatopenshift/openshiftclusters/{resourceName}/breakglass/new").HandlerFunc(p.breakGlassKubeconfig)
+
+	// admin actions
+	r.Methods(http.MethodPost).Path("/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/microsoft.redhatopenshift/openshiftclusters/{resourceName}/actions/{action}").HandlerFunc(p.action)
+
 	// ssh
 	r.Methods(http.MethodPost).Path("/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/microsoft.redhatopenshift/openshiftclusters/{resourceName}/ssh/new").HandlerFunc(sshStruct.New)
 
@@ -402,6 +426,17 @@ func (p *portal) serve(path string) func(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// roles returns the mapping of the portal's AAD groups to roles.  Members of
+// the access groups are operators and members of the elevated groups have
+// breakglass access.
+func (p *portal) roles() *middleware.Roles {
+	return &middleware.Roles{
+		ViewerGroupIDs:     p.viewerGroupIDs,
+		OperatorGroupIDs:   p.groupIDs,
+		BreakglassGroupIDs: p.elevatedGroupIDs,
+	}
+}
+
 func (p *portal) getResourceID(subscriptionID, resourceGroup, clusterName string) string {
 	return strings.ToLower(
 		fmt.Sprintf(
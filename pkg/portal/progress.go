@@ -0,0 +1,99 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// progressPollInterval is how often the progress stream re-reads the cluster
+// document
+var progressPollInterval = 5 * time.Second
+
+type progressEvent struct {
+	ProvisioningState       api.ProvisioningState  `json:"provisioningState"`
+	FailedProvisioningState api.ProvisioningState  `json:"failedProvisioningState,omitempty"`
+	LastProvisioningState   api.ProvisioningState  `json:"lastProvisioningState,omitempty"`
+	LastAdminUpdateError    string                 `json:"lastAdminUpdateError,omitempty"`
+	MaintenanceTask         api.MaintenanceTask    `json:"maintenanceTask,omitempty"`
+	Progress                *api.OperationProgress `json:"progress,omitempty"`
+}
+
+func newProgressEvent(doc *api.OpenShiftClusterDocument) *progressEvent {
+	return &progressEvent{
+		ProvisioningState:       doc.OpenShiftCluster.Properties.ProvisioningState,
+		FailedProvisioningState: doc.OpenShiftCluster.Properties.FailedProvisioningState,
+		LastProvisioningState:   doc.OpenShiftCluster.Properties.LastProvisioningState,
+		LastAdminUpdateError:    doc.OpenShiftCluster.Properties.LastAdminUpdateError,
+		MaintenanceTask:         doc.OpenShiftCluster.Properties.MaintenanceTask,
+		Progress:                doc.Progress,
+	}
+}
+
+// progress streams the progress of a cluster's operation as server-sent
+// events.  An event is sent whenever the provisioning state or the step being
+// run by the backend changes, and a final "done" event is sent once the
+// cluster reaches a terminal provisioning state.
+func (p *portal) progress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	apiVars := mux.Vars(r)
+	resourceID := p.getResourceID(apiVars["subscription"], apiVars["resourceGroup"], apiVars["clusterName"])
+
+	doc, err := p.dbOpenShiftClusters.Get(ctx, resourceID)
+	if err != nil {
+		http.Error(w, "Cluster not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	fw := newFlushWriter(w)
+
+	t := time.NewTicker(progressPollInterval)
+	defer t.Stop()
+
+	var last []byte
+	for {
+		b, err := json.Marshal(newProgressEvent(doc))
+		if err != nil {
+			p.log.Error(err)
+			return
+		}
+
+		if !bytes.Equal(b, last) {
+			_, err = fmt.Fprintf(fw, "data: %s\n\n", b)
+			if err != nil {
+				return
+			}
+			last = b
+		}
+
+		if doc.OpenShiftCluster.Properties.ProvisioningState.IsTerminal() {
+			_, _ = fmt.Fprint(fw, "event: done\ndata: {}\n\n")
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		doc, err = p.dbOpenShiftClusters.Get(ctx, resourceID)
+		if err != nil {
+			p.log.Warn(err)
+			_, _ = fmt.Fprint(fw, "event: error\ndata: {}\n\n")
+			return
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestProgress(t *testing.T) {
+	ctx := context.Background()
+
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/resourcename"
+
+	defer func(d time.Duration) { progressPollInterval = d }(progressPollInterval)
+	progressPollInterval = 10 * time.Millisecond
+
+	dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+	fixture := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+	fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+		Key: resourceID,
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID: resourceID,
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState:     api.ProvisioningStateAdminUpdating,
+				LastProvisioningState: api.ProvisioningStateSucceeded,
+				MaintenanceTask:       api.MaintenanceTaskEverything,
+			},
+		},
+		Progress: &api.OperationProgress{
+			Operation: "adminUpdate",
+			Step:      "[Action fixEtcd]",
+			StepIndex: 1,
+			StepCount: 10,
+		},
+	})
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &portal{
+		log:                 utillog.GetLogger(),
+		dbOpenShiftClusters: dbOpenShiftClusters,
+	}
+
+	r := mux.NewRouter()
+	p.aadAuthenticatedRoutes(r, nil, nil, nil)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/00000000-0000-0000-0000-000000000000/resourcegroupname/resourcename/progress")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatal(resp.StatusCode)
+	}
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Error(resp.Header.Get("Content-Type"))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	readEvent := func() string {
+		var lines []string
+		for scanner.Scan() {
+			if scanner.Text() == "" {
+				break
+			}
+			lines = append(lines, scanner.Text())
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	if got := readEvent(); got != `data: {"provisioningState":"AdminUpdating","lastProvisioningState":"Succeeded","maintenanceTask":"Everything","progress":{"operation":"adminUpdate","step":"[Action fixEtcd]","stepIndex":1,"stepCount":10,"startTime":"0001-01-01T00:00:00Z"}}` {
+		t.Error(got)
+	}
+
+	_, err = dbOpenShiftClusters.Patch(ctx, resourceID, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateSucceeded
+		doc.OpenShiftCluster.Properties.LastProvisioningState = ""
+		doc.OpenShiftCluster.Properties.MaintenanceTask = ""
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readEvent(); got != `data: {"provisioningState":"Succeeded","progress":{"operation":"adminUpdate","step":"[Action fixEtcd]","stepIndex":1,"stepCount":10,"startTime":"0001-01-01T00:00:00Z"}}` {
+		t.Error(got)
+	}
+
+	if got := readEvent(); got != "event: done\ndata: {}" {
+		t.Error(got)
+	}
+
+	if got := readEvent(); got != "" {
+		t.Error(got)
+	}
+}
+
+func TestProgressNotFound(t *testing.T) {
+	dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+
+	p := &portal{
+		log:                 utillog.GetLogger(),
+		dbOpenShiftClusters: dbOpenShiftClusters,
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/api/00000000-0000-0000-0000-000000000000/resourcegroupname/resourcename/progress", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := mux.NewRouter()
+	p.aadAuthenticatedRoutes(r, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Error(w.Code)
+	}
+}
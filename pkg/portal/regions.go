@@ -68,16 +68,15 @@ type RegionInfo struct {
 	Regions []Region `json:"regions"`
 }
 
-func (p *portal) regions(w http.ResponseWriter, r *http.Request) {
-	resp := &RegionInfo{
-		Regions: make([]Region, 0, len(PROD_REGIONS)),
-	}
+// regionList returns the regional portals within this cloud
+func regionList() []Region {
+	regions := make([]Region, 0, len(PROD_REGIONS))
 
 	if value, found := os.LookupEnv("AZURE_ENVIRONMENT"); found {
 		// AZURE_ENVIRONMENT variable can either be AZUREPUBLICCLOUD or AZUREUSGOVERNMENTCLOUD
 		if strings.EqualFold(value, azureclient.PublicCloud.Environment.Name) {
 			for _, region := range PROD_REGIONS {
-				resp.Regions = append(resp.Regions, Region{
+				regions = append(regions, Region{
 					Name: region,
 					URL:  fmt.Sprintf("https://%s.admin.aro.azure.com", region),
 				})
@@ -85,6 +84,14 @@ func (p *portal) regions(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	return regions
+}
+
+func (p *portal) regions(w http.ResponseWriter, r *http.Request) {
+	resp := &RegionInfo{
+		Regions: regionList(),
+	}
+
 	b, err := json.MarshalIndent(resp, "", "    ")
 	if err != nil {
 		p.internalServerError(w, err)
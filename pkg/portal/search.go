@@ -0,0 +1,194 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+const (
+	searchMinQueryLength = 3
+	searchMaxResults     = 100
+)
+
+// searchRank orders search results; lower ranks are better matches
+type searchRank int
+
+const (
+	searchRankExact searchRank = iota
+	searchRankPrefix
+	searchRankSubstring
+)
+
+type SearchResult struct {
+	ResourceID        string `json:"resourceId"`
+	Name              string `json:"name"`
+	Subscription      string `json:"subscription"`
+	ResourceGroup     string `json:"resourceGroup"`
+	Domain            string `json:"domain"`
+	ProvisioningState string `json:"provisioningState"`
+	Version           string `json:"version"`
+
+	// Match is the field which the query matched: resourceId, name, domain or
+	// subscription
+	Match string `json:"match"`
+
+	// URL links to the cluster in the portal, relative to the portal's URL
+	URL string `json:"url"`
+
+	rank searchRank
+}
+
+type SearchResults struct {
+	Location string          `json:"location"`
+	Clusters []*SearchResult `json:"clusters"`
+
+	// Peers lists the search URLs of the other regions' portals.  The browser
+	// queries these directly, as the SRE's session is not valid in other
+	// regions.
+	Peers []Region `json:"peers,omitempty"`
+}
+
+// matchCluster returns how well the lower case query q matches doc, or false
+// if it does not match
+func matchCluster(q string, doc *api.OpenShiftClusterDocument) (*SearchResult, bool) {
+	resourceID := strings.ToLower(doc.OpenShiftCluster.ID)
+	domain := strings.ToLower(doc.OpenShiftCluster.Properties.ClusterProfile.Domain)
+
+	result := &SearchResult{
+		ResourceID:        doc.OpenShiftCluster.ID,
+		Domain:            doc.OpenShiftCluster.Properties.ClusterProfile.Domain,
+		ProvisioningState: doc.OpenShiftCluster.Properties.ProvisioningState.String(),
+		Version:           doc.OpenShiftCluster.Properties.ClusterProfile.Version,
+		URL:               resourceID,
+	}
+
+	if resource, err := azure.ParseResourceID(doc.OpenShiftCluster.ID); err == nil {
+		result.Name = resource.ResourceName
+		result.Subscription = resource.SubscriptionID
+		result.ResourceGroup = resource.ResourceGroup
+	}
+
+	name := strings.ToLower(result.Name)
+
+	// queries may be URLs or hostnames of the cluster, e.g.
+	// https://api.<domain>.<location>.aroapp.io:6443
+	host := q
+	if u, err := url.Parse(q); err == nil && u.Host != "" {
+		host = u.Hostname()
+	}
+
+	switch {
+	case q == resourceID:
+		result.Match, result.rank = "resourceId", searchRankExact
+	case domain != "" && (host == domain || strings.Contains("."+host+".", "."+domain+".")):
+		result.Match, result.rank = "domain", searchRankExact
+	case q == strings.ToLower(result.Subscription):
+		result.Match, result.rank = "subscription", searchRankExact
+	case q == name:
+		result.Match, result.rank = "name", searchRankExact
+	case strings.HasPrefix(name, q):
+		result.Match, result.rank = "name", searchRankPrefix
+	case strings.HasPrefix(domain, q):
+		result.Match, result.rank = "domain", searchRankPrefix
+	case strings.Contains(resourceID, q):
+		result.Match, result.rank = "resourceId", searchRankSubstring
+	case strings.Contains(domain, q):
+		result.Match, result.rank = "domain", searchRankSubstring
+	default:
+		return nil, false
+	}
+
+	return result, true
+}
+
+// search finds clusters in the region-local database by resource ID
+// fragment, domain or customer subscription.  If peers=true, it also returns
+// the search URLs of the other regions' portals.
+func (p *portal) search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	p.allowPeerOrigin(w, r)
+
+	q := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("q")))
+	if len(q) < searchMinQueryLength {
+		p.badRequest(w, errors.New("query must be at least 3 characters"))
+		return
+	}
+
+	docs, err := p.dbOpenShiftClusters.ListAll(ctx)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	results := &SearchResults{
+		Location: p.env.Location(),
+		Clusters: []*SearchResult{},
+	}
+
+	for _, doc := range docs.OpenShiftClusterDocuments {
+		if doc.OpenShiftCluster == nil || doc.SoftDeleted != nil {
+			continue
+		}
+
+		if result, ok := matchCluster(q, doc); ok {
+			results.Clusters = append(results.Clusters, result)
+		}
+	}
+
+	sort.SliceStable(results.Clusters, func(i, j int) bool {
+		if results.Clusters[i].rank != results.Clusters[j].rank {
+			return results.Clusters[i].rank < results.Clusters[j].rank
+		}
+		return strings.Compare(results.Clusters[i].URL, results.Clusters[j].URL) < 0
+	})
+
+	if len(results.Clusters) > searchMaxResults {
+		results.Clusters = results.Clusters[:searchMaxResults]
+	}
+
+	if r.URL.Query().Get("peers") == "true" {
+		for _, region := range regionList() {
+			if strings.EqualFold(region.Name, p.env.Location()) {
+				continue
+			}
+
+			results.Peers = append(results.Peers, Region{
+				Name: region.Name,
+				URL:  region.URL + "/api/search?q=" + url.QueryEscape(q),
+			})
+		}
+	}
+
+	p.writeJSON(w, results)
+}
+
+// allowPeerOrigin allows the other regions' portals to read the response from
+// the browser, which sends the SRE's session cookie for this region with the
+// request
+func (p *portal) allowPeerOrigin(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", "Origin")
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+
+	for _, region := range regionList() {
+		if origin == region.URL {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			return
+		}
+	}
+}
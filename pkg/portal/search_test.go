@@ -0,0 +1,241 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestSearch(t *testing.T) {
+	subscriptionID := "00000000-0000-0000-0000-000000000000"
+	otherSubscriptionID := "00000000-0000-0000-0000-000000000001"
+	resourceID := func(subscriptionID, name string) string {
+		return "/subscriptions/" + subscriptionID + "/resourcegroups/resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/" + name
+	}
+
+	dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+
+	fixture := testdatabase.NewFixture().
+		WithOpenShiftClusters(dbOpenShiftClusters)
+
+	for i, c := range []struct {
+		subscriptionID string
+		name           string
+		domain         string
+		softDeleted    bool
+	}{
+		{subscriptionID, "prod", "abc123", false},
+		{subscriptionID, "production", "prod.example.com", false},
+		{otherSubscriptionID, "staging", "def456", false},
+		{otherSubscriptionID, "deleted", "prod999", true},
+	} {
+		doc := &api.OpenShiftClusterDocument{
+			ID:  string(rune('a' + i)),
+			Key: resourceID(c.subscriptionID, c.name),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: resourceID(c.subscriptionID, c.name),
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: api.ProvisioningStateSucceeded,
+					ClusterProfile: api.ClusterProfile{
+						Domain:  c.domain,
+						Version: "4.12.25",
+					},
+				},
+			},
+		}
+		if c.softDeleted {
+			doc.SoftDeleted = &api.SoftDeleted{}
+		}
+		fixture.AddOpenShiftClusterDocuments(doc)
+	}
+
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := func(subscriptionID, name, domain, match string) *SearchResult {
+		return &SearchResult{
+			ResourceID:        resourceID(subscriptionID, name),
+			Name:              name,
+			Subscription:      subscriptionID,
+			ResourceGroup:     "resourcegroupname",
+			Domain:            domain,
+			ProvisioningState: "Succeeded",
+			Version:           "4.12.25",
+			Match:             match,
+			URL:               resourceID(subscriptionID, name),
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		query          string
+		peers          bool
+		origin         string
+		wantStatusCode int
+		wantResults    *SearchResults
+		wantCORS       bool
+	}{
+		{
+			name:           "query too short",
+			query:          "ab",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "name, ranked exact before prefix",
+			query:          "PROD",
+			wantStatusCode: http.StatusOK,
+			wantResults: &SearchResults{
+				Location: "eastus",
+				Clusters: []*SearchResult{
+					result(subscriptionID, "prod", "abc123", "name"),
+					result(subscriptionID, "production", "prod.example.com", "name"),
+				},
+			},
+		},
+		{
+			name:           "domain from API server URL",
+			query:          "https://api.abc123.eastus.aroapp.io:6443",
+			wantStatusCode: http.StatusOK,
+			wantResults: &SearchResults{
+				Location: "eastus",
+				Clusters: []*SearchResult{
+					result(subscriptionID, "prod", "abc123", "domain"),
+				},
+			},
+		},
+		{
+			name:           "subscription",
+			query:          otherSubscriptionID,
+			wantStatusCode: http.StatusOK,
+			wantResults: &SearchResults{
+				Location: "eastus",
+				Clusters: []*SearchResult{
+					result(otherSubscriptionID, "staging", "def456", "subscription"),
+				},
+			},
+		},
+		{
+			name:           "resource ID fragment",
+			query:          "resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/stag",
+			wantStatusCode: http.StatusOK,
+			wantResults: &SearchResults{
+				Location: "eastus",
+				Clusters: []*SearchResult{
+					result(otherSubscriptionID, "staging", "def456", "resourceId"),
+				},
+			},
+		},
+		{
+			name:           "no match, with peers",
+			query:          "nothing",
+			peers:          true,
+			origin:         "https://westus.admin.aro.azure.com",
+			wantStatusCode: http.StatusOK,
+			wantResults: &SearchResults{
+				Location: "eastus",
+				Clusters: []*SearchResult{},
+			},
+			wantCORS: true,
+		},
+		{
+			name:           "unknown origin",
+			query:          "nothing",
+			origin:         "https://example.com",
+			wantStatusCode: http.StatusOK,
+			wantResults: &SearchResults{
+				Location: "eastus",
+				Clusters: []*SearchResult{},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AZURE_ENVIRONMENT", azureclient.PublicCloud.Environment.Name)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			_env := mock_env.NewMockCore(ctrl)
+			_env.EXPECT().Location().AnyTimes().Return("eastus")
+
+			p := &portal{
+				env:                 _env,
+				log:                 utillog.GetLogger(),
+				dbOpenShiftClusters: dbOpenShiftClusters,
+			}
+
+			path := "/api/search?q=" + url.QueryEscape(tt.query)
+			if tt.peers {
+				path += "&peers=true"
+			}
+
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+
+			r := mux.NewRouter()
+			p.aadAuthenticatedRoutes(r, nil, nil, nil)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatusCode {
+				t.Fatal(w.Code)
+			}
+
+			allowOrigin := w.Header().Get("Access-Control-Allow-Origin")
+			if tt.wantCORS && allowOrigin != tt.origin || !tt.wantCORS && allowOrigin != "" {
+				t.Error(allowOrigin)
+			}
+
+			if tt.wantResults == nil {
+				return
+			}
+
+			var results *SearchResults
+			err = json.NewDecoder(w.Body).Decode(&results)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.peers {
+				if len(results.Peers) != len(PROD_REGIONS)-1 {
+					t.Errorf("expected %d peers, got %d", len(PROD_REGIONS)-1, len(results.Peers))
+				}
+				for _, peer := range results.Peers {
+					if peer.Name == "eastus" {
+						t.Error("local region returned as a peer")
+					}
+					if peer.Name == "westus" && peer.URL != "https://westus.admin.aro.azure.com/api/search?q=nothing" {
+						t.Error(peer.URL)
+					}
+				}
+				results.Peers = nil
+			}
+
+			for _, l := range deep.Equal(tt.wantResults, results) {
+				t.Error(l)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"k8s.io/utils/strings/slices"
 
+	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/metrics/noop"
 	"github.com/Azure/ARO-RP/pkg/portal/middleware"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
@@ -34,6 +35,7 @@ import (
 )
 
 var (
+	viewerGroupIDs      = []string{"00000000-2222-2222-2222-000000000000"}
 	nonElevatedGroupIDs = []string{"00000000-1111-1111-1111-000000000000"}
 	elevatedGroupIDs    = []string{"00000000-0000-0000-0000-000000000000"}
 )
@@ -74,6 +76,21 @@ func TestSecurity(t *testing.T) {
 
 	dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
 	dbPortal, _ := testdatabase.NewFakePortal()
+	dbPortalSessions, _ := testdatabase.NewFakePortalSessions()
+
+	fixture := testdatabase.NewFixture().
+		WithOpenShiftClusters(dbOpenShiftClusters)
+	fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+		Key: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/resourcename",
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/resourcename",
+		},
+	})
+
+	err = fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	pool := x509.NewCertPool()
 	pool.AddCert(servercerts[0])
@@ -90,7 +107,7 @@ func TestSecurity(t *testing.T) {
 		},
 	}
 
-	p := NewPortal(_env, portalAuditLog, portalLog, portalAccessLog, l, sshl, nil, "", serverkey, servercerts, "", nil, nil, make([]byte, 32), sshkey, nil, elevatedGroupIDs, dbOpenShiftClusters, dbPortal, nil, &noop.Noop{})
+	p := NewPortal(_env, portalAuditLog, portalLog, portalAccessLog, l, sshl, nil, "", serverkey, servercerts, "", nil, nil, make([]byte, 32), sshkey, nil, nonElevatedGroupIDs, elevatedGroupIDs, dbOpenShiftClusters, dbPortal, dbPortalSessions, nil, nil, nil, &noop.Noop{})
 	go func() {
 		err := p.Run(ctx)
 		if err != nil {
@@ -297,7 +314,7 @@ func TestSecurity(t *testing.T) {
 				}
 
 				if tt2.authenticated {
-					var groups []string
+					groups := nonElevatedGroupIDs
 					if tt2.elevated {
 						groups = elevatedGroupIDs
 					}
@@ -0,0 +1,108 @@
+package ssh
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	cryptossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+const (
+	// certificateClockSkew allows for clock skew between the portal and the
+	// cluster nodes when validating a certificate
+	certificateClockSkew = 5 * time.Minute
+)
+
+// newCertificateMetadata returns the metadata of the certificate which will be
+// minted for an SSH session when the SRE connects.  A certificate remains valid
+// for the lifetime of the longest possible session so that agent forwarding
+// from a master to a worker node keeps working.
+func newCertificateMetadata(sessionID string, now time.Time) (*api.SSHCertificate, error) {
+	b := make([]byte, 8)
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.SSHCertificate{
+		KeyID:       sessionID,
+		Serial:      binary.BigEndian.Uint64(b),
+		ValidBefore: int(now.Add(sshNewTimeout + sshTimeout).Unix()),
+	}, nil
+}
+
+// newCertificateKey generates a key and signs a user certificate for it with
+// the cluster SSH certificate authority.  The key is never persisted.
+func newCertificateKey(sshCAKey []byte, metadata *api.SSHCertificate, now time.Time) (cryptossh.Signer, *agent.AddedKey, error) {
+	if int64(metadata.ValidBefore) <= now.Unix() {
+		return nil, nil, fmt.Errorf("certificate %s expired", metadata.KeyID)
+	}
+
+	caKey, err := x509.ParsePKCS1PrivateKey(sshCAKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	caSigner, err := cryptossh.NewSignerFromSigner(caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pubKey, err := cryptossh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert := &cryptossh.Certificate{
+		Key:             pubKey,
+		Serial:          metadata.Serial,
+		CertType:        cryptossh.UserCert,
+		KeyId:           metadata.KeyID,
+		ValidPrincipals: []string{"core"},
+		ValidAfter:      uint64(now.Add(-certificateClockSkew).Unix()),
+		ValidBefore:     uint64(metadata.ValidBefore),
+		Permissions: cryptossh.Permissions{
+			Extensions: map[string]string{
+				"permit-agent-forwarding": "",
+				"permit-port-forwarding":  "",
+				"permit-pty":              "",
+			},
+		},
+	}
+
+	err = cert.SignCert(rand.Reader, caSigner)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signer, err := cryptossh.NewSignerFromSigner(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certSigner, err := cryptossh.NewCertSigner(cert, signer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return certSigner, &agent.AddedKey{
+		PrivateKey:   key,
+		Certificate:  cert,
+		LifetimeSecs: uint32(int64(metadata.ValidBefore) - now.Unix()),
+	}, nil
+}
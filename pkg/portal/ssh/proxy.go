@@ -31,7 +31,10 @@ const (
 // This file handles smart proxying of SSH connections between SRE->portal and
 // portal->cluster.  We don't want to give the SRE the cluster SSH key, thus
 // this has to be an application-level proxy so we can replace the validated
-// one-time password that the SRE uses to authenticate with the cluster SSH key.
+// one-time password that the SRE uses to authenticate with a key of our own.
+// Where the cluster has an SSH certificate authority, this is a throwaway key
+// with a short-lived certificate minted for the session; otherwise it is the
+// cluster SSH key.
 //
 // Given that we're now an application-level proxy, we pull a second trick as
 // well: we inject SSH agent forwarding into the portal->cluster connection leg,
@@ -153,12 +156,7 @@ func (s *SSH) newConn(ctx context.Context, clientConn net.Conn) error {
 
 	defer c2.Close()
 
-	key, err := x509.ParsePKCS1PrivateKey(openShiftDoc.OpenShiftCluster.Properties.SSHKey)
-	if err != nil {
-		return err
-	}
-
-	signer, err := cryptossh.NewSignerFromSigner(key)
+	signer, agentKey, err := s.clusterKey(accessLog, openShiftDoc, portalDoc)
 	if err != nil {
 		return err
 	}
@@ -184,7 +182,7 @@ func (s *SSH) newConn(ctx context.Context, clientConn net.Conn) error {
 	}()
 
 	keyring := agent.NewKeyring()
-	err = keyring.Add(agent.AddedKey{PrivateKey: key})
+	err = keyring.Add(*agentKey)
 	if err != nil {
 		return err
 	}
@@ -193,6 +191,38 @@ func (s *SSH) newConn(ctx context.Context, clientConn net.Conn) error {
 	return s.proxyConn(ctx, accessLog, keyring, upstreamConn, downstreamConn, upstreamNewChannels, downstreamNewChannels, upstreamRequests, downstreamRequests)
 }
 
+// clusterKey returns the key with which the portal authenticates to the
+// cluster, and which it offers to the cluster via agent forwarding.  This is a
+// key with a certificate minted for the session if the session has one, or the
+// cluster SSH key otherwise.
+func (s *SSH) clusterKey(accessLog *logrus.Entry, openShiftDoc *api.OpenShiftClusterDocument, portalDoc *api.PortalDocument) (cryptossh.Signer, *agent.AddedKey, error) {
+	if portalDoc.Portal.SSH.Certificate != nil && openShiftDoc.OpenShiftCluster.Properties.SSHCAKey != nil {
+		signer, agentKey, err := newCertificateKey(openShiftDoc.OpenShiftCluster.Properties.SSHCAKey, portalDoc.Portal.SSH.Certificate, time.Now())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		accessLog.WithFields(logrus.Fields{
+			"certificate_key_id": agentKey.Certificate.KeyId,
+			"certificate_serial": agentKey.Certificate.Serial,
+		}).Print("certificate minted")
+
+		return signer, agentKey, nil
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(openShiftDoc.OpenShiftCluster.Properties.SSHKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signer, err := cryptossh.NewSignerFromSigner(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return signer, &agent.AddedKey{PrivateKey: key}, nil
+}
+
 // proxyConn handles incoming new channel and administrative requests.  It calls
 // newChannel to handle new channels, each on a new goroutine.
 func (s *SSH) proxyConn(ctx context.Context, accessLog *logrus.Entry, keyring agent.Agent, upstreamConn, downstreamConn cryptossh.Conn, upstreamNewChannels, downstreamNewChannels <-chan cryptossh.NewChannel, upstreamRequests, downstreamRequests <-chan *cryptossh.Request) error {
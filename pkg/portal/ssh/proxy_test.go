@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
@@ -64,8 +65,9 @@ func fakeClient(c net.Conn, serverKey *rsa.PublicKey, user string, password stri
 }
 
 // fakeServer returns a test listener for an SSH server which validates the
-// client key, reads ping request(s) and writes pong replies
-func fakeServer(clientKey *rsa.PublicKey) (*listener.Listener, error) {
+// client key or, if it is a certificate, that it is signed by the CA key, reads
+// ping request(s) and writes pong replies
+func fakeServer(clientKey *rsa.PublicKey, caKey *rsa.PublicKey) (*listener.Listener, error) {
 	l := listener.NewListener()
 
 	clientPublicKey, err := cryptossh.NewPublicKey(clientKey)
@@ -73,11 +75,25 @@ func fakeServer(clientKey *rsa.PublicKey) (*listener.Listener, error) {
 		return nil, err
 	}
 
+	caPublicKey, err := cryptossh.NewPublicKey(caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	certChecker := &cryptossh.CertChecker{
+		IsUserAuthority: func(auth cryptossh.PublicKey) bool {
+			return bytes.Equal(auth.Marshal(), caPublicKey.Marshal())
+		},
+	}
+
 	config := &cryptossh.ServerConfig{
 		PublicKeyCallback: func(conn cryptossh.ConnMetadata, key cryptossh.PublicKey) (*cryptossh.Permissions, error) {
 			if conn.User() != "core" {
 				return nil, fmt.Errorf("invalid user")
 			}
+			if _, ok := key.(*cryptossh.Certificate); ok {
+				return certChecker.Authenticate(conn, key)
+			}
 			if !bytes.Equal(key.Marshal(), clientPublicKey.Marshal()) {
 				return nil, fmt.Errorf("invalid key")
 			}
@@ -154,7 +170,12 @@ func TestProxy(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	l, err := fakeServer(&clusterKey.PublicKey)
+	caKey, _, err := utiltls.GenerateKeyAndCertificate("ca", nil, nil, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := fakeServer(&clusterKey.PublicKey, &caKey.PublicKey)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -246,6 +267,74 @@ func TestProxy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "good, with certificate",
+			username: username,
+			password: password,
+			fixtureChecker: func(tt *test, fixture *testdatabase.Fixture, checker *testdatabase.Checker, openShiftClustersClient *cosmosdb.FakeOpenShiftClusterDocumentClient, portalClient *cosmosdb.FakePortalDocumentClient) {
+				certificate := &api.SSHCertificate{
+					KeyID:       "session",
+					Serial:      42,
+					ValidBefore: int(time.Now().Add(time.Hour).Unix()),
+				}
+				portalDocument := goodPortalDocument(tt.password)
+				portalDocument.Portal.SSH.Certificate = certificate
+				fixture.AddPortalDocuments(portalDocument)
+				openShiftClusterDocument := goodOpenShiftClusterDocument()
+				openShiftClusterDocument.OpenShiftCluster.Properties.SSHKey = nil
+				openShiftClusterDocument.OpenShiftCluster.Properties.SSHCAKey = api.SecureBytes(x509.MarshalPKCS1PrivateKey(caKey))
+				fixture.AddOpenShiftClusterDocuments(openShiftClusterDocument)
+				portalDocument = goodPortalDocument(tt.password)
+				portalDocument.Portal.SSH.Certificate = certificate
+				portalDocument.Portal.SSH.Authenticated = true
+				checker.AddPortalDocuments(portalDocument)
+				checker.AddOpenShiftClusterDocuments(openShiftClusterDocument)
+			},
+			mocks: func(dialer *mock_proxy.MockDialer) {
+				dialer.EXPECT().DialContext(gomock.Any(), "tcp", apiServerPrivateEndpointIP+":2201").Return(l.DialContext(ctx, "", ""))
+			},
+			wantLogs: []map[string]types.GomegaMatcher{
+				{
+					"level":       gomega.Equal(logrus.InfoLevel),
+					"msg":         gomega.Equal("authentication succeeded"),
+					"remote_addr": gomega.Not(gomega.BeEmpty()),
+					"username":    gomega.Equal(username),
+				},
+				{
+					"level":              gomega.Equal(logrus.InfoLevel),
+					"msg":                gomega.Equal("certificate minted"),
+					"certificate_key_id": gomega.Equal("session"),
+					"certificate_serial": gomega.BeEquivalentTo(42),
+					"hostname":           gomega.Equal("master-1"),
+					"resource_group":     gomega.Equal(resourceGroup),
+					"resource_id":        gomega.Equal(resourceID),
+					"resource_name":      gomega.Equal(resourceName),
+					"subscription_id":    gomega.Equal(subscriptionID),
+					"username":           gomega.Equal(username),
+				},
+				{
+					"level":           gomega.Equal(logrus.InfoLevel),
+					"msg":             gomega.Equal("connected"),
+					"hostname":        gomega.Equal("master-1"),
+					"resource_group":  gomega.Equal(resourceGroup),
+					"resource_id":     gomega.Equal(resourceID),
+					"resource_name":   gomega.Equal(resourceName),
+					"subscription_id": gomega.Equal(subscriptionID),
+					"username":        gomega.Equal(username),
+				},
+				{
+					"level":           gomega.Equal(logrus.InfoLevel),
+					"msg":             gomega.Equal("disconnected"),
+					"duration":        gomega.BeNumerically(">", 0),
+					"hostname":        gomega.Equal("master-1"),
+					"resource_group":  gomega.Equal(resourceGroup),
+					"resource_id":     gomega.Equal(resourceID),
+					"resource_name":   gomega.Equal(resourceName),
+					"subscription_id": gomega.Equal(subscriptionID),
+					"username":        gomega.Equal(username),
+				},
+			},
+		},
 		{
 			name:     "bad username",
 			username: "bad",
@@ -413,7 +502,7 @@ func TestProxy(t *testing.T) {
 
 			hook, log := testlog.New()
 
-			s, err := New(nil, nil, log, nil, hostKey, nil, dbOpenShiftClusters, dbPortal, dialer)
+			s, err := New(nil, nil, log, nil, hostKey, nil, dbOpenShiftClusters, dbPortal, nil, dialer)
 			if err != nil {
 				t.Fatal(err)
 			}
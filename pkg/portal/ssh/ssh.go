@@ -41,6 +41,7 @@ type SSH struct {
 
 	dbOpenShiftClusters database.OpenShiftClusters
 	dbPortal            database.Portal
+	dbPortalSessions    database.PortalSessions
 
 	dialer proxy.Dialer
 
@@ -57,6 +58,7 @@ func New(env env.Core,
 	elevatedGroupIDs []string,
 	dbOpenShiftClusters database.OpenShiftClusters,
 	dbPortal database.Portal,
+	dbPortalSessions database.PortalSessions,
 	dialer proxy.Dialer,
 ) (*SSH, error) {
 	hostPubKey, err := cryptossh.NewPublicKey(&hostKey.PublicKey)
@@ -74,6 +76,7 @@ func New(env env.Core,
 
 		dbOpenShiftClusters: dbOpenShiftClusters,
 		dbPortal:            dbPortal,
+		dbPortalSessions:    dbPortalSessions,
 
 		dialer: dialer,
 
@@ -141,6 +144,26 @@ func (s *SSH) New(w http.ResponseWriter, r *http.Request) {
 	username := r.Context().Value(middleware.ContextKeyUsername).(string)
 	username = strings.SplitN(username, "@", 2)[0]
 
+	openShiftDoc, err := s.dbOpenShiftClusters.Get(ctx, strings.ToLower(resourceID))
+	if err != nil {
+		s.internalServerError(w, err)
+		return
+	}
+
+	sessionID := s.dbPortalSessions.NewUUID()
+	now := time.Now()
+
+	// clusters which have not yet been given an SSH certificate authority
+	// fall back to the cluster SSH key
+	var certificate *api.SSHCertificate
+	if openShiftDoc.OpenShiftCluster.Properties.SSHCAKey != nil {
+		certificate, err = newCertificateMetadata(sessionID, now)
+		if err != nil {
+			s.internalServerError(w, err)
+			return
+		}
+	}
+
 	password := s.dbPortal.NewUUID()
 	portalDoc := &api.PortalDocument{
 		ID:  password,
@@ -149,7 +172,8 @@ func (s *SSH) New(w http.ResponseWriter, r *http.Request) {
 			Username: ctx.Value(middleware.ContextKeyUsername).(string),
 			ID:       resourceID,
 			SSH: &api.SSH{
-				Master: req.Master,
+				Master:      req.Master,
+				Certificate: certificate,
 			},
 		},
 	}
@@ -160,6 +184,24 @@ func (s *SSH) New(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	_, err = s.dbPortalSessions.Create(ctx, &api.PortalSessionDocument{
+		ID:  sessionID,
+		Key: strings.ToLower(resourceID),
+		PortalSession: &api.PortalSession{
+			Username:       portalDoc.Portal.Username,
+			ResourceID:     resourceID,
+			Kind:           api.PortalSessionKindSSH,
+			Elevated:       elevated,
+			Master:         req.Master,
+			SSHCertificate: certificate,
+			CreationTime:   int(now.Unix()),
+		},
+	})
+	if err != nil {
+		s.internalServerError(w, err)
+		return
+	}
+
 	host := r.Host
 	if strings.ContainsRune(r.Host, ':') {
 		host, _, err = net.SplitHostPort(r.Host)
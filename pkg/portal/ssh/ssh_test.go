@@ -6,6 +6,7 @@ package ssh
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"net/http"
@@ -44,8 +45,14 @@ func TestNew(t *testing.T) {
 	}
 	khline := knownhosts.Line([]string{"localhost"}, hostPubKey)
 
+	caKey, _, err := utiltls.GenerateKeyAndCertificate("ca", nil, nil, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	for _, tt := range []struct {
 		name           string
+		sshCAKey       []byte
 		r              func(*http.Request)
 		checker        func(*testdatabase.Checker, *cosmosdb.FakePortalDocumentClient)
 		wantStatusCode int
@@ -65,6 +72,54 @@ func TestNew(t *testing.T) {
 						},
 					},
 				})
+				checker.AddPortalSessionDocuments(&api.PortalSessionDocument{
+					Key: resourceID,
+					PortalSession: &api.PortalSession{
+						Username:   username,
+						ResourceID: resourceID,
+						Kind:       api.PortalSessionKindSSH,
+						Elevated:   true,
+						Master:     master,
+					},
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+    "command": "echo '` + khline + `' > localhost_known_host ; ssh -o UserKnownHostsFile=localhost_known_host username@localhost",
+    "password": "03030303-0303-0303-0303-030303030001"
+}
+`,
+		},
+		{
+			name:     "success, with certificate",
+			sshCAKey: x509.MarshalPKCS1PrivateKey(caKey),
+			checker: func(checker *testdatabase.Checker, portalClient *cosmosdb.FakePortalDocumentClient) {
+				certificate := &api.SSHCertificate{
+					KeyID: "07070707-0707-0707-0707-070707070001",
+				}
+				checker.AddPortalDocuments(&api.PortalDocument{
+					ID:  password,
+					TTL: 60,
+					Portal: &api.Portal{
+						Username: username,
+						ID:       resourceID,
+						SSH: &api.SSH{
+							Master:      master,
+							Certificate: certificate,
+						},
+					},
+				})
+				checker.AddPortalSessionDocuments(&api.PortalSessionDocument{
+					Key: resourceID,
+					PortalSession: &api.PortalSession{
+						Username:       username,
+						ResourceID:     resourceID,
+						Kind:           api.PortalSessionKindSSH,
+						Elevated:       true,
+						Master:         master,
+						SSHCertificate: certificate,
+					},
+				})
 			},
 			wantStatusCode: http.StatusOK,
 			wantBody: `{
@@ -126,6 +181,25 @@ func TestNew(t *testing.T) {
 			ctx := context.Background()
 
 			dbPortal, portalClient := testdatabase.NewFakePortal()
+			dbPortalSessions, portalSessionsClient := testdatabase.NewFakePortalSessions()
+			dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+
+			fixture := testdatabase.NewFixture().
+				WithOpenShiftClusters(dbOpenShiftClusters)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: resourceID,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						SSHCAKey: tt.sshCAKey,
+					},
+				},
+			})
+
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
 
 			checker := testdatabase.NewChecker()
 
@@ -149,7 +223,7 @@ func TestNew(t *testing.T) {
 			env := mock_env.NewMockCore(ctrl)
 			env.EXPECT().IsLocalDevelopmentMode().AnyTimes().Return(false)
 
-			s, err := New(env, logrus.NewEntry(logrus.StandardLogger()), nil, nil, hostKey, elevatedGroupIDs, nil, dbPortal, nil)
+			s, err := New(env, logrus.NewEntry(logrus.StandardLogger()), nil, nil, hostKey, elevatedGroupIDs, dbOpenShiftClusters, dbPortal, dbPortalSessions, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -171,6 +245,10 @@ func TestNew(t *testing.T) {
 				t.Error(err)
 			}
 
+			for _, err = range checker.CheckPortalSessions(portalSessionsClient) {
+				t.Error(err)
+			}
+
 			resp := w.Response()
 
 			if resp.StatusCode != tt.wantStatusCode {
@@ -12,13 +12,23 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
+
+	xproxy "golang.org/x/net/proxy"
 )
 
+// SOCKS5ProxyEnvVar names the environment variable which, in local
+// development mode, points the dialer at a SOCKS5 proxy instead of the
+// development CONNECT proxy.  It holds either host:port or a socks5:// (or
+// socks5h://) URL, optionally with credentials.
+const SOCKS5ProxyEnvVar = "SOCKS5_PROXY"
+
 type Dialer interface {
 	DialContext(ctx context.Context, network, address string) (net.Conn, error)
 }
@@ -107,16 +117,69 @@ func (d *dev) DialContext(ctx context.Context, network, address string) (net.Con
 	return &conn{Conn: c, r: r}, nil
 }
 
+type socks5 struct {
+	d xproxy.ContextDialer
+}
+
+func (s *socks5) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if network != "tcp" {
+		return nil, fmt.Errorf("unimplemented network %q", network)
+	}
+
+	return s.d.DialContext(ctx, network, address)
+}
+
+// NewSOCKS5Dialer returns a Dialer which tunnels connections through the
+// SOCKS5 proxy at address.  address is either host:port or a socks5:// URL.
+func NewSOCKS5Dialer(address string) (Dialer, error) {
+	if !strings.Contains(address, "://") {
+		address = "socks5://" + address
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "socks5" && u.Scheme != "socks5h" {
+		return nil, fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+	}
+
+	if u.Port() == "" {
+		return nil, fmt.Errorf("missing port in proxy address %q", u.Host)
+	}
+
+	d, err := xproxy.FromURL(u, &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cd, ok := d.(xproxy.ContextDialer)
+	if !ok {
+		return nil, fmt.Errorf("proxy dialer does not support contexts")
+	}
+
+	return &socks5{d: cd}, nil
+}
+
 // NewDialer returns a Dialer which can dial a customer cluster API server. When
 // not in local development mode, there is no magic here.  In local development
 // mode, this dials the development proxy, which proxies to the requested
 // endpoint.  This enables the RP to run without routeability to its vnet in
-// local development mode.
+// local development mode.  If SOCKS5_PROXY is set, the SOCKS5 proxy it names
+// is used in place of the development proxy.
 func NewDialer(isLocalDevelopmentMode bool) (Dialer, error) {
 	if !isLocalDevelopmentMode {
 		return &prod{}, nil
 	}
 
+	if address := os.Getenv(SOCKS5ProxyEnvVar); address != "" {
+		return NewSOCKS5Dialer(address)
+	}
+
 	d := &dev{}
 
 	basepath := os.Getenv("ARO_CHECKOUT_PATH")
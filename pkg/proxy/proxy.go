@@ -124,12 +124,30 @@ func (s Server) validateProxyRequest(w http.ResponseWriter, r *http.Request) err
 // a second Connection (c2) to the requested end Host and then copies data in
 // both directions (c1->c2 and c2->c1).
 func Proxy(log *logrus.Entry, w http.ResponseWriter, r *http.Request, sz int) {
+	ProxyWithReader(log, w, r, sz, nil)
+}
+
+// ProxyWithReader is like Proxy, but if wrap is not nil, data is read from each
+// Connection through the io.Reader that wrap returns for it.  This allows the
+// caller to meter or throttle the proxied data.
+func ProxyWithReader(log *logrus.Entry, w http.ResponseWriter, r *http.Request, sz int, wrap func(io.Reader) io.Reader) {
 	c2, err := utilnet.Dial("tcp", r.Host, sz)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	ProxyConn(log, w, r, c2, wrap)
+}
+
+// ProxyConn is like ProxyWithReader, but copies data to and from c2, which the
+// caller has already dialled.  This allows the caller to control how c2 is
+// dialled.  ProxyConn closes c2.
+func ProxyConn(log *logrus.Entry, w http.ResponseWriter, r *http.Request, c2 net.Conn, wrap func(io.Reader) io.Reader) {
+	if wrap == nil {
+		wrap = func(r io.Reader) io.Reader { return r }
+	}
+
 	defer c2.Close()
 
 	hijacker, ok := w.(http.Hijacker)
@@ -168,7 +186,7 @@ func Proxy(log *logrus.Entry, w http.ResponseWriter, r *http.Request, sz int) {
 				conn2.CloseWrite()
 			}
 		}()
-		_, _ = io.Copy(c2, buf)
+		_, _ = io.Copy(c2, wrap(buf))
 	}()
 
 	// copy from c2->c1.  Call c1.CloseWrite() when done.
@@ -178,5 +196,5 @@ func Proxy(log *logrus.Entry, w http.ResponseWriter, r *http.Request, sz int) {
 			closeWriter.CloseWrite()
 		}
 	}()
-	_, _ = io.Copy(c1, c2)
+	_, _ = io.Copy(c1, wrap(c2))
 }
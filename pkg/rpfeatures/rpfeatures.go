@@ -0,0 +1,151 @@
+package rpfeatures
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+// Refresher keeps the RP feature flags up to date with the RPFeatures
+// documents in the database, so that features can be toggled without
+// restarting the RP
+type Refresher struct {
+	log      *logrus.Entry
+	m        metrics.Emitter
+	features *env.Features
+
+	iterator cosmosdb.RPFeaturesDocumentIterator
+
+	// docs holds the latest version of each RPFeatures document seen on the
+	// change feed, by document ID
+	docs map[string]*api.RPFeatures
+}
+
+// NewRefresher returns a new Refresher which applies the RPFeatures documents
+// in dbRPFeatures to features
+func NewRefresher(log *logrus.Entry, m metrics.Emitter, dbRPFeatures database.RPFeatures, features *env.Features) *Refresher {
+	return &Refresher{
+		log:      log,
+		m:        m,
+		features: features,
+		iterator: dbRPFeatures.ChangeFeed(),
+		docs:     map[string]*api.RPFeatures{},
+	}
+}
+
+// Run reads the change feed every interval until ctx is done
+func (r *Refresher) Run(ctx context.Context, interval time.Duration) {
+	defer recover.Panic(r.log)
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		r.refresh(ctx)
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refresh reads all pending documents from the change feed, applies the
+// merged configuration if any have changed and emits the flag states
+func (r *Refresher) refresh(ctx context.Context) {
+	var changed bool
+
+	for {
+		docs, err := r.iterator.Next(ctx, -1)
+		if err != nil {
+			r.log.Error(err)
+			break
+		}
+		if docs == nil {
+			break
+		}
+
+		for _, doc := range docs.RPFeaturesDocuments {
+			r.docs[doc.ID] = doc.RPFeatures
+			changed = true
+		}
+	}
+
+	if changed {
+		features, overrides := r.merge()
+
+		err := r.features.Apply(features, overrides)
+		if err != nil {
+			r.log.Warn(err)
+		}
+
+		r.log.Printf("applied feature flags from %d documents", len(r.docs))
+	}
+
+	r.emitMetrics()
+}
+
+// merge combines the documents in ID order, so that where documents configure
+// the same feature, the document with the greater ID wins
+func (r *Refresher) merge() (map[string]bool, map[string]map[string]bool) {
+	ids := make([]string, 0, len(r.docs))
+	for id := range r.docs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	features := map[string]bool{}
+	overrides := map[string]map[string]bool{}
+
+	for _, id := range ids {
+		doc := r.docs[id]
+		if doc == nil {
+			continue
+		}
+
+		for name, set := range doc.Features {
+			features[name] = set
+		}
+
+		for subscriptionID, m := range doc.SubscriptionOverrides {
+			subscriptionID = strings.ToLower(subscriptionID)
+			if overrides[subscriptionID] == nil {
+				overrides[subscriptionID] = map[string]bool{}
+			}
+			for name, set := range m {
+				overrides[subscriptionID][name] = set
+			}
+		}
+	}
+
+	return features, overrides
+}
+
+func (r *Refresher) emitMetrics() {
+	for _, f := range env.FeatureValues() {
+		var value int64
+		if r.features.IsSet(f) {
+			value = 1
+		}
+
+		r.m.EmitGauge("rp.features.enabled", value, map[string]string{
+			"feature": strings.TrimPrefix(f.String(), "Feature"),
+		})
+	}
+
+	r.m.EmitGauge("rp.features.subscriptionoverrides", int64(r.features.Overrides()), nil)
+}
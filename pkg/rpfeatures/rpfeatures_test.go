@@ -0,0 +1,75 @@
+package rpfeatures
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestRefresh(t *testing.T) {
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("rp.features.enabled", int64(1), map[string]string{"feature": "RequireD2sV3Workers"})
+	m.EXPECT().EmitGauge("rp.features.enabled", int64(0), gomock.Any()).AnyTimes()
+	m.EXPECT().EmitGauge("rp.features.subscriptionoverrides", int64(1), nil)
+
+	features := env.NewFeatures(env.FeatureDisableDenyAssignments)
+
+	r := &Refresher{
+		log:      logrus.NewEntry(logrus.StandardLogger()),
+		m:        m,
+		features: features,
+		iterator: cosmosdb.NewFakeRPFeaturesDocumentIterator([]*api.RPFeaturesDocument{
+			{
+				ID: "b",
+				RPFeatures: &api.RPFeatures{
+					Features: map[string]bool{
+						"DisableDenyAssignments": false,
+					},
+				},
+			},
+			{
+				ID: "a",
+				RPFeatures: &api.RPFeatures{
+					Features: map[string]bool{
+						"DisableDenyAssignments": true,
+						"RequireD2sV3Workers":    true,
+					},
+					SubscriptionOverrides: map[string]map[string]bool{
+						"00000000-0000-0000-0000-000000000000": {
+							"RequireD2sV3Workers": false,
+						},
+					},
+				},
+			},
+		}, 0),
+		docs: map[string]*api.RPFeatures{},
+	}
+
+	r.refresh(ctx)
+
+	// document b is merged after document a, so it wins
+	if features.IsSet(env.FeatureDisableDenyAssignments) {
+		t.Error("DisableDenyAssignments should be unset")
+	}
+	if !features.IsSet(env.FeatureRequireD2sV3Workers) {
+		t.Error("RequireD2sV3Workers should be set")
+	}
+	if features.IsSetForSubscription(env.FeatureRequireD2sV3Workers, "00000000-0000-0000-0000-000000000000") {
+		t.Error("RequireD2sV3Workers should be unset for overridden subscription")
+	}
+}
@@ -0,0 +1,118 @@
+package swagger
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"embed"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed readmes
+var readmes embed.FS
+
+var readmeTemplates = template.Must(template.ParseFS(readmes, "readmes/*.md.gotmpl"))
+
+// apiVersion is an API version for which a swagger spec has been generated
+type apiVersion struct {
+	// Version is the API version, e.g. 2023-04-01 or 2024-08-12-preview
+	Version string
+	// Folder is stable or preview
+	Folder string
+}
+
+// Tag is the autorest tag which selects the API version
+func (v apiVersion) Tag() string {
+	return "package-" + v.Version
+}
+
+// Package is the API version in the form used in SDK package names
+func (v apiVersion) Package() string {
+	return "v" + strings.ReplaceAll(v.Version, "-", "_")
+}
+
+func (v apiVersion) IsPreview() bool {
+	return v.Folder == "preview"
+}
+
+// listAPIVersions returns the API versions for which there is a spec under
+// specDir, in ascending order
+func listAPIVersions(specDir string) ([]apiVersion, error) {
+	var versions []apiVersion
+
+	for _, folder := range []string{"stable", "preview"} {
+		entries, err := os.ReadDir(filepath.Join(specDir, folder))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			_, err := os.Stat(filepath.Join(specDir, folder, entry.Name(), "redhatopenshift.json"))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			versions = append(versions, apiVersion{
+				Version: entry.Name(),
+				Folder:  folder,
+			})
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+
+	return versions, nil
+}
+
+type readmeData struct {
+	Versions []apiVersion
+	// Default is the API version generated when no tag is given: the latest
+	// stable version
+	Default apiVersion
+}
+
+// generateReadmes (re)writes the autorest configuration used to generate the
+// SDKs, the Azure CLI extension and the resource schemas from the specs of
+// every API version under resourceManagerDir, so that each new API version
+// is picked up as soon as its spec is generated
+func generateReadmes(resourceManagerDir string) error {
+	versions, err := listAPIVersions(filepath.Join(resourceManagerDir, "Microsoft.RedHatOpenShift"))
+	if err != nil {
+		return err
+	}
+
+	data := &readmeData{
+		Versions: versions,
+	}
+	for _, v := range versions {
+		if !v.IsPreview() {
+			data.Default = v
+		}
+	}
+
+	for _, tmpl := range readmeTemplates.Templates() {
+		buf := &bytes.Buffer{}
+		err = tmpl.Execute(buf, data)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(filepath.Join(resourceManagerDir, strings.TrimSuffix(tmpl.Name(), ".gotmpl")), buf.Bytes(), 0666)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
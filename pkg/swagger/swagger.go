@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"os"
+	"path/filepath"
 	"reflect"
 
 	"k8s.io/utils/strings/slices"
@@ -247,7 +248,13 @@ func Run(api, outputDir string) error {
 		return err
 	}
 
-	return os.WriteFile(outputDir+"/redhatopenshift.json", b, 0666)
+	err = os.WriteFile(outputDir+"/redhatopenshift.json", b, 0666)
+	if err != nil {
+		return err
+	}
+
+	// outputDir is <resource-manager>/Microsoft.RedHatOpenShift/{stable,preview}/<version>
+	return generateReadmes(filepath.Dir(filepath.Dir(filepath.Dir(outputDir))))
 }
 
 func deepCopy(v interface{}) (interface{}, error) {
@@ -95,6 +95,9 @@ func (tw *typeWalker) schemaFromType(t types.Type, deps map[*types.Named]struct{
 			s.Format = "int32"
 		case types.String:
 			s.Type = "string"
+		case types.Float64:
+			s.Type = "number"
+			s.Format = "double"
 		default:
 			panic(t)
 		}
@@ -115,6 +118,9 @@ func (tw *typeWalker) schemaFromType(t types.Type, deps map[*types.Named]struct{
 			// handle []byte as a string (it'll be base64 encoded by json.Marshal)
 			if e.Kind() == types.Uint8 {
 				s.Type = "string"
+			} else {
+				s.Type = "array"
+				s.Items = tw.schemaFromType(e, deps)
 			}
 		} else {
 			s.Type = "array"
@@ -0,0 +1,86 @@
+package otlp
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+// maxQueueSize is the most spans we hold on to between exports.  If the
+// collector is unavailable, further spans are dropped.
+const maxQueueSize = 10000
+
+// New returns a new tracer provider which exports to the OTLP/gRPC endpoint,
+// e.g. http://localhost:4317.  An http endpoint is connected to without TLS.
+// Ended spans are batched and exported every interval, and the remaining spans
+// are flushed when ctx is done.  serviceName and namespace are recorded as the
+// service.name and service.namespace resource attributes.  Every span is
+// sampled.
+func New(ctx context.Context, log *logrus.Entry, env env.Core, endpoint, serviceName, namespace string, interval time.Duration) (*sdktrace.TracerProvider, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(u.Host),
+	}
+
+	switch u.Scheme {
+	case "http":
+		options = append(options, otlptracegrpc.WithInsecure())
+	case "https":
+	default:
+		return nil, fmt.Errorf("unsupported OTLP endpoint %q. Expecting http://<host>:<port> or https://<host>:<port>", endpoint)
+	}
+
+	// the exporter does not block on connecting, so a collector which is not
+	// yet available does not stop us starting
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter,
+			sdktrace.WithBatchTimeout(interval),
+			sdktrace.WithMaxQueueSize(maxQueueSize),
+		),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+			semconv.ServiceNamespace(namespace),
+			semconv.CloudRegion(env.Location()),
+			semconv.HostName(env.Hostname()),
+		)),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+
+	go func() {
+		defer recover.Panic(log)
+
+		<-ctx.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := tp.Shutdown(ctx)
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	return tp, nil
+}
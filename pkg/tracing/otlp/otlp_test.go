@@ -0,0 +1,193 @@
+package otlp
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+
+	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func attributes(kvs []*commonpb.KeyValue) map[string]interface{} {
+	m := map[string]interface{}{}
+	for _, kv := range kvs {
+		switch v := kv.Value.Value.(type) {
+		case *commonpb.AnyValue_StringValue:
+			m[kv.Key] = v.StringValue
+		case *commonpb.AnyValue_IntValue:
+			m[kv.Key] = v.IntValue
+		case *commonpb.AnyValue_BoolValue:
+			m[kv.Key] = v.BoolValue
+		case *commonpb.AnyValue_DoubleValue:
+			m[kv.Key] = v.DoubleValue
+		}
+	}
+	return m
+}
+
+// fakeTraceService passes the spans it receives to spans
+type fakeTraceService struct {
+	coltracepb.UnimplementedTraceServiceServer
+	spans chan *tracepb.Span
+}
+
+func (s *fakeTraceService) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	for _, resourceSpans := range req.ResourceSpans {
+		if attributes(resourceSpans.Resource.Attributes)["service.name"] != "aro-test" {
+			return nil, errors.New("missing resource attributes")
+		}
+
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			if scopeSpans.Scope.GetName() != "test" {
+				return nil, errors.New("missing scope")
+			}
+
+			for _, span := range scopeSpans.Spans {
+				s.spans <- span
+			}
+		}
+	}
+
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func TestTracerProvider(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	spans := make(chan *tracepb.Span, 10)
+
+	s := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(s, &fakeTraceService{spans: spans})
+	defer s.Stop()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = s.Serve(l)
+	}()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	env := mock_env.NewMockCore(controller)
+	env.EXPECT().Location().AnyTimes().Return("eastus")
+	env.EXPECT().Hostname().AnyTimes().Return("test-host")
+
+	tp, err := New(ctx, utillog.GetLogger(), env, "http://"+l.Addr().String(), "aro-test", "test", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a remote parent, as extracted from W3C Trace Context headers
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	ctx = trace.ContextWithRemoteSpanContext(ctx, remote)
+
+	ctx, parent := tp.Tracer("test").Start(ctx, "parent", trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attribute.String("key", "value")))
+	_, child := tp.Tracer("test").Start(ctx, "child")
+
+	if !child.IsRecording() {
+		t.Error("child not recording")
+	}
+	if child.SpanContext().TraceID() != remote.TraceID() || !child.SpanContext().IsSampled() {
+		t.Error(child.SpanContext())
+	}
+
+	child.SetAttributes(attribute.Int("count", 3))
+	child.RecordError(errors.New("failed"))
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+
+	if child.IsRecording() {
+		t.Error("child still recording")
+	}
+
+	parent.SetName("renamed")
+	parent.SetStatus(codes.Ok, "ignored")
+	parent.SetStatus(codes.Error, "too late")
+	parent.End()
+
+	err = tp.ForceFlush(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]*tracepb.Span{}
+	timeout := time.After(10 * time.Second)
+	for len(got) < 2 {
+		select {
+		case s := <-spans:
+			got[s.Name] = s
+		case <-timeout:
+			t.Fatalf("timed out, got %v", got)
+		}
+	}
+
+	p, c := got["renamed"], got["child"]
+	if p == nil || c == nil {
+		t.Fatal(got)
+	}
+
+	traceID := remote.TraceID()
+	if !bytes.Equal(p.TraceId, traceID[:]) || !bytes.Equal(c.TraceId, traceID[:]) {
+		t.Error("trace IDs not propagated")
+	}
+	if !bytes.Equal(p.ParentSpanId, []byte{2, 0, 0, 0, 0, 0, 0, 0}) {
+		t.Error(p.ParentSpanId)
+	}
+	if !bytes.Equal(c.ParentSpanId, p.SpanId) {
+		t.Error(c.ParentSpanId)
+	}
+
+	if p.Kind != tracepb.Span_SPAN_KIND_SERVER || c.Kind != tracepb.Span_SPAN_KIND_INTERNAL {
+		t.Error(p.Kind, c.Kind)
+	}
+	if p.EndTimeUnixNano < p.StartTimeUnixNano {
+		t.Error(p.StartTimeUnixNano, p.EndTimeUnixNano)
+	}
+
+	if attributes(p.Attributes)["key"] != "value" || attributes(c.Attributes)["count"] != int64(3) {
+		t.Error(p.Attributes, c.Attributes)
+	}
+
+	if p.Status.Code != tracepb.Status_STATUS_CODE_OK || p.Status.Message != "" {
+		t.Error(p.Status)
+	}
+	if c.Status.Code != tracepb.Status_STATUS_CODE_ERROR || c.Status.Message != "failed" {
+		t.Error(c.Status)
+	}
+
+	if len(c.Events) != 1 || c.Events[0].Name != "exception" ||
+		attributes(c.Events[0].Attributes)["exception.message"] != "failed" {
+		t.Error(c.Events)
+	}
+}
+
+func TestNewInvalidEndpoint(t *testing.T) {
+	_, err := New(context.Background(), utillog.GetLogger(), nil, "unix:///tmp/socket", "aro-test", "test", time.Second)
+	utilerror.AssertErrorMessage(t, err, `unsupported OTLP endpoint "unix:///tmp/socket". Expecting http://<host>:<port> or https://<host>:<port>`)
+}
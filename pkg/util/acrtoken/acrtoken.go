@@ -7,18 +7,38 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	mgmtcontainerregistry "github.com/Azure/azure-sdk-for-go/services/preview/containerregistry/mgmt/2020-11-01-preview/containerregistry"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/Azure/go-autorest/autorest/to"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/containerregistry"
+	"github.com/Azure/ARO-RP/pkg/util/cache"
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 )
 
+const (
+	// PasswordLifetime is how long a generated token password remains valid
+	PasswordLifetime = 90 * 24 * time.Hour
+
+	// RotateBefore is how long before its password expires that a token
+	// becomes due for rotation
+	RotateBefore = 30 * 24 * time.Hour
+
+	// tokenPropertiesTTL is how long the properties of a token are cached
+	// for.  They are invalidated whenever this package changes the token.
+	tokenPropertiesTTL = 5 * time.Minute
+)
+
+// tokenProperties is shared by all Managers, which are short-lived, so that
+// retried rotations do not fetch the same token again
+var tokenProperties = cache.New[string, mgmtcontainerregistry.TokenProperties](tokenPropertiesTTL)
+
 type Manager interface {
 	GetRegistryProfile(oc *api.OpenShiftCluster) *api.RegistryProfile
 	NewRegistryProfile(oc *api.OpenShiftCluster) *api.RegistryProfile
@@ -31,9 +51,11 @@ type Manager interface {
 type manager struct {
 	env env.Interface
 	r   azure.Resource
+	now func() time.Time
 
-	tokens     containerregistry.TokensClient
-	registries containerregistry.RegistriesClient
+	tokens          containerregistry.TokensClient
+	registries      containerregistry.RegistriesClient
+	tokenProperties *cache.Cache[string, mgmtcontainerregistry.TokenProperties]
 }
 
 func NewManager(env env.Interface, localFPAuthorizer autorest.Authorizer) (Manager, error) {
@@ -45,17 +67,34 @@ func NewManager(env env.Interface, localFPAuthorizer autorest.Authorizer) (Manag
 	m := &manager{
 		env: env,
 		r:   r,
+		now: time.Now,
 
-		tokens:     containerregistry.NewTokensClient(env.Environment(), r.SubscriptionID, localFPAuthorizer),
-		registries: containerregistry.NewRegistriesClient(env.Environment(), r.SubscriptionID, localFPAuthorizer),
+		tokens:          containerregistry.NewTokensClient(env.Environment(), r.SubscriptionID, localFPAuthorizer),
+		registries:      containerregistry.NewRegistriesClient(env.Environment(), r.SubscriptionID, localFPAuthorizer),
+		tokenProperties: tokenProperties,
 	}
 
 	return m, nil
 }
 
-func (m *manager) GetRegistryProfile(oc *api.OpenShiftCluster) *api.RegistryProfile {
+// GetRegistryProfileFromCluster returns the registry profile of the RP's ACR
+// from the cluster, without needing a Manager.
+func GetRegistryProfileFromCluster(env env.Interface, oc *api.OpenShiftCluster) (*api.RegistryProfile, error) {
+	r, err := azure.ParseResourceID(env.ACRResourceID())
+	if err != nil {
+		return nil, err
+	}
+
+	return getRegistryProfile(registryProfileName(env, r), oc), nil
+}
+
+func registryProfileName(env env.Interface, r azure.Resource) string {
+	return fmt.Sprintf("%s.%s", r.ResourceName, env.Environment().ContainerRegistryDNSSuffix)
+}
+
+func getRegistryProfile(name string, oc *api.OpenShiftCluster) *api.RegistryProfile {
 	for i, rp := range oc.Properties.RegistryProfiles {
-		if rp.Name == fmt.Sprintf("%s.%s", m.r.ResourceName, m.env.Environment().ContainerRegistryDNSSuffix) {
+		if rp.Name == name {
 			return oc.Properties.RegistryProfiles[i]
 		}
 	}
@@ -63,9 +102,13 @@ func (m *manager) GetRegistryProfile(oc *api.OpenShiftCluster) *api.RegistryProf
 	return nil
 }
 
+func (m *manager) GetRegistryProfile(oc *api.OpenShiftCluster) *api.RegistryProfile {
+	return getRegistryProfile(registryProfileName(m.env, m.r), oc)
+}
+
 func (m *manager) NewRegistryProfile(oc *api.OpenShiftCluster) *api.RegistryProfile {
 	return &api.RegistryProfile{
-		Name:     fmt.Sprintf("%s.%s", m.r.ResourceName, m.env.Environment().ContainerRegistryDNSSuffix),
+		Name:     registryProfileName(m.env, m.r),
 		Username: "token-" + uuid.DefaultGenerator.Generate(),
 	}
 }
@@ -106,7 +149,9 @@ func (m *manager) EnsureTokenAndPassword(ctx context.Context, rp *api.RegistryPr
 // password with the oldest creation date, generates a new password, and
 // then updates the registry profile with the newly generated password.
 func (m *manager) RotateTokenPassword(ctx context.Context, rp *api.RegistryProfile) error {
-	tokenProperties, err := m.tokens.GetTokenProperties(ctx, m.r.ResourceGroup, m.r.ResourceName, rp.Username)
+	tokenProperties, err := m.tokenProperties.GetOrLoad(ctx, m.tokenID(rp), func(ctx context.Context) (mgmtcontainerregistry.TokenProperties, error) {
+		return m.tokens.GetTokenProperties(ctx, m.r.ResourceGroup, m.r.ResourceName, rp.Username)
+	})
 	if err != nil {
 		return err
 	}
@@ -143,16 +188,23 @@ func (m *manager) RotateTokenPassword(ctx context.Context, rp *api.RegistryProfi
 }
 
 // generateTokenPassword takes an existing ACR token and generates
-// a password for the specified password name
+// a password for the specified password name.  The password expires after
+// PasswordLifetime; its expiry is recorded in the registry profile.
 func (m *manager) generateTokenPassword(ctx context.Context, passwordName mgmtcontainerregistry.TokenPasswordName, rp *api.RegistryProfile) (string, error) {
+	expiry := m.now().Add(PasswordLifetime).UTC()
+
 	creds, err := m.registries.GenerateCredentials(ctx, m.r.ResourceGroup, m.r.ResourceName, mgmtcontainerregistry.GenerateCredentialsParameters{
-		TokenID: to.StringPtr(m.env.ACRResourceID() + "/tokens/" + rp.Username),
+		TokenID: to.StringPtr(m.tokenID(rp)),
+		Expiry:  &date.Time{Time: expiry},
 		Name:    passwordName,
 	})
 	if err != nil {
 		return "", err
 	}
 
+	m.tokenProperties.Delete(m.tokenID(rp))
+	rp.Expiry = &expiry
+
 	// response details from Azure API
 	// https://learn.microsoft.com/en-us/rest/api/containerregistry/tokens/create?tabs=Go#tokencreate
 
@@ -166,6 +218,8 @@ func (m *manager) generateTokenPassword(ctx context.Context, passwordName mgmtco
 }
 
 func (m *manager) Delete(ctx context.Context, rp *api.RegistryProfile) error {
+	m.tokenProperties.Delete(m.tokenID(rp))
+
 	err := m.tokens.DeleteAndWait(ctx, m.r.ResourceGroup, m.r.ResourceName, rp.Username)
 	if detailedErr, ok := err.(autorest.DetailedError); ok &&
 		detailedErr.StatusCode == http.StatusNotFound {
@@ -173,3 +227,14 @@ func (m *manager) Delete(ctx context.Context, rp *api.RegistryProfile) error {
 	}
 	return err
 }
+
+func (m *manager) tokenID(rp *api.RegistryProfile) string {
+	return m.env.ACRResourceID() + "/tokens/" + rp.Username
+}
+
+// RotationDue returns true if the registry profile's token password expires
+// within RotateBefore of now.  Profiles created before expiries were recorded
+// are always due, so that they pick up an expiring password.
+func RotationDue(rp *api.RegistryProfile, now time.Time) bool {
+	return rp.Expiry == nil || rp.Expiry.Sub(now) < RotateBefore
+}
@@ -15,10 +15,13 @@ import (
 	"github.com/golang/mock/gomock"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/cache"
 	mock_containerregistry "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/containerregistry"
 	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
 )
 
+var testNow = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
 const (
 	tokenName          = "token-12345"
 	registryResourceID = "/subscriptions/93aeba23-2f76-4307-be82-02921df010cf/resourceGroups/global/providers/Microsoft.ContainerRegistry/registries/arointsvc"
@@ -26,6 +29,7 @@ const (
 
 func TestEnsureTokenAndPassword(t *testing.T) {
 	ctx := context.Background()
+	now := time.Now()
 
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -62,18 +66,24 @@ func TestEnsureTokenAndPassword(t *testing.T) {
 	m := &manager{
 		env: env,
 		r:   r,
+		now: func() time.Time { return now },
 
-		registries: registries,
-		tokens:     tokens,
+		registries:      registries,
+		tokens:          tokens,
+		tokenProperties: cache.New[string, mgmtcontainerregistry.TokenProperties](time.Minute),
 	}
 
-	password, err := m.EnsureTokenAndPassword(ctx, &api.RegistryProfile{Username: tokenName})
+	rp := &api.RegistryProfile{Username: tokenName}
+	password, err := m.EnsureTokenAndPassword(ctx, rp)
 	if err != nil {
 		t.Fatal(err)
 	}
 	if password != "foo" {
 		t.Error(password)
 	}
+	if rp.Expiry == nil || !rp.Expiry.Equal(now.Add(PasswordLifetime)) {
+		t.Error(rp.Expiry)
+	}
 }
 
 func TestRotateTokenPassword(t *testing.T) {
@@ -180,10 +190,12 @@ func setupManager(controller *gomock.Controller, tc *mock_containerregistry.Mock
 	env.EXPECT().ACRResourceID().AnyTimes().Return(registryResourceID)
 	r, _ := azure.ParseResourceID(registryResourceID)
 	return &manager{
-		env:        env,
-		r:          r,
-		tokens:     tc,
-		registries: rc,
+		env:             env,
+		r:               r,
+		now:             func() time.Time { return testNow },
+		tokens:          tc,
+		registries:      rc,
+		tokenProperties: cache.New[string, mgmtcontainerregistry.TokenProperties](time.Minute),
 	}
 }
 
@@ -213,6 +225,45 @@ func fakeTokenProperties(tp *[]mgmtcontainerregistry.TokenPassword) mgmtcontaine
 func generateCredentialsParameters(tpn mgmtcontainerregistry.TokenPasswordName) mgmtcontainerregistry.GenerateCredentialsParameters {
 	return mgmtcontainerregistry.GenerateCredentialsParameters{
 		TokenID: to.StringPtr(registryResourceID + "/tokens/" + tokenName),
+		Expiry:  toDate(testNow.Add(PasswordLifetime)),
 		Name:    tpn,
 	}
 }
+
+func TestRotationDue(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		expiry *time.Time
+		want   bool
+	}{
+		{
+			name: "no recorded expiry",
+			want: true,
+		},
+		{
+			name:   "expires within rotation window",
+			expiry: timePtr(testNow.Add(RotateBefore - time.Hour)),
+			want:   true,
+		},
+		{
+			name:   "already expired",
+			expiry: timePtr(testNow.Add(-time.Hour)),
+			want:   true,
+		},
+		{
+			name:   "recently rotated",
+			expiry: timePtr(testNow.Add(PasswordLifetime)),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RotationDue(&api.RegistryProfile{Expiry: tt.expiry}, testNow)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -48,6 +48,8 @@ func DeployTemplate(ctx context.Context, log *logrus.Entry, deployments features
 	if serviceErr != nil {
 		b, _ := json.Marshal(serviceErr)
 
+		// the installer log is parsed for the JSON encoded error in the
+		// message of the detail, so it is kept as is
 		return &api.CloudError{
 			StatusCode: http.StatusBadRequest,
 			CloudErrorBody: &api.CloudErrorBody{
@@ -55,7 +57,8 @@ func DeployTemplate(ctx context.Context, log *logrus.Entry, deployments features
 				Message: "Deployment failed.",
 				Details: []api.CloudErrorBody{
 					{
-						Message: string(b),
+						Message:        string(b),
+						RemediationURL: remediationURL(ServiceErrorToCloudErrorBody(serviceErr)),
 					},
 				},
 			},
@@ -64,3 +67,64 @@ func DeployTemplate(ctx context.Context, log *logrus.Entry, deployments features
 
 	return err
 }
+
+// ServiceErrorToCloudErrorBody converts an ARM error to a CloudErrorBody,
+// keeping its nested details and linking to remediation documentation where
+// the error code is known
+func ServiceErrorToCloudErrorBody(serviceErr *azure.ServiceError) api.CloudErrorBody {
+	body := api.CloudErrorBody{
+		Code:           serviceErr.Code,
+		Message:        serviceErr.Message,
+		RemediationURL: api.RemediationURLForCode(serviceErr.Code),
+		Details:        cloudErrorBodies(serviceErr.Details),
+	}
+	if serviceErr.Target != nil {
+		body.Target = *serviceErr.Target
+	}
+
+	return body
+}
+
+// remediationURL returns the remediation URL of body or, if it has none, of
+// the first of its details which has one
+func remediationURL(body api.CloudErrorBody) string {
+	if body.RemediationURL != "" {
+		return body.RemediationURL
+	}
+
+	for _, detail := range body.Details {
+		if url := remediationURL(detail); url != "" {
+			return url
+		}
+	}
+
+	return ""
+}
+
+// cloudErrorBodies converts the details of a *azure.ServiceError, which are
+// themselves ARM errors, to CloudErrorBodies
+func cloudErrorBodies(details []map[string]interface{}) []api.CloudErrorBody {
+	var bodies []api.CloudErrorBody
+
+	for _, detail := range details {
+		body := api.CloudErrorBody{}
+		body.Code, _ = detail["code"].(string)
+		body.Message, _ = detail["message"].(string)
+		body.Target, _ = detail["target"].(string)
+		body.RemediationURL = api.RemediationURLForCode(body.Code)
+
+		if nested, ok := detail["details"].([]interface{}); ok {
+			var nestedDetails []map[string]interface{}
+			for _, n := range nested {
+				if m, ok := n.(map[string]interface{}); ok {
+					nestedDetails = append(nestedDetails, m)
+				}
+			}
+			body.Details = cloudErrorBodies(nestedDetails)
+		}
+
+		bodies = append(bodies, body)
+	}
+
+	return bodies
+}
@@ -5,6 +5,7 @@ package arm
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
@@ -14,6 +15,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/wait"
 
+	"github.com/Azure/ARO-RP/pkg/api"
 	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
@@ -116,3 +118,52 @@ func TestDeployARMTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceErrorToCloudErrorBody(t *testing.T) {
+	serviceErr := &azure.ServiceError{
+		Code:    "InvalidTemplateDeployment",
+		Message: "The template deployment failed.",
+		Details: []map[string]interface{}{
+			{
+				"code":    "RequestDisallowedByPolicy",
+				"message": "Resource 'test-bootstrap' was disallowed by policy.",
+				"target":  "test-bootstrap",
+				"details": []interface{}{
+					map[string]interface{}{
+						"code":    "SkuNotAvailable",
+						"message": "The requested size is not available.",
+					},
+				},
+			},
+		},
+	}
+
+	want := api.CloudErrorBody{
+		Code:    "InvalidTemplateDeployment",
+		Message: "The template deployment failed.",
+		Details: []api.CloudErrorBody{
+			{
+				Code:           "RequestDisallowedByPolicy",
+				Message:        "Resource 'test-bootstrap' was disallowed by policy.",
+				Target:         "test-bootstrap",
+				RemediationURL: api.RemediationURLPolicy,
+				Details: []api.CloudErrorBody{
+					{
+						Code:           "SkuNotAvailable",
+						Message:        "The requested size is not available.",
+						RemediationURL: api.RemediationURLSKUNotAvailable,
+					},
+				},
+			},
+		},
+	}
+
+	got := ServiceErrorToCloudErrorBody(serviceErr)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+
+	if url := remediationURL(got); url != api.RemediationURLPolicy {
+		t.Error(url)
+	}
+}
@@ -26,6 +26,8 @@ func (r *Resource) MarshalJSON() ([]byte, error) {
 		} else if reflect.TypeOf(r.Resource) == reflect.TypeOf(&sdkcosmos.SQLContainerCreateUpdateParameters{}) {
 			b, err = r.Resource.(*sdkcosmos.SQLContainerCreateUpdateParameters).MarshalJSON()
 		}
+	} else if strings.HasPrefix(r.Type, "Microsoft.DocumentDB/databaseAccounts/sqlRoleAssignments") {
+		b, err = r.Resource.(*sdkcosmos.SQLRoleAssignmentCreateUpdateParameters).MarshalJSON()
 	} else if strings.HasPrefix(r.Type, "Microsoft.DocumentDB/databaseAccounts") {
 		b, err = r.Resource.(*sdkcosmos.DatabaseAccountCreateUpdateParameters).MarshalJSON()
 	}
@@ -42,6 +44,20 @@ func (r *Resource) MarshalJSON() ([]byte, error) {
 		}
 
 		dataMap["apiVersion"] = r.APIVersion
+
+		// SQLRoleAssignmentCreateUpdateParameters has no name or type of its
+		// own, so these are taken from r
+		if _, ok := r.Resource.(*sdkcosmos.SQLRoleAssignmentCreateUpdateParameters); ok {
+			dataMap["name"] = r.Name
+			dataMap["type"] = r.Type
+			if r.Condition != nil {
+				dataMap["condition"] = r.Condition
+			}
+			if len(r.DependsOn) > 0 {
+				dataMap["dependsOn"] = r.DependsOn
+			}
+		}
+
 		return json.Marshal(dataMap)
 	}
 
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"testing"
 
+	sdkcosmos "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos/v2"
 	"github.com/Azure/go-autorest/autorest/to"
 )
 
@@ -81,6 +82,33 @@ func TestResourceMarshal(t *testing.T) {
         "tags": null
     },
     "name": "test"
+}`),
+		},
+		{
+			name: "cosmos sql role assignment",
+			r: &Resource{
+				Name:       "test",
+				Type:       "Microsoft.DocumentDB/databaseAccounts/sqlRoleAssignments",
+				Condition:  "[condition]",
+				APIVersion: "2021-01-15",
+				DependsOn:  []string{"[dependency]"},
+				Resource: &sdkcosmos.SQLRoleAssignmentCreateUpdateParameters{
+					Properties: &sdkcosmos.SQLRoleAssignmentResource{
+						PrincipalID: to.StringPtr("principal"),
+					},
+				},
+			},
+			want: []byte(`{
+    "apiVersion": "2021-01-15",
+    "condition": "[condition]",
+    "dependsOn": [
+        "[dependency]"
+    ],
+    "name": "test",
+    "properties": {
+        "principalId": "principal"
+    },
+    "type": "Microsoft.DocumentDB/databaseAccounts/sqlRoleAssignments"
 }`),
 		},
 		{
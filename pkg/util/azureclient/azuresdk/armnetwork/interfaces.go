@@ -0,0 +1,31 @@
+package armnetwork
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/azcore"
+)
+
+// InterfacesClient is a minimal interface for azure-sdk-for-go network interfaces client
+type InterfacesClient interface {
+	Get(ctx context.Context, resourceGroupName, networkInterfaceName string, options *sdknetwork.InterfacesClientGetOptions) (sdknetwork.InterfacesClientGetResponse, error)
+	InterfacesClientAddons
+}
+
+type interfacesClient struct {
+	*sdknetwork.InterfacesClient
+}
+
+var _ InterfacesClient = (*interfacesClient)(nil)
+
+func NewInterfacesClient(subscriptionID string, credential azcore.TokenCredential, options *arm.ClientOptions) (InterfacesClient, error) {
+	client, err := sdknetwork.NewInterfacesClient(subscriptionID, credential, options)
+
+	return &interfacesClient{client}, err
+}
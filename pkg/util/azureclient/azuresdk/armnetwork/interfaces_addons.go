@@ -0,0 +1,50 @@
+package armnetwork
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+)
+
+// InterfacesClientAddons contains addons for InterfacesClient
+type InterfacesClientAddons interface {
+	CreateOrUpdateAndWait(ctx context.Context, resourceGroupName, networkInterfaceName string, parameters sdknetwork.Interface, options *sdknetwork.InterfacesClientBeginCreateOrUpdateOptions) error
+	DeleteAndWait(ctx context.Context, resourceGroupName, networkInterfaceName string, options *sdknetwork.InterfacesClientBeginDeleteOptions) error
+	List(ctx context.Context, resourceGroupName string, options *sdknetwork.InterfacesClientListOptions) ([]*sdknetwork.Interface, error)
+}
+
+func (c *interfacesClient) CreateOrUpdateAndWait(ctx context.Context, resourceGroupName, networkInterfaceName string, parameters sdknetwork.Interface, options *sdknetwork.InterfacesClientBeginCreateOrUpdateOptions) error {
+	poller, err := c.InterfacesClient.BeginCreateOrUpdate(ctx, resourceGroupName, networkInterfaceName, parameters, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+func (c *interfacesClient) DeleteAndWait(ctx context.Context, resourceGroupName, networkInterfaceName string, options *sdknetwork.InterfacesClientBeginDeleteOptions) error {
+	poller, err := c.InterfacesClient.BeginDelete(ctx, resourceGroupName, networkInterfaceName, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+func (c *interfacesClient) List(ctx context.Context, resourceGroupName string, options *sdknetwork.InterfacesClientListOptions) (result []*sdknetwork.Interface, err error) {
+	pager := c.InterfacesClient.NewListPager(resourceGroupName, options)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page.Value...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,31 @@
+package armnetwork
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/azcore"
+)
+
+// LoadBalancersClient is a minimal interface for azure-sdk-for-go load balancers client
+type LoadBalancersClient interface {
+	Get(ctx context.Context, resourceGroupName, loadBalancerName string, options *sdknetwork.LoadBalancersClientGetOptions) (sdknetwork.LoadBalancersClientGetResponse, error)
+	LoadBalancersClientAddons
+}
+
+type loadBalancersClient struct {
+	*sdknetwork.LoadBalancersClient
+}
+
+var _ LoadBalancersClient = (*loadBalancersClient)(nil)
+
+func NewLoadBalancersClient(subscriptionID string, credential azcore.TokenCredential, options *arm.ClientOptions) (LoadBalancersClient, error) {
+	client, err := sdknetwork.NewLoadBalancersClient(subscriptionID, credential, options)
+
+	return &loadBalancersClient{client}, err
+}
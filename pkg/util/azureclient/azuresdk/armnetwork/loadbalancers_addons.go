@@ -0,0 +1,50 @@
+package armnetwork
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+)
+
+// LoadBalancersClientAddons contains addons for LoadBalancersClient
+type LoadBalancersClientAddons interface {
+	CreateOrUpdateAndWait(ctx context.Context, resourceGroupName, loadBalancerName string, parameters sdknetwork.LoadBalancer, options *sdknetwork.LoadBalancersClientBeginCreateOrUpdateOptions) error
+	DeleteAndWait(ctx context.Context, resourceGroupName, loadBalancerName string, options *sdknetwork.LoadBalancersClientBeginDeleteOptions) error
+	List(ctx context.Context, resourceGroupName string, options *sdknetwork.LoadBalancersClientListOptions) ([]*sdknetwork.LoadBalancer, error)
+}
+
+func (c *loadBalancersClient) CreateOrUpdateAndWait(ctx context.Context, resourceGroupName, loadBalancerName string, parameters sdknetwork.LoadBalancer, options *sdknetwork.LoadBalancersClientBeginCreateOrUpdateOptions) error {
+	poller, err := c.LoadBalancersClient.BeginCreateOrUpdate(ctx, resourceGroupName, loadBalancerName, parameters, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+func (c *loadBalancersClient) DeleteAndWait(ctx context.Context, resourceGroupName, loadBalancerName string, options *sdknetwork.LoadBalancersClientBeginDeleteOptions) error {
+	poller, err := c.LoadBalancersClient.BeginDelete(ctx, resourceGroupName, loadBalancerName, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+func (c *loadBalancersClient) List(ctx context.Context, resourceGroupName string, options *sdknetwork.LoadBalancersClientListOptions) (result []*sdknetwork.LoadBalancer, err error) {
+	pager := c.LoadBalancersClient.NewListPager(resourceGroupName, options)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page.Value...)
+	}
+
+	return result, nil
+}
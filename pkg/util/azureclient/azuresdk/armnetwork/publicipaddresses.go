@@ -0,0 +1,31 @@
+package armnetwork
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/azcore"
+)
+
+// PublicIPAddressesClient is a minimal interface for azure-sdk-for-go public IP addresses client
+type PublicIPAddressesClient interface {
+	Get(ctx context.Context, resourceGroupName, publicIPAddressName string, options *sdknetwork.PublicIPAddressesClientGetOptions) (sdknetwork.PublicIPAddressesClientGetResponse, error)
+	PublicIPAddressesClientAddons
+}
+
+type publicIPAddressesClient struct {
+	*sdknetwork.PublicIPAddressesClient
+}
+
+var _ PublicIPAddressesClient = (*publicIPAddressesClient)(nil)
+
+func NewPublicIPAddressesClient(subscriptionID string, credential azcore.TokenCredential, options *arm.ClientOptions) (PublicIPAddressesClient, error) {
+	client, err := sdknetwork.NewPublicIPAddressesClient(subscriptionID, credential, options)
+
+	return &publicIPAddressesClient{client}, err
+}
@@ -0,0 +1,50 @@
+package armnetwork
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+)
+
+// PublicIPAddressesClientAddons contains addons for PublicIPAddressesClient
+type PublicIPAddressesClientAddons interface {
+	CreateOrUpdateAndWait(ctx context.Context, resourceGroupName, publicIPAddressName string, parameters sdknetwork.PublicIPAddress, options *sdknetwork.PublicIPAddressesClientBeginCreateOrUpdateOptions) error
+	DeleteAndWait(ctx context.Context, resourceGroupName, publicIPAddressName string, options *sdknetwork.PublicIPAddressesClientBeginDeleteOptions) error
+	List(ctx context.Context, resourceGroupName string, options *sdknetwork.PublicIPAddressesClientListOptions) ([]*sdknetwork.PublicIPAddress, error)
+}
+
+func (c *publicIPAddressesClient) CreateOrUpdateAndWait(ctx context.Context, resourceGroupName, publicIPAddressName string, parameters sdknetwork.PublicIPAddress, options *sdknetwork.PublicIPAddressesClientBeginCreateOrUpdateOptions) error {
+	poller, err := c.PublicIPAddressesClient.BeginCreateOrUpdate(ctx, resourceGroupName, publicIPAddressName, parameters, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+func (c *publicIPAddressesClient) DeleteAndWait(ctx context.Context, resourceGroupName, publicIPAddressName string, options *sdknetwork.PublicIPAddressesClientBeginDeleteOptions) error {
+	poller, err := c.PublicIPAddressesClient.BeginDelete(ctx, resourceGroupName, publicIPAddressName, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+func (c *publicIPAddressesClient) List(ctx context.Context, resourceGroupName string, options *sdknetwork.PublicIPAddressesClientListOptions) (result []*sdknetwork.PublicIPAddress, err error) {
+	pager := c.PublicIPAddressesClient.NewListPager(resourceGroupName, options)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page.Value...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,31 @@
+package armnetwork
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/azcore"
+)
+
+// SecurityGroupsClient is a minimal interface for azure-sdk-for-go security groups client
+type SecurityGroupsClient interface {
+	Get(ctx context.Context, resourceGroupName, networkSecurityGroupName string, options *sdknetwork.SecurityGroupsClientGetOptions) (sdknetwork.SecurityGroupsClientGetResponse, error)
+	SecurityGroupsClientAddons
+}
+
+type securityGroupsClient struct {
+	*sdknetwork.SecurityGroupsClient
+}
+
+var _ SecurityGroupsClient = (*securityGroupsClient)(nil)
+
+func NewSecurityGroupsClient(subscriptionID string, credential azcore.TokenCredential, options *arm.ClientOptions) (SecurityGroupsClient, error) {
+	client, err := sdknetwork.NewSecurityGroupsClient(subscriptionID, credential, options)
+
+	return &securityGroupsClient{client}, err
+}
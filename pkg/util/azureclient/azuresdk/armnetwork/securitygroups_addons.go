@@ -0,0 +1,50 @@
+package armnetwork
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+)
+
+// SecurityGroupsClientAddons contains addons for SecurityGroupsClient
+type SecurityGroupsClientAddons interface {
+	CreateOrUpdateAndWait(ctx context.Context, resourceGroupName, networkSecurityGroupName string, parameters sdknetwork.SecurityGroup, options *sdknetwork.SecurityGroupsClientBeginCreateOrUpdateOptions) error
+	DeleteAndWait(ctx context.Context, resourceGroupName, networkSecurityGroupName string, options *sdknetwork.SecurityGroupsClientBeginDeleteOptions) error
+	List(ctx context.Context, resourceGroupName string, options *sdknetwork.SecurityGroupsClientListOptions) ([]*sdknetwork.SecurityGroup, error)
+}
+
+func (c *securityGroupsClient) CreateOrUpdateAndWait(ctx context.Context, resourceGroupName, networkSecurityGroupName string, parameters sdknetwork.SecurityGroup, options *sdknetwork.SecurityGroupsClientBeginCreateOrUpdateOptions) error {
+	poller, err := c.SecurityGroupsClient.BeginCreateOrUpdate(ctx, resourceGroupName, networkSecurityGroupName, parameters, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+func (c *securityGroupsClient) DeleteAndWait(ctx context.Context, resourceGroupName, networkSecurityGroupName string, options *sdknetwork.SecurityGroupsClientBeginDeleteOptions) error {
+	poller, err := c.SecurityGroupsClient.BeginDelete(ctx, resourceGroupName, networkSecurityGroupName, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+func (c *securityGroupsClient) List(ctx context.Context, resourceGroupName string, options *sdknetwork.SecurityGroupsClientListOptions) (result []*sdknetwork.SecurityGroup, err error) {
+	pager := c.SecurityGroupsClient.NewListPager(resourceGroupName, options)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page.Value...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package armresourcegraph
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// clusterResourceGroups joins resources to the managed resource group of the
+// ARO cluster which owns them
+const clusterResourceGroups = `
+| extend resourceGroup = tolower(resourceGroup)
+| join kind=inner (
+	ResourceContainers
+	| where type =~ 'microsoft.resources/subscriptions/resourcegroups'
+	| where tolower(managedBy) contains '/providers/microsoft.redhatopenshift/openshiftclusters/'
+	| project subscriptionId, resourceGroup = tolower(name), clusterResourceId = tolower(managedBy)
+) on subscriptionId, resourceGroup
+| project id, clusterResourceId`
+
+var rxVMSize = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// ClusterResource is a resource in the managed resource group of an ARO
+// cluster
+type ClusterResource struct {
+	ID                string
+	ClusterResourceID string
+}
+
+// ClusterVMsOfSize returns all ARO cluster VMs of size vmSize (e.g.
+// Standard_D8s_v3) in subscriptions
+func ClusterVMsOfSize(ctx context.Context, c ResourcesClient, subscriptions []string, vmSize string) ([]ClusterResource, error) {
+	if !rxVMSize.MatchString(vmSize) {
+		return nil, fmt.Errorf("invalid VM size %q", vmSize)
+	}
+
+	return clusterResources(ctx, c, subscriptions, `Resources
+| where type =~ 'microsoft.compute/virtualmachines'
+| where properties.hardwareProfile.vmSize =~ '`+vmSize+`'`+clusterResourceGroups)
+}
+
+// ClusterPublicLoadBalancersWithoutOutboundRules returns all ARO cluster
+// public load balancers in subscriptions which have no outbound rules, and so
+// give the cluster no explicit egress
+func ClusterPublicLoadBalancersWithoutOutboundRules(ctx context.Context, c ResourcesClient, subscriptions []string) ([]ClusterResource, error) {
+	return clusterResources(ctx, c, subscriptions, `Resources
+| where type =~ 'microsoft.network/loadbalancers'
+| where tostring(properties.frontendIPConfigurations) contains '/publicipaddresses/'
+| where array_length(properties.outboundRules) == 0`+clusterResourceGroups)
+}
+
+func clusterResources(ctx context.Context, c ResourcesClient, subscriptions []string, query string) ([]ClusterResource, error) {
+	rows, err := c.ResourcesAll(ctx, subscriptions, query)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ClusterResource, 0, len(rows))
+	for _, row := range rows {
+		id, _ := row["id"].(string)
+		clusterResourceID, _ := row["clusterResourceId"].(string)
+
+		resources = append(resources, ClusterResource{
+			ID:                id,
+			ClusterResourceID: clusterResourceID,
+		})
+	}
+
+	return resources, nil
+}
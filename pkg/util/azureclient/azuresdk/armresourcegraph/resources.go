@@ -0,0 +1,105 @@
+package armresourcegraph
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// azure-sdk-for-go does not yet ship a Resource Graph module which we vendor,
+// so this is a minimal client for the resources query API on top of the azcore
+// ARM pipeline.
+
+const (
+	moduleName    = "armresourcegraph"
+	moduleVersion = "v0.1.0"
+	apiVersion    = "2021-03-01"
+)
+
+// QueryRequest is a Resource Graph query
+type QueryRequest struct {
+	// Subscriptions to query.  If empty, all subscriptions the caller can
+	// read are queried.
+	Subscriptions []string             `json:"subscriptions,omitempty"`
+	Query         string               `json:"query"`
+	Options       *QueryRequestOptions `json:"options,omitempty"`
+}
+
+type QueryRequestOptions struct {
+	SkipToken    *string `json:"$skipToken,omitempty"`
+	Top          *int32  `json:"$top,omitempty"`
+	ResultFormat string  `json:"resultFormat,omitempty"`
+}
+
+// QueryResponse is a page of Resource Graph query results.  Rows are returned
+// in the objectArray format.
+type QueryResponse struct {
+	TotalRecords    int64                    `json:"totalRecords"`
+	Count           int64                    `json:"count"`
+	ResultTruncated string                   `json:"resultTruncated"`
+	SkipToken       *string                  `json:"$skipToken,omitempty"`
+	Data            []map[string]interface{} `json:"data"`
+}
+
+// ResourcesClient is a minimal interface for the Resource Graph resources API
+type ResourcesClient interface {
+	Resources(ctx context.Context, query QueryRequest) (QueryResponse, error)
+	ResourcesClientAddons
+}
+
+type resourcesClient struct {
+	internal *arm.Client
+}
+
+var _ ResourcesClient = (*resourcesClient)(nil)
+
+func NewResourcesClient(credential azcore.TokenCredential, options *arm.ClientOptions) (ResourcesClient, error) {
+	client, err := arm.NewClient(moduleName, moduleVersion, credential, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resourcesClient{internal: client}, nil
+}
+
+// Resources runs query and returns a single page of results
+func (c *resourcesClient) Resources(ctx context.Context, query QueryRequest) (QueryResponse, error) {
+	if query.Options == nil {
+		query.Options = &QueryRequestOptions{}
+	}
+	query.Options.ResultFormat = "objectArray"
+
+	req, err := runtime.NewRequest(ctx, http.MethodPost, runtime.JoinPaths(c.internal.Endpoint(), "/providers/Microsoft.ResourceGraph/resources"))
+	if err != nil {
+		return QueryResponse{}, err
+	}
+
+	q := req.Raw().URL.Query()
+	q.Set("api-version", apiVersion)
+	req.Raw().URL.RawQuery = q.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+
+	err = runtime.MarshalAsJSON(req, query)
+	if err != nil {
+		return QueryResponse{}, err
+	}
+
+	resp, err := c.internal.Pipeline().Do(req)
+	if err != nil {
+		return QueryResponse{}, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return QueryResponse{}, runtime.NewResponseError(resp)
+	}
+
+	var result QueryResponse
+	err = runtime.UnmarshalAsJSON(resp, &result)
+	return result, err
+}
@@ -0,0 +1,38 @@
+package armresourcegraph
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+)
+
+// ResourcesClientAddons contains addons for ResourcesClient
+type ResourcesClientAddons interface {
+	ResourcesAll(ctx context.Context, subscriptions []string, query string) ([]map[string]interface{}, error)
+}
+
+// ResourcesAll runs query and returns all pages of results
+func (c *resourcesClient) ResourcesAll(ctx context.Context, subscriptions []string, query string) (result []map[string]interface{}, err error) {
+	req := QueryRequest{
+		Subscriptions: subscriptions,
+		Query:         query,
+	}
+
+	for {
+		page, err := c.Resources(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, page.Data...)
+
+		if page.SkipToken == nil || *page.SkipToken == "" {
+			return result, nil
+		}
+
+		req.Options = &QueryRequestOptions{
+			SkipToken: page.SkipToken,
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package armresourcegraph
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func TestClusterVMsOfSize(t *testing.T) {
+	ctx := context.Background()
+
+	var queries []QueryRequest
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/providers/Microsoft.ResourceGraph/resources" || r.URL.Query().Get("api-version") != apiVersion {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+
+		var q QueryRequest
+		err := json.NewDecoder(r.Body).Decode(&q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		queries = append(queries, q)
+
+		// return two pages
+		resp := QueryResponse{
+			Data: []map[string]interface{}{
+				{"id": "vm1", "clusterResourceId": "cluster1"},
+			},
+		}
+		if q.Options.SkipToken == nil {
+			resp.SkipToken = func(s string) *string { return &s }("next")
+		} else {
+			resp.Data[0]["id"] = "vm2"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	c, err := NewResourcesClient(fakeCredential{}, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloud.Configuration{
+				ActiveDirectoryAuthorityHost: ts.URL,
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {
+						Audience: "https://management.core.windows.net/",
+						Endpoint: ts.URL,
+					},
+				},
+			},
+			Transport: ts.Client(),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resources, err := ClusterVMsOfSize(ctx, c, []string{"sub"}, "Standard_D8s_v3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(resources, []ClusterResource{
+		{ID: "vm1", ClusterResourceID: "cluster1"},
+		{ID: "vm2", ClusterResourceID: "cluster1"},
+	}) {
+		t.Error(resources)
+	}
+
+	if len(queries) != 2 {
+		t.Fatal(len(queries))
+	}
+	if !reflect.DeepEqual(queries[0].Subscriptions, []string{"sub"}) {
+		t.Error(queries[0].Subscriptions)
+	}
+	if !strings.Contains(queries[0].Query, "=~ 'Standard_D8s_v3'") {
+		t.Error(queries[0].Query)
+	}
+	if queries[0].Options.ResultFormat != "objectArray" {
+		t.Error(queries[0].Options.ResultFormat)
+	}
+	if queries[1].Options.SkipToken == nil || *queries[1].Options.SkipToken != "next" {
+		t.Error(queries[1].Options.SkipToken)
+	}
+
+	_, err = ClusterVMsOfSize(ctx, c, nil, "Standard_D8s_v3' or 1==1")
+	if err == nil || err.Error() != `invalid VM size "Standard_D8s_v3' or 1==1"` {
+		t.Error(err)
+	}
+}
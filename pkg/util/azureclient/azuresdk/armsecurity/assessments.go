@@ -0,0 +1,128 @@
+package armsecurity
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// azure-sdk-for-go does not yet ship a Security module which we vendor, so
+// this is a minimal client for the Defender for Cloud assessments API on top
+// of the azcore ARM pipeline.
+
+const (
+	moduleName    = "armsecurity"
+	moduleVersion = "v0.1.0"
+	apiVersion    = "2021-06-01"
+)
+
+// AssessmentStatusCode is the result of an assessment of a resource
+type AssessmentStatusCode string
+
+// AssessmentStatusCode constants
+const (
+	AssessmentStatusCodeHealthy       AssessmentStatusCode = "Healthy"
+	AssessmentStatusCodeUnhealthy     AssessmentStatusCode = "Unhealthy"
+	AssessmentStatusCodeNotApplicable AssessmentStatusCode = "NotApplicable"
+)
+
+// Severity is the severity of an unhealthy assessment
+type Severity string
+
+// Severity constants
+const (
+	SeverityLow    Severity = "Low"
+	SeverityMedium Severity = "Medium"
+	SeverityHigh   Severity = "High"
+)
+
+// Assessment is the result of a security assessment of a resource
+type Assessment struct {
+	Properties AssessmentProperties `json:"properties"`
+}
+
+type AssessmentProperties struct {
+	ResourceDetails ResourceDetails     `json:"resourceDetails"`
+	Status          AssessmentStatus    `json:"status"`
+	Metadata        *AssessmentMetadata `json:"metadata,omitempty"`
+	AdditionalData  map[string]string   `json:"additionalData,omitempty"`
+}
+
+type ResourceDetails struct {
+	Source string `json:"source"`
+	ID     string `json:"id"`
+}
+
+type AssessmentStatus struct {
+	Code        AssessmentStatusCode `json:"code"`
+	Cause       string               `json:"cause,omitempty"`
+	Description string               `json:"description,omitempty"`
+}
+
+// AssessmentMetadata describes a custom assessment to the customer.  It is
+// sent with each assessment, so that no metadata has to be created in the
+// customer's subscription beforehand.
+type AssessmentMetadata struct {
+	DisplayName            string   `json:"displayName"`
+	Description            string   `json:"description,omitempty"`
+	RemediationDescription string   `json:"remediationDescription,omitempty"`
+	Severity               Severity `json:"severity"`
+	AssessmentType         string   `json:"assessmentType"`
+}
+
+// AssessmentsClient is a minimal interface for the Defender for Cloud
+// assessments API
+type AssessmentsClient interface {
+	CreateOrUpdate(ctx context.Context, resourceID, assessmentName string, assessment *Assessment) error
+}
+
+type assessmentsClient struct {
+	internal *arm.Client
+}
+
+var _ AssessmentsClient = (*assessmentsClient)(nil)
+
+func NewAssessmentsClient(credential azcore.TokenCredential, options *arm.ClientOptions) (AssessmentsClient, error) {
+	client, err := arm.NewClient(moduleName, moduleVersion, credential, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &assessmentsClient{internal: client}, nil
+}
+
+// CreateOrUpdate sets the result of the named assessment of the resource
+func (c *assessmentsClient) CreateOrUpdate(ctx context.Context, resourceID, assessmentName string, assessment *Assessment) error {
+	req, err := runtime.NewRequest(ctx, http.MethodPut, runtime.JoinPaths(c.internal.Endpoint(), resourceID, "/providers/Microsoft.Security/assessments/", url.PathEscape(assessmentName)))
+	if err != nil {
+		return err
+	}
+
+	q := req.Raw().URL.Query()
+	q.Set("api-version", apiVersion)
+	req.Raw().URL.RawQuery = q.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+
+	err = runtime.MarshalAsJSON(req, assessment)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.internal.Pipeline().Do(req)
+	if err != nil {
+		return err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated) {
+		return runtime.NewResponseError(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package armsecurity
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+
+	var got *Assessment
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut ||
+			r.URL.Path != resourceID+"/providers/Microsoft.Security/assessments/assessment" ||
+			r.URL.Query().Get("api-version") != apiVersion {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		got = &Assessment{}
+		err := json.NewDecoder(r.Body).Decode(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	c, err := NewAssessmentsClient(fakeCredential{}, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloud.Configuration{
+				ActiveDirectoryAuthorityHost: ts.URL,
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {
+						Audience: "https://management.core.windows.net/",
+						Endpoint: ts.URL,
+					},
+				},
+			},
+			Transport: ts.Client(),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assessment := &Assessment{
+		Properties: AssessmentProperties{
+			ResourceDetails: ResourceDetails{
+				Source: "Azure",
+				ID:     resourceID,
+			},
+			Status: AssessmentStatus{
+				Code:  AssessmentStatusCodeUnhealthy,
+				Cause: "cause",
+			},
+			Metadata: &AssessmentMetadata{
+				DisplayName:    "name",
+				Severity:       SeverityHigh,
+				AssessmentType: "CustomerManaged",
+			},
+		},
+	}
+
+	err = c.CreateOrUpdate(ctx, resourceID, "assessment", assessment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, assessment) {
+		t.Error(got)
+	}
+}
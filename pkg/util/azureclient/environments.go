@@ -4,7 +4,10 @@ package azureclient
 // Licensed under the Apache License 2.0.
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -34,6 +37,10 @@ type AROEnvironment struct {
 	ResourceManagerScope string
 	KeyVaultScope        string
 	MicrosoftGraphScope  string
+	// TokenExchangeScope is the scope of the managed identity token which is
+	// exchanged for a token of an application trusting the identity through a
+	// federated credential
+	TokenExchangeScope string
 }
 
 // AzureRbacPDPEnvironment contains cloud specific instance of Authz RBAC PDP Remote Server
@@ -62,6 +69,7 @@ var (
 		ResourceManagerScope: azure.PublicCloud.ResourceManagerEndpoint + "/.default",
 		KeyVaultScope:        azure.PublicCloud.ResourceIdentifiers.KeyVault + "/.default",
 		MicrosoftGraphScope:  azure.PublicCloud.MicrosoftGraphEndpoint + "/.default",
+		TokenExchangeScope:   "api://AzureADTokenExchange/.default",
 	}
 
 	// USGovernmentCloud contains additional ARO information for the US Gov cloud environment.
@@ -84,6 +92,27 @@ var (
 		ResourceManagerScope: azure.USGovernmentCloud.ResourceManagerEndpoint + "/.default",
 		KeyVaultScope:        azure.USGovernmentCloud.ResourceIdentifiers.KeyVault + "/.default",
 		MicrosoftGraphScope:  azure.USGovernmentCloud.MicrosoftGraphEndpoint + "/.default",
+		TokenExchangeScope:   "api://AzureADTokenExchangeUSGov/.default",
+	}
+
+	// ChinaCloud contains additional ARO information for the Azure China cloud environment.
+	ChinaCloud = AROEnvironment{
+		Environment:              azure.ChinaCloud,
+		ActualCloudName:          "AzureChinaCloud",
+		GenevaMonitoringEndpoint: "https://gcs.monitoring.core.chinacloudapi.cn/",
+		AppSuffix:                "aro.azure.cn",
+		Cloud:                    cloud.AzureChina,
+		// AppLens is not available in the China cloud
+		PkiIssuerUrlTemplate: "",
+		PkiCaName:            "",
+		AzureRbacPDPEnvironment: AzureRbacPDPEnvironment{
+			Endpoint:   "https://%s.authorization.azure.cn/providers/Microsoft.Authorization/checkAccess?api-version=2021-06-01-preview",
+			OAuthScope: "https://authorization.azure.cn/.default",
+		},
+		ResourceManagerScope: azure.ChinaCloud.ResourceManagerEndpoint + "/.default",
+		KeyVaultScope:        azure.ChinaCloud.ResourceIdentifiers.KeyVault + "/.default",
+		MicrosoftGraphScope:  azure.ChinaCloud.MicrosoftGraphEndpoint + "/.default",
+		TokenExchangeScope:   "api://AzureADTokenExchangeChina/.default",
 	}
 )
 
@@ -94,49 +123,126 @@ func EnvironmentFromName(name string) (AROEnvironment, error) {
 		return PublicCloud, nil
 	case "AZUREUSGOVERNMENTCLOUD":
 		return USGovernmentCloud, nil
+	case "AZURECHINACLOUD":
+		return ChinaCloud, nil
+	case "AZURESTACKCLOUD":
+		// as in go-autorest, the endpoints of an Azure Stack or other custom
+		// cloud are read from the file named by AZURE_ENVIRONMENT_FILEPATH
+		return EnvironmentFromFile(os.Getenv(azure.EnvironmentFilepathName))
 	}
 	return AROEnvironment{}, fmt.Errorf("cloud environment %q is unsupported by ARO", name)
 }
 
+// EnvironmentFromFile returns the AROEnvironment described by the JSON file at
+// path.  The file holds the fields of a go-autorest azure.Environment alongside
+// the ARO-specific fields of AROEnvironment; the azcore cloud configuration and
+// the token scopes are derived from its endpoints.
+func EnvironmentFromFile(path string) (AROEnvironment, error) {
+	if path == "" {
+		return AROEnvironment{}, fmt.Errorf("environment variable %q unset", azure.EnvironmentFilepathName)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return AROEnvironment{}, err
+	}
+
+	var e AROEnvironment
+	err = json.Unmarshal(b, &e)
+	if err != nil {
+		return AROEnvironment{}, err
+	}
+
+	if e.ActualCloudName == "" {
+		e.ActualCloudName = e.Name
+	}
+
+	audience := e.TokenAudience
+	if audience == "" {
+		audience = e.ResourceManagerEndpoint
+	}
+
+	e.Cloud = cloud.Configuration{
+		ActiveDirectoryAuthorityHost: e.ActiveDirectoryEndpoint,
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Audience: audience,
+				Endpoint: e.ResourceManagerEndpoint,
+			},
+		},
+	}
+	e.ResourceManagerScope = e.ResourceManagerEndpoint + "/.default"
+	e.KeyVaultScope = e.ResourceIdentifiers.KeyVault + "/.default"
+	e.MicrosoftGraphScope = e.MicrosoftGraphEndpoint + "/.default"
+
+	return e, e.validate()
+}
+
+// validate checks that the endpoints and DNS suffixes which the RP derives
+// its clients and resource names from are all set
+func (e *AROEnvironment) validate() error {
+	var missing []string
+	for _, f := range []struct {
+		name  string
+		value string
+	}{
+		{"name", e.Name},
+		{"resourceManagerEndpoint", e.ResourceManagerEndpoint},
+		{"activeDirectoryEndpoint", e.ActiveDirectoryEndpoint},
+		{"microsoftGraphEndpoint", e.MicrosoftGraphEndpoint},
+		{"keyVaultDNSSuffix", e.KeyVaultDNSSuffix},
+		{"storageEndpointSuffix", e.StorageEndpointSuffix},
+		{"containerRegistryDNSSuffix", e.ContainerRegistryDNSSuffix},
+		{"cosmosDBDNSSuffix", e.CosmosDBDNSSuffix},
+		{"resourceIdentifiers.keyVault", e.ResourceIdentifiers.KeyVault},
+	} {
+		if f.value == "" || f.value == azure.NotAvailable {
+			missing = append(missing, f.name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.New("cloud environment is missing " + strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
 func (e *AROEnvironment) ClientCertificateCredentialOptions() *azidentity.ClientCertificateCredentialOptions {
 	return &azidentity.ClientCertificateCredentialOptions{
-		ClientOptions: azcore.ClientOptions{
-			Cloud: e.Cloud,
-		},
+		ClientOptions: e.clientOptions(),
 		// Required for Subject Name/Issuer (SNI) authentication
 		SendCertificateChain: true,
 	}
 }
 
+func (e *AROEnvironment) ClientAssertionCredentialOptions() *azidentity.ClientAssertionCredentialOptions {
+	return &azidentity.ClientAssertionCredentialOptions{
+		ClientOptions: e.clientOptions(),
+	}
+}
+
 func (e *AROEnvironment) ClientSecretCredentialOptions() *azidentity.ClientSecretCredentialOptions {
 	return &azidentity.ClientSecretCredentialOptions{
-		ClientOptions: azcore.ClientOptions{
-			Cloud: e.Cloud,
-		},
+		ClientOptions: e.clientOptions(),
 	}
 }
 
 func (e *AROEnvironment) DefaultAzureCredentialOptions() *azidentity.DefaultAzureCredentialOptions {
 	return &azidentity.DefaultAzureCredentialOptions{
-		ClientOptions: azcore.ClientOptions{
-			Cloud: e.Cloud,
-		},
+		ClientOptions: e.clientOptions(),
 	}
 }
 
 func (e *AROEnvironment) EnvironmentCredentialOptions() *azidentity.EnvironmentCredentialOptions {
 	return &azidentity.EnvironmentCredentialOptions{
-		ClientOptions: azcore.ClientOptions{
-			Cloud: e.Cloud,
-		},
+		ClientOptions: e.clientOptions(),
 	}
 }
 
 func (e *AROEnvironment) ManagedIdentityCredentialOptions() *azidentity.ManagedIdentityCredentialOptions {
 	return &azidentity.ManagedIdentityCredentialOptions{
-		ClientOptions: azcore.ClientOptions{
-			Cloud: e.Cloud,
-		},
+		ClientOptions: e.clientOptions(),
 	}
 }
 
@@ -4,18 +4,48 @@ package azureclient
 // Licensed under the Apache License 2.0.
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/go-autorest/autorest/azure"
 
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
+const customEnvironment = `{
+	"name": "AzureStackCloud",
+	"resourceManagerEndpoint": "https://management.local.azurestack.external/",
+	"activeDirectoryEndpoint": "https://login.local.azurestack.external/",
+	"microsoftGraphEndpoint": "https://graph.local.azurestack.external/",
+	"keyVaultDNSSuffix": "vault.local.azurestack.external",
+	"storageEndpointSuffix": "local.azurestack.external",
+	"containerRegistryDNSSuffix": "azurecr.local.azurestack.external",
+	"cosmosDBDNSSuffix": "documents.local.azurestack.external",
+	"resourceIdentifiers": {
+		"keyVault": "https://vault.local.azurestack.external"
+	},
+	"genevaMonitoringEndpoint": "https://gcs.local.azurestack.external/",
+	"appSuffix": "aro.local.azurestack.external"
+}`
+
+func writeEnvironmentFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "environment.json")
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
 func TestEnvironmentFromName(t *testing.T) {
 	for _, tt := range []struct {
-		name    string
-		wantErr string
-		azEnv   string
+		name     string
+		wantErr  string
+		azEnv    string
+		filepath string
 	}{
 		{
 			name:    "fail: invalid az environment",
@@ -30,11 +60,124 @@ func TestEnvironmentFromName(t *testing.T) {
 			name:  "pass: US government cloud",
 			azEnv: azure.USGovernmentCloud.Name,
 		},
+		{
+			name:  "pass: China cloud",
+			azEnv: azure.ChinaCloud.Name,
+		},
+		{
+			name:     "pass: custom cloud",
+			azEnv:    "AzureStackCloud",
+			filepath: writeEnvironmentFile(t, customEnvironment),
+		},
+		{
+			name:    "fail: custom cloud without environment file",
+			azEnv:   "AzureStackCloud",
+			wantErr: `environment variable "AZURE_ENVIRONMENT_FILEPATH" unset`,
+		},
+		{
+			name:     "fail: custom cloud with incomplete environment file",
+			azEnv:    "AzureStackCloud",
+			filepath: writeEnvironmentFile(t, `{"name": "AzureStackCloud", "resourceManagerEndpoint": "https://management.local.azurestack.external/"}`),
+			wantErr:  "cloud environment is missing activeDirectoryEndpoint, microsoftGraphEndpoint, keyVaultDNSSuffix, storageEndpointSuffix, containerRegistryDNSSuffix, cosmosDBDNSSuffix, resourceIdentifiers.keyVault",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(azure.EnvironmentFilepathName, tt.filepath)
+
 			_, err := EnvironmentFromName(tt.azEnv)
 
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
 		})
 	}
 }
+
+func TestEnvironmentFromFile(t *testing.T) {
+	e, err := EnvironmentFromFile(writeEnvironmentFile(t, customEnvironment))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.ActualCloudName != "AzureStackCloud" {
+		t.Error(e.ActualCloudName)
+	}
+	if e.GenevaMonitoringEndpoint != "https://gcs.local.azurestack.external/" {
+		t.Error(e.GenevaMonitoringEndpoint)
+	}
+	if e.AppSuffix != "aro.local.azurestack.external" {
+		t.Error(e.AppSuffix)
+	}
+	if e.Cloud.Services[cloud.ResourceManager].Audience != e.ResourceManagerEndpoint {
+		t.Error(e.Cloud.Services[cloud.ResourceManager].Audience)
+	}
+}
+
+// TestEnvironmentConformance checks that every supported cloud environment
+// provides the endpoints the RP needs, and that the go-autorest and azcore
+// views of each cloud agree
+func TestEnvironmentConformance(t *testing.T) {
+	custom, err := EnvironmentFromFile(writeEnvironmentFile(t, customEnvironment))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range []AROEnvironment{
+		PublicCloud,
+		USGovernmentCloud,
+		ChinaCloud,
+		custom,
+	} {
+		t.Run(e.Name, func(t *testing.T) {
+			err := e.validate()
+			if err != nil {
+				t.Error(err)
+			}
+
+			if e.ActualCloudName == "" {
+				t.Error("ActualCloudName unset")
+			}
+			if e.GenevaMonitoringEndpoint == "" {
+				t.Error("GenevaMonitoringEndpoint unset")
+			}
+			if e.AppSuffix == "" {
+				t.Error("AppSuffix unset")
+			}
+
+			if e.Cloud.ActiveDirectoryAuthorityHost != e.ActiveDirectoryEndpoint {
+				t.Errorf("azcore authority host %q does not match %q", e.Cloud.ActiveDirectoryAuthorityHost, e.ActiveDirectoryEndpoint)
+			}
+			if rm, ok := e.Cloud.Services[cloud.ResourceManager]; ok &&
+				strings.TrimSuffix(rm.Endpoint, "/") != strings.TrimSuffix(e.ResourceManagerEndpoint, "/") {
+				t.Errorf("azcore resource manager endpoint %q does not match %q", rm.Endpoint, e.ResourceManagerEndpoint)
+			}
+
+			for _, scope := range []struct {
+				scope    string
+				resource string
+			}{
+				{e.ResourceManagerScope, e.ResourceManagerEndpoint},
+				{e.KeyVaultScope, e.ResourceIdentifiers.KeyVault},
+				{e.MicrosoftGraphScope, e.MicrosoftGraphEndpoint},
+			} {
+				if scope.scope != scope.resource+"/.default" {
+					t.Errorf("scope %q is not derived from %q", scope.scope, scope.resource)
+				}
+			}
+
+			if e.Endpoint != "" && !strings.Contains(e.Endpoint, "%s") {
+				t.Errorf("RBAC PDP endpoint %q has no placeholder for the location", e.Endpoint)
+			}
+
+			named, err := EnvironmentFromName(e.Name)
+			if e.Name == custom.Name {
+				// custom clouds are only resolvable by name with AZURE_ENVIRONMENT_FILEPATH set
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if named.Name != e.Name {
+				t.Error(named.Name)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 // BaseClientAddons contains addons for BaseClient
 type BaseClientAddons interface {
+	GetCertificateVersions(ctx context.Context, vaultBaseURL string, certificateName string, maxresults *int32) (certificates []azkeyvault.CertificateItem, err error)
 	GetSecrets(ctx context.Context, vaultBaseURL string, maxresults *int32) (secrets []azkeyvault.SecretItem, err error)
 	GetSecretVersions(ctx context.Context, vaultBaseURL string, secretName string, maxresults *int32) (result []azkeyvault.SecretItem, err error)
 }
@@ -50,3 +51,21 @@ func (c *baseClient) GetSecretVersions(ctx context.Context, vaultBaseURL string,
 
 	return secrets, nil
 }
+
+func (c *baseClient) GetCertificateVersions(ctx context.Context, vaultBaseURL string, certificateName string, maxresults *int32) (certificates []azkeyvault.CertificateItem, err error) {
+	page, err := c.BaseClient.GetCertificateVersions(ctx, vaultBaseURL, certificateName, maxresults)
+	if err != nil {
+		return nil, err
+	}
+
+	for page.NotDone() {
+		certificates = append(certificates, page.Values()...)
+
+		err = page.NextWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return certificates, nil
+}
@@ -0,0 +1,70 @@
+package compute
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+)
+
+// RunCommandOutput is the result of a script executed by a managed run
+// command
+type RunCommandOutput struct {
+	ExitCode int32
+	Stdout   string
+	Stderr   string
+}
+
+// runCommandWithOutput creates a managed run command with create, waits for
+// the script to finish, reads its output with get and finally removes the run
+// command with del.  Unlike the action-based RunCommand API, managed run
+// commands report the exit code and output streams of the script.  An error is
+// returned alongside the output if the script did not succeed.
+func runCommandWithOutput(ctx context.Context, runCommand mgmtcompute.VirtualMachineRunCommand,
+	create func(mgmtcompute.VirtualMachineRunCommand) error,
+	get func() (mgmtcompute.VirtualMachineRunCommand, error),
+	del func() error) (output *RunCommandOutput, err error) {
+	if runCommand.VirtualMachineRunCommandProperties == nil {
+		runCommand.VirtualMachineRunCommandProperties = &mgmtcompute.VirtualMachineRunCommandProperties{}
+	}
+	// the script must have completed when create returns
+	runCommand.AsyncExecution = to.BoolPtr(false)
+
+	err = create(runCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		delErr := del()
+		if err == nil {
+			err = delErr
+		}
+	}()
+
+	rc, err := get()
+	if err != nil {
+		return nil, err
+	}
+
+	if rc.VirtualMachineRunCommandProperties == nil || rc.InstanceView == nil {
+		return nil, fmt.Errorf("run command returned no instance view")
+	}
+
+	iv := rc.InstanceView
+	output = &RunCommandOutput{
+		ExitCode: to.Int32(iv.ExitCode),
+		Stdout:   to.String(iv.Output),
+		Stderr:   to.String(iv.Error),
+	}
+
+	if iv.ExecutionState != mgmtcompute.ExecutionStateSucceeded {
+		return output, fmt.Errorf("run command finished in state %q with exit code %d: %s", iv.ExecutionState, output.ExitCode, to.String(iv.ExecutionMessage))
+	}
+
+	return output, nil
+}
@@ -21,6 +21,7 @@ type VirtualMachinesClient interface {
 
 type virtualMachinesClient struct {
 	mgmtcompute.VirtualMachinesClient
+	runCommands mgmtcompute.VirtualMachineRunCommandsClient
 }
 
 var _ VirtualMachinesClient = &virtualMachinesClient{}
@@ -31,7 +32,12 @@ func NewVirtualMachinesClient(environment *azureclient.AROEnvironment, subscript
 	client.Authorizer = authorizer
 	client.PollingDuration = 30 * time.Minute
 
+	runCommands := mgmtcompute.NewVirtualMachineRunCommandsClientWithBaseURI(environment.ResourceManagerEndpoint, subscriptionID)
+	runCommands.Authorizer = authorizer
+	runCommands.PollingDuration = 30 * time.Minute
+
 	return &virtualMachinesClient{
 		VirtualMachinesClient: client,
+		runCommands:           runCommands,
 	}
 }
@@ -18,6 +18,8 @@ type VirtualMachinesClientAddons interface {
 	StartAndWait(ctx context.Context, resourceGroupName string, VMName string) error
 	StopAndWait(ctx context.Context, resourceGroupName string, VMName string, deallocateVM bool) error
 	List(ctx context.Context, resourceGroupName string) (result []mgmtcompute.VirtualMachine, err error)
+	ListAll(ctx context.Context, statusOnly string) (result []mgmtcompute.VirtualMachine, err error)
+	RunCommandWithOutput(ctx context.Context, resourceGroupName string, VMName string, runCommandName string, runCommand mgmtcompute.VirtualMachineRunCommand) (*RunCommandOutput, error)
 }
 
 func (c *virtualMachinesClient) CreateOrUpdateAndWait(ctx context.Context, resourceGroupName string, VMName string, parameters mgmtcompute.VirtualMachine) error {
@@ -95,3 +97,45 @@ func (c *virtualMachinesClient) List(ctx context.Context, resourceGroupName stri
 
 	return result, nil
 }
+
+func (c *virtualMachinesClient) ListAll(ctx context.Context, statusOnly string) (result []mgmtcompute.VirtualMachine, err error) {
+	page, err := c.VirtualMachinesClient.ListAll(ctx, statusOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	for page.NotDone() {
+		result = append(result, page.Values()...)
+
+		err = page.NextWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (c *virtualMachinesClient) RunCommandWithOutput(ctx context.Context, resourceGroupName string, VMName string, runCommandName string, runCommand mgmtcompute.VirtualMachineRunCommand) (*RunCommandOutput, error) {
+	return runCommandWithOutput(ctx, runCommand,
+		func(runCommand mgmtcompute.VirtualMachineRunCommand) error {
+			future, err := c.runCommands.CreateOrUpdate(ctx, resourceGroupName, VMName, runCommandName, runCommand)
+			if err != nil {
+				return err
+			}
+
+			return future.WaitForCompletionRef(ctx, c.runCommands.Client)
+		},
+		func() (mgmtcompute.VirtualMachineRunCommand, error) {
+			return c.runCommands.GetByVirtualMachine(ctx, resourceGroupName, VMName, runCommandName, "instanceView")
+		},
+		func() error {
+			future, err := c.runCommands.Delete(ctx, resourceGroupName, VMName, runCommandName)
+			if err != nil {
+				return err
+			}
+
+			return future.WaitForCompletionRef(ctx, c.runCommands.Client)
+		},
+	)
+}
@@ -21,6 +21,7 @@ type VirtualMachineScaleSetVMsClient interface {
 
 type virtualMachineScaleSetVMsClient struct {
 	mgmtcompute.VirtualMachineScaleSetVMsClient
+	runCommands mgmtcompute.VirtualMachineScaleSetVMRunCommandsClient
 }
 
 var _ VirtualMachineScaleSetVMsClient = &virtualMachineScaleSetVMsClient{}
@@ -31,7 +32,12 @@ func NewVirtualMachineScaleSetVMsClient(environment *azureclient.AROEnvironment,
 	client.Authorizer = authorizer
 	client.PollingDuration = time.Hour
 
+	runCommands := mgmtcompute.NewVirtualMachineScaleSetVMRunCommandsClientWithBaseURI(environment.ResourceManagerEndpoint, subscriptionID)
+	runCommands.Authorizer = authorizer
+	runCommands.PollingDuration = time.Hour
+
 	return &virtualMachineScaleSetVMsClient{
 		VirtualMachineScaleSetVMsClient: client,
+		runCommands:                     runCommands,
 	}
 }
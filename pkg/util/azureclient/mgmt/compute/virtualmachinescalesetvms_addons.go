@@ -12,6 +12,7 @@ import (
 type VirtualMachineScaleSetVMsClientAddons interface {
 	List(ctx context.Context, resourceGroupName string, virtualMachineScaleSetName string, filter string, selectParameter string, expand string) ([]mgmtcompute.VirtualMachineScaleSetVM, error)
 	RunCommandAndWait(ctx context.Context, resourceGroupName string, VMScaleSetName string, instanceID string, parameters mgmtcompute.RunCommandInput) error
+	RunCommandWithOutput(ctx context.Context, resourceGroupName string, VMScaleSetName string, instanceID string, runCommandName string, runCommand mgmtcompute.VirtualMachineRunCommand) (*RunCommandOutput, error)
 }
 
 func (c *virtualMachineScaleSetVMsClient) RunCommandAndWait(ctx context.Context, resourceGroupName string, VMScaleSetName string, instanceID string, parameters mgmtcompute.RunCommandInput) error {
@@ -23,6 +24,30 @@ func (c *virtualMachineScaleSetVMsClient) RunCommandAndWait(ctx context.Context,
 	return future.WaitForCompletionRef(ctx, c.VirtualMachineScaleSetVMsClient.Client)
 }
 
+func (c *virtualMachineScaleSetVMsClient) RunCommandWithOutput(ctx context.Context, resourceGroupName string, VMScaleSetName string, instanceID string, runCommandName string, runCommand mgmtcompute.VirtualMachineRunCommand) (*RunCommandOutput, error) {
+	return runCommandWithOutput(ctx, runCommand,
+		func(runCommand mgmtcompute.VirtualMachineRunCommand) error {
+			future, err := c.runCommands.CreateOrUpdate(ctx, resourceGroupName, VMScaleSetName, instanceID, runCommandName, runCommand)
+			if err != nil {
+				return err
+			}
+
+			return future.WaitForCompletionRef(ctx, c.runCommands.Client)
+		},
+		func() (mgmtcompute.VirtualMachineRunCommand, error) {
+			return c.runCommands.Get(ctx, resourceGroupName, VMScaleSetName, instanceID, runCommandName, "instanceView")
+		},
+		func() error {
+			future, err := c.runCommands.Delete(ctx, resourceGroupName, VMScaleSetName, instanceID, runCommandName)
+			if err != nil {
+				return err
+			}
+
+			return future.WaitForCompletionRef(ctx, c.runCommands.Client)
+		},
+	)
+}
+
 func (c *virtualMachineScaleSetVMsClient) List(ctx context.Context, resourceGroupName string, virtualMachineScaleSetName string, filter string, selectParameter string, expand string) ([]mgmtcompute.VirtualMachineScaleSetVM, error) {
 	var scaleSetsVMs []mgmtcompute.VirtualMachineScaleSetVM
 	result, err := c.VirtualMachineScaleSetVMsClient.List(ctx, resourceGroupName, virtualMachineScaleSetName, filter, selectParameter, expand)
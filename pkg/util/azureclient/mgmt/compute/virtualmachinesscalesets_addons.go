@@ -12,6 +12,8 @@ import (
 type VirtualMachineScaleSetsClientAddons interface {
 	List(ctx context.Context, resourceGroupName string) ([]mgmtcompute.VirtualMachineScaleSet, error)
 	DeleteAndWait(ctx context.Context, resourceGroupName, vmScaleSetName string) error
+	UpdateAndWait(ctx context.Context, resourceGroupName, vmScaleSetName string, parameters mgmtcompute.VirtualMachineScaleSetUpdate) error
+	UpdateInstancesAndWait(ctx context.Context, resourceGroupName, vmScaleSetName string, instanceIDs []string) error
 }
 
 func (c *virtualMachineScaleSetsClient) DeleteAndWait(ctx context.Context, resourceGroupName string, vmScaleSetName string) error {
@@ -23,6 +25,28 @@ func (c *virtualMachineScaleSetsClient) DeleteAndWait(ctx context.Context, resou
 	return future.WaitForCompletionRef(ctx, c.VirtualMachineScaleSetsClient.Client)
 }
 
+func (c *virtualMachineScaleSetsClient) UpdateAndWait(ctx context.Context, resourceGroupName string, vmScaleSetName string, parameters mgmtcompute.VirtualMachineScaleSetUpdate) error {
+	future, err := c.VirtualMachineScaleSetsClient.Update(ctx, resourceGroupName, vmScaleSetName, parameters)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, c.VirtualMachineScaleSetsClient.Client)
+}
+
+// UpdateInstancesAndWait upgrades the given instances to the latest model of
+// the scaleset
+func (c *virtualMachineScaleSetsClient) UpdateInstancesAndWait(ctx context.Context, resourceGroupName string, vmScaleSetName string, instanceIDs []string) error {
+	future, err := c.VirtualMachineScaleSetsClient.UpdateInstances(ctx, resourceGroupName, vmScaleSetName, mgmtcompute.VirtualMachineScaleSetVMInstanceRequiredIDs{
+		InstanceIds: &instanceIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, c.VirtualMachineScaleSetsClient.Client)
+}
+
 func (c *virtualMachineScaleSetsClient) List(ctx context.Context, resourceGroupName string) ([]mgmtcompute.VirtualMachineScaleSet, error) {
 	var scaleSets []mgmtcompute.VirtualMachineScaleSet
 	result, err := c.VirtualMachineScaleSetsClient.List(ctx, resourceGroupName)
@@ -0,0 +1,56 @@
+package insights
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	mgmtinsights "github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+)
+
+// DiagnosticSettingsClient is a minimal interface for azure DiagnosticSettingsClient
+type DiagnosticSettingsClient interface {
+	CreateOrUpdate(ctx context.Context, resourceURI string, parameters mgmtinsights.DiagnosticSettingsResource, name string) (result mgmtinsights.DiagnosticSettingsResource, err error)
+	Delete(ctx context.Context, resourceURI string, name string) (result autorest.Response, err error)
+}
+
+type diagnosticSettingsClient struct {
+	mgmtinsights.DiagnosticSettingsClient
+}
+
+var _ DiagnosticSettingsClient = &diagnosticSettingsClient{}
+
+// NewDiagnosticSettingsClient creates a new DiagnosticSettingsClient
+func NewDiagnosticSettingsClient(environment *azureclient.AROEnvironment, subscriptionID string, authorizer autorest.Authorizer) DiagnosticSettingsClient {
+	client := mgmtinsights.NewDiagnosticSettingsClientWithBaseURI(environment.ResourceManagerEndpoint, subscriptionID)
+	client.Authorizer = authorizer
+
+	return &diagnosticSettingsClient{
+		DiagnosticSettingsClient: client,
+	}
+}
+
+// DiagnosticSettingsCategoryClient is a minimal interface for azure DiagnosticSettingsCategoryClient
+type DiagnosticSettingsCategoryClient interface {
+	List(ctx context.Context, resourceURI string) (result mgmtinsights.DiagnosticSettingsCategoryResourceCollection, err error)
+}
+
+type diagnosticSettingsCategoryClient struct {
+	mgmtinsights.DiagnosticSettingsCategoryClient
+}
+
+var _ DiagnosticSettingsCategoryClient = &diagnosticSettingsCategoryClient{}
+
+// NewDiagnosticSettingsCategoryClient creates a new DiagnosticSettingsCategoryClient
+func NewDiagnosticSettingsCategoryClient(environment *azureclient.AROEnvironment, subscriptionID string, authorizer autorest.Authorizer) DiagnosticSettingsCategoryClient {
+	client := mgmtinsights.NewDiagnosticSettingsCategoryClientWithBaseURI(environment.ResourceManagerEndpoint, subscriptionID)
+	client.Authorizer = authorizer
+
+	return &diagnosticSettingsCategoryClient{
+		DiagnosticSettingsCategoryClient: client,
+	}
+}
@@ -0,0 +1,34 @@
+package insights
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	mgmtinsights "github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+)
+
+// MetricsClient is a minimal interface for azure MetricsClient
+type MetricsClient interface {
+	List(ctx context.Context, resourceURI string, timespan string, interval *string, metricnames string, aggregation string, top *int32, orderby string, filter string, resultType mgmtinsights.ResultType, metricnamespace string) (result mgmtinsights.Response, err error)
+}
+
+type metricsClient struct {
+	mgmtinsights.MetricsClient
+}
+
+var _ MetricsClient = &metricsClient{}
+
+// NewMetricsClient creates a new MetricsClient
+func NewMetricsClient(environment *azureclient.AROEnvironment, subscriptionID string, authorizer autorest.Authorizer) MetricsClient {
+	client := mgmtinsights.NewMetricsClientWithBaseURI(environment.ResourceManagerEndpoint, subscriptionID)
+	client.Authorizer = authorizer
+
+	return &metricsClient{
+		MetricsClient: client,
+	}
+}
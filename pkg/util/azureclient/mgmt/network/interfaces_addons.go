@@ -13,6 +13,7 @@ import (
 type InterfacesClientAddons interface {
 	CreateOrUpdateAndWait(ctx context.Context, resourceGroupName string, networkInterfaceName string, parameters mgmtnetwork.Interface) (err error)
 	DeleteAndWait(ctx context.Context, resourceGroupName string, networkInterfaceName string) (err error)
+	ListVirtualMachineScaleSetNetworkInterfaces(ctx context.Context, resourceGroupName string, virtualMachineScaleSetName string) (result []mgmtnetwork.Interface, err error)
 }
 
 func (c *interfacesClient) CreateOrUpdateAndWait(ctx context.Context, resourceGroupName string, networkInterfaceName string, parameters mgmtnetwork.Interface) error {
@@ -32,3 +33,20 @@ func (c *interfacesClient) DeleteAndWait(ctx context.Context, resourceGroupName
 
 	return future.WaitForCompletionRef(ctx, c.Client)
 }
+
+func (c *interfacesClient) ListVirtualMachineScaleSetNetworkInterfaces(ctx context.Context, resourceGroupName string, virtualMachineScaleSetName string) (result []mgmtnetwork.Interface, err error) {
+	page, err := c.InterfacesClient.ListVirtualMachineScaleSetNetworkInterfaces(ctx, resourceGroupName, virtualMachineScaleSetName)
+	if err != nil {
+		return nil, err
+	}
+
+	for page.NotDone() {
+		result = append(result, page.Values()...)
+
+		err = page.NextWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -13,6 +13,7 @@ import (
 type PrivateEndpointsClientAddons interface {
 	CreateOrUpdateAndWait(ctx context.Context, resourceGroupName string, privateEndpointName string, parameters mgmtnetwork.PrivateEndpoint) (err error)
 	DeleteAndWait(ctx context.Context, resourceGroupName string, publicIPAddressName string) (err error)
+	List(ctx context.Context, resourceGroupName string) (privateendpoints []mgmtnetwork.PrivateEndpoint, err error)
 }
 
 func (c *privateEndpointsClient) CreateOrUpdateAndWait(ctx context.Context, resourceGroupName string, privateEndpointName string, parameters mgmtnetwork.PrivateEndpoint) error {
@@ -32,3 +33,21 @@ func (c *privateEndpointsClient) DeleteAndWait(ctx context.Context, resourceGrou
 
 	return future.WaitForCompletionRef(ctx, c.Client)
 }
+
+func (c *privateEndpointsClient) List(ctx context.Context, resourceGroupName string) (privateendpoints []mgmtnetwork.PrivateEndpoint, err error) {
+	page, err := c.PrivateEndpointsClient.List(ctx, resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	for page.NotDone() {
+		privateendpoints = append(privateendpoints, page.Values()...)
+
+		err = page.NextWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return privateendpoints, nil
+}
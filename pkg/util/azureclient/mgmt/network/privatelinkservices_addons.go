@@ -5,13 +5,20 @@ package network
 
 import (
 	"context"
+	"strings"
 
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
 )
 
 // PrivateLinkServicesClientAddons contains addons for PrivateLinkServicesClient
 type PrivateLinkServicesClientAddons interface {
 	List(ctx context.Context, resourceGroupName string) (privatelinkservices []mgmtnetwork.PrivateLinkService, err error)
+	ListPrivateEndpointConnections(ctx context.Context, resourceGroupName string, serviceName string) (peconns []mgmtnetwork.PrivateEndpointConnection, err error)
+	ApprovePrivateEndpointConnection(ctx context.Context, resourceGroupName string, serviceName string, peConnectionName string, description string) error
+	RejectPrivateEndpointConnection(ctx context.Context, resourceGroupName string, serviceName string, peConnectionName string, description string) error
+	DeletePrivateEndpointConnectionAndWait(ctx context.Context, resourceGroupName string, serviceName string, peConnectionName string) error
+	SetVisibilityAndWait(ctx context.Context, resourceGroupName string, serviceName string, subscriptions []string) error
 }
 
 func (c *privateLinkServicesClient) List(ctx context.Context, resourceGroupName string) (privatelinkservices []mgmtnetwork.PrivateLinkService, err error) {
@@ -31,3 +38,88 @@ func (c *privateLinkServicesClient) List(ctx context.Context, resourceGroupName
 
 	return privatelinkservices, nil
 }
+
+func (c *privateLinkServicesClient) ListPrivateEndpointConnections(ctx context.Context, resourceGroupName string, serviceName string) (peconns []mgmtnetwork.PrivateEndpointConnection, err error) {
+	page, err := c.PrivateLinkServicesClient.ListPrivateEndpointConnections(ctx, resourceGroupName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	for page.NotDone() {
+		peconns = append(peconns, page.Values()...)
+
+		err = page.NextWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return peconns, nil
+}
+
+func (c *privateLinkServicesClient) ApprovePrivateEndpointConnection(ctx context.Context, resourceGroupName string, serviceName string, peConnectionName string, description string) error {
+	return c.setPrivateEndpointConnectionStatus(ctx, resourceGroupName, serviceName, peConnectionName, "Approved", description)
+}
+
+func (c *privateLinkServicesClient) RejectPrivateEndpointConnection(ctx context.Context, resourceGroupName string, serviceName string, peConnectionName string, description string) error {
+	return c.setPrivateEndpointConnectionStatus(ctx, resourceGroupName, serviceName, peConnectionName, "Rejected", description)
+}
+
+// setPrivateEndpointConnectionStatus updates the connection state of a
+// private endpoint connection, unless it already has the given status
+func (c *privateLinkServicesClient) setPrivateEndpointConnectionStatus(ctx context.Context, resourceGroupName string, serviceName string, peConnectionName string, status string, description string) error {
+	conn, err := c.GetPrivateEndpointConnection(ctx, resourceGroupName, serviceName, peConnectionName, "")
+	if err != nil {
+		return err
+	}
+
+	if conn.PrivateEndpointConnectionProperties == nil {
+		conn.PrivateEndpointConnectionProperties = &mgmtnetwork.PrivateEndpointConnectionProperties{}
+	}
+	if conn.PrivateLinkServiceConnectionState == nil {
+		conn.PrivateLinkServiceConnectionState = &mgmtnetwork.PrivateLinkServiceConnectionState{}
+	}
+
+	if strings.EqualFold(to.String(conn.PrivateLinkServiceConnectionState.Status), status) {
+		return nil
+	}
+
+	conn.PrivateLinkServiceConnectionState.Status = to.StringPtr(status)
+	conn.PrivateLinkServiceConnectionState.Description = to.StringPtr(description)
+
+	_, err = c.UpdatePrivateEndpointConnection(ctx, resourceGroupName, serviceName, peConnectionName, conn)
+	return err
+}
+
+func (c *privateLinkServicesClient) DeletePrivateEndpointConnectionAndWait(ctx context.Context, resourceGroupName string, serviceName string, peConnectionName string) error {
+	future, err := c.PrivateLinkServicesClient.DeletePrivateEndpointConnection(ctx, resourceGroupName, serviceName, peConnectionName)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, c.Client)
+}
+
+// SetVisibilityAndWait replaces the list of subscriptions which can see, and
+// so create private endpoints to, the private link service
+func (c *privateLinkServicesClient) SetVisibilityAndWait(ctx context.Context, resourceGroupName string, serviceName string, subscriptions []string) error {
+	pls, err := c.Get(ctx, resourceGroupName, serviceName, "")
+	if err != nil {
+		return err
+	}
+
+	if pls.PrivateLinkServiceProperties == nil {
+		pls.PrivateLinkServiceProperties = &mgmtnetwork.PrivateLinkServiceProperties{}
+	}
+
+	pls.Visibility = &mgmtnetwork.PrivateLinkServicePropertiesVisibility{
+		Subscriptions: &subscriptions,
+	}
+
+	future, err := c.CreateOrUpdate(ctx, resourceGroupName, serviceName, pls)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, c.Client)
+}
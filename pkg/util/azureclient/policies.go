@@ -0,0 +1,68 @@
+package azureclient
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+var (
+	optionsMu       sync.RWMutex
+	perCallPolicies []policy.Policy
+
+	// retryOptions is left zero, i.e. the azure-sdk-for-go/sdk defaults,
+	// unless overridden by SetRetryOptions
+	retryOptions policy.RetryOptions
+)
+
+// RegisterPerCallPolicy adds p to the pipeline of every azure-sdk-for-go/sdk
+// client subsequently created with options from AROEnvironment, e.g. to emit
+// dependency metrics.  It is the counterpart of go-autorest's
+// tracing.Register and should be called at startup.
+func RegisterPerCallPolicy(p policy.Policy) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+
+	perCallPolicies = append(perCallPolicies, p)
+}
+
+// SetRetryOptions overrides the retry and backoff behaviour of every
+// azure-sdk-for-go/sdk/resourcemanager client subsequently created with
+// options from AROEnvironment.ArmClientOptions.  It should be called at
+// startup.
+func SetRetryOptions(o policy.RetryOptions) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+
+	retryOptions = o
+}
+
+// clientOptions returns the azcore.ClientOptions shared by all clients
+func (e *AROEnvironment) clientOptions() azcore.ClientOptions {
+	optionsMu.RLock()
+	defer optionsMu.RUnlock()
+
+	return azcore.ClientOptions{
+		Cloud:           e.Cloud,
+		PerCallPolicies: append([]policy.Policy{traceContextPolicy{}}, perCallPolicies...),
+	}
+}
+
+// ArmClientOptions returns the arm.ClientOptions to use for
+// azure-sdk-for-go/sdk/resourcemanager clients
+func (e *AROEnvironment) ArmClientOptions() *arm.ClientOptions {
+	o := e.clientOptions()
+
+	optionsMu.RLock()
+	o.Retry = retryOptions
+	optionsMu.RUnlock()
+
+	return &arm.ClientOptions{
+		ClientOptions: o,
+	}
+}
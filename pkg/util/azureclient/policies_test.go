@@ -0,0 +1,57 @@
+package azureclient
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type testPolicy struct{}
+
+func (testPolicy) Do(req *policy.Request) (*http.Response, error) {
+	return req.Next()
+}
+
+func TestRegisterPerCallPolicy(t *testing.T) {
+	defer func() { perCallPolicies = nil }()
+
+	// the trace context policy is always present
+	before := PublicCloud.ManagedIdentityCredentialOptions()
+	if len(before.PerCallPolicies) != 1 {
+		t.Fatal(before.PerCallPolicies)
+	}
+
+	RegisterPerCallPolicy(testPolicy{})
+
+	after := PublicCloud.ManagedIdentityCredentialOptions()
+	if len(after.PerCallPolicies) != 2 {
+		t.Error(after.PerCallPolicies)
+	}
+	if after.Cloud.ActiveDirectoryAuthorityHost != PublicCloud.Cloud.ActiveDirectoryAuthorityHost {
+		t.Error(after.Cloud)
+	}
+
+	// options returned earlier are not affected
+	if len(before.PerCallPolicies) != 1 {
+		t.Error(before.PerCallPolicies)
+	}
+}
+
+func TestSetRetryOptions(t *testing.T) {
+	defer func() { retryOptions = policy.RetryOptions{} }()
+
+	SetRetryOptions(policy.RetryOptions{MaxRetries: 5})
+
+	if got := PublicCloud.ArmClientOptions().Retry.MaxRetries; got != 5 {
+		t.Errorf("got %d retries", got)
+	}
+
+	// credentials keep the azure-sdk-for-go/sdk defaults
+	if got := PublicCloud.ManagedIdentityCredentialOptions().Retry.MaxRetries; got != 0 {
+		t.Errorf("got %d retries", got)
+	}
+}
@@ -0,0 +1,77 @@
+package throttle
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+var _ policy.Policy = (*throttlePolicy)(nil)
+
+type throttlePolicy struct {
+	mgr *Manager
+}
+
+// Policy returns a per-call policy which throttles azure-sdk-for-go/sdk
+// clients.  Register it with azureclient.RegisterPerCallPolicy.
+func (mgr *Manager) Policy() policy.Policy {
+	return &throttlePolicy{mgr: mgr}
+}
+
+func (p *throttlePolicy) Do(req *policy.Request) (*http.Response, error) {
+	err := p.mgr.Wait(req.Raw().Context(), req.Raw())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Next()
+	p.mgr.Observe(req.Raw(), resp)
+
+	return resp, err
+}
+
+type transport struct {
+	mgr  *Manager
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	err := t.mgr.Wait(req.Context(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	t.mgr.Observe(req, resp)
+
+	return resp, err
+}
+
+var _ tracing.Tracer = (*tracer)(nil)
+
+// tracer wraps a go-autorest tracer, adding the throttle to the transport of
+// go-autorest's default sender, which all go-autorest clients share
+type tracer struct {
+	tracing.Tracer
+	mgr *Manager
+}
+
+// Tracer returns t with the throttle added to its transport.  Register the
+// result with tracing.Register.
+func (mgr *Manager) Tracer(t tracing.Tracer) tracing.Tracer {
+	return &tracer{
+		Tracer: t,
+		mgr:    mgr,
+	}
+}
+
+func (t *tracer) NewTransport(base *http.Transport) http.RoundTripper {
+	return &transport{
+		mgr:  t.mgr,
+		base: t.Tracer.NewTransport(base),
+	}
+}
@@ -0,0 +1,194 @@
+package throttle
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/Azure/ARO-RP/pkg/metrics"
+)
+
+type kind string
+
+const (
+	kindReads   kind = "reads"
+	kindWrites  kind = "writes"
+	kindDeletes kind = "deletes"
+)
+
+// budgets are ARM's per-subscription, per-hour request limits.  Requests made
+// by the RP on behalf of a cluster all count against the customer's
+// subscription, so a mass adminUpdate can exhaust them quickly.
+var budgets = map[kind]int{
+	kindReads:   12000,
+	kindWrites:  1200,
+	kindDeletes: 15000,
+}
+
+const (
+	// burstFraction is the fraction of the hourly budget which may be spent
+	// at once before requests are spread out over the hour
+	burstFraction = 10
+
+	// lowBudgetFraction: once ARM reports fewer than 1/lowBudgetFraction of
+	// the hourly budget remaining, requests of that kind are paused for
+	// lowBudgetBackoff to let the budget refill
+	lowBudgetFraction = 20
+	lowBudgetBackoff  = time.Minute
+
+	// defaultRetryAfter is the pause after a 429 response without a usable
+	// Retry-After header
+	defaultRetryAfter = time.Minute
+)
+
+type budget struct {
+	limiter *rate.Limiter
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// Manager is a client-side throttle shared by all Azure clients in a process.
+// It spreads requests to each subscription over the hour so that they stay
+// within ARM's limits, and backs off when ARM reports that the remaining
+// budget is low or throttles a request.
+type Manager struct {
+	m   metrics.Emitter
+	now func() time.Time
+
+	mu      sync.Mutex
+	budgets map[string]*budget
+}
+
+func NewManager(m metrics.Emitter) *Manager {
+	return &Manager{
+		m:       m,
+		now:     time.Now,
+		budgets: map[string]*budget{},
+	}
+}
+
+// classify returns the subscription and kind of ARM request req, or an empty
+// subscription if req is not made against a subscription.
+func classify(req *http.Request) (string, kind) {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(parts) < 2 || !strings.EqualFold(parts[0], "subscriptions") {
+		return "", ""
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return strings.ToLower(parts[1]), kindReads
+	case http.MethodDelete:
+		return strings.ToLower(parts[1]), kindDeletes
+	default:
+		return strings.ToLower(parts[1]), kindWrites
+	}
+}
+
+func (mgr *Manager) budget(subscriptionID string, k kind) *budget {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	key := subscriptionID + "/" + string(k)
+
+	b := mgr.budgets[key]
+	if b == nil {
+		b = &budget{
+			limiter: rate.NewLimiter(rate.Limit(float64(budgets[k])/time.Hour.Seconds()), budgets[k]/burstFraction),
+		}
+		mgr.budgets[key] = b
+	}
+
+	return b
+}
+
+// Wait blocks until req may be sent without exceeding its subscription's
+// budget, or ctx is done.
+func (mgr *Manager) Wait(ctx context.Context, req *http.Request) error {
+	subscriptionID, k := classify(req)
+	if subscriptionID == "" {
+		return nil
+	}
+
+	b := mgr.budget(subscriptionID, k)
+
+	b.mu.Lock()
+	d := b.pausedUntil.Sub(mgr.now())
+	b.mu.Unlock()
+
+	if d > 0 {
+		mgr.m.EmitGauge("client.azure.ratelimit.delayed", 1, map[string]string{
+			"kind": string(k),
+		})
+
+		t := time.NewTimer(d)
+		defer t.Stop()
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return b.limiter.Wait(ctx)
+}
+
+// Observe records the remaining budget which ARM reports in resp, and pauses
+// further requests of the same kind if it is low or resp is a 429.
+func (mgr *Manager) Observe(req *http.Request, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	subscriptionID, k := classify(req)
+	if subscriptionID == "" {
+		return
+	}
+
+	b := mgr.budget(subscriptionID, k)
+
+	if remaining, err := strconv.Atoi(resp.Header.Get("x-ms-ratelimit-remaining-subscription-" + string(k))); err == nil {
+		mgr.m.EmitGauge("client.azure.ratelimit.remaining", int64(remaining), map[string]string{
+			"kind":           string(k),
+			"subscriptionId": subscriptionID,
+		})
+
+		if remaining < budgets[k]/lowBudgetFraction {
+			b.pause(mgr.now().Add(lowBudgetBackoff))
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		mgr.m.EmitGauge("client.azure.ratelimit.throttled", 1, map[string]string{
+			"kind": string(k),
+		})
+
+		retryAfter := defaultRetryAfter
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+
+		b.pause(mgr.now().Add(retryAfter))
+	}
+}
+
+// pause holds back requests until t, unless they are already held back for
+// longer
+func (b *budget) pause(t time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if t.After(b.pausedUntil) {
+		b.pausedUntil = t
+	}
+}
@@ -0,0 +1,196 @@
+package throttle
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+)
+
+func TestClassify(t *testing.T) {
+	for _, tt := range []struct {
+		method             string
+		url                string
+		wantSubscriptionID string
+		wantKind           kind
+	}{
+		{
+			method:             http.MethodGet,
+			url:                "https://management.azure.com/subscriptions/00000000-0000-0000-0000-00000000000A/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet",
+			wantSubscriptionID: "00000000-0000-0000-0000-00000000000a",
+			wantKind:           kindReads,
+		},
+		{
+			method:             http.MethodPut,
+			url:                "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg",
+			wantSubscriptionID: "00000000-0000-0000-0000-000000000000",
+			wantKind:           kindWrites,
+		},
+		{
+			method:             http.MethodPost,
+			url:                "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm/start",
+			wantSubscriptionID: "00000000-0000-0000-0000-000000000000",
+			wantKind:           kindWrites,
+		},
+		{
+			method:             http.MethodDelete,
+			url:                "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg",
+			wantSubscriptionID: "00000000-0000-0000-0000-000000000000",
+			wantKind:           kindDeletes,
+		},
+		{
+			method: http.MethodGet,
+			url:    "https://management.azure.com/providers/Microsoft.Network/operations",
+		},
+		{
+			method: http.MethodPost,
+			url:    "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+		},
+	} {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+
+			subscriptionID, k := classify(req)
+			if subscriptionID != tt.wantSubscriptionID {
+				t.Error(subscriptionID)
+			}
+			if k != tt.wantKind {
+				t.Error(k)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	url := "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg"
+
+	for _, tt := range []struct {
+		name            string
+		method          string
+		statusCode      int
+		header          http.Header
+		wantPausedUntil time.Time
+	}{
+		{
+			name:       "plenty of budget left",
+			method:     http.MethodPut,
+			statusCode: http.StatusOK,
+			header: http.Header{
+				"X-Ms-Ratelimit-Remaining-Subscription-Writes": []string{"1199"},
+			},
+		},
+		{
+			name:       "budget low",
+			method:     http.MethodPut,
+			statusCode: http.StatusOK,
+			header: http.Header{
+				"X-Ms-Ratelimit-Remaining-Subscription-Writes": []string{"10"},
+			},
+			wantPausedUntil: now.Add(lowBudgetBackoff),
+		},
+		{
+			name:       "read budget header ignored for writes",
+			method:     http.MethodPut,
+			statusCode: http.StatusOK,
+			header: http.Header{
+				"X-Ms-Ratelimit-Remaining-Subscription-Reads": []string{"10"},
+			},
+		},
+		{
+			name:       "throttled with Retry-After",
+			method:     http.MethodGet,
+			statusCode: http.StatusTooManyRequests,
+			header: http.Header{
+				"Retry-After": []string{"17"},
+			},
+			wantPausedUntil: now.Add(17 * time.Second),
+		},
+		{
+			name:            "throttled without Retry-After",
+			method:          http.MethodDelete,
+			statusCode:      http.StatusTooManyRequests,
+			header:          http.Header{},
+			wantPausedUntil: now.Add(defaultRetryAfter),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewManager(&noop.Noop{})
+			mgr.now = func() time.Time { return now }
+
+			req := httptest.NewRequest(tt.method, url, nil)
+			mgr.Observe(req, &http.Response{
+				StatusCode: tt.statusCode,
+				Header:     tt.header,
+			})
+
+			subscriptionID, k := classify(req)
+			b := mgr.budget(subscriptionID, k)
+			if !b.pausedUntil.Equal(tt.wantPausedUntil) {
+				t.Errorf("got paused until %s, wanted %s", b.pausedUntil, tt.wantPausedUntil)
+			}
+		})
+	}
+}
+
+func TestWait(t *testing.T) {
+	mgr := NewManager(&noop.Noop{})
+	url := "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg"
+
+	// a paused subscription blocks until the context is done
+	mgr.Observe(httptest.NewRequest(http.MethodGet, url, nil), &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := mgr.Wait(ctx, httptest.NewRequest(http.MethodGet, url, nil))
+	if err != context.DeadlineExceeded {
+		t.Error(err)
+	}
+
+	// other kinds and subscriptions are not affected
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPut, url, nil),
+		httptest.NewRequest(http.MethodGet, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg", nil),
+	} {
+		err := mgr.Wait(context.Background(), req)
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-ratelimit-remaining-subscription-reads", "1")
+	}))
+	defer ts.Close()
+
+	mgr := NewManager(&noop.Noop{})
+	c := &http.Client{
+		Transport: &transport{
+			mgr:  mgr,
+			base: http.DefaultTransport,
+		},
+	}
+
+	resp, err := c.Get(ts.URL + "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	b := mgr.budget("00000000-0000-0000-0000-000000000000", kindReads)
+	if b.pausedUntil.IsZero() {
+		t.Error("expected reads to be paused")
+	}
+}
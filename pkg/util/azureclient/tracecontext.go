@@ -0,0 +1,59 @@
+package azureclient
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/go-autorest/tracing"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// traceContext propagates the OpenTelemetry span in the context of each
+// request to Azure as W3C Trace Context headers, so that Azure calls made by
+// a step appear in the same distributed trace as the step itself.  Nothing is
+// sent if the context has no sampled span.
+var traceContext = propagation.TraceContext{}
+
+var _ policy.Policy = (*traceContextPolicy)(nil)
+
+type traceContextPolicy struct{}
+
+func (traceContextPolicy) Do(req *policy.Request) (*http.Response, error) {
+	traceContext.Inject(req.Raw().Context(), propagation.HeaderCarrier(req.Raw().Header))
+	return req.Next()
+}
+
+type traceContextTransport struct {
+	base http.RoundTripper
+}
+
+func (t *traceContextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the original request
+	req = req.Clone(req.Context())
+	traceContext.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+
+	return t.base.RoundTrip(req)
+}
+
+var _ tracing.Tracer = (*traceContextTracer)(nil)
+
+type traceContextTracer struct {
+	tracing.Tracer
+}
+
+// NewTraceContextTracer returns t with trace context propagation added to its
+// transport, which all go-autorest clients share.  Register the result with
+// tracing.Register.  azure-sdk-for-go/sdk clients created with options from
+// AROEnvironment propagate trace context already.
+func NewTraceContextTracer(t tracing.Tracer) tracing.Tracer {
+	return &traceContextTracer{Tracer: t}
+}
+
+func (t *traceContextTracer) NewTransport(base *http.Transport) http.RoundTripper {
+	return &traceContextTransport{
+		base: t.Tracer.NewTransport(base),
+	}
+}
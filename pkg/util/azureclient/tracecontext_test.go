@@ -0,0 +1,70 @@
+package azureclient
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTraceContextTransport(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	for _, tt := range []struct {
+		name       string
+		ctx        context.Context
+		wantHeader string
+	}{
+		{
+			name:       "span in context",
+			ctx:        trace.ContextWithSpanContext(context.Background(), sc),
+			wantHeader: "00-01000000000000000000000000000000-0200000000000000-01",
+		},
+		{
+			name: "no span in context",
+			ctx:  context.Background(),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var header string
+			rt := &traceContextTransport{
+				base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					header = req.Header.Get("traceparent")
+					return httptest.NewRecorder().Result(), nil
+				}),
+			}
+
+			req, err := http.NewRequestWithContext(tt.ctx, http.MethodGet, "https://management.azure.com/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = rt.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if header != tt.wantHeader {
+				t.Error(header)
+			}
+			if req.Header.Get("traceparent") != "" {
+				t.Error("original request was modified")
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package benchmark
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultRegressionThreshold is the ratio to its baseline above which a
+// measurement regresses, unless thresholds say otherwise
+const DefaultRegressionThreshold = 1.25
+
+// Result is the outcome of one run of the benchmarks
+type Result struct {
+	RunAt    time.Time `json:"runAt"`
+	Location string    `json:"location,omitempty"`
+
+	// GitCommit is the commit of the RP which was benchmarked
+	GitCommit string `json:"gitCommit,omitempty"`
+
+	// Measurements are durations in seconds, keyed by name
+	Measurements map[string]float64 `json:"measurements"`
+}
+
+// Thresholds configures when measurements regress
+type Thresholds struct {
+	// Default is the ratio to its baseline above which a measurement
+	// regresses.  If zero, DefaultRegressionThreshold is used.
+	Default float64 `json:"default,omitempty"`
+
+	// Measurements overrides Default for the named measurements
+	Measurements map[string]float64 `json:"measurements,omitempty"`
+
+	// IgnoreBelow is the baseline, in seconds, below which measurements are
+	// too noisy to compare
+	IgnoreBelow float64 `json:"ignoreBelow,omitempty"`
+}
+
+func (t *Thresholds) threshold(name string) float64 {
+	if t != nil {
+		if threshold, found := t.Measurements[name]; found {
+			return threshold
+		}
+		if t.Default != 0 {
+			return t.Default
+		}
+	}
+
+	return DefaultRegressionThreshold
+}
+
+// Regression is a measurement which exceeded its threshold
+type Regression struct {
+	Name      string
+	Value     float64
+	Baseline  float64
+	Threshold float64
+}
+
+func (r Regression) String() string {
+	return fmt.Sprintf("%s: %.3fs is more than %.2f times the baseline of %.3fs", r.Name, r.Value, r.Threshold, r.Baseline)
+}
+
+// Baseline returns the median of each measurement over results.  The median
+// keeps a single slow run from moving the baseline.
+func Baseline(results []Result) map[string]float64 {
+	values := map[string][]float64{}
+	for _, result := range results {
+		for name, value := range result.Measurements {
+			values[name] = append(values[name], value)
+		}
+	}
+
+	baseline := make(map[string]float64, len(values))
+	for name, v := range values {
+		sort.Float64s(v)
+
+		if len(v)%2 == 1 {
+			baseline[name] = v[len(v)/2]
+		} else {
+			baseline[name] = (v[len(v)/2-1] + v[len(v)/2]) / 2
+		}
+	}
+
+	return baseline
+}
+
+// Compare returns the measurements of result which regress against baseline,
+// in name order.  Measurements without a baseline are not compared.
+func Compare(result *Result, baseline map[string]float64, thresholds *Thresholds) []Regression {
+	var regressions []Regression
+
+	for name, value := range result.Measurements {
+		b, found := baseline[name]
+		if !found || b <= 0 {
+			continue
+		}
+
+		if thresholds != nil && b < thresholds.IgnoreBelow {
+			continue
+		}
+
+		threshold := thresholds.threshold(name)
+		if value > b*threshold {
+			regressions = append(regressions, Regression{
+				Name:      name,
+				Value:     value,
+				Baseline:  b,
+				Threshold: threshold,
+			})
+		}
+	}
+
+	sort.Slice(regressions, func(i, j int) bool { return regressions[i].Name < regressions[j].Name })
+
+	return regressions
+}
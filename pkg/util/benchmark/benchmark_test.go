@@ -0,0 +1,145 @@
+package benchmark
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBaseline(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		results []Result
+		want    map[string]float64
+	}{
+		{
+			name: "no results",
+			want: map[string]float64{},
+		},
+		{
+			name: "odd and even counts",
+			results: []Result{
+				{Measurements: map[string]float64{"install": 10, "adminupdate": 4}},
+				{Measurements: map[string]float64{"install": 100, "adminupdate": 2}},
+				{Measurements: map[string]float64{"install": 20}},
+			},
+			want: map[string]float64{
+				"install":     20,
+				"adminupdate": 3,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Baseline(tt.results)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := map[string]float64{
+		"install":     100,
+		"adminupdate": 50,
+		"latency.p95": 0.1,
+	}
+
+	for _, tt := range []struct {
+		name         string
+		measurements map[string]float64
+		thresholds   *Thresholds
+		want         []Regression
+	}{
+		{
+			name: "within default threshold",
+			measurements: map[string]float64{
+				"install":     125,
+				"adminupdate": 40,
+			},
+		},
+		{
+			name: "exceeds default threshold",
+			measurements: map[string]float64{
+				"install":     126,
+				"adminupdate": 70,
+				"new":         1000,
+			},
+			want: []Regression{
+				{Name: "adminupdate", Value: 70, Baseline: 50, Threshold: DefaultRegressionThreshold},
+				{Name: "install", Value: 126, Baseline: 100, Threshold: DefaultRegressionThreshold},
+			},
+		},
+		{
+			name: "configured thresholds",
+			measurements: map[string]float64{
+				"install":     126,
+				"adminupdate": 60,
+				"latency.p95": 1,
+			},
+			thresholds: &Thresholds{
+				Default:      1.1,
+				Measurements: map[string]float64{"install": 1.5},
+				IgnoreBelow:  1,
+			},
+			want: []Regression{
+				{Name: "adminupdate", Value: 60, Baseline: 50, Threshold: 1.1},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compare(&Result{Measurements: tt.measurements}, baseline, tt.thresholds)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	var calls int32
+
+	latencies, failures := Load(context.Background(), 10, 3, func(context.Context) error {
+		if atomic.AddInt32(&calls, 1)%5 == 0 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	if calls != 10 {
+		t.Errorf("got %d calls", calls)
+	}
+	if len(latencies) != 8 {
+		t.Errorf("got %d latencies", len(latencies))
+	}
+	if failures != 2 {
+		t.Errorf("got %d failures", failures)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	for p, want := range map[float64]time.Duration{
+		50:  50 * time.Millisecond,
+		95:  95 * time.Millisecond,
+		100: 100 * time.Millisecond,
+	} {
+		if got := Percentile(latencies, p); got != want {
+			t.Errorf("p%v: got %s, wanted %s", p, got, want)
+		}
+	}
+
+	if got := Percentile(nil, 50); got != 0 {
+		t.Error(got)
+	}
+}
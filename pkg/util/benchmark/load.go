@@ -0,0 +1,68 @@
+package benchmark
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Load calls do n times from concurrency goroutines, and returns the latency of
+// each successful call and the number of calls which failed
+func Load(ctx context.Context, n, concurrency int, do func(context.Context) error) (latencies []time.Duration, failures int) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	ch := make(chan struct{})
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for range ch {
+				start := time.Now()
+				err := do(ctx)
+				latency := time.Since(start)
+
+				mu.Lock()
+				if err != nil {
+					failures++
+				} else {
+					latencies = append(latencies, latency)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		ch <- struct{}{}
+	}
+	close(ch)
+
+	wg.Wait()
+
+	return latencies, failures
+}
+
+// Percentile returns the pth percentile (0 < p <= 100) of latencies, using the
+// nearest-rank method
+func Percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration{}, latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
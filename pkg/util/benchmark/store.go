@@ -0,0 +1,115 @@
+package benchmark
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+
+	azstorage "github.com/Azure/azure-sdk-for-go/storage"
+)
+
+// resultTimeFormat names result blobs so that they sort by the time of their
+// run
+const resultTimeFormat = "20060102T150405Z"
+
+// Store persists benchmark results
+type Store interface {
+	Put(context.Context, *Result) error
+
+	// Latest returns up to n of the most recent results, oldest first
+	Latest(ctx context.Context, n int) ([]Result, error)
+}
+
+type blobStore struct {
+	container *azstorage.Container
+	prefix    string
+}
+
+// NewBlobStore returns a Store which keeps results as JSON blobs under prefix
+// in the named container, creating the container if needed
+func NewBlobStore(blobService *azstorage.BlobStorageClient, containerName, prefix string) (Store, error) {
+	container := blobService.GetContainerReference(containerName)
+
+	_, err := container.CreateIfNotExists(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blobStore{
+		container: container,
+		prefix:    prefix,
+	}, nil
+}
+
+func (s *blobStore) Put(ctx context.Context, result *Result) error {
+	b, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	blob := s.container.GetBlobReference(path.Join(s.prefix, result.RunAt.UTC().Format(resultTimeFormat)+".json"))
+	blob.Properties.ContentType = "application/json"
+
+	return blob.CreateBlockBlobFromReader(bytes.NewReader(b), nil)
+}
+
+func (s *blobStore) Latest(ctx context.Context, n int) ([]Result, error) {
+	var names []string
+
+	params := azstorage.ListBlobsParameters{
+		Prefix: s.prefix + "/",
+	}
+	for {
+		list, err := s.container.ListBlobs(params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, blob := range list.Blobs {
+			names = append(names, blob.Name)
+		}
+
+		if list.NextMarker == "" {
+			break
+		}
+		params.Marker = list.NextMarker
+	}
+
+	sort.Strings(names)
+	if len(names) > n {
+		names = names[len(names)-n:]
+	}
+
+	results := make([]Result, 0, len(names))
+	for _, name := range names {
+		result, err := s.get(name)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+func (s *blobStore) get(name string) (*Result, error) {
+	rc, err := s.container.GetBlobReference(name).Get(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var result *Result
+	err = json.NewDecoder(rc).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
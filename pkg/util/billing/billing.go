@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	azstorage "github.com/Azure/azure-sdk-for-go/storage"
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -31,6 +32,7 @@ const (
 type Manager interface {
 	Ensure(context.Context, *api.OpenShiftClusterDocument, *api.SubscriptionDocument) error
 	Delete(context.Context, *api.OpenShiftClusterDocument) error
+	Snapshot(context.Context, *api.OpenShiftClusterDocument) error
 }
 
 type manager struct {
@@ -91,8 +93,10 @@ func (m *manager) Ensure(ctx context.Context, doc *api.OpenShiftClusterDocument,
 		ClusterResourceGroupIDKey: doc.ClusterResourceGroupIDKey,
 		InfraID:                   doc.OpenShiftCluster.Properties.InfraID,
 		Billing: &api.Billing{
-			TenantID: sub.Subscription.Properties.TenantID,
-			Location: doc.OpenShiftCluster.Location,
+			TenantID:    sub.Subscription.Properties.TenantID,
+			Location:    doc.OpenShiftCluster.Location,
+			Snapshot:    snapshot(doc),
+			Marketplace: marketplacePlan(doc),
 		},
 	})
 	if err, ok := err.(*cosmosdb.Error); ok &&
@@ -112,6 +116,16 @@ func (m *manager) Ensure(ctx context.Context, doc *api.OpenShiftClusterDocument,
 }
 
 func (m *manager) Delete(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	if s := snapshot(doc); s != nil {
+		_, err := m.billingDB.UpdateSnapshot(ctx, doc.ID, s)
+		if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	m.log.Printf("updating billing record with deletion time")
 	billingDoc, err := m.billingDB.MarkForDeletion(ctx, doc.ID)
 	if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
@@ -129,6 +143,70 @@ func (m *manager) Delete(ctx context.Context, doc *api.OpenShiftClusterDocument)
 	return nil
 }
 
+// Snapshot records the current billable shape of the cluster in its billing
+// document.  It is called periodically by the backend billing pass; clusters
+// without a billing document are ignored.
+func (m *manager) Snapshot(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	s := snapshot(doc)
+	if s == nil {
+		return nil
+	}
+
+	billingDoc, err := m.billingDB.UpdateSnapshot(ctx, doc.ID, s)
+	if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if e2eErr := m.createOrUpdateE2EBlob(ctx, billingDoc); e2eErr != nil {
+		m.log.Warnf("createOrUpdateE2EBlob failed: %s", e2eErr)
+	}
+
+	return nil
+}
+
+// snapshot returns the billable shape of the cluster, or nil if it is not yet
+// known
+func snapshot(doc *api.OpenShiftClusterDocument) *api.BillingSnapshot {
+	if doc.OpenShiftCluster == nil {
+		return nil
+	}
+
+	workerProfiles, _ := api.GetEnrichedWorkerProfiles(doc.OpenShiftCluster.Properties)
+	if doc.OpenShiftCluster.Properties.MasterProfile.VMSize == "" && len(workerProfiles) == 0 {
+		return nil
+	}
+
+	s := &api.BillingSnapshot{
+		Timestamp:    int(time.Now().Unix()),
+		MasterVMSize: doc.OpenShiftCluster.Properties.MasterProfile.VMSize,
+	}
+
+	for _, wp := range workerProfiles {
+		s.WorkerProfiles = append(s.WorkerProfiles, api.BillingWorkerProfile{
+			VMSize: wp.VMSize,
+			Count:  wp.Count,
+		})
+	}
+
+	return s
+}
+
+// marketplacePlan returns the marketplace plan of the cluster, or nil if it was
+// not created with one
+func marketplacePlan(doc *api.OpenShiftClusterDocument) *api.BillingMarketplace {
+	if doc.OpenShiftCluster == nil || doc.OpenShiftCluster.Plan == nil {
+		return nil
+	}
+
+	return &api.BillingMarketplace{
+		ResourceID: doc.OpenShiftCluster.ID,
+		PlanID:     doc.OpenShiftCluster.Plan.Name,
+	}
+}
+
 // isSubscriptionRegisteredForE2E returns true if the subscription has the
 // "Microsoft.RedHatOpenShift/SaveAROTestConfig" feature registered
 func isSubscriptionRegisteredForE2E(sub *api.SubscriptionProperties) bool {
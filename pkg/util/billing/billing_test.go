@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -433,3 +434,77 @@ func TestEnsure(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshot(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		doc  *api.OpenShiftClusterDocument
+		want *api.BillingSnapshot
+	}{
+		{
+			name: "no cluster",
+			doc:  &api.OpenShiftClusterDocument{},
+		},
+		{
+			name: "no profiles",
+			doc: &api.OpenShiftClusterDocument{
+				OpenShiftCluster: &api.OpenShiftCluster{},
+			},
+		},
+		{
+			name: "enriched worker profiles are preferred",
+			doc: &api.OpenShiftClusterDocument{
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						MasterProfile: api.MasterProfile{
+							VMSize: api.VMSizeStandardD8sV3,
+						},
+						WorkerProfiles: []api.WorkerProfile{
+							{
+								VMSize: api.VMSizeStandardD4sV3,
+								Count:  3,
+							},
+						},
+						WorkerProfilesStatus: []api.WorkerProfile{
+							{
+								VMSize: api.VMSizeStandardD4sV3,
+								Count:  1,
+							},
+							{
+								VMSize: api.VMSizeStandardD8sV3,
+								Count:  2,
+							},
+						},
+					},
+				},
+			},
+			want: &api.BillingSnapshot{
+				MasterVMSize: api.VMSizeStandardD8sV3,
+				WorkerProfiles: []api.BillingWorkerProfile{
+					{
+						VMSize: api.VMSizeStandardD4sV3,
+						Count:  1,
+					},
+					{
+						VMSize: api.VMSizeStandardD8sV3,
+						Count:  2,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := snapshot(tt.doc)
+			if got != nil {
+				if got.Timestamp == 0 {
+					t.Error("expected timestamp to be set")
+				}
+				got.Timestamp = 0
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
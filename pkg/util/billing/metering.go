@@ -0,0 +1,239 @@
+package billing
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/util/marketplace"
+)
+
+const (
+	// MeteringDimension is the marketplace dimension which the worker
+	// core-hours are reported in
+	MeteringDimension = "workercorehours"
+
+	// meteringWindow is how long after its start an hour of usage is
+	// accepted by the Metering API
+	meteringWindow = 24 * time.Hour
+
+	// meteringRetention is how long the outcome of a reported hour is kept in
+	// the billing document, for reconciliation
+	meteringRetention = 7 * 24 * time.Hour
+)
+
+// Meter reports the worker usage of clusters with a marketplace plan to the
+// Marketplace Metering API
+type Meter interface {
+	Report(context.Context, *api.BillingDocument) (*MeteringReport, error)
+}
+
+// MeteringReport reconciles the worker usage sampled by the monitor with the
+// usage reported to the Metering API.  The counts are of hours of usage.
+type MeteringReport struct {
+	Clusters int `json:"clusters"`
+
+	// Reported, Duplicate, Expired, Rejected and Failed count the hours
+	// by the outcome of the pass
+	Reported  int `json:"reported"`
+	Duplicate int `json:"duplicate"`
+	Expired   int `json:"expired"`
+	Rejected  int `json:"rejected"`
+	Failed    int `json:"failed"`
+
+	// ReportedCoreHours is the usage accepted by the Metering API during the
+	// pass
+	ReportedCoreHours int `json:"reportedCoreHours"`
+
+	// MissingHours counts the hours since the oldest hour held in the billing
+	// documents which were never sampled, e.g. because the monitor could not
+	// list the VMs of the cluster
+	MissingHours int `json:"missingHours"`
+}
+
+// Add adds the counts of o to r
+func (r *MeteringReport) Add(o *MeteringReport) {
+	r.Clusters += o.Clusters
+	r.Reported += o.Reported
+	r.Duplicate += o.Duplicate
+	r.Expired += o.Expired
+	r.Rejected += o.Rejected
+	r.Failed += o.Failed
+	r.ReportedCoreHours += o.ReportedCoreHours
+	r.MissingHours += o.MissingHours
+}
+
+type meter struct {
+	log       *logrus.Entry
+	billingDB database.Billing
+	client    marketplace.MeteringClient
+
+	backoff wait.Backoff
+	now     func() time.Time
+}
+
+func NewMeter(log *logrus.Entry, billingDB database.Billing, client marketplace.MeteringClient) Meter {
+	return &meter{
+		log:       log,
+		billingDB: billingDB,
+		client:    client,
+
+		backoff: retry.DefaultBackoff,
+		now:     time.Now,
+	}
+}
+
+// Report submits a usage event for each completed hour of usage of the
+// cluster which is not yet reported, and records the outcome in its billing
+// document.  The Metering API rejects a second usage event for the same
+// resource, dimension and hour, so an hour whose outcome was lost is recorded
+// as a duplicate rather than billed twice.  Hours which fail transiently are
+// retried by the next pass until they fall out of the window accepted by the
+// Metering API.  Documents without a marketplace plan are ignored.
+func (mt *meter) Report(ctx context.Context, doc *api.BillingDocument) (*MeteringReport, error) {
+	if doc.Billing == nil || doc.Billing.Marketplace == nil {
+		return nil, nil
+	}
+
+	log := mt.log.WithField("resource", doc.Billing.Marketplace.ResourceID)
+
+	now := mt.now()
+	current := int(now.Truncate(time.Hour).Unix())
+	oldest := int(now.Add(-meteringRetention).Unix())
+
+	report := &MeteringReport{Clusters: 1}
+
+	var updates []api.BillingUsage
+	var prune bool
+	for _, u := range doc.Billing.Marketplace.Usage {
+		if u.State != "" {
+			prune = prune || u.Hour < oldest
+			continue
+		}
+
+		// the hour is not over yet
+		if u.Hour >= current {
+			continue
+		}
+
+		mt.report(ctx, log, doc.Billing.Marketplace, &u, report)
+		updates = append(updates, u)
+	}
+
+	if len(updates) > 0 || prune {
+		var err error
+		doc, err = mt.billingDB.UpdateUsage(ctx, doc.ID, updates, oldest)
+		if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+			return report, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report.MissingHours = missingHours(doc.Billing, current)
+
+	return report, nil
+}
+
+// report submits the usage event of a single hour and records its outcome in
+// u and in the report
+func (mt *meter) report(ctx context.Context, log *logrus.Entry, m *api.BillingMarketplace, u *api.BillingUsage, report *MeteringReport) {
+	start := time.Unix(int64(u.Hour), 0).UTC()
+	log = log.WithField("hour", start.Format(time.RFC3339))
+
+	if mt.now().Sub(start) > meteringWindow {
+		log.Errorf("hour of usage expired after %d attempts", u.Attempts)
+		u.State = api.BillingUsageStateExpired
+		report.Expired++
+		return
+	}
+
+	// there is nothing to bill for an hour without workers
+	if u.WorkerCores == 0 {
+		u.State = api.BillingUsageStateReported
+		report.Reported++
+		return
+	}
+
+	var result *marketplace.UsageEventResult
+	err := retry.OnError(mt.backoff, marketplace.IsRetryable, func() (err error) {
+		u.Attempts++
+		result, err = mt.client.SubmitUsageEvent(ctx, &marketplace.UsageEvent{
+			ResourceURI:        m.ResourceID,
+			Quantity:           float64(u.WorkerCores),
+			Dimension:          MeteringDimension,
+			EffectiveStartTime: start,
+			PlanID:             m.PlanID,
+		})
+		return err
+	})
+
+	switch {
+	case err == nil && result.Status == marketplace.StatusDuplicate:
+		log.Infof("usage event already accepted as %s", result.UsageEventID)
+		u.State = api.BillingUsageStateDuplicate
+		u.UsageEventID = result.UsageEventID
+		report.Duplicate++
+
+	case err == nil:
+		u.State = api.BillingUsageStateReported
+		u.UsageEventID = result.UsageEventID
+		report.Reported++
+		report.ReportedCoreHours += u.WorkerCores
+
+	case marketplace.IsRetryable(err):
+		log.Warn(err)
+		report.Failed++
+
+	default:
+		log.Error(err)
+		u.State = api.BillingUsageStateRejected
+		report.Rejected++
+	}
+}
+
+// missingHours returns the number of completed hours since the oldest hour
+// held in the billing document which were never sampled.  The hours after the
+// deletion of the cluster are not counted.
+func missingHours(billing *api.Billing, current int) int {
+	if billing.Marketplace == nil || len(billing.Marketplace.Usage) == 0 {
+		return 0
+	}
+
+	end := current
+	if billing.DeletionTime != 0 {
+		deleted := int(time.Unix(int64(billing.DeletionTime), 0).Truncate(time.Hour).Unix())
+		if deleted < end {
+			end = deleted
+		}
+	}
+
+	start := end
+	sampled := map[int]struct{}{}
+	for _, u := range billing.Marketplace.Usage {
+		sampled[u.Hour] = struct{}{}
+		if u.Hour < start {
+			start = u.Hour
+		}
+	}
+
+	var missing int
+	for hour := start; hour < end; hour += int(time.Hour / time.Second) {
+		if _, ok := sampled[hour]; !ok {
+			missing++
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,244 @@
+package billing
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/marketplace"
+	mock_marketplace "github.com/Azure/ARO-RP/pkg/util/mocks/marketplace"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestReport(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		docID      = "00000000-0000-0000-0000-000000000000"
+		resourceID = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster"
+	)
+
+	now := time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC)
+	hour := func(h int) int {
+		return int(time.Date(2024, 1, 10, h, 0, 0, 0, time.UTC).Unix())
+	}
+
+	event := func(h, cores int) *marketplace.UsageEvent {
+		return &marketplace.UsageEvent{
+			ResourceURI:        resourceID,
+			Quantity:           float64(cores),
+			Dimension:          MeteringDimension,
+			EffectiveStartTime: time.Unix(int64(hour(h)), 0).UTC(),
+			PlanID:             "plan",
+		}
+	}
+
+	unavailable := &marketplace.Error{StatusCode: http.StatusServiceUnavailable}
+	badRequest := &marketplace.Error{StatusCode: http.StatusBadRequest, Code: "BadArgument"}
+
+	for _, tt := range []struct {
+		name        string
+		billing     *api.Billing
+		mocks       func(*mock_marketplace.MockMeteringClient)
+		wantReport  *MeteringReport
+		wantBilling *api.Billing
+	}{
+		{
+			name:        "no marketplace plan",
+			billing:     &api.Billing{},
+			mocks:       func(*mock_marketplace.MockMeteringClient) {},
+			wantBilling: &api.Billing{},
+		},
+		{
+			name: "completed hours are reported",
+			billing: &api.Billing{
+				Marketplace: &api.BillingMarketplace{
+					ResourceID: resourceID,
+					PlanID:     "plan",
+					Usage: []api.BillingUsage{
+						{Hour: hour(8), WorkerCores: 12},
+						{Hour: hour(9), WorkerCores: 16},
+						{Hour: hour(10), WorkerCores: 16},
+					},
+				},
+			},
+			mocks: func(client *mock_marketplace.MockMeteringClient) {
+				client.EXPECT().SubmitUsageEvent(gomock.Any(), event(8, 12)).Return(&marketplace.UsageEventResult{UsageEventID: "id-8", Status: marketplace.StatusAccepted}, nil)
+				client.EXPECT().SubmitUsageEvent(gomock.Any(), event(9, 16)).Return(&marketplace.UsageEventResult{UsageEventID: "id-9", Status: marketplace.StatusAccepted}, nil)
+			},
+			wantReport: &MeteringReport{Clusters: 1, Reported: 2, ReportedCoreHours: 28},
+			wantBilling: &api.Billing{
+				Marketplace: &api.BillingMarketplace{
+					ResourceID: resourceID,
+					PlanID:     "plan",
+					Usage: []api.BillingUsage{
+						{Hour: hour(8), WorkerCores: 12, State: api.BillingUsageStateReported, UsageEventID: "id-8", Attempts: 1},
+						{Hour: hour(9), WorkerCores: 16, State: api.BillingUsageStateReported, UsageEventID: "id-9", Attempts: 1},
+						{Hour: hour(10), WorkerCores: 16},
+					},
+				},
+			},
+		},
+		{
+			name: "duplicates, transient and permanent failures",
+			billing: &api.Billing{
+				Marketplace: &api.BillingMarketplace{
+					ResourceID: resourceID,
+					PlanID:     "plan",
+					Usage: []api.BillingUsage{
+						{Hour: hour(6), WorkerCores: 8},
+						{Hour: hour(7), WorkerCores: 8, Attempts: 3},
+						{Hour: hour(9), WorkerCores: 8},
+					},
+				},
+			},
+			mocks: func(client *mock_marketplace.MockMeteringClient) {
+				client.EXPECT().SubmitUsageEvent(gomock.Any(), event(6, 8)).Return(&marketplace.UsageEventResult{UsageEventID: "id-6", Status: marketplace.StatusDuplicate}, nil)
+				client.EXPECT().SubmitUsageEvent(gomock.Any(), event(7, 8)).Return(nil, unavailable).Times(2)
+				client.EXPECT().SubmitUsageEvent(gomock.Any(), event(9, 8)).Return(nil, badRequest)
+			},
+			wantReport: &MeteringReport{Clusters: 1, Duplicate: 1, Failed: 1, Rejected: 1, MissingHours: 1},
+			wantBilling: &api.Billing{
+				Marketplace: &api.BillingMarketplace{
+					ResourceID: resourceID,
+					PlanID:     "plan",
+					Usage: []api.BillingUsage{
+						{Hour: hour(6), WorkerCores: 8, State: api.BillingUsageStateDuplicate, UsageEventID: "id-6", Attempts: 1},
+						{Hour: hour(7), WorkerCores: 8, Attempts: 5},
+						{Hour: hour(9), WorkerCores: 8, State: api.BillingUsageStateRejected, Attempts: 1},
+					},
+				},
+			},
+		},
+		{
+			name: "expired and empty hours are not submitted, old outcomes are pruned",
+			billing: &api.Billing{
+				Marketplace: &api.BillingMarketplace{
+					ResourceID: resourceID,
+					PlanID:     "plan",
+					Usage: []api.BillingUsage{
+						{Hour: hour(9) - 8*24*3600, WorkerCores: 8, State: api.BillingUsageStateReported},
+						{Hour: hour(9) - 24*3600, WorkerCores: 8, Attempts: 2},
+						{Hour: hour(9), WorkerCores: 0},
+					},
+				},
+			},
+			mocks:      func(*mock_marketplace.MockMeteringClient) {},
+			wantReport: &MeteringReport{Clusters: 1, Expired: 1, Reported: 1, MissingHours: 23},
+			wantBilling: &api.Billing{
+				Marketplace: &api.BillingMarketplace{
+					ResourceID: resourceID,
+					PlanID:     "plan",
+					Usage: []api.BillingUsage{
+						{Hour: hour(9) - 24*3600, WorkerCores: 8, State: api.BillingUsageStateExpired, Attempts: 2},
+						{Hour: hour(9), State: api.BillingUsageStateReported},
+					},
+				},
+			},
+		},
+		{
+			name: "hours after the deletion are not missing",
+			billing: &api.Billing{
+				DeletionTime: hour(8) + 1200,
+				Marketplace: &api.BillingMarketplace{
+					ResourceID: resourceID,
+					PlanID:     "plan",
+					Usage: []api.BillingUsage{
+						{Hour: hour(6), WorkerCores: 8, State: api.BillingUsageStateReported},
+						{Hour: hour(8), WorkerCores: 8, State: api.BillingUsageStateReported},
+					},
+				},
+			},
+			mocks:      func(*mock_marketplace.MockMeteringClient) {},
+			wantReport: &MeteringReport{Clusters: 1, MissingHours: 1},
+			wantBilling: &api.Billing{
+				DeletionTime: hour(8) + 1200,
+				Marketplace: &api.BillingMarketplace{
+					ResourceID: resourceID,
+					PlanID:     "plan",
+					Usage: []api.BillingUsage{
+						{Hour: hour(6), WorkerCores: 8, State: api.BillingUsageStateReported},
+						{Hour: hour(8), WorkerCores: 8, State: api.BillingUsageStateReported},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			client := mock_marketplace.NewMockMeteringClient(controller)
+			tt.mocks(client)
+
+			dbBilling, _ := testdatabase.NewFakeBilling()
+			fixture := testdatabase.NewFixture().WithBilling(dbBilling)
+			fixture.AddBillingDocuments(&api.BillingDocument{
+				ID:      docID,
+				Billing: tt.billing,
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := dbBilling.Get(ctx, docID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mt := &meter{
+				log:       logrus.NewEntry(logrus.StandardLogger()),
+				billingDB: dbBilling,
+				client:    client,
+				backoff:   wait.Backoff{Steps: 2},
+				now:       func() time.Time { return now },
+			}
+
+			report, err := mt.Report(ctx, doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(report, tt.wantReport) {
+				t.Errorf("got report %#v, want %#v", report, tt.wantReport)
+			}
+
+			doc, err = dbBilling.Get(ctx, docID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the creation time is set by a trigger
+			if !reflect.DeepEqual(doc.Billing.Marketplace, tt.wantBilling.Marketplace) {
+				t.Errorf("got marketplace %#v, want %#v", doc.Billing.Marketplace, tt.wantBilling.Marketplace)
+			}
+		})
+	}
+}
+
+func TestMarketplacePlan(t *testing.T) {
+	const resourceID = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster"
+
+	if got := marketplacePlan(&api.OpenShiftClusterDocument{OpenShiftCluster: &api.OpenShiftCluster{}}); got != nil {
+		t.Error(got)
+	}
+
+	got := marketplacePlan(&api.OpenShiftClusterDocument{
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID:   resourceID,
+			Plan: &api.Plan{Name: "plan", Publisher: "redhat", Product: "aro"},
+		},
+	})
+	if !reflect.DeepEqual(got, &api.BillingMarketplace{ResourceID: resourceID, PlanID: "plan"}) {
+		t.Error(got)
+	}
+}
@@ -0,0 +1,291 @@
+package breakglass
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+)
+
+const (
+	// SignerCommonName is the common name of the break-glass certificate
+	// authority.  It identifies the certificate authority in the client CA
+	// bundle of the API server.
+	SignerCommonName = "aro-break-glass-signer"
+
+	// ClientCANamespace, ClientCAName and ClientCAKey locate the client CA
+	// bundle trusted by the API server for admin kubeconfigs, to which the
+	// break-glass certificate authority is added
+	ClientCANamespace = "openshift-config"
+	ClientCAName      = "admin-kubeconfig-client-ca"
+	ClientCAKey       = "ca-bundle.crt"
+
+	// UsernamePrefix is prefixed to the name of the requester in the common
+	// name of break-glass certificates, so that their use can be told apart
+	// in the API server audit log
+	UsernamePrefix = "system:aro-break-glass:"
+
+	// Validity is the lifetime of break-glass certificates
+	Validity = time.Hour
+
+	caValidity = 10 * 365 * 24 * time.Hour
+)
+
+// NewCA returns a new break-glass certificate authority, as a PEM encoded
+// private key followed by its self-signed certificate
+func NewCA() ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	template := &x509.Certificate{
+		SerialNumber:          new(big.Int).SetUint64(serial),
+		NotBefore:             now,
+		NotAfter:              now.Add(caValidity),
+		Subject:               pkix.Name{CommonName: SignerCommonName},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+	}
+
+	b, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(b)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := utilpem.Encode(key)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM, err := utilpem.Encode(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(keyPEM, certPEM...), nil
+}
+
+// NewKubeconfig mints a client certificate for username signed by the
+// break-glass certificate authority of oc, valid from now for Validity, and
+// returns a kubeconfig using it together with a description of the
+// certificate for the audit store.  The certificate is a member of
+// system:masters, so it keeps working when the cluster's authentication stack
+// is broken.
+func NewKubeconfig(oc *api.OpenShiftCluster, username string, now time.Time) ([]byte, *api.BreakGlassCertificate, error) {
+	if oc.Properties.BreakGlassCA == nil {
+		return nil, nil, errors.New("cluster has no break-glass certificate authority")
+	}
+
+	// only clusters in a managed domain serve a publicly trusted API server
+	// certificate.  Clusters in a custom domain, or deployed by an RP with
+	// signed certificates disabled, serve one signed by the cluster's own
+	// certificate authority, which must then be trusted by the kubeconfig.
+	var caData []byte
+	if !dns.IsManagedDomain(oc.Properties.ClusterProfile.Domain) {
+		var err error
+		caData, err = clusterCAData(oc)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	caKey, caCerts, err := utilpem.Parse(oc.Properties.BreakGlassCA)
+	if err != nil {
+		return nil, nil, err
+	}
+	if caKey == nil || len(caCerts) == 0 {
+		return nil, nil, errors.New("invalid break-glass certificate authority")
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := newSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now = now.UTC()
+	template := &x509.Certificate{
+		SerialNumber: new(big.Int).SetUint64(serial),
+		// allow for clock skew between the RP and the cluster
+		NotBefore: now.Add(-5 * time.Minute),
+		NotAfter:  now.Add(Validity),
+		Subject: pkix.Name{
+			CommonName:   UsernamePrefix + username,
+			Organization: []string{"system:masters"},
+		},
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	b, err := x509.CreateCertificate(rand.Reader, template, caCerts[0], &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert, err := x509.ParseCertificate(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err := utilpem.Encode(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM, err := utilpem.Encode(cert)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kubeconfig, err := json.MarshalIndent(&clientcmdv1.Config{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters: []clientcmdv1.NamedCluster{
+			{
+				Name: "cluster",
+				Cluster: clientcmdv1.Cluster{
+					Server:                   oc.Properties.APIServerProfile.URL,
+					CertificateAuthorityData: caData,
+				},
+			},
+		},
+		AuthInfos: []clientcmdv1.NamedAuthInfo{
+			{
+				Name: "break-glass",
+				AuthInfo: clientcmdv1.AuthInfo{
+					ClientCertificateData: certPEM,
+					ClientKeyData:         keyPEM,
+				},
+			},
+		},
+		Contexts: []clientcmdv1.NamedContext{
+			{
+				Name: "break-glass",
+				Context: clientcmdv1.Context{
+					Cluster:   "cluster",
+					Namespace: "default",
+					AuthInfo:  "break-glass",
+				},
+			},
+		},
+		CurrentContext: "break-glass",
+	}, "", "    ")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return kubeconfig, &api.BreakGlassCertificate{
+		CommonName: cert.Subject.CommonName,
+		Serial:     serial,
+		NotAfter:   int(cert.NotAfter.Unix()),
+	}, nil
+}
+
+// clusterCAData returns the certificate authority data of the cluster API
+// server from the admin kubeconfig of oc
+func clusterCAData(oc *api.OpenShiftCluster) ([]byte, error) {
+	config, err := clientcmd.Load(oc.Properties.AdminKubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if context := config.Contexts[config.CurrentContext]; context != nil {
+		if cluster := config.Clusters[context.Cluster]; cluster != nil && len(cluster.CertificateAuthorityData) > 0 {
+			return cluster.CertificateAuthorityData, nil
+		}
+	}
+
+	return nil, errors.New("admin kubeconfig has no certificate authority data")
+}
+
+// UpdateClientCABundle returns bundle with any break-glass certificate
+// authority replaced by the one in ca, and whether this changed it.  Other
+// certificates in bundle are preserved.  Replacing the certificate authority
+// revokes every certificate which was signed by the previous one.
+func UpdateClientCABundle(bundle string, ca []byte) (string, bool, error) {
+	_, caCerts, err := utilpem.Parse(ca)
+	if err != nil {
+		return "", false, err
+	}
+	if len(caCerts) == 0 {
+		return "", false, errors.New("invalid break-glass certificate authority")
+	}
+
+	_, certs, err := utilpem.Parse([]byte(bundle))
+	if err != nil {
+		return "", false, err
+	}
+
+	var found bool
+	var updated []*x509.Certificate
+	for _, cert := range certs {
+		if cert.Subject.CommonName == SignerCommonName {
+			if bytes.Equal(cert.Raw, caCerts[0].Raw) {
+				found = true
+			} else {
+				continue
+			}
+		}
+		updated = append(updated, cert)
+	}
+
+	if found && len(updated) == len(certs) {
+		return bundle, false, nil
+	}
+
+	if !found {
+		updated = append(updated, caCerts[0])
+	}
+
+	b, err := utilpem.Encode(updated...)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(b), true, nil
+}
+
+// newSerial returns a random certificate serial number which fits in a uint64
+// and so can be recorded in the audit store
+func newSerial() (uint64, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err != nil {
+		return 0, err
+	}
+
+	return n.Uint64() + 1, nil
+}
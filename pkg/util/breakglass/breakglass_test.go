@@ -0,0 +1,202 @@
+package breakglass
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+)
+
+func TestNewKubeconfig(t *testing.T) {
+	ca, err := NewCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, caCerts, err := utilpem.Parse(ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adminKubeconfig, err := json.Marshal(&clientcmdv1.Config{
+		Clusters: []clientcmdv1.NamedCluster{
+			{
+				Name: "cluster",
+				Cluster: clientcmdv1.Cluster{
+					Server:                   "https://api-int.example.com:6443",
+					CertificateAuthorityData: []byte("cluster-ca"),
+				},
+			},
+		},
+		Contexts: []clientcmdv1.NamedContext{
+			{
+				Name: "admin",
+				Context: clientcmdv1.Context{
+					Cluster: "cluster",
+				},
+			},
+		},
+		CurrentContext: "admin",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oc := &api.OpenShiftCluster{
+		Properties: api.OpenShiftClusterProperties{
+			ClusterProfile: api.ClusterProfile{
+				Domain: "example.com",
+			},
+			APIServerProfile: api.APIServerProfile{
+				URL: "https://api.example.com:6443/",
+			},
+			BreakGlassCA:    ca,
+			AdminKubeconfig: adminKubeconfig,
+		},
+	}
+
+	now := time.Now().Truncate(time.Second)
+
+	b, breakGlassCert, err := NewKubeconfig(oc, "sre@example.com", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var kubeconfig *clientcmdv1.Config
+	err = json.Unmarshal(b, &kubeconfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if kubeconfig.Clusters[0].Cluster.Server != oc.Properties.APIServerProfile.URL {
+		t.Error(kubeconfig.Clusters[0].Cluster.Server)
+	}
+
+	// a cluster in a custom domain serves a certificate signed by its own
+	// certificate authority
+	if string(kubeconfig.Clusters[0].Cluster.CertificateAuthorityData) != "cluster-ca" {
+		t.Error(string(kubeconfig.Clusters[0].Cluster.CertificateAuthorityData))
+	}
+
+	cert, err := utilpem.ParseFirstCertificate(kubeconfig.AuthInfos[0].AuthInfo.ClientCertificateData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCerts[0])
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:       pool,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if cert.Subject.CommonName != "system:aro-break-glass:sre@example.com" {
+		t.Error(cert.Subject.CommonName)
+	}
+	if !reflect.DeepEqual(cert.Subject.Organization, []string{"system:masters"}) {
+		t.Error(cert.Subject.Organization)
+	}
+	if !cert.NotAfter.Equal(now.Add(Validity)) {
+		t.Error(cert.NotAfter)
+	}
+
+	if !reflect.DeepEqual(breakGlassCert, &api.BreakGlassCertificate{
+		CommonName: cert.Subject.CommonName,
+		Serial:     cert.SerialNumber.Uint64(),
+		NotAfter:   int(now.Add(Validity).Unix()),
+	}) {
+		t.Errorf("unexpected certificate description %#v", breakGlassCert)
+	}
+
+	// a cluster in a managed domain serves a publicly trusted certificate
+	oc.Properties.ClusterProfile.Domain = "cluster.location.aroapp.io"
+	oc.Properties.AdminKubeconfig = nil
+
+	b, _, err = NewKubeconfig(oc, "sre@example.com", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kubeconfig = nil
+	err = json.Unmarshal(b, &kubeconfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if kubeconfig.Clusters[0].Cluster.CertificateAuthorityData != nil {
+		t.Error(string(kubeconfig.Clusters[0].Cluster.CertificateAuthorityData))
+	}
+
+	oc.Properties.ClusterProfile.Domain = "example.com"
+
+	_, _, err = NewKubeconfig(oc, "sre@example.com", now)
+	if err == nil {
+		t.Error("expected an error without an admin kubeconfig")
+	}
+
+	_, _, err = NewKubeconfig(&api.OpenShiftCluster{}, "sre@example.com", now)
+	if err == nil {
+		t.Error("expected an error without a certificate authority")
+	}
+}
+
+func TestUpdateClientCABundle(t *testing.T) {
+	ca, err := NewCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotatedCA, err := NewCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, changed, err := UpdateClientCABundle("", ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Error("expected the empty bundle to change")
+	}
+
+	unchanged, changed, err := UpdateClientCABundle(bundle, ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed || unchanged != bundle {
+		t.Error("expected the bundle not to change")
+	}
+
+	rotated, changed, err := UpdateClientCABundle(bundle, rotatedCA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Error("expected the bundle to change on rotation")
+	}
+
+	_, certs, err := utilpem.Parse([]byte(rotated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, rotatedCerts, err := utilpem.Parse(rotatedCA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 1 || !certs[0].Equal(rotatedCerts[0]) {
+		t.Errorf("expected only the rotated certificate authority, got %d certificates", len(certs))
+	}
+}
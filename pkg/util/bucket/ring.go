@@ -0,0 +1,152 @@
+package bucket
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"strconv"
+)
+
+const (
+	// virtualNodes is the number of points each member is given on the ring.
+	// More points give a more even spread of buckets between members.
+	virtualNodes = 64
+
+	// loadSlackPercent is how far above an even share of the total weight a
+	// member may be loaded before BalancedBuckets passes buckets on from it.
+	// Slack keeps buckets with their ring owner while weights drift a little.
+	loadSlackPercent = 25
+)
+
+type point struct {
+	hash   uint32
+	member string
+}
+
+// Ring shares buckets out between members using consistent hashing: when a
+// member joins or leaves, only the buckets it gains or loses change owner.
+type Ring struct {
+	points []point
+}
+
+// NewRing returns a Ring containing members
+func NewRing(members []string) *Ring {
+	r := &Ring{
+		points: make([]point, 0, len(members)*virtualNodes),
+	}
+
+	for _, member := range members {
+		for i := 0; i < virtualNodes; i++ {
+			r.points = append(r.points, point{
+				hash:   hash(member + "-" + strconv.Itoa(i)),
+				member: member,
+			})
+		}
+	}
+
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash == r.points[j].hash {
+			return r.points[i].member < r.points[j].member
+		}
+		return r.points[i].hash < r.points[j].hash
+	})
+
+	return r
+}
+
+// Owner returns the member which owns bucket, or "" if the ring is empty
+func (r *Ring) Owner(bucket int) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+
+	return r.points[r.search(bucket)].member
+}
+
+// Buckets returns the buckets owned by member, in order
+func (r *Ring) Buckets(member string) []int {
+	var buckets []int
+	for i := 0; i < Buckets; i++ {
+		if r.Owner(i) == member {
+			buckets = append(buckets, i)
+		}
+	}
+	return buckets
+}
+
+// BalancedBuckets returns the buckets owned by member when bucket i carries
+// weights[i].  Each bucket goes to its owner as in Buckets unless that would
+// load the owner more than loadSlackPercent above an even share of the total
+// weight, in which case it passes clockwise round the ring to the first member
+// with room for it.  Every member computing with the same weights agrees on the
+// result, and buckets only leave their owner when it is over its share, so
+// small weight changes move few buckets.
+func (r *Ring) BalancedBuckets(member string, weights []int) []int {
+	if len(r.points) == 0 {
+		return nil
+	}
+
+	members := map[string]struct{}{}
+	for _, p := range r.points {
+		members[p.member] = struct{}{}
+	}
+
+	var total int
+	for i := 0; i < Buckets && i < len(weights); i++ {
+		total += weights[i]
+	}
+
+	n := len(members) * 100
+	capacity := (total*(100+loadSlackPercent) + n - 1) / n
+
+	var buckets []int
+	load := make(map[string]int, len(members))
+	for i := 0; i < Buckets; i++ {
+		var weight int
+		if i < len(weights) {
+			weight = weights[i]
+		}
+
+		start := r.search(i)
+
+		// if no member has room, which can only happen for a bucket heavier
+		// than the slack, it stays with its owner
+		owner := r.points[start].member
+		for j := range r.points {
+			p := r.points[(start+j)%len(r.points)]
+			if load[p.member]+weight <= capacity {
+				owner = p.member
+				break
+			}
+		}
+
+		load[owner] += weight
+		if owner == member {
+			buckets = append(buckets, i)
+		}
+	}
+
+	return buckets
+}
+
+// search returns the index of the point which owns bucket.  Caller must ensure
+// that the ring is not empty.
+func (r *Ring) search(bucket int) int {
+	h := hash(strconv.Itoa(bucket))
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= h
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+
+	return i
+}
+
+func hash(s string) uint32 {
+	h := sha256.Sum256([]byte(s))
+	return binary.BigEndian.Uint32(h[:])
+}
@@ -0,0 +1,153 @@
+package bucket
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func owners(r *Ring) []string {
+	owners := make([]string, Buckets)
+	for i := range owners {
+		owners[i] = r.Owner(i)
+	}
+	return owners
+}
+
+func TestRing(t *testing.T) {
+	members := []string{"one", "two", "three", "four"}
+
+	t.Run("empty ring owns nothing", func(t *testing.T) {
+		r := NewRing(nil)
+		for i := 0; i < Buckets; i++ {
+			if owner := r.Owner(i); owner != "" {
+				t.Fatal(i, owner)
+			}
+		}
+	})
+
+	t.Run("every bucket is owned and buckets are spread", func(t *testing.T) {
+		r := NewRing(members)
+
+		counts := map[string]int{}
+		for i, owner := range owners(r) {
+			if owner == "" {
+				t.Fatal(i)
+			}
+			counts[owner]++
+		}
+
+		for _, member := range members {
+			if counts[member] < Buckets/len(members)/2 {
+				t.Error(member, counts[member])
+			}
+			if len(r.Buckets(member)) != counts[member] {
+				t.Error(member, r.Buckets(member))
+			}
+		}
+	})
+
+	t.Run("ownership does not depend on member order", func(t *testing.T) {
+		a := owners(NewRing(members))
+		b := owners(NewRing([]string{"four", "three", "two", "one"}))
+
+		for i := range a {
+			if a[i] != b[i] {
+				t.Fatal(i, a[i], b[i])
+			}
+		}
+	})
+
+	for _, tt := range []struct {
+		name   string
+		before []string
+		after  []string
+		member string
+	}{
+		{
+			name:   "join moves buckets only to the new member",
+			before: members,
+			after:  append(append([]string(nil), members...), "five"),
+			member: "five",
+		},
+		{
+			name:   "leave moves buckets only from the old member",
+			before: members,
+			after:  members[1:],
+			member: members[0],
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			before := owners(NewRing(tt.before))
+			after := owners(NewRing(tt.after))
+
+			var moved int
+			for i := range before {
+				if before[i] == after[i] {
+					continue
+				}
+				moved++
+
+				if before[i] != tt.member && after[i] != tt.member {
+					t.Error(fmt.Sprintf("bucket %d moved from %s to %s", i, before[i], after[i]))
+				}
+			}
+
+			if moved == 0 {
+				t.Error("no buckets moved")
+			}
+		})
+	}
+}
+
+func TestBalancedBuckets(t *testing.T) {
+	members := []string{"one", "two", "three", "four"}
+	r := NewRing(members)
+
+	t.Run("empty ring owns nothing", func(t *testing.T) {
+		if buckets := NewRing(nil).BalancedBuckets("one", make([]int, Buckets)); buckets != nil {
+			t.Fatal(buckets)
+		}
+	})
+
+	t.Run("unweighted buckets stay with their owners", func(t *testing.T) {
+		for _, member := range members {
+			if got, want := r.BalancedBuckets(member, nil), r.Buckets(member); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: got %v, want %v", member, got, want)
+			}
+		}
+	})
+
+	t.Run("heavy buckets are spread", func(t *testing.T) {
+		// all the weight sits in the buckets of one member
+		weights := make([]int, Buckets)
+		var total int
+		for _, i := range r.Buckets("one") {
+			weights[i] = 10
+			total += 10
+		}
+
+		owned := map[int]string{}
+		for _, member := range members {
+			var load int
+			for _, i := range r.BalancedBuckets(member, weights) {
+				if owner, found := owned[i]; found {
+					t.Fatalf("bucket %d owned by %s and %s", i, owner, member)
+				}
+				owned[i] = member
+				load += weights[i]
+			}
+
+			if load > total*(100+loadSlackPercent)/100/len(members)+10 {
+				t.Errorf("%s: load %d of %d", member, load, total)
+			}
+		}
+
+		if len(owned) != Buckets {
+			t.Errorf("%d buckets owned", len(owned))
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package cache
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var errLoadPanicked = errors.New("cache: load panicked")
+
+// Cache is a typed in-memory cache whose entries expire ttl after they are
+// stored.  Concurrent GetOrLoad calls for a key which is not cached are
+// coalesced into a single call to the loader, so that a burst of requests for
+// the same Azure lookup results in one call to ARM.
+type Cache[K comparable, V any] struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[K]entry[V]
+	calls     map[K]*call[V]
+	nextSweep time.Time
+}
+
+type entry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// call is a load in progress.  done is closed once value and err are set.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// New returns an empty Cache whose entries expire after ttl
+func New[K comparable, V any](ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[K]entry[V]{},
+		calls:   map[K]*call[V]{},
+	}
+}
+
+// Get returns the value cached for key, if it has not expired
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.get(key)
+}
+
+func (c *Cache[K, V]) get(key K) (v V, ok bool) {
+	e, found := c.entries[key]
+	if !found || !c.now().Before(e.expires) {
+		return v, false
+	}
+
+	return e.value, true
+}
+
+// Set caches value for key, replacing any existing entry
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value)
+}
+
+func (c *Cache[K, V]) set(key K, value V) {
+	now := c.now()
+
+	// expired entries are only removed here, at most once per ttl, so that
+	// keys which are never read again do not accumulate
+	if !now.Before(c.nextSweep) {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+
+	c.entries[key] = entry[V]{
+		value:   value,
+		expires: now.Add(c.ttl),
+	}
+}
+
+// Delete removes any value cached for key.  A load already in progress for
+// key is not affected.
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// GetOrLoad returns the value cached for key.  If there is none, it calls load
+// and caches the value returned, unless load returns an error, which is not
+// cached.  If a load for key is already in progress, GetOrLoad waits for its
+// result instead of calling load again.  The load runs with the context of the
+// caller which started it.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, load func(context.Context) (V, error)) (V, error) {
+	c.mu.Lock()
+
+	if v, ok := c.get(key); ok {
+		c.mu.Unlock()
+		return v, nil
+	}
+
+	if cl, found := c.calls[key]; found {
+		c.mu.Unlock()
+
+		select {
+		case <-cl.done:
+			return cl.value, cl.err
+		case <-ctx.Done():
+			var v V
+			return v, ctx.Err()
+		}
+	}
+
+	cl := &call[V]{
+		done: make(chan struct{}),
+	}
+	c.calls[key] = cl
+	c.mu.Unlock()
+
+	var returned bool
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		// waiters must not see a zero value if load panics
+		if !returned {
+			cl.err = errLoadPanicked
+		}
+
+		delete(c.calls, key)
+		if cl.err == nil {
+			c.set(key, cl.value)
+		}
+		close(cl.done)
+	}()
+
+	cl.value, cl.err = load(ctx)
+	returned = true
+
+	return cl.value, cl.err
+}
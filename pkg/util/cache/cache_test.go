@@ -0,0 +1,156 @@
+package cache
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheExpiry(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	c := New[string, int](time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Error(v, ok)
+	}
+
+	now = now.Add(time.Minute)
+
+	if v, ok := c.Get("a"); ok {
+		t.Error(v, ok)
+	}
+
+	// storing a new entry sweeps the expired one
+	c.Set("b", 2)
+	if _, found := c.entries["a"]; found {
+		t.Error("expired entry was not removed")
+	}
+
+	c.Delete("b")
+	if v, ok := c.Get("b"); ok {
+		t.Error(v, ok)
+	}
+}
+
+func TestCacheGetOrLoad(t *testing.T) {
+	ctx := context.Background()
+
+	c := New[string, int](time.Minute)
+
+	var calls int32
+	load := func(ctx context.Context) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return 1, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		v, err := c.GetOrLoad(ctx, "a", load)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != 1 {
+			t.Error(v)
+		}
+	}
+
+	if calls != 1 {
+		t.Error(calls)
+	}
+
+	// errors are not cached
+	_, err := c.GetOrLoad(ctx, "b", func(ctx context.Context) (int, error) {
+		return 0, errors.New("failed")
+	})
+	if err == nil || err.Error() != "failed" {
+		t.Error(err)
+	}
+	if v, ok := c.Get("b"); ok {
+		t.Error(v, ok)
+	}
+}
+
+func TestCacheGetOrLoadCoalesces(t *testing.T) {
+	ctx := context.Background()
+
+	c := New[string, int](time.Minute)
+
+	var calls int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 1, nil
+	}
+
+	// start the load which the other callers wait for
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = c.GetOrLoad(ctx, "a", load)
+	}()
+	for {
+		c.mu.Lock()
+		_, found := c.calls["a"]
+		c.mu.Unlock()
+		if found {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	results := make(chan int, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrLoad(ctx, "a", load)
+			if err != nil {
+				t.Error(err)
+			}
+			results <- v
+		}()
+	}
+
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for v := range results {
+		if v != 1 {
+			t.Error(v)
+		}
+	}
+
+	if calls != 1 {
+		t.Error(calls)
+	}
+}
+
+func TestCacheGetOrLoadWaiterCancelled(t *testing.T) {
+	c := New[string, int](time.Minute)
+
+	// a load is in progress which never completes
+	c.calls["a"] = &call[int]{done: make(chan struct{})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.GetOrLoad(ctx, "a", func(ctx context.Context) (int, error) {
+		t.Fatal("unexpected load")
+		return 0, nil
+	})
+	if err != context.Canceled {
+		t.Error(err)
+	}
+}
@@ -63,40 +63,56 @@ func NewARM(log *logrus.Entry, im instancemetadata.InstanceMetadata) ClientAutho
 	return a
 }
 
-func (a *arm) IsAuthorized(cs *tls.ConnectionState) bool {
+// Authorize authorizes a client whose leaf certificate is one of the client
+// certificates published in the ARM metadata.  Both the published validity
+// period and the certificate's own must include the current time.
+func (a *arm) Authorize(cs *tls.ConnectionState) error {
 	if cs == nil || len(cs.PeerCertificates) == 0 {
-		return false
+		return unauthorized(ReasonNoCertificate)
 	}
 
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
+	if len(a.m.ClientCertificates) == 0 {
+		return unauthorized(ReasonNotReady)
+	}
+
 	now := a.now()
+	leaf := cs.PeerCertificates[0]
 	for _, c := range a.m.ClientCertificates {
-		if c.NotBefore.Before(now) &&
-			c.NotAfter.After(now) &&
-			bytes.Equal(c.Certificate, cs.PeerCertificates[0].Raw) {
-			return true
+		if !bytes.Equal(c.Certificate, leaf.Raw) {
+			continue
 		}
+
+		if !c.NotBefore.Before(now) || !c.NotAfter.After(now) ||
+			(!leaf.NotBefore.IsZero() && leaf.NotBefore.After(now)) ||
+			(!leaf.NotAfter.IsZero() && leaf.NotAfter.Before(now)) {
+			return unauthorized(ReasonExpiredCertificate)
+		}
+
+		return nil
 	}
 
-	return false
+	return unauthorized(ReasonUnknownCertificate)
 }
 
+// refresh refreshes the metadata hourly, or every minute after a failure so
+// that a transient error does not leave the cached metadata stale for long
 func (a *arm) refresh() {
 	defer recover.Panic(a.log)
 
-	t := time.NewTicker(time.Hour)
-
 	for {
 		a.log.Print("refreshing metadata")
 
+		interval := time.Hour
 		err := a.refreshOnce()
 		if err != nil {
 			a.log.Error(err)
+			interval = time.Minute
 		}
 
-		<-t.C
+		time.Sleep(interval)
 	}
 }
 
@@ -194,6 +194,7 @@ func TestARMIsAuthorized(t *testing.T) {
 		cs             *tls.ConnectionState
 		wantReady      bool
 		wantAuthorized bool
+		wantReason     string
 	}{
 		{
 			name: "leaf cert matches the client certificate",
@@ -259,6 +260,7 @@ func TestARMIsAuthorized(t *testing.T) {
 					},
 				},
 			},
+			wantReason: ReasonExpiredCertificate,
 		},
 		{
 			name: "leaf cert does not match future client certificate",
@@ -276,6 +278,28 @@ func TestARMIsAuthorized(t *testing.T) {
 					},
 				},
 			},
+			wantReason: ReasonExpiredCertificate,
+		},
+		{
+			name: "leaf cert matches but has itself expired",
+			certs: []clientCertificate{
+				{
+					Certificate: []byte("current"),
+					NotBefore:   now.Add(-time.Hour),
+					NotAfter:    now.Add(time.Hour),
+				},
+			},
+			cs: &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{
+					{
+						Raw:       []byte("current"),
+						NotBefore: now.Add(-2 * time.Hour),
+						NotAfter:  now.Add(-time.Hour),
+					},
+				},
+			},
+			wantReady:  true,
+			wantReason: ReasonExpiredCertificate,
 		},
 		{
 			name: "non-leaf cert does not match client certificate",
@@ -296,14 +320,28 @@ func TestARMIsAuthorized(t *testing.T) {
 					},
 				},
 			},
-			wantReady: true,
+			wantReady:  true,
+			wantReason: ReasonUnknownCertificate,
+		},
+		{
+			name:       "invalid connection state - not TLS",
+			wantReason: ReasonNoCertificate,
 		},
 		{
-			name: "invalid connection state - not TLS",
+			name:       "invalid connection state - no PeerCertificates",
+			cs:         &tls.ConnectionState{},
+			wantReason: ReasonNoCertificate,
 		},
 		{
-			name: "invalid connection state - no PeerCertificates",
-			cs:   &tls.ConnectionState{},
+			name: "metadata not yet retrieved",
+			cs: &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{
+					{
+						Raw: []byte("current"),
+					},
+				},
+			},
+			wantReason: ReasonNotReady,
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
@@ -329,10 +367,13 @@ func TestARMIsAuthorized(t *testing.T) {
 				t.Error(ready)
 			}
 
-			isAuthorized := a.IsAuthorized(tt.cs)
-			if isAuthorized != tt.wantAuthorized {
+			err := a.Authorize(tt.cs)
+			if isAuthorized := err == nil; isAuthorized != tt.wantAuthorized {
 				t.Error(isAuthorized)
 			}
+			if reason := Reason(err); reason != tt.wantReason {
+				t.Error(reason)
+			}
 		})
 	}
 }
@@ -5,9 +5,46 @@ package clientauthorizer
 
 import (
 	"crypto/tls"
+	"errors"
 )
 
 type ClientAuthorizer interface {
-	IsAuthorized(*tls.ConnectionState) bool
+	// Authorize returns nil if the client which presented cs is authorized,
+	// or otherwise an *UnauthorizedError giving the reason it is not
+	Authorize(cs *tls.ConnectionState) error
 	IsReady() bool
 }
+
+// Reasons for which a client is not authorized
+const (
+	ReasonNoCertificate           = "NoCertificate"
+	ReasonUnknownCertificate      = "UnknownCertificate"
+	ReasonExpiredCertificate      = "ExpiredCertificate"
+	ReasonInvalidCertificateChain = "InvalidCertificateChain"
+	ReasonUnexpectedSubject       = "UnexpectedSubject"
+	ReasonNotReady                = "NotReady"
+)
+
+// UnauthorizedError is returned by Authorize when a client is not authorized
+type UnauthorizedError struct {
+	Reason string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return "client is not authorized: " + e.Reason
+}
+
+func unauthorized(reason string) error {
+	return &UnauthorizedError{Reason: reason}
+}
+
+// Reason returns the reason carried by err if it is an *UnauthorizedError,
+// or the empty string otherwise
+func Reason(err error) string {
+	var unauthorizedErr *UnauthorizedError
+	if errors.As(err, &unauthorizedErr) {
+		return unauthorizedErr.Reason
+	}
+
+	return ""
+}
@@ -18,12 +18,16 @@ func NewOne(cert []byte) ClientAuthorizer {
 	}
 }
 
-func (o *one) IsAuthorized(cs *tls.ConnectionState) bool {
+func (o *one) Authorize(cs *tls.ConnectionState) error {
 	if cs == nil || len(cs.PeerCertificates) == 0 {
-		return false
+		return unauthorized(ReasonNoCertificate)
 	}
 
-	return bytes.Equal(o.cert, cs.PeerCertificates[0].Raw)
+	if !bytes.Equal(o.cert, cs.PeerCertificates[0].Raw) {
+		return unauthorized(ReasonUnknownCertificate)
+	}
+
+	return nil
 }
 
 func (one) IsReady() bool {
@@ -0,0 +1,126 @@
+package clientauthorizer
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+
+	"github.com/Azure/ARO-RP/pkg/util/oidc"
+)
+
+// Reasons for which a proof-of-possession token is not accepted
+const (
+	ReasonMissingPoPToken       = "MissingPoPToken"
+	ReasonInvalidPoPToken       = "InvalidPoPToken"
+	ReasonInvalidAccessToken    = "InvalidAccessToken"
+	ReasonPoPKeyMismatch        = "PoPKeyMismatch"
+	ReasonPoPRequestMismatch    = "PoPRequestMismatch"
+	ReasonPoPTokenOutsideWindow = "PoPTokenOutsideWindow"
+)
+
+// popTokenWindow is how far the timestamp of a proof-of-possession token may
+// be from the current time
+const popTokenWindow = 5 * time.Minute
+
+// PoPAuthorizer authorizes requests which carry a proof-of-possession token
+type PoPAuthorizer interface {
+	// Authorize returns nil if r carries a valid proof-of-possession token,
+	// or otherwise an *UnauthorizedError giving the reason it does not
+	Authorize(r *http.Request) error
+}
+
+type pop struct {
+	verifier oidc.Verifier
+	now      func() time.Time
+}
+
+// NewPoP returns a PoPAuthorizer which accepts signed HTTP requests: requests
+// whose Authorization header is "PoP <token>", where token is a JWS over the
+// request's method, host, path and timestamp, signed with the key which is
+// bound to the access token it carries.  The access token is verified by
+// verifier.
+func NewPoP(verifier oidc.Verifier) PoPAuthorizer {
+	return &pop{
+		verifier: verifier,
+		now:      time.Now,
+	}
+}
+
+type popClaims struct {
+	AccessToken  string `json:"at"`
+	Timestamp    int64  `json:"ts"`
+	Method       string `json:"m"`
+	Host         string `json:"u"`
+	Path         string `json:"p"`
+	Confirmation struct {
+		JWK *jose.JSONWebKey `json:"jwk"`
+	} `json:"cnf"`
+}
+
+type accessTokenClaims struct {
+	Confirmation struct {
+		KeyID string `json:"kid"`
+	} `json:"cnf"`
+}
+
+func (p *pop) Authorize(r *http.Request) error {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "PoP") {
+		return unauthorized(ReasonMissingPoPToken)
+	}
+
+	jws, err := jose.ParseSigned(token)
+	if err != nil {
+		return unauthorized(ReasonInvalidPoPToken)
+	}
+
+	// the key which signs the token is carried in the token itself; it is
+	// trusted only once it is matched against the verified access token
+	var claims popClaims
+	err = json.Unmarshal(jws.UnsafePayloadWithoutVerification(), &claims)
+	if err != nil || claims.Confirmation.JWK == nil {
+		return unauthorized(ReasonInvalidPoPToken)
+	}
+
+	_, err = jws.Verify(claims.Confirmation.JWK)
+	if err != nil {
+		return unauthorized(ReasonInvalidPoPToken)
+	}
+
+	at, err := p.verifier.Verify(r.Context(), claims.AccessToken)
+	if err != nil {
+		return unauthorized(ReasonInvalidAccessToken)
+	}
+
+	var atClaims accessTokenClaims
+	err = at.Claims(&atClaims)
+	if err != nil {
+		return unauthorized(ReasonInvalidAccessToken)
+	}
+
+	thumbprint, err := claims.Confirmation.JWK.Thumbprint(crypto.SHA256)
+	if err != nil || atClaims.Confirmation.KeyID != base64.RawURLEncoding.EncodeToString(thumbprint) {
+		return unauthorized(ReasonPoPKeyMismatch)
+	}
+
+	if !strings.EqualFold(claims.Method, r.Method) ||
+		!strings.EqualFold(claims.Host, r.Host) ||
+		!strings.EqualFold(claims.Path, r.URL.Path) {
+		return unauthorized(ReasonPoPRequestMismatch)
+	}
+
+	ts := time.Unix(claims.Timestamp, 0)
+	if now := p.now(); ts.Before(now.Add(-popTokenWindow)) || ts.After(now.Add(popTokenWindow)) {
+		return unauthorized(ReasonPoPTokenOutsideWindow)
+	}
+
+	return nil
+}
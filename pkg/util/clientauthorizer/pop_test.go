@@ -0,0 +1,189 @@
+package clientauthorizer
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+
+	"github.com/Azure/ARO-RP/pkg/util/oidc"
+)
+
+func TestPoPAuthorize(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwk := jose.JSONWebKey{Key: key.Public(), Algorithm: string(jose.RS256)}
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accessToken := func(kid string) string {
+		b, err := json.Marshal(map[string]interface{}{
+			"cnf": map[string]string{
+				"kid": kid,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	// sign returns a PoP token for claims signed with signingKey
+	sign := func(signingKey *rsa.PrivateKey, claims map[string]interface{}) string {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: signingKey}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := json.Marshal(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jws, err := signer.Sign(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		token, err := jws.CompactSerialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	validClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"at": accessToken(base64.RawURLEncoding.EncodeToString(thumbprint)),
+			"ts": now.Unix(),
+			"m":  http.MethodGet,
+			"u":  "server",
+			"p":  "/subscriptions/00000000-0000-0000-0000-000000000000",
+			"cnf": map[string]interface{}{
+				"jwk": jwk,
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name          string
+		authorization func() string
+		verifierErr   error
+		wantReason    string
+	}{
+		{
+			name: "valid",
+			authorization: func() string {
+				return "PoP " + sign(key, validClaims())
+			},
+		},
+		{
+			name: "no authorization header",
+			authorization: func() string {
+				return ""
+			},
+			wantReason: ReasonMissingPoPToken,
+		},
+		{
+			name: "bearer token",
+			authorization: func() string {
+				return "Bearer " + sign(key, validClaims())
+			},
+			wantReason: ReasonMissingPoPToken,
+		},
+		{
+			name: "not a JWS",
+			authorization: func() string {
+				return "PoP invalid"
+			},
+			wantReason: ReasonInvalidPoPToken,
+		},
+		{
+			name: "signed with a different key",
+			authorization: func() string {
+				return "PoP " + sign(otherKey, validClaims())
+			},
+			wantReason: ReasonInvalidPoPToken,
+		},
+		{
+			name: "access token not verified",
+			authorization: func() string {
+				return "PoP " + sign(key, validClaims())
+			},
+			verifierErr: errors.New("invalid"),
+			wantReason:  ReasonInvalidAccessToken,
+		},
+		{
+			name: "access token bound to a different key",
+			authorization: func() string {
+				claims := validClaims()
+				claims["at"] = accessToken("other")
+				return "PoP " + sign(key, claims)
+			},
+			wantReason: ReasonPoPKeyMismatch,
+		},
+		{
+			name: "signed for a different request",
+			authorization: func() string {
+				claims := validClaims()
+				claims["m"] = http.MethodDelete
+				return "PoP " + sign(key, claims)
+			},
+			wantReason: ReasonPoPRequestMismatch,
+		},
+		{
+			name: "signed too long ago",
+			authorization: func() string {
+				claims := validClaims()
+				claims["ts"] = now.Add(-time.Hour).Unix()
+				return "PoP " + sign(key, claims)
+			},
+			wantReason: ReasonPoPTokenOutsideWindow,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &pop{
+				verifier: &oidc.NoopVerifier{Err: tt.verifierErr},
+				now:      func() time.Time { return now },
+			}
+
+			r, err := http.NewRequest(http.MethodGet, "https://server/subscriptions/00000000-0000-0000-0000-000000000000", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if authorization := tt.authorization(); authorization != "" {
+				r.Header.Set("Authorization", authorization)
+			}
+
+			err = p.Authorize(r)
+			if reason := Reason(err); reason != tt.wantReason {
+				t.Error(err)
+			}
+			if (err == nil) != (tt.wantReason == "") {
+				t.Error(err)
+			}
+		})
+	}
+}
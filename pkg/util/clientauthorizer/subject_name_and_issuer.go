@@ -60,16 +60,16 @@ func (sni *subjectNameAndIssuer) readCABundle(caBundlePath string) error {
 	return nil
 }
 
-func (sni *subjectNameAndIssuer) IsAuthorized(cs *tls.ConnectionState) bool {
+func (sni *subjectNameAndIssuer) Authorize(cs *tls.ConnectionState) error {
 	if sni.roots == nil {
 		// Should never happen.  Do not fall back to system CA bundle.
 		sni.log.Error("no CA certificate configured")
-		return false
+		return unauthorized(ReasonNotReady)
 	}
 
 	if cs == nil || len(cs.PeerCertificates) == 0 {
 		sni.log.Debug("no certificate present for the connection")
-		return false
+		return unauthorized(ReasonNoCertificate)
 	}
 
 	verifyOpts := x509.VerifyOptions{
@@ -85,15 +85,18 @@ func (sni *subjectNameAndIssuer) IsAuthorized(cs *tls.ConnectionState) bool {
 	_, err := cs.PeerCertificates[0].Verify(verifyOpts)
 	if err != nil {
 		sni.log.Debug(err)
-		return false
+		if certErr, ok := err.(x509.CertificateInvalidError); ok && certErr.Reason == x509.Expired {
+			return unauthorized(ReasonExpiredCertificate)
+		}
+		return unauthorized(ReasonInvalidCertificateChain)
 	}
 
 	if cs.PeerCertificates[0].Subject.CommonName != sni.clientCertCommonName {
 		sni.log.Debug("unexpected common name in the admin API client certificate")
-		return false
+		return unauthorized(ReasonUnexpectedSubject)
 	}
 
-	return true
+	return nil
 }
 
 func (sni *subjectNameAndIssuer) IsReady() bool {
@@ -143,7 +143,7 @@ func TestSubjectNameAndIssuer(t *testing.T) {
 				t.Error(err)
 			}
 
-			result := authorizer.IsAuthorized(cs)
+			result := authorizer.Authorize(cs) == nil
 			if result != tt.want {
 				t.Error(result)
 			}
@@ -197,12 +197,12 @@ func TestSubjectNameAndIssuerReadCABundle(t *testing.T) {
 				readFile: tt.readFile,
 			}
 
-			if authorizer.IsAuthorized(cs) {
+			if authorizer.Authorize(cs) == nil {
 				t.Error("expected deny before the readCABundle call")
 			}
 
 			readCABundleErr := authorizer.readCABundle("/fake/path/to/ca/cert.pem")
-			IsAuthorized := authorizer.IsAuthorized(cs)
+			IsAuthorized := authorizer.Authorize(cs) == nil
 
 			if tt.want {
 				if readCABundleErr != nil {
@@ -168,14 +168,16 @@ func (c *Cluster) DeleteApp(ctx context.Context) error {
 	return c.deleteApplication(ctx, os.Getenv("AZURE_CLUSTER_APP_ID"))
 }
 
-func (c *Cluster) Create(ctx context.Context, vnetResourceGroup, clusterName string, osClusterVersion string) error {
+// Create creates a cluster of the given profile, or adopts the cluster if it
+// already exists
+func (c *Cluster) Create(ctx context.Context, vnetResourceGroup, clusterName string, profile *Profile) error {
 	clusterGet, err := c.openshiftclustersv20230904.Get(ctx, vnetResourceGroup, clusterName)
 	if err == nil {
 		if clusterGet.ProvisioningState == mgmtredhatopenshift20230904.Failed {
 			return fmt.Errorf("cluster exists and is in failed provisioning state, please delete and retry")
 		}
 		c.log.Print("cluster already exists, skipping create")
-		return nil
+		return profile.validate(&clusterGet)
 	}
 
 	err = env.ValidateVars(
@@ -193,11 +195,7 @@ func (c *Cluster) Create(ctx context.Context, vnetResourceGroup, clusterName str
 	appID := os.Getenv("AZURE_CLUSTER_APP_ID")
 	appSecret := os.Getenv("AZURE_CLUSTER_APP_SECRET")
 
-	visibility := api.VisibilityPublic
-
-	if os.Getenv("PRIVATE_CLUSTER") != "" || os.Getenv("NO_INTERNET") != "" {
-		visibility = api.VisibilityPrivate
-	}
+	c.log.Infof("creating cluster of profile %s", profile.Name)
 
 	if c.ci {
 		c.log.Infof("creating resource group")
@@ -256,8 +254,7 @@ func (c *Cluster) Create(ctx context.Context, vnetResourceGroup, clusterName str
 		"kvName":                    {Value: kvName},
 	}
 
-	// TODO: ick
-	if os.Getenv("NO_INTERNET") != "" {
+	if profile.UserDefinedRouting {
 		parameters["routes"] = &arm.ParametersParameter{
 			Value: []mgmtnetwork.Route{
 				{
@@ -339,7 +336,7 @@ func (c *Cluster) Create(ctx context.Context, vnetResourceGroup, clusterName str
 	}
 
 	c.log.Info("creating cluster")
-	err = c.createCluster(ctx, vnetResourceGroup, clusterName, appID, appSecret, diskEncryptionSetID, visibility, osClusterVersion)
+	err = c.createCluster(ctx, vnetResourceGroup, clusterName, appID, appSecret, diskEncryptionSetID, profile)
 
 	if err != nil {
 		return err
@@ -383,6 +380,16 @@ func (c *Cluster) generateSubnets() (vnetPrefix string, masterSubnet string, wor
 	return
 }
 
+// Adopt checks that the existing cluster was created with the given profile
+func (c *Cluster) Adopt(ctx context.Context, vnetResourceGroup, clusterName string, profile *Profile) error {
+	oc, err := c.openshiftclustersv20230904.Get(ctx, vnetResourceGroup, clusterName)
+	if err != nil {
+		return err
+	}
+
+	return profile.validate(&oc)
+}
+
 func (c *Cluster) Delete(ctx context.Context, vnetResourceGroup, clusterName string) error {
 	var errs []error
 
@@ -415,15 +422,15 @@ func (c *Cluster) Delete(ctx context.Context, vnetResourceGroup, clusterName str
 // createCluster created new clusters, based on where it is running.
 // development - using preview api
 // production - using stable GA api
-func (c *Cluster) createCluster(ctx context.Context, vnetResourceGroup, clusterName, clientID, clientSecret, diskEncryptionSetID string, visibility api.Visibility, osClusterVersion string) error {
+func (c *Cluster) createCluster(ctx context.Context, vnetResourceGroup, clusterName, clientID, clientSecret, diskEncryptionSetID string, profile *Profile) error {
 	// using internal representation for "singe source" of options
 	oc := api.OpenShiftCluster{
 		Properties: api.OpenShiftClusterProperties{
 			ClusterProfile: api.ClusterProfile{
 				Domain:               strings.ToLower(clusterName),
 				ResourceGroupID:      fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", c.env.SubscriptionID(), "aro-"+clusterName),
-				FipsValidatedModules: api.FipsValidatedModulesEnabled,
-				Version:              osClusterVersion,
+				FipsValidatedModules: api.FipsValidatedModulesDisabled,
+				Version:              profile.Version,
 			},
 			ServicePrincipalProfile: api.ServicePrincipalProfile{
 				ClientID:     clientID,
@@ -452,18 +459,31 @@ func (c *Cluster) createCluster(ctx context.Context, vnetResourceGroup, clusterN
 				},
 			},
 			APIServerProfile: api.APIServerProfile{
-				Visibility: visibility,
+				Visibility: profile.Visibility,
 			},
 			IngressProfiles: []api.IngressProfile{
 				{
 					Name:       "default",
-					Visibility: visibility,
+					Visibility: profile.Visibility,
 				},
 			},
 		},
 		Location: c.env.Location(),
 	}
 
+	if profile.FIPS {
+		oc.Properties.ClusterProfile.FipsValidatedModules = api.FipsValidatedModulesEnabled
+	}
+
+	if !profile.EncryptionAtHost {
+		oc.Properties.MasterProfile.EncryptionAtHost = api.EncryptionAtHostDisabled
+		oc.Properties.WorkerProfiles[0].EncryptionAtHost = api.EncryptionAtHostDisabled
+	}
+
+	if profile.UserDefinedRouting {
+		oc.Properties.NetworkProfile.OutboundType = api.OutboundTypeUserDefinedRouting
+	}
+
 	if c.env.IsLocalDevelopmentMode() {
 		err := c.registerSubscription(ctx)
 		if err != nil {
@@ -0,0 +1,191 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mgmtredhatopenshift20230904 "github.com/Azure/ARO-RP/pkg/client/services/redhatopenshift/mgmt/2023-09-04/redhatopenshift"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+// Labels describing the features of a cluster profile.  Specs which depend on
+// a feature are labelled with it, so that they are filtered out when running
+// against a cluster of a profile which lacks it.
+const (
+	LabelPublic             = "public"
+	LabelPrivate            = "private"
+	LabelUserDefinedRouting = "udr"
+	LabelFIPS               = "fips"
+	LabelEncryptionAtHost   = "encryption-at-host"
+)
+
+// Profile describes the configuration of a cluster created for testing
+type Profile struct {
+	Name string
+
+	Visibility api.Visibility
+
+	// UserDefinedRouting clusters have no route to the internet: their
+	// subnets' default route is blackholed and their outbound type is
+	// UserDefinedRouting.  They are always private.
+	UserDefinedRouting bool
+
+	FIPS             bool
+	EncryptionAtHost bool
+
+	// Version is the OpenShift version to install
+	Version string
+}
+
+// DefaultProfile is the name of the profile used when none is selected
+const DefaultProfile = "default"
+
+var profiles = map[string]Profile{
+	DefaultProfile: {
+		Visibility:       api.VisibilityPublic,
+		FIPS:             true,
+		EncryptionAtHost: true,
+	},
+	"private": {
+		Visibility:       api.VisibilityPrivate,
+		FIPS:             true,
+		EncryptionAtHost: true,
+	},
+	"udr": {
+		Visibility:         api.VisibilityPrivate,
+		UserDefinedRouting: true,
+		FIPS:               true,
+		EncryptionAtHost:   true,
+	},
+	"no-fips": {
+		Visibility:       api.VisibilityPublic,
+		EncryptionAtHost: true,
+	},
+	"no-encryption-at-host": {
+		Visibility: api.VisibilityPublic,
+		FIPS:       true,
+	},
+}
+
+// ProfileNames returns the names of the available profiles
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ProfileFromEnv returns the named profile, or the default profile if name is
+// empty.  For compatibility, PRIVATE_CLUSTER makes the profile private,
+// NO_INTERNET makes it use user defined routing, and OS_CLUSTER_VERSION
+// selects the version to install, which otherwise is the default install
+// version.
+func ProfileFromEnv(name string) (*Profile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	p, found := profiles[name]
+	if !found {
+		return nil, fmt.Errorf("unknown cluster profile %q: must be one of %s", name, strings.Join(ProfileNames(), ", "))
+	}
+	p.Name = name
+
+	if os.Getenv("PRIVATE_CLUSTER") != "" {
+		p.Visibility = api.VisibilityPrivate
+	}
+
+	if os.Getenv("NO_INTERNET") != "" {
+		p.Visibility = api.VisibilityPrivate
+		p.UserDefinedRouting = true
+	}
+
+	p.Version = os.Getenv("OS_CLUSTER_VERSION")
+	if p.Version == "" {
+		p.Version = version.DefaultInstallStream.Version.String()
+	}
+
+	return &p, nil
+}
+
+// Labels returns the labels of the features of the profile
+func (p *Profile) Labels() []string {
+	labels := []string{LabelPublic}
+	if p.Visibility == api.VisibilityPrivate {
+		labels = []string{LabelPrivate}
+	}
+	if p.UserDefinedRouting {
+		labels = append(labels, LabelUserDefinedRouting)
+	}
+	if p.FIPS {
+		labels = append(labels, LabelFIPS)
+	}
+	if p.EncryptionAtHost {
+		labels = append(labels, LabelEncryptionAtHost)
+	}
+
+	return labels
+}
+
+// LabelFilter returns a Ginkgo label filter which excludes the specs which
+// depend on features that the profile lacks
+func (p *Profile) LabelFilter() string {
+	has := map[string]bool{}
+	for _, label := range p.Labels() {
+		has[label] = true
+	}
+
+	var filters []string
+	for _, label := range []string{LabelPublic, LabelPrivate, LabelUserDefinedRouting, LabelFIPS, LabelEncryptionAtHost} {
+		if !has[label] {
+			filters = append(filters, "!"+label)
+		}
+	}
+
+	return strings.Join(filters, " && ")
+}
+
+// validate returns an error if the existing cluster oc was not created with
+// the profile
+func (p *Profile) validate(oc *mgmtredhatopenshift20230904.OpenShiftCluster) error {
+	var mismatches []string
+
+	if oc.OpenShiftClusterProperties == nil {
+		return fmt.Errorf("cluster has no properties")
+	}
+	props := oc.OpenShiftClusterProperties
+
+	if props.ApiserverProfile == nil || string(props.ApiserverProfile.Visibility) != string(p.Visibility) {
+		mismatches = append(mismatches, fmt.Sprintf("visibility is not %s", p.Visibility))
+	}
+
+	userDefinedRouting := props.NetworkProfile != nil && props.NetworkProfile.OutboundType == mgmtredhatopenshift20230904.UserDefinedRouting
+	if userDefinedRouting != p.UserDefinedRouting {
+		mismatches = append(mismatches, fmt.Sprintf("user defined routing is not %t", p.UserDefinedRouting))
+	}
+
+	fips := props.ClusterProfile != nil && props.ClusterProfile.FipsValidatedModules == mgmtredhatopenshift20230904.FipsValidatedModulesEnabled
+	if fips != p.FIPS {
+		mismatches = append(mismatches, fmt.Sprintf("FIPS is not %t", p.FIPS))
+	}
+
+	encryptionAtHost := props.MasterProfile != nil && props.MasterProfile.EncryptionAtHost == mgmtredhatopenshift20230904.Enabled
+	if encryptionAtHost != p.EncryptionAtHost {
+		mismatches = append(mismatches, fmt.Sprintf("encryption at host is not %t", p.EncryptionAtHost))
+	}
+
+	if mismatches != nil {
+		return fmt.Errorf("cluster does not match profile %q: %s", p.Name, strings.Join(mismatches, ", "))
+	}
+
+	return nil
+}
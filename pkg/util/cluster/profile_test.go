@@ -0,0 +1,121 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+
+	mgmtredhatopenshift20230904 "github.com/Azure/ARO-RP/pkg/client/services/redhatopenshift/mgmt/2023-09-04/redhatopenshift"
+)
+
+func TestProfileFromEnv(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		profile         string
+		env             map[string]string
+		wantLabelFilter string
+		wantVersion     string
+		wantErr         string
+	}{
+		{
+			name:            "default",
+			wantLabelFilter: "!private && !udr",
+		},
+		{
+			name:            "private",
+			profile:         "private",
+			wantLabelFilter: "!public && !udr",
+		},
+		{
+			name:            "no internet",
+			env:             map[string]string{"NO_INTERNET": "true"},
+			wantLabelFilter: "!public",
+		},
+		{
+			name:            "no encryption at host",
+			profile:         "no-encryption-at-host",
+			env:             map[string]string{"OS_CLUSTER_VERSION": "4.13.1"},
+			wantLabelFilter: "!private && !udr && !encryption-at-host",
+			wantVersion:     "4.13.1",
+		},
+		{
+			name:    "unknown",
+			profile: "invalid",
+			wantErr: `unknown cluster profile "invalid": must be one of default, no-encryption-at-host, no-fips, private, udr`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"PRIVATE_CLUSTER", "NO_INTERNET", "OS_CLUSTER_VERSION"} {
+				t.Setenv(k, tt.env[k])
+			}
+
+			p, err := ProfileFromEnv(tt.profile)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+			if err != nil {
+				return
+			}
+
+			if filter := p.LabelFilter(); filter != tt.wantLabelFilter {
+				t.Error(filter)
+			}
+			if tt.wantVersion != "" && p.Version != tt.wantVersion {
+				t.Error(p.Version)
+			}
+		})
+	}
+}
+
+func TestProfileValidate(t *testing.T) {
+	oc := func(visibility mgmtredhatopenshift20230904.Visibility, fips mgmtredhatopenshift20230904.FipsValidatedModules) *mgmtredhatopenshift20230904.OpenShiftCluster {
+		return &mgmtredhatopenshift20230904.OpenShiftCluster{
+			OpenShiftClusterProperties: &mgmtredhatopenshift20230904.OpenShiftClusterProperties{
+				ClusterProfile: &mgmtredhatopenshift20230904.ClusterProfile{
+					FipsValidatedModules: fips,
+					Version:              to.StringPtr("4.13.1"),
+				},
+				NetworkProfile: &mgmtredhatopenshift20230904.NetworkProfile{
+					OutboundType: mgmtredhatopenshift20230904.Loadbalancer,
+				},
+				MasterProfile: &mgmtredhatopenshift20230904.MasterProfile{
+					EncryptionAtHost: mgmtredhatopenshift20230904.Enabled,
+				},
+				ApiserverProfile: &mgmtredhatopenshift20230904.APIServerProfile{
+					Visibility: visibility,
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		oc      *mgmtredhatopenshift20230904.OpenShiftCluster
+		wantErr string
+	}{
+		{
+			name: "matches",
+			oc:   oc(mgmtredhatopenshift20230904.Public, mgmtredhatopenshift20230904.FipsValidatedModulesEnabled),
+		},
+		{
+			name:    "does not match",
+			oc:      oc(mgmtredhatopenshift20230904.Private, mgmtredhatopenshift20230904.FipsValidatedModulesDisabled),
+			wantErr: `cluster does not match profile "default": visibility is not Public, FIPS is not true`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := profiles[DefaultProfile]
+			p.Name = DefaultProfile
+
+			err := p.validate(tt.oc)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Error(err)
+			}
+		})
+	}
+}
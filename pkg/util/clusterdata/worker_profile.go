@@ -91,6 +91,35 @@ func (ce machineClientEnricher) Enrich(
 		if machineProviderSpec.OSDisk.ManagedDisk.DiskEncryptionSet != nil {
 			workerProfiles[i].DiskEncryptionSetID = machineProviderSpec.OSDisk.ManagedDisk.DiskEncryptionSet.ID
 		}
+
+		switch {
+		case machineProviderSpec.UltraSSDCapability == machinev1beta1.AzureUltraSSDCapabilityEnabled:
+			workerProfiles[i].DiskType = api.DiskTypeUltraSSDLRS
+		case machineProviderSpec.OSDisk.ManagedDisk.StorageAccountType == string(machinev1beta1.StorageAccountPremiumLRS):
+			workerProfiles[i].DiskType = api.DiskTypePremiumLRS
+		}
+
+		acceleratedNetworking := api.AcceleratedNetworkingDisabled
+		if machineProviderSpec.AcceleratedNetworking {
+			acceleratedNetworking = api.AcceleratedNetworkingEnabled
+		}
+
+		workerProfiles[i].AcceleratedNetworking = acceleratedNetworking
+
+		if machineProviderSpec.Zone != nil && *machineProviderSpec.Zone != "" {
+			workerProfiles[i].Zones = []string{*machineProviderSpec.Zone}
+		}
+
+		if machineProviderSpec.SpotVMOptions != nil {
+			// the machine API always deletes evicted VMs
+			workerProfiles[i].SpotVMOptions = &api.SpotVMOptions{
+				EvictionPolicy: api.SpotEvictionPolicyDelete,
+			}
+			if machineProviderSpec.SpotVMOptions.MaxPrice != nil {
+				maxPrice := machineProviderSpec.SpotVMOptions.MaxPrice.AsApproximateFloat64()
+				workerProfiles[i].SpotVMOptions.MaxPrice = &maxPrice
+			}
+		}
 	}
 
 	sort.Slice(workerProfiles, func(i, j int) bool { return workerProfiles[i].Name < workerProfiles[j].Name })
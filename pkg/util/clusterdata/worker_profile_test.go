@@ -61,6 +61,12 @@ func TestWorkerProfilesEnricherTask(t *testing.T) {
 			wantOc:  getWantOc(clusterID, validWorkerProfile()),
 			givenOc: getGivenOc(clusterID),
 		},
+		{
+			name:    "machine set objects exist - spot provider spec",
+			client:  machinefake.NewSimpleClientset(createMachineSet("fake-worker-profile-1", spotProvSpec())),
+			wantOc:  getWantOc(clusterID, spotWorkerProfile()),
+			givenOc: getGivenOc(clusterID),
+		},
 		{
 			name:    "machine set objects exist - invalid provider spec JSON",
 			client:  machinefake.NewSimpleClientset(createMachineSet("fake-worker-profile-1", invalidProvSpec)),
@@ -169,6 +175,37 @@ func validProvSpec() machinev1beta1.ProviderSpec {
 	}
 }
 
+// This func returns a ProviderSpec object for a machine which is a Spot VM
+// in zone 2 with Ultra disks and accelerated networking.
+func spotProvSpec() machinev1beta1.ProviderSpec {
+	return machinev1beta1.ProviderSpec{
+		Value: &kruntime.RawExtension{
+			Raw: []byte(fmt.Sprintf(`{
+    "apiVersion": "machine.openshift.io/v1beta1",
+    "kind": "AzureMachineProviderSpec",
+    "osDisk": {
+        "diskSizeGB": 128,
+        "managedDisk": {
+            "storageAccountType": "Premium_LRS"
+        }
+    },
+    "vmSize": "Standard_D4s_v3",
+    "networkResourceGroup": "%s",
+    "vnet": "%s",
+    "subnet": "%s",
+    "acceleratedNetworking": true,
+    "ultraSSDCapability": "Enabled",
+    "zone": "2",
+    "spotVMOptions": {
+        "maxPrice": "0.05"
+    }
+}`,
+				mockVnetRG, mockVnetName, mockSubnetName,
+			)),
+		},
+	}
+}
+
 // This function creates a fake client with a reactor function
 // that returns an error when listing MachineSets.
 func createFakeClientWithError() machineclient.Interface {
@@ -206,20 +243,46 @@ func validWorkerProfile() []api.WorkerProfile {
 
 	return []api.WorkerProfile{
 		{
-			Name:             "fake-worker-profile-1",
-			VMSize:           api.VMSizeStandardD4sV3,
-			DiskSizeGB:       512,
-			EncryptionAtHost: api.EncryptionAtHostDisabled,
-			SubnetID:         workerSubnetID,
-			Count:            1,
+			Name:                  "fake-worker-profile-1",
+			VMSize:                api.VMSizeStandardD4sV3,
+			DiskSizeGB:            512,
+			EncryptionAtHost:      api.EncryptionAtHostDisabled,
+			AcceleratedNetworking: api.AcceleratedNetworkingDisabled,
+			SubnetID:              workerSubnetID,
+			Count:                 1,
 		},
 		{
-			Name:             "fake-worker-profile-2",
-			VMSize:           api.VMSizeStandardD4sV3,
-			DiskSizeGB:       512,
-			EncryptionAtHost: api.EncryptionAtHostDisabled,
-			SubnetID:         workerSubnetID,
-			Count:            1,
+			Name:                  "fake-worker-profile-2",
+			VMSize:                api.VMSizeStandardD4sV3,
+			DiskSizeGB:            512,
+			EncryptionAtHost:      api.EncryptionAtHostDisabled,
+			AcceleratedNetworking: api.AcceleratedNetworkingDisabled,
+			SubnetID:              workerSubnetID,
+			Count:                 1,
+		},
+	}
+}
+
+// This func returns the api.WorkerProfile object for a MachineSet of Spot VMs.
+func spotWorkerProfile() []api.WorkerProfile {
+	return []api.WorkerProfile{
+		{
+			Name:                  "fake-worker-profile-1",
+			VMSize:                api.VMSizeStandardD4sV3,
+			DiskSizeGB:            128,
+			EncryptionAtHost:      api.EncryptionAtHostDisabled,
+			DiskType:              api.DiskTypeUltraSSDLRS,
+			AcceleratedNetworking: api.AcceleratedNetworkingEnabled,
+			Zones:                 []string{"2"},
+			SubnetID: fmt.Sprintf(
+				"/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s/subnets/%s",
+				mockSubscriptionID, mockVnetRG, mockVnetName, mockSubnetName,
+			),
+			Count: 1,
+			SpotVMOptions: &api.SpotVMOptions{
+				MaxPrice:       to.Float64Ptr(0.05),
+				EvictionPolicy: api.SpotEvictionPolicyDelete,
+			},
 		},
 	}
 }
@@ -4,6 +4,7 @@ package computeskus
 // Licensed under the Apache License 2.0.
 
 import (
+	"strconv"
 	"strings"
 
 	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
@@ -13,6 +14,9 @@ const (
 	standardDisk          = "StandardSSD_LRS"
 	premiumDisk           = "Premium_LRS"
 	premiumDiskCapability = "PremiumIO"
+	vCPUsCapability       = "vCPUs"
+
+	confidentialComputingCapability = "ConfidentialComputingType"
 )
 
 // Zones returns zone information for the resource SKU
@@ -26,24 +30,113 @@ func Zones(sku *mgmtcompute.ResourceSku) []string {
 	return *(*sku.LocationInfo)[0].Zones
 }
 
+// ZonesWithCapability returns the zones of the resource SKU in which it has a
+// specific capability, such as UltraSSDAvailable
+func ZonesWithCapability(sku *mgmtcompute.ResourceSku, capabilityName string) []string {
+	if sku.LocationInfo == nil ||
+		len(*sku.LocationInfo) == 0 ||
+		(*sku.LocationInfo)[0].ZoneDetails == nil {
+		return nil
+	}
+
+	var zones []string
+	for _, zd := range *(*sku.LocationInfo)[0].ZoneDetails {
+		if zd.Name == nil || zd.Capabilities == nil {
+			continue
+		}
+
+		for _, c := range *zd.Capabilities {
+			if c.Name != nil && *c.Name == capabilityName && c.Value != nil && *c.Value == "True" {
+				zones = append(zones, *zd.Name...)
+				break
+			}
+		}
+	}
+
+	return zones
+}
+
+// AvailableZones returns the zones of the resource SKU in which it is not
+// restricted in a given location
+func AvailableZones(sku *mgmtcompute.ResourceSku, location string) []string {
+	restricted := map[string]struct{}{}
+	if sku.Restrictions != nil {
+		for _, restriction := range *sku.Restrictions {
+			if restriction.Type != mgmtcompute.Zone || restriction.RestrictionInfo == nil ||
+				restriction.RestrictionInfo.Locations == nil || restriction.RestrictionInfo.Zones == nil {
+				continue
+			}
+
+			for _, restrictedLocation := range *restriction.RestrictionInfo.Locations {
+				if strings.EqualFold(restrictedLocation, location) {
+					for _, zone := range *restriction.RestrictionInfo.Zones {
+						restricted[zone] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	var zones []string
+	for _, zone := range Zones(sku) {
+		if _, found := restricted[zone]; !found {
+			zones = append(zones, zone)
+		}
+	}
+
+	return zones
+}
+
 // HasCapability checks whether given resource SKU has specific capability
 func HasCapability(sku *mgmtcompute.ResourceSku, capabilityName string) bool {
+	value, found := capability(sku, capabilityName)
+	return found && value == "True"
+}
+
+// IsConfidential checks whether given resource SKU supports confidential
+// computing
+func IsConfidential(sku *mgmtcompute.ResourceSku) bool {
+	_, found := capability(sku, confidentialComputingCapability)
+	return found
+}
+
+// VCPUs returns the number of vCPUs of the given resource SKU, if it is known
+func VCPUs(sku *mgmtcompute.ResourceSku) (int, bool) {
+	value, found := capability(sku, vCPUsCapability)
+	if !found {
+		return 0, false
+	}
+
+	vcpus, err := strconv.Atoi(value)
+	return vcpus, err == nil
+}
+
+func capability(sku *mgmtcompute.ResourceSku, capabilityName string) (string, bool) {
 	if sku.Capabilities == nil {
-		return false
+		return "", false
 	}
 
 	for _, c := range *sku.Capabilities {
 		if *c.Name == capabilityName {
-			return *c.Value == "True"
+			if c.Value == nil {
+				return "", true
+			}
+			return *c.Value, true
 		}
 	}
 
-	return false
+	return "", false
 }
 
-// IsRestricted checks whether given resource SKU is restricted in a given location
+// IsRestricted checks whether given resource SKU is restricted in a given
+// location.  A SKU which is restricted only in some of the location's zones is
+// not; see AvailableZones.
 func IsRestricted(skus map[string]*mgmtcompute.ResourceSku, location, VMSize string) bool {
 	for _, restriction := range *skus[VMSize].Restrictions {
+		if restriction.Type == mgmtcompute.Zone {
+			continue
+		}
+
 		for _, restrictedLocation := range *restriction.RestrictionInfo.Locations {
 			if restrictedLocation == location {
 				return true
@@ -54,7 +147,7 @@ func IsRestricted(skus map[string]*mgmtcompute.ResourceSku, location, VMSize str
 	return false
 }
 
-// FilterVMSizes filters resource SKU by location and returns only virtual machines, their names, restrictions, location info, and capabilities.
+// FilterVMSizes filters resource SKU by location and returns only virtual machines, their names, families, restrictions, location info, and capabilities.
 func FilterVMSizes(skus []mgmtcompute.ResourceSku, location string) map[string]*mgmtcompute.ResourceSku {
 	vmskus := map[string]*mgmtcompute.ResourceSku{}
 	for _, sku := range skus {
@@ -75,6 +168,7 @@ func FilterVMSizes(skus []mgmtcompute.ResourceSku, location string) map[string]*
 		// a lot of data in memory.
 		vmskus[*sku.Name] = &mgmtcompute.ResourceSku{
 			Name:         sku.Name,
+			Family:       sku.Family,
 			Restrictions: sku.Restrictions,
 			LocationInfo: sku.LocationInfo,
 			Capabilities: sku.Capabilities,
@@ -57,6 +57,60 @@ func TestZones(t *testing.T) {
 	}
 }
 
+func TestZonesWithCapability(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		sku       *mgmtcompute.ResourceSku
+		wantZones []string
+	}{
+		{
+			name: "sku with zone details",
+			sku: &mgmtcompute.ResourceSku{
+				LocationInfo: &([]mgmtcompute.ResourceSkuLocationInfo{
+					{
+						Zones: &([]string{"1", "2", "3"}),
+						ZoneDetails: &([]mgmtcompute.ResourceSkuZoneDetails{
+							{
+								Name: &([]string{"1", "3"}),
+								Capabilities: &([]mgmtcompute.ResourceSkuCapabilities{
+									{Name: to.StringPtr("UltraSSDAvailable"), Value: to.StringPtr("True")},
+								}),
+							},
+							{
+								Name: &([]string{"2"}),
+								Capabilities: &([]mgmtcompute.ResourceSkuCapabilities{
+									{Name: to.StringPtr("UltraSSDAvailable"), Value: to.StringPtr("False")},
+								}),
+							},
+						}),
+					},
+				}),
+			},
+			wantZones: []string{"1", "3"},
+		},
+		{
+			name: "sku without zone details",
+			sku: &mgmtcompute.ResourceSku{
+				LocationInfo: &([]mgmtcompute.ResourceSkuLocationInfo{
+					{Zones: &([]string{"1", "2", "3"})},
+				}),
+			},
+		},
+		{
+			name: "sku with location info missing",
+			sku:  &mgmtcompute.ResourceSku{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			zones := ZonesWithCapability(tt.sku, "UltraSSDAvailable")
+
+			if !reflect.DeepEqual(tt.wantZones, zones) {
+				t.Error(cmp.Diff(tt.wantZones, zones))
+			}
+		})
+	}
+}
+
 func TestHasCapability(t *testing.T) {
 	fakeCapabilityName := "fakeCapability"
 
@@ -103,6 +157,137 @@ func TestHasCapability(t *testing.T) {
 	}
 }
 
+func TestAvailableZones(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		restrictions []mgmtcompute.ResourceSkuRestrictions
+		wantZones    []string
+	}{
+		{
+			name:      "no restrictions",
+			wantZones: []string{"1", "2", "3"},
+		},
+		{
+			name: "restricted in a zone",
+			restrictions: []mgmtcompute.ResourceSkuRestrictions{
+				{
+					Type: mgmtcompute.Zone,
+					RestrictionInfo: &mgmtcompute.ResourceSkuRestrictionInfo{
+						Locations: &[]string{"EastUS"},
+						Zones:     &[]string{"2"},
+					},
+				},
+			},
+			wantZones: []string{"1", "3"},
+		},
+		{
+			name: "restricted in a zone of another location",
+			restrictions: []mgmtcompute.ResourceSkuRestrictions{
+				{
+					Type: mgmtcompute.Zone,
+					RestrictionInfo: &mgmtcompute.ResourceSkuRestrictionInfo{
+						Locations: &[]string{"westus"},
+						Zones:     &[]string{"2"},
+					},
+				},
+			},
+			wantZones: []string{"1", "2", "3"},
+		},
+		{
+			name: "restricted in every zone",
+			restrictions: []mgmtcompute.ResourceSkuRestrictions{
+				{
+					Type: mgmtcompute.Zone,
+					RestrictionInfo: &mgmtcompute.ResourceSkuRestrictionInfo{
+						Locations: &[]string{"eastus"},
+						Zones:     &[]string{"1", "2", "3"},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sku := &mgmtcompute.ResourceSku{
+				LocationInfo: &[]mgmtcompute.ResourceSkuLocationInfo{
+					{Zones: &[]string{"1", "2", "3"}},
+				},
+				Restrictions: &tt.restrictions,
+			}
+
+			zones := AvailableZones(sku, "eastus")
+			if !reflect.DeepEqual(zones, tt.wantZones) {
+				t.Error(zones)
+			}
+		})
+	}
+}
+
+func TestIsConfidential(t *testing.T) {
+	sku := &mgmtcompute.ResourceSku{
+		Capabilities: &[]mgmtcompute.ResourceSkuCapabilities{
+			{
+				Name:  to.StringPtr("ConfidentialComputingType"),
+				Value: to.StringPtr("SNP"),
+			},
+		},
+	}
+	if !IsConfidential(sku) {
+		t.Error("expected confidential SKU")
+	}
+
+	if IsConfidential(&mgmtcompute.ResourceSku{}) {
+		t.Error("expected non-confidential SKU")
+	}
+}
+
+func TestVCPUs(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		capabilities *[]mgmtcompute.ResourceSkuCapabilities
+		wantVCPUs    int
+		wantOK       bool
+	}{
+		{
+			name: "vCPUs known",
+			capabilities: &[]mgmtcompute.ResourceSkuCapabilities{
+				{
+					Name:  to.StringPtr("PremiumIO"),
+					Value: to.StringPtr("True"),
+				},
+				{
+					Name:  to.StringPtr("vCPUs"),
+					Value: to.StringPtr("8"),
+				},
+			},
+			wantVCPUs: 8,
+			wantOK:    true,
+		},
+		{
+			name: "vCPUs not a number",
+			capabilities: &[]mgmtcompute.ResourceSkuCapabilities{
+				{
+					Name:  to.StringPtr("vCPUs"),
+					Value: to.StringPtr("eight"),
+				},
+			},
+		},
+		{
+			name:         "vCPUs missing",
+			capabilities: &[]mgmtcompute.ResourceSkuCapabilities{},
+		},
+		{
+			name: "no capabilities",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			vcpus, ok := VCPUs(&mgmtcompute.ResourceSku{Capabilities: tt.capabilities})
+			if vcpus != tt.wantVCPUs || ok != tt.wantOK {
+				t.Error(vcpus, ok)
+			}
+		})
+	}
+}
+
 func TestFilterVmSizes(t *testing.T) {
 	for _, tt := range []struct {
 		name             string
@@ -125,7 +310,8 @@ func TestFilterVmSizes(t *testing.T) {
 
 			wantResult: map[string]*mgmtcompute.ResourceSku{
 				"Fake_Sku": {
-					Name: to.StringPtr("Fake_Sku"),
+					Name:   to.StringPtr("Fake_Sku"),
+					Family: to.StringPtr("fakeFamily"),
 					Restrictions: &[]mgmtcompute.ResourceSkuRestrictions{{
 						ReasonCode: mgmtcompute.NotAvailableForSubscription}},
 					LocationInfo: &[]mgmtcompute.ResourceSkuLocationInfo{{
@@ -173,7 +359,8 @@ func TestFilterVmSizes(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			sku := []mgmtcompute.ResourceSku{
 				{
-					Name: to.StringPtr("Fake_Sku"),
+					Name:   to.StringPtr("Fake_Sku"),
+					Family: to.StringPtr("fakeFamily"),
 					Capabilities: &[]mgmtcompute.ResourceSkuCapabilities{
 						{
 							Name: to.StringPtr(tt.skuCapabilities),
@@ -232,6 +419,23 @@ func TestIsRestricted(t *testing.T) {
 			},
 			wantResult: true,
 		},
+		{
+			name:     "sku is restricted only in some zones",
+			location: "eastus",
+			vmsize:   "Standard_Sku_1",
+			sku: map[string]*mgmtcompute.ResourceSku{
+				"Standard_Sku_1": {Restrictions: &[]mgmtcompute.ResourceSkuRestrictions{
+					{
+						Type: mgmtcompute.Zone,
+						RestrictionInfo: &mgmtcompute.ResourceSkuRestrictionInfo{
+							Locations: &[]string{"eastus"},
+							Zones:     &[]string{"1"},
+						},
+					},
+				}},
+			},
+			wantResult: false,
+		},
 		{
 			name:     "sku is not restricted",
 			location: "eastus",
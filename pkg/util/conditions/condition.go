@@ -37,6 +37,9 @@ func SetCondition(ctx context.Context, c client.Client, cond *operatorv1.Operato
 
 		var changed bool
 		cluster.Status.Conditions, changed = setCondition(cluster.Status.Conditions, *cond)
+		if changed {
+			cluster.Status.ConditionHistory = recordTransition(cluster.Status.ConditionHistory, *cond, kubeclock.Now())
+		}
 
 		if cleanStaleConditions(cluster, role) {
 			changed = true
@@ -88,6 +91,17 @@ func cleanStaleConditions(cluster *arov1alpha1.Cluster, role string) (changed bo
 
 	cluster.Status.Conditions = conditions
 
+	history := make([]arov1alpha1.ConditionTransition, 0, len(cluster.Status.ConditionHistory))
+	for _, transition := range cluster.Status.ConditionHistory {
+		if _, ok := current[transition.Type]; ok || conditionIsControllerStatus(transition.Type) {
+			history = append(history, transition)
+		} else {
+			changed = true
+		}
+	}
+
+	cluster.Status.ConditionHistory = history
+
 	if role == operator.RoleMaster && cluster.Status.OperatorVersion != version.GitCommit {
 		cluster.Status.OperatorVersion = version.GitCommit
 		changed = true
@@ -66,6 +66,13 @@ func TestSetCondition(t *testing.T) {
 					},
 				},
 				OperatorVersion: version,
+				ConditionHistory: []arov1alpha1.ConditionTransition{
+					{
+						Type:   arov1alpha1.InternetReachableFromMaster,
+						Status: operatorv1.ConditionFalse,
+						Time:   transitionTime,
+					},
+				},
 			},
 		},
 		{
@@ -162,6 +169,13 @@ func TestSetCondition(t *testing.T) {
 					},
 				},
 				OperatorVersion: version,
+				ConditionHistory: []arov1alpha1.ConditionTransition{
+					{
+						Type:   arov1alpha1.InternetReachableFromMaster,
+						Status: operatorv1.ConditionTrue,
+						Time:   transitionTime,
+					},
+				},
 			},
 		},
 		{
@@ -176,6 +190,13 @@ func TestSetCondition(t *testing.T) {
 						},
 					},
 					OperatorVersion: version,
+					ConditionHistory: []arov1alpha1.ConditionTransition{
+						{
+							Type:   "staleType",
+							Status: operatorv1.ConditionTrue,
+							Time:   metav1.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+						},
+					},
 				},
 			},
 			input: &operatorv1.OperatorCondition{
@@ -191,6 +212,13 @@ func TestSetCondition(t *testing.T) {
 					},
 				},
 				OperatorVersion: version,
+				ConditionHistory: []arov1alpha1.ConditionTransition{
+					{
+						Type:   arov1alpha1.InternetReachableFromMaster,
+						Status: operatorv1.ConditionTrue,
+						Time:   transitionTime,
+					},
+				},
 			},
 		},
 	} {
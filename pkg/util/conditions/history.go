@@ -0,0 +1,82 @@
+package conditions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+)
+
+// historyLength is the number of transitions kept per condition type
+const historyLength = 10
+
+// recordTransition appends cond to history as changed at now, dropping the
+// oldest transitions of its type beyond historyLength
+func recordTransition(history []arov1alpha1.ConditionTransition, cond operatorv1.OperatorCondition, now time.Time) []arov1alpha1.ConditionTransition {
+	history = append(history, arov1alpha1.ConditionTransition{
+		Type:    cond.Type,
+		Status:  cond.Status,
+		Reason:  cond.Reason,
+		Message: cond.Message,
+		Time:    metav1.Time{Time: now},
+	})
+
+	var count int
+	for _, t := range history {
+		if t.Type == cond.Type {
+			count++
+		}
+	}
+
+	trimmed := make([]arov1alpha1.ConditionTransition, 0, len(history))
+	for _, t := range history {
+		if t.Type == cond.Type && count > historyLength {
+			count--
+			continue
+		}
+		trimmed = append(trimmed, t)
+	}
+
+	return trimmed
+}
+
+// History returns the recorded transitions of the condition of type t, oldest
+// first
+func History(history []arov1alpha1.ConditionTransition, t string) []arov1alpha1.ConditionTransition {
+	var transitions []arov1alpha1.ConditionTransition
+	for _, transition := range history {
+		if transition.Type == t {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions
+}
+
+// TransitionsSince returns the number of recorded transitions of the condition
+// of type t at or after since.  A condition which transitions more often than
+// its check interval would suggest is flapping.
+func TransitionsSince(history []arov1alpha1.ConditionTransition, t string, since time.Time) int {
+	var count int
+	for _, transition := range history {
+		if transition.Type == t && !transition.Time.Time.Before(since) {
+			count++
+		}
+	}
+	return count
+}
+
+// LastTransition returns the most recent recorded transition of the condition
+// of type t, or nil if there is none
+func LastTransition(history []arov1alpha1.ConditionTransition, t string) *arov1alpha1.ConditionTransition {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Type == t {
+			return &history[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package conditions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+)
+
+func TestRecordTransition(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var history []arov1alpha1.ConditionTransition
+	history = recordTransition(history, operatorv1.OperatorCondition{
+		Type:   arov1alpha1.MachineValid,
+		Status: operatorv1.ConditionTrue,
+	}, start)
+
+	// flap InternetReachableFromMaster more often than historyLength
+	for i := 0; i < historyLength+5; i++ {
+		status := operatorv1.ConditionTrue
+		if i%2 == 1 {
+			status = operatorv1.ConditionFalse
+		}
+
+		history = recordTransition(history, operatorv1.OperatorCondition{
+			Type:    arov1alpha1.InternetReachableFromMaster,
+			Status:  status,
+			Reason:  "CheckDone",
+			Message: "check done",
+		}, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	if len(history) != historyLength+1 {
+		t.Fatal(len(history))
+	}
+
+	// other condition types are not dropped
+	if got := History(history, arov1alpha1.MachineValid); len(got) != 1 {
+		t.Error(got)
+	}
+
+	got := History(history, arov1alpha1.InternetReachableFromMaster)
+	if len(got) != historyLength {
+		t.Fatal(len(got))
+	}
+	if !got[0].Time.Time.Equal(start.Add(5 * time.Minute)) {
+		t.Error(got[0].Time)
+	}
+
+	last := LastTransition(history, arov1alpha1.InternetReachableFromMaster)
+	if last == nil || !last.Time.Time.Equal(start.Add(time.Duration(historyLength+4)*time.Minute)) ||
+		last.Status != operatorv1.ConditionTrue || last.Reason != "CheckDone" {
+		t.Error(last)
+	}
+
+	if LastTransition(history, arov1alpha1.DefaultIngressCertificate) != nil {
+		t.Error("unexpected transition")
+	}
+
+	if n := TransitionsSince(history, arov1alpha1.InternetReachableFromMaster, start.Add(10*time.Minute)); n != 5 {
+		t.Error(n)
+	}
+}
@@ -26,6 +26,8 @@ type Manager interface {
 	Create(context.Context, *api.OpenShiftCluster) error
 	Update(context.Context, *api.OpenShiftCluster, string) error
 	CreateOrUpdateRouter(context.Context, *api.OpenShiftCluster, string) error
+	UpdateIPv6(context.Context, *api.OpenShiftCluster, string) error
+	CreateOrUpdateRouterIPv6(context.Context, *api.OpenShiftCluster, string) error
 	Delete(context.Context, *api.OpenShiftCluster) error
 }
 
@@ -122,6 +124,28 @@ func (m *manager) CreateOrUpdateRouter(ctx context.Context, oc *api.OpenShiftClu
 	return err
 }
 
+// UpdateIPv6 sets the AAAA record of the API server of a dual-stack cluster.
+// It must be called after Update, which checks that the domain is ours.
+func (m *manager) UpdateIPv6(ctx context.Context, oc *api.OpenShiftCluster, ip string) error {
+	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
+	if err != nil || prefix == "" {
+		return err
+	}
+
+	return m.createOrUpdateAAAA(ctx, oc, "api."+prefix, ip)
+}
+
+// CreateOrUpdateRouterIPv6 sets the AAAA record of the default router of a
+// dual-stack cluster
+func (m *manager) CreateOrUpdateRouterIPv6(ctx context.Context, oc *api.OpenShiftCluster, routerIP string) error {
+	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
+	if err != nil || prefix == "" {
+		return err
+	}
+
+	return m.createOrUpdateAAAA(ctx, oc, "*.apps."+prefix, routerIP)
+}
+
 func (m *manager) Delete(ctx context.Context, oc *api.OpenShiftCluster) error {
 	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
 	if err != nil || prefix == "" {
@@ -141,6 +165,17 @@ func (m *manager) Delete(ctx context.Context, oc *api.OpenShiftCluster) error {
 		return nil
 	}
 
+	// the A record of the API server marks the domain as ours, so it is
+	// deleted last
+	if oc.Properties.NetworkProfile.IsDualStack() {
+		for _, name := range []string{"*.apps." + prefix, "api." + prefix} {
+			_, err = m.recordsets.Delete(ctx, m.env.ResourceGroup(), m.env.Domain(), name, mgmtdns.AAAA, "")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	_, err = m.recordsets.Delete(ctx, m.env.ResourceGroup(), m.env.Domain(), "*.apps."+prefix, mgmtdns.A, "")
 	if err != nil {
 		return err
@@ -179,6 +214,24 @@ func (m *manager) createOrUpdate(ctx context.Context, oc *api.OpenShiftCluster,
 	return err
 }
 
+func (m *manager) createOrUpdateAAAA(ctx context.Context, oc *api.OpenShiftCluster, name, ip string) error {
+	_, err := m.recordsets.CreateOrUpdate(ctx, m.env.ResourceGroup(), m.env.Domain(), name, mgmtdns.AAAA, mgmtdns.RecordSet{
+		RecordSetProperties: &mgmtdns.RecordSetProperties{
+			Metadata: map[string]*string{
+				resourceID: &oc.ID,
+			},
+			TTL: to.Int64Ptr(300),
+			AaaaRecords: &[]mgmtdns.AaaaRecord{
+				{
+					Ipv6Address: &ip,
+				},
+			},
+		},
+	}, "", "")
+
+	return err
+}
+
 func (m *manager) managedDomainPrefix(clusterDomain string) (string, error) {
 	managedDomain, err := ManagedDomain(m.env, clusterDomain)
 	if err != nil || managedDomain == "" {
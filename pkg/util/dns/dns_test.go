@@ -419,6 +419,18 @@ func TestDelete(t *testing.T) {
 		},
 	}
 
+	dualStackOc := &api.OpenShiftCluster{
+		Properties: api.OpenShiftClusterProperties{
+			ClusterProfile: api.ClusterProfile{
+				Domain: "domain",
+			},
+			NetworkProfile: api.NetworkProfile{
+				PodCIDRIPv6:     "fd01::/48",
+				ServiceCIDRIPv6: "fd02::/112",
+			},
+		},
+	}
+
 	unmanagedOc := &api.OpenShiftCluster{
 		Properties: api.OpenShiftClusterProperties{
 			ClusterProfile: api.ClusterProfile{
@@ -470,6 +482,37 @@ func TestDelete(t *testing.T) {
 					Return(autorest.Response{}, nil)
 			},
 		},
+		{
+			name: "managed dual-stack, our record exists",
+			oc:   dualStackOc,
+			mocks: func(tt *test, recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Return(mgmtdns.RecordSet{
+						Etag: to.StringPtr("etag"),
+						RecordSetProperties: &mgmtdns.RecordSetProperties{
+							Metadata: map[string]*string{
+								"resourceId": &tt.oc.ID,
+							},
+						},
+					}, nil)
+
+				gomock.InOrder(
+					recordsets.EXPECT().
+						Delete(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.AAAA, "").
+						Return(autorest.Response{}, nil),
+					recordsets.EXPECT().
+						Delete(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.AAAA, "").
+						Return(autorest.Response{}, nil),
+					recordsets.EXPECT().
+						Delete(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.A, "").
+						Return(autorest.Response{}, nil),
+					recordsets.EXPECT().
+						Delete(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A, "etag").
+						Return(autorest.Response{}, nil),
+				)
+			},
+		},
 		{
 			name: "managed, someone else's record exists",
 			oc:   managedOc,
@@ -524,6 +567,98 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestCreateOrUpdateIPv6(t *testing.T) {
+	ctx := context.Background()
+
+	managedOc := &api.OpenShiftCluster{
+		ID: "id",
+		Properties: api.OpenShiftClusterProperties{
+			ClusterProfile: api.ClusterProfile{
+				Domain: "domain",
+			},
+		},
+	}
+
+	unmanagedOc := &api.OpenShiftCluster{
+		Properties: api.OpenShiftClusterProperties{
+			ClusterProfile: api.ClusterProfile{
+				Domain: "domain.notmanaged",
+			},
+		},
+	}
+
+	wantRecordSet := mgmtdns.RecordSet{
+		RecordSetProperties: &mgmtdns.RecordSetProperties{
+			Metadata: map[string]*string{
+				"resourceId": to.StringPtr("id"),
+			},
+			TTL: to.Int64Ptr(300),
+			AaaaRecords: &[]mgmtdns.AaaaRecord{
+				{
+					Ipv6Address: to.StringPtr("2001:db8::1"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		oc      *api.OpenShiftCluster
+		f       func(Manager, context.Context, *api.OpenShiftCluster, string) error
+		mocks   func(*mock_dns.MockRecordSetsClient)
+		wantErr string
+	}{
+		{
+			name: "managed, API server",
+			oc:   managedOc,
+			f:    Manager.UpdateIPv6,
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					CreateOrUpdate(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.AAAA, wantRecordSet, "", "").
+					Return(mgmtdns.RecordSet{}, nil)
+			},
+		},
+		{
+			name: "managed, router",
+			oc:   managedOc,
+			f:    Manager.CreateOrUpdateRouterIPv6,
+			mocks: func(recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					CreateOrUpdate(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.AAAA, wantRecordSet, "", "").
+					Return(mgmtdns.RecordSet{}, fmt.Errorf("random error"))
+			},
+			wantErr: "random error",
+		},
+		{
+			name: "unmanaged",
+			oc:   unmanagedOc,
+			f:    Manager.UpdateIPv6,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			env := mock_env.NewMockInterface(controller)
+			env.EXPECT().ResourceGroup().AnyTimes().Return("rpResourcegroup")
+			env.EXPECT().Domain().AnyTimes().Return("domain")
+
+			recordsets := mock_dns.NewMockRecordSetsClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(recordsets)
+			}
+
+			m := &manager{
+				env:        env,
+				recordsets: recordsets,
+			}
+
+			err := tt.f(m, ctx, tt.oc, "2001:db8::1")
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestManagedDomain(t *testing.T) {
 	for _, tt := range []struct {
 		domain  string
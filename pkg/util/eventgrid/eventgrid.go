@@ -0,0 +1,130 @@
+package eventgrid
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+)
+
+const (
+	// scope is the Microsoft Entra scope for publishing to Event Grid topics
+	scope = "https://eventgrid.azure.net/.default"
+
+	apiVersion = "2018-01-01"
+)
+
+// Event is an event in the Event Grid event schema
+type Event struct {
+	ID          string      `json:"id"`
+	Subject     string      `json:"subject"`
+	EventType   string      `json:"eventType"`
+	EventTime   time.Time   `json:"eventTime"`
+	Data        interface{} `json:"data"`
+	DataVersion string      `json:"dataVersion"`
+}
+
+// Publisher publishes events to an Event Grid topic
+type Publisher interface {
+	Publish(ctx context.Context, events ...*Event) error
+}
+
+type publisher struct {
+	endpoint string
+	cred     azcore.TokenCredential
+	cli      *http.Client
+}
+
+// NewPublisherFromEnvironment returns a publisher to the Event Grid topic at
+// EVENTGRID_TOPIC_ENDPOINT, authenticated with the RP's managed identity, or
+// nil if EVENTGRID_TOPIC_ENDPOINT is not set, in which case no events are
+// published
+func NewPublisherFromEnvironment(_env env.Core) (Publisher, error) {
+	if os.Getenv("EVENTGRID_TOPIC_ENDPOINT") == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(os.Getenv("EVENTGRID_TOPIC_ENDPOINT"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid EVENTGRID_TOPIC_ENDPOINT %q", os.Getenv("EVENTGRID_TOPIC_ENDPOINT"))
+	}
+
+	cred, err := _env.NewMSITokenCredential()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPublisher(u.String(), cred), nil
+}
+
+func NewPublisher(endpoint string, cred azcore.TokenCredential) Publisher {
+	return &publisher{
+		endpoint: endpoint,
+		cred:     cred,
+		cli: &http.Client{
+			Timeout: time.Minute,
+		},
+	}
+}
+
+// Publish sends the events to the topic in a single request
+func (p *publisher) Publish(ctx context.Context, events ...*Event) error {
+	b, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	token, err := p.cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{scope}})
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(p.endpoint)
+	if err != nil {
+		return err
+	}
+	u.RawQuery = url.Values{"api-version": []string{apiVersion}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var egErr struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&egErr)
+
+		return fmt.Errorf("unexpected status code %d: %s: %s", resp.StatusCode, egErr.Error.Code, egErr.Error.Message)
+	}
+
+	return nil
+}
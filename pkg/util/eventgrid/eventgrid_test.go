@@ -0,0 +1,82 @@
+package eventgrid
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	if !reflect.DeepEqual(options.Scopes, []string{scope}) {
+		return azcore.AccessToken{}, nil
+	}
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func TestPublish(t *testing.T) {
+	ctx := context.Background()
+
+	var published []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":"Unauthorized","message":"invalid token"}}`))
+			return
+		}
+
+		if r.Method != http.MethodPost || r.URL.Path != "/api/events" || r.URL.Query().Get("api-version") != apiVersion {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&published)
+	}))
+	defer srv.Close()
+
+	eventTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	p := NewPublisher(srv.URL+"/api/events", fakeCredential{})
+	err := p.Publish(ctx, &Event{
+		ID:          "id",
+		Subject:     "subject",
+		EventType:   "type",
+		EventTime:   eventTime,
+		Data:        map[string]string{"key": "value"},
+		DataVersion: "1.0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(published, []map[string]interface{}{
+		{
+			"id":          "id",
+			"subject":     "subject",
+			"eventType":   "type",
+			"eventTime":   "2024-01-01T00:00:00Z",
+			"data":        map[string]interface{}{"key": "value"},
+			"dataVersion": "1.0",
+		},
+	}) {
+		t.Error(published)
+	}
+
+	p = NewPublisher(srv.URL+"/missing", fakeCredential{})
+	err = p.Publish(ctx, &Event{})
+	if err == nil || !strings.Contains(err.Error(), "unexpected status code 404") {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,59 @@
+package feature
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/cache"
+)
+
+// Client reports whether subscriptions are registered for AFEC features
+type Client interface {
+	IsRegisteredForFeature(ctx context.Context, subscriptionID, feature string) (bool, error)
+}
+
+// subscriptionGetter is the subset of database.Subscriptions used by client
+type subscriptionGetter interface {
+	Get(ctx context.Context, id string) (*api.SubscriptionDocument, error)
+}
+
+type client struct {
+	dbSubscriptions subscriptionGetter
+
+	// properties caches the properties of the subscriptions looked up
+	properties *cache.Cache[string, *api.SubscriptionProperties]
+}
+
+// NewClient returns a Client which reads the registered features of a
+// subscription from the subscription document ARM last sent us, caching them
+// for ttl
+func NewClient(dbSubscriptions subscriptionGetter, ttl time.Duration) Client {
+	return &client{
+		dbSubscriptions: dbSubscriptions,
+		properties:      cache.New[string, *api.SubscriptionProperties](ttl),
+	}
+}
+
+func (c *client) IsRegisteredForFeature(ctx context.Context, subscriptionID, feature string) (bool, error) {
+	properties, err := c.properties.GetOrLoad(ctx, subscriptionID, func(ctx context.Context) (*api.SubscriptionProperties, error) {
+		doc, err := c.dbSubscriptions.Get(ctx, subscriptionID)
+		if err != nil {
+			return nil, err
+		}
+
+		if doc.Subscription == nil {
+			return nil, nil
+		}
+
+		return doc.Subscription.Properties, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return properties != nil && IsRegisteredForFeature(properties, feature), nil
+}
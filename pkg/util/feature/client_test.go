@@ -0,0 +1,77 @@
+package feature
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type fakeSubscriptions struct {
+	docs  map[string]*api.SubscriptionDocument
+	calls int
+}
+
+func (f *fakeSubscriptions) Get(ctx context.Context, id string) (*api.SubscriptionDocument, error) {
+	f.calls++
+
+	doc, ok := f.docs[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return doc, nil
+}
+
+func TestClientIsRegisteredForFeature(t *testing.T) {
+	ctx := context.Background()
+	subscriptionID := "00000000-0000-0000-0000-000000000000"
+
+	dbSubscriptions := &fakeSubscriptions{
+		docs: map[string]*api.SubscriptionDocument{
+			subscriptionID: {
+				ID: subscriptionID,
+				Subscription: &api.Subscription{
+					Properties: &api.SubscriptionProperties{
+						RegisteredFeatures: []api.RegisteredFeatureProfile{
+							{Name: "Test/Registered", State: "Registered"},
+							{Name: "Test/Pending", State: "Pending"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	c := NewClient(dbSubscriptions, time.Minute)
+
+	for _, tt := range []struct {
+		feature        string
+		wantRegistered bool
+	}{
+		{feature: "Test/Registered", wantRegistered: true},
+		{feature: "Test/Pending"},
+		{feature: "Test/Unknown"},
+	} {
+		registered, err := c.IsRegisteredForFeature(ctx, subscriptionID, tt.feature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if registered != tt.wantRegistered {
+			t.Errorf("%s: got %t", tt.feature, registered)
+		}
+	}
+
+	if dbSubscriptions.calls != 1 {
+		t.Errorf("got %d database calls, expected 1", dbSubscriptions.calls)
+	}
+
+	_, err := c.IsRegisteredForFeature(ctx, "11111111-1111-1111-1111-111111111111", "Test/Registered")
+	utilerror.AssertErrorMessage(t, err, "not found")
+}
@@ -0,0 +1,113 @@
+package health
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// checkTimeout bounds the time taken by each readiness check
+const checkTimeout = 5 * time.Second
+
+// Check reports whether a dependency of a service is ready
+type Check func(context.Context) error
+
+// Health serves the /healthz and /readyz endpoints of a service.  /healthz
+// reports that the process is alive.  /readyz reports that the service has
+// started, is not shutting down, and that all of its checks pass.
+type Health struct {
+	mu     sync.RWMutex
+	ready  bool
+	checks map[string]Check
+}
+
+// New returns a Health which is not yet ready
+func New() *Health {
+	return &Health{
+		checks: map[string]Check{},
+	}
+}
+
+// AddCheck adds a readiness check, replacing any existing check of the same
+// name
+func (h *Health) AddCheck(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks[name] = check
+}
+
+// SetReady marks the service as started (true) or shutting down (false)
+func (h *Health) SetReady(ready bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.ready = ready
+}
+
+// Handler returns the handler serving /healthz and /readyz
+func (h *Health) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", h.readyz)
+	return mux
+}
+
+func (h *Health) readyz(w http.ResponseWriter, r *http.Request) {
+	failures := h.failures(r.Context())
+	if len(failures) > 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, strings.Join(failures, "\n"))
+		return
+	}
+
+	fmt.Fprintln(w, "ok")
+}
+
+// failures returns a description of each reason the service is not ready, in
+// a stable order
+func (h *Health) failures(ctx context.Context) []string {
+	h.mu.RLock()
+	ready := h.ready
+	checks := make(map[string]Check, len(h.checks))
+	for name, check := range h.checks {
+		checks[name] = check
+	}
+	h.mu.RUnlock()
+
+	var failures []string
+	if !ready {
+		failures = append(failures, "not started or shutting down")
+	}
+
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		err := runCheck(ctx, checks[name])
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+
+	return failures
+}
+
+func runCheck(ctx context.Context, check Check) error {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	return check(ctx)
+}
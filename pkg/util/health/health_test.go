@@ -0,0 +1,48 @@
+package health
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealth(t *testing.T) {
+	h := New()
+
+	get := func(path string) (int, string) {
+		w := httptest.NewRecorder()
+		h.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code, w.Body.String()
+	}
+
+	if code, _ := get("/healthz"); code != http.StatusOK {
+		t.Error(code)
+	}
+
+	if code, body := get("/readyz"); code != http.StatusServiceUnavailable || body != "not started or shutting down\n" {
+		t.Error(code, body)
+	}
+
+	h.SetReady(true)
+
+	if code, _ := get("/readyz"); code != http.StatusOK {
+		t.Error(code)
+	}
+
+	h.AddCheck("b", func(context.Context) error { return errors.New("failed") })
+	h.AddCheck("a", func(context.Context) error { return errors.New("also failed") })
+
+	if code, body := get("/readyz"); code != http.StatusServiceUnavailable || body != "a: also failed\nb: failed\n" {
+		t.Error(code, body)
+	}
+
+	// a liveness check does not depend on readiness
+	if code, _ := get("/healthz"); code != http.StatusOK {
+		t.Error(code)
+	}
+}
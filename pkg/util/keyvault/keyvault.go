@@ -9,9 +9,11 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	azkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
@@ -37,8 +39,10 @@ type Manager interface {
 	GetBase64Secret(context.Context, string, string) ([]byte, error)
 	GetBase64Secrets(context.Context, string) ([][]byte, error)
 	GetCertificateSecret(context.Context, string) (*rsa.PrivateKey, []*x509.Certificate, error)
+	GetLatestCertificateSecret(context.Context, string) (*rsa.PrivateKey, []*x509.Certificate, error)
 	GetSecret(context.Context, string) (azkeyvault.SecretBundle, error)
 	GetSecrets(context.Context) ([]azkeyvault.SecretItem, error)
+	ImportCertificate(context.Context, string, *rsa.PrivateKey, []*x509.Certificate) error
 	SetCertificateIssuer(ctx context.Context, issuerName string, parameter azkeyvault.CertificateIssuerSetParameters) (result azkeyvault.IssuerBundle, err error)
 	SetSecret(context.Context, string, azkeyvault.SecretSetParameters) error
 	WaitForCertificateOperation(context.Context, string) error
@@ -47,6 +51,20 @@ type Manager interface {
 type manager struct {
 	kv          keyvault.BaseClient
 	keyvaultURI string
+
+	now func() time.Time
+
+	// certificates caches the most recently fetched version of each
+	// certificate, so that GetLatestCertificateSecret only fetches the secret
+	// when a new version is rolled out
+	mu           sync.Mutex
+	certificates map[string]*cachedCertificate
+}
+
+type cachedCertificate struct {
+	version string
+	key     *rsa.PrivateKey
+	certs   []*x509.Certificate
 }
 
 // NewManager returns a pointer to a manager containing a BaseClient.  The
@@ -54,8 +72,10 @@ type manager struct {
 // access a key vault.
 func NewManager(kvAuthorizer autorest.Authorizer, keyvaultURI string) Manager {
 	return &manager{
-		kv:          keyvault.New(kvAuthorizer),
-		keyvaultURI: keyvaultURI,
+		kv:           keyvault.New(kvAuthorizer),
+		keyvaultURI:  keyvaultURI,
+		now:          time.Now,
+		certificates: map[string]*cachedCertificate{},
 	}
 }
 
@@ -196,6 +216,97 @@ func (m *manager) GetCertificateSecret(ctx context.Context, secretName string) (
 	return key, certs, nil
 }
 
+// GetLatestCertificateSecret returns the key and certificates of the most
+// recently created version of a certificate which is enabled and currently
+// valid.  Unlike GetCertificateSecret, a new version which is disabled or not
+// yet valid is skipped, so that a rotation can be staged in advance.
+func (m *manager) GetLatestCertificateSecret(ctx context.Context, certificateName string) (*rsa.PrivateKey, []*x509.Certificate, error) {
+	versions, err := m.kv.GetCertificateVersions(ctx, m.keyvaultURI, certificateName, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version := latestEnabledVersion(versions, m.now())
+	if version == "" {
+		return nil, nil, fmt.Errorf("no enabled version of certificate %q found", certificateName)
+	}
+
+	m.mu.Lock()
+	cached := m.certificates[certificateName]
+	m.mu.Unlock()
+
+	if cached != nil && cached.version == version {
+		return cached.key, cached.certs, nil
+	}
+
+	// a certificate's key and certificates are held in the secret of the same
+	// name and version
+	bundle, err := m.kv.GetSecret(ctx, m.keyvaultURI, certificateName, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, certs, err := utilpem.Parse([]byte(*bundle.Value))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if key == nil {
+		return nil, nil, fmt.Errorf("no private key found")
+	}
+
+	if len(certs) == 0 {
+		return nil, nil, fmt.Errorf("no certificate found")
+	}
+
+	m.mu.Lock()
+	m.certificates[certificateName] = &cachedCertificate{
+		version: version,
+		key:     key,
+		certs:   certs,
+	}
+	m.mu.Unlock()
+
+	return key, certs, nil
+}
+
+// latestEnabledVersion returns the version of the most recently created
+// certificate in versions which is enabled and valid at now
+func latestEnabledVersion(versions []azkeyvault.CertificateItem, now time.Time) string {
+	var latest *azkeyvault.CertificateItem
+	for i, v := range versions {
+		if v.ID == nil || v.Attributes == nil || !to.Bool(v.Attributes.Enabled) {
+			continue
+		}
+
+		if v.Attributes.NotBefore != nil && now.Before(time.Time(*v.Attributes.NotBefore)) {
+			continue
+		}
+
+		if v.Attributes.Expires != nil && !now.Before(time.Time(*v.Attributes.Expires)) {
+			continue
+		}
+
+		if latest == nil || createdAt(v).After(createdAt(*latest)) {
+			latest = &versions[i]
+		}
+	}
+
+	if latest == nil {
+		return ""
+	}
+
+	return filepath.Base(*latest.ID)
+}
+
+func createdAt(v azkeyvault.CertificateItem) time.Time {
+	if v.Attributes.Created == nil {
+		return time.Time{}
+	}
+
+	return time.Time(*v.Attributes.Created)
+}
+
 func (m *manager) GetSecret(ctx context.Context, secretName string) (azkeyvault.SecretBundle, error) {
 	return m.kv.GetSecret(ctx, m.keyvaultURI, secretName, "")
 }
@@ -204,6 +315,33 @@ func (m *manager) GetSecrets(ctx context.Context) ([]azkeyvault.SecretItem, erro
 	return m.kv.GetSecrets(ctx, m.keyvaultURI, nil)
 }
 
+// ImportCertificate imports key and certs as a new version of a certificate,
+// e.g. to roll out a first party or Geneva certificate issued elsewhere
+func (m *manager) ImportCertificate(ctx context.Context, certificateName string, key *rsa.PrivateKey, certs []*x509.Certificate) error {
+	// Key Vault expects the private key in PKCS#8 form
+	b, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b})
+
+	pemCerts, err := utilpem.Encode(certs...)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.kv.ImportCertificate(ctx, m.keyvaultURI, certificateName, azkeyvault.CertificateImportParameters{
+		Base64EncodedCertificate: to.StringPtr(string(append(pemKey, pemCerts...))),
+		CertificatePolicy: &azkeyvault.CertificatePolicy{
+			SecretProperties: &azkeyvault.SecretProperties{
+				ContentType: to.StringPtr("application/x-pem-file"),
+			},
+		},
+	})
+	return err
+}
+
 func (m *manager) SetCertificateIssuer(ctx context.Context, issuerName string, parameter azkeyvault.CertificateIssuerSetParameters) (azkeyvault.IssuerBundle, error) {
 	return m.kv.SetCertificateIssuer(ctx, m.keyvaultURI, issuerName, parameter)
 }
@@ -4,8 +4,19 @@ package keyvault
 // Licensed under the Apache License 2.0.
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
+
+	azkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+
+	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/keyvault"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
 )
 
 func TestShortCommonName(t *testing.T) {
@@ -36,3 +47,140 @@ func TestShortCommonName(t *testing.T) {
 		})
 	}
 }
+
+func TestLatestEnabledVersion(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	version := func(name string, enabled bool, created, notBefore, expires time.Duration) azkeyvault.CertificateItem {
+		v := azkeyvault.CertificateItem{
+			ID: to.StringPtr("https://vault.vault.azure.net/certificates/cert/" + name),
+			Attributes: &azkeyvault.CertificateAttributes{
+				Enabled: to.BoolPtr(enabled),
+				Created: unixTime(now.Add(created)),
+				Expires: unixTime(now.Add(expires)),
+			},
+		}
+		if notBefore != 0 {
+			v.Attributes.NotBefore = unixTime(now.Add(notBefore))
+		}
+		return v
+	}
+
+	for _, tt := range []struct {
+		name     string
+		versions []azkeyvault.CertificateItem
+		want     string
+	}{
+		{
+			name: "no versions",
+		},
+		{
+			name: "newest enabled version wins",
+			versions: []azkeyvault.CertificateItem{
+				version("old", true, -48*time.Hour, 0, time.Hour),
+				version("new", true, -24*time.Hour, 0, time.Hour),
+			},
+			want: "new",
+		},
+		{
+			name: "disabled version skipped",
+			versions: []azkeyvault.CertificateItem{
+				version("old", true, -48*time.Hour, 0, time.Hour),
+				version("new", false, -24*time.Hour, 0, time.Hour),
+			},
+			want: "old",
+		},
+		{
+			name: "not yet valid version skipped",
+			versions: []azkeyvault.CertificateItem{
+				version("old", true, -48*time.Hour, 0, time.Hour),
+				version("new", true, -24*time.Hour, time.Hour, 2*time.Hour),
+			},
+			want: "old",
+		},
+		{
+			name: "expired version skipped",
+			versions: []azkeyvault.CertificateItem{
+				version("old", true, -48*time.Hour, 0, -time.Hour),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := latestEnabledVersion(tt.versions, now)
+			if got != tt.want {
+				t.Errorf("got %q, wanted %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetLatestCertificateSecret(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	key, certs, err := utiltls.GenerateKeyAndCertificate("test", nil, nil, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pemKey, err := utilpem.Encode(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pemCerts, err := utilpem.Encode(certs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := azkeyvault.SecretBundle{
+		Value: to.StringPtr(string(append(pemKey, pemCerts...))),
+	}
+
+	version := func(name string) azkeyvault.CertificateItem {
+		return azkeyvault.CertificateItem{
+			ID: to.StringPtr("https://vault.vault.azure.net/certificates/cert/" + name),
+			Attributes: &azkeyvault.CertificateAttributes{
+				Enabled: to.BoolPtr(true),
+				Created: unixTime(now.Add(-time.Hour)),
+			},
+		}
+	}
+
+	kv := mock_keyvault.NewMockBaseClient(controller)
+	gomock.InOrder(
+		kv.EXPECT().GetCertificateVersions(ctx, "https://vault.vault.azure.net/", "cert", nil).Return([]azkeyvault.CertificateItem{version("v1")}, nil),
+		kv.EXPECT().GetSecret(ctx, "https://vault.vault.azure.net/", "cert", "v1").Return(secret, nil),
+		// unchanged version is served from the cache
+		kv.EXPECT().GetCertificateVersions(ctx, "https://vault.vault.azure.net/", "cert", nil).Return([]azkeyvault.CertificateItem{version("v1")}, nil),
+		// new version is fetched
+		kv.EXPECT().GetCertificateVersions(ctx, "https://vault.vault.azure.net/", "cert", nil).Return([]azkeyvault.CertificateItem{version("v2")}, nil),
+		kv.EXPECT().GetSecret(ctx, "https://vault.vault.azure.net/", "cert", "v2").Return(secret, nil),
+	)
+
+	m := &manager{
+		kv:           kv,
+		keyvaultURI:  "https://vault.vault.azure.net/",
+		now:          time.Now,
+		certificates: map[string]*cachedCertificate{},
+	}
+
+	for i := 0; i < 3; i++ {
+		gotKey, gotCerts, err := m.GetLatestCertificateSecret(ctx, "cert")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !gotKey.Equal(key) || len(gotCerts) != 1 || !gotCerts[0].Equal(certs[0]) {
+			t.Error("unexpected key or certificates")
+		}
+	}
+}
+
+func unixTime(t time.Time) *date.UnixTime {
+	u := date.UnixTime(t)
+	return &u
+}
@@ -10,9 +10,7 @@ import (
 
 func (p *prod) UseCheckAccess(ctx context.Context) (bool, error) {
 	// TODO: Replace with RP Live Service Config (KeyVault)
-	checkAccess := os.Getenv(useCheckAccess)
-	if checkAccess == "enabled" {
-		return true, nil
-	}
-	return false, nil
+	// CheckAccess is used unless it is explicitly disabled, in which case
+	// permissions are inferred from the role assignments instead
+	return os.Getenv(useCheckAccess) != "disabled", nil
 }
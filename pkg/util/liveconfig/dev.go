@@ -73,9 +73,5 @@ func (p *dev) AdoptByHive(ctx context.Context) (bool, error) {
 }
 
 func (d *dev) UseCheckAccess(ctx context.Context) (bool, error) {
-	checkAccess := os.Getenv(useCheckAccess)
-	if checkAccess == "enabled" {
-		return true, nil
-	}
-	return false, nil
+	return os.Getenv(useCheckAccess) != "disabled", nil
 }
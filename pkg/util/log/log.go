@@ -25,7 +25,8 @@ var (
 	pkgpath           = filepath.Dir(thisfile)
 	repopath          = strings.Replace(thisfile, "pkg/util/log/log.go", "", -1)
 
-	loglevel = flag.String("loglevel", "info", "{panic,fatal,error,warning,info,debug,trace}")
+	loglevel  = flag.String("loglevel", "info", "{panic,fatal,error,warning,info,debug,trace}")
+	logformat = flag.String("logformat", "text", "{text,json}")
 
 	// matches URLs that look like /subscriptions/%s/providers/%s/%s
 	RXProviderResourceKind = regexp.MustCompile(`^/subscriptions/([^/]+)/providers/([^/]+)/([^/]+)$`)
@@ -56,12 +57,24 @@ func MapStatusCodeToResultType(statusCode int) ResultType {
 	return ServerErrorResultType
 }
 
+// newFormatter returns a formatter for the format selected by -logformat
+func newFormatter(callerPrettyfier func(*runtime.Frame) (string, string)) logrus.Formatter {
+	if *logformat == "json" {
+		return &logrus.JSONFormatter{
+			CallerPrettyfier: callerPrettyfier,
+		}
+	}
+
+	return &logrus.TextFormatter{
+		FullTimestamp:    true,
+		CallerPrettyfier: callerPrettyfier,
+	}
+}
+
 func getBaseLogger() *logrus.Logger {
 	logger := logrus.New()
 
-	logger.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp: true,
-	})
+	logger.SetFormatter(newFormatter(nil))
 
 	logger.AddHook(&logrHook{})
 
@@ -96,10 +109,7 @@ func GetLogger() *logrus.Entry {
 	logger := getBaseLogger()
 
 	logger.SetReportCaller(true)
-	logger.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:    true,
-		CallerPrettyfier: relativeFilePathPrettier,
-	})
+	logger.SetFormatter(newFormatter(relativeFilePathPrettier))
 
 	if journal.Enabled() {
 		logger.AddHook(&journaldHook{})
@@ -114,6 +124,10 @@ func GetLogger() *logrus.Entry {
 		log.Warn(err)
 	}
 
+	if *logformat != "text" && *logformat != "json" {
+		log.Warnf("invalid log format %q, using text", *logformat)
+	}
+
 	return log
 }
 
@@ -0,0 +1,198 @@
+package marketplace
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+)
+
+const (
+	// scope is the Microsoft Entra scope of the Marketplace Metering API
+	scope = "20e940b3-4c77-4b0b-9a53-9e16a1b010a7/.default"
+
+	apiVersion = "2018-08-31"
+)
+
+// Usage event statuses
+const (
+	// StatusAccepted means that the usage event was accepted
+	StatusAccepted = "Accepted"
+	// StatusDuplicate means that a usage event was already accepted for the
+	// resource, dimension and hour
+	StatusDuplicate = "Duplicate"
+)
+
+// UsageEvent is a usage event of the Marketplace Metering API
+type UsageEvent struct {
+	ResourceURI        string    `json:"resourceUri"`
+	Quantity           float64   `json:"quantity"`
+	Dimension          string    `json:"dimension"`
+	EffectiveStartTime time.Time `json:"effectiveStartTime"`
+	PlanID             string    `json:"planId"`
+}
+
+// UsageEventResult is the outcome of submitting a usage event
+type UsageEventResult struct {
+	UsageEventID string
+	Status       string
+}
+
+// Error is an error response of the Marketplace Metering API
+type Error struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Target     string `json:"target"`
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s: %s", err.StatusCode, err.Code, err.Message)
+}
+
+// IsRetryable returns true if err is a transient failure after which the
+// usage event may be submitted again
+func IsRetryable(err error) bool {
+	var merr *Error
+	if !errors.As(err, &merr) {
+		// e.g. network errors
+		return true
+	}
+
+	return merr.StatusCode == http.StatusTooManyRequests ||
+		merr.StatusCode >= http.StatusInternalServerError
+}
+
+// MeteringClient submits usage events to the Marketplace Metering API
+type MeteringClient interface {
+	SubmitUsageEvent(ctx context.Context, event *UsageEvent) (*UsageEventResult, error)
+}
+
+type meteringClient struct {
+	endpoint string
+	cred     azcore.TokenCredential
+	cli      *http.Client
+}
+
+// NewMeteringClientFromEnvironment returns a client of the Marketplace
+// Metering API at MARKETPLACE_METERING_ENDPOINT, authenticated with the RP's
+// managed identity, or nil if MARKETPLACE_METERING_ENDPOINT is not set, in
+// which case no usage is reported
+func NewMeteringClientFromEnvironment(_env env.Core) (MeteringClient, error) {
+	if os.Getenv("MARKETPLACE_METERING_ENDPOINT") == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(os.Getenv("MARKETPLACE_METERING_ENDPOINT"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid MARKETPLACE_METERING_ENDPOINT %q", os.Getenv("MARKETPLACE_METERING_ENDPOINT"))
+	}
+
+	cred, err := _env.NewMSITokenCredential()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMeteringClient(u.String(), cred), nil
+}
+
+func NewMeteringClient(endpoint string, cred azcore.TokenCredential) MeteringClient {
+	return &meteringClient{
+		endpoint: endpoint,
+		cred:     cred,
+		cli: &http.Client{
+			Timeout: time.Minute,
+		},
+	}
+}
+
+// SubmitUsageEvent submits a single usage event.  A usage event which
+// duplicates one which was already accepted is not an error: its result has
+// the ID of the accepted usage event and StatusDuplicate.
+func (c *meteringClient) SubmitUsageEvent(ctx context.Context, event *UsageEvent) (*UsageEventResult, error) {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := c.cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{scope}})
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = url.Values{"api-version": []string{apiVersion}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var accepted struct {
+			UsageEventID string `json:"usageEventId"`
+			Status       string `json:"status"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&accepted)
+		if err != nil {
+			return nil, err
+		}
+
+		return &UsageEventResult{
+			UsageEventID: accepted.UsageEventID,
+			Status:       accepted.Status,
+		}, nil
+
+	case http.StatusConflict:
+		var conflict struct {
+			AdditionalInfo struct {
+				AcceptedMessage struct {
+					UsageEventID string `json:"usageEventId"`
+				} `json:"acceptedMessage"`
+			} `json:"additionalInfo"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&conflict)
+		if err != nil {
+			return nil, err
+		}
+
+		return &UsageEventResult{
+			UsageEventID: conflict.AdditionalInfo.AcceptedMessage.UsageEventID,
+			Status:       StatusDuplicate,
+		}, nil
+	}
+
+	merr := &Error{StatusCode: resp.StatusCode}
+	_ = json.NewDecoder(resp.Body).Decode(merr)
+
+	return nil, merr
+}
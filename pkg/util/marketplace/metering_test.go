@@ -0,0 +1,131 @@
+package marketplace
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	if !reflect.DeepEqual(options.Scopes, []string{scope}) {
+		return azcore.AccessToken{}, nil
+	}
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func TestSubmitUsageEvent(t *testing.T) {
+	ctx := context.Background()
+
+	effectiveStartTime := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	var submitted map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.Method != http.MethodPost || r.URL.Query().Get("api-version") != apiVersion {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		submitted = nil
+		_ = json.NewDecoder(r.Body).Decode(&submitted)
+
+		switch r.URL.Path {
+		case "/accepted":
+			_, _ = w.Write([]byte(`{"usageEventId":"accepted-id","status":"Accepted"}`))
+		case "/conflict":
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"additionalInfo":{"acceptedMessage":{"usageEventId":"earlier-id","status":"Duplicate"}},"message":"This usage event already exist.","code":"Conflict"}`))
+		case "/badrequest":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"The effectiveStartTime is too old.","target":"effectiveStartTime","code":"BadArgument"}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	event := &UsageEvent{
+		ResourceURI:        "/subscriptions/subscriptionId/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster",
+		Quantity:           24,
+		Dimension:          "workercorehours",
+		EffectiveStartTime: effectiveStartTime,
+		PlanID:             "plan",
+	}
+
+	for _, tt := range []struct {
+		name          string
+		path          string
+		want          *UsageEventResult
+		wantErr       string
+		wantRetryable bool
+	}{
+		{
+			name: "accepted",
+			path: "/accepted",
+			want: &UsageEventResult{UsageEventID: "accepted-id", Status: StatusAccepted},
+		},
+		{
+			name: "duplicate",
+			path: "/conflict",
+			want: &UsageEventResult{UsageEventID: "earlier-id", Status: StatusDuplicate},
+		},
+		{
+			name:    "rejected",
+			path:    "/badrequest",
+			wantErr: "unexpected status code 400: BadArgument: The effectiveStartTime is too old.",
+		},
+		{
+			name:          "unavailable",
+			path:          "/unavailable",
+			wantErr:       "unexpected status code 503: : ",
+			wantRetryable: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMeteringClient(srv.URL+tt.path, fakeCredential{})
+
+			got, err := c.SubmitUsageEvent(ctx, event)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatalf("got error %v, want %q", err, tt.wantErr)
+			}
+			if err != nil && IsRetryable(err) != tt.wantRetryable {
+				t.Errorf("got retryable %v", IsRetryable(err))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(submitted, map[string]interface{}{
+				"resourceUri":        event.ResourceURI,
+				"quantity":           float64(24),
+				"dimension":          "workercorehours",
+				"effectiveStartTime": "2024-01-01T10:00:00Z",
+				"planId":             "plan",
+			}) {
+				t.Error(submitted)
+			}
+		})
+	}
+
+	if !IsRetryable(errors.New("connection reset")) {
+		t.Error("network errors should be retryable")
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armsecurity (interfaces: AssessmentsClient)
+
+// Package mock_armsecurity is a generated GoMock package.
+package mock_armsecurity
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	armsecurity "github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armsecurity"
+)
+
+// MockAssessmentsClient is a mock of AssessmentsClient interface.
+type MockAssessmentsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAssessmentsClientMockRecorder
+}
+
+// MockAssessmentsClientMockRecorder is the mock recorder for MockAssessmentsClient.
+type MockAssessmentsClientMockRecorder struct {
+	mock *MockAssessmentsClient
+}
+
+// NewMockAssessmentsClient creates a new mock instance.
+func NewMockAssessmentsClient(ctrl *gomock.Controller) *MockAssessmentsClient {
+	mock := &MockAssessmentsClient{ctrl: ctrl}
+	mock.recorder = &MockAssessmentsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAssessmentsClient) EXPECT() *MockAssessmentsClientMockRecorder {
+	return m.recorder
+}
+
+// CreateOrUpdate mocks base method.
+func (m *MockAssessmentsClient) CreateOrUpdate(arg0 context.Context, arg1, arg2 string, arg3 *armsecurity.Assessment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdate indicates an expected call of CreateOrUpdate.
+func (mr *MockAssessmentsClientMockRecorder) CreateOrUpdate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockAssessmentsClient)(nil).CreateOrUpdate), arg0, arg1, arg2, arg3)
+}
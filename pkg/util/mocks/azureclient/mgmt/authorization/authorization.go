@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/authorization (interfaces: RoleAssignmentsClient,DenyAssignmentClient,RoleDefinitionsClient)
+// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/authorization (interfaces: RoleAssignmentsClient,DenyAssignmentClient,RoleDefinitionsClient,PermissionsClient)
 
 // Package mock_authorization is a generated GoMock package.
 package mock_authorization
@@ -185,3 +185,41 @@ func (mr *MockRoleDefinitionsClientMockRecorder) List(arg0, arg1, arg2 interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRoleDefinitionsClient)(nil).List), arg0, arg1, arg2)
 }
+
+// MockPermissionsClient is a mock of PermissionsClient interface.
+type MockPermissionsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionsClientMockRecorder
+}
+
+// MockPermissionsClientMockRecorder is the mock recorder for MockPermissionsClient.
+type MockPermissionsClientMockRecorder struct {
+	mock *MockPermissionsClient
+}
+
+// NewMockPermissionsClient creates a new mock instance.
+func NewMockPermissionsClient(ctrl *gomock.Controller) *MockPermissionsClient {
+	mock := &MockPermissionsClient{ctrl: ctrl}
+	mock.recorder = &MockPermissionsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionsClient) EXPECT() *MockPermissionsClientMockRecorder {
+	return m.recorder
+}
+
+// ListForResource mocks base method.
+func (m *MockPermissionsClient) ListForResource(arg0 context.Context, arg1, arg2, arg3, arg4, arg5 string) ([]authorization.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForResource", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]authorization.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForResource indicates an expected call of ListForResource.
+func (mr *MockPermissionsClientMockRecorder) ListForResource(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForResource", reflect.TypeOf((*MockPermissionsClient)(nil).ListForResource), arg0, arg1, arg2, arg3, arg4, arg5)
+}
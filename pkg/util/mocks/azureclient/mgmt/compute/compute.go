@@ -8,8 +8,10 @@ import (
 	context "context"
 	reflect "reflect"
 
-	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	compute0 "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	gomock "github.com/golang/mock/gomock"
+
+	compute "github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
 )
 
 // MockDisksClient is a mock of DisksClient interface.
@@ -50,10 +52,10 @@ func (mr *MockDisksClientMockRecorder) DeleteAndWait(arg0, arg1, arg2 interface{
 }
 
 // Get mocks base method.
-func (m *MockDisksClient) Get(arg0 context.Context, arg1, arg2 string) (compute.Disk, error) {
+func (m *MockDisksClient) Get(arg0 context.Context, arg1, arg2 string) (compute0.Disk, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
-	ret0, _ := ret[0].(compute.Disk)
+	ret0, _ := ret[0].(compute0.Disk)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -88,10 +90,10 @@ func (m *MockResourceSkusClient) EXPECT() *MockResourceSkusClientMockRecorder {
 }
 
 // List mocks base method.
-func (m *MockResourceSkusClient) List(arg0 context.Context, arg1 string) ([]compute.ResourceSku, error) {
+func (m *MockResourceSkusClient) List(arg0 context.Context, arg1 string) ([]compute0.ResourceSku, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", arg0, arg1)
-	ret0, _ := ret[0].([]compute.ResourceSku)
+	ret0, _ := ret[0].([]compute0.ResourceSku)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -126,7 +128,7 @@ func (m *MockVirtualMachinesClient) EXPECT() *MockVirtualMachinesClientMockRecor
 }
 
 // CreateOrUpdateAndWait mocks base method.
-func (m *MockVirtualMachinesClient) CreateOrUpdateAndWait(arg0 context.Context, arg1, arg2 string, arg3 compute.VirtualMachine) error {
+func (m *MockVirtualMachinesClient) CreateOrUpdateAndWait(arg0 context.Context, arg1, arg2 string, arg3 compute0.VirtualMachine) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateOrUpdateAndWait", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
@@ -154,10 +156,10 @@ func (mr *MockVirtualMachinesClientMockRecorder) DeleteAndWait(arg0, arg1, arg2,
 }
 
 // Get mocks base method.
-func (m *MockVirtualMachinesClient) Get(arg0 context.Context, arg1, arg2 string, arg3 compute.InstanceViewTypes) (compute.VirtualMachine, error) {
+func (m *MockVirtualMachinesClient) Get(arg0 context.Context, arg1, arg2 string, arg3 compute0.InstanceViewTypes) (compute0.VirtualMachine, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(compute.VirtualMachine)
+	ret0, _ := ret[0].(compute0.VirtualMachine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -169,10 +171,10 @@ func (mr *MockVirtualMachinesClientMockRecorder) Get(arg0, arg1, arg2, arg3 inte
 }
 
 // List mocks base method.
-func (m *MockVirtualMachinesClient) List(arg0 context.Context, arg1 string) ([]compute.VirtualMachine, error) {
+func (m *MockVirtualMachinesClient) List(arg0 context.Context, arg1 string) ([]compute0.VirtualMachine, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", arg0, arg1)
-	ret0, _ := ret[0].([]compute.VirtualMachine)
+	ret0, _ := ret[0].([]compute0.VirtualMachine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -183,6 +185,21 @@ func (mr *MockVirtualMachinesClientMockRecorder) List(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVirtualMachinesClient)(nil).List), arg0, arg1)
 }
 
+// ListAll mocks base method.
+func (m *MockVirtualMachinesClient) ListAll(arg0 context.Context, arg1 string) ([]compute0.VirtualMachine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", arg0, arg1)
+	ret0, _ := ret[0].([]compute0.VirtualMachine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockVirtualMachinesClientMockRecorder) ListAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockVirtualMachinesClient)(nil).ListAll), arg0, arg1)
+}
+
 // RedeployAndWait mocks base method.
 func (m *MockVirtualMachinesClient) RedeployAndWait(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -197,6 +214,21 @@ func (mr *MockVirtualMachinesClientMockRecorder) RedeployAndWait(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeployAndWait", reflect.TypeOf((*MockVirtualMachinesClient)(nil).RedeployAndWait), arg0, arg1, arg2)
 }
 
+// RunCommandWithOutput mocks base method.
+func (m *MockVirtualMachinesClient) RunCommandWithOutput(arg0 context.Context, arg1, arg2, arg3 string, arg4 compute0.VirtualMachineRunCommand) (*compute.RunCommandOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunCommandWithOutput", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*compute.RunCommandOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunCommandWithOutput indicates an expected call of RunCommandWithOutput.
+func (mr *MockVirtualMachinesClientMockRecorder) RunCommandWithOutput(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCommandWithOutput", reflect.TypeOf((*MockVirtualMachinesClient)(nil).RunCommandWithOutput), arg0, arg1, arg2, arg3, arg4)
+}
+
 // StartAndWait mocks base method.
 func (m *MockVirtualMachinesClient) StartAndWait(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -249,10 +281,10 @@ func (m *MockUsageClient) EXPECT() *MockUsageClientMockRecorder {
 }
 
 // List mocks base method.
-func (m *MockUsageClient) List(arg0 context.Context, arg1 string) ([]compute.Usage, error) {
+func (m *MockUsageClient) List(arg0 context.Context, arg1 string) ([]compute0.Usage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", arg0, arg1)
-	ret0, _ := ret[0].([]compute.Usage)
+	ret0, _ := ret[0].([]compute0.Usage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -287,10 +319,10 @@ func (m *MockVirtualMachineScaleSetVMsClient) EXPECT() *MockVirtualMachineScaleS
 }
 
 // GetInstanceView mocks base method.
-func (m *MockVirtualMachineScaleSetVMsClient) GetInstanceView(arg0 context.Context, arg1, arg2, arg3 string) (compute.VirtualMachineScaleSetVMInstanceView, error) {
+func (m *MockVirtualMachineScaleSetVMsClient) GetInstanceView(arg0 context.Context, arg1, arg2, arg3 string) (compute0.VirtualMachineScaleSetVMInstanceView, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetInstanceView", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(compute.VirtualMachineScaleSetVMInstanceView)
+	ret0, _ := ret[0].(compute0.VirtualMachineScaleSetVMInstanceView)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -302,10 +334,10 @@ func (mr *MockVirtualMachineScaleSetVMsClientMockRecorder) GetInstanceView(arg0,
 }
 
 // List mocks base method.
-func (m *MockVirtualMachineScaleSetVMsClient) List(arg0 context.Context, arg1, arg2, arg3, arg4, arg5 string) ([]compute.VirtualMachineScaleSetVM, error) {
+func (m *MockVirtualMachineScaleSetVMsClient) List(arg0 context.Context, arg1, arg2, arg3, arg4, arg5 string) ([]compute0.VirtualMachineScaleSetVM, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3, arg4, arg5)
-	ret0, _ := ret[0].([]compute.VirtualMachineScaleSetVM)
+	ret0, _ := ret[0].([]compute0.VirtualMachineScaleSetVM)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -317,7 +349,7 @@ func (mr *MockVirtualMachineScaleSetVMsClientMockRecorder) List(arg0, arg1, arg2
 }
 
 // RunCommandAndWait mocks base method.
-func (m *MockVirtualMachineScaleSetVMsClient) RunCommandAndWait(arg0 context.Context, arg1, arg2, arg3 string, arg4 compute.RunCommandInput) error {
+func (m *MockVirtualMachineScaleSetVMsClient) RunCommandAndWait(arg0 context.Context, arg1, arg2, arg3 string, arg4 compute0.RunCommandInput) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RunCommandAndWait", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
@@ -330,6 +362,21 @@ func (mr *MockVirtualMachineScaleSetVMsClientMockRecorder) RunCommandAndWait(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCommandAndWait", reflect.TypeOf((*MockVirtualMachineScaleSetVMsClient)(nil).RunCommandAndWait), arg0, arg1, arg2, arg3, arg4)
 }
 
+// RunCommandWithOutput mocks base method.
+func (m *MockVirtualMachineScaleSetVMsClient) RunCommandWithOutput(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 compute0.VirtualMachineRunCommand) (*compute.RunCommandOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunCommandWithOutput", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*compute.RunCommandOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunCommandWithOutput indicates an expected call of RunCommandWithOutput.
+func (mr *MockVirtualMachineScaleSetVMsClientMockRecorder) RunCommandWithOutput(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCommandWithOutput", reflect.TypeOf((*MockVirtualMachineScaleSetVMsClient)(nil).RunCommandWithOutput), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // MockVirtualMachineScaleSetsClient is a mock of VirtualMachineScaleSetsClient interface.
 type MockVirtualMachineScaleSetsClient struct {
 	ctrl     *gomock.Controller
@@ -367,11 +414,26 @@ func (mr *MockVirtualMachineScaleSetsClientMockRecorder) DeleteAndWait(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAndWait", reflect.TypeOf((*MockVirtualMachineScaleSetsClient)(nil).DeleteAndWait), arg0, arg1, arg2)
 }
 
+// Get mocks base method.
+func (m *MockVirtualMachineScaleSetsClient) Get(arg0 context.Context, arg1, arg2 string) (compute0.VirtualMachineScaleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(compute0.VirtualMachineScaleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockVirtualMachineScaleSetsClientMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVirtualMachineScaleSetsClient)(nil).Get), arg0, arg1, arg2)
+}
+
 // List mocks base method.
-func (m *MockVirtualMachineScaleSetsClient) List(arg0 context.Context, arg1 string) ([]compute.VirtualMachineScaleSet, error) {
+func (m *MockVirtualMachineScaleSetsClient) List(arg0 context.Context, arg1 string) ([]compute0.VirtualMachineScaleSet, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", arg0, arg1)
-	ret0, _ := ret[0].([]compute.VirtualMachineScaleSet)
+	ret0, _ := ret[0].([]compute0.VirtualMachineScaleSet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -382,6 +444,34 @@ func (mr *MockVirtualMachineScaleSetsClientMockRecorder) List(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVirtualMachineScaleSetsClient)(nil).List), arg0, arg1)
 }
 
+// UpdateAndWait mocks base method.
+func (m *MockVirtualMachineScaleSetsClient) UpdateAndWait(arg0 context.Context, arg1, arg2 string, arg3 compute0.VirtualMachineScaleSetUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAndWait", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAndWait indicates an expected call of UpdateAndWait.
+func (mr *MockVirtualMachineScaleSetsClientMockRecorder) UpdateAndWait(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAndWait", reflect.TypeOf((*MockVirtualMachineScaleSetsClient)(nil).UpdateAndWait), arg0, arg1, arg2, arg3)
+}
+
+// UpdateInstancesAndWait mocks base method.
+func (m *MockVirtualMachineScaleSetsClient) UpdateInstancesAndWait(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstancesAndWait", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInstancesAndWait indicates an expected call of UpdateInstancesAndWait.
+func (mr *MockVirtualMachineScaleSetsClientMockRecorder) UpdateInstancesAndWait(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstancesAndWait", reflect.TypeOf((*MockVirtualMachineScaleSetsClient)(nil).UpdateInstancesAndWait), arg0, arg1, arg2, arg3)
+}
+
 // MockDiskEncryptionSetsClient is a mock of DiskEncryptionSetsClient interface.
 type MockDiskEncryptionSetsClient struct {
 	ctrl     *gomock.Controller
@@ -406,10 +496,10 @@ func (m *MockDiskEncryptionSetsClient) EXPECT() *MockDiskEncryptionSetsClientMoc
 }
 
 // Get mocks base method.
-func (m *MockDiskEncryptionSetsClient) Get(arg0 context.Context, arg1, arg2 string) (compute.DiskEncryptionSet, error) {
+func (m *MockDiskEncryptionSetsClient) Get(arg0 context.Context, arg1, arg2 string) (compute0.DiskEncryptionSet, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
-	ret0, _ := ret[0].(compute.DiskEncryptionSet)
+	ret0, _ := ret[0].(compute0.DiskEncryptionSet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
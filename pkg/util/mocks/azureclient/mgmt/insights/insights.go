@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/insights (interfaces: DiagnosticSettingsClient,DiagnosticSettingsCategoryClient,MetricsClient)
+
+// Package mock_insights is a generated GoMock package.
+package mock_insights
+
+import (
+	context "context"
+	reflect "reflect"
+
+	insights "github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	autorest "github.com/Azure/go-autorest/autorest"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDiagnosticSettingsClient is a mock of DiagnosticSettingsClient interface.
+type MockDiagnosticSettingsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiagnosticSettingsClientMockRecorder
+}
+
+// MockDiagnosticSettingsClientMockRecorder is the mock recorder for MockDiagnosticSettingsClient.
+type MockDiagnosticSettingsClientMockRecorder struct {
+	mock *MockDiagnosticSettingsClient
+}
+
+// NewMockDiagnosticSettingsClient creates a new mock instance.
+func NewMockDiagnosticSettingsClient(ctrl *gomock.Controller) *MockDiagnosticSettingsClient {
+	mock := &MockDiagnosticSettingsClient{ctrl: ctrl}
+	mock.recorder = &MockDiagnosticSettingsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiagnosticSettingsClient) EXPECT() *MockDiagnosticSettingsClientMockRecorder {
+	return m.recorder
+}
+
+// CreateOrUpdate mocks base method.
+func (m *MockDiagnosticSettingsClient) CreateOrUpdate(arg0 context.Context, arg1 string, arg2 insights.DiagnosticSettingsResource, arg3 string) (insights.DiagnosticSettingsResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(insights.DiagnosticSettingsResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdate indicates an expected call of CreateOrUpdate.
+func (mr *MockDiagnosticSettingsClientMockRecorder) CreateOrUpdate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockDiagnosticSettingsClient)(nil).CreateOrUpdate), arg0, arg1, arg2, arg3)
+}
+
+// Delete mocks base method.
+func (m *MockDiagnosticSettingsClient) Delete(arg0 context.Context, arg1, arg2 string) (autorest.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(autorest.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDiagnosticSettingsClientMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDiagnosticSettingsClient)(nil).Delete), arg0, arg1, arg2)
+}
+
+// MockDiagnosticSettingsCategoryClient is a mock of DiagnosticSettingsCategoryClient interface.
+type MockDiagnosticSettingsCategoryClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiagnosticSettingsCategoryClientMockRecorder
+}
+
+// MockDiagnosticSettingsCategoryClientMockRecorder is the mock recorder for MockDiagnosticSettingsCategoryClient.
+type MockDiagnosticSettingsCategoryClientMockRecorder struct {
+	mock *MockDiagnosticSettingsCategoryClient
+}
+
+// NewMockDiagnosticSettingsCategoryClient creates a new mock instance.
+func NewMockDiagnosticSettingsCategoryClient(ctrl *gomock.Controller) *MockDiagnosticSettingsCategoryClient {
+	mock := &MockDiagnosticSettingsCategoryClient{ctrl: ctrl}
+	mock.recorder = &MockDiagnosticSettingsCategoryClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiagnosticSettingsCategoryClient) EXPECT() *MockDiagnosticSettingsCategoryClientMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockDiagnosticSettingsCategoryClient) List(arg0 context.Context, arg1 string) (insights.DiagnosticSettingsCategoryResourceCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(insights.DiagnosticSettingsCategoryResourceCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDiagnosticSettingsCategoryClientMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDiagnosticSettingsCategoryClient)(nil).List), arg0, arg1)
+}
+
+// MockMetricsClient is a mock of MetricsClient interface.
+type MockMetricsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsClientMockRecorder
+}
+
+// MockMetricsClientMockRecorder is the mock recorder for MockMetricsClient.
+type MockMetricsClientMockRecorder struct {
+	mock *MockMetricsClient
+}
+
+// NewMockMetricsClient creates a new mock instance.
+func NewMockMetricsClient(ctrl *gomock.Controller) *MockMetricsClient {
+	mock := &MockMetricsClient{ctrl: ctrl}
+	mock.recorder = &MockMetricsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsClient) EXPECT() *MockMetricsClientMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockMetricsClient) List(arg0 context.Context, arg1, arg2 string, arg3 *string, arg4, arg5 string, arg6 *int32, arg7, arg8 string, arg9 insights.ResultType, arg10 string) (insights.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
+	ret0, _ := ret[0].(insights.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMetricsClientMockRecorder) List(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMetricsClient)(nil).List), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network (interfaces: InterfacesClient,LoadBalancersClient,PrivateEndpointsClient,PrivateLinkServicesClient,PublicIPAddressesClient,LoadBalancerBackendAddressPoolsClient,RouteTablesClient,SubnetsClient,VirtualNetworksClient,SecurityGroupsClient,VirtualNetworkPeeringsClient,UsageClient,FlowLogsClient)
+// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network (interfaces: InterfacesClient,LoadBalancersClient,PrivateEndpointsClient,PrivateLinkServicesClient,PublicIPAddressesClient,PublicIPPrefixesClient,LoadBalancerBackendAddressPoolsClient,RouteTablesClient,SubnetsClient,VirtualNetworksClient,SecurityGroupsClient,VirtualNetworkPeeringsClient,UsageClient,FlowLogsClient)
 
 // Package mock_network is a generated GoMock package.
 package mock_network
@@ -78,6 +78,21 @@ func (mr *MockInterfacesClientMockRecorder) Get(arg0, arg1, arg2, arg3 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInterfacesClient)(nil).Get), arg0, arg1, arg2, arg3)
 }
 
+// ListVirtualMachineScaleSetNetworkInterfaces mocks base method.
+func (m *MockInterfacesClient) ListVirtualMachineScaleSetNetworkInterfaces(arg0 context.Context, arg1, arg2 string) ([]network.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVirtualMachineScaleSetNetworkInterfaces", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]network.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVirtualMachineScaleSetNetworkInterfaces indicates an expected call of ListVirtualMachineScaleSetNetworkInterfaces.
+func (mr *MockInterfacesClientMockRecorder) ListVirtualMachineScaleSetNetworkInterfaces(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVirtualMachineScaleSetNetworkInterfaces", reflect.TypeOf((*MockInterfacesClient)(nil).ListVirtualMachineScaleSetNetworkInterfaces), arg0, arg1, arg2)
+}
+
 // MockLoadBalancersClient is a mock of LoadBalancersClient interface.
 type MockLoadBalancersClient struct {
 	ctrl     *gomock.Controller
@@ -196,6 +211,21 @@ func (mr *MockPrivateEndpointsClientMockRecorder) Get(arg0, arg1, arg2, arg3 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPrivateEndpointsClient)(nil).Get), arg0, arg1, arg2, arg3)
 }
 
+// List mocks base method.
+func (m *MockPrivateEndpointsClient) List(arg0 context.Context, arg1 string) ([]network.PrivateEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]network.PrivateEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPrivateEndpointsClientMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPrivateEndpointsClient)(nil).List), arg0, arg1)
+}
+
 // MockPrivateLinkServicesClient is a mock of PrivateLinkServicesClient interface.
 type MockPrivateLinkServicesClient struct {
 	ctrl     *gomock.Controller
@@ -219,6 +249,20 @@ func (m *MockPrivateLinkServicesClient) EXPECT() *MockPrivateLinkServicesClientM
 	return m.recorder
 }
 
+// ApprovePrivateEndpointConnection mocks base method.
+func (m *MockPrivateLinkServicesClient) ApprovePrivateEndpointConnection(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApprovePrivateEndpointConnection", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApprovePrivateEndpointConnection indicates an expected call of ApprovePrivateEndpointConnection.
+func (mr *MockPrivateLinkServicesClientMockRecorder) ApprovePrivateEndpointConnection(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApprovePrivateEndpointConnection", reflect.TypeOf((*MockPrivateLinkServicesClient)(nil).ApprovePrivateEndpointConnection), arg0, arg1, arg2, arg3, arg4)
+}
+
 // DeletePrivateEndpointConnection mocks base method.
 func (m *MockPrivateLinkServicesClient) DeletePrivateEndpointConnection(arg0 context.Context, arg1, arg2, arg3 string) (network.PrivateLinkServicesDeletePrivateEndpointConnectionFuture, error) {
 	m.ctrl.T.Helper()
@@ -234,6 +278,20 @@ func (mr *MockPrivateLinkServicesClientMockRecorder) DeletePrivateEndpointConnec
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateEndpointConnection", reflect.TypeOf((*MockPrivateLinkServicesClient)(nil).DeletePrivateEndpointConnection), arg0, arg1, arg2, arg3)
 }
 
+// DeletePrivateEndpointConnectionAndWait mocks base method.
+func (m *MockPrivateLinkServicesClient) DeletePrivateEndpointConnectionAndWait(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateEndpointConnectionAndWait", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateEndpointConnectionAndWait indicates an expected call of DeletePrivateEndpointConnectionAndWait.
+func (mr *MockPrivateLinkServicesClientMockRecorder) DeletePrivateEndpointConnectionAndWait(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateEndpointConnectionAndWait", reflect.TypeOf((*MockPrivateLinkServicesClient)(nil).DeletePrivateEndpointConnectionAndWait), arg0, arg1, arg2, arg3)
+}
+
 // Get mocks base method.
 func (m *MockPrivateLinkServicesClient) Get(arg0 context.Context, arg1, arg2, arg3 string) (network.PrivateLinkService, error) {
 	m.ctrl.T.Helper()
@@ -264,6 +322,49 @@ func (mr *MockPrivateLinkServicesClientMockRecorder) List(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPrivateLinkServicesClient)(nil).List), arg0, arg1)
 }
 
+// ListPrivateEndpointConnections mocks base method.
+func (m *MockPrivateLinkServicesClient) ListPrivateEndpointConnections(arg0 context.Context, arg1, arg2 string) ([]network.PrivateEndpointConnection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrivateEndpointConnections", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]network.PrivateEndpointConnection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrivateEndpointConnections indicates an expected call of ListPrivateEndpointConnections.
+func (mr *MockPrivateLinkServicesClientMockRecorder) ListPrivateEndpointConnections(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrivateEndpointConnections", reflect.TypeOf((*MockPrivateLinkServicesClient)(nil).ListPrivateEndpointConnections), arg0, arg1, arg2)
+}
+
+// RejectPrivateEndpointConnection mocks base method.
+func (m *MockPrivateLinkServicesClient) RejectPrivateEndpointConnection(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectPrivateEndpointConnection", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectPrivateEndpointConnection indicates an expected call of RejectPrivateEndpointConnection.
+func (mr *MockPrivateLinkServicesClientMockRecorder) RejectPrivateEndpointConnection(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectPrivateEndpointConnection", reflect.TypeOf((*MockPrivateLinkServicesClient)(nil).RejectPrivateEndpointConnection), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SetVisibilityAndWait mocks base method.
+func (m *MockPrivateLinkServicesClient) SetVisibilityAndWait(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVisibilityAndWait", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVisibilityAndWait indicates an expected call of SetVisibilityAndWait.
+func (mr *MockPrivateLinkServicesClientMockRecorder) SetVisibilityAndWait(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVisibilityAndWait", reflect.TypeOf((*MockPrivateLinkServicesClient)(nil).SetVisibilityAndWait), arg0, arg1, arg2, arg3)
+}
+
 // UpdatePrivateEndpointConnection mocks base method.
 func (m *MockPrivateLinkServicesClient) UpdatePrivateEndpointConnection(arg0 context.Context, arg1, arg2, arg3 string, arg4 network.PrivateEndpointConnection) (network.PrivateEndpointConnection, error) {
 	m.ctrl.T.Helper()
@@ -360,6 +461,44 @@ func (mr *MockPublicIPAddressesClientMockRecorder) List(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPublicIPAddressesClient)(nil).List), arg0, arg1)
 }
 
+// MockPublicIPPrefixesClient is a mock of PublicIPPrefixesClient interface.
+type MockPublicIPPrefixesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublicIPPrefixesClientMockRecorder
+}
+
+// MockPublicIPPrefixesClientMockRecorder is the mock recorder for MockPublicIPPrefixesClient.
+type MockPublicIPPrefixesClientMockRecorder struct {
+	mock *MockPublicIPPrefixesClient
+}
+
+// NewMockPublicIPPrefixesClient creates a new mock instance.
+func NewMockPublicIPPrefixesClient(ctrl *gomock.Controller) *MockPublicIPPrefixesClient {
+	mock := &MockPublicIPPrefixesClient{ctrl: ctrl}
+	mock.recorder = &MockPublicIPPrefixesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublicIPPrefixesClient) EXPECT() *MockPublicIPPrefixesClientMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPublicIPPrefixesClient) Get(arg0 context.Context, arg1, arg2, arg3 string) (network.PublicIPPrefix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(network.PublicIPPrefix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPublicIPPrefixesClientMockRecorder) Get(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPublicIPPrefixesClient)(nil).Get), arg0, arg1, arg2, arg3)
+}
+
 // MockLoadBalancerBackendAddressPoolsClient is a mock of LoadBalancerBackendAddressPoolsClient interface.
 type MockLoadBalancerBackendAddressPoolsClient struct {
 	ctrl     *gomock.Controller
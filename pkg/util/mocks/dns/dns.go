@@ -64,6 +64,20 @@ func (mr *MockManagerMockRecorder) CreateOrUpdateRouter(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateRouter", reflect.TypeOf((*MockManager)(nil).CreateOrUpdateRouter), arg0, arg1, arg2)
 }
 
+// CreateOrUpdateRouterIPv6 mocks base method.
+func (m *MockManager) CreateOrUpdateRouterIPv6(arg0 context.Context, arg1 *api.OpenShiftCluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateRouterIPv6", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdateRouterIPv6 indicates an expected call of CreateOrUpdateRouterIPv6.
+func (mr *MockManagerMockRecorder) CreateOrUpdateRouterIPv6(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateRouterIPv6", reflect.TypeOf((*MockManager)(nil).CreateOrUpdateRouterIPv6), arg0, arg1, arg2)
+}
+
 // Delete mocks base method.
 func (m *MockManager) Delete(arg0 context.Context, arg1 *api.OpenShiftCluster) error {
 	m.ctrl.T.Helper()
@@ -91,3 +105,17 @@ func (mr *MockManagerMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockManager)(nil).Update), arg0, arg1, arg2)
 }
+
+// UpdateIPv6 mocks base method.
+func (m *MockManager) UpdateIPv6(arg0 context.Context, arg1 *api.OpenShiftCluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIPv6", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIPv6 indicates an expected call of UpdateIPv6.
+func (mr *MockManagerMockRecorder) UpdateIPv6(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIPv6", reflect.TypeOf((*MockManager)(nil).UpdateIPv6), arg0, arg1, arg2)
+}
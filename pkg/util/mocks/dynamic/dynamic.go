@@ -75,6 +75,20 @@ func (m *MockDynamic) EXPECT() *MockDynamicMockRecorder {
 	return m.recorder
 }
 
+// ValidateDiagnosticSettings mocks base method.
+func (m *MockDynamic) ValidateDiagnosticSettings(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateDiagnosticSettings", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateDiagnosticSettings indicates an expected call of ValidateDiagnosticSettings.
+func (mr *MockDynamicMockRecorder) ValidateDiagnosticSettings(ctx, oc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDiagnosticSettings", reflect.TypeOf((*MockDynamic)(nil).ValidateDiagnosticSettings), ctx, oc)
+}
+
 // ValidateDiskEncryptionSets mocks base method.
 func (m *MockDynamic) ValidateDiskEncryptionSets(ctx context.Context, oc *api.OpenShiftCluster) error {
 	m.ctrl.T.Helper()
@@ -131,6 +145,20 @@ func (mr *MockDynamicMockRecorder) ValidatePreConfiguredNSGs(ctx, oc, subnets in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePreConfiguredNSGs", reflect.TypeOf((*MockDynamic)(nil).ValidatePreConfiguredNSGs), ctx, oc, subnets)
 }
 
+// ValidatePublicIPPrefix mocks base method.
+func (m *MockDynamic) ValidatePublicIPPrefix(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatePublicIPPrefix", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidatePublicIPPrefix indicates an expected call of ValidatePublicIPPrefix.
+func (mr *MockDynamicMockRecorder) ValidatePublicIPPrefix(ctx, oc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePublicIPPrefix", reflect.TypeOf((*MockDynamic)(nil).ValidatePublicIPPrefix), ctx, oc)
+}
+
 // ValidateServicePrincipal mocks base method.
 func (m *MockDynamic) ValidateServicePrincipal(ctx context.Context, spTokenCredential azcore.TokenCredential) error {
 	m.ctrl.T.Helper()
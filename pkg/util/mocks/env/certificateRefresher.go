@@ -7,10 +7,13 @@ package mock_env
 import (
 	context "context"
 	rsa "crypto/rsa"
+	tls "crypto/tls"
 	x509 "crypto/x509"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+
+	metrics "github.com/Azure/ARO-RP/pkg/metrics"
 )
 
 // MockCertificateRefresher is a mock of CertificateRefresher interface.
@@ -36,6 +39,18 @@ func (m *MockCertificateRefresher) EXPECT() *MockCertificateRefresherMockRecorde
 	return m.recorder
 }
 
+// EmitMetrics mocks base method.
+func (m *MockCertificateRefresher) EmitMetrics(arg0 metrics.Emitter) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EmitMetrics", arg0)
+}
+
+// EmitMetrics indicates an expected call of EmitMetrics.
+func (mr *MockCertificateRefresherMockRecorder) EmitMetrics(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmitMetrics", reflect.TypeOf((*MockCertificateRefresher)(nil).EmitMetrics), arg0)
+}
+
 // GetCertificates mocks base method.
 func (m *MockCertificateRefresher) GetCertificates() (*rsa.PrivateKey, []*x509.Certificate) {
 	m.ctrl.T.Helper()
@@ -51,6 +66,21 @@ func (mr *MockCertificateRefresherMockRecorder) GetCertificates() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificates", reflect.TypeOf((*MockCertificateRefresher)(nil).GetCertificates))
 }
 
+// GetTLSCertificate mocks base method.
+func (m *MockCertificateRefresher) GetTLSCertificate(arg0 *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTLSCertificate", arg0)
+	ret0, _ := ret[0].(*tls.Certificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTLSCertificate indicates an expected call of GetTLSCertificate.
+func (mr *MockCertificateRefresherMockRecorder) GetTLSCertificate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTLSCertificate", reflect.TypeOf((*MockCertificateRefresher)(nil).GetTLSCertificate), arg0)
+}
+
 // Start mocks base method.
 func (m *MockCertificateRefresher) Start(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	env "github.com/Azure/ARO-RP/pkg/env"
 	azureclient "github.com/Azure/ARO-RP/pkg/util/azureclient"
 	liveconfig "github.com/Azure/ARO-RP/pkg/util/liveconfig"
 	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -39,6 +40,20 @@ func (m *MockCore) EXPECT() *MockCoreMockRecorder {
 	return m.recorder
 }
 
+// AuthMode mocks base method.
+func (m *MockCore) AuthMode() env.AuthMode {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthMode")
+	ret0, _ := ret[0].(env.AuthMode)
+	return ret0
+}
+
+// AuthMode indicates an expected call of AuthMode.
+func (mr *MockCoreMockRecorder) AuthMode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthMode", reflect.TypeOf((*MockCore)(nil).AuthMode))
+}
+
 // Component mocks base method.
 func (m *MockCore) Component() string {
 	m.ctrl.T.Helper()
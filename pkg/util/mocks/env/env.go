@@ -12,7 +12,6 @@ import (
 	reflect "reflect"
 
 	azcore "github.com/Azure/azure-sdk-for-go/sdk/azcore"
-	azidentity "github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
@@ -118,6 +117,20 @@ func (mr *MockInterfaceMockRecorder) ArmClientAuthorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArmClientAuthorizer", reflect.TypeOf((*MockInterface)(nil).ArmClientAuthorizer))
 }
 
+// AuthMode mocks base method.
+func (m *MockInterface) AuthMode() env.AuthMode {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthMode")
+	ret0, _ := ret[0].(env.AuthMode)
+	return ret0
+}
+
+// AuthMode indicates an expected call of AuthMode.
+func (mr *MockInterfaceMockRecorder) AuthMode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthMode", reflect.TypeOf((*MockInterface)(nil).AuthMode))
+}
+
 // ClusterGenevaLoggingAccount mocks base method.
 func (m *MockInterface) ClusterGenevaLoggingAccount() string {
 	m.ctrl.T.Helper()
@@ -294,6 +307,20 @@ func (mr *MockInterfaceMockRecorder) FPAuthorizer(arg0 interface{}, arg1 ...inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FPAuthorizer", reflect.TypeOf((*MockInterface)(nil).FPAuthorizer), varargs...)
 }
 
+// FPCertificateRefresher mocks base method.
+func (m *MockInterface) FPCertificateRefresher() env.CertificateRefresher {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FPCertificateRefresher")
+	ret0, _ := ret[0].(env.CertificateRefresher)
+	return ret0
+}
+
+// FPCertificateRefresher indicates an expected call of FPCertificateRefresher.
+func (mr *MockInterfaceMockRecorder) FPCertificateRefresher() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FPCertificateRefresher", reflect.TypeOf((*MockInterface)(nil).FPCertificateRefresher))
+}
+
 // FPClientID mocks base method.
 func (m *MockInterface) FPClientID() string {
 	m.ctrl.T.Helper()
@@ -308,19 +335,19 @@ func (mr *MockInterfaceMockRecorder) FPClientID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FPClientID", reflect.TypeOf((*MockInterface)(nil).FPClientID))
 }
 
-// FPNewClientCertificateCredential mocks base method.
-func (m *MockInterface) FPNewClientCertificateCredential(arg0 string) (*azidentity.ClientCertificateCredential, error) {
+// FPNewTokenCredential mocks base method.
+func (m *MockInterface) FPNewTokenCredential(arg0 string) (azcore.TokenCredential, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FPNewClientCertificateCredential", arg0)
-	ret0, _ := ret[0].(*azidentity.ClientCertificateCredential)
+	ret := m.ctrl.Call(m, "FPNewTokenCredential", arg0)
+	ret0, _ := ret[0].(azcore.TokenCredential)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FPNewClientCertificateCredential indicates an expected call of FPNewClientCertificateCredential.
-func (mr *MockInterfaceMockRecorder) FPNewClientCertificateCredential(arg0 interface{}) *gomock.Call {
+// FPNewTokenCredential indicates an expected call of FPNewTokenCredential.
+func (mr *MockInterfaceMockRecorder) FPNewTokenCredential(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FPNewClientCertificateCredential", reflect.TypeOf((*MockInterface)(nil).FPNewClientCertificateCredential), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FPNewTokenCredential", reflect.TypeOf((*MockInterface)(nil).FPNewTokenCredential), arg0)
 }
 
 // FeatureIsSet mocks base method.
@@ -337,6 +364,34 @@ func (mr *MockInterfaceMockRecorder) FeatureIsSet(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeatureIsSet", reflect.TypeOf((*MockInterface)(nil).FeatureIsSet), arg0)
 }
 
+// FeatureIsSetForSubscription mocks base method.
+func (m *MockInterface) FeatureIsSetForSubscription(arg0 env.Feature, arg1 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeatureIsSetForSubscription", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// FeatureIsSetForSubscription indicates an expected call of FeatureIsSetForSubscription.
+func (mr *MockInterfaceMockRecorder) FeatureIsSetForSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeatureIsSetForSubscription", reflect.TypeOf((*MockInterface)(nil).FeatureIsSetForSubscription), arg0, arg1)
+}
+
+// Features mocks base method.
+func (m *MockInterface) Features() *env.Features {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Features")
+	ret0, _ := ret[0].(*env.Features)
+	return ret0
+}
+
+// Features indicates an expected call of Features.
+func (mr *MockInterfaceMockRecorder) Features() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Features", reflect.TypeOf((*MockInterface)(nil).Features))
+}
+
 // GatewayDomains mocks base method.
 func (m *MockInterface) GatewayDomains() []string {
 	m.ctrl.T.Helper()
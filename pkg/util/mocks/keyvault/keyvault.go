@@ -113,6 +113,22 @@ func (mr *MockManagerMockRecorder) GetCertificateSecret(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificateSecret", reflect.TypeOf((*MockManager)(nil).GetCertificateSecret), arg0, arg1)
 }
 
+// GetLatestCertificateSecret mocks base method.
+func (m *MockManager) GetLatestCertificateSecret(arg0 context.Context, arg1 string) (*rsa.PrivateKey, []*x509.Certificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestCertificateSecret", arg0, arg1)
+	ret0, _ := ret[0].(*rsa.PrivateKey)
+	ret1, _ := ret[1].([]*x509.Certificate)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLatestCertificateSecret indicates an expected call of GetLatestCertificateSecret.
+func (mr *MockManagerMockRecorder) GetLatestCertificateSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestCertificateSecret", reflect.TypeOf((*MockManager)(nil).GetLatestCertificateSecret), arg0, arg1)
+}
+
 // GetSecret mocks base method.
 func (m *MockManager) GetSecret(arg0 context.Context, arg1 string) (keyvault0.SecretBundle, error) {
 	m.ctrl.T.Helper()
@@ -143,6 +159,20 @@ func (mr *MockManagerMockRecorder) GetSecrets(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockManager)(nil).GetSecrets), arg0)
 }
 
+// ImportCertificate mocks base method.
+func (m *MockManager) ImportCertificate(arg0 context.Context, arg1 string, arg2 *rsa.PrivateKey, arg3 []*x509.Certificate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCertificate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCertificate indicates an expected call of ImportCertificate.
+func (mr *MockManagerMockRecorder) ImportCertificate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCertificate", reflect.TypeOf((*MockManager)(nil).ImportCertificate), arg0, arg1, arg2, arg3)
+}
+
 // SetCertificateIssuer mocks base method.
 func (m *MockManager) SetCertificateIssuer(arg0 context.Context, arg1 string, arg2 keyvault0.CertificateIssuerSetParameters) (keyvault0.IssuerBundle, error) {
 	m.ctrl.T.Helper()
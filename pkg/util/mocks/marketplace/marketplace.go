@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Azure/ARO-RP/pkg/util/marketplace (interfaces: MeteringClient)
+
+// Package mock_marketplace is a generated GoMock package.
+package mock_marketplace
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	marketplace "github.com/Azure/ARO-RP/pkg/util/marketplace"
+)
+
+// MockMeteringClient is a mock of MeteringClient interface.
+type MockMeteringClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMeteringClientMockRecorder
+}
+
+// MockMeteringClientMockRecorder is the mock recorder for MockMeteringClient.
+type MockMeteringClientMockRecorder struct {
+	mock *MockMeteringClient
+}
+
+// NewMockMeteringClient creates a new mock instance.
+func NewMockMeteringClient(ctrl *gomock.Controller) *MockMeteringClient {
+	mock := &MockMeteringClient{ctrl: ctrl}
+	mock.recorder = &MockMeteringClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMeteringClient) EXPECT() *MockMeteringClientMockRecorder {
+	return m.recorder
+}
+
+// SubmitUsageEvent mocks base method.
+func (m *MockMeteringClient) SubmitUsageEvent(arg0 context.Context, arg1 *marketplace.UsageEvent) (*marketplace.UsageEventResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitUsageEvent", arg0, arg1)
+	ret0, _ := ret[0].(*marketplace.UsageEventResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitUsageEvent indicates an expected call of SubmitUsageEvent.
+func (mr *MockMeteringClientMockRecorder) SubmitUsageEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitUsageEvent", reflect.TypeOf((*MockMeteringClient)(nil).SubmitUsageEvent), arg0, arg1)
+}
@@ -37,6 +37,20 @@ func (m *MockManager) EXPECT() *MockManagerMockRecorder {
 	return m.recorder
 }
 
+// AddServiceEndpoints mocks base method.
+func (m *MockManager) AddServiceEndpoints(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddServiceEndpoints", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddServiceEndpoints indicates an expected call of AddServiceEndpoints.
+func (mr *MockManagerMockRecorder) AddServiceEndpoints(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddServiceEndpoints", reflect.TypeOf((*MockManager)(nil).AddServiceEndpoints), arg0, arg1, arg2)
+}
+
 // CreateOrUpdate mocks base method.
 func (m *MockManager) CreateOrUpdate(arg0 context.Context, arg1 string, arg2 *network.Subnet) error {
 	m.ctrl.T.Helper()
@@ -110,6 +124,34 @@ func (mr *MockManagerMockRecorder) GetHighestFreeIP(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighestFreeIP", reflect.TypeOf((*MockManager)(nil).GetHighestFreeIP), arg0, arg1)
 }
 
+// RemoveServiceEndpoints mocks base method.
+func (m *MockManager) RemoveServiceEndpoints(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveServiceEndpoints", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveServiceEndpoints indicates an expected call of RemoveServiceEndpoints.
+func (mr *MockManagerMockRecorder) RemoveServiceEndpoints(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveServiceEndpoints", reflect.TypeOf((*MockManager)(nil).RemoveServiceEndpoints), arg0, arg1, arg2)
+}
+
+// VerifyNoOverlap mocks base method.
+func (m *MockManager) VerifyNoOverlap(arg0 context.Context, arg1, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyNoOverlap", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyNoOverlap indicates an expected call of VerifyNoOverlap.
+func (mr *MockManagerMockRecorder) VerifyNoOverlap(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyNoOverlap", reflect.TypeOf((*MockManager)(nil).VerifyNoOverlap), arg0, arg1, arg2)
+}
+
 // MockKubeManager is a mock of KubeManager interface.
 type MockKubeManager struct {
 	ctrl     *gomock.Controller
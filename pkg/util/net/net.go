@@ -39,7 +39,14 @@ func Listen(network, address string, sz int) (net.Listener, error) {
 // Dial returns a dialled connection with its send and receive buffer sizes set.
 // If sz <= 0, we leave the default size.
 func Dial(network, address string, sz int) (net.Conn, error) {
-	return (&net.Dialer{
+	return Dialer(sz).Dial(network, address)
+}
+
+// Dialer returns a dialer whose connections have their send and receive buffer
+// sizes set, for callers which need to tune its timeouts.  If sz <= 0, we
+// leave the default size.
+func Dialer(sz int) *net.Dialer {
+	return &net.Dialer{
 		Control: func(network, address string, rc syscall.RawConn) error {
 			if sz <= 0 {
 				return nil
@@ -47,7 +54,7 @@ func Dial(network, address string, sz int) (net.Conn, error) {
 
 			return setBuffers(rc, sz)
 		},
-	}).Dial(network, address)
+	}
 }
 
 // read socket(7)
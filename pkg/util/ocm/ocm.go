@@ -0,0 +1,206 @@
+package ocm
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/pullsecret"
+)
+
+// PullSecretKey is the pull secret key whose token identifies the Red Hat
+// account which the cluster is registered to
+const PullSecretKey = "cloud.openshift.com"
+
+type SubscriptionStatus string
+
+// Subscription statuses which the RP sets
+const (
+	SubscriptionStatusActive        SubscriptionStatus = "Active"
+	SubscriptionStatusDisconnected  SubscriptionStatus = "Disconnected"
+	SubscriptionStatusDeprovisioned SubscriptionStatus = "Deprovisioned"
+)
+
+// Cluster describes a cluster to OCM
+type Cluster struct {
+	ID          string `json:"cluster_id"`
+	DisplayName string `json:"display_name,omitempty"`
+	Version     string `json:"openshift_version,omitempty"`
+	Channel     string `json:"channel,omitempty"`
+}
+
+// Subscription is the OCM subscription of a registered cluster, which Red Hat
+// support entitlements are attached to
+type Subscription struct {
+	ID     string             `json:"id,omitempty"`
+	Status SubscriptionStatus `json:"status,omitempty"`
+}
+
+// Client registers clusters with OpenShift Cluster Manager and updates their
+// subscriptions.  Each call is authenticated with the cluster's
+// cloud.openshift.com pull secret token.
+type Client interface {
+	RegisterCluster(ctx context.Context, token string, cluster *Cluster) (*Subscription, error)
+	UpdateSubscription(ctx context.Context, token string, cluster *Cluster, subscription *Subscription) error
+}
+
+type client struct {
+	baseURL string
+	cli     *http.Client
+}
+
+// NewClientFromEnvironment returns a client for the OCM API at OCM_URL, or nil
+// if OCM_URL is not set, in which case clusters are not registered
+func NewClientFromEnvironment() (Client, error) {
+	if os.Getenv("OCM_URL") == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(os.Getenv("OCM_URL"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid OCM_URL %q", os.Getenv("OCM_URL"))
+	}
+
+	return NewClient(strings.TrimSuffix(u.String(), "/")), nil
+}
+
+func NewClient(baseURL string) Client {
+	return &client{
+		baseURL: baseURL,
+		cli: &http.Client{
+			Timeout: time.Minute,
+		},
+	}
+}
+
+// RegisterCluster registers the cluster with the Red Hat account of the
+// token.  Registration is idempotent: registering a registered cluster
+// returns its existing subscription.
+func (c *client) RegisterCluster(ctx context.Context, token string, cluster *Cluster) (*Subscription, error) {
+	var registration struct {
+		SubscriptionID string `json:"subscription_id"`
+	}
+
+	err := c.do(ctx, http.MethodPost, "/api/accounts_mgmt/v1/cluster_registrations", token, cluster.ID, &struct {
+		*Cluster
+		AuthorizationToken string `json:"authorization_token"`
+	}{
+		Cluster:            cluster,
+		AuthorizationToken: token,
+	}, &registration)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &Subscription{}
+	err = c.do(ctx, http.MethodGet, "/api/accounts_mgmt/v1/subscriptions/"+url.PathEscape(registration.SubscriptionID), token, cluster.ID, nil, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// UpdateSubscription sets the status of the subscription, together with the
+// display name, version and channel of the cluster
+func (c *client) UpdateSubscription(ctx context.Context, token string, cluster *Cluster, subscription *Subscription) error {
+	return c.do(ctx, http.MethodPatch, "/api/accounts_mgmt/v1/subscriptions/"+url.PathEscape(subscription.ID), token, cluster.ID, &struct {
+		Status      SubscriptionStatus `json:"status"`
+		DisplayName string             `json:"display_name,omitempty"`
+		Version     string             `json:"openshift_version,omitempty"`
+		Channel     string             `json:"channel,omitempty"`
+	}{
+		Status:      subscription.Status,
+		DisplayName: cluster.DisplayName,
+		Version:     cluster.Version,
+		Channel:     cluster.Channel,
+	}, nil)
+}
+
+func (c *client) do(ctx context.Context, method, path, token, clusterID string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	// OCM authenticates clusters by their ID and pull secret token
+	req.Header.Set("Authorization", fmt.Sprintf("AccessToken %s:%s", clusterID, token))
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var ocmErr struct {
+			Code   string `json:"code"`
+			Reason string `json:"reason"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&ocmErr)
+
+		return fmt.Errorf("%s %s: unexpected status code %d: %s: %s", method, path, resp.StatusCode, ocmErr.Code, ocmErr.Reason)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Token returns the cloud.openshift.com token of the cluster's pull secret.
+// It returns an empty token if the pull secret has none, in which case the
+// cluster cannot be registered.
+func Token(oc *api.OpenShiftCluster) (string, error) {
+	ps := string(oc.Properties.ClusterProfile.PullSecret)
+	if ps == "" {
+		return "", nil
+	}
+
+	token, _, err := pullsecret.Auth(ps, PullSecretKey)
+	return token, err
+}
+
+// SubscriptionStatusFor returns the OCM subscription status which reflects the
+// state of the cluster and of its Azure subscription
+func SubscriptionStatusFor(doc *api.OpenShiftClusterDocument, sub *api.SubscriptionDocument) SubscriptionStatus {
+	if doc.SoftDeleted != nil || doc.OpenShiftCluster.Properties.ProvisioningState == api.ProvisioningStateDeleting {
+		return SubscriptionStatusDeprovisioned
+	}
+
+	if sub != nil && sub.Subscription != nil {
+		switch sub.Subscription.State {
+		case api.SubscriptionStateSuspended, api.SubscriptionStateDeleted:
+			return SubscriptionStatusDisconnected
+		}
+	}
+
+	return SubscriptionStatusActive
+}
@@ -0,0 +1,192 @@
+package ocm
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	var patched map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "AccessToken cluster:token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/accounts_mgmt/v1/cluster_registrations":
+			var body map[string]interface{}
+			err := json.NewDecoder(r.Body).Decode(&body)
+			if err != nil || body["cluster_id"] != "cluster" || body["authorization_token"] != "token" || body["openshift_version"] != "4.14.16" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"account_id":"account","cluster_id":"cluster","subscription_id":"sub"}`))
+
+		case "GET /api/accounts_mgmt/v1/subscriptions/sub":
+			_, _ = w.Write([]byte(`{"id":"sub","status":"Reserved"}`))
+
+		case "PATCH /api/accounts_mgmt/v1/subscriptions/sub":
+			_ = json.NewDecoder(r.Body).Decode(&patched)
+			_, _ = w.Write([]byte(`{}`))
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"OCM-EX-404","reason":"not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	cluster := &Cluster{
+		ID:          "cluster",
+		DisplayName: "name",
+		Version:     "4.14.16",
+	}
+
+	subscription, err := c.RegisterCluster(ctx, "token", cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(subscription, &Subscription{ID: "sub", Status: "Reserved"}) {
+		t.Error(subscription)
+	}
+
+	subscription.Status = SubscriptionStatusActive
+	err = c.UpdateSubscription(ctx, "token", cluster, subscription)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(patched, map[string]interface{}{"status": "Active", "display_name": "name", "openshift_version": "4.14.16"}) {
+		t.Error(patched)
+	}
+
+	err = c.UpdateSubscription(ctx, "token", cluster, &Subscription{ID: "missing"})
+	if err == nil || !strings.Contains(err.Error(), "unexpected status code 404: OCM-EX-404: not found") {
+		t.Error(err)
+	}
+
+	_, err = c.RegisterCluster(ctx, "wrong", cluster)
+	if err == nil || !strings.Contains(err.Error(), "unexpected status code 401") {
+		t.Error(err)
+	}
+}
+
+func TestToken(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		pullSecret api.SecureString
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "token",
+			pullSecret: `{"auths":{"cloud.openshift.com":{"auth":"token"}}}`,
+			want:       "token",
+		},
+		{
+			name:       "no cloud.openshift.com key",
+			pullSecret: `{"auths":{"registry.redhat.io":{"auth":"token"}}}`,
+		},
+		{
+			name: "no pull secret",
+		},
+		{
+			name:       "invalid pull secret",
+			pullSecret: `{`,
+			wantErr:    "malformed pullsecret (invalid JSON)",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := Token(&api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						PullSecret: tt.pullSecret,
+					},
+				},
+			})
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Error(err)
+			}
+			if token != tt.want {
+				t.Error(token)
+			}
+		})
+	}
+}
+
+func TestSubscriptionStatusFor(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		state api.ProvisioningState
+		tomb  bool
+		sub   *api.SubscriptionDocument
+		want  SubscriptionStatus
+	}{
+		{
+			name:  "running cluster",
+			state: api.ProvisioningStateSucceeded,
+			sub:   &api.SubscriptionDocument{Subscription: &api.Subscription{State: api.SubscriptionStateRegistered}},
+			want:  SubscriptionStatusActive,
+		},
+		{
+			name:  "warned subscription",
+			state: api.ProvisioningStateSucceeded,
+			sub:   &api.SubscriptionDocument{Subscription: &api.Subscription{State: api.SubscriptionStateWarned}},
+			want:  SubscriptionStatusActive,
+		},
+		{
+			name:  "suspended subscription",
+			state: api.ProvisioningStateSucceeded,
+			sub:   &api.SubscriptionDocument{Subscription: &api.Subscription{State: api.SubscriptionStateSuspended}},
+			want:  SubscriptionStatusDisconnected,
+		},
+		{
+			name:  "unknown subscription",
+			state: api.ProvisioningStateFailed,
+			want:  SubscriptionStatusActive,
+		},
+		{
+			name:  "deleting cluster",
+			state: api.ProvisioningStateDeleting,
+			sub:   &api.SubscriptionDocument{Subscription: &api.Subscription{State: api.SubscriptionStateSuspended}},
+			want:  SubscriptionStatusDeprovisioned,
+		},
+		{
+			name:  "deleted cluster",
+			state: api.ProvisioningStateSucceeded,
+			tomb:  true,
+			want:  SubscriptionStatusDeprovisioned,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &api.OpenShiftClusterDocument{
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: tt.state,
+					},
+				},
+			}
+			if tt.tomb {
+				doc.SoftDeleted = &api.SoftDeleted{}
+			}
+
+			if got := SubscriptionStatusFor(doc, tt.sub); got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package permissions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"regexp"
+	"strings"
+
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+)
+
+// CanDoAction returns true if the given permissions allow action a, i.e. if
+// any permission has an action matching a and no not-action matching a.
+// Actions may contain '*' wildcards and are matched case-insensitively.
+func CanDoAction(ps []mgmtauthorization.Permission, a string) (bool, error) {
+	for _, p := range ps {
+		matched, err := matchesAny(p.Actions, a)
+		if err != nil {
+			return false, err
+		}
+		if !matched {
+			continue
+		}
+
+		excluded, err := matchesAny(p.NotActions, a)
+		if err != nil {
+			return false, err
+		}
+		if !excluded {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func matchesAny(patterns *[]string, a string) (bool, error) {
+	if patterns == nil {
+		return false, nil
+	}
+
+	for _, pattern := range *patterns {
+		rx, err := regexp.Compile("(?i)^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$")
+		if err != nil {
+			return false, err
+		}
+
+		if rx.MatchString(a) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
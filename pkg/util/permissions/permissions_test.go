@@ -0,0 +1,97 @@
+package permissions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+)
+
+func TestCanDoAction(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		ps     []mgmtauthorization.Permission
+		action string
+		want   bool
+	}{
+		{
+			name:   "no permissions",
+			action: "Microsoft.Network/virtualNetworks/read",
+		},
+		{
+			name: "exact match",
+			ps: []mgmtauthorization.Permission{
+				{
+					Actions: &[]string{"Microsoft.Network/virtualNetworks/read"},
+				},
+			},
+			action: "Microsoft.Network/virtualNetworks/read",
+			want:   true,
+		},
+		{
+			name: "case insensitive match",
+			ps: []mgmtauthorization.Permission{
+				{
+					Actions: &[]string{"microsoft.network/virtualnetworks/READ"},
+				},
+			},
+			action: "Microsoft.Network/virtualNetworks/read",
+			want:   true,
+		},
+		{
+			name: "wildcard match",
+			ps: []mgmtauthorization.Permission{
+				{
+					Actions: &[]string{"Microsoft.Network/*/read"},
+				},
+			},
+			action: "Microsoft.Network/virtualNetworks/subnets/read",
+			want:   true,
+		},
+		{
+			name: "wildcard does not match",
+			ps: []mgmtauthorization.Permission{
+				{
+					Actions: &[]string{"Microsoft.Network/*/read"},
+				},
+			},
+			action: "Microsoft.Network/virtualNetworks/write",
+		},
+		{
+			name: "excluded by not action",
+			ps: []mgmtauthorization.Permission{
+				{
+					Actions:    &[]string{"*"},
+					NotActions: &[]string{"Microsoft.Network/virtualNetworks/write"},
+				},
+			},
+			action: "Microsoft.Network/virtualNetworks/write",
+		},
+		{
+			name: "allowed by another permission",
+			ps: []mgmtauthorization.Permission{
+				{
+					Actions:    &[]string{"*"},
+					NotActions: &[]string{"Microsoft.Network/virtualNetworks/write"},
+				},
+				{
+					Actions: &[]string{"Microsoft.Network/virtualNetworks/write"},
+				},
+			},
+			action: "Microsoft.Network/virtualNetworks/write",
+			want:   true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanDoAction(tt.ps, tt.action)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -36,21 +36,35 @@ func userPassFromBase64(secret string) (*UserPass, error) {
 // Extract decodes a username and password for a given domain from a
 // JSON-encoded pull secret (e.g. from docker auth)
 func Extract(rawPullSecret, domain string) (*UserPass, error) {
+	token, found, err := Auth(rawPullSecret, domain)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("missing '%s' key in pullsecret", domain)
+	}
+
+	return userPassFromBase64(token)
+}
+
+// Auth returns the undecoded auth token for a given domain from a
+// JSON-encoded pull secret, and whether the pull secret has the domain
+func Auth(rawPullSecret, domain string) (string, bool, error) {
 	pullSecrets := &pullSecret{}
 	err := json.Unmarshal([]byte(rawPullSecret), pullSecrets)
 	if err != nil {
-		return nil, errors.New("malformed pullsecret (invalid JSON)")
+		return "", false, errors.New("malformed pullsecret (invalid JSON)")
 	}
 
 	auth, ok := pullSecrets.Auths[domain]
 	if !ok {
-		return nil, fmt.Errorf("missing '%s' key in pullsecret", domain)
+		return "", false, nil
 	}
 
-	token, ok := auth["auth"]
+	token, ok := auth["auth"].(string)
 	if !ok {
-		return nil, errors.New("malformed pullsecret (no auth key)")
+		return "", false, errors.New("malformed pullsecret (no auth key)")
 	}
 
-	return userPassFromBase64(token.(string))
+	return token, true, nil
 }
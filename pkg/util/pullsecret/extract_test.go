@@ -53,6 +53,25 @@ var _ = Describe("Extract()", func() {
 	})
 })
 
+var _ = Describe("Auth()", func() {
+	It("returns the undecoded auth token", func() {
+		pullSecret := "{\"auths\": {\"example.com\": {\"auth\": \"dGVzdHVzZXI6dGVzdHBhc3M=\"}}}"
+
+		token, found, err := Auth(pullSecret, "example.com")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(token).To(Equal("dGVzdHVzZXI6dGVzdHBhc3M="))
+	})
+
+	It("returns not found if no pullsecret for that name exists", func() {
+		pullSecret := "{\"auths\": {\"example.com\": {\"auth\": \"dGVzdHVzZXI6dGVzdHBhc3M=\"}}}"
+
+		_, found, err := Auth(pullSecret, "missingexample.com")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+})
+
 func TestPullSecret(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "PullSecret Suite")
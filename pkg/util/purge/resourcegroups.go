@@ -112,7 +112,7 @@ func (rc *ResourceCleaner) cleanPrivateLink(ctx context.Context, resourceGroup m
 		for _, peconn := range *pls.PrivateEndpointConnections {
 			rc.log.Debugf("Deleting private endpoint connection %s/%s/%s", *resourceGroup.Name, *pls.Name, *peconn.Name)
 			if !rc.dryRun {
-				_, err := rc.privatelinkservicescli.DeletePrivateEndpointConnection(ctx, *resourceGroup.Name, *pls.Name, *peconn.Name)
+				err := rc.privatelinkservicescli.DeletePrivateEndpointConnectionAndWait(ctx, *resourceGroup.Name, *pls.Name, *peconn.Name)
 				if err != nil {
 					return err
 				}
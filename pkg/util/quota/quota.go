@@ -0,0 +1,180 @@
+package quota
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+	"github.com/Azure/ARO-RP/pkg/util/computeskus"
+)
+
+// VMSkuFunc returns the SKU of a given VM size
+type VMSkuFunc func(vmSize string) (*mgmtcompute.ResourceSku, error)
+
+// addRequiredResources adds the quota required by count VMs of vmSize.  The
+// family and core count are taken from the VM SKU where it is known, falling
+// back to the static table of supported VM sizes.  Neither availability zones
+// nor encryption at host change the quota consumed: quota is regional, and a
+// VM with encryption at host is still billed against its SKU family.
+func addRequiredResources(requiredResources map[string]int, vmSku VMSkuFunc, vmSize api.VMSize, count int) error {
+	family, coreCount, ok := vmSizeQuota(vmSku, vmSize)
+	if !ok {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The provided VM SKU %s is not supported.", vmSize).WithRemediationURL(api.RemediationURLVMSizes)
+	}
+
+	requiredResources["virtualMachines"] += count
+	requiredResources["PremiumDiskCount"] += count
+
+	requiredResources[family] += coreCount * count
+	requiredResources["cores"] += coreCount * count
+	return nil
+}
+
+// addRequiredSpotResources adds the quota required by count Spot VMs of
+// vmSize.  Spot VM cores are counted against the regional low priority quota
+// instead of the family and regional core quotas.
+func addRequiredSpotResources(requiredResources map[string]int, vmSku VMSkuFunc, vmSize api.VMSize, count int) error {
+	_, coreCount, ok := vmSizeQuota(vmSku, vmSize)
+	if !ok {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The provided VM SKU %s is not supported.", vmSize).WithRemediationURL(api.RemediationURLVMSizes)
+	}
+
+	requiredResources["virtualMachines"] += count
+	requiredResources["PremiumDiskCount"] += count
+
+	requiredResources["lowPriorityCores"] += coreCount * count
+	return nil
+}
+
+func vmSizeQuota(vmSku VMSkuFunc, vmSize api.VMSize) (string, int, bool) {
+	if sku, err := vmSku(string(vmSize)); err == nil && sku.Family != nil {
+		if coreCount, ok := computeskus.VCPUs(sku); ok {
+			return *sku.Family, coreCount, true
+		}
+	}
+
+	vm, ok := validate.VMSizeFromName(vmSize)
+	return vm.Family, vm.CoreCount, ok
+}
+
+// RequiredResources returns the quota required to create oc: its
+// masters, the bootstrap node, its workers and its public IP addresses
+func RequiredResources(oc *api.OpenShiftCluster, vmSku VMSkuFunc) (map[string]int, error) {
+	requiredResources := map[string]int{}
+
+	err := addRequiredResources(requiredResources, vmSku, oc.Properties.MasterProfile.VMSize, 4)
+	if err != nil {
+		return nil, err
+	}
+
+	workerProfiles, _ := api.GetEnrichedWorkerProfiles(oc.Properties)
+	//worker node resource calculation
+	for _, w := range workerProfiles {
+		add := addRequiredResources
+		if w.SpotVMOptions != nil {
+			add = addRequiredSpotResources
+		}
+
+		err := add(requiredResources, vmSku, w.VMSize, w.Count)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//Public IP Addresses minimum requirement: 2 for ARM template deployment and 1 for kube-controller-manager
+	requiredResources["PublicIPAddresses"] = 3
+
+	// the first managed outbound IP is one of the above; large clusters in
+	// particular need further managed outbound IPs for SNAT ports
+	if lbp := oc.Properties.NetworkProfile.LoadBalancerProfile; lbp != nil &&
+		lbp.ManagedOutboundIPs != nil && lbp.ManagedOutboundIPs.Count > 1 {
+		requiredResources["PublicIPAddresses"] += lbp.ManagedOutboundIPs.Count - 1
+	}
+
+	return requiredResources, nil
+}
+
+// Validate returns an error if the quotas of the subscription cannot
+// accommodate both oc and the pending clusters, whose resources are not yet
+// counted by the Usage API
+func Validate(ctx context.Context, oc *api.OpenShiftCluster, pending []*api.OpenShiftCluster, vmSku VMSkuFunc, spNetworkUsage network.UsageClient, spComputeUsage compute.UsageClient) error {
+	// If ValidateQuota runs outside install process, we should skip quota validation
+	requiredResources, err := RequiredResources(oc, vmSku)
+	if err != nil {
+		return err
+	}
+
+	pendingResources := map[string]int{}
+	for _, p := range pending {
+		// a pending cluster was validated when it was created; if its VM
+		// sizes are no longer known, don't fail this cluster because of it
+		r, err := RequiredResources(p, vmSku)
+		if err != nil {
+			continue
+		}
+
+		for name, required := range r {
+			pendingResources[name] += required
+		}
+	}
+
+	//check requirements vs. usage
+
+	// we're only checking the limits returned by the Usage API and ignoring usage limits missing from the results
+	// rationale:
+	// 1. if the Usage API doesn't send a limit because a resource is no longer limited, RP will continue cluster creation without impact
+	// 2. if the Usage API doesn't send a limit that is still enforced, cluster creation will fail on the backend and we will get an error in the RP logs
+	computeUsages, err := spComputeUsage.List(ctx, oc.Location)
+	if err != nil {
+		return err
+	}
+
+	for _, usage := range computeUsages {
+		err = checkQuota(*usage.Name.Value, *usage.Limit, int64(*usage.CurrentValue), requiredResources, pendingResources)
+		if err != nil {
+			return err
+		}
+	}
+
+	netUsages, err := spNetworkUsage.List(ctx, oc.Location)
+	if err != nil {
+		return err
+	}
+
+	for _, netUsage := range netUsages {
+		err = checkQuota(*netUsage.Name.Value, *netUsage.Limit, *netUsage.CurrentValue, requiredResources, pendingResources)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkQuota returns an error if the quota of name cannot accommodate both
+// what the cluster requires and what clusters being created will consume
+func checkQuota(name string, limit, current int64, requiredResources, pendingResources map[string]int) error {
+	required, present := requiredResources[name]
+	if !present {
+		return nil
+	}
+
+	pending := pendingResources[name]
+	if int64(required+pending) <= limit-current {
+		return nil
+	}
+
+	if pending > 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceQuotaExceeded, "", "Resource quota of %s exceeded. Maximum allowed: %d, Current in use: %d, Pending for clusters being created: %d, Additional requested: %d.", name, limit, current, pending, required).WithRemediationURL(api.RemediationURLQuota)
+	}
+
+	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceQuotaExceeded, "", "Resource quota of %s exceeded. Maximum allowed: %d, Current in use: %d, Additional requested: %d.", name, limit, current, required).WithRemediationURL(api.RemediationURLQuota)
+}
@@ -0,0 +1,348 @@
+package quota
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateQuota(t *testing.T) {
+	ctx := context.Background()
+
+	noVMSkus := func(vmSize string) (*mgmtcompute.ResourceSku, error) {
+		return nil, fmt.Errorf("sku information not found for vm size %q", vmSize)
+	}
+
+	type test struct {
+		name    string
+		modify  func(*api.OpenShiftCluster)
+		pending []*api.OpenShiftCluster
+		vmSku   VMSkuFunc
+		mocks   func(*test, *mock_compute.MockUsageClient, *mock_network.MockUsageClient)
+		wantErr string
+	}
+	for _, tt := range []*test{
+		{
+			name: "allow when there's enough resources - limits set to exact requirements, offset by 100 of current value",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("cores"),
+							},
+							CurrentValue: to.Int32Ptr(100),
+							Limit:        to.Int64Ptr(212),
+						},
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("virtualMachines"),
+							},
+							CurrentValue: to.Int32Ptr(100),
+							Limit:        to.Int64Ptr(114),
+						},
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("standardDSv3Family"),
+							},
+							CurrentValue: to.Int32Ptr(100),
+							Limit:        to.Int64Ptr(212),
+						},
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("PremiumDiskCount"),
+							},
+							CurrentValue: to.Int32Ptr(100),
+							Limit:        to.Int64Ptr(114),
+						},
+					}, nil)
+				nuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtnetwork.Usage{
+						{
+							Name: &mgmtnetwork.UsageName{
+								Value: to.StringPtr("PublicIPAddresses"),
+							},
+							CurrentValue: to.Int64Ptr(4),
+							Limit:        to.Int64Ptr(10),
+						},
+					}, nil)
+			},
+		},
+		{
+			name:    "not enough cores",
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of cores exceeded. Maximum allowed: 212, Current in use: 101, Additional requested: 112.",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("cores"),
+							},
+							CurrentValue: to.Int32Ptr(101),
+							Limit:        to.Int64Ptr(212),
+						},
+					}, nil)
+			},
+		},
+		{
+			name:    "not enough virtualMachines",
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of virtualMachines exceeded. Maximum allowed: 114, Current in use: 101, Additional requested: 14.",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("virtualMachines"),
+							},
+							CurrentValue: to.Int32Ptr(101),
+							Limit:        to.Int64Ptr(114),
+						},
+					}, nil)
+			},
+		},
+		{
+			name:    "not enough standardDSv3Family",
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of standardDSv3Family exceeded. Maximum allowed: 212, Current in use: 101, Additional requested: 112.",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("standardDSv3Family"),
+							},
+							CurrentValue: to.Int32Ptr(101),
+							Limit:        to.Int64Ptr(212),
+						},
+					}, nil)
+			},
+		},
+		{
+			name:    "not enough premium disks",
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of PremiumDiskCount exceeded. Maximum allowed: 114, Current in use: 101, Additional requested: 14.",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("PremiumDiskCount"),
+							},
+							CurrentValue: to.Int32Ptr(101),
+							Limit:        to.Int64Ptr(114),
+						},
+					}, nil)
+			},
+		},
+		{
+			name:    "not enough public ip addresses",
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of PublicIPAddresses exceeded. Maximum allowed: 6, Current in use: 4, Additional requested: 3.",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{}, nil)
+				nuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtnetwork.Usage{
+						{
+							Name: &mgmtnetwork.UsageName{
+								Value: to.StringPtr("PublicIPAddresses"),
+							},
+							CurrentValue: to.Int64Ptr(4),
+							Limit:        to.Int64Ptr(6),
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "not enough public ip addresses for managed outbound ips",
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile = &api.LoadBalancerProfile{
+					ManagedOutboundIPs: &api.ManagedOutboundIPs{
+						Count: 5,
+					},
+				}
+			},
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of PublicIPAddresses exceeded. Maximum allowed: 10, Current in use: 4, Additional requested: 7.",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{}, nil)
+				nuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtnetwork.Usage{
+						{
+							Name: &mgmtnetwork.UsageName{
+								Value: to.StringPtr("PublicIPAddresses"),
+							},
+							CurrentValue: to.Int64Ptr(4),
+							Limit:        to.Int64Ptr(10),
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "spot workers use low priority cores",
+			modify: func(oc *api.OpenShiftCluster) {
+				oc.Properties.WorkerProfiles = append(oc.Properties.WorkerProfiles, api.WorkerProfile{
+					VMSize:        "Standard_D8s_v3",
+					Count:         5,
+					SpotVMOptions: &api.SpotVMOptions{},
+				})
+			},
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of lowPriorityCores exceeded. Maximum allowed: 30, Current in use: 0, Additional requested: 40.",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("cores"),
+							},
+							CurrentValue: to.Int32Ptr(100),
+							Limit:        to.Int64Ptr(212),
+						},
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("lowPriorityCores"),
+							},
+							CurrentValue: to.Int32Ptr(0),
+							Limit:        to.Int64Ptr(30),
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "use the VM SKU family and vCPUs when the SKU is known",
+			vmSku: func(vmSize string) (*mgmtcompute.ResourceSku, error) {
+				return &mgmtcompute.ResourceSku{
+					Name:   to.StringPtr(vmSize),
+					Family: to.StringPtr("standardDSv5Family"),
+					Capabilities: &[]mgmtcompute.ResourceSkuCapabilities{
+						{
+							Name:  to.StringPtr("vCPUs"),
+							Value: to.StringPtr("16"),
+						},
+					},
+				}, nil
+			},
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of standardDSv5Family exceeded. Maximum allowed: 300, Current in use: 100, Additional requested: 224.",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("standardDSv3Family"),
+							},
+							CurrentValue: to.Int32Ptr(100),
+							Limit:        to.Int64Ptr(212),
+						},
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("standardDSv5Family"),
+							},
+							CurrentValue: to.Int32Ptr(100),
+							Limit:        to.Int64Ptr(300),
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "not enough cores once clusters being created are accounted for",
+			pending: []*api.OpenShiftCluster{
+				{
+					Properties: api.OpenShiftClusterProperties{
+						MasterProfile: api.MasterProfile{
+							VMSize: "Standard_D8s_v3",
+						},
+						WorkerProfiles: []api.WorkerProfile{
+							{
+								VMSize: "Standard_D4s_v3",
+								Count:  3,
+							},
+						},
+					},
+				},
+				{
+					Properties: api.OpenShiftClusterProperties{
+						MasterProfile: api.MasterProfile{
+							VMSize: "Unknown_VMSize",
+						},
+					},
+				},
+			},
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of cores exceeded. Maximum allowed: 212, Current in use: 100, Pending for clusters being created: 44, Additional requested: 112.",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("cores"),
+							},
+							CurrentValue: to.Int32Ptr(100),
+							Limit:        to.Int64Ptr(212),
+						},
+					}, nil)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			computeUsageClient := mock_compute.NewMockUsageClient(controller)
+			networkUsageClient := mock_network.NewMockUsageClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(tt, computeUsageClient, networkUsageClient)
+			}
+
+			oc := &api.OpenShiftCluster{
+				Location: "ocLocation",
+				Properties: api.OpenShiftClusterProperties{
+					Install: &api.Install{
+						Phase: api.InstallPhaseBootstrap,
+					},
+					MasterProfile: api.MasterProfile{
+						VMSize: "Standard_D8s_v3",
+					},
+					WorkerProfiles: []api.WorkerProfile{
+						{
+							VMSize: "Standard_D8s_v3",
+							Count:  10,
+						},
+					},
+				},
+			}
+			if tt.modify != nil {
+				tt.modify(oc)
+			}
+
+			vmSku := tt.vmSku
+			if vmSku == nil {
+				vmSku = noVMSkus
+			}
+
+			err := Validate(ctx, oc, tt.pending, vmSku, networkUsageClient, computeUsageClient)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
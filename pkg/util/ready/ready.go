@@ -9,14 +9,36 @@ import (
 
 	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
 	mcoclientv1 "github.com/openshift/machine-config-operator/pkg/generated/clientset/versioned/typed/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	policyv1client "k8s.io/client-go/kubernetes/typed/policy/v1"
 )
 
+// WithLogging returns a function which calls check and logs its result
+// against the kind and name of the object checked, so that all readiness
+// polls log in the same way
+func WithLogging(log *logrus.Entry, kind, name string, check func() (bool, error)) func() (bool, error) {
+	return func() (bool, error) {
+		ok, err := check()
+		switch {
+		case err != nil:
+			log.Infof("%s %q readiness check failed: %v", kind, name, err)
+		case ok:
+			log.Infof("%s %q is ready", kind, name)
+		default:
+			log.Infof("%s %q is not ready", kind, name)
+		}
+
+		return ok, err
+	}
+}
+
 // NodeIsReady returns true if a Node is considered ready
 func NodeIsReady(node *corev1.Node) bool {
 	for _, c := range node.Status.Conditions {
@@ -170,6 +192,22 @@ func StatefulSetIsReady(s *appsv1.StatefulSet) bool {
 		s.Generation == s.Status.ObservedGeneration
 }
 
+// CheckStatefulSetIsReady returns a function which polls a StatefulSet and
+// returns its readiness
+func CheckStatefulSetIsReady(ctx context.Context, cli appsv1client.StatefulSetInterface, name string) func() (bool, error) {
+	return func() (bool, error) {
+		s, err := cli.Get(ctx, name, metav1.GetOptions{})
+		switch {
+		case kerrors.IsNotFound(err):
+			return false, nil
+		case err != nil:
+			return false, err
+		}
+
+		return StatefulSetIsReady(s), nil
+	}
+}
+
 // MachineConfigPoolIsReady returns true if a MachineConfigPool is considered
 // ready
 func MachineConfigPoolIsReady(s *mcv1.MachineConfigPool) bool {
@@ -193,3 +231,27 @@ func CheckMachineConfigPoolIsReady(ctx context.Context, cli mcoclientv1.MachineC
 		return MachineConfigPoolIsReady(s), nil
 	}
 }
+
+// PodDisruptionAllowed returns true if a PodDisruptionBudget currently allows
+// at least one of its pods to be evicted
+func PodDisruptionAllowed(pdb *policyv1.PodDisruptionBudget) bool {
+	return pdb.Status.DisruptionsAllowed > 0 &&
+		pdb.Generation == pdb.Status.ObservedGeneration
+}
+
+// CheckPodDisruptionAllowed returns a function which polls a
+// PodDisruptionBudget and returns if it allows a disruption.  A missing
+// PodDisruptionBudget does not restrict evictions, so it allows one.
+func CheckPodDisruptionAllowed(ctx context.Context, cli policyv1client.PodDisruptionBudgetInterface, name string) func() (bool, error) {
+	return func() (bool, error) {
+		pdb, err := cli.Get(ctx, name, metav1.GetOptions{})
+		switch {
+		case kerrors.IsNotFound(err):
+			return true, nil
+		case err != nil:
+			return false, err
+		}
+
+		return PodDisruptionAllowed(pdb), nil
+	}
+}
@@ -10,8 +10,11 @@ import (
 
 	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
 	mcofake "github.com/openshift/machine-config-operator/pkg/generated/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
@@ -650,3 +653,122 @@ func TestCheckPodsAreReadyError(t *testing.T) {
 		t.Fatalf("check pod error is: %v", err)
 	}
 }
+
+func TestCheckStatefulSetIsReady(t *testing.T) {
+	ctx := context.Background()
+
+	clientset := fake.NewSimpleClientset(&appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "statefulset",
+			Namespace: "default",
+		},
+		Status: appsv1.StatefulSetStatus{
+			ReadyReplicas:   1,
+			UpdatedReplicas: 1,
+		},
+	})
+
+	ok, err := CheckStatefulSetIsReady(ctx, clientset.AppsV1().StatefulSets("default"), "statefulset")()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("expected statefulset to be ready")
+	}
+
+	ok, err = CheckStatefulSetIsReady(ctx, clientset.AppsV1().StatefulSets("default"), "statefulset-not-found")()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("expected missing statefulset not to be ready")
+	}
+}
+
+func TestCheckPodDisruptionAllowed(t *testing.T) {
+	ctx := context.Background()
+
+	clientset := fake.NewSimpleClientset(
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "allowed",
+				Namespace: "default",
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{
+				DisruptionsAllowed: 1,
+			},
+		},
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "not-allowed",
+				Namespace: "default",
+			},
+		},
+	)
+
+	for _, tt := range []struct {
+		name string
+		want bool
+	}{
+		{
+			name: "allowed",
+			want: true,
+		},
+		{
+			name: "not-allowed",
+		},
+		{
+			name: "not-found",
+			want: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := CheckPodDisruptionAllowed(ctx, clientset.PolicyV1().PodDisruptionBudgets("default"), tt.name)()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.want {
+				t.Error(ok)
+			}
+		})
+	}
+}
+
+func TestWithLogging(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		ok      bool
+		err     error
+		wantMsg string
+	}{
+		{
+			name:    "ready",
+			ok:      true,
+			wantMsg: `deployment "test" is ready`,
+		},
+		{
+			name:    "not ready",
+			wantMsg: `deployment "test" is not ready`,
+		},
+		{
+			name:    "error",
+			err:     errors.New("failed"),
+			wantMsg: `deployment "test" readiness check failed: failed`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, hook := test.NewNullLogger()
+
+			ok, err := WithLogging(logrus.NewEntry(logger), "deployment", "test", func() (bool, error) {
+				return tt.ok, tt.err
+			})()
+			if ok != tt.ok || err != tt.err {
+				t.Error(ok, err)
+			}
+
+			if hook.LastEntry() == nil || hook.LastEntry().Message != tt.wantMsg {
+				t.Error(hook.AllEntries())
+			}
+		})
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/Azure/ARO-RP/pkg/api"
@@ -82,7 +84,10 @@ func (c conditionStep) run(ctx context.Context, log *logrus.Entry) error {
 	// is ErrWaitTimeout. Internal ErrWaitTimeout errors are wrapped to avoid
 	// confusion with wait.PollImmediateUntil's own behavior of returning
 	// ErrWaitTimeout when the condition is not met.
+	var attempts int
 	err := wait.PollImmediateUntil(pollInterval, func() (bool, error) {
+		attempts++
+
 		// We use the outer context, not the timeout context, as we do not want
 		// to time out the condition function itself, only stop retrying once
 		// timeoutCtx's timeout has fired.
@@ -94,6 +99,8 @@ func (c conditionStep) run(ctx context.Context, log *logrus.Entry) error {
 		return cnd, cndErr
 	}, timeoutCtx.Done())
 
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("aro.step.retries", attempts-1))
+
 	if err != nil && !c.fail {
 		log.Warnf("step %s failed but has configured 'fail=%t'. Continuing. Error: %s", c, c.fail, err.Error())
 		return nil
@@ -0,0 +1,185 @@
+package steps
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// Node is a step of a graph run by RunGraph.  It is run once all of the nodes
+// it depends on have succeeded.
+type Node struct {
+	Name      string
+	Step      Step
+	DependsOn []string
+}
+
+// StatusFunc is called by RunGraph from a single goroutine whenever the
+// status of a node changes, so that the status can be persisted
+type StatusFunc func(ctx context.Context, name string, status api.StepStatus)
+
+// RunGraph runs the steps of the dependency graph nodes, running up to
+// parallelism steps at once.  Nodes whose status in statuses is
+// api.StepStatusSucceeded, i.e. which succeeded in an earlier run, are
+// skipped.  Once a step fails no further steps are started; the steps which
+// are running are waited for and the first error is returned.
+func RunGraph(ctx context.Context, log *logrus.Entry, nodes []Node, parallelism int, statuses map[string]api.StepStatus, status StatusFunc) (err error) {
+	err = validateGraph(nodes)
+	if err != nil {
+		return err
+	}
+
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	steps := make([]Step, 0, len(nodes))
+	for _, n := range nodes {
+		steps = append(steps, n.Step)
+	}
+
+	ctx, span := startRunSpan(ctx, steps)
+	defer func() { endSpan(span, err) }()
+
+	succeeded := map[string]bool{}
+	var pending []Node
+	for _, n := range nodes {
+		if statuses[n.Name] == api.StepStatusSucceeded {
+			log.Infof("skipping step %s: succeeded in an earlier run", n.Step)
+			succeeded[n.Name] = true
+			continue
+		}
+		pending = append(pending, n)
+	}
+
+	type result struct {
+		node Node
+		err  error
+	}
+
+	results := make(chan result)
+	var running int
+
+	for {
+		if err == nil {
+			var waiting []Node
+			for _, n := range pending {
+				if running == parallelism || !dependenciesSucceeded(n, succeeded) {
+					waiting = append(waiting, n)
+					continue
+				}
+
+				if status != nil {
+					status(ctx, n.Name, api.StepStatusRunning)
+				}
+
+				running++
+				go func(n Node) {
+					log.Infof("running step %s", n.Step)
+
+					stepCtx, stepSpan := startStepSpan(ctx, n.Step)
+					err := n.Step.run(stepCtx, log)
+					endSpan(stepSpan, err)
+
+					results <- result{node: n, err: err}
+				}(n)
+			}
+			pending = waiting
+		}
+
+		if running == 0 {
+			break
+		}
+
+		r := <-results
+		running--
+
+		if r.err != nil {
+			if status != nil {
+				status(ctx, r.node.Name, api.StepStatusFailed)
+			}
+			if err == nil {
+				err = stepError(log, r.node.Step, r.err)
+			}
+			continue
+		}
+
+		if status != nil {
+			status(ctx, r.node.Name, api.StepStatusSucceeded)
+		}
+		succeeded[r.node.Name] = true
+	}
+
+	return err
+}
+
+func dependenciesSucceeded(n Node, succeeded map[string]bool) bool {
+	for _, d := range n.DependsOn {
+		if !succeeded[d] {
+			return false
+		}
+	}
+	return true
+}
+
+// validateGraph returns an error if the names of nodes are not unique, if a
+// node depends on an unknown node, or if the graph has a cycle
+func validateGraph(nodes []Node) error {
+	byName := map[string]Node{}
+	for _, n := range nodes {
+		if _, ok := byName[n.Name]; ok {
+			return fmt.Errorf("duplicate step %q", n.Name)
+		}
+		byName[n.Name] = n
+	}
+
+	for _, n := range nodes {
+		for _, d := range n.DependsOn {
+			if _, ok := byName[d]; !ok {
+				return fmt.Errorf("step %q depends on unknown step %q", n.Name, d)
+			}
+		}
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := map[string]int{}
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("dependency cycle through step %q", name)
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		for _, d := range byName[name].DependsOn {
+			err := visit(d)
+			if err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+
+		return nil
+	}
+
+	for _, n := range nodes {
+		err := visit(n.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
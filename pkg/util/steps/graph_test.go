@@ -0,0 +1,223 @@
+package steps
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+// graphRecorder records which steps ran and how many ran at once
+type graphRecorder struct {
+	mu          sync.Mutex
+	ran         []string
+	running     int
+	maxRunning  int
+	failingStep string
+}
+
+func (g *graphRecorder) node(name string, dependsOn ...string) Node {
+	return Node{
+		Name: name,
+		Step: Action(func(ctx context.Context) error {
+			g.mu.Lock()
+			g.running++
+			if g.running > g.maxRunning {
+				g.maxRunning = g.running
+			}
+			g.mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			g.mu.Lock()
+			defer g.mu.Unlock()
+			g.running--
+			g.ran = append(g.ran, name)
+
+			if name == g.failingStep {
+				return errors.New("oh no!")
+			}
+			return nil
+		}),
+		DependsOn: dependsOn,
+	}
+}
+
+func TestRunGraph(t *testing.T) {
+	ctx := context.Background()
+	log := logrus.NewEntry(logrus.StandardLogger())
+
+	for _, tt := range []struct {
+		name           string
+		parallelism    int
+		statuses       map[string]api.StepStatus
+		failingStep    string
+		wantRan        map[string]bool
+		wantStatuses   map[string]api.StepStatus
+		wantMaxRunning int
+		wantErr        string
+	}{
+		{
+			name:        "all steps run, bounded by the parallelism",
+			parallelism: 2,
+			wantRan: map[string]bool{
+				"a": true, "b": true, "c": true, "d": true, "e": true,
+			},
+			wantStatuses: map[string]api.StepStatus{
+				"a": api.StepStatusSucceeded,
+				"b": api.StepStatusSucceeded,
+				"c": api.StepStatusSucceeded,
+				"d": api.StepStatusSucceeded,
+				"e": api.StepStatusSucceeded,
+			},
+			wantMaxRunning: 2,
+		},
+		{
+			name:        "steps which succeeded in an earlier run are skipped",
+			parallelism: 4,
+			statuses: map[string]api.StepStatus{
+				"a": api.StepStatusSucceeded,
+				"b": api.StepStatusSucceeded,
+				"c": api.StepStatusFailed,
+			},
+			wantRan: map[string]bool{
+				"c": true, "d": true, "e": true,
+			},
+			wantStatuses: map[string]api.StepStatus{
+				"a": api.StepStatusSucceeded,
+				"b": api.StepStatusSucceeded,
+				"c": api.StepStatusSucceeded,
+				"d": api.StepStatusSucceeded,
+				"e": api.StepStatusSucceeded,
+			},
+			wantMaxRunning: 2,
+		},
+		{
+			name:        "dependents of a failed step are not run",
+			parallelism: 4,
+			failingStep: "a",
+			wantRan: map[string]bool{
+				"a": true, "b": true, "e": true,
+			},
+			wantStatuses: map[string]api.StepStatus{
+				"a": api.StepStatusFailed,
+				"b": api.StepStatusSucceeded,
+				"e": api.StepStatusSucceeded,
+			},
+			wantMaxRunning: 3,
+			wantErr:        "oh no!",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &graphRecorder{failingStep: tt.failingStep}
+
+			// c depends on a and b, d depends on c, e is independent
+			nodes := []Node{
+				g.node("a"),
+				g.node("b"),
+				g.node("c", "a", "b"),
+				g.node("d", "c"),
+				g.node("e"),
+			}
+
+			statuses := map[string]api.StepStatus{}
+			for k, v := range tt.statuses {
+				statuses[k] = v
+			}
+
+			var order []string
+			err := RunGraph(ctx, log, nodes, tt.parallelism, tt.statuses, func(ctx context.Context, name string, status api.StepStatus) {
+				statuses[name] = status
+				if status == api.StepStatusRunning {
+					order = append(order, name)
+				}
+			})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			ran := map[string]bool{}
+			for _, name := range g.ran {
+				ran[name] = true
+			}
+			if !reflect.DeepEqual(ran, tt.wantRan) {
+				t.Errorf("got steps run %v, want %v", ran, tt.wantRan)
+			}
+
+			if !reflect.DeepEqual(statuses, tt.wantStatuses) {
+				t.Errorf("got statuses %v, want %v", statuses, tt.wantStatuses)
+			}
+
+			if g.maxRunning != tt.wantMaxRunning {
+				t.Errorf("got %d steps running at once, want %d", g.maxRunning, tt.wantMaxRunning)
+			}
+
+			started := map[string]int{}
+			for i, name := range order {
+				started[name] = i
+			}
+			for _, n := range nodes {
+				for _, d := range n.DependsOn {
+					if i, ok := started[n.Name]; ok && tt.statuses[d] != api.StepStatusSucceeded && started[d] > i {
+						t.Errorf("step %s started before its dependency %s", n.Name, d)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestValidateGraph(t *testing.T) {
+	step := Action(successfulFunc)
+
+	for _, tt := range []struct {
+		name    string
+		nodes   []Node
+		wantErr string
+	}{
+		{
+			name: "valid",
+			nodes: []Node{
+				{Name: "a", Step: step},
+				{Name: "b", Step: step, DependsOn: []string{"a"}},
+			},
+		},
+		{
+			name: "duplicate step",
+			nodes: []Node{
+				{Name: "a", Step: step},
+				{Name: "a", Step: step},
+			},
+			wantErr: `duplicate step "a"`,
+		},
+		{
+			name: "unknown dependency",
+			nodes: []Node{
+				{Name: "a", Step: step, DependsOn: []string{"b"}},
+			},
+			wantErr: `step "a" depends on unknown step "b"`,
+		},
+		{
+			name: "cycle",
+			nodes: []Node{
+				{Name: "a", Step: step, DependsOn: []string{"c"}},
+				{Name: "b", Step: step, DependsOn: []string{"a"}},
+				{Name: "c", Step: step, DependsOn: []string{"b"}},
+			},
+			wantErr: `dependency cycle through step "a"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGraph(tt.nodes)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
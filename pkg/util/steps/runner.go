@@ -44,34 +44,38 @@ type Step interface {
 	metricsName() string
 }
 
+// ProgressFunc is called by RunWithProgress before each step is run, with the
+// step's name, its index and the total number of steps
+type ProgressFunc func(ctx context.Context, step string, index, count int)
+
 // Run executes the provided steps in order until one fails or all steps
 // are completed. Errors from failed steps are returned directly.
 // time cost for each step run will be recorded for metrics usage
 func Run(ctx context.Context, log *logrus.Entry, pollInterval time.Duration, steps []Step, now func() time.Time) (map[string]int64, error) {
-	stepTimeRun := make(map[string]int64)
-	for _, step := range steps {
+	return RunWithProgress(ctx, log, pollInterval, steps, now, nil)
+}
+
+// RunWithProgress is Run, but also reports each step to progress if it is not
+// nil
+func RunWithProgress(ctx context.Context, log *logrus.Entry, pollInterval time.Duration, steps []Step, now func() time.Time, progress ProgressFunc) (stepTimeRun map[string]int64, err error) {
+	ctx, span := startRunSpan(ctx, steps)
+	defer func() { endSpan(span, err) }()
+
+	stepTimeRun = make(map[string]int64)
+	for i, step := range steps {
 		log.Infof("running step %s", step)
 
+		if progress != nil {
+			progress(ctx, step.String(), i, len(steps))
+		}
+
 		startTime := time.Now()
-		err := step.run(ctx, log)
+		stepCtx, stepSpan := startStepSpan(ctx, step)
+		err = step.run(stepCtx, log)
+		endSpan(stepSpan, err)
 
 		if err != nil {
-			if azureerrors.IsUnauthorizedClientError(err) ||
-				azureerrors.HasAuthorizationFailedError(err) ||
-				azureerrors.IsInvalidSecretError(err) {
-				err = api.NewCloudError(http.StatusBadRequest, step.String(),
-					"encountered error",
-					err.Error())
-				log.Error(err)
-			} else {
-				log.Errorf("step %s encountered error: %s", step, err.Error())
-			}
-
-			if oDataError, ok := err.(msgraph_errors.ODataErrorable); ok {
-				spew.Fdump(log.Writer(), oDataError.GetErrorEscaped())
-			}
-
-			return nil, err
+			return nil, stepError(log, step, err)
 		}
 
 		if now != nil {
@@ -81,3 +85,24 @@ func Run(ctx context.Context, log *logrus.Entry, pollInterval time.Duration, ste
 	}
 	return stepTimeRun, nil
 }
+
+// stepError logs the error returned by step, and returns it as a bad request
+// if it is caused by the customer's credentials
+func stepError(log *logrus.Entry, step Step, err error) error {
+	if azureerrors.IsUnauthorizedClientError(err) ||
+		azureerrors.HasAuthorizationFailedError(err) ||
+		azureerrors.IsInvalidSecretError(err) {
+		err = api.NewCloudError(http.StatusBadRequest, step.String(),
+			"encountered error",
+			err.Error())
+		log.Error(err)
+	} else {
+		log.Errorf("step %s encountered error: %s", step, err.Error())
+	}
+
+	if oDataError, ok := err.(msgraph_errors.ODataErrorable); ok {
+		spew.Fdump(log.Writer(), oDataError.GetErrorEscaped())
+	}
+
+	return err
+}
@@ -6,6 +6,8 @@ package steps
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -305,3 +307,30 @@ func TestStepMetricsNameFormatting(t *testing.T) {
 		})
 	}
 }
+
+func TestRunWithProgress(t *testing.T) {
+	ctx := context.Background()
+	_, log := testlog.New()
+
+	s := []Step{
+		Action(successfulFunc),
+		Condition(alwaysTrueCondition, 1*time.Millisecond, true),
+		Action(failingFunc),
+		Action(successfulFunc),
+	}
+
+	var got []string
+	_, err := RunWithProgress(ctx, log, time.Millisecond, s, nil, func(ctx context.Context, step string, index, count int) {
+		got = append(got, fmt.Sprintf("%d/%d %s", index, count, step))
+	})
+	utilerror.AssertErrorMessage(t, err, "oh no!")
+
+	want := []string{
+		"0/4 [Action github.com/Azure/ARO-RP/pkg/util/steps.successfulFunc]",
+		"1/4 [Condition github.com/Azure/ARO-RP/pkg/util/steps.alwaysTrueCondition, timeout 1ms]",
+		"2/4 [Action github.com/Azure/ARO-RP/pkg/util/steps.failingFunc]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Error(got)
+	}
+}
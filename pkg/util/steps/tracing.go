@@ -0,0 +1,61 @@
+package steps
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Azure/ARO-RP/pkg/util/steps"
+
+type contextKey int
+
+const (
+	contextKeyResourceID contextKey = iota
+)
+
+// WithResourceID returns a copy of ctx which attributes the spans of steps run
+// with it to the cluster resourceID
+func WithResourceID(ctx context.Context, resourceID string) context.Context {
+	return context.WithValue(ctx, contextKeyResourceID, resourceID)
+}
+
+func resourceIDAttributes(ctx context.Context) []attribute.KeyValue {
+	if resourceID, ok := ctx.Value(contextKeyResourceID).(string); ok && resourceID != "" {
+		return []attribute.KeyValue{attribute.String("aro.resource_id", resourceID)}
+	}
+	return nil
+}
+
+// startRunSpan starts the span which is the parent of the spans of all the
+// steps in a run, so that a whole run can be viewed as one trace
+func startRunSpan(ctx context.Context, steps []Step) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "steps.Run",
+		trace.WithAttributes(resourceIDAttributes(ctx)...),
+		trace.WithAttributes(attribute.Int("aro.steps.count", len(steps))),
+	)
+}
+
+// startStepSpan starts a span for step.  The context returned carries the
+// span, so Azure calls made by the step are part of the same trace.
+func startStepSpan(ctx context.Context, step Step) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, step.metricsName(),
+		trace.WithAttributes(resourceIDAttributes(ctx)...),
+		trace.WithAttributes(attribute.String("aro.step", step.String())),
+	)
+}
+
+// endSpan records err, if any, on span and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -2,6 +2,7 @@ package subnet
 
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
+
 import (
 	"strings"
 
@@ -9,6 +10,47 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 )
 
+// AddServiceEndpoints adds the endpoints which are missing from subnet, or
+// which are not in succeeded state, to subnet and returns true if subnet
+// changed.  It does not update the subnet in Azure.
+func AddServiceEndpoints(subnet *mgmtnetwork.Subnet, endpoints []string) (subnetChanged bool) {
+	if subnet.SubnetPropertiesFormat == nil {
+		subnet.SubnetPropertiesFormat = &mgmtnetwork.SubnetPropertiesFormat{}
+	}
+
+	return addEndpointsToSubnet(endpoints, subnet)
+}
+
+// RemoveServiceEndpoints removes the endpoints from subnet and returns true if
+// subnet changed.  It does not update the subnet in Azure.
+func RemoveServiceEndpoints(subnet *mgmtnetwork.Subnet, endpoints []string) (subnetChanged bool) {
+	if subnet.SubnetPropertiesFormat == nil || subnet.ServiceEndpoints == nil {
+		return false
+	}
+
+	serviceEndpoints := make([]mgmtnetwork.ServiceEndpointPropertiesFormat, 0, len(*subnet.ServiceEndpoints))
+	for _, serviceEndpoint := range *subnet.ServiceEndpoints {
+		var remove bool
+		for _, endpoint := range endpoints {
+			if strings.EqualFold(*serviceEndpoint.Service, endpoint) {
+				remove = true
+				break
+			}
+		}
+
+		if remove {
+			subnetChanged = true
+			continue
+		}
+
+		serviceEndpoints = append(serviceEndpoints, serviceEndpoint)
+	}
+
+	subnet.ServiceEndpoints = &serviceEndpoints
+
+	return subnetChanged
+}
+
 // addEndpointsToSubnets adds the endpoints (that either are missing in subnets
 // or aren't in succeded state in subnets) to the subnets and returns those updated subnets.
 // This method does not talk to any external dependecies to remain pure bussiness logic.
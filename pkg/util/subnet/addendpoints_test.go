@@ -237,3 +237,32 @@ func TestAddEndpointsToSubnets(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveServiceEndpoints(t *testing.T) {
+	subnet := &mgmtnetwork.Subnet{
+		SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+			ServiceEndpoints: &[]mgmtnetwork.ServiceEndpointPropertiesFormat{
+				{Service: to.StringPtr("Microsoft.ContainerRegistry")},
+				{Service: to.StringPtr("Microsoft.Storage")},
+			},
+		},
+	}
+
+	if !RemoveServiceEndpoints(subnet, []string{"microsoft.storage"}) {
+		t.Error("expected subnet to change")
+	}
+
+	if !reflect.DeepEqual(*subnet.ServiceEndpoints, []mgmtnetwork.ServiceEndpointPropertiesFormat{
+		{Service: to.StringPtr("Microsoft.ContainerRegistry")},
+	}) {
+		t.Error(*subnet.ServiceEndpoints)
+	}
+
+	if RemoveServiceEndpoints(subnet, []string{"Microsoft.Storage"}) {
+		t.Error("expected subnet not to change")
+	}
+
+	if RemoveServiceEndpoints(&mgmtnetwork.Subnet{}, []string{"Microsoft.Storage"}) {
+		t.Error("expected subnet without properties not to change")
+	}
+}
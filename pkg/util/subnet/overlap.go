@@ -0,0 +1,84 @@
+package subnet
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"net"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/apparentlymart/go-cidr/cidr"
+)
+
+// OverlapError is returned by VerifyNoOverlap when address ranges overlap
+type OverlapError struct {
+	err error
+}
+
+func (e *OverlapError) Error() string {
+	return e.err.Error()
+}
+
+// AddressPrefixes returns the parsed address prefixes of subnet, from
+// AddressPrefix or AddressPrefixes, whichever is set
+func AddressPrefixes(subnet *mgmtnetwork.Subnet) ([]*net.IPNet, error) {
+	if subnet.SubnetPropertiesFormat == nil {
+		return nil, fmt.Errorf("subnet %q has no properties", to.String(subnet.ID))
+	}
+
+	var prefixes []string
+	switch {
+	case subnet.AddressPrefix != nil:
+		prefixes = []string{*subnet.AddressPrefix}
+	case subnet.AddressPrefixes != nil:
+		prefixes = *subnet.AddressPrefixes
+	}
+
+	if len(prefixes) == 0 {
+		return nil, fmt.Errorf("subnet %q has no address prefix", to.String(subnet.ID))
+	}
+
+	nets := make([]*net.IPNet, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		_, n, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+
+	return nets, nil
+}
+
+// VerifyNoOverlap returns an *OverlapError if any of the address prefixes of
+// subnets and cidrs (e.g. the cluster's pod and service CIDRs) overlap.
+// Subnets are expected to be unique.  Errors parsing the address ranges are
+// returned unwrapped.
+func VerifyNoOverlap(subnets []*mgmtnetwork.Subnet, cidrs []string) error {
+	var nets []*net.IPNet
+
+	for _, subnet := range subnets {
+		prefixes, err := AddressPrefixes(subnet)
+		if err != nil {
+			return err
+		}
+		nets = append(nets, prefixes...)
+	}
+
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			return err
+		}
+		nets = append(nets, n)
+	}
+
+	err := cidr.VerifyNoOverlap(nets, &net.IPNet{IP: net.IPv4zero, Mask: net.IPMask(net.IPv4zero)})
+	if err != nil {
+		return &OverlapError{err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package subnet
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestVerifyNoOverlap(t *testing.T) {
+	master := &mgmtnetwork.Subnet{
+		ID: to.StringPtr("master"),
+		SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+			AddressPrefix: to.StringPtr("10.0.0.0/24"),
+		},
+	}
+	worker := &mgmtnetwork.Subnet{
+		ID: to.StringPtr("worker"),
+		SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+			AddressPrefixes: &[]string{"10.0.1.0/24", "10.0.2.0/24"},
+		},
+	}
+
+	for _, tt := range []struct {
+		name        string
+		subnets     []*mgmtnetwork.Subnet
+		cidrs       []string
+		wantErr     string
+		wantOverlap bool
+	}{
+		{
+			name:    "no overlap",
+			subnets: []*mgmtnetwork.Subnet{master, worker},
+			cidrs:   []string{"10.128.0.0/14", "172.30.0.0/16"},
+		},
+		{
+			name:        "subnet overlaps pod CIDR",
+			subnets:     []*mgmtnetwork.Subnet{master, worker},
+			cidrs:       []string{"10.0.0.0/14", "172.30.0.0/16"},
+			wantErr:     "10.0.0.0/14 overlaps with 10.0.0.0/24",
+			wantOverlap: true,
+		},
+		{
+			name: "subnets overlap",
+			subnets: []*mgmtnetwork.Subnet{master, {
+				ID: to.StringPtr("other"),
+				SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+					AddressPrefix: to.StringPtr("10.0.2.0/23"),
+				},
+			}, worker},
+			wantErr:     "10.0.2.0/24 overlaps with 10.0.2.0/23",
+			wantOverlap: true,
+		},
+		{
+			name:    "invalid CIDR",
+			subnets: []*mgmtnetwork.Subnet{master},
+			cidrs:   []string{"invalid"},
+			wantErr: "invalid CIDR address: invalid",
+		},
+		{
+			name: "subnet without address prefix",
+			subnets: []*mgmtnetwork.Subnet{{
+				ID:                     to.StringPtr("empty"),
+				SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{},
+			}},
+			wantErr: `subnet "empty" has no address prefix`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyNoOverlap(tt.subnets, tt.cidrs)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if _, ok := err.(*OverlapError); ok != tt.wantOverlap {
+				t.Errorf("got overlap error %v", ok)
+			}
+		})
+	}
+}
@@ -5,7 +5,6 @@ package subnet
 
 import (
 	"context"
-	"net"
 
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
 	"github.com/Azure/go-autorest/autorest"
@@ -29,6 +28,9 @@ type Manager interface {
 	GetHighestFreeIP(ctx context.Context, subnetID string) (string, error)
 	CreateOrUpdate(ctx context.Context, subnetID string, subnet *mgmtnetwork.Subnet) error
 	CreateOrUpdateFromIds(ctx context.Context, subnetIds []string, gatewayEnabled bool) error
+	AddServiceEndpoints(ctx context.Context, subnetID string, endpoints []string) error
+	RemoveServiceEndpoints(ctx context.Context, subnetID string, endpoints []string) error
+	VerifyNoOverlap(ctx context.Context, subnetIds []string, cidrs []string) error
 }
 
 type manager struct {
@@ -75,18 +77,14 @@ func (m *manager) GetHighestFreeIP(ctx context.Context, subnetID string) (string
 		return "", err
 	}
 
-	// grab the first addresPrefix in the subnet
-	var subnetCIDR *net.IPNet
-	if subnet.AddressPrefix == nil {
-		_, subnetCIDR, err = net.ParseCIDR((*subnet.AddressPrefixes)[0])
-	} else {
-		_, subnetCIDR, err = net.ParseCIDR(*subnet.AddressPrefix)
-	}
-
+	prefixes, err := AddressPrefixes(subnet)
 	if err != nil {
 		return "", err
 	}
 
+	// grab the first addresPrefix in the subnet
+	subnetCIDR := prefixes[0]
+
 	bottom, top := cidr.AddressRange(subnetCIDR)
 
 	allocated := map[string]struct{}{}
@@ -172,3 +170,43 @@ func (m *manager) createOrUpdateSubnets(ctx context.Context, subnets []*mgmtnetw
 	}
 	return nil
 }
+
+// AddServiceEndpoints adds the endpoints to the linked subnet, if they are
+// missing or not in succeeded state
+func (m *manager) AddServiceEndpoints(ctx context.Context, subnetID string, endpoints []string) error {
+	subnet, err := m.Get(ctx, subnetID)
+	if err != nil {
+		return err
+	}
+
+	if !AddServiceEndpoints(subnet, endpoints) {
+		return nil
+	}
+
+	return m.CreateOrUpdate(ctx, subnetID, subnet)
+}
+
+// RemoveServiceEndpoints removes the endpoints from the linked subnet
+func (m *manager) RemoveServiceEndpoints(ctx context.Context, subnetID string, endpoints []string) error {
+	subnet, err := m.Get(ctx, subnetID)
+	if err != nil {
+		return err
+	}
+
+	if !RemoveServiceEndpoints(subnet, endpoints) {
+		return nil
+	}
+
+	return m.CreateOrUpdate(ctx, subnetID, subnet)
+}
+
+// VerifyNoOverlap returns an *OverlapError if the address prefixes of the
+// linked subnets and cidrs overlap
+func (m *manager) VerifyNoOverlap(ctx context.Context, subnetIds []string, cidrs []string) error {
+	subnets, err := m.GetAll(ctx, subnetIds)
+	if err != nil {
+		return err
+	}
+
+	return VerifyNoOverlap(subnets, cidrs)
+}
@@ -0,0 +1,39 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// ValidateDiagnosticSettings checks that the authorizer can read the Log
+// Analytics workspace of the cluster's diagnostic settings profile and link
+// diagnostic settings to it
+func (dv *dynamic) ValidateDiagnosticSettings(ctx context.Context, oc *api.OpenShiftCluster) error {
+	dv.log.Print("ValidateDiagnosticSettings")
+
+	if oc.Properties.DiagnosticSettingsProfile == nil {
+		return nil
+	}
+
+	r, err := azure.ParseResourceID(oc.Properties.DiagnosticSettingsProfile.WorkspaceResourceID)
+	if err != nil {
+		return err
+	}
+
+	const path = "properties.diagnosticSettingsProfile.workspaceResourceId"
+
+	return dv.validatePermissions(ctx, permissionCheck{
+		resource: r,
+		kind:     "Log Analytics workspace",
+		actions:  workspaceActions,
+		path:     path,
+		notFound: api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedWorkspace, path, "The Log Analytics workspace '%s' could not be found.", r.String()).WithTargetResourceID(r.String()),
+	})
+}
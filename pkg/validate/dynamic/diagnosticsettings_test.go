@@ -0,0 +1,120 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/authz/remotepdp"
+	mock_remotepdp "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/authz/remotepdp"
+	mock_azcore "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/azcore"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateDiagnosticSettings(t *testing.T) {
+	workspaceID := "/subscriptions/0000000-0000-0000-0000-000000000000/resourceGroups/fakeRG/providers/Microsoft.OperationalInsights/workspaces/fakeWorkspace"
+
+	oc := &api.OpenShiftCluster{
+		Properties: api.OpenShiftClusterProperties{
+			DiagnosticSettingsProfile: &api.DiagnosticSettingsProfile{
+				WorkspaceResourceID: workspaceID,
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		oc      *api.OpenShiftCluster
+		mocks   func(*mock_remotepdp.MockRemotePDPClient, context.CancelFunc)
+		wantErr string
+	}{
+		{
+			name: "no diagnostic settings profile",
+			oc:   &api.OpenShiftCluster{},
+		},
+		{
+			name: "pass",
+			oc:   oc,
+			mocks: func(pdpClient *mock_remotepdp.MockRemotePDPClient, cancel context.CancelFunc) {
+				pdpClient.EXPECT().
+					CheckAccess(gomock.Any(), gomock.Any()).
+					Return(workspaceAuthorizationDecision(remotepdp.Allowed), nil)
+			},
+		},
+		{
+			name: "fail: missing permissions",
+			oc:   oc,
+			mocks: func(pdpClient *mock_remotepdp.MockRemotePDPClient, cancel context.CancelFunc) {
+				pdpClient.EXPECT().
+					CheckAccess(gomock.Any(), gomock.Any()).
+					Do(func(arg0, arg1 interface{}) {
+						cancel()
+					}).
+					Return(workspaceAuthorizationDecision(remotepdp.NotAllowed), nil)
+			},
+			wantErr: fmt.Sprintf("400: InvalidResourceProviderPermissions: properties.diagnosticSettingsProfile.workspaceResourceId: The resource provider service principal (Application ID: ) does not have the permissions required on the following resources: Log Analytics workspace '%s' is missing Microsoft.OperationalInsights/workspaces/read, Microsoft.OperationalInsights/workspaces/sharedKeys/action.", workspaceID),
+		},
+		{
+			name: "fail: workspace not found",
+			oc:   oc,
+			mocks: func(pdpClient *mock_remotepdp.MockRemotePDPClient, cancel context.CancelFunc) {
+				pdpClient.EXPECT().
+					CheckAccess(gomock.Any(), gomock.Any()).
+					Do(func(arg0, arg1 interface{}) {
+						cancel()
+					}).
+					Return(nil, autorest.DetailedError{StatusCode: http.StatusNotFound})
+			},
+			wantErr: fmt.Sprintf("400: InvalidLinkedWorkspace: properties.diagnosticSettingsProfile.workspaceResourceId: The Log Analytics workspace '%s' could not be found.", workspaceID),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tokenCred := mock_azcore.NewMockTokenCredential(controller)
+			mockTokenCredential(tokenCred)
+
+			pdpClient := mock_remotepdp.NewMockRemotePDPClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(pdpClient, cancel)
+			}
+
+			dv := &dynamic{
+				azEnv:                      &azureclient.PublicCloud,
+				authorizerType:             AuthorizerFirstParty,
+				log:                        logrus.NewEntry(logrus.StandardLogger()),
+				pdpClient:                  pdpClient,
+				useCheckAccess:             true,
+				checkAccessSubjectInfoCred: tokenCred,
+			}
+
+			err := dv.ValidateDiagnosticSettings(ctx, tt.oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func workspaceAuthorizationDecision(decision remotepdp.AccessDecision) *remotepdp.AuthorizationDecisionResponse {
+	response := &remotepdp.AuthorizationDecisionResponse{}
+	for _, action := range workspaceActions {
+		response.Value = append(response.Value, remotepdp.AuthorizationDecision{
+			ActionId:       action,
+			AccessDecision: decision,
+		})
+	}
+	return response
+}
@@ -11,7 +11,6 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
-	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 )
@@ -46,18 +45,31 @@ func (dv *dynamic) ValidateDiskEncryptionSets(ctx context.Context, oc *api.OpenS
 		}
 	}
 
+	resources := make([]azure.Resource, 0, len(ids))
+	checks := make([]permissionCheck, 0, len(ids))
 	for i, id := range ids {
 		r, err := azure.ParseResourceID(id)
 		if err != nil {
 			return err
 		}
 
-		err = dv.validateDiskEncryptionSetPermissions(ctx, &r, paths[i])
-		if err != nil {
-			return err
-		}
+		resources = append(resources, r)
+		checks = append(checks, permissionCheck{
+			resource: r,
+			kind:     "disk encryption set",
+			actions:  diskEncryptionSetActions,
+			path:     paths[i],
+			notFound: api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, paths[i], "The disk encryption set '%s' could not be found.", r.String()).WithTargetResourceID(r.String()),
+		})
+	}
 
-		err = dv.validateDiskEncryptionSetLocation(ctx, &r, oc.Location, paths[i])
+	err := dv.validatePermissions(ctx, checks...)
+	if err != nil {
+		return err
+	}
+
+	for i := range resources {
+		err = dv.validateDiskEncryptionSetLocation(ctx, &resources[i], oc.Location, paths[i])
 		if err != nil {
 			return err
 		}
@@ -66,29 +78,6 @@ func (dv *dynamic) ValidateDiskEncryptionSets(ctx context.Context, oc *api.OpenS
 	return nil
 }
 
-func (dv *dynamic) validateDiskEncryptionSetPermissions(ctx context.Context, desr *azure.Resource, path string) error {
-	dv.log.Print("validateDiskEncryptionSetPermissions")
-
-	errCode := api.CloudErrorCodeInvalidResourceProviderPermissions
-	if dv.authorizerType == AuthorizerClusterServicePrincipal {
-		errCode = api.CloudErrorCodeInvalidServicePrincipalPermissions
-	}
-
-	err := dv.validateActions(ctx, desr, []string{
-		"Microsoft.Compute/diskEncryptionSets/read",
-	})
-
-	if err == wait.ErrWaitTimeout {
-		return api.NewCloudError(http.StatusBadRequest, errCode, path, "The %s service principal does not have Reader permission on disk encryption set '%s'.", dv.authorizerType, desr.String())
-	}
-	if detailedErr, ok := err.(autorest.DetailedError); ok &&
-		detailedErr.StatusCode == http.StatusNotFound {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, path, "The disk encryption set '%s' could not be found.", desr.String())
-	}
-
-	return err
-}
-
 func (dv *dynamic) validateDiskEncryptionSetLocation(ctx context.Context, desr *azure.Resource, location, path string) error {
 	dv.log.Print("validateDiskEncryptionSetLocation")
 
@@ -96,13 +85,13 @@ func (dv *dynamic) validateDiskEncryptionSetLocation(ctx context.Context, desr *
 	if err != nil {
 		if detailedErr, ok := err.(autorest.DetailedError); ok &&
 			detailedErr.StatusCode == http.StatusNotFound {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, path, "The disk encryption set '%s' could not be found.", desr.String())
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, path, "The disk encryption set '%s' could not be found.", desr.String()).WithTargetResourceID(desr.String())
 		}
 		return err
 	}
 
 	if !strings.EqualFold(*des.Location, location) {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, "", "The disk encryption set location '%s' must match the cluster location '%s'.", *des.Location, location)
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, "", "The disk encryption set location '%s' must match the cluster location '%s'.", *des.Location, location).WithTargetResourceID(desr.String())
 	}
 
 	return nil
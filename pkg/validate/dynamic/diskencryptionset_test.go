@@ -226,9 +226,38 @@ func TestValidateDiskEncryptionSets(t *testing.T) {
 							CheckAccess(gomock.Any(), gomock.Any()).
 							Do(func(arg0, arg1 interface{}) {
 								cancel()
-							})
+							}).
+							Times(2)
 					},
-					wantErr: fmt.Sprintf("400: %s: properties.masterProfile.diskEncryptionSetId: The %s service principal does not have Reader permission on disk encryption set '%s'.", wantErrCode, authorizerType, fakeDesID1),
+					wantErr: fmt.Sprintf("400: %s: : The %s service principal (Application ID: ) does not have the permissions required on the following resources: disk encryption set '%s' is missing Microsoft.Compute/diskEncryptionSets/read; disk encryption set '%s' is missing Microsoft.Compute/diskEncryptionSets/read. Details: %[1]s: properties.masterProfile.diskEncryptionSetId: The disk encryption set is missing Microsoft.Compute/diskEncryptionSets/read., %[1]s: properties.workerProfiles[0].diskEncryptionSetId: The disk encryption set is missing Microsoft.Compute/diskEncryptionSets/read.", wantErrCode, authorizerType, fakeDesID1, fakeDesID2),
+				},
+				{
+					name: "invalid permissions on one of the disk encryption sets",
+					oc: &api.OpenShiftCluster{
+						Location: "eastus",
+						Properties: api.OpenShiftClusterProperties{
+							MasterProfile: api.MasterProfile{
+								DiskEncryptionSetID: fakeDesID1,
+							},
+							WorkerProfiles: []api.WorkerProfile{{
+								DiskEncryptionSetID: fakeDesID2,
+							}},
+						},
+					},
+					mocks: func(diskEncryptionSets *mock_compute.MockDiskEncryptionSetsClient, pdpClient *mock_remotepdp.MockRemotePDPClient, tokenCred *mock_azcore.MockTokenCredential, cancel context.CancelFunc) {
+						mockTokenCredential(tokenCred)
+						pdpClient.EXPECT().
+							CheckAccess(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(_ context.Context, authReq remotepdp.AuthorizationRequest) (*remotepdp.AuthorizationDecisionResponse, error) {
+								cancel() // wait.PollImmediateUntil will always be invoked at least once
+								if authReq.Resource.Id == fakeDesR1.String() {
+									return validDiskEncryptionAuthorizationDecision, nil
+								}
+								return invalidDiskEncryptionAuthorizationDecisionsReadNotAllowed, nil
+							}).
+							Times(2)
+					},
+					wantErr: fmt.Sprintf("400: %s: properties.workerProfiles[0].diskEncryptionSetId: The %s service principal (Application ID: ) does not have the permissions required on the following resources: disk encryption set '%s' is missing Microsoft.Compute/diskEncryptionSets/read.", wantErrCode, authorizerType, fakeDesID2),
 				},
 				{
 					name: "one of the disk encryption set permissions not found",
@@ -258,9 +287,6 @@ func TestValidateDiskEncryptionSets(t *testing.T) {
 								return invalidDiskEncryptionAuthorizationDecisionsReadNotAllowed, nil
 							},
 							).AnyTimes()
-						diskEncryptionSets.EXPECT().
-							Get(gomock.Any(), fakeDesR1.ResourceGroup, fakeDesR1.ResourceName).
-							Return(mgmtcompute.DiskEncryptionSet{Location: to.StringPtr("eastus")}, nil)
 					},
 					wantErr: fmt.Sprintf("400: InvalidLinkedDiskEncryptionSet: properties.workerProfiles[0].diskEncryptionSetId: The disk encryption set '%s' could not be found.", fakeDesID2),
 				},
@@ -310,6 +336,7 @@ func TestValidateDiskEncryptionSets(t *testing.T) {
 						log:                        logrus.NewEntry(logrus.StandardLogger()),
 						diskEncryptionSets:         diskEncryptionSetsClient,
 						pdpClient:                  remotePDPClient,
+						useCheckAccess:             true,
 						checkAccessSubjectInfoCred: tokenCred,
 					}
 
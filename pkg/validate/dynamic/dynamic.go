@@ -9,14 +9,11 @@ import (
 	"net"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
-	"github.com/apparentlymart/go-cidr/cidr"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/wait"
 
@@ -25,28 +22,28 @@ import (
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/authz/remotepdp"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/authorization"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
-	"github.com/Azure/ARO-RP/pkg/util/token"
+	"github.com/Azure/ARO-RP/pkg/util/subnet"
 )
 
 var (
-	errMsgNSGAttached                       = "The provided subnet '%s' is invalid: must not have a network security group attached."
-	errMsgOriginalNSGNotAttached            = "The provided subnet '%s' is invalid: must have network security group '%s' attached."
-	errMsgNSGNotAttached                    = "The provided subnet '%s' is invalid: must have a network security group attached."
-	errMsgNSGNotProperlyAttached            = "When the enable-preconfigured-nsg option is specified, both the master and worker subnets should have network security groups (NSG) attached to them before starting the cluster installation."
-	errMsgSPHasNoRequiredPermissionsOnNSG   = "The %s service principal (Application ID: %s) does not have Network Contributor role on network security group '%s'. This is required when the enable-preconfigured-nsg option is specified."
-	errMsgSubnetNotFound                    = "The provided subnet '%s' could not be found."
-	errMsgSubnetNotInSucceededState         = "The provided subnet '%s' is not in a Succeeded state"
-	errMsgSubnetInvalidSize                 = "The provided subnet '%s' is invalid: must be /27 or larger."
-	errMsgSPHasNoRequiredPermissionsOnVNet  = "The %s service principal (Application ID: %s) does not have Network Contributor role on vnet '%s'."
-	errMsgVnetNotFound                      = "The vnet '%s' could not be found."
-	errMsgSPHasNoRequiredPermissionsOnRT    = "The %s service principal does not have Network Contributor role on route table '%s'."
-	errMsgRTNotFound                        = "The route table '%s' could not be found."
-	errMsgSPHasNoRequiredPermissionsOnNatGW = "The %s service principal does not have Network Contributor role on nat gateway '%s'."
-	errMsgNatGWNotFound                     = "The nat gateway '%s' could not be found."
-	errMsgCIDROverlaps                      = "The provided CIDRs must not overlap: '%s'."
-	errMsgInvalidVNetLocation               = "The vnet location '%s' must match the cluster location '%s'."
+	errMsgNSGAttached                     = "The provided subnet '%s' is invalid: must not have a network security group attached."
+	errMsgOriginalNSGNotAttached          = "The provided subnet '%s' is invalid: must have network security group '%s' attached."
+	errMsgNSGNotAttached                  = "The provided subnet '%s' is invalid: must have a network security group attached."
+	errMsgNSGNotProperlyAttached          = "When the enable-preconfigured-nsg option is specified, both the master and worker subnets should have network security groups (NSG) attached to them before starting the cluster installation."
+	errMsgSPHasNoRequiredPermissionsOnNSG = "The %s service principal (Application ID: %s) does not have Network Contributor role on network security group '%s'. This is required when the enable-preconfigured-nsg option is specified."
+	errMsgSubnetNotFound                  = "The provided subnet '%s' could not be found."
+	errMsgSubnetNotInSucceededState       = "The provided subnet '%s' is not in a Succeeded state"
+	errMsgSubnetInvalidSize               = "The provided subnet '%s' is invalid: must be /27 or larger."
+	errMsgSubnetNoIPv6                    = "The provided subnet '%s' is invalid: must have an IPv6 address prefix on a dual-stack cluster."
+	errMsgSPHasNoRequiredPermissions      = "The %s service principal (Application ID: %s) does not have the permissions required on the following resources: %s."
+	errMsgVnetNotFound                    = "The vnet '%s' could not be found."
+	errMsgRTNotFound                      = "The route table '%s' could not be found."
+	errMsgNatGWNotFound                   = "The nat gateway '%s' could not be found."
+	errMsgCIDROverlaps                    = "The provided CIDRs must not overlap: '%s'."
+	errMsgInvalidVNetLocation             = "The vnet location '%s' must match the cluster location '%s'."
 )
 
 const minimumSubnetMaskSize int = 27
@@ -70,6 +67,8 @@ type Dynamic interface {
 	ValidateVnet(ctx context.Context, location string, subnets []Subnet, additionalCIDRs ...string) error
 	ValidateSubnets(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
 	ValidateDiskEncryptionSets(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidateDiagnosticSettings(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidatePublicIPPrefix(ctx context.Context, oc *api.OpenShiftCluster) error
 	ValidateEncryptionAtHost(ctx context.Context, oc *api.OpenShiftCluster) error
 	ValidateLoadBalancerProfile(ctx context.Context, oc *api.OpenShiftCluster) error
 	ValidatePreConfiguredNSGs(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
@@ -89,8 +88,15 @@ type dynamic struct {
 	resourceSkusClient                    compute.ResourceSkusClient
 	spComputeUsage                        compute.UsageClient
 	spNetworkUsage                        network.UsageClient
+	publicIPPrefixes                      network.PublicIPPrefixesClient
 	loadBalancerBackendAddressPoolsClient network.LoadBalancerBackendAddressPoolsClient
 	pdpClient                             remotepdp.RemotePDPClient
+
+	// useCheckAccess selects whether permissions are validated using
+	// CheckAccess or, if it is disabled, by listing the authorizer's
+	// permissions on each resource
+	useCheckAccess bool
+	permissions    authorization.PermissionsClient
 }
 
 type AuthorizerType string
@@ -110,6 +116,7 @@ func NewValidator(
 	authorizerType AuthorizerType,
 	cred azcore.TokenCredential,
 	pdpClient remotepdp.RemotePDPClient,
+	useCheckAccess bool,
 ) Dynamic {
 	return &dynamic{
 		log:                        log,
@@ -128,6 +135,10 @@ func NewValidator(
 		resourceSkusClient:                    compute.NewResourceSkusClient(azEnv, subscriptionID, authorizer),
 		pdpClient:                             pdpClient,
 		loadBalancerBackendAddressPoolsClient: network.NewLoadBalancerBackendAddressPoolsClient(azEnv, subscriptionID, authorizer),
+		publicIPPrefixes:                      network.NewPublicIPPrefixesClient(azEnv, subscriptionID, authorizer),
+
+		useCheckAccess: useCheckAccess,
+		permissions:    authorization.NewPermissionsClient(azEnv, subscriptionID, authorizer),
 	}
 }
 
@@ -161,6 +172,7 @@ func (dv *dynamic) ValidateVnet(
 
 	// get unique vnets from subnets
 	vnets := make(map[string]azure.Resource)
+	vnetIDs := []string{}
 	for _, s := range subnets {
 		vnetID, _, err := apisubnet.Split(s.ID)
 		if err != nil {
@@ -171,310 +183,161 @@ func (dv *dynamic) ValidateVnet(
 		if err != nil {
 			return err
 		}
+		if _, found := vnets[strings.ToLower(vnetID)]; !found {
+			vnetIDs = append(vnetIDs, strings.ToLower(vnetID))
+		}
 		vnets[strings.ToLower(vnetID)] = vnetr
 	}
 
-	// validate at vnet level
-	for _, vnet := range vnets {
-		err := dv.validateVnetPermissions(ctx, vnet)
+	// gather the permissions needed on every vnet, route table and nat
+	// gateway, so that all missing permissions are reported together
+	checks := []permissionCheck{}
+	for _, vnetID := range vnetIDs {
+		checks = append(checks, dv.vnetPermissionCheck(vnets[vnetID]))
+	}
+
+	// the route tables and nat gateways can't be found if the vnet can't be
+	// read, in which case the vnet permission check will fail first
+	var subnetErr error
+	seen := map[string]struct{}{}
+	add := func(check *permissionCheck, err error) {
 		if err != nil {
-			return err
+			if subnetErr == nil {
+				subnetErr = err
+			}
+			return
+		}
+		if check == nil {
+			return
 		}
 
-		err = dv.validateVnetLocation(ctx, vnet, location)
-		if err != nil {
-			return err
+		id := strings.ToLower(check.resource.String())
+		if _, found := seen[id]; !found {
+			seen[id] = struct{}{}
+			checks = append(checks, *check)
 		}
 	}
-
-	// validate at subnets level
 	for _, s := range subnets {
-		err := dv.validateRouteTablePermissions(ctx, s)
-		if err != nil {
-			return err
-		}
+		add(dv.routeTablePermissionCheck(ctx, s))
 	}
-
 	for _, s := range subnets {
-		err := dv.validateNatGatewayPermissions(ctx, s)
-		if err != nil {
-			return err
-		}
+		add(dv.natGatewayPermissionCheck(ctx, s))
 	}
-	return dv.validateCIDRRanges(ctx, subnets, additionalCIDRs...)
-}
-
-func (dv *dynamic) validateVnetPermissions(ctx context.Context, vnet azure.Resource) error {
-	dv.log.Printf("validateVnetPermissions")
 
-	errCode := api.CloudErrorCodeInvalidResourceProviderPermissions
-	if dv.authorizerType == AuthorizerClusterServicePrincipal {
-		errCode = api.CloudErrorCodeInvalidServicePrincipalPermissions
+	err := dv.validatePermissions(ctx, checks...)
+	if err != nil {
+		return err
 	}
-
-	err := dv.validateActions(ctx, &vnet, []string{
-		"Microsoft.Network/virtualNetworks/join/action",
-		"Microsoft.Network/virtualNetworks/read",
-		"Microsoft.Network/virtualNetworks/write",
-		"Microsoft.Network/virtualNetworks/subnets/join/action",
-		"Microsoft.Network/virtualNetworks/subnets/read",
-		"Microsoft.Network/virtualNetworks/subnets/write",
-	})
-
-	noPermissionsErr := api.NewCloudError(
-		http.StatusBadRequest,
-		errCode,
-		"",
-		errMsgSPHasNoRequiredPermissionsOnVNet,
-		dv.authorizerType,
-		dv.appID,
-		vnet.String(),
-	)
-
-	if err == wait.ErrWaitTimeout {
-		return noPermissionsErr
+	if subnetErr != nil {
+		return subnetErr
 	}
-	if detailedErr, ok := err.(autorest.DetailedError); ok {
-		dv.log.Error(detailedErr)
 
-		switch detailedErr.StatusCode {
-		case http.StatusNotFound:
-			return api.NewCloudError(
-				http.StatusBadRequest,
-				api.CloudErrorCodeInvalidLinkedVNet,
-				"",
-				errMsgVnetNotFound,
-				vnet.String(),
-			)
-		case http.StatusForbidden:
-			noPermissionsErr.Message = fmt.Sprintf(
-				"%s\nOriginal error message: %s",
-				noPermissionsErr.Message,
-				detailedErr.Message,
-			)
-			return noPermissionsErr
+	for _, vnetID := range vnetIDs {
+		err := dv.validateVnetLocation(ctx, vnets[vnetID], location)
+		if err != nil {
+			return err
 		}
 	}
-	return err
-}
 
-// validateRouteTablesPermissions will validate permissions on provided subnet
-func (dv *dynamic) validateRouteTablePermissions(ctx context.Context, s Subnet) error {
-	dv.log.Printf("validateRouteTablePermissions")
-
-	vnetID, _, err := apisubnet.Split(s.ID)
-	if err != nil {
-		return err
-	}
+	return dv.validateCIDRRanges(ctx, subnets, additionalCIDRs...)
+}
 
-	vnetr, err := azure.ParseResourceID(vnetID)
-	if err != nil {
-		return err
+func (dv *dynamic) vnetPermissionCheck(vnet azure.Resource) permissionCheck {
+	return permissionCheck{
+		resource: vnet,
+		kind:     "vnet",
+		actions:  vnetActions,
+		notFound: api.NewCloudError(
+			http.StatusBadRequest,
+			api.CloudErrorCodeInvalidLinkedVNet,
+			"",
+			errMsgVnetNotFound,
+			vnet.String(),
+		).WithTargetResourceID(vnet.String()),
 	}
+}
 
-	vnet, err := dv.virtualNetworks.Get(ctx, vnetr.ResourceGroup, vnetr.ResourceName, "")
+// routeTablePermissionCheck returns the permissions needed on the route table
+// attached to the provided subnet, or nil if there is none
+func (dv *dynamic) routeTablePermissionCheck(ctx context.Context, s Subnet) (*permissionCheck, error) {
+	vnet, err := dv.subnetVnet(ctx, s)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	rtID, err := getRouteTableID(&vnet, s.ID)
+	rtID, err := getRouteTableID(vnet, s.ID)
 	if err != nil || rtID == "" { // error or no route table
-		return err
+		return nil, err
 	}
 
 	rtr, err := azure.ParseResourceID(rtID)
 	if err != nil {
-		return err
-	}
-
-	errCode := api.CloudErrorCodeInvalidResourceProviderPermissions
-	if dv.authorizerType == AuthorizerClusterServicePrincipal {
-		errCode = api.CloudErrorCodeInvalidServicePrincipalPermissions
+		return nil, err
 	}
 
-	err = dv.validateActions(ctx, &rtr, []string{
-		"Microsoft.Network/routeTables/join/action",
-		"Microsoft.Network/routeTables/read",
-		"Microsoft.Network/routeTables/write",
-	})
-	if err == wait.ErrWaitTimeout {
-		return api.NewCloudError(
-			http.StatusBadRequest,
-			errCode,
-			"",
-			errMsgSPHasNoRequiredPermissionsOnRT,
-			dv.authorizerType,
-			rtID,
-		)
-	}
-	if detailedErr, ok := err.(autorest.DetailedError); ok &&
-		detailedErr.StatusCode == http.StatusNotFound {
-		return api.NewCloudError(
+	return &permissionCheck{
+		resource: rtr,
+		kind:     "route table",
+		actions:  routeTableActions,
+		notFound: api.NewCloudError(
 			http.StatusBadRequest,
 			api.CloudErrorCodeInvalidLinkedRouteTable,
 			"",
 			errMsgRTNotFound,
 			rtID,
-		)
-	}
-	return err
+		).WithTargetResourceID(rtID),
+	}, nil
 }
 
-// validateNatGatewayPermissions will validate permissions on provided subnet
-func (dv *dynamic) validateNatGatewayPermissions(ctx context.Context, s Subnet) error {
-	dv.log.Printf("validateNatGatewayPermissions")
-
-	vnetID, _, err := apisubnet.Split(s.ID)
-	if err != nil {
-		return err
-	}
-
-	vnetr, err := azure.ParseResourceID(vnetID)
+// natGatewayPermissionCheck returns the permissions needed on the nat gateway
+// attached to the provided subnet, or nil if there is none
+func (dv *dynamic) natGatewayPermissionCheck(ctx context.Context, s Subnet) (*permissionCheck, error) {
+	vnet, err := dv.subnetVnet(ctx, s)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	vnet, err := dv.virtualNetworks.Get(ctx, vnetr.ResourceGroup, vnetr.ResourceName, "")
-	if err != nil {
-		return err
-	}
-
-	ngID, err := getNatGatewayID(&vnet, s.ID)
-	if err != nil {
-		return err
-	}
-
-	if ngID == "" { // empty nat gateway
-		return nil
+	ngID, err := getNatGatewayID(vnet, s.ID)
+	if err != nil || ngID == "" { // error or no nat gateway
+		return nil, err
 	}
 
 	ngr, err := azure.ParseResourceID(ngID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	errCode := api.CloudErrorCodeInvalidResourceProviderPermissions
-	if dv.authorizerType == AuthorizerClusterServicePrincipal {
-		errCode = api.CloudErrorCodeInvalidServicePrincipalPermissions
-	}
-
-	err = dv.validateActions(ctx, &ngr, []string{
-		"Microsoft.Network/natGateways/join/action",
-		"Microsoft.Network/natGateways/read",
-		"Microsoft.Network/natGateways/write",
-	})
-	if err == wait.ErrWaitTimeout {
-		return api.NewCloudError(
-			http.StatusBadRequest,
-			errCode,
-			"",
-			errMsgSPHasNoRequiredPermissionsOnNatGW,
-			dv.authorizerType,
-			ngID,
-		)
-	}
-	if detailedErr, ok := err.(autorest.DetailedError); ok &&
-		detailedErr.StatusCode == http.StatusNotFound {
-		return api.NewCloudError(
+	return &permissionCheck{
+		resource: ngr,
+		kind:     "nat gateway",
+		actions:  natGatewayActions,
+		notFound: api.NewCloudError(
 			http.StatusBadRequest,
 			api.CloudErrorCodeInvalidLinkedNatGateway,
 			"",
 			errMsgNatGWNotFound,
 			ngID,
-		)
-	}
-	return err
-}
-
-func (dv *dynamic) validateActions(ctx context.Context, r *azure.Resource, actions []string) error {
-	// ARM has a 5 minute cache around role assignment creation, so wait one minute longer
-	timeoutCtx, cancel := context.WithTimeout(ctx, 6*time.Minute)
-	defer cancel()
-
-	c := closure{dv: dv, ctx: ctx, resource: r, actions: actions}
-
-	return wait.PollImmediateUntil(30*time.Second, c.usingCheckAccessV2, timeoutCtx.Done())
+		).WithTargetResourceID(ngID),
+	}, nil
 }
 
-// closure is the closure used in PollImmediateUntil's ConditionalFunc
-type closure struct {
-	dv       *dynamic
-	ctx      context.Context
-	resource *azure.Resource
-	actions  []string
-	oid      *string
-}
-
-// usingCheckAccessV2 uses the new RBAC checkAccessV2 API
-func (c closure) usingCheckAccessV2() (bool, error) {
-	c.dv.log.Info("validateActions with CheckAccessV2")
-
-	// reusing oid during retries
-	if c.oid == nil {
-		scope := c.dv.azEnv.ResourceManagerEndpoint + "/.default"
-		t, err := c.dv.checkAccessSubjectInfoCred.GetToken(c.ctx, policy.TokenRequestOptions{Scopes: []string{scope}})
-		if err != nil {
-			c.dv.log.Error("Unable to get the token from AAD: ", err)
-			return false, err
-		}
-
-		oid, err := token.GetObjectId(t.Token)
-		if err != nil {
-			c.dv.log.Error("Unable to parse the token oid claim: ", err)
-			return false, err
-		}
-		c.oid = &oid
-	}
-
-	authReq := createAuthorizationRequest(*c.oid, c.resource.String(), c.actions...)
-	results, err := c.dv.pdpClient.CheckAccess(c.ctx, authReq)
+func (dv *dynamic) subnetVnet(ctx context.Context, s Subnet) (*mgmtnetwork.VirtualNetwork, error) {
+	vnetID, _, err := apisubnet.Split(s.ID)
 	if err != nil {
-		c.dv.log.Error("Unexpected error when calling CheckAccessV2: ", err)
-		return false, err
+		return nil, err
 	}
 
-	if results == nil {
-		c.dv.log.Info("nil response returned from CheckAccessV2")
-		return false, nil
-	}
-
-	for _, action := range c.actions {
-		found := false
-		for _, result := range results.Value {
-			if result.ActionId == action {
-				found = true
-				if result.AccessDecision == remotepdp.Allowed {
-					break
-				}
-				return false, nil
-			}
-		}
-		if !found {
-			c.dv.log.Infof("The result didn't include permission %s", action)
-			return false, nil
-		}
+	vnetr, err := azure.ParseResourceID(vnetID)
+	if err != nil {
+		return nil, err
 	}
 
-	return true, nil
-}
-
-func createAuthorizationRequest(subject, resourceId string, actions ...string) remotepdp.AuthorizationRequest {
-	actionInfos := []remotepdp.ActionInfo{}
-	for _, action := range actions {
-		actionInfos = append(actionInfos, remotepdp.ActionInfo{Id: action})
+	vnet, err := dv.virtualNetworks.Get(ctx, vnetr.ResourceGroup, vnetr.ResourceName, "")
+	if err != nil {
+		return nil, err
 	}
 
-	return remotepdp.AuthorizationRequest{
-		Subject: remotepdp.SubjectInfo{
-			Attributes: remotepdp.SubjectAttributes{
-				ObjectId: subject,
-			},
-		},
-		Actions: actionInfos,
-		Resource: remotepdp.ResourceInfo{
-			Id: resourceId,
-		},
-	}
+	return &vnet, nil
 }
 
 func (dv *dynamic) validateCIDRRanges(ctx context.Context, subnets []Subnet, additionalCIDRs ...string) error {
@@ -485,7 +348,7 @@ func (dv *dynamic) validateCIDRRanges(ctx context.Context, subnets []Subnet, add
 	// only cares about unique CIDR ranges.
 	subnets = uniqueSubnetSlice(subnets)
 
-	var CIDRArray []*net.IPNet
+	var subnetObjects []*mgmtnetwork.Subnet
 
 	// unique names of subnets from all node pools
 	for _, s := range subnets {
@@ -509,44 +372,21 @@ func (dv *dynamic) validateCIDRRanges(ctx context.Context, subnets []Subnet, add
 			return err
 		}
 
-		// Validate the CIDR of AddressPrefix or AddressPrefixes, whichever is defined
-		if s.AddressPrefix == nil {
-			for _, address := range *s.AddressPrefixes {
-				_, net, err := net.ParseCIDR(address)
-				if err != nil {
-					return err
-				}
-				CIDRArray = append(CIDRArray, net)
-			}
-		} else {
-			_, net, err := net.ParseCIDR(*s.AddressPrefix)
-			if err != nil {
-				return err
-			}
-			CIDRArray = append(CIDRArray, net)
-		}
-	}
-
-	for _, c := range additionalCIDRs {
-		_, cidr, err := net.ParseCIDR(c)
-		if err != nil {
-			return err
-		}
-		CIDRArray = append(CIDRArray, cidr)
+		subnetObjects = append(subnetObjects, s)
 	}
 
-	err := cidr.VerifyNoOverlap(CIDRArray, &net.IPNet{IP: net.IPv4zero, Mask: net.IPMask(net.IPv4zero)})
-	if err != nil {
+	err := subnet.VerifyNoOverlap(subnetObjects, additionalCIDRs)
+	if overlapErr, ok := err.(*subnet.OverlapError); ok {
 		return api.NewCloudError(
 			http.StatusBadRequest,
 			api.CloudErrorCodeInvalidLinkedVNet,
 			"",
 			errMsgCIDROverlaps,
-			err,
+			overlapErr,
 		)
 	}
 
-	return nil
+	return err
 }
 
 func (dv *dynamic) validateVnetLocation(ctx context.Context, vnetr azure.Resource, location string) error {
@@ -565,7 +405,7 @@ func (dv *dynamic) validateVnetLocation(ctx context.Context, vnetr azure.Resourc
 			errMsgInvalidVNetLocation,
 			*vnet.Location,
 			location,
-		)
+		).WithTargetResourceID(vnetr.String())
 	}
 
 	return nil
@@ -603,7 +443,7 @@ func (dv *dynamic) createSubnetMapByID(ctx context.Context, subnets []Subnet) (m
 				s.Path,
 				errMsgSubnetNotFound,
 				s.ID,
-			)
+			).WithTargetResourceID(s.ID)
 		}
 
 		subnetByID[s.ID] = ss
@@ -668,7 +508,7 @@ func (dv *dynamic) ValidateSubnets(ctx context.Context, oc *api.OpenShiftCluster
 					return api.NewCloudError(
 						http.StatusBadRequest,
 						api.CloudErrorCodeInvalidLinkedVNet,
-						s.Path, errMsgNSGAttached, s.ID)
+						s.Path, errMsgNSGAttached, s.ID).WithTargetResourceID(s.ID)
 				}
 			}
 		} else {
@@ -686,7 +526,7 @@ func (dv *dynamic) ValidateSubnets(ctx context.Context, oc *api.OpenShiftCluster
 						errMsgOriginalNSGNotAttached,
 						s.ID,
 						nsgID,
-					)
+					).WithTargetResourceID(s.ID)
 				}
 			} else {
 				if !subnetHasNSGAttached(ss) {
@@ -696,7 +536,7 @@ func (dv *dynamic) ValidateSubnets(ctx context.Context, oc *api.OpenShiftCluster
 						s.Path,
 						errMsgNSGNotAttached,
 						s.ID,
-					)
+					).WithTargetResourceID(s.ID)
 				}
 			}
 		}
@@ -709,7 +549,7 @@ func (dv *dynamic) ValidateSubnets(ctx context.Context, oc *api.OpenShiftCluster
 				s.Path,
 				errMsgSubnetNotInSucceededState,
 				s.ID,
-			)
+			).WithTargetResourceID(s.ID)
 		}
 
 		// Handle both addressPrefix & addressPrefixes
@@ -724,6 +564,16 @@ func (dv *dynamic) ValidateSubnets(ctx context.Context, oc *api.OpenShiftCluster
 				return err
 			}
 		}
+
+		if oc.Properties.NetworkProfile.IsDualStack() && !subnetHasIPv6Prefix(ss) {
+			return api.NewCloudError(
+				http.StatusBadRequest,
+				api.CloudErrorCodeInvalidLinkedVNet,
+				s.Path,
+				errMsgSubnetNoIPv6,
+				s.ID,
+			).WithTargetResourceID(s.ID)
+		}
 	}
 
 	return nil
@@ -737,6 +587,11 @@ func validateSubnetSize(s Subnet, address string) error {
 		return err
 	}
 
+	// Azure IPv6 subnets are always /64
+	if net.IP.To4() == nil {
+		return nil
+	}
+
 	ones, _ := net.Mask.Size()
 	if ones > minimumSubnetMaskSize {
 		return api.NewCloudError(
@@ -745,11 +600,30 @@ func validateSubnetSize(s Subnet, address string) error {
 			s.Path,
 			errMsgSubnetInvalidSize,
 			s.ID,
-		)
+		).WithTargetResourceID(s.ID)
 	}
 	return nil
 }
 
+func subnetHasIPv6Prefix(ss *mgmtnetwork.Subnet) bool {
+	addresses := []string{}
+	if ss.AddressPrefix != nil {
+		addresses = append(addresses, *ss.AddressPrefix)
+	}
+	if ss.AddressPrefixes != nil {
+		addresses = append(addresses, *ss.AddressPrefixes...)
+	}
+
+	for _, address := range addresses {
+		ip, _, err := net.ParseCIDR(address)
+		if err == nil && ip.To4() == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (dv *dynamic) ValidatePreConfiguredNSGs(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error {
 	dv.log.Print("ValidatePreConfiguredNSGs")
 
@@ -803,7 +677,7 @@ func (dv *dynamic) validateNSGPermissions(ctx context.Context, nsgID string) err
 			dv.authorizerType,
 			dv.appID,
 			nsgID,
-		)
+		).WithTargetResourceID(nsgID).WithRemediationURL(api.RemediationURLPermissions)
 	}
 
 	return err
@@ -858,7 +732,7 @@ func findSubnet(vnet *mgmtnetwork.VirtualNetwork, subnetID string) (*mgmtnetwork
 		"",
 		errMsgSubnetNotFound,
 		subnetID,
-	)
+	).WithTargetResourceID(subnetID)
 }
 
 // uniqueSubnetSlice returns string subnets with unique values only
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -543,6 +544,31 @@ func TestValidateSubnets(t *testing.T) {
 			},
 			wantErr: "400: InvalidLinkedVNet: properties.masterProfile.subnetId: The provided subnet '" + masterSubnet + "' is invalid: must be /27 or larger.",
 		},
+		{
+			name: "pass: dual-stack subnet",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PodCIDRIPv6 = "fd01::/48"
+			},
+			vnetMocks: func(vnetClient *mock_network.MockVirtualNetworksClient, vnet mgmtnetwork.VirtualNetwork) {
+				(*vnet.Subnets)[0].AddressPrefix = nil
+				(*vnet.Subnets)[0].AddressPrefixes = to.StringSlicePtr([]string{"10.0.0.0/24", "fd00:db8:deca::/64"})
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, vnetName, "").
+					Return(vnet, nil)
+			},
+		},
+		{
+			name: "fail: dual-stack cluster with IPv4-only subnet",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.NetworkProfile.PodCIDRIPv6 = "fd01::/48"
+			},
+			vnetMocks: func(vnetClient *mock_network.MockVirtualNetworksClient, vnet mgmtnetwork.VirtualNetwork) {
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, vnetName, "").
+					Return(vnet, nil)
+			},
+			wantErr: "400: InvalidLinkedVNet: properties.masterProfile.subnetId: The provided subnet '" + masterSubnet + "' is invalid: must have an IPv6 address prefix on a dual-stack cluster.",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			controller := gomock.NewController(t)
@@ -721,7 +747,7 @@ func TestValidateVnetPermissions(t *testing.T) {
 					}).
 					Return(&invalidSubnetsAuthorizationDecisionsReadNotAllowed, nil)
 			},
-			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: fff51942-b1f9-4119-9453-aaa922259eb7) does not have Network Contributor role on vnet '" + vnetID + "'.",
+			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: fff51942-b1f9-4119-9453-aaa922259eb7) does not have the permissions required on the following resources: vnet '" + vnetID + "' is missing Microsoft.Network/virtualNetworks/read.",
 		},
 		{
 			name: "fail: CheckAccess Return less entries than requested",
@@ -734,7 +760,7 @@ func TestValidateVnetPermissions(t *testing.T) {
 					}).
 					Return(&invalidSubnetsAuthorizationDecisionsMissingWrite, nil)
 			},
-			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: fff51942-b1f9-4119-9453-aaa922259eb7) does not have Network Contributor role on vnet '" + vnetID + "'.",
+			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: fff51942-b1f9-4119-9453-aaa922259eb7) does not have the permissions required on the following resources: vnet '" + vnetID + "' is missing Microsoft.Network/virtualNetworks/read, Microsoft.Network/virtualNetworks/subnets/write.",
 		},
 		{
 			name: "fail: getting an invalid token from AAD",
@@ -768,7 +794,7 @@ func TestValidateVnetPermissions(t *testing.T) {
 					}).
 					Return(nil, nil)
 			},
-			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: fff51942-b1f9-4119-9453-aaa922259eb7) does not have Network Contributor role on vnet '" + vnetID + "'.",
+			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: fff51942-b1f9-4119-9453-aaa922259eb7) does not have the permissions required on the following resources: vnet '" + vnetID + "' is missing Microsoft.Network/virtualNetworks/join/action, Microsoft.Network/virtualNetworks/read, Microsoft.Network/virtualNetworks/write, Microsoft.Network/virtualNetworks/subnets/join/action, Microsoft.Network/virtualNetworks/subnets/read, Microsoft.Network/virtualNetworks/subnets/write.",
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
@@ -789,6 +815,7 @@ func TestValidateVnetPermissions(t *testing.T) {
 				authorizerType:             AuthorizerClusterServicePrincipal,
 				log:                        logrus.NewEntry(logrus.StandardLogger()),
 				pdpClient:                  pdpClient,
+				useCheckAccess:             true,
 				checkAccessSubjectInfoCred: tokenCred,
 			}
 
@@ -797,7 +824,7 @@ func TestValidateVnetPermissions(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			err = dv.validateVnetPermissions(ctx, vnetr)
+			err = dv.validatePermissions(ctx, dv.vnetPermissionCheck(vnetr))
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
 		})
 	}
@@ -921,7 +948,7 @@ func TestValidateRouteTablesPermissions(t *testing.T) {
 					}).
 					Return(&invalidRouteTablesAuthorizationDecisionsWriteNotAllowed, nil)
 			},
-			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal does not have Network Contributor role on route table '" + workerRtID + "'.",
+			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: ) does not have the permissions required on the following resources: route table '" + workerRtID + "' is missing Microsoft.Network/routeTables/write.",
 		},
 		{
 			name:   "fail: CheckAccessV2 doesn't return all the entries",
@@ -940,7 +967,7 @@ func TestValidateRouteTablesPermissions(t *testing.T) {
 					}).
 					Return(&invalidRouteTablesAuthorizationDecisionsMissingWrite, nil)
 			},
-			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal does not have Network Contributor role on route table '" + workerRtID + "'.",
+			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: ) does not have the permissions required on the following resources: route table '" + workerRtID + "' is missing Microsoft.Network/routeTables/write.",
 		},
 		{
 			name:   "pass",
@@ -1001,6 +1028,7 @@ func TestValidateRouteTablesPermissions(t *testing.T) {
 				log:                        logrus.NewEntry(logrus.StandardLogger()),
 				checkAccessSubjectInfoCred: tokenCred,
 				pdpClient:                  pdpClient,
+				useCheckAccess:             true,
 				virtualNetworks:            vnetClient,
 			}
 
@@ -1012,7 +1040,10 @@ func TestValidateRouteTablesPermissions(t *testing.T) {
 				tt.vnetMocks(vnetClient, *vnet)
 			}
 
-			err := dv.validateRouteTablePermissions(ctx, tt.subnet)
+			check, err := dv.routeTablePermissionCheck(ctx, tt.subnet)
+			if err == nil && check != nil {
+				err = dv.validatePermissions(ctx, *check)
+			}
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
 		})
 	}
@@ -1126,7 +1157,7 @@ func TestValidateNatGatewaysPermissions(t *testing.T) {
 					}).
 					Return(&invalidNatGWAuthorizationDecisionsReadNotAllowed, nil)
 			},
-			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal does not have Network Contributor role on nat gateway '" + workerNgID + "'.",
+			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: ) does not have the permissions required on the following resources: nat gateway '" + workerNgID + "' is missing Microsoft.Network/natGateways/read.",
 		},
 		{
 			name:   "fail: CheckAccessV2 doesn't return all permissions",
@@ -1146,7 +1177,7 @@ func TestValidateNatGatewaysPermissions(t *testing.T) {
 					}).
 					Return(&invalidNatGWAuthorizationDecisionsMissingWrite, nil)
 			},
-			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal does not have Network Contributor role on nat gateway '" + workerNgID + "'.",
+			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: ) does not have the permissions required on the following resources: nat gateway '" + workerNgID + "' is missing Microsoft.Network/natGateways/write.",
 		},
 		{
 			name:   "pass",
@@ -1218,6 +1249,7 @@ func TestValidateNatGatewaysPermissions(t *testing.T) {
 				log:                        logrus.NewEntry(logrus.StandardLogger()),
 				checkAccessSubjectInfoCred: tokenCred,
 				pdpClient:                  pdpClient,
+				useCheckAccess:             true,
 				virtualNetworks:            vnetClient,
 			}
 
@@ -1229,12 +1261,104 @@ func TestValidateNatGatewaysPermissions(t *testing.T) {
 				tt.vnetMocks(vnetClient, *vnet)
 			}
 
-			err := dv.validateNatGatewayPermissions(ctx, tt.subnet)
+			check, err := dv.natGatewayPermissionCheck(ctx, tt.subnet)
+			if err == nil && check != nil {
+				err = dv.validatePermissions(ctx, *check)
+			}
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
 		})
 	}
 }
 
+func TestValidateVnetReportsAllMissingPermissions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	tokenCred := mock_azcore.NewMockTokenCredential(controller)
+	pdpClient := mock_remotepdp.NewMockRemotePDPClient(controller)
+	vnetClient := mock_network.NewMockVirtualNetworksClient(controller)
+
+	mockTokenCredential(tokenCred)
+	vnetClient.EXPECT().
+		Get(gomock.Any(), resourceGroupName, vnetName, "").
+		AnyTimes().
+		Return(mgmtnetwork.VirtualNetwork{
+			ID: &vnetID,
+			VirtualNetworkPropertiesFormat: &mgmtnetwork.VirtualNetworkPropertiesFormat{
+				Subnets: &[]mgmtnetwork.Subnet{
+					{
+						ID: &masterSubnet,
+						SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+							RouteTable: &mgmtnetwork.RouteTable{
+								ID: &masterRtID,
+							},
+							NatGateway: &mgmtnetwork.SubResource{
+								ID: &masterNgID,
+							},
+						},
+					},
+					{
+						ID: &workerSubnet,
+						SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+							// the route table is shared, so is only checked once
+							RouteTable: &mgmtnetwork.RouteTable{
+								ID: &masterRtID,
+							},
+						},
+					},
+				},
+			},
+		}, nil)
+	pdpClient.EXPECT().
+		CheckAccess(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, authReq remotepdp.AuthorizationRequest) (*remotepdp.AuthorizationDecisionResponse, error) {
+			cancel() // wait.PollImmediateUntil will always be invoked at least once
+			switch authReq.Resource.Id {
+			case vnetID:
+				return &invalidSubnetsAuthorizationDecisionsReadNotAllowed, nil
+			case masterRtID:
+				return &invalidRouteTablesAuthorizationDecisionsWriteNotAllowed, nil
+			case masterNgID:
+				return &invalidNatGWAuthorizationDecisionsReadNotAllowed, nil
+			}
+			return nil, errors.New("unexpected resource " + authReq.Resource.Id)
+		}).
+		Times(3)
+
+	dv := &dynamic{
+		azEnv:                      &azureclient.PublicCloud,
+		appID:                      "fff51942-b1f9-4119-9453-aaa922259eb7",
+		authorizerType:             AuthorizerClusterServicePrincipal,
+		log:                        logrus.NewEntry(logrus.StandardLogger()),
+		checkAccessSubjectInfoCred: tokenCred,
+		pdpClient:                  pdpClient,
+		useCheckAccess:             true,
+		virtualNetworks:            vnetClient,
+	}
+
+	err := dv.ValidateVnet(ctx, "eastus", []Subnet{{ID: masterSubnet}, {ID: workerSubnet}})
+	utilerror.AssertErrorMessage(t, err, "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: fff51942-b1f9-4119-9453-aaa922259eb7) does not have the permissions required on the following resources: "+
+		"vnet '"+vnetID+"' is missing Microsoft.Network/virtualNetworks/read; "+
+		"route table '"+masterRtID+"' is missing Microsoft.Network/routeTables/write; "+
+		"nat gateway '"+masterNgID+"' is missing Microsoft.Network/natGateways/read. Details: "+
+		"InvalidServicePrincipalPermissions: : The vnet is missing Microsoft.Network/virtualNetworks/read., "+
+		"InvalidServicePrincipalPermissions: : The route table is missing Microsoft.Network/routeTables/write., "+
+		"InvalidServicePrincipalPermissions: : The nat gateway is missing Microsoft.Network/natGateways/read.")
+
+	cloudErr := err.(*api.CloudError)
+	if cloudErr.RemediationURL != api.RemediationURLPermissions {
+		t.Error(cloudErr.RemediationURL)
+	}
+	for i, id := range []string{vnetID, masterRtID, masterNgID} {
+		if !strings.EqualFold(cloudErr.Details[i].TargetResourceID, id) {
+			t.Error(i, cloudErr.Details[i].TargetResourceID)
+		}
+	}
+}
+
 func TestCheckPreconfiguredNSG(t *testing.T) {
 	subnetWithNSG := &mgmtnetwork.Subnet{
 		SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
@@ -1435,6 +1559,7 @@ func TestValidatePreconfiguredNSGPermissions(t *testing.T) {
 				authorizerType:             AuthorizerClusterServicePrincipal,
 				virtualNetworks:            vnetClient,
 				pdpClient:                  pdpClient,
+				useCheckAccess:             true,
 				checkAccessSubjectInfoCred: tokenCred,
 				log:                        logrus.NewEntry(logrus.StandardLogger()),
 			}
@@ -11,7 +11,13 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/stringutils"
 )
 
-const snatPortsPerIP = 63992
+const (
+	snatPortsPerIP = 63992
+
+	// allocatedOutboundPorts is the number of SNAT ports per node which the
+	// outbound rule of a large cluster allocates
+	allocatedOutboundPorts = 1024
+)
 
 func (dv *dynamic) ValidateLoadBalancerProfile(ctx context.Context, oc *api.OpenShiftCluster) error {
 	dv.log.Print("ValidateLoadBalancerProfile")
@@ -27,7 +33,12 @@ func (dv *dynamic) ValidateLoadBalancerProfile(ctx context.Context, oc *api.Open
 		}
 	}
 
-	err := dv.validateOBRuleV4FrontendPorts(ctx, oc)
+	err := dv.validateMaxNodesOutboundIPs(ctx, oc)
+	if err != nil {
+		return err
+	}
+
+	err = dv.validateOBRuleV4FrontendPorts(ctx, oc)
 	if err != nil {
 		return err
 	}
@@ -35,6 +46,32 @@ func (dv *dynamic) ValidateLoadBalancerProfile(ctx context.Context, oc *api.Open
 	return nil
 }
 
+// validateMaxNodesOutboundIPs checks that a large cluster has enough managed
+// outbound IPs to give each of its nodes, masters included, its SNAT ports
+// when it is scaled to its maximum worker count
+func (dv *dynamic) validateMaxNodesOutboundIPs(ctx context.Context, oc *api.OpenShiftCluster) error {
+	dv.log.Print("validateMaxNodesOutboundIPs")
+
+	if oc.Properties.MaxNodesProfile == nil ||
+		oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPs == nil {
+		return nil
+	}
+
+	nodes := oc.Properties.MaxNodesProfile.MaxNodes + 3
+	requiredIPs := (nodes*allocatedOutboundPorts + snatPortsPerIP - 1) / snatPortsPerIP
+
+	if oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPs.Count < requiredIPs {
+		return api.NewCloudError(
+			http.StatusBadRequest,
+			api.CloudErrorCodeInvalidParameter,
+			"properties.networkProfile.loadBalancerProfile.managedOutboundIps.count",
+			"The cluster requires at least %d managed outbound IPs to support %d nodes.", requiredIPs, nodes,
+		)
+	}
+
+	return nil
+}
+
 // public IP quota is also checked on the frontend, but only during cluster creation
 func (dv *dynamic) validatePublicIPQuota(ctx context.Context, oc *api.OpenShiftCluster) error {
 	dv.log.Print("validatePublicIPQuota")
@@ -58,7 +95,7 @@ func (dv *dynamic) validatePublicIPQuota(ctx context.Context, oc *api.OpenShiftC
 	for _, netUsage := range netUsages {
 		if *netUsage.Name.Value == "PublicIPAddresses" {
 			if int64(requestedIPs) > (*netUsage.Limit - *netUsage.CurrentValue) {
-				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceQuotaExceeded, "properties.networkProfile.loadBalancerProfile.ManagedOutboundIPs.Count", "Resource quota of %s exceeded. Maximum allowed: %d, Current in use: %d, Additional requested: %d.", *netUsage.Name.Value, *netUsage.Limit, *netUsage.CurrentValue, requestedIPs)
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceQuotaExceeded, "properties.networkProfile.loadBalancerProfile.ManagedOutboundIPs.Count", "Resource quota of %s exceeded. Maximum allowed: %d, Current in use: %d, Additional requested: %d.", *netUsage.Name.Value, *netUsage.Limit, *netUsage.CurrentValue, requestedIPs).WithRemediationURL(api.RemediationURLQuota)
 			}
 		}
 	}
@@ -81,8 +118,6 @@ func (dv *dynamic) validateOBRuleV4FrontendPorts(ctx context.Context, oc *api.Op
 	}
 
 	totalBackendInstances := len(*backendPools.BackendAddressPoolPropertiesFormat.BackendIPConfigurations)
-	// TODO: update once allocatedOutboundPorts is implemented
-	allocatedOutboundPorts := 1024
 	var desiredNumIPs int
 	// TODO: add OutboundIPs and OutboundIPPrefixes
 	if oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPs != nil {
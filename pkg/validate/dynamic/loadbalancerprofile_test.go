@@ -432,6 +432,57 @@ func TestValidateOBRuleV4FrontendPorts(t *testing.T) {
 	}
 }
 
+func TestValidateMaxNodesOutboundIPs(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		maxNodes int
+		count    int
+		wantErr  string
+	}{
+		{
+			name:  "not a large cluster",
+			count: 1,
+		},
+		{
+			name:     "sufficient managed outbound IPs",
+			maxNodes: 120,
+			count:    2,
+		},
+		{
+			name:     "insufficient managed outbound IPs",
+			maxNodes: 250,
+			count:    4,
+			wantErr:  "400: InvalidParameter: properties.networkProfile.loadBalancerProfile.managedOutboundIps.count: The cluster requires at least 5 managed outbound IPs to support 253 nodes.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					NetworkProfile: api.NetworkProfile{
+						LoadBalancerProfile: &api.LoadBalancerProfile{
+							ManagedOutboundIPs: &api.ManagedOutboundIPs{
+								Count: tt.count,
+							},
+						},
+					},
+				},
+			}
+			if tt.maxNodes != 0 {
+				oc.Properties.MaxNodesProfile = &api.MaxNodesProfile{
+					MaxNodes: tt.maxNodes,
+				}
+			}
+
+			dv := &dynamic{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+			}
+
+			err := dv.validateMaxNodesOutboundIPs(context.Background(), oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
 func getFakeBackendIPConfigs(ipConfigCount int) *[]mgmtnetwork.InterfaceIPConfiguration {
 	ipConfigs := []mgmtnetwork.InterfaceIPConfiguration{}
 	for i := 0; i < ipConfigCount; i++ {
@@ -0,0 +1,355 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/authz/remotepdp"
+	"github.com/Azure/ARO-RP/pkg/util/permissions"
+	"github.com/Azure/ARO-RP/pkg/util/token"
+)
+
+var (
+	vnetActions = []string{
+		"Microsoft.Network/virtualNetworks/join/action",
+		"Microsoft.Network/virtualNetworks/read",
+		"Microsoft.Network/virtualNetworks/write",
+		"Microsoft.Network/virtualNetworks/subnets/join/action",
+		"Microsoft.Network/virtualNetworks/subnets/read",
+		"Microsoft.Network/virtualNetworks/subnets/write",
+	}
+	routeTableActions = []string{
+		"Microsoft.Network/routeTables/join/action",
+		"Microsoft.Network/routeTables/read",
+		"Microsoft.Network/routeTables/write",
+	}
+	natGatewayActions = []string{
+		"Microsoft.Network/natGateways/join/action",
+		"Microsoft.Network/natGateways/read",
+		"Microsoft.Network/natGateways/write",
+	}
+	diskEncryptionSetActions = []string{
+		"Microsoft.Compute/diskEncryptionSets/read",
+	}
+	workspaceActions = []string{
+		"Microsoft.OperationalInsights/workspaces/read",
+		"Microsoft.OperationalInsights/workspaces/sharedKeys/action",
+	}
+	publicIPPrefixActions = []string{
+		"Microsoft.Network/publicIPPrefixes/join/action",
+		"Microsoft.Network/publicIPPrefixes/read",
+	}
+)
+
+// permissionCheck is a set of actions which the authorizer must be allowed to
+// perform on a resource
+type permissionCheck struct {
+	resource azure.Resource
+	// kind names the resource in error messages, e.g. "route table"
+	kind    string
+	actions []string
+	// path is the path in the cluster document which references the resource
+	path string
+	// notFound is returned if evaluating the check reports that the resource
+	// does not exist
+	notFound error
+}
+
+// permissionsPoll is the state of a PollImmediateUntil loop over the
+// permission checks
+type permissionsPoll struct {
+	dv     *dynamic
+	ctx    context.Context
+	checks []permissionCheck
+	oid    *string
+
+	// missing holds, for each check which has been evaluated, the actions
+	// which were not allowed the last time it was evaluated
+	missing map[int][]string
+	// failed holds the indexes of the checks on the resource whose
+	// evaluation returned an error
+	failed []int
+}
+
+// validatePermissions checks that every action of every check is allowed,
+// waiting for up to six minutes for role assignments to propagate.  If any
+// are not, it returns a single CloudError listing every missing action on
+// every resource, so that they can all be granted at once.
+func (dv *dynamic) validatePermissions(ctx context.Context, checks ...permissionCheck) error {
+	dv.log.Print("validatePermissions")
+
+	if len(checks) == 0 {
+		return nil
+	}
+
+	p, err := dv.pollPermissions(ctx, checks)
+	if err == nil {
+		return nil
+	}
+
+	var forbidden string
+	if detailedErr, ok := err.(autorest.DetailedError); ok {
+		dv.log.Error(detailedErr)
+
+		switch detailedErr.StatusCode {
+		case http.StatusNotFound:
+			if checks[p.failed[0]].notFound != nil {
+				return checks[p.failed[0]].notFound
+			}
+		case http.StatusForbidden:
+			for _, i := range p.failed {
+				p.missing[i] = checks[i].actions
+			}
+			forbidden = detailedErr.Message
+			err = wait.ErrWaitTimeout
+		}
+	}
+	if err != wait.ErrWaitTimeout {
+		return err
+	}
+
+	return dv.missingPermissionsError(p, forbidden)
+}
+
+// pollPermissions polls until every action of checks is allowed, the timeout
+// expires, or evaluating a check returns an error.  Checks which have passed
+// are not evaluated again.
+func (dv *dynamic) pollPermissions(ctx context.Context, checks []permissionCheck) (*permissionsPoll, error) {
+	// ARM has a 5 minute cache around role assignment creation, so wait one minute longer
+	timeoutCtx, cancel := context.WithTimeout(ctx, 6*time.Minute)
+	defer cancel()
+
+	p := &permissionsPoll{
+		dv:      dv,
+		ctx:     ctx,
+		checks:  checks,
+		missing: map[int][]string{},
+	}
+
+	return p, wait.PollImmediateUntil(30*time.Second, p.poll, timeoutCtx.Done())
+}
+
+// validateActions checks that actions are allowed on r.  It returns
+// wait.ErrWaitTimeout if they are not.
+func (dv *dynamic) validateActions(ctx context.Context, r *azure.Resource, actions []string) error {
+	_, err := dv.pollPermissions(ctx, []permissionCheck{{resource: *r, actions: actions}})
+	return err
+}
+
+// poll evaluates the checks which have not yet passed.  The actions of every
+// check on the same resource are evaluated together, so that each resource
+// costs a single call per poll however many checks reference it.
+func (p *permissionsPoll) poll() (bool, error) {
+	allowedActions := p.usingCheckAccessV2
+	if !p.dv.useCheckAccess {
+		allowedActions = p.usingListPermissions
+	}
+
+	var resources []string
+	checksByResource := map[string][]int{}
+	for i, c := range p.checks {
+		if missing, found := p.missing[i]; found && len(missing) == 0 {
+			continue
+		}
+
+		id := strings.ToLower(c.resource.String())
+		if _, found := checksByResource[id]; !found {
+			resources = append(resources, id)
+		}
+		checksByResource[id] = append(checksByResource[id], i)
+	}
+
+	done := true
+	for _, id := range resources {
+		indexes := checksByResource[id]
+
+		var actions []string
+		seen := map[string]struct{}{}
+		for _, i := range indexes {
+			for _, action := range p.checks[i].actions {
+				if _, found := seen[action]; !found {
+					seen[action] = struct{}{}
+					actions = append(actions, action)
+				}
+			}
+		}
+
+		allowed, err := allowedActions(p.checks[indexes[0]].resource, actions)
+		if err != nil {
+			p.failed = indexes
+			return false, err
+		}
+
+		for _, i := range indexes {
+			missing := []string{}
+			for _, action := range p.checks[i].actions {
+				if !allowed[action] {
+					missing = append(missing, action)
+				}
+			}
+
+			p.missing[i] = missing
+			if len(missing) > 0 {
+				done = false
+			}
+		}
+	}
+
+	return done, nil
+}
+
+// usingCheckAccessV2 uses the new RBAC checkAccessV2 API to return which of
+// actions are allowed on r
+func (p *permissionsPoll) usingCheckAccessV2(r azure.Resource, actions []string) (map[string]bool, error) {
+	p.dv.log.Info("validateActions with CheckAccessV2")
+
+	// reusing oid during retries
+	if p.oid == nil {
+		scope := p.dv.azEnv.ResourceManagerEndpoint + "/.default"
+		t, err := p.dv.checkAccessSubjectInfoCred.GetToken(p.ctx, policy.TokenRequestOptions{Scopes: []string{scope}})
+		if err != nil {
+			p.dv.log.Error("Unable to get the token from AAD: ", err)
+			return nil, err
+		}
+
+		oid, err := token.GetObjectId(t.Token)
+		if err != nil {
+			p.dv.log.Error("Unable to parse the token oid claim: ", err)
+			return nil, err
+		}
+		p.oid = &oid
+	}
+
+	authReq := createAuthorizationRequest(*p.oid, r.String(), actions...)
+	results, err := p.dv.pdpClient.CheckAccess(p.ctx, authReq)
+	if err != nil {
+		p.dv.log.Error("Unexpected error when calling CheckAccessV2: ", err)
+		return nil, err
+	}
+
+	allowed := map[string]bool{}
+	if results == nil {
+		p.dv.log.Info("nil response returned from CheckAccessV2")
+		return allowed, nil
+	}
+
+	for _, action := range actions {
+		found := false
+		for _, result := range results.Value {
+			if result.ActionId == action {
+				found = true
+				allowed[action] = result.AccessDecision == remotepdp.Allowed
+				break
+			}
+		}
+		if !found {
+			p.dv.log.Infof("The result didn't include permission %s", action)
+		}
+	}
+
+	return allowed, nil
+}
+
+// usingListPermissions infers which of actions are allowed on r from the
+// permissions which the authorizer's role assignments grant on it.  It is
+// used when CheckAccess is disabled.
+func (p *permissionsPoll) usingListPermissions(r azure.Resource, actions []string) (map[string]bool, error) {
+	p.dv.log.Info("validateActions with ListPermissions")
+
+	perms, err := p.dv.permissions.ListForResource(p.ctx, r.ResourceGroup, r.Provider, "", r.ResourceType, r.ResourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := map[string]bool{}
+	for _, action := range actions {
+		ok, err := permissions.CanDoAction(perms, action)
+		if err != nil {
+			return nil, err
+		}
+		allowed[action] = ok
+	}
+
+	return allowed, nil
+}
+
+// missingPermissionsError returns a CloudError listing the actions which were
+// not allowed on each resource the last time it was evaluated
+func (dv *dynamic) missingPermissionsError(p *permissionsPoll, forbidden string) error {
+	errCode := api.CloudErrorCodeInvalidResourceProviderPermissions
+	if dv.authorizerType == AuthorizerClusterServicePrincipal {
+		errCode = api.CloudErrorCodeInvalidServicePrincipalPermissions
+	}
+
+	var resources []string
+	var details []api.CloudErrorBody
+	for i, c := range p.checks {
+		missing := p.missing[i]
+		if len(missing) == 0 {
+			continue
+		}
+
+		resources = append(resources, fmt.Sprintf("%s '%s' is missing %s", c.kind, c.resource.String(), strings.Join(missing, ", ")))
+		details = append(details, api.CloudErrorBody{
+			Code:             errCode,
+			Message:          fmt.Sprintf("The %s is missing %s.", c.kind, strings.Join(missing, ", ")),
+			Target:           c.path,
+			TargetResourceID: c.resource.String(),
+		})
+	}
+
+	// the target is only meaningful if a single resource is missing
+	// permissions; otherwise it is given in each detail
+	var target, targetResourceID string
+	if len(details) == 1 {
+		target = details[0].Target
+		targetResourceID = details[0].TargetResourceID
+		details = nil
+	}
+
+	cloudErr := api.NewCloudError(
+		http.StatusBadRequest,
+		errCode,
+		target,
+		errMsgSPHasNoRequiredPermissions,
+		dv.authorizerType,
+		dv.appID,
+		strings.Join(resources, "; "),
+	).WithTargetResourceID(targetResourceID).WithRemediationURL(api.RemediationURLPermissions).WithDetails(details...)
+	if forbidden != "" {
+		cloudErr.Message = fmt.Sprintf("%s\nOriginal error message: %s", cloudErr.Message, forbidden)
+	}
+
+	return cloudErr
+}
+
+func createAuthorizationRequest(subject, resourceId string, actions ...string) remotepdp.AuthorizationRequest {
+	actionInfos := []remotepdp.ActionInfo{}
+	for _, action := range actions {
+		actionInfos = append(actionInfos, remotepdp.ActionInfo{Id: action})
+	}
+
+	return remotepdp.AuthorizationRequest{
+		Subject: remotepdp.SubjectInfo{
+			Attributes: remotepdp.SubjectAttributes{
+				ObjectId: subject,
+			},
+		},
+		Actions: actionInfos,
+		Resource: remotepdp.ResourceInfo{
+			Id: resourceId,
+		},
+	}
+}
@@ -0,0 +1,138 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/authz/remotepdp"
+	mock_remotepdp "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/authz/remotepdp"
+	mock_azcore "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/azcore"
+	mock_authorization "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/authorization"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidatePermissions(t *testing.T) {
+	ctx := context.Background()
+
+	vnetr, err := azure.ParseResourceID(vnetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []permissionCheck{
+		{
+			resource: vnetr,
+			kind:     "vnet",
+			actions:  vnetActions,
+			path:     masterSubnetPath,
+		},
+		{
+			resource: vnetr,
+			kind:     "vnet",
+			actions:  []string{"Microsoft.Network/virtualNetworks/read"},
+			path:     workerSubnetPath,
+		},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		useCheckAccess bool
+		mocks          func(*mock_remotepdp.MockRemotePDPClient, *mock_authorization.MockPermissionsClient, context.CancelFunc)
+		wantErr        string
+	}{
+		{
+			name:           "CheckAccess is called once per resource",
+			useCheckAccess: true,
+			mocks: func(pdpClient *mock_remotepdp.MockRemotePDPClient, _ *mock_authorization.MockPermissionsClient, _ context.CancelFunc) {
+				pdpClient.EXPECT().
+					CheckAccess(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, authReq remotepdp.AuthorizationRequest) {
+						if authReq.Resource.Id != vnetID || len(authReq.Actions) != len(vnetActions) {
+							t.Error(authReq)
+						}
+					}).
+					Return(&validSubnetsAuthorizationDecisions, nil)
+			},
+		},
+		{
+			name:           "CheckAccess fails every check on the resource",
+			useCheckAccess: true,
+			mocks: func(pdpClient *mock_remotepdp.MockRemotePDPClient, _ *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+				pdpClient.EXPECT().
+					CheckAccess(gomock.Any(), gomock.Any()).
+					Do(func(arg0, arg1 interface{}) {
+						cancel()
+					}).
+					Return(&invalidSubnetsAuthorizationDecisionsReadNotAllowed, nil)
+			},
+			wantErr: "400: InvalidServicePrincipalPermissions: : The cluster service principal (Application ID: fff51942-b1f9-4119-9453-aaa922259eb7) does not have the permissions required on the following resources: vnet '" + vnetID + "' is missing Microsoft.Network/virtualNetworks/read; vnet '" + vnetID + "' is missing Microsoft.Network/virtualNetworks/read. Details: InvalidServicePrincipalPermissions: properties.masterProfile.subnetId: The vnet is missing Microsoft.Network/virtualNetworks/read., InvalidServicePrincipalPermissions: properties.workerProfile.subnetId: The vnet is missing Microsoft.Network/virtualNetworks/read.",
+		},
+		{
+			name: "CheckAccess disabled: list permissions pass",
+			mocks: func(_ *mock_remotepdp.MockRemotePDPClient, permissions *mock_authorization.MockPermissionsClient, _ context.CancelFunc) {
+				permissions.EXPECT().
+					ListForResource(gomock.Any(), resourceGroupName, "Microsoft.Network", "", "virtualNetworks", vnetName).
+					Return([]mgmtauthorization.Permission{
+						{
+							Actions: &[]string{"Microsoft.Network/virtualNetworks/*"},
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "CheckAccess disabled: list permissions fail",
+			mocks: func(_ *mock_remotepdp.MockRemotePDPClient, permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+				permissions.EXPECT().
+					ListForResource(gomock.Any(), resourceGroupName, "Microsoft.Network", "", "virtualNetworks", vnetName).
+					Do(func(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) {
+						cancel()
+					}).
+					Return([]mgmtauthorization.Permission{
+						{
+							Actions:    &[]string{"Microsoft.Network/virtualNetworks/*"},
+							NotActions: &[]string{"Microsoft.Network/virtualNetworks/subnets/write"},
+						},
+					}, nil)
+			},
+			wantErr: "400: InvalidServicePrincipalPermissions: properties.masterProfile.subnetId: The cluster service principal (Application ID: fff51942-b1f9-4119-9453-aaa922259eb7) does not have the permissions required on the following resources: vnet '" + vnetID + "' is missing Microsoft.Network/virtualNetworks/subnets/write.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			tokenCred := mock_azcore.NewMockTokenCredential(controller)
+			mockTokenCredential(tokenCred)
+
+			pdpClient := mock_remotepdp.NewMockRemotePDPClient(controller)
+			permissions := mock_authorization.NewMockPermissionsClient(controller)
+			tt.mocks(pdpClient, permissions, cancel)
+
+			dv := &dynamic{
+				azEnv:                      &azureclient.PublicCloud,
+				appID:                      "fff51942-b1f9-4119-9453-aaa922259eb7",
+				authorizerType:             AuthorizerClusterServicePrincipal,
+				log:                        logrus.NewEntry(logrus.StandardLogger()),
+				pdpClient:                  pdpClient,
+				useCheckAccess:             tt.useCheckAccess,
+				permissions:                permissions,
+				checkAccessSubjectInfoCred: tokenCred,
+			}
+
+			err := dv.validatePermissions(ctx, checks...)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
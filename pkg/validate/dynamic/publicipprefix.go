@@ -0,0 +1,95 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// ValidatePublicIPPrefix checks that the authorizer can allocate public IPs
+// from the cluster's public IP prefix, and that the prefix is in the
+// cluster's location and has room for the public IPs which the cluster needs
+func (dv *dynamic) ValidatePublicIPPrefix(ctx context.Context, oc *api.OpenShiftCluster) error {
+	dv.log.Print("ValidatePublicIPPrefix")
+
+	if oc.Properties.NetworkProfile.PublicIPPrefixID == "" {
+		return nil
+	}
+
+	r, err := azure.ParseResourceID(oc.Properties.NetworkProfile.PublicIPPrefixID)
+	if err != nil {
+		return err
+	}
+
+	const path = "properties.networkProfile.publicIpPrefixId"
+
+	err = dv.validatePermissions(ctx, permissionCheck{
+		resource: r,
+		kind:     "public IP prefix",
+		actions:  publicIPPrefixActions,
+		path:     path,
+		notFound: api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedPublicIPPrefix, path, "The public IP prefix '%s' could not be found.", r.String()).WithTargetResourceID(r.String()),
+	})
+	if err != nil {
+		return err
+	}
+
+	prefix, err := dv.publicIPPrefixes.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+	if err != nil {
+		if detailedErr, ok := err.(autorest.DetailedError); ok &&
+			detailedErr.StatusCode == http.StatusNotFound {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedPublicIPPrefix, path, "The public IP prefix '%s' could not be found.", r.String()).WithTargetResourceID(r.String())
+		}
+		return err
+	}
+
+	if prefix.Location == nil || !strings.EqualFold(*prefix.Location, oc.Location) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedPublicIPPrefix, path, "The public IP prefix location '%s' must match the cluster location '%s'.", to.String(prefix.Location), oc.Location).WithTargetResourceID(r.String())
+	}
+
+	if prefix.PublicIPPrefixPropertiesFormat == nil ||
+		prefix.PrefixLength == nil ||
+		prefix.PublicIPAddressVersion == mgmtnetwork.IPv6 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedPublicIPPrefix, path, "The public IP prefix '%s' is invalid: must be IPv4.", r.String()).WithTargetResourceID(r.String())
+	}
+
+	needed := publicIPPrefixAddressesNeeded(oc)
+	if free := publicIPPrefixFreeAddresses(&prefix); free < needed {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedPublicIPPrefix, path, "The public IP prefix '%s' has %d free addresses, but the cluster requires %d.", r.String(), free, needed).WithTargetResourceID(r.String())
+	}
+
+	return nil
+}
+
+// publicIPPrefixAddressesNeeded returns the number of public IPs which are
+// allocated from the prefix at install time: one each for a public API server
+// and a public default ingress
+func publicIPPrefixAddressesNeeded(oc *api.OpenShiftCluster) int {
+	var needed int
+	if oc.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
+		needed++
+	}
+	if len(oc.Properties.IngressProfiles) > 0 &&
+		oc.Properties.IngressProfiles[0].Visibility == api.VisibilityPublic {
+		needed++
+	}
+	return needed
+}
+
+func publicIPPrefixFreeAddresses(prefix *mgmtnetwork.PublicIPPrefix) int {
+	free := 1 << (32 - *prefix.PrefixLength)
+	if prefix.PublicIPAddresses != nil {
+		free -= len(*prefix.PublicIPAddresses)
+	}
+	return free
+}
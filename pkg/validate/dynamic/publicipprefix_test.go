@@ -0,0 +1,185 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/authz/remotepdp"
+	mock_remotepdp "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/authz/remotepdp"
+	mock_azcore "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/azcore"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidatePublicIPPrefix(t *testing.T) {
+	prefixID := "/subscriptions/0000000-0000-0000-0000-000000000000/resourceGroups/fakeRG/providers/Microsoft.Network/publicIPPrefixes/fakePrefix"
+	location := "eastus"
+
+	oc := &api.OpenShiftCluster{
+		Location: location,
+		Properties: api.OpenShiftClusterProperties{
+			NetworkProfile: api.NetworkProfile{
+				PublicIPPrefixID: prefixID,
+			},
+			APIServerProfile: api.APIServerProfile{
+				Visibility: api.VisibilityPublic,
+			},
+			IngressProfiles: []api.IngressProfile{
+				{
+					Visibility: api.VisibilityPublic,
+				},
+			},
+		},
+	}
+
+	prefix := func(location string, length int32, version mgmtnetwork.IPVersion, used int) mgmtnetwork.PublicIPPrefix {
+		addresses := make([]mgmtnetwork.ReferencedPublicIPAddress, used)
+		return mgmtnetwork.PublicIPPrefix{
+			Location: &location,
+			PublicIPPrefixPropertiesFormat: &mgmtnetwork.PublicIPPrefixPropertiesFormat{
+				PrefixLength:           to.Int32Ptr(length),
+				PublicIPAddressVersion: version,
+				PublicIPAddresses:      &addresses,
+			},
+		}
+	}
+
+	allowed := func(pdpClient *mock_remotepdp.MockRemotePDPClient) {
+		pdpClient.EXPECT().
+			CheckAccess(gomock.Any(), gomock.Any()).
+			Return(publicIPPrefixAuthorizationDecision(remotepdp.Allowed), nil)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		oc      *api.OpenShiftCluster
+		mocks   func(*mock_remotepdp.MockRemotePDPClient, *mock_network.MockPublicIPPrefixesClient, context.CancelFunc)
+		wantErr string
+	}{
+		{
+			name: "no public IP prefix",
+			oc:   &api.OpenShiftCluster{},
+		},
+		{
+			name: "pass",
+			oc:   oc,
+			mocks: func(pdpClient *mock_remotepdp.MockRemotePDPClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient, cancel context.CancelFunc) {
+				allowed(pdpClient)
+				publicIPPrefixes.EXPECT().
+					Get(gomock.Any(), "fakeRG", "fakePrefix", "").
+					Return(prefix(location, 30, mgmtnetwork.IPv4, 2), nil)
+			},
+		},
+		{
+			name: "fail: missing permissions",
+			oc:   oc,
+			mocks: func(pdpClient *mock_remotepdp.MockRemotePDPClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient, cancel context.CancelFunc) {
+				pdpClient.EXPECT().
+					CheckAccess(gomock.Any(), gomock.Any()).
+					Do(func(arg0, arg1 interface{}) {
+						cancel()
+					}).
+					Return(publicIPPrefixAuthorizationDecision(remotepdp.NotAllowed), nil)
+			},
+			wantErr: fmt.Sprintf("400: InvalidResourceProviderPermissions: properties.networkProfile.publicIpPrefixId: The resource provider service principal (Application ID: ) does not have the permissions required on the following resources: public IP prefix '%s' is missing Microsoft.Network/publicIPPrefixes/join/action, Microsoft.Network/publicIPPrefixes/read.", prefixID),
+		},
+		{
+			name: "fail: prefix not found",
+			oc:   oc,
+			mocks: func(pdpClient *mock_remotepdp.MockRemotePDPClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient, cancel context.CancelFunc) {
+				allowed(pdpClient)
+				publicIPPrefixes.EXPECT().
+					Get(gomock.Any(), "fakeRG", "fakePrefix", "").
+					Return(mgmtnetwork.PublicIPPrefix{}, autorest.DetailedError{StatusCode: http.StatusNotFound})
+			},
+			wantErr: fmt.Sprintf("400: InvalidLinkedPublicIPPrefix: properties.networkProfile.publicIpPrefixId: The public IP prefix '%s' could not be found.", prefixID),
+		},
+		{
+			name: "fail: wrong location",
+			oc:   oc,
+			mocks: func(pdpClient *mock_remotepdp.MockRemotePDPClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient, cancel context.CancelFunc) {
+				allowed(pdpClient)
+				publicIPPrefixes.EXPECT().
+					Get(gomock.Any(), "fakeRG", "fakePrefix", "").
+					Return(prefix("westus", 28, mgmtnetwork.IPv4, 0), nil)
+			},
+			wantErr: "400: InvalidLinkedPublicIPPrefix: properties.networkProfile.publicIpPrefixId: The public IP prefix location 'westus' must match the cluster location 'eastus'.",
+		},
+		{
+			name: "fail: IPv6",
+			oc:   oc,
+			mocks: func(pdpClient *mock_remotepdp.MockRemotePDPClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient, cancel context.CancelFunc) {
+				allowed(pdpClient)
+				publicIPPrefixes.EXPECT().
+					Get(gomock.Any(), "fakeRG", "fakePrefix", "").
+					Return(prefix(location, 124, mgmtnetwork.IPv6, 0), nil)
+			},
+			wantErr: fmt.Sprintf("400: InvalidLinkedPublicIPPrefix: properties.networkProfile.publicIpPrefixId: The public IP prefix '%s' is invalid: must be IPv4.", prefixID),
+		},
+		{
+			name: "fail: prefix full",
+			oc:   oc,
+			mocks: func(pdpClient *mock_remotepdp.MockRemotePDPClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient, cancel context.CancelFunc) {
+				allowed(pdpClient)
+				publicIPPrefixes.EXPECT().
+					Get(gomock.Any(), "fakeRG", "fakePrefix", "").
+					Return(prefix(location, 30, mgmtnetwork.IPv4, 3), nil)
+			},
+			wantErr: fmt.Sprintf("400: InvalidLinkedPublicIPPrefix: properties.networkProfile.publicIpPrefixId: The public IP prefix '%s' has 1 free addresses, but the cluster requires 2.", prefixID),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tokenCred := mock_azcore.NewMockTokenCredential(controller)
+			mockTokenCredential(tokenCred)
+
+			pdpClient := mock_remotepdp.NewMockRemotePDPClient(controller)
+			publicIPPrefixes := mock_network.NewMockPublicIPPrefixesClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(pdpClient, publicIPPrefixes, cancel)
+			}
+
+			dv := &dynamic{
+				azEnv:                      &azureclient.PublicCloud,
+				authorizerType:             AuthorizerFirstParty,
+				log:                        logrus.NewEntry(logrus.StandardLogger()),
+				pdpClient:                  pdpClient,
+				publicIPPrefixes:           publicIPPrefixes,
+				useCheckAccess:             true,
+				checkAccessSubjectInfoCred: tokenCred,
+			}
+
+			err := dv.ValidatePublicIPPrefix(ctx, tt.oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func publicIPPrefixAuthorizationDecision(decision remotepdp.AccessDecision) *remotepdp.AuthorizationDecisionResponse {
+	response := &remotepdp.AuthorizationDecisionResponse{}
+	for _, action := range publicIPPrefixActions {
+		response.Value = append(response.Value, remotepdp.AuthorizationDecision{
+			ActionId:       action,
+			AccessDecision: decision,
+		})
+	}
+	return response
+}
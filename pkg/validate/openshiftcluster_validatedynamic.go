@@ -122,7 +122,7 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	fpClientCred, err := dv.env.FPNewClientCertificateCredential(tenantID)
+	fpClientCred, err := dv.env.FPNewTokenCredential(tenantID)
 	if err != nil {
 		return err
 	}
@@ -157,6 +157,7 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 		dynamic.AuthorizerClusterServicePrincipal,
 		spClientCred,
 		pdpClient,
+		useCheckAccess,
 	)
 
 	// SP validation
@@ -217,6 +218,7 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 		dynamic.AuthorizerFirstParty,
 		fpClientCred,
 		pdpClient,
+		useCheckAccess,
 	)
 
 	err = fpDynamic.ValidateVnet(
@@ -235,6 +237,16 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 		return err
 	}
 
+	err = fpDynamic.ValidateDiagnosticSettings(ctx, dv.oc)
+	if err != nil {
+		return err
+	}
+
+	err = fpDynamic.ValidatePublicIPPrefix(ctx, dv.oc)
+	if err != nil {
+		return err
+	}
+
 	err = fpDynamic.ValidatePreConfiguredNSGs(ctx, dv.oc, subnets)
 	if err != nil {
 		return err
@@ -30,6 +30,7 @@ import { ClusterDetailPanel } from "./ClusterDetail"
 import { ClusterList } from "./ClusterList"
 import { fetchInfo, fetchRegions, ProcessLogOut } from "./Request"
 import { RegionComponent } from "./RegionList"
+import { ClusterSearch } from "./ClusterSearch"
 import { Routes, Route } from "react-router"
 
 const containerStackTokens: IStackTokens = {}
@@ -176,7 +177,7 @@ export interface IClusterDetail {
 }
 
 function App() {
-  const [data, updateData] = useState({ location: "", csrf: "", elevated: false, username: "" })
+  const [data, updateData] = useState({ location: "", csrf: "", elevated: false, role: "", username: "" })
   const [regions, setRegions] = useState<any>([])
   const [error, setError] = useState<AxiosResponse | null>(null)
   const [isOpen, { setTrue: openPanel, setFalse: dismissPanel }] = useBoolean(false)
@@ -284,7 +285,12 @@ function App() {
               </Text>
             </Stack.Item>
             <Stack.Item>
-              <Text>{data.username}</Text>
+              <ClusterSearch />
+            </Stack.Item>
+            <Stack.Item>
+              <TooltipHost content={`Role: ${data.role}`}>
+                <Text>{data.username}</Text>
+              </TooltipHost>
             </Stack.Item>
 
             <Stack.Item hidden={!data.elevated}>
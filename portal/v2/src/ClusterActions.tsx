@@ -0,0 +1,117 @@
+import { useBoolean } from "@fluentui/react-hooks"
+import {
+  Dialog,
+  DialogFooter,
+  DialogType,
+  Dropdown,
+  IDropdownOption,
+  IconButton,
+  MessageBar,
+  MessageBarType,
+  Stack,
+  TooltipHost,
+} from "@fluentui/react"
+import { PrimaryButton, DefaultButton } from "@fluentui/react/lib/Button"
+import { AxiosResponse } from "axios"
+import { MutableRefObject, useState } from "react"
+import { RequestAction } from "./Request"
+
+const actionOptions: IDropdownOption[] = [
+  { key: "adminupdate", text: "Admin update" },
+  { key: "renewcertificates", text: "Renew certificates" },
+  { key: "requeue", text: "Requeue stuck operation" },
+]
+
+type ClusterActionsProps = {
+  csrfToken: MutableRefObject<string>
+  resourceId: string
+  name: string
+}
+
+export function ClusterActions({ csrfToken, resourceId, name }: ClusterActionsProps) {
+  const [isDialogHidden, { setTrue: hideDialog, setFalse: showDialog }] = useBoolean(true)
+  const [isConfirming, { setTrue: confirm, setFalse: unconfirm }] = useBoolean(false)
+  const [action, setAction] = useState<IDropdownOption>()
+  const [running, setRunning] = useState(false)
+  const [result, setResult] = useState<AxiosResponse | null>(null)
+
+  const onOpen = () => {
+    setAction(undefined)
+    setResult(null)
+    unconfirm()
+    showDialog()
+  }
+
+  const onRun = () => {
+    if (!action) {
+      return
+    }
+    setRunning(true)
+    RequestAction(csrfToken.current, resourceId, String(action.key)).then((result) => {
+      setResult(result)
+      setRunning(false)
+      unconfirm()
+    })
+  }
+
+  const resultBar = (): any => {
+    if (!result) {
+      return null
+    }
+    const succeeded = result.status === 202
+    return (
+      <MessageBar
+        messageBarType={succeeded ? MessageBarType.success : MessageBarType.error}
+        isMultiline={true}
+        onDismiss={() => setResult(null)}
+        dismissButtonAriaLabel="Close">
+        {succeeded ? `${action?.text} started.` : result.data || result.statusText}
+      </MessageBar>
+    )
+  }
+
+  return (
+    <>
+      <TooltipHost content={`Actions`}>
+        <IconButton iconProps={{ iconName: "Settings" }} aria-label="Actions" onClick={onOpen} />
+      </TooltipHost>
+      <Dialog
+        hidden={isDialogHidden}
+        onDismiss={hideDialog}
+        dialogContentProps={{
+          type: DialogType.normal,
+          title: isConfirming ? "Confirm action" : "Cluster actions",
+          subText: isConfirming
+            ? `Run "${action?.text}" on cluster ${name}? This action is audited.`
+            : undefined,
+        }}
+        modalProps={{ isBlocking: true }}>
+        <Stack tokens={{ childrenGap: 15 }}>
+          {resultBar()}
+          {!isConfirming && (
+            <Dropdown
+              id="actionsDropdown"
+              label="Action"
+              selectedKey={action?.key}
+              onChange={(_, option) => setAction(option)}
+              options={actionOptions}
+            />
+          )}
+        </Stack>
+        <DialogFooter>
+          {isConfirming ? (
+            <>
+              <PrimaryButton onClick={onRun} text="Run" disabled={running} />
+              <DefaultButton onClick={unconfirm} text="Back" disabled={running} />
+            </>
+          ) : (
+            <>
+              <PrimaryButton onClick={confirm} text="Continue" disabled={!action} />
+              <DefaultButton onClick={hideDialog} text="Close" />
+            </>
+          )}
+        </DialogFooter>
+      </Dialog>
+    </>
+  )
+}
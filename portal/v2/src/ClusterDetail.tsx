@@ -17,6 +17,7 @@ import { fetchClusterInfo } from "./Request"
 import { IClusterCoordinates, headerStyles } from "./App"
 import { Nav, INavLink, INavStyles } from "@fluentui/react/lib/Nav"
 import { ToolIcons } from "./ToolIcons"
+import { ClusterActions } from "./ClusterActions"
 import { MemoisedClusterDetailListComponent } from "./ClusterDetailList"
 import React from "react"
 import { useLinkClickHandler, useNavigate, useParams } from "react-router-dom"
@@ -75,6 +76,10 @@ export const kcmStatisticsKey = "kcmstatistics"
 export const dnsStatisticsKey = "dnsstatistics"
 export const ingressStatisticsKey = "ingressstatistics"
 export const clusterOperatorsKey = "clusteroperators"
+export const flagsKey = "flags"
+export const podsKey = "pods"
+export const dashboardsKey = "dashboards"
+export const progressKey = "progress"
 
 const errorBarStyles: Partial<IMessageBarStyles> = { root: { marginBottom: 15 } }
 
@@ -182,6 +187,30 @@ export function ClusterDetailPanel(props: {
           url: clusterOperatorsKey,
           icon: 'Shapes',
         },
+        {
+          name: 'Flags',
+          key: flagsKey,
+          url: flagsKey,
+          icon: 'Flag',
+        },
+        {
+          name: 'Pods',
+          key: podsKey,
+          url: podsKey,
+          icon: 'Processing',
+        },
+        {
+          name: 'Dashboards',
+          key: dashboardsKey,
+          url: dashboardsKey,
+          icon: 'ViewDashboard',
+        },
+        {
+          name: 'Progress',
+          key: progressKey,
+          url: progressKey,
+          icon: 'ProgressRingDots',
+        },
       ],
     },
   ]
@@ -297,6 +326,7 @@ export function ClusterDetailPanel(props: {
             <div id="ClusterDetailName" className={headerStyles.titleText}>{currentCluster?.name}</div>
             <div className={headerStyles.subtitleText}>Cluster</div>                        
             <ToolIcons resourceId={currentCluster ? currentCluster?.resourceId : ""} version={Number(data?.version) !== undefined ? Number(data?.version) : 0} csrfToken={props.csrfToken} sshBox={props.sshBox}/>
+            <ClusterActions resourceId={currentCluster ? currentCluster?.resourceId : ""} name={currentCluster ? currentCluster?.name : ""} csrfToken={props.csrfToken}/>
           </Stack.Item>
         </Stack>
       </>
@@ -331,6 +361,7 @@ export function ClusterDetailPanel(props: {
               item={data}
               cluster={currentCluster}
               isDataLoaded={dataLoaded}
+              csrfToken={props.csrfToken}
             />
           </Stack.Item>
         </Stack>
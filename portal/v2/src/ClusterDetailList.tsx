@@ -1,4 +1,4 @@
-import React from "react"
+import React, { MutableRefObject } from "react"
 import { Navigate, Route, Routes } from "react-router-dom"
 
 import { OverviewWrapper } from "./ClusterDetailListComponents/OverviewWrapper"
@@ -7,14 +7,19 @@ import { MachinesWrapper } from "./ClusterDetailListComponents/MachinesWrapper"
 import { MachineSetsWrapper } from "./ClusterDetailListComponents/MachineSetsWrapper"
 import { Statistics } from "./ClusterDetailListComponents/Statistics/Statistics"
 import { ClusterOperatorsWrapper } from "./ClusterDetailListComponents/ClusterOperatorsWrapper";
+import { FlagsWrapper } from "./ClusterDetailListComponents/FlagsWrapper"
+import { PodsWrapper } from "./ClusterDetailListComponents/PodsWrapper"
+import { DashboardsWrapper } from "./ClusterDetailListComponents/DashboardsWrapper"
+import { ProgressWrapper } from "./ClusterDetailListComponents/ProgressWrapper"
 
 import { IClusterCoordinates } from "./App"
-import { apiStatisticsKey, clusterOperatorsKey, dnsStatisticsKey, ingressStatisticsKey, kcmStatisticsKey, machineSetsKey, machinesKey, nodesKey, overviewKey } from "./ClusterDetail"
+import { apiStatisticsKey, clusterOperatorsKey, dashboardsKey, dnsStatisticsKey, flagsKey, ingressStatisticsKey, kcmStatisticsKey, machineSetsKey, machinesKey, nodesKey, overviewKey, podsKey, progressKey } from "./ClusterDetail"
 
 interface ClusterDetailComponentProps {
   item: IClusterDetails
   cluster: IClusterCoordinates | null
   isDataLoaded: boolean
+  csrfToken: MutableRefObject<string>
 }
 
 export interface IClusterDetails {
@@ -57,6 +62,10 @@ export function ClusterDetailComponent(props: ClusterDetailComponentProps) {
       <Route path="dnsstatistics" element={<Statistics currentCluster={props.cluster!} detailPanelSelected={dnsStatisticsKey} loaded={props.isDataLoaded} statisticsType="dns" />} />
       <Route path="ingressstatistics" element={<Statistics currentCluster={props.cluster!} detailPanelSelected={ingressStatisticsKey} loaded={props.isDataLoaded} statisticsType="ingress" />} />
       <Route path="clusteroperators" element={<ClusterOperatorsWrapper currentCluster={props.cluster!} detailPanelSelected={clusterOperatorsKey} loaded={props.isDataLoaded} />} />
+      <Route path="flags" element={<FlagsWrapper currentCluster={props.cluster!} detailPanelSelected={flagsKey} loaded={props.isDataLoaded} />} />
+      <Route path="pods" element={<PodsWrapper currentCluster={props.cluster!} detailPanelSelected={podsKey} loaded={props.isDataLoaded} csrfToken={props.csrfToken} />} />
+      <Route path="dashboards" element={<DashboardsWrapper currentCluster={props.cluster!} detailPanelSelected={dashboardsKey} loaded={props.isDataLoaded} />} />
+      <Route path="progress" element={<ProgressWrapper currentCluster={props.cluster!} detailPanelSelected={progressKey} loaded={props.isDataLoaded} />} />
     </Routes>
   )
 }
@@ -0,0 +1,194 @@
+import { useState, useEffect } from "react"
+import { AxiosResponse } from "axios"
+import { fetchDashboard, fetchDashboards } from "../Request"
+import {
+  IMessageBarStyles,
+  MessageBar,
+  MessageBarType,
+  Stack,
+  CommandBar,
+  ICommandBarItemProps,
+  Dropdown,
+  IDropdownOption,
+  Text,
+} from "@fluentui/react"
+import { dashboardsKey } from "../ClusterDetail"
+import { WrapperProps } from "../ClusterDetailList"
+import { StatisticsComponent } from "./Statistics/StatisticsComponent"
+import { GraphOptionsComponent } from "./Statistics/GraphOptionsComponent"
+import { IMetrics, IMetricValue } from "./Statistics/StatisticsWrapper"
+
+export interface IDashboard {
+  name: string
+  title: string
+}
+
+interface IPanel {
+  title: string
+  unit?: string
+  metrics: IMetrics[]
+  error?: string
+}
+
+const errorBarStyles: Partial<IMessageBarStyles> = { root: { marginBottom: 15 } }
+
+const graphHeight = 300
+const graphWidth = 1000
+
+export function DashboardsWrapper(props: WrapperProps) {
+  const [dashboards, setDashboards] = useState<IDashboard[]>([])
+  const [selected, setSelected] = useState("")
+  const [panels, setPanels] = useState<IPanel[]>([])
+  const [error, setError] = useState<AxiosResponse | null>(null)
+  const [fetching, setFetching] = useState("")
+  const [panelsFetching, setPanelsFetching] = useState("")
+  const [duration, setDuration] = useState("1h")
+  const [endDate, setEndDate] = useState(new Date())
+  const [refresh, setRefresh] = useState(0)
+
+  const errorBar = (): any => {
+    return (
+      <MessageBar
+        messageBarType={MessageBarType.error}
+        isMultiline={false}
+        onDismiss={() => setError(null)}
+        dismissButtonAriaLabel="Close"
+        styles={errorBarStyles}>
+        {error?.statusText}
+      </MessageBar>
+    )
+  }
+
+  const controlStyles = {
+    root: {
+      paddingLeft: 0,
+      float: "right",
+    },
+  }
+
+  const _items: ICommandBarItemProps[] = [
+    {
+      key: "refresh",
+      text: "Refresh",
+      iconProps: { iconName: "Refresh" },
+      onClick: () => {
+        setPanels([])
+        setRefresh((n) => n + 1)
+      },
+    },
+  ]
+
+  // fetch the list of built-in dashboards once the panel is opened
+  useEffect(() => {
+    const onData = (result: AxiosResponse | null) => {
+      if (result?.status === 200) {
+        setDashboards(result.data)
+        if (result.data.length > 0) {
+          setSelected(result.data[0].name)
+        }
+      } else {
+        setError(result)
+      }
+      if (props.currentCluster) {
+        setFetching(props.currentCluster.name)
+      }
+    }
+
+    if (
+      props.detailPanelSelected.toLowerCase() == dashboardsKey &&
+      fetching === "" &&
+      props.loaded &&
+      props.currentCluster
+    ) {
+      setFetching("FETCHING")
+      fetchDashboards(props.currentCluster).then(onData)
+    }
+  }, [dashboards, props.loaded, props.detailPanelSelected])
+
+  // run the panels of the selected dashboard whenever it or the time range
+  // changes
+  useEffect(() => {
+    const onData = (result: AxiosResponse | null) => {
+      if (result?.status === 200) {
+        setPanels(
+          result.data.panels.map(
+            (panel: {
+              title: string
+              unit?: string
+              error?: string
+              metrics: { metricname: string; metricvalue: IMetricValue[] }[]
+            }) => ({
+              title: panel.title,
+              unit: panel.unit,
+              error: panel.error,
+              metrics: panel.metrics.map((m) => ({ Name: m.metricname, MetricValue: m.metricvalue })),
+            })
+          )
+        )
+        setPanelsFetching("success")
+      } else {
+        setError(result)
+        setPanelsFetching("error")
+      }
+    }
+
+    if (selected !== "" && props.currentCluster) {
+      setPanelsFetching("FETCHING")
+      fetchDashboard(props.currentCluster, selected, duration, endDate).then(onData)
+    }
+  }, [selected, duration, endDate, refresh])
+
+  const dashboardOptions: IDropdownOption[] = dashboards.map((d) => ({
+    key: d.name,
+    text: d.title,
+  }))
+
+  return (
+    <Stack>
+      <Stack.Item grow>{error && errorBar()}</Stack.Item>
+      <Stack tokens={{ childrenGap: 10 }}>
+        <CommandBar items={_items} ariaLabel="Refresh" styles={controlStyles} />
+        <Stack horizontal tokens={{ childrenGap: 20 }} verticalAlign="end">
+          <Dropdown
+            label="Dashboard"
+            selectedKey={selected}
+            options={dashboardOptions}
+            styles={{ root: { minWidth: 250 } }}
+            onChange={(_, option) => {
+              setPanels([])
+              setSelected(String(option?.key || ""))
+            }}
+          />
+          <GraphOptionsComponent
+            duration={duration}
+            setDuration={setDuration}
+            endDate={endDate}
+            setEndDate={setEndDate}
+          />
+        </Stack>
+        {panels.map((panel) => (
+          <Stack key={panel.title} tokens={{ childrenGap: 5 }}>
+            <Text variant="large">
+              {panel.unit ? `${panel.title} (${panel.unit})` : panel.title}
+            </Text>
+            {panel.error ? (
+              <MessageBar messageBarType={MessageBarType.warning} styles={errorBarStyles}>
+                {panel.error}
+              </MessageBar>
+            ) : (
+              <StatisticsComponent
+                metrics={panel.metrics}
+                fetchStatus={panelsFetching}
+                duration={duration}
+                clusterName={props.currentCluster != null ? props.currentCluster.name : ""}
+                height={graphHeight}
+                width={graphWidth}
+                endDate={endDate}
+              />
+            )}
+          </Stack>
+        ))}
+      </Stack>
+    </Stack>
+  )
+}
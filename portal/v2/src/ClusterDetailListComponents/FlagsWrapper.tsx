@@ -0,0 +1,230 @@
+import { useState, useEffect } from "react"
+import { AxiosResponse } from "axios"
+import { fetchFlags } from "../Request"
+import {
+  IMessageBarStyles,
+  MessageBar,
+  MessageBarType,
+  Stack,
+  CommandBar,
+  ICommandBarItemProps,
+  SelectionMode,
+  Text,
+  TooltipHost,
+} from "@fluentui/react"
+import { Link } from "@fluentui/react/lib/Link"
+import { DetailsList, IColumn } from "@fluentui/react/lib/DetailsList"
+import { flagsKey } from "../ClusterDetail"
+import { WrapperProps } from "../ClusterDetailList"
+
+export interface IAdminRequest {
+  method: string
+  url: string
+  body: string
+}
+
+export interface ISubscriptionFeature {
+  name: string
+  state: string
+  known: boolean
+}
+
+export interface IOperatorFlag {
+  name: string
+  default: string
+  document: string
+  cluster: string
+  nonDefault: boolean
+  drift: boolean
+  change: IAdminRequest
+}
+
+export interface IFlags {
+  subscriptionFeatures: ISubscriptionFeature[]
+  operatorFlags: IOperatorFlag[]
+  clusterError?: string
+}
+
+const errorBarStyles: Partial<IMessageBarStyles> = { root: { marginBottom: 15 } }
+
+const driftStyle = { backgroundColor: "#fed9cc" }
+const nonDefaultStyle = { fontWeight: 600 }
+
+// adminRequestText renders an admin API request so that it can be pasted into
+// a tool which calls the RP admin API
+const adminRequestText = (change: IAdminRequest): string => {
+  return `${change.method} ${change.url}\n${change.body}`
+}
+
+const subscriptionFeatureColumns: IColumn[] = [
+  {
+    key: "name",
+    name: "Feature",
+    fieldName: "name",
+    minWidth: 300,
+    maxWidth: 450,
+    isResizable: true,
+  },
+  {
+    key: "state",
+    name: "State",
+    fieldName: "state",
+    minWidth: 100,
+    maxWidth: 150,
+    isResizable: true,
+  },
+  {
+    key: "known",
+    name: "Read by RP",
+    minWidth: 100,
+    maxWidth: 100,
+    onRender: (item: ISubscriptionFeature) => (item.known ? "Yes" : "No"),
+  },
+]
+
+const operatorFlagColumns: IColumn[] = [
+  {
+    key: "name",
+    name: "Flag",
+    fieldName: "name",
+    minWidth: 300,
+    maxWidth: 400,
+    isResizable: true,
+  },
+  {
+    key: "default",
+    name: "Default",
+    fieldName: "default",
+    minWidth: 80,
+    maxWidth: 100,
+  },
+  {
+    key: "document",
+    name: "Cluster document",
+    minWidth: 120,
+    maxWidth: 150,
+    onRender: (item: IOperatorFlag) => (
+      <TooltipHost content={`Copy admin request: ${item.change.method} ${item.change.url}`}>
+        <Link
+          style={item.nonDefault ? nonDefaultStyle : undefined}
+          onClick={() => navigator.clipboard.writeText(adminRequestText(item.change))}>
+          {item.document || "(unset)"}
+        </Link>
+      </TooltipHost>
+    ),
+  },
+  {
+    key: "cluster",
+    name: "Cluster CR",
+    minWidth: 120,
+    maxWidth: 150,
+    onRender: (item: IOperatorFlag) => (
+      <span style={item.drift ? driftStyle : undefined}>{item.cluster || "(unset)"}</span>
+    ),
+  },
+]
+
+export function FlagsWrapper(props: WrapperProps) {
+  const [flags, setFlags] = useState<IFlags | null>(null)
+  const [error, setError] = useState<AxiosResponse | null>(null)
+  const [fetching, setFetching] = useState("")
+
+  const errorBar = (): any => {
+    return (
+      <MessageBar
+        messageBarType={MessageBarType.error}
+        isMultiline={false}
+        onDismiss={() => setError(null)}
+        dismissButtonAriaLabel="Close"
+        styles={errorBarStyles}>
+        {error?.statusText}
+      </MessageBar>
+    )
+  }
+
+  const clusterErrorBar = (): any => {
+    return (
+      <MessageBar messageBarType={MessageBarType.warning} isMultiline={true} styles={errorBarStyles}>
+        Could not read the Cluster CR, drift is not shown: {flags?.clusterError}
+      </MessageBar>
+    )
+  }
+
+  const controlStyles = {
+    root: {
+      paddingLeft: 0,
+      float: "right",
+    },
+  }
+
+  const _items: ICommandBarItemProps[] = [
+    {
+      key: "refresh",
+      text: "Refresh",
+      iconProps: { iconName: "Refresh" },
+      onClick: () => {
+        setFlags(null)
+        setFetching("")
+      },
+    },
+  ]
+
+  useEffect(() => {
+    const onData = (result: AxiosResponse | null) => {
+      if (result?.status === 200) {
+        setFlags(result.data)
+      } else {
+        setError(result)
+      }
+      if (props.currentCluster) {
+        setFetching(props.currentCluster.name)
+      }
+    }
+
+    if (
+      props.detailPanelSelected.toLowerCase() == flagsKey &&
+      fetching === "" &&
+      props.loaded &&
+      props.currentCluster
+    ) {
+      setFetching("FETCHING")
+      fetchFlags(props.currentCluster).then(onData)
+    }
+  }, [flags, props.loaded, props.detailPanelSelected])
+
+  const drifted = flags?.operatorFlags.filter((flag) => flag.drift).length || 0
+
+  return (
+    <Stack>
+      <Stack.Item grow>{error && errorBar()}</Stack.Item>
+      <Stack>
+        <CommandBar items={_items} ariaLabel="Refresh" styles={controlStyles} />
+        {flags?.clusterError && clusterErrorBar()}
+        {drifted > 0 && (
+          <MessageBar messageBarType={MessageBarType.severeWarning} styles={errorBarStyles}>
+            {drifted} operator flag(s) differ between the cluster document and the Cluster CR.
+            Run an OperatorUpdate admin update to push the cluster document flags to the cluster.
+          </MessageBar>
+        )}
+        <Text variant="large">Subscription features</Text>
+        <DetailsList
+          compact={true}
+          items={flags?.subscriptionFeatures || []}
+          columns={subscriptionFeatureColumns}
+          selectionMode={SelectionMode.none}
+        />
+        <Text variant="large">Operator flags</Text>
+        <Text variant="small">
+          Bold values differ from the default, highlighted values differ from the cluster document.
+          Click a cluster document value to copy the admin request which sets it.
+        </Text>
+        <DetailsList
+          compact={true}
+          items={flags?.operatorFlags || []}
+          columns={operatorFlagColumns}
+          selectionMode={SelectionMode.none}
+        />
+      </Stack>
+    </Stack>
+  )
+}
@@ -0,0 +1,313 @@
+import { useState, useEffect, useRef, MutableRefObject } from "react"
+import { AxiosResponse } from "axios"
+import { fetchPods, streamPodExec, streamPodLogs } from "../Request"
+import {
+  IMessageBarStyles,
+  MessageBar,
+  MessageBarType,
+  Stack,
+  CommandBar,
+  ICommandBarItemProps,
+  Dropdown,
+  IDropdownOption,
+  PrimaryButton,
+  DefaultButton,
+  Selection,
+  SelectionMode,
+  Text,
+  TextField,
+} from "@fluentui/react"
+import { DetailsList, IColumn } from "@fluentui/react/lib/DetailsList"
+import { podsKey } from "../ClusterDetail"
+import { WrapperProps } from "../ClusterDetailList"
+
+export interface IPodContainer {
+  name: string
+  ready: boolean
+  restartCount: number
+}
+
+export interface IPod {
+  name: string
+  namespace: string
+  nodeName: string
+  phase: string
+  createdTime: string
+  containers: IPodContainer[]
+}
+
+interface PodsWrapperProps extends WrapperProps {
+  csrfToken: MutableRefObject<string>
+}
+
+const errorBarStyles: Partial<IMessageBarStyles> = { root: { marginBottom: 15 } }
+
+const outputStyles = {
+  backgroundColor: "#1e1e1e",
+  color: "#d4d4d4",
+  fontFamily: "monospace",
+  fontSize: 12,
+  padding: 10,
+  maxHeight: 500,
+  overflow: "auto",
+  whiteSpace: "pre-wrap" as const,
+}
+
+const podColumns: IColumn[] = [
+  {
+    key: "namespace",
+    name: "Namespace",
+    fieldName: "namespace",
+    minWidth: 200,
+    maxWidth: 300,
+    isResizable: true,
+  },
+  {
+    key: "name",
+    name: "Pod",
+    fieldName: "name",
+    minWidth: 250,
+    maxWidth: 400,
+    isResizable: true,
+  },
+  {
+    key: "phase",
+    name: "Phase",
+    fieldName: "phase",
+    minWidth: 80,
+    maxWidth: 100,
+  },
+  {
+    key: "ready",
+    name: "Ready",
+    minWidth: 60,
+    maxWidth: 60,
+    onRender: (item: IPod) =>
+      `${item.containers.filter((c) => c.ready).length}/${item.containers.length}`,
+  },
+  {
+    key: "restarts",
+    name: "Restarts",
+    minWidth: 60,
+    maxWidth: 60,
+    onRender: (item: IPod) => item.containers.reduce((n, c) => n + c.restartCount, 0),
+  },
+  {
+    key: "nodeName",
+    name: "Node",
+    fieldName: "nodeName",
+    minWidth: 200,
+    isResizable: true,
+  },
+]
+
+export function PodsWrapper(props: PodsWrapperProps) {
+  const [pods, setPods] = useState<IPod[]>([])
+  const [error, setError] = useState<AxiosResponse | null>(null)
+  const [fetching, setFetching] = useState("")
+  const [filter, setFilter] = useState("")
+  const [selected, setSelected] = useState<IPod | null>(null)
+  const [container, setContainer] = useState("")
+  const [command, setCommand] = useState("")
+  const [output, setOutput] = useState("")
+  const [streamError, setStreamError] = useState<string | null>(null)
+  const [streaming, setStreaming] = useState(false)
+  const abort = useRef<AbortController | null>(null)
+
+  const selection = useRef(
+    new Selection({
+      onSelectionChanged: () => {
+        const pod = (selection.current.getSelection()[0] as IPod) || null
+        setSelected(pod)
+        setContainer(pod?.containers[0]?.name || "")
+      },
+    })
+  )
+
+  const errorBar = (): any => {
+    return (
+      <MessageBar
+        messageBarType={MessageBarType.error}
+        isMultiline={false}
+        onDismiss={() => setError(null)}
+        dismissButtonAriaLabel="Close"
+        styles={errorBarStyles}>
+        {error?.statusText}
+      </MessageBar>
+    )
+  }
+
+  const controlStyles = {
+    root: {
+      paddingLeft: 0,
+      float: "right",
+    },
+  }
+
+  const _items: ICommandBarItemProps[] = [
+    {
+      key: "refresh",
+      text: "Refresh",
+      iconProps: { iconName: "Refresh" },
+      onClick: () => {
+        setPods([])
+        setFetching("")
+      },
+    },
+  ]
+
+  useEffect(() => {
+    const onData = (result: AxiosResponse | null) => {
+      if (result?.status === 200) {
+        setPods(result.data.pods)
+      } else {
+        setError(result)
+      }
+      if (props.currentCluster) {
+        setFetching(props.currentCluster.name)
+      }
+    }
+
+    if (
+      props.detailPanelSelected.toLowerCase() == podsKey &&
+      fetching === "" &&
+      props.loaded &&
+      props.currentCluster
+    ) {
+      setFetching("FETCHING")
+      fetchPods(props.currentCluster).then(onData)
+    }
+  }, [pods, props.loaded, props.detailPanelSelected])
+
+  // stop any stream when the panel is closed
+  useEffect(() => {
+    return () => abort.current?.abort()
+  }, [])
+
+  const stream = (start: (signal: AbortSignal, onChunk: (chunk: string) => void) => Promise<string | null>) => {
+    abort.current?.abort()
+    const controller = new AbortController()
+    abort.current = controller
+
+    setOutput("")
+    setStreamError(null)
+    setStreaming(true)
+    start(controller.signal, (chunk) => setOutput((o) => o + chunk)).then((err) => {
+      if (abort.current === controller) {
+        setStreamError(err)
+        setStreaming(false)
+      }
+    })
+  }
+
+  const showLogs = (follow: boolean) => {
+    if (!selected || !props.currentCluster) {
+      return
+    }
+    const cluster = props.currentCluster
+    stream((signal, onChunk) =>
+      streamPodLogs(cluster, selected.namespace, selected.name, container, follow, signal, onChunk)
+    )
+  }
+
+  const runCommand = () => {
+    if (!selected || !props.currentCluster || command.trim() === "") {
+      return
+    }
+    const cluster = props.currentCluster
+    stream((signal, onChunk) =>
+      streamPodExec(
+        props.csrfToken.current,
+        cluster,
+        selected.namespace,
+        selected.name,
+        container,
+        command.trim().split(/\s+/),
+        signal,
+        onChunk
+      )
+    )
+  }
+
+  const stop = () => {
+    abort.current?.abort()
+    abort.current = null
+    setStreaming(false)
+  }
+
+  const containerOptions: IDropdownOption[] =
+    selected?.containers.map((c) => ({ key: c.name, text: c.name })) || []
+
+  const filtered = pods.filter(
+    (pod) => filter === "" || pod.namespace.includes(filter) || pod.name.includes(filter)
+  )
+
+  return (
+    <Stack>
+      <Stack.Item grow>{error && errorBar()}</Stack.Item>
+      <Stack>
+        <CommandBar items={_items} ariaLabel="Refresh" styles={controlStyles} />
+        <Text variant="small">
+          Pods in openshift-* namespaces.  Viewing logs and running commands requires elevated
+          access, and every session is recorded.
+        </Text>
+        <TextField
+          placeholder="Filter by namespace or pod name"
+          value={filter}
+          onChange={(_, value) => setFilter(value || "")}
+        />
+        <div style={{ maxHeight: 400, overflow: "auto" }}>
+          <DetailsList
+            compact={true}
+            items={filtered}
+            columns={podColumns}
+            selection={selection.current}
+            selectionMode={SelectionMode.single}
+            getKey={(item: IPod) => `${item.namespace}/${item.name}`}
+            setKey="pods"
+          />
+        </div>
+        {selected && (
+          <Stack tokens={{ childrenGap: 10 }} styles={{ root: { marginTop: 15 } }}>
+            <Text variant="large">
+              {selected.namespace}/{selected.name}
+            </Text>
+            <Dropdown
+              label="Container"
+              selectedKey={container}
+              options={containerOptions}
+              onChange={(_, option) => setContainer(String(option?.key || ""))}
+            />
+            <Stack horizontal tokens={{ childrenGap: 10 }}>
+              <DefaultButton text="Logs" onClick={() => showLogs(false)} />
+              <DefaultButton text="Follow logs" onClick={() => showLogs(true)} />
+              <DefaultButton text="Stop" disabled={!streaming} onClick={stop} />
+            </Stack>
+            <Stack horizontal tokens={{ childrenGap: 10 }} verticalAlign="end">
+              <Stack.Item grow>
+                <TextField
+                  label="Command"
+                  placeholder="ls -l /"
+                  description="Read-only commands only: df, ls, ps and uptime, with a limited set of flags."
+                  value={command}
+                  onChange={(_, value) => setCommand(value || "")}
+                  onKeyDown={(e) => e.key === "Enter" && runCommand()}
+                />
+              </Stack.Item>
+              <PrimaryButton text="Run" onClick={runCommand} />
+            </Stack>
+            {streamError && (
+              <MessageBar
+                messageBarType={MessageBarType.error}
+                onDismiss={() => setStreamError(null)}
+                styles={errorBarStyles}>
+                {streamError}
+              </MessageBar>
+            )}
+            <pre style={outputStyles}>{output}</pre>
+          </Stack>
+        )}
+      </Stack>
+    </Stack>
+  )
+}
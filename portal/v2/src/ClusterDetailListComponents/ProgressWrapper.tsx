@@ -0,0 +1,109 @@
+import { useState, useEffect } from "react"
+import {
+  IMessageBarStyles,
+  MessageBar,
+  MessageBarType,
+  ProgressIndicator,
+  Stack,
+  Text,
+} from "@fluentui/react"
+import { progressKey } from "../ClusterDetail"
+import { WrapperProps } from "../ClusterDetailList"
+import { progressURL } from "../Request"
+
+export interface IOperationProgress {
+  operation: string
+  step: string
+  stepIndex?: number
+  stepCount?: number
+  startTime: string
+}
+
+export interface IProgressEvent {
+  provisioningState: string
+  failedProvisioningState?: string
+  lastProvisioningState?: string
+  lastAdminUpdateError?: string
+  maintenanceTask?: string
+  progress?: IOperationProgress
+}
+
+const errorBarStyles: Partial<IMessageBarStyles> = { root: { marginBottom: 15 } }
+
+export function ProgressWrapper(props: WrapperProps) {
+  const [event, setEvent] = useState<IProgressEvent | null>(null)
+  const [done, setDone] = useState(false)
+  const [error, setError] = useState<string | null>(null)
+
+  // follow the progress stream while the panel is open; the browser
+  // reconnects the stream itself if the connection drops before the cluster
+  // reaches a terminal state
+  useEffect(() => {
+    if (
+      props.detailPanelSelected.toLowerCase() != progressKey ||
+      !props.loaded ||
+      !props.currentCluster
+    ) {
+      return
+    }
+
+    setDone(false)
+    setError(null)
+
+    const source = new EventSource(progressURL(props.currentCluster))
+    source.onmessage = (e) => setEvent(JSON.parse(e.data))
+    source.addEventListener("done", () => {
+      setDone(true)
+      source.close()
+    })
+    source.addEventListener("error", () => {
+      if (source.readyState === EventSource.CLOSED) {
+        setError("The progress stream was closed.")
+      }
+    })
+
+    return () => source.close()
+  }, [props.loaded, props.detailPanelSelected, props.currentCluster])
+
+  const progress = event?.progress
+  const stepCount = progress?.stepCount || 0
+  const stepIndex = progress?.stepIndex || 0
+
+  return (
+    <Stack tokens={{ childrenGap: 10 }}>
+      {error && (
+        <MessageBar
+          messageBarType={MessageBarType.error}
+          onDismiss={() => setError(null)}
+          styles={errorBarStyles}>
+          {error}
+        </MessageBar>
+      )}
+      {event?.lastAdminUpdateError && (
+        <MessageBar messageBarType={MessageBarType.warning} styles={errorBarStyles}>
+          Last admin update error: {event.lastAdminUpdateError}
+        </MessageBar>
+      )}
+      <Text variant="large">
+        Provisioning state: {event?.provisioningState || "unknown"}
+        {event?.failedProvisioningState && ` (failed while ${event.failedProvisioningState})`}
+        {event?.maintenanceTask && `, maintenance task ${event.maintenanceTask}`}
+      </Text>
+      {progress && (
+        <Stack tokens={{ childrenGap: 5 }}>
+          <ProgressIndicator
+            label={`${done ? "Last" : "Current"} ${progress.operation} step ${
+              stepIndex + 1
+            } of ${stepCount}`}
+            description={`${progress.step}, started ${new Date(
+              progress.startTime
+            ).toLocaleString()}`}
+            percentComplete={done || stepCount === 0 ? 1 : stepIndex / stepCount}
+          />
+        </Stack>
+      )}
+      {!progress && event && <Text>No steps have been recorded for this cluster.</Text>}
+      {done && <Text variant="small">The cluster is in a terminal state; the stream has ended.</Text>}
+    </Stack>
+  )
+}
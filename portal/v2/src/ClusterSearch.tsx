@@ -0,0 +1,176 @@
+import {
+  DetailsList,
+  DetailsListLayoutMode,
+  IColumn,
+  Link,
+  MessageBar,
+  MessageBarType,
+  Panel,
+  PanelType,
+  SearchBox,
+  SelectionMode,
+  Spinner,
+  Stack,
+  Text,
+} from "@fluentui/react"
+import { useBoolean } from "@fluentui/react-hooks"
+import { useState } from "react"
+import { useNavigate } from "react-router-dom"
+import { fetchPeerSearch, fetchSearch } from "./Request"
+import { IRegion } from "./RegionList"
+
+interface ISearchResult {
+  resourceId: string
+  name: string
+  subscription: string
+  resourceGroup: string
+  domain: string
+  provisioningState: string
+  version: string
+  match: string
+  url: string
+  region: string
+  regionUrl: string
+}
+
+interface IPeerStatus {
+  name: string
+  url: string
+  state: "searching" | "done" | "unavailable"
+}
+
+const columns: IColumn[] = [
+  { key: "name", name: "Name", fieldName: "name", minWidth: 100, maxWidth: 200, isResizable: true },
+  { key: "region", name: "Region", fieldName: "region", minWidth: 80, maxWidth: 120, isResizable: true },
+  { key: "match", name: "Matched", fieldName: "match", minWidth: 80, maxWidth: 100 },
+  { key: "domain", name: "Domain", fieldName: "domain", minWidth: 100, maxWidth: 200, isResizable: true },
+  { key: "subscription", name: "Subscription", fieldName: "subscription", minWidth: 100, maxWidth: 280, isResizable: true },
+  { key: "provisioningState", name: "State", fieldName: "provisioningState", minWidth: 80, maxWidth: 120 },
+  { key: "version", name: "Version", fieldName: "version", minWidth: 60, maxWidth: 80 },
+]
+
+export function ClusterSearch() {
+  const [isOpen, { setTrue: openPanel, setFalse: dismissPanel }] = useBoolean(false)
+  const [results, setResults] = useState<ISearchResult[]>([])
+  const [peers, setPeers] = useState<IPeerStatus[]>([])
+  const [searching, setSearching] = useState(false)
+  const [error, setError] = useState<string>("")
+  const navigate = useNavigate()
+
+  const onSearch = (query: string) => {
+    setResults([])
+    setPeers([])
+    setError("")
+    setSearching(true)
+    openPanel()
+
+    fetchSearch(query).then((result) => {
+      setSearching(false)
+      if (result?.status !== 200) {
+        setError(result?.data || result?.statusText || "Search failed")
+        return
+      }
+
+      const local = result.data.clusters.map((c: ISearchResult) => ({
+        ...c,
+        region: result.data.location,
+        regionUrl: "",
+      }))
+      setResults(local)
+
+      const peerRegions: IRegion[] = result.data.peers || []
+      setPeers(peerRegions.map((peer) => ({ name: peer.name, url: peer.url, state: "searching" })))
+
+      peerRegions.forEach((peer) => {
+        fetchPeerSearch(peer.url).then((peerResult) => {
+          const available = peerResult?.status === 200
+          setPeers((current) =>
+            current.map((p) => (p.name === peer.name ? { ...p, state: available ? "done" : "unavailable" } : p))
+          )
+          if (!available) {
+            return
+          }
+          const regionUrl = new URL(peer.url).origin
+          setResults((current) =>
+            current.concat(
+              peerResult.data.clusters.map((c: ISearchResult) => ({
+                ...c,
+                region: peer.name,
+                regionUrl: regionUrl,
+              }))
+            )
+          )
+        })
+      })
+    })
+  }
+
+  const onRenderItemColumn = (item: ISearchResult, index?: number, column?: IColumn): any => {
+    if (column?.key === "name") {
+      if (item.regionUrl) {
+        return (
+          <Link href={item.regionUrl + item.url} target="_blank">
+            {item.name}
+          </Link>
+        )
+      }
+      return (
+        <Link
+          onClick={() => {
+            dismissPanel()
+            navigate(item.url)
+          }}>
+          {item.name}
+        </Link>
+      )
+    }
+    return item[column?.fieldName as keyof ISearchResult]
+  }
+
+  const unavailable = peers.filter((p) => p.state === "unavailable")
+  const pending = peers.filter((p) => p.state === "searching").length
+
+  return (
+    <>
+      <SearchBox
+        placeholder="Search all regions by resource ID, domain or subscription"
+        onSearch={onSearch}
+        styles={{ root: { width: 420 } }}
+      />
+      <Panel
+        isLightDismiss
+        isOpen={isOpen}
+        onDismiss={dismissPanel}
+        type={PanelType.large}
+        headerText="Cluster search"
+        closeButtonAriaLabel="Close">
+        <Stack tokens={{ childrenGap: 10 }}>
+          {error && <MessageBar messageBarType={MessageBarType.error}>{error}</MessageBar>}
+          {(searching || pending > 0) && (
+            <Spinner label={pending > 0 ? `Searching ${pending} other regions...` : "Searching..."} />
+          )}
+          <DetailsList
+            items={results}
+            columns={columns}
+            selectionMode={SelectionMode.none}
+            layoutMode={DetailsListLayoutMode.justified}
+            onRenderItemColumn={onRenderItemColumn}
+          />
+          {!searching && results.length === 0 && !error && <Text>No clusters found.</Text>}
+          {unavailable.length > 0 && (
+            <Text>
+              Sign in to these regions to include them in the search:{" "}
+              {unavailable.map((p) => (
+                <span key={p.name}>
+                  <Link href={new URL(p.url).origin} target="_blank">
+                    {p.name}
+                  </Link>{" "}
+                </span>
+              ))}
+            </Text>
+          )}
+        </Stack>
+      </Panel>
+    </>
+  )
+}
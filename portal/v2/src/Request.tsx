@@ -106,6 +106,107 @@ export const fetchClusterOperators = async (cluster: IClusterCoordinates): Promi
   }
 }
 
+export const fetchFlags = async (cluster: IClusterCoordinates): Promise<AxiosResponse | null> => {
+  try {
+    const result = await axios(
+      ["/api", cluster.subscription, cluster.resourceGroup, cluster.name, "flags"].join("/"))
+    return result
+  } catch (e: any) {
+    const err = e.response as AxiosResponse
+    return OnError(err)
+  }
+}
+
+export const fetchPods = async (cluster: IClusterCoordinates): Promise<AxiosResponse | null> => {
+  try {
+    const result = await axios(
+      ["/api", cluster.subscription, cluster.resourceGroup, cluster.name, "pods"].join("/"))
+    return result
+  } catch (e: any) {
+    const err = e.response as AxiosResponse
+    return OnError(err)
+  }
+}
+
+// streamResponse passes each chunk of a streamed text/plain response to
+// onChunk.  axios buffers whole responses in the browser, so fetch is used.
+// It resolves to an error message, or null once the stream ends.
+const streamResponse = async (
+  input: string,
+  init: RequestInit,
+  onChunk: (chunk: string) => void
+): Promise<string | null> => {
+  let response: Response
+  try {
+    response = await fetch(input, init)
+  } catch (e: any) {
+    return String(e)
+  }
+
+  if (!response.ok || !response.body) {
+    return (await response.text()) || response.statusText
+  }
+
+  const reader = response.body.getReader()
+  const decoder = new TextDecoder()
+  try {
+    for (;;) {
+      const { done, value } = await reader.read()
+      if (done) {
+        return null
+      }
+      onChunk(decoder.decode(value, { stream: true }))
+    }
+  } catch (e: any) {
+    if (init.signal?.aborted) {
+      return null
+    }
+    return String(e)
+  }
+}
+
+const podPath = (cluster: IClusterCoordinates, namespace: string, pod: string): string => {
+  return ["/api", cluster.subscription, cluster.resourceGroup, cluster.name, "namespaces", namespace, "pods", pod].join("/")
+}
+
+export const streamPodLogs = async (
+  cluster: IClusterCoordinates,
+  namespace: string,
+  pod: string,
+  container: string,
+  follow: boolean,
+  signal: AbortSignal,
+  onChunk: (chunk: string) => void
+): Promise<string | null> => {
+  const params = new URLSearchParams({ container: container, follow: String(follow) })
+  return streamResponse(podPath(cluster, namespace, pod) + "/logs?" + params.toString(), { signal: signal }, onChunk)
+}
+
+export const streamPodExec = async (
+  csrfToken: string,
+  cluster: IClusterCoordinates,
+  namespace: string,
+  pod: string,
+  container: string,
+  command: string[],
+  signal: AbortSignal,
+  onChunk: (chunk: string) => void
+): Promise<string | null> => {
+  return streamResponse(
+    podPath(cluster, namespace, pod) + "/exec",
+    {
+      method: "POST",
+      headers: {
+        "Content-Type": "application/json",
+        "X-CSRF-Token": csrfToken,
+      },
+      body: JSON.stringify({ container: container, command: command }),
+      signal: signal,
+    },
+    onChunk
+  )
+}
+
 export const fetchRegions = async (): Promise<AxiosResponse | null> => {
   try {
     const result = await axios("/api/regions")
@@ -116,6 +217,27 @@ export const fetchRegions = async (): Promise<AxiosResponse | null> => {
   }
 }
 
+export const fetchSearch = async (query: string): Promise<AxiosResponse | null> => {
+  try {
+    const result = await axios("/api/search", { params: { q: query, peers: true } })
+    return result
+  } catch (e: any) {
+    let err = e.response as AxiosResponse
+    return OnError(err)
+  }
+}
+
+// fetchPeerSearch queries another region's portal.  It fails if the user has
+// not signed in to that portal, in which case the caller links to it instead.
+export const fetchPeerSearch = async (url: string): Promise<AxiosResponse | null> => {
+  try {
+    const result = await axios(url, { withCredentials: true, maxRedirects: 0 })
+    return result
+  } catch (e: any) {
+    return null
+  }
+}
+
 export const ProcessLogOut = async (): Promise<any> => {
   try {
     const result = await axios({ method: "POST", url: "/api/logout" })
@@ -164,3 +286,58 @@ export const fetchStatistics = async (
     return OnError(err)
   }
 }
+
+export const progressURL = (cluster: IClusterCoordinates): string => {
+  return `/api/${cluster.subscription}/${cluster.resourceGroup}/${cluster.name}/progress`
+}
+
+export const fetchDashboards = async (cluster: IClusterCoordinates): Promise<AxiosResponse | null> => {
+  try {
+    const result = await axios(
+      `/api/${cluster.subscription}/${cluster.resourceGroup}/${cluster.name}/dashboards`
+    )
+    return result
+  } catch (e: any) {
+    const err = e.response as AxiosResponse
+    return OnError(err)
+  }
+}
+
+export const fetchDashboard = async (
+  cluster: IClusterCoordinates,
+  dashboardName: string,
+  duration: string,
+  endDate: Date
+): Promise<AxiosResponse | null> => {
+  duration = convertTimeToHours(duration)
+  let endDateJSON = endDate.toJSON()
+  try {
+    const result = await axios(
+      `/api/${cluster.subscription}/${cluster.resourceGroup}/${cluster.name}/dashboards/${dashboardName}?duration=${duration}&endtime=${endDateJSON}`
+    )
+    return result
+  } catch (e: any) {
+    const err = e.response as AxiosResponse
+    return OnError(err)
+  }
+}
+
+export const RequestAction = async (
+  csrfToken: string,
+  resourceID: string,
+  action: string
+): Promise<AxiosResponse | null> => {
+  try {
+    const result = await axios({
+      method: "POST",
+      url: resourceID + "/actions/" + action,
+      headers: {
+        "X-CSRF-Token": csrfToken,
+      },
+    })
+    return result
+  } catch (e: any) {
+    const err = e.response as AxiosResponse
+    return OnError(err)
+  }
+}
@@ -72,6 +72,10 @@ class CloudErrorBody(msrest.serialization.Model):
     :ivar target: The target of the particular error. For example, the name of the property in
      error.
     :vartype target: str
+    :ivar target_resource_id: The resource ID of the Azure resource in error, if any.
+    :vartype target_resource_id: str
+    :ivar remediation_url: A link to documentation describing how to resolve the error, if any.
+    :vartype remediation_url: str
     :ivar details: A list of additional details about the error.
     :vartype details: list[~azure.mgmt.redhatopenshift.v2020_04_30.models.CloudErrorBody]
     """
@@ -80,6 +84,8 @@ class CloudErrorBody(msrest.serialization.Model):
         'code': {'key': 'code', 'type': 'str'},
         'message': {'key': 'message', 'type': 'str'},
         'target': {'key': 'target', 'type': 'str'},
+        'target_resource_id': {'key': 'targetResourceId', 'type': 'str'},
+        'remediation_url': {'key': 'remediationUrl', 'type': 'str'},
         'details': {'key': 'details', 'type': '[CloudErrorBody]'},
     }
 
@@ -89,6 +95,8 @@ class CloudErrorBody(msrest.serialization.Model):
         code: Optional[str] = None,
         message: Optional[str] = None,
         target: Optional[str] = None,
+        target_resource_id: Optional[str] = None,
+        remediation_url: Optional[str] = None,
         details: Optional[List["CloudErrorBody"]] = None,
         **kwargs
     ):
@@ -102,6 +110,11 @@ class CloudErrorBody(msrest.serialization.Model):
         :keyword target: The target of the particular error. For example, the name of the property in
          error.
         :paramtype target: str
+        :keyword target_resource_id: The resource ID of the Azure resource in error, if any.
+        :paramtype target_resource_id: str
+        :keyword remediation_url: A link to documentation describing how to resolve the error, if
+         any.
+        :paramtype remediation_url: str
         :keyword details: A list of additional details about the error.
         :paramtype details: list[~azure.mgmt.redhatopenshift.v2020_04_30.models.CloudErrorBody]
         """
@@ -109,6 +122,8 @@ class CloudErrorBody(msrest.serialization.Model):
         self.code = code
         self.message = message
         self.target = target
+        self.target_resource_id = target_resource_id
+        self.remediation_url = remediation_url
         self.details = details
 
 
@@ -64,6 +64,10 @@ class CloudErrorBody(msrest.serialization.Model):
     :ivar target: The target of the particular error. For example, the name of the property in
      error.
     :vartype target: str
+    :ivar target_resource_id: The resource ID of the Azure resource in error, if any.
+    :vartype target_resource_id: str
+    :ivar remediation_url: A link to documentation describing how to resolve the error, if any.
+    :vartype remediation_url: str
     :ivar details: A list of additional details about the error.
     :vartype details: list[~azure.mgmt.redhatopenshift.v2022_04_01.models.CloudErrorBody]
     """
@@ -72,6 +76,8 @@ class CloudErrorBody(msrest.serialization.Model):
         'code': {'key': 'code', 'type': 'str'},
         'message': {'key': 'message', 'type': 'str'},
         'target': {'key': 'target', 'type': 'str'},
+        'target_resource_id': {'key': 'targetResourceId', 'type': 'str'},
+        'remediation_url': {'key': 'remediationUrl', 'type': 'str'},
         'details': {'key': 'details', 'type': '[CloudErrorBody]'},
     }
 
@@ -89,6 +95,11 @@ class CloudErrorBody(msrest.serialization.Model):
         :keyword target: The target of the particular error. For example, the name of the property in
          error.
         :paramtype target: str
+        :keyword target_resource_id: The resource ID of the Azure resource in error, if any.
+        :paramtype target_resource_id: str
+        :keyword remediation_url: A link to documentation describing how to resolve the error, if
+         any.
+        :paramtype remediation_url: str
         :keyword details: A list of additional details about the error.
         :paramtype details: list[~azure.mgmt.redhatopenshift.v2022_04_01.models.CloudErrorBody]
         """
@@ -96,6 +107,8 @@ class CloudErrorBody(msrest.serialization.Model):
         self.code = kwargs.get('code', None)
         self.message = kwargs.get('message', None)
         self.target = kwargs.get('target', None)
+        self.target_resource_id = kwargs.get('target_resource_id', None)
+        self.remediation_url = kwargs.get('remediation_url', None)
         self.details = kwargs.get('details', None)
 
 
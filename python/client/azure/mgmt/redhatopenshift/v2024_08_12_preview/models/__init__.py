@@ -20,6 +20,7 @@ try:
     from ._models_py3 import ClusterProfile
     from ._models_py3 import ClusterUserAssignedIdentity
     from ._models_py3 import ConsoleProfile
+    from ._models_py3 import DiagnosticSettingsProfile
     from ._models_py3 import Display
     from ._models_py3 import EffectiveOutboundIP
     from ._models_py3 import Identity
@@ -30,6 +31,7 @@ try:
     from ._models_py3 import MachinePoolUpdate
     from ._models_py3 import ManagedOutboundIPs
     from ._models_py3 import MasterProfile
+    from ._models_py3 import MaxNodesProfile
     from ._models_py3 import NetworkProfile
     from ._models_py3 import OpenShiftCluster
     from ._models_py3 import OpenShiftClusterAdminKubeconfig
@@ -48,6 +50,7 @@ try:
     from ._models_py3 import SecretList
     from ._models_py3 import SecretUpdate
     from ._models_py3 import ServicePrincipalProfile
+    from ._models_py3 import SpotVMOptions
     from ._models_py3 import SyncIdentityProvider
     from ._models_py3 import SyncIdentityProviderList
     from ._models_py3 import SyncIdentityProviderUpdate
@@ -63,6 +66,7 @@ except (SyntaxError, ImportError):
     from ._models import ClusterProfile  # type: ignore
     from ._models import ClusterUserAssignedIdentity  # type: ignore
     from ._models import ConsoleProfile  # type: ignore
+    from ._models import DiagnosticSettingsProfile  # type: ignore
     from ._models import Display  # type: ignore
     from ._models import EffectiveOutboundIP  # type: ignore
     from ._models import Identity  # type: ignore
@@ -73,6 +77,7 @@ except (SyntaxError, ImportError):
     from ._models import MachinePoolUpdate  # type: ignore
     from ._models import ManagedOutboundIPs  # type: ignore
     from ._models import MasterProfile  # type: ignore
+    from ._models import MaxNodesProfile  # type: ignore
     from ._models import NetworkProfile  # type: ignore
     from ._models import OpenShiftCluster  # type: ignore
     from ._models import OpenShiftClusterAdminKubeconfig  # type: ignore
@@ -91,6 +96,7 @@ except (SyntaxError, ImportError):
     from ._models import SecretList  # type: ignore
     from ._models import SecretUpdate  # type: ignore
     from ._models import ServicePrincipalProfile  # type: ignore
+    from ._models import SpotVMOptions  # type: ignore
     from ._models import SyncIdentityProvider  # type: ignore
     from ._models import SyncIdentityProviderList  # type: ignore
     from ._models import SyncIdentityProviderUpdate  # type: ignore
@@ -102,13 +108,17 @@ except (SyntaxError, ImportError):
     from ._models import WorkerProfile  # type: ignore
 
 from ._azure_red_hat_open_shift_client_enums import (
+    AcceleratedNetworking,
     CreatedByType,
+    DiskType,
     EncryptionAtHost,
     FipsValidatedModules,
     OutboundType,
     PreconfiguredNSG,
     ProvisioningState,
+    SpotEvictionPolicy,
     Visibility,
+    ZonePlacement,
 )
 
 __all__ = [
@@ -117,6 +127,7 @@ __all__ = [
     'ClusterProfile',
     'ClusterUserAssignedIdentity',
     'ConsoleProfile',
+    'DiagnosticSettingsProfile',
     'Display',
     'EffectiveOutboundIP',
     'Identity',
@@ -127,6 +138,7 @@ __all__ = [
     'MachinePoolUpdate',
     'ManagedOutboundIPs',
     'MasterProfile',
+    'MaxNodesProfile',
     'NetworkProfile',
     'OpenShiftCluster',
     'OpenShiftClusterAdminKubeconfig',
@@ -145,6 +157,7 @@ __all__ = [
     'SecretList',
     'SecretUpdate',
     'ServicePrincipalProfile',
+    'SpotVMOptions',
     'SyncIdentityProvider',
     'SyncIdentityProviderList',
     'SyncIdentityProviderUpdate',
@@ -154,11 +167,15 @@ __all__ = [
     'SystemData',
     'TrackedResource',
     'WorkerProfile',
+    'AcceleratedNetworking',
     'CreatedByType',
+    'DiskType',
     'EncryptionAtHost',
     'FipsValidatedModules',
     'OutboundType',
     'PreconfiguredNSG',
     'ProvisioningState',
+    'SpotEvictionPolicy',
     'Visibility',
+    'ZonePlacement',
 ]
@@ -19,6 +19,13 @@ from six import with_metaclass
 from azure.core import CaseInsensitiveEnumMeta
 
 
+class AcceleratedNetworking(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """AcceleratedNetworking represents accelerated networking state
+    """
+
+    DISABLED = "Disabled"
+    ENABLED = "Enabled"
+
 class CreatedByType(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     """The type of identity that created the resource.
     """
@@ -28,6 +35,13 @@ class CreatedByType(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     MANAGED_IDENTITY = "ManagedIdentity"
     KEY = "Key"
 
+class DiskType(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """DiskType represents the managed disk type of VMs.
+    """
+
+    PREMIUM_LRS = "Premium_LRS"
+    ULTRA_SSD_LRS = "UltraSSD_LRS"
+
 class EncryptionAtHost(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     """EncryptionAtHost represents encryption at host state
     """
@@ -68,9 +82,22 @@ class ProvisioningState(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     SUCCEEDED = "Succeeded"
     UPDATING = "Updating"
 
+class SpotEvictionPolicy(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """SpotEvictionPolicy represents a Spot VM eviction policy.
+    """
+
+    DELETE = "Delete"
+
 class Visibility(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     """Visibility represents visibility.
     """
 
     PRIVATE = "Private"
     PUBLIC = "Public"
+
+class ZonePlacement(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """ZonePlacement represents how the cluster VMs are placed in availability zones.
+    """
+
+    AUTOMATIC = "Automatic"
+    NON_ZONAL = "NonZonal"
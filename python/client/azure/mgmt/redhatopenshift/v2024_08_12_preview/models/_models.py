@@ -28,17 +28,21 @@ class APIServerProfile(msrest.serialization.Model):
     :vartype url: str
     :ivar ip: The IP of the cluster API server.
     :vartype ip: str
+    :ivar ipv6: The IPv6 IP of the cluster API server of a dual-stack cluster.
+    :vartype ipv6: str
     """
 
     _validation = {
         'url': {'readonly': True},
         'ip': {'readonly': True},
+        'ipv6': {'readonly': True},
     }
 
     _attribute_map = {
         'visibility': {'key': 'visibility', 'type': 'str'},
         'url': {'key': 'url', 'type': 'str'},
         'ip': {'key': 'ip', 'type': 'str'},
+        'ipv6': {'key': 'ipv6', 'type': 'str'},
     }
 
     def __init__(
@@ -53,6 +57,7 @@ class APIServerProfile(msrest.serialization.Model):
         self.visibility = kwargs.get('visibility', None)
         self.url = None
         self.ip = None
+        self.ipv6 = None
 
 
 class CloudErrorBody(msrest.serialization.Model):
@@ -67,6 +72,10 @@ class CloudErrorBody(msrest.serialization.Model):
     :ivar target: The target of the particular error. For example, the name of the property in
      error.
     :vartype target: str
+    :ivar target_resource_id: The resource ID of the Azure resource in error, if any.
+    :vartype target_resource_id: str
+    :ivar remediation_url: A link to documentation describing how to resolve the error, if any.
+    :vartype remediation_url: str
     :ivar details: A list of additional details about the error.
     :vartype details: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.CloudErrorBody]
     """
@@ -75,6 +84,8 @@ class CloudErrorBody(msrest.serialization.Model):
         'code': {'key': 'code', 'type': 'str'},
         'message': {'key': 'message', 'type': 'str'},
         'target': {'key': 'target', 'type': 'str'},
+        'target_resource_id': {'key': 'targetResourceId', 'type': 'str'},
+        'remediation_url': {'key': 'remediationUrl', 'type': 'str'},
         'details': {'key': 'details', 'type': '[CloudErrorBody]'},
     }
 
@@ -92,6 +103,11 @@ class CloudErrorBody(msrest.serialization.Model):
         :keyword target: The target of the particular error. For example, the name of the property in
          error.
         :paramtype target: str
+        :keyword target_resource_id: The resource ID of the Azure resource in error, if any.
+        :paramtype target_resource_id: str
+        :keyword remediation_url: A link to documentation describing how to resolve the error, if
+         any.
+        :paramtype remediation_url: str
         :keyword details: A list of additional details about the error.
         :paramtype details: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.CloudErrorBody]
         """
@@ -99,6 +115,8 @@ class CloudErrorBody(msrest.serialization.Model):
         self.code = kwargs.get('code', None)
         self.message = kwargs.get('message', None)
         self.target = kwargs.get('target', None)
+        self.target_resource_id = kwargs.get('target_resource_id', None)
+        self.remediation_url = kwargs.get('remediation_url', None)
         self.details = kwargs.get('details', None)
 
 
@@ -215,6 +233,31 @@ class ConsoleProfile(msrest.serialization.Model):
         self.url = None
 
 
+class DiagnosticSettingsProfile(msrest.serialization.Model):
+    """DiagnosticSettingsProfile represents the Azure Monitor diagnostic settings of the cluster's load balancers, network security groups and key vaults.
+
+    :ivar workspace_resource_id: The resource ID of the Log Analytics workspace to which logs and
+     metrics are sent.
+    :vartype workspace_resource_id: str
+    """
+
+    _attribute_map = {
+        'workspace_resource_id': {'key': 'workspaceResourceId', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword workspace_resource_id: The resource ID of the Log Analytics workspace to which logs
+         and metrics are sent.
+        :paramtype workspace_resource_id: str
+        """
+        super(DiagnosticSettingsProfile, self).__init__(**kwargs)
+        self.workspace_resource_id = kwargs.get('workspace_resource_id', None)
+
+
 class Display(msrest.serialization.Model):
     """Display represents the display details of an operation.
 
@@ -323,16 +366,20 @@ class IngressProfile(msrest.serialization.Model):
     :vartype visibility: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Visibility
     :ivar ip: The IP of the ingress.
     :vartype ip: str
+    :ivar ipv6: The IPv6 IP of the ingress of a dual-stack cluster.
+    :vartype ipv6: str
     """
 
     _validation = {
         'ip': {'readonly': True},
+        'ipv6': {'readonly': True},
     }
 
     _attribute_map = {
         'name': {'key': 'name', 'type': 'str'},
         'visibility': {'key': 'visibility', 'type': 'str'},
         'ip': {'key': 'ip', 'type': 'str'},
+        'ipv6': {'key': 'ipv6', 'type': 'str'},
     }
 
     def __init__(
@@ -349,6 +396,7 @@ class IngressProfile(msrest.serialization.Model):
         self.name = kwargs.get('name', None)
         self.visibility = kwargs.get('visibility', None)
         self.ip = None
+        self.ipv6 = None
 
 
 class LoadBalancerProfile(msrest.serialization.Model):
@@ -626,6 +674,17 @@ class MasterProfile(msrest.serialization.Model):
     :ivar disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
      applicable.
     :vartype disk_encryption_set_id: str
+    :ivar disk_type: The managed disk type of the master VMs.  OS disks are always Premium_LRS;
+     UltraSSD_LRS also allows Ultra disks to be attached. Possible values include: "Premium_LRS",
+     "UltraSSD_LRS".
+    :vartype disk_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiskType
+    :ivar accelerated_networking: Whether the master VMs use accelerated networking. Possible
+     values include: "Disabled", "Enabled".
+    :vartype accelerated_networking: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.AcceleratedNetworking
+    :ivar zones: The availability zones of the master VMs.  If empty, the zones are selected by the
+     service.
+    :vartype zones: list[str]
     """
 
     _attribute_map = {
@@ -633,6 +692,9 @@ class MasterProfile(msrest.serialization.Model):
         'subnet_id': {'key': 'subnetId', 'type': 'str'},
         'encryption_at_host': {'key': 'encryptionAtHost', 'type': 'str'},
         'disk_encryption_set_id': {'key': 'diskEncryptionSetId', 'type': 'str'},
+        'disk_type': {'key': 'diskType', 'type': 'str'},
+        'accelerated_networking': {'key': 'acceleratedNetworking', 'type': 'str'},
+        'zones': {'key': 'zones', 'type': '[str]'},
     }
 
     def __init__(
@@ -651,12 +713,49 @@ class MasterProfile(msrest.serialization.Model):
         :keyword disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
          applicable.
         :paramtype disk_encryption_set_id: str
+        :keyword disk_type: The managed disk type of the master VMs.  OS disks are always Premium_LRS;
+         UltraSSD_LRS also allows Ultra disks to be attached. Possible values include: "Premium_LRS",
+         "UltraSSD_LRS".
+        :paramtype disk_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiskType
+        :keyword accelerated_networking: Whether the master VMs use accelerated networking. Possible
+         values include: "Disabled", "Enabled".
+        :paramtype accelerated_networking: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.AcceleratedNetworking
+        :keyword zones: The availability zones of the master VMs.  If empty, the zones are selected by
+         the service.
+        :paramtype zones: list[str]
         """
         super(MasterProfile, self).__init__(**kwargs)
         self.vm_size = kwargs.get('vm_size', None)
         self.subnet_id = kwargs.get('subnet_id', None)
         self.encryption_at_host = kwargs.get('encryption_at_host', None)
         self.disk_encryption_set_id = kwargs.get('disk_encryption_set_id', None)
+        self.disk_type = kwargs.get('disk_type', None)
+        self.accelerated_networking = kwargs.get('accelerated_networking', None)
+        self.zones = kwargs.get('zones', None)
+
+
+class MaxNodesProfile(msrest.serialization.Model):
+    """MaxNodesProfile represents the largest number of worker nodes which a large cluster is sized for.
+
+    :ivar max_nodes: The maximum number of worker nodes.
+    :vartype max_nodes: int
+    """
+
+    _attribute_map = {
+        'max_nodes': {'key': 'maxNodes', 'type': 'int'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword max_nodes: The maximum number of worker nodes.
+        :paramtype max_nodes: int
+        """
+        super(MaxNodesProfile, self).__init__(**kwargs)
+        self.max_nodes = kwargs.get('max_nodes', None)
 
 
 class NetworkProfile(msrest.serialization.Model):
@@ -666,6 +765,11 @@ class NetworkProfile(msrest.serialization.Model):
     :vartype pod_cidr: str
     :ivar service_cidr: The CIDR used for OpenShift/Kubernetes Services.
     :vartype service_cidr: str
+    :ivar pod_cidr_ipv6: The IPv6 CIDR used for OpenShift/Kubernetes Pods of a dual-stack cluster.
+    :vartype pod_cidr_ipv6: str
+    :ivar service_cidr_ipv6: The IPv6 CIDR used for OpenShift/Kubernetes Services of a dual-stack
+     cluster.
+    :vartype service_cidr_ipv6: str
     :ivar outbound_type: The OutboundType used for egress traffic. Possible values include:
      "Loadbalancer", "UserDefinedRouting".
     :vartype outbound_type: str or
@@ -677,14 +781,20 @@ class NetworkProfile(msrest.serialization.Model):
      values include: "Disabled", "Enabled".
     :vartype preconfigured_nsg: str or
      ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.PreconfiguredNSG
+    :ivar public_ip_prefix_id: The resource ID of a public IP prefix which the public IPs of the
+     API server and the default ingress are allocated from.
+    :vartype public_ip_prefix_id: str
     """
 
     _attribute_map = {
         'pod_cidr': {'key': 'podCidr', 'type': 'str'},
         'service_cidr': {'key': 'serviceCidr', 'type': 'str'},
+        'pod_cidr_ipv6': {'key': 'podCidrIpv6', 'type': 'str'},
+        'service_cidr_ipv6': {'key': 'serviceCidrIpv6', 'type': 'str'},
         'outbound_type': {'key': 'outboundType', 'type': 'str'},
         'load_balancer_profile': {'key': 'loadBalancerProfile', 'type': 'LoadBalancerProfile'},
         'preconfigured_nsg': {'key': 'preconfiguredNSG', 'type': 'str'},
+        'public_ip_prefix_id': {'key': 'publicIpPrefixId', 'type': 'str'},
     }
 
     def __init__(
@@ -696,6 +806,12 @@ class NetworkProfile(msrest.serialization.Model):
         :paramtype pod_cidr: str
         :keyword service_cidr: The CIDR used for OpenShift/Kubernetes Services.
         :paramtype service_cidr: str
+        :keyword pod_cidr_ipv6: The IPv6 CIDR used for OpenShift/Kubernetes Pods of a dual-stack
+         cluster.
+        :paramtype pod_cidr_ipv6: str
+        :keyword service_cidr_ipv6: The IPv6 CIDR used for OpenShift/Kubernetes Services of a
+         dual-stack cluster.
+        :paramtype service_cidr_ipv6: str
         :keyword outbound_type: The OutboundType used for egress traffic. Possible values include:
          "Loadbalancer", "UserDefinedRouting".
         :paramtype outbound_type: str or
@@ -707,13 +823,19 @@ class NetworkProfile(msrest.serialization.Model):
          values include: "Disabled", "Enabled".
         :paramtype preconfigured_nsg: str or
          ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.PreconfiguredNSG
+        :keyword public_ip_prefix_id: The resource ID of a public IP prefix which the public IPs of the
+         API server and the default ingress are allocated from.
+        :paramtype public_ip_prefix_id: str
         """
         super(NetworkProfile, self).__init__(**kwargs)
         self.pod_cidr = kwargs.get('pod_cidr', None)
         self.service_cidr = kwargs.get('service_cidr', None)
+        self.pod_cidr_ipv6 = kwargs.get('pod_cidr_ipv6', None)
+        self.service_cidr_ipv6 = kwargs.get('service_cidr_ipv6', None)
         self.outbound_type = kwargs.get('outbound_type', None)
         self.load_balancer_profile = kwargs.get('load_balancer_profile', None)
         self.preconfigured_nsg = kwargs.get('preconfigured_nsg', None)
+        self.public_ip_prefix_id = kwargs.get('public_ip_prefix_id', None)
 
 
 class TrackedResource(Resource):
@@ -818,12 +940,23 @@ class OpenShiftCluster(TrackedResource):
     :ivar worker_profiles_status: The cluster worker profiles status.
     :vartype worker_profiles_status:
      list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+    :ivar zone_placement: Whether the cluster VMs are placed in availability zones.  With
+     Automatic, the zones of profiles which do not list their own are selected by the service.
+     Possible values include: "Automatic", "NonZonal".
+    :vartype zone_placement: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ZonePlacement
     :ivar apiserver_profile: The cluster API server profile.
     :vartype apiserver_profile:
      ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
     :ivar ingress_profiles: The cluster ingress profiles.
     :vartype ingress_profiles:
      list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+    :ivar diagnostic_settings_profile: The cluster diagnostic settings profile.
+    :vartype diagnostic_settings_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiagnosticSettingsProfile
+    :ivar max_nodes_profile: The cluster max nodes profile.
+    :vartype max_nodes_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaxNodesProfile
     """
 
     _validation = {
@@ -851,8 +984,11 @@ class OpenShiftCluster(TrackedResource):
         'master_profile': {'key': 'properties.masterProfile', 'type': 'MasterProfile'},
         'worker_profiles': {'key': 'properties.workerProfiles', 'type': '[WorkerProfile]'},
         'worker_profiles_status': {'key': 'properties.workerProfilesStatus', 'type': '[WorkerProfile]'},
+        'zone_placement': {'key': 'properties.zonePlacement', 'type': 'str'},
         'apiserver_profile': {'key': 'properties.apiserverProfile', 'type': 'APIServerProfile'},
         'ingress_profiles': {'key': 'properties.ingressProfiles', 'type': '[IngressProfile]'},
+        'diagnostic_settings_profile': {'key': 'properties.diagnosticSettingsProfile', 'type': 'DiagnosticSettingsProfile'},
+        'max_nodes_profile': {'key': 'properties.maxNodesProfile', 'type': 'MaxNodesProfile'},
     }
 
     def __init__(
@@ -888,12 +1024,23 @@ class OpenShiftCluster(TrackedResource):
         :keyword worker_profiles: The cluster worker profiles.
         :paramtype worker_profiles:
          list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+        :keyword zone_placement: Whether the cluster VMs are placed in availability zones.  With
+         Automatic, the zones of profiles which do not list their own are selected by the service.
+         Possible values include: "Automatic", "NonZonal".
+        :paramtype zone_placement: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ZonePlacement
         :keyword apiserver_profile: The cluster API server profile.
         :paramtype apiserver_profile:
          ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
         :keyword ingress_profiles: The cluster ingress profiles.
         :paramtype ingress_profiles:
          list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+        :keyword diagnostic_settings_profile: The cluster diagnostic settings profile.
+        :paramtype diagnostic_settings_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiagnosticSettingsProfile
+        :keyword max_nodes_profile: The cluster max nodes profile.
+        :paramtype max_nodes_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaxNodesProfile
         """
         super(OpenShiftCluster, self).__init__(**kwargs)
         self.provisioning_state = kwargs.get('provisioning_state', None)
@@ -905,8 +1052,11 @@ class OpenShiftCluster(TrackedResource):
         self.master_profile = kwargs.get('master_profile', None)
         self.worker_profiles = kwargs.get('worker_profiles', None)
         self.worker_profiles_status = None
+        self.zone_placement = kwargs.get('zone_placement', None)
         self.apiserver_profile = kwargs.get('apiserver_profile', None)
         self.ingress_profiles = kwargs.get('ingress_profiles', None)
+        self.diagnostic_settings_profile = kwargs.get('diagnostic_settings_profile', None)
+        self.max_nodes_profile = kwargs.get('max_nodes_profile', None)
 
 
 class OpenShiftClusterAdminKubeconfig(msrest.serialization.Model):
@@ -1026,12 +1176,23 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
     :ivar worker_profiles_status: The cluster worker profiles status.
     :vartype worker_profiles_status:
      list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+    :ivar zone_placement: Whether the cluster VMs are placed in availability zones.  With
+     Automatic, the zones of profiles which do not list their own are selected by the service.
+     Possible values include: "Automatic", "NonZonal".
+    :vartype zone_placement: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ZonePlacement
     :ivar apiserver_profile: The cluster API server profile.
     :vartype apiserver_profile:
      ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
     :ivar ingress_profiles: The cluster ingress profiles.
     :vartype ingress_profiles:
      list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+    :ivar diagnostic_settings_profile: The cluster diagnostic settings profile.
+    :vartype diagnostic_settings_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiagnosticSettingsProfile
+    :ivar max_nodes_profile: The cluster max nodes profile.
+    :vartype max_nodes_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaxNodesProfile
     """
 
     _validation = {
@@ -1052,8 +1213,11 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         'master_profile': {'key': 'properties.masterProfile', 'type': 'MasterProfile'},
         'worker_profiles': {'key': 'properties.workerProfiles', 'type': '[WorkerProfile]'},
         'worker_profiles_status': {'key': 'properties.workerProfilesStatus', 'type': '[WorkerProfile]'},
+        'zone_placement': {'key': 'properties.zonePlacement', 'type': 'str'},
         'apiserver_profile': {'key': 'properties.apiserverProfile', 'type': 'APIServerProfile'},
         'ingress_profiles': {'key': 'properties.ingressProfiles', 'type': '[IngressProfile]'},
+        'diagnostic_settings_profile': {'key': 'properties.diagnosticSettingsProfile', 'type': 'DiagnosticSettingsProfile'},
+        'max_nodes_profile': {'key': 'properties.maxNodesProfile', 'type': 'MaxNodesProfile'},
     }
 
     def __init__(
@@ -1090,12 +1254,23 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         :keyword worker_profiles: The cluster worker profiles.
         :paramtype worker_profiles:
          list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+        :keyword zone_placement: Whether the cluster VMs are placed in availability zones.  With
+         Automatic, the zones of profiles which do not list their own are selected by the service.
+         Possible values include: "Automatic", "NonZonal".
+        :paramtype zone_placement: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ZonePlacement
         :keyword apiserver_profile: The cluster API server profile.
         :paramtype apiserver_profile:
          ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
         :keyword ingress_profiles: The cluster ingress profiles.
         :paramtype ingress_profiles:
          list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+        :keyword diagnostic_settings_profile: The cluster diagnostic settings profile.
+        :paramtype diagnostic_settings_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiagnosticSettingsProfile
+        :keyword max_nodes_profile: The cluster max nodes profile.
+        :paramtype max_nodes_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaxNodesProfile
         """
         super(OpenShiftClusterUpdate, self).__init__(**kwargs)
         self.tags = kwargs.get('tags', None)
@@ -1110,8 +1285,11 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         self.master_profile = kwargs.get('master_profile', None)
         self.worker_profiles = kwargs.get('worker_profiles', None)
         self.worker_profiles_status = None
+        self.zone_placement = kwargs.get('zone_placement', None)
         self.apiserver_profile = kwargs.get('apiserver_profile', None)
         self.ingress_profiles = kwargs.get('ingress_profiles', None)
+        self.diagnostic_settings_profile = kwargs.get('diagnostic_settings_profile', None)
+        self.max_nodes_profile = kwargs.get('max_nodes_profile', None)
 
 
 class OpenShiftVersion(ProxyResource):
@@ -1463,6 +1641,41 @@ class ServicePrincipalProfile(msrest.serialization.Model):
         self.client_secret = kwargs.get('client_secret', None)
 
 
+class SpotVMOptions(msrest.serialization.Model):
+    """SpotVMOptions represents the Spot VM options of a worker profile.
+
+    :ivar max_price: The maximum hourly price in US dollars, or -1 to pay up to the on-demand
+     price.
+    :vartype max_price: float
+    :ivar eviction_policy: What happens to a worker VM when it is evicted. Possible values include:
+     "Delete".
+    :vartype eviction_policy: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SpotEvictionPolicy
+    """
+
+    _attribute_map = {
+        'max_price': {'key': 'maxPrice', 'type': 'float'},
+        'eviction_policy': {'key': 'evictionPolicy', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        **kwargs
+    ):
+        """
+        :keyword max_price: The maximum hourly price in US dollars, or -1 to pay up to the on-demand
+         price.
+        :paramtype max_price: float
+        :keyword eviction_policy: What happens to a worker VM when it is evicted. Possible values
+         include: "Delete".
+        :paramtype eviction_policy: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SpotEvictionPolicy
+        """
+        super(SpotVMOptions, self).__init__(**kwargs)
+        self.max_price = kwargs.get('max_price', None)
+        self.eviction_policy = kwargs.get('eviction_policy', None)
+
+
 class SyncIdentityProvider(ProxyResource):
     """SyncIdentityProvider represents a SyncIdentityProvider.
 
@@ -1749,7 +1962,8 @@ class WorkerProfile(msrest.serialization.Model):
 
     :ivar name: The worker profile name.
     :vartype name: str
-    :ivar vm_size: The size of the worker VMs.
+    :ivar vm_size: The size of the worker VMs.  Changing it on an existing cluster replaces the
+     worker VMs of the profile with VMs of the new size.
     :vartype vm_size: str
     :ivar disk_size_gb: The disk size of the worker VMs.
     :vartype disk_size_gb: int
@@ -1764,6 +1978,23 @@ class WorkerProfile(msrest.serialization.Model):
     :ivar disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
      applicable.
     :vartype disk_encryption_set_id: str
+    :ivar disk_type: The managed disk type of the worker VMs.  OS disks are always Premium_LRS;
+     UltraSSD_LRS also allows Ultra disks to be attached. Possible values include: "Premium_LRS",
+     "UltraSSD_LRS".
+    :vartype disk_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiskType
+    :ivar accelerated_networking: Whether the worker VMs use accelerated networking. Possible
+     values include: "Disabled", "Enabled".
+    :vartype accelerated_networking: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.AcceleratedNetworking
+    :ivar zones: The availability zones across which the worker VMs are spread.  If empty, the
+     zones are selected by the service.
+    :vartype zones: list[str]
+    :ivar spot_vm_options: The Spot VM options, if the worker VMs are Azure Spot VMs.
+    :vartype spot_vm_options: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SpotVMOptions
+    :ivar max_surge: The number of worker VMs of the new size which may be created above the worker
+     count of each MachineSet while the worker VMs are replaced after a change of size.  Defaults
+     to 1.
+    :vartype max_surge: int
     """
 
     _attribute_map = {
@@ -1774,6 +2005,11 @@ class WorkerProfile(msrest.serialization.Model):
         'count': {'key': 'count', 'type': 'int'},
         'encryption_at_host': {'key': 'encryptionAtHost', 'type': 'str'},
         'disk_encryption_set_id': {'key': 'diskEncryptionSetId', 'type': 'str'},
+        'disk_type': {'key': 'diskType', 'type': 'str'},
+        'accelerated_networking': {'key': 'acceleratedNetworking', 'type': 'str'},
+        'zones': {'key': 'zones', 'type': '[str]'},
+        'spot_vm_options': {'key': 'spotVMOptions', 'type': 'SpotVMOptions'},
+        'max_surge': {'key': 'maxSurge', 'type': 'int'},
     }
 
     def __init__(
@@ -1783,7 +2019,8 @@ class WorkerProfile(msrest.serialization.Model):
         """
         :keyword name: The worker profile name.
         :paramtype name: str
-        :keyword vm_size: The size of the worker VMs.
+        :keyword vm_size: The size of the worker VMs.  Changing it on an existing cluster replaces the
+         worker VMs of the profile with VMs of the new size.
         :paramtype vm_size: str
         :keyword disk_size_gb: The disk size of the worker VMs.
         :paramtype disk_size_gb: int
@@ -1798,6 +2035,24 @@ class WorkerProfile(msrest.serialization.Model):
         :keyword disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
          applicable.
         :paramtype disk_encryption_set_id: str
+        :keyword disk_type: The managed disk type of the worker VMs.  OS disks are always Premium_LRS;
+         UltraSSD_LRS also allows Ultra disks to be attached. Possible values include: "Premium_LRS",
+         "UltraSSD_LRS".
+        :paramtype disk_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiskType
+        :keyword accelerated_networking: Whether the worker VMs use accelerated networking. Possible
+         values include: "Disabled", "Enabled".
+        :paramtype accelerated_networking: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.AcceleratedNetworking
+        :keyword zones: The availability zones across which the worker VMs are spread.  If empty, the
+         zones are selected by the service.
+        :paramtype zones: list[str]
+        :keyword spot_vm_options: The Spot VM options, if the worker VMs are Azure Spot VMs.
+        :paramtype spot_vm_options:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SpotVMOptions
+        :keyword max_surge: The number of worker VMs of the new size which may be created above the
+         worker count of each MachineSet while the worker VMs are replaced after a change of size.
+         Defaults to 1.
+        :paramtype max_surge: int
         """
         super(WorkerProfile, self).__init__(**kwargs)
         self.name = kwargs.get('name', None)
@@ -1807,3 +2062,8 @@ class WorkerProfile(msrest.serialization.Model):
         self.count = kwargs.get('count', None)
         self.encryption_at_host = kwargs.get('encryption_at_host', None)
         self.disk_encryption_set_id = kwargs.get('disk_encryption_set_id', None)
+        self.disk_type = kwargs.get('disk_type', None)
+        self.accelerated_networking = kwargs.get('accelerated_networking', None)
+        self.zones = kwargs.get('zones', None)
+        self.spot_vm_options = kwargs.get('spot_vm_options', None)
+        self.max_surge = kwargs.get('max_surge', None)
@@ -33,17 +33,21 @@ class APIServerProfile(msrest.serialization.Model):
     :vartype url: str
     :ivar ip: The IP of the cluster API server.
     :vartype ip: str
+    :ivar ipv6: The IPv6 IP of the cluster API server of a dual-stack cluster.
+    :vartype ipv6: str
     """
 
     _validation = {
         'url': {'readonly': True},
         'ip': {'readonly': True},
+        'ipv6': {'readonly': True},
     }
 
     _attribute_map = {
         'visibility': {'key': 'visibility', 'type': 'str'},
         'url': {'key': 'url', 'type': 'str'},
         'ip': {'key': 'ip', 'type': 'str'},
+        'ipv6': {'key': 'ipv6', 'type': 'str'},
     }
 
     def __init__(
@@ -60,6 +64,7 @@ class APIServerProfile(msrest.serialization.Model):
         self.visibility = visibility
         self.url = None
         self.ip = None
+        self.ipv6 = None
 
 
 class CloudErrorBody(msrest.serialization.Model):
@@ -74,6 +79,10 @@ class CloudErrorBody(msrest.serialization.Model):
     :ivar target: The target of the particular error. For example, the name of the property in
      error.
     :vartype target: str
+    :ivar target_resource_id: The resource ID of the Azure resource in error, if any.
+    :vartype target_resource_id: str
+    :ivar remediation_url: A link to documentation describing how to resolve the error, if any.
+    :vartype remediation_url: str
     :ivar details: A list of additional details about the error.
     :vartype details: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.CloudErrorBody]
     """
@@ -82,6 +91,8 @@ class CloudErrorBody(msrest.serialization.Model):
         'code': {'key': 'code', 'type': 'str'},
         'message': {'key': 'message', 'type': 'str'},
         'target': {'key': 'target', 'type': 'str'},
+        'target_resource_id': {'key': 'targetResourceId', 'type': 'str'},
+        'remediation_url': {'key': 'remediationUrl', 'type': 'str'},
         'details': {'key': 'details', 'type': '[CloudErrorBody]'},
     }
 
@@ -91,6 +102,8 @@ class CloudErrorBody(msrest.serialization.Model):
         code: Optional[str] = None,
         message: Optional[str] = None,
         target: Optional[str] = None,
+        target_resource_id: Optional[str] = None,
+        remediation_url: Optional[str] = None,
         details: Optional[List["CloudErrorBody"]] = None,
         **kwargs
     ):
@@ -104,6 +117,11 @@ class CloudErrorBody(msrest.serialization.Model):
         :keyword target: The target of the particular error. For example, the name of the property in
          error.
         :paramtype target: str
+        :keyword target_resource_id: The resource ID of the Azure resource in error, if any.
+        :paramtype target_resource_id: str
+        :keyword remediation_url: A link to documentation describing how to resolve the error, if
+         any.
+        :paramtype remediation_url: str
         :keyword details: A list of additional details about the error.
         :paramtype details: list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.CloudErrorBody]
         """
@@ -111,6 +129,8 @@ class CloudErrorBody(msrest.serialization.Model):
         self.code = code
         self.message = message
         self.target = target
+        self.target_resource_id = target_resource_id
+        self.remediation_url = remediation_url
         self.details = details
 
 
@@ -237,6 +257,33 @@ class ConsoleProfile(msrest.serialization.Model):
         self.url = None
 
 
+class DiagnosticSettingsProfile(msrest.serialization.Model):
+    """DiagnosticSettingsProfile represents the Azure Monitor diagnostic settings of the cluster's load balancers, network security groups and key vaults.
+
+    :ivar workspace_resource_id: The resource ID of the Log Analytics workspace to which logs and
+     metrics are sent.
+    :vartype workspace_resource_id: str
+    """
+
+    _attribute_map = {
+        'workspace_resource_id': {'key': 'workspaceResourceId', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        workspace_resource_id: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword workspace_resource_id: The resource ID of the Log Analytics workspace to which logs
+         and metrics are sent.
+        :paramtype workspace_resource_id: str
+        """
+        super(DiagnosticSettingsProfile, self).__init__(**kwargs)
+        self.workspace_resource_id = workspace_resource_id
+
+
 class Display(msrest.serialization.Model):
     """Display represents the display details of an operation.
 
@@ -355,16 +402,20 @@ class IngressProfile(msrest.serialization.Model):
     :vartype visibility: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.Visibility
     :ivar ip: The IP of the ingress.
     :vartype ip: str
+    :ivar ipv6: The IPv6 IP of the ingress of a dual-stack cluster.
+    :vartype ipv6: str
     """
 
     _validation = {
         'ip': {'readonly': True},
+        'ipv6': {'readonly': True},
     }
 
     _attribute_map = {
         'name': {'key': 'name', 'type': 'str'},
         'visibility': {'key': 'visibility', 'type': 'str'},
         'ip': {'key': 'ip', 'type': 'str'},
+        'ipv6': {'key': 'ipv6', 'type': 'str'},
     }
 
     def __init__(
@@ -384,6 +435,7 @@ class IngressProfile(msrest.serialization.Model):
         self.name = name
         self.visibility = visibility
         self.ip = None
+        self.ipv6 = None
 
 
 class LoadBalancerProfile(msrest.serialization.Model):
@@ -672,6 +724,17 @@ class MasterProfile(msrest.serialization.Model):
     :ivar disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
      applicable.
     :vartype disk_encryption_set_id: str
+    :ivar disk_type: The managed disk type of the master VMs.  OS disks are always Premium_LRS;
+     UltraSSD_LRS also allows Ultra disks to be attached. Possible values include: "Premium_LRS",
+     "UltraSSD_LRS".
+    :vartype disk_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiskType
+    :ivar accelerated_networking: Whether the master VMs use accelerated networking. Possible
+     values include: "Disabled", "Enabled".
+    :vartype accelerated_networking: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.AcceleratedNetworking
+    :ivar zones: The availability zones of the master VMs.  If empty, the zones are selected by the
+     service.
+    :vartype zones: list[str]
     """
 
     _attribute_map = {
@@ -679,6 +742,9 @@ class MasterProfile(msrest.serialization.Model):
         'subnet_id': {'key': 'subnetId', 'type': 'str'},
         'encryption_at_host': {'key': 'encryptionAtHost', 'type': 'str'},
         'disk_encryption_set_id': {'key': 'diskEncryptionSetId', 'type': 'str'},
+        'disk_type': {'key': 'diskType', 'type': 'str'},
+        'accelerated_networking': {'key': 'acceleratedNetworking', 'type': 'str'},
+        'zones': {'key': 'zones', 'type': '[str]'},
     }
 
     def __init__(
@@ -688,6 +754,9 @@ class MasterProfile(msrest.serialization.Model):
         subnet_id: Optional[str] = None,
         encryption_at_host: Optional[Union[str, "EncryptionAtHost"]] = None,
         disk_encryption_set_id: Optional[str] = None,
+        disk_type: Optional[Union[str, "DiskType"]] = None,
+        accelerated_networking: Optional[Union[str, "AcceleratedNetworking"]] = None,
+        zones: Optional[List[str]] = None,
         **kwargs
     ):
         """
@@ -702,12 +771,51 @@ class MasterProfile(msrest.serialization.Model):
         :keyword disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
          applicable.
         :paramtype disk_encryption_set_id: str
+        :keyword disk_type: The managed disk type of the master VMs.  OS disks are always Premium_LRS;
+         UltraSSD_LRS also allows Ultra disks to be attached. Possible values include: "Premium_LRS",
+         "UltraSSD_LRS".
+        :paramtype disk_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiskType
+        :keyword accelerated_networking: Whether the master VMs use accelerated networking. Possible
+         values include: "Disabled", "Enabled".
+        :paramtype accelerated_networking: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.AcceleratedNetworking
+        :keyword zones: The availability zones of the master VMs.  If empty, the zones are selected by
+         the service.
+        :paramtype zones: list[str]
         """
         super(MasterProfile, self).__init__(**kwargs)
         self.vm_size = vm_size
         self.subnet_id = subnet_id
         self.encryption_at_host = encryption_at_host
         self.disk_encryption_set_id = disk_encryption_set_id
+        self.disk_type = disk_type
+        self.accelerated_networking = accelerated_networking
+        self.zones = zones
+
+
+class MaxNodesProfile(msrest.serialization.Model):
+    """MaxNodesProfile represents the largest number of worker nodes which a large cluster is sized for.
+
+    :ivar max_nodes: The maximum number of worker nodes.
+    :vartype max_nodes: int
+    """
+
+    _attribute_map = {
+        'max_nodes': {'key': 'maxNodes', 'type': 'int'},
+    }
+
+    def __init__(
+        self,
+        *,
+        max_nodes: Optional[int] = None,
+        **kwargs
+    ):
+        """
+        :keyword max_nodes: The maximum number of worker nodes.
+        :paramtype max_nodes: int
+        """
+        super(MaxNodesProfile, self).__init__(**kwargs)
+        self.max_nodes = max_nodes
 
 
 class NetworkProfile(msrest.serialization.Model):
@@ -717,6 +825,11 @@ class NetworkProfile(msrest.serialization.Model):
     :vartype pod_cidr: str
     :ivar service_cidr: The CIDR used for OpenShift/Kubernetes Services.
     :vartype service_cidr: str
+    :ivar pod_cidr_ipv6: The IPv6 CIDR used for OpenShift/Kubernetes Pods of a dual-stack cluster.
+    :vartype pod_cidr_ipv6: str
+    :ivar service_cidr_ipv6: The IPv6 CIDR used for OpenShift/Kubernetes Services of a dual-stack
+     cluster.
+    :vartype service_cidr_ipv6: str
     :ivar outbound_type: The OutboundType used for egress traffic. Possible values include:
      "Loadbalancer", "UserDefinedRouting".
     :vartype outbound_type: str or
@@ -728,14 +841,20 @@ class NetworkProfile(msrest.serialization.Model):
      values include: "Disabled", "Enabled".
     :vartype preconfigured_nsg: str or
      ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.PreconfiguredNSG
+    :ivar public_ip_prefix_id: The resource ID of a public IP prefix which the public IPs of the
+     API server and the default ingress are allocated from.
+    :vartype public_ip_prefix_id: str
     """
 
     _attribute_map = {
         'pod_cidr': {'key': 'podCidr', 'type': 'str'},
         'service_cidr': {'key': 'serviceCidr', 'type': 'str'},
+        'pod_cidr_ipv6': {'key': 'podCidrIpv6', 'type': 'str'},
+        'service_cidr_ipv6': {'key': 'serviceCidrIpv6', 'type': 'str'},
         'outbound_type': {'key': 'outboundType', 'type': 'str'},
         'load_balancer_profile': {'key': 'loadBalancerProfile', 'type': 'LoadBalancerProfile'},
         'preconfigured_nsg': {'key': 'preconfiguredNSG', 'type': 'str'},
+        'public_ip_prefix_id': {'key': 'publicIpPrefixId', 'type': 'str'},
     }
 
     def __init__(
@@ -743,9 +862,12 @@ class NetworkProfile(msrest.serialization.Model):
         *,
         pod_cidr: Optional[str] = None,
         service_cidr: Optional[str] = None,
+        pod_cidr_ipv6: Optional[str] = None,
+        service_cidr_ipv6: Optional[str] = None,
         outbound_type: Optional[Union[str, "OutboundType"]] = None,
         load_balancer_profile: Optional["LoadBalancerProfile"] = None,
         preconfigured_nsg: Optional[Union[str, "PreconfiguredNSG"]] = None,
+        public_ip_prefix_id: Optional[str] = None,
         **kwargs
     ):
         """
@@ -753,6 +875,12 @@ class NetworkProfile(msrest.serialization.Model):
         :paramtype pod_cidr: str
         :keyword service_cidr: The CIDR used for OpenShift/Kubernetes Services.
         :paramtype service_cidr: str
+        :keyword pod_cidr_ipv6: The IPv6 CIDR used for OpenShift/Kubernetes Pods of a dual-stack
+         cluster.
+        :paramtype pod_cidr_ipv6: str
+        :keyword service_cidr_ipv6: The IPv6 CIDR used for OpenShift/Kubernetes Services of a
+         dual-stack cluster.
+        :paramtype service_cidr_ipv6: str
         :keyword outbound_type: The OutboundType used for egress traffic. Possible values include:
          "Loadbalancer", "UserDefinedRouting".
         :paramtype outbound_type: str or
@@ -764,13 +892,19 @@ class NetworkProfile(msrest.serialization.Model):
          values include: "Disabled", "Enabled".
         :paramtype preconfigured_nsg: str or
          ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.PreconfiguredNSG
+        :keyword public_ip_prefix_id: The resource ID of a public IP prefix which the public IPs of the
+         API server and the default ingress are allocated from.
+        :paramtype public_ip_prefix_id: str
         """
         super(NetworkProfile, self).__init__(**kwargs)
         self.pod_cidr = pod_cidr
         self.service_cidr = service_cidr
+        self.pod_cidr_ipv6 = pod_cidr_ipv6
+        self.service_cidr_ipv6 = service_cidr_ipv6
         self.outbound_type = outbound_type
         self.load_balancer_profile = load_balancer_profile
         self.preconfigured_nsg = preconfigured_nsg
+        self.public_ip_prefix_id = public_ip_prefix_id
 
 
 class TrackedResource(Resource):
@@ -878,12 +1012,23 @@ class OpenShiftCluster(TrackedResource):
     :ivar worker_profiles_status: The cluster worker profiles status.
     :vartype worker_profiles_status:
      list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+    :ivar zone_placement: Whether the cluster VMs are placed in availability zones.  With
+     Automatic, the zones of profiles which do not list their own are selected by the service.
+     Possible values include: "Automatic", "NonZonal".
+    :vartype zone_placement: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ZonePlacement
     :ivar apiserver_profile: The cluster API server profile.
     :vartype apiserver_profile:
      ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
     :ivar ingress_profiles: The cluster ingress profiles.
     :vartype ingress_profiles:
      list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+    :ivar diagnostic_settings_profile: The cluster diagnostic settings profile.
+    :vartype diagnostic_settings_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiagnosticSettingsProfile
+    :ivar max_nodes_profile: The cluster max nodes profile.
+    :vartype max_nodes_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaxNodesProfile
     """
 
     _validation = {
@@ -911,8 +1056,11 @@ class OpenShiftCluster(TrackedResource):
         'master_profile': {'key': 'properties.masterProfile', 'type': 'MasterProfile'},
         'worker_profiles': {'key': 'properties.workerProfiles', 'type': '[WorkerProfile]'},
         'worker_profiles_status': {'key': 'properties.workerProfilesStatus', 'type': '[WorkerProfile]'},
+        'zone_placement': {'key': 'properties.zonePlacement', 'type': 'str'},
         'apiserver_profile': {'key': 'properties.apiserverProfile', 'type': 'APIServerProfile'},
         'ingress_profiles': {'key': 'properties.ingressProfiles', 'type': '[IngressProfile]'},
+        'diagnostic_settings_profile': {'key': 'properties.diagnosticSettingsProfile', 'type': 'DiagnosticSettingsProfile'},
+        'max_nodes_profile': {'key': 'properties.maxNodesProfile', 'type': 'MaxNodesProfile'},
     }
 
     def __init__(
@@ -928,8 +1076,11 @@ class OpenShiftCluster(TrackedResource):
         network_profile: Optional["NetworkProfile"] = None,
         master_profile: Optional["MasterProfile"] = None,
         worker_profiles: Optional[List["WorkerProfile"]] = None,
+        zone_placement: Optional[Union[str, "ZonePlacement"]] = None,
         apiserver_profile: Optional["APIServerProfile"] = None,
         ingress_profiles: Optional[List["IngressProfile"]] = None,
+        diagnostic_settings_profile: Optional["DiagnosticSettingsProfile"] = None,
+        max_nodes_profile: Optional["MaxNodesProfile"] = None,
         **kwargs
     ):
         """
@@ -961,12 +1112,23 @@ class OpenShiftCluster(TrackedResource):
         :keyword worker_profiles: The cluster worker profiles.
         :paramtype worker_profiles:
          list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+        :keyword zone_placement: Whether the cluster VMs are placed in availability zones.  With
+         Automatic, the zones of profiles which do not list their own are selected by the service.
+         Possible values include: "Automatic", "NonZonal".
+        :paramtype zone_placement: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ZonePlacement
         :keyword apiserver_profile: The cluster API server profile.
         :paramtype apiserver_profile:
          ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
         :keyword ingress_profiles: The cluster ingress profiles.
         :paramtype ingress_profiles:
          list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+        :keyword diagnostic_settings_profile: The cluster diagnostic settings profile.
+        :paramtype diagnostic_settings_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiagnosticSettingsProfile
+        :keyword max_nodes_profile: The cluster max nodes profile.
+        :paramtype max_nodes_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaxNodesProfile
         """
         super(OpenShiftCluster, self).__init__(tags=tags, location=location, **kwargs)
         self.provisioning_state = provisioning_state
@@ -978,8 +1140,11 @@ class OpenShiftCluster(TrackedResource):
         self.master_profile = master_profile
         self.worker_profiles = worker_profiles
         self.worker_profiles_status = None
+        self.zone_placement = zone_placement
         self.apiserver_profile = apiserver_profile
         self.ingress_profiles = ingress_profiles
+        self.diagnostic_settings_profile = diagnostic_settings_profile
+        self.max_nodes_profile = max_nodes_profile
 
 
 class OpenShiftClusterAdminKubeconfig(msrest.serialization.Model):
@@ -1107,12 +1272,23 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
     :ivar worker_profiles_status: The cluster worker profiles status.
     :vartype worker_profiles_status:
      list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+    :ivar zone_placement: Whether the cluster VMs are placed in availability zones.  With
+     Automatic, the zones of profiles which do not list their own are selected by the service.
+     Possible values include: "Automatic", "NonZonal".
+    :vartype zone_placement: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ZonePlacement
     :ivar apiserver_profile: The cluster API server profile.
     :vartype apiserver_profile:
      ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
     :ivar ingress_profiles: The cluster ingress profiles.
     :vartype ingress_profiles:
      list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+    :ivar diagnostic_settings_profile: The cluster diagnostic settings profile.
+    :vartype diagnostic_settings_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiagnosticSettingsProfile
+    :ivar max_nodes_profile: The cluster max nodes profile.
+    :vartype max_nodes_profile:
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaxNodesProfile
     """
 
     _validation = {
@@ -1133,8 +1309,11 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         'master_profile': {'key': 'properties.masterProfile', 'type': 'MasterProfile'},
         'worker_profiles': {'key': 'properties.workerProfiles', 'type': '[WorkerProfile]'},
         'worker_profiles_status': {'key': 'properties.workerProfilesStatus', 'type': '[WorkerProfile]'},
+        'zone_placement': {'key': 'properties.zonePlacement', 'type': 'str'},
         'apiserver_profile': {'key': 'properties.apiserverProfile', 'type': 'APIServerProfile'},
         'ingress_profiles': {'key': 'properties.ingressProfiles', 'type': '[IngressProfile]'},
+        'diagnostic_settings_profile': {'key': 'properties.diagnosticSettingsProfile', 'type': 'DiagnosticSettingsProfile'},
+        'max_nodes_profile': {'key': 'properties.maxNodesProfile', 'type': 'MaxNodesProfile'},
     }
 
     def __init__(
@@ -1150,8 +1329,11 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         network_profile: Optional["NetworkProfile"] = None,
         master_profile: Optional["MasterProfile"] = None,
         worker_profiles: Optional[List["WorkerProfile"]] = None,
+        zone_placement: Optional[Union[str, "ZonePlacement"]] = None,
         apiserver_profile: Optional["APIServerProfile"] = None,
         ingress_profiles: Optional[List["IngressProfile"]] = None,
+        diagnostic_settings_profile: Optional["DiagnosticSettingsProfile"] = None,
+        max_nodes_profile: Optional["MaxNodesProfile"] = None,
         **kwargs
     ):
         """
@@ -1184,12 +1366,23 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         :keyword worker_profiles: The cluster worker profiles.
         :paramtype worker_profiles:
          list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.WorkerProfile]
+        :keyword zone_placement: Whether the cluster VMs are placed in availability zones.  With
+         Automatic, the zones of profiles which do not list their own are selected by the service.
+         Possible values include: "Automatic", "NonZonal".
+        :paramtype zone_placement: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.ZonePlacement
         :keyword apiserver_profile: The cluster API server profile.
         :paramtype apiserver_profile:
          ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.APIServerProfile
         :keyword ingress_profiles: The cluster ingress profiles.
         :paramtype ingress_profiles:
          list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+        :keyword diagnostic_settings_profile: The cluster diagnostic settings profile.
+        :paramtype diagnostic_settings_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiagnosticSettingsProfile
+        :keyword max_nodes_profile: The cluster max nodes profile.
+        :paramtype max_nodes_profile:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaxNodesProfile
         """
         super(OpenShiftClusterUpdate, self).__init__(**kwargs)
         self.tags = tags
@@ -1204,8 +1397,11 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         self.master_profile = master_profile
         self.worker_profiles = worker_profiles
         self.worker_profiles_status = None
+        self.zone_placement = zone_placement
         self.apiserver_profile = apiserver_profile
         self.ingress_profiles = ingress_profiles
+        self.diagnostic_settings_profile = diagnostic_settings_profile
+        self.max_nodes_profile = max_nodes_profile
 
 
 class OpenShiftVersion(ProxyResource):
@@ -1584,6 +1780,44 @@ class ServicePrincipalProfile(msrest.serialization.Model):
         self.client_secret = client_secret
 
 
+class SpotVMOptions(msrest.serialization.Model):
+    """SpotVMOptions represents the Spot VM options of a worker profile.
+
+    :ivar max_price: The maximum hourly price in US dollars, or -1 to pay up to the on-demand
+     price.
+    :vartype max_price: float
+    :ivar eviction_policy: What happens to a worker VM when it is evicted. Possible values include:
+     "Delete".
+    :vartype eviction_policy: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SpotEvictionPolicy
+    """
+
+    _attribute_map = {
+        'max_price': {'key': 'maxPrice', 'type': 'float'},
+        'eviction_policy': {'key': 'evictionPolicy', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        max_price: Optional[float] = None,
+        eviction_policy: Optional[Union[str, "SpotEvictionPolicy"]] = None,
+        **kwargs
+    ):
+        """
+        :keyword max_price: The maximum hourly price in US dollars, or -1 to pay up to the on-demand
+         price.
+        :paramtype max_price: float
+        :keyword eviction_policy: What happens to a worker VM when it is evicted. Possible values
+         include: "Delete".
+        :paramtype eviction_policy: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SpotEvictionPolicy
+        """
+        super(SpotVMOptions, self).__init__(**kwargs)
+        self.max_price = max_price
+        self.eviction_policy = eviction_policy
+
+
 class SyncIdentityProvider(ProxyResource):
     """SyncIdentityProvider represents a SyncIdentityProvider.
 
@@ -1891,7 +2125,8 @@ class WorkerProfile(msrest.serialization.Model):
 
     :ivar name: The worker profile name.
     :vartype name: str
-    :ivar vm_size: The size of the worker VMs.
+    :ivar vm_size: The size of the worker VMs.  Changing it on an existing cluster replaces the
+     worker VMs of the profile with VMs of the new size.
     :vartype vm_size: str
     :ivar disk_size_gb: The disk size of the worker VMs.
     :vartype disk_size_gb: int
@@ -1906,6 +2141,23 @@ class WorkerProfile(msrest.serialization.Model):
     :ivar disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
      applicable.
     :vartype disk_encryption_set_id: str
+    :ivar disk_type: The managed disk type of the worker VMs.  OS disks are always Premium_LRS;
+     UltraSSD_LRS also allows Ultra disks to be attached. Possible values include: "Premium_LRS",
+     "UltraSSD_LRS".
+    :vartype disk_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiskType
+    :ivar accelerated_networking: Whether the worker VMs use accelerated networking. Possible
+     values include: "Disabled", "Enabled".
+    :vartype accelerated_networking: str or
+     ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.AcceleratedNetworking
+    :ivar zones: The availability zones across which the worker VMs are spread.  If empty, the
+     zones are selected by the service.
+    :vartype zones: list[str]
+    :ivar spot_vm_options: The Spot VM options, if the worker VMs are Azure Spot VMs.
+    :vartype spot_vm_options: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SpotVMOptions
+    :ivar max_surge: The number of worker VMs of the new size which may be created above the worker
+     count of each MachineSet while the worker VMs are replaced after a change of size.  Defaults
+     to 1.
+    :vartype max_surge: int
     """
 
     _attribute_map = {
@@ -1916,6 +2168,11 @@ class WorkerProfile(msrest.serialization.Model):
         'count': {'key': 'count', 'type': 'int'},
         'encryption_at_host': {'key': 'encryptionAtHost', 'type': 'str'},
         'disk_encryption_set_id': {'key': 'diskEncryptionSetId', 'type': 'str'},
+        'disk_type': {'key': 'diskType', 'type': 'str'},
+        'accelerated_networking': {'key': 'acceleratedNetworking', 'type': 'str'},
+        'zones': {'key': 'zones', 'type': '[str]'},
+        'spot_vm_options': {'key': 'spotVMOptions', 'type': 'SpotVMOptions'},
+        'max_surge': {'key': 'maxSurge', 'type': 'int'},
     }
 
     def __init__(
@@ -1928,12 +2185,18 @@ class WorkerProfile(msrest.serialization.Model):
         count: Optional[int] = None,
         encryption_at_host: Optional[Union[str, "EncryptionAtHost"]] = None,
         disk_encryption_set_id: Optional[str] = None,
+        disk_type: Optional[Union[str, "DiskType"]] = None,
+        accelerated_networking: Optional[Union[str, "AcceleratedNetworking"]] = None,
+        zones: Optional[List[str]] = None,
+        spot_vm_options: Optional["SpotVMOptions"] = None,
+        max_surge: Optional[int] = None,
         **kwargs
     ):
         """
         :keyword name: The worker profile name.
         :paramtype name: str
-        :keyword vm_size: The size of the worker VMs.
+        :keyword vm_size: The size of the worker VMs.  Changing it on an existing cluster replaces the
+         worker VMs of the profile with VMs of the new size.
         :paramtype vm_size: str
         :keyword disk_size_gb: The disk size of the worker VMs.
         :paramtype disk_size_gb: int
@@ -1948,6 +2211,24 @@ class WorkerProfile(msrest.serialization.Model):
         :keyword disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
          applicable.
         :paramtype disk_encryption_set_id: str
+        :keyword disk_type: The managed disk type of the worker VMs.  OS disks are always Premium_LRS;
+         UltraSSD_LRS also allows Ultra disks to be attached. Possible values include: "Premium_LRS",
+         "UltraSSD_LRS".
+        :paramtype disk_type: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.DiskType
+        :keyword accelerated_networking: Whether the worker VMs use accelerated networking. Possible
+         values include: "Disabled", "Enabled".
+        :paramtype accelerated_networking: str or
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.AcceleratedNetworking
+        :keyword zones: The availability zones across which the worker VMs are spread.  If empty, the
+         zones are selected by the service.
+        :paramtype zones: list[str]
+        :keyword spot_vm_options: The Spot VM options, if the worker VMs are Azure Spot VMs.
+        :paramtype spot_vm_options:
+         ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SpotVMOptions
+        :keyword max_surge: The number of worker VMs of the new size which may be created above the
+         worker count of each MachineSet while the worker VMs are replaced after a change of size.
+         Defaults to 1.
+        :paramtype max_surge: int
         """
         super(WorkerProfile, self).__init__(**kwargs)
         self.name = name
@@ -1957,3 +2238,8 @@ class WorkerProfile(msrest.serialization.Model):
         self.count = count
         self.encryption_at_host = encryption_at_host
         self.disk_encryption_set_id = disk_encryption_set_id
+        self.disk_type = disk_type
+        self.accelerated_networking = accelerated_networking
+        self.zones = zones
+        self.spot_vm_options = spot_vm_options
+        self.max_surge = max_surge
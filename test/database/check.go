@@ -23,6 +23,7 @@ type Checker struct {
 	billingDocuments          []*api.BillingDocument
 	asyncOperationDocuments   []*api.AsyncOperationDocument
 	portalDocuments           []*api.PortalDocument
+	portalSessionDocuments    []*api.PortalSessionDocument
 	gatewayDocuments          []*api.GatewayDocument
 	openShiftVersionDocuments []*api.OpenShiftVersionDocument
 	validationResult          []*api.ValidationResult
@@ -87,6 +88,17 @@ func (f *Checker) AddPortalDocuments(docs ...*api.PortalDocument) {
 	}
 }
 
+func (f *Checker) AddPortalSessionDocuments(docs ...*api.PortalSessionDocument) {
+	for _, doc := range docs {
+		docCopy, err := deepCopy(doc)
+		if err != nil {
+			panic(err)
+		}
+
+		f.portalSessionDocuments = append(f.portalSessionDocuments, docCopy.(*api.PortalSessionDocument))
+	}
+}
+
 func (f *Checker) AddGatewayDocuments(docs ...*api.GatewayDocument) {
 	for _, doc := range docs {
 		docCopy, err := deepCopy(doc)
@@ -232,6 +244,26 @@ func (f *Checker) CheckPortals(portals *cosmosdb.FakePortalDocumentClient) (errs
 	return errs
 }
 
+func (f *Checker) CheckPortalSessions(portalSessions *cosmosdb.FakePortalSessionDocumentClient) (errs []error) {
+	ctx := context.Background()
+
+	all, err := portalSessions.ListAll(ctx, nil)
+	if err != nil {
+		return []error{err}
+	}
+
+	if len(f.portalSessionDocuments) != 0 && len(all.PortalSessionDocuments) == len(f.portalSessionDocuments) {
+		diff := deep.Equal(all.PortalSessionDocuments, f.portalSessionDocuments)
+		for _, i := range diff {
+			errs = append(errs, errors.New(i))
+		}
+	} else if len(all.PortalSessionDocuments) != 0 || len(f.portalSessionDocuments) != 0 {
+		errs = append(errs, fmt.Errorf("portalSessions length different, %d vs %d", len(all.PortalSessionDocuments), len(f.portalSessionDocuments)))
+	}
+
+	return errs
+}
+
 func (f *Checker) CheckGateways(gateways *cosmosdb.FakeGatewayDocumentClient) (errs []error) {
 	ctx := context.Background()
 
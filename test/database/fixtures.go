@@ -17,6 +17,7 @@ type Fixture struct {
 	billingDocuments                     []*api.BillingDocument
 	asyncOperationDocuments              []*api.AsyncOperationDocument
 	portalDocuments                      []*api.PortalDocument
+	portalSessionDocuments               []*api.PortalSessionDocument
 	gatewayDocuments                     []*api.GatewayDocument
 	openShiftVersionDocuments            []*api.OpenShiftVersionDocument
 	clusterManagerConfigurationDocuments []*api.ClusterManagerConfigurationDocument
@@ -26,6 +27,7 @@ type Fixture struct {
 	subscriptionsDatabase                database.Subscriptions
 	asyncOperationsDatabase              database.AsyncOperations
 	portalDatabase                       database.Portal
+	portalSessionsDatabase               database.PortalSessions
 	gatewayDatabase                      database.Gateway
 	openShiftVersionsDatabase            database.OpenShiftVersions
 	clusterManagerConfigurationsDatabase database.ClusterManagerConfigurations
@@ -67,6 +69,11 @@ func (f *Fixture) WithPortal(db database.Portal) *Fixture {
 	return f
 }
 
+func (f *Fixture) WithPortalSessions(db database.PortalSessions) *Fixture {
+	f.portalSessionsDatabase = db
+	return f
+}
+
 func (f *Fixture) WithGateway(db database.Gateway) *Fixture {
 	f.gatewayDatabase = db
 	return f
@@ -133,6 +140,17 @@ func (f *Fixture) AddPortalDocuments(docs ...*api.PortalDocument) {
 	}
 }
 
+func (f *Fixture) AddPortalSessionDocuments(docs ...*api.PortalSessionDocument) {
+	for _, doc := range docs {
+		docCopy, err := deepCopy(doc)
+		if err != nil {
+			panic(err)
+		}
+
+		f.portalSessionDocuments = append(f.portalSessionDocuments, docCopy.(*api.PortalSessionDocument))
+	}
+}
+
 func (f *Fixture) AddGatewayDocuments(docs ...*api.GatewayDocument) {
 	for _, doc := range docs {
 		docCopy, err := deepCopy(doc)
@@ -207,6 +225,16 @@ func (f *Fixture) Create() error {
 		}
 	}
 
+	for _, i := range f.portalSessionDocuments {
+		if i.ID == "" {
+			i.ID = f.portalSessionsDatabase.NewUUID()
+		}
+		_, err := f.portalSessionsDatabase.Create(ctx, i)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, i := range f.gatewayDocuments {
 		_, err := f.gatewayDatabase.Create(ctx, i)
 		if err != nil {
@@ -0,0 +1,32 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+func fakeGatewayGetByClusterResourceIDQuery(client cosmosdb.GatewayDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.GatewayDocumentRawIterator {
+	input, err := client.ListAll(context.Background(), options)
+	if err != nil {
+		return cosmosdb.NewFakeGatewayDocumentErroringRawIterator(err)
+	}
+
+	var results []*api.GatewayDocument
+	for _, r := range input.GatewayDocuments {
+		if r.Key == query.Parameters[0].Value {
+			results = append(results, r)
+		}
+	}
+
+	return cosmosdb.NewFakeGatewayDocumentIterator(results, 0)
+}
+
+func injectGateway(c *cosmosdb.FakeGatewayDocumentClient) {
+	c.SetQueryHandler(database.GatewayGetByClusterResourceIDQuery, fakeGatewayGetByClusterResourceIDQuery)
+}
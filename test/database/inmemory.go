@@ -59,9 +59,18 @@ func NewFakePortal() (db database.Portal, client *cosmosdb.FakePortalDocumentCli
 	return db, client
 }
 
+func NewFakePortalSessions() (db database.PortalSessions, client *cosmosdb.FakePortalSessionDocumentClient) {
+	uuid := deterministicuuid.NewTestUUIDGenerator(deterministicuuid.PORTALSESSIONS)
+	client = cosmosdb.NewFakePortalSessionDocumentClient(jsonHandle)
+	injectPortalSessions(client)
+	db = database.NewPortalSessionsWithProvidedClient(client, uuid)
+	return db, client
+}
+
 func NewFakeGateway() (db database.Gateway, client *cosmosdb.FakeGatewayDocumentClient) {
 	uuid := deterministicuuid.NewTestUUIDGenerator(deterministicuuid.GATEWAY)
 	client = cosmosdb.NewFakeGatewayDocumentClient(jsonHandle)
+	injectGateway(client)
 	db = database.NewGatewayWithProvidedClient(client, uuid)
 	return db, client
 }
@@ -72,6 +81,18 @@ func NewFakeOpenShiftVersions(uuid uuid.Generator) (db database.OpenShiftVersion
 	return db, client
 }
 
+func NewFakeRPFeatures() (db database.RPFeatures, client *cosmosdb.FakeRPFeaturesDocumentClient) {
+	client = cosmosdb.NewFakeRPFeaturesDocumentClient(jsonHandle)
+	db = database.NewRPFeaturesWithProvidedClient(client)
+	return db, client
+}
+
+func NewFakeMirroredImages() (db database.MirroredImages, client *cosmosdb.FakeMirroredImageDocumentClient) {
+	client = cosmosdb.NewFakeMirroredImageDocumentClient(jsonHandle)
+	db = database.NewMirroredImagesWithProvidedClient(client)
+	return db, client
+}
+
 func NewFakeClusterManager() (db database.ClusterManagerConfigurations, client *cosmosdb.FakeClusterManagerConfigurationDocumentClient) {
 	uuid := deterministicuuid.NewTestUUIDGenerator(deterministicuuid.CLUSTERMANAGER)
 	client = cosmosdb.NewFakeClusterManagerConfigurationDocumentClient(jsonHandle)
@@ -32,6 +32,10 @@ func getQueuedOpenShiftDocuments(client cosmosdb.OpenShiftClusterDocumentClient)
 	}
 
 	for _, r := range docs {
+		if r.SoftDeleted != nil {
+			continue
+		}
+
 		var include bool
 		switch r.OpenShiftCluster.Properties.ProvisioningState {
 		case
@@ -85,6 +89,12 @@ func fakeOpenshiftClustersMatchQuery(client cosmosdb.OpenShiftClusterDocumentCli
 		switch query.Parameters[0].Name {
 		case "@key":
 			key = r.Key
+			if query.Query == database.OpenShiftClustersSoftDeletedQuery {
+				if r.SoftDeleted == nil {
+					continue
+				}
+				key = r.SoftDeleted.Key
+			}
 		case "@clientID":
 			key = r.ClientIDKey
 		case "@resourceGroupID":
@@ -99,6 +109,46 @@ func fakeOpenshiftClustersMatchQuery(client cosmosdb.OpenShiftClusterDocumentCli
 	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(results, startingIndex)
 }
 
+func fakeOpenShiftClustersExpiredQuery(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+	retention, err := strconv.ParseInt(query.Parameters[0].Value, 10, 64)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	docs, err := fakeOpenShiftClustersGetAllDocuments(client)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	var results []*api.OpenShiftClusterDocument
+	for _, r := range docs {
+		if r.SoftDeleted != nil && int64(r.SoftDeleted.DeletionTime) < time.Now().Unix()-retention {
+			results = append(results, r)
+		}
+	}
+	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(results, 0)
+}
+
+func fakeOpenShiftClustersNotSoftDeletedQuery(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+	startingIndex, err := fakeOpenShiftClustersGetContinuation(options)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	docs, err := fakeOpenShiftClustersGetAllDocuments(client)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	var results []*api.OpenShiftClusterDocument
+	for _, r := range docs {
+		if r.SoftDeleted == nil {
+			results = append(results, r)
+		}
+	}
+	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(results, startingIndex)
+}
+
 func fakeOpenShiftClustersGetAllDocuments(client cosmosdb.OpenShiftClusterDocumentClient) ([]*api.OpenShiftClusterDocument, error) {
 	input, err := client.ListAll(context.Background(), nil)
 	if err != nil {
@@ -158,6 +208,9 @@ func injectOpenShiftClusters(c *cosmosdb.FakeOpenShiftClusterDocumentClient) {
 	c.SetQueryHandler(database.OpenshiftClustersClientIdQuery, fakeOpenshiftClustersMatchQuery)
 	c.SetQueryHandler(database.OpenshiftClustersResourceGroupQuery, fakeOpenshiftClustersMatchQuery)
 	c.SetQueryHandler(database.OpenshiftClustersPrefixQuery, fakeOpenshiftClustersPrefixQuery)
+	c.SetQueryHandler(database.OpenShiftClustersSoftDeletedQuery, fakeOpenshiftClustersMatchQuery)
+	c.SetQueryHandler(database.OpenShiftClustersExpiredQuery, fakeOpenShiftClustersExpiredQuery)
+	c.SetQueryHandler(database.OpenShiftClustersNotSoftDeletedQuery, fakeOpenShiftClustersNotSoftDeletedQuery)
 
 	c.SetTriggerHandler("renewLease", fakeOpenShiftClustersRenewLeaseTrigger)
 
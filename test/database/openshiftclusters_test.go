@@ -0,0 +1,247 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+func TestOpenShiftClustersSoftDelete(t *testing.T) {
+	ctx := context.Background()
+
+	key := strings.ToLower(GetResourcePath("00000000-0000-0000-0000-000000000000", "cluster"))
+
+	openShiftClusters, _ := NewFakeOpenShiftClusters()
+
+	doc, err := openShiftClusters.Create(ctx, &api.OpenShiftClusterDocument{
+		ID:                        openShiftClusters.NewUUID(),
+		Key:                       key,
+		ClusterResourceGroupIDKey: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/aro-cluster",
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateDeleting,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = openShiftClusters.SoftDelete(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = openShiftClusters.Get(ctx, key)
+	if !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	n, err := openShiftClusters.QueueLength(ctx, "OpenShiftClusters")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("expected queue length 0, got %d", n)
+	}
+
+	docs, err := openShiftClusters.ListSoftDeleted(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs.OpenShiftClusterDocuments) != 1 {
+		t.Fatalf("expected 1 tombstone, got %d", len(docs.OpenShiftClusterDocuments))
+	}
+	if docs.OpenShiftClusterDocuments[0].ClusterResourceGroupIDKey == doc.ClusterResourceGroupIDKey {
+		t.Error("expected cluster resource group key to be moved aside")
+	}
+
+	docs, err = openShiftClusters.ListExpired(ctx, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs.OpenShiftClusterDocuments) != 0 {
+		t.Errorf("expected no expired tombstones, got %d", len(docs.OpenShiftClusterDocuments))
+	}
+
+	doc, err = openShiftClusters.Restore(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.SoftDeleted != nil || doc.ClusterResourceGroupIDKey != "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/aro-cluster" {
+		t.Errorf("unexpected restored document %v", doc)
+	}
+
+	_, err = openShiftClusters.Get(ctx, key)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = openShiftClusters.Restore(ctx, key)
+	if !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestOpenShiftClustersPatchAll(t *testing.T) {
+	ctx := context.Background()
+
+	query := &cosmosdb.Query{
+		Query: "SELECT * FROM OpenShiftClusters doc",
+	}
+
+	openShiftClusters, client := NewFakeOpenShiftClusters()
+	client.SetQueryHandler(query.Query, func(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+		docs, err := fakeOpenShiftClustersGetAllDocuments(client)
+		if err != nil {
+			return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+		}
+		sort.Sort(ByKey(docs))
+
+		var continuation int
+		if options != nil && options.Continuation != "" {
+			continuation, err = strconv.Atoi(options.Continuation)
+			if err != nil {
+				return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+			}
+		}
+
+		return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(docs, continuation)
+	})
+
+	var keys []string
+	for i := 0; i < 4; i++ {
+		key := strings.ToLower(GetResourcePath("00000000-0000-0000-0000-000000000000", fmt.Sprintf("cluster%d", i)))
+		keys = append(keys, key)
+
+		_, err := openShiftClusters.Create(ctx, &api.OpenShiftClusterDocument{
+			ID:  openShiftClusters.NewUUID(),
+			Key: key,
+			OpenShiftCluster: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: api.ProvisioningStateSucceeded,
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the tombstone of a deleted cluster is returned by the query but must not
+	// be patched
+	tombstone, err := openShiftClusters.Create(ctx, &api.OpenShiftClusterDocument{
+		ID:  openShiftClusters.NewUUID(),
+		Key: strings.ToLower(GetResourcePath("00000000-0000-0000-0000-000000000000", "deleted")),
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateDeleting,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = openShiftClusters.SoftDelete(ctx, tombstone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mutator := func(doc *api.OpenShiftClusterDocument) error {
+		if doc.Key == keys[1] {
+			return errors.New("broken")
+		}
+
+		doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskEverything
+		return nil
+	}
+
+	// interrupt the run after the first page
+	errInterrupted := errors.New("interrupted")
+	checkpoint, err := openShiftClusters.PatchAll(ctx, query, mutator, &database.PatchAllOptions{
+		PageSize: 2,
+		OnCheckpoint: func(ctx context.Context, checkpoint *database.PatchAllCheckpoint) error {
+			return errInterrupted
+		},
+	})
+	if err != errInterrupted {
+		t.Fatalf("expected interruption, got %v", err)
+	}
+	wantFailed := []database.PatchAllFailure{
+		{
+			Key:   keys[1],
+			Error: "broken",
+		},
+	}
+	if checkpoint.Continuation != "2" || checkpoint.Patched != 1 || !reflect.DeepEqual(checkpoint.Failed, wantFailed) {
+		t.Fatalf("unexpected checkpoint %#v", checkpoint)
+	}
+
+	doc, err := openShiftClusters.Get(ctx, keys[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenShiftCluster.Properties.MaintenanceTask != "" {
+		t.Error("document beyond the checkpoint was patched")
+	}
+
+	// resume from the checkpoint
+	checkpoint, err = openShiftClusters.PatchAll(ctx, query, mutator, &database.PatchAllOptions{
+		PageSize:              2,
+		RequestUnitsPerSecond: 1000,
+		Checkpoint:            checkpoint,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint.Continuation != "" || checkpoint.Patched != 3 || !reflect.DeepEqual(checkpoint.Failed, wantFailed) {
+		t.Fatalf("unexpected checkpoint %#v", checkpoint)
+	}
+
+	for i, key := range keys {
+		doc, err := openShiftClusters.Get(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var want api.MaintenanceTask
+		if i != 1 {
+			want = api.MaintenanceTaskEverything
+		}
+		if doc.OpenShiftCluster.Properties.MaintenanceTask != want {
+			t.Errorf("%s: got maintenance task %q, want %q", key, doc.OpenShiftCluster.Properties.MaintenanceTask, want)
+		}
+	}
+
+	tombstones, err := openShiftClusters.ListSoftDeleted(ctx, tombstone.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tombstones.OpenShiftClusterDocuments) != 1 || tombstones.OpenShiftClusterDocuments[0].OpenShiftCluster.Properties.MaintenanceTask != "" {
+		t.Error("tombstone was patched")
+	}
+
+	// the default query leaves out tombstones
+	checkpoint, err = openShiftClusters.PatchAll(ctx, nil, mutator, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint.Patched != 3 || !reflect.DeepEqual(checkpoint.Failed, wantFailed) {
+		t.Errorf("unexpected checkpoint %#v", checkpoint)
+	}
+}
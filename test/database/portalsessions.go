@@ -0,0 +1,37 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+func fakePortalSessionsListByClusterResourceIDQuery(client cosmosdb.PortalSessionDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.PortalSessionDocumentRawIterator {
+	input, err := client.ListAll(context.Background(), options)
+	if err != nil {
+		return cosmosdb.NewFakePortalSessionDocumentErroringRawIterator(err)
+	}
+
+	var results []*api.PortalSessionDocument
+	for _, r := range input.PortalSessionDocuments {
+		if r.Key == query.Parameters[0].Value {
+			results = append(results, r)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].PortalSession.CreationTime > results[j].PortalSession.CreationTime
+	})
+
+	return cosmosdb.NewFakePortalSessionDocumentIterator(results, 0)
+}
+
+func injectPortalSessions(c *cosmosdb.FakePortalSessionDocumentClient) {
+	c.SetQueryHandler(database.PortalSessionsListByClusterResourceIDQuery, fakePortalSessionsListByClusterResourceIDQuery)
+}
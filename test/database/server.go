@@ -0,0 +1,690 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+// ServerDocument is the raw JSON representation of a document held by the
+// Server
+type ServerDocument map[string]interface{}
+
+// ServerTriggerHandler mutates a document in place, emulating a CosmosDB
+// pre-trigger
+type ServerTriggerHandler func(doc ServerDocument) error
+
+// ServerQueryHandler emulates a CosmosDB SQL query.  It is passed a copy of
+// all documents in the target collection and partition and the query
+// parameters, and returns the rows to be returned to the client
+type ServerQueryHandler func(docs []ServerDocument, parameters map[string]string) ([]interface{}, error)
+
+// Server is an in-memory fake of the CosmosDB REST API, sufficient to back the
+// generated clients in pkg/database/cosmosdb.  If path is not empty, the state
+// of the server is loaded from and persisted to a file so that it survives
+// restarts during local development.
+type Server struct {
+	log  *logrus.Entry
+	path string
+
+	mu              sync.Mutex
+	state           *serverState
+	triggerHandlers map[string]ServerTriggerHandler
+	queryHandlers   map[string]ServerQueryHandler
+}
+
+type serverState struct {
+	LSN         int                                  `json:"lsn"`
+	Collections map[string]map[string]ServerDocument `json:"collections"`
+	Triggers    map[string]map[string]ServerDocument `json:"triggers"`
+}
+
+// NewServer returns a new Server.  If path is not empty and exists, state is
+// loaded from it
+func NewServer(log *logrus.Entry, path string) (*Server, error) {
+	s := &Server{
+		log:  log,
+		path: path,
+		state: &serverState{
+			Collections: map[string]map[string]ServerDocument{},
+			Triggers:    map[string]map[string]ServerDocument{},
+		},
+		triggerHandlers: map[string]ServerTriggerHandler{},
+		queryHandlers:   map[string]ServerQueryHandler{},
+	}
+
+	if path != "" {
+		b, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			return nil, err
+		default:
+			err = json.Unmarshal(b, s.state)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	injectServer(s)
+
+	return s, nil
+}
+
+// SetTriggerHandler sets or unsets a trigger handler
+func (s *Server) SetTriggerHandler(triggerName string, trigger ServerTriggerHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.triggerHandlers[triggerName] = trigger
+}
+
+// SetQueryHandler sets or unsets a query handler
+func (s *Server) SetQueryHandler(query string, handler ServerQueryHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queryHandlers[query] = handler
+}
+
+// NewTLSServer starts the Server on a local TLS listener and returns a
+// database client connected to it.  The caller must close the returned
+// httptest.Server
+func (s *Server) NewTLSServer() (*httptest.Server, cosmosdb.DatabaseClient) {
+	ts := httptest.NewTLSServer(s)
+
+	hc := ts.Client()
+	hc.Transport.(*http.Transport).TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+
+	return ts, cosmosdb.NewDatabaseClient(s.log, hc, jsonHandle, strings.TrimPrefix(ts.URL, "https://"), nil)
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// dbs/{db}/colls/{coll}/{docs,triggers,pkranges}[/{id}]
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] != "dbs" || parts[2] != "colls" {
+		s.error(w, http.StatusNotFound, "NotFound", "resource not found")
+		return
+	}
+
+	coll := strings.Join(parts[:4], "/")
+	var resource, id string
+	if len(parts) > 4 {
+		resource = parts[4]
+	}
+	if len(parts) > 5 {
+		id = parts[5]
+	}
+
+	var err error
+	switch {
+	case resource == "docs" && id == "" && r.Method == http.MethodPost && r.Header.Get("X-Ms-Documentdb-Isquery") != "":
+		err = s.query(w, r, coll)
+	case resource == "docs" && id == "" && r.Method == http.MethodPost:
+		err = s.createDocument(w, r, coll)
+	case resource == "docs" && id == "" && r.Method == http.MethodGet && r.Header.Get("A-IM") != "":
+		err = s.changeFeed(w, r, coll)
+	case resource == "docs" && id == "" && r.Method == http.MethodGet:
+		err = s.listDocuments(w, r, coll)
+	case resource == "docs" && r.Method == http.MethodGet:
+		err = s.getDocument(w, r, coll, id)
+	case resource == "docs" && r.Method == http.MethodPut:
+		err = s.replaceDocument(w, r, coll, id)
+	case resource == "docs" && r.Method == http.MethodDelete:
+		err = s.deleteDocument(w, r, coll, id)
+	case resource == "triggers" && id == "" && r.Method == http.MethodPost:
+		err = s.createTrigger(w, r, coll)
+	case resource == "pkranges" && r.Method == http.MethodGet:
+		s.reply(w, http.StatusOK, nil, &cosmosdb.PartitionKeyRanges{
+			Count:              1,
+			PartitionKeyRanges: []cosmosdb.PartitionKeyRange{{ID: "0"}},
+		})
+	default:
+		s.error(w, http.StatusNotImplemented, "NotImplemented", fmt.Sprintf("%s %s is not implemented", r.Method, r.URL.Path))
+	}
+
+	if err != nil {
+		s.log.Warn(err)
+		s.error(w, http.StatusInternalServerError, "InternalServerError", err.Error())
+	}
+}
+
+func (s *Server) createDocument(w http.ResponseWriter, r *http.Request, coll string) error {
+	var doc ServerDocument
+	err := json.NewDecoder(r.Body).Decode(&doc)
+	if err != nil {
+		s.error(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return nil
+	}
+
+	id, _ := doc["id"].(string)
+	if id == "" {
+		s.error(w, http.StatusBadRequest, "BadRequest", "The input content is invalid because the required properties - 'id; ' - are missing")
+		return nil
+	}
+
+	if _, exists := s.state.Collections[coll][id]; exists {
+		s.error(w, http.StatusConflict, "Conflict", "Entity with the specified id already exists in the system")
+		return nil
+	}
+
+	err = s.runTriggers(r, doc)
+	if err != nil {
+		return err
+	}
+
+	return s.write(w, http.StatusCreated, coll, id, doc)
+}
+
+func (s *Server) replaceDocument(w http.ResponseWriter, r *http.Request, coll, id string) error {
+	existing, exists := s.state.Collections[coll][id]
+	if !exists {
+		s.error(w, http.StatusNotFound, "NotFound", "Entity with the specified id does not exist in the system")
+		return nil
+	}
+
+	if etag := r.Header.Get("If-Match"); etag != "" && etag != existing["_etag"] {
+		s.error(w, http.StatusPreconditionFailed, "PreconditionFailed", "Operation cannot be performed because one of the specified precondition is not met")
+		return nil
+	}
+
+	var doc ServerDocument
+	err := json.NewDecoder(r.Body).Decode(&doc)
+	if err != nil {
+		s.error(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return nil
+	}
+
+	err = s.runTriggers(r, doc)
+	if err != nil {
+		return err
+	}
+
+	return s.write(w, http.StatusOK, coll, id, doc)
+}
+
+func (s *Server) getDocument(w http.ResponseWriter, r *http.Request, coll, id string) error {
+	doc, exists := s.state.Collections[coll][id]
+	if !exists {
+		s.error(w, http.StatusNotFound, "NotFound", "Entity with the specified id does not exist in the system")
+		return nil
+	}
+
+	s.reply(w, http.StatusOK, http.Header{"Etag": []string{doc["_etag"].(string)}}, doc)
+	return nil
+}
+
+func (s *Server) deleteDocument(w http.ResponseWriter, r *http.Request, coll, id string) error {
+	existing, exists := s.state.Collections[coll][id]
+	if !exists {
+		s.error(w, http.StatusNotFound, "NotFound", "Entity with the specified id does not exist in the system")
+		return nil
+	}
+
+	if etag := r.Header.Get("If-Match"); etag != "" && etag != existing["_etag"] {
+		s.error(w, http.StatusPreconditionFailed, "PreconditionFailed", "Operation cannot be performed because one of the specified precondition is not met")
+		return nil
+	}
+
+	delete(s.state.Collections[coll], id)
+
+	err := s.persist()
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) listDocuments(w http.ResponseWriter, r *http.Request, coll string) error {
+	rows := make([]interface{}, 0, len(s.state.Collections[coll]))
+	for _, doc := range s.sortedDocuments(coll, r.Header.Get("X-Ms-Documentdb-Partitionkey")) {
+		rows = append(rows, doc)
+	}
+
+	return s.page(w, r, rows)
+}
+
+func (s *Server) changeFeed(w http.ResponseWriter, r *http.Request, coll string) error {
+	var since int
+	if continuation := r.Header.Get("If-None-Match"); continuation != "" {
+		var err error
+		since, err = strconv.Atoi(strings.Trim(continuation, `"`))
+		if err != nil {
+			s.error(w, http.StatusBadRequest, "BadRequest", err.Error())
+			return nil
+		}
+	}
+
+	docs := s.sortedDocuments(coll, "")
+	sort.SliceStable(docs, func(i, j int) bool { return lsn(docs[i]) < lsn(docs[j]) })
+
+	rows := []interface{}{}
+	for _, doc := range docs {
+		if lsn(doc) > since {
+			rows = append(rows, doc)
+			since = lsn(doc)
+		}
+	}
+
+	if len(rows) == 0 {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	s.reply(w, http.StatusOK, http.Header{"Etag": []string{strconv.Itoa(since)}}, map[string]interface{}{
+		"_count":    len(rows),
+		"Documents": rows,
+	})
+	return nil
+}
+
+func (s *Server) query(w http.ResponseWriter, r *http.Request, coll string) error {
+	var q cosmosdb.Query
+	err := json.NewDecoder(r.Body).Decode(&q)
+	if err != nil {
+		s.error(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return nil
+	}
+
+	handler, found := s.queryHandlers[q.Query]
+	if !found {
+		s.error(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("query %q is not implemented", q.Query))
+		return nil
+	}
+
+	parameters := map[string]string{}
+	for _, p := range q.Parameters {
+		parameters[p.Name] = p.Value
+	}
+
+	rows, err := handler(s.sortedDocuments(coll, r.Header.Get("X-Ms-Documentdb-Partitionkey")), parameters)
+	if err != nil {
+		return err
+	}
+
+	return s.page(w, r, rows)
+}
+
+func (s *Server) createTrigger(w http.ResponseWriter, r *http.Request, coll string) error {
+	var trigger ServerDocument
+	err := json.NewDecoder(r.Body).Decode(&trigger)
+	if err != nil {
+		s.error(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return nil
+	}
+
+	id, _ := trigger["id"].(string)
+	if _, exists := s.state.Triggers[coll][id]; exists {
+		s.error(w, http.StatusConflict, "Conflict", "Entity with the specified id already exists in the system")
+		return nil
+	}
+
+	if s.state.Triggers[coll] == nil {
+		s.state.Triggers[coll] = map[string]ServerDocument{}
+	}
+	s.state.Triggers[coll][id] = trigger
+
+	err = s.persist()
+	if err != nil {
+		return err
+	}
+
+	s.reply(w, http.StatusCreated, nil, trigger)
+	return nil
+}
+
+// runTriggers runs the pre-triggers requested on r against doc.  Triggers are
+// emulated by Go handlers registered with SetTriggerHandler, keyed by trigger
+// ID
+func (s *Server) runTriggers(r *http.Request, doc ServerDocument) error {
+	header := r.Header.Get("X-Ms-Documentdb-Pre-Trigger-Include")
+	if header == "" {
+		return nil
+	}
+
+	for _, name := range strings.Split(header, ",") {
+		handler, found := s.triggerHandlers[name]
+		if !found {
+			return fmt.Errorf("trigger %q is not implemented", name)
+		}
+
+		err := handler(doc)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) write(w http.ResponseWriter, statusCode int, coll, id string, doc ServerDocument) error {
+	s.state.LSN++
+
+	doc["id"] = id
+	doc["_etag"] = fmt.Sprintf(`"%d"`, s.state.LSN)
+	doc["_lsn"] = s.state.LSN
+	doc["_ts"] = time.Now().Unix()
+	doc["_self"] = coll + "/docs/" + id
+
+	if s.state.Collections[coll] == nil {
+		s.state.Collections[coll] = map[string]ServerDocument{}
+	}
+	s.state.Collections[coll][id] = doc
+
+	err := s.persist()
+	if err != nil {
+		return err
+	}
+
+	s.reply(w, statusCode, http.Header{"Etag": []string{doc["_etag"].(string)}}, doc)
+	return nil
+}
+
+// page writes out rows, honouring X-Ms-Max-Item-Count and X-Ms-Continuation.
+// The continuation token is the index of the next row to return
+func (s *Server) page(w http.ResponseWriter, r *http.Request, rows []interface{}) error {
+	var start int
+	if continuation := r.Header.Get("X-Ms-Continuation"); continuation != "" {
+		var err error
+		start, err = strconv.Atoi(continuation)
+		if err != nil || start > len(rows) {
+			s.error(w, http.StatusBadRequest, "BadRequest", "invalid continuation token")
+			return nil
+		}
+	}
+
+	end := len(rows)
+	if maxItemCount, err := strconv.Atoi(r.Header.Get("X-Ms-Max-Item-Count")); err == nil && maxItemCount > 0 && start+maxItemCount < end {
+		end = start + maxItemCount
+	}
+
+	header := http.Header{}
+	if end < len(rows) {
+		header.Set("X-Ms-Continuation", strconv.Itoa(end))
+	}
+
+	s.reply(w, http.StatusOK, header, map[string]interface{}{
+		"_count":    end - start,
+		"Documents": rows[start:end],
+	})
+	return nil
+}
+
+// sortedDocuments returns copies of the documents in coll, filtered by the
+// JSON-encoded partition key if it is set, ordered by ID for test stability
+func (s *Server) sortedDocuments(coll, partitionKey string) []ServerDocument {
+	var key string
+	if partitionKey != "" {
+		var keys []string
+		if json.Unmarshal([]byte(partitionKey), &keys) == nil && len(keys) == 1 {
+			key = keys[0]
+		}
+	}
+
+	docs := make([]ServerDocument, 0, len(s.state.Collections[coll]))
+	for _, doc := range s.state.Collections[coll] {
+		if key != "" && doc["partitionKey"] != nil && doc["partitionKey"] != key {
+			continue
+		}
+
+		docs = append(docs, copyDocument(doc))
+	}
+
+	sort.Slice(docs, func(i, j int) bool { return docs[i]["id"].(string) < docs[j]["id"].(string) })
+
+	return docs
+}
+
+// persist writes the server state to s.path, if set.  It writes to a
+// temporary file first so that a crash never leaves a truncated state file
+func (s *Server) persist() error {
+	if s.path == "" {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(b)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *Server) reply(w http.ResponseWriter, statusCode int, header http.Header, body interface{}) {
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		s.log.Warn(err)
+	}
+}
+
+func (s *Server) error(w http.ResponseWriter, statusCode int, code, message string) {
+	s.reply(w, statusCode, nil, &cosmosdb.Error{Code: code, Message: message})
+}
+
+func copyDocument(doc ServerDocument) ServerDocument {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+
+	var c ServerDocument
+	err = json.Unmarshal(b, &c)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func lsn(doc ServerDocument) int {
+	switch v := doc["_lsn"].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// field returns the string value at the dotted path in doc, or "" if it is
+// not present
+func field(doc ServerDocument, path string) string {
+	var v interface{} = map[string]interface{}(doc)
+	for _, p := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		v = m[p]
+	}
+
+	s, _ := v.(string)
+	return s
+}
+
+func serverRenewLeaseTrigger(doc ServerDocument) error {
+	doc["leaseExpires"] = time.Now().Unix() + 60
+	return nil
+}
+
+func serverRetryLaterTrigger(doc ServerDocument) error {
+	doc["leaseExpires"] = time.Now().Unix() + 600
+	return nil
+}
+
+func serverBillingTimestampTrigger(name string) ServerTriggerHandler {
+	return func(doc ServerDocument) error {
+		billing, ok := doc["billing"].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if v, _ := billing[name].(float64); v == 0 {
+			billing[name] = time.Now().Unix()
+		}
+		return nil
+	}
+}
+
+func serverMatchQuery(path, parameter string) ServerQueryHandler {
+	return func(docs []ServerDocument, parameters map[string]string) (rows []interface{}, err error) {
+		for _, doc := range docs {
+			if field(doc, path) == parameters[parameter] {
+				rows = append(rows, doc)
+			}
+		}
+		return rows, nil
+	}
+}
+
+func serverPrefixQuery(path, parameter string) ServerQueryHandler {
+	return func(docs []ServerDocument, parameters map[string]string) (rows []interface{}, err error) {
+		for _, doc := range docs {
+			if strings.HasPrefix(field(doc, path), parameters[parameter]) {
+				rows = append(rows, doc)
+			}
+		}
+		return rows, nil
+	}
+}
+
+func serverLeaseExpired(doc ServerDocument) bool {
+	leaseExpires, _ := doc["leaseExpires"].(float64)
+	return int64(leaseExpires) < time.Now().Unix()
+}
+
+func serverQueuedOpenShiftClusters(docs []ServerDocument) (rows []interface{}) {
+	for _, doc := range docs {
+		if _, ok := doc["softDeleted"]; ok {
+			continue
+		}
+
+		switch field(doc, "openShiftCluster.properties.provisioningState") {
+		case "Creating", "Deleting", "Updating", "AdminUpdating":
+			if serverLeaseExpired(doc) {
+				rows = append(rows, doc)
+			}
+		}
+	}
+	return rows
+}
+
+func injectServer(s *Server) {
+	s.triggerHandlers["renewLease"] = serverRenewLeaseTrigger
+	s.triggerHandlers["retryLater"] = serverRetryLaterTrigger
+	s.triggerHandlers["setCreationBillingTimeStamp"] = serverBillingTimestampTrigger("creationTime")
+	s.triggerHandlers["setDeletionBillingTimeStamp"] = serverBillingTimestampTrigger("deletionTime")
+
+	s.queryHandlers[database.OpenShiftClustersGetQuery] = serverMatchQuery("key", "@key")
+	s.queryHandlers[database.OpenshiftClustersClientIdQuery] = serverMatchQuery("clientIdKey", "@clientID")
+	s.queryHandlers[database.OpenshiftClustersResourceGroupQuery] = serverMatchQuery("clusterResourceGroupIdKey", "@resourceGroupID")
+	s.queryHandlers[database.OpenshiftClustersPrefixQuery] = serverPrefixQuery("key", "@prefix")
+	s.queryHandlers[database.OpenShiftClustersSoftDeletedQuery] = serverMatchQuery("softDeleted.key", "@key")
+	s.queryHandlers[database.ClusterManagerConfigurationsGetQuery] = serverMatchQuery("key", "@key")
+	s.queryHandlers[database.GatewayGetByClusterResourceIDQuery] = serverMatchQuery("key", "@key")
+
+	s.queryHandlers[database.OpenShiftClustersDequeueQuery] = func(docs []ServerDocument, parameters map[string]string) ([]interface{}, error) {
+		return serverQueuedOpenShiftClusters(docs), nil
+	}
+	s.queryHandlers[database.OpenShiftClustersQueueLengthQuery] = func(docs []ServerDocument, parameters map[string]string) ([]interface{}, error) {
+		return []interface{}{len(serverQueuedOpenShiftClusters(docs))}, nil
+	}
+	s.queryHandlers[database.PortalSessionsListByClusterResourceIDQuery] = func(docs []ServerDocument, parameters map[string]string) ([]interface{}, error) {
+		rows, err := serverMatchQuery("key", "@key")(docs, parameters)
+		sort.SliceStable(rows, func(i, j int) bool {
+			ti, _ := rows[i].(ServerDocument)["portalSession"].(map[string]interface{})["creationTime"].(float64)
+			tj, _ := rows[j].(ServerDocument)["portalSession"].(map[string]interface{})["creationTime"].(float64)
+			return ti > tj
+		})
+		return rows, err
+	}
+	s.queryHandlers[database.OpenShiftClustersExpiredQuery] = func(docs []ServerDocument, parameters map[string]string) (rows []interface{}, err error) {
+		retention, err := strconv.ParseInt(parameters["@retention"], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range docs {
+			softDeleted, ok := doc["softDeleted"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			deletionTime, _ := softDeleted["deletionTime"].(float64)
+			if int64(deletionTime) < time.Now().Unix()-retention {
+				rows = append(rows, doc)
+			}
+		}
+		return rows, nil
+	}
+	s.queryHandlers[database.OpenShiftClustersNotSoftDeletedQuery] = func(docs []ServerDocument, parameters map[string]string) (rows []interface{}, err error) {
+		for _, doc := range docs {
+			if _, ok := doc["softDeleted"]; !ok {
+				rows = append(rows, doc)
+			}
+		}
+		return rows, nil
+	}
+	s.queryHandlers[database.MonitorsListQuery] = func(docs []ServerDocument, parameters map[string]string) (rows []interface{}, err error) {
+		for _, doc := range docs {
+			if _, ok := doc["monitor"]; ok && doc["id"] != "master" {
+				rows = append(rows, doc)
+			}
+		}
+		return rows, nil
+	}
+	s.queryHandlers[database.SubscriptionsDequeueQuery] = func(docs []ServerDocument, parameters map[string]string) (rows []interface{}, err error) {
+		for _, doc := range docs {
+			if deleting, _ := doc["deleting"].(bool); deleting && serverLeaseExpired(doc) {
+				rows = append(rows, doc)
+			}
+		}
+		return rows, nil
+	}
+}
@@ -0,0 +1,126 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	log := logrus.NewEntry(logrus.StandardLogger())
+	path := filepath.Join(t.TempDir(), "cosmosdb.json")
+
+	const key = "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/cluster"
+
+	s, err := NewServer(log, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts, dbc := s.NewTLSServer()
+
+	openShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, "ARO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := openShiftClusters.Create(ctx, &api.OpenShiftClusterDocument{
+		ID:  openShiftClusters.NewUUID(),
+		Key: key,
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateCreating,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.ETag == "" {
+		t.Error("expected ETag to be set")
+	}
+
+	_, err = openShiftClusters.Create(ctx, &api.OpenShiftClusterDocument{
+		Key: key + "-noid",
+	})
+	if !cosmosdb.IsErrorStatusCode(err, http.StatusBadRequest) {
+		t.Errorf("expected bad request for document without id, got %v", err)
+	}
+
+	n, err := openShiftClusters.QueueLength(ctx, "OpenShiftClusters")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("expected queue length 1, got %d", n)
+	}
+
+	doc, err = openShiftClusters.Dequeue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc == nil || doc.LeaseExpires == 0 {
+		t.Fatalf("expected leased document, got %v", doc)
+	}
+
+	stale := *doc
+	stale.ETag = `"0"`
+	c := cosmosdb.NewOpenShiftClusterDocumentClient(cosmosdb.NewCollectionClient(dbc, "ARO"), "OpenShiftClusters")
+	_, err = c.Replace(ctx, stale.PartitionKey, &stale, &cosmosdb.Options{})
+	if !cosmosdb.IsErrorStatusCode(err, http.StatusPreconditionFailed) {
+		t.Errorf("expected precondition failed, got %v", err)
+	}
+
+	docs, err := openShiftClusters.ChangeFeed().Next(ctx, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if docs == nil || len(docs.OpenShiftClusterDocuments) != 1 {
+		t.Errorf("expected 1 document in change feed, got %v", docs)
+	}
+
+	ts.Close()
+
+	// restart the server from the persisted state
+	s, err = NewServer(log, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts, dbc = s.NewTLSServer()
+	defer ts.Close()
+
+	openShiftClusters, err = database.NewOpenShiftClusters(ctx, dbc, "ARO")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err = openShiftClusters.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenShiftCluster.Properties.ProvisioningState != api.ProvisioningStateCreating {
+		t.Error(doc.OpenShiftCluster.Properties.ProvisioningState)
+	}
+
+	err = openShiftClusters.Delete(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = openShiftClusters.Get(ctx, key)
+	if !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
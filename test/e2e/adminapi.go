@@ -28,7 +28,7 @@ func adminRequest(ctx context.Context, method, path string, params url.Values, s
 		params = url.Values{}
 	}
 
-	params.Add("api-version", admin.APIVersion)
+	params.Add("api-version", admin.LatestAPIVersion)
 
 	adminAPIBaseURI := "https://localhost:8443"
 	adminURL, err := url.Parse(adminAPIBaseURI + path)
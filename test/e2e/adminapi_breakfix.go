@@ -0,0 +1,225 @@
+package e2e
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/pullsecret"
+	"github.com/Azure/ARO-RP/pkg/util/ready"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+const (
+	// breakFixLabel labels the specs which break a cluster the way customers
+	// do and repair it with the admin action that SRE runbooks prescribe
+	breakFixLabel = "breakfix"
+
+	// adminUpdateTimeout is the time within which an admin update must
+	// complete
+	adminUpdateTimeout = 30 * time.Minute
+)
+
+// adminUpdateCluster runs an admin update of the cluster and waits for it to
+// succeed
+func adminUpdateCluster(ctx context.Context) {
+	GinkgoHelper()
+
+	adminPatchCluster(ctx, json.RawMessage("{}"))
+}
+
+// adminPatchCluster patches the cluster via the admin API, which runs an admin
+// update, and waits for the update to succeed
+func adminPatchCluster(ctx context.Context, patch interface{}) {
+	GinkgoHelper()
+
+	By("triggering the update via RP admin API")
+	oc := &admin.OpenShiftCluster{}
+	resp, err := adminRequest(ctx, http.MethodPatch, clusterResourceID, nil, true, patch, oc)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+	By("waiting for the update to complete")
+	Eventually(func(g Gomega, ctx context.Context) {
+		oc = adminGetCluster(g, ctx, clusterResourceID)
+		g.Expect(oc.Properties.ProvisioningState).To(Equal(admin.ProvisioningStateSucceeded))
+	}).WithContext(ctx).WithTimeout(adminUpdateTimeout).WithPolling(time.Minute).Should(Succeed())
+
+	Expect(oc.Properties.LastAdminUpdateError).To(Equal(""))
+}
+
+// setSecretDockerConfigJSON sets the docker config JSON of a secret, retrying
+// on conflict
+func setSecretDockerConfigJSON(ctx context.Context, namespace, name string, data []byte) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		s, err := clients.Kubernetes.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if s.Data == nil {
+			s.Data = map[string][]byte{}
+		}
+		s.Data[corev1.DockerConfigJsonKey] = data
+
+		_, err = clients.Kubernetes.CoreV1().Secrets(namespace).Update(ctx, s, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+var _ = Describe("[Admin API] Break/fix", Label(breakFixLabel), Serial, func() {
+	BeforeEach(skipIfNotInDevelopmentEnv)
+
+	It("must re-attach a detached NSG on admin update", func(ctx context.Context) {
+		repaired := detachedWorkerSubnetNSG(ctx)
+
+		adminUpdateCluster(ctx)
+
+		By("checking that the NSG is attached to the worker subnet again")
+		Eventually(repaired).WithContext(ctx).WithTimeout(time.Minute).WithPolling(10 * time.Second).Should(Succeed())
+	})
+
+	It("must start a stopped master VM on the startvm action", func(ctx context.Context) {
+		By("getting the resource group where the VM instances live in")
+		oc, err := clients.OpenshiftClusters.Get(ctx, vnetResourceGroup, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		clusterResourceGroup := stringutils.LastTokenByte(*oc.OpenShiftClusterProperties.ClusterProfile.ResourceGroupID, '/')
+
+		By("picking a master VM to stop")
+		vms, err := clients.VirtualMachines.List(ctx, clusterResourceGroup)
+		Expect(err).NotTo(HaveOccurred())
+
+		var vmName string
+		for _, vm := range vms {
+			if strings.Contains(*vm.Name, "-master-") {
+				vmName = *vm.Name
+				break
+			}
+		}
+		Expect(vmName).NotTo(BeEmpty())
+		log.Infof("selected vm: %s", vmName)
+
+		DeferCleanup(func(ctx context.Context) {
+			vm, err := clients.VirtualMachines.Get(ctx, clusterResourceGroup, vmName, mgmtcompute.InstanceView)
+			Expect(err).NotTo(HaveOccurred())
+
+			if vm.InstanceView == nil || vm.InstanceView.Statuses == nil {
+				return
+			}
+			for _, status := range *vm.InstanceView.Statuses {
+				if status.Code != nil && *status.Code == "PowerState/running" {
+					return
+				}
+			}
+
+			By("starting the VM which the spec left stopped")
+			err = clients.VirtualMachines.StartAndWait(ctx, clusterResourceGroup, vmName)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		By("stopping the VM via Azure")
+		err = clients.VirtualMachines.StopAndWait(ctx, clusterResourceGroup, vmName, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("waiting for the node to become NotReady in OpenShift")
+		Eventually(func(g Gomega, ctx context.Context) {
+			getFunc := clients.Kubernetes.CoreV1().Nodes().Get
+			node := GetK8sObjectWithRetry(ctx, getFunc, vmName, metav1.GetOptions{})
+
+			g.Expect(ready.NodeIsReady(node)).To(BeFalse())
+		}).WithContext(ctx).WithTimeout(10 * time.Minute).WithPolling(30 * time.Second).Should(Succeed())
+
+		By("starting the VM via RP admin API")
+		resp, err := adminRequest(ctx, http.MethodPost, "/admin"+clusterResourceID+"/startvm", url.Values{"vmName": []string{vmName}}, true, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		By("waiting for the VM to report Running power state in Azure")
+		Eventually(func(g Gomega, ctx context.Context) {
+			vm, err := clients.VirtualMachines.Get(ctx, clusterResourceGroup, vmName, mgmtcompute.InstanceView)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			g.Expect(*vm.InstanceView.Statuses).To(ContainElement(HaveField("Code", HaveValue(Equal("PowerState/running")))))
+		}).WithContext(ctx).WithTimeout(10 * time.Minute).WithPolling(time.Minute).Should(Succeed())
+
+		By("waiting for the node to become Ready in OpenShift")
+		Eventually(func(g Gomega, ctx context.Context) {
+			getFunc := clients.Kubernetes.CoreV1().Nodes().Get
+			node := GetK8sObjectWithRetry(ctx, getFunc, vmName, metav1.GetOptions{})
+
+			g.Expect(ready.NodeIsReady(node)).To(BeTrue())
+		}).WithContext(ctx).WithTimeout(10 * time.Minute).WithPolling(time.Minute).Should(Succeed())
+	})
+
+	It("must restore the ARO pull secret on admin update", func(ctx context.Context) {
+		co, err := clients.AROClusters.AroV1alpha1().Clusters().Get(ctx, arov1alpha1.SingletonClusterName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		if !co.Spec.OperatorFlags.GetSimpleBoolean(operator.PullSecretEnabled) || !co.Spec.OperatorFlags.GetSimpleBoolean(operator.PullSecretManaged) {
+			Skip("PullSecret Controller is not managing the pull secret, skipping test")
+		}
+
+		By("saving the operator's and the global pull secrets")
+		operatorSecret, err := clients.Kubernetes.CoreV1().Secrets(operator.Namespace).Get(ctx, operator.SecretName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		operatorPullSecret := operatorSecret.Data[corev1.DockerConfigJsonKey]
+
+		aroAuths, err := pullsecret.UnmarshalSecretData(operatorSecret)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(aroAuths).NotTo(BeEmpty())
+
+		globalSecret, err := clients.Kubernetes.CoreV1().Secrets("openshift-config").Get(ctx, "pull-secret", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		globalPullSecret := globalSecret.Data[corev1.DockerConfigJsonKey]
+
+		DeferCleanup(func(ctx context.Context) {
+			By("restoring the pull secrets")
+			err := setSecretDockerConfigJSON(ctx, operator.Namespace, operator.SecretName, operatorPullSecret)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = setSecretDockerConfigJSON(ctx, "openshift-config", "pull-secret", globalPullSecret)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		// the operator repairs the global pull secret from its own copy, so
+		// both are corrupted to leave the admin update as the only fix
+		By("corrupting the operator's and the global pull secrets")
+		err = setSecretDockerConfigJSON(ctx, operator.Namespace, operator.SecretName, []byte(`{"auths":{}}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		err = setSecretDockerConfigJSON(ctx, "openshift-config", "pull-secret", []byte(`{"auths":{}}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		adminUpdateCluster(ctx)
+
+		By("checking that the global pull secret contains the ARO registries again")
+		Eventually(func(g Gomega, ctx context.Context) {
+			s, err := clients.Kubernetes.CoreV1().Secrets("openshift-config").Get(ctx, "pull-secret", metav1.GetOptions{})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			auths, err := pullsecret.UnmarshalSecretData(s)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for registry := range aroAuths {
+				g.Expect(auths).To(HaveKey(registry))
+			}
+		}).WithContext(ctx).WithTimeout(5 * time.Minute).WithPolling(10 * time.Second).Should(Succeed())
+	})
+})
@@ -0,0 +1,268 @@
+package e2e
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/onsi/ginkgo/v2/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// recentEventsWindow is how far back events are collected from when a spec
+// fails
+const recentEventsWindow = 30 * time.Minute
+
+// artifactsReportEntry names the report entry recording the directory in
+// which the artifacts of a failed spec were collected
+const artifactsReportEntry = "artifacts"
+
+var (
+	artifactsDir = flag.String("e2e.artifacts-dir", "e2e-artifacts", "directory in which to collect artifacts of failed specs")
+
+	// artifactsRunDir is the timestamped directory, under artifactsDir, in
+	// which the artifacts of this run are collected
+	artifactsRunDir string
+)
+
+// artifactCollector writes artifacts describing the state of the cluster to
+// dir
+type artifactCollector func(ctx context.Context, dir string) error
+
+// artifactCollectors are run, in name order, whenever a spec fails
+var artifactCollectors = map[string]artifactCollector{
+	"clusteroperators": collectClusterOperators,
+	"arocluster":       collectAROCluster,
+	"operatorlogs":     collectOperatorLogs,
+	"events":           collectRecentEvents,
+	"azureresources":   collectAzureResources,
+}
+
+func initArtifacts(now time.Time) {
+	artifactsRunDir = filepath.Join(*artifactsDir, now.UTC().Format("20060102T150405Z"))
+}
+
+// specArtifactsDir returns the directory in which the artifacts of the spec
+// of report are collected
+func specArtifactsDir(report SpecReport) string {
+	name := disallowedInFilenameRegex.ReplaceAllString(strings.ReplaceAll(report.FullText(), " ", "_"), "_")
+	if len(name) > 80 {
+		name = name[:80]
+	}
+
+	return filepath.Join(artifactsRunDir, fmt.Sprintf("%s-%s-attempt%d", report.StartTime.UTC().Format("150405"), name, report.NumAttempts))
+}
+
+// collectArtifacts runs all the artifact collectors for the failed spec of
+// report.  Collection is best effort: collector failures are logged and do not
+// affect the outcome of the spec.
+func collectArtifacts(ctx context.Context, report SpecReport) {
+	dir := specArtifactsDir(report)
+
+	err := os.MkdirAll(dir, 0777)
+	if err != nil {
+		log.Errorf("creating artifacts directory: %v", err)
+		return
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "failure.txt"), []byte(report.FailureMessage()+"\n\n"+report.FailureLocation().String()+"\n"), 0666)
+	if err != nil {
+		log.Errorf("writing failure: %v", err)
+	}
+
+	names := make([]string, 0, len(artifactCollectors))
+	for name := range artifactCollectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		err := artifactCollectors[name](ctx, dir)
+		if err != nil {
+			log.Errorf("collecting %s artifacts: %v", name, err)
+		}
+	}
+
+	AddReportEntry(artifactsReportEntry, dir)
+	log.Infof("artifacts of failed spec saved to %s", dir)
+}
+
+func writeJSONArtifact(dir, name string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, name), b, 0666)
+}
+
+func collectClusterOperators(ctx context.Context, dir string) error {
+	cos, err := clients.ConfigClient.ConfigV1().ClusterOperators().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	return writeJSONArtifact(dir, "clusteroperators.json", cos)
+}
+
+func collectAROCluster(ctx context.Context, dir string) error {
+	co, err := clients.AROClusters.AroV1alpha1().Clusters().Get(ctx, arov1alpha1.SingletonClusterName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	return writeJSONArtifact(dir, "arocluster.json", co)
+}
+
+func collectOperatorLogs(ctx context.Context, dir string) error {
+	pods, err := clients.Kubernetes.CoreV1().Pods(operator.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	logsDir := filepath.Join(dir, "operatorlogs")
+	err = os.MkdirAll(logsDir, 0777)
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			b, err := clients.Kubernetes.CoreV1().Pods(operator.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: container.Name}).DoRaw(ctx)
+			if err != nil {
+				log.Errorf("getting logs of %s/%s: %v", pod.Name, container.Name, err)
+				continue
+			}
+
+			err = os.WriteFile(filepath.Join(logsDir, pod.Name+"-"+container.Name+".log"), b, 0666)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func collectRecentEvents(ctx context.Context, dir string) error {
+	events, err := clients.Kubernetes.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	since := time.Now().Add(-recentEventsWindow)
+
+	var recent []corev1.Event
+	for _, event := range events.Items {
+		if eventTime(&event).After(since) {
+			recent = append(recent, event)
+		}
+	}
+
+	sort.Slice(recent, func(i, j int) bool {
+		return eventTime(&recent[i]).Before(eventTime(&recent[j]))
+	})
+
+	return writeJSONArtifact(dir, "events.json", recent)
+}
+
+// eventTime returns the time at which event last occurred
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func collectAzureResources(ctx context.Context, dir string) error {
+	oc, err := clients.OpenshiftClusters.Get(ctx, vnetResourceGroup, clusterName)
+	if err != nil {
+		return err
+	}
+
+	err = writeJSONArtifact(dir, "openshiftcluster.json", oc)
+	if err != nil {
+		return err
+	}
+
+	if oc.OpenShiftClusterProperties == nil || oc.ClusterProfile == nil || oc.ClusterProfile.ResourceGroupID == nil {
+		return nil
+	}
+	clusterResourceGroup := stringutils.LastTokenByte(*oc.ClusterProfile.ResourceGroupID, '/')
+
+	vms, err := clients.VirtualMachines.List(ctx, clusterResourceGroup)
+	if err != nil {
+		return err
+	}
+
+	err = writeJSONArtifact(dir, "virtualmachines.json", vms)
+	if err != nil {
+		return err
+	}
+
+	resources, err := clients.Resources.ListByResourceGroup(ctx, clusterResourceGroup, "", "", nil)
+	if err != nil {
+		return err
+	}
+
+	return writeJSONArtifact(dir, "resources.json", resources)
+}
+
+var _ = JustAfterEach(func(ctx context.Context) {
+	report := CurrentSpecReport()
+	if !report.Failed() || clients == nil {
+		return
+	}
+
+	collectArtifacts(ctx, report)
+}, NodeTimeout(5*time.Minute))
+
+var _ = ReportAfterSuite("artifacts", func(report Report) {
+	var failed []string
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt || !spec.Failed() {
+			continue
+		}
+
+		for _, entry := range spec.ReportEntries {
+			if entry.Name == artifactsReportEntry {
+				failed = append(failed, fmt.Sprintf("%s: %s", spec.FullText(), entry.StringRepresentation()))
+			}
+		}
+	}
+
+	if failed == nil {
+		return
+	}
+
+	err := os.MkdirAll(artifactsRunDir, 0777)
+	if err != nil {
+		log.Errorf("creating artifacts directory: %v", err)
+		return
+	}
+
+	err = os.WriteFile(filepath.Join(artifactsRunDir, "failures.txt"), []byte(strings.Join(failed, "\n")+"\n"), 0666)
+	if err != nil {
+		log.Errorf("writing failures: %v", err)
+	}
+})
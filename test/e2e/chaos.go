@@ -0,0 +1,225 @@
+package e2e
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/azure"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers"
+	imageController "github.com/Azure/ARO-RP/pkg/operator/controllers/imageconfig"
+)
+
+const (
+	// chaosLabel labels the specs which inject faults into the resources the
+	// operator manages
+	chaosLabel = "chaos"
+
+	// chaosReconcileSLA is the time within which the operator must repair an
+	// injected fault
+	chaosReconcileSLA = 5 * time.Minute
+
+	// chaosSpecTimeout allows for injecting the fault and cleaning up after it
+	chaosSpecTimeout = chaosReconcileSLA + 5*time.Minute
+)
+
+// chaosExcludedKinds are never deleted, because deleting them deletes
+// everything in them too
+var chaosExcludedKinds = map[string]bool{
+	"Namespace":                true,
+	"CustomResourceDefinition": true,
+}
+
+// chaosFault injects a fault into the cluster and returns a function which
+// succeeds once the operator has repaired it
+type chaosFault func(ctx context.Context) func(g Gomega, ctx context.Context)
+
+func newUnstructured(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	o := &unstructured.Unstructured{}
+	o.SetAPIVersion(apiVersion)
+	o.SetKind(kind)
+	o.SetNamespace(namespace)
+	o.SetName(name)
+
+	return o
+}
+
+// deletion returns a fault which deletes the resource identified by want and
+// which is repaired when the resource is recreated
+func deletion(want *unstructured.Unstructured) chaosFault {
+	return func(ctx context.Context) func(g Gomega, ctx context.Context) {
+		o := want.DeepCopy()
+		key := client.ObjectKeyFromObject(o)
+
+		err := clients.Client.Get(ctx, key, o)
+		if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			Skip(fmt.Sprintf("%s %s is not deployed on this cluster", want.GetKind(), key))
+		}
+		Expect(err).NotTo(HaveOccurred())
+		uid := o.GetUID()
+
+		By(fmt.Sprintf("deleting %s %s", o.GetKind(), key))
+		err = clients.Client.Delete(ctx, o)
+		Expect(err).To(SatisfyAny(
+			Not(HaveOccurred()),
+			MatchError(kerrors.IsNotFound),
+		))
+
+		return func(g Gomega, ctx context.Context) {
+			err := clients.Client.Get(ctx, key, o)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(o.GetUID()).NotTo(Equal(uid))
+		}
+	}
+}
+
+// managedResourceEntries returns an entry deleting each resource which the
+// operator's controllers deploy from their manifests
+func managedResourceEntries() []TableEntry {
+	resources, err := controllers.ManagedResources()
+	if err != nil {
+		panic(err)
+	}
+
+	var entries []TableEntry
+	for _, r := range resources {
+		// resources whose name is templated cannot be identified
+		if chaosExcludedKinds[r.Object.GetKind()] || r.Object.GetName() == "" {
+			continue
+		}
+
+		entries = append(entries, Entry(fmt.Sprintf("deleting %s %s %s", r.Controller, r.Object.GetKind(), client.ObjectKeyFromObject(r.Object)), deletion(r.Object), SpecTimeout(chaosSpecTimeout)))
+	}
+
+	return entries
+}
+
+// blockedServiceRegistry blocks one of the ARO service registries in the
+// Image config
+func blockedServiceRegistry(ctx context.Context) func(g Gomega, ctx context.Context) {
+	instance, err := clients.AROClusters.AroV1alpha1().Clusters().Get(ctx, arov1alpha1.SingletonClusterName, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(operator.ImageConfigEnabled) {
+		Skip("ImageConfig Controller is not enabled, skipping test")
+	}
+
+	requiredRegistries, err := imageController.GetCloudAwareRegistries(instance)
+	Expect(err).NotTo(HaveOccurred())
+	registry := requiredRegistries[0]
+
+	var original []string
+	setBlockedRegistries := func(ctx context.Context, blocked func([]string) []string) error {
+		return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			imageConfig, err := clients.ConfigClient.ConfigV1().Images().Get(ctx, "cluster", metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			imageConfig.Spec.RegistrySources.BlockedRegistries = blocked(imageConfig.Spec.RegistrySources.BlockedRegistries)
+			_, err = clients.ConfigClient.ConfigV1().Images().Update(ctx, imageConfig, metav1.UpdateOptions{})
+			return err
+		})
+	}
+
+	By(fmt.Sprintf("blocking the ARO service registry %s", registry))
+	err = setBlockedRegistries(ctx, func(blocked []string) []string {
+		original = blocked
+		return append(append([]string{}, blocked...), registry)
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	DeferCleanup(func(ctx context.Context) {
+		By("restoring the blocked registries")
+		err := setBlockedRegistries(ctx, func([]string) []string { return original })
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	return func(g Gomega, ctx context.Context) {
+		imageConfig, err := clients.ConfigClient.ConfigV1().Images().Get(ctx, "cluster", metav1.GetOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(imageConfig.Spec.RegistrySources.BlockedRegistries).NotTo(ContainElement(registry))
+	}
+}
+
+// detachedWorkerSubnetNSG detaches the cluster NSG from the worker subnet
+func detachedWorkerSubnetNSG(ctx context.Context) func(g Gomega, ctx context.Context) {
+	co, err := clients.AROClusters.AroV1alpha1().Clusters().Get(ctx, arov1alpha1.SingletonClusterName, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+
+	if co.Spec.OperatorFlags[operator.AzureSubnetsNsgManaged] == operator.FlagFalse {
+		Skip("preconfiguredNSG is enabled, skipping test")
+	}
+
+	oc, err := clients.OpenshiftClusters.Get(ctx, vnetResourceGroup, clusterName)
+	Expect(err).NotTo(HaveOccurred())
+
+	vnetID, subnetName, err := apisubnet.Split(*(*oc.OpenShiftClusterProperties.WorkerProfiles)[0].SubnetID)
+	Expect(err).NotTo(HaveOccurred())
+
+	r, err := azure.ParseResourceID(vnetID)
+	Expect(err).NotTo(HaveOccurred())
+
+	subnet, err := clients.Subnet.Get(ctx, r.ResourceGroup, r.ResourceName, subnetName, "")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(subnet.NetworkSecurityGroup).NotTo(BeNil())
+	nsgID := *subnet.NetworkSecurityGroup.ID
+
+	DeferCleanup(func(ctx context.Context) {
+		subnet, err := clients.Subnet.Get(ctx, r.ResourceGroup, r.ResourceName, subnetName, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		if subnet.NetworkSecurityGroup == nil || !strings.EqualFold(*subnet.NetworkSecurityGroup.ID, nsgID) {
+			By("restoring the worker subnet NSG")
+			subnet.NetworkSecurityGroup = &mgmtnetwork.SecurityGroup{ID: &nsgID}
+			err = clients.Subnet.CreateOrUpdateAndWait(ctx, r.ResourceGroup, r.ResourceName, subnetName, subnet)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	By(fmt.Sprintf("detaching the NSG from subnet %q", subnetName))
+	subnet.NetworkSecurityGroup = nil
+	err = clients.Subnet.CreateOrUpdateAndWait(ctx, r.ResourceGroup, r.ResourceName, subnetName, subnet)
+	Expect(err).NotTo(HaveOccurred())
+
+	return func(g Gomega, ctx context.Context) {
+		subnet, err := clients.Subnet.Get(ctx, r.ResourceGroup, r.ResourceName, subnetName, "")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(subnet.NetworkSecurityGroup).NotTo(BeNil())
+		g.Expect(strings.EqualFold(*subnet.NetworkSecurityGroup.ID, nsgID)).To(BeTrue())
+	}
+}
+
+var _ = Describe("ARO Operator - Chaos", Label(chaosLabel), func() {
+	DescribeTable("must repair the resources it manages",
+		func(ctx context.Context, fault chaosFault) {
+			repaired := fault(ctx)
+
+			By("waiting for the operator to repair the fault")
+			Eventually(repaired).WithContext(ctx).WithTimeout(chaosReconcileSLA).WithPolling(10 * time.Second).Should(Succeed())
+		},
+		managedResourceEntries(),
+		Entry("deleting the master dnsmasq MachineConfig", chaosFault(deletion(newUnstructured("machineconfiguration.openshift.io/v1", "MachineConfig", "", "99-master-aro-dns"))), SpecTimeout(chaosSpecTimeout)),
+		Entry("deleting the worker dnsmasq MachineConfig", chaosFault(deletion(newUnstructured("machineconfiguration.openshift.io/v1", "MachineConfig", "", "99-worker-aro-dns"))), SpecTimeout(chaosSpecTimeout)),
+		Entry("deleting the geneva certificates Secret", chaosFault(deletion(newUnstructured("v1", "Secret", "openshift-azure-logging", "certificates"))), SpecTimeout(chaosSpecTimeout)),
+		Entry("blocking an ARO service registry in the Image config", chaosFault(blockedServiceRegistry), SpecTimeout(chaosSpecTimeout)),
+		Entry("detaching the NSG from the worker subnet", chaosFault(detachedWorkerSubnetNSG), SpecTimeout(chaosSpecTimeout)),
+	)
+})
@@ -68,10 +68,8 @@ var _ = Describe("Cluster", Serial, func() {
 
 			By("verifying the stateful set is ready")
 			Eventually(func(g Gomega, ctx context.Context) {
-				s, err := clients.Kubernetes.AppsV1().StatefulSets(project.Name).Get(ctx, ssName, metav1.GetOptions{})
-				g.Expect(err).NotTo(HaveOccurred())
-				g.Expect(ready.StatefulSetIsReady(s)).To(BeTrue(), "expect stateful to be ready")
-				GinkgoWriter.Println(s)
+				g.Expect(ready.CheckStatefulSetIsReady(ctx, clients.Kubernetes.AppsV1().StatefulSets(project.Name), ssName)()).
+					To(BeTrue(), "expect stateful to be ready")
 			}).WithContext(ctx).WithTimeout(DefaultEventuallyTimeout).Should(Succeed())
 		})
 
@@ -173,9 +171,8 @@ var _ = Describe("Cluster", Serial, func() {
 
 			By("verifying the stateful set is ready")
 			Eventually(func(g Gomega, ctx context.Context) {
-				s, err := clients.Kubernetes.AppsV1().StatefulSets(project.Name).Get(ctx, ssName, metav1.GetOptions{})
-				g.Expect(err).NotTo(HaveOccurred())
-				g.Expect(ready.StatefulSetIsReady(s)).To(BeTrue(), "expect stateful to be ready")
+				g.Expect(ready.CheckStatefulSetIsReady(ctx, clients.Kubernetes.AppsV1().StatefulSets(project.Name), ssName)()).
+					To(BeTrue(), "expect stateful to be ready")
 
 				pvcName := statefulSetPVCName(ssName, testPVCName, 0)
 				pvc, err := clients.Kubernetes.CoreV1().PersistentVolumeClaims(project.Name).Get(ctx, pvcName, metav1.GetOptions{})
@@ -248,10 +245,8 @@ var _ = Describe("Cluster", Serial, func() {
 
 		By("verifying the deployment is ready")
 		Eventually(func(g Gomega, ctx context.Context) {
-			s, err := clients.Kubernetes.AppsV1().Deployments(project.Name).Get(ctx, deployName, metav1.GetOptions{})
-			g.Expect(err).NotTo(HaveOccurred())
-
-			g.Expect(ready.DeploymentIsReady(s)).To(BeTrue(), "expect stateful to be ready")
+			g.Expect(ready.CheckDeploymentIsReady(ctx, clients.Kubernetes.AppsV1().Deployments(project.Name), deployName)()).
+				To(BeTrue(), "expect deployment to be ready")
 		}).WithContext(ctx).WithTimeout(DefaultEventuallyTimeout).Should(Succeed())
 	})
 })
@@ -12,25 +12,18 @@ import (
 	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/Azure/ARO-RP/pkg/util/cluster"
 	"github.com/Azure/ARO-RP/pkg/util/stringutils"
 	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
-// E2E cluster comes with encryption at host and encryption at rest enabled,
-// and it is a part of E2E set up.
-// However but it is not a standard configuration: by default encryption
-// at host is disabled and encryption at rest is enabled, but with
+// E2E clusters come with encryption at rest enabled with a customer
+// managed key, and with encryption at host enabled unless the cluster profile
+// disables it.  However this is not a standard configuration: by default
+// encryption at host is disabled and encryption at rest is enabled, but with
 // Azure managed encryption key.
-//
-// Ideally we should test permutations of cluster features. For example:
-//   - Both encryption at host and encryption at rest enabled
-//   - Both disabled
-//   - Encryption at host enabled, but encryption at rest disabled
-//   - Encryption at host disabled, but encryption at rest enabled, etc
-// Same for other cluster configurations such as public/private
-// API server visibility. But we are not there yet.
-
-var _ = Describe("Encryption at host", func() {
+
+var _ = Describe("Encryption at host", Label(cluster.LabelEncryptionAtHost), func() {
 	It("must be enabled on the test cluster and each VM must have encryption at host enabled", func(ctx context.Context) {
 		By("getting the test cluster resource")
 		oc, err := clients.OpenshiftClusters.Get(ctx, vnetResourceGroup, clusterName)
@@ -8,7 +8,11 @@ package e2e
 
 import (
 	"flag"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -16,16 +20,36 @@ import (
 	"github.com/onsi/gomega/format"
 	"github.com/sirupsen/logrus"
 
+	"github.com/Azure/ARO-RP/pkg/util/cluster"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
 	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
+var profileName = flag.String("e2e.profile", os.Getenv("E2E_PROFILE"), fmt.Sprintf("cluster profile to test: one of %s", strings.Join(cluster.ProfileNames(), ", ")))
+
 func TestE2E(t *testing.T) {
 	flag.Parse()
 	logrus.SetOutput(GinkgoWriter)
 	log = utillog.GetLogger()
 	log.Infof("e2e tests starting, git commit %s\n", version.GitCommit)
+	initArtifacts(time.Now())
+
+	var err error
+	profile, err = cluster.ProfileFromEnv(*profileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Infof("testing cluster profile %s with labels %s", profile.Name, strings.Join(profile.Labels(), ", "))
+
+	suiteConfig, reporterConfig := GinkgoConfiguration()
+	if filter := profile.LabelFilter(); filter != "" {
+		if suiteConfig.LabelFilter != "" {
+			filter = fmt.Sprintf("(%s) && (%s)", suiteConfig.LabelFilter, filter)
+		}
+		suiteConfig.LabelFilter = filter
+	}
+
 	RegisterFailHandler(Fail)
 	format.TruncatedDiff = false
-	RunSpecs(t, "e2e tests")
+	RunSpecs(t, "e2e tests", suiteConfig, reporterConfig)
 }
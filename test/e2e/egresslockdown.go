@@ -0,0 +1,274 @@
+package e2e
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/ready"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+const (
+	// egressLockdownLabel labels the specs which check that a cluster whose
+	// egress is restricted to the published requirements still works
+	egressLockdownLabel = "egresslockdown"
+
+	egressCheckTimeout = 5 * time.Minute
+)
+
+// egressCheckScript prints "resolve <domain> <address>" for each domain in
+// $DOMAINS and "reach <url> <http status code>" for each URL in $URLS, as
+// seen from the node the pod runs on.  A status code of 000 means that no
+// HTTP response was received.
+const egressCheckScript = `
+for domain in $DOMAINS; do
+	echo "resolve $domain $(getent ahostsv4 "$domain" | awk 'NR==1 { print $1 }')"
+done
+for url in $URLS; do
+	echo "reach $url $(curl -sS -o /dev/null -m 30 -w '%{http_code}' "$url" 2>/dev/null)"
+done
+`
+
+// egressCheckResults are the results of egressCheckScript
+type egressCheckResults struct {
+	resolved map[string]string
+	reached  map[string]string
+}
+
+// runEgressCheck runs egressCheckScript in a host network pod on the node, so
+// that it uses the node's DNS configuration and routes
+func runEgressCheck(ctx context.Context, namespace, nodeName string, domains, urls []string) *egressCheckResults {
+	GinkgoHelper()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "egress-check-",
+			Namespace:    namespace,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{
+				{
+					Name:    "egress-check",
+					Image:   "image-registry.openshift-image-registry.svc:5000/openshift/cli",
+					Command: []string{"/bin/bash", "-c", egressCheckScript},
+					Env: []corev1.EnvVar{
+						{Name: "DOMAINS", Value: strings.Join(domains, " ")},
+						{Name: "URLS", Value: strings.Join(urls, " ")},
+					},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+			HostNetwork:   true,
+			Tolerations: []corev1.Toleration{
+				{Operator: corev1.TolerationOpExists},
+			},
+		},
+	}
+	pod = CreateK8sObjectWithRetry(ctx, clients.Kubernetes.CoreV1().Pods(namespace).Create, pod, metav1.CreateOptions{})
+
+	Eventually(func(g Gomega, ctx context.Context) {
+		pod, err := clients.Kubernetes.CoreV1().Pods(namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(pod.Status.Phase).To(Equal(corev1.PodSucceeded))
+	}).WithContext(ctx).WithTimeout(egressCheckTimeout).WithPolling(5 * time.Second).Should(Succeed())
+
+	logs := GetK8sPodLogsWithRetry(ctx, namespace, pod.Name, corev1.PodLogOptions{})
+
+	results := &egressCheckResults{
+		resolved: map[string]string{},
+		reached:  map[string]string{},
+	}
+	for _, line := range strings.Split(logs, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			// nothing was resolved or reached
+			fields = append(fields, "")
+		}
+		if len(fields) != 3 {
+			continue
+		}
+
+		switch fields[0] {
+		case "resolve":
+			results.resolved[fields[1]] = fields[2]
+		case "reach":
+			results.reached[fields[1]] = fields[2]
+		}
+	}
+
+	return results
+}
+
+// egressCheckNodes returns the name of a master and of a worker node, as the
+// requirements apply to both
+func egressCheckNodes(ctx context.Context) []string {
+	GinkgoHelper()
+
+	nodes := ListK8sObjectWithRetry(ctx, clients.Kubernetes.CoreV1().Nodes().List, metav1.ListOptions{})
+
+	var master, worker string
+	for _, node := range nodes.Items {
+		if !ready.NodeIsReady(&node) {
+			continue
+		}
+
+		if isWorkerNode(node) {
+			if worker == "" {
+				worker = node.Name
+			}
+		} else if master == "" {
+			master = node.Name
+		}
+	}
+	Expect(master).NotTo(BeEmpty(), "no ready master node")
+	Expect(worker).NotTo(BeEmpty(), "no ready worker node")
+
+	return []string{master, worker}
+}
+
+var _ = Describe("[Egress lockdown]", Label(egressLockdownLabel), Serial, func() {
+	var co *arov1alpha1.Cluster
+	var project Project
+
+	BeforeEach(func(ctx context.Context) {
+		var err error
+		co, err = clients.AROClusters.AroV1alpha1().Clusters().Get(ctx, arov1alpha1.SingletonClusterName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		if !operator.GatewayEnabled(co) || co.Spec.GatewayPrivateEndpointIP == "" {
+			Skip("egress lockdown is not enabled on the cluster, skipping test")
+		}
+
+		By("creating a test namespace")
+		project = BuildNewProject(ctx, clients.Kubernetes, clients.Project, fmt.Sprintf("test-e2e-egress-%d", GinkgoParallelProcess()))
+
+		By("verifying the namespace is ready")
+		Eventually(func(ctx context.Context) error {
+			return project.VerifyProjectIsReady(ctx)
+		}).WithContext(ctx).WithTimeout(DefaultEventuallyTimeout).Should(BeNil())
+
+		DeferCleanup(func(ctx context.Context) {
+			By("deleting the test namespace")
+			project.CleanUp(ctx)
+		})
+	})
+
+	It("must resolve the gateway domains to the gateway private endpoint", func(ctx context.Context) {
+		for _, nodeName := range egressCheckNodes(ctx) {
+			By(fmt.Sprintf("resolving the gateway domains on node %s", nodeName))
+			results := runEgressCheck(ctx, project.Name, nodeName, co.Spec.GatewayDomains, nil)
+
+			for _, domain := range co.Spec.GatewayDomains {
+				Expect(results.resolved).To(HaveKeyWithValue(domain, co.Spec.GatewayPrivateEndpointIP), "on node %s", nodeName)
+			}
+		}
+	})
+
+	It("must reach the required endpoints", func(ctx context.Context) {
+		for _, nodeName := range egressCheckNodes(ctx) {
+			By(fmt.Sprintf("reaching the required endpoints from node %s", nodeName))
+			results := runEgressCheck(ctx, project.Name, nodeName, nil, co.Spec.InternetChecker.URLs)
+
+			// any HTTP response shows that the endpoint is reachable; the
+			// endpoints are not expected to serve an anonymous GET
+			for _, u := range co.Spec.InternetChecker.URLs {
+				Expect(results.reached).To(HaveKey(u))
+				Expect(results.reached[u]).NotTo(Or(BeEmpty(), Equal("000")), "%s is not reachable from node %s", u, nodeName)
+			}
+		}
+	})
+
+	It("must reach Geneva via the gateway", func(ctx context.Context) {
+		genevaURL := _env.Environment().GenevaMonitoringEndpoint
+		u, err := url.Parse(genevaURL)
+		Expect(err).NotTo(HaveOccurred())
+
+		if !stringutils.Contains(co.Spec.GatewayDomains, u.Hostname()) {
+			Skip("Geneva is not a gateway domain, skipping test")
+		}
+
+		By("checking that mdsd, which sends the cluster logs to Geneva, is ready")
+		Eventually(func(g Gomega, ctx context.Context) {
+			done, err := ready.CheckDaemonSetIsReady(ctx, clients.Kubernetes.AppsV1().DaemonSets("openshift-azure-logging"), "mdsd")()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(done).To(BeTrue())
+		}).WithContext(ctx).WithTimeout(egressCheckTimeout).WithPolling(10 * time.Second).Should(Succeed())
+
+		for _, nodeName := range egressCheckNodes(ctx) {
+			By(fmt.Sprintf("reaching Geneva via the gateway from node %s", nodeName))
+			results := runEgressCheck(ctx, project.Name, nodeName, []string{u.Hostname()}, []string{genevaURL})
+
+			Expect(results.resolved).To(HaveKeyWithValue(u.Hostname(), co.Spec.GatewayPrivateEndpointIP))
+			Expect(results.reached[genevaURL]).NotTo(Or(BeEmpty(), Equal("000")), "Geneva is not reachable from node %s", nodeName)
+		}
+	})
+
+	It("must pull the ARO images", func(ctx context.Context) {
+		By("getting the ARO operator image")
+		deployment := GetK8sObjectWithRetry(ctx, clients.Kubernetes.AppsV1().Deployments(operator.Namespace).Get, "aro-operator-master", metav1.GetOptions{})
+		image := deployment.Spec.Template.Spec.Containers[0].Image
+
+		for _, nodeName := range egressCheckNodes(ctx) {
+			By(fmt.Sprintf("pulling %s on node %s", image, nodeName))
+			pod := CreateK8sObjectWithRetry(ctx, clients.Kubernetes.CoreV1().Pods(project.Name).Create, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "egress-pull-",
+				},
+				Spec: corev1.PodSpec{
+					NodeName: nodeName,
+					Containers: []corev1.Container{
+						{
+							Name:            "pull",
+							Image:           image,
+							ImagePullPolicy: corev1.PullAlways,
+							Command:         []string{"/bin/true"},
+						},
+					},
+					RestartPolicy: corev1.RestartPolicyNever,
+					Tolerations: []corev1.Toleration{
+						{Operator: corev1.TolerationOpExists},
+					},
+				},
+			}, metav1.CreateOptions{})
+
+			// the image was pulled once the container has an image ID,
+			// whether or not the command then succeeds
+			Eventually(func(g Gomega, ctx context.Context) {
+				pod, err := clients.Kubernetes.CoreV1().Pods(project.Name).Get(ctx, pod.Name, metav1.GetOptions{})
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(pod.Status.ContainerStatuses).To(HaveLen(1))
+				g.Expect(pod.Status.ContainerStatuses[0].ImageID).NotTo(BeEmpty())
+			}).WithContext(ctx).WithTimeout(egressCheckTimeout).WithPolling(5 * time.Second).Should(Succeed())
+		}
+	})
+
+	It("must complete an admin update", func(ctx context.Context) {
+		skipIfNotInDevelopmentEnv()
+
+		adminUpdateCluster(ctx)
+
+		By("checking that the required endpoints are still reachable")
+		for _, nodeName := range egressCheckNodes(ctx) {
+			results := runEgressCheck(ctx, project.Name, nodeName, nil, co.Spec.InternetChecker.URLs)
+
+			for _, u := range co.Spec.InternetChecker.URLs {
+				Expect(results.reached[u]).NotTo(Or(BeEmpty(), Equal("000")), "%s is not reachable from node %s", u, nodeName)
+			}
+		}
+	})
+})
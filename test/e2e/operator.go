@@ -437,12 +437,12 @@ var _ = Describe("ARO Operator - Azure Subnet Reconciler", func() {
 	})
 })
 
-var _ = Describe("ARO Operator - MUO Deployment", func() {
-	const (
-		managedUpgradeOperatorNamespace  = "openshift-managed-upgrade-operator"
-		managedUpgradeOperatorDeployment = "managed-upgrade-operator"
-	)
+const (
+	managedUpgradeOperatorNamespace  = "openshift-managed-upgrade-operator"
+	managedUpgradeOperatorDeployment = "managed-upgrade-operator"
+)
 
+var _ = Describe("ARO Operator - MUO Deployment", func() {
 	It("must be deployed by default with FIPS crypto mandated", func(ctx context.Context) {
 		By("getting MUO pods")
 		pods := ListK8sObjectWithRetry(
@@ -0,0 +1,187 @@
+package e2e
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/ready"
+)
+
+const (
+	// operatorUpgradeLabel labels the spec which updates the ARO operator to
+	// the version in ARO_E2E_OPERATOR_VERSION, which must be pushed to the
+	// RP's ACR
+	operatorUpgradeLabel = "operatorupgrade"
+
+	// operatorUpgradeRolloutTimeout is the time within which both operator
+	// deployments must run the new version after the admin update
+	operatorUpgradeRolloutTimeout = 10 * time.Minute
+
+	// operatorUpgradeConditionsTimeout allows for the checkers, which run
+	// periodically, to report their conditions again after the rollout
+	operatorUpgradeConditionsTimeout = 10 * time.Minute
+)
+
+// controllerStartedRegex matches the line which controller-runtime logs when
+// the workers of a controller start.  The workers first reconcile every object
+// the controller watches, so a controller whose workers started has
+// reconciled at least once.
+var controllerStartedRegex = regexp.MustCompile(`msg="Starting workers".* controller=([^ ]+)`)
+
+func skipIfOperatorUpgradeNotEnabled() {
+	if os.Getenv("ARO_E2E_OPERATOR_VERSION") == "" {
+		Skip("ARO_E2E_OPERATOR_VERSION not set, skipping operator upgrade e2e")
+	}
+}
+
+// operatorDeployments are the deployments of the ARO operator
+var operatorDeployments = []string{"aro-operator-master", "aro-operator-worker"}
+
+// startedControllers returns the names of the controllers which started in
+// the running pods of the deployment
+func startedControllers(ctx context.Context, deployment string) map[string]bool {
+	GinkgoHelper()
+
+	pods := ListK8sObjectWithRetry(ctx, clients.Kubernetes.CoreV1().Pods(operator.Namespace).List, metav1.ListOptions{
+		LabelSelector: "app=" + deployment,
+	})
+
+	controllers := map[string]bool{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		logs := GetK8sPodLogsWithRetry(ctx, operator.Namespace, pod.Name, corev1.PodLogOptions{})
+		for _, m := range controllerStartedRegex.FindAllStringSubmatch(logs, -1) {
+			controllers[strings.Trim(m[1], `"`)] = true
+		}
+	}
+
+	return controllers
+}
+
+// operatorVersionPatch returns the admin API patch which updates the ARO
+// operator to the version.  An empty version reverts to the RP's default
+// operator version.
+func operatorVersionPatch(version string) map[string]interface{} {
+	return map[string]interface{}{
+		"properties": map[string]interface{}{
+			"maintenanceTask": admin.MaintenanceTaskOperator,
+			"operatorVersion": version,
+		},
+	}
+}
+
+// operatorConditions returns the status of each condition of the ARO Cluster
+// resource
+func operatorConditions(g Gomega, ctx context.Context) map[string]operatorv1.ConditionStatus {
+	co, err := clients.AROClusters.AroV1alpha1().Clusters().Get(ctx, arov1alpha1.SingletonClusterName, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	conditions := map[string]operatorv1.ConditionStatus{}
+	for _, c := range co.Status.Conditions {
+		conditions[c.Type] = c.Status
+	}
+
+	return conditions
+}
+
+var _ = Describe("[Admin API] ARO operator upgrade", Label(operatorUpgradeLabel), Serial, func() {
+	BeforeEach(skipIfNotInDevelopmentEnv)
+	BeforeEach(skipIfOperatorUpgradeNotEnabled)
+
+	It("must roll out a new operator version on admin update", func(ctx context.Context) {
+		newVersion := os.Getenv("ARO_E2E_OPERATOR_VERSION")
+
+		By("saving the current operator version, started controllers and conditions")
+		oc := &admin.OpenShiftCluster{}
+		Eventually(func(g Gomega, ctx context.Context) {
+			oc = adminGetCluster(g, ctx, clusterResourceID)
+		}).WithContext(ctx).WithTimeout(DefaultTimeout).Should(Succeed())
+		oldVersion := oc.Properties.OperatorVersion
+
+		oldControllers := map[string]map[string]bool{}
+		for _, deployment := range operatorDeployments {
+			d := GetK8sObjectWithRetry(ctx, clients.Kubernetes.AppsV1().Deployments(operator.Namespace).Get, deployment, metav1.GetOptions{})
+			Expect(d.Labels["version"]).NotTo(Equal(newVersion), "the operator already runs version %s", newVersion)
+
+			oldControllers[deployment] = startedControllers(ctx, deployment)
+			Expect(oldControllers[deployment]).NotTo(BeEmpty(), "no started controllers logged by %s", deployment)
+		}
+
+		var oldConditions map[string]operatorv1.ConditionStatus
+		Eventually(func(g Gomega, ctx context.Context) {
+			oldConditions = operatorConditions(g, ctx)
+		}).WithContext(ctx).WithTimeout(DefaultTimeout).Should(Succeed())
+
+		DeferCleanup(func(ctx context.Context) {
+			By(fmt.Sprintf("restoring the operator version to %q", oldVersion))
+			adminPatchCluster(ctx, operatorVersionPatch(oldVersion))
+		})
+
+		By(fmt.Sprintf("updating the operator to version %s", newVersion))
+		adminPatchCluster(ctx, operatorVersionPatch(newVersion))
+
+		for _, deployment := range operatorDeployments {
+			By(fmt.Sprintf("waiting for %s to roll out version %s", deployment, newVersion))
+			Eventually(func(g Gomega, ctx context.Context) {
+				d, err := clients.Kubernetes.AppsV1().Deployments(operator.Namespace).Get(ctx, deployment, metav1.GetOptions{})
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(d.Labels).To(HaveKeyWithValue("version", newVersion))
+				g.Expect(d.Spec.Template.Spec.Containers[0].Image).To(HaveSuffix(":" + newVersion))
+				g.Expect(ready.DeploymentIsReady(d)).To(BeTrue())
+
+				pods, err := clients.Kubernetes.CoreV1().Pods(operator.Namespace).List(ctx, metav1.ListOptions{
+					LabelSelector: "app=" + deployment,
+				})
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(pods.Items).NotTo(BeEmpty())
+				for _, pod := range pods.Items {
+					g.Expect(pod.Spec.Containers[0].Image).To(HaveSuffix(":"+newVersion), "pod %s", pod.Name)
+					g.Expect(ready.PodIsRunning(&pod)).To(BeTrue(), "pod %s", pod.Name)
+				}
+			}).WithContext(ctx).WithTimeout(operatorUpgradeRolloutTimeout).WithPolling(10 * time.Second).Should(Succeed())
+
+			By(fmt.Sprintf("checking that every controller of %s started and reconciled", deployment))
+			Eventually(func(g Gomega, ctx context.Context) {
+				newControllers := startedControllers(ctx, deployment)
+				for controller := range oldControllers[deployment] {
+					g.Expect(newControllers).To(HaveKey(controller))
+				}
+			}).WithContext(ctx).WithTimeout(5 * time.Minute).WithPolling(10 * time.Second).Should(Succeed())
+		}
+
+		By("checking that the master operator reports the new version")
+		Eventually(func(g Gomega, ctx context.Context) {
+			co, err := clients.AROClusters.AroV1alpha1().Clusters().Get(ctx, arov1alpha1.SingletonClusterName, metav1.GetOptions{})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(co.Status.OperatorVersion).To(Equal(newVersion))
+		}).WithContext(ctx).WithTimeout(5 * time.Minute).WithPolling(10 * time.Second).Should(Succeed())
+
+		By("checking that no condition regressed")
+		Eventually(func(g Gomega, ctx context.Context) {
+			conditions := operatorConditions(g, ctx)
+			for condition, status := range oldConditions {
+				g.Expect(conditions).To(HaveKeyWithValue(condition, status), "condition %s", condition)
+			}
+		}).WithContext(ctx).WithTimeout(operatorUpgradeConditionsTimeout).WithPolling(30 * time.Second).Should(Succeed())
+	})
+})
@@ -39,6 +39,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/hive"
 	aroclient "github.com/Azure/ARO-RP/pkg/operator/clientset/versioned"
+	"github.com/Azure/ARO-RP/pkg/proxy"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
@@ -49,7 +50,6 @@ import (
 	msgraph_errors "github.com/Azure/ARO-RP/pkg/util/graph/graphsdk/models/odataerrors"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
-	"github.com/Azure/ARO-RP/pkg/util/version"
 	"github.com/Azure/ARO-RP/test/util/kubeadminkubeconfig"
 )
 
@@ -96,6 +96,7 @@ var (
 	vnetResourceGroup string
 	clusterName       string
 	osClusterVersion  string
+	profile           *cluster.Profile
 	clusterResourceID string
 	clients           *clientSet
 )
@@ -139,8 +140,14 @@ func SaveScreenshot(wd selenium.WebDriver, e error) {
 		errorString = errorString[:59] + "_" + uuid.DefaultGenerator.Generate()
 	}
 
-	imagePath := "./" + errorString + ".png"
-	sourcePath := "./" + errorString + ".html"
+	dir := specArtifactsDir(CurrentSpecReport())
+	err = os.MkdirAll(dir, 0777)
+	if err != nil {
+		panic(err)
+	}
+
+	imagePath := filepath.Join(dir, errorString+".png")
+	sourcePath := filepath.Join(dir, errorString+".html")
 
 	imageAbsPath, err := filepath.Abs(imagePath)
 	if err != nil {
@@ -259,7 +266,17 @@ func newClientSet(ctx context.Context) (*clientSet, error) {
 	scopes := []string{_env.Environment().ResourceManagerScope}
 	authorizer := azidext.NewTokenCredentialAdapter(tokenCredential, scopes)
 
-	configv1, err := kubeadminkubeconfig.Get(ctx, log, _env, authorizer, resourceIDFromEnv())
+	// Allow running against private clusters from environments where only
+	// SOCKS egress is available.
+	var dialer proxy.Dialer
+	if address := os.Getenv(proxy.SOCKS5ProxyEnvVar); address != "" {
+		dialer, err = proxy.NewSOCKS5Dialer(address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	configv1, err := kubeadminkubeconfig.Get(ctx, log, _env, authorizer, resourceIDFromEnv(), dialer)
 	if err != nil {
 		return nil, err
 	}
@@ -276,6 +293,9 @@ func newClientSet(ctx context.Context) (*clientSet, error) {
 	if err != nil {
 		return nil, err
 	}
+	if dialer != nil {
+		restconfig.Dial = dialer.DialContext
+	}
 
 	cli, err := kubernetes.NewForConfig(restconfig)
 	if err != nil {
@@ -412,22 +432,20 @@ func setup(ctx context.Context) error {
 	}
 	clusterName = os.Getenv("CLUSTER")
 
-	osClusterVersion = os.Getenv("OS_CLUSTER_VERSION")
-
-	if os.Getenv("CI") != "" { // always create cluster in CI
-		cluster, err := cluster.New(log, _env, os.Getenv("CI") != "")
-		if err != nil {
-			return err
-		}
+	osClusterVersion = profile.Version
 
-		if osClusterVersion == "" {
-			osClusterVersion = version.DefaultInstallStream.Version.String()
-		}
+	cluster, err := cluster.New(log, _env, os.Getenv("CI") != "")
+	if err != nil {
+		return err
+	}
 
-		err = cluster.Create(ctx, vnetResourceGroup, clusterName, osClusterVersion)
-		if err != nil {
-			return err
-		}
+	if os.Getenv("CI") != "" { // always create cluster in CI
+		err = cluster.Create(ctx, vnetResourceGroup, clusterName, profile)
+	} else {
+		err = cluster.Adopt(ctx, vnetResourceGroup, clusterName, profile)
+	}
+	if err != nil {
+		return err
 	}
 
 	clusterResourceID = resourceIDFromEnv()
@@ -0,0 +1,280 @@
+package e2e
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	configv1 "github.com/openshift/api/config/v1"
+	cov1Helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/util/ready"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+const (
+	// upgradeLabel labels the specs which upgrade the cluster.  An upgrade
+	// cannot be undone, so they only run when ARO_E2E_UPGRADE is set.
+	upgradeLabel = "upgrade"
+
+	upgradeTimeout = 3 * time.Hour
+
+	// upgradeHealthGracePeriod is how long a component may be unhealthy
+	// during the upgrade, e.g. while the node it runs on is drained
+	upgradeHealthGracePeriod = 15 * time.Minute
+
+	managedUpgradeConfigName = "managed-upgrade-config"
+)
+
+func skipIfUpgradeNotEnabled() {
+	if os.Getenv("ARO_E2E_UPGRADE") == "" {
+		Skip("ARO_E2E_UPGRADE not set, skipping upgrade e2e")
+	}
+}
+
+// upgradeHealthCheck returns an error if the component is not healthy
+type upgradeHealthCheck func(ctx context.Context) error
+
+var upgradeHealthChecks = map[string]upgradeHealthCheck{
+	"ARO operator": func(ctx context.Context) error {
+		for _, name := range []string{"aro-operator-master", "aro-operator-worker"} {
+			ok, err := ready.CheckDeploymentIsReady(ctx, clients.Kubernetes.AppsV1().Deployments("openshift-azure-operator"), name)()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("deployment %s is not ready", name)
+			}
+		}
+		return nil
+	},
+	"geneva logging": func(ctx context.Context) error {
+		ok, err := ready.CheckDaemonSetIsReady(ctx, clients.Kubernetes.AppsV1().DaemonSets("openshift-azure-logging"), "mdsd")()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("daemonset mdsd is not ready")
+		}
+		return nil
+	},
+	"monitoring": func(ctx context.Context) error {
+		co, err := clients.ConfigClient.ConfigV1().ClusterOperators().Get(ctx, "monitoring", metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if !cov1Helpers.IsStatusConditionTrue(co.Status.Conditions, configv1.OperatorAvailable) {
+			return fmt.Errorf("cluster operator monitoring is not available")
+		}
+		return nil
+	},
+}
+
+// upgradeHealthWatcher records since when each component has been unhealthy
+type upgradeHealthWatcher struct {
+	unhealthySince map[string]time.Time
+}
+
+// check fails if a component has been unhealthy for longer than the grace
+// period
+func (w *upgradeHealthWatcher) check(ctx context.Context) {
+	GinkgoHelper()
+
+	for name, check := range upgradeHealthChecks {
+		err := check(ctx)
+		if err == nil {
+			delete(w.unhealthySince, name)
+			continue
+		}
+
+		since, found := w.unhealthySince[name]
+		if !found {
+			log.Infof("%s is unhealthy: %s", name, err)
+			w.unhealthySince[name] = time.Now()
+			continue
+		}
+
+		if time.Since(since) > upgradeHealthGracePeriod {
+			StopTrying(fmt.Sprintf("%s has been unhealthy for more than %s", name, upgradeHealthGracePeriod)).Wrap(err).Now()
+		}
+	}
+}
+
+// nextMinorUpdate sets the channel of the cluster to the stable channel of the
+// next minor version and returns the latest version in it which the cluster
+// can update to
+func nextMinorUpdate(ctx context.Context, current *version.Version) (*version.Version, string) {
+	GinkgoHelper()
+
+	channel := fmt.Sprintf("stable-%d.%d", current.V[0], current.V[1]+1)
+
+	By(fmt.Sprintf("setting the channel to %s", channel))
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cv, err := clients.ConfigClient.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		cv.Spec.Channel = channel
+		_, err = clients.ConfigClient.ConfigV1().ClusterVersions().Update(ctx, cv, metav1.UpdateOptions{})
+		return err
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	By("waiting for an update to the next minor version to be available")
+	var target *version.Version
+	Eventually(func(g Gomega, ctx context.Context) {
+		cv, err := clients.ConfigClient.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		for _, update := range cv.Status.AvailableUpdates {
+			v, err := version.ParseVersion(update.Version)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if v.V[0] == current.V[0] && v.V[1] == current.V[1]+1 && (target == nil || target.Lt(v)) {
+				target = v
+			}
+		}
+		g.Expect(target).NotTo(BeNil(), "no update to %d.%d is available in %s", current.V[0], current.V[1]+1, channel)
+	}).WithContext(ctx).WithTimeout(10 * time.Minute).WithPolling(30 * time.Second).Should(Succeed())
+
+	return target, channel
+}
+
+func newUpgradeConfig() *unstructured.Unstructured {
+	return newUnstructured("upgrade.managed.openshift.io/v1alpha1", "UpgradeConfig", managedUpgradeOperatorNamespace, managedUpgradeConfigName)
+}
+
+var _ = Describe("[Upgrade] Managed upgrade", Label(upgradeLabel), Serial, func() {
+	BeforeEach(skipIfUpgradeNotEnabled)
+
+	It("must upgrade the cluster to the next minor version", func(ctx context.Context) {
+		By("getting the installed version")
+		cv, err := clients.ConfigClient.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		current, err := version.GetClusterVersion(cv)
+		Expect(err).NotTo(HaveOccurred())
+		log.Infof("installed version: %s", current)
+
+		originalChannel := cv.Spec.Channel
+		DeferCleanup(func(ctx context.Context) {
+			cv, err := clients.ConfigClient.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			// the channel of the next minor version is only kept once the
+			// cluster has upgraded to it
+			v, err := version.GetClusterVersion(cv)
+			Expect(err).NotTo(HaveOccurred())
+			if !v.Eq(current) {
+				return
+			}
+
+			By(fmt.Sprintf("restoring the channel to %q", originalChannel))
+			cv.Spec.Channel = originalChannel
+			_, err = clients.ConfigClient.ConfigV1().ClusterVersions().Update(ctx, cv, metav1.UpdateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		target, channel := nextMinorUpdate(ctx, current)
+		log.Infof("upgrading to %s", target)
+
+		By("checking that the components are healthy before the upgrade")
+		for name, check := range upgradeHealthChecks {
+			Expect(check(ctx)).To(Succeed(), name)
+		}
+
+		By("creating the UpgradeConfig")
+		uc := newUpgradeConfig()
+		err = clients.Client.Delete(ctx, uc)
+		Expect(err).To(SatisfyAny(
+			Not(HaveOccurred()),
+			MatchError(kerrors.IsNotFound),
+		))
+
+		err = unstructured.SetNestedMap(uc.Object, map[string]interface{}{
+			"type":                 "ARO",
+			"upgradeAt":            time.Now().UTC().Format(time.RFC3339),
+			"PDBForceDrainTimeout": int64(60),
+			"desired": map[string]interface{}{
+				"version": target.String(),
+				"channel": channel,
+			},
+		}, "spec")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = clients.Client.Create(ctx, uc)
+		Expect(err).NotTo(HaveOccurred())
+
+		DeferCleanup(func(ctx context.Context) {
+			By("deleting the UpgradeConfig")
+			err := clients.Client.Delete(ctx, newUpgradeConfig())
+			Expect(err).To(SatisfyAny(
+				Not(HaveOccurred()),
+				MatchError(kerrors.IsNotFound),
+			))
+		})
+
+		By("waiting for the upgrade to complete, checking that the components stay healthy")
+		watcher := &upgradeHealthWatcher{unhealthySince: map[string]time.Time{}}
+		Eventually(func(g Gomega, ctx context.Context) {
+			watcher.check(ctx)
+
+			uc := newUpgradeConfig()
+			err := clients.Client.Get(ctx, client.ObjectKeyFromObject(uc), uc)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			history, _, err := unstructured.NestedSlice(uc.Object, "status", "history")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(history).NotTo(BeEmpty(), "the upgrade has not started")
+
+			latest, _ := history[0].(map[string]interface{})
+			phase, _, _ := unstructured.NestedString(latest, "phase")
+			if phase == "Failed" {
+				StopTrying("the upgrade failed").Attach("history", latest).Now()
+			}
+			g.Expect(phase).To(Equal("Upgraded"))
+
+			cv, err := clients.ConfigClient.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			v, err := version.GetClusterVersion(cv)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(v.String()).To(Equal(target.String()))
+		}).WithContext(ctx).WithTimeout(upgradeTimeout).WithPolling(time.Minute).Should(Succeed())
+
+		By("checking that the components are healthy after the upgrade")
+		Eventually(func(g Gomega, ctx context.Context) {
+			for name, check := range upgradeHealthChecks {
+				g.Expect(check(ctx)).To(Succeed(), name)
+			}
+		}).WithContext(ctx).WithTimeout(upgradeHealthGracePeriod).WithPolling(30 * time.Second).Should(Succeed())
+
+		By("checking that the RP reports the new version")
+		oc, err := clients.OpenshiftClusters.Get(ctx, vnetResourceGroup, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*oc.OpenShiftClusterProperties.ClusterProfile.Version).To(Equal(target.String()))
+
+		if _env.IsLocalDevelopmentMode() {
+			var adminOC admin.OpenShiftCluster
+			resp, err := adminRequest(ctx, http.MethodGet, clusterResourceID, nil, true, nil, &adminOC)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(adminOC.Properties.ClusterProfile.Version).To(Equal(target.String()))
+		}
+	}, SpecTimeout(upgradeTimeout+30*time.Minute))
+})
@@ -19,6 +19,7 @@ const (
 	GATEWAY
 	OPENSHIFT_VERSIONS
 	CLUSTERMANAGER
+	PORTALSESSIONS
 )
 
 type gen struct {
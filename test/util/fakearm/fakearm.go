@@ -0,0 +1,383 @@
+package fakearm
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+)
+
+// Server is a fake of the subset of the ARM REST API which the cluster manager
+// uses: resource groups, deployments, network, compute and private DNS.
+// Resources are kept in memory, keyed case insensitively by resource ID, and
+// every PUT succeeds synchronously.  Failures can be scripted to be returned
+// for matching requests, so that tests can make a chosen step fail.
+type Server struct {
+	srv *httptest.Server
+
+	mu        sync.Mutex
+	resources map[string]map[string]interface{}
+	failures  []*Failure
+	requests  []Request
+}
+
+// Request is a request which the server received
+type Request struct {
+	Method string
+	Path   string
+}
+
+// Failure is a scripted failure, returned instead of handling a request whose
+// method and path match
+type Failure struct {
+	Method string
+	Path   *regexp.Regexp
+
+	StatusCode int
+	Code       string
+	Message    string
+
+	// Times is how many requests fail; if zero, every matching request fails
+	Times int
+}
+
+// NewServer returns a started fake ARM server.  Close must be called when it
+// is no longer needed.
+func NewServer() *Server {
+	s := &Server{
+		resources: map[string]map[string]interface{}{},
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Environment returns an AROEnvironment whose Resource Manager endpoint is the
+// server, for constructing azureclients which talk to it
+func (s *Server) Environment() *azureclient.AROEnvironment {
+	return &azureclient.AROEnvironment{
+		Environment: azure.Environment{
+			Name:                    azure.PublicCloud.Name,
+			ResourceManagerEndpoint: s.srv.URL + "/",
+		},
+	}
+}
+
+// Authorizer returns an authorizer for the azureclients which talk to the
+// server, which does not check authorization
+func (s *Server) Authorizer() autorest.Authorizer {
+	return autorest.NullAuthorizer{}
+}
+
+// Put stores a resource, as a test fixture.  The SDK types do not marshal
+// their read-only fields, so a resource which needs them must be passed as a
+// map.
+func (s *Server) Put(id string, resource interface{}) error {
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+
+	var r map[string]interface{}
+	err = json.Unmarshal(b, &r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.put(id, r)
+
+	return nil
+}
+
+// Get unmarshals a stored resource into resource, and returns false if it does
+// not exist
+func (s *Server) Get(id string, resource interface{}) (bool, error) {
+	s.mu.Lock()
+	r, found := s.resources[strings.ToLower(id)]
+	s.mu.Unlock()
+
+	if !found {
+		return false, nil
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return false, err
+	}
+
+	return true, json.Unmarshal(b, resource)
+}
+
+// Fail scripts a failure
+func (s *Server) Fail(f *Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, f)
+}
+
+// Requests returns the requests which the server has received
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request{}, s.requests...)
+}
+
+func (s *Server) put(id string, r map[string]interface{}) {
+	r["id"] = id
+	r["name"] = id[strings.LastIndex(id, "/")+1:]
+	if t := resourceType(id); t != "" {
+		r["type"] = t
+	}
+
+	properties, _ := r["properties"].(map[string]interface{})
+	if properties == nil {
+		properties = map[string]interface{}{}
+		r["properties"] = properties
+	}
+	properties["provisioningState"] = "Succeeded"
+
+	s.resources[strings.ToLower(id)] = r
+}
+
+// failure returns the scripted failure for the request, if any
+func (s *Server) failure(method, path string) *Failure {
+	for i, f := range s.failures {
+		if (f.Method == "" || strings.EqualFold(f.Method, method)) && f.Path.MatchString(path) {
+			if f.Times > 0 {
+				f.Times--
+				if f.Times == 0 {
+					s.failures = append(s.failures[:i], s.failures[i+1:]...)
+				}
+			}
+			return f
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path})
+
+	if f := s.failure(r.Method, path); f != nil {
+		writeError(w, f.StatusCode, f.Code, f.Message)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.get(w, path)
+	case http.MethodPut, http.MethodPatch:
+		s.putOrPatch(w, r, path)
+	case http.MethodDelete:
+		s.delete(w, path)
+	case http.MethodPost:
+		// actions, e.g. starting a VM, are accepted but have no effect
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("The method %s is not allowed.", r.Method))
+	}
+}
+
+func (s *Server) get(w http.ResponseWriter, path string) {
+	if r, found := s.resources[strings.ToLower(path)]; found {
+		writeJSON(w, http.StatusOK, r)
+		return
+	}
+
+	// a GET of a collection lists the resources directly in it, and a GET of
+	// the resources of a resource group lists every resource in it
+	var ids []string
+	prefix := strings.ToLower(path) + "/"
+	if isResourceGroupResources(path) {
+		prefix = strings.ToLower(strings.TrimSuffix(path, "/resources")) + "/providers/"
+	}
+	for id := range s.resources {
+		if !strings.HasPrefix(id, prefix) {
+			continue
+		}
+		if isResourceGroupResources(path) || !strings.Contains(id[len(prefix):], "/") {
+			ids = append(ids, id)
+		}
+	}
+
+	if ids != nil || isCollection(path) || isResourceGroupResources(path) {
+		sort.Strings(ids)
+
+		value := make([]map[string]interface{}, 0, len(ids))
+		for _, id := range ids {
+			value = append(value, s.resources[id])
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"value": value})
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource '%s' was not found.", path))
+}
+
+func (s *Server) putOrPatch(w http.ResponseWriter, r *http.Request, path string) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	var body map[string]interface{}
+	if len(b) > 0 {
+		err = json.Unmarshal(b, &body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+	}
+	if body == nil {
+		body = map[string]interface{}{}
+	}
+
+	existing, found := s.resources[strings.ToLower(path)]
+	if r.Method == http.MethodPatch {
+		if !found {
+			writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource '%s' was not found.", path))
+			return
+		}
+
+		body = merge(existing, body)
+	}
+
+	s.put(path, body)
+
+	statusCode := http.StatusCreated
+	if found {
+		statusCode = http.StatusOK
+	}
+	writeJSON(w, statusCode, s.resources[strings.ToLower(path)])
+}
+
+func (s *Server) delete(w http.ResponseWriter, path string) {
+	key := strings.ToLower(path)
+	if _, found := s.resources[key]; !found {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// deleting a resource group, or a parent resource, deletes everything in
+	// it
+	for id := range s.resources {
+		if id == key || strings.HasPrefix(id, key+"/") {
+			delete(s.resources, id)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// resourceType returns the type of the resource with the given ID, e.g.
+// Microsoft.Network/virtualNetworks/subnets, or "" if it is not a provider
+// resource
+func resourceType(id string) string {
+	parts := strings.Split(strings.Trim(id, "/"), "/")
+
+	for i := len(parts) - 1; i >= 0; i-- {
+		if strings.EqualFold(parts[i], "providers") && i+1 < len(parts) {
+			segments := []string{parts[i+1]}
+			for j := i + 2; j < len(parts); j += 2 {
+				segments = append(segments, parts[j])
+			}
+			return strings.Join(segments, "/")
+		}
+	}
+
+	if len(parts) == 4 && strings.EqualFold(parts[2], "resourcegroups") {
+		return "Microsoft.Resources/resourceGroups"
+	}
+
+	return ""
+}
+
+// isCollection returns true if path names a collection of resources, i.e. it
+// ends with a resource type rather than a resource name
+func isCollection(path string) bool {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i := len(parts) - 1; i >= 0; i-- {
+		if strings.EqualFold(parts[i], "providers") {
+			// providers/<namespace>/<type>[/<name>/<type>]...
+			return (len(parts)-i)%2 == 1
+		}
+	}
+
+	// subscriptions/<id>/resourcegroups
+	return len(parts) == 3 && strings.EqualFold(parts[2], "resourcegroups")
+}
+
+// isResourceGroupResources returns true if path names the resources of a
+// resource group
+func isResourceGroupResources(path string) bool {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	return len(parts) == 5 && strings.EqualFold(parts[2], "resourcegroups") && strings.EqualFold(parts[4], "resources")
+}
+
+// merge applies a JSON merge patch to a resource
+func merge(resource, patch map[string]interface{}) map[string]interface{} {
+	for k, v := range patch {
+		if v == nil {
+			delete(resource, k)
+			continue
+		}
+
+		if p, ok := v.(map[string]interface{}); ok {
+			if r, ok := resource[k].(map[string]interface{}); ok {
+				resource[k] = merge(r, p)
+				continue
+			}
+		}
+
+		resource[k] = v
+	}
+
+	return resource
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,221 @@
+package fakearm
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+)
+
+const subscriptionID = "00000000-0000-0000-0000-000000000000"
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	resourceGroups := features.NewResourceGroupsClient(s.Environment(), subscriptionID, s.Authorizer())
+	deployments := features.NewDeploymentsClient(s.Environment(), subscriptionID, s.Authorizer())
+	subnets := network.NewSubnetsClient(s.Environment(), subscriptionID, s.Authorizer())
+	virtualMachines := compute.NewVirtualMachinesClient(s.Environment(), subscriptionID, s.Authorizer())
+
+	_, err := resourceGroups.CreateOrUpdate(ctx, "rg", mgmtfeatures.ResourceGroup{Location: to.StringPtr("eastus")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = deployments.CreateOrUpdateAndWait(ctx, "rg", "deployment", mgmtfeatures.Deployment{
+		Properties: &mgmtfeatures.DeploymentProperties{
+			Mode: mgmtfeatures.Incremental,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subnetID := "/subscriptions/" + subscriptionID + "/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/master"
+	err = subnets.CreateOrUpdateAndWait(ctx, "rg", "vnet", "master", mgmtnetwork.Subnet{
+		SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+			AddressPrefix: to.StringPtr("10.0.0.0/24"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subnet, err := subnets.Get(ctx, "rg", "vnet", "master", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *subnet.ID != subnetID || *subnet.AddressPrefix != "10.0.0.0/24" || subnet.ProvisioningState != mgmtnetwork.Succeeded {
+		t.Errorf("unexpected subnet %#v", subnet)
+	}
+
+	var stored mgmtnetwork.Subnet
+	found, err := s.Get(subnetID, &stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || *stored.AddressPrefix != "10.0.0.0/24" {
+		t.Errorf("unexpected stored subnet %#v", stored)
+	}
+
+	for _, name := range []string{"master-0", "master-1"} {
+		err = virtualMachines.CreateOrUpdateAndWait(ctx, "rg", name, mgmtcompute.VirtualMachine{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	vms, err := virtualMachines.List(ctx, "rg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, vm := range vms {
+		names = append(names, *vm.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"master-0", "master-1"}) {
+		t.Error(names)
+	}
+
+	err = virtualMachines.StartAndWait(ctx, "rg", "master-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = resourceGroups.DeleteAndWait(ctx, "rg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err = s.Get(subnetID, &stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Error("subnet was not deleted with its resource group")
+	}
+}
+
+func TestFail(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewServer()
+	defer s.Close()
+
+	subnets := network.NewSubnetsClient(s.Environment(), subscriptionID, s.Authorizer())
+
+	s.Fail(&Failure{
+		Method:     http.MethodPut,
+		Path:       regexp.MustCompile(`/subnets/master$`),
+		StatusCode: http.StatusBadRequest,
+		Code:       "InvalidParameter",
+		Message:    "The subnet is invalid.",
+		Times:      1,
+	})
+
+	err := subnets.CreateOrUpdateAndWait(ctx, "rg", "vnet", "master", mgmtnetwork.Subnet{})
+	detailedErr, ok := err.(autorest.DetailedError)
+	if !ok || detailedErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unexpected error %#v", err)
+	}
+	if serviceErr, ok := detailedErr.Original.(*azure.ServiceError); !ok || serviceErr.Code != "InvalidParameter" {
+		t.Errorf("unexpected error %#v", detailedErr.Original)
+	}
+
+	// the failure was scripted once, so a retry succeeds
+	err = subnets.CreateOrUpdateAndWait(ctx, "rg", "vnet", "master", mgmtnetwork.Subnet{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Request{
+		{Method: http.MethodPut, Path: "/subscriptions/" + subscriptionID + "/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/master"},
+		{Method: http.MethodPut, Path: "/subscriptions/" + subscriptionID + "/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/master"},
+	}
+	if got := s.Requests(); !reflect.DeepEqual(got, want) {
+		t.Error(got)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	id := "/subscriptions/" + subscriptionID + "/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/nsg"
+	err := s.Put(id, map[string]interface{}{
+		"location": "eastus",
+		"tags":     map[string]interface{}{"a": "1", "b": "2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPatch, s.srv.URL+id, strings.NewReader(`{"tags":{"a":null,"c":"3"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatal(resp.StatusCode)
+	}
+
+	var nsg mgmtnetwork.SecurityGroup
+	_, err = s.Get(id, &nsg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(nsg.Tags, map[string]*string{"b": to.StringPtr("2"), "c": to.StringPtr("3")}) {
+		t.Error(nsg.Tags)
+	}
+}
+
+func TestResourceType(t *testing.T) {
+	for id, want := range map[string]string{
+		"/subscriptions/sub/resourceGroups/rg":                                                            "Microsoft.Resources/resourceGroups",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet":           "Microsoft.Network/virtualNetworks",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/s": "Microsoft.Network/virtualNetworks/subnets",
+		"/subscriptions/sub": "",
+	} {
+		if got := resourceType(id); got != want {
+			t.Errorf("%s: got %q", id, got)
+		}
+	}
+}
+
+func TestIsCollection(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/subscriptions/sub/resourceGroups":                                                          true,
+		"/subscriptions/sub/resourceGroups/rg":                                                       false,
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines":           true,
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm":        false,
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/v/subnets": true,
+	} {
+		if got := isCollection(path); got != want {
+			t.Errorf("%s: got %v", path, got)
+		}
+	}
+}
@@ -16,10 +16,13 @@ import (
 	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 
 	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/proxy"
 	redhatopenshift20200430 "github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/redhatopenshift/2020-04-30/redhatopenshift"
 )
 
-func Get(ctx context.Context, log *logrus.Entry, env env.Core, authorizer autorest.Authorizer, resourceID string) (*clientcmdv1.Config, error) {
+// Get returns a kubeadmin kubeconfig for the cluster.  If dialer is not nil,
+// the token request to the cluster OAuth server is made through it.
+func Get(ctx context.Context, log *logrus.Entry, env env.Core, authorizer autorest.Authorizer, resourceID string, dialer proxy.Dialer) (*clientcmdv1.Config, error) {
 	res, err := azure.ParseResourceID(resourceID)
 	if err != nil {
 		return nil, err
@@ -48,7 +51,7 @@ func Get(ctx context.Context, log *logrus.Entry, env env.Core, authorizer autore
 	defer cancel()
 
 	err = wait.PollImmediateUntil(time.Second, func() (bool, error) {
-		token, err = getAuthorizedToken(ctx, tokenURL, *creds.KubeadminUsername, *creds.KubeadminPassword)
+		token, err = getAuthorizedToken(ctx, tokenURL, *creds.KubeadminUsername, *creds.KubeadminPassword, dialer)
 		if err != nil {
 			log.Print(err)
 			return false, nil
@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/Azure/ARO-RP/pkg/proxy"
 )
 
 func parseTokenResponse(location string) (string, error) {
@@ -59,7 +61,7 @@ func getTokenURLFromConsoleURL(consoleURL string) (*url.URL, error) {
 	return tokenURL, nil
 }
 
-func getAuthorizedToken(ctx context.Context, tokenURL *url.URL, username, password string) (string, error) {
+func getAuthorizedToken(ctx context.Context, tokenURL *url.URL, username, password string, dialer proxy.Dialer) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
 	if err != nil {
 		return "", err
@@ -68,12 +70,17 @@ func getAuthorizedToken(ctx context.Context, tokenURL *url.URL, username, passwo
 	req.SetBasicAuth(username, password)
 	req.Header.Add("X-CSRF-Token", "1")
 
-	cli := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true,
 		},
+	}
+	if dialer != nil {
+		transport.DialContext = dialer.DialContext
+	}
+
+	cli := &http.Client{
+		Transport: transport,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
 		},
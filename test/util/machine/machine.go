@@ -0,0 +1,94 @@
+package machine
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	namespace       = "openshift-machine-api"
+	machineSetLabel = "machine.openshift.io/cluster-api-machineset"
+)
+
+// ProviderSpec returns spec as the provider spec value of a MachineSet or a
+// Machine
+func ProviderSpec(t *testing.T, spec *machinev1beta1.AzureMachineProviderSpec) *kruntime.RawExtension {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &kruntime.RawExtension{Raw: b}
+}
+
+// MachineSet returns a MachineSet of replicas Machines of vmSize.  The
+// MachineSet and its Machines carry labels, and its Machines are selected by
+// the MachineSet label.
+func MachineSet(t *testing.T, name, vmSize string, replicas int32, labels, annotations map[string]string) *machinev1beta1.MachineSet {
+	templateLabels := map[string]string{
+		machineSetLabel: name,
+	}
+	for k, v := range labels {
+		templateLabels[k] = v
+	}
+
+	return &machinev1beta1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: machinev1beta1.MachineSetSpec{
+			Replicas: to.Int32Ptr(replicas),
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					machineSetLabel: name,
+				},
+			},
+			Template: machinev1beta1.MachineTemplateSpec{
+				ObjectMeta: machinev1beta1.ObjectMeta{
+					Labels: templateLabels,
+				},
+				Spec: machinev1beta1.MachineSpec{
+					ProviderSpec: machinev1beta1.ProviderSpec{
+						Value: ProviderSpec(t, &machinev1beta1.AzureMachineProviderSpec{
+							VMSize: vmSize,
+						}),
+					},
+				},
+			},
+		},
+	}
+}
+
+// Machine returns a Machine of vmSize in phase, created by machineSet
+func Machine(t *testing.T, name, machineSet, vmSize, phase string) *machinev1beta1.Machine {
+	return &machinev1beta1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				machineSetLabel: machineSet,
+			},
+		},
+		Spec: machinev1beta1.MachineSpec{
+			ProviderSpec: machinev1beta1.ProviderSpec{
+				Value: ProviderSpec(t, &machinev1beta1.AzureMachineProviderSpec{
+					VMSize: vmSize,
+				}),
+			},
+		},
+		Status: machinev1beta1.MachineStatus{
+			Phase: to.StringPtr(phase),
+		},
+	}
+}